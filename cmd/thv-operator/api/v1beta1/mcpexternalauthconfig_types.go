@@ -275,6 +275,11 @@ type OBOConfig struct {
 // This configuration is used to exchange incoming authentication tokens for tokens
 // that can be used with external services.
 // The structure matches the tokenexchange.Config from pkg/oauthproto/tokenexchange/middleware.go
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.clientSecretRef) && has(self.clientAssertionKeyRef))",message="clientSecretRef and clientAssertionKeyRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.clientAssertionKeyRef) || (has(self.clientId) && size(self.clientId) > 0)",message="clientId is required when clientAssertionKeyRef is set"
+//
+//nolint:lll // CEL validation rules exceed line length limit
 type TokenExchangeConfig struct {
 	// TokenURL is the OAuth 2.0 token endpoint URL for token exchange
 	// +kubebuilder:validation:Required
@@ -290,6 +295,18 @@ type TokenExchangeConfig struct {
 	// +optional
 	ClientSecretRef *SecretKeyRef `json:"clientSecretRef,omitempty"`
 
+	// ClientAssertionKeyRef is a reference to a secret containing a PEM-encoded RSA or EC
+	// private key. When set, the client authenticates to the token endpoint with a JWT
+	// signed by this key (private_key_jwt, RFC 7523) instead of a client secret.
+	// Mutually exclusive with ClientSecretRef; requires ClientID.
+	// +optional
+	ClientAssertionKeyRef *SecretKeyRef `json:"clientAssertionKeyRef,omitempty"`
+
+	// ClientAssertionKeyID is the key ID sent in the "kid" header of client assertions,
+	// so the authorization server can select the matching public key.
+	// +optional
+	ClientAssertionKeyID string `json:"clientAssertionKeyId,omitempty"`
+
 	// Audience is the target audience for the exchanged token
 	// +kubebuilder:validation:Required
 	Audience string `json:"audience"`
@@ -1499,8 +1516,9 @@ func (r *MCPExternalAuthConfig) Validate() error {
 			return fmt.Errorf("xaa requires configuration")
 		}
 		return nil
-	case ExternalAuthTypeTokenExchange,
-		ExternalAuthTypeHeaderInjection,
+	case ExternalAuthTypeTokenExchange:
+		return r.validateTokenExchange()
+	case ExternalAuthTypeHeaderInjection,
 		ExternalAuthTypeBearerToken,
 		ExternalAuthTypeUnauthenticated:
 		// No complex validation needed for these types
@@ -1534,6 +1552,23 @@ func (r *MCPExternalAuthConfig) Validate() error {
 	}
 }
 
+// validateTokenExchange validates the client authentication of the token
+// exchange configuration. It mirrors the CEL XValidation rules on
+// TokenExchangeConfig.
+func (r *MCPExternalAuthConfig) validateTokenExchange() error {
+	cfg := r.Spec.TokenExchange
+	if cfg == nil || cfg.ClientAssertionKeyRef == nil {
+		return nil
+	}
+	if cfg.ClientSecretRef != nil {
+		return fmt.Errorf("tokenExchange: clientSecretRef and clientAssertionKeyRef are mutually exclusive")
+	}
+	if cfg.ClientID == "" {
+		return fmt.Errorf("tokenExchange: clientId is required when clientAssertionKeyRef is set")
+	}
+	return nil
+}
+
 // typeConfigEntry maps an ExternalAuthType to its corresponding config field presence check.
 type typeConfigEntry struct {
 	authType  ExternalAuthType
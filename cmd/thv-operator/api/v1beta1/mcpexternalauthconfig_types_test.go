@@ -66,6 +66,62 @@ func TestMCPExternalAuthConfig_Validate(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "valid tokenExchange type with client assertion key",
+			config: &MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-token-jwt",
+					Namespace: "default",
+				},
+				Spec: MCPExternalAuthConfigSpec{
+					Type: ExternalAuthTypeTokenExchange,
+					TokenExchange: &TokenExchangeConfig{
+						TokenURL:              "https://example.com/token",
+						ClientID:              "client",
+						ClientAssertionKeyRef: &SecretKeyRef{Name: "key", Key: "tls.key"},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid tokenExchange with client secret and client assertion key",
+			config: &MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-token-both",
+					Namespace: "default",
+				},
+				Spec: MCPExternalAuthConfigSpec{
+					Type: ExternalAuthTypeTokenExchange,
+					TokenExchange: &TokenExchangeConfig{
+						TokenURL:              "https://example.com/token",
+						ClientID:              "client",
+						ClientSecretRef:       &SecretKeyRef{Name: "secret", Key: "client-secret"},
+						ClientAssertionKeyRef: &SecretKeyRef{Name: "key", Key: "tls.key"},
+					},
+				},
+			},
+			expectErr: true,
+			errMsg:    "mutually exclusive",
+		},
+		{
+			name: "invalid tokenExchange with client assertion key and no client ID",
+			config: &MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-token-no-client",
+					Namespace: "default",
+				},
+				Spec: MCPExternalAuthConfigSpec{
+					Type: ExternalAuthTypeTokenExchange,
+					TokenExchange: &TokenExchangeConfig{
+						TokenURL:              "https://example.com/token",
+						ClientAssertionKeyRef: &SecretKeyRef{Name: "key", Key: "tls.key"},
+					},
+				},
+			},
+			expectErr: true,
+			errMsg:    "clientId is required",
+		},
 		{
 			name: "valid headerInjection type",
 			config: &MCPExternalAuthConfig{
@@ -178,6 +178,22 @@ const (
 	ConditionReasonTelemetryConfigRefError = "TelemetryConfigRefError"
 )
 
+// ConditionExternalSecretRefsValidated indicates whether the environment variables
+// sourced from external secrets managers were resolved.
+const ConditionExternalSecretRefsValidated = "ExternalSecretRefsValidated"
+
+const (
+	// ConditionReasonExternalSecretRefsValid indicates every external secret reference was resolved
+	ConditionReasonExternalSecretRefsValid = "ExternalSecretRefsValid"
+
+	// ConditionReasonExternalSecretRefOutOfScope indicates a reference is outside the secrets
+	// the MCPServer's namespace may read
+	ConditionReasonExternalSecretRefOutOfScope = "ExternalSecretRefOutOfScope"
+
+	// ConditionReasonExternalSecretRefError indicates a reference could not be resolved
+	ConditionReasonExternalSecretRefError = "ExternalSecretRefError"
+)
+
 // ConditionStdioReplicaCapped indicates spec.replicas was capped at 1 for stdio transport.
 const ConditionStdioReplicaCapped = "StdioReplicaCapped"
 
@@ -188,6 +204,19 @@ const (
 	ConditionReasonStdioReplicaCapNotActive = "StdioReplicaCapNotActive"
 )
 
+// ConditionDryRun is set while the MCPServer carries the dry-run annotation; its message lists
+// the changes a reconcile would make to the MCPServer's resources.
+const ConditionDryRun = "DryRun"
+
+const (
+	// ConditionReasonDryRunChangesPending is set when a reconcile would create or update resources.
+	ConditionReasonDryRunChangesPending = "ChangesPending"
+	// ConditionReasonDryRunNoChanges is set when the resources already match the spec.
+	ConditionReasonDryRunNoChanges = "NoChanges"
+	// ConditionReasonDryRunPlanFailed is set when the desired resources could not be computed.
+	ConditionReasonDryRunPlanFailed = "PlanFailed"
+)
+
 // ConditionSessionStorageWarning indicates replicas > 1 but no Redis session storage is configured.
 const ConditionSessionStorageWarning = "SessionStorageWarning"
 
@@ -263,7 +292,7 @@ type MCPServerSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	// +optional
-	Env []EnvVar `json:"env,omitempty"`
+	Env []MCPServerEnvVar `json:"env,omitempty"`
 
 	// Volumes are volumes to mount in the MCP server container
 	// +listType=map
@@ -300,6 +329,14 @@ type MCPServerSpec struct {
 	// +kubebuilder:validation:Type=object
 	PodTemplateSpec *runtime.RawExtension `json:"podTemplateSpec,omitempty"`
 
+	// WritableRootFilesystem opts the MCP server container out of the read-only root filesystem.
+	// By default the `mcp` container runs with a read-only root filesystem, all capabilities
+	// dropped, privilege escalation disabled, runAsNonRoot, and the RuntimeDefault seccomp profile.
+	// Set this for servers that need to write to their filesystem. Security context settings
+	// given explicitly in PodTemplateSpec always take precedence.
+	// +optional
+	WritableRootFilesystem bool `json:"writableRootFilesystem,omitempty"`
+
 	// ResourceOverrides allows overriding annotations and labels for resources created by the operator
 	// +optional
 	ResourceOverrides *ResourceOverrides `json:"resourceOverrides,omitempty"`
@@ -469,6 +506,46 @@ type EnvVar struct {
 	Value string `json:"value"`
 }
 
+// MCPServerEnvVar represents an environment variable in the MCP server container.
+// Unlike EnvVar, its value can be sourced from an external secrets manager.
+// +kubebuilder:validation:XValidation:rule="!(has(self.value) && has(self.valueFrom))",message="value and valueFrom are mutually exclusive"
+type MCPServerEnvVar struct {
+	// Name of the environment variable
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Value of the environment variable
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom sources the value of the environment variable from an external secrets manager
+	// +optional
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty"`
+}
+
+// EnvVarSource is a source for the value of an MCPServerEnvVar
+type EnvVarSource struct {
+	// ExternalSecretRef references a secret kept in an external secrets manager
+	// +kubebuilder:validation:Required
+	ExternalSecretRef *ExternalSecretRef `json:"externalSecretRef"`
+}
+
+// ExternalSecretRef references a secret kept in an external secrets manager, such as HashiCorp Vault.
+// The operator resolves the reference when it builds the MCP server pod and injects the value
+// through a Secret it manages, so the value never appears in the pod spec.
+type ExternalSecretRef struct {
+	// Provider is the secrets manager holding the secret, e.g. "vault" or "1password"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Provider string `json:"provider"`
+
+	// Key identifies the secret in the provider, using the provider's secret name format,
+	// e.g. "github-token" or "database#password" for Vault
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
 // Volume represents a volume to mount in a container
 type Volume struct {
 	// Name is the name of the volume
@@ -720,6 +797,14 @@ type AuthzConfigRef struct {
 	// +kubebuilder:validation:MaxLength=63
 	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
 	GroupEntityType string `json:"groupEntityType,omitempty"`
+
+	// HotReload reloads the Cedar policies and entities in place when they
+	// change, instead of restarting the proxy. Invalid policies are rejected and
+	// the previous policy set stays in force. Other options, such as the claim
+	// mappings, still take effect through a restart. Supported by MCPServer and
+	// MCPRemoteProxy; ignored by VirtualMCPServer.
+	// +optional
+	HotReload bool `json:"hotReload,omitempty"`
 }
 
 // DeprecatedInlinePrimaryUpstreamProvider returns the legacy inline
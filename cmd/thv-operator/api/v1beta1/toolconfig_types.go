@@ -4,6 +4,7 @@
 package v1beta1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,21 @@ type MCPToolConfigSpec struct {
 	// This allows renaming tools and/or changing their descriptions.
 	// +optional
 	ToolsOverride map[string]ToolOverride `json:"toolsOverride,omitempty"`
+
+	// ToolsAlias is a map from actual tool names to an alias the tool is
+	// advertised under by a VirtualMCPServer that references this config.
+	// Unlike a ToolsOverride rename, the actual name keeps routing to the tool.
+	// Aliases are ignored by MCPServer and MCPRemoteProxy.
+	// +optional
+	ToolsAlias map[string]string `json:"toolsAlias,omitempty"`
+
+	// ToolsParameterDefaults is a map from actual tool names to argument values
+	// a VirtualMCPServer that references this config injects into calls to the
+	// tool when the client does not provide them. A value the client provides
+	// always wins, and defaults the tool's input schema rejects are dropped.
+	// Defaults are ignored by MCPServer and MCPRemoteProxy.
+	// +optional
+	ToolsParameterDefaults map[string]map[string]apiextensionsv1.JSON `json:"toolsParameterDefaults,omitempty"`
 }
 
 // ToolAnnotationsOverride defines overrides for tool annotation fields.
@@ -66,7 +66,7 @@ func WithMCPGroupRef(name string) MCPServerOption {
 }
 
 // WithEnv replaces the environment variables.
-func WithEnv(env ...mcpv1beta1.EnvVar) MCPServerOption {
+func WithEnv(env ...mcpv1beta1.MCPServerEnvVar) MCPServerOption {
 	return func(m *mcpv1beta1.MCPServer) { m.Spec.Env = env }
 }
 
@@ -90,6 +90,11 @@ func WithVMCPSessionStorage(cfg *mcpv1beta1.SessionStorageConfig) VirtualMCPServ
 	return func(v *mcpv1beta1.VirtualMCPServer) { v.Spec.SessionStorage = cfg }
 }
 
+// WithVMCPResourceScaling sets the backend-count-based resource scaling configuration.
+func WithVMCPResourceScaling(cfg *mcpv1beta1.ResourceScalingConfig) VirtualMCPServerOption {
+	return func(v *mcpv1beta1.VirtualMCPServer) { v.Spec.ResourceScaling = cfg }
+}
+
 // WithVMCPServiceAccount sets the service account name.
 func WithVMCPServiceAccount(name string) VirtualMCPServerOption {
 	return func(v *mcpv1beta1.VirtualMCPServer) { v.Spec.ServiceAccount = &name }
@@ -76,6 +76,14 @@ type VirtualMCPServerSpec struct {
 	// +kubebuilder:validation:Type=object
 	PodTemplateSpec *runtime.RawExtension `json:"podTemplateSpec,omitempty"`
 
+	// WritableRootFilesystem opts the Virtual MCP server container out of the read-only root filesystem.
+	// By default the 'vmcp' container runs with a read-only root filesystem, all capabilities
+	// dropped, privilege escalation disabled, runAsNonRoot, and the RuntimeDefault seccomp profile.
+	// Set this for servers that need to write to their filesystem. Security context settings
+	// given explicitly in PodTemplateSpec always take precedence.
+	// +optional
+	WritableRootFilesystem bool `json:"writableRootFilesystem,omitempty"`
+
 	// GroupRef references the MCPGroup that defines backend workloads.
 	// The referenced MCPGroup must exist in the same namespace.
 	// +kubebuilder:validation:Required
@@ -123,6 +131,13 @@ type VirtualMCPServerSpec struct {
 	// +optional
 	SessionStorage *SessionStorageConfig `json:"sessionStorage,omitempty"`
 
+	// ResourceScaling derives the vMCP container's resource requests and limits from the
+	// number of backend workloads in the referenced MCPGroup, so large aggregations get
+	// more memory while small ones stay lean. When nil, fixed defaults are used.
+	// Resources set on the 'vmcp' container through PodTemplateSpec take precedence.
+	// +optional
+	ResourceScaling *ResourceScalingConfig `json:"resourceScaling,omitempty"`
+
 	// ImagePullSecrets allows specifying image pull secrets for the vMCP workload.
 	// These are applied to both the vMCP Deployment's PodSpec.ImagePullSecrets
 	// and to the operator-managed ServiceAccount the vMCP server runs as, so private
@@ -159,6 +174,21 @@ type VirtualMCPServerSpec struct {
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
+// ResourceScalingConfig configures how the vMCP container's resources grow with the
+// number of backends. Each request and limit is its default plus PerBackend for every
+// backend, capped at Max.
+type ResourceScalingConfig struct {
+	// PerBackend is the amount added to each request and limit for every backend.
+	// Unset resources default to 25m CPU and 32Mi memory.
+	// +optional
+	PerBackend ResourceList `json:"perBackend,omitempty"`
+
+	// Max caps the computed requests and limits.
+	// Unset resources default to 2 CPU and 2Gi memory.
+	// +optional
+	Max ResourceList `json:"max,omitempty"`
+}
+
 // EmbeddingServerRef references an existing EmbeddingServer resource by name.
 // This follows the same pattern as ExternalAuthConfigRef and ToolConfigRef.
 type EmbeddingServerRef struct {
@@ -688,8 +718,11 @@ func (r *VirtualMCPServer) validateAggregation() error {
 			// No additional validation required
 
 		case vmcptypes.ConflictStrategyPriority:
-			if len(resConfig.PriorityOrder) == 0 {
-				return fmt.Errorf("config.aggregation.conflictResolutionConfig.priorityOrder is required when conflictResolution is priority")
+			if len(resConfig.PriorityOrder) > 0 && len(resConfig.BackendPriorities) > 0 {
+				return fmt.Errorf("config.aggregation.conflictResolutionConfig.priorityOrder and backendPriorities are mutually exclusive")
+			}
+			if len(resConfig.PriorityOrder) == 0 && len(resConfig.BackendPriorities) == 0 {
+				return fmt.Errorf("config.aggregation.conflictResolutionConfig.priorityOrder or backendPriorities is required when conflictResolution is priority") //nolint:lll
 			}
 
 		case vmcptypes.ConflictStrategyManual:
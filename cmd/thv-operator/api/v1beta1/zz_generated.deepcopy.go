@@ -505,6 +505,26 @@ func (in *EnvVar) DeepCopy() *EnvVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarSource) DeepCopyInto(out *EnvVarSource) {
+	*out = *in
+	if in.ExternalSecretRef != nil {
+		in, out := &in.ExternalSecretRef, &out.ExternalSecretRef
+		*out = new(ExternalSecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarSource.
+func (in *EnvVarSource) DeepCopy() *EnvVarSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalAuthConfigRef) DeepCopyInto(out *ExternalAuthConfigRef) {
 	*out = *in
@@ -520,6 +540,21 @@ func (in *ExternalAuthConfigRef) DeepCopy() *ExternalAuthConfigRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretRef) DeepCopyInto(out *ExternalSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRef.
+func (in *ExternalSecretRef) DeepCopy() *ExternalSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeaderForwardConfig) DeepCopyInto(out *HeaderForwardConfig) {
 	*out = *in
@@ -1654,6 +1689,26 @@ func (in *MCPServerEntryStatus) DeepCopy() *MCPServerEntryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerEnvVar) DeepCopyInto(out *MCPServerEnvVar) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(EnvVarSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerEnvVar.
+func (in *MCPServerEnvVar) DeepCopy() *MCPServerEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerList) DeepCopyInto(out *MCPServerList) {
 	*out = *in
@@ -1696,8 +1751,10 @@ func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]EnvVar, len(*in))
-		copy(*out, *in)
+		*out = make([]MCPServerEnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
@@ -2083,6 +2140,31 @@ func (in *MCPToolConfigSpec) DeepCopyInto(out *MCPToolConfigSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ToolsAlias != nil {
+		in, out := &in.ToolsAlias, &out.ToolsAlias
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ToolsParameterDefaults != nil {
+		in, out := &in.ToolsParameterDefaults, &out.ToolsParameterDefaults
+		*out = make(map[string]map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			var outVal map[string]apiextensionsv1.JSON
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]apiextensionsv1.JSON, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolConfigSpec.
@@ -2701,6 +2783,23 @@ func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceScalingConfig) DeepCopyInto(out *ResourceScalingConfig) {
+	*out = *in
+	out.PerBackend = in.PerBackend
+	out.Max = in.Max
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceScalingConfig.
+func (in *ResourceScalingConfig) DeepCopy() *ResourceScalingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceScalingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
 	*out = *in
@@ -2810,6 +2909,11 @@ func (in *TokenExchangeConfig) DeepCopyInto(out *TokenExchangeConfig) {
 		*out = new(SecretKeyRef)
 		**out = **in
 	}
+	if in.ClientAssertionKeyRef != nil {
+		in, out := &in.ClientAssertionKeyRef, &out.ClientAssertionKeyRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
 	if in.Scopes != nil {
 		in, out := &in.Scopes, &out.Scopes
 		*out = make([]string, len(*in))
@@ -3254,6 +3358,11 @@ func (in *VirtualMCPServerSpec) DeepCopyInto(out *VirtualMCPServerSpec) {
 		*out = new(SessionStorageConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceScaling != nil {
+		in, out := &in.ResourceScaling, &out.ResourceScaling
+		*out = new(ResourceScalingConfig)
+		**out = **in
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]corev1.LocalObjectReference, len(*in))
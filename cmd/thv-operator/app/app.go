@@ -11,7 +11,9 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -28,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server" // Import for metricsserver
 	"sigs.k8s.io/controller-runtime/pkg/webhook"                      // Import for webhook
 
@@ -35,6 +38,7 @@ import (
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	"github.com/stacklok/toolhive/cmd/thv-operator/controllers"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/externalsecrets"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/imagepullsecrets"
 	// Import authorizer backends so they register with the factory registry.
 	// Placed in the binary entrypoint (not the controller) to keep the
@@ -123,6 +127,20 @@ func Run() {
 		)
 	}
 
+	// Operator metrics are exported alongside the controller-runtime ones on
+	// the manager's metrics endpoint.
+	meterProvider, err := telemetry.NewMeterProvider(ctrlmetrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to set up metrics")
+		os.Exit(1)
+	}
+	operatorMetrics, err := telemetry.NewMetrics(meterProvider)
+	if err != nil {
+		setupLog.Error(err, "unable to set up metrics")
+		os.Exit(1)
+	}
+	telemetry.SetDefault(operatorMetrics)
+
 	if err := setupControllersAndWebhooks(mgr, imagePullSecretsDefaults); err != nil {
 		setupLog.Error(err, "unable to setup controllers and webhooks")
 		os.Exit(1)
@@ -140,6 +158,7 @@ func Run() {
 	telemetryService := telemetry.NewService(mgr.GetClient(), podNamespace)
 	if err := mgr.Add(&telemetry.LeaderTelemetryRunnable{
 		TelemetryService: telemetryService,
+		Metrics:          operatorMetrics,
 	}); err != nil {
 		setupLog.Error(err, "unable to add telemetry runnable")
 		os.Exit(1)
@@ -281,6 +300,13 @@ func setupServerControllers(mgr ctrl.Manager, imagePullSecretsDefaults imagepull
 		return err
 	}
 
+	// External secret references can only be resolved within the configured scopes
+	externalSecretScopes, err := externalsecrets.LoadScopesFromEnv(slices.Collect(maps.Keys(externalsecrets.SupportedProviders())))
+	if err != nil {
+		return fmt.Errorf("unable to load external secrets scopes: %w", err)
+	}
+	setupLog.Info("loaded external secrets scopes", "providers", slices.Sorted(maps.Keys(externalSecretScopes)))
+
 	// Set up MCPServer controller
 	rec := &controllers.MCPServerReconciler{
 		Client:                   mgr.GetClient(),
@@ -288,6 +314,7 @@ func setupServerControllers(mgr ctrl.Manager, imagePullSecretsDefaults imagepull
 		Recorder:                 mgr.GetEventRecorder("mcpserver-controller"),
 		PlatformDetector:         ctrlutil.NewSharedPlatformDetector(),
 		ImagePullSecretsDefaults: imagePullSecretsDefaults,
+		ExternalSecretResolver:   externalsecrets.NewProviderResolver(externalSecretScopes),
 	}
 	if err := rec.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller MCPServer: %w", err)
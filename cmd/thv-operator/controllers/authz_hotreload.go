@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+)
+
+const (
+	// eventReasonAuthzPolicyRejected is the Warning event reason emitted when a
+	// hot-reloaded authorization policy set does not compile and the previous
+	// policy set is kept.
+	eventReasonAuthzPolicyRejected = "AuthzPolicyRejected"
+
+	// eventActionReloadPolicy is the action recorded on policy reload events.
+	eventActionReloadPolicy = "ReloadPolicy"
+)
+
+// ensureAuthzPolicySet ensures the inline authorization ConfigMap for owner
+// (see EnsureAuthzConfigMap) and, when hot reload is enabled, checks the policy
+// set the proxy is about to reload. A rejected policy set is not an error: it is
+// reported with a Warning event and the proxy keeps its previous policy set,
+// since the inline ConfigMap is left unchanged and the proxy itself refuses an
+// invalid referenced ConfigMap.
+func ensureAuthzPolicySet(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	rec events.EventRecorder,
+	owner client.Object,
+	authzRef *mcpv1beta1.AuthzConfigRef,
+	labels map[string]string,
+) error {
+	err := ctrlutil.EnsureAuthzConfigMap(
+		ctx, c, scheme, owner, owner.GetNamespace(), owner.GetName(), authzRef, labels,
+	)
+	if err == nil && authzRef != nil && authzRef.Type == mcpv1beta1.AuthzConfigTypeConfigMap {
+		err = ctrlutil.CheckAuthzPolicySet(ctx, c, owner.GetNamespace(), authzRef)
+	}
+	if !stderrors.Is(err, ctrlutil.ErrAuthzPolicyRejected) {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Rejected authorization policy update, keeping the previous policy set",
+		"error", err.Error())
+	emitConfigEvent(rec, owner, corev1.EventTypeWarning, eventReasonAuthzPolicyRejected, eventActionReloadPolicy,
+		"Keeping the previous authorization policy set: %s", err.Error())
+	return nil
+}
+
+// hotReloadsAuthzConfigMap reports whether authzRef reloads its policies from
+// the named ConfigMap.
+func hotReloadsAuthzConfigMap(authzRef *mcpv1beta1.AuthzConfigRef, configMapName string) bool {
+	return authzRef != nil && authzRef.HotReload &&
+		authzRef.Type == mcpv1beta1.AuthzConfigTypeConfigMap &&
+		authzRef.ConfigMap != nil && authzRef.ConfigMap.Name == configMapName
+}
+
+// mapAuthzConfigMapToServers maps ConfigMap changes to reconciliation requests
+// for the MCPServers that hot reload their policies from it, so a rejected
+// update is reported as soon as it is made.
+func (r *MCPServerReconciler) mapAuthzConfigMapToServers(
+	ctx context.Context, obj client.Object,
+) []reconcile.Request {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+
+	mcpServerList := &mcpv1beta1.MCPServerList{}
+	if err := r.List(ctx, mcpServerList, client.InNamespace(cm.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list MCPServers for authz ConfigMap watch")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, server := range mcpServerList.Items {
+		if hotReloadsAuthzConfigMap(server.Spec.AuthzConfig, cm.Name) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      server.Name,
+					Namespace: server.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// mapAuthzConfigMapToMCPRemoteProxy maps ConfigMap changes to reconciliation
+// requests for the MCPRemoteProxies that hot reload their policies from it.
+func (r *MCPRemoteProxyReconciler) mapAuthzConfigMapToMCPRemoteProxy(
+	ctx context.Context, obj client.Object,
+) []reconcile.Request {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+
+	proxyList := &mcpv1beta1.MCPRemoteProxyList{}
+	if err := r.List(ctx, proxyList, client.InNamespace(cm.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list MCPRemoteProxies for authz ConfigMap watch")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, proxy := range proxyList.Items {
+		if hotReloadsAuthzConfigMap(proxy.Spec.AuthzConfig, cm.Name) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      proxy.Name,
+					Namespace: proxy.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
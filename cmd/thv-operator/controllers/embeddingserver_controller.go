@@ -30,6 +30,7 @@ import (
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/imagepullsecrets"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 // EmbeddingServerReconciler reconciles a EmbeddingServer object
@@ -1027,5 +1028,5 @@ func (r *EmbeddingServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("embeddingserver", r))
 }
@@ -27,6 +27,7 @@ import (
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 const (
@@ -431,7 +432,7 @@ func (r *MCPAuthzConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&mcpv1beta1.MCPRemoteProxy{},
 			handler.EnqueueRequestsFromMapFunc(r.mapMCPRemoteProxyToAuthzConfig),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcpauthzconfig", r))
 }
 
 // mapMCPServerToAuthzConfig maps an MCPServer to the MCPAuthzConfig it currently references.
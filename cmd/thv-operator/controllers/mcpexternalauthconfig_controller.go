@@ -28,6 +28,7 @@ import (
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
 	"github.com/stacklok/toolhive/pkg/auth/obo"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 const (
@@ -608,7 +609,7 @@ func (r *MCPExternalAuthConfigReconciler) SetupWithManager(mgr ctrl.Manager) err
 			handler.EnqueueRequestsFromMapFunc(r.mapMCPRemoteProxyToExternalAuthConfig),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcpexternalauthconfig", r))
 }
 
 // mapMCPServerToExternalAuthConfig maps an MCPServer to the MCPExternalAuthConfig(s)
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 const (
@@ -550,5 +551,5 @@ func (r *MCPGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&mcpv1beta1.MCPServerEntry{}, handler.EnqueueRequestsFromMapFunc(r.findMCPGroupForMCPServerEntry),
 		).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcpgroup", r))
 }
@@ -26,6 +26,7 @@ import (
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 const (
@@ -405,7 +406,7 @@ func (r *MCPOIDCConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.mapMCPRemoteProxyToOIDCConfig),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcpoidcconfig", r))
 }
 
 // mapMCPServerToOIDCConfig maps an MCPServer to the MCPOIDCConfig it currently
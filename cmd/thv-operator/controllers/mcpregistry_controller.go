@@ -26,6 +26,7 @@ import (
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/imagepullsecrets"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/registryapi"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/registryapi/config"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 // Default timing constants for the controller
@@ -199,6 +200,7 @@ func (r *MCPRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		ctxLogger.Error(apiErr, "Failed to reconcile API service")
 		reconcileErr = apiErr
 	}
+	operatortelemetry.Default().RecordRegistrySync(ctx, mcpRegistry.Namespace, reconcileErr)
 
 	// 4. Determine and persist status
 	isReady, statusUpdateErr := r.updateRegistryStatus(ctx, mcpRegistry, reconcileErr, podTemplateCondition)
@@ -269,7 +271,7 @@ func (r *MCPRegistryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcpregistry", r))
 }
 
 // updateRegistryStatus determines the MCPRegistry phase from the API deployment state
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,6 +35,7 @@ import (
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/kubernetes/rbac"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/runconfig/configmap/checksum"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/validation"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 // MCPRemoteProxyReconciler reconciles a MCPRemoteProxy object
@@ -324,9 +326,7 @@ func (r *MCPRemoteProxyReconciler) ensureAllResources(ctx context.Context, proxy
 func (r *MCPRemoteProxyReconciler) ensureAuthzConfigMapForProxy(ctx context.Context, proxy *mcpv1beta1.MCPRemoteProxy) error {
 	authzLabels := labelsForMCPRemoteProxy(proxy.Name)
 	authzLabels[authzLabelKey] = authzLabelValueInline
-	return ctrlutil.EnsureAuthzConfigMap(
-		ctx, r.Client, r.Scheme, proxy, proxy.Namespace, proxy.Name, proxy.Spec.AuthzConfig, authzLabels,
-	)
+	return ensureAuthzPolicySet(ctx, r.Client, r.Scheme, r.Recorder, proxy, proxy.Spec.AuthzConfig, authzLabels)
 }
 
 // getRunConfigChecksum fetches the RunConfig ConfigMap checksum annotation for this proxy.
@@ -2153,5 +2153,10 @@ func (r *MCPRemoteProxyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&mcpv1beta1.MCPAuthzConfig{},
 			handler.EnqueueRequestsFromMapFunc(r.mapAuthzConfigToMCPRemoteProxy),
 		).
-		Complete(r)
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.mapAuthzConfigMapToMCPRemoteProxy),
+			builder.WithPredicates(configMapDataChangedPredicate()),
+		).
+		Complete(operatortelemetry.InstrumentReconciler("mcpremoteproxy", r))
 }
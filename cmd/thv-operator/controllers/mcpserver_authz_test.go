@@ -13,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -236,6 +237,78 @@ func TestEnsureAuthzConfigMap_Updates(t *testing.T) {
 	require.NotEqual(t, initialData, updatedData, "ConfigMap data should have been updated")
 }
 
+func TestEnsureAuthzConfigMap_HotReload(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewScheme(t)
+	mcpServer := &mcpv1beta1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-server",
+			Namespace: "test-namespace",
+			UID:       "test-uid",
+		},
+		Spec: mcpv1beta1.MCPServerSpec{
+			Image: "test-image",
+			AuthzConfig: &mcpv1beta1.AuthzConfigRef{
+				Type:      mcpv1beta1.AuthzConfigTypeInline,
+				HotReload: true,
+				Inline: &mcpv1beta1.InlineAuthzConfig{
+					Policies: []string{
+						`permit(principal, action == Action::"call_tool", resource == Tool::"weather");`,
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(mcpServer).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	reconciler := newTestMCPServerReconciler(fakeClient, scheme, kubernetes.PlatformKubernetes)
+	reconciler.Recorder = recorder
+
+	ctx := t.Context()
+	authzData := func() string {
+		t.Helper()
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{
+			Name:      mcpServer.Name + "-authz-inline",
+			Namespace: mcpServer.Namespace,
+		}, configMap))
+		return configMap.Data["authz.json"]
+	}
+
+	// Initial policy set
+	require.NoError(t, reconciler.ensureAuthzConfigMap(ctx, mcpServer))
+	initialData := authzData()
+	require.Contains(t, initialData, `weather`)
+	assert.Empty(t, drainEvents(recorder))
+
+	// An invalid update is rejected without failing the reconcile: the
+	// ConfigMap, and so the proxy's policy set, stay as they were.
+	mcpServer.Spec.AuthzConfig.Inline.Policies = []string{
+		`permit(principal, action == Action::"call_tool", resource == Tool::"search"`,
+	}
+	require.NoError(t, reconciler.ensureAuthzConfigMap(ctx, mcpServer))
+	assert.Equal(t, initialData, authzData())
+	evts := drainEvents(recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], corev1.EventTypeWarning)
+	assert.Contains(t, evts[0], eventReasonAuthzPolicyRejected)
+
+	// A valid update is applied.
+	mcpServer.Spec.AuthzConfig.Inline.Policies = []string{
+		`permit(principal, action == Action::"call_tool", resource == Tool::"search");`,
+	}
+	require.NoError(t, reconciler.ensureAuthzConfigMap(ctx, mcpServer))
+	updatedData := authzData()
+	assert.Contains(t, updatedData, `search`)
+	assert.NotContains(t, updatedData, `weather`)
+	assert.Empty(t, drainEvents(recorder))
+}
+
 func TestGenerateAuthzVolumeConfig(t *testing.T) {
 	t.Parallel()
 
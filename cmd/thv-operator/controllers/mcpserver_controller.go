@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -38,12 +39,14 @@ import (
 	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/externalsecrets"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/imagepullsecrets"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/kubernetes/rbac"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/runconfig/configmap/checksum"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/validation"
 	"github.com/stacklok/toolhive/pkg/auth/obo"
 	"github.com/stacklok/toolhive/pkg/container/kubernetes"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 	"github.com/stacklok/toolhive/pkg/transport"
 	"github.com/stacklok/toolhive/pkg/transport/session"
 )
@@ -58,6 +61,9 @@ type MCPServerReconciler struct {
 	// operator chart that are merged with the per-CR imagePullSecrets when
 	// constructing workloads. The zero value is a usable empty Defaults.
 	ImagePullSecretsDefaults imagepullsecrets.Defaults
+	// ExternalSecretResolver resolves environment variables sourced from external
+	// secrets managers. When nil, MCPServers using such references fail to reconcile.
+	ExternalSecretResolver externalsecrets.Resolver
 }
 
 // defaultRBACRules are the default RBAC rules that the
@@ -163,7 +169,7 @@ func (r *MCPServerReconciler) detectPlatform(ctx context.Context) (kubernetes.Pl
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
@@ -209,6 +215,11 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// In dry-run mode, only record what a reconcile would change
+	if isDryRun(mcpServer) {
+		return r.reconcileDryRun(ctx, mcpServer)
+	}
+
 	// Add finalizer for this CR
 	if !controllerutil.ContainsFinalizer(mcpServer, MCPServerFinalizerName) {
 		if err := ctrlutil.MutateAndPatchSpec(ctx, r.Client, mcpServer, func(m *mcpv1beta1.MCPServer) {
@@ -218,6 +229,11 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// Clear the result of an earlier dry run now that the annotation is gone
+	if err := r.clearDryRunCondition(ctx, mcpServer); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Check if the restart annotation has been updated and trigger a rolling restart if needed
 	if shouldTriggerRestart, err := r.handleRestartAnnotation(ctx, mcpServer); err != nil {
 		ctxLogger.Error(err, "Failed to handle restart annotation")
@@ -359,6 +375,29 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// Resolve environment variables sourced from external secrets managers
+	if err := r.ensureExternalSecretEnv(ctx, mcpServer); err != nil {
+		ctxLogger.Error(err, "Failed to resolve external secrets")
+		mcpServer.Status.Phase = mcpv1beta1.MCPServerPhaseFailed
+		mcpServer.Status.Message = fmt.Sprintf("Failed to resolve external secrets: %s", err.Error())
+		setExternalSecretRefsFailedCondition(mcpServer, err)
+		setReadyCondition(mcpServer, metav1.ConditionFalse, mcpv1beta1.ConditionReasonNotReady, mcpServer.Status.Message)
+		if statusErr := r.Status().Update(ctx, mcpServer); statusErr != nil {
+			ctxLogger.Error(statusErr, "Failed to update MCPServer status after external secrets error")
+		}
+		if stderrors.Is(err, externalsecrets.ErrOutOfScope) {
+			// Retrying cannot help until the MCPServer or the operator's scopes change
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if setExternalSecretRefsResolvedCondition(mcpServer) {
+		if err := r.Status().Update(ctx, mcpServer); err != nil {
+			ctxLogger.Error(err, "Failed to update MCPServer status after resolving external secrets")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Ensure RunConfig ConfigMap exists and is up to date
 	if err := r.ensureRunConfigConfigMap(ctx, mcpServer); err != nil {
 		ctxLogger.Error(err, "Failed to ensure RunConfig ConfigMap")
@@ -540,6 +579,11 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// External secrets can rotate without any change to the cluster, so poll them
+	if hasExternalSecretEnv(mcpServer) {
+		return ctrl.Result{RequeueAfter: externalSecretRefreshInterval}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -1068,7 +1112,9 @@ func (r *MCPServerReconciler) deploymentForMCPServer(
 	}
 	finalPodTemplateSpec := builder.
 		WithServiceAccount(serviceAccount).
-		WithSecrets(m.Spec.Secrets).
+		WithSecrets(mcpServerSecretRefs(m)).
+		WithSecurityHardening(m.Spec.WritableRootFilesystem).
+		WithAnnotation(externalSecretEnvVersionAnnotation, r.externalSecretEnvVersion(ctx, m)).
 		Build()
 	// Add pod template patch if we have one
 	if finalPodTemplateSpec != nil {
@@ -1914,7 +1960,9 @@ func (r *MCPServerReconciler) deploymentNeedsUpdate(
 
 		expectedPodTemplateSpec := builder.
 			WithServiceAccount(serviceAccount).
-			WithSecrets(mcpServer.Spec.Secrets).
+			WithSecrets(mcpServerSecretRefs(mcpServer)).
+			WithSecurityHardening(mcpServer.Spec.WritableRootFilesystem).
+			WithAnnotation(externalSecretEnvVersionAnnotation, r.externalSecretEnvVersion(ctx, mcpServer)).
 			Build()
 
 		// Find the current pod template patch in the container args
@@ -2606,8 +2654,8 @@ func (r *MCPServerReconciler) handleWebhookConfig(ctx context.Context, m *mcpv1b
 // embedding the resolved authz config directly in the RunConfig (see
 // AddAuthzConfigRefOptions), which is the path the proxy actually reads.
 func (r *MCPServerReconciler) ensureAuthzConfigMap(ctx context.Context, m *mcpv1beta1.MCPServer) error {
-	return ctrlutil.EnsureAuthzConfigMap(
-		ctx, r.Client, r.Scheme, m, m.Namespace, m.Name, m.Spec.AuthzConfig, labelsForInlineAuthzConfig(m.Name),
+	return ensureAuthzPolicySet(
+		ctx, r.Client, r.Scheme, r.Recorder, m, m.Spec.AuthzConfig, labelsForInlineAuthzConfig(m.Name),
 	)
 }
 
@@ -2954,5 +3002,10 @@ func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&mcpv1beta1.MCPTelemetryConfig{}, telemetryConfigHandler).
 		Watches(&mcpv1alpha1.MCPWebhookConfig{}, webhookConfigHandler).
 		Watches(&mcpv1beta1.MCPToolConfig{}, toolConfigHandler).
-		Complete(r)
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.mapAuthzConfigMapToServers),
+			builder.WithPredicates(configMapDataChangedPredicate()),
+		).
+		Complete(operatortelemetry.InstrumentReconciler("mcpserver", r))
 }
@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/runconfig/configmap/checksum"
+)
+
+// DryRunAnnotationKey, set to "true" on an MCPServer, makes the reconciler compute the
+// resources it would create or update and record them in the DryRun condition and an
+// event, without creating or updating any of them.
+const DryRunAnnotationKey = "toolhive.stacklok.dev/dry-run"
+
+// isDryRun reports whether the MCPServer asks for dry-run reconciles.
+func isDryRun(mcpServer *mcpv1beta1.MCPServer) bool {
+	return mcpServer.Annotations[DryRunAnnotationKey] == "true"
+}
+
+// plannedChange is a change a reconcile would make to one resource.
+type plannedChange struct {
+	// action is "create" or "update".
+	action string
+	kind   string
+	name   string
+	// detail names what differs for an update, when known.
+	detail string
+}
+
+func (c plannedChange) String() string {
+	s := fmt.Sprintf("%s %s %s", c.action, c.kind, c.name)
+	if c.detail != "" {
+		s += " (" + c.detail + ")"
+	}
+	return s
+}
+
+// errPlanFailed marks errors building the desired resources, as opposed to errors
+// reading the current ones.
+var errPlanFailed = stderrors.New("failed to compute desired resources")
+
+// reconcileDryRun records the changes a reconcile would make to the MCPServer's
+// resources in the DryRun condition, and in an event, without writing anything else:
+// no finalizer is added and no status other than the condition is touched. The status
+// is only updated when the outcome changes, so repeated dry runs do not churn it.
+func (r *MCPServerReconciler) reconcileDryRun(ctx context.Context, mcpServer *mcpv1beta1.MCPServer) (ctrl.Result, error) {
+	ctxLogger := log.FromContext(ctx)
+
+	changes, err := r.planMCPServer(ctx, mcpServer)
+	if err != nil && !stderrors.Is(err, errPlanFailed) {
+		ctxLogger.Error(err, "Failed to read resources for dry run")
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               mcpv1beta1.ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: mcpServer.Generation,
+	}
+	eventType := corev1.EventTypeNormal
+	switch {
+	case err != nil:
+		condition.Reason = mcpv1beta1.ConditionReasonDryRunPlanFailed
+		condition.Message = err.Error()
+		eventType = corev1.EventTypeWarning
+	case len(changes) == 0:
+		condition.Reason = mcpv1beta1.ConditionReasonDryRunNoChanges
+		condition.Message = "Dry run: no changes"
+	default:
+		descriptions := make([]string, len(changes))
+		for i, change := range changes {
+			descriptions[i] = change.String()
+		}
+		condition.Reason = mcpv1beta1.ConditionReasonDryRunChangesPending
+		condition.Message = "Dry run: would " + strings.Join(descriptions, "; ")
+	}
+
+	existing := meta.FindStatusCondition(mcpServer.Status.Conditions, mcpv1beta1.ConditionDryRun)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&mcpServer.Status.Conditions, condition)
+	if err := r.Status().Update(ctx, mcpServer); err != nil {
+		ctxLogger.Error(err, "Failed to update MCPServer status with dry run result")
+		return ctrl.Result{}, err
+	}
+	ctxLogger.Info("Recorded dry run", "reason", condition.Reason, "message", condition.Message)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(mcpServer, nil, eventType, condition.Reason, "DryRun", "%s", condition.Message)
+	}
+	return ctrl.Result{}, nil
+}
+
+// clearDryRunCondition removes the DryRun condition left by an earlier dry run once the
+// annotation is gone.
+func (r *MCPServerReconciler) clearDryRunCondition(ctx context.Context, mcpServer *mcpv1beta1.MCPServer) error {
+	if !meta.RemoveStatusCondition(&mcpServer.Status.Conditions, mcpv1beta1.ConditionDryRun) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, mcpServer); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to clear MCPServer dry run condition")
+		return err
+	}
+	return nil
+}
+
+// planMCPServer returns the changes a reconcile would make to the resources owned by
+// the MCPServer, computed with the same builders and drift checks as Reconcile.
+// Errors building the desired resources wrap errPlanFailed.
+func (r *MCPServerReconciler) planMCPServer(ctx context.Context, mcpServer *mcpv1beta1.MCPServer) ([]plannedChange, error) {
+	var changes []plannedChange
+	namespace := mcpServer.Namespace
+
+	// RBAC for the proxy runner, and the MCP server's own ServiceAccount unless one is given
+	proxyRunnerName := ctrlutil.ProxyRunnerServiceAccountName(mcpServer.Name)
+	serviceAccounts := []string{proxyRunnerName}
+	if mcpServer.Spec.ServiceAccount == nil {
+		serviceAccounts = append(serviceAccounts, mcpServerServiceAccountName(mcpServer.Name))
+	}
+	for _, name := range serviceAccounts {
+		if change, err := r.planCreate(ctx, &corev1.ServiceAccount{}, "ServiceAccount", name, namespace); err != nil {
+			return nil, err
+		} else if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	role := &rbacv1.Role{}
+	if found, err := r.getForPlan(ctx, role, proxyRunnerName, namespace); err != nil {
+		return nil, err
+	} else if !found {
+		changes = append(changes, plannedChange{action: "create", kind: "Role", name: proxyRunnerName})
+	} else if !equality.Semantic.DeepEqual(role.Rules, defaultRBACRules) {
+		changes = append(changes, plannedChange{action: "update", kind: "Role", name: proxyRunnerName, detail: "rules"})
+	}
+	if change, err := r.planCreate(ctx, &rbacv1.RoleBinding{}, "RoleBinding", proxyRunnerName, namespace); err != nil {
+		return nil, err
+	} else if change != nil {
+		changes = append(changes, *change)
+	}
+
+	// Inline authorization ConfigMap
+	if authz := mcpServer.Spec.AuthzConfig; authz != nil && authz.Type == mcpv1beta1.AuthzConfigTypeInline && authz.Inline != nil {
+		name := fmt.Sprintf("%s-authz-inline", mcpServer.Name)
+		if change, err := r.planCreate(ctx, &corev1.ConfigMap{}, "ConfigMap", name, namespace); err != nil {
+			return nil, err
+		} else if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	// RunConfig ConfigMap; the Deployment is built with the checksum of the desired content
+	desiredRunConfig, err := r.runConfigConfigMapForMCPServer(ctx, mcpServer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errPlanFailed, err)
+	}
+	runConfigChecksum := desiredRunConfig.Annotations[checksum.ContentChecksumAnnotation]
+	runConfig := &corev1.ConfigMap{}
+	if found, err := r.getForPlan(ctx, runConfig, desiredRunConfig.Name, namespace); err != nil {
+		return nil, err
+	} else if !found {
+		changes = append(changes, plannedChange{action: "create", kind: "ConfigMap", name: desiredRunConfig.Name})
+	} else if runConfig.Annotations[checksum.ContentChecksumAnnotation] != runConfigChecksum {
+		changes = append(changes, plannedChange{
+			action: "update", kind: "ConfigMap", name: desiredRunConfig.Name, detail: "runconfig.json",
+		})
+	}
+
+	// Deployment
+	if _, err := r.deploymentForMCPServer(ctx, mcpServer, runConfigChecksum); err != nil {
+		return nil, fmt.Errorf("%w: failed to build Deployment: %w", errPlanFailed, err)
+	}
+	deployment := &appsv1.Deployment{}
+	if found, err := r.getForPlan(ctx, deployment, mcpServer.Name, namespace); err != nil {
+		return nil, err
+	} else if !found {
+		changes = append(changes, plannedChange{action: "create", kind: "Deployment", name: mcpServer.Name})
+	} else if r.deploymentNeedsUpdate(ctx, deployment, mcpServer, runConfigChecksum) {
+		changes = append(changes, plannedChange{action: "update", kind: "Deployment", name: mcpServer.Name})
+	}
+
+	// Service
+	serviceName := ctrlutil.CreateProxyServiceName(mcpServer.Name)
+	service := &corev1.Service{}
+	if found, err := r.getForPlan(ctx, service, serviceName, namespace); err != nil {
+		return nil, err
+	} else if !found {
+		changes = append(changes, plannedChange{action: "create", kind: "Service", name: serviceName})
+	} else if serviceNeedsUpdate(service, mcpServer) {
+		changes = append(changes, plannedChange{action: "update", kind: "Service", name: serviceName})
+	}
+
+	return changes, nil
+}
+
+// planCreate returns a create change for the named object when it does not exist.
+func (r *MCPServerReconciler) planCreate(
+	ctx context.Context, obj client.Object, kind, name, namespace string,
+) (*plannedChange, error) {
+	found, err := r.getForPlan(ctx, obj, name, namespace)
+	if err != nil || found {
+		return nil, err
+	}
+	return &plannedChange{action: "create", kind: kind, name: name}, nil
+}
+
+// getForPlan reads the named object into obj and reports whether it exists.
+func (r *MCPServerReconciler) getForPlan(ctx context.Context, obj client.Object, name, namespace string) (bool, error) {
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	return true, nil
+}
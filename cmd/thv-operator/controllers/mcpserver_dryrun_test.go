@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	"github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1/v1beta1test"
+	"github.com/stacklok/toolhive/cmd/thv-operator/internal/testutil"
+	"github.com/stacklok/toolhive/pkg/container/kubernetes"
+)
+
+type dryRunTestContext struct {
+	t          *testing.T
+	client     client.Client
+	reconciler *MCPServerReconciler
+	recorder   *events.FakeRecorder
+	key        types.NamespacedName
+}
+
+func setupDryRunTest(t *testing.T, dryRun bool) *dryRunTestContext {
+	t.Helper()
+	mcpServer := v1beta1test.NewMCPServer("dry-run-server", testNamespaceDefault)
+	if dryRun {
+		mcpServer.Annotations = map[string]string{DryRunAnnotationKey: "true"}
+	}
+	testScheme := testutil.NewScheme(t)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(mcpServer).
+		WithStatusSubresource(&mcpv1beta1.MCPServer{}).
+		Build()
+
+	recorder := events.NewFakeRecorder(10)
+	reconciler := newTestMCPServerReconciler(fakeClient, testScheme, kubernetes.PlatformKubernetes)
+	reconciler.Recorder = recorder
+
+	return &dryRunTestContext{
+		t:          t,
+		client:     fakeClient,
+		reconciler: reconciler,
+		recorder:   recorder,
+		key:        types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace},
+	}
+}
+
+func (tc *dryRunTestContext) reconcile() ctrl.Result {
+	tc.t.Helper()
+	result, err := tc.reconciler.Reconcile(tc.t.Context(), ctrl.Request{NamespacedName: tc.key})
+	require.NoError(tc.t, err)
+	return result
+}
+
+// reconcileUntilSettled reconciles until the reconciler stops asking for a requeue.
+func (tc *dryRunTestContext) reconcileUntilSettled() {
+	tc.t.Helper()
+	for range 10 {
+		result := tc.reconcile()
+		//nolint:staticcheck // Requeue is what the controller actually returns
+		if !result.Requeue && result.RequeueAfter == 0 {
+			return
+		}
+	}
+	tc.t.Fatal("reconcile did not settle")
+}
+
+func (tc *dryRunTestContext) server() *mcpv1beta1.MCPServer {
+	tc.t.Helper()
+	mcpServer := &mcpv1beta1.MCPServer{}
+	require.NoError(tc.t, tc.client.Get(tc.t.Context(), tc.key, mcpServer))
+	return mcpServer
+}
+
+func (tc *dryRunTestContext) updateServer(mutate func(*mcpv1beta1.MCPServer)) {
+	tc.t.Helper()
+	mcpServer := tc.server()
+	mutate(mcpServer)
+	require.NoError(tc.t, tc.client.Update(tc.t.Context(), mcpServer))
+}
+
+func (tc *dryRunTestContext) assertNotFound(obj client.Object, name string) {
+	tc.t.Helper()
+	err := tc.client.Get(tc.t.Context(), types.NamespacedName{Name: name, Namespace: tc.key.Namespace}, obj)
+	assert.True(tc.t, errors.IsNotFound(err), "%T %s must not be created in dry run, got err %v", obj, name, err)
+}
+
+func TestMCPServerDryRun_CreatesNothing(t *testing.T) {
+	t.Parallel()
+	tc := setupDryRunTest(t, true)
+
+	result := tc.reconcile()
+	assert.Equal(t, ctrl.Result{}, result)
+
+	tc.assertNotFound(&appsv1.Deployment{}, "dry-run-server")
+	tc.assertNotFound(&corev1.Service{}, "mcp-dry-run-server-proxy")
+	tc.assertNotFound(&corev1.ConfigMap{}, "dry-run-server-runconfig")
+	tc.assertNotFound(&corev1.ServiceAccount{}, "dry-run-server-proxy-runner")
+
+	mcpServer := tc.server()
+	assert.NotContains(t, mcpServer.Finalizers, MCPServerFinalizerName, "dry run must not add the finalizer")
+
+	condition := meta.FindStatusCondition(mcpServer.Status.Conditions, mcpv1beta1.ConditionDryRun)
+	require.NotNil(t, condition)
+	assert.Equal(t, mcpv1beta1.ConditionReasonDryRunChangesPending, condition.Reason)
+	for _, want := range []string{
+		"create ServiceAccount dry-run-server-proxy-runner",
+		"create Role dry-run-server-proxy-runner",
+		"create RoleBinding dry-run-server-proxy-runner",
+		"create ConfigMap dry-run-server-runconfig",
+		"create Deployment dry-run-server",
+		"create Service mcp-dry-run-server-proxy",
+	} {
+		assert.Contains(t, condition.Message, want)
+	}
+	assert.Len(t, mcpServer.Status.Conditions, 1, "dry run must only set the DryRun condition")
+
+	evts := drainEvents(tc.recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], mcpv1beta1.ConditionReasonDryRunChangesPending)
+	assert.Contains(t, evts[0], "create Deployment dry-run-server")
+}
+
+func TestMCPServerDryRun_DoesNotFlap(t *testing.T) {
+	t.Parallel()
+	tc := setupDryRunTest(t, true)
+
+	tc.reconcile()
+	first := tc.server()
+	drainEvents(tc.recorder)
+
+	tc.reconcile()
+	tc.reconcile()
+	second := tc.server()
+
+	assert.Equal(t, first.ResourceVersion, second.ResourceVersion, "unchanged plan must not rewrite the status")
+	assert.Empty(t, drainEvents(tc.recorder), "unchanged plan must not emit events")
+}
+
+func TestMCPServerDryRun_RecordsDiffAgainstExistingResources(t *testing.T) {
+	t.Parallel()
+	tc := setupDryRunTest(t, false)
+	tc.reconcileUntilSettled()
+	drainEvents(tc.recorder)
+
+	// In sync with the spec: nothing to change.
+	tc.updateServer(func(m *mcpv1beta1.MCPServer) {
+		m.Annotations = map[string]string{DryRunAnnotationKey: "true"}
+	})
+	tc.reconcile()
+	condition := meta.FindStatusCondition(tc.server().Status.Conditions, mcpv1beta1.ConditionDryRun)
+	require.NotNil(t, condition)
+	assert.Equal(t, mcpv1beta1.ConditionReasonDryRunNoChanges, condition.Reason, condition.Message)
+
+	// Changing the image is planned as updates, but not applied.
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, tc.client.Get(t.Context(), tc.key, deployment))
+	tc.updateServer(func(m *mcpv1beta1.MCPServer) {
+		m.Spec.Image = "new-image:v2"
+		m.Generation++
+	})
+	tc.reconcile()
+
+	condition = meta.FindStatusCondition(tc.server().Status.Conditions, mcpv1beta1.ConditionDryRun)
+	require.NotNil(t, condition)
+	assert.Equal(t, mcpv1beta1.ConditionReasonDryRunChangesPending, condition.Reason)
+	assert.Contains(t, condition.Message, "update ConfigMap dry-run-server-runconfig (runconfig.json)")
+	assert.Contains(t, condition.Message, "update Deployment dry-run-server")
+	assert.NotContains(t, condition.Message, "create")
+
+	unchanged := &appsv1.Deployment{}
+	require.NoError(t, tc.client.Get(t.Context(), tc.key, unchanged))
+	assert.Equal(t, deployment.ResourceVersion, unchanged.ResourceVersion, "dry run must not update the Deployment")
+
+	// Leaving dry-run mode applies the change and clears the condition.
+	tc.updateServer(func(m *mcpv1beta1.MCPServer) {
+		delete(m.Annotations, DryRunAnnotationKey)
+	})
+	tc.reconcileUntilSettled()
+	assert.Nil(t, meta.FindStatusCondition(tc.server().Status.Conditions, mcpv1beta1.ConditionDryRun))
+	require.NoError(t, tc.client.Get(t.Context(), tc.key, unchanged))
+	assert.NotEqual(t, deployment.ResourceVersion, unchanged.ResourceVersion)
+}
+
+func TestMCPServerDryRun_PlanFailure(t *testing.T) {
+	t.Parallel()
+	tc := setupDryRunTest(t, true)
+	tc.updateServer(func(m *mcpv1beta1.MCPServer) {
+		m.Spec.Image = ""
+	})
+
+	result := tc.reconcile()
+	assert.Equal(t, ctrl.Result{}, result, "an invalid spec must not be retried until it changes")
+
+	condition := meta.FindStatusCondition(tc.server().Status.Conditions, mcpv1beta1.ConditionDryRun)
+	require.NotNil(t, condition)
+	assert.Equal(t, mcpv1beta1.ConditionReasonDryRunPlanFailed, condition.Reason)
+
+	evts := drainEvents(tc.recorder)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0], corev1.EventTypeWarning)
+}
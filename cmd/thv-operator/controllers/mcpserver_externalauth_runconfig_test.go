@@ -15,7 +15,12 @@
 package controllers
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,10 +40,23 @@ import (
 	"github.com/stacklok/toolhive/pkg/transport/types"
 )
 
+// generateClientAssertionKeyPEM returns a PEM-encoded EC private key for token exchange
+// client assertion tests.
+func generateClientAssertionKeyPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
 // TestAddExternalAuthConfigOptions tests the addExternalAuthConfigOptions function
 func TestAddExternalAuthConfigOptions(t *testing.T) {
 	t.Parallel()
 
+	clientAssertionKey := generateClientAssertionKeyPEM(t)
+
 	tests := []struct {
 		name           string
 		mcpServer      *mcpv1beta1.MCPServer
@@ -446,6 +464,109 @@ func TestAddExternalAuthConfigOptions(t *testing.T) {
 				assert.Len(t, opts, 1, "Should have one middleware config option")
 			},
 		},
+		{
+			name: "token exchange with client assertion key",
+			mcpServer: v1beta1test.NewMCPServer("test-server", "default",
+				v1beta1test.WithImage("test-image"),
+				v1beta1test.WithExternalAuthConfigRef("jwt-client-config"),
+			),
+			externalAuth: &mcpv1beta1.MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jwt-client-config",
+					Namespace: "default",
+				},
+				Spec: mcpv1beta1.MCPExternalAuthConfigSpec{
+					Type: mcpv1beta1.ExternalAuthTypeTokenExchange,
+					TokenExchange: &mcpv1beta1.TokenExchangeConfig{
+						TokenURL: "https://oauth.example.com/token",
+						ClientID: "jwt-client",
+						ClientAssertionKeyRef: &mcpv1beta1.SecretKeyRef{
+							Name: "jwt-client-key",
+							Key:  "tls.key",
+						},
+						ClientAssertionKeyID: "key-1",
+						Audience:             "api",
+					},
+				},
+			},
+			clientSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jwt-client-key",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"tls.key": clientAssertionKey,
+				},
+			},
+			expectError: false,
+			validateConfig: func(t *testing.T, opts []runner.RunConfigBuilderOption) {
+				t.Helper()
+				assert.Len(t, opts, 1)
+			},
+		},
+		{
+			name: "token exchange with invalid client assertion key",
+			mcpServer: v1beta1test.NewMCPServer("test-server", "default",
+				v1beta1test.WithImage("test-image"),
+				v1beta1test.WithExternalAuthConfigRef("bad-key-config"),
+			),
+			externalAuth: &mcpv1beta1.MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bad-key-config",
+					Namespace: "default",
+				},
+				Spec: mcpv1beta1.MCPExternalAuthConfigSpec{
+					Type: mcpv1beta1.ExternalAuthTypeTokenExchange,
+					TokenExchange: &mcpv1beta1.TokenExchangeConfig{
+						TokenURL: "https://oauth.example.com/token",
+						ClientID: "jwt-client",
+						ClientAssertionKeyRef: &mcpv1beta1.SecretKeyRef{
+							Name: "bad-key",
+							Key:  "tls.key",
+						},
+						Audience: "api",
+					},
+				},
+			},
+			clientSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bad-key",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"tls.key": []byte("not a key"),
+				},
+			},
+			expectError: true,
+			errContains: "invalid client assertion key default/bad-key",
+		},
+		{
+			name: "token exchange with missing client assertion key secret",
+			mcpServer: v1beta1test.NewMCPServer("test-server", "default",
+				v1beta1test.WithImage("test-image"),
+				v1beta1test.WithExternalAuthConfigRef("missing-key-config"),
+			),
+			externalAuth: &mcpv1beta1.MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "missing-key-config",
+					Namespace: "default",
+				},
+				Spec: mcpv1beta1.MCPExternalAuthConfigSpec{
+					Type: mcpv1beta1.ExternalAuthTypeTokenExchange,
+					TokenExchange: &mcpv1beta1.TokenExchangeConfig{
+						TokenURL: "https://oauth.example.com/token",
+						ClientID: "jwt-client",
+						ClientAssertionKeyRef: &mcpv1beta1.SecretKeyRef{
+							Name: "missing-key",
+							Key:  "tls.key",
+						},
+						Audience: "api",
+					},
+				},
+			},
+			expectError: true,
+			errContains: "failed to get client assertion key default/missing-key",
+		},
 		{
 			name: "token exchange with empty client ID but no secret ref",
 			mcpServer: v1beta1test.NewMCPServer("test-server", "default",
@@ -786,6 +907,41 @@ func TestGenerateTokenExchangeEnvVars(t *testing.T) {
 				assert.Equal(t, "client-secret", envVars[0].ValueFrom.SecretKeyRef.Key)
 			},
 		},
+		{
+			name: "client assertion key ref generates key env var",
+			mcpServer: v1beta1test.NewMCPServer("test-server", "default",
+				v1beta1test.WithImage("test-image"),
+				v1beta1test.WithExternalAuthConfigRef("jwt-client-config"),
+			),
+			externalAuth: &mcpv1beta1.MCPExternalAuthConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jwt-client-config",
+					Namespace: "default",
+				},
+				Spec: mcpv1beta1.MCPExternalAuthConfigSpec{
+					Type: mcpv1beta1.ExternalAuthTypeTokenExchange,
+					TokenExchange: &mcpv1beta1.TokenExchangeConfig{
+						TokenURL: "https://oauth.example.com/token",
+						ClientID: "client-id",
+						ClientAssertionKeyRef: &mcpv1beta1.SecretKeyRef{
+							Name: "client-key",
+							Key:  "tls.key",
+						},
+						Audience: "api",
+					},
+				},
+			},
+			expectError: false,
+			validate: func(t *testing.T, envVars []corev1.EnvVar) {
+				t.Helper()
+				require.Len(t, envVars, 1)
+				assert.Equal(t, "TOOLHIVE_TOKEN_EXCHANGE_CLIENT_ASSERTION_KEY", envVars[0].Name)
+				require.NotNil(t, envVars[0].ValueFrom)
+				require.NotNil(t, envVars[0].ValueFrom.SecretKeyRef)
+				assert.Equal(t, "client-key", envVars[0].ValueFrom.SecretKeyRef.Name)
+				assert.Equal(t, "tls.key", envVars[0].ValueFrom.SecretKeyRef.Key)
+			},
+		},
 		{
 			name: "unsupported auth type returns empty env vars",
 			mcpServer: v1beta1test.NewMCPServer("test-server", "default",
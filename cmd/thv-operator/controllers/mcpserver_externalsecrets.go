@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/externalsecrets"
+	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/kubernetes/secrets"
+)
+
+const (
+	// externalSecretEnvVersionAnnotation records the resource version of the managed
+	// external-env Secret on the MCP server pod template, so that the pod is
+	// restarted when a resolved value changes.
+	externalSecretEnvVersionAnnotation = "toolhive.stacklok.dev/external-env-version"
+
+	// externalSecretRefreshInterval is how often an MCPServer with external secret
+	// references is reconciled again to pick up rotated values.
+	externalSecretRefreshInterval = 5 * time.Minute
+)
+
+// externalSecretEnvName returns the name of the Secret holding the resolved values
+// of an MCPServer's externally sourced environment variables.
+func externalSecretEnvName(mcpServerName string) string {
+	return fmt.Sprintf("%s-external-env", mcpServerName)
+}
+
+// hasExternalSecretEnv reports whether any environment variable of the MCPServer
+// is sourced from an external secrets manager.
+func hasExternalSecretEnv(m *mcpv1beta1.MCPServer) bool {
+	return slices.ContainsFunc(m.Spec.Env, isExternalSecretEnv)
+}
+
+func isExternalSecretEnv(env mcpv1beta1.MCPServerEnvVar) bool {
+	return env.ValueFrom != nil && env.ValueFrom.ExternalSecretRef != nil
+}
+
+// mcpServerSecretRefs returns the Secret references to inject into the MCP server
+// container: the user-declared secrets followed by one reference into the managed
+// external-env Secret for every externally sourced environment variable.
+func mcpServerSecretRefs(m *mcpv1beta1.MCPServer) []mcpv1beta1.SecretRef {
+	if !hasExternalSecretEnv(m) {
+		return m.Spec.Secrets
+	}
+
+	refs := slices.Clone(m.Spec.Secrets)
+	for _, env := range m.Spec.Env {
+		if !isExternalSecretEnv(env) {
+			continue
+		}
+		refs = append(refs, mcpv1beta1.SecretRef{
+			Name: externalSecretEnvName(m.Name),
+			Key:  env.Name,
+		})
+	}
+	return refs
+}
+
+// ensureExternalSecretEnv resolves the MCPServer's external secret references and
+// stores the values in a Secret owned by the MCPServer, which the MCP server pod
+// consumes through secretKeyRef environment variables. The resolved values never
+// appear in the Deployment, the pod template patch, or the RunConfig ConfigMap.
+// The Secret is removed once the MCPServer no longer references external secrets.
+func (r *MCPServerReconciler) ensureExternalSecretEnv(ctx context.Context, m *mcpv1beta1.MCPServer) error {
+	name := externalSecretEnvName(m.Name)
+
+	existing := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: m.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get Secret %s: %w", name, err)
+	}
+	exists := err == nil
+
+	if !hasExternalSecretEnv(m) {
+		// Only remove a Secret this MCPServer created
+		if exists && metav1.IsControlledBy(existing, m) {
+			if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete Secret %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	if exists && !metav1.IsControlledBy(existing, m) {
+		return fmt.Errorf("secret %s already exists and is not managed by MCPServer %s", name, m.Name)
+	}
+	if r.ExternalSecretResolver == nil {
+		return fmt.Errorf("external secret references are not supported: no external secrets resolver is configured")
+	}
+
+	data := make(map[string][]byte)
+	for _, env := range m.Spec.Env {
+		if !isExternalSecretEnv(env) {
+			continue
+		}
+		ref := *env.ValueFrom.ExternalSecretRef
+		value, err := r.ExternalSecretResolver.Resolve(ctx, m.Namespace, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve env var %s from %s secret %q: %w", env.Name, ref.Provider, ref.Key, err)
+		}
+		data[env.Name] = []byte(value)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: m.Namespace,
+			Labels:    labelsForMCPServer(m.Name),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	result, err := secrets.NewClient(r.Client, r.Scheme).UpsertWithOwnerReference(ctx, secret, m)
+	if err != nil {
+		return err
+	}
+	log.FromContext(ctx).V(1).Info("Ensured external secret env Secret", "Secret.Name", name, "result", result)
+	return nil
+}
+
+// setExternalSecretRefsCondition sets the ExternalSecretRefsValidated status condition.
+func setExternalSecretRefsCondition(m *mcpv1beta1.MCPServer, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+		Type:               mcpv1beta1.ConditionExternalSecretRefsValidated,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: m.Generation,
+	})
+}
+
+// setExternalSecretRefsFailedCondition records why the MCPServer's external
+// secret references could not be resolved.
+func setExternalSecretRefsFailedCondition(m *mcpv1beta1.MCPServer, err error) {
+	reason := mcpv1beta1.ConditionReasonExternalSecretRefError
+	if stderrors.Is(err, externalsecrets.ErrOutOfScope) {
+		reason = mcpv1beta1.ConditionReasonExternalSecretRefOutOfScope
+	}
+	setExternalSecretRefsCondition(m, metav1.ConditionFalse, reason, err.Error())
+}
+
+// setExternalSecretRefsResolvedCondition marks the MCPServer's external secret
+// references as resolved, or removes the condition when it has none. It
+// reports whether the status changed.
+func setExternalSecretRefsResolvedCondition(m *mcpv1beta1.MCPServer) bool {
+	if !hasExternalSecretEnv(m) {
+		return meta.RemoveStatusCondition(&m.Status.Conditions, mcpv1beta1.ConditionExternalSecretRefsValidated)
+	}
+	return setExternalSecretRefsCondition(m, metav1.ConditionTrue, mcpv1beta1.ConditionReasonExternalSecretRefsValid,
+		"All external secret references are resolved")
+}
+
+// externalSecretEnvVersion returns the resource version of the managed external-env
+// Secret, or an empty string when the MCPServer has no external secret references
+// or the Secret does not exist yet.
+func (r *MCPServerReconciler) externalSecretEnvVersion(ctx context.Context, m *mcpv1beta1.MCPServer) string {
+	if !hasExternalSecretEnv(m) {
+		return ""
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: externalSecretEnvName(m.Name), Namespace: m.Namespace}, secret)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "Failed to get external secret env Secret")
+		}
+		return ""
+	}
+	return secret.ResourceVersion
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	"github.com/stacklok/toolhive/cmd/thv-operator/internal/testutil"
+	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/externalsecrets"
+	"github.com/stacklok/toolhive/pkg/container/kubernetes"
+	"github.com/stacklok/toolhive/pkg/secrets"
+	secretsmocks "github.com/stacklok/toolhive/pkg/secrets/mocks"
+)
+
+// fakeExternalSecretResolver resolves references from a map keyed by "provider/key".
+type fakeExternalSecretResolver map[string]string
+
+func (f fakeExternalSecretResolver) Resolve(_ context.Context, _ string, ref mcpv1beta1.ExternalSecretRef) (string, error) {
+	value, ok := f[ref.Provider+"/"+ref.Key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s/%s", externalsecrets.ErrNotFound, ref.Provider, ref.Key)
+	}
+	return value, nil
+}
+
+func externalSecretTestContext(t *testing.T, resolver externalsecrets.Resolver) *testContext {
+	t.Helper()
+
+	tc := setupTest(t, "external-env", "default")
+	tc.mcpServer.UID = types.UID("external-env-uid")
+	tc.mcpServer.Spec.Env = []mcpv1beta1.MCPServerEnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{
+			Name: "GITHUB_TOKEN",
+			ValueFrom: &mcpv1beta1.EnvVarSource{
+				ExternalSecretRef: &mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "github#token"},
+			},
+		},
+	}
+	tc.reconciler.ExternalSecretResolver = resolver
+	return tc
+}
+
+func podTemplatePatchFromDeployment(t *testing.T, dep *appsv1.Deployment) *corev1.PodTemplateSpec {
+	t.Helper()
+
+	for _, arg := range dep.Spec.Template.Spec.Containers[0].Args {
+		if patch, ok := strings.CutPrefix(arg, "--k8s-pod-patch="); ok {
+			spec := &corev1.PodTemplateSpec{}
+			require.NoError(t, json.Unmarshal([]byte(patch), spec))
+			return spec
+		}
+	}
+	require.Fail(t, "deployment has no pod template patch")
+	return nil
+}
+
+func TestEnsureExternalSecretEnv(t *testing.T) {
+	t.Parallel()
+
+	resolver := fakeExternalSecretResolver{"vault/github#token": "ghp_resolved"}
+	tc := externalSecretTestContext(t, resolver)
+	ctx := t.Context()
+
+	require.NoError(t, tc.reconciler.ensureExternalSecretEnv(ctx, tc.mcpServer))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, tc.client.Get(ctx, types.NamespacedName{
+		Name:      externalSecretEnvName(tc.mcpServer.Name),
+		Namespace: tc.mcpServer.Namespace,
+	}, secret))
+	assert.Equal(t, map[string][]byte{"GITHUB_TOKEN": []byte("ghp_resolved")}, secret.Data)
+	assert.True(t, metav1.IsControlledBy(secret, tc.mcpServer), "Secret must be owned by the MCPServer")
+
+	dep, err := tc.reconciler.deploymentForMCPServer(ctx, tc.mcpServer, "test-checksum")
+	require.NoError(t, err)
+
+	// The value is injected through a secretKeyRef, never inlined
+	patch := podTemplatePatchFromDeployment(t, dep)
+	var mcpContainer *corev1.Container
+	for i := range patch.Spec.Containers {
+		if patch.Spec.Containers[i].Name == mcpContainerName {
+			mcpContainer = &patch.Spec.Containers[i]
+		}
+	}
+	require.NotNil(t, mcpContainer)
+	assert.Contains(t, mcpContainer.Env, corev1.EnvVar{
+		Name: "GITHUB_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: externalSecretEnvName(tc.mcpServer.Name)},
+				Key:                  "GITHUB_TOKEN",
+			},
+		},
+	})
+	assert.Equal(t, secret.ResourceVersion, patch.Annotations[externalSecretEnvVersionAnnotation])
+
+	depJSON, err := json.Marshal(dep)
+	require.NoError(t, err)
+	assert.NotContains(t, string(depJSON), "ghp_resolved")
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, convertEnvVarsFromMCPServer(tc.mcpServer.Spec.Env),
+		"externally sourced env vars must not be written to the RunConfig")
+
+	assert.False(t, tc.reconciler.deploymentNeedsUpdate(ctx, dep, tc.mcpServer, "test-checksum"))
+
+	// A rotated value updates the Secret and rolls the MCP server pod
+	resolver["vault/github#token"] = "ghp_rotated"
+	require.NoError(t, tc.reconciler.ensureExternalSecretEnv(ctx, tc.mcpServer))
+	assert.True(t, tc.reconciler.deploymentNeedsUpdate(ctx, dep, tc.mcpServer, "test-checksum"))
+
+	// Dropping the reference removes the managed Secret
+	tc.mcpServer.Spec.Env = tc.mcpServer.Spec.Env[:1]
+	require.NoError(t, tc.reconciler.ensureExternalSecretEnv(ctx, tc.mcpServer))
+	err = tc.client.Get(ctx, types.NamespacedName{
+		Name:      externalSecretEnvName(tc.mcpServer.Name),
+		Namespace: tc.mcpServer.Namespace,
+	}, &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err), "managed Secret should be deleted, got %v", err)
+}
+
+func TestEnsureExternalSecretEnv_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing reference", func(t *testing.T) {
+		t.Parallel()
+
+		tc := externalSecretTestContext(t, fakeExternalSecretResolver{})
+		err := tc.reconciler.ensureExternalSecretEnv(t.Context(), tc.mcpServer)
+		require.ErrorIs(t, err, externalsecrets.ErrNotFound)
+		assert.Contains(t, err.Error(), `failed to resolve env var GITHUB_TOKEN from vault secret "github#token"`)
+	})
+
+	t.Run("no resolver configured", func(t *testing.T) {
+		t.Parallel()
+
+		tc := externalSecretTestContext(t, nil)
+		err := tc.reconciler.ensureExternalSecretEnv(t.Context(), tc.mcpServer)
+		require.ErrorContains(t, err, "no external secrets resolver is configured")
+	})
+
+	t.Run("existing unmanaged Secret is left alone", func(t *testing.T) {
+		t.Parallel()
+
+		tc := externalSecretTestContext(t, fakeExternalSecretResolver{"vault/github#token": "ghp_resolved"})
+		unmanaged := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      externalSecretEnvName(tc.mcpServer.Name),
+				Namespace: tc.mcpServer.Namespace,
+			},
+			Data: map[string][]byte{"user": []byte("data")},
+		}
+		require.NoError(t, tc.client.Create(t.Context(), unmanaged))
+
+		err := tc.reconciler.ensureExternalSecretEnv(t.Context(), tc.mcpServer)
+		require.ErrorContains(t, err, "is not managed by MCPServer")
+
+		// Without external references the unmanaged Secret must not be deleted
+		tc.mcpServer.Spec.Env = tc.mcpServer.Spec.Env[:1]
+		require.NoError(t, tc.reconciler.ensureExternalSecretEnv(t.Context(), tc.mcpServer))
+		require.NoError(t, tc.client.Get(t.Context(), types.NamespacedName{
+			Name:      unmanaged.Name,
+			Namespace: unmanaged.Namespace,
+		}, &corev1.Secret{}))
+	})
+}
+
+func TestReconcile_ExternalSecretRefOutOfScope(t *testing.T) {
+	t.Parallel()
+
+	// Each namespace may only read keys under its own prefix. The provider is
+	// never called, so it must not be asked for the other namespace's secret.
+	provider := secretsmocks.NewMockProvider(gomock.NewController(t))
+	resolver := externalsecrets.NewProviderResolverWithFactories(
+		map[string]externalsecrets.ProviderFactory{
+			"vault": func() (secrets.Provider, error) { return provider, nil },
+		},
+		map[string]externalsecrets.Scope{"vault": {KeyPrefix: "toolhive/{namespace}/"}},
+	)
+
+	mcpServer := createTestMCPServer("external-env", "team-a")
+	mcpServer.Spec.Env = []mcpv1beta1.MCPServerEnvVar{{
+		Name: "GITHUB_TOKEN",
+		ValueFrom: &mcpv1beta1.EnvVarSource{
+			ExternalSecretRef: &mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "toolhive/team-b/github#token"},
+		},
+	}}
+
+	testScheme := testutil.NewScheme(t)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(mcpServer).
+		WithStatusSubresource(&mcpv1beta1.MCPServer{}).
+		Build()
+	reconciler := newTestMCPServerReconciler(fakeClient, testScheme, kubernetes.PlatformKubernetes)
+	reconciler.ExternalSecretResolver = resolver
+
+	key := types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}
+	result, err := reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err, "an out-of-scope reference is not retried")
+	assert.Equal(t, ctrl.Result{}, result)
+
+	updated := &mcpv1beta1.MCPServer{}
+	require.NoError(t, fakeClient.Get(t.Context(), key, updated))
+	assert.Equal(t, mcpv1beta1.MCPServerPhaseFailed, updated.Status.Phase)
+	condition := meta.FindStatusCondition(updated.Status.Conditions, mcpv1beta1.ConditionExternalSecretRefsValidated)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, mcpv1beta1.ConditionReasonExternalSecretRefOutOfScope, condition.Reason)
+	assert.Contains(t, condition.Message, `must start with "toolhive/team-a/"`)
+
+	err = fakeClient.Get(t.Context(), types.NamespacedName{
+		Name:      externalSecretEnvName(mcpServer.Name),
+		Namespace: mcpServer.Namespace,
+	}, &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err), "no Secret may be created for an out-of-scope reference, got %v", err)
+}
@@ -334,6 +334,91 @@ func TestProxyRunnerSecurityContext(t *testing.T) {
 	assert.False(t, *proxyRunnerContainerSecurityContext.AllowPrivilegeEscalation, "ProxyRunner container AllowPrivilegeEscalation should be false")
 }
 
+func TestDeploymentForMCPServerSecurityHardening(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		writableRootFilesystem bool
+		podTemplateSpec        *corev1.PodTemplateSpec
+		expectedReadOnly       bool
+		expectedDrop           []corev1.Capability
+	}{
+		{
+			name:             "hardened by default",
+			expectedReadOnly: true,
+			expectedDrop:     []corev1.Capability{"ALL"},
+		},
+		{
+			name:                   "writable root filesystem opt-out",
+			writableRootFilesystem: true,
+			expectedReadOnly:       false,
+			expectedDrop:           []corev1.Capability{"ALL"},
+		},
+		{
+			name: "user security context is preserved",
+			podTemplateSpec: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "mcp",
+						SecurityContext: &corev1.SecurityContext{
+							ReadOnlyRootFilesystem: boolPtr(false),
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"NET_RAW"},
+							},
+						},
+					}},
+				},
+			},
+			expectedReadOnly: false,
+			expectedDrop:     []corev1.Capability{"NET_RAW"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mcpServer := v1beta1test.NewMCPServer("test-mcp-server-hardening", "default")
+			mcpServer.Spec.WritableRootFilesystem = tt.writableRootFilesystem
+			mcpServer.Spec.PodTemplateSpec = podTemplateSpecToRawExtension(t, tt.podTemplateSpec)
+
+			s := testutil.NewScheme(t)
+			s.AddKnownTypes(mcpv1beta1.GroupVersion, &mcpv1beta1.MCPServer{})
+			s.AddKnownTypes(mcpv1beta1.GroupVersion, &mcpv1beta1.MCPServerList{})
+			r := newTestMCPServerReconciler(nil, s, kubernetes.PlatformKubernetes)
+
+			deployment, err := r.deploymentForMCPServer(context.Background(), mcpServer, "test-checksum")
+			require.NoError(t, err)
+
+			var podTemplatePatch string
+			for _, arg := range deployment.Spec.Template.Spec.Containers[0].Args {
+				if strings.HasPrefix(arg, "--k8s-pod-patch=") {
+					podTemplatePatch = strings.TrimPrefix(arg, "--k8s-pod-patch=")
+					break
+				}
+			}
+			require.NotEmpty(t, podTemplatePatch, "Pod template patch should be present in args")
+
+			var patch corev1.PodTemplateSpec
+			require.NoError(t, json.Unmarshal([]byte(podTemplatePatch), &patch))
+
+			require.NotNil(t, patch.Spec.SecurityContext)
+			assert.True(t, *patch.Spec.SecurityContext.RunAsNonRoot)
+			require.NotNil(t, patch.Spec.SecurityContext.SeccompProfile)
+			assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, patch.Spec.SecurityContext.SeccompProfile.Type)
+
+			require.Len(t, patch.Spec.Containers, 1)
+			assert.Equal(t, "mcp", patch.Spec.Containers[0].Name)
+			securityContext := patch.Spec.Containers[0].SecurityContext
+			require.NotNil(t, securityContext)
+			assert.Equal(t, tt.expectedReadOnly, *securityContext.ReadOnlyRootFilesystem)
+			assert.False(t, *securityContext.AllowPrivilegeEscalation)
+			assert.Equal(t, tt.expectedDrop, securityContext.Capabilities.Drop)
+		})
+	}
+}
+
 func TestProxyRunnerStructuredLogsEnvVar(t *testing.T) {
 	t.Parallel()
 
@@ -34,19 +34,38 @@ const defaultAPITimeout = 15 * time.Second
 
 // ensureRunConfigConfigMap ensures the RunConfig ConfigMap exists and is up to date
 func (r *MCPServerReconciler) ensureRunConfigConfigMap(ctx context.Context, m *mcpv1beta1.MCPServer) error {
+	cm, err := r.runConfigConfigMapForMCPServer(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	// Use the kubernetes configmaps client for upsert operations
+	configMapsClient := configmaps.NewClient(r.Client, r.Scheme)
+	if _, err := configMapsClient.UpsertWithOwnerReference(ctx, cm, m); err != nil {
+		return fmt.Errorf("failed to upsert RunConfig ConfigMap: %w", err)
+	}
+
+	return nil
+}
+
+// runConfigConfigMapForMCPServer builds the desired RunConfig ConfigMap, with its
+// content checksum annotation, without writing it.
+func (r *MCPServerReconciler) runConfigConfigMapForMCPServer(
+	ctx context.Context, m *mcpv1beta1.MCPServer,
+) (*corev1.ConfigMap, error) {
 	runConfig, err := r.createRunConfigFromMCPServer(m)
 	if err != nil {
-		return fmt.Errorf("failed to create RunConfig from MCPServer: %w", err)
+		return nil, fmt.Errorf("failed to create RunConfig from MCPServer: %w", err)
 	}
 
 	// Validate the RunConfig before creating the ConfigMap
 	if err := r.validateRunConfig(ctx, runConfig); err != nil {
-		return fmt.Errorf("invalid RunConfig: %w", err)
+		return nil, fmt.Errorf("invalid RunConfig: %w", err)
 	}
 
 	runConfigJSON, err := json.MarshalIndent(runConfig, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal run config: %w", err)
+		return nil, fmt.Errorf("failed to marshal run config: %w", err)
 	}
 
 	configMapName := fmt.Sprintf("%s-runconfig", m.Name)
@@ -68,13 +87,7 @@ func (r *MCPServerReconciler) ensureRunConfigConfigMap(ctx context.Context, m *m
 		checksum.ContentChecksumAnnotation: cs,
 	}
 
-	// Use the kubernetes configmaps client for upsert operations
-	configMapsClient := configmaps.NewClient(r.Client, r.Scheme)
-	if _, err := configMapsClient.UpsertWithOwnerReference(ctx, cm, m); err != nil {
-		return fmt.Errorf("failed to upsert RunConfig ConfigMap: %w", err)
-	}
-
-	return nil
+	return cm, nil
 }
 
 // createRunConfigFromMCPServer converts MCPServer spec to RunConfig using the builder pattern
@@ -575,15 +588,23 @@ func (*MCPServerReconciler) validateToolsFilter(config *runner.RunConfig) error
 	return nil
 }
 
-// convertEnvVarsFromMCPServer converts MCPServer environment variables to builder format
-func convertEnvVarsFromMCPServer(envs []mcpv1beta1.EnvVar) map[string]string {
+// convertEnvVarsFromMCPServer converts MCPServer environment variables to builder format.
+// Variables sourced from an external secrets manager are skipped: their values are injected
+// into the pod from the managed external-env Secret and must not land in the RunConfig.
+func convertEnvVarsFromMCPServer(envs []mcpv1beta1.MCPServerEnvVar) map[string]string {
 	if len(envs) == 0 {
 		return nil
 	}
 	envVars := make(map[string]string, len(envs))
 	for _, env := range envs {
+		if env.ValueFrom != nil {
+			continue
+		}
 		envVars[env.Name] = env.Value
 	}
+	if len(envVars) == 0 {
+		return nil
+	}
 	return envVars
 }
 
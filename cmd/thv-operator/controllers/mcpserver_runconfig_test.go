@@ -36,7 +36,7 @@ const (
 	streamableHTTPProxyMode = "streamable-http"
 )
 
-func createTestMCPServerWithConfig(name, namespace, image string, envVars []mcpv1beta1.EnvVar) *mcpv1beta1.MCPServer {
+func createTestMCPServerWithConfig(name, namespace, image string, envVars []mcpv1beta1.MCPServerEnvVar) *mcpv1beta1.MCPServer {
 	return v1beta1test.NewMCPServer(name, namespace,
 		v1beta1test.WithImage(image),
 		v1beta1test.WithEnv(envVars...))
@@ -68,8 +68,8 @@ func TestCreateRunConfigFromMCPServer(t *testing.T) {
 				v1beta1test.WithTransport("sse"),
 				v1beta1test.WithProxyPort(9090),
 				v1beta1test.WithEnv(
-					mcpv1beta1.EnvVar{Name: "VAR1", Value: "value1"},
-					mcpv1beta1.EnvVar{Name: "VAR2", Value: "value2"},
+					mcpv1beta1.MCPServerEnvVar{Name: "VAR1", Value: "value1"},
+					mcpv1beta1.MCPServerEnvVar{Name: "VAR2", Value: "value2"},
 				)),
 			//nolint:thelper // We want to see the error at the specific line
 			expected: func(t *testing.T, config *runner.RunConfig) {
@@ -221,7 +221,7 @@ func TestCreateRunConfigFromMCPServer(t *testing.T) {
 					MCPPort:   8080,
 					ProxyMode: "streamable-http",
 					Args:      []string{"--comprehensive", "--test"},
-					Env: []mcpv1beta1.EnvVar{
+					Env: []mcpv1beta1.MCPServerEnvVar{
 						{Name: "ENV1", Value: "value1"},
 						{Name: "ENV2", Value: "value2"},
 						{Name: "EMPTY_VALUE", Value: ""},
@@ -423,7 +423,7 @@ func TestDeterministicConfigMapGeneration(t *testing.T) {
 			ProxyPort: 9090,
 			MCPPort:   8080,
 			Args:      []string{"--arg1", "--arg2", "--complex-flag=value"},
-			Env: []mcpv1beta1.EnvVar{
+			Env: []mcpv1beta1.MCPServerEnvVar{
 				{Name: "VAR_C", Value: "value_c"},
 				{Name: "VAR_A", Value: "value_a"},
 				{Name: "VAR_B", Value: "value_b"},
@@ -1061,7 +1061,7 @@ func TestEnsureRunConfigConfigMapCompleteFlow(t *testing.T) {
 	}
 
 	// Step 1: Create initial MCPServer and ConfigMap
-	mcpServer := createTestMCPServerWithConfig("flow-server", "flow-ns", "test:v1", []mcpv1beta1.EnvVar{
+	mcpServer := createTestMCPServerWithConfig("flow-server", "flow-ns", "test:v1", []mcpv1beta1.MCPServerEnvVar{
 		{Name: "ENV1", Value: "value1"},
 	})
 
@@ -1092,7 +1092,7 @@ func TestEnsureRunConfigConfigMapCompleteFlow(t *testing.T) {
 	// The checksum will automatically change when content changes
 
 	mcpServer.Spec.Image = "test:v2"
-	mcpServer.Spec.Env = []mcpv1beta1.EnvVar{
+	mcpServer.Spec.Env = []mcpv1beta1.MCPServerEnvVar{
 		{Name: "ENV1", Value: "value1"},
 		{Name: "ENV2", Value: "value2"},
 	}
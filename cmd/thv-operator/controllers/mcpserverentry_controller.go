@@ -22,6 +22,7 @@ import (
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/validation"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 const (
@@ -199,7 +200,7 @@ func (r *MCPServerEntryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findEntriesForHeaderSecret),
 		).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcpserverentry", r))
 }
 
 // validateGroupRef checks that the referenced MCPGroup exists and is ready.
@@ -28,6 +28,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 // Public contract for the StorageVersionMigrator controller.
@@ -272,7 +274,7 @@ func (r *StorageVersionMigratorReconciler) SetupWithManager(mgr ctrl.Manager) er
 				predicate.ResourceVersionChangedPredicate{},
 			),
 		).
-		Complete(r); err != nil {
+		Complete(operatortelemetry.InstrumentReconciler("storageversionmigrator", r)); err != nil {
 		return err
 	}
 
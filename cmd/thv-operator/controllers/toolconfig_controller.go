@@ -24,6 +24,7 @@ import (
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	ctrlutil "github.com/stacklok/toolhive/cmd/thv-operator/pkg/controllerutil"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 )
 
 const (
@@ -273,7 +274,7 @@ func (r *ToolConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&mcpv1beta1.MCPServer{},
 			handler.EnqueueRequestsFromMapFunc(r.mapMCPServerToToolConfig),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("mcptoolconfig", r))
 }
 
 // mapMCPServerToToolConfig maps an MCPServer to the MCPToolConfig it currently
@@ -21,12 +21,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,6 +46,7 @@ import (
 	operatorvmcpconfig "github.com/stacklok/toolhive/cmd/thv-operator/pkg/vmcpconfig"
 	"github.com/stacklok/toolhive/pkg/authserver"
 	"github.com/stacklok/toolhive/pkg/networking"
+	operatortelemetry "github.com/stacklok/toolhive/pkg/operator/telemetry"
 	vmcptypes "github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/auth/converters"
 	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
@@ -1718,6 +1721,26 @@ func (r *VirtualMCPServerReconciler) containerNeedsUpdate(
 		return true
 	}
 
+	// Check if resource requirements have changed (they follow the backend count when
+	// spec.resourceScaling is set)
+	expectedResources, err := r.expectedVmcpContainerResources(ctx, vmcp, len(typedWorkloads))
+	if err != nil {
+		return true // Trigger update to surface the error
+	}
+	if !equality.Semantic.DeepEqual(container.Resources, expectedResources) {
+		return true
+	}
+
+	// Check if the root filesystem mode has changed (spec.writableRootFilesystem)
+	expectedReadOnly, err := expectedVmcpReadOnlyRootFilesystem(vmcp)
+	if err != nil {
+		return true
+	}
+	if container.SecurityContext == nil ||
+		ptr.Deref(container.SecurityContext.ReadOnlyRootFilesystem, false) != expectedReadOnly {
+		return true
+	}
+
 	// Check if service account has changed
 	expectedServiceAccountName := r.serviceAccountNameForVmcp(vmcp)
 	currentServiceAccountName := deployment.Spec.Template.Spec.ServiceAccountName
@@ -2242,6 +2265,12 @@ func (*VirtualMCPServerReconciler) convertExternalAuthConfigToStrategy(
 		externalAuthConfig.Spec.TokenExchange.ClientSecretRef != nil {
 		strategy.TokenExchange.ClientSecretEnv = ctrlutil.GenerateUniqueTokenExchangeEnvVarName(externalAuthConfig.Name)
 	}
+	if strategy.TokenExchange != nil &&
+		externalAuthConfig.Spec.TokenExchange != nil &&
+		externalAuthConfig.Spec.TokenExchange.ClientAssertionKeyRef != nil {
+		strategy.TokenExchange.ClientAssertionKeyEnv =
+			ctrlutil.GenerateUniqueTokenExchangeClientAssertionKeyEnvVarName(externalAuthConfig.Name)
+	}
 	if strategy.HeaderInjection != nil &&
 		externalAuthConfig.Spec.HeaderInjection != nil &&
 		externalAuthConfig.Spec.HeaderInjection.ValueSecretRef != nil {
@@ -2798,7 +2827,7 @@ func (r *VirtualMCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.mapAuthzConfigMapToVirtualMCPServer),
 			builder.WithPredicates(configMapDataChangedPredicate()),
 		).
-		Complete(r)
+		Complete(operatortelemetry.InstrumentReconciler("virtualmcpserver", r))
 }
 
 // mapMCPGroupToVirtualMCPServer maps MCPGroup changes to VirtualMCPServer reconciliation requests
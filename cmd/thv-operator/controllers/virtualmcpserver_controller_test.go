@@ -1612,8 +1612,12 @@ func TestVirtualMCPServerContainerNeedsUpdate(t *testing.T) {
 									Ports: []corev1.ContainerPort{
 										{ContainerPort: 4483},
 									},
-									Args: reconciler.buildContainerArgsForVmcp(vmcp),
-									Env:  mustBuildEnvVarsForVmcp(reconciler, vmcp),
+									Args:      reconciler.buildContainerArgsForVmcp(vmcp),
+									Env:       mustBuildEnvVarsForVmcp(reconciler, vmcp),
+									Resources: mustResourceRequirementsForVmcp(vmcp),
+									SecurityContext: &corev1.SecurityContext{
+										ReadOnlyRootFilesystem: ptr.To(true),
+									},
 								},
 							},
 							ServiceAccountName: vmcpServiceAccountName(vmcp.Name),
@@ -2048,8 +2052,12 @@ func TestVirtualMCPServerDeploymentNeedsUpdate(t *testing.T) {
 									Ports: []corev1.ContainerPort{
 										{ContainerPort: 4483},
 									},
-									Args: reconciler.buildContainerArgsForVmcp(vmcp),
-									Env:  mustBuildEnvVarsForVmcp(reconciler, vmcp),
+									Args:      reconciler.buildContainerArgsForVmcp(vmcp),
+									Env:       mustBuildEnvVarsForVmcp(reconciler, vmcp),
+									Resources: mustResourceRequirementsForVmcp(vmcp),
+									SecurityContext: &corev1.SecurityContext{
+										ReadOnlyRootFilesystem: ptr.To(true),
+									},
 								},
 							},
 							ServiceAccountName: vmcpServiceAccountName(vmcp.Name),
@@ -3137,6 +3145,14 @@ func mustBuildEnvVarsForVmcp(r *VirtualMCPServerReconciler, vmcp *mcpv1beta1.Vir
 	return env
 }
 
+func mustResourceRequirementsForVmcp(vmcp *mcpv1beta1.VirtualMCPServer) corev1.ResourceRequirements {
+	resources, err := resourceRequirementsForVmcp(vmcp, 0)
+	if err != nil {
+		panic("mustResourceRequirementsForVmcp: " + err.Error())
+	}
+	return resources
+}
+
 // TestGetExternalAuthConfigNameFromWorkload tests auth config ref extraction from all workload types
 func TestGetExternalAuthConfigNameFromWorkload(t *testing.T) {
 	t.Parallel()
@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -79,6 +80,13 @@ const (
 	vmcpDefaultMemoryRequest = "128Mi"
 	vmcpDefaultCPULimit      = "500m"
 	vmcpDefaultMemoryLimit   = "512Mi"
+
+	// Defaults for spec.resourceScaling: the amount each backend adds to the requests and
+	// limits above, and the cap on the result
+	vmcpDefaultCPUPerBackend    = "25m"
+	vmcpDefaultMemoryPerBackend = "32Mi"
+	vmcpDefaultMaxCPU           = "2"
+	vmcpDefaultMaxMemory        = "2Gi"
 )
 
 // RBAC rules for VirtualMCPServer service account in inline mode
@@ -153,6 +161,11 @@ func (r *VirtualMCPServerReconciler) deploymentForVirtualMCPServer(
 		log.FromContext(ctx).Error(err, "Failed to build env vars for VirtualMCPServer")
 		return nil
 	}
+	resources, err := resourceRequirementsForVmcp(vmcp, len(typedWorkloads))
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to build resource requirements for VirtualMCPServer")
+		return nil
+	}
 
 	// Add CA bundle volumes for MCPServerEntry backends with caBundleRef
 	caVolumes, caMounts, err := r.buildCABundleVolumesForEntries(ctx, vmcp.Namespace, typedWorkloads)
@@ -221,16 +234,7 @@ func (r *VirtualMCPServerReconciler) deploymentForVirtualMCPServer(
 							vmcpReadinessInitialDelay, vmcpReadinessPeriod, vmcpReadinessTimeout, vmcpReadinessFailures,
 						),
 						SecurityContext: containerSecurityContext,
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(vmcpDefaultCPURequest),
-								corev1.ResourceMemory: resource.MustParse(vmcpDefaultMemoryRequest),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(vmcpDefaultCPULimit),
-								corev1.ResourceMemory: resource.MustParse(vmcpDefaultMemoryLimit),
-							},
-						},
+						Resources:       resources,
 					}},
 					Volumes:         volumes,
 					SecurityContext: podSecurityContext,
@@ -257,6 +261,80 @@ func (r *VirtualMCPServerReconciler) deploymentForVirtualMCPServer(
 	return dep
 }
 
+// resourceRequirementsForVmcp returns the vmcp container's resource requirements: the
+// fixed defaults, or, when spec.resourceScaling is set, the defaults grown with the
+// number of backends and capped as configured.
+func resourceRequirementsForVmcp(
+	vmcp *mcpv1beta1.VirtualMCPServer,
+	backendCount int,
+) (corev1.ResourceRequirements, error) {
+	base := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(vmcpDefaultCPURequest),
+			corev1.ResourceMemory: resource.MustParse(vmcpDefaultMemoryRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(vmcpDefaultCPULimit),
+			corev1.ResourceMemory: resource.MustParse(vmcpDefaultMemoryLimit),
+		},
+	}
+	scaling := vmcp.Spec.ResourceScaling
+	if scaling == nil {
+		return base, nil
+	}
+
+	perBackend, err := ctrlutil.ParseResourceList(scaling.PerBackend)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("spec.resourceScaling.perBackend: %w", err)
+	}
+	maxResources, err := ctrlutil.ParseResourceList(scaling.Max)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("spec.resourceScaling.max: %w", err)
+	}
+	setDefaultQuantity(perBackend, corev1.ResourceCPU, vmcpDefaultCPUPerBackend)
+	setDefaultQuantity(perBackend, corev1.ResourceMemory, vmcpDefaultMemoryPerBackend)
+	setDefaultQuantity(maxResources, corev1.ResourceCPU, vmcpDefaultMaxCPU)
+	setDefaultQuantity(maxResources, corev1.ResourceMemory, vmcpDefaultMaxMemory)
+
+	return ctrlutil.ScaleResourceRequirements(base, perBackend, maxResources, backendCount), nil
+}
+
+// expectedVmcpContainerResources returns the resources the vmcp container is deployed
+// with: resourceRequirementsForVmcp with any PodTemplateSpec override merged on top, so
+// that an override is not mistaken for drift.
+func (r *VirtualMCPServerReconciler) expectedVmcpContainerResources(
+	ctx context.Context,
+	vmcp *mcpv1beta1.VirtualMCPServer,
+	backendCount int,
+) (corev1.ResourceRequirements, error) {
+	resources, err := resourceRequirementsForVmcp(vmcp, backendCount)
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	if vmcp.Spec.PodTemplateSpec == nil || len(vmcp.Spec.PodTemplateSpec.Raw) == 0 {
+		return resources, nil
+	}
+
+	dep := &appsv1.Deployment{}
+	dep.Spec.Template.Spec.Containers = []corev1.Container{{Name: "vmcp", Resources: resources}}
+	if err := r.applyPodTemplateSpecToDeployment(ctx, vmcp, dep); err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	for _, container := range dep.Spec.Template.Spec.Containers {
+		if container.Name == "vmcp" {
+			return container.Resources, nil
+		}
+	}
+	return resources, nil
+}
+
+// setDefaultQuantity sets name in list to value unless it is already set.
+func setDefaultQuantity(list corev1.ResourceList, name corev1.ResourceName, value string) {
+	if _, ok := list[name]; !ok {
+		list[name] = resource.MustParse(value)
+	}
+}
+
 // buildContainerArgsForVmcp builds the container arguments for vmcp
 func (*VirtualMCPServerReconciler) buildContainerArgsForVmcp(
 	vmcp *mcpv1beta1.VirtualMCPServer,
@@ -816,14 +894,7 @@ func (r *VirtualMCPServerReconciler) getExternalAuthConfigSecretEnvVars(
 
 	switch externalAuthConfig.Spec.Type {
 	case mcpv1beta1.ExternalAuthTypeTokenExchange:
-		if externalAuthConfig.Spec.TokenExchange == nil {
-			return nil, nil
-		}
-		if externalAuthConfig.Spec.TokenExchange.ClientSecretRef == nil {
-			return nil, nil // No secret to mount
-		}
-		envVarName = ctrlutil.GenerateUniqueTokenExchangeEnvVarName(externalAuthConfigName)
-		secretRef = externalAuthConfig.Spec.TokenExchange.ClientSecretRef
+		return tokenExchangeSecretEnvVars(externalAuthConfig, externalAuthConfigName), nil
 
 	case mcpv1beta1.ExternalAuthTypeHeaderInjection:
 		if externalAuthConfig.Spec.HeaderInjection == nil {
@@ -888,6 +959,41 @@ func (r *VirtualMCPServerReconciler) getExternalAuthConfigSecretEnvVars(
 	}}, nil
 }
 
+// tokenExchangeSecretEnvVars returns the env vars needed to mount the token exchange
+// client secret or client assertion key into the vMCP pod. Returns nil when the
+// token exchange spec is absent or has no secret refs.
+func tokenExchangeSecretEnvVars(
+	externalAuthConfig *mcpv1beta1.MCPExternalAuthConfig, configName string,
+) []corev1.EnvVar {
+	if externalAuthConfig.Spec.TokenExchange == nil {
+		return nil
+	}
+	var envVars []corev1.EnvVar
+	if ref := externalAuthConfig.Spec.TokenExchange.ClientSecretRef; ref != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: ctrlutil.GenerateUniqueTokenExchangeEnvVarName(configName),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+					Key:                  ref.Key,
+				},
+			},
+		})
+	}
+	if ref := externalAuthConfig.Spec.TokenExchange.ClientAssertionKeyRef; ref != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: ctrlutil.GenerateUniqueTokenExchangeClientAssertionKeyEnvVarName(configName),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+					Key:                  ref.Key,
+				},
+			},
+		})
+	}
+	return envVars
+}
+
 // xaaSecretEnvVars returns the env vars needed to mount XAA client secrets into
 // the vMCP pod. Returns nil when the XAA spec is absent or has no secret refs.
 func xaaSecretEnvVars(externalAuthConfig *mcpv1beta1.MCPExternalAuthConfig, configName string) []corev1.EnvVar {
@@ -1006,7 +1112,29 @@ func (r *VirtualMCPServerReconciler) buildSecurityContextsForVmcp(
 	}
 
 	securityBuilder := kubernetes.NewSecurityContextBuilder(detectedPlatform)
-	return securityBuilder.BuildPodSecurityContext(), securityBuilder.BuildContainerSecurityContext()
+	containerSecurityContext := securityBuilder.BuildContainerSecurityContext()
+	if vmcp.Spec.WritableRootFilesystem {
+		containerSecurityContext.ReadOnlyRootFilesystem = ptr.To(false)
+	}
+	return securityBuilder.BuildPodSecurityContext(), containerSecurityContext
+}
+
+// expectedVmcpReadOnlyRootFilesystem returns whether the vmcp container should run
+// with a read-only root filesystem once spec.podTemplateSpec has been applied.
+// An explicit setting in the PodTemplateSpec takes precedence over
+// spec.writableRootFilesystem.
+func expectedVmcpReadOnlyRootFilesystem(vmcp *mcpv1beta1.VirtualMCPServer) (bool, error) {
+	builder, err := ctrlutil.NewPodTemplateSpecBuilder(vmcp.Spec.PodTemplateSpec, "vmcp")
+	if err != nil {
+		return false, err
+	}
+	spec := builder.WithSecurityHardening(vmcp.Spec.WritableRootFilesystem).Build()
+	for _, container := range spec.Spec.Containers {
+		if container.Name == "vmcp" {
+			return ptr.Deref(container.SecurityContext.ReadOnlyRootFilesystem, true), nil
+		}
+	}
+	return !vmcp.Spec.WritableRootFilesystem, nil
 }
 
 // buildContainerPortsForVmcp builds container port configuration
@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -367,7 +368,56 @@ func TestBuildSecurityContextsForVmcp(t *testing.T) {
 	podSecCtx, containerSecCtx := r.buildSecurityContextsForVmcp(context.Background(), vmcp)
 
 	assert.NotNil(t, podSecCtx)
-	assert.NotNil(t, containerSecCtx)
+	require.NotNil(t, containerSecCtx)
+	assert.True(t, *containerSecCtx.ReadOnlyRootFilesystem)
+
+	writable := v1beta1test.NewVirtualMCPServer("test-vmcp", "default")
+	writable.Spec.WritableRootFilesystem = true
+	_, containerSecCtx = r.buildSecurityContextsForVmcp(context.Background(), writable)
+	require.NotNil(t, containerSecCtx)
+	assert.False(t, *containerSecCtx.ReadOnlyRootFilesystem)
+}
+
+// TestExpectedVmcpReadOnlyRootFilesystem tests that explicit PodTemplateSpec
+// settings take precedence over spec.writableRootFilesystem.
+func TestExpectedVmcpReadOnlyRootFilesystem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		writable        bool
+		podTemplateSpec string
+		expected        bool
+	}{
+		{name: "read-only by default", expected: true},
+		{name: "writable opt-out", writable: true, expected: false},
+		{
+			name:            "user override is preserved",
+			podTemplateSpec: `{"spec":{"containers":[{"name":"vmcp","securityContext":{"readOnlyRootFilesystem":false}}]}}`,
+			expected:        false,
+		},
+		{
+			name:            "other containers are ignored",
+			podTemplateSpec: `{"spec":{"containers":[{"name":"sidecar","securityContext":{"readOnlyRootFilesystem":false}}]}}`,
+			expected:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vmcp := v1beta1test.NewVirtualMCPServer("test-vmcp", "default")
+			vmcp.Spec.WritableRootFilesystem = tt.writable
+			if tt.podTemplateSpec != "" {
+				vmcp.Spec.PodTemplateSpec = &runtime.RawExtension{Raw: []byte(tt.podTemplateSpec)}
+			}
+
+			readOnly, err := expectedVmcpReadOnlyRootFilesystem(vmcp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, readOnly)
+		})
+	}
 }
 
 // TestBuildContainerPortsForVmcp tests container port generation
@@ -1103,6 +1153,115 @@ func TestDeploymentForVirtualMCPServer_AuthServerConfig_NoUpdateLoop(t *testing.
 		"deploymentNeedsUpdate must not loop on a vMCP with AuthServerConfig (regression #5616)")
 }
 
+// TestDeploymentForVirtualMCPServer_ResourceScaling verifies that spec.resourceScaling
+// grows the vmcp container's resources with the group's backends, that the drift check
+// follows the backend count, and that a PodTemplateSpec override is not seen as drift.
+func TestDeploymentForVirtualMCPServer_ResourceScaling(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewScheme(t)
+	r := &VirtualMCPServerReconciler{
+		Client:           fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:           scheme,
+		PlatformDetector: ctrlutil.NewSharedPlatformDetector(),
+	}
+	backends := func(n int) []workloads.TypedWorkload {
+		typed := make([]workloads.TypedWorkload, n)
+		for i := range typed {
+			typed[i] = workloads.TypedWorkload{Name: fmt.Sprintf("backend-%d", i), Type: workloads.WorkloadTypeMCPServer}
+		}
+		return typed
+	}
+	const cfgChecksum = "test-checksum"
+
+	t.Run("defaults scale with the backend count", func(t *testing.T) {
+		t.Parallel()
+
+		vmcp := v1beta1test.NewVirtualMCPServer("test-vmcp", "default",
+			v1beta1test.WithVMCPGroupRef("test-group"),
+			v1beta1test.WithVMCPResourceScaling(&mcpv1beta1.ResourceScalingConfig{}),
+		)
+
+		dep := r.deploymentForVirtualMCPServer(t.Context(), vmcp, cfgChecksum, nil, backends(8))
+		require.NotNil(t, dep)
+		resources := dep.Spec.Template.Spec.Containers[0].Resources
+		assert.Equal(t, "300m", resources.Requests.Cpu().String())
+		assert.Equal(t, "384Mi", resources.Requests.Memory().String())
+		assert.Equal(t, "700m", resources.Limits.Cpu().String())
+		assert.Equal(t, "768Mi", resources.Limits.Memory().String())
+
+		assert.False(t, r.containerNeedsUpdate(t.Context(), dep, vmcp, nil, backends(8)))
+		assert.True(t, r.containerNeedsUpdate(t.Context(), dep, vmcp, nil, backends(9)),
+			"a new backend must resize the container")
+	})
+
+	t.Run("configured amounts and caps", func(t *testing.T) {
+		t.Parallel()
+
+		vmcp := v1beta1test.NewVirtualMCPServer("test-vmcp", "default",
+			v1beta1test.WithVMCPGroupRef("test-group"),
+			v1beta1test.WithVMCPResourceScaling(&mcpv1beta1.ResourceScalingConfig{
+				PerBackend: mcpv1beta1.ResourceList{Memory: "64Mi"},
+				Max:        mcpv1beta1.ResourceList{Memory: "1Gi"},
+			}),
+		)
+
+		resources, err := resourceRequirementsForVmcp(vmcp, 100)
+		require.NoError(t, err)
+		assert.Equal(t, "1Gi", resources.Requests.Memory().String())
+		assert.Equal(t, "1Gi", resources.Limits.Memory().String())
+		assert.Equal(t, "2", resources.Limits.Cpu().String(), "the CPU cap defaults to 2")
+	})
+
+	t.Run("without resourceScaling the fixed defaults apply", func(t *testing.T) {
+		t.Parallel()
+
+		vmcp := v1beta1test.NewVirtualMCPServer("test-vmcp", "default",
+			v1beta1test.WithVMCPGroupRef("test-group"),
+		)
+
+		resources, err := resourceRequirementsForVmcp(vmcp, 50)
+		require.NoError(t, err)
+		assert.Equal(t, vmcpDefaultMemoryRequest, resources.Requests.Memory().String())
+		assert.Equal(t, vmcpDefaultMemoryLimit, resources.Limits.Memory().String())
+	})
+
+	t.Run("invalid quantity blocks the deployment", func(t *testing.T) {
+		t.Parallel()
+
+		vmcp := v1beta1test.NewVirtualMCPServer("test-vmcp", "default",
+			v1beta1test.WithVMCPGroupRef("test-group"),
+			v1beta1test.WithVMCPResourceScaling(&mcpv1beta1.ResourceScalingConfig{
+				Max: mcpv1beta1.ResourceList{CPU: "lots"},
+			}),
+		)
+
+		_, err := resourceRequirementsForVmcp(vmcp, 1)
+		assert.ErrorContains(t, err, "spec.resourceScaling.max")
+		assert.Nil(t, r.deploymentForVirtualMCPServer(t.Context(), vmcp, cfgChecksum, nil, backends(1)))
+	})
+
+	t.Run("PodTemplateSpec override is not drift", func(t *testing.T) {
+		t.Parallel()
+
+		vmcp := v1beta1test.NewVirtualMCPServer("test-vmcp", "default",
+			v1beta1test.WithVMCPGroupRef("test-group"),
+			v1beta1test.WithVMCPResourceScaling(&mcpv1beta1.ResourceScalingConfig{}),
+			v1beta1test.WithVMCPPodTemplateSpec(&runtime.RawExtension{
+				Raw: []byte(`{"spec":{"containers":[{"name":"vmcp","resources":{"limits":{"memory":"4Gi"}}}]}}`),
+			}),
+		)
+
+		dep := r.deploymentForVirtualMCPServer(t.Context(), vmcp, cfgChecksum, nil, backends(3))
+		require.NotNil(t, dep)
+		resources := dep.Spec.Template.Spec.Containers[0].Resources
+		assert.Equal(t, "4Gi", resources.Limits.Memory().String())
+		assert.Equal(t, "224Mi", resources.Requests.Memory().String())
+
+		assert.False(t, r.containerNeedsUpdate(t.Context(), dep, vmcp, nil, backends(3)))
+	})
+}
+
 // TestImagePullSecretsHash verifies the hash helper normalizes order, treats an
 // empty list as the sentinel "" hash, and produces stable hashes across calls.
 func TestImagePullSecretsHash(t *testing.T) {
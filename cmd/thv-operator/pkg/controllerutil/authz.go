@@ -7,6 +7,7 @@ package controllerutil
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -34,8 +35,20 @@ const (
 	// `authz.Config.Validate()` does not see two divergent values from the same
 	// pipeline. The rest of `pkg/authz/` uses the same literal.
 	AuthzConfigVersion = "1.0"
+
+	// AuthzConfigMountPath is where the authorization ConfigMap is mounted in
+	// the proxy container
+	AuthzConfigMountPath = "/etc/toolhive/authz"
+
+	// AuthzPolicyFilePath is the mounted authorization config file the proxy
+	// reloads its policy set from when hot reload is enabled
+	AuthzPolicyFilePath = AuthzConfigMountPath + "/" + DefaultAuthzKey
 )
 
+// ErrAuthzPolicyRejected is returned when a hot-reloaded policy set does not
+// compile. The proxy keeps enforcing its previous policy set.
+var ErrAuthzPolicyRejected = errors.New("authorization policy set rejected")
+
 // GenerateAuthzVolumeConfig generates volume mount and volume for authorization policies
 func GenerateAuthzVolumeConfig(
 	authzConfig *mcpv1beta1.AuthzConfigRef,
@@ -53,7 +66,7 @@ func GenerateAuthzVolumeConfig(
 
 		volumeMount := &corev1.VolumeMount{
 			Name:      "authz-config",
-			MountPath: "/etc/toolhive/authz",
+			MountPath: AuthzConfigMountPath,
 			ReadOnly:  true,
 		}
 
@@ -88,7 +101,7 @@ func GenerateAuthzVolumeConfig(
 
 		volumeMount := &corev1.VolumeMount{
 			Name:      "authz-config",
-			MountPath: "/etc/toolhive/authz",
+			MountPath: AuthzConfigMountPath,
 			ReadOnly:  true,
 		}
 
@@ -116,7 +129,9 @@ func GenerateAuthzVolumeConfig(
 	}
 }
 
-// EnsureAuthzConfigMap ensures the authorization ConfigMap exists for inline configuration
+// EnsureAuthzConfigMap ensures the authorization ConfigMap exists for inline configuration.
+// When hot reload is enabled and the inline policies do not compile, the ConfigMap is left
+// unchanged and an error wrapping ErrAuthzPolicyRejected is returned.
 func EnsureAuthzConfigMap(
 	ctx context.Context,
 	c client.Client,
@@ -132,6 +147,12 @@ func EnsureAuthzConfigMap(
 		return nil
 	}
 
+	// With hot reload the running proxy picks up whatever is written here, so
+	// an invalid policy set is not written at all.
+	if err := CheckAuthzPolicySet(ctx, c, namespace, authzConfig); err != nil {
+		return err
+	}
+
 	configMapName := fmt.Sprintf("%s-authz-inline", resourceName)
 
 	authzConfigData := map[string]interface{}{
@@ -210,7 +231,61 @@ func addAuthzInlineConfigOptions(
 	if err != nil {
 		return err
 	}
-	*options = append(*options, runner.WithAuthzConfig(authzCfg))
+	return addAuthzRunConfigOptions(authzCfg, authzRef, options)
+}
+
+// addAuthzRunConfigOptions adds cfg to the builder options. With hot reload,
+// the policy set is left out of the RunConfig and read from the mounted
+// ConfigMap instead, so that editing the policies changes neither the
+// RunConfig nor its checksum and does not restart the proxy.
+func addAuthzRunConfigOptions(
+	cfg *authz.Config,
+	authzRef *mcpv1beta1.AuthzConfigRef,
+	options *[]runner.RunConfigBuilderOption,
+) error {
+	if authzRef.HotReload {
+		stripped, err := cedar.ReplacePolicySet(cfg, nil, "")
+		if err != nil {
+			return fmt.Errorf("hot reload requires a Cedar authz config: %w", err)
+		}
+		cfg = stripped
+		*options = append(*options, runner.WithAuthzPolicyPath(AuthzPolicyFilePath))
+	}
+	*options = append(*options, runner.WithAuthzConfig(cfg))
+	return nil
+}
+
+// CheckAuthzPolicySet verifies that the policy set authzRef points at compiles,
+// for an AuthzConfigRef with hot reload enabled; it is a no-op otherwise. An
+// invalid policy set yields an error wrapping ErrAuthzPolicyRejected, while an
+// error resolving the config (e.g. a missing ConfigMap) is returned as is.
+func CheckAuthzPolicySet(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	authzRef *mcpv1beta1.AuthzConfigRef,
+) error {
+	if authzRef == nil || !authzRef.HotReload {
+		return nil
+	}
+
+	var cfg *authz.Config
+	var err error
+	switch authzRef.Type {
+	case mcpv1beta1.AuthzConfigTypeInline:
+		cfg, err = BuildInlineCedarAuthzConfig(authzRef)
+	case mcpv1beta1.AuthzConfigTypeConfigMap:
+		cfg, err = LoadAuthzConfigFromConfigMap(ctx, c, namespace, authzRef)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := authz.NewAuthorizer(cfg, ""); err != nil {
+		return fmt.Errorf("%w: %w", ErrAuthzPolicyRejected, err)
+	}
 	return nil
 }
 
@@ -365,8 +440,7 @@ func AddAuthzConfigOptions(
 		if err != nil {
 			return fmt.Errorf("failed to apply claim mapping overrides: %w", err)
 		}
-		*options = append(*options, runner.WithAuthzConfig(cfg))
-		return nil
+		return addAuthzRunConfigOptions(cfg, authzRef, options)
 
 	default:
 		return fmt.Errorf("unknown authz config type: %s", authzRef.Type)
@@ -927,3 +927,101 @@ func TestExtractCedarAuthzOptions(t *testing.T) {
 		assert.Nil(t, opts)
 	})
 }
+
+func TestAddAuthzConfigOptions_HotReload(t *testing.T) {
+	t.Parallel()
+
+	authzRef := &mcpv1beta1.AuthzConfigRef{
+		Type:      mcpv1beta1.AuthzConfigTypeInline,
+		HotReload: true,
+		Inline: &mcpv1beta1.InlineAuthzConfig{
+			Policies: []string{`permit(principal, action, resource);`},
+		},
+		GroupClaimName: "groups",
+	}
+
+	var options []runner.RunConfigBuilderOption
+	require.NoError(t, AddAuthzConfigOptions(context.Background(), nil, "default", authzRef, &options))
+	rc, err := runner.NewOperatorRunConfigBuilder(context.Background(), nil, nil, nil, options...)
+	require.NoError(t, err)
+
+	// The policy set is read from the mounted ConfigMap, so it is left out of
+	// the RunConfig; the other options are kept.
+	assert.Equal(t, AuthzPolicyFilePath, rc.AuthzPolicyPath)
+	cedarOpts, err := ExtractCedarAuthzOptions(rc.AuthzConfig)
+	require.NoError(t, err)
+	assert.Empty(t, cedarOpts.Policies)
+	assert.Equal(t, "groups", cedarOpts.GroupClaimName)
+}
+
+func TestCheckAuthzPolicySet(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewScheme(t)
+	inline := func(hotReload bool, policy string) *mcpv1beta1.AuthzConfigRef {
+		return &mcpv1beta1.AuthzConfigRef{
+			Type:      mcpv1beta1.AuthzConfigTypeInline,
+			HotReload: hotReload,
+			Inline:    &mcpv1beta1.InlineAuthzConfig{Policies: []string{policy}},
+		}
+	}
+	const (
+		validPolicy   = `permit(principal, action == Action::"call_tool", resource == Tool::"weather");`
+		invalidPolicy = `permit(principal, action == Action::"call_tool", resource == Tool::"weather"`
+	)
+
+	t.Run("Without hot reload nothing is checked", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, CheckAuthzPolicySet(context.Background(), nil, "default", inline(false, invalidPolicy)))
+	})
+
+	t.Run("Valid inline policies are accepted", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, CheckAuthzPolicySet(context.Background(), nil, "default", inline(true, validPolicy)))
+	})
+
+	t.Run("Invalid inline policies are rejected", func(t *testing.T) {
+		t.Parallel()
+		err := CheckAuthzPolicySet(context.Background(), nil, "default", inline(true, invalidPolicy))
+		assert.ErrorIs(t, err, ErrAuthzPolicyRejected)
+	})
+
+	t.Run("Invalid ConfigMap policies are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(cedar.Config{
+			Version: AuthzConfigVersion,
+			Type:    cedar.ConfigType,
+			Options: &cedar.ConfigOptions{Policies: []string{invalidPolicy}, EntitiesJSON: "[]"},
+		})
+		require.NoError(t, err)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "default"},
+			Data:       map[string]string{DefaultAuthzKey: string(data)},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+		authzRef := &mcpv1beta1.AuthzConfigRef{
+			Type:      mcpv1beta1.AuthzConfigTypeConfigMap,
+			HotReload: true,
+			ConfigMap: &mcpv1beta1.ConfigMapAuthzRef{Name: "authz"},
+		}
+
+		err = CheckAuthzPolicySet(context.Background(), client, "default", authzRef)
+		assert.ErrorIs(t, err, ErrAuthzPolicyRejected)
+	})
+
+	t.Run("Missing ConfigMap is not a rejection", func(t *testing.T) {
+		t.Parallel()
+
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		authzRef := &mcpv1beta1.AuthzConfigRef{
+			Type:      mcpv1beta1.AuthzConfigTypeConfigMap,
+			HotReload: true,
+			ConfigMap: &mcpv1beta1.ConfigMapAuthzRef{Name: "missing"},
+		}
+
+		err := CheckAuthzPolicySet(context.Background(), client, "default", authzRef)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrAuthzPolicyRejected)
+	})
+}
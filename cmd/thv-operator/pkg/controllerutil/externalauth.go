@@ -29,6 +29,18 @@ func GenerateUniqueTokenExchangeEnvVarName(configName string) string {
 	return fmt.Sprintf("TOOLHIVE_TOKEN_EXCHANGE_CLIENT_SECRET_%s", sanitized)
 }
 
+// GenerateUniqueTokenExchangeClientAssertionKeyEnvVarName generates a unique environment variable name
+// for token exchange client assertion keys, incorporating the ExternalAuthConfig name to ensure uniqueness.
+// Used by both the converter and deployment controller for consistent naming.
+//
+// Example: For an ExternalAuthConfig named "my-auth-config", this returns:
+// "TOOLHIVE_TOKEN_EXCHANGE_CLIENT_ASSERTION_KEY_MY_AUTH_CONFIG"
+func GenerateUniqueTokenExchangeClientAssertionKeyEnvVarName(configName string) string {
+	sanitized := strings.ToUpper(strings.ReplaceAll(configName, "-", "_"))
+	sanitized = envVarSanitizer.ReplaceAllString(sanitized, "_")
+	return fmt.Sprintf("TOOLHIVE_TOKEN_EXCHANGE_CLIENT_ASSERTION_KEY_%s", sanitized)
+}
+
 // GenerateUniqueHeaderInjectionEnvVarName generates a unique environment variable name for header injection
 // values, incorporating the ExternalAuthConfig name to ensure uniqueness.
 // This function is used by both the converter and deployment controller to ensure consistent
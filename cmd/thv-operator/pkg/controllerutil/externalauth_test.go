@@ -57,6 +57,13 @@ func TestGenerateUniqueTokenExchangeEnvVarName(t *testing.T) {
 	}
 }
 
+func TestGenerateUniqueTokenExchangeClientAssertionKeyEnvVarName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "TOOLHIVE_TOKEN_EXCHANGE_CLIENT_ASSERTION_KEY_MY_AUTH_CONFIG",
+		GenerateUniqueTokenExchangeClientAssertionKeyEnvVarName("my-auth.config"))
+}
+
 // TestGenerateUniqueHeaderInjectionEnvVarName tests the GenerateUniqueHeaderInjectionEnvVarName function
 func TestGenerateUniqueHeaderInjectionEnvVarName(t *testing.T) {
 	t.Parallel()
@@ -7,9 +7,11 @@ package controllerutil
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 )
@@ -18,11 +20,11 @@ import (
 // It is used by both MCPServer and VirtualMCPServer controllers.
 type PodTemplateSpecBuilder struct {
 	spec          *corev1.PodTemplateSpec
-	containerName string // Container name for WithSecrets (e.g., "mcp" or "vmcp")
+	containerName string // Container targeted by WithSecrets and WithSecurityHardening (e.g., "mcp" or "vmcp")
 }
 
 // NewPodTemplateSpecBuilder creates a new builder, optionally starting with a user-provided template.
-// The containerName parameter specifies which container WithSecrets() will target.
+// The containerName parameter specifies which container WithSecrets() and WithSecurityHardening() will target.
 // Returns an error if the provided raw extension cannot be unmarshaled into a valid PodTemplateSpec.
 func NewPodTemplateSpecBuilder(userTemplateRaw *runtime.RawExtension, containerName string) (*PodTemplateSpecBuilder, error) {
 	if containerName == "" {
@@ -84,31 +86,65 @@ func (b *PodTemplateSpecBuilder) WithSecrets(secrets []mcpv1beta1.SecretRef) *Po
 		})
 	}
 
-	// Find existing container or create new one
-	containerIndex := -1
-	for i, container := range b.spec.Spec.Containers {
-		if container.Name == b.containerName {
-			containerIndex = i
-			break
+	container := b.targetContainer()
+	container.Env = append(container.Env, secretEnvVars...)
+	return b
+}
+
+// WithSecurityHardening applies restricted security defaults to the pod and the
+// target container: runAsNonRoot, the RuntimeDefault seccomp profile, a read-only
+// root filesystem, no privilege escalation, and all capabilities dropped.
+// Only fields left unset in the user-provided template are filled in, so explicit
+// user settings always win. When writableRootFilesystem is true the root
+// filesystem is explicitly left writable for servers that need to write to it.
+func (b *PodTemplateSpecBuilder) WithSecurityHardening(writableRootFilesystem bool) *PodTemplateSpecBuilder {
+	if b.spec.Spec.SecurityContext == nil {
+		b.spec.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSecurityContext := b.spec.Spec.SecurityContext
+	if podSecurityContext.RunAsNonRoot == nil {
+		podSecurityContext.RunAsNonRoot = ptr.To(true)
+	}
+	if podSecurityContext.SeccompProfile == nil {
+		podSecurityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
 		}
 	}
 
-	if containerIndex >= 0 {
-		// Merge env vars into existing container
-		b.spec.Spec.Containers[containerIndex].Env = append(
-			b.spec.Spec.Containers[containerIndex].Env,
-			secretEnvVars...,
-		)
-	} else {
-		// Add new container with env vars
-		b.spec.Spec.Containers = append(b.spec.Spec.Containers, corev1.Container{
-			Name: b.containerName,
-			Env:  secretEnvVars,
-		})
+	container := b.targetContainer()
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+	if securityContext.ReadOnlyRootFilesystem == nil {
+		securityContext.ReadOnlyRootFilesystem = ptr.To(!writableRootFilesystem)
+	}
+	// Kubernetes rejects allowPrivilegeEscalation=false alongside privileged mode
+	// or CAP_SYS_ADMIN, so leave it unset when the user asked for either.
+	if securityContext.AllowPrivilegeEscalation == nil && !requiresPrivilegeEscalation(securityContext) {
+		securityContext.AllowPrivilegeEscalation = ptr.To(false)
+	}
+	if securityContext.Capabilities == nil {
+		securityContext.Capabilities = &corev1.Capabilities{}
+	}
+	if len(securityContext.Capabilities.Drop) == 0 {
+		securityContext.Capabilities.Drop = []corev1.Capability{"ALL"}
 	}
 	return b
 }
 
+// WithAnnotation sets an annotation on the pod template if value is non-empty.
+func (b *PodTemplateSpecBuilder) WithAnnotation(key, value string) *PodTemplateSpecBuilder {
+	if value == "" {
+		return b
+	}
+	if b.spec.Annotations == nil {
+		b.spec.Annotations = make(map[string]string)
+	}
+	b.spec.Annotations[key] = value
+	return b
+}
+
 // Build returns the final PodTemplateSpec, or nil if no customizations were made.
 func (b *PodTemplateSpecBuilder) Build() *corev1.PodTemplateSpec {
 	if b.isEmpty() {
@@ -117,6 +153,33 @@ func (b *PodTemplateSpecBuilder) Build() *corev1.PodTemplateSpec {
 	return b.spec
 }
 
+// targetContainer returns the container named containerName, adding an empty
+// one to the template if the user did not define it.
+func (b *PodTemplateSpecBuilder) targetContainer() *corev1.Container {
+	for i := range b.spec.Spec.Containers {
+		if b.spec.Spec.Containers[i].Name == b.containerName {
+			return &b.spec.Spec.Containers[i]
+		}
+	}
+	b.spec.Spec.Containers = append(b.spec.Spec.Containers, corev1.Container{Name: b.containerName})
+	return &b.spec.Spec.Containers[len(b.spec.Spec.Containers)-1]
+}
+
+// requiresPrivilegeEscalation reports whether the security context runs the
+// container privileged or grants CAP_SYS_ADMIN, both of which imply privilege
+// escalation.
+func requiresPrivilegeEscalation(securityContext *corev1.SecurityContext) bool {
+	if ptr.Deref(securityContext.Privileged, false) {
+		return true
+	}
+	if securityContext.Capabilities == nil {
+		return false
+	}
+	return slices.ContainsFunc(securityContext.Capabilities.Add, func(capability corev1.Capability) bool {
+		return capability == "SYS_ADMIN" || capability == "CAP_SYS_ADMIN"
+	})
+}
+
 // isEmpty checks if the builder contains any meaningful customizations.
 func (b *PodTemplateSpecBuilder) isEmpty() bool {
 	if b.spec == nil {
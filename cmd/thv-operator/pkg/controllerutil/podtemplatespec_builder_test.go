@@ -8,7 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 )
@@ -114,8 +116,8 @@ func TestPodTemplateSpecBuilder_WithServiceAccount(t *testing.T) {
 		expected string
 	}{
 		{"nil pointer", nil, ""},
-		{"empty string", ptr(""), ""},
-		{"valid name", ptr("my-service-account"), "my-service-account"},
+		{"empty string", ptr.To(""), ""},
+		{"valid name", ptr.To("my-service-account"), "my-service-account"},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +137,30 @@ func TestPodTemplateSpecBuilder_WithServiceAccount(t *testing.T) {
 	}
 }
 
+func TestPodTemplateSpecBuilder_WithAnnotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty value does nothing", func(t *testing.T) {
+		t.Parallel()
+		builder, err := NewPodTemplateSpecBuilder(nil, testContainerName)
+		require.NoError(t, err)
+
+		builder.WithAnnotation("example.com/key", "")
+		assert.Nil(t, builder.Build())
+	})
+
+	t.Run("merges with user annotations", func(t *testing.T) {
+		t.Parallel()
+		raw := &runtime.RawExtension{Raw: []byte(`{"metadata":{"annotations":{"user":"value"}}}`)}
+		builder, err := NewPodTemplateSpecBuilder(raw, testContainerName)
+		require.NoError(t, err)
+
+		result := builder.WithAnnotation("example.com/key", "42").Build()
+		require.NotNil(t, result)
+		assert.Equal(t, map[string]string{"user": "value", "example.com/key": "42"}, result.Annotations)
+	})
+}
+
 func TestPodTemplateSpecBuilder_WithSecrets(t *testing.T) {
 	t.Parallel()
 
@@ -208,6 +234,95 @@ func TestPodTemplateSpecBuilder_WithSecrets(t *testing.T) {
 	})
 }
 
+func TestPodTemplateSpecBuilder_WithSecurityHardening(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies hardening by default", func(t *testing.T) {
+		t.Parallel()
+		builder, err := NewPodTemplateSpecBuilder(nil, testContainerName)
+		require.NoError(t, err)
+
+		result := builder.WithSecurityHardening(false).Build()
+
+		require.NotNil(t, result)
+		require.NotNil(t, result.Spec.SecurityContext)
+		assert.Equal(t, ptr.To(true), result.Spec.SecurityContext.RunAsNonRoot)
+		require.NotNil(t, result.Spec.SecurityContext.SeccompProfile)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, result.Spec.SecurityContext.SeccompProfile.Type)
+
+		require.Len(t, result.Spec.Containers, 1)
+		assert.Equal(t, testContainerName, result.Spec.Containers[0].Name)
+		securityContext := result.Spec.Containers[0].SecurityContext
+		require.NotNil(t, securityContext)
+		assert.Equal(t, ptr.To(true), securityContext.ReadOnlyRootFilesystem)
+		assert.Equal(t, ptr.To(false), securityContext.AllowPrivilegeEscalation)
+		require.NotNil(t, securityContext.Capabilities)
+		assert.Equal(t, []corev1.Capability{"ALL"}, securityContext.Capabilities.Drop)
+	})
+
+	t.Run("writable root filesystem opts out of read-only", func(t *testing.T) {
+		t.Parallel()
+		builder, err := NewPodTemplateSpecBuilder(nil, testContainerName)
+		require.NoError(t, err)
+
+		result := builder.WithSecurityHardening(true).Build()
+
+		require.Len(t, result.Spec.Containers, 1)
+		securityContext := result.Spec.Containers[0].SecurityContext
+		require.NotNil(t, securityContext)
+		assert.Equal(t, ptr.To(false), securityContext.ReadOnlyRootFilesystem)
+		assert.Equal(t, []corev1.Capability{"ALL"}, securityContext.Capabilities.Drop)
+	})
+
+	t.Run("preserves explicit user settings", func(t *testing.T) {
+		t.Parallel()
+		raw := &runtime.RawExtension{Raw: []byte(`{
+			"spec": {
+				"securityContext": {
+					"runAsNonRoot": false,
+					"seccompProfile": {"type": "Unconfined"}
+				},
+				"containers": [{
+					"name": "test-container",
+					"securityContext": {
+						"readOnlyRootFilesystem": false,
+						"capabilities": {"add": ["NET_BIND_SERVICE"], "drop": ["NET_RAW"]}
+					}
+				}]
+			}
+		}`)}
+		builder, err := NewPodTemplateSpecBuilder(raw, testContainerName)
+		require.NoError(t, err)
+
+		result := builder.WithSecurityHardening(false).Build()
+
+		assert.Equal(t, ptr.To(false), result.Spec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, corev1.SeccompProfileTypeUnconfined, result.Spec.SecurityContext.SeccompProfile.Type)
+		require.Len(t, result.Spec.Containers, 1)
+		securityContext := result.Spec.Containers[0].SecurityContext
+		assert.Equal(t, ptr.To(false), securityContext.ReadOnlyRootFilesystem)
+		assert.Equal(t, []corev1.Capability{"NET_BIND_SERVICE"}, securityContext.Capabilities.Add)
+		assert.Equal(t, []corev1.Capability{"NET_RAW"}, securityContext.Capabilities.Drop)
+		// Unset fields are still hardened
+		assert.Equal(t, ptr.To(false), securityContext.AllowPrivilegeEscalation)
+	})
+
+	t.Run("privileged container keeps privilege escalation unset", func(t *testing.T) {
+		t.Parallel()
+		raw := &runtime.RawExtension{Raw: []byte(
+			`{"spec":{"containers":[{"name":"test-container","securityContext":{"privileged":true}}]}}`,
+		)}
+		builder, err := NewPodTemplateSpecBuilder(raw, testContainerName)
+		require.NoError(t, err)
+
+		result := builder.WithSecurityHardening(false).Build()
+
+		securityContext := result.Spec.Containers[0].SecurityContext
+		assert.Nil(t, securityContext.AllowPrivilegeEscalation)
+		assert.Equal(t, ptr.To(true), securityContext.ReadOnlyRootFilesystem)
+	})
+}
+
 func TestPodTemplateSpecBuilder_isEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -252,8 +367,3 @@ func TestPodTemplateSpecBuilder_Chaining(t *testing.T) {
 	require.Len(t, result.Spec.Containers, 1)
 	assert.Equal(t, testContainerName, result.Spec.Containers[0].Name)
 }
-
-// ptr is a helper to create a pointer to a string.
-func ptr(s string) *string {
-	return &s
-}
@@ -45,6 +45,66 @@ func BuildResourceRequirements(resourceSpec mcpv1beta1.ResourceRequirements) cor
 	return resources
 }
 
+// ParseResourceList parses a CRD resource list into a Kubernetes one, returning an error
+// for an invalid quantity. Unset resources are left out.
+func ParseResourceList(list mcpv1beta1.ResourceList) (corev1.ResourceList, error) {
+	parsed := corev1.ResourceList{}
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    list.CPU,
+		corev1.ResourceMemory: list.Memory,
+	} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s quantity %q: %w", name, value, err)
+		}
+		parsed[name] = quantity
+	}
+	return parsed, nil
+}
+
+// ScaleResourceRequirements grows base with the number of backends a workload serves.
+// Every request and limit in base becomes
+//
+//	min(base + backendCount * perBackend, maxResources)
+//
+// Resources missing from perBackend do not grow and resources missing from maxResources
+// are not capped. Requests and limits grow by the same amount and share the cap, so a
+// request at or below its limit stays there. The result never decreases as backendCount
+// grows and never exceeds maxResources.
+func ScaleResourceRequirements(
+	base corev1.ResourceRequirements, perBackend, maxResources corev1.ResourceList, backendCount int,
+) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: scaleResourceList(base.Requests, perBackend, maxResources, backendCount),
+		Limits:   scaleResourceList(base.Limits, perBackend, maxResources, backendCount),
+	}
+}
+
+func scaleResourceList(
+	base, perBackend, maxResources corev1.ResourceList, backendCount int,
+) corev1.ResourceList {
+	if base == nil {
+		return nil
+	}
+	scaled := make(corev1.ResourceList, len(base))
+	for name, quantity := range base {
+		if increment, ok := perBackend[name]; ok && backendCount > 0 {
+			milli := quantity.MilliValue() + int64(backendCount)*increment.MilliValue()
+			quantity = *resource.NewMilliQuantity(milli, quantity.Format)
+		} else {
+			quantity = quantity.DeepCopy()
+		}
+		if limit, ok := maxResources[name]; ok && quantity.Cmp(limit) > 0 {
+			quantity = limit.DeepCopy()
+		}
+		scaled[name] = quantity
+	}
+	return scaled
+}
+
 // BuildHealthProbe builds a Kubernetes health probe configuration
 // Shared between MCPServer and MCPRemoteProxy
 func BuildHealthProbe(
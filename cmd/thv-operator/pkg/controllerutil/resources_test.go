@@ -8,8 +8,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
 	"github.com/stacklok/toolhive/pkg/secrets"
 )
 
@@ -353,3 +357,104 @@ func TestMergeStringMaps(t *testing.T) {
 		})
 	}
 }
+
+func TestParseResourceList(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := ParseResourceList(mcpv1beta1.ResourceList{CPU: "250m"})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")}, parsed)
+
+	_, err = ParseResourceList(mcpv1beta1.ResourceList{Memory: "lots"})
+	assert.ErrorContains(t, err, `invalid memory quantity "lots"`)
+}
+
+func TestScaleResourceRequirements(t *testing.T) {
+	t.Parallel()
+
+	base := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	perBackend := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("25m"),
+		corev1.ResourceMemory: resource.MustParse("32Mi"),
+	}
+	maxResources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+
+	t.Run("no backends keeps the base", func(t *testing.T) {
+		t.Parallel()
+
+		scaled := ScaleResourceRequirements(base, perBackend, maxResources, 0)
+		assert.True(t, equality.Semantic.DeepEqual(base, scaled), "got %v", scaled)
+	})
+
+	t.Run("adds the per-backend amount for every backend", func(t *testing.T) {
+		t.Parallel()
+
+		scaled := ScaleResourceRequirements(base, perBackend, maxResources, 4)
+		assert.Equal(t, "200m", scaled.Requests.Cpu().String())
+		assert.Equal(t, "256Mi", scaled.Requests.Memory().String())
+		assert.Equal(t, "600m", scaled.Limits.Cpu().String())
+		assert.Equal(t, "640Mi", scaled.Limits.Memory().String())
+	})
+
+	t.Run("scales monotonically and respects the caps", func(t *testing.T) {
+		t.Parallel()
+
+		previous := ScaleResourceRequirements(base, perBackend, maxResources, 0)
+		for backends := 1; backends <= 100; backends++ {
+			scaled := ScaleResourceRequirements(base, perBackend, maxResources, backends)
+			for _, lists := range [][2]corev1.ResourceList{
+				{previous.Requests, scaled.Requests},
+				{previous.Limits, scaled.Limits},
+			} {
+				for name, quantity := range lists[1] {
+					prev := lists[0][name]
+					assert.GreaterOrEqual(t, quantity.Cmp(prev), 0,
+						"%s decreased from %s to %s at %d backends", name, prev.String(), quantity.String(), backends)
+					limit := maxResources[name]
+					assert.LessOrEqual(t, quantity.Cmp(limit), 0,
+						"%s %s exceeds cap %s at %d backends", name, quantity.String(), limit.String(), backends)
+				}
+			}
+			for name, request := range scaled.Requests {
+				assert.LessOrEqual(t, request.Cmp(scaled.Limits[name]), 0, "%s request exceeds limit", name)
+			}
+			previous = scaled
+		}
+
+		// Far past the point where every value reaches its cap
+		assert.True(t, equality.Semantic.DeepEqual(corev1.ResourceRequirements{
+			Requests: maxResources,
+			Limits:   maxResources,
+		}, previous), "got %v", previous)
+	})
+
+	t.Run("resources without a per-backend amount or cap are left alone", func(t *testing.T) {
+		t.Parallel()
+
+		scaled := ScaleResourceRequirements(base,
+			corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}, nil, 10)
+		assert.Equal(t, "100m", scaled.Requests.Cpu().String())
+		assert.Equal(t, "10368Mi", scaled.Requests.Memory().String())
+	})
+
+	t.Run("a cap below the base wins", func(t *testing.T) {
+		t.Parallel()
+
+		scaled := ScaleResourceRequirements(base, perBackend,
+			corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}, 0)
+		assert.Equal(t, "128Mi", scaled.Requests.Memory().String())
+		assert.Equal(t, "256Mi", scaled.Limits.Memory().String())
+	})
+}
@@ -202,6 +202,21 @@ func GenerateTokenExchangeEnvVars(
 		})
 	}
 
+	// The client assertion key is passed the same way, as the PEM content of the referenced secret
+	if tokenExchangeSpec.ClientAssertionKeyRef != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: tokenexchange.EnvClientAssertionKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: tokenExchangeSpec.ClientAssertionKeyRef.Name,
+					},
+					Key: tokenExchangeSpec.ClientAssertionKeyRef.Key,
+				},
+			},
+		})
+	}
+
 	return envVars, nil
 }
 
@@ -336,18 +351,31 @@ func addTokenExchangeConfig(
 
 	// Validate that the referenced Kubernetes secret exists (if ClientSecretRef is provided)
 	if tokenExchangeSpec.ClientSecretRef != nil {
-		var secret corev1.Secret
-		if err := c.Get(ctx, types.NamespacedName{
-			Namespace: namespace,
-			Name:      tokenExchangeSpec.ClientSecretRef.Name,
-		}, &secret); err != nil {
-			return fmt.Errorf("failed to get client secret %s/%s: %w",
-				namespace, tokenExchangeSpec.ClientSecretRef.Name, err)
+		if _, err := getTokenExchangeSecretValue(
+			ctx, c, namespace, "client secret", tokenExchangeSpec.ClientSecretRef,
+		); err != nil {
+			return err
 		}
+	}
 
-		if _, ok := secret.Data[tokenExchangeSpec.ClientSecretRef.Key]; !ok {
-			return fmt.Errorf("client secret %s/%s is missing key %q",
-				namespace, tokenExchangeSpec.ClientSecretRef.Name, tokenExchangeSpec.ClientSecretRef.Key)
+	// Validate that the client assertion key exists and holds a usable private key,
+	// so a bad key is reported here rather than when the proxy starts
+	if tokenExchangeSpec.ClientAssertionKeyRef != nil {
+		if tokenExchangeSpec.ClientSecretRef != nil {
+			return fmt.Errorf("clientSecretRef and clientAssertionKeyRef are mutually exclusive")
+		}
+		if tokenExchangeSpec.ClientID == "" {
+			return fmt.Errorf("clientId is required when clientAssertionKeyRef is set")
+		}
+		keyPEM, err := getTokenExchangeSecretValue(
+			ctx, c, namespace, "client assertion key", tokenExchangeSpec.ClientAssertionKeyRef,
+		)
+		if err != nil {
+			return err
+		}
+		if _, err := tokenexchange.ParseClientAssertionKey(keyPEM, tokenExchangeSpec.ClientAssertionKeyID); err != nil {
+			return fmt.Errorf("invalid client assertion key %s/%s: %w",
+				namespace, tokenExchangeSpec.ClientAssertionKeyRef.Name, err)
 		}
 	}
 
@@ -364,11 +392,13 @@ func addTokenExchangeConfig(
 	}
 
 	// Build token exchange configuration
-	// Client secret is provided via TOOLHIVE_TOKEN_EXCHANGE_CLIENT_SECRET environment variable
-	// to avoid embedding plaintext secrets in the ConfigMap
+	// Client secret and client assertion key are provided via the TOOLHIVE_TOKEN_EXCHANGE_CLIENT_SECRET
+	// and TOOLHIVE_TOKEN_EXCHANGE_CLIENT_ASSERTION_KEY environment variables to avoid embedding
+	// plaintext secrets in the ConfigMap
 	tokenExchangeConfig := &tokenexchange.Config{
 		TokenURL:                tokenExchangeSpec.TokenURL,
 		ClientID:                tokenExchangeSpec.ClientID,
+		ClientAssertionKeyID:    tokenExchangeSpec.ClientAssertionKeyID,
 		Audience:                tokenExchangeSpec.Audience,
 		Scopes:                  tokenExchangeSpec.Scopes,
 		SubjectTokenType:        normalizedTokenType,
@@ -383,6 +413,27 @@ func addTokenExchangeConfig(
 	return nil
 }
 
+// getTokenExchangeSecretValue fetches the value a token exchange secret reference points to.
+// The description names the credential in error messages.
+func getTokenExchangeSecretValue(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	description string,
+	ref *mcpv1beta1.SecretKeyRef,
+) ([]byte, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", description, namespace, ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("%s %s/%s is missing key %q", description, namespace, ref.Name, ref.Key)
+	}
+	return value, nil
+}
+
 // addHeaderInjectionConfig adds header injection configuration to runner options
 // For now, this is a no-op as header injection for MCPServer is not implemented
 // Header injection is primarily used for vMCP outgoing auth, not for MCPServer incoming auth
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package externalsecrets resolves references to secrets kept in external
+// secrets managers, such as HashiCorp Vault, for MCPServer environment
+// variables declared with valueFrom.externalSecretRef.
+//
+// Providers are backed by the ToolHive secrets providers in pkg/secrets and
+// read their credentials from the operator's own environment, using the same
+// variables as the thv CLI (VAULT_ADDR, VAULT_TOKEN, OP_SERVICE_ACCOUNT_TOKEN,
+// and so on). Since those credentials are shared by every namespace,
+// references are scoped by namespace: a provider is only usable once a Scope
+// is configured for it, and a reference outside the scope of the referencing
+// MCPServer's namespace is refused with ErrOutOfScope.
+package externalsecrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	"github.com/stacklok/toolhive/pkg/secrets"
+)
+
+// ErrNotFound is returned when a referenced secret does not exist in its provider.
+var ErrNotFound = errors.New("external secret not found")
+
+// ErrUnsupportedProvider is returned when a reference names a provider the
+// resolver does not know about.
+var ErrUnsupportedProvider = errors.New("unsupported external secrets provider")
+
+// ErrOutOfScope is returned when a reference is outside the scope the
+// referencing namespace may read.
+var ErrOutOfScope = errors.New("external secret reference is out of scope")
+
+// Resolver resolves external secret references to their current values.
+type Resolver interface {
+	// Resolve returns the value of the referenced secret for an MCPServer in
+	// namespace. It returns an error wrapping ErrOutOfScope when namespace may
+	// not read the secret, and ErrNotFound when the secret does not exist.
+	Resolve(ctx context.Context, namespace string, ref mcpv1beta1.ExternalSecretRef) (string, error)
+}
+
+// ProviderFactory creates a secrets provider.
+type ProviderFactory func() (secrets.Provider, error)
+
+// ProviderResolver is a Resolver backed by ToolHive secrets providers.
+// Providers are created lazily on first use and reused afterwards, so that
+// authenticated sessions and dynamic secret leases survive across reconciles.
+// Only providers with a Scope can be used.
+type ProviderResolver struct {
+	factories map[string]ProviderFactory
+	scopes    map[string]Scope
+
+	mu        sync.Mutex
+	providers map[string]secrets.Provider
+}
+
+// NewProviderResolver creates a ProviderResolver supporting the "vault" and
+// "1password" providers, restricted to scopes keyed by provider name.
+func NewProviderResolver(scopes map[string]Scope) *ProviderResolver {
+	return NewProviderResolverWithFactories(SupportedProviders(), scopes)
+}
+
+// SupportedProviders returns the factories of the providers NewProviderResolver
+// supports, keyed by provider name.
+func SupportedProviders() map[string]ProviderFactory {
+	return map[string]ProviderFactory{
+		string(secrets.VaultType):       secrets.NewVaultManager,
+		string(secrets.OnePasswordType): secrets.NewOnePasswordManager,
+	}
+}
+
+// NewProviderResolverWithFactories creates a ProviderResolver using the given
+// factories and scopes, both keyed by provider name.
+func NewProviderResolverWithFactories(factories map[string]ProviderFactory, scopes map[string]Scope) *ProviderResolver {
+	return &ProviderResolver{
+		factories: factories,
+		scopes:    scopes,
+		providers: make(map[string]secrets.Provider),
+	}
+}
+
+// Resolve implements Resolver.
+func (r *ProviderResolver) Resolve(ctx context.Context, namespace string, ref mcpv1beta1.ExternalSecretRef) (string, error) {
+	if _, ok := r.factories[ref.Provider]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedProvider, ref.Provider)
+	}
+	scope, ok := r.scopes[ref.Provider]
+	if !ok {
+		return "", fmt.Errorf("%w: no scope is configured for the %s provider", ErrOutOfScope, ref.Provider)
+	}
+	if err := scope.Check(namespace, ref.Key); err != nil {
+		return "", fmt.Errorf("%s/%s: %w", ref.Provider, ref.Key, err)
+	}
+
+	provider, err := r.provider(ref.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := provider.GetSecret(ctx, ref.Key)
+	if err != nil {
+		if secrets.IsNotFoundError(err) {
+			return "", fmt.Errorf("%w: %s/%s", ErrNotFound, ref.Provider, ref.Key)
+		}
+		return "", fmt.Errorf("failed to read %s/%s: %w", ref.Provider, ref.Key, err)
+	}
+	return value, nil
+}
+
+// Close releases all providers created by the resolver.
+func (r *ProviderResolver) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for name, provider := range r.providers {
+		if err := provider.Cleanup(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clean up %s provider: %w", name, err))
+		}
+	}
+	r.providers = make(map[string]secrets.Provider)
+	return errors.Join(errs...)
+}
+
+func (r *ProviderResolver) provider(name string) (secrets.Provider, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if provider, ok := r.providers[name]; ok {
+		return provider, nil
+	}
+
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedProvider, name)
+	}
+
+	// Creation failures are not cached so that fixing the operator's
+	// configuration takes effect on the next reconcile.
+	provider, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s provider: %w", name, err)
+	}
+	r.providers[name] = provider
+	return provider, nil
+}
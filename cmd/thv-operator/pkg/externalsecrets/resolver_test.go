@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package externalsecrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	"github.com/stacklok/toolhive/pkg/secrets"
+	secretsmocks "github.com/stacklok/toolhive/pkg/secrets/mocks"
+)
+
+func TestProviderResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := secretsmocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetSecret(gomock.Any(), "github-token").Return("ghp_123", nil).Times(2)
+	provider.EXPECT().GetSecret(gomock.Any(), "missing").
+		Return("", errors.Join(secrets.ErrSecretNotFound, errors.New("missing"))).Times(1)
+	provider.EXPECT().GetSecret(gomock.Any(), "broken").Return("", errors.New("permission denied")).Times(1)
+
+	created := 0
+	resolver := NewProviderResolverWithFactories(map[string]ProviderFactory{
+		"vault": func() (secrets.Provider, error) {
+			created++
+			return provider, nil
+		},
+	}, map[string]Scope{"vault": {Namespaces: []string{"default"}}})
+	ctx := context.Background()
+
+	value, err := resolver.Resolve(ctx, "default", mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "github-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_123", value)
+
+	value, err = resolver.Resolve(ctx, "default", mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "github-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_123", value)
+	assert.Equal(t, 1, created, "provider should be created once and reused")
+
+	_, err = resolver.Resolve(ctx, "default", mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "missing"})
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "vault/missing")
+
+	_, err = resolver.Resolve(ctx, "default", mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "broken"})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "permission denied")
+
+	_, err = resolver.Resolve(ctx, "default", mcpv1beta1.ExternalSecretRef{Provider: "aws", Key: "x"})
+	require.ErrorIs(t, err, ErrUnsupportedProvider)
+
+	_, err = resolver.Resolve(ctx, "other", mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "github-token"})
+	require.ErrorIs(t, err, ErrOutOfScope)
+}
+
+func TestProviderResolver_ProviderWithoutScopeIsRefused(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewProviderResolverWithFactories(map[string]ProviderFactory{
+		"vault": func() (secrets.Provider, error) {
+			require.Fail(t, "a provider without a scope must not be created")
+			return nil, nil
+		},
+	}, nil)
+
+	_, err := resolver.Resolve(context.Background(), "default", mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "token"})
+	require.ErrorIs(t, err, ErrOutOfScope)
+	assert.Contains(t, err.Error(), "no scope is configured for the vault provider")
+}
+
+func TestProviderResolver_ProviderCreationFailureIsRetried(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := secretsmocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetSecret(gomock.Any(), "token").Return("value", nil)
+	provider.EXPECT().Cleanup().Return(nil)
+
+	fail := true
+	resolver := NewProviderResolverWithFactories(map[string]ProviderFactory{
+		"vault": func() (secrets.Provider, error) {
+			if fail {
+				return nil, errors.New("VAULT_ADDR is not set")
+			}
+			return provider, nil
+		},
+	}, map[string]Scope{"vault": {Namespaces: []string{"default"}}})
+	ref := mcpv1beta1.ExternalSecretRef{Provider: "vault", Key: "token"}
+
+	_, err := resolver.Resolve(context.Background(), "default", ref)
+	require.ErrorContains(t, err, "VAULT_ADDR is not set")
+
+	fail = false
+	value, err := resolver.Resolve(context.Background(), "default", ref)
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	require.NoError(t, resolver.Close())
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package externalsecrets
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// NamespacePlaceholder is replaced by the namespace of the referencing
+// MCPServer in Scope.KeyPrefix.
+const NamespacePlaceholder = "{namespace}"
+
+const (
+	// envPrefix starts the names of the environment variables configuring the
+	// scope of a provider, e.g. TOOLHIVE_EXTERNAL_SECRETS_VAULT_KEY_PREFIX.
+	envPrefix = "TOOLHIVE_EXTERNAL_SECRETS_"
+
+	// keyPrefixEnvSuffix ends the variable holding Scope.KeyPrefix.
+	keyPrefixEnvSuffix = "_KEY_PREFIX"
+
+	// namespacesEnvSuffix ends the variable holding Scope.Namespaces as a
+	// comma-separated list.
+	namespacesEnvSuffix = "_NAMESPACES"
+)
+
+// Scope restricts the secrets MCPServers may reference through one provider.
+type Scope struct {
+	// KeyPrefix is a prefix every referenced key must start with, after
+	// NamespacePlaceholder is replaced by the namespace of the MCPServer,
+	// e.g. "toolhive/{namespace}/". End it with a separator, so that the
+	// prefix of one namespace is not a prefix of another's.
+	KeyPrefix string
+
+	// Namespaces are the namespaces allowed to reference the provider. Empty
+	// allows every namespace, as long as KeyPrefix separates them.
+	Namespaces []string
+}
+
+// Validate checks that the scope separates namespaces, either by giving each
+// its own key prefix or by allowing an explicit list of namespaces.
+func (s Scope) Validate() error {
+	if len(s.Namespaces) == 0 && !strings.Contains(s.KeyPrefix, NamespacePlaceholder) {
+		return fmt.Errorf("a scope needs a key prefix containing %s or a list of namespaces", NamespacePlaceholder)
+	}
+	return nil
+}
+
+// Check returns an error wrapping ErrOutOfScope if an MCPServer in namespace
+// may not reference key.
+func (s Scope) Check(namespace, key string) error {
+	if len(s.Namespaces) > 0 && !slices.Contains(s.Namespaces, namespace) {
+		return fmt.Errorf("%w: namespace %s may not reference this provider", ErrOutOfScope, namespace)
+	}
+	// A relative segment could climb out of the prefix once the provider
+	// resolves the path
+	for _, segment := range strings.FieldsFunc(key, func(r rune) bool { return r == '/' || r == '#' }) {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("%w: key must not contain relative path segments", ErrOutOfScope)
+		}
+	}
+	prefix := strings.ReplaceAll(s.KeyPrefix, NamespacePlaceholder, namespace)
+	if !strings.HasPrefix(key, prefix) {
+		return fmt.Errorf("%w: keys referenced from namespace %s must start with %q", ErrOutOfScope, namespace, prefix)
+	}
+	return nil
+}
+
+// LoadScopesFromEnv parses the scope of every provider in providers from the
+// TOOLHIVE_EXTERNAL_SECRETS_<PROVIDER>_KEY_PREFIX and
+// TOOLHIVE_EXTERNAL_SECRETS_<PROVIDER>_NAMESPACES environment variables, where
+// <PROVIDER> is the upper-cased provider name. Providers with neither variable
+// set have no scope and cannot be used.
+func LoadScopesFromEnv(providers []string) (map[string]Scope, error) {
+	scopes := make(map[string]Scope)
+	for _, provider := range providers {
+		name := envPrefix + strings.ToUpper(provider)
+		keyPrefix, hasKeyPrefix := os.LookupEnv(name + keyPrefixEnvSuffix)
+		namespaces, hasNamespaces := os.LookupEnv(name + namespacesEnvSuffix)
+		if !hasKeyPrefix && !hasNamespaces {
+			continue
+		}
+
+		scope := Scope{KeyPrefix: keyPrefix}
+		for _, namespace := range strings.Split(namespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				scope.Namespaces = append(scope.Namespaces, namespace)
+			}
+		}
+		if err := scope.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scope for the %s provider: %w", provider, err)
+		}
+		scopes[provider] = scope
+	}
+	return scopes, nil
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package externalsecrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		scope     Scope
+		namespace string
+		key       string
+		wantErr   bool
+	}{
+		{
+			name:      "key under the namespace prefix",
+			scope:     Scope{KeyPrefix: "toolhive/{namespace}/"},
+			namespace: "team-a",
+			key:       "toolhive/team-a/github#token",
+		},
+		{
+			name:      "key under another namespace's prefix",
+			scope:     Scope{KeyPrefix: "toolhive/{namespace}/"},
+			namespace: "team-a",
+			key:       "toolhive/team-b/github#token",
+			wantErr:   true,
+		},
+		{
+			name:      "relative segment climbing out of the prefix",
+			scope:     Scope{KeyPrefix: "toolhive/{namespace}/"},
+			namespace: "team-a",
+			key:       "toolhive/team-a/../team-b/github#token",
+			wantErr:   true,
+		},
+		{
+			name:      "allowed namespace",
+			scope:     Scope{Namespaces: []string{"team-a", "team-b"}},
+			namespace: "team-b",
+			key:       "shared/github#token",
+		},
+		{
+			name:      "namespace not in the allow-list",
+			scope:     Scope{Namespaces: []string{"team-a"}},
+			namespace: "team-b",
+			key:       "shared/github#token",
+			wantErr:   true,
+		},
+		{
+			name:      "allowed namespace outside the prefix",
+			scope:     Scope{KeyPrefix: "toolhive/", Namespaces: []string{"team-a"}},
+			namespace: "team-a",
+			key:       "other/github#token",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.scope.Check(tt.namespace, tt.key)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrOutOfScope)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestScope_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Scope{KeyPrefix: "toolhive/{namespace}/"}.Validate())
+	require.NoError(t, Scope{Namespaces: []string{"team-a"}}.Validate())
+	require.Error(t, Scope{KeyPrefix: "toolhive/"}.Validate(), "a fixed prefix does not separate namespaces")
+	require.Error(t, Scope{}.Validate())
+}
+
+//nolint:paralleltest // Uses t.Setenv
+func TestLoadScopesFromEnv(t *testing.T) {
+	t.Setenv("TOOLHIVE_EXTERNAL_SECRETS_VAULT_KEY_PREFIX", "toolhive/{namespace}/")
+	t.Setenv("TOOLHIVE_EXTERNAL_SECRETS_1PASSWORD_NAMESPACES", "team-a, team-b,")
+
+	scopes, err := LoadScopesFromEnv([]string{"vault", "1password", "aws"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Scope{
+		"vault":     {KeyPrefix: "toolhive/{namespace}/"},
+		"1password": {Namespaces: []string{"team-a", "team-b"}},
+	}, scopes)
+
+	t.Setenv("TOOLHIVE_EXTERNAL_SECRETS_VAULT_KEY_PREFIX", "toolhive/")
+	_, err = LoadScopesFromEnv([]string{"vault"})
+	require.ErrorContains(t, err, "invalid scope for the vault provider")
+}
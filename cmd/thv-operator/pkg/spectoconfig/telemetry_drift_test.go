@@ -71,6 +71,11 @@ var telemetryIgnoredOnRuntimeOnly = map[string]string{
 	"environmentVariables": "CLI-only, not applicable to CRD-managed telemetry",
 	"caCertPath": "filesystem path assigned by runconfig.AddMCPTelemetryConfigRefOptions (cmd/thv-operator/pkg/runconfig/telemetry.go) " +
 		"after the operator computes the volume-mount path from openTelemetry.caBundleRef; not user-facing in the CRD",
+	"samplingOverrides": "per-operation sampling is only configurable through the CLI config and the VirtualMCPServer " +
+		"spec.config.telemetry block; MCPTelemetryConfig only exposes the global openTelemetry.tracing.samplingRate",
+	"prometheusBasicAuth.username": "/metrics basic auth is only configurable through the CLI config and the VirtualMCPServer " +
+		"spec.config.telemetry block; MCPTelemetryConfig has no way to reference a password file",
+	"prometheusBasicAuth.passwordFile": "see prometheusBasicAuth.username",
 }
 
 // TestTelemetryConfigDrift exercises the full bidirectional drift contract
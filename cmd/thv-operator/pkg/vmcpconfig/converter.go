@@ -6,7 +6,9 @@ package vmcpconfig
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -19,6 +21,7 @@ import (
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/oidc"
 	"github.com/stacklok/toolhive/cmd/thv-operator/pkg/spectoconfig"
 	"github.com/stacklok/toolhive/pkg/authserver"
+	thvjson "github.com/stacklok/toolhive/pkg/json"
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	"github.com/stacklok/toolhive/pkg/vmcp/auth/converters"
 	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
@@ -713,6 +716,12 @@ func (*Converter) convertExternalAuthConfigToStrategy(
 		externalAuthConfig.Spec.TokenExchange.ClientSecretRef != nil {
 		strategy.TokenExchange.ClientSecretEnv = controllerutil.GenerateUniqueTokenExchangeEnvVarName(externalAuthConfig.Name)
 	}
+	if strategy.TokenExchange != nil &&
+		externalAuthConfig.Spec.TokenExchange != nil &&
+		externalAuthConfig.Spec.TokenExchange.ClientAssertionKeyRef != nil {
+		strategy.TokenExchange.ClientAssertionKeyEnv =
+			controllerutil.GenerateUniqueTokenExchangeClientAssertionKeyEnvVarName(externalAuthConfig.Name)
+	}
 	if strategy.HeaderInjection != nil &&
 		externalAuthConfig.Spec.HeaderInjection != nil &&
 		externalAuthConfig.Spec.HeaderInjection.ValueSecretRef != nil {
@@ -730,8 +739,11 @@ func (c *Converter) convertAggregation(
 	// Start with a deep copy of the source config
 	srcAgg := vmcp.Spec.Config.Aggregation
 	agg := &vmcpconfig.AggregationConfig{
-		ConflictResolution: srcAgg.ConflictResolution,
-		ExcludeAllTools:    srcAgg.ExcludeAllTools,
+		ConflictResolution:   srcAgg.ConflictResolution,
+		ExcludeAllTools:      srcAgg.ExcludeAllTools,
+		MaxConcurrentQueries: srcAgg.MaxConcurrentQueries,
+		CapabilityCacheTTL:   srcAgg.CapabilityCacheTTL,
+		ToolTimeout:          srcAgg.ToolTimeout,
 	}
 
 	// Apply defaults for conflict resolution
@@ -758,8 +770,9 @@ func (*Converter) applyConflictResolutionDefaults(
 	// Copy or create conflict resolution config
 	if srcAgg.ConflictResolutionConfig != nil {
 		agg.ConflictResolutionConfig = &vmcpconfig.ConflictResolutionConfig{
-			PrefixFormat:  srcAgg.ConflictResolutionConfig.PrefixFormat,
-			PriorityOrder: srcAgg.ConflictResolutionConfig.PriorityOrder,
+			PrefixFormat:      srcAgg.ConflictResolutionConfig.PrefixFormat,
+			PriorityOrder:     srcAgg.ConflictResolutionConfig.PriorityOrder,
+			BackendPriorities: srcAgg.ConflictResolutionConfig.BackendPriorities,
 		}
 	} else if agg.ConflictResolution == conflictResolutionPrefix {
 		// Provide default prefix format if using prefix strategy without explicit config
@@ -790,9 +803,11 @@ func (c *Converter) resolveToolConfigRefs(
 	for _, toolConfig := range srcAgg.Tools {
 		// Deep copy the tool config
 		wtc := &vmcpconfig.WorkloadToolConfig{
-			Workload:   toolConfig.Workload,
-			Filter:     toolConfig.Filter,
-			ExcludeAll: toolConfig.ExcludeAll,
+			Workload:            toolConfig.Workload,
+			Filter:              toolConfig.Filter,
+			ExcludeAll:          toolConfig.ExcludeAll,
+			ToolTimeouts:        maps.Clone(toolConfig.ToolTimeouts),
+			DescriptionTemplate: toolConfig.DescriptionTemplate,
 		}
 
 		// Copy inline overrides first
@@ -804,6 +819,13 @@ func (c *Converter) resolveToolConfigRefs(
 				}
 			}
 		}
+		wtc.Aliases = maps.Clone(toolConfig.Aliases)
+		if len(toolConfig.ParameterDefaults) > 0 {
+			wtc.ParameterDefaults = make(map[string]thvjson.Map, len(toolConfig.ParameterDefaults))
+			for name, defaults := range toolConfig.ParameterDefaults {
+				wtc.ParameterDefaults[name] = *defaults.DeepCopy()
+			}
+		}
 
 		// Resolve ToolConfigRef if present (this may merge with inline config)
 		if err := c.resolveToolConfigRef(ctx, ctxLogger, vmcp.Namespace, toolConfig, wtc); err != nil {
@@ -843,6 +865,11 @@ func (c *Converter) resolveToolConfigRef(
 
 	c.mergeToolConfigFilter(wtc, resolvedConfig)
 	c.mergeToolConfigOverrides(wtc, resolvedConfig)
+	c.mergeToolConfigAliases(wtc, resolvedConfig)
+	if err := c.mergeToolConfigParameterDefaults(wtc, resolvedConfig); err != nil {
+		return fmt.Errorf("MCPToolConfig %q has invalid parameter defaults: %w",
+			toolConfig.ToolConfigRef.Name, err)
+	}
 	return nil
 }
 
@@ -876,6 +903,57 @@ func (*Converter) mergeToolConfigOverrides(
 	}
 }
 
+// mergeToolConfigAliases merges aliases from MCPToolConfig
+func (*Converter) mergeToolConfigAliases(
+	wtc *vmcpconfig.WorkloadToolConfig,
+	resolvedConfig *mcpv1beta1.MCPToolConfig,
+) {
+	if len(resolvedConfig.Spec.ToolsAlias) == 0 {
+		return
+	}
+
+	if wtc.Aliases == nil {
+		wtc.Aliases = make(map[string]string)
+	}
+
+	for toolName, alias := range resolvedConfig.Spec.ToolsAlias {
+		if _, exists := wtc.Aliases[toolName]; !exists {
+			wtc.Aliases[toolName] = alias
+		}
+	}
+}
+
+// mergeToolConfigParameterDefaults merges parameter defaults from MCPToolConfig.
+// Inline defaults for a tool take precedence over the whole MCPToolConfig entry.
+func (*Converter) mergeToolConfigParameterDefaults(
+	wtc *vmcpconfig.WorkloadToolConfig,
+	resolvedConfig *mcpv1beta1.MCPToolConfig,
+) error {
+	if len(resolvedConfig.Spec.ToolsParameterDefaults) == 0 {
+		return nil
+	}
+
+	if wtc.ParameterDefaults == nil {
+		wtc.ParameterDefaults = make(map[string]thvjson.Map)
+	}
+
+	for toolName, params := range resolvedConfig.Spec.ToolsParameterDefaults {
+		if _, exists := wtc.ParameterDefaults[toolName]; exists {
+			continue
+		}
+		defaults := make(map[string]any, len(params))
+		for param, raw := range params {
+			var value any
+			if err := json.Unmarshal(raw.Raw, &value); err != nil {
+				return fmt.Errorf("tool %q parameter %q: %w", toolName, param, err)
+			}
+			defaults[param] = value
+		}
+		wtc.ParameterDefaults[toolName] = thvjson.NewMap(defaults)
+	}
+	return nil
+}
+
 // convertCRDToolOverride converts a CRD ToolOverride to a config ToolOverride.
 func convertCRDToolOverride(src *mcpv1beta1.ToolOverride) *vmcpconfig.ToolOverride {
 	o := &vmcpconfig.ToolOverride{
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -1137,6 +1138,78 @@ func TestMergeToolConfigOverrides(t *testing.T) {
 	}
 }
 
+func TestConverter_MergeToolConfigAliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing map[string]string
+		aliases  map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "aliases from config",
+			aliases:  map[string]string{"search_issues_and_pull_requests": "search_issues"},
+			expected: map[string]string{"search_issues_and_pull_requests": "search_issues"},
+		},
+		{
+			name:     "inline takes precedence",
+			existing: map[string]string{"tool1": "inline_alias"},
+			aliases:  map[string]string{"tool1": "config_alias", "tool2": "config_alias2"},
+			expected: map[string]string{"tool1": "inline_alias", "tool2": "config_alias2"},
+		},
+		{
+			name:     "no change when config has no aliases",
+			existing: map[string]string{"tool1": "inline_alias"},
+			expected: map[string]string{"tool1": "inline_alias"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := newMCPToolConfig("", "", nil, nil)
+			config.Spec.ToolsAlias = tt.aliases
+			wtc := &vmcpconfig.WorkloadToolConfig{Aliases: tt.existing}
+			(&Converter{}).mergeToolConfigAliases(wtc, config)
+
+			assert.Equal(t, tt.expected, wtc.Aliases)
+		})
+	}
+}
+
+func TestConverter_MergeToolConfigParameterDefaults(t *testing.T) {
+	t.Parallel()
+
+	config := newMCPToolConfig("", "", nil, nil)
+	config.Spec.ToolsParameterDefaults = map[string]map[string]apiextensionsv1.JSON{
+		"search_issues": {
+			"org":   {Raw: []byte(`"acme"`)},
+			"limit": {Raw: []byte(`10`)},
+			"tags":  {Raw: []byte(`["bug","p1"]`)},
+		},
+		"search_code": {"org": {Raw: []byte(`"config"`)}},
+	}
+	wtc := &vmcpconfig.WorkloadToolConfig{
+		ParameterDefaults: map[string]thvjson.Map{
+			"search_code": thvjson.NewMap(map[string]any{"org": "inline"}),
+		},
+	}
+
+	require.NoError(t, (&Converter{}).mergeToolConfigParameterDefaults(wtc, config))
+	assert.Equal(t, map[string]thvjson.Map{
+		"search_issues": thvjson.NewMap(map[string]any{"org": "acme", "limit": float64(10), "tags": []any{"bug", "p1"}}),
+		"search_code":   thvjson.NewMap(map[string]any{"org": "inline"}), // inline takes precedence
+	}, wtc.ParameterDefaults)
+
+	config.Spec.ToolsParameterDefaults = map[string]map[string]apiextensionsv1.JSON{
+		"broken": {"org": {Raw: []byte(`{`)}},
+	}
+	err := (&Converter{}).mergeToolConfigParameterDefaults(&vmcpconfig.WorkloadToolConfig{}, config)
+	assert.ErrorContains(t, err, `tool "broken" parameter "org"`)
+}
+
 func TestConvertCRDToolOverride(t *testing.T) {
 	t.Parallel()
 
@@ -1584,6 +1657,38 @@ func TestConverter_RateLimitingPassThrough(t *testing.T) {
 	assert.EqualValues(t, 5, config.RateLimiting.Tools[0].Shared.MaxTokens)
 }
 
+func TestConverter_AggregationSettingsPassThrough(t *testing.T) {
+	t.Parallel()
+
+	vmcpServer := v1beta1test.NewVirtualMCPServer("test-vmcp", "default",
+		v1beta1test.WithVMCPGroupRef("test-group"),
+		v1beta1test.WithVMCPConfig(vmcpconfig.Config{
+			Aggregation: &vmcpconfig.AggregationConfig{
+				MaxConcurrentQueries: 4,
+				CapabilityCacheTTL:   vmcpconfig.Duration(time.Minute),
+				ToolTimeout:          vmcpconfig.Duration(time.Minute),
+				Tools: []*vmcpconfig.WorkloadToolConfig{{
+					Workload:     "search",
+					ToolTimeouts: map[string]vmcpconfig.Duration{"deep_search": vmcpconfig.Duration(5 * time.Minute)},
+				}},
+			},
+		}),
+	)
+
+	converter := newTestConverter(t, newNoOpMockResolver(t))
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	config, _, err := converter.Convert(ctx, vmcpServer, nil)
+	require.NoError(t, err)
+	require.NotNil(t, config.Aggregation)
+	assert.Equal(t, 4, config.Aggregation.MaxConcurrentQueries)
+	assert.Equal(t, vmcpconfig.Duration(time.Minute), config.Aggregation.CapabilityCacheTTL)
+	assert.Equal(t, vmcpconfig.Duration(time.Minute), config.Aggregation.ToolTimeout)
+	require.Len(t, config.Aggregation.Tools, 1)
+	assert.Equal(t, map[string]vmcpconfig.Duration{"deep_search": vmcpconfig.Duration(5 * time.Minute)},
+		config.Aggregation.Tools[0].ToolTimeouts)
+}
+
 func TestDeriveAllowedAudiences(t *testing.T) {
 	t.Parallel()
 
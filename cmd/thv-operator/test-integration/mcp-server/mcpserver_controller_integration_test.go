@@ -69,7 +69,7 @@ var _ = Describe("MCPServer Controller Integration Tests", func() {
 					ProxyPort: 8080,
 					MCPPort:   8080,
 					Args:      []string{"--verbose"},
-					Env: []mcpv1beta1.EnvVar{
+					Env: []mcpv1beta1.MCPServerEnvVar{
 						{
 							Name:  "DEBUG",
 							Value: "true",
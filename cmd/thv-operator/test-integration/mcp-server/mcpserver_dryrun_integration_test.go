@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+	"github.com/stacklok/toolhive/cmd/thv-operator/controllers"
+)
+
+var _ = Describe("MCPServer dry-run annotation", func() {
+	const (
+		timeout  = time.Second * 30
+		interval = time.Millisecond * 250
+		dryRunNS = "dry-run-test-ns"
+	)
+
+	BeforeEach(func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: dryRunNS}}
+		_ = k8sClient.Create(ctx, ns)
+	})
+
+	cleanupServer := func(key types.NamespacedName) {
+		fresh := &mcpv1beta1.MCPServer{}
+		if err := k8sClient.Get(ctx, key, fresh); err != nil {
+			return
+		}
+		if len(fresh.Finalizers) > 0 {
+			original := fresh.DeepCopy()
+			fresh.Finalizers = nil
+			// Test-only teardown: no concurrent writers, so plain MergeFrom is fine.
+			_ = k8sClient.Patch(ctx, fresh, client.MergeFrom(original))
+		}
+		_ = k8sClient.Delete(ctx, fresh)
+	}
+
+	It("Records the planned resources without creating them, then applies them when the annotation is removed", func() {
+		name := "dry-run-server"
+		server := &mcpv1beta1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   dryRunNS,
+				Annotations: map[string]string{controllers.DryRunAnnotationKey: "true"},
+			},
+			Spec: mcpv1beta1.MCPServerSpec{
+				Image:     "example/mcp-server:v1.0.0",
+				Transport: "stdio",
+				ProxyMode: "sse",
+				ProxyPort: 8080,
+				MCPPort:   8081,
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		key := types.NamespacedName{Name: name, Namespace: dryRunNS}
+		DeferCleanup(func() { cleanupServer(key) })
+
+		var condition *metav1.Condition
+		Eventually(func(g Gomega) {
+			fresh := &mcpv1beta1.MCPServer{}
+			g.Expect(k8sClient.Get(ctx, key, fresh)).To(Succeed())
+			condition = meta.FindStatusCondition(fresh.Status.Conditions, mcpv1beta1.ConditionDryRun)
+			g.Expect(condition).NotTo(BeNil())
+		}, timeout, interval).Should(Succeed())
+
+		Expect(condition.Reason).To(Equal(mcpv1beta1.ConditionReasonDryRunChangesPending))
+		Expect(condition.Message).To(ContainSubstring("create Deployment " + name))
+		Expect(condition.Message).To(ContainSubstring("create ConfigMap " + name + "-runconfig"))
+
+		// Nothing is created while the annotation is set, and the status settles.
+		var settled *mcpv1beta1.MCPServer
+		Consistently(func(g Gomega) {
+			err := k8sClient.Get(ctx, key, &appsv1.Deployment{})
+			g.Expect(errors.IsNotFound(err)).To(BeTrue(), "Deployment must not be created in dry run")
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: name + "-runconfig", Namespace: dryRunNS}, &corev1.ConfigMap{})
+			g.Expect(errors.IsNotFound(err)).To(BeTrue(), "RunConfig ConfigMap must not be created in dry run")
+
+			fresh := &mcpv1beta1.MCPServer{}
+			g.Expect(k8sClient.Get(ctx, key, fresh)).To(Succeed())
+			g.Expect(fresh.Finalizers).To(BeEmpty())
+			if settled != nil {
+				g.Expect(fresh.ResourceVersion).To(Equal(settled.ResourceVersion), "dry run status must not flap")
+			}
+			settled = fresh
+		}, 3*time.Second, interval).Should(Succeed())
+
+		// Removing the annotation reconciles normally and clears the condition.
+		Eventually(func() error {
+			fresh := &mcpv1beta1.MCPServer{}
+			if err := k8sClient.Get(ctx, key, fresh); err != nil {
+				return err
+			}
+			delete(fresh.Annotations, controllers.DryRunAnnotationKey)
+			return k8sClient.Update(ctx, fresh)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, &appsv1.Deployment{})).To(Succeed())
+			fresh := &mcpv1beta1.MCPServer{}
+			g.Expect(k8sClient.Get(ctx, key, fresh)).To(Succeed())
+			g.Expect(meta.FindStatusCondition(fresh.Status.Conditions, mcpv1beta1.ConditionDryRun)).To(BeNil())
+		}, timeout, interval).Should(Succeed())
+	})
+})
@@ -74,6 +74,7 @@ type RemoteAuthFlags struct {
 	RemoteAuthScopes           []string
 	RemoteAuthScopeParamName   string
 	RemoteAuthSkipBrowser      bool
+	RemoteAuthDeviceFlow       bool
 	RemoteAuthTimeout          time.Duration
 	RemoteAuthCallbackPort     int
 	RemoteAuthIssuer           string
@@ -94,6 +95,10 @@ type RemoteAuthFlags struct {
 	TokenExchangeScopes           []string
 	TokenExchangeSubjectTokenType string
 	TokenExchangeHeaderName       string
+
+	// JWT client assertion (private_key_jwt) as an alternative to the client secret
+	TokenExchangeClientAssertionKeyFile string
+	TokenExchangeClientAssertionKeyID   string
 }
 
 // BuildTokenExchangeConfig creates a TokenExchangeConfig from the RemoteAuthFlags.
@@ -115,6 +120,29 @@ func (f *RemoteAuthFlags) BuildTokenExchangeConfig() (*tokenexchange.Config, err
 		return nil, err
 	}
 
+	// The key file is kept as a path and read by the middleware, so the private
+	// key itself is never written to the run configuration.
+	var clientAssertionKeyFile string
+	if f.TokenExchangeClientAssertionKeyFile != "" {
+		if clientSecret != "" {
+			return nil, fmt.Errorf("token exchange client secret and client assertion key are mutually exclusive")
+		}
+		if f.TokenExchangeClientID == "" {
+			return nil, fmt.Errorf("--token-exchange-client-id is required when using a client assertion key")
+		}
+		clientAssertionKeyFile, err = filepath.Abs(f.TokenExchangeClientAssertionKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve client assertion key path: %w", err)
+		}
+		keyPEM, err := os.ReadFile(clientAssertionKeyFile) // #nosec G304 - path is provided by the user via CLI flag
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client assertion key: %w", err)
+		}
+		if _, err := tokenexchange.ParseClientAssertionKey(keyPEM, ""); err != nil {
+			return nil, fmt.Errorf("invalid client assertion key: %w", err)
+		}
+	}
+
 	// Determine header strategy based on whether custom header name is provided
 	var headerStrategy string
 	var externalTokenHeaderName string
@@ -139,6 +167,8 @@ func (f *RemoteAuthFlags) BuildTokenExchangeConfig() (*tokenexchange.Config, err
 		TokenURL:                f.TokenExchangeURL,
 		ClientID:                f.TokenExchangeClientID,
 		ClientSecret:            clientSecret,
+		ClientAssertionKeyFile:  clientAssertionKeyFile,
+		ClientAssertionKeyID:    f.TokenExchangeClientAssertionKeyID,
 		Audience:                f.TokenExchangeAudience,
 		Scopes:                  f.TokenExchangeScopes,
 		SubjectTokenType:        normalizedTokenType,
@@ -168,6 +198,9 @@ func AddRemoteAuthFlags(cmd *cobra.Command, config *RemoteAuthFlags) {
 		"Override the query parameter name for scopes in the authorization URL (e.g., 'user_scope' for Slack OAuth)")
 	cmd.Flags().BoolVar(&config.RemoteAuthSkipBrowser, "remote-auth-skip-browser", false,
 		"Skip opening browser for remote server OAuth flow (default false)")
+	cmd.Flags().BoolVar(&config.RemoteAuthDeviceFlow, "remote-auth-device-flow", false,
+		"Use the OAuth device authorization grant (RFC 8628) instead of the browser-based flow; "+
+			"used automatically when no browser is available (default false)")
 	cmd.Flags().DurationVar(&config.RemoteAuthTimeout, "remote-auth-timeout", 30*time.Second,
 		"Timeout for OAuth authentication flow (e.g., 30s, 1m, 2m30s)")
 	cmd.Flags().IntVar(&config.RemoteAuthCallbackPort, "remote-auth-callback-port", runner.DefaultCallbackPort,
@@ -197,6 +230,11 @@ func AddRemoteAuthFlags(cmd *cobra.Command, config *RemoteAuthFlags) {
 		"OAuth client secret for token exchange operations")
 	cmd.Flags().StringVar(&config.TokenExchangeClientSecretFile, "token-exchange-client-secret-file", "",
 		"Path to file containing OAuth client secret for token exchange (alternative to --token-exchange-client-secret)")
+	cmd.Flags().StringVar(&config.TokenExchangeClientAssertionKeyFile, "token-exchange-client-assertion-key-file", "",
+		"Path to a PEM-encoded RSA or EC private key used to authenticate to the token exchange endpoint with a "+
+			"signed JWT (private_key_jwt, RFC 7523) instead of a client secret")
+	cmd.Flags().StringVar(&config.TokenExchangeClientAssertionKeyID, "token-exchange-client-assertion-key-id", "",
+		"Key ID sent in the 'kid' header of token exchange client assertions")
 	cmd.Flags().StringVar(&config.TokenExchangeAudience, "token-exchange-audience", "",
 		"Target audience for exchanged tokens")
 	cmd.Flags().StringSliceVar(&config.TokenExchangeScopes, "token-exchange-scopes", []string{},
@@ -207,4 +245,6 @@ func AddRemoteAuthFlags(cmd *cobra.Command, config *RemoteAuthFlags) {
 		"Custom header name for injecting exchanged token (default: replaces Authorization header)")
 
 	cmd.MarkFlagsMutuallyExclusive("token-exchange-client-secret", "token-exchange-client-secret-file")
+	cmd.MarkFlagsMutuallyExclusive("token-exchange-client-secret", "token-exchange-client-assertion-key-file")
+	cmd.MarkFlagsMutuallyExclusive("token-exchange-client-secret-file", "token-exchange-client-assertion-key-file")
 }
@@ -16,6 +16,10 @@ import (
 	"github.com/stacklok/toolhive/pkg/updates"
 )
 
+// logLevel is the level of the default logger. It is a variable so that
+// long-running commands can change it when their config is reloaded.
+var logLevel = new(slog.LevelVar)
+
 var rootCmd = &cobra.Command{
 	Use:               "thv",
 	DisableAutoGenTag: true,
@@ -35,11 +39,10 @@ container-based isolation for running MCP servers.`,
 	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
 		// Re-initialize logger now that cobra has parsed flags and viper has
 		// the correct value for "debug".
-		var opts []logging.Option
 		if viper.GetBool("debug") {
-			opts = append(opts, logging.WithLevel(slog.LevelDebug))
+			logLevel.Set(slog.LevelDebug)
 		}
-		slog.SetDefault(logging.New(opts...))
+		slog.SetDefault(logging.New(logging.WithLevel(logLevel)))
 
 		// Check for desktop app conflict
 		return desktop.ValidateDesktopAlignment()
@@ -75,6 +78,7 @@ func NewRootCmd(enableUpdates bool) *cobra.Command {
 	rootCmd.AddCommand(groupCmd)
 	rootCmd.AddCommand(skillCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(upgradeCmd)
 
@@ -110,6 +114,8 @@ func IsInformationalCommand(args []string) bool {
 	// backend discovery is used (i.e. when no static backends are configured).
 	// "secret" is safe here: secrets management is pure config/credential I/O and
 	// does not interact with container runtimes.
+	// "doctor" reports a missing container runtime itself and must not run the
+	// migrations it may be diagnosing.
 	informationalCommands := map[string]bool{
 		"version":    true,
 		"search":     true,
@@ -120,6 +126,12 @@ func IsInformationalCommand(args []string) bool {
 		"skill":      true,
 		"vmcp":       true,
 		"llm":        true,
+		"doctor":     true,
+	}
+
+	// "config schema" only describes the config types.
+	if command == "config" && len(args) > 2 && args[2] == "schema" {
+		return true
 	}
 
 	return informationalCommands[command]
@@ -50,7 +50,7 @@ func IsOIDCEnabled(cmd *cobra.Command) bool {
 
 // SetSecretsProvider sets the secrets provider type in the configuration.
 // It validates the input, tests the provider functionality, and updates the configuration.
-// Choices are `encrypted`, `1password`, and `environment`.
+// Choices are `encrypted`, `1password`, `environment`, and `vault`.
 func SetSecretsProvider(ctx context.Context, provider secrets.ProviderType) error {
 	// Validate input
 	if provider == "" {
@@ -62,13 +62,15 @@ func SetSecretsProvider(ctx context.Context, provider secrets.ProviderType) erro
 	case secrets.EncryptedType:
 	case secrets.OnePasswordType:
 	case secrets.EnvironmentType:
+	case secrets.VaultType:
 		// Valid provider type
 	default:
-		return fmt.Errorf("invalid secrets provider type: %s (valid types: %s, %s, %s)",
+		return fmt.Errorf("invalid secrets provider type: %s (valid types: %s, %s, %s, %s)",
 			provider,
 			string(secrets.EncryptedType),
 			string(secrets.OnePasswordType),
 			string(secrets.EnvironmentType),
+			string(secrets.VaultType),
 		)
 	}
 
@@ -331,7 +331,7 @@ func enhanceRegistryError(err error, url, registryType string) error {
 	return fmt.Errorf("failed to set %s: %w", registryType, err)
 }
 
-func usageMetricsCmdFunc(_ *cobra.Command, args []string) error {
+func usageMetricsCmdFunc(cmd *cobra.Command, args []string) error {
 	action := args[0]
 
 	var disable bool
@@ -344,7 +344,7 @@ func usageMetricsCmdFunc(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid argument: %s (expected 'enable' or 'disable')", action)
 	}
 
-	err := config.UpdateConfig(func(c *config.Config) error {
+	err := config.UpdateConfigContext(cmd.Context(), func(c *config.Config) error {
 		c.DisableUsageMetrics = disable
 		return nil
 	})
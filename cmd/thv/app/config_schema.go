@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive/pkg/config"
+	vmcpconfig "github.com/stacklok/toolhive/pkg/vmcp/config"
+)
+
+// configSchemas maps each schema name accepted by "thv config schema" to its generator.
+var configSchemas = map[string]func() (map[string]any, error){
+	"toolhive": config.JSONSchema,
+	"vmcp":     vmcpconfig.JSONSchema,
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema <toolhive|vmcp>",
+	Short: "Print the JSON Schema of a configuration file",
+	Long: `Print the JSON Schema of the ToolHive configuration file (toolhive) or the
+Virtual MCP Server configuration file (vmcp) to stdout.
+
+Editors use the schema to validate and autocomplete configuration files. With
+yaml-language-server (e.g. the VS Code YAML extension), save the schema and
+reference it from the first line of the file:
+
+  # yaml-language-server: $schema=./vmcp-config.schema.json
+
+Examples:
+  thv config schema vmcp > vmcp-config.schema.json
+  thv config schema toolhive > toolhive-config.schema.json`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"toolhive", "vmcp"},
+	RunE:      configSchemaCmdFunc,
+}
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func configSchemaCmdFunc(cmd *cobra.Command, args []string) error {
+	generate, ok := configSchemas[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown config schema %q: must be one of toolhive, vmcp", args[0])
+	}
+	schema, err := generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate %s config schema: %w", args[0], err)
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s config schema: %w", args[0], err)
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return err
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive/pkg/config"
+)
+
+var configValidateFile string
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the ToolHive configuration file",
+	Long: `Check every field of the ToolHive configuration file against the rules
+applied when it is set through "thv config" commands, and report all invalid
+fields at once. Useful after editing the file by hand.
+
+The command exits with a non-zero status when any field is invalid. The file
+is only read, never modified.
+
+Examples:
+  thv config validate
+  thv config validate --file ./config.yaml`,
+	Args: cobra.NoArgs,
+	RunE: configValidateCmdFunc,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	configValidateCmd.Flags().StringVar(&configValidateFile, "file", "",
+		"Path of the configuration file to validate (defaults to the ToolHive config file)")
+}
+
+func configValidateCmdFunc(cmd *cobra.Command, _ []string) error {
+	return validateConfigFile(cmd.OutOrStdout(), configValidateFile)
+}
+
+// validateConfigFile validates the config file at path, or the default config
+// file when path is empty, and writes every invalid field to w.
+func validateConfigFile(w io.Writer, path string) error {
+	cfg, err := config.LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	fieldErrs := cfg.ValidateFields()
+	if len(fieldErrs) == 0 {
+		_, err := fmt.Fprintln(w, "Configuration is valid.")
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "Configuration has invalid fields:"); err != nil {
+		return err
+	}
+	for _, fieldErr := range fieldErrs {
+		// Fields reporting several problems list one per line
+		message := strings.ReplaceAll(fieldErr.Error(), "\n", "\n    ")
+		if _, err := fmt.Fprintf(w, "  - %s\n", message); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("configuration has %d invalid field(s)", len(fieldErrs))
+}
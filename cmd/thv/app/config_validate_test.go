@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		t.Parallel()
+
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+secrets:
+  provider_type: keychain
+otel:
+  endpoint: http://collector:4318
+  sampling-rate: -1
+build_env:
+  lower_case: value
+  PATH: /tmp
+`), 0600))
+
+		var out bytes.Buffer
+		err := validateConfigFile(&out, configPath)
+		require.ErrorContains(t, err, "4 invalid field(s)")
+
+		output := out.String()
+		assert.Contains(t, output, "  - secrets.provider_type: ")
+		assert.Contains(t, output, "  - otel.endpoint: endpoint URL should not start with http:// or https://")
+		assert.Contains(t, output, "  - otel.sampling-rate: sampling rate must be between 0.0 and 1.0")
+		assert.Contains(t, output, "  - build_env: ")
+		assert.Contains(t, output, "\n    ", "multiple problems in one field are listed on separate lines")
+	})
+
+	t.Run("valid config", func(t *testing.T) {
+		t.Parallel()
+
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte("registry_url: https://example.com/registry.json\n"), 0600))
+
+		var out bytes.Buffer
+		require.NoError(t, validateConfigFile(&out, configPath))
+		assert.Equal(t, "Configuration is valid.\n", out.String())
+	})
+}
@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive/pkg/config"
+	"github.com/stacklok/toolhive/pkg/container"
+	"github.com/stacklok/toolhive/pkg/k8s"
+	"github.com/stacklok/toolhive/pkg/lockfile"
+	"github.com/stacklok/toolhive/pkg/registry"
+)
+
+var doctorFormat string
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose common ToolHive setup problems",
+	Long: `Check the environment ToolHive depends on and print a pass/fail report with
+a hint for every problem found. The checks cover:
+
+- Container runtime: a Docker-compatible runtime (Docker, Podman, Colima) is available
+- Kubernetes: a Kubernetes configuration can be loaded (optional)
+- Configuration: every field of the ToolHive configuration file is valid
+- Registry: the configured MCP server registry can be loaded
+- Lock files: no stale lock files are left behind by crashed processes
+
+The command exits with a non-zero status when any check fails. Warnings, such
+as Kubernetes not being configured, do not affect the exit status.
+
+Examples:
+  thv doctor
+  thv doctor --format json`,
+	Args:    cobra.NoArgs,
+	PreRunE: ValidateFormat(&doctorFormat),
+	RunE:    doctorCmdFunc,
+}
+
+func init() {
+	AddFormatFlag(doctorCmd, &doctorFormat)
+}
+
+// doctorStatus is the outcome of a single doctor check.
+type doctorStatus string
+
+const (
+	doctorPass doctorStatus = "pass"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+)
+
+// doctorResult is the report entry of a single check.
+type doctorResult struct {
+	Name    string       `json:"name"`
+	Status  doctorStatus `json:"status"`
+	Message string       `json:"message"`
+	// Hint tells the user how to fix a failed or warning check.
+	Hint string `json:"hint,omitempty"`
+}
+
+// doctorReport is the full output of thv doctor.
+type doctorReport struct {
+	Healthy bool           `json:"healthy"`
+	Checks  []doctorResult `json:"checks"`
+}
+
+// failures returns the number of failed checks.
+func (r *doctorReport) failures() int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == doctorFail {
+			count++
+		}
+	}
+	return count
+}
+
+// doctorEnv holds the components thv doctor inspects, so that tests can
+// replace them.
+type doctorEnv struct {
+	checkRuntime     func() error
+	kubernetesStatus func() k8s.Availability
+	loadConfig       func() (*config.Config, error)
+	loadRegistry     func() (int, error)
+	findStaleLocks   func() []string
+}
+
+// defaultDoctorEnv returns the components of the running installation.
+func defaultDoctorEnv() *doctorEnv {
+	return &doctorEnv{
+		checkRuntime:     container.CheckRuntimeAvailable,
+		kubernetesStatus: k8s.AvailabilityStatus,
+		loadConfig: func() (*config.Config, error) {
+			return config.LoadConfigFile("")
+		},
+		loadRegistry: func() (int, error) {
+			provider, err := registry.GetDefaultProvider()
+			if err != nil {
+				return 0, err
+			}
+			servers, err := provider.ListServers()
+			return len(servers), err
+		},
+		findStaleLocks: func() []string {
+			return lockfile.FindStaleLocks(lockfile.DefaultDirectories(), lockfile.DefaultMaxAge)
+		},
+	}
+}
+
+func doctorCmdFunc(cmd *cobra.Command, _ []string) error {
+	report := defaultDoctorEnv().run()
+
+	var err error
+	if doctorFormat == FormatJSON {
+		err = printDoctorJSON(cmd.OutOrStdout(), report)
+	} else {
+		err = printDoctorText(cmd.OutOrStdout(), report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if failures := report.failures(); failures > 0 {
+		// The report already explains every failure
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d doctor check(s) failed", failures)
+	}
+	return nil
+}
+
+// run performs every check, in a fixed order, and collects the results.
+func (e *doctorEnv) run() *doctorReport {
+	report := &doctorReport{
+		Checks: []doctorResult{
+			e.checkContainerRuntime(),
+			e.checkKubernetes(),
+			e.checkConfig(),
+			e.checkRegistry(),
+			e.checkLockFiles(),
+		},
+	}
+	report.Healthy = report.failures() == 0
+	return report
+}
+
+func (e *doctorEnv) checkContainerRuntime() doctorResult {
+	result := doctorResult{Name: "Container runtime"}
+	if err := e.checkRuntime(); err != nil {
+		result.Status = doctorFail
+		result.Message = err.Error()
+		result.Hint = "Install and start Docker, Podman, or Colima, or select an installed runtime " +
+			"with the TOOLHIVE_RUNTIME environment variable"
+		return result
+	}
+	result.Status = doctorPass
+	result.Message = "a container runtime is available"
+	return result
+}
+
+func (e *doctorEnv) checkKubernetes() doctorResult {
+	result := doctorResult{Name: "Kubernetes"}
+	status := e.kubernetesStatus()
+	result.Message = status.String()
+	if !status.Available {
+		// Kubernetes is only needed to run MCP servers in a cluster
+		result.Status = doctorWarn
+		result.Hint = "Only needed to run MCP servers on Kubernetes: point KUBECONFIG at a kubeconfig " +
+			"file or create ~/.kube/config"
+		return result
+	}
+	result.Status = doctorPass
+	return result
+}
+
+func (e *doctorEnv) checkConfig() doctorResult {
+	result := doctorResult{Name: "Configuration"}
+	cfg, err := e.loadConfig()
+	if errors.Is(err, os.ErrNotExist) {
+		result.Status = doctorPass
+		result.Message = "no configuration file, defaults are used"
+		return result
+	}
+	if err != nil {
+		result.Status = doctorFail
+		result.Message = err.Error()
+		result.Hint = "Fix the YAML syntax of the configuration file, or remove it to start from the defaults"
+		return result
+	}
+
+	fieldErrs := cfg.ValidateFields()
+	if len(fieldErrs) == 0 {
+		result.Status = doctorPass
+		result.Message = "configuration is valid"
+		return result
+	}
+	messages := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		// Fields reporting several problems join them with newlines
+		messages = append(messages, strings.ReplaceAll(fieldErr.Error(), "\n", "; "))
+	}
+	result.Status = doctorFail
+	result.Message = fmt.Sprintf("%d invalid field(s): %s", len(fieldErrs), strings.Join(messages, "; "))
+	result.Hint = `Correct the fields with the "thv config" commands or by editing the file; ` +
+		`run "thv config validate" to check it again`
+	return result
+}
+
+func (e *doctorEnv) checkRegistry() doctorResult {
+	result := doctorResult{Name: "Registry"}
+	count, err := e.loadRegistry()
+	if err != nil {
+		result.Status = doctorFail
+		result.Message = err.Error()
+		result.Hint = `Check the registry configured with "thv config set-registry", ` +
+			`or run "thv config unset-registry" to use the built-in registry`
+		return result
+	}
+	result.Status = doctorPass
+	result.Message = fmt.Sprintf("registry lists %d server(s)", count)
+	return result
+}
+
+func (e *doctorEnv) checkLockFiles() doctorResult {
+	result := doctorResult{Name: "Lock files"}
+	stale := e.findStaleLocks()
+	if len(stale) > 0 {
+		result.Status = doctorFail
+		result.Message = fmt.Sprintf("%d stale lock file(s): %s", len(stale), strings.Join(stale, ", "))
+		result.Hint = "No running ToolHive process owns these files and they could not be removed " +
+			"automatically; check their permissions and delete them"
+		return result
+	}
+	result.Status = doctorPass
+	result.Message = "no stale lock files"
+	return result
+}
+
+func printDoctorJSON(w io.Writer, report *doctorReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal doctor report: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func printDoctorText(w io.Writer, report *doctorReport) error {
+	for _, check := range report.Checks {
+		if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", strings.ToUpper(string(check.Status)), check.Name, check.Message); err != nil {
+			return err
+		}
+		if check.Hint != "" {
+			if _, err := fmt.Fprintf(w, "       Hint: %s\n", check.Hint); err != nil {
+				return err
+			}
+		}
+	}
+
+	summary := "All checks passed."
+	if failures := report.failures(); failures > 0 {
+		summary = fmt.Sprintf("%d check(s) failed.", failures)
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", summary)
+	return err
+}
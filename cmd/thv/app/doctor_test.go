@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/config"
+	"github.com/stacklok/toolhive/pkg/k8s"
+)
+
+// healthyDoctorEnv returns components for which every check passes.
+func healthyDoctorEnv() *doctorEnv {
+	return &doctorEnv{
+		checkRuntime: func() error { return nil },
+		kubernetesStatus: func() k8s.Availability {
+			return k8s.Availability{Available: true, Method: k8s.DetectionKubeconfig}
+		},
+		loadConfig:     func() (*config.Config, error) { return &config.Config{}, nil },
+		loadRegistry:   func() (int, error) { return 3, nil },
+		findStaleLocks: func() []string { return nil },
+	}
+}
+
+func TestDoctor_AllChecksPass(t *testing.T) {
+	t.Parallel()
+
+	report := healthyDoctorEnv().run()
+	assert.True(t, report.Healthy)
+	for _, check := range report.Checks {
+		assert.Equal(t, doctorPass, check.Status, check.Name)
+		assert.Empty(t, check.Hint, check.Name)
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printDoctorText(&out, report))
+	assert.Contains(t, out.String(), "[PASS] Registry: registry lists 3 server(s)\n")
+	assert.Contains(t, out.String(), "All checks passed.")
+}
+
+func TestDoctor_ReportsEveryFailureWithHint(t *testing.T) {
+	t.Parallel()
+
+	env := healthyDoctorEnv()
+	env.checkRuntime = func() error { return errors.New("no container runtime available") }
+	env.kubernetesStatus = func() k8s.Availability {
+		return k8s.Availability{Method: k8s.DetectionKubeconfig, Err: errors.New("no kubeconfig found")}
+	}
+	env.loadConfig = func() (*config.Config, error) {
+		return &config.Config{LogLevel: "verbose", OTEL: config.OpenTelemetryConfig{SamplingRate: 2}}, nil
+	}
+	env.loadRegistry = func() (int, error) {
+		return 0, errors.New("custom registry at https://registry.example.com is not reachable")
+	}
+	env.findStaleLocks = func() []string { return []string{"/data/toolhive/updates.json.lock"} }
+
+	report := env.run()
+	assert.False(t, report.Healthy)
+	assert.Equal(t, 4, report.failures())
+
+	results := make(map[string]doctorResult, len(report.Checks))
+	for _, check := range report.Checks {
+		results[check.Name] = check
+		assert.NotEmpty(t, check.Hint, "%s must tell the user how to fix it", check.Name)
+	}
+	assert.Equal(t, doctorFail, results["Container runtime"].Status)
+	assert.Equal(t, doctorWarn, results["Kubernetes"].Status, "Kubernetes is optional")
+	assert.Equal(t, doctorFail, results["Configuration"].Status)
+	assert.Contains(t, results["Configuration"].Message, "log_level")
+	assert.Contains(t, results["Configuration"].Message, "otel.sampling-rate")
+	assert.Equal(t, doctorFail, results["Registry"].Status)
+	assert.Contains(t, results["Registry"].Message, "not reachable")
+	assert.Equal(t, doctorFail, results["Lock files"].Status)
+	assert.Contains(t, results["Lock files"].Message, "updates.json.lock")
+
+	var text bytes.Buffer
+	require.NoError(t, printDoctorText(&text, report))
+	for _, check := range report.Checks {
+		assert.Contains(t, text.String(), fmt.Sprintf("%s: %s\n       Hint: %s\n", check.Name, check.Message, check.Hint))
+	}
+	assert.Contains(t, text.String(), "[FAIL] Container runtime: ")
+	assert.Contains(t, text.String(), "[WARN] Kubernetes: ")
+	assert.Contains(t, text.String(), "4 check(s) failed.")
+
+	var out bytes.Buffer
+	require.NoError(t, printDoctorJSON(&out, report))
+	var decoded doctorReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, *report, decoded)
+}
+
+func TestDoctor_ConfigChecks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file uses defaults", func(t *testing.T) {
+		t.Parallel()
+
+		env := healthyDoctorEnv()
+		env.loadConfig = func() (*config.Config, error) {
+			return nil, fmt.Errorf("unable to read config file: %w", os.ErrNotExist)
+		}
+		assert.Equal(t, doctorPass, env.checkConfig().Status)
+	})
+
+	t.Run("unparsable file", func(t *testing.T) {
+		t.Parallel()
+
+		env := healthyDoctorEnv()
+		env.loadConfig = func() (*config.Config, error) {
+			return nil, errors.New("failed to parse config file yaml")
+		}
+		result := env.checkConfig()
+		assert.Equal(t, doctorFail, result.Status)
+		assert.Contains(t, result.Hint, "YAML")
+	})
+}
@@ -5,11 +5,17 @@ package app
 
 import (
 	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stacklok/toolhive/pkg/certs"
 	"github.com/stacklok/toolhive/pkg/config"
 )
 
@@ -200,8 +206,138 @@ var unsetOtelEnablePrometheusMetricsPathCmd = &cobra.Command{
 	RunE:  unsetOtelEnablePrometheusMetricsPathCmdFunc,
 }
 
+var setOtelHeadersCmd = &cobra.Command{
+	Use:   "set-headers <key=value> [key=value...]",
+	Short: "Set the OpenTelemetry OTLP export headers",
+	Long: `Set headers sent with every OTLP export, such as an API key for an authenticated collector.
+
+These headers replace any previously configured ones. Headers passed with the --otel-headers flag
+take precedence over configured headers with the same name. Header values are stored in the
+configuration file in plain text and are redacted when displayed.
+
+With --from-file, each value is instead the path of a file holding the header value. The file is
+read whenever telemetry starts, so the value is not stored in the configuration. Leading and
+trailing whitespace in the file is ignored.
+
+Examples:
+
+	thv config otel set-headers x-honeycomb-team=your-api-key x-honeycomb-dataset=toolhive
+	thv config otel set-headers --from-file x-honeycomb-team=/etc/toolhive/honeycomb-key`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: setOtelHeadersCmdFunc,
+}
+
+var getOtelHeadersCmd = &cobra.Command{
+	Use:   "get-headers",
+	Short: "Get the currently configured OpenTelemetry OTLP export headers",
+	Long: `Display the names of the OpenTelemetry OTLP export headers that are currently configured.
+Values are redacted; headers read from a file show the file path instead.`,
+	RunE: getOtelHeadersCmdFunc,
+}
+
+var unsetOtelHeadersCmd = &cobra.Command{
+	Use:   "unset-headers",
+	Short: "Remove the configured OpenTelemetry OTLP export headers",
+	Long:  "Remove the OpenTelemetry OTLP export headers configuration.",
+	RunE:  unsetOtelHeadersCmdFunc,
+}
+
+var setOtelCACertPathCmd = &cobra.Command{
+	Use:   "set-ca-cert-path <path>",
+	Short: "Set the CA certificate for the OpenTelemetry endpoint",
+	Long: `Set a CA certificate bundle to trust, in addition to the system CAs, when connecting to the OTLP endpoint.
+
+Use this when the collector's certificate is signed by a private CA.
+
+Example:
+
+	thv config otel set-ca-cert-path /etc/ssl/certs/collector-ca.pem`,
+	Args: cobra.ExactArgs(1),
+	RunE: setOtelCACertPathCmdFunc,
+}
+
+var getOtelCACertPathCmd = &cobra.Command{
+	Use:   "get-ca-cert-path",
+	Short: "Get the currently configured CA certificate for the OpenTelemetry endpoint",
+	Long:  "Display the CA certificate bundle path that is currently configured for the OTLP endpoint.",
+	RunE:  getOtelCACertPathCmdFunc,
+}
+
+var unsetOtelCACertPathCmd = &cobra.Command{
+	Use:   "unset-ca-cert-path",
+	Short: "Remove the configured CA certificate for the OpenTelemetry endpoint",
+	Long:  "Remove the OpenTelemetry endpoint CA certificate configuration.",
+	RunE:  unsetOtelCACertPathCmdFunc,
+}
+
+var setOtelSamplingOverridesCmd = &cobra.Command{
+	Use:   "set-sampling-overrides <operation=rate> [operation=rate...]",
+	Short: "Set per-operation OpenTelemetry sampling rates",
+	Long: `Set trace sampling rates (between 0.0 and 1.0) for individual operations, used instead of the global sampling rate.
+
+An operation is a span name or an MCP method such as tools/call, which also matches the spans
+for individual tools (e.g. "tools/call fetch"). The most specific match wins. Spans whose parent
+is sampled are always sampled. These overrides replace any previously configured ones.
+
+Example:
+
+	thv config otel set-sampling-overrides tools/call=1.0 ping=0.01`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: setOtelSamplingOverridesCmdFunc,
+}
+
+var getOtelSamplingOverridesCmd = &cobra.Command{
+	Use:   "get-sampling-overrides",
+	Short: "Get the currently configured per-operation OpenTelemetry sampling rates",
+	Long:  "Display the per-operation OpenTelemetry sampling rates that are currently configured.",
+	RunE:  getOtelSamplingOverridesCmdFunc,
+}
+
+var unsetOtelSamplingOverridesCmd = &cobra.Command{
+	Use:   "unset-sampling-overrides",
+	Short: "Remove the configured per-operation OpenTelemetry sampling rates",
+	Long:  "Remove the per-operation OpenTelemetry sampling rates configuration.",
+	RunE:  unsetOtelSamplingOverridesCmdFunc,
+}
+
+var setOtelPrometheusBasicAuthCmd = &cobra.Command{
+	Use:   "set-prometheus-basic-auth <username> <password-file>",
+	Short: "Require basic auth on the Prometheus metrics endpoint",
+	Long: `Require HTTP basic authentication on the Prometheus /metrics endpoint.
+
+The password is read from password-file whenever telemetry starts, so it is not stored in the
+configuration. Leading and trailing whitespace in the file is ignored. The endpoint itself is
+enabled with set-enable-prometheus-metrics-path.
+
+Example:
+
+	thv config otel set-prometheus-basic-auth prometheus /etc/toolhive/metrics-password`,
+	Args: cobra.ExactArgs(2),
+	RunE: setOtelPrometheusBasicAuthCmdFunc,
+}
+
+var getOtelPrometheusBasicAuthCmd = &cobra.Command{
+	Use:   "get-prometheus-basic-auth",
+	Short: "Get the currently configured basic auth for the Prometheus metrics endpoint",
+	Long:  "Display the username and password file currently required on the Prometheus /metrics endpoint.",
+	RunE:  getOtelPrometheusBasicAuthCmdFunc,
+}
+
+var unsetOtelPrometheusBasicAuthCmd = &cobra.Command{
+	Use:   "unset-prometheus-basic-auth",
+	Short: "Remove the configured basic auth for the Prometheus metrics endpoint",
+	Long:  "Remove the Prometheus /metrics endpoint basic auth configuration.",
+	RunE:  unsetOtelPrometheusBasicAuthCmdFunc,
+}
+
+// otelHeadersFromFile makes set-headers read header values from files.
+var otelHeadersFromFile bool
+
 // init sets up the OTEL command hierarchy
 func init() {
+	setOtelHeadersCmd.Flags().BoolVar(&otelHeadersFromFile, "from-file", false,
+		"Treat each value as the path of a file holding the header value")
+
 	// Add OTEL subcommands to otel command
 	OtelCmd.AddCommand(setOtelEndpointCmd)
 	OtelCmd.AddCommand(getOtelEndpointCmd)
@@ -224,14 +360,26 @@ func init() {
 	OtelCmd.AddCommand(setOtelEnablePrometheusMetricsPathCmd)
 	OtelCmd.AddCommand(getOtelEnablePrometheusMetricsPathCmd)
 	OtelCmd.AddCommand(unsetOtelEnablePrometheusMetricsPathCmd)
+	OtelCmd.AddCommand(setOtelHeadersCmd)
+	OtelCmd.AddCommand(getOtelHeadersCmd)
+	OtelCmd.AddCommand(unsetOtelHeadersCmd)
+	OtelCmd.AddCommand(setOtelCACertPathCmd)
+	OtelCmd.AddCommand(getOtelCACertPathCmd)
+	OtelCmd.AddCommand(unsetOtelCACertPathCmd)
+	OtelCmd.AddCommand(setOtelSamplingOverridesCmd)
+	OtelCmd.AddCommand(getOtelSamplingOverridesCmd)
+	OtelCmd.AddCommand(unsetOtelSamplingOverridesCmd)
+	OtelCmd.AddCommand(setOtelPrometheusBasicAuthCmd)
+	OtelCmd.AddCommand(getOtelPrometheusBasicAuthCmd)
+	OtelCmd.AddCommand(unsetOtelPrometheusBasicAuthCmd)
 }
 
 func setOtelEndpointCmdFunc(_ *cobra.Command, args []string) error {
 	endpoint := args[0]
 
 	// The endpoint should not start with http:// or https://
-	if endpoint != "" && (strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://")) {
-		return fmt.Errorf("endpoint URL should not start with http:// or https://")
+	if err := config.ValidateOTELEndpoint(endpoint); err != nil {
+		return err
 	}
 
 	// Update the configuration
@@ -289,8 +437,8 @@ func setOtelSamplingRateCmdFunc(_ *cobra.Command, args []string) error {
 	}
 
 	// Validate the rate
-	if rate < 0.0 || rate > 1.0 {
-		return fmt.Errorf("sampling rate must be between 0.0 and 1.0")
+	if err := config.ValidateSamplingRate(rate); err != nil {
+		return err
 	}
 
 	// Update the configuration
@@ -594,3 +742,327 @@ func unsetOtelEnablePrometheusMetricsPathCmdFunc(_ *cobra.Command, _ []string) e
 	fmt.Println("Successfully disabled the Prometheus metrics path configuration.")
 	return nil
 }
+
+func setOtelHeadersCmdFunc(_ *cobra.Command, args []string) error {
+	headers, err := parseOtelHeaders(args)
+	if err != nil {
+		return err
+	}
+	if otelHeadersFromFile {
+		for _, name := range slices.Sorted(maps.Keys(headers)) {
+			if err := config.ValidateOTELHeaderFile(name, headers[name]); err != nil {
+				return fmt.Errorf("invalid header file: %w", err)
+			}
+		}
+	}
+
+	// Update the configuration
+	err = config.UpdateConfig(func(c *config.Config) error {
+		if otelHeadersFromFile {
+			c.OTEL.Headers = nil
+			c.OTEL.HeaderFiles = headers
+		} else {
+			c.OTEL.Headers = headers
+			c.OTEL.HeaderFiles = nil
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Printf("Successfully set OpenTelemetry headers: %s\n", strings.Join(slices.Sorted(maps.Keys(headers)), ", "))
+	return nil
+}
+
+// parseOtelHeaders parses key=value arguments into a header map. Values are
+// split on the first '=' only, so they may contain '=' themselves.
+func parseOtelHeaders(args []string) (map[string]string, error) {
+	headers := make(map[string]string, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q: expected key=value", name)
+		}
+		headers[name] = value
+	}
+	return headers, nil
+}
+
+func getOtelHeadersCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if len(cfg.OTEL.Headers) == 0 && len(cfg.OTEL.HeaderFiles) == 0 {
+		fmt.Println("No OpenTelemetry headers are currently configured.")
+		return nil
+	}
+
+	fmt.Println("Current OpenTelemetry headers:")
+	printOtelHeaders(os.Stdout, cfg.OTEL)
+	return nil
+}
+
+// printOtelHeaders writes the configured OTLP headers to w, one per line,
+// with their values redacted. Headers read from a file show the file path.
+func printOtelHeaders(w io.Writer, otel config.OpenTelemetryConfig) {
+	display := config.RedactOTELHeaders(otel.Headers)
+	if display == nil {
+		display = make(map[string]string, len(otel.HeaderFiles))
+	}
+	for name, path := range otel.HeaderFiles {
+		display[name] = "(from file " + path + ")"
+	}
+	for _, name := range slices.Sorted(maps.Keys(display)) {
+		fmt.Fprintf(w, "  %s: %s\n", name, display[name])
+	}
+}
+
+func unsetOtelHeadersCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if len(cfg.OTEL.Headers) == 0 && len(cfg.OTEL.HeaderFiles) == 0 {
+		fmt.Println("No OpenTelemetry headers are currently configured.")
+		return nil
+	}
+
+	// Update the configuration
+	err := config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.Headers = nil
+		c.OTEL.HeaderFiles = nil
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Println("Successfully removed OpenTelemetry headers configuration.")
+	return nil
+}
+
+func setOtelCACertPathCmdFunc(_ *cobra.Command, args []string) error {
+	certPath := filepath.Clean(args[0])
+
+	// #nosec G304: the path is provided by the user configuring their own CLI
+	certContent, err := os.ReadFile(certPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	if err := certs.ValidateCACertificate(certContent); err != nil {
+		return fmt.Errorf("invalid CA certificate: %w", err)
+	}
+
+	// Update the configuration
+	err = config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.CACertPath = certPath
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Printf("Successfully set OpenTelemetry CA certificate: %s\n", certPath)
+	return nil
+}
+
+func getOtelCACertPathCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if cfg.OTEL.CACertPath == "" {
+		fmt.Println("No OpenTelemetry CA certificate is currently configured.")
+		return nil
+	}
+
+	fmt.Printf("Current OpenTelemetry CA certificate: %s\n", cfg.OTEL.CACertPath)
+	if _, err := os.Stat(cfg.OTEL.CACertPath); err != nil {
+		fmt.Println("Warning: The configured CA certificate file is not accessible")
+	}
+	return nil
+}
+
+func unsetOtelCACertPathCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if cfg.OTEL.CACertPath == "" {
+		fmt.Println("No OpenTelemetry CA certificate is currently configured.")
+		return nil
+	}
+
+	// Update the configuration
+	err := config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.CACertPath = ""
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Println("Successfully removed OpenTelemetry CA certificate configuration.")
+	return nil
+}
+
+func setOtelSamplingOverridesCmdFunc(_ *cobra.Command, args []string) error {
+	overrides, err := parseOtelSamplingOverrides(args)
+	if err != nil {
+		return err
+	}
+
+	// Update the configuration
+	err = config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.SamplingOverrides = overrides
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Println("Successfully set OpenTelemetry sampling overrides:")
+	printOtelSamplingOverrides(overrides)
+	return nil
+}
+
+// parseOtelSamplingOverrides parses operation=rate arguments.
+func parseOtelSamplingOverrides(args []string) (map[string]float64, error) {
+	overrides := make(map[string]float64, len(args))
+	for _, arg := range args {
+		idx := strings.LastIndex(arg, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid sampling override %q: expected operation=rate", arg)
+		}
+		operation := strings.TrimSpace(arg[:idx])
+		if operation == "" {
+			return nil, fmt.Errorf("invalid sampling override %q: expected operation=rate", arg)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(arg[idx+1:]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampling rate for %q: %w", operation, err)
+		}
+		if !(rate >= 0.0 && rate <= 1.0) {
+			return nil, fmt.Errorf("sampling rate for %q must be between 0.0 and 1.0", operation)
+		}
+		overrides[operation] = rate
+	}
+	return overrides, nil
+}
+
+func printOtelSamplingOverrides(overrides map[string]float64) {
+	for _, operation := range slices.Sorted(maps.Keys(overrides)) {
+		fmt.Printf("  %s: %s\n", operation, strconv.FormatFloat(overrides[operation], 'f', -1, 64))
+	}
+}
+
+func getOtelSamplingOverridesCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if len(cfg.OTEL.SamplingOverrides) == 0 {
+		fmt.Println("No OpenTelemetry sampling overrides are currently configured.")
+		return nil
+	}
+
+	fmt.Println("Current OpenTelemetry sampling overrides:")
+	printOtelSamplingOverrides(cfg.OTEL.SamplingOverrides)
+	return nil
+}
+
+func unsetOtelSamplingOverridesCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if len(cfg.OTEL.SamplingOverrides) == 0 {
+		fmt.Println("No OpenTelemetry sampling overrides are currently configured.")
+		return nil
+	}
+
+	// Update the configuration
+	err := config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.SamplingOverrides = nil
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Println("Successfully removed OpenTelemetry sampling overrides configuration.")
+	return nil
+}
+
+func setOtelPrometheusBasicAuthCmdFunc(_ *cobra.Command, args []string) error {
+	username := args[0]
+	if username == "" {
+		return fmt.Errorf("username must not be empty")
+	}
+
+	// The proxies read the file after they are detached, so store an absolute path.
+	passwordFile, err := filepath.Abs(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to resolve password file path: %w", err)
+	}
+	// #nosec G304: the path is provided by the user configuring their own CLI
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return fmt.Errorf("failed to read password file: %w", err)
+	}
+	if strings.TrimSpace(string(password)) == "" {
+		return fmt.Errorf("password file %s is empty", passwordFile)
+	}
+
+	// Update the configuration
+	err = config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.PrometheusBasicAuth = &config.PrometheusBasicAuthConfig{
+			Username:     username,
+			PasswordFile: passwordFile,
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Printf("Successfully set Prometheus metrics basic auth for user %s (password file: %s)\n", username, passwordFile)
+	return nil
+}
+
+func getOtelPrometheusBasicAuthCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	auth := cfg.OTEL.PrometheusBasicAuth
+	if auth == nil {
+		fmt.Println("No Prometheus metrics basic auth is currently configured.")
+		return nil
+	}
+
+	fmt.Printf("Current Prometheus metrics basic auth user: %s\n", auth.Username)
+	fmt.Printf("Password file: %s\n", auth.PasswordFile)
+	if _, err := os.Stat(auth.PasswordFile); err != nil {
+		fmt.Println("Warning: The configured password file is not accessible")
+	}
+	return nil
+}
+
+func unsetOtelPrometheusBasicAuthCmdFunc(_ *cobra.Command, _ []string) error {
+	configProvider := config.NewDefaultProvider()
+	cfg := configProvider.GetConfig()
+
+	if cfg.OTEL.PrometheusBasicAuth == nil {
+		fmt.Println("No Prometheus metrics basic auth is currently configured.")
+		return nil
+	}
+
+	// Update the configuration
+	err := config.UpdateConfig(func(c *config.Config) error {
+		c.OTEL.PrometheusBasicAuth = nil
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	fmt.Println("Successfully removed Prometheus metrics basic auth configuration.")
+	return nil
+}
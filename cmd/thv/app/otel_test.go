@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/config"
+)
+
+func TestPrintOtelHeaders_RedactsValues(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	printOtelHeaders(&out, config.OpenTelemetryConfig{
+		Headers:     map[string]string{"x-honeycomb-team": "super-secret-key"},
+		HeaderFiles: map[string]string{"x-api-key": "/etc/toolhive/api-key"},
+	})
+
+	assert.NotContains(t, out.String(), "super-secret-key")
+	assert.Equal(t,
+		"  x-api-key: (from file /etc/toolhive/api-key)\n"+
+			"  x-honeycomb-team: [REDACTED]\n",
+		out.String())
+}
+
+func TestParseOtelHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers, err := parseOtelHeaders([]string{"authorization=Basic dXNlcjpwYXNz==", " x-team =abc"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Basic dXNlcjpwYXNz==", "x-team": "abc"}, headers)
+
+	_, err = parseOtelHeaders([]string{"no-separator"})
+	assert.Error(t, err)
+}
@@ -70,6 +70,11 @@ with the authorization server using RFC 7591 dynamic client registration:
 - Automatically discovers registration endpoint via OIDC
 - Supports PKCE flow for enhanced security
 
+#### Reloading configuration
+
+Send SIGHUP to the proxy to reload the ToolHive configuration (the registry and
+log_level) without restarting it. An invalid configuration is logged and ignored.
+
 #### Examples
 
 Basic transparent proxy:
@@ -176,6 +181,10 @@ func init() {
 func proxyCmdFunc(cmd *cobra.Command, args []string) error {
 	ctx, stopSignal := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 	defer stopSignal()
+
+	// Reload the configuration on SIGHUP instead of exiting
+	newConfigReloader().handleSIGHUP(ctx)
+
 	// Get the server name
 	serverName := args[0]
 
@@ -399,6 +408,7 @@ func handleOutgoingAuthentication(ctx context.Context) (*discovery.OAuthFlowResu
 			CallbackPort:    remoteAuthFlags.RemoteAuthCallbackPort,
 			Timeout:         remoteAuthFlags.RemoteAuthTimeout,
 			SkipBrowser:     remoteAuthFlags.RemoteAuthSkipBrowser,
+			UseDeviceFlow:   remoteAuthFlags.RemoteAuthDeviceFlow,
 			ScopeParamName:  remoteAuthFlags.RemoteAuthScopeParamName,
 			AllowPrivateIPs: networking.TargetIsPrivate(ctx, proxyTargetURI),
 		}
@@ -431,6 +441,7 @@ func handleOutgoingAuthentication(ctx context.Context) (*discovery.OAuthFlowResu
 			CallbackPort:    remoteAuthFlags.RemoteAuthCallbackPort,
 			Timeout:         remoteAuthFlags.RemoteAuthTimeout,
 			SkipBrowser:     remoteAuthFlags.RemoteAuthSkipBrowser,
+			UseDeviceFlow:   remoteAuthFlags.RemoteAuthDeviceFlow,
 			ScopeParamName:  remoteAuthFlags.RemoteAuthScopeParamName,
 			AllowPrivateIPs: networking.TargetIsPrivate(ctx, proxyTargetURI),
 		}
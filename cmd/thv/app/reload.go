@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/viper"
+
+	"github.com/stacklok/toolhive/pkg/config"
+	"github.com/stacklok/toolhive/pkg/registry"
+)
+
+// configReloader reloads the ToolHive configuration of a long-running command
+// without restarting it. It picks up a changed registry (including a new local
+// registry file) and the configured log level.
+type configReloader struct {
+	// configPath is the config file to reload, or empty for the default path.
+	configPath string
+	// debug pins the log level to debug, as requested with --debug.
+	debug bool
+	// reloaded, if set, is called with the outcome of every reload.
+	reloaded func(error)
+}
+
+// newConfigReloader returns a reloader for the default config file.
+func newConfigReloader() *configReloader {
+	return &configReloader{debug: viper.GetBool("debug")}
+}
+
+// handleSIGHUP applies the configured log level and then reloads the
+// configuration whenever the process receives SIGHUP, until ctx is done. A
+// config that fails to load or validate is logged and the current one is kept.
+// SIGHUP is never delivered on Windows, where this only applies the log level.
+func (r *configReloader) handleSIGHUP(ctx context.Context) {
+	r.applyLogLevel(config.NewDefaultProvider().GetConfig())
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				slog.Info("received SIGHUP, reloading configuration")
+				err := r.reload()
+				if err != nil {
+					slog.Error("failed to reload configuration, keeping the current one", "error", err)
+				} else {
+					slog.Info("configuration reloaded")
+				}
+				if r.reloaded != nil {
+					r.reloaded(err)
+				}
+			}
+		}
+	}()
+}
+
+// reload re-reads the config file and applies it.
+func (r *configReloader) reload() error {
+	cfg, err := config.ReloadConfig(r.configPath)
+	if err != nil {
+		return err
+	}
+	// The registry provider is created from the config on first use
+	registry.ResetDefaultProvider()
+	r.applyLogLevel(cfg)
+	return nil
+}
+
+// applyLogLevel sets the level of the default logger from cfg, unless --debug
+// was passed.
+func (r *configReloader) applyLogLevel(cfg *config.Config) {
+	level := slog.LevelInfo
+	if r.debug {
+		level = slog.LevelDebug
+	} else if cfg.LogLevel != "" {
+		parsed, err := config.ParseLogLevel(cfg.LogLevel)
+		if err != nil {
+			slog.Warn("ignoring invalid log level in config", "error", err)
+		} else {
+			level = parsed
+		}
+	}
+	logLevel.Set(level)
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/config"
+	"github.com/stacklok/toolhive/pkg/registry"
+)
+
+// writeReloadTestRegistry writes a local registry file serving a single server.
+func writeReloadTestRegistry(t *testing.T, path, serverName string) {
+	t.Helper()
+
+	body := fmt.Sprintf(`{
+		"version": "1.0.0",
+		"meta": {"last_updated": "2025-01-01T00:00:00Z"},
+		"data": {
+			"servers": [
+				{
+					"name": %q,
+					"description": "Reload test server",
+					"packages": [
+						{
+							"registryType": "oci",
+							"identifier": "example/server:latest",
+							"transport": {"type": "stdio"}
+						}
+					]
+				}
+			]
+		}
+	}`, serverName)
+	require.NoError(t, os.WriteFile(path, []byte(body), 0600))
+}
+
+func writeReloadTestConfig(t *testing.T, path, registryPath, level string) {
+	t.Helper()
+
+	content := fmt.Sprintf("local_registry_path: %s\nlog_level: %s\n", registryPath, level)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func waitForReload(t *testing.T, results <-chan error) error {
+	t.Helper()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	select {
+	case err := <-results:
+		return err
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "configuration was not reloaded after SIGHUP")
+		return nil
+	}
+}
+
+//nolint:paralleltest // Mutates the process-wide config, registry and log level
+func TestConfigReloader_SIGHUP(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	originalLevel := logLevel.Level()
+	t.Cleanup(func() {
+		logLevel.Set(originalLevel)
+		config.ResetSingleton()
+		registry.ResetDefaultProvider()
+	})
+	config.ResetSingleton()
+	registry.ResetDefaultProvider()
+
+	dir := t.TempDir()
+	beforePath := filepath.Join(dir, "before.json")
+	afterPath := filepath.Join(dir, "after.json")
+	writeReloadTestRegistry(t, beforePath, "before-reload")
+	writeReloadTestRegistry(t, afterPath, "after-reload")
+
+	configPath := filepath.Join(configHome, "toolhive", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0750))
+	writeReloadTestConfig(t, configPath, beforePath, "warn")
+
+	results := make(chan error, 1)
+	reloader := &configReloader{reloaded: func(err error) { results <- err }}
+	reloader.handleSIGHUP(t.Context())
+	assert.Equal(t, slog.LevelWarn, logLevel.Level())
+
+	provider, err := registry.GetDefaultProvider()
+	require.NoError(t, err)
+	_, err = provider.GetServer("before-reload")
+	require.NoError(t, err)
+
+	// A valid change is applied without restarting
+	writeReloadTestConfig(t, configPath, afterPath, "debug")
+	require.NoError(t, waitForReload(t, results))
+
+	assert.Equal(t, slog.LevelDebug, logLevel.Level())
+	assert.Equal(t, "debug", config.NewDefaultProvider().GetConfig().LogLevel)
+	provider, err = registry.GetDefaultProvider()
+	require.NoError(t, err)
+	_, err = provider.GetServer("after-reload")
+	assert.NoError(t, err, "registry must be rebuilt from the reloaded config")
+
+	// An invalid change is rejected and the current config kept
+	writeReloadTestConfig(t, configPath, afterPath, "verbose")
+	err = waitForReload(t, results)
+	require.ErrorContains(t, err, "log_level")
+
+	assert.Equal(t, slog.LevelDebug, logLevel.Level())
+	assert.Equal(t, "debug", config.NewDefaultProvider().GetConfig().LogLevel)
+}
+
+//nolint:paralleltest // Mutates the process-wide log level
+func TestConfigReloader_DebugFlagWins(t *testing.T) {
+	originalLevel := logLevel.Level()
+	t.Cleanup(func() { logLevel.Set(originalLevel) })
+
+	reloader := &configReloader{debug: true}
+	reloader.applyLogLevel(&config.Config{LogLevel: "error"})
+	assert.Equal(t, slog.LevelDebug, logLevel.Level())
+}
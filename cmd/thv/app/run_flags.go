@@ -455,7 +455,8 @@ func setupTelemetryConfiguration(cmd *cobra.Command, runFlags *RunFlags, appConf
 		runFlags.OtelServiceName, finalTelemetry.OtelTracingEnabled, finalTelemetry.OtelMetricsEnabled,
 		finalTelemetry.OtelSamplingRate, runFlags.OtelHeaders, finalTelemetry.OtelInsecure,
 		finalTelemetry.OtelEnvironmentVariables, runFlags.OtelCustomAttributes,
-		finalTelemetry.OtelUseLegacyAttributes)
+		finalTelemetry.OtelUseLegacyAttributes, finalTelemetry.OtelHeaders, finalTelemetry.OtelCACertPath,
+		finalTelemetry.OtelSamplingOverrides, finalTelemetry.OtelPrometheusBasicAuth)
 }
 
 // setupRuntimeAndValidation creates container runtime and selects environment variable validator.
@@ -955,12 +956,13 @@ func getRemoteAuthFromRemoteServerMetadata(
 	}
 
 	authCfg := &remote.Config{
-		ClientID:     f.RemoteAuthClientID,
-		ClientSecret: clientSecret,
-		SkipBrowser:  f.RemoteAuthSkipBrowser,
-		Timeout:      f.RemoteAuthTimeout,
-		Headers:      remoteServerMetadata.Headers,
-		EnvVars:      remoteServerMetadata.EnvVars,
+		ClientID:      f.RemoteAuthClientID,
+		ClientSecret:  clientSecret,
+		SkipBrowser:   f.RemoteAuthSkipBrowser,
+		UseDeviceFlow: f.RemoteAuthDeviceFlow,
+		Timeout:       f.RemoteAuthTimeout,
+		Headers:       remoteServerMetadata.Headers,
+		EnvVars:       remoteServerMetadata.EnvVars,
 	}
 
 	// Scopes: CLI overrides if provided
@@ -1063,6 +1065,7 @@ func getRemoteAuthFromRunFlags(runFlags *RunFlags) (*remote.Config, error) {
 		Scopes:          runFlags.RemoteAuthFlags.RemoteAuthScopes,
 		ScopeParamName:  runFlags.RemoteAuthFlags.RemoteAuthScopeParamName,
 		SkipBrowser:     runFlags.RemoteAuthFlags.RemoteAuthSkipBrowser,
+		UseDeviceFlow:   runFlags.RemoteAuthFlags.RemoteAuthDeviceFlow,
 		Timeout:         runFlags.RemoteAuthFlags.RemoteAuthTimeout,
 		CallbackPort:    runFlags.RemoteAuthFlags.RemoteAuthCallbackPort,
 		Issuer:          runFlags.RemoteAuthFlags.RemoteAuthIssuer,
@@ -1099,6 +1102,14 @@ type finalTelemetry struct {
 	OtelUseLegacyAttributes         bool
 	OtelTracingEnabled              bool
 	OtelMetricsEnabled              bool
+	// OtelHeaders, OtelCACertPath, OtelSamplingOverrides and
+	// OtelPrometheusBasicAuth only come from the global config; OtelHeaders holds
+	// the configured headers with those read from header files, and header flags
+	// are merged over it when the telemetry config is built.
+	OtelHeaders             map[string]string
+	OtelCACertPath          string
+	OtelSamplingOverrides   map[string]float64
+	OtelPrometheusBasicAuth *cfg.PrometheusBasicAuthConfig
 }
 
 // getTelemetryFromFlags extracts telemetry configuration from command flags
@@ -1150,6 +1161,11 @@ func getTelemetryFromFlags(cmd *cobra.Command, config *cfg.Config, otelEndpoint
 		finalOtelUseLegacyAttributes = *config.OTEL.UseLegacyAttributes
 	}
 
+	otelHeaders, err := config.OTEL.ResolveHeaders()
+	if err != nil {
+		slog.Warn("Failed to resolve OTLP headers from files", "error", err)
+	}
+
 	return finalTelemetry{
 		OtelEndpoint:                    finalOtelEndpoint,
 		OtelSamplingRate:                finalOtelSamplingRate,
@@ -1159,6 +1175,10 @@ func getTelemetryFromFlags(cmd *cobra.Command, config *cfg.Config, otelEndpoint
 		OtelUseLegacyAttributes:         finalOtelUseLegacyAttributes,
 		OtelTracingEnabled:              finalOtelTracingEnabled,
 		OtelMetricsEnabled:              finalOtelMetricsEnabled,
+		OtelHeaders:                     otelHeaders,
+		OtelCACertPath:                  config.OTEL.CACertPath,
+		OtelSamplingOverrides:           config.OTEL.SamplingOverrides,
+		OtelPrometheusBasicAuth:         config.OTEL.PrometheusBasicAuth,
 	}
 }
 
@@ -1192,7 +1212,8 @@ func createOIDCConfig(oidcIssuer, oidcAudience, oidcJwksURL, oidcIntrospectionUR
 func createTelemetryConfig(otelEndpoint string, otelEnablePrometheusMetricsPath bool,
 	otelServiceName string, otelTracingEnabled bool, otelMetricsEnabled bool, otelSamplingRate float64, otelHeaders []string,
 	otelInsecure bool, otelEnvironmentVariables []string, otelCustomAttributes string,
-	otelUseLegacyAttributes bool) *telemetry.Config {
+	otelUseLegacyAttributes bool, otelConfigHeaders map[string]string, otelCACertPath string,
+	otelSamplingOverrides map[string]float64, otelPrometheusBasicAuth *cfg.PrometheusBasicAuthConfig) *telemetry.Config {
 	return runner.BuildTelemetryConfigFromAppConfig(
 		cfg.OpenTelemetryConfig{
 			Endpoint:                    otelEndpoint,
@@ -1203,6 +1224,10 @@ func createTelemetryConfig(otelEndpoint string, otelEnablePrometheusMetricsPath
 			Insecure:                    otelInsecure,
 			EnablePrometheusMetricsPath: otelEnablePrometheusMetricsPath,
 			UseLegacyAttributes:         &otelUseLegacyAttributes,
+			Headers:                     otelConfigHeaders,
+			CACertPath:                  otelCACertPath,
+			SamplingOverrides:           otelSamplingOverrides,
+			PrometheusBasicAuth:         otelPrometheusBasicAuth,
 		},
 		otelServiceName,
 		otelHeaders,
@@ -585,7 +585,7 @@ func TestCreateTelemetryConfig_DisabledSignals(t *testing.T) {
 			result := createTelemetryConfig(
 				tt.endpoint, tt.enablePrometheusMetricsPath,
 				"test-service", tt.tracingEnabled, tt.metricsEnabled,
-				1.0, nil, false, nil, "", true,
+				1.0, nil, false, nil, "", true, nil, "", nil, nil,
 			)
 
 			if tt.expectNil {
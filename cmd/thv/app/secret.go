@@ -59,7 +59,8 @@ making it suitable for scripted deployments and automation.
 		Valid secrets providers:
 		  - encrypted: Full read-write secrets provider using AES-256-GCM encryption
 		  - 1password: Read-only secrets provider (requires OP_SERVICE_ACCOUNT_TOKEN)
-		  - environment: Read-only secrets provider from TOOLHIVE_SECRET_* env vars`,
+		  - environment: Read-only secrets provider from TOOLHIVE_SECRET_* env vars
+		  - vault: Full read-write secrets provider backed by HashiCorp Vault KV v2 (requires VAULT_ADDR)`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			provider := args[0]
@@ -82,9 +83,11 @@ configuration and ensures the selected provider initializes properly.
 			  - %s: Stores secrets in an encrypted file using AES-256-GCM using the OS keyring
 			  - %s: Read-only access to 1Password secrets (requires OP_SERVICE_ACCOUNT_TOKEN environment variable)
 			  - %s: Read-only access to secrets from TOOLHIVE_SECRET_* env vars
+			  - %s: Stores secrets in a HashiCorp Vault KV v2 engine (requires VAULT_ADDR and VAULT_TOKEN, or Kubernetes auth)
 
 Run this command before using any other secrets functionality.`,
-			string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType)), //nolint:gofmt,gci
+			string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType),
+			string(secrets.VaultType)), //nolint:gofmt,gci
 		Args: cobra.NoArgs,
 		RunE: runSecretsSetup,
 	}
@@ -415,12 +418,15 @@ Please select a secrets provider:
   %s - Store secrets in an encrypted file (full read/write)
   %s - Use 1Password for secrets (read-only, requires service account)
   %s - Read secrets from environment variables
-`, string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType))
+  %s - Use HashiCorp Vault for secrets (full read/write)
+`, string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType),
+		string(secrets.VaultType))
 
 	var providerType secrets.ProviderType
 	for {
-		fmt.Printf("\nEnter provider (%s/%s/%s): ",
-			string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType))
+		fmt.Printf("\nEnter provider (%s/%s/%s/%s): ",
+			string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType),
+			string(secrets.VaultType))
 		input, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
@@ -434,9 +440,12 @@ Please select a secrets provider:
 			providerType = secrets.OnePasswordType
 		case string(secrets.EnvironmentType):
 			providerType = secrets.EnvironmentType
+		case string(secrets.VaultType):
+			providerType = secrets.VaultType
 		default:
-			fmt.Printf("Invalid provider. Please enter '%s', '%s', or '%s'.\n",
-				string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType))
+			fmt.Printf("Invalid provider. Please enter '%s', '%s', '%s', or '%s'.\n",
+				string(secrets.EncryptedType), string(secrets.OnePasswordType), string(secrets.EnvironmentType),
+				string(secrets.VaultType))
 			continue
 		}
 		break
@@ -463,6 +472,16 @@ For more information, visit: https://developer.1password.com/docs/service-accoun
 		fmt.Println(`Setting up environment variable secrets provider...
 	Secrets will be read from environment variables with the TOOLHIVE_SECRET_ prefix.
 	This provider is read-only and suitable for CI/CD and containerized environments.`)
+	case secrets.VaultType:
+		fmt.Println(`Setting up HashiCorp Vault secrets provider...
+
+To use Vault as your secrets provider, set the following environment variables:
+1. VAULT_ADDR to the address of your Vault server
+2. VAULT_TOKEN to a token that can read and write the KV v2 secrets engine,
+   or TOOLHIVE_VAULT_AUTH_METHOD=kubernetes and TOOLHIVE_VAULT_KUBERNETES_ROLE
+   to log in with the pod's service account
+3. Optionally, TOOLHIVE_VAULT_MOUNT (default: secret) and
+   TOOLHIVE_VAULT_PATH_PREFIX (default: toolhive) to choose where secrets are kept`)
 	}
 
 	// SetSecretsProvider will handle validation and configuration
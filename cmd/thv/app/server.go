@@ -38,11 +38,16 @@ var ApplyServerExtensions func(*s.ServerBuilder)
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the ToolHive API server",
-	Long:  `Starts the ToolHive API server and listen for HTTP requests.`,
+	Long: `Starts the ToolHive API server and listen for HTTP requests.
+
+Send SIGHUP to the server to reload the ToolHive configuration (the registry and
+log_level) without restarting it. An invalid configuration is logged and ignored.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		// Ensure server is shutdown gracefully on Ctrl+C or SIGTERM.
 		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 		defer cancel()
+		// Reload the configuration on SIGHUP instead of exiting
+		newConfigReloader().handleSIGHUP(ctx)
 
 		// Get debug mode flag
 		debugMode, _ := cmd.Flags().GetBool("debug")
@@ -140,6 +145,14 @@ var serveCmd = &cobra.Command{
 			WithOIDCConfig(oidcConfig).
 			WithOtelEnabled(otelEnabled)
 
+		// Serve the OTEL metrics in Prometheus format when enabled with
+		// thv config otel set-enable-prometheus-metrics-path.
+		if otelProvider != nil {
+			if handler := otelProvider.PrometheusHandler(); handler != nil {
+				builder.WithRoute("/metrics", handler)
+			}
+		}
+
 		if ApplyServerExtensions != nil {
 			ApplyServerExtensions(builder)
 		}
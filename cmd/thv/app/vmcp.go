@@ -49,7 +49,8 @@ func newVMCPServeCommand() *cobra.Command {
 
 The server reads the configuration file specified by --config and starts
 listening for MCP client connections, aggregating tools, resources, and
-prompts from all configured backend MCP servers.
+prompts from all configured backend MCP servers. Use --config - to read the
+configuration from stdin, e.g. when it is generated by a templating pipeline.
 
 When --config is omitted, --group enables zero-config quick mode: a minimal
 in-memory configuration is generated from the named ToolHive group, so no
@@ -58,6 +59,7 @@ configuration file is needed for the common case of aggregating a local group.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return vmcpcli.Serve(cmd.Context(), vmcpcli.ServeConfig{
 				ConfigPath:      configPath,
+				Stdin:           cmd.InOrStdin(),
 				GroupRef:        group,
 				Host:            host,
 				Port:            port,
@@ -70,7 +72,7 @@ configuration file is needed for the common case of aggregating a local group.`,
 			})
 		},
 	}
-	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to vMCP configuration file")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to vMCP configuration file, or - to read it from stdin")
 	cmd.Flags().StringVar(&group, "group", "", "ToolHive group name (zero-config quick mode when --config is omitted)")
 	cmd.Flags().BoolVar(&enableOptimizer, "optimizer", false,
 		"Enable FTS5 keyword optimizer (Tier 1): exposes find_tool and call_tool instead of all backend tools")
@@ -136,15 +138,17 @@ func newVMCPValidateCommand() *cobra.Command {
 
 This command checks YAML syntax, required field presence, middleware
 configuration correctness, and backend configuration validity. Exits 0
-for valid configurations, non-zero with a descriptive error otherwise.`,
+for valid configurations, non-zero with a descriptive error otherwise.
+Use --config - to validate a configuration read from stdin.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return vmcpcli.Validate(cmd.Context(), vmcpcli.ValidateConfig{
 				ConfigPath: configPath,
+				Stdin:      cmd.InOrStdin(),
 			})
 		},
 	}
-	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to vMCP configuration file (required)")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to vMCP configuration file, or - to read it from stdin (required)")
 	_ = cmd.MarkFlagRequired("config")
 	return cmd
 }
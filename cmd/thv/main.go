@@ -10,9 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	"github.com/adrg/xdg"
 	"github.com/spf13/viper"
 
 	"github.com/stacklok/toolhive-core/logging"
@@ -54,17 +52,11 @@ func main() {
 
 	// Skip migrations for informational commands that don't need container runtime
 	if !app.IsInformationalCommand(os.Args) {
-		// Check and perform telemetry config migration if needed
-		// Converts telemetry_config.samplingRate from float64 to string in run configs
-		migration.CheckAndPerformTelemetryConfigMigration()
-
-		// Check and perform middleware telemetry migration if needed
-		// Ensures middleware-based telemetry configs are properly migrated
-		migration.CheckAndPerformMiddlewareTelemetryMigration()
-
-		// Check and perform secret scope migration if needed
-		// Renames bare system keys (BEARER_TOKEN_, REGISTRY_OAUTH_, etc.) to __thv_<scope>_ namespace
-		migration.CheckAndPerformSecretScopeMigration()
+		// Apply pending migrations, in order. A failed migration is retried
+		// on the next start and does not prevent the command from running.
+		if err := migration.RunStartupMigrations(ctx); err != nil {
+			slog.Error("failed to run migrations", "error", err)
+		}
 
 		// Ensure the default group exists on fresh installs so that commands
 		// which default to --group default (e.g. run, list) work without the
@@ -106,24 +98,5 @@ func setupSignalHandler() context.Context {
 
 // cleanupStaleLockFiles removes stale lock files from known directories on startup
 func cleanupStaleLockFiles() {
-	// Common directories where lock files are created
-	var directories []string
-
-	// Config directory
-	if configDir, err := xdg.ConfigFile("toolhive"); err == nil {
-		directories = append(directories, configDir)
-	}
-
-	// Data directory (for statuses and updates)
-	if dataDir, err := xdg.DataFile("toolhive"); err == nil {
-		directories = append(directories, dataDir)
-
-		// Specific subdirectories
-		if statusDir, err := xdg.DataFile("toolhive/statuses"); err == nil {
-			directories = append(directories, statusDir)
-		}
-	}
-
-	// Clean up lock files older than 5 minutes (should be safe for most operations)
-	lockfile.CleanupStaleLocks(directories, 5*time.Minute)
+	lockfile.CleanupStaleLocks(lockfile.DefaultDirectories(), lockfile.DefaultMaxAge)
 }
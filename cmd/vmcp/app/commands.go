@@ -7,6 +7,7 @@ package app
 import (
 	"fmt"
 	"log/slog"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -61,7 +62,7 @@ func NewRootCmd() *cobra.Command {
 		slog.Error(fmt.Sprintf("Error binding debug flag: %v", err))
 	}
 
-	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to vMCP configuration file")
+	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to vMCP configuration file, or - to read it from stdin")
 	err = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	if err != nil {
 		slog.Error(fmt.Sprintf("Error binding config flag: %v", err))
@@ -71,6 +72,8 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(newServeCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newValidateCmd())
+	rootCmd.AddCommand(newCapabilitiesCmd())
+	rootCmd.AddCommand(newWorkflowLogsCmd())
 
 	// Silence printing the usage on error
 	rootCmd.SilenceUsage = true
@@ -87,7 +90,8 @@ func newServeCmd() *cobra.Command {
 
 The server will read the configuration file specified by --config flag and start
 listening for MCP client connections. It will aggregate tools, resources, and prompts
-from all configured backend MCP servers.`,
+from all configured backend MCP servers. Use --config - to read the configuration
+from stdin, e.g. when it is generated by a templating pipeline.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			configPath := viper.GetString("config")
 			if configPath == "" {
@@ -101,6 +105,7 @@ from all configured backend MCP servers.`,
 
 			return vmcpcli.Serve(cmd.Context(), vmcpcli.ServeConfig{
 				ConfigPath:  configPath,
+				Stdin:       cmd.InOrStdin(),
 				Host:        host,
 				Port:        port,
 				EnableAudit: enableAudit,
@@ -150,7 +155,95 @@ This command checks:
 			}
 			return vmcpcli.Validate(cmd.Context(), vmcpcli.ValidateConfig{
 				ConfigPath: configPath,
+				Stdin:      cmd.InOrStdin(),
 			})
 		},
 	}
 }
+
+// newCapabilitiesCmd creates the capabilities command for inspecting the
+// aggregated capabilities of a configuration
+func newCapabilitiesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "capabilities [config.yaml]",
+		Short: "Show the aggregated capabilities of a configuration",
+		Long: `Load the vMCP configuration, discover its backends, and run capability
+aggregation without starting the server.
+
+The tools, resources, and prompts the vMCP would expose are printed with the
+backend each one routes to, followed by any tool name conflicts between
+backends and how they were resolved. Use this to verify aggregation before
+deploying.
+
+The configuration file is taken from the argument, or from --config.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := viper.GetString("config")
+			if len(args) == 1 {
+				configPath = args[0]
+			}
+			if configPath == "" {
+				return fmt.Errorf("no configuration file specified, pass it as an argument or use --config flag")
+			}
+
+			asJSON, _ := cmd.Flags().GetBool("json")
+			noConnect, _ := cmd.Flags().GetBool("no-connect")
+
+			return vmcpcli.Capabilities(cmd.Context(), vmcpcli.CapabilitiesConfig{
+				ConfigPath: configPath,
+				JSON:       asJSON,
+				NoConnect:  noConnect,
+				Out:        cmd.OutOrStdout(),
+			})
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "Print the capabilities as JSON")
+	cmd.Flags().Bool("no-connect", false, "Only validate the configuration, without contacting any backend")
+
+	return cmd
+}
+
+// newWorkflowLogsCmd creates the workflow-logs command for tailing the step
+// events of a composite tool workflow on a running server
+func newWorkflowLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workflow-logs [workflow-id]",
+		Short: "Tail the step events of a running composite tool workflow",
+		Long: `Stream the step-by-step execution of a composite tool workflow running on a
+vMCP server. Each step reports when it starts, the arguments it is called with,
+its output, and whether it failed or was skipped. The stream ends when the
+workflow finishes. Steps that already ran are shown first.
+
+Without a workflow ID, the IDs of the running workflows are listed.
+
+The server exposes workflow events through its admin API, which requires
+incoming authentication and a token with the vmcp:admin scope. Step arguments
+and outputs are redacted with the audit redactFields configuration.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowID := ""
+			if len(args) == 1 {
+				workflowID = args[0]
+			}
+			serverURL, _ := cmd.Flags().GetString("url")
+			token, _ := cmd.Flags().GetString("token")
+			asJSON, _ := cmd.Flags().GetBool("json")
+
+			return vmcpcli.WorkflowLogs(cmd.Context(), vmcpcli.WorkflowLogsConfig{
+				URL:        serverURL,
+				WorkflowID: workflowID,
+				Token:      token,
+				JSON:       asJSON,
+				Out:        cmd.OutOrStdout(),
+			})
+		},
+	}
+
+	cmd.Flags().String("url", "http://127.0.0.1:4483", "Base URL of the running vMCP server")
+	cmd.Flags().String("token", os.Getenv("VMCP_ADMIN_TOKEN"),
+		"Bearer token with the vmcp:admin scope (default from VMCP_ADMIN_TOKEN)")
+	cmd.Flags().Bool("json", false, "Print the step events as newline-delimited JSON")
+
+	return cmd
+}
@@ -188,7 +188,7 @@ const docTemplate = `{
                         "type": "boolean"
                     },
                     "eventTypes": {
-                        "description": "EventTypes specifies which event types to audit. If empty, all events are audited.\n+optional",
+                        "description": "EventTypes specifies which event types to audit. If empty, all events are audited\nexcept the vMCP dispatch events (vmcp_tool_call, vmcp_resource_read, vmcp_prompt_get),\nwhich repeat what the MCP request events already record and must be listed explicitly.\n+optional",
                         "items": {
                             "type": "string"
                         },
@@ -203,6 +203,22 @@ const docTemplate = `{
                         "type": "array",
                         "uniqueItems": false
                     },
+                    "excludeOutcomes": {
+                        "description": "ExcludeOutcomes specifies which event outcomes to exclude from auditing.\nThis takes precedence over Outcomes.\n+optional",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "excludeToolNames": {
+                        "description": "ExcludeToolNames lists glob patterns for the tools whose calls are not audited.\nThis takes precedence over ToolNames.\n+optional",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
                     "includeRequestData": {
                         "description": "IncludeRequestData determines whether to include request data in audit logs.\n+kubebuilder:default=false\n+optional",
                         "type": "boolean"
@@ -218,6 +234,42 @@ const docTemplate = `{
                     "maxDataSize": {
                         "description": "MaxDataSize limits the size of request/response data included in audit logs (in bytes).\n+kubebuilder:default=1024\n+optional",
                         "type": "integer"
+                    },
+                    "maxLogFileAgeDays": {
+                        "description": "MaxLogFileAgeDays is the number of days to keep rotated log files. Older ones\nare removed on rotation. Zero keeps them regardless of age.\n+kubebuilder:validation:Minimum=0\n+optional",
+                        "type": "integer"
+                    },
+                    "maxLogFileBackups": {
+                        "description": "MaxLogFileBackups is the number of rotated log files to keep. Older ones are\nremoved on rotation. Zero keeps all of them.\n+kubebuilder:validation:Minimum=0\n+optional",
+                        "type": "integer"
+                    },
+                    "maxLogFileSizeMB": {
+                        "description": "MaxLogFileSizeMB is the size in megabytes at which LogFile is rotated: it is\nrenamed with a timestamp suffix and a new file is started. Zero disables rotation.\n+kubebuilder:validation:Minimum=0\n+optional",
+                        "type": "integer"
+                    },
+                    "outcomes": {
+                        "description": "Outcomes specifies which event outcomes to audit: success, failure, error,\ndenied or application_error. If empty, events of every outcome are audited.\n+optional",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "redactFields": {
+                        "description": "RedactFields lists field names whose values are replaced with \"[REDACTED]\"\nin request data included in audit logs. Matching is case-insensitive and\napplies at any nesting depth, e.g. \"password\" redacts a tool argument\nnamed \"password\" as well as a nested \"Password\" field.\n+optional",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "toolNames": {
+                        "description": "ToolNames lists glob patterns, e.g. \"github_*\", for the tools whose calls are\naudited. It only applies to tool call events (mcp_tool_call, vmcp_tool_call);\nother events are not affected. If empty, calls to every tool are audited.\n+optional",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
                     }
                 },
                 "type": "object"
@@ -584,6 +636,10 @@ const docTemplate = `{
                         "type": "array",
                         "uniqueItems": false
                     },
+                    "fallback_key_grace_period": {
+                        "description": "FallbackKeyGracePeriod is how long fallback keys stay in the JWKS endpoint after\nthe signing key file was last modified, as a Go duration string (e.g., \"2h\"). Set it\nto at least the access token lifespan. If empty, fallback keys are published until removed.",
+                        "type": "string"
+                    },
                     "key_dir": {
                         "description": "KeyDir is the directory containing PEM-encoded private key files.\nAll key filenames are relative to this directory.\nIn Kubernetes, this is typically a mounted Secret volume.",
                         "type": "string"
@@ -963,6 +1019,10 @@ const docTemplate = `{
                         "description": "DEPRECATED: Middleware configuration.\nAuthzConfigPath is the path to the authorization configuration file",
                         "type": "string"
                     },
+                    "authz_policy_path": {
+                        "description": "AuthzPolicyPath is the path to an authorization configuration file whose\nCedar policies and entities are enforced on top of AuthzConfig, and\nreloaded without a restart whenever the file changes",
+                        "type": "string"
+                    },
                     "aws_sts_config": {
                         "$ref": "#/components/schemas/github_com_stacklok_toolhive_pkg_auth_awssts.Config"
                     },
@@ -2334,6 +2394,24 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "pkg_api_v1.WorkloadEvent": {
+                "description": "A change to a workload, streamed by the workload watch endpoint",
+                "properties": {
+                    "type": {
+                        "description": "Type is what happened to the workload",
+                        "enum": [
+                            "added",
+                            "modified",
+                            "deleted"
+                        ],
+                        "type": "string"
+                    },
+                    "workload": {
+                        "$ref": "#/components/schemas/core.Workload"
+                    }
+                },
+                "type": "object"
+            },
             "pkg_api_v1.buildListResponse": {
                 "description": "Response containing a list of locally-built OCI skill artifacts",
                 "properties": {
@@ -2638,6 +2716,23 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "pkg_api_v1.exportSecretsResponse": {
+                "description": "Response containing the exported secrets",
+                "properties": {
+                    "redacted": {
+                        "description": "Whether the values were replaced with a placeholder",
+                        "type": "boolean"
+                    },
+                    "secrets": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "description": "Secret values keyed by secret name",
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
             "pkg_api_v1.getRegistryResponse": {
                 "description": "Response containing registry details",
                 "properties": {
@@ -2747,6 +2842,49 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "pkg_api_v1.importSecretsRequest": {
+                "description": "Request to import secrets in bulk",
+                "properties": {
+                    "overwrite": {
+                        "description": "Replace secrets that already exist instead of skipping them",
+                        "type": "boolean"
+                    },
+                    "secrets": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "description": "Secret values keyed by secret name",
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
+            "pkg_api_v1.importSecretsResponse": {
+                "description": "Response after importing secrets in bulk",
+                "properties": {
+                    "failed": {
+                        "description": "Secret key whose check or write failed; the import stopped there",
+                        "type": "string"
+                    },
+                    "imported": {
+                        "description": "Secret keys that were written",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "skipped": {
+                        "description": "Secret keys that already existed and were left unchanged",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "pkg_api_v1.installSkillRequest": {
                 "description": "Request to install a skill",
                 "properties": {
@@ -3087,7 +3225,7 @@ const docTemplate = `{
                         "type": "string"
                     },
                     "provider_type": {
-                        "description": "Type of the secrets provider (encrypted, 1password, environment)",
+                        "description": "Type of the secrets provider (encrypted, 1password, environment, vault)",
                         "type": "string"
                     }
                 },
@@ -3431,6 +3569,10 @@ const docTemplate = `{
             "pkg_api_v1.workloadListResponse": {
                 "description": "Response containing a list of workloads",
                 "properties": {
+                    "continue": {
+                        "description": "Continue is the token to pass as the continue parameter to fetch the\nnext page. Empty when there are no more workloads.",
+                        "type": "string"
+                    },
                     "workloads": {
                         "description": "List of container information for each workload",
                         "items": {
@@ -4164,6 +4306,10 @@ const docTemplate = `{
                     "token_url": {
                         "type": "string"
                     },
+                    "use_device_flow": {
+                        "description": "UseDeviceFlow selects the OAuth 2.0 Device Authorization Grant (RFC 8628)\ninstead of the browser-based flow. The device flow is also used\nautomatically when no browser is available and the authorization server\nsupports it.",
+                        "type": "boolean"
+                    },
                     "use_pkce": {
                         "type": "boolean"
                     }
@@ -4235,6 +4381,14 @@ const docTemplate = `{
                     "tls": {
                         "$ref": "#/components/schemas/storage.RedisTLSRunConfig"
                     },
+                    "upstream_token_encryption_key_files": {
+                        "description": "UpstreamTokenEncryptionKeyFiles are paths to secrets used to encrypt\nupstream IDP tokens at rest. The first file is the current key; the\nremaining files are previous keys kept to decrypt entries written before\na rotation. When empty, upstream tokens are stored unencrypted.",
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
                     "write_timeout": {
                         "description": "WriteTimeout is the timeout for write operations (e.g., \"3s\").",
                         "type": "string"
@@ -4336,6 +4490,16 @@ const docTemplate = `{
                         "description": "MetricsEnabled controls whether OTLP metrics are enabled.\nWhen false, OTLP metrics are not sent even if an endpoint is configured.\nThis is independent of EnablePrometheusMetricsPath.\n+kubebuilder:default=false\n+optional",
                         "type": "boolean"
                     },
+                    "prometheusBasicAuth": {
+                        "$ref": "#/components/schemas/telemetry.PrometheusBasicAuth"
+                    },
+                    "samplingOverrides": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "description": "SamplingOverrides maps operation names to a trace sampling rate (0.0-1.0, as a\nstring) used for them instead of SamplingRate, e.g. to sample every\n\"tools/call\" span but only a few \"ping\" spans. A key matches spans with that\nexact name and MCP spans for that method, which are named\n\"{mcp.method.name} {target}\"; the most specific key wins.\nSpans whose parent is sampled are always sampled.\nExample: {\"tools/call\": \"1.0\", \"ping\": \"0.01\"}\n+optional",
+                        "type": "object"
+                    },
                     "samplingRate": {
                         "description": "SamplingRate is the trace sampling rate (0.0-1.0) as a string.\nOnly used when TracingEnabled is true.\nExample: \"0.05\" for 5% sampling.\n+kubebuilder:default=\"0.05\"\n+optional",
                         "type": "string"
@@ -4359,6 +4523,20 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "telemetry.PrometheusBasicAuth": {
+                "description": "PrometheusBasicAuth, when set, requires HTTP basic authentication on the\nPrometheus /metrics endpoint. Only used when EnablePrometheusMetricsPath is true.\n+optional",
+                "properties": {
+                    "passwordFile": {
+                        "description": "PasswordFile is the path to a file containing the password scrapers must\npresent. Leading and trailing whitespace in the file is ignored.\n+kubebuilder:validation:MinLength=1",
+                        "type": "string"
+                    },
+                    "username": {
+                        "description": "Username is the user name scrapers must present.\n+kubebuilder:validation:MinLength=1",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "templates.RuntimeConfig": {
                 "description": "RuntimeConfig allows overriding the default runtime configuration\nfor this specific workload (base images and packages)",
                 "properties": {
@@ -4391,6 +4569,14 @@ const docTemplate = `{
                         "description": "Audience is the target audience for the exchanged token",
                         "type": "string"
                     },
+                    "client_assertion_key_file": {
+                        "description": "ClientAssertionKeyFile is the path to a PEM-encoded private key used to\nauthenticate the client with a signed JWT (private_key_jwt, RFC 7523)\ninstead of a client secret. If empty, the key is read from the\nEnvClientAssertionKey environment variable when set.",
+                        "type": "string"
+                    },
+                    "client_assertion_key_id": {
+                        "description": "ClientAssertionKeyID is the key ID sent in the \"kid\" header of client assertions",
+                        "type": "string"
+                    },
                     "client_id": {
                         "description": "ClientID is the OAuth 2.0 client identifier",
                         "type": "string"
@@ -5440,7 +5626,7 @@ const docTemplate = `{
         },
         "/api/v1beta/registry/{name}/servers": {
             "get": {
-                "description": "Get a list of servers in a specific registry",
+                "description": "Get a list of servers in a specific registry, optionally filtered and sorted",
                 "parameters": [
                     {
                         "description": "Registry name",
@@ -5450,6 +5636,51 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Filter by server name substring (case-insensitive)",
+                        "in": "query",
+                        "name": "q",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Filter by tag; repeat to require several tags",
+                        "in": "query",
+                        "name": "tag",
+                        "schema": {
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        }
+                    },
+                    {
+                        "description": "Filter by transport type",
+                        "in": "query",
+                        "name": "transport",
+                        "schema": {
+                            "enum": [
+                                "stdio",
+                                "sse",
+                                "streamable-http"
+                            ],
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Sort order (default: name)",
+                        "in": "query",
+                        "name": "sort",
+                        "schema": {
+                            "enum": [
+                                "name",
+                                "-name",
+                                "stars"
+                            ],
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
@@ -5463,6 +5694,16 @@ const docTemplate = `{
                         },
                         "description": "OK"
                     },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -5633,6 +5874,158 @@ const docTemplate = `{
                 ]
             }
         },
+        "/api/v1beta/secrets/default/export": {
+            "get": {
+                "description": "Export all secrets from the default provider, with values redacted unless plaintext is true",
+                "parameters": [
+                    {
+                        "description": "Return secret values instead of redacting them",
+                        "in": "query",
+                        "name": "plaintext",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/pkg_api_v1.exportSecretsResponse"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Not Found - Provider not setup"
+                    },
+                    "405": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Method Not Allowed - Provider doesn't support listing or reading"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
+                    }
+                },
+                "summary": "Export secrets",
+                "tags": [
+                    "secrets"
+                ]
+            }
+        },
+        "/api/v1beta/secrets/default/import": {
+            "post": {
+                "description": "Create secrets in the default provider from a map of keys to values, skipping existing ones unless overwrite is set",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/pkg_api_v1.importSecretsRequest",
+                                        "summary": "request",
+                                        "description": "Import secrets request"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Import secrets request",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/pkg_api_v1.importSecretsResponse"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Not Found - Provider not setup"
+                    },
+                    "405": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Method Not Allowed - Provider doesn't support writing"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/pkg_api_v1.importSecretsResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error - Import stopped at a failed check or write; lists the secrets already written"
+                    }
+                },
+                "summary": "Import secrets",
+                "tags": [
+                    "secrets"
+                ]
+            }
+        },
         "/api/v1beta/secrets/default/keys": {
             "get": {
                 "description": "Get a list of all secret keys from the default provider",
@@ -6873,6 +7266,22 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Maximum number of workloads to return (default: all)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Continue token from the previous page's response",
+                        "in": "query",
+                        "name": "continue",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
@@ -6886,6 +7295,16 @@ const docTemplate = `{
                         },
                         "description": "OK"
                     },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Invalid limit or continue token"
+                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -7174,6 +7593,65 @@ const docTemplate = `{
                 ]
             }
         },
+        "/api/v1beta/workloads/watch": {
+            "get": {
+                "description": "Stream workload changes as newline-delimited JSON, starting with every existing workload as added",
+                "parameters": [
+                    {
+                        "description": "Include stopped workloads",
+                        "in": "query",
+                        "name": "all",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Only watch workloads in this group",
+                        "in": "query",
+                        "name": "group",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/x-ndjson": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/pkg_api_v1.WorkloadEvent"
+                                }
+                            }
+                        },
+                        "description": "Stream of workload events, one JSON object per line"
+                    },
+                    "400": {
+                        "content": {
+                            "application/x-ndjson": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Invalid group name"
+                    },
+                    "404": {
+                        "content": {
+                            "application/x-ndjson": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "Group not found"
+                    }
+                },
+                "summary": "Watch workloads",
+                "tags": [
+                    "workloads"
+                ]
+            }
+        },
         "/api/v1beta/workloads/{name}": {
             "delete": {
                 "description": "Delete a workload asynchronously. Returns 202 Accepted immediately.\nThe deletion happens in the background. Poll the workload list to confirm deletion.",
@@ -444,6 +444,9 @@ type Server struct {
 	addrType     string
 	nonce        string
 	storeCloser  io.Closer
+
+	// registryWatcher reloads the registry when a local registry file changes.
+	registryWatcher io.Closer
 }
 
 // NewServer creates a new Server instance from a pre-configured builder
@@ -503,6 +506,14 @@ func (s *Server) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Reload the registry in place when a local registry file is edited, so
+	// hand-maintained registries don't require a server restart.
+	if watcher, err := registry.WatchRegistryFile(config.NewDefaultProvider()); err != nil {
+		slog.Warn("failed to watch registry file, changes will require a restart", "error", err)
+	} else if watcher != nil {
+		s.registryWatcher = watcher
+	}
+
 	// Start server in a goroutine
 	serverErr := make(chan error, 1)
 	go func() {
@@ -602,6 +613,11 @@ func (s *Server) cleanup() {
 			slog.Warn("failed to remove discovery file", "error", err)
 		}
 	}
+	if s.registryWatcher != nil {
+		if err := s.registryWatcher.Close(); err != nil {
+			slog.Warn("failed to stop registry file watcher", "error", err)
+		}
+	}
 	if s.storeCloser != nil {
 		if err := s.storeCloser.Close(); err != nil {
 			slog.Warn("failed to close skill store", "error", err)
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/stacklok/toolhive-core/permissions"
+)
+
+// validatePermissionProfile checks a permission profile for entries that are
+// malformed or contradict each other. Rather than stopping at the first
+// problem it reports all of them, joined into one error, so that a caller can
+// fix the whole profile in one go. Problems refer to fields by their JSON
+// names, e.g. network.outbound.allow_port[1].
+//
+// A nil profile is valid; the workload then gets the default profile.
+func validatePermissionProfile(profile *permissions.Profile) error {
+	if profile == nil {
+		return nil
+	}
+
+	errs := validateProfileMounts(profile)
+	errs = append(errs, validateProfileNetwork(profile.Network)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid permission profile: %w", errors.Join(errs...))
+}
+
+// profileMount is a parsed read or write mount declaration.
+type profileMount struct {
+	field  string
+	source string
+}
+
+// validateProfileMounts reports mount declarations that cannot be parsed and
+// container paths that are mounted more than once in conflicting ways.
+func validateProfileMounts(profile *permissions.Profile) []error {
+	var errs []error
+	mounts := make(map[string]profileMount)
+
+	for _, list := range []struct {
+		name         string
+		declarations []permissions.MountDeclaration
+	}{
+		{"read", profile.Read},
+		{"write", profile.Write},
+	} {
+		for i, declaration := range list.declarations {
+			field := fmt.Sprintf("%s[%d]", list.name, i)
+			source, target, err := declaration.Parse()
+			if err != nil {
+				errs = append(errs, fmt.Errorf(
+					"%s %q is not a valid mount: %w; use a path, host-path:container-path or resource-uri:container-path",
+					field, declaration, err))
+				continue
+			}
+
+			previous, ok := mounts[target]
+			switch {
+			case !ok:
+				mounts[target] = profileMount{field: field, source: source}
+			case !strings.HasPrefix(previous.field, list.name+"["):
+				errs = append(errs, fmt.Errorf(
+					"container path %q is both read-only (%s) and writable (%s); remove it from one of the lists",
+					target, previous.field, field))
+			case previous.source != source:
+				errs = append(errs, fmt.Errorf(
+					"container path %q is mounted from both %q (%s) and %q (%s); mount each container path only once",
+					target, previous.source, previous.field, source, field))
+			}
+		}
+	}
+	return errs
+}
+
+// validateProfileNetwork reports malformed hosts and ports and network rules
+// that contradict each other.
+func validateProfileNetwork(network *permissions.NetworkPermissions) []error {
+	if network == nil {
+		return nil
+	}
+
+	var errs []error
+	if outbound := network.Outbound; outbound != nil {
+		if outbound.InsecureAllowAll && (len(outbound.AllowHost) > 0 || len(outbound.AllowPort) > 0) {
+			errs = append(errs, errors.New(
+				"network.outbound.insecure_allow_all permits every destination, so allow_host and allow_port "+
+					"have no effect; remove them or disable insecure_allow_all"))
+		}
+		errs = append(errs, validateProfileHosts("network.outbound.allow_host", outbound.AllowHost)...)
+		for i, port := range outbound.AllowPort {
+			if port < 1 || port > 65535 {
+				errs = append(errs, fmt.Errorf(
+					"network.outbound.allow_port[%d] %d is not a valid port; use a number between 1 and 65535", i, port))
+			}
+		}
+	}
+	if inbound := network.Inbound; inbound != nil {
+		errs = append(errs, validateProfileHosts("network.inbound.allow_host", inbound.AllowHost)...)
+	}
+
+	if network.Mode == "none" && allowsNetworkTraffic(network) {
+		errs = append(errs, errors.New(
+			"network.mode \"none\" disables networking, so the outbound and inbound rules can never apply; "+
+				"remove them or choose another network mode"))
+	}
+	return errs
+}
+
+// validateProfileHosts reports allow_host entries that are not bare host
+// names, wildcard domains or IP addresses.
+func validateProfileHosts(field string, hosts []string) []error {
+	var errs []error
+	for i, host := range hosts {
+		var problem string
+		switch {
+		case strings.TrimSpace(host) == "":
+			problem = "is empty"
+		case strings.Contains(host, "://"):
+			problem = "must not include a scheme"
+		case strings.ContainsAny(host, "/?#"):
+			problem = "must not include a path"
+		case strings.ContainsAny(host, " \t\r\n"):
+			problem = "must not contain whitespace"
+		case strings.Contains(host, ":") && net.ParseIP(host) == nil:
+			problem = "must not include a port; list ports in network.outbound.allow_port instead"
+		default:
+			continue
+		}
+		errs = append(errs, fmt.Errorf(
+			"%s[%d] %q %s; use a host name such as api.github.com or .github.com for a domain and its subdomains",
+			field, i, host, problem))
+	}
+	return errs
+}
+
+// allowsNetworkTraffic reports whether network has any rule granting traffic.
+func allowsNetworkTraffic(network *permissions.NetworkPermissions) bool {
+	if outbound := network.Outbound; outbound != nil &&
+		(outbound.InsecureAllowAll || len(outbound.AllowHost) > 0 || len(outbound.AllowPort) > 0) {
+		return true
+	}
+	return network.Inbound != nil && len(network.Inbound.AllowHost) > 0
+}
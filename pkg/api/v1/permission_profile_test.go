@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-core/httperr"
+	"github.com/stacklok/toolhive-core/permissions"
+)
+
+// profileProblems returns the individual problems joined into err.
+func profileProblems(t *testing.T, err error) []string {
+	t.Helper()
+
+	joined, ok := errors.Unwrap(err).(interface{ Unwrap() []error })
+	require.True(t, ok, "expected the problems to be joined into one error, got %v", err)
+
+	var problems []string
+	for _, e := range joined.Unwrap() {
+		problems = append(problems, e.Error())
+	}
+	return problems
+}
+
+func TestValidatePermissionProfile_Valid(t *testing.T) {
+	t.Parallel()
+
+	for name, profile := range map[string]*permissions.Profile{
+		"nil":                nil,
+		"none":               permissions.BuiltinNoneProfile(),
+		"network":            permissions.BuiltinNetworkProfile(),
+		"no network section": {Read: []permissions.MountDeclaration{"/etc/ssl"}},
+		"restricted": {
+			Read:  []permissions.MountDeclaration{"/etc/ssl", "/home/user/data:/data"},
+			Write: []permissions.MountDeclaration{"/tmp/cache:/cache"},
+			Network: &permissions.NetworkPermissions{
+				Outbound: &permissions.OutboundNetworkPermissions{
+					AllowHost: []string{"api.github.com", ".googleapis.com", "10.0.0.1", "::1"},
+					AllowPort: []int{443, 8443},
+				},
+				Inbound: &permissions.InboundNetworkPermissions{AllowHost: []string{"localhost"}},
+			},
+		},
+		"isolated": {
+			Network: &permissions.NetworkPermissions{Mode: "none"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.NoError(t, validatePermissionProfile(profile))
+		})
+	}
+}
+
+func TestValidatePermissionProfile_ReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		profile  *permissions.Profile
+		problems []string
+	}{
+		{
+			name: "filesystem rules",
+			profile: &permissions.Profile{
+				Read: []permissions.MountDeclaration{
+					"/data",
+					"/home/a:/shared",
+					"/a:/b:/c",
+				},
+				Write: []permissions.MountDeclaration{
+					"/data",
+					"/home/b:/shared",
+				},
+			},
+			problems: []string{
+				`read[2] "/a:/b:/c" is not a valid mount`,
+				`container path "/data" is both read-only (read[0]) and writable (write[0])`,
+				`container path "/shared" is both read-only (read[1]) and writable (write[1])`,
+			},
+		},
+		{
+			name: "same container path from two sources",
+			profile: &permissions.Profile{
+				Write: []permissions.MountDeclaration{"/home/a:/workspace", "/home/b:/workspace"},
+			},
+			problems: []string{
+				`container path "/workspace" is mounted from both "/home/a" (write[0]) and "/home/b" (write[1])`,
+			},
+		},
+		{
+			name: "network rules",
+			profile: &permissions.Profile{
+				Network: &permissions.NetworkPermissions{
+					Outbound: &permissions.OutboundNetworkPermissions{
+						AllowHost: []string{"https://api.github.com", "example.com:443", "", "example.com/path"},
+						AllowPort: []int{443, 0, 70000},
+					},
+					Inbound: &permissions.InboundNetworkPermissions{AllowHost: []string{"bad host"}},
+				},
+			},
+			problems: []string{
+				`network.outbound.allow_host[0] "https://api.github.com" must not include a scheme`,
+				`network.outbound.allow_host[1] "example.com:443" must not include a port`,
+				`network.outbound.allow_host[2] "" is empty`,
+				`network.outbound.allow_host[3] "example.com/path" must not include a path`,
+				`network.outbound.allow_port[1] 0 is not a valid port`,
+				`network.outbound.allow_port[2] 70000 is not a valid port`,
+				`network.inbound.allow_host[0] "bad host" must not contain whitespace`,
+			},
+		},
+		{
+			name: "conflicting allow rules",
+			profile: &permissions.Profile{
+				Network: &permissions.NetworkPermissions{
+					Mode: "none",
+					Outbound: &permissions.OutboundNetworkPermissions{
+						InsecureAllowAll: true,
+						AllowHost:        []string{"api.github.com"},
+					},
+				},
+			},
+			problems: []string{
+				"network.outbound.insecure_allow_all permits every destination, so allow_host and allow_port have no effect",
+				`network.mode "none" disables networking, so the outbound and inbound rules can never apply`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validatePermissionProfile(tt.profile)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "invalid permission profile")
+
+			problems := profileProblems(t, err)
+			require.Len(t, problems, len(tt.problems), "problems: %q", problems)
+			for i, want := range tt.problems {
+				assert.Contains(t, problems[i], want)
+			}
+		})
+	}
+}
+
+func TestBuildFullRunConfig_RejectsInvalidPermissionProfile(t *testing.T) {
+	t.Parallel()
+
+	service := roundTripWorkloadService(t)
+	req := &createRequest{
+		Name: "fetch",
+		updateRequest: updateRequest{
+			Image: "ghcr.io/stackloklabs/fetch:latest",
+			PermissionProfile: &permissions.Profile{
+				Read:  []permissions.MountDeclaration{"/data"},
+				Write: []permissions.MountDeclaration{"/data"},
+			},
+		},
+	}
+
+	_, err := service.BuildFullRunConfig(context.Background(), req, 0)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, httperr.Code(err))
+	assert.ErrorContains(t, err, `container path "/data" is both read-only (read[0]) and writable (write[0])`)
+}
@@ -788,11 +788,16 @@ func (*RegistryRoutes) removeRegistry(w http.ResponseWriter, r *http.Request) {
 //	 listServers
 //
 //		@Summary		List servers in a registry
-//		@Description	Get a list of servers in a specific registry
+//		@Description	Get a list of servers in a specific registry, optionally filtered and sorted
 //		@Tags			registry
 //		@Produce		json
-//		@Param			name	path		string	true	"Registry name"
+//		@Param			name		path		string		true	"Registry name"
+//		@Param			q			query		string		false	"Filter by server name substring (case-insensitive)"
+//		@Param			tag			query		[]string	false	"Filter by tag; repeat to require several tags"	collectionFormat(multi)
+//		@Param			transport	query		string		false	"Filter by transport type"	Enums(stdio, sse, streamable-http)
+//		@Param			sort		query		string		false	"Sort order (default: name)"	Enums(name, -name, stars)
 //		@Success		200	{object}	listServersResponse
+//		@Failure		400	{string}	string	"Bad Request"
 //		@Failure		404	{string}	string	"Not Found"
 //		@Router			/api/v1beta/registry/{name}/servers [get]
 func (rr *RegistryRoutes) listServers(w http.ResponseWriter, r *http.Request) {
@@ -804,6 +809,12 @@ func (rr *RegistryRoutes) listServers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	opts, err := parseRegistrySearchOptions(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	provider, ok := rr.getCurrentProvider(w)
 	if !ok {
 		return
@@ -821,20 +832,7 @@ func (rr *RegistryRoutes) listServers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build response with both container and remote servers
-	response := listServersResponse{
-		Servers:       make([]*registry.ImageMetadata, 0, len(reg.Servers)),
-		RemoteServers: make([]*registry.RemoteServerMetadata, 0, len(reg.RemoteServers)),
-	}
-
-	// Add container servers
-	for _, server := range reg.Servers {
-		response.Servers = append(response.Servers, server)
-	}
-
-	// Add remote servers
-	for _, server := range reg.RemoteServers {
-		response.RemoteServers = append(response.RemoteServers, server)
-	}
+	response := searchRegistryServers(reg, opts)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	registry "github.com/stacklok/toolhive-core/registry/types"
+)
+
+// Sort orders accepted by the registry server list endpoint.
+const (
+	// serverSortName sorts servers by name, ascending. This is the default.
+	serverSortName = "name"
+	// serverSortNameDesc sorts servers by name, descending.
+	serverSortNameDesc = "-name"
+	// serverSortStars sorts servers by star count, most starred first. Ties
+	// are broken by name.
+	serverSortStars = "stars"
+)
+
+// RegistrySearchOptions filters and orders the servers returned by the
+// registry server list endpoint. The zero value matches every server and
+// sorts by name.
+type RegistrySearchOptions struct {
+	// Name matches servers whose name contains this substring (case-insensitive).
+	Name string
+	// Tags matches servers carrying every one of these tags (case-insensitive).
+	Tags []string
+	// Transport matches servers using this transport, e.g. "stdio" or
+	// "streamable-http" (case-insensitive).
+	Transport string
+	// Sort is the sort order: "name" (default), "-name" or "stars".
+	Sort string
+}
+
+// parseRegistrySearchOptions reads RegistrySearchOptions from the query
+// parameters q (name substring), tag (repeatable), transport and sort.
+func parseRegistrySearchOptions(query url.Values) (RegistrySearchOptions, error) {
+	opts := RegistrySearchOptions{
+		Name:      strings.TrimSpace(query.Get("q")),
+		Transport: strings.TrimSpace(query.Get("transport")),
+		Sort:      strings.TrimSpace(query.Get("sort")),
+	}
+	for _, tag := range query["tag"] {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			opts.Tags = append(opts.Tags, tag)
+		}
+	}
+
+	switch opts.Sort {
+	case "", serverSortName, serverSortNameDesc, serverSortStars:
+	default:
+		return RegistrySearchOptions{}, fmt.Errorf(
+			"invalid sort %q: must be one of %s, %s, %s", opts.Sort, serverSortName, serverSortNameDesc, serverSortStars)
+	}
+
+	return opts, nil
+}
+
+// searchRegistryServers returns the container and remote servers of reg that
+// match opts, each sorted as requested.
+func searchRegistryServers(reg *registry.Registry, opts RegistrySearchOptions) listServersResponse {
+	response := listServersResponse{
+		Servers:       make([]*registry.ImageMetadata, 0, len(reg.Servers)),
+		RemoteServers: make([]*registry.RemoteServerMetadata, 0, len(reg.RemoteServers)),
+	}
+
+	for _, server := range reg.Servers {
+		if opts.matches(server) {
+			response.Servers = append(response.Servers, server)
+		}
+	}
+	for _, server := range reg.RemoteServers {
+		if opts.matches(server) {
+			response.RemoteServers = append(response.RemoteServers, server)
+		}
+	}
+
+	slices.SortFunc(response.Servers, serverComparator[*registry.ImageMetadata](opts.Sort))
+	slices.SortFunc(response.RemoteServers, serverComparator[*registry.RemoteServerMetadata](opts.Sort))
+
+	return response
+}
+
+// matches reports whether server satisfies every filter in opts.
+func (opts RegistrySearchOptions) matches(server registry.ServerMetadata) bool {
+	if opts.Name != "" && !strings.Contains(strings.ToLower(server.GetName()), strings.ToLower(opts.Name)) {
+		return false
+	}
+	if opts.Transport != "" && !strings.EqualFold(server.GetTransport(), opts.Transport) {
+		return false
+	}
+	for _, want := range opts.Tags {
+		if !slices.ContainsFunc(server.GetTags(), func(tag string) bool {
+			return strings.EqualFold(tag, want)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// serverComparator returns a comparison function ordering servers by the
+// given sort order, for use with slices.SortFunc.
+func serverComparator[T registry.ServerMetadata](order string) func(a, b T) int {
+	return func(a, b T) int {
+		switch order {
+		case serverSortNameDesc:
+			return strings.Compare(b.GetName(), a.GetName())
+		case serverSortStars:
+			if c := cmp.Compare(serverStars(b), serverStars(a)); c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	}
+}
+
+// serverStars returns the star count of server, or zero if it has no metadata.
+func serverStars(server registry.ServerMetadata) int {
+	if md := server.GetMetadata(); md != nil {
+		return md.Stars
+	}
+	return 0
+}
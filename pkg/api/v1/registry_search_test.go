@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	registry "github.com/stacklok/toolhive-core/registry/types"
+)
+
+// searchFixtureRegistry returns a small registry with container and remote
+// servers spanning several tags, transports and star counts.
+func searchFixtureRegistry() *registry.Registry {
+	image := func(name, transport string, stars int, tags ...string) *registry.ImageMetadata {
+		return &registry.ImageMetadata{
+			BaseServerMetadata: registry.BaseServerMetadata{
+				Name:      name,
+				Transport: transport,
+				Tags:      tags,
+				Metadata:  &registry.Metadata{Stars: stars},
+			},
+		}
+	}
+	remote := func(name, transport string, stars int, tags ...string) *registry.RemoteServerMetadata {
+		return &registry.RemoteServerMetadata{
+			BaseServerMetadata: registry.BaseServerMetadata{
+				Name:      name,
+				Transport: transport,
+				Tags:      tags,
+				Metadata:  &registry.Metadata{Stars: stars},
+			},
+		}
+	}
+
+	return &registry.Registry{
+		Servers: map[string]*registry.ImageMetadata{
+			"fetch":       image("fetch", "streamable-http", 50, "web", "http"),
+			"github":      image("github", "stdio", 200, "vcs", "api"),
+			"gitlab":      image("gitlab", "stdio", 50, "vcs"),
+			"filesystem":  image("filesystem", "stdio", 0, "files"),
+			"postgres-db": image("postgres-db", "sse", 120, "database"),
+		},
+		RemoteServers: map[string]*registry.RemoteServerMetadata{
+			"remote-github": remote("remote-github", "streamable-http", 10, "vcs", "api"),
+			"remote-notion": remote("remote-notion", "sse", 30, "docs"),
+		},
+	}
+}
+
+func imageNames(servers []*registry.ImageMetadata) []string {
+	names := make([]string, 0, len(servers))
+	for _, s := range servers {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func remoteNames(servers []*registry.RemoteServerMetadata) []string {
+	names := make([]string, 0, len(servers))
+	for _, s := range servers {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestSearchRegistryServers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        RegistrySearchOptions
+		wantServers []string
+		wantRemote  []string
+	}{
+		{
+			name:        "no filters returns all sorted by name",
+			opts:        RegistrySearchOptions{},
+			wantServers: []string{"fetch", "filesystem", "github", "gitlab", "postgres-db"},
+			wantRemote:  []string{"remote-github", "remote-notion"},
+		},
+		{
+			name:        "name substring is case-insensitive",
+			opts:        RegistrySearchOptions{Name: "GIT"},
+			wantServers: []string{"github", "gitlab"},
+			wantRemote:  []string{"remote-github"},
+		},
+		{
+			name:        "single tag",
+			opts:        RegistrySearchOptions{Tags: []string{"vcs"}},
+			wantServers: []string{"github", "gitlab"},
+			wantRemote:  []string{"remote-github"},
+		},
+		{
+			name:        "multiple tags must all match",
+			opts:        RegistrySearchOptions{Tags: []string{"VCS", "api"}},
+			wantServers: []string{"github"},
+			wantRemote:  []string{"remote-github"},
+		},
+		{
+			name:        "transport",
+			opts:        RegistrySearchOptions{Transport: "sse"},
+			wantServers: []string{"postgres-db"},
+			wantRemote:  []string{"remote-notion"},
+		},
+		{
+			name:        "filters combine",
+			opts:        RegistrySearchOptions{Name: "git", Transport: "stdio", Tags: []string{"api"}},
+			wantServers: []string{"github"},
+			wantRemote:  []string{},
+		},
+		{
+			name:        "no matches",
+			opts:        RegistrySearchOptions{Name: "nonexistent"},
+			wantServers: []string{},
+			wantRemote:  []string{},
+		},
+		{
+			name:        "sort by name descending",
+			opts:        RegistrySearchOptions{Sort: serverSortNameDesc},
+			wantServers: []string{"postgres-db", "gitlab", "github", "filesystem", "fetch"},
+			wantRemote:  []string{"remote-notion", "remote-github"},
+		},
+		{
+			name:        "sort by stars with name as tie breaker",
+			opts:        RegistrySearchOptions{Sort: serverSortStars},
+			wantServers: []string{"github", "postgres-db", "fetch", "gitlab", "filesystem"},
+			wantRemote:  []string{"remote-notion", "remote-github"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := searchRegistryServers(searchFixtureRegistry(), tt.opts)
+
+			assert.Equal(t, tt.wantServers, imageNames(got.Servers))
+			assert.Equal(t, tt.wantRemote, remoteNames(got.RemoteServers))
+		})
+	}
+}
+
+func TestParseRegistrySearchOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		want    RegistrySearchOptions
+		wantErr bool
+	}{
+		{
+			name:  "empty query",
+			query: "",
+			want:  RegistrySearchOptions{},
+		},
+		{
+			name:  "all parameters",
+			query: "q=git&tag=vcs&tag=api&transport=stdio&sort=stars",
+			want: RegistrySearchOptions{
+				Name:      "git",
+				Tags:      []string{"vcs", "api"},
+				Transport: "stdio",
+				Sort:      serverSortStars,
+			},
+		},
+		{
+			name:  "blank tags are ignored",
+			query: "tag=&tag=%20web%20",
+			want:  RegistrySearchOptions{Tags: []string{"web"}},
+		},
+		{
+			name:    "invalid sort",
+			query:   "sort=popularity",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, err := parseRegistrySearchOptions(query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -28,6 +28,9 @@ const (
 // SecretsRoutes defines the routes for the secrets API.
 type SecretsRoutes struct {
 	configProvider config.Provider
+	// newProvider creates the secrets provider the handlers operate on. When
+	// nil, the provider configured in configProvider is used.
+	newProvider func() (secrets.Provider, error)
 }
 
 // NewSecretsRoutes creates a new SecretsRoutes with the default config provider
@@ -59,6 +62,8 @@ func secretsRouterWithRoutes(routes *SecretsRoutes) http.Handler {
 	// Default provider routes
 	r.Route("/default", func(r chi.Router) {
 		r.Get("/", apierrors.ErrorHandler(routes.getSecretsProvider))
+		r.Post("/import", apierrors.ErrorHandler(routes.importSecrets))
+		r.Get("/export", apierrors.ErrorHandler(routes.exportSecrets))
 		r.Route("/keys", func(r chi.Router) {
 			r.Get("/", apierrors.ErrorHandler(routes.listSecrets))
 			r.Post("/", apierrors.ErrorHandler(routes.createSecret))
@@ -102,6 +107,8 @@ func (s *SecretsRoutes) setupSecretsProvider(w http.ResponseWriter, r *http.Requ
 		providerType = secrets.OnePasswordType
 	case string(secrets.EnvironmentType):
 		providerType = secrets.EnvironmentType
+	case string(secrets.VaultType):
+		providerType = secrets.VaultType
 	case "":
 		return httperr.WithCode(
 			fmt.Errorf("provider type cannot be empty"),
@@ -109,11 +116,12 @@ func (s *SecretsRoutes) setupSecretsProvider(w http.ResponseWriter, r *http.Requ
 		)
 	default:
 		return httperr.WithCode(
-			fmt.Errorf("invalid secrets provider type: %s (valid types: %s, %s, %s)",
+			fmt.Errorf("invalid secrets provider type: %s (valid types: %s, %s, %s, %s)",
 				req.ProviderType,
 				string(secrets.EncryptedType),
 				string(secrets.OnePasswordType),
 				string(secrets.EnvironmentType),
+				string(secrets.VaultType),
 			),
 			http.StatusBadRequest,
 		)
@@ -182,7 +190,7 @@ func (s *SecretsRoutes) setupSecretsProvider(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Update the secrets provider type and mark setup as completed
-	err := s.configProvider.UpdateConfig(func(c *config.Config) error {
+	err := config.UpdateProviderConfig(r.Context(), s.configProvider, func(c *config.Config) error {
 		c.Secrets.ProviderType = string(providerType)
 		c.Secrets.SetupCompleted = true
 		return nil
@@ -510,6 +518,10 @@ func (s *SecretsRoutes) deleteSecret(w http.ResponseWriter, r *http.Request) err
 
 // getSecretsManager is a helper function to get the secrets manager
 func (s *SecretsRoutes) getSecretsManager() (secrets.Provider, error) {
+	if s.newProvider != nil {
+		return s.newProvider()
+	}
+
 	cfg := s.configProvider.GetConfig()
 
 	// Check if secrets setup has been completed
@@ -531,7 +543,7 @@ func (s *SecretsRoutes) getSecretsManager() (secrets.Provider, error) {
 //
 //	@Description	Request to setup a secrets provider
 type setupSecretsRequest struct {
-	// Type of the secrets provider (encrypted, 1password, environment)
+	// Type of the secrets provider (encrypted, 1password, environment, vault)
 	ProviderType string `json:"provider_type"`
 	// Password for encrypted provider (optional, can be set via environment variable)
 	// TODO Review environment variable for this
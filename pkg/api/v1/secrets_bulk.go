@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/stacklok/toolhive-core/httperr"
+	"github.com/stacklok/toolhive/pkg/secrets"
+)
+
+// redactedSecretValue replaces every secret value in an export that was not
+// explicitly requested in plaintext.
+const redactedSecretValue = "REDACTED"
+
+// ImportError is returned by ImportSecrets when checking for or writing a
+// secret fails.
+type ImportError struct {
+	// Key is the secret whose check or write failed.
+	Key string
+	// Err is the provider's error.
+	Err error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("failed to import secret %s: %v", e.Key, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// ImportSecrets writes every key-value pair in values to provider. Keys that
+// already exist are left untouched unless overwrite is set; they are returned
+// as skipped. Existing keys can only be detected when the provider supports
+// reading, otherwise every key is written. A key is only written without
+// overwrite when the provider reports it as not found; any other read error
+// fails the import, since the key may exist.
+//
+// Keys are written in sorted order and the import stops at the first failed
+// check or write, so the keys imported before the failure are returned
+// alongside an *ImportError.
+func ImportSecrets(
+	ctx context.Context, provider secrets.Provider, values map[string]string, overwrite bool,
+) (imported, skipped []string, err error) {
+	if !provider.Capabilities().CanWrite {
+		return nil, nil, httperr.WithCode(
+			fmt.Errorf("secrets provider does not support creating secrets"),
+			http.StatusMethodNotAllowed,
+		)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if key == "" || value == "" {
+			return nil, nil, httperr.WithCode(
+				fmt.Errorf("secret keys and values must not be empty"),
+				http.StatusBadRequest,
+			)
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	canRead := provider.Capabilities().CanRead
+	imported = make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !overwrite && canRead {
+			_, err := provider.GetSecret(ctx, key)
+			if err == nil {
+				skipped = append(skipped, key)
+				continue
+			}
+			if !secrets.IsNotFoundError(err) {
+				return imported, skipped, &ImportError{Key: key, Err: err}
+			}
+		}
+		if err := provider.SetSecret(ctx, key, values[key]); err != nil {
+			return imported, skipped, &ImportError{Key: key, Err: err}
+		}
+		imported = append(imported, key)
+	}
+	return imported, skipped, nil
+}
+
+// ExportSecrets returns every secret in provider keyed by name. Values are
+// replaced with redactedSecretValue unless plaintext is set, so that an export
+// only reveals secret values when the caller explicitly asks for them.
+func ExportSecrets(ctx context.Context, provider secrets.Provider, plaintext bool) (map[string]string, error) {
+	capabilities := provider.Capabilities()
+	if !capabilities.CanList {
+		return nil, httperr.WithCode(
+			fmt.Errorf("secrets provider does not support listing keys"),
+			http.StatusMethodNotAllowed,
+		)
+	}
+	if plaintext && !capabilities.CanRead {
+		return nil, httperr.WithCode(
+			fmt.Errorf("secrets provider does not support reading secrets"),
+			http.StatusMethodNotAllowed,
+		)
+	}
+
+	descriptions, err := provider.ListSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	values := make(map[string]string, len(descriptions))
+	for _, desc := range descriptions {
+		if !plaintext {
+			values[desc.Key] = redactedSecretValue
+			continue
+		}
+		value, err := provider.GetSecret(ctx, desc.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret %s: %w", desc.Key, err)
+		}
+		values[desc.Key] = value
+	}
+	return values, nil
+}
+
+// importSecrets
+//
+//	@Summary		Import secrets
+//	@Description	Create secrets in the default provider from a map of keys to values, skipping existing ones unless overwrite is set
+//	@Tags			secrets
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		importSecretsRequest	true	"Import secrets request"
+//	@Success		200		{object}	importSecretsResponse
+//	@Failure		400		{string}	string	"Bad Request"
+//	@Failure		404		{string}	string	"Not Found - Provider not setup"
+//	@Failure		405		{string}	string	"Method Not Allowed - Provider doesn't support writing"
+//	@Failure		500		{object}	importSecretsResponse	"Internal Server Error - Import stopped at a failed check or write; lists the secrets already written"
+//	@Router			/api/v1beta/secrets/default/import [post]
+func (s *SecretsRoutes) importSecrets(w http.ResponseWriter, r *http.Request) error {
+	var req importSecretsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return httperr.WithCode(
+			fmt.Errorf("invalid request body: %w", err),
+			http.StatusBadRequest,
+		)
+	}
+
+	if len(req.Secrets) == 0 {
+		return httperr.WithCode(
+			fmt.Errorf("at least one secret is required"),
+			http.StatusBadRequest,
+		)
+	}
+
+	provider, err := s.getSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	imported, skipped, err := ImportSecrets(r.Context(), provider, req.Secrets, req.Overwrite)
+	var importErr *ImportError
+	if err != nil && !errors.As(err, &importErr) {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := importSecretsResponse{
+		Imported: imported,
+		Skipped:  skipped,
+	}
+	if importErr != nil {
+		// The secrets written before the failure stay written, so the caller is
+		// told which they are rather than only that the import failed. The
+		// provider's error may carry internal details and is only logged.
+		slog.Error("secrets import stopped at a failed check or write", "imported", len(imported), "error", err)
+		resp.Failed = importErr.Key
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	return nil
+}
+
+// exportSecrets
+//
+//	@Summary		Export secrets
+//	@Description	Export all secrets from the default provider, with values redacted unless plaintext is true
+//	@Tags			secrets
+//	@Produce		json
+//	@Param			plaintext	query		bool	false	"Return secret values instead of redacting them"
+//	@Success		200			{object}	exportSecretsResponse
+//	@Failure		400			{string}	string	"Bad Request"
+//	@Failure		404			{string}	string	"Not Found - Provider not setup"
+//	@Failure		405			{string}	string	"Method Not Allowed - Provider doesn't support listing or reading"
+//	@Failure		500			{string}	string	"Internal Server Error"
+//	@Router			/api/v1beta/secrets/default/export [get]
+func (s *SecretsRoutes) exportSecrets(w http.ResponseWriter, r *http.Request) error {
+	plaintext := false
+	if p := r.URL.Query().Get("plaintext"); p != "" {
+		var err error
+		if plaintext, err = strconv.ParseBool(p); err != nil {
+			return httperr.WithCode(
+				fmt.Errorf("invalid plaintext parameter %q: must be a boolean", p),
+				http.StatusBadRequest,
+			)
+		}
+	}
+
+	provider, err := s.getSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	values, err := ExportSecrets(r.Context(), provider, plaintext)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	resp := exportSecretsResponse{
+		Secrets:  values,
+		Redacted: !plaintext,
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	return nil
+}
+
+// importSecretsRequest represents the request for importing secrets
+//
+//	@Description	Request to import secrets in bulk
+type importSecretsRequest struct {
+	// Secret values keyed by secret name
+	Secrets map[string]string `json:"secrets"`
+	// Replace secrets that already exist instead of skipping them
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+// importSecretsResponse represents the response for importing secrets
+//
+//	@Description	Response after importing secrets in bulk
+type importSecretsResponse struct {
+	// Secret keys that were written
+	Imported []string `json:"imported"`
+	// Secret keys that already existed and were left unchanged
+	Skipped []string `json:"skipped,omitempty"`
+	// Secret key whose check or write failed; the import stopped there
+	Failed string `json:"failed,omitempty"`
+}
+
+// exportSecretsResponse represents the response for exporting secrets
+//
+//	@Description	Response containing the exported secrets
+type exportSecretsResponse struct {
+	// Secret values keyed by secret name
+	Secrets map[string]string `json:"secrets"`
+	// Whether the values were replaced with a placeholder
+	Redacted bool `json:"redacted"`
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/secrets"
+)
+
+// memorySecretsProvider is an in-memory secrets.Provider with configurable
+// capabilities.
+type memorySecretsProvider struct {
+	mu           sync.Mutex
+	values       map[string]string
+	capabilities secrets.ProviderCapabilities
+	// failWrite is a key whose SetSecret fails.
+	failWrite string
+	// failRead is a key whose GetSecret fails with an error other than not found.
+	failRead string
+}
+
+func newMemorySecretsProvider(values map[string]string) *memorySecretsProvider {
+	p := &memorySecretsProvider{
+		values: map[string]string{},
+		capabilities: secrets.ProviderCapabilities{
+			CanRead: true, CanWrite: true, CanDelete: true, CanList: true, CanCleanup: true,
+		},
+	}
+	for k, v := range values {
+		p.values[k] = v
+	}
+	return p
+}
+
+func (p *memorySecretsProvider) GetSecret(_ context.Context, name string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if name == p.failRead {
+		return "", errors.New("backend read failed: 1Password request timed out")
+	}
+	value, ok := p.values[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", secrets.ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+func (p *memorySecretsProvider) SetSecret(_ context.Context, name, value string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if name == p.failWrite {
+		return errors.New("backend write failed: connection to vault-internal:8200 refused")
+	}
+	p.values[name] = value
+	return nil
+}
+
+func (p *memorySecretsProvider) DeleteSecret(_ context.Context, name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.values, name)
+	return nil
+}
+
+func (p *memorySecretsProvider) ListSecrets(_ context.Context) ([]secrets.SecretDescription, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	descriptions := make([]secrets.SecretDescription, 0, len(p.values))
+	for key := range p.values {
+		descriptions = append(descriptions, secrets.SecretDescription{Key: key})
+	}
+	return descriptions, nil
+}
+
+func (p *memorySecretsProvider) DeleteSecrets(ctx context.Context, names []string) error {
+	for _, name := range names {
+		_ = p.DeleteSecret(ctx, name)
+	}
+	return nil
+}
+
+func (*memorySecretsProvider) Cleanup() error { return nil }
+
+func (p *memorySecretsProvider) Capabilities() secrets.ProviderCapabilities {
+	return p.capabilities
+}
+
+func routesWithSecretsProvider(provider secrets.Provider) http.Handler {
+	return secretsRouterWithRoutes(&SecretsRoutes{
+		newProvider: func() (secrets.Provider, error) { return provider, nil },
+	})
+}
+
+func exportSecretsFrom(t *testing.T, router http.Handler, query string) (int, exportSecretsResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/default/export"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp exportSecretsResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	}
+	return w.Code, resp
+}
+
+func importSecretsInto(t *testing.T, router http.Handler, body importSecretsRequest) (int, importSecretsResponse) {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/default/import", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp importSecretsResponse
+	if w.Header().Get("Content-Type") == "application/json" {
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	}
+	return w.Code, resp
+}
+
+func TestSecretsImportExport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	source := routesWithSecretsProvider(newMemorySecretsProvider(map[string]string{
+		"github-token": "ghp_example",
+		"slack-token":  "xoxb-example",
+	}))
+
+	code, exported := exportSecretsFrom(t, source, "?plaintext=true")
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, exported.Redacted)
+
+	destination := newMemorySecretsProvider(nil)
+	code, imported := importSecretsInto(t, routesWithSecretsProvider(destination),
+		importSecretsRequest{Secrets: exported.Secrets})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"github-token", "slack-token"}, imported.Imported)
+	assert.Empty(t, imported.Skipped)
+
+	assert.Equal(t, map[string]string{
+		"github-token": "ghp_example",
+		"slack-token":  "xoxb-example",
+	}, destination.values)
+}
+
+func TestSecretsExport_PlaintextRequiresFlag(t *testing.T) {
+	t.Parallel()
+
+	router := routesWithSecretsProvider(newMemorySecretsProvider(map[string]string{"api-key": "s3cret"}))
+
+	for _, query := range []string{"", "?plaintext=false"} {
+		code, resp := exportSecretsFrom(t, router, query)
+		require.Equal(t, http.StatusOK, code)
+		assert.True(t, resp.Redacted)
+		assert.Equal(t, map[string]string{"api-key": redactedSecretValue}, resp.Secrets)
+	}
+
+	code, _ := exportSecretsFrom(t, router, "?plaintext=yes-please")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, resp := exportSecretsFrom(t, router, "?plaintext=true")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"api-key": "s3cret"}, resp.Secrets)
+}
+
+func TestSecretsImport_Overwrite(t *testing.T) {
+	t.Parallel()
+
+	provider := newMemorySecretsProvider(map[string]string{"existing": "old"})
+	router := routesWithSecretsProvider(provider)
+	values := map[string]string{"existing": "new", "fresh": "value"}
+
+	code, resp := importSecretsInto(t, router, importSecretsRequest{Secrets: values})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"fresh"}, resp.Imported)
+	assert.Equal(t, []string{"existing"}, resp.Skipped)
+	assert.Equal(t, "old", provider.values["existing"])
+
+	code, resp = importSecretsInto(t, router, importSecretsRequest{Secrets: values, Overwrite: true})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"existing", "fresh"}, resp.Imported)
+	assert.Equal(t, "new", provider.values["existing"])
+}
+
+func TestSecretsImport_PartialFailureReportsImported(t *testing.T) {
+	t.Parallel()
+
+	provider := newMemorySecretsProvider(map[string]string{"existing": "old"})
+	provider.failWrite = "c-key"
+	router := routesWithSecretsProvider(provider)
+
+	code, resp := importSecretsInto(t, router, importSecretsRequest{Secrets: map[string]string{
+		"a-key": "1", "b-key": "2", "c-key": "3", "d-key": "4", "existing": "new",
+	}})
+	require.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, []string{"a-key", "b-key"}, resp.Imported, "secrets written before the failure are reported")
+	assert.Empty(t, resp.Skipped, "keys after the failure are not reached")
+	assert.Equal(t, "c-key", resp.Failed)
+	assert.Equal(t, map[string]string{"existing": "old", "a-key": "1", "b-key": "2"}, provider.values)
+}
+
+func TestSecretsImport_ReadFailureDoesNotOverwrite(t *testing.T) {
+	t.Parallel()
+
+	provider := newMemorySecretsProvider(map[string]string{"a-key": "1", "existing": "old"})
+	provider.failRead = "existing"
+	router := routesWithSecretsProvider(provider)
+
+	code, resp := importSecretsInto(t, router, importSecretsRequest{Secrets: map[string]string{
+		"a-key": "new", "existing": "new", "fresh": "value",
+	}})
+	require.Equal(t, http.StatusInternalServerError, code)
+	assert.Empty(t, resp.Imported)
+	assert.Equal(t, []string{"a-key"}, resp.Skipped)
+	assert.Equal(t, "existing", resp.Failed, "a key that cannot be checked must not be written")
+	assert.Equal(t, map[string]string{"a-key": "1", "existing": "old"}, provider.values)
+}
+
+func TestSecretsImportExport_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty import", func(t *testing.T) {
+		t.Parallel()
+		code, _ := importSecretsInto(t, routesWithSecretsProvider(newMemorySecretsProvider(nil)),
+			importSecretsRequest{})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("empty value", func(t *testing.T) {
+		t.Parallel()
+		provider := newMemorySecretsProvider(nil)
+		code, _ := importSecretsInto(t, routesWithSecretsProvider(provider),
+			importSecretsRequest{Secrets: map[string]string{"a": "1", "b": ""}})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Empty(t, provider.values, "nothing is written when the request is invalid")
+	})
+
+	t.Run("read-only provider rejects import", func(t *testing.T) {
+		t.Parallel()
+		provider := newMemorySecretsProvider(nil)
+		provider.capabilities = secrets.ProviderCapabilities{CanRead: true}
+		code, _ := importSecretsInto(t, routesWithSecretsProvider(provider),
+			importSecretsRequest{Secrets: map[string]string{"a": "1"}})
+		assert.Equal(t, http.StatusMethodNotAllowed, code)
+	})
+
+	t.Run("provider without listing rejects export", func(t *testing.T) {
+		t.Parallel()
+		provider := newMemorySecretsProvider(nil)
+		provider.capabilities = secrets.ProviderCapabilities{CanRead: true}
+		code, _ := exportSecretsFrom(t, routesWithSecretsProvider(provider), "")
+		assert.Equal(t, http.StatusMethodNotAllowed, code)
+	})
+}
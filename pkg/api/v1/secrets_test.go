@@ -108,7 +108,7 @@ func TestSetupSecretsProvider_InvalidRequests(t *testing.T) {
 				ProviderType: "invalid",
 			},
 			expectedCode: http.StatusBadRequest,
-			errorMessage: "invalid secrets provider type: invalid (valid types: encrypted, 1password, environment)",
+			errorMessage: "invalid secrets provider type: invalid (valid types: encrypted, 1password, environment, vault)",
 		},
 		{
 			name:         "invalid json body",
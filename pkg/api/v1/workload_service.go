@@ -193,6 +193,11 @@ func (s *WorkloadService) BuildFullRunConfig(
 		return nil, fmt.Errorf("%w: %w", retriever.ErrInvalidRunConfig, err)
 	}
 
+	// Validate the permission profile before it is applied to the workload
+	if err := validatePermissionProfile(req.PermissionProfile); err != nil {
+		return nil, fmt.Errorf("%w: %w", retriever.ErrInvalidRunConfig, err)
+	}
+
 	// Default group if not specified
 	groupName := req.Group
 	if groupName == "" {
@@ -346,8 +351,8 @@ func (s *WorkloadService) BuildFullRunConfig(
 		runner.WithProxyMode(types.ProxyMode(req.ProxyMode)),
 		runner.WithTransportAndPorts(req.Transport, req.ProxyPort, req.TargetPort),
 		runner.WithAuditEnabled(false, ""),
-		runner.WithOIDCConfig(req.OIDC.Issuer, req.OIDC.Audience, req.OIDC.JwksURL, "",
-			req.OIDC.ClientID, "", "", "", "", false, false, req.OIDC.Scopes),
+		runner.WithOIDCConfig(req.OIDC.Issuer, req.OIDC.Audience, req.OIDC.JwksURL, req.OIDC.IntrospectionURL,
+			req.OIDC.ClientID, req.OIDC.ClientSecret, "", "", "", false, false, req.OIDC.Scopes),
 		runner.WithToolsFilter(req.ToolsFilter),
 		runner.WithToolsOverride(toolsOverride),
 		runner.WithTelemetryConfig(telemetryConfig),
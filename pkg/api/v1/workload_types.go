@@ -25,6 +25,9 @@ import (
 type workloadListResponse struct {
 	// List of container information for each workload
 	Workloads []core.Workload `json:"workloads"`
+	// Continue is the token to pass as the continue parameter to fetch the
+	// next page. Empty when there are no more workloads.
+	Continue string `json:"continue,omitempty"`
 }
 
 // workloadStatusResponse represents the response for getting workload status
@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-core/permissions"
+	regtypes "github.com/stacklok/toolhive-core/registry/types"
+	"github.com/stacklok/toolhive/pkg/auth/remote"
+	"github.com/stacklok/toolhive/pkg/config"
+	"github.com/stacklok/toolhive/pkg/container/templates"
+	"github.com/stacklok/toolhive/pkg/environment"
+	groupsmocks "github.com/stacklok/toolhive/pkg/groups/mocks"
+	"github.com/stacklok/toolhive/pkg/networking"
+	"github.com/stacklok/toolhive/pkg/runner"
+	"github.com/stacklok/toolhive/pkg/runner/retriever"
+	"github.com/stacklok/toolhive/pkg/secrets"
+	"github.com/stacklok/toolhive/pkg/transport/types"
+)
+
+// assertRoundTrip converts in to its internal representation and back, and
+// asserts that the result equals in. normalize, when set, is applied to a copy
+// of in before comparing. It must only adjust the fields that are documented
+// not to survive the round trip unchanged, so that every other field is still
+// compared.
+func assertRoundTrip[API, Internal any](
+	t *testing.T,
+	in API,
+	toInternal func(API) (Internal, error),
+	toAPI func(Internal) API,
+	normalize func(*API),
+) {
+	t.Helper()
+
+	internal, err := toInternal(in)
+	require.NoError(t, err)
+
+	want := in
+	if normalize != nil {
+		normalize(&want)
+	}
+	assert.Equal(t, want, toAPI(internal))
+}
+
+// roundTripWorkloadService returns a WorkloadService that builds RunConfigs
+// without touching a registry, container runtime or image store.
+func roundTripWorkloadService(t *testing.T) *WorkloadService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockGroupManager := groupsmocks.NewMockManager(ctrl)
+	mockGroupManager.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+
+	return &WorkloadService{
+		groupManager: mockGroupManager,
+		imageRetriever: func(
+			_ context.Context, image string, _ string, _ string, _ string, _ *templates.RuntimeConfig,
+		) (string, regtypes.ServerMetadata, error) {
+			return image, &regtypes.ImageMetadata{Image: image}, nil
+		},
+		imagePuller:       func(_ context.Context, _ string) error { return nil },
+		configProvider:    config.NewPathProvider(t.TempDir() + "/config.yaml"),
+		imageVerification: retriever.VerifyImageDisabled,
+	}
+}
+
+// createRequestRoundTrip converts a createRequest to a RunConfig the way the
+// create endpoint does, and back the way the get endpoint does.
+func createRequestRoundTrip(t *testing.T, in createRequest) {
+	t.Helper()
+
+	service := roundTripWorkloadService(t)
+	assertRoundTrip(t, in,
+		func(req createRequest) (*runner.RunConfig, error) {
+			return service.BuildFullRunConfig(context.Background(), &req, 0)
+		},
+		func(runConfig *runner.RunConfig) createRequest {
+			return *runConfigToCreateRequest(runConfig)
+		},
+		normalizeCreateRequest,
+	)
+}
+
+// normalizeCreateRequest applies to an expected createRequest the changes that
+// a round trip through a RunConfig legitimately makes:
+//   - AuthzConfig is a path to a file whose contents are loaded into the
+//     RunConfig; the path itself is not kept.
+//   - Registry and Server only select the server metadata at creation time.
+//   - The transport environment variables (MCP_TRANSPORT, MCP_PORT and
+//     FASTMCP_PORT) are added for the server.
+//   - ProxyMode only applies to the stdio transport; other transports report
+//     their own mode.
+//   - Container workloads get a default remote auth config, which reports
+//     the default OAuth callback port.
+//   - A workload without a permission profile gets the builtin network
+//     profile, and one without tool overrides an empty set of them.
+func normalizeCreateRequest(req *createRequest) {
+	req.AuthzConfig = ""
+	req.Registry = ""
+	req.Server = ""
+
+	envVars := maps.Clone(req.EnvVars)
+	if envVars == nil {
+		envVars = map[string]string{}
+	}
+	environment.SetTransportEnvironmentVariables(envVars, req.Transport, req.TargetPort)
+	req.EnvVars = envVars
+
+	req.ProxyMode = string(types.EffectiveProxyMode(types.TransportType(req.Transport), types.ProxyMode(req.ProxyMode)))
+
+	if req.URL == "" && req.OAuthConfig.CallbackPort == 0 {
+		req.OAuthConfig.CallbackPort = remote.DefaultCallbackPort
+	}
+	if req.PermissionProfile == nil {
+		req.PermissionProfile = permissions.BuiltinNetworkProfile()
+	}
+	if req.ToolsOverride == nil {
+		req.ToolsOverride = map[string]toolOverride{}
+	}
+}
+
+func TestCreateRequestRoundTrip_Container(t *testing.T) {
+	t.Parallel()
+
+	proxyPort := networking.FindAvailable()
+	require.NotZero(t, proxyPort)
+	isolate := false
+	dataDir := t.TempDir()
+
+	// stdio is the only transport that keeps the requested proxy mode.
+
+	createRequestRoundTrip(t, createRequest{
+		Name: "fetch",
+		updateRequest: updateRequest{
+			Image:        "ghcr.io/stackloklabs/fetch:latest",
+			Host:         "127.0.0.1",
+			CmdArguments: []string{"--verbose", "--limit=10"},
+			ProxyPort:    proxyPort,
+			EnvVars:      map[string]string{"LOG_LEVEL": "debug", "EMPTY": ""},
+			Secrets: []secrets.SecretParameter{
+				{Name: "github-token", Target: "GITHUB_TOKEN"},
+				{Name: "api-key", Target: "API_KEY"},
+			},
+			Volumes:   []string{dataDir + ":/data:ro"},
+			Transport: "stdio",
+			OIDC: oidcOptions{
+				Issuer:           "https://issuer.example.com",
+				Audience:         "toolhive",
+				JwksURL:          "https://issuer.example.com/jwks",
+				IntrospectionURL: "https://issuer.example.com/introspect",
+				ClientID:         "client",
+				ClientSecret:     "client-secret",
+				Scopes:           []string{"openid", "profile"},
+			},
+			PermissionProfile: &permissions.Profile{
+				Read:  []permissions.MountDeclaration{"/etc/ssl"},
+				Write: []permissions.MountDeclaration{"/tmp"},
+				Network: &permissions.NetworkPermissions{
+					Outbound: &permissions.OutboundNetworkPermissions{
+						AllowHost: []string{"api.github.com"},
+						AllowPort: []int{443},
+					},
+				},
+			},
+			ProxyMode:          "sse",
+			NetworkIsolation:   &isolate,
+			TrustProxyHeaders:  true,
+			AllowDockerGateway: true,
+			ToolsFilter:        []string{"fetch"},
+			ToolsOverride: map[string]toolOverride{
+				"fetch": {Name: "get_url", Description: "Fetch a URL"},
+			},
+			Group: "research",
+			HeaderForward: &headerForwardConfig{
+				AddPlaintextHeaders:  map[string]string{"X-Tenant-ID": "acme"},
+				AddHeadersFromSecret: map[string]string{"Authorization": "upstream-token"},
+			},
+		},
+	})
+}
+
+func TestCreateRequestRoundTrip_Remote(t *testing.T) {
+	t.Parallel()
+
+	proxyPort := networking.FindAvailable()
+	require.NotZero(t, proxyPort)
+	isolate := true
+
+	createRequestRoundTrip(t, createRequest{
+		Name: "remote",
+		updateRequest: updateRequest{
+			Host:             "127.0.0.1",
+			TargetPort:       8080,
+			ProxyPort:        proxyPort,
+			Secrets:          []secrets.SecretParameter{},
+			Transport:        "streamable-http",
+			ProxyMode:        "streamable-http",
+			NetworkIsolation: &isolate,
+			Group:            "default",
+			URL:              "https://mcp.example.com/mcp",
+			OAuthConfig: remoteOAuthConfig{
+				Issuer:       "https://auth.example.com",
+				AuthorizeURL: "https://auth.example.com/authorize",
+				TokenURL:     "https://auth.example.com/token",
+				ClientID:     "client",
+				ClientSecret: &secrets.SecretParameter{Name: "client-secret", Target: "oauth_secret"},
+				BearerToken:  &secrets.SecretParameter{Name: "bearer", Target: "bearer_token"},
+				Scopes:       []string{"read", "write"},
+				UsePKCE:      true,
+				OAuthParams:  map[string]string{"prompt": "consent"},
+				SkipBrowser:  true,
+				Resource:     "https://mcp.example.com",
+			},
+			Headers: []*regtypes.Header{{Name: "X-API-Version", Default: "2"}},
+		},
+	})
+}
+
+func TestSecretParameterRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, param := range []secrets.SecretParameter{
+		{Name: "github-token", Target: "GITHUB_TOKEN"},
+		{Name: "name.with.dots", Target: "lower_case"},
+		{Name: "a", Target: "B"},
+	} {
+		assertRoundTrip(t, param,
+			func(p secrets.SecretParameter) (string, error) { return p.ToCLIString(), nil },
+			func(s string) secrets.SecretParameter {
+				p, err := secrets.ParseSecretParameter(s)
+				require.NoError(t, err)
+				return p
+			},
+			nil,
+		)
+	}
+}
@@ -122,6 +122,9 @@ func WorkloadRouter(
 	// Register the literal /upgrade-check before /{name} so chi routes it
 	// distinctly from the single-workload wildcard.
 	r.With(stdTimeout).Get("/upgrade-check", apierrors.ErrorHandler(routes.upgradeCheckBulk))
+	// The watch stream is long-lived, so it gets no route timeout; it ends when
+	// the client disconnects.
+	r.Get("/watch", apierrors.ErrorHandler(routes.watchWorkloads))
 	r.With(stdTimeout).Get("/{name}/upgrade-check", apierrors.ErrorHandler(routes.upgradeCheckSingle))
 	// The upgrade apply path verifies and pulls the candidate image, so it gets
 	// the longer timeout. The /{name}/upgrade sub-path is distinct from /{name}
@@ -147,7 +150,10 @@ func WorkloadRouter(
 //		@Produce		json
 //		@Param			all	query		bool	false	"List all workloads, including stopped ones"
 //		@Param			group	query		string	false	"Filter workloads by group name"
+//		@Param			limit	query		integer	false	"Maximum number of workloads to return (default: all)"
+//		@Param			continue	query	string	false	"Continue token from the previous page's response"
 //		@Success		200	{object}	workloadListResponse
+//		@Failure		400	{string}	string	"Invalid limit or continue token"
 //		@Failure		404	{string}	string	"Group not found"
 //		@Router			/api/v1beta/workloads [get]
 func (s *WorkloadRoutes) listWorkloads(w http.ResponseWriter, r *http.Request) error {
@@ -155,6 +161,11 @@ func (s *WorkloadRoutes) listWorkloads(w http.ResponseWriter, r *http.Request) e
 	listAll := r.URL.Query().Get("all") == "true"
 	groupFilter := r.URL.Query().Get("group")
 
+	listOpts, err := parseWorkloadListOptions(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
 	workloadList, err := s.workloadManager.ListWorkloads(ctx, listAll)
 	if err != nil {
 		return fmt.Errorf("failed to list workloads: %w", err)
@@ -162,20 +173,8 @@ func (s *WorkloadRoutes) listWorkloads(w http.ResponseWriter, r *http.Request) e
 
 	// Apply group filtering if specified
 	if groupFilter != "" {
-		if err := groupval.ValidateName(groupFilter); err != nil {
-			return httperr.WithCode(
-				fmt.Errorf("invalid group name: %w", err),
-				http.StatusBadRequest,
-			)
-		}
-		// FilterByGroup silently returns an empty slice for an unknown group,
-		// so check existence explicitly to honor the documented 404.
-		exists, existsErr := s.groupManager.Exists(ctx, groupFilter)
-		if existsErr != nil {
-			return fmt.Errorf("failed to check group existence: %w", existsErr)
-		}
-		if !exists {
-			return fmt.Errorf("%w: %s", groups.ErrGroupNotFound, groupFilter)
+		if err := s.checkGroupFilter(ctx, groupFilter); err != nil {
+			return err
 		}
 		workloadList, err = workloads.FilterByGroup(workloadList, groupFilter)
 		if err != nil {
@@ -183,13 +182,38 @@ func (s *WorkloadRoutes) listWorkloads(w http.ResponseWriter, r *http.Request) e
 		}
 	}
 
+	page, continueToken, err := paginateWorkloads(workloadList, listOpts)
+	if err != nil {
+		return err
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(workloadListResponse{Workloads: workloadList}); err != nil {
+	if err := json.NewEncoder(w).Encode(workloadListResponse{Workloads: page, Continue: continueToken}); err != nil {
 		return fmt.Errorf("failed to marshal workload list: %w", err)
 	}
 	return nil
 }
 
+// checkGroupFilter validates a group name used to filter workloads and checks
+// that the group exists. FilterByGroup silently returns an empty slice for an
+// unknown group, so the explicit check honors the documented 404.
+func (s *WorkloadRoutes) checkGroupFilter(ctx context.Context, group string) error {
+	if err := groupval.ValidateName(group); err != nil {
+		return httperr.WithCode(
+			fmt.Errorf("invalid group name: %w", err),
+			http.StatusBadRequest,
+		)
+	}
+	exists, err := s.groupManager.Exists(ctx, group)
+	if err != nil {
+		return fmt.Errorf("failed to check group existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", groups.ErrGroupNotFound, group)
+	}
+	return nil
+}
+
 // getWorkload
 //
 //	@Summary		Get workload details
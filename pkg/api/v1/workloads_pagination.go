@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stacklok/toolhive-core/httperr"
+	"github.com/stacklok/toolhive/pkg/core"
+)
+
+// errInvalidContinueToken is returned for a continue token that was not
+// produced by a previous workload list call.
+var errInvalidContinueToken = errors.New("invalid continue token")
+
+// WorkloadListOptions pages through the workload list, following Kubernetes
+// list semantics: a caller asks for at most Limit workloads and, when more
+// remain, receives a continue token to pass back for the next page.
+//
+// Workloads are paged in name order and the token records the last name
+// returned, so a page boundary stays stable when workloads are created or
+// deleted between calls. The token does not capture the other list filters;
+// callers must repeat the same all and group parameters on every page.
+type WorkloadListOptions struct {
+	// Limit is the maximum number of workloads to return. Zero returns all
+	// remaining workloads.
+	Limit int
+	// Continue is the token from the previous page's response. Empty starts
+	// from the first workload.
+	Continue string
+}
+
+// parseWorkloadListOptions reads WorkloadListOptions from the limit and
+// continue query parameters.
+func parseWorkloadListOptions(query url.Values) (WorkloadListOptions, error) {
+	opts := WorkloadListOptions{Continue: query.Get("continue")}
+	if l := query.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 0 {
+			return WorkloadListOptions{}, httperr.WithCode(
+				fmt.Errorf("invalid limit %q: must be a non-negative integer", l),
+				http.StatusBadRequest,
+			)
+		}
+		opts.Limit = limit
+	}
+	return opts, nil
+}
+
+// paginateWorkloads returns the page of workloadList selected by opts, sorted
+// by name, and the continue token for the next page. The token is empty when
+// the page is the last one.
+func paginateWorkloads(workloadList []core.Workload, opts WorkloadListOptions) ([]core.Workload, string, error) {
+	sorted := slices.Clone(workloadList)
+	slices.SortFunc(sorted, func(a, b core.Workload) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	start := 0
+	if opts.Continue != "" {
+		after, err := decodeContinueToken(opts.Continue)
+		if err != nil {
+			return nil, "", httperr.WithCode(err, http.StatusBadRequest)
+		}
+		start, _ = slices.BinarySearchFunc(sorted, after, func(w core.Workload, name string) int {
+			if w.Name <= name {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	page := sorted[start:]
+	if opts.Limit == 0 || len(page) <= opts.Limit {
+		return page, "", nil
+	}
+	page = page[:opts.Limit]
+	return page, encodeContinueToken(page[len(page)-1].Name), nil
+}
+
+// continueTokenVersion is the format version of the continue tokens this
+// server issues. A token with any other version is rejected, so the format can
+// change without an old token being misread.
+const continueTokenVersion = 1
+
+// continueToken is the decoded form of a continue token.
+type continueToken struct {
+	// Version is the token format version.
+	Version int `json:"v"`
+	// After is the name of the last workload of the previous page.
+	After string `json:"after"`
+}
+
+// encodeContinueToken returns an opaque token resuming the list after name.
+func encodeContinueToken(name string) string {
+	// Marshalling a struct of an int and a string cannot fail.
+	raw, _ := json.Marshal(continueToken{Version: continueTokenVersion, After: name})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeContinueToken returns the workload name a continue token resumes
+// after. It returns errInvalidContinueToken for anything that is not a token
+// of the current version naming a workload.
+func decodeContinueToken(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errInvalidContinueToken
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	var decoded continueToken
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return "", errInvalidContinueToken
+	}
+	if decoded.Version != continueTokenVersion {
+		return "", fmt.Errorf("%w: unsupported version %d", errInvalidContinueToken, decoded.Version)
+	}
+	if decoded.After == "" {
+		return "", errInvalidContinueToken
+	}
+	return decoded.After, nil
+}
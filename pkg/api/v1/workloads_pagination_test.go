@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	apierrors "github.com/stacklok/toolhive/pkg/api/errors"
+	"github.com/stacklok/toolhive/pkg/core"
+	workloadsmocks "github.com/stacklok/toolhive/pkg/workloads/mocks"
+)
+
+func workloadsNamed(names ...string) []core.Workload {
+	list := make([]core.Workload, 0, len(names))
+	for _, name := range names {
+		list = append(list, core.Workload{Name: name})
+	}
+	return list
+}
+
+// listWorkloadsPage calls listWorkloads with the given query and returns the
+// recorded response.
+func listWorkloadsPage(t *testing.T, routes *WorkloadRoutes, query url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	apierrors.ErrorHandler(routes.listWorkloads).ServeHTTP(w, req)
+	return w
+}
+
+func TestListWorkloads_Pagination(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockWorkloadManager := workloadsmocks.NewMockManager(ctrl)
+	routes := &WorkloadRoutes{workloadManager: mockWorkloadManager}
+
+	// The manager returns workloads in no particular order; between the first
+	// and second page a workload sorting before the page boundary is created.
+	gomock.InOrder(
+		mockWorkloadManager.EXPECT().ListWorkloads(gomock.Any(), false).
+			Return(workloadsNamed("echo", "alpha", "delta", "bravo", "charlie"), nil),
+		mockWorkloadManager.EXPECT().ListWorkloads(gomock.Any(), false).
+			Return(workloadsNamed("echo", "alpha", "aardvark", "delta", "bravo", "charlie"), nil),
+		mockWorkloadManager.EXPECT().ListWorkloads(gomock.Any(), false).
+			Return(workloadsNamed("echo", "alpha", "aardvark", "delta", "bravo", "charlie"), nil),
+	)
+
+	var (
+		pages [][]string
+		next  string
+	)
+	for {
+		query := url.Values{"limit": {"2"}}
+		if next != "" {
+			query.Set("continue", next)
+		}
+		w := listWorkloadsPage(t, routes, query)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp workloadListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+		names := make([]string, 0, len(resp.Workloads))
+		for _, wl := range resp.Workloads {
+			names = append(names, wl.Name)
+		}
+		pages = append(pages, names)
+
+		if resp.Continue == "" {
+			break
+		}
+		next = resp.Continue
+	}
+
+	// Every workload present at the start is returned exactly once, in name
+	// order; the one created mid-listing before the boundary is not.
+	assert.Equal(t, [][]string{{"alpha", "bravo"}, {"charlie", "delta"}, {"echo"}}, pages)
+}
+
+func TestListWorkloads_PaginationErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		query        url.Values
+		expectedBody string
+	}{
+		{
+			name:         "continue token is not base64",
+			query:        url.Values{"continue": {"not a token!"}},
+			expectedBody: "invalid continue token",
+		},
+		{
+			name:         "padded continue token",
+			query:        url.Values{"continue": {"="}},
+			expectedBody: "invalid continue token",
+		},
+		{
+			name:         "base64 that is not a token",
+			query:        url.Values{"continue": {base64.RawURLEncoding.EncodeToString([]byte("alpha"))}},
+			expectedBody: "invalid continue token",
+		},
+		{
+			name:         "unsupported token version",
+			query:        url.Values{"continue": {base64.RawURLEncoding.EncodeToString([]byte(`{"v":2,"after":"alpha"}`))}},
+			expectedBody: "invalid continue token",
+		},
+		{
+			name:         "token without a workload name",
+			query:        url.Values{"continue": {base64.RawURLEncoding.EncodeToString([]byte(`{"v":1}`))}},
+			expectedBody: "invalid continue token",
+		},
+		{
+			name:         "token with unknown fields",
+			query:        url.Values{"continue": {base64.RawURLEncoding.EncodeToString([]byte(`{"v":1,"after":"alpha","x":1}`))}},
+			expectedBody: "invalid continue token",
+		},
+		{
+			name:         "negative limit",
+			query:        url.Values{"limit": {"-1"}},
+			expectedBody: "invalid limit",
+		},
+		{
+			name:         "non-numeric limit",
+			query:        url.Values{"limit": {"ten"}},
+			expectedBody: "invalid limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockWorkloadManager := workloadsmocks.NewMockManager(ctrl)
+			mockWorkloadManager.EXPECT().ListWorkloads(gomock.Any(), false).
+				Return(workloadsNamed("alpha", "bravo"), nil).AnyTimes()
+			routes := &WorkloadRoutes{workloadManager: mockWorkloadManager}
+
+			w := listWorkloadsPage(t, routes, tt.query)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestPaginateWorkloads(t *testing.T) {
+	t.Parallel()
+
+	list := workloadsNamed("charlie", "alpha", "bravo")
+
+	page, next, err := paginateWorkloads(list, WorkloadListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, workloadsNamed("alpha", "bravo", "charlie"), page)
+	assert.Empty(t, next, "no limit returns everything without a continue token")
+
+	page, next, err = paginateWorkloads(list, WorkloadListOptions{Limit: 3})
+	require.NoError(t, err)
+	assert.Len(t, page, 3)
+	assert.Empty(t, next, "an exactly full last page has no continue token")
+
+	// A token for a workload that has since been deleted resumes after its name.
+	page, next, err = paginateWorkloads(list, WorkloadListOptions{Limit: 1, Continue: encodeContinueToken("azure")})
+	require.NoError(t, err)
+	assert.Equal(t, workloadsNamed("bravo"), page)
+	assert.Equal(t, encodeContinueToken("bravo"), next)
+
+	assert.Equal(t, workloadsNamed("charlie", "alpha", "bravo"), list, "input must not be reordered")
+}
+
+func TestDecodeContinueToken(t *testing.T) {
+	t.Parallel()
+
+	name, err := decodeContinueToken(encodeContinueToken("bravo"))
+	require.NoError(t, err)
+	assert.Equal(t, "bravo", name, "a token round-trips")
+
+	for _, token := range []string{
+		"not a token!",
+		base64.RawURLEncoding.EncodeToString([]byte("bravo")),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"v":0,"after":"bravo"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"v":1,"after":""}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"v":1,"after":"bravo"}{}`)),
+	} {
+		_, err := decodeContinueToken(token)
+		require.ErrorIs(t, err, errInvalidContinueToken, "token %q", token)
+	}
+}
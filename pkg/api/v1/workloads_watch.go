@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/core"
+	"github.com/stacklok/toolhive/pkg/workloads"
+)
+
+// defaultWorkloadWatchInterval is how often a workload watch lists workloads
+// from the runtime when WatchOptions.Interval is zero. Every listing inspects
+// all containers, so the interval trades event latency for runtime load.
+const defaultWorkloadWatchInterval = 5 * time.Second
+
+// WorkloadEventType identifies what happened to a workload.
+type WorkloadEventType string
+
+const (
+	// WorkloadEventAdded is sent for a workload that appeared, including every
+	// workload that already exists when the watch starts.
+	WorkloadEventAdded WorkloadEventType = "added"
+	// WorkloadEventModified is sent when a workload's state changed, e.g. its
+	// status went from starting to running.
+	WorkloadEventModified WorkloadEventType = "modified"
+	// WorkloadEventDeleted is sent for a workload that disappeared. Workload
+	// holds its last known state.
+	WorkloadEventDeleted WorkloadEventType = "deleted"
+)
+
+// WorkloadEvent describes a change to a workload.
+//
+//	@Description	A change to a workload, streamed by the workload watch endpoint
+type WorkloadEvent struct {
+	// Type is what happened to the workload
+	Type WorkloadEventType `json:"type" enums:"added,modified,deleted"`
+	// Workload is the state of the workload after the change
+	Workload core.Workload `json:"workload"`
+}
+
+// WatchOptions selects the workloads a watch reports on.
+type WatchOptions struct {
+	// All includes stopped workloads, like the all parameter of the list endpoint.
+	All bool
+	// Group restricts the watch to workloads in this group. Empty watches all groups.
+	Group string
+	// Interval is how often the runtime is listed. Zero uses
+	// defaultWorkloadWatchInterval.
+	Interval time.Duration
+}
+
+// workloadLister lists workloads. It is satisfied by workloads.Manager.
+type workloadLister interface {
+	ListWorkloads(ctx context.Context, listAll bool, labelFilters ...string) ([]core.Workload, error)
+}
+
+// Watch streams changes to the workloads selected by opts until ctx is
+// cancelled. The container runtimes expose no change feed, and workload
+// statuses also change outside the runtime (the status files of remote and
+// proxied workloads), so Watch lists the workloads every opts.Interval and
+// sends an event for each workload that was added, modified or deleted since
+// the previous listing. The first events report every existing workload as
+// added.
+//
+// The returned channel is closed once ctx is cancelled; the caller must keep
+// receiving until then or cancel ctx. Watch fails only if the initial listing
+// fails; later listing errors are logged and retried on the next interval.
+func (s *WorkloadRoutes) Watch(ctx context.Context, opts WatchOptions) (<-chan WorkloadEvent, error) {
+	return pollWorkloads(ctx, s.workloadManager, opts)
+}
+
+// pollWorkloads implements Watch on top of lister.
+func pollWorkloads(ctx context.Context, lister workloadLister, opts WatchOptions) (<-chan WorkloadEvent, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWorkloadWatchInterval
+	}
+
+	list := func() (map[string]core.Workload, error) {
+		workloadList, err := lister.ListWorkloads(ctx, opts.All)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Group != "" {
+			if workloadList, err = workloads.FilterByGroup(workloadList, opts.Group); err != nil {
+				return nil, err
+			}
+		}
+		snapshot := make(map[string]core.Workload, len(workloadList))
+		for _, w := range workloadList {
+			snapshot[w.Name] = w
+		}
+		return snapshot, nil
+	}
+
+	current, err := list()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	events := make(chan WorkloadEvent)
+	go func() {
+		defer close(events)
+
+		send := func(batch []WorkloadEvent) bool {
+			for _, event := range batch {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+
+		if !send(diffWorkloads(nil, current)) {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			next, err := list()
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Debug("failed to list workloads for watch", "error", err)
+				}
+				continue
+			}
+			if !send(diffWorkloads(current, next)) {
+				return
+			}
+			current = next
+		}
+	}()
+
+	return events, nil
+}
+
+// diffWorkloads returns the events turning the previous snapshot into the
+// next one, sorted by workload name within each event type.
+func diffWorkloads(previous, next map[string]core.Workload) []WorkloadEvent {
+	var added, modified, deleted []core.Workload
+	for name, w := range next {
+		old, ok := previous[name]
+		switch {
+		case !ok:
+			added = append(added, w)
+		case workloadChanged(old, w):
+			modified = append(modified, w)
+		}
+	}
+	for name, w := range previous {
+		if _, ok := next[name]; !ok {
+			deleted = append(deleted, w)
+		}
+	}
+
+	events := make([]WorkloadEvent, 0, len(added)+len(modified)+len(deleted))
+	for _, batch := range []struct {
+		eventType WorkloadEventType
+		workloads []core.Workload
+	}{
+		{WorkloadEventAdded, added},
+		{WorkloadEventModified, modified},
+		{WorkloadEventDeleted, deleted},
+	} {
+		core.SortWorkloadsByName(batch.workloads)
+		for _, w := range batch.workloads {
+			events = append(events, WorkloadEvent{Type: batch.eventType, Workload: w})
+		}
+	}
+	return events
+}
+
+// workloadChanged reports whether a workload changed in a way worth an event.
+// StatusContext is ignored: runtimes fill it with text such as "Up 5 minutes"
+// that changes on every listing without the workload changing.
+func workloadChanged(old, next core.Workload) bool {
+	return old.Status != next.Status ||
+		old.URL != next.URL ||
+		old.Port != next.Port ||
+		old.Package != next.Package ||
+		old.TransportType != next.TransportType ||
+		old.ProxyMode != next.ProxyMode ||
+		old.Group != next.Group ||
+		old.Remote != next.Remote ||
+		!old.CreatedAt.Equal(next.CreatedAt) ||
+		!old.StartedAt.Equal(next.StartedAt) ||
+		!maps.Equal(old.Labels, next.Labels) ||
+		!slices.Equal(old.ToolsFilter, next.ToolsFilter)
+}
+
+//	 watchWorkloads
+//		@Summary		Watch workloads
+//		@Description	Stream workload changes as newline-delimited JSON, starting with every existing workload as added
+//		@Tags			workloads
+//		@Produce		application/x-ndjson
+//		@Param			all	query		bool	false	"Include stopped workloads"
+//		@Param			group	query		string	false	"Only watch workloads in this group"
+//		@Success		200	{object}	WorkloadEvent	"Stream of workload events, one JSON object per line"
+//		@Failure		400	{string}	string	"Invalid group name"
+//		@Failure		404	{string}	string	"Group not found"
+//		@Router			/api/v1beta/workloads/watch [get]
+func (s *WorkloadRoutes) watchWorkloads(w http.ResponseWriter, r *http.Request) error {
+	opts := WatchOptions{
+		All:   r.URL.Query().Get("all") == "true",
+		Group: r.URL.Query().Get("group"),
+	}
+	if opts.Group != "" {
+		if err := s.checkGroupFilter(r.Context(), opts.Group); err != nil {
+			return err
+		}
+	}
+
+	// Cancelling ctx when the handler returns stops the watch goroutine even
+	// if the stream ended because a write to the client failed.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	events, err := s.Watch(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	encoder := json.NewEncoder(w)
+	for event := range events {
+		if err := encoder.Encode(event); err != nil {
+			slog.Debug("stopped streaming workload events", "error", err)
+			return nil
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
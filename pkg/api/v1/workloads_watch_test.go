@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	apierrors "github.com/stacklok/toolhive/pkg/api/errors"
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/core"
+	groupsmocks "github.com/stacklok/toolhive/pkg/groups/mocks"
+	workloadsmocks "github.com/stacklok/toolhive/pkg/workloads/mocks"
+)
+
+// fakeWorkloadSource is a workloadLister whose workloads tests change between
+// listings.
+type fakeWorkloadSource struct {
+	mu        sync.Mutex
+	workloads []core.Workload
+	err       error
+}
+
+func (f *fakeWorkloadSource) ListWorkloads(_ context.Context, _ bool, _ ...string) ([]core.Workload, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return append([]core.Workload(nil), f.workloads...), nil
+}
+
+func (f *fakeWorkloadSource) set(workloads ...core.Workload) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.workloads = workloads
+}
+
+// receiveEvent returns the next event from events, failing the test if none
+// arrives in time or the channel is closed.
+func receiveEvent(t *testing.T, events <-chan WorkloadEvent) WorkloadEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "event channel closed unexpectedly")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for workload event")
+		return WorkloadEvent{}
+	}
+}
+
+func TestPollWorkloads_DeliversEvents(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeWorkloadSource{}
+	source.set(
+		core.Workload{Name: "fetch", Status: runtime.WorkloadStatusRunning},
+		core.Workload{Name: "github", Status: runtime.WorkloadStatusStarting},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := pollWorkloads(ctx, source, WatchOptions{Interval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	// Existing workloads are reported as added, in name order.
+	assert.Equal(t,
+		WorkloadEvent{Type: WorkloadEventAdded, Workload: core.Workload{Name: "fetch", Status: runtime.WorkloadStatusRunning}},
+		receiveEvent(t, events))
+	assert.Equal(t,
+		WorkloadEvent{Type: WorkloadEventAdded, Workload: core.Workload{Name: "github", Status: runtime.WorkloadStatusStarting}},
+		receiveEvent(t, events))
+
+	// github becomes running, fetch is removed and osv is created.
+	source.set(
+		core.Workload{Name: "github", Status: runtime.WorkloadStatusRunning},
+		core.Workload{Name: "osv", Status: runtime.WorkloadStatusStarting},
+	)
+
+	assert.Equal(t,
+		WorkloadEvent{Type: WorkloadEventAdded, Workload: core.Workload{Name: "osv", Status: runtime.WorkloadStatusStarting}},
+		receiveEvent(t, events))
+	assert.Equal(t,
+		WorkloadEvent{Type: WorkloadEventModified, Workload: core.Workload{Name: "github", Status: runtime.WorkloadStatusRunning}},
+		receiveEvent(t, events))
+	assert.Equal(t,
+		WorkloadEvent{Type: WorkloadEventDeleted, Workload: core.Workload{Name: "fetch", Status: runtime.WorkloadStatusRunning}},
+		receiveEvent(t, events))
+}
+
+func TestPollWorkloads_IgnoresStatusContext(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeWorkloadSource{}
+	source.set(core.Workload{Name: "fetch", Status: runtime.WorkloadStatusRunning, StatusContext: "Up 1 minute"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := pollWorkloads(ctx, source, WatchOptions{Interval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadEventAdded, receiveEvent(t, events).Type)
+
+	// Only the uptime text changes: no event.
+	source.set(core.Workload{Name: "fetch", Status: runtime.WorkloadStatusRunning, StatusContext: "Up 2 minutes"})
+	select {
+	case event := <-events:
+		require.FailNow(t, "unexpected event for a status context change", "%+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	source.set(core.Workload{
+		Name:          "fetch",
+		Status:        runtime.WorkloadStatusRunning,
+		StatusContext: "Up 3 minutes",
+		Labels:        map[string]string{"team": "a"},
+	})
+	event := receiveEvent(t, events)
+	assert.Equal(t, WorkloadEventModified, event.Type)
+	assert.Equal(t, map[string]string{"team": "a"}, event.Workload.Labels)
+}
+
+func TestPollWorkloads_FiltersByGroup(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeWorkloadSource{}
+	source.set(core.Workload{Name: "a", Group: "dev"}, core.Workload{Name: "b", Group: "prod"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := pollWorkloads(ctx, source, WatchOptions{Group: "dev", Interval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	assert.Equal(t, "a", receiveEvent(t, events).Workload.Name)
+
+	source.set(
+		core.Workload{Name: "a", Group: "dev"},
+		core.Workload{Name: "c", Group: "dev"},
+		core.Workload{Name: "b", Group: "prod", Status: runtime.WorkloadStatusStopped},
+	)
+	event := receiveEvent(t, events)
+	assert.Equal(t, WorkloadEventAdded, event.Type)
+	assert.Equal(t, "c", event.Workload.Name, "changes outside the group must not be reported")
+}
+
+func TestPollWorkloads_ClosesOnCancel(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeWorkloadSource{}
+	source.set(core.Workload{Name: "fetch"}, core.Workload{Name: "github"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := pollWorkloads(ctx, source, WatchOptions{Interval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	// Cancel while the watcher is blocked sending the second initial event;
+	// it must give up the send and close the channel rather than leak.
+	receiveEvent(t, events)
+	cancel()
+
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-events:
+			return !ok
+		default:
+			return false
+		}
+	}, 5*time.Second, 5*time.Millisecond, "event channel must close after cancellation")
+}
+
+func TestPollWorkloads_InitialListError(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeWorkloadSource{err: errors.New("runtime unavailable")}
+
+	events, err := pollWorkloads(context.Background(), source, WatchOptions{})
+	require.ErrorContains(t, err, "runtime unavailable")
+	assert.Nil(t, events)
+}
+
+func TestWatchWorkloadsHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams workload events as NDJSON", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockWorkloadManager := workloadsmocks.NewMockManager(ctrl)
+		mockWorkloadManager.EXPECT().ListWorkloads(gomock.Any(), true).
+			Return([]core.Workload{{Name: "fetch", Status: runtime.WorkloadStatusStopped}}, nil).AnyTimes()
+		routes := &WorkloadRoutes{workloadManager: mockWorkloadManager}
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = routes.watchWorkloads(w, r)
+		}))
+		t.Cleanup(srv.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?all=true", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		scanner := bufio.NewScanner(resp.Body)
+		require.True(t, scanner.Scan(), "expected a streamed event")
+		var event WorkloadEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		assert.Equal(t, WorkloadEventAdded, event.Type)
+		assert.Equal(t, "fetch", event.Workload.Name)
+	})
+
+	t.Run("unknown group returns 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockGroupManager := groupsmocks.NewMockManager(ctrl)
+		mockGroupManager.EXPECT().Exists(gomock.Any(), "missing").Return(false, nil)
+		routes := &WorkloadRoutes{
+			workloadManager: workloadsmocks.NewMockManager(ctrl),
+			groupManager:    mockGroupManager,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/watch?group=missing", nil)
+		w := httptest.NewRecorder()
+		apierrors.ErrorHandler(routes.watchWorkloads).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/correlation"
 	"github.com/stacklok/toolhive/pkg/mcp"
 	"github.com/stacklok/toolhive/pkg/transport/types"
 )
@@ -79,13 +80,16 @@ type Auditor struct {
 	auditLogger   *slog.Logger
 	transportType string // e.g., "sse", "streamable-http"
 	logWriter     io.Writer
+	redactor      redactor
 }
 
 // NewAuditorWithTransport creates a new Auditor with the given configuration and transport information.
 func NewAuditorWithTransport(config *Config, transportType string) (*Auditor, error) {
 	var logWriter io.Writer = os.Stdout // default to stdout
+	var fields []string
 
 	if config != nil {
+		fields = config.RedactFields
 		w, err := config.GetLogWriter()
 		if err != nil {
 			// Log error and fall back to stdout
@@ -101,6 +105,7 @@ func NewAuditorWithTransport(config *Config, transportType string) (*Auditor, er
 		auditLogger:   NewAuditLogger(logWriter),
 		transportType: transportType,
 		logWriter:     logWriter,
+		redactor:      newRedactor(fields),
 	}, nil
 }
 
@@ -272,8 +277,9 @@ func (a *Auditor) logAuditEvent(r *http.Request, rw *responseWriter, requestData
 		}
 	}
 
-	// Check if we should audit this event
-	if !a.config.ShouldAuditEvent(eventType) {
+	// Check if we should audit this event before building it, so filtered-out
+	// events cost no serialization
+	if !a.config.ShouldAudit(eventType, outcome, mcp.GetMCPResourceID(r.Context())) {
 		return
 	}
 
@@ -573,6 +579,12 @@ func (a *Auditor) addMetadata(event *AuditEvent, r *http.Request, duration time.
 	if backendInfo, ok := BackendInfoFromContext(r.Context()); ok && backendInfo != nil && backendInfo.BackendName != "" {
 		event.Metadata.Extra["backend_name"] = backendInfo.BackendName
 	}
+
+	// Add the correlation ID so the event can be matched with the logs, spans,
+	// and backend requests of the same call.
+	if correlationID := correlation.FromContext(r.Context()); correlationID != "" {
+		event.Metadata.Extra[MetadataExtraKeyCorrelationID] = correlationID
+	}
 }
 
 // addEventData adds request/response data to the audit event if configured.
@@ -587,7 +599,7 @@ func (a *Auditor) addEventData(event *AuditEvent, _ *http.Request, rw *responseW
 		// Try to parse as JSON, otherwise store as string
 		var requestJSON any
 		if err := json.Unmarshal(requestData, &requestJSON); err == nil {
-			data["request"] = requestJSON
+			data["request"] = a.redactor.redact(requestJSON)
 		} else {
 			data["request"] = string(requestData)
 		}
@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/mcp"
 )
 
 func TestNewAuditor(t *testing.T) {
@@ -1040,3 +1041,88 @@ func TestAuditLoggerLevelFormat(t *testing.T) {
 		assert.NotContains(t, logOutput, `"level":"AUDIT"`)
 	})
 }
+
+func TestAuditorMiddlewareFiltersEvents(t *testing.T) {
+	t.Parallel()
+
+	toolCall := func(name string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":%q}}`, name)
+	}
+	resourceRead := `{"jsonrpc":"2.0","id":"2","method":"resources/read","params":{"uri":"file:///readme.md"}}`
+
+	tests := []struct {
+		name      string
+		config    Config
+		body      string
+		status    int
+		wantEvent string
+	}{
+		{
+			name:      "tool matching glob is written",
+			config:    Config{ToolNames: []string{"github_*"}},
+			body:      toolCall("github_create_issue"),
+			status:    http.StatusOK,
+			wantEvent: EventTypeMCPToolCall,
+		},
+		{
+			name:   "tool not matching glob is dropped",
+			config: Config{ToolNames: []string{"github_*"}},
+			body:   toolCall("gitlab_create_issue"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "excluded tool is dropped",
+			config: Config{ExcludeToolNames: []string{"*_debug"}},
+			body:   toolCall("github_debug"),
+			status: http.StatusOK,
+		},
+		{
+			name:      "non-tool event ignores tool globs",
+			config:    Config{ToolNames: []string{"github_*"}},
+			body:      resourceRead,
+			status:    http.StatusOK,
+			wantEvent: EventTypeMCPResourceRead,
+		},
+		{
+			name:      "included outcome is written",
+			config:    Config{Outcomes: []string{OutcomeDenied}},
+			body:      toolCall("github_create_issue"),
+			status:    http.StatusForbidden,
+			wantEvent: EventTypeMCPToolCall,
+		},
+		{
+			name:   "excluded outcome is dropped",
+			config: Config{ExcludeOutcomes: []string{OutcomeSuccess}},
+			body:   toolCall("github_create_issue"),
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var logBuf bytes.Buffer
+			auditor, err := NewAuditorWithTransport(&tt.config, "streamable-http")
+			require.NoError(t, err)
+			auditor.auditLogger = NewAuditLogger(&logBuf)
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			})
+			middleware := mcp.ParsingMiddleware(auditor.Middleware(handler))
+
+			req := httptest.NewRequest("POST", "/mcp", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			middleware.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.wantEvent == "" {
+				assert.Empty(t, logBuf.String(), "filtered-out events must not be written")
+				return
+			}
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(logBuf.Bytes(), &entry))
+			assert.Equal(t, tt.wantEvent, entry["type"])
+		})
+	}
+}
@@ -10,7 +10,10 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"time"
 )
 
 // Config represents the audit logging configuration.
@@ -25,13 +28,32 @@ type Config struct {
 	// Component is the component name to use in audit events.
 	// +optional
 	Component string `json:"component,omitempty" yaml:"component,omitempty"`
-	// EventTypes specifies which event types to audit. If empty, all events are audited.
+	// EventTypes specifies which event types to audit. If empty, all events are audited
+	// except the vMCP dispatch events (vmcp_tool_call, vmcp_resource_read, vmcp_prompt_get),
+	// which repeat what the MCP request events already record and must be listed explicitly.
 	// +optional
 	EventTypes []string `json:"eventTypes,omitempty" yaml:"eventTypes,omitempty"`
 	// ExcludeEventTypes specifies which event types to exclude from auditing.
 	// This takes precedence over EventTypes.
 	// +optional
 	ExcludeEventTypes []string `json:"excludeEventTypes,omitempty" yaml:"excludeEventTypes,omitempty"`
+	// Outcomes specifies which event outcomes to audit: success, failure, error,
+	// denied or application_error. If empty, events of every outcome are audited.
+	// +optional
+	Outcomes []string `json:"outcomes,omitempty" yaml:"outcomes,omitempty"`
+	// ExcludeOutcomes specifies which event outcomes to exclude from auditing.
+	// This takes precedence over Outcomes.
+	// +optional
+	ExcludeOutcomes []string `json:"excludeOutcomes,omitempty" yaml:"excludeOutcomes,omitempty"`
+	// ToolNames lists glob patterns, e.g. "github_*", for the tools whose calls are
+	// audited. It only applies to tool call events (mcp_tool_call, vmcp_tool_call);
+	// other events are not affected. If empty, calls to every tool are audited.
+	// +optional
+	ToolNames []string `json:"toolNames,omitempty" yaml:"toolNames,omitempty"`
+	// ExcludeToolNames lists glob patterns for the tools whose calls are not audited.
+	// This takes precedence over ToolNames.
+	// +optional
+	ExcludeToolNames []string `json:"excludeToolNames,omitempty" yaml:"excludeToolNames,omitempty"`
 	// IncludeRequestData determines whether to include request data in audit logs.
 	// +kubebuilder:default=false
 	// +optional
@@ -55,21 +77,48 @@ type Config struct {
 	// LogFile specifies the file path for audit logs. If empty, logs to stdout.
 	// +optional
 	LogFile string `json:"logFile,omitempty" yaml:"logFile,omitempty"`
+	// MaxLogFileSizeMB is the size in megabytes at which LogFile is rotated: it is
+	// renamed with a timestamp suffix and a new file is started. Zero disables rotation.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxLogFileSizeMB int `json:"maxLogFileSizeMB,omitempty" yaml:"maxLogFileSizeMB,omitempty"`
+	// MaxLogFileBackups is the number of rotated log files to keep. Older ones are
+	// removed on rotation. Zero keeps all of them.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxLogFileBackups int `json:"maxLogFileBackups,omitempty" yaml:"maxLogFileBackups,omitempty"`
+	// MaxLogFileAgeDays is the number of days to keep rotated log files. Older ones
+	// are removed on rotation. Zero keeps them regardless of age.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxLogFileAgeDays int `json:"maxLogFileAgeDays,omitempty" yaml:"maxLogFileAgeDays,omitempty"`
+	// RedactFields lists field names whose values are replaced with "[REDACTED]"
+	// in request data included in audit logs. Matching is case-insensitive and
+	// applies at any nesting depth, e.g. "password" redacts a tool argument
+	// named "password" as well as a nested "Password" field.
+	// +optional
+	RedactFields []string `json:"redactFields,omitempty" yaml:"redactFields,omitempty"`
 }
 
 // GetLogWriter creates and returns the appropriate io.Writer based on the configuration.
+// A LogFile is opened once per process: every writer for the same file shares one
+// file handle, which is closed when the last writer is closed. When MaxLogFileSizeMB
+// is set the shared writer also rotates the file; it must not be shared with other processes.
 func (c *Config) GetLogWriter() (io.Writer, error) {
 	if c == nil || c.LogFile == "" {
 		return os.Stdout, nil
 	}
 
 	// Clean the path to prevent directory traversal
-	file, err := os.OpenFile(filepath.Clean(c.LogFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	writer, err := openRotatingFile(filepath.Clean(c.LogFile), rotationPolicy{
+		maxSize:    int64(c.MaxLogFileSizeMB) * 1024 * 1024,
+		maxBackups: c.MaxLogFileBackups,
+		maxAge:     time.Duration(c.MaxLogFileAgeDays) * 24 * time.Hour,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to open audit log file %s: %w", c.LogFile, err)
+		return nil, err
 	}
-
-	return file, nil
+	return writer, nil
 }
 
 // DefaultConfig returns a default audit configuration.
@@ -130,21 +179,64 @@ func (c *Config) ShouldAuditEvent(eventType string) bool {
 		}
 	}
 
-	// If specific event types are configured, check if this event type is included
-	if len(c.EventTypes) > 0 {
-		found := false
-		for _, allowedType := range c.EventTypes {
-			if allowedType == eventType {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
+	// Without an explicit list, audit everything except opt-in event types
+	if len(c.EventTypes) == 0 {
+		return !optInEventTypes[eventType]
+	}
+
+	// Specific event types are configured, check if this event type is included
+	return slices.Contains(c.EventTypes, eventType)
+}
+
+// ShouldAudit determines whether an event should be audited based on its
+// type, its outcome and, for tool call events, the name of the tool. Callers
+// check it before building the event, so filtered-out events are never
+// serialized.
+func (c *Config) ShouldAudit(eventType, outcome, toolName string) bool {
+	if !c.ShouldAuditEvent(eventType) {
+		return false
+	}
+
+	if slices.Contains(c.ExcludeOutcomes, outcome) {
+		return false
+	}
+	if len(c.Outcomes) > 0 && !slices.Contains(c.Outcomes, outcome) {
+		return false
+	}
+
+	if !toolCallEventTypes[eventType] {
+		return true
+	}
+	if matchesAnyGlob(c.ExcludeToolNames, toolName) {
+		return false
+	}
+	return len(c.ToolNames) == 0 || matchesAnyGlob(c.ToolNames, toolName)
+}
+
+// toolCallEventTypes are the event types that ToolNames and ExcludeToolNames apply to.
+var toolCallEventTypes = map[string]bool{
+	EventTypeMCPToolCall:  true,
+	EventTypeVMCPToolCall: true,
+}
+
+// matchesAnyGlob reports whether name matches any of the path.Match patterns.
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
 		}
 	}
+	return false
+}
 
-	return true
+// optInEventTypes are event types that are not audited unless listed in
+// EventTypes. The vMCP dispatch events describe the same calls as the MCP
+// request events logged by the audit middleware, so enabling them by default
+// would double the audit volume for every vMCP request.
+var optInEventTypes = map[string]bool{
+	EventTypeVMCPToolCall:     true,
+	EventTypeVMCPResourceRead: true,
+	EventTypeVMCPPromptGet:    true,
 }
 
 // Validate validates the audit configuration.
@@ -158,6 +250,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("maxDataSize cannot be negative")
 	}
 
+	if err := c.validateRotation(); err != nil {
+		return err
+	}
+
 	// Validate event types (basic validation - could be extended)
 	validEventTypes := map[string]bool{
 		EventTypeMCPInitialize:       true,
@@ -181,6 +277,10 @@ func (c *Config) Validate() error {
 		EventTypeWorkflowStepCompleted: true,
 		EventTypeWorkflowStepFailed:    true,
 		EventTypeWorkflowStepSkipped:   true,
+		// vMCP dispatch event types
+		EventTypeVMCPToolCall:     true,
+		EventTypeVMCPResourceRead: true,
+		EventTypeVMCPPromptGet:    true,
 		// Fallback event types that can also be emitted by the middleware
 		EventTypeMCPRequest:  true,
 		EventTypeHTTPRequest: true,
@@ -198,5 +298,43 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	validOutcomes := []string{OutcomeSuccess, OutcomeFailure, OutcomeError, OutcomeDenied, OutcomeApplicationError}
+	for _, outcome := range c.Outcomes {
+		if !slices.Contains(validOutcomes, outcome) {
+			return fmt.Errorf("unknown outcome: %s", outcome)
+		}
+	}
+	for _, outcome := range c.ExcludeOutcomes {
+		if !slices.Contains(validOutcomes, outcome) {
+			return fmt.Errorf("unknown exclude outcome: %s", outcome)
+		}
+	}
+
+	for _, pattern := range slices.Concat(c.ToolNames, c.ExcludeToolNames) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool name pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRotation validates the log file rotation settings.
+func (c *Config) validateRotation() error {
+	if c.MaxLogFileSizeMB < 0 {
+		return fmt.Errorf("maxLogFileSizeMB cannot be negative")
+	}
+	if c.MaxLogFileBackups < 0 {
+		return fmt.Errorf("maxLogFileBackups cannot be negative")
+	}
+	if c.MaxLogFileAgeDays < 0 {
+		return fmt.Errorf("maxLogFileAgeDays cannot be negative")
+	}
+	if c.MaxLogFileSizeMB == 0 && (c.MaxLogFileBackups > 0 || c.MaxLogFileAgeDays > 0) {
+		return fmt.Errorf("maxLogFileBackups and maxLogFileAgeDays require maxLogFileSizeMB")
+	}
+	if c.MaxLogFileSizeMB > 0 && c.LogFile == "" {
+		return fmt.Errorf("maxLogFileSizeMB requires logFile")
+	}
 	return nil
 }
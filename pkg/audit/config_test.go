@@ -80,6 +80,17 @@ func TestShouldAuditEventAllEventsEnabled(t *testing.T) {
 	assert.True(t, config.ShouldAuditEvent("custom_event"))
 }
 
+func TestShouldAuditEventOptInTypes(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&Config{}).ShouldAuditEvent(EventTypeVMCPToolCall))
+	assert.False(t, (&Config{}).ShouldAuditEvent(EventTypeVMCPPromptGet))
+
+	config := &Config{EventTypes: []string{EventTypeVMCPToolCall}}
+	assert.True(t, config.ShouldAuditEvent(EventTypeVMCPToolCall))
+	assert.False(t, config.ShouldAuditEvent(EventTypeVMCPResourceRead))
+}
+
 func TestShouldAuditEventSpecificTypes(t *testing.T) {
 	t.Parallel()
 	config := &Config{
@@ -116,6 +127,108 @@ func TestShouldAuditEventExcludeTakesPrecedence(t *testing.T) {
 	assert.False(t, config.ShouldAuditEvent("mcp_resource_read")) // Not in EventTypes
 }
 
+func TestShouldAudit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		config    Config
+		eventType string
+		outcome   string
+		toolName  string
+		expected  bool
+	}{
+		{
+			name:      "no filters",
+			eventType: EventTypeMCPToolCall,
+			outcome:   OutcomeSuccess,
+			toolName:  "github_create_issue",
+			expected:  true,
+		},
+		{
+			name:      "event type filter still applies",
+			config:    Config{ExcludeEventTypes: []string{EventTypeMCPToolCall}},
+			eventType: EventTypeMCPToolCall,
+			outcome:   OutcomeFailure,
+			expected:  false,
+		},
+		{
+			name:      "outcome included",
+			config:    Config{Outcomes: []string{OutcomeFailure, OutcomeDenied}},
+			eventType: EventTypeMCPToolCall,
+			outcome:   OutcomeDenied,
+			expected:  true,
+		},
+		{
+			name:      "outcome not included",
+			config:    Config{Outcomes: []string{OutcomeFailure, OutcomeDenied}},
+			eventType: EventTypeMCPToolCall,
+			outcome:   OutcomeSuccess,
+			expected:  false,
+		},
+		{
+			name: "excluded outcome takes precedence",
+			config: Config{
+				Outcomes:        []string{OutcomeSuccess, OutcomeFailure},
+				ExcludeOutcomes: []string{OutcomeSuccess},
+			},
+			eventType: EventTypeMCPResourceRead,
+			outcome:   OutcomeSuccess,
+			expected:  false,
+		},
+		{
+			name:      "tool name matches glob",
+			config:    Config{ToolNames: []string{"github_*"}},
+			eventType: EventTypeMCPToolCall,
+			outcome:   OutcomeSuccess,
+			toolName:  "github_create_issue",
+			expected:  true,
+		},
+		{
+			name:      "tool name does not match glob",
+			config:    Config{ToolNames: []string{"github_*"}},
+			eventType: EventTypeMCPToolCall,
+			outcome:   OutcomeSuccess,
+			toolName:  "gitlab_create_issue",
+			expected:  false,
+		},
+		{
+			name: "excluded tool name takes precedence",
+			config: Config{
+				ToolNames:        []string{"github_*"},
+				ExcludeToolNames: []string{"github_*_debug"},
+			},
+			eventType: EventTypeVMCPToolCall,
+			outcome:   OutcomeSuccess,
+			toolName:  "github_issue_debug",
+			expected:  false,
+		},
+		{
+			name:      "tool name filter applies to vMCP tool calls",
+			config:    Config{EventTypes: []string{EventTypeVMCPToolCall}, ToolNames: []string{"fetch?"}},
+			eventType: EventTypeVMCPToolCall,
+			outcome:   OutcomeSuccess,
+			toolName:  "fetch2",
+			expected:  true,
+		},
+		{
+			name:      "tool name filter ignores other event types",
+			config:    Config{ToolNames: []string{"github_*"}},
+			eventType: EventTypeMCPResourceRead,
+			outcome:   OutcomeSuccess,
+			toolName:  "file:///readme.md",
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.config.ShouldAudit(tt.eventType, tt.outcome, tt.toolName))
+		})
+	}
+}
+
 func TestValidateValidConfig(t *testing.T) {
 	t.Parallel()
 	config := &Config{
@@ -176,6 +289,59 @@ func TestValidateInvalidExcludeEventType(t *testing.T) {
 	assert.Contains(t, err.Error(), "unknown exclude event type: invalid_exclude_type")
 }
 
+func TestValidateOutcomeAndToolNameFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name: "valid filters",
+			config: Config{
+				Outcomes:         []string{OutcomeFailure, OutcomeError, OutcomeApplicationError},
+				ExcludeOutcomes:  []string{OutcomeDenied},
+				ToolNames:        []string{"github_*", "fetch?"},
+				ExcludeToolNames: []string{"[a-c]*"},
+			},
+		},
+		{
+			name:    "unknown outcome",
+			config:  Config{Outcomes: []string{"ok"}},
+			wantErr: "unknown outcome: ok",
+		},
+		{
+			name:    "unknown exclude outcome",
+			config:  Config{ExcludeOutcomes: []string{"blocked"}},
+			wantErr: "unknown exclude outcome: blocked",
+		},
+		{
+			name:    "invalid tool name pattern",
+			config:  Config{ToolNames: []string{"github_["}},
+			wantErr: `invalid tool name pattern "github_["`,
+		},
+		{
+			name:    "invalid exclude tool name pattern",
+			config:  Config{ExcludeToolNames: []string{"[z-"}},
+			wantErr: `invalid tool name pattern "[z-"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestValidateAllValidEventTypes(t *testing.T) {
 	t.Parallel()
 	validEventTypes := []string{
@@ -191,6 +357,9 @@ func TestValidateAllValidEventTypes(t *testing.T) {
 		EventTypeMCPLogging,
 		EventTypeMCPCompletion,
 		EventTypeMCPRootsListChanged,
+		EventTypeVMCPToolCall,
+		EventTypeVMCPResourceRead,
+		EventTypeVMCPPromptGet,
 	}
 
 	config := &Config{
@@ -51,6 +51,14 @@ const (
 	// EventTypeWorkflowStepSkipped represents conditional step skip
 	EventTypeWorkflowStepSkipped = "vmcp_workflow_step_skipped"
 
+	// vMCP dispatch event types, emitted when vMCP dispatches a call to a backend
+	// EventTypeVMCPToolCall represents a tool call dispatched by vMCP
+	EventTypeVMCPToolCall = "vmcp_tool_call"
+	// EventTypeVMCPResourceRead represents a resource read dispatched by vMCP
+	EventTypeVMCPResourceRead = "vmcp_resource_read"
+	// EventTypeVMCPPromptGet represents a prompt fetch dispatched by vMCP
+	EventTypeVMCPPromptGet = "vmcp_prompt_get"
+
 	// Fallback event types for unrecognized or generic requests
 	// EventTypeMCPRequest represents a generic MCP request when specific type cannot be determined
 	EventTypeMCPRequest = "mcp_request"
@@ -96,6 +104,8 @@ const (
 	TargetKeyStepType = "step_type"
 	// TargetKeyToolName is the key for the tool being called (for tool steps)
 	TargetKeyToolName = "tool_name"
+	// TargetKeyBackendID is the key for the backend a vMCP call was dispatched to
+	TargetKeyBackendID = "backend_id"
 )
 
 // MCP-specific subject field keys
@@ -136,4 +146,6 @@ const (
 	MetadataExtraKeyStepCount = "step_count"
 	// MetadataExtraKeyTimeout is the key for the workflow timeout in milliseconds
 	MetadataExtraKeyTimeout = "timeout_ms"
+	// MetadataExtraKeyCorrelationID is the key for the request correlation ID in the metadata extra map
+	MetadataExtraKeyCorrelationID = "correlation_id"
 )
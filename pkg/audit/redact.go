@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import "strings"
+
+// RedactedValue replaces the value of a redacted field in audit event data.
+const RedactedValue = "[REDACTED]"
+
+// redactor replaces the values of configured field names in audit event data.
+// Names are lowercased once when the redactor is built, so matching is
+// case-insensitive without re-normalizing the configuration on every event.
+type redactor map[string]struct{}
+
+// newRedactor builds a redactor for the given field names. It returns nil,
+// which redacts nothing, when no fields are configured.
+func newRedactor(fields []string) redactor {
+	if len(fields) == 0 {
+		return nil
+	}
+	r := make(redactor, len(fields))
+	for _, f := range fields {
+		r[strings.ToLower(f)] = struct{}{}
+	}
+	return r
+}
+
+// redact returns a copy of data with the values of the configured fields
+// replaced by RedactedValue. Maps and slices are walked recursively. data is
+// never modified. When no fields are configured, data is returned unchanged.
+func (r redactor) redact(data any) any {
+	if len(r) == 0 {
+		return data
+	}
+	return redactValue(data, r)
+}
+
+func redactValue(v any, fields redactor) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			if _, ok := fields[strings.ToLower(k)]; ok {
+				out[k] = RedactedValue
+				continue
+			}
+			out[k] = redactValue(item, fields)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = redactValue(item, fields)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Redact returns a copy of data with the values of the named fields replaced
+// by RedactedValue, applying the same case-insensitive, any-depth matching as
+// Config.RedactFields. It lets other event streams honor the fields operators
+// configured for audit logs. data is never modified.
+func Redact(data any, fields []string) any {
+	return newRedactor(fields).redact(data)
+}
@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp embedded in rotated file names, e.g.
+// audit-2026-01-02T15-04-05.000000000.log. It sorts lexically in time order
+// and contains no characters that are invalid in Windows file names.
+const backupTimeFormat = "2006-01-02T15-04-05.000000000"
+
+// rotationPolicy controls when an audit log file is rotated and which rotated
+// files are kept.
+type rotationPolicy struct {
+	// maxSize is the size in bytes at which the file is rotated; zero never
+	// rotates it.
+	maxSize int64
+	// maxBackups is the number of rotated files to keep; zero keeps all.
+	maxBackups int
+	// maxAge is how long rotated files are kept; zero keeps them regardless of age.
+	maxAge time.Duration
+}
+
+// rotatingFile is an io.WriteCloser that appends to a file and, once a write
+// would take it past the policy's maximum size, renames it to a timestamped
+// backup and starts a new one. Rotated files beyond the retention policy are
+// removed after every rotation.
+//
+// Each Write is written to a single file, so records written with one Write
+// call, as the slog handlers do, are never split across files. All methods
+// are safe for concurrent use.
+type rotatingFile struct {
+	path   string
+	policy rotationPolicy
+	now    func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	// refs counts the writers returned by openRotatingFile that have not been closed.
+	refs int
+}
+
+var (
+	// rotatingFiles holds the open audit log files, keyed by path, so that every
+	// auditor in the process writing to the same file shares one writer. Two
+	// writers rotating the same file independently would each keep writing to
+	// whichever file they had open, so records would end up in backups; without
+	// rotation, sharing still keeps a single file handle per path.
+	rotatingFiles   = map[string]*rotatingFile{}
+	rotatingFilesMu sync.Mutex
+)
+
+// openRotatingFile returns a writer for the rotating file at path, sharing it
+// with any other open writer for the same path. The policy of the first open
+// writer applies until every writer for the path is closed.
+func openRotatingFile(path string, policy rotationPolicy) (*rotatingFileWriter, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve audit log file path %s: %w", path, err)
+	}
+
+	rotatingFilesMu.Lock()
+	defer rotatingFilesMu.Unlock()
+
+	f, ok := rotatingFiles[absPath]
+	if !ok {
+		f = &rotatingFile{path: absPath, policy: policy, now: time.Now}
+		if err := f.open(); err != nil {
+			return nil, err
+		}
+		rotatingFiles[absPath] = f
+	} else if f.policy != policy {
+		slog.Warn("audit log file is already open with a different rotation policy; keeping the existing policy",
+			"path", absPath)
+	}
+
+	f.mu.Lock()
+	f.refs++
+	f.mu.Unlock()
+	return &rotatingFileWriter{rotatingFile: f}, nil
+}
+
+// rotatingFileWriter is a reference to a shared rotatingFile. Closing it
+// closes the file once no other reference remains.
+type rotatingFileWriter struct {
+	*rotatingFile
+	closeOnce sync.Once
+}
+
+// Close releases this reference to the file.
+func (w *rotatingFileWriter) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		err = w.release()
+	})
+	return err
+}
+
+func (f *rotatingFile) release() error {
+	rotatingFilesMu.Lock()
+	defer rotatingFilesMu.Unlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.refs--
+	if f.refs > 0 {
+		return nil
+	}
+	delete(rotatingFiles, f.path)
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// Write appends p to the file, rotating it first if p would take it past the
+// maximum size. A write larger than the maximum size goes to a new file on its own.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.policy.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.policy.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// open opens the file for appending, creating it if needed. The caller must
+// hold f.mu or have exclusive access to f.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat audit log file %s: %w", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file to a timestamped backup, opens a new file
+// and prunes old backups. The caller must hold f.mu.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log file %s: %w", f.path, err)
+	}
+	f.file = nil
+
+	backup, err := f.backupName(f.now())
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate audit log file %s: %w", f.path, err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// Pruning failures leave extra files behind but do not lose audit records,
+	// so they are logged rather than failing the write.
+	if err := f.prune(); err != nil {
+		slog.Warn("failed to remove old audit log files", "path", f.path, "error", err)
+	}
+	return nil
+}
+
+// backupName returns an unused name for a backup of the file rotated at t.
+// If rotations happen faster than the clock's resolution, the timestamp is
+// advanced until the name is free.
+func (f *rotatingFile) backupName(t time.Time) (string, error) {
+	dir, prefix, ext := f.nameParts()
+	t = t.UTC()
+	for {
+		name := filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+		if _, err := os.Lstat(name); errors.Is(err, os.ErrNotExist) {
+			return name, nil
+		} else if err != nil {
+			return "", fmt.Errorf("failed to check audit log backup %s: %w", name, err)
+		}
+		t = t.Add(time.Nanosecond)
+	}
+}
+
+// nameParts splits the file path into the directory, the prefix shared by its
+// backups and the extension, so that backups of audit.log are named
+// audit-<timestamp>.log.
+func (f *rotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(f.path)
+	base := filepath.Base(f.path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+type backupFile struct {
+	path      string
+	rotatedAt time.Time
+}
+
+// backups returns the rotated files of f, newest first.
+func (f *rotatingFile) backups() ([]backupFile, error) {
+	dir, prefix, ext := f.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		name := entry.Name()
+		timestamp, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		timestamp, ok = strings.CutSuffix(timestamp, ext)
+		if !ok {
+			continue
+		}
+		rotatedAt, err := time.Parse(backupTimeFormat, timestamp)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), rotatedAt: rotatedAt})
+	}
+	slices.SortFunc(backups, func(a, b backupFile) int { return b.rotatedAt.Compare(a.rotatedAt) })
+	return backups, nil
+}
+
+// prune removes the backups that exceed the retention policy.
+func (f *rotatingFile) prune() error {
+	if f.policy.maxBackups <= 0 && f.policy.maxAge <= 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	cutoff := f.now().Add(-f.policy.maxAge)
+	var errs []error
+	for i, backup := range backups {
+		tooMany := f.policy.maxBackups > 0 && i >= f.policy.maxBackups
+		tooOld := f.policy.maxAge > 0 && backup.rotatedAt.Before(cutoff)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(backup.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,322 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a clock that advances by one second on every call, so
+// every rotation gets a distinct, predictable timestamp.
+func fakeClock(start time.Time) func() time.Time {
+	var mu sync.Mutex
+	now := start
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+// logFiles returns the names of the files in dir. Backups sort before the
+// current file, oldest first.
+func logFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// readEvents returns the audit IDs of every event in the given files.
+func readEvents(t *testing.T, paths ...string) []string {
+	t.Helper()
+	var ids []string
+	for _, path := range paths {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "every line must be a complete JSON record")
+			id, _ := record["audit_id"].(string)
+			ids = append(ids, id)
+		}
+		require.NoError(t, scanner.Err())
+		require.NoError(t, file.Close())
+	}
+	return ids
+}
+
+// writeEvents logs count audit events to w, with IDs prefix-0000, prefix-0001, ...
+func writeEvents(t *testing.T, w io.Writer, prefix string, count int) {
+	t.Helper()
+	logger := NewAuditLogger(w)
+	for i := range count {
+		event := NewAuditEventWithID(fmt.Sprintf("%s-%04d", prefix, i), EventTypeMCPToolCall,
+			EventSource{Type: SourceTypeNetwork, Value: "127.0.0.1"},
+			OutcomeSuccess, map[string]string{SubjectKeyUser: "alice"}, "test")
+		event.LogTo(t.Context(), logger, LevelAudit)
+	}
+}
+
+func TestRotatingFile_RotatesAndKeepsMaxBackups(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	w, err := openRotatingFile(path, rotationPolicy{maxSize: 2048, maxBackups: 2})
+	require.NoError(t, err)
+	w.now = fakeClock(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+
+	writeEvents(t, w, "event", 100)
+	require.NoError(t, w.Close())
+
+	files := logFiles(t, dir)
+	require.Len(t, files, 3, "the current file and two backups are kept: %v", files)
+	assert.Contains(t, files, "audit.log")
+
+	backups, err := (&rotatingFile{path: path}).backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.True(t, backups[0].rotatedAt.After(backups[1].rotatedAt), "backups are listed newest first")
+	for _, backup := range backups {
+		assert.Regexp(t, `^audit-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{9}\.log$`, filepath.Base(backup.path))
+		info, err := os.Stat(backup.path)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(2048))
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// The newest events are kept, in order, and the oldest were pruned.
+	ids := readEvents(t, backups[1].path, backups[0].path, path)
+	require.NotEmpty(t, ids)
+	assert.Equal(t, "event-0099", ids[len(ids)-1])
+	assert.NotContains(t, ids, "event-0000")
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i-1], ids[i])
+	}
+}
+
+func TestRotatingFile_PrunesByAge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	oldBackup := filepath.Join(dir, "audit-"+now.Add(-10*24*time.Hour).Format(backupTimeFormat)+".log")
+	recentBackup := filepath.Join(dir, "audit-"+now.Add(-24*time.Hour).Format(backupTimeFormat)+".log")
+	unrelated := filepath.Join(dir, "audit-notes.log")
+	for _, name := range []string{oldBackup, recentBackup, unrelated} {
+		require.NoError(t, os.WriteFile(name, []byte("{}\n"), 0600))
+	}
+
+	w, err := openRotatingFile(path, rotationPolicy{maxSize: 1024, maxAge: 7 * 24 * time.Hour})
+	require.NoError(t, err)
+	w.now = func() time.Time { return now }
+
+	writeEvents(t, w, "event", 10)
+	require.NoError(t, w.Close())
+
+	assert.NoFileExists(t, oldBackup, "backups older than the maximum age are removed")
+	assert.FileExists(t, recentBackup)
+	assert.FileExists(t, unrelated, "files that are not backups are left alone")
+	assert.FileExists(t, path)
+}
+
+func TestRotatingFile_RotatesExistingOversizedFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", 4096)+"\n"), 0600))
+
+	w, err := openRotatingFile(path, rotationPolicy{maxSize: 1024})
+	require.NoError(t, err)
+	writeEvents(t, w, "event", 1)
+	require.NoError(t, w.Close())
+
+	assert.Len(t, logFiles(t, dir), 2)
+	assert.Equal(t, []string{"event-0000"}, readEvents(t, path))
+}
+
+func TestRotatingFile_ConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	cfg := &Config{LogFile: path, MaxLogFileSizeMB: 1}
+
+	// Every auditor opening the file gets the same rotating writer.
+	const writers = 8
+	const eventsPerWriter = 200
+	ws := make([]io.Writer, writers)
+	for i := range ws {
+		w, err := cfg.GetLogWriter()
+		require.NoError(t, err)
+		ws[i] = w
+	}
+	shared := ws[0].(*rotatingFileWriter).rotatingFile
+	// Rotate every few events rather than every megabyte to exercise rotation
+	// under contention without writing megabytes of logs.
+	shared.mu.Lock()
+	shared.policy.maxSize = 4096
+	shared.mu.Unlock()
+	for _, w := range ws[1:] {
+		require.Same(t, shared, w.(*rotatingFileWriter).rotatingFile)
+	}
+
+	var wg sync.WaitGroup
+	for i, w := range ws {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			writeEvents(t, w, fmt.Sprintf("writer%d", i), eventsPerWriter)
+		}()
+	}
+	wg.Wait()
+	for _, w := range ws {
+		require.NoError(t, w.(io.Closer).Close())
+	}
+
+	var paths []string
+	for _, name := range logFiles(t, dir) {
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	assert.Greater(t, len(paths), 1, "the log must have been rotated")
+	ids := readEvents(t, paths...)
+	assert.Len(t, ids, writers*eventsPerWriter, "no events are lost or split across files")
+}
+
+func TestRotatingFile_CloseReleasesSharedFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	policy := rotationPolicy{maxSize: 1024}
+
+	first, err := openRotatingFile(path, policy)
+	require.NoError(t, err)
+	second, err := openRotatingFile(path, policy)
+	require.NoError(t, err)
+	require.Same(t, first.rotatingFile, second.rotatingFile)
+
+	require.NoError(t, first.Close())
+	require.NoError(t, first.Close(), "closing twice releases the reference once")
+	writeEvents(t, second, "event", 1)
+	require.NoError(t, second.Close())
+
+	rotatingFilesMu.Lock()
+	_, open := rotatingFiles[path]
+	rotatingFilesMu.Unlock()
+	assert.False(t, open, "the file is closed once every writer is closed")
+
+	third, err := openRotatingFile(path, policy)
+	require.NoError(t, err)
+	assert.NotSame(t, first.rotatingFile, third.rotatingFile)
+	require.NoError(t, third.Close())
+}
+
+func TestGetLogWriter_SharesFileWithoutRotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	config := &Config{LogFile: filepath.Join(dir, "audit.log")}
+
+	first, err := config.GetLogWriter()
+	require.NoError(t, err)
+	second, err := config.GetLogWriter()
+	require.NoError(t, err)
+	firstFile, ok := first.(*rotatingFileWriter)
+	require.True(t, ok)
+	secondFile, ok := second.(*rotatingFileWriter)
+	require.True(t, ok)
+	assert.Same(t, firstFile.rotatingFile, secondFile.rotatingFile, "the log file is opened once")
+
+	writeEvents(t, first, "first", 20)
+	writeEvents(t, second, "second", 20)
+	require.NoError(t, firstFile.Close())
+	require.NoError(t, secondFile.Close())
+
+	assert.Equal(t, []string{"audit.log"}, logFiles(t, dir), "a file is never rotated without a maximum size")
+	assert.Len(t, readEvents(t, config.LogFile), 40)
+}
+
+func TestValidateRotation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:   "rotation disabled",
+			config: Config{LogFile: "/var/log/audit.log"},
+		},
+		{
+			name: "full rotation policy",
+			config: Config{
+				LogFile:           "/var/log/audit.log",
+				MaxLogFileSizeMB:  100,
+				MaxLogFileBackups: 5,
+				MaxLogFileAgeDays: 30,
+			},
+		},
+		{
+			name:    "negative size",
+			config:  Config{LogFile: "/var/log/audit.log", MaxLogFileSizeMB: -1},
+			wantErr: "maxLogFileSizeMB cannot be negative",
+		},
+		{
+			name:    "negative backups",
+			config:  Config{LogFile: "/var/log/audit.log", MaxLogFileSizeMB: 1, MaxLogFileBackups: -1},
+			wantErr: "maxLogFileBackups cannot be negative",
+		},
+		{
+			name:    "negative age",
+			config:  Config{LogFile: "/var/log/audit.log", MaxLogFileSizeMB: 1, MaxLogFileAgeDays: -1},
+			wantErr: "maxLogFileAgeDays cannot be negative",
+		},
+		{
+			name:    "retention without size",
+			config:  Config{LogFile: "/var/log/audit.log", MaxLogFileBackups: 3},
+			wantErr: "require maxLogFileSizeMB",
+		},
+		{
+			name:    "rotation without log file",
+			config:  Config{MaxLogFileSizeMB: 10},
+			wantErr: "maxLogFileSizeMB requires logFile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/correlation"
+)
+
+// VMCPAuditor provides audit logging for the calls vMCP dispatches to its
+// backends: tool calls, resource reads and prompt fetches. Unlike the HTTP
+// middleware-based Auditor, which records what a client asked for, it records
+// which backend served the call and how it ended, including calls made by
+// embedders using the core directly. Composite tool invocations are logged
+// without a backend; the backend calls made by their steps are recorded by
+// WorkflowAuditor step events instead. Its event types are opt-in (see
+// Config.EventTypes). A nil *VMCPAuditor is valid and logs nothing.
+type VMCPAuditor struct {
+	auditLogger *slog.Logger
+	config      *Config
+	component   string
+	redactor    redactor
+}
+
+// VMCPCall describes one call dispatched by vMCP.
+type VMCPCall struct {
+	// Identity is the caller. Nil means anonymous.
+	Identity *auth.Identity
+
+	// BackendID is the backend the call was routed to. Empty when the call
+	// did not resolve to a backend (e.g. unknown name, denied, composite tool).
+	BackendID string
+
+	// Name is the tool or prompt name. Unused for resource reads.
+	Name string
+
+	// URI is the resource URI. Only used for resource reads.
+	URI string
+
+	// Arguments are the call arguments. Included in the event only when
+	// IncludeRequestData is set, after RedactFields is applied.
+	Arguments map[string]any
+
+	// Duration is how long the call took.
+	Duration time.Duration
+
+	// Outcome is the event outcome: OutcomeSuccess, OutcomeFailure (the call
+	// completed but the tool reported an error), OutcomeError (the call failed,
+	// e.g. unknown name or backend unreachable) or OutcomeDenied (authorization
+	// refused the call).
+	Outcome string
+}
+
+// NewVMCPAuditor creates a new vMCP call auditor.
+// If config is nil, creates a default configuration with stdout logging.
+func NewVMCPAuditor(config *Config) (*VMCPAuditor, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	logWriter, err := config.GetLogWriter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log writer: %w", err)
+	}
+
+	// Use configured component or default to vmcp
+	component := config.Component
+	if component == "" {
+		component = "vmcp"
+	}
+
+	return &VMCPAuditor{
+		auditLogger: NewAuditLogger(logWriter),
+		config:      config,
+		component:   component,
+		redactor:    newRedactor(config.RedactFields),
+	}, nil
+}
+
+// LogToolCall logs a tool call dispatched by vMCP.
+func (v *VMCPAuditor) LogToolCall(ctx context.Context, call VMCPCall) {
+	v.log(ctx, EventTypeVMCPToolCall, call, map[string]string{
+		TargetKeyType:   TargetTypeTool,
+		TargetKeyName:   call.Name,
+		TargetKeyMethod: "tools/call",
+	})
+}
+
+// LogResourceRead logs a resource read dispatched by vMCP.
+func (v *VMCPAuditor) LogResourceRead(ctx context.Context, call VMCPCall) {
+	v.log(ctx, EventTypeVMCPResourceRead, call, map[string]string{
+		TargetKeyType:   TargetTypeResource,
+		TargetKeyURI:    call.URI,
+		TargetKeyMethod: "resources/read",
+	})
+}
+
+// LogPromptGet logs a prompt fetch dispatched by vMCP.
+func (v *VMCPAuditor) LogPromptGet(ctx context.Context, call VMCPCall) {
+	v.log(ctx, EventTypeVMCPPromptGet, call, map[string]string{
+		TargetKeyType:   TargetTypePrompt,
+		TargetKeyName:   call.Name,
+		TargetKeyMethod: "prompts/get",
+	})
+}
+
+func (v *VMCPAuditor) log(ctx context.Context, eventType string, call VMCPCall, target map[string]string) {
+	if v == nil || !v.config.ShouldAudit(eventType, call.Outcome, target[TargetKeyName]) {
+		return
+	}
+
+	event := NewAuditEvent(
+		eventType,
+		EventSource{Type: SourceTypeLocal, Value: v.component, Extra: map[string]any{}},
+		call.Outcome,
+		v.extractSubjects(call.Identity),
+		v.component,
+	)
+
+	if call.BackendID != "" {
+		target[TargetKeyBackendID] = call.BackendID
+	}
+	event.WithTarget(target)
+
+	event.Metadata.Extra = map[string]any{
+		MetadataExtraKeyDuration: call.Duration.Milliseconds(),
+	}
+	if correlationID := correlation.FromContext(ctx); correlationID != "" {
+		event.Metadata.Extra[MetadataExtraKeyCorrelationID] = correlationID
+	}
+
+	// Add call arguments as data (if configured), redacting sensitive fields.
+	// Using same structure as HTTP auditor for consistency
+	if v.config.IncludeRequestData && call.Arguments != nil {
+		data := map[string]any{
+			"request": v.redactor.redact(call.Arguments),
+		}
+		if dataBytes, err := json.Marshal(data); err == nil {
+			rawMsg := json.RawMessage(dataBytes)
+			event.WithData(&rawMsg)
+		}
+	}
+
+	event.LogTo(ctx, v.auditLogger, LevelAudit)
+}
+
+// extractSubjects extracts subject information from the caller identity.
+func (*VMCPAuditor) extractSubjects(identity *auth.Identity) map[string]string {
+	subjects := make(map[string]string)
+	if identity != nil {
+		subjects = extractSubjectsFromIdentity(identity)
+	}
+
+	// If no user found, set anonymous
+	if subjects[SubjectKeyUser] == "" {
+		subjects[SubjectKeyUser] = "anonymous"
+	}
+
+	return subjects
+}
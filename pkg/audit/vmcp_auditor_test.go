@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/correlation"
+)
+
+// createTestVMCPAuditor creates a VMCPAuditor for testing with captured output.
+func createTestVMCPAuditor(t *testing.T, config *Config) (*VMCPAuditor, *testLogWriter) {
+	t.Helper()
+
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	writer := &testLogWriter{}
+	auditor := &VMCPAuditor{
+		auditLogger: NewAuditLogger(writer),
+		config:      config,
+		component:   "vmcp",
+		redactor:    newRedactor(config.RedactFields),
+	}
+
+	return auditor, writer
+}
+
+func TestVMCPAuditor_LogToolCall(t *testing.T) {
+	t.Parallel()
+
+	identity := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "user-123"}}
+
+	tests := []struct {
+		name        string
+		config      *Config
+		call        VMCPCall
+		wantLogged  bool
+		wantOutcome string
+		wantRequest map[string]any
+		wantUser    string
+	}{
+		{
+			name: "success with redacted arguments",
+			config: &Config{
+				EventTypes:         []string{EventTypeVMCPToolCall},
+				IncludeRequestData: true,
+				RedactFields:       []string{"password", "API_KEY"},
+			},
+			call: VMCPCall{
+				Identity:  identity,
+				BackendID: "github",
+				Name:      "github_create_issue",
+				Arguments: map[string]any{
+					"title":    "bug",
+					"password": "hunter2",
+					"auth":     map[string]any{"api_key": "secret", "scope": "repo"},
+				},
+				Duration: 150 * time.Millisecond,
+				Outcome:  OutcomeSuccess,
+			},
+			wantLogged:  true,
+			wantOutcome: OutcomeSuccess,
+			wantRequest: map[string]any{
+				"title":    "bug",
+				"password": RedactedValue,
+				"auth":     map[string]any{"api_key": RedactedValue, "scope": "repo"},
+			},
+		},
+		{
+			name:   "tool error without request data",
+			config: &Config{EventTypes: []string{EventTypeVMCPToolCall}},
+			call: VMCPCall{
+				Identity:  identity,
+				BackendID: "github",
+				Name:      "github_create_issue",
+				Arguments: map[string]any{"title": "bug"},
+				Outcome:   OutcomeFailure,
+			},
+			wantLogged:  true,
+			wantOutcome: OutcomeFailure,
+		},
+		{
+			name:   "unrouted call by anonymous caller",
+			config: &Config{EventTypes: []string{EventTypeVMCPToolCall}},
+			call: VMCPCall{
+				Name:    "unknown_tool",
+				Outcome: OutcomeError,
+			},
+			wantLogged:  true,
+			wantOutcome: OutcomeError,
+			wantUser:    "anonymous",
+		},
+		{
+			name:       "not logged unless opted in",
+			config:     &Config{},
+			call:       VMCPCall{Name: "github_create_issue", Outcome: OutcomeSuccess},
+			wantLogged: false,
+		},
+		{
+			name: "filtered out by config",
+			config: &Config{
+				EventTypes:        []string{EventTypeVMCPToolCall},
+				ExcludeEventTypes: []string{EventTypeVMCPToolCall},
+			},
+			call:       VMCPCall{Name: "github_create_issue", Outcome: OutcomeSuccess},
+			wantLogged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auditor, writer := createTestVMCPAuditor(t, tt.config)
+			auditor.LogToolCall(context.Background(), tt.call)
+
+			if !tt.wantLogged {
+				assert.Empty(t, writer.logs, "expected no logs")
+				return
+			}
+
+			require.NotEmpty(t, writer.logs, "expected log entry")
+			entry := parseLogEntry(t, writer.getLastLog())
+
+			assert.Equal(t, EventTypeVMCPToolCall, entry["type"])
+			assert.Equal(t, "vmcp", entry["component"])
+			assert.Equal(t, tt.wantOutcome, entry["outcome"])
+
+			target, ok := entry["target"].(map[string]any)
+			require.True(t, ok, "target should be a map")
+			assert.Equal(t, TargetTypeTool, target[TargetKeyType])
+			assert.Equal(t, tt.call.Name, target[TargetKeyName])
+			if tt.call.BackendID != "" {
+				assert.Equal(t, tt.call.BackendID, target[TargetKeyBackendID])
+			} else {
+				assert.NotContains(t, target, TargetKeyBackendID)
+			}
+
+			subjects, ok := entry["subjects"].(map[string]any)
+			require.True(t, ok, "subjects should be a map")
+			if tt.call.Identity != nil {
+				assert.Equal(t, tt.call.Identity.Subject, subjects[SubjectKeyUserID])
+			}
+			if tt.wantUser != "" {
+				assert.Equal(t, tt.wantUser, subjects[SubjectKeyUser])
+			}
+
+			metadata, ok := entry["metadata"].(map[string]any)
+			require.True(t, ok, "metadata should be a map")
+			extra, ok := metadata["extra"].(map[string]any)
+			require.True(t, ok, "metadata.extra should be a map")
+			assert.Equal(t, float64(tt.call.Duration.Milliseconds()), extra[MetadataExtraKeyDuration])
+
+			if tt.wantRequest != nil {
+				data, ok := entry["data"].(map[string]any)
+				require.True(t, ok, "data should be a map")
+				assert.Equal(t, tt.wantRequest, data["request"])
+			} else {
+				assert.NotContains(t, entry, "data")
+			}
+		})
+	}
+}
+
+func TestVMCPAuditor_LogResourceReadAndPromptGet(t *testing.T) {
+	t.Parallel()
+
+	auditor, writer := createTestVMCPAuditor(t, &Config{
+		EventTypes: []string{EventTypeVMCPResourceRead, EventTypeVMCPPromptGet},
+	})
+
+	auditor.LogResourceRead(context.Background(), VMCPCall{
+		BackendID: "docs",
+		URI:       "file:///readme.md",
+		Outcome:   OutcomeSuccess,
+	})
+	entry := parseLogEntry(t, writer.getLastLog())
+	assert.Equal(t, EventTypeVMCPResourceRead, entry["type"])
+	target := entry["target"].(map[string]any)
+	assert.Equal(t, TargetTypeResource, target[TargetKeyType])
+	assert.Equal(t, "file:///readme.md", target[TargetKeyURI])
+	assert.Equal(t, "docs", target[TargetKeyBackendID])
+
+	auditor.LogPromptGet(context.Background(), VMCPCall{
+		BackendID: "docs",
+		Name:      "summarize",
+		Outcome:   OutcomeDenied,
+	})
+	entry = parseLogEntry(t, writer.getLastLog())
+	assert.Equal(t, EventTypeVMCPPromptGet, entry["type"])
+	assert.Equal(t, OutcomeDenied, entry["outcome"])
+	target = entry["target"].(map[string]any)
+	assert.Equal(t, TargetTypePrompt, target[TargetKeyType])
+	assert.Equal(t, "summarize", target[TargetKeyName])
+}
+
+func TestVMCPAuditor_CorrelationID(t *testing.T) {
+	t.Parallel()
+
+	auditor, writer := createTestVMCPAuditor(t, &Config{
+		EventTypes: []string{EventTypeVMCPToolCall},
+	})
+
+	ctx := correlation.WithID(context.Background(), "corr-123")
+	auditor.LogToolCall(ctx, VMCPCall{BackendID: "github", Name: "tool", Outcome: OutcomeSuccess})
+	entry := parseLogEntry(t, writer.getLastLog())
+	extra := entry["metadata"].(map[string]any)["extra"].(map[string]any)
+	assert.Equal(t, "corr-123", extra[MetadataExtraKeyCorrelationID])
+
+	auditor.LogToolCall(context.Background(), VMCPCall{BackendID: "github", Name: "tool", Outcome: OutcomeSuccess})
+	entry = parseLogEntry(t, writer.getLastLog())
+	extra = entry["metadata"].(map[string]any)["extra"].(map[string]any)
+	assert.NotContains(t, extra, MetadataExtraKeyCorrelationID)
+}
+
+func TestVMCPAuditor_FiltersByOutcomeAndToolName(t *testing.T) {
+	t.Parallel()
+
+	auditor, writer := createTestVMCPAuditor(t, &Config{
+		EventTypes:       []string{EventTypeVMCPToolCall},
+		ExcludeOutcomes:  []string{OutcomeSuccess},
+		ToolNames:        []string{"github_*"},
+		ExcludeToolNames: []string{"github_debug_*"},
+	})
+
+	calls := []VMCPCall{
+		{BackendID: "github", Name: "github_create_issue", Outcome: OutcomeFailure},
+		{BackendID: "github", Name: "github_list_issues", Outcome: OutcomeSuccess},
+		{BackendID: "gitlab", Name: "gitlab_create_issue", Outcome: OutcomeFailure},
+		{BackendID: "github", Name: "github_debug_dump", Outcome: OutcomeError},
+		{BackendID: "github", Name: "github_merge", Outcome: OutcomeDenied},
+	}
+	for _, call := range calls {
+		auditor.LogToolCall(context.Background(), call)
+	}
+
+	require.Len(t, writer.logs, 2, "only failed and denied calls to non-debug github tools are written")
+	var names []any
+	for _, log := range writer.logs {
+		names = append(names, parseLogEntry(t, log)["target"].(map[string]any)[TargetKeyName])
+	}
+	assert.Equal(t, []any{"github_create_issue", "github_merge"}, names)
+}
+
+func TestVMCPAuditor_NilIsNoop(t *testing.T) {
+	t.Parallel()
+
+	var auditor *VMCPAuditor
+	assert.NotPanics(t, func() {
+		auditor.LogToolCall(context.Background(), VMCPCall{Name: "tool"})
+		auditor.LogResourceRead(context.Background(), VMCPCall{URI: "file:///x"})
+		auditor.LogPromptGet(context.Background(), VMCPCall{Name: "prompt"})
+	})
+}
+
+func TestRedactor(t *testing.T) {
+	t.Parallel()
+
+	original := map[string]any{
+		"Token": "abc",
+		"items": []any{
+			map[string]any{"token": "def", "id": float64(1)},
+			"plain",
+		},
+	}
+
+	t.Run("redacts matching fields at any depth without mutating input", func(t *testing.T) {
+		t.Parallel()
+		got := newRedactor([]string{"token"}).redact(original)
+
+		assert.Equal(t, map[string]any{
+			"Token": RedactedValue,
+			"items": []any{
+				map[string]any{"token": RedactedValue, "id": float64(1)},
+				"plain",
+			},
+		}, got)
+		assert.Equal(t, "abc", original["Token"])
+	})
+
+	t.Run("returns data unchanged without redact fields", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, newRedactor(nil))
+		assert.Equal(t, original, newRedactor(nil).redact(original))
+	})
+}
@@ -21,6 +21,7 @@ type WorkflowAuditor struct {
 	auditLogger *slog.Logger
 	config      *Config
 	component   string
+	redactor    redactor
 }
 
 // NewWorkflowAuditor creates a new workflow auditor.
@@ -45,6 +46,7 @@ func NewWorkflowAuditor(config *Config) (*WorkflowAuditor, error) {
 		auditLogger: NewAuditLogger(logWriter),
 		config:      config,
 		component:   component,
+		redactor:    newRedactor(config.RedactFields),
 	}, nil
 }
 
@@ -56,7 +58,7 @@ func (w *WorkflowAuditor) LogWorkflowStarted(
 	parameters map[string]any,
 	timeout time.Duration,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowStarted) {
+	if !w.config.ShouldAudit(EventTypeWorkflowStarted, OutcomeSuccess, "") {
 		return
 	}
 
@@ -87,7 +89,7 @@ func (w *WorkflowAuditor) LogWorkflowStarted(
 	// Using same structure as HTTP auditor for consistency
 	if w.config.IncludeRequestData && parameters != nil {
 		data := map[string]any{
-			"request": parameters,
+			"request": w.redactor.redact(parameters),
 		}
 		if dataBytes, err := json.Marshal(data); err == nil {
 			rawMsg := json.RawMessage(dataBytes)
@@ -107,7 +109,7 @@ func (w *WorkflowAuditor) LogWorkflowCompleted(
 	stepCount int,
 	output map[string]any,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowCompleted) {
+	if !w.config.ShouldAudit(EventTypeWorkflowCompleted, OutcomeSuccess, "") {
 		return
 	}
 
@@ -159,7 +161,7 @@ func (w *WorkflowAuditor) LogWorkflowFailed(
 	stepCount int,
 	_ error,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowFailed) {
+	if !w.config.ShouldAudit(EventTypeWorkflowFailed, OutcomeFailure, "") {
 		return
 	}
 
@@ -198,7 +200,7 @@ func (w *WorkflowAuditor) LogWorkflowTimedOut(
 	duration time.Duration,
 	stepCount int,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowTimedOut) {
+	if !w.config.ShouldAudit(EventTypeWorkflowTimedOut, OutcomeFailure, "") {
 		return
 	}
 
@@ -237,7 +239,7 @@ func (w *WorkflowAuditor) LogStepStarted(
 	stepType string,
 	toolName string,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowStepStarted) {
+	if !w.config.ShouldAudit(EventTypeWorkflowStepStarted, OutcomeSuccess, "") {
 		return
 	}
 
@@ -274,7 +276,7 @@ func (w *WorkflowAuditor) LogStepCompleted(
 	duration time.Duration,
 	retryCount int,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowStepCompleted) {
+	if !w.config.ShouldAudit(EventTypeWorkflowStepCompleted, OutcomeSuccess, "") {
 		return
 	}
 
@@ -313,7 +315,7 @@ func (w *WorkflowAuditor) LogStepFailed(
 	retryCount int,
 	_ error,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowStepFailed) {
+	if !w.config.ShouldAudit(EventTypeWorkflowStepFailed, OutcomeFailure, "") {
 		return
 	}
 
@@ -350,7 +352,7 @@ func (w *WorkflowAuditor) LogStepSkipped(
 	stepID string,
 	condition string,
 ) {
-	if !w.config.ShouldAuditEvent(EventTypeWorkflowStepSkipped) {
+	if !w.config.ShouldAudit(EventTypeWorkflowStepSkipped, OutcomeSuccess, "") {
 		return
 	}
 
@@ -52,6 +52,7 @@ func createTestAuditor(t *testing.T, config *Config) (*WorkflowAuditor, *testLog
 		auditLogger: NewAuditLogger(writer),
 		config:      config,
 		component:   "vmcp-composer",
+		redactor:    newRedactor(config.RedactFields),
 	}
 
 	return auditor, writer
@@ -35,11 +35,36 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeOutcomes != nil {
+		in, out := &in.ExcludeOutcomes, &out.ExcludeOutcomes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToolNames != nil {
+		in, out := &in.ToolNames, &out.ToolNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeToolNames != nil {
+		in, out := &in.ExcludeToolNames, &out.ExcludeToolNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.DetectApplicationErrors != nil {
 		in, out := &in.DetectApplicationErrors, &out.DetectApplicationErrors
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RedactFields != nil {
+		in, out := &in.RedactFields, &out.RedactFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
@@ -58,6 +58,7 @@ type AuthServerInfo struct {
 	AuthorizationURL                  string
 	TokenURL                          string
 	RegistrationEndpoint              string
+	DeviceAuthorizationURL            string
 	ClientIDMetadataDocumentSupported bool
 }
 
@@ -520,6 +521,14 @@ type OAuthFlowConfig struct {
 	OAuthParams          map[string]string
 	ScopeParamName       string // Override scope query parameter name (e.g., "user_scope" for Slack)
 
+	// UseDeviceFlow selects the OAuth 2.0 Device Authorization Grant (RFC 8628)
+	// instead of the browser-based authorization code flow, for environments
+	// where no browser can reach the local callback server. It requires a
+	// pre-registered ClientID. DeviceAuthorizationURL is discovered from the
+	// issuer when not set.
+	UseDeviceFlow          bool
+	DeviceAuthorizationURL string
+
 	// AllowPrivateIPs permits the Dynamic Client Registration calls (discovery
 	// fetch and registration POST) to reach private/loopback/link-local
 	// addresses. Callers set it from networking.TargetIsPrivate on the remote
@@ -576,6 +585,10 @@ func PerformOAuthFlow(ctx context.Context, issuer string, config *OAuthFlowConfi
 		return nil, fmt.Errorf("OAuth flow config cannot be nil")
 	}
 
+	if config.UseDeviceFlow {
+		return performDeviceFlow(ctx, issuer, config)
+	}
+
 	// Resolve port availability before registration. DCR clients allow port fallback
 	// because the actual port is registered after selection. Pre-registered and CIMD
 	// clients require the configured port to be available as-is — it is already
@@ -954,6 +967,69 @@ func newOAuthFlow(ctx context.Context, oauthConfig *oauth.Config, config *OAuthF
 	return buildOAuthFlowResult(source, oauthConfig, tokenResult, config), nil
 }
 
+// performDeviceFlow runs the OAuth 2.0 Device Authorization Grant (RFC 8628).
+// The user completes authentication on another device, so no callback server
+// or browser is needed. Dynamic Client Registration is not attempted: a client
+// registered for the device grant must be configured.
+func performDeviceFlow(ctx context.Context, issuer string, config *OAuthFlowConfig) (*OAuthFlowResult, error) {
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("the device authorization grant requires a pre-registered OAuth client. " +
+			"Please configure one using the --remote-auth-client-id flag")
+	}
+
+	// Discover whichever endpoints were not already found by earlier discovery
+	if config.DeviceAuthorizationURL == "" || config.TokenURL == "" {
+		doc, err := oauth.DiscoverOIDCEndpoints(ctx, issuer, !config.AllowPrivateIPs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover device authorization endpoint: %w", err)
+		}
+		if config.DeviceAuthorizationURL == "" {
+			config.DeviceAuthorizationURL = doc.DeviceAuthorizationEndpoint
+		}
+		if config.TokenURL == "" {
+			config.TokenURL = doc.TokenEndpoint
+		}
+	}
+	if config.DeviceAuthorizationURL == "" {
+		return nil, fmt.Errorf("authorization server %s does not support the device authorization grant (RFC 8628)", issuer)
+	}
+
+	oauthConfig := &oauth.Config{
+		ClientID:       config.ClientID,
+		ClientSecret:   config.ClientSecret,
+		AuthURL:        config.AuthorizeURL,
+		TokenURL:       config.TokenURL,
+		DeviceAuthURL:  config.DeviceAuthorizationURL,
+		Scopes:         config.Scopes,
+		Resource:       config.Resource,
+		ScopeParamName: config.ScopeParamName,
+	}
+	flow, err := oauth.NewDeviceFlow(oauthConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OAuth device flow: %w", err)
+	}
+
+	oauthTimeout := config.Timeout
+	if oauthTimeout <= 0 {
+		oauthTimeout = DefaultOAuthTimeout
+	}
+
+	oauthCtx, cancel := context.WithTimeout(ctx, oauthTimeout)
+	defer cancel()
+
+	tokenResult, err := flow.StartDevice(oauthCtx)
+	if err != nil {
+		if errors.Is(oauthCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("OAuth device flow timed out after %v - user did not complete authentication", oauthTimeout)
+		}
+		return nil, fmt.Errorf("OAuth device flow failed: %w", err)
+	}
+
+	slog.Debug("OAuth device authentication successful")
+
+	return buildOAuthFlowResult(flow.TokenSource(), oauthConfig, tokenResult, config), nil
+}
+
 func buildOAuthFlowResult(
 	tokenSource oauth2.TokenSource,
 	oauthConfig *oauth.Config,
@@ -977,6 +1053,26 @@ func buildOAuthFlowResult(
 	}
 }
 
+// ResourceMetadataValidators are the HTTP cache validators of a protected
+// resource metadata response. Sending them back lets the server answer a
+// revalidation with 304 Not Modified instead of the full document.
+type ResourceMetadataValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// ResourceMetadataResult is the result of a conditional protected resource
+// metadata fetch.
+type ResourceMetadataResult struct {
+	// Metadata is the fetched metadata. It is nil when NotModified is set.
+	Metadata *auth.RFC9728AuthInfo
+	// Validators are the validators to use for the next revalidation.
+	Validators ResourceMetadataValidators
+	// NotModified reports that the server confirmed the previously fetched
+	// metadata is still current.
+	NotModified bool
+}
+
 // FetchResourceMetadata fetches RFC 9728 protected-resource metadata from a
 // server-supplied URL.
 //
@@ -988,6 +1084,23 @@ func buildOAuthFlowResult(
 // public; when the operator deliberately targets an internal network it is
 // false so legitimately-internal auth metadata stays reachable.
 func FetchResourceMetadata(ctx context.Context, metadataURL string, blockPrivateIPs bool) (*auth.RFC9728AuthInfo, error) {
+	result, err := FetchResourceMetadataConditional(ctx, metadataURL, blockPrivateIPs, ResourceMetadataValidators{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Metadata, nil
+}
+
+// FetchResourceMetadataConditional behaves like FetchResourceMetadata, but
+// sends validators from a previous fetch as If-None-Match / If-Modified-Since
+// so the server can confirm the metadata is unchanged without resending it.
+// With empty validators it is an unconditional fetch.
+func FetchResourceMetadataConditional(
+	ctx context.Context,
+	metadataURL string,
+	blockPrivateIPs bool,
+	validators ResourceMetadataValidators,
+) (*ResourceMetadataResult, error) {
 	if metadataURL == "" {
 		return nil, fmt.Errorf("metadata URL is empty")
 	}
@@ -1026,6 +1139,12 @@ func FetchResourceMetadata(ctx context.Context, metadataURL string, blockPrivate
 	}
 
 	req.Header.Set("Accept", "application/json")
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
 
 	// #nosec G704 -- metadataURL is server-controlled; the client refuses cross-host and
 	// HTTPS->HTTP redirects (networking.SameHostRedirectPolicy) to contain SSRF.
@@ -1039,6 +1158,10 @@ func FetchResourceMetadata(ctx context.Context, metadataURL string, blockPrivate
 		}
 	}()
 
+	if resp.StatusCode == http.StatusNotModified && validators != (ResourceMetadataValidators{}) {
+		return &ResourceMetadataResult{Validators: validators, NotModified: true}, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("metadata request failed with status %d", resp.StatusCode)
 	}
@@ -1062,7 +1185,13 @@ func FetchResourceMetadata(ctx context.Context, metadataURL string, blockPrivate
 		return nil, fmt.Errorf("metadata missing required 'resource' field")
 	}
 
-	return &metadata, nil
+	return &ResourceMetadataResult{
+		Metadata: &metadata,
+		Validators: ResourceMetadataValidators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		},
+	}, nil
 }
 
 // ValidateAndDiscoverAuthServer attempts to validate if a URL is an authorization server
@@ -1091,6 +1220,7 @@ func ValidateAndDiscoverAuthServer(ctx context.Context, potentialIssuer string,
 			AuthorizationURL:                  doc.AuthorizationEndpoint,
 			TokenURL:                          doc.TokenEndpoint,
 			RegistrationEndpoint:              doc.RegistrationEndpoint,
+			DeviceAuthorizationURL:            doc.DeviceAuthorizationEndpoint,
 			ClientIDMetadataDocumentSupported: doc.ClientIDMetadataDocumentSupported,
 		}, nil
 	}
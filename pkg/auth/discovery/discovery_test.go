@@ -6,11 +6,13 @@ package discovery
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -1362,3 +1364,123 @@ func TestBuildOAuthFlowResult_CopiesDCRRenewalMetadata(t *testing.T) {
 	assert.Equal(t, config.TokenEndpointAuthMethod, result.TokenEndpointAuthMethod)
 	assert.Equal(t, config.RegisteredCallbackPort, result.RegisteredCallbackPort)
 }
+
+// newDeviceGrantServer starts a stub authorization server whose metadata
+// advertises a device authorization endpoint when withDeviceEndpoint is set.
+// Its token endpoint answers authorization_pending once, then issues a token.
+func newDeviceGrantServer(t *testing.T, withDeviceEndpoint bool) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	var polls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		metadata := map[string]any{
+			"issuer":                                server.URL,
+			"authorization_endpoint":                server.URL + "/authorize",
+			"token_endpoint":                        server.URL + "/token",
+			"jwks_uri":                              server.URL + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		}
+		if withDeviceEndpoint {
+			metadata["device_authorization_endpoint"] = server.URL + "/device"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(metadata)
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-code",
+			"user_code":        "WXYZ-1234",
+			"verification_uri": server.URL + "/activate",
+			"expires_in":       60,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if polls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "authorization_pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "device-access-token",
+			"refresh_token": "device-refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPerformOAuthFlow_DeviceFlow(t *testing.T) {
+	t.Parallel()
+
+	server := newDeviceGrantServer(t, true)
+
+	result, err := PerformOAuthFlow(context.Background(), server.URL, &OAuthFlowConfig{
+		ClientID:        "device-client",
+		Scopes:          []string{"openid"},
+		UseDeviceFlow:   true,
+		AllowPrivateIPs: true, // loopback test server; guard would otherwise refuse to dial it
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "device-access-token", result.AccessToken)
+	assert.Equal(t, "device-refresh-token", result.RefreshToken)
+	assert.Equal(t, "device-client", result.ClientID)
+	assert.Equal(t, server.URL+"/token", result.Config.TokenURL)
+	assert.Equal(t, server.URL+"/device", result.Config.DeviceAuthURL)
+}
+
+func TestPerformOAuthFlow_DeviceFlowErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		clientID           string
+		withDeviceEndpoint bool
+		errorMsg           string
+	}{
+		{
+			name:               "no client ID",
+			withDeviceEndpoint: true,
+			errorMsg:           "--remote-auth-client-id",
+		},
+		{
+			name:     "no device authorization endpoint",
+			clientID: "device-client",
+			errorMsg: "does not support the device authorization grant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newDeviceGrantServer(t, tt.withDeviceEndpoint)
+			_, err := PerformOAuthFlow(context.Background(), server.URL, &OAuthFlowConfig{
+				ClientID:        tt.clientID,
+				UseDeviceFlow:   true,
+				AllowPrivateIPs: true,
+			})
+			require.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestValidateAndDiscoverAuthServer_DeviceAuthorizationURL(t *testing.T) {
+	t.Parallel()
+
+	server := newDeviceGrantServer(t, true)
+
+	info, err := ValidateAndDiscoverAuthServer(context.Background(), server.URL, false)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/device", info.DeviceAuthorizationURL)
+}
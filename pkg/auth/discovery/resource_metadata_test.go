@@ -176,6 +176,36 @@ func TestFetchResourceMetadata_InvalidURL(t *testing.T) {
 	}
 }
 
+func TestFetchResourceMetadataConditional(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` &&
+			r.Header.Get("If-Modified-Since") == "Wed, 01 Jan 2025 00:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 01 Jan 2025 00:00:00 GMT")
+		_, _ = w.Write([]byte(`{"resource":"https://mcp.example.com","authorization_servers":["https://auth.example.com"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	first, err := FetchResourceMetadataConditional(context.Background(), server.URL, false, ResourceMetadataValidators{})
+	require.NoError(t, err)
+	require.False(t, first.NotModified)
+	require.NotNil(t, first.Metadata)
+	assert.Equal(t, "https://mcp.example.com", first.Metadata.Resource)
+	assert.Equal(t, ResourceMetadataValidators{ETag: `"v1"`, LastModified: "Wed, 01 Jan 2025 00:00:00 GMT"}, first.Validators)
+
+	second, err := FetchResourceMetadataConditional(context.Background(), server.URL, false, first.Validators)
+	require.NoError(t, err)
+	assert.True(t, second.NotModified)
+	assert.Nil(t, second.Metadata)
+	assert.Equal(t, first.Validators, second.Validators)
+}
+
 func TestValidateAndDiscoverAuthServer(t *testing.T) {
 	t.Parallel()
 
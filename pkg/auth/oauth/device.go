@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2"
+
+	"github.com/stacklok/toolhive/pkg/oauthproto"
+)
+
+// NewDeviceFlow creates a flow for the OAuth 2.0 Device Authorization Grant
+// (RFC 8628). Unlike NewFlow it needs no callback server or redirect URL, only
+// the device authorization and token endpoints. Run it with StartDevice.
+func NewDeviceFlow(config *Config) (*Flow, error) {
+	if config == nil {
+		return nil, errors.New("OAuth config cannot be nil")
+	}
+
+	if config.ClientID == "" {
+		return nil, errors.New("client ID is required")
+	}
+
+	if config.DeviceAuthURL == "" {
+		return nil, errors.New("device authorization URL is required")
+	}
+
+	if config.TokenURL == "" {
+		return nil, errors.New("token URL is required")
+	}
+
+	// Same client authentication rules as NewFlow: public clients send their
+	// client_id in the request body.
+	authStyle := oauth2.AuthStyleInParams
+	if config.ClientSecret != "" {
+		authStyle = oauth2.AuthStyleAutoDetect
+	}
+
+	return &Flow{
+		config: config,
+		oauth2Config: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			Scopes:       config.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:       config.AuthURL,
+				TokenURL:      config.TokenURL,
+				DeviceAuthURL: config.DeviceAuthURL,
+				AuthStyle:     authStyle,
+			},
+		},
+		deviceOutput: os.Stderr,
+	}, nil
+}
+
+// StartDevice runs the device authorization grant: it requests a device code,
+// shows the user code and verification URL, then polls the token endpoint
+// until the user approves the request on another device. Polling honours the
+// interval returned by the server and backs off on slow_down responses. It
+// stops with an error when the user denies the request, the device code
+// expires, or ctx is done.
+func (f *Flow) StartDevice(ctx context.Context) (*TokenResult, error) {
+	var opts []oauth2.AuthCodeOption
+	if f.config.Resource != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("resource", f.config.Resource))
+	}
+
+	// Use an HTTP client that sets the ToolHive User-Agent for both the device
+	// authorization request and the token polling.
+	httpCtx := context.WithValue(ctx, oauth2.HTTPClient, oauthproto.NewHTTPClient())
+
+	deviceAuth, err := f.oauth2Config.DeviceAuth(httpCtx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+
+	f.printDeviceInstructions(deviceAuth)
+
+	token, err := f.oauth2Config.DeviceAccessToken(httpCtx, deviceAuth, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("device access token request failed: %w", err)
+	}
+
+	return f.processToken(ctx, token), nil
+}
+
+// printDeviceInstructions tells the user where to enter the user code.
+func (f *Flow) printDeviceInstructions(deviceAuth *oauth2.DeviceAuthResponse) {
+	out := f.deviceOutput
+	if out == nil {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, "To authenticate, open %s in a browser on any device and enter the code: %s\n",
+		deviceAuth.VerificationURI, deviceAuth.UserCode)
+	if deviceAuth.VerificationURIComplete != "" {
+		fmt.Fprintf(out, "Alternatively, open this URL to skip entering the code: %s\n", deviceAuth.VerificationURIComplete)
+	}
+	fmt.Fprintln(out, "Waiting for device authorization")
+}
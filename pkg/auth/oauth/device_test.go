@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDeviceAuthServer starts a stub authorization server for the device
+// authorization grant. Its token endpoint answers authorization_pending for
+// the first pendingPolls polls, then finalError if set, otherwise a token.
+func newDeviceAuthServer(t *testing.T, pendingPolls int32, finalError string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var polls atomic.Int32
+	writeJSON := func(w http.ResponseWriter, status int, body map[string]any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "device-client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "https://mcp.example.com", r.PostForm.Get("resource"))
+		writeJSON(w, http.StatusOK, map[string]any{
+			"device_code":               "device-code",
+			"user_code":                 "ABCD-EFGH",
+			"verification_uri":          "https://auth.example.com/device",
+			"verification_uri_complete": "https://auth.example.com/device?user_code=ABCD-EFGH",
+			"expires_in":                60,
+			"interval":                  1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "device-code", r.PostForm.Get("device_code"))
+		if polls.Add(1) <= pendingPolls {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "authorization_pending"})
+			return
+		}
+		if finalError != "" {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": finalError})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token":  "device-access-token",
+			"refresh_token": "device-refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &polls
+}
+
+func TestNewDeviceFlow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   *Config
+		errorMsg string
+	}{
+		{
+			name:     "nil config",
+			errorMsg: "OAuth config cannot be nil",
+		},
+		{
+			name:     "missing client ID",
+			config:   &Config{DeviceAuthURL: "https://auth.example.com/device", TokenURL: "https://auth.example.com/token"},
+			errorMsg: "client ID is required",
+		},
+		{
+			name:     "missing device authorization URL",
+			config:   &Config{ClientID: "client", TokenURL: "https://auth.example.com/token"},
+			errorMsg: "device authorization URL is required",
+		},
+		{
+			name:     "missing token URL",
+			config:   &Config{ClientID: "client", DeviceAuthURL: "https://auth.example.com/device"},
+			errorMsg: "token URL is required",
+		},
+		{
+			name: "valid config without authorization URL",
+			config: &Config{
+				ClientID:      "client",
+				DeviceAuthURL: "https://auth.example.com/device",
+				TokenURL:      "https://auth.example.com/token",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			flow, err := NewDeviceFlow(tt.config)
+			if tt.errorMsg != "" {
+				require.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.config.DeviceAuthURL, flow.oauth2Config.Endpoint.DeviceAuthURL)
+		})
+	}
+}
+
+func TestStartDevice_PendingThenSuccess(t *testing.T) {
+	t.Parallel()
+
+	server, polls := newDeviceAuthServer(t, 1, "")
+	flow, err := NewDeviceFlow(&Config{
+		ClientID:      "device-client",
+		DeviceAuthURL: server.URL + "/device",
+		TokenURL:      server.URL + "/token",
+		Resource:      "https://mcp.example.com",
+	})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	flow.deviceOutput = &out
+
+	result, err := flow.StartDevice(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "device-access-token", result.AccessToken)
+	assert.Equal(t, "device-refresh-token", result.RefreshToken)
+	assert.Equal(t, int32(2), polls.Load(), "the token endpoint must be polled until authorization completes")
+	assert.Contains(t, out.String(), "ABCD-EFGH")
+	assert.Contains(t, out.String(), "https://auth.example.com/device")
+	require.NotNil(t, flow.TokenSource())
+}
+
+func TestStartDevice_AccessDenied(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newDeviceAuthServer(t, 0, "access_denied")
+	flow, err := NewDeviceFlow(&Config{
+		ClientID:      "device-client",
+		DeviceAuthURL: server.URL + "/device",
+		TokenURL:      server.URL + "/token",
+		Resource:      "https://mcp.example.com",
+	})
+	require.NoError(t, err)
+	flow.deviceOutput = &bytes.Buffer{}
+
+	_, err = flow.StartDevice(context.Background())
+	require.ErrorContains(t, err, "access_denied")
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"html"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
@@ -44,6 +45,10 @@ type Config struct {
 	// TokenURL is the token endpoint URL
 	TokenURL string
 
+	// DeviceAuthURL is the device authorization endpoint URL (RFC 8628), used
+	// only by flows created with NewDeviceFlow
+	DeviceAuthURL string
+
 	// Scopes are the OAuth scopes to request
 	Scopes []string
 
@@ -83,6 +88,9 @@ type Flow struct {
 	state         string
 
 	tokenSource oauth2.TokenSource
+
+	// deviceOutput receives the user code instructions of a device flow
+	deviceOutput io.Writer
 }
 
 // TokenResult contains the result of the OAuth flow
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"os"
+	"runtime"
+)
+
+// browserAvailable reports whether a browser can plausibly be opened on this
+// machine to complete the authorization code flow. macOS and Windows are
+// assumed to have one unless the process runs in an SSH session; other
+// systems need a graphical session.
+func browserAvailable() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return os.Getenv("SSH_CONNECTION") == "" && os.Getenv("SSH_TTY") == ""
+	default:
+		return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+	}
+}
@@ -27,6 +27,12 @@ type Config struct {
 	CallbackPort     int           `json:"callback_port,omitempty" yaml:"callback_port,omitempty"`
 	UsePKCE          bool          `json:"use_pkce" yaml:"use_pkce"`
 
+	// UseDeviceFlow selects the OAuth 2.0 Device Authorization Grant (RFC 8628)
+	// instead of the browser-based flow. The device flow is also used
+	// automatically when no browser is available and the authorization server
+	// supports it.
+	UseDeviceFlow bool `json:"use_device_flow,omitempty" yaml:"use_device_flow,omitempty"`
+
 	// Resource is the OAuth 2.0 resource indicator (RFC 8707).
 	Resource string `json:"resource,omitempty" yaml:"resource,omitempty"`
 
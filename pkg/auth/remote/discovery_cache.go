@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/auth/discovery"
+)
+
+// DefaultDiscoveryCacheTTL is how long discovered protected resource metadata
+// (RFC 9728) and authorization server metadata (RFC 8414) are reused before
+// they are revalidated.
+const DefaultDiscoveryCacheTTL = 10 * time.Minute
+
+// sharedDiscoveryCache is used by every Handler created with NewHandler, so
+// reconnecting to a remote server skips discovery even when the connection is
+// re-established through a new Handler.
+var sharedDiscoveryCache = newDiscoveryCache(DefaultDiscoveryCacheTTL)
+
+// resourceMetadataEntry is a cached protected resource metadata document
+// together with the authorization server resolved from it.
+type resourceMetadataEntry struct {
+	metadata       *auth.RFC9728AuthInfo
+	validators     discovery.ResourceMetadataValidators
+	authServerInfo *discovery.AuthServerInfo
+	fetchedAt      time.Time
+}
+
+// authServerEntry is cached authorization server metadata.
+type authServerEntry struct {
+	info      *discovery.AuthServerInfo
+	fetchedAt time.Time
+}
+
+// discoveryCache caches successful discovery results for a TTL. Failed
+// discovery is never cached, and a failed revalidation evicts the entry, so an
+// error is retried on the next call. A nil *discoveryCache performs every
+// lookup without caching.
+type discoveryCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu          sync.Mutex
+	resources   map[string]*resourceMetadataEntry
+	authServers map[string]*authServerEntry
+}
+
+func newDiscoveryCache(ttl time.Duration) *discoveryCache {
+	return &discoveryCache{
+		ttl:         ttl,
+		now:         time.Now,
+		resources:   make(map[string]*resourceMetadataEntry),
+		authServers: make(map[string]*authServerEntry),
+	}
+}
+
+// discoveryCacheKey keys an entry by URL and SSRF policy, so a lookup that
+// blocks private addresses never reuses a result fetched without the guard.
+func discoveryCacheKey(url string, blockPrivateIPs bool) string {
+	return fmt.Sprintf("%s|%t", url, blockPrivateIPs)
+}
+
+// resourceMetadata returns the protected resource metadata at metadataURL and
+// the first valid authorization server it lists. A fresh entry is returned as
+// is. A stale entry with HTTP validators is revalidated with a conditional
+// request, and reused without new authorization server discovery if the
+// server answers 304 Not Modified. resolve picks the authorization server from
+// newly fetched metadata; the result is cached only if it finds one.
+func (c *discoveryCache) resourceMetadata(
+	ctx context.Context,
+	metadataURL string,
+	blockPrivateIPs bool,
+	resolve func(*auth.RFC9728AuthInfo) *discovery.AuthServerInfo,
+) (*auth.RFC9728AuthInfo, *discovery.AuthServerInfo, error) {
+	if c == nil {
+		metadata, err := discovery.FetchResourceMetadata(ctx, metadataURL, blockPrivateIPs)
+		if err != nil {
+			return nil, nil, err
+		}
+		return metadata, resolve(metadata), nil
+	}
+
+	key := discoveryCacheKey(metadataURL, blockPrivateIPs)
+	c.mu.Lock()
+	cached := c.resources[key]
+	c.mu.Unlock()
+
+	var validators discovery.ResourceMetadataValidators
+	if cached != nil {
+		if c.now().Sub(cached.fetchedAt) < c.ttl {
+			return cached.metadata, copyAuthServerInfo(cached.authServerInfo), nil
+		}
+		validators = cached.validators
+	}
+
+	result, err := discovery.FetchResourceMetadataConditional(ctx, metadataURL, blockPrivateIPs, validators)
+	if err != nil {
+		c.evictResource(key)
+		return nil, nil, err
+	}
+
+	if result.NotModified {
+		c.mu.Lock()
+		c.resources[key] = &resourceMetadataEntry{
+			metadata:       cached.metadata,
+			validators:     result.Validators,
+			authServerInfo: cached.authServerInfo,
+			fetchedAt:      c.now(),
+		}
+		c.mu.Unlock()
+		return cached.metadata, copyAuthServerInfo(cached.authServerInfo), nil
+	}
+
+	authServerInfo := resolve(result.Metadata)
+	if authServerInfo == nil {
+		c.evictResource(key)
+		return result.Metadata, nil, nil
+	}
+
+	c.mu.Lock()
+	c.resources[key] = &resourceMetadataEntry{
+		metadata:       result.Metadata,
+		validators:     result.Validators,
+		authServerInfo: authServerInfo,
+		fetchedAt:      c.now(),
+	}
+	c.mu.Unlock()
+	return result.Metadata, copyAuthServerInfo(authServerInfo), nil
+}
+
+// authServer validates issuer as an authorization server, reusing a result
+// cached within the TTL.
+func (c *discoveryCache) authServer(
+	ctx context.Context,
+	issuer string,
+	blockPrivateIPs bool,
+) (*discovery.AuthServerInfo, error) {
+	if c == nil {
+		return discovery.ValidateAndDiscoverAuthServer(ctx, issuer, blockPrivateIPs)
+	}
+
+	key := discoveryCacheKey(issuer, blockPrivateIPs)
+	c.mu.Lock()
+	cached := c.authServers[key]
+	c.mu.Unlock()
+	if cached != nil && c.now().Sub(cached.fetchedAt) < c.ttl {
+		return copyAuthServerInfo(cached.info), nil
+	}
+
+	info, err := discovery.ValidateAndDiscoverAuthServer(ctx, issuer, blockPrivateIPs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		delete(c.authServers, key)
+		return nil, err
+	}
+	c.authServers[key] = &authServerEntry{info: info, fetchedAt: c.now()}
+	return copyAuthServerInfo(info), nil
+}
+
+func (c *discoveryCache) evictResource(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.resources, key)
+}
+
+// copyAuthServerInfo returns a copy of info so callers cannot modify a cached
+// entry.
+func copyAuthServerInfo(info *discovery.AuthServerInfo) *discovery.AuthServerInfo {
+	if info == nil {
+		return nil
+	}
+	clone := *info
+	return &clone
+}
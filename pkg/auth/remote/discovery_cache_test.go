@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discoveryStub is a remote MCP server that is also its own authorization
+// server. It counts requests to the discovery endpoints.
+type discoveryStub struct {
+	server              *httptest.Server
+	resourceFetches     atomic.Int32
+	notModified         atomic.Int32
+	authServerFetches   atomic.Int32
+	failResourceFetches atomic.Bool
+}
+
+func newDiscoveryStub(t *testing.T) *discoveryStub {
+	t.Helper()
+
+	stub := &discoveryStub{}
+	writeJSON := func(w http.ResponseWriter, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer resource_metadata="%s%s"`, stub.server.URL, resourceMetadataPath))
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc(resourceMetadataPath, func(w http.ResponseWriter, r *http.Request) {
+		stub.resourceFetches.Add(1)
+		if stub.failResourceFetches.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			stub.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		writeJSON(w, map[string]any{
+			"resource":              stub.server.URL + "/mcp",
+			"authorization_servers": []string{stub.server.URL},
+		})
+	})
+	authServerMetadata := func(w http.ResponseWriter, _ *http.Request) {
+		stub.authServerFetches.Add(1)
+		writeJSON(w, map[string]any{
+			"issuer":                   stub.server.URL,
+			"authorization_endpoint":   stub.server.URL + "/authorize",
+			"token_endpoint":           stub.server.URL + "/token",
+			"response_types_supported": []string{"code"},
+		})
+	}
+	mux.HandleFunc("/.well-known/oauth-authorization-server", authServerMetadata)
+	mux.HandleFunc("/.well-known/openid-configuration", authServerMetadata)
+	mux.HandleFunc("/token", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+
+	stub.server = httptest.NewServer(mux)
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+// discoveryRequests returns the number of discovery requests served so far.
+func (s *discoveryStub) discoveryRequests() int32 {
+	return s.resourceFetches.Load() + s.authServerFetches.Load()
+}
+
+// newCachingTestHandler returns a Handler that restores its session from a
+// cached refresh token, so Authenticate completes without a browser, and that
+// uses cache instead of the shared discovery cache.
+func newCachingTestHandler(t *testing.T, cache *discoveryCache) *Handler {
+	t.Helper()
+
+	h := NewHandler(&Config{CachedRefreshTokenRef: "refresh-ref"})
+	h.SetSecretProvider(newTestSecretProvider(t, map[string]string{"refresh-ref": "refresh-token"}))
+	h.discoveryCache = cache
+	return h
+}
+
+func TestAuthenticate_ReusesCachedDiscovery(t *testing.T) {
+	t.Parallel()
+
+	stub := newDiscoveryStub(t)
+	cache := newDiscoveryCache(time.Minute)
+
+	_, err := newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+	first := stub.discoveryRequests()
+	require.Positive(t, first)
+
+	// A new Handler for the same server, as when reconnecting, reuses the
+	// discovered metadata within the TTL.
+	_, err = newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, first, stub.discoveryRequests(), "no discovery requests are expected within the TTL")
+}
+
+func TestAuthenticate_RevalidatesStaleDiscovery(t *testing.T) {
+	t.Parallel()
+
+	stub := newDiscoveryStub(t)
+	now := time.Now()
+	cache := newDiscoveryCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, err := newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+	authServerFetches := stub.authServerFetches.Load()
+
+	// Past the TTL the metadata is revalidated with its ETag. A 304 keeps the
+	// cached authorization server, so it is not discovered again.
+	now = now.Add(2 * time.Minute)
+	_, err = newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), stub.notModified.Load(), "stale metadata must be revalidated conditionally")
+	assert.Equal(t, authServerFetches, stub.authServerFetches.Load())
+
+	// The revalidation restarted the TTL.
+	_, err = newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), stub.resourceFetches.Load())
+}
+
+func TestAuthenticate_FailedRevalidationIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	stub := newDiscoveryStub(t)
+	now := time.Now()
+	cache := newDiscoveryCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, err := newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+
+	// A failed revalidation evicts the entry rather than caching the error
+	// or serving stale metadata.
+	now = now.Add(2 * time.Minute)
+	stub.failResourceFetches.Store(true)
+	_, _ = newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	assert.Empty(t, cache.resources)
+
+	// Once the server recovers, metadata is fetched in full again.
+	stub.failResourceFetches.Store(false)
+	fetches := stub.resourceFetches.Load()
+	_, err = newCachingTestHandler(t, cache).Authenticate(context.Background(), stub.server.URL+"/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, fetches+1, stub.resourceFetches.Load())
+	assert.Len(t, cache.resources, 1)
+}
@@ -7,8 +7,11 @@
 // discovery support for remote MCP servers. It handles:
 //   - OAuth issuer discovery (RFC 8414)
 //   - Protected resource metadata (RFC 9728)
-//   - OAuth flow execution (PKCE-based)
-//   - Token source creation for HTTP transports
+//   - Caching of discovered metadata, with conditional revalidation
+//   - OAuth flow execution (PKCE-based, or the RFC 8628 device authorization
+//     grant when no browser is available)
+//   - Token source creation for HTTP transports, with proactive refresh
+//     before expiry and a ReauthRequiredError when refresh fails
 //
 // The main entry point is Handler.Authenticate() which takes a remote URL
 // and performs all necessary discovery and authentication steps.
@@ -13,6 +13,7 @@ import (
 
 	"golang.org/x/oauth2"
 
+	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/auth/discovery"
 	"github.com/stacklok/toolhive/pkg/networking"
 	"github.com/stacklok/toolhive/pkg/oauthproto"
@@ -27,12 +28,17 @@ type Handler struct {
 	clientCredentialsPersister ClientCredentialsPersister
 	secretProvider             secrets.Provider
 	httpClient                 networking.HTTPClient
+	tokenRefreshMargin         time.Duration
+	browserAvailable           func() bool
+	discoveryCache             *discoveryCache
 }
 
 // NewHandler creates a new remote authentication handler
 func NewHandler(config *Config) *Handler {
 	return &Handler{
-		config: config,
+		config:           config,
+		browserAvailable: browserAvailable,
+		discoveryCache:   sharedDiscoveryCache,
 	}
 }
 
@@ -58,6 +64,12 @@ func (h *Handler) SetHTTPClient(client networking.HTTPClient) {
 	h.httpClient = client
 }
 
+// SetTokenRefreshMargin sets how long before expiry access tokens are
+// refreshed. Zero or negative uses DefaultTokenRefreshMargin.
+func (h *Handler) SetTokenRefreshMargin(margin time.Duration) {
+	h.tokenRefreshMargin = margin
+}
+
 // Authenticate is the main entry point for remote MCP server authentication
 func (h *Handler) Authenticate(ctx context.Context, remoteURL string) (oauth2.TokenSource, error) {
 	// Priority 1: Bearer token authentication (if configured)
@@ -153,7 +165,9 @@ func (h *Handler) performOAuthFlow(
 	// Priority 2: CIMD — AS advertises support and no credentials are set; use metadata URL as client_id.
 	// Priority 3: DCR — PerformOAuthFlow handles this when ClientID is still empty after the above.
 	flowConfig := h.buildOAuthFlowConfig(scopes, authServerInfo, allowPrivateIPs)
-	if shouldUseCIMD(authServerInfo, flowConfig) {
+	if flowConfig.UseDeviceFlow {
+		slog.Debug("Using OAuth device authorization grant", "issuer", issuer)
+	} else if shouldUseCIMD(authServerInfo, flowConfig) {
 		flowConfig.ClientID = oauthproto.ToolHiveClientMetadataDocumentURL
 		slog.Debug("Using CIMD client_id", "url", oauthproto.ToolHiveClientMetadataDocumentURL)
 	}
@@ -206,10 +220,34 @@ func (h *Handler) buildOAuthFlowConfig(
 			"token", authServerInfo.TokenURL,
 			"registration", authServerInfo.RegistrationEndpoint)
 	}
+	if authServerInfo != nil {
+		flowConfig.DeviceAuthorizationURL = authServerInfo.DeviceAuthorizationURL
+	}
+	flowConfig.UseDeviceFlow = h.shouldUseDeviceFlow(flowConfig)
 
 	return flowConfig
 }
 
+// shouldUseDeviceFlow reports whether the device authorization grant should be
+// used instead of the browser-based flow: either because it is configured, or
+// because no browser is available and the authorization server advertises a
+// device authorization endpoint. The automatic fallback needs a configured
+// client ID, since clients obtained through DCR or CIMD are registered for the
+// authorization code grant only.
+func (h *Handler) shouldUseDeviceFlow(flowConfig *discovery.OAuthFlowConfig) bool {
+	if h.config.UseDeviceFlow {
+		return true
+	}
+	if flowConfig.DeviceAuthorizationURL == "" || flowConfig.ClientID == "" {
+		return false
+	}
+	if h.browserAvailable == nil || h.browserAvailable() {
+		return false
+	}
+	slog.Info("No browser available, using the OAuth device authorization grant")
+	return true
+}
+
 // wrapWithPersistence wraps the OAuth result with token persistence
 func (h *Handler) wrapWithPersistence(result *discovery.OAuthFlowResult) oauth2.TokenSource {
 	// Persist the refresh token for future restarts
@@ -248,15 +286,56 @@ func (h *Handler) wrapWithPersistence(result *discovery.OAuthFlowResult) oauth2.
 		slog.Debug("Persisted CIMD client_id for future restarts", "url", result.ClientID)
 	}
 
+	// Refresh proactively, before the access token expires, and report a
+	// failed refresh as requiring re-authentication.
+	var tokenSource oauth2.TokenSource = result.TokenSource
+	if result.Config != nil {
+		tokenSource = newRefreshingTokenSource(
+			refreshConfig(result.ClientID, result.ClientSecret, result.Config.AuthURL, result.Config.TokenURL,
+				result.Config.Scopes),
+			&oauth2.Token{
+				AccessToken:  result.AccessToken,
+				RefreshToken: result.RefreshToken,
+				Expiry:       result.Expiry,
+				TokenType:    "Bearer",
+			},
+			result.Config.Resource,
+			h.tokenRefreshMargin,
+		)
+	}
+
 	// Wrap the token source to persist refreshed tokens
-	tokenSource := result.TokenSource
 	if h.tokenPersister != nil {
-		tokenSource = NewPersistingTokenSource(result.TokenSource, h.tokenPersister)
+		tokenSource = NewPersistingTokenSource(tokenSource, h.tokenPersister)
 	}
 
 	return tokenSource
 }
 
+// refreshConfig builds the OAuth2 config used to refresh tokens.
+func refreshConfig(clientID, clientSecret, authURL, tokenURL string, scopes []string) *oauth2.Config {
+	// Public clients (no secret) must use AuthStyleInParams: strict OAuth 2.1 servers
+	// (e.g. Datadog) reject Basic Auth for token_endpoint_auth_method=none clients and
+	// consume the single-use auth code in doing so. Confidential clients (DCR or
+	// statically configured) use AutoDetect so servers that mandate
+	// client_secret_basic are not broken.
+	authStyle := oauth2.AuthStyleInParams
+	if clientSecret != "" {
+		authStyle = oauth2.AuthStyleAutoDetect
+	}
+
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   authURL,
+			TokenURL:  tokenURL,
+			AuthStyle: authStyle,
+		},
+	}
+}
+
 // resolveClientCredentials returns the client ID and secret to use, preferring
 // cached DCR credentials over statically configured ones.
 func (h *Handler) resolveClientCredentials(ctx context.Context) (clientID, clientSecret string) {
@@ -334,27 +413,8 @@ func (h *Handler) tryRestoreFromCachedTokens(
 	// Resolve client credentials - prefer cached DCR credentials over config
 	clientID, clientSecret := h.resolveClientCredentials(ctx)
 
-	// Public clients (no secret) must use AuthStyleInParams: strict OAuth 2.1 servers
-	// (e.g. Datadog) reject Basic Auth for token_endpoint_auth_method=none clients and
-	// consume the single-use auth code in doing so. Confidential clients (DCR or
-	// statically configured) use AutoDetect so servers that mandate client_secret_basic
-	// are not broken.
-	authStyle := oauth2.AuthStyleInParams
-	if clientSecret != "" {
-		authStyle = oauth2.AuthStyleAutoDetect
-	}
-
 	// Build OAuth2 config for token refresh
-	oauth2Config := &oauth2.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		Scopes:       scopes,
-		Endpoint: oauth2.Endpoint{
-			AuthURL:   h.config.AuthorizeURL,
-			TokenURL:  h.config.TokenURL,
-			AuthStyle: authStyle,
-		},
-	}
+	oauth2Config := refreshConfig(clientID, clientSecret, h.config.AuthorizeURL, h.config.TokenURL, scopes)
 
 	// Use discovered endpoints if available
 	if authServerInfo != nil {
@@ -366,13 +426,18 @@ func (h *Handler) tryRestoreFromCachedTokens(
 		}
 	}
 
-	// Create token source from cached refresh token.
-	// Passes resource for RFC 8707 compliance when configured.
-	baseSource := CreateTokenSourceFromCached(
+	// Create token source from cached refresh token. The empty access token
+	// makes the first Token call refresh. Passes resource for RFC 8707
+	// compliance when configured.
+	baseSource := newRefreshingTokenSource(
 		oauth2Config,
-		refreshToken,
-		h.config.CachedTokenExpiry,
+		&oauth2.Token{
+			RefreshToken: refreshToken,
+			Expiry:       h.config.CachedTokenExpiry,
+			TokenType:    "Bearer",
+		},
 		h.config.Resource,
+		h.tokenRefreshMargin,
 	)
 
 	// Try to get a token to verify the cached tokens are valid
@@ -417,7 +482,7 @@ func (h *Handler) discoverIssuerAndScopes(
 	// issuer is pre-configured, so CIMD detection works on this path.
 	if h.config.Issuer != "" {
 		slog.Debug("Using configured issuer", "issuer", h.config.Issuer)
-		authServerInfo, _ := discovery.ValidateAndDiscoverAuthServer(ctx, h.config.Issuer, false)
+		authServerInfo, _ := h.discoveryCache.authServer(ctx, h.config.Issuer, false)
 		return h.config.Issuer, h.config.Scopes, authServerInfo, allowPrivateIPs, nil
 	}
 
@@ -427,7 +492,7 @@ func (h *Handler) discoverIssuerAndScopes(
 		derivedIssuer := discovery.DeriveIssuerFromRealm(authInfo.Realm)
 		if derivedIssuer != "" {
 			slog.Debug("Derived issuer from realm", "issuer", derivedIssuer)
-			authServerInfo, _ := discovery.ValidateAndDiscoverAuthServer(ctx, derivedIssuer, blockPrivateIPs)
+			authServerInfo, _ := h.discoveryCache.authServer(ctx, derivedIssuer, blockPrivateIPs)
 			return derivedIssuer, h.config.Scopes, authServerInfo, allowPrivateIPs, nil
 		}
 	}
@@ -471,7 +536,14 @@ func (h *Handler) tryDiscoverFromResourceMetadata(
 ) (string, []string, *discovery.AuthServerInfo, error) {
 	slog.Debug("Fetching resource metadata", "url", resourceMetadataURL)
 
-	metadata, err := discovery.FetchResourceMetadata(ctx, resourceMetadataURL, blockPrivateIPs)
+	// Discovery results are cached per metadata URL, so reconnecting to the
+	// same server skips both the metadata and authorization server fetches.
+	metadata, authServerInfo, err := h.discoveryCache.resourceMetadata(ctx, resourceMetadataURL, blockPrivateIPs,
+		func(metadata *auth.RFC9728AuthInfo) *discovery.AuthServerInfo {
+			// Try to find a valid authorization server from the list
+			authServerInfo, _ := h.findValidAuthServer(ctx, metadata.AuthorizationServers, blockPrivateIPs)
+			return authServerInfo
+		})
 	if err != nil {
 		slog.Debug("Failed to fetch resource metadata", "error", err)
 		return "", nil, nil, fmt.Errorf("could not determine OAuth issuer")
@@ -481,8 +553,6 @@ func (h *Handler) tryDiscoverFromResourceMetadata(
 		return "", nil, nil, fmt.Errorf("could not determine OAuth issuer")
 	}
 
-	// Try to find a valid authorization server from the list
-	authServerInfo, issuer := h.findValidAuthServer(ctx, metadata.AuthorizationServers, blockPrivateIPs)
 	if authServerInfo == nil {
 		if len(metadata.AuthorizationServers) > 0 {
 			slog.Warn("Resource metadata contained authorization_servers, " +
@@ -498,11 +568,11 @@ func (h *Handler) tryDiscoverFromResourceMetadata(
 		slog.Debug("Using scopes from resource metadata", "scopes", scopes)
 	}
 
-	return issuer, scopes, authServerInfo, nil
+	return authServerInfo.Issuer, scopes, authServerInfo, nil
 }
 
 // findValidAuthServer validates authorization servers and returns the first valid one
-func (*Handler) findValidAuthServer(
+func (h *Handler) findValidAuthServer(
 	ctx context.Context,
 	authServers []string,
 	blockPrivateIPs bool,
@@ -510,7 +580,7 @@ func (*Handler) findValidAuthServer(
 	for _, authServer := range authServers {
 		slog.Debug("Validating authorization server", "server", authServer)
 
-		authServerInfo, err := discovery.ValidateAndDiscoverAuthServer(ctx, authServer, blockPrivateIPs)
+		authServerInfo, err := h.discoveryCache.authServer(ctx, authServer, blockPrivateIPs)
 		if err != nil {
 			slog.Debug("Authorization server validation failed", "server", authServer, "error", err)
 			continue
@@ -541,7 +611,7 @@ func (h *Handler) tryDiscoverFromWellKnown(
 
 	// Try to discover the actual issuer without validation
 	// This uses DiscoverActualIssuer which doesn't validate issuer match
-	authServerInfo, err := discovery.ValidateAndDiscoverAuthServer(ctx, derivedURL, blockPrivateIPs)
+	authServerInfo, err := h.discoveryCache.authServer(ctx, derivedURL, blockPrivateIPs)
 	if err != nil {
 		return "", nil, nil, fmt.Errorf("well-known discovery failed: %w", err)
 	}
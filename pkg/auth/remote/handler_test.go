@@ -1029,3 +1029,71 @@ func TestBuildOAuthFlowConfig_ThreadsAllowPrivateIPs(t *testing.T) {
 	assert.False(t, flowConfig.AllowPrivateIPs,
 		"allowPrivateIPs=false must reach OAuthFlowConfig.AllowPrivateIPs")
 }
+
+func TestBuildOAuthFlowConfig_DeviceFlow(t *testing.T) {
+	t.Parallel()
+
+	withDevice := &discovery.AuthServerInfo{
+		AuthorizationURL:       "https://auth.example.com/authorize",
+		TokenURL:               "https://auth.example.com/token",
+		DeviceAuthorizationURL: "https://auth.example.com/device",
+	}
+	withoutDevice := &discovery.AuthServerInfo{
+		AuthorizationURL: "https://auth.example.com/authorize",
+		TokenURL:         "https://auth.example.com/token",
+	}
+
+	tests := []struct {
+		name           string
+		config         *Config
+		authServerInfo *discovery.AuthServerInfo
+		hasBrowser     bool
+		wantDeviceFlow bool
+	}{
+		{
+			name:           "configured",
+			config:         &Config{UseDeviceFlow: true},
+			authServerInfo: withoutDevice,
+			hasBrowser:     true,
+			wantDeviceFlow: true,
+		},
+		{
+			name:           "browser available",
+			config:         &Config{ClientID: "client"},
+			authServerInfo: withDevice,
+			hasBrowser:     true,
+			wantDeviceFlow: false,
+		},
+		{
+			name:           "no browser and device endpoint advertised",
+			config:         &Config{ClientID: "client"},
+			authServerInfo: withDevice,
+			wantDeviceFlow: true,
+		},
+		{
+			name:           "no browser and no device endpoint",
+			config:         &Config{ClientID: "client"},
+			authServerInfo: withoutDevice,
+			wantDeviceFlow: false,
+		},
+		{
+			name:           "no browser and no client ID",
+			config:         &Config{},
+			authServerInfo: withDevice,
+			wantDeviceFlow: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHandler(tt.config)
+			h.browserAvailable = func() bool { return tt.hasBrowser }
+
+			flowConfig := h.buildOAuthFlowConfig([]string{"openid"}, tt.authServerInfo, false)
+			assert.Equal(t, tt.wantDeviceFlow, flowConfig.UseDeviceFlow)
+			assert.Equal(t, tt.authServerInfo.DeviceAuthorizationURL, flowConfig.DeviceAuthorizationURL)
+		})
+	}
+}
@@ -4,15 +4,11 @@
 package remote
 
 import (
-	"context"
 	"log/slog"
 	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
-
-	"github.com/stacklok/toolhive/pkg/auth/oauth"
-	"github.com/stacklok/toolhive/pkg/oauthproto"
 )
 
 // TokenPersister is a callback function that persists OAuth refresh tokens.
@@ -93,7 +89,8 @@ func (p *PersistingTokenSource) Token() (*oauth2.Token, error) {
 
 // CreateTokenSourceFromCached creates an oauth2.TokenSource from a cached refresh token.
 // The returned token source will immediately refresh to get a new access token,
-// then automatically refresh when it expires.
+// then refresh again DefaultTokenRefreshMargin before each access token expires.
+// A failed refresh returns a *ReauthRequiredError.
 // If resource is non-empty, it is included in all refresh requests per RFC 8707.
 func CreateTokenSourceFromCached(
 	config *oauth2.Config,
@@ -101,28 +98,14 @@ func CreateTokenSourceFromCached(
 	expiry time.Time,
 	resource string,
 ) oauth2.TokenSource {
-	// Create a token with only the refresh token.
-	// The access token is intentionally empty - ReuseTokenSource will detect
-	// that the token is expired (since Expiry is in the past or AccessToken is empty)
-	// and trigger a refresh using the refresh token.
+	// Create a token with only the refresh token. The access token is
+	// intentionally empty so the first Token call refreshes.
 	token := &oauth2.Token{
-		AccessToken:  "", // Empty - will trigger immediate refresh
+		AccessToken:  "",
 		RefreshToken: refreshToken,
 		Expiry:       expiry,
 		TokenType:    "Bearer",
 	}
 
-	// Use resource-aware token source if configured (RFC 8707)
-	var base oauth2.TokenSource
-	if resource != "" {
-		base = oauth.NewResourceTokenSource(config, token, resource)
-	} else {
-		// Inject an HTTP client whose transport sets the ToolHive User-Agent
-		// so the oauth2 library does not fall back to Go-http-client/2.0 on
-		// token refresh requests.
-		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, oauthproto.NewHTTPClient())
-		base = config.TokenSource(ctx, token)
-	}
-
-	return oauth2.ReuseTokenSource(token, base)
+	return newRefreshingTokenSource(config, token, resource, DefaultTokenRefreshMargin)
 }
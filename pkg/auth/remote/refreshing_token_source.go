@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/stacklok/toolhive/pkg/auth/oauth"
+)
+
+// DefaultTokenRefreshMargin is how long before its expiry an access token is
+// refreshed. Refreshing early keeps a request that starts just before expiry
+// from reaching the remote server with a token that expires in flight. The
+// margin is capped at half the token's lifetime, so that a short-lived token
+// is still used for a while before it is refreshed.
+const DefaultTokenRefreshMargin = 60 * time.Second
+
+// ReauthRequiredError is returned by a RefreshingTokenSource when the access
+// token could not be refreshed, e.g. because the refresh token was revoked or
+// has expired. The caller must run the OAuth flow again to get new tokens.
+type ReauthRequiredError struct {
+	// Err is the refresh failure.
+	Err error
+}
+
+// Error implements error.
+func (e *ReauthRequiredError) Error() string {
+	return fmt.Sprintf("token refresh failed, re-authentication required: %v", e.Err)
+}
+
+// Unwrap returns the refresh failure.
+func (e *ReauthRequiredError) Unwrap() error {
+	return e.Err
+}
+
+// RefreshingTokenSource is an oauth2.TokenSource that returns a cached access
+// token until it is within a margin of its expiry, then refreshes it through
+// a refresher. Concurrent callers share a single refresh: the first one
+// refreshes while the rest wait for, and reuse, its result.
+type RefreshingTokenSource struct {
+	refresher oauth2.TokenSource
+	margin    time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	token *oauth2.Token
+	// refreshedAt is when token was refreshed, or zero for the initial token.
+	refreshedAt time.Time
+}
+
+// NewRefreshingTokenSource creates a RefreshingTokenSource starting from token,
+// which may be nil or lack an access token to force a refresh on first use.
+// refresher must perform a token-endpoint refresh on every call, e.g. an
+// oauth.NonCachingRefresher. A non-positive margin uses
+// DefaultTokenRefreshMargin.
+func NewRefreshingTokenSource(token *oauth2.Token, refresher oauth2.TokenSource, margin time.Duration) *RefreshingTokenSource {
+	if margin <= 0 {
+		margin = DefaultTokenRefreshMargin
+	}
+	return &RefreshingTokenSource{
+		refresher: refresher,
+		margin:    margin,
+		now:       time.Now,
+		token:     token,
+	}
+}
+
+// Token returns the cached access token, refreshing it first if it expires
+// within the margin. A failed refresh returns a *ReauthRequiredError.
+func (s *RefreshingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fresh() {
+		return s.token, nil
+	}
+
+	token, err := s.refresher.Token()
+	if err != nil {
+		slog.Warn("OAuth token refresh failed, re-authentication required", "error", err)
+		return nil, &ReauthRequiredError{Err: err}
+	}
+	s.token = token
+	s.refreshedAt = s.now()
+	return token, nil
+}
+
+// fresh reports whether the cached token can be used without a refresh. A
+// token without an expiry never needs one.
+func (s *RefreshingTokenSource) fresh() bool {
+	if s.token == nil || s.token.AccessToken == "" {
+		return false
+	}
+	if s.token.Expiry.IsZero() {
+		return true
+	}
+	margin := s.margin
+	if lifetime := s.lifetime(); lifetime > 0 {
+		margin = min(margin, lifetime/2)
+	}
+	return s.now().Add(margin).Before(s.token.Expiry)
+}
+
+// lifetime returns how long the cached token was valid for when it was
+// issued, or zero when that is unknown.
+func (s *RefreshingTokenSource) lifetime() time.Duration {
+	if s.token.ExpiresIn > 0 {
+		return time.Duration(s.token.ExpiresIn) * time.Second
+	}
+	if !s.refreshedAt.IsZero() {
+		return s.token.Expiry.Sub(s.refreshedAt)
+	}
+	return 0
+}
+
+// newRefreshingTokenSource creates a RefreshingTokenSource that refreshes
+// token through config's token endpoint, including resource in refresh
+// requests per RFC 8707 when non-empty.
+func newRefreshingTokenSource(
+	config *oauth2.Config,
+	token *oauth2.Token,
+	resource string,
+	margin time.Duration,
+) *RefreshingTokenSource {
+	return NewRefreshingTokenSource(token, oauth.NewNonCachingRefresher(config, token.RefreshToken, resource), margin)
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/stacklok/toolhive/pkg/auth/discovery"
+	"github.com/stacklok/toolhive/pkg/auth/oauth"
+)
+
+// stubTokenEndpoint serves refresh_token grants, counting them. When fail is
+// set it rejects them as invalid_grant.
+type stubTokenEndpoint struct {
+	refreshes atomic.Int32
+	fail      atomic.Bool
+	url       string
+}
+
+func newStubTokenEndpoint(t *testing.T) *stubTokenEndpoint {
+	t.Helper()
+	stub := &stubTokenEndpoint{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		if stub.fail.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+			return
+		}
+		n := stub.refreshes.Add(1)
+		// Slow enough that concurrent callers overlap with the refresh.
+		time.Sleep(20 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  fmt.Sprintf("access-%d", n),
+			"refresh_token": "refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+	stub.url = server.URL
+	return stub
+}
+
+func (s *stubTokenEndpoint) config() *oauth2.Config {
+	return refreshConfig("client", "", "", s.url, nil)
+}
+
+func TestRefreshingTokenSource_Refresh(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		token         *oauth2.Token
+		wantRefreshes int32
+	}{
+		{
+			name:          "no access token refreshes",
+			token:         &oauth2.Token{RefreshToken: "refresh-token"},
+			wantRefreshes: 1,
+		},
+		{
+			name: "valid token is reused",
+			token: &oauth2.Token{
+				AccessToken: "cached", RefreshToken: "refresh-token", Expiry: time.Now().Add(time.Hour),
+			},
+			wantRefreshes: 0,
+		},
+		{
+			name: "token expiring within the margin refreshes early",
+			token: &oauth2.Token{
+				AccessToken: "cached", RefreshToken: "refresh-token", Expiry: time.Now().Add(30 * time.Second),
+			},
+			wantRefreshes: 1,
+		},
+		{
+			name:          "token without expiry is reused",
+			token:         &oauth2.Token{AccessToken: "cached", RefreshToken: "refresh-token"},
+			wantRefreshes: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stub := newStubTokenEndpoint(t)
+			source := newRefreshingTokenSource(stub.config(), tt.token, "", time.Minute)
+
+			for range 3 {
+				token, err := source.Token()
+				require.NoError(t, err)
+				if tt.wantRefreshes == 0 {
+					assert.Equal(t, "cached", token.AccessToken)
+				} else {
+					assert.Equal(t, "access-1", token.AccessToken)
+				}
+			}
+			assert.Equal(t, tt.wantRefreshes, stub.refreshes.Load())
+		})
+	}
+}
+
+func TestRefreshingTokenSource_ConcurrentCallersShareOneRefresh(t *testing.T) {
+	t.Parallel()
+
+	stub := newStubTokenEndpoint(t)
+	source := newRefreshingTokenSource(stub.config(), &oauth2.Token{RefreshToken: "refresh-token"}, "", time.Minute)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Token()
+			assert.NoError(t, err)
+			assert.Equal(t, "access-1", token.AccessToken)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), stub.refreshes.Load())
+}
+
+func TestRefreshingTokenSource_FailureRequiresReauth(t *testing.T) {
+	t.Parallel()
+
+	stub := newStubTokenEndpoint(t)
+	stub.fail.Store(true)
+	source := newRefreshingTokenSource(stub.config(), &oauth2.Token{
+		AccessToken: "expired", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Minute),
+	}, "", time.Minute)
+
+	_, err := source.Token()
+	var reauthErr *ReauthRequiredError
+	require.ErrorAs(t, err, &reauthErr)
+	assert.Contains(t, err.Error(), "invalid_grant")
+
+	// A recovered token endpoint is used on the next call.
+	stub.fail.Store(false)
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+}
+
+func TestRefreshingTokenSource_ShortLivedTokenIsReused(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	refresher := &countingRefresher{token: &oauth2.Token{
+		AccessToken: "short-lived", Expiry: now.Add(30 * time.Second), ExpiresIn: 30,
+	}}
+	source := NewRefreshingTokenSource(nil, refresher, DefaultTokenRefreshMargin)
+	source.now = func() time.Time { return now }
+
+	// A token living for less than the margin is refreshed once, then reused
+	// until half its lifetime is left.
+	for range 3 {
+		token, err := source.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "short-lived", token.AccessToken)
+	}
+	assert.Equal(t, 1, refresher.calls)
+
+	source.now = func() time.Time { return now.Add(16 * time.Second) }
+	_, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, 2, refresher.calls)
+}
+
+// countingRefresher returns the same token on every call, counting the calls.
+type countingRefresher struct {
+	token *oauth2.Token
+	calls int
+}
+
+func (r *countingRefresher) Token() (*oauth2.Token, error) {
+	r.calls++
+	return r.token, nil
+}
+
+func TestRefreshingTokenSource_DefaultMargin(t *testing.T) {
+	t.Parallel()
+
+	source := NewRefreshingTokenSource(nil, nil, 0)
+	assert.Equal(t, DefaultTokenRefreshMargin, source.margin)
+}
+
+func TestWrapWithPersistence_RefreshesFlowTokens(t *testing.T) {
+	t.Parallel()
+
+	stub := newStubTokenEndpoint(t)
+	handler := NewHandler(&Config{})
+	handler.SetTokenRefreshMargin(time.Minute)
+
+	source := handler.wrapWithPersistence(&discovery.OAuthFlowResult{
+		Config:       &oauth.Config{ClientID: "client", TokenURL: stub.url},
+		ClientID:     "client",
+		AccessToken:  "from-flow",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Now().Add(10 * time.Second),
+	})
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken, "a flow token expiring within the margin must be refreshed")
+	assert.Equal(t, int32(1), stub.refreshes.Load())
+}
@@ -202,6 +202,11 @@ type SigningKeyRunConfig struct {
 	// These keys are included in the JWKS endpoint for token verification but are NOT
 	// used for signing new tokens. Useful for key rotation.
 	FallbackKeyFiles []string `json:"fallback_key_files,omitempty" yaml:"fallback_key_files,omitempty"`
+
+	// FallbackKeyGracePeriod is how long fallback keys stay in the JWKS endpoint after
+	// the signing key file was last modified, as a Go duration string (e.g., "2h"). Set it
+	// to at least the access token lifespan. If empty, fallback keys are published until removed.
+	FallbackKeyGracePeriod string `json:"fallback_key_grace_period,omitempty" yaml:"fallback_key_grace_period,omitempty"`
 }
 
 // TokenLifespanRunConfig holds token lifetime configuration.
@@ -604,6 +604,76 @@ func TestIntegration_TokenEndpoint_RefreshToken(t *testing.T) {
 	require.GreaterOrEqual(t, replayResp.StatusCode, 400, "old refresh token must be rejected after rotation")
 }
 
+// TestIntegration_TokenEndpoint_RefreshTokenReuse tests that replaying a
+// rotated-away refresh token is treated as theft: the request fails with
+// invalid_grant and every refresh token of the grant is revoked, including the
+// one legitimately issued by the rotation (RFC 6819 Section 5.2.2.3).
+func TestIntegration_TokenEndpoint_RefreshTokenReuse(t *testing.T) {
+	t.Parallel()
+
+	for name, opts := range map[string][]testServerOption{
+		"memory": nil,
+		"redis":  {withRedisBackedStorage()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := startMockOIDC(t)
+			ts := setupTestServerWithMockOIDC(t, m, opts...)
+
+			verifier := servercrypto.GeneratePKCEVerifier()
+			authCode, _ := completeAuthorizationFlow(t, ts.Server.URL, authorizationParams{
+				ClientID:     testClientID,
+				RedirectURI:  testRedirectURI,
+				State:        "refresh-reuse-state",
+				Challenge:    servercrypto.ComputePKCEChallenge(verifier),
+				Scope:        "openid profile offline_access",
+				ResponseType: "code",
+			})
+
+			resp := makeTokenRequest(t, ts.Server.URL, url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {authCode},
+				"client_id":     {testClientID},
+				"redirect_uri":  {testRedirectURI},
+				"code_verifier": {verifier},
+			})
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			originalRefreshToken, ok := parseTokenResponse(t, resp)["refresh_token"].(string)
+			require.True(t, ok, "response should contain a refresh_token")
+
+			refresh := func(refreshToken string) *http.Response {
+				return makeTokenRequest(t, ts.Server.URL, url.Values{
+					"grant_type":    {"refresh_token"},
+					"refresh_token": {refreshToken},
+					"client_id":     {testClientID},
+				})
+			}
+
+			// Legitimate rotation.
+			rotateResp := refresh(originalRefreshToken)
+			defer rotateResp.Body.Close()
+			require.Equal(t, http.StatusOK, rotateResp.StatusCode, "legitimate rotation should succeed")
+			rotatedRefreshToken, ok := parseTokenResponse(t, rotateResp)["refresh_token"].(string)
+			require.True(t, ok, "rotation should issue a new refresh_token")
+
+			// Replay of the rotated-away token.
+			replayResp := refresh(originalRefreshToken)
+			defer replayResp.Body.Close()
+			require.Equal(t, http.StatusBadRequest, replayResp.StatusCode)
+			assert.Equal(t, "invalid_grant", parseTokenResponse(t, replayResp)["error"])
+
+			// The whole family is revoked: the legitimately rotated token is gone too.
+			familyResp := refresh(rotatedRefreshToken)
+			defer familyResp.Body.Close()
+			require.Equal(t, http.StatusBadRequest, familyResp.StatusCode,
+				"refresh tokens of a replayed grant must be revoked")
+			assert.Equal(t, "invalid_grant", parseTokenResponse(t, familyResp)["error"])
+		})
+	}
+}
+
 // ============================================================================
 // RFC 8693 Token Exchange Wiring Tests
 // ============================================================================
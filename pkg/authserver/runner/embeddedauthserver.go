@@ -359,6 +359,16 @@ func createKeyProvider(cfg *authserver.SigningKeyRunConfig) (keys.KeyProvider, e
 		SigningKeyFile:   cfg.SigningKeyFile,
 		FallbackKeyFiles: cfg.FallbackKeyFiles,
 	}
+	if cfg.FallbackKeyGracePeriod != "" {
+		gracePeriod, err := time.ParseDuration(cfg.FallbackKeyGracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback_key_grace_period: %w", err)
+		}
+		if gracePeriod <= 0 {
+			return nil, fmt.Errorf("fallback_key_grace_period must be positive, got %s", cfg.FallbackKeyGracePeriod)
+		}
+		keyCfg.FallbackKeyGracePeriod = gracePeriod
+	}
 
 	return keys.NewFileProvider(keyCfg)
 }
@@ -711,11 +721,51 @@ func createStorage(ctx context.Context, cfg *storage.RunConfig) (storage.Storage
 		if err != nil {
 			return nil, fmt.Errorf("invalid Redis config: %w", err)
 		}
-		return storage.NewRedisStorage(ctx, redisCfg, cfg.RedisConfig.KeyPrefix)
+		var opts []storage.RedisStorageOption
+		cipher, err := loadUpstreamTokenCipher(cfg.RedisConfig.UpstreamTokenEncryptionKeyFiles)
+		if err != nil {
+			return nil, err
+		}
+		if cipher != nil {
+			opts = append(opts, storage.WithUpstreamTokenCipher(cipher))
+		}
+		return storage.NewRedisStorage(ctx, redisCfg, cfg.RedisConfig.KeyPrefix, opts...)
 	}
 	return nil, fmt.Errorf("unsupported storage type: %s", cfg.Type)
 }
 
+// loadUpstreamTokenCipher reads the upstream token encryption secrets from
+// files: the first is the current key and the rest are previous keys.
+// Returns nil if no files are configured (tokens are stored unencrypted).
+func loadUpstreamTokenCipher(files []string) (*storage.TokenCipher, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	secrets := make([][]byte, 0, len(files))
+	for _, file := range files {
+		if file == "" {
+			continue // Skip empty paths
+		}
+		// #nosec G304 - file path is from configuration, not user input
+		secret, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read upstream token encryption key from %s: %w", file, err)
+		}
+		// Trim whitespace (Kubernetes Secret mounts may include trailing newlines)
+		secrets = append(secrets, bytes.TrimSpace(secret))
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	cipher, err := storage.NewTokenCipher(secrets[0], secrets[1:]...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream_token_encryption_key_files: %w", err)
+	}
+	return cipher, nil
+}
+
 // convertRedisRunConfig converts a serializable RedisRunConfig to a runtime
 // tcredis.Config. It resolves ACL credentials from environment variables and
 // parses duration strings. Connection-mode topology and defaulting are handled
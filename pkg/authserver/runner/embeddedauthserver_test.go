@@ -110,6 +110,21 @@ func TestCreateKeyProvider(t *testing.T) {
 		_, err := createKeyProvider(cfg)
 		require.Error(t, err)
 	})
+
+	t.Run("invalid fallback key grace period returns error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, gracePeriod := range []string{"soon", "-1h"} {
+			cfg := &authserver.SigningKeyRunConfig{
+				KeyDir:                 "/some/dir",
+				SigningKeyFile:         "key.pem",
+				FallbackKeyGracePeriod: gracePeriod,
+			}
+
+			_, err := createKeyProvider(cfg)
+			require.ErrorContains(t, err, "fallback_key_grace_period")
+		}
+	})
 }
 
 func TestLoadHMACSecrets(t *testing.T) {
@@ -209,6 +224,51 @@ func TestLoadHMACSecrets(t *testing.T) {
 	})
 }
 
+func TestLoadUpstreamTokenCipher(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	currentFile := filepath.Join(tmpDir, "current")
+	previousFile := filepath.Join(tmpDir, "previous")
+	shortFile := filepath.Join(tmpDir, "short")
+	require.NoError(t, os.WriteFile(currentFile, []byte("current-key-that-is-at-least-32-bytes-long\n"), 0600))
+	require.NoError(t, os.WriteFile(previousFile, []byte("previous-key-that-is-at-least-32-bytes-long"), 0600))
+	require.NoError(t, os.WriteFile(shortFile, []byte("too-short"), 0600))
+
+	t.Run("no files disables encryption", func(t *testing.T) {
+		t.Parallel()
+		cipher, err := loadUpstreamTokenCipher(nil)
+		require.NoError(t, err)
+		assert.Nil(t, cipher)
+	})
+
+	t.Run("previous key decrypts after rotation", func(t *testing.T) {
+		t.Parallel()
+		before, err := loadUpstreamTokenCipher([]string{previousFile})
+		require.NoError(t, err)
+		after, err := loadUpstreamTokenCipher([]string{currentFile, previousFile})
+		require.NoError(t, err)
+
+		encrypted, err := before.Encrypt("token")
+		require.NoError(t, err)
+		decrypted, err := after.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "token", decrypted)
+	})
+
+	t.Run("short key", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadUpstreamTokenCipher([]string{shortFile})
+		assert.ErrorContains(t, err, "upstream_token_encryption_key_files")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadUpstreamTokenCipher([]string{filepath.Join(tmpDir, "missing")})
+		assert.ErrorContains(t, err, "failed to read upstream token encryption key")
+	})
+}
+
 func TestParseTokenLifespans(t *testing.T) {
 	t.Parallel()
 
@@ -52,11 +52,20 @@ func (h *Handler) CallbackHandler(w http.ResponseWriter, req *http.Request) {
 
 	// Load and delete pending authorization (single-use)
 	pending, err := h.storage.LoadPendingAuthorization(ctx, internalState)
+	if errors.Is(err, storage.ErrExpired) {
+		// The user took longer than the pending authorization TTL at the upstream
+		// IDP. Drop the stale entry now rather than waiting for storage cleanup.
+		slog.Warn("pending authorization expired")
+		_ = h.storage.DeletePendingAuthorization(ctx, internalState)
+		http.Error(w, "authorization expired, please restart the authorization flow", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		slog.Warn("pending authorization not found",
 			"error", err,
 		)
-		http.Error(w, "authorization request not found or expired", http.StatusBadRequest)
+		http.Error(w, "authorization request not found or expired, please restart the authorization flow",
+			http.StatusBadRequest)
 		return
 	}
 
@@ -57,6 +57,33 @@ func TestCallbackHandler_PendingAuthorizationNotFound(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "not found")
 }
 
+func TestCallbackHandler_PendingAuthorizationExpired(t *testing.T) {
+	t.Parallel()
+	handler, storState, mockUpstream := handlerTestSetup(t)
+
+	storState.pendingAuths[testInternalState] = &storage.PendingAuthorization{
+		ClientID:             testAuthClientID,
+		RedirectURI:          testAuthRedirectURI,
+		State:                "client-state",
+		PKCEChallenge:        "challenge123",
+		PKCEMethod:           "S256",
+		Scopes:               []string{"openid"},
+		InternalState:        testInternalState,
+		UpstreamProviderName: "test-upstream",
+		CreatedAt:            time.Now().Add(-storage.DefaultPendingAuthorizationTTL - time.Minute),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth/callback?code=upstream-code&state="+testInternalState, nil)
+	rec := httptest.NewRecorder()
+
+	handler.CallbackHandler(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "authorization expired, please restart")
+	assert.NotContains(t, storState.pendingAuths, testInternalState, "expired entry should be deleted")
+	assert.Empty(t, mockUpstream.capturedCode, "expired state must not reach the upstream")
+}
+
 func TestCallbackHandler_UpstreamError(t *testing.T) {
 	t.Parallel()
 	handler, storState, _ := handlerTestSetup(t)
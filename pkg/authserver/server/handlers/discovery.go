@@ -29,9 +29,12 @@ import (
 // Cache-Control max-age values for discovery endpoints.
 // These are not exposed to users but extracted as constants for documentation and maintainability.
 const (
-	// DefaultJWKSCacheMaxAge is the Cache-Control max-age for the JWKS endpoint (1 hour).
-	// This balances caching efficiency with timely key rotation propagation.
-	DefaultJWKSCacheMaxAge = 3600
+	// DefaultJWKSCacheMaxAge is the Cache-Control max-age for the JWKS endpoint (15 minutes).
+	// Verifiers refetch at least this often, so a key added ahead of a rotation or
+	// dropped after its grace window propagates quickly, while steady-state load stays
+	// at a few requests per hour per verifier. Keep fallback key grace periods well
+	// above this value.
+	DefaultJWKSCacheMaxAge = 900
 
 	// DefaultDiscoveryCacheMaxAge is the Cache-Control max-age for the discovery endpoint (1 hour).
 	// Aligned with Google's OIDC discovery cache policy.
@@ -106,7 +106,7 @@ func TestJWKSHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
-	assert.Equal(t, "public, max-age=3600", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "public, max-age=900", rec.Header().Get("Cache-Control"))
 
 	// Parse the response as JWKS
 	var jwks jose.JSONWebKeySet
@@ -105,8 +105,10 @@ type testStorageState struct {
 type baseTestSetupOption func(*baseTestSetupConfig)
 
 type baseTestSetupConfig struct {
-	storePendingErr            error // if non-nil, StorePendingAuthorization always returns this error
-	getLatestUpstreamTokensErr error // if non-nil, GetLatestUpstreamTokensForUser always returns this error
+	storePendingErr            error    // if non-nil, StorePendingAuthorization always returns this error
+	getLatestUpstreamTokensErr error    // if non-nil, GetLatestUpstreamTokensForUser always returns this error
+	allowedAudiences           []string // if non-nil, replaces the server's allowed audiences
+	clientAudience             []string // allowed audiences declared by the test client
 }
 
 func withStorePendingError(err error) baseTestSetupOption {
@@ -121,6 +123,18 @@ func withGetLatestUpstreamTokensError(err error) baseTestSetupOption {
 	}
 }
 
+func withAllowedAudiences(audiences ...string) baseTestSetupOption {
+	return func(c *baseTestSetupConfig) {
+		c.allowedAudiences = audiences
+	}
+}
+
+func withClientAudience(audiences ...string) baseTestSetupOption {
+	return func(c *baseTestSetupConfig) {
+		c.clientAudience = audiences
+	}
+}
+
 // baseTestSetup creates the shared test infrastructure (RSA keys, fosite provider, mock storage
 // with all expectations wired, including upstream token mocks). Callers create the Handler.
 func baseTestSetup(t *testing.T, opts ...baseTestSetupOption) (fosite.OAuth2Provider, *server.AuthorizationServerConfig, *mocks.MockStorage, *testStorageState) {
@@ -155,6 +169,9 @@ func baseTestSetup(t *testing.T, opts ...baseTestSetupOption) (fosite.OAuth2Prov
 		SigningKey:           rsaKey,
 		AllowedAudiences:     []string{"https://api.example.com"},
 	}
+	if setupCfg.allowedAudiences != nil {
+		cfg.AllowedAudiences = setupCfg.allowedAudiences
+	}
 
 	oauth2Config, err := server.NewAuthorizationServerConfig(cfg)
 	require.NoError(t, err)
@@ -180,6 +197,7 @@ func baseTestSetup(t *testing.T, opts ...baseTestSetupOption) (fosite.OAuth2Prov
 		ResponseTypes: []string{"code"},
 		GrantTypes:    []string{"authorization_code", "refresh_token"},
 		Scopes:        []string{"openid", "profile", "email"},
+		Audience:      setupCfg.clientAudience,
 		Public:        true,
 	}
 	storState.clients[testAuthClientID] = testClient
@@ -223,6 +241,10 @@ func baseTestSetup(t *testing.T, opts ...baseTestSetupOption) (fosite.OAuth2Prov
 	stor.EXPECT().LoadPendingAuthorization(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(_ context.Context, state string) (*storage.PendingAuthorization, error) {
 			if p, ok := storState.pendingAuths[state]; ok {
+				// Mirror the storage TTL check so tests can seed expired entries.
+				if !p.CreatedAt.IsZero() && time.Since(p.CreatedAt) > storage.DefaultPendingAuthorizationTTL {
+					return nil, storage.ErrExpired
+				}
 				return p, nil
 			}
 			return nil, storage.ErrNotFound
@@ -4,8 +4,12 @@
 package handlers
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"slices"
+
+	"github.com/ory/fosite"
 
 	"github.com/stacklok/toolhive/pkg/authserver/server"
 	"github.com/stacklok/toolhive/pkg/authserver/server/session"
@@ -34,47 +38,24 @@ func (h *Handler) TokenHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// RFC 8707: Handle resource parameter for audience claim.
-	// The resource parameter allows clients to specify which protected resource (MCP server)
-	// the token is intended for. This value becomes the "aud" claim in the JWT.
-	//
-	// Note: RFC 8707 allows multiple resource parameters, but we explicitly reject them
-	// for security reasons (simpler audience model, clearer token scope).
-	resources := accessRequest.GetRequestForm()["resource"]
-	if len(resources) > 1 {
-		slog.Debug("multiple resource parameters not supported", //nolint:gosec // G706: count is an integer
-			"count", len(resources),
-		)
-		h.provider.WriteAccessError(ctx, w, accessRequest,
-			server.ErrInvalidTarget.WithHint("Multiple resource parameters are not supported"))
-		return
-	}
-	if len(resources) == 1 && resources[0] != "" {
-		resource := resources[0]
-		// Validate URI format per RFC 8707
-		if err := server.ValidateAudienceURI(resource); err != nil {
-			slog.Debug("invalid resource URI format", //nolint:gosec // G706: resource URI from token request
-				"resource", resource,
-				"error", err,
-			)
-			h.provider.WriteAccessError(ctx, w, accessRequest, err)
-			return
-		}
-
-		// Validate against allowed audiences list
-		if err := server.ValidateAudienceAllowed(resource, h.config.AllowedAudiences); err != nil {
-			slog.Debug("resource not in allowed audiences", //nolint:gosec // G706: resource URI from token request
-				"resource", resource,
-				"error", err,
-			)
+	// RFC 8707: Handle resource parameters for the audience claim.
+	// Each resource parameter names a protected resource (MCP server or vMCP
+	// backend) the token is intended for, and every granted resource becomes a
+	// value of the "aud" claim in the JWT. Resource servers only accept tokens
+	// whose "aud" includes them, so a token can be scoped to several backends.
+	resources := requestedResources(accessRequest.GetRequestForm()["resource"])
+	if len(resources) > 0 {
+		if err := h.validateRequestedResources(ctx, accessRequest.GetClient(), resources); err != nil {
 			h.provider.WriteAccessError(ctx, w, accessRequest, err)
 			return
 		}
 
-		slog.Debug("granting audience from resource parameter", //nolint:gosec // G706: resource URI from token request
-			"resource", resource,
+		slog.Debug("granting audiences from resource parameters", //nolint:gosec // G706: resource URIs from token request
+			"resources", resources,
 		)
-		accessRequest.GrantAudience(resource)
+		for _, resource := range resources {
+			accessRequest.GrantAudience(resource)
+		}
 	} else if accessRequest.GetGrantTypes().ExactOne("authorization_code") && len(h.config.AllowedAudiences) == 1 {
 		// No resource parameter provided (or provided as empty) during an authorization_code
 		// exchange; default to the sole allowed audience. The len == 1 guard makes the
@@ -115,3 +96,54 @@ func (h *Handler) TokenHandler(w http.ResponseWriter, req *http.Request) {
 	// Write the token response
 	h.provider.WriteAccessResponse(ctx, w, accessRequest, response)
 }
+
+// requestedResources returns the non-empty resource parameters of a token
+// request, without duplicates and in request order.
+func requestedResources(values []string) []string {
+	var resources []string
+	for _, resource := range values {
+		if resource != "" && !slices.Contains(resources, resource) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// validateRequestedResources checks that every requested resource is a valid
+// RFC 8707 URI, is one of the server's allowed audiences, and, when the client
+// declares its own allowed audiences, is one of them. Clients that declare no
+// audience (e.g. CIMD clients) are bound by the server's allowlist only.
+func (h *Handler) validateRequestedResources(ctx context.Context, client fosite.Client, resources []string) error {
+	for _, resource := range resources {
+		// Validate URI format per RFC 8707
+		if err := server.ValidateAudienceURI(resource); err != nil {
+			slog.Debug("invalid resource URI format", //nolint:gosec // G706: resource URI from token request
+				"resource", resource,
+				"error", err,
+			)
+			return err
+		}
+
+		// Validate against allowed audiences list
+		if err := server.ValidateAudienceAllowed(resource, h.config.AllowedAudiences); err != nil {
+			slog.Debug("resource not in allowed audiences", //nolint:gosec // G706: resource URI from token request
+				"resource", resource,
+				"error", err,
+			)
+			return err
+		}
+	}
+
+	if client == nil || len(client.GetAudience()) == 0 {
+		return nil
+	}
+	if err := h.config.GetAudienceStrategy(ctx)(client.GetAudience(), resources); err != nil {
+		slog.Debug("resource not in client's allowed audiences", //nolint:gosec // G706: resource URIs from token request
+			"client_id", client.GetID(),
+			"resources", resources,
+			"error", err,
+		)
+		return server.ErrInvalidTarget.WithHintf("Client %q is not allowed to request one or more of the resources", client.GetID())
+	}
+	return nil
+}
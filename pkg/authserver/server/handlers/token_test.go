@@ -279,6 +279,107 @@ func TestTokenHandler_AudienceClaim(t *testing.T) {
 	}
 }
 
+func TestTokenHandler_MultiAudience(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiAudience    = "https://api.example.com"
+		githubAudience = "https://github.backend.example.com"
+		jiraAudience   = "https://jira.backend.example.com"
+	)
+
+	tests := []struct {
+		name       string
+		setup      []baseTestSetupOption
+		resources  []string
+		wantStatus int
+		wantAud    []any
+		wantError  string
+	}{
+		{
+			name:       "single audience",
+			setup:      []baseTestSetupOption{withAllowedAudiences(githubAudience, jiraAudience)},
+			resources:  []string{githubAudience},
+			wantStatus: http.StatusOK,
+			wantAud:    []any{githubAudience},
+		},
+		{
+			name:       "multiple audiences",
+			setup:      []baseTestSetupOption{withAllowedAudiences(githubAudience, jiraAudience)},
+			resources:  []string{githubAudience, jiraAudience, githubAudience},
+			wantStatus: http.StatusOK,
+			wantAud:    []any{githubAudience, jiraAudience},
+		},
+		{
+			name:       "audience not allowed by the server",
+			setup:      []baseTestSetupOption{withAllowedAudiences(githubAudience)},
+			resources:  []string{githubAudience, jiraAudience},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid_target",
+		},
+		{
+			name: "audience not allowed for the client",
+			setup: []baseTestSetupOption{
+				withAllowedAudiences(apiAudience, githubAudience, jiraAudience),
+				withClientAudience(apiAudience, githubAudience),
+			},
+			resources:  []string{githubAudience, jiraAudience},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid_target",
+		},
+		{
+			name: "audiences allowed for the client",
+			setup: []baseTestSetupOption{
+				withAllowedAudiences(apiAudience, githubAudience, jiraAudience),
+				withClientAudience(apiAudience, githubAudience),
+			},
+			resources:  []string{apiAudience, githubAudience},
+			wantStatus: http.StatusOK,
+			wantAud:    []any{apiAudience, githubAudience},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			handler, storState, _ := handlerTestSetup(t, tc.setup...)
+			authorizeCode := simulateAuthorizeFlow(t, handler, storState)
+
+			form := url.Values{
+				"grant_type":    {"authorization_code"},
+				"client_id":     {testAuthClientID},
+				"redirect_uri":  {testAuthRedirectURI},
+				"code":          {authorizeCode},
+				"code_verifier": {testPKCEVerifier},
+				"resource":      tc.resources,
+			}
+			req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			handler.TokenHandler(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code, "got %d: %s", rec.Code, rec.Body.String())
+			if tc.wantError != "" {
+				assert.Contains(t, rec.Body.String(), tc.wantError)
+				assert.NotContains(t, rec.Body.String(), "access_token")
+				return
+			}
+
+			var tokenResp map[string]any
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&tokenResp))
+			accessToken, ok := tokenResp["access_token"].(string)
+			require.True(t, ok, "access_token should be a string")
+
+			parsedToken, err := josejwt.ParseSigned(accessToken, []jose.SignatureAlgorithm{jose.RS256})
+			require.NoError(t, err)
+			var claims map[string]any
+			require.NoError(t, parsedToken.UnsafeClaimsWithoutVerification(&claims))
+			assert.Equal(t, tc.wantAud, claims["aud"])
+		})
+	}
+}
+
 func TestTokenHandler_RouteRegistered(t *testing.T) {
 	t.Parallel()
 	handler, _, _ := handlerTestSetup(t)
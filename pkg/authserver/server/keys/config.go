@@ -3,6 +3,8 @@
 
 package keys
 
+import "time"
+
 // Config holds configuration for creating a KeyProvider.
 // The caller is responsible for populating this from their own config source
 // (environment variables, YAML files, flags, etc.).
@@ -37,6 +39,14 @@ type Config struct {
 	//  2. Promote it to SigningKeyFile, move the old key to FallbackKeyFiles, roll out.
 	//  3. Remove the old key from FallbackKeyFiles after its tokens have expired.
 	FallbackKeyFiles []string
+
+	// FallbackKeyGracePeriod bounds how long fallback keys stay published after
+	// the signing key file was last modified, i.e. after the rotation that
+	// replaced them. Restarts do not extend it. Set it to at least the access token lifespan so
+	// in-flight tokens signed with a rotated-out key still verify, while the
+	// rotated-out key drops from JWKS without a further rollout.
+	// Zero publishes fallback keys until they are removed from FallbackKeyFiles.
+	FallbackKeyGracePeriod time.Duration
 }
 
 // NewProviderFromConfig creates a KeyProvider based on the configuration.
@@ -11,6 +11,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"log/slog"
+	"os"
 	"path/filepath"
 	"sync"
 	"time"
@@ -49,6 +50,11 @@ type KeyProvider interface {
 type FileProvider struct {
 	signingKey *SigningKeyData
 	allKeys    []*SigningKeyData
+
+	// fallbackExpiresAt is when fallback keys stop being published, or zero
+	// if they never expire.
+	fallbackExpiresAt time.Time
+	now               func() time.Time
 }
 
 // NewFileProvider creates a provider that loads keys from a directory.
@@ -81,9 +87,23 @@ func NewFileProvider(cfg Config) (*FileProvider, error) {
 		allKeys = append(allKeys, key)
 	}
 
+	// The grace period runs from the rotation, which installed the signing key
+	// file, rather than from the load: a restart must not extend it, and every
+	// replica must expire the fallback keys at the same time.
+	var fallbackExpiresAt time.Time
+	if cfg.FallbackKeyGracePeriod > 0 {
+		info, err := os.Stat(signingKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat signing key: %w", err)
+		}
+		fallbackExpiresAt = info.ModTime().Add(cfg.FallbackKeyGracePeriod)
+	}
+
 	return &FileProvider{
-		signingKey: signingKey,
-		allKeys:    allKeys,
+		signingKey:        signingKey,
+		allKeys:           allKeys,
+		fallbackExpiresAt: fallbackExpiresAt,
+		now:               time.Now,
 	}, nil
 }
 
@@ -121,15 +141,25 @@ func (p *FileProvider) SigningKey(_ context.Context) (*SigningKeyData, error) {
 // PublicKeys returns public keys for all loaded keys (signing + additional).
 // This enables verification of tokens signed with any of the loaded keys,
 // supporting key rotation scenarios where old keys must remain valid.
+// The signing key is always first; fallback keys are omitted once their
+// grace period has passed.
 func (p *FileProvider) PublicKeys(_ context.Context) ([]*PublicKeyData, error) {
+	now := p.now()
 	pubKeys := make([]*PublicKeyData, 0, len(p.allKeys))
 	for _, key := range p.allKeys {
-		pubKeys = append(pubKeys, &PublicKeyData{
+		pubKey := &PublicKeyData{
 			KeyID:     key.KeyID,
 			Algorithm: key.Algorithm,
 			PublicKey: key.Key.Public(),
 			CreatedAt: key.CreatedAt,
-		})
+		}
+		if key != p.signingKey {
+			pubKey.ExpiresAt = p.fallbackExpiresAt
+		}
+		if pubKey.Expired(now) {
+			continue
+		}
+		pubKeys = append(pubKeys, pubKey)
 	}
 	return pubKeys, nil
 }
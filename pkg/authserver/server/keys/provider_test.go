@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -149,6 +150,55 @@ func TestFileProvider(t *testing.T) {
 		}
 	})
 
+	t.Run("fallback keys expire after the grace period", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+
+		der1, err := x509.MarshalECPrivateKey(generateTestKey(t))
+		require.NoError(t, err)
+		signingFile := writePEM(t, dir, "signing.pem", der1)
+		der2, err := x509.MarshalECPrivateKey(generateTestKey(t))
+		require.NoError(t, err)
+		fallbackFile := writePEM(t, dir, "old.pem", der2)
+
+		// The rotation installed the signing key file 30 minutes ago
+		rotatedAt := time.Now().Add(-30 * time.Minute).Truncate(time.Second)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, signingFile), rotatedAt, rotatedAt))
+
+		cfg := Config{
+			KeyDir:                 dir,
+			SigningKeyFile:         signingFile,
+			FallbackKeyFiles:       []string{fallbackFile},
+			FallbackKeyGracePeriod: time.Hour,
+		}
+		provider, err := NewFileProvider(cfg)
+		require.NoError(t, err)
+		signingKey, err := provider.SigningKey(context.Background())
+		require.NoError(t, err)
+
+		provider.now = func() time.Time { return rotatedAt.Add(59 * time.Minute) }
+		pubKeys, err := provider.PublicKeys(context.Background())
+		require.NoError(t, err)
+		require.Len(t, pubKeys, 2, "rotated key is published during the grace period")
+		assert.Equal(t, signingKey.KeyID, pubKeys[0].KeyID)
+		assert.True(t, pubKeys[0].ExpiresAt.IsZero(), "signing key never expires")
+		assert.Equal(t, rotatedAt.Add(time.Hour), pubKeys[1].ExpiresAt)
+
+		provider.now = func() time.Time { return rotatedAt.Add(time.Hour) }
+		pubKeys, err = provider.PublicKeys(context.Background())
+		require.NoError(t, err)
+		require.Len(t, pubKeys, 1, "rotated key is dropped after the grace period")
+		assert.Equal(t, signingKey.KeyID, pubKeys[0].KeyID)
+
+		// A restart reloads the keys but does not extend the grace period
+		restarted, err := NewFileProvider(cfg)
+		require.NoError(t, err)
+		restarted.now = func() time.Time { return rotatedAt.Add(time.Hour) }
+		pubKeys, err = restarted.PublicKeys(context.Background())
+		require.NoError(t, err)
+		require.Len(t, pubKeys, 1, "a restart must not republish the rotated key")
+	})
+
 	t.Run("signing key returns first key only", func(t *testing.T) {
 		t.Parallel()
 		dir := t.TempDir()
@@ -45,4 +45,13 @@ type PublicKeyData struct {
 
 	// CreatedAt is when this key was generated or loaded.
 	CreatedAt time.Time
+
+	// ExpiresAt is when a rotated-out key stops being published for verification.
+	// The zero value means the key does not expire.
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the key's verification grace window has passed at now.
+func (k *PublicKeyData) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
 }
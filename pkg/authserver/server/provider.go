@@ -27,6 +27,7 @@ import (
 	"github.com/ory/fosite"
 
 	servercrypto "github.com/stacklok/toolhive/pkg/authserver/server/crypto"
+	"github.com/stacklok/toolhive/pkg/authserver/server/keys"
 	"github.com/stacklok/toolhive/pkg/authserver/server/registration"
 )
 
@@ -52,6 +53,9 @@ type AuthorizationServerConfig struct {
 	*fosite.Config
 	SigningKey  *jose.JSONWebKey
 	SigningJWKS *jose.JSONWebKeySet
+	// verificationKeys are the additional public keys published in the JWKS
+	// so tokens signed with a rotated-out key still verify.
+	verificationKeys []verificationKey
 	// AllowedAudiences is the list of valid resource URIs that tokens can be issued for.
 	// Per RFC 8707, the "resource" parameter in token requests is validated against this list.
 	// Security: An empty list means NO audiences are permitted (secure default).
@@ -72,6 +76,12 @@ type AuthorizationServerConfig struct {
 	CIMDEnabled bool
 }
 
+// verificationKey is a public key published in the JWKS until it expires.
+type verificationKey struct {
+	jwk  jose.JSONWebKey
+	data *keys.PublicKeyData
+}
+
 // Factory is a constructor which is used to create an OAuth2 endpoint handler.
 // NewAuthorizationServer handles consuming the new struct and attaching it
 // to the parts of the config that it implements.
@@ -92,6 +102,10 @@ type AuthorizationServerParams struct {
 	SigningKeyID         string
 	SigningKeyAlgorithm  string
 	SigningKey           crypto.Signer
+	// VerificationKeys are public keys published in the JWKS alongside the
+	// signing key, typically the keys of a rotation (see keys.KeyProvider.PublicKeys).
+	// Each key is published until its ExpiresAt; an entry for the signing key is ignored.
+	VerificationKeys []*keys.PublicKeyData
 	// AllowedAudiences is the list of valid resource URIs that tokens can be issued for.
 	// Per RFC 8707, the "resource" parameter in token requests is validated against this list.
 	// Security: An empty list means NO audiences are permitted (secure default).
@@ -254,10 +268,29 @@ func NewAuthorizationServerConfig(cfg *AuthorizationServerParams) (*Authorizatio
 		ScopeStrategy: fosite.ExactScopeStrategy,
 	}
 
+	// Symmetric HMAC secrets rotate through fosite's RotatedGlobalSecrets; the
+	// asymmetric equivalent is publishing rotated-out public keys in the JWKS.
+	verificationKeys := make([]verificationKey, 0, len(cfg.VerificationKeys))
+	for _, key := range cfg.VerificationKeys {
+		if key == nil || key.KeyID == cfg.SigningKeyID {
+			continue
+		}
+		verificationKeys = append(verificationKeys, verificationKey{
+			jwk: jose.JSONWebKey{
+				Key:       key.PublicKey,
+				KeyID:     key.KeyID,
+				Algorithm: key.Algorithm,
+				Use:       "sig",
+			},
+			data: key,
+		})
+	}
+
 	return &AuthorizationServerConfig{
 		Config:                       fositeConfig,
 		SigningKey:                   &jwk,
 		SigningJWKS:                  &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk}},
+		verificationKeys:             verificationKeys,
 		AllowedAudiences:             cfg.AllowedAudiences,
 		ScopesSupported:              cfg.ScopesSupported,
 		BaselineClientScopes:         cfg.BaselineClientScopes,
@@ -332,13 +365,15 @@ func (c *AuthorizationServerConfig) GetPrivateSigningJWKS(_ context.Context) *jo
 }
 
 // PublicJWKS returns a copy of the JWKS containing only public keys.
+// The signing keys always come first, followed by the verification keys
+// whose rotation grace window has not yet passed.
 func (c *AuthorizationServerConfig) PublicJWKS() *jose.JSONWebKeySet {
 	if c.SigningJWKS == nil {
 		return nil
 	}
 
 	publicJWKS := &jose.JSONWebKeySet{
-		Keys: make([]jose.JSONWebKey, 0, len(c.SigningJWKS.Keys)),
+		Keys: make([]jose.JSONWebKey, 0, len(c.SigningJWKS.Keys)+len(c.verificationKeys)),
 	}
 
 	for _, key := range c.SigningJWKS.Keys {
@@ -346,6 +381,14 @@ func (c *AuthorizationServerConfig) PublicJWKS() *jose.JSONWebKeySet {
 		publicJWKS.Keys = append(publicJWKS.Keys, publicKey)
 	}
 
+	now := time.Now()
+	for _, key := range c.verificationKeys {
+		if key.data.Expired(now) {
+			continue
+		}
+		publicJWKS.Keys = append(publicJWKS.Keys, key.jwk)
+	}
+
 	return publicJWKS
 }
 
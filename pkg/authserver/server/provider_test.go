@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	servercrypto "github.com/stacklok/toolhive/pkg/authserver/server/crypto"
+	"github.com/stacklok/toolhive/pkg/authserver/server/keys"
 )
 
 func TestNewAuthorizationServerConfig(t *testing.T) {
@@ -513,6 +514,50 @@ func TestAuthorizationServerConfig_PublicJWKS(t *testing.T) {
 	assert.True(t, ok, "expected public key, got %T", publicJWKS.Keys[0].Key)
 }
 
+func TestAuthorizationServerConfig_PublicJWKS_RotatedKeys(t *testing.T) {
+	t.Parallel()
+
+	newKey := func() *rsa.PrivateKey {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		return key
+	}
+	signingKey, rotatedKey, expiredKey := newKey(), newKey(), newKey()
+
+	params := &AuthorizationServerParams{
+		Issuer:               "https://auth.example.com",
+		AccessTokenLifespan:  time.Hour,
+		RefreshTokenLifespan: time.Hour * 24,
+		AuthCodeLifespan:     time.Minute * 10,
+		HMACSecrets:          servercrypto.NewHMACSecrets([]byte("test-secret-with-32-bytes-long!!")),
+		SigningKeyID:         "current",
+		SigningKeyAlgorithm:  "RS256",
+		SigningKey:           signingKey,
+		VerificationKeys: []*keys.PublicKeyData{
+			// The provider lists the signing key too; it must not be duplicated.
+			{KeyID: "current", Algorithm: "RS256", PublicKey: signingKey.Public()},
+			{KeyID: "rotated", Algorithm: "RS256", PublicKey: rotatedKey.Public(), ExpiresAt: time.Now().Add(time.Hour)},
+			{KeyID: "expired", Algorithm: "RS256", PublicKey: expiredKey.Public(), ExpiresAt: time.Now().Add(-time.Second)},
+		},
+	}
+
+	authzServerConfig, err := NewAuthorizationServerConfig(params)
+	require.NoError(t, err)
+
+	publicJWKS := authzServerConfig.PublicJWKS()
+	require.NotNil(t, publicJWKS)
+	require.Len(t, publicJWKS.Keys, 2)
+	assert.Equal(t, "current", publicJWKS.Keys[0].KeyID, "current key is always published first")
+	assert.Equal(t, "rotated", publicJWKS.Keys[1].KeyID, "rotated key is published during its grace window")
+	for _, key := range publicJWKS.Keys {
+		assert.True(t, key.IsPublic(), "key %s must be public", key.KeyID)
+		assert.Equal(t, "sig", key.Use)
+	}
+
+	// The private signing JWKS never includes verification-only keys.
+	assert.Len(t, authzServerConfig.GetPrivateSigningJWKS(context.Background()).Keys, 1)
+}
+
 // mockStorage is a minimal fosite.Storage implementation for testing.
 type mockStorage struct{}
 
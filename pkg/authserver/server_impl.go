@@ -125,6 +125,10 @@ func newServer(ctx context.Context, cfg Config, stor storage.Storage, opts ...se
 	if err != nil {
 		return nil, fmt.Errorf("failed to get signing key: %w", err)
 	}
+	verificationKeys, err := cfg.KeyProvider.PublicKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verification keys: %w", err)
+	}
 
 	// Create OAuth2 config from authserver.Config
 	oauthParams := &oauthserver.AuthorizationServerParams{
@@ -136,6 +140,7 @@ func newServer(ctx context.Context, cfg Config, stor storage.Storage, opts ...se
 		SigningKeyID:                 signingKey.KeyID,
 		SigningKeyAlgorithm:          signingKey.Algorithm,
 		SigningKey:                   signingKey.Key,
+		VerificationKeys:             verificationKeys,
 		ScopesSupported:              cfg.ScopesSupported,
 		BaselineClientScopes:         cfg.BaselineClientScopes,
 		AllowedAudiences:             cfg.AllowedAudiences,
@@ -104,6 +104,13 @@ type RedisRunConfig struct {
 
 	// SentinelTLS configures TLS for Sentinel connections. Only applies when SentinelConfig is set.
 	SentinelTLS *RedisTLSRunConfig `json:"sentinel_tls,omitempty" yaml:"sentinel_tls,omitempty"`
+
+	// UpstreamTokenEncryptionKeyFiles are paths to secrets used to encrypt
+	// upstream IDP tokens at rest. The first file is the current key; the
+	// remaining files are previous keys kept to decrypt entries written before
+	// a rotation. When empty, upstream tokens are stored unencrypted.
+	//nolint:lll // field tags require full JSON+YAML names
+	UpstreamTokenEncryptionKeyFiles []string `json:"upstream_token_encryption_key_files,omitempty" yaml:"upstream_token_encryption_key_files,omitempty"`
 }
 
 // SentinelRunConfig contains Redis Sentinel configuration.
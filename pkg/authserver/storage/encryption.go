@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/secrets/aes"
+)
+
+// MinEncryptionSecretLength is the minimum length in bytes of a secret used
+// to encrypt upstream tokens at rest.
+const MinEncryptionSecretLength = 32
+
+// encryptedValuePrefix marks a ciphertext produced by TokenCipher. The full
+// format is "enc:v1:<key id>:<base64(nonce|ciphertext|tag)>".
+const encryptedValuePrefix = "enc:v1:"
+
+// upstreamTokenKeyInfo binds derived keys to their purpose, so a secret shared
+// with another component never yields the same AES key.
+const upstreamTokenKeyInfo = "toolhive authserver upstream token encryption"
+
+// ErrUnknownEncryptionKey is returned when a value was encrypted with a key
+// that is neither the current nor a previous key of the TokenCipher.
+var ErrUnknownEncryptionKey = errors.New("storage: value encrypted with an unknown key")
+
+// encryptionKey is an AES-256 key derived from a configured secret.
+type encryptionKey struct {
+	id  string
+	key []byte
+}
+
+// TokenCipher encrypts upstream token secrets at rest with AES-256-GCM.
+//
+// New values are always encrypted with the current key; values encrypted with
+// a previous key still decrypt, so a key can be rotated by promoting a new
+// secret to current and keeping the old one as previous until every entry it
+// encrypted has expired or been rewritten. Values stored before encryption was
+// enabled are returned unchanged.
+//
+// A nil *TokenCipher is valid and leaves values in plaintext.
+type TokenCipher struct {
+	current  encryptionKey
+	previous []encryptionKey
+}
+
+// NewTokenCipher creates a TokenCipher from the current secret and any
+// previous secrets still needed to decrypt existing values. Each secret must
+// be at least MinEncryptionSecretLength bytes.
+func NewTokenCipher(current []byte, previous ...[]byte) (*TokenCipher, error) {
+	return NewTokenCipherForPurpose(upstreamTokenKeyInfo, current, previous...)
+}
+
+// NewTokenCipherForPurpose is like NewTokenCipher for values other than the
+// upstream tokens of the auth server. The keys are derived for purpose, so a
+// secret shared between components never yields the same AES key.
+func NewTokenCipherForPurpose(purpose string, current []byte, previous ...[]byte) (*TokenCipher, error) {
+	currentKey, err := deriveEncryptionKey(current, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current encryption secret: %w", err)
+	}
+
+	c := &TokenCipher{current: currentKey}
+	for i, secret := range previous {
+		key, err := deriveEncryptionKey(secret, purpose)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous encryption secret %d: %w", i, err)
+		}
+		c.previous = append(c.previous, key)
+	}
+	return c, nil
+}
+
+func deriveEncryptionKey(secret []byte, purpose string) (encryptionKey, error) {
+	if len(secret) < MinEncryptionSecretLength {
+		return encryptionKey{}, fmt.Errorf("secret must be at least %d bytes", MinEncryptionSecretLength)
+	}
+	key, err := hkdf.Key(sha256.New, secret, nil, purpose, 32)
+	if err != nil {
+		return encryptionKey{}, fmt.Errorf("failed to derive key: %w", err)
+	}
+	// The key ID identifies the key in ciphertexts without revealing it.
+	sum := sha256.Sum256(key)
+	return encryptionKey{id: hex.EncodeToString(sum[:4]), key: key}, nil
+}
+
+// Encrypt encrypts plaintext with the current key. Empty values are returned
+// unchanged so that absent tokens stay absent.
+func (c *TokenCipher) Encrypt(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	sealed, err := aes.Encrypt([]byte(plaintext), c.current.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+	return encryptedValuePrefix + c.current.id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value produced by Encrypt with the current or a previous
+// key. Values without the encryption prefix were stored in plaintext and are
+// returned unchanged.
+func (c *TokenCipher) Decrypt(value string) (string, error) {
+	rest, ok := strings.CutPrefix(value, encryptedValuePrefix)
+	if !ok {
+		return value, nil
+	}
+	if c == nil {
+		return "", errors.New("storage: encrypted value found but no encryption key is configured")
+	}
+
+	keyID, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", errors.New("storage: malformed encrypted value")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("storage: malformed encrypted value: %w", err)
+	}
+
+	for _, key := range append([]encryptionKey{c.current}, c.previous...) {
+		if key.id != keyID {
+			continue
+		}
+		plaintext, err := aes.Decrypt(sealed, key.key)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt value: %w", err)
+		}
+		return string(plaintext), nil
+	}
+	return "", ErrUnknownEncryptionKey
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEncryptionSecretA = []byte("encryption-secret-a-that-is-at-least-32-bytes")
+	testEncryptionSecretB = []byte("encryption-secret-b-that-is-at-least-32-bytes")
+)
+
+func TestNewTokenCipher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		current  []byte
+		previous [][]byte
+		wantErr  string
+	}{
+		{name: "current only", current: testEncryptionSecretA},
+		{name: "with previous", current: testEncryptionSecretB, previous: [][]byte{testEncryptionSecretA}},
+		{name: "short current", current: []byte("too-short"), wantErr: "invalid current encryption secret"},
+		{
+			name:     "short previous",
+			current:  testEncryptionSecretA,
+			previous: [][]byte{[]byte("too-short")},
+			wantErr:  "invalid previous encryption secret 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cipher, err := NewTokenCipher(tt.current, tt.previous...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, cipher)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, cipher)
+		})
+	}
+}
+
+func TestTokenCipher_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	cipherA, err := NewTokenCipher(testEncryptionSecretA)
+	require.NoError(t, err)
+	cipherB, err := NewTokenCipher(testEncryptionSecretB)
+	require.NoError(t, err)
+	rotated, err := NewTokenCipher(testEncryptionSecretB, testEncryptionSecretA)
+	require.NoError(t, err)
+
+	encrypted, err := cipherA.Encrypt("upstream-access-token")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, encryptedValuePrefix))
+	assert.NotContains(t, encrypted, "upstream-access-token")
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		got, err := cipherA.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "upstream-access-token", got)
+	})
+
+	t.Run("previous key decrypts after rotation", func(t *testing.T) {
+		t.Parallel()
+		got, err := rotated.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "upstream-access-token", got)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		t.Parallel()
+		_, err := cipherB.Decrypt(encrypted)
+		assert.ErrorIs(t, err, ErrUnknownEncryptionKey)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		t.Parallel()
+		tampered := encrypted[:len(encrypted)-2] + "AA"
+		if tampered == encrypted {
+			tampered = encrypted[:len(encrypted)-2] + "BB"
+		}
+		_, err := cipherA.Decrypt(tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("plaintext passes through", func(t *testing.T) {
+		t.Parallel()
+		got, err := cipherA.Decrypt("legacy-plaintext-token")
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-plaintext-token", got)
+	})
+
+	t.Run("empty values stay empty", func(t *testing.T) {
+		t.Parallel()
+		got, err := cipherA.Encrypt("")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("nil cipher", func(t *testing.T) {
+		t.Parallel()
+		var nilCipher *TokenCipher
+		got, err := nilCipher.Encrypt("token")
+		require.NoError(t, err)
+		assert.Equal(t, "token", got)
+
+		_, err = nilCipher.Decrypt(encrypted)
+		assert.ErrorContains(t, err, "no encryption key is configured")
+	})
+}
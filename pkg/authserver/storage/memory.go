@@ -69,6 +69,12 @@ type MemoryStorage struct {
 	// via request ID for token rotation per RFC 6749.
 	refreshTokens map[string]*timedEntry[fosite.Requester]
 
+	// rotatedRefreshTokens maps the signature of a rotated-away refresh token ->
+	// Requester until the token would have expired. A rotated token presented
+	// again is reported as inactive so fosite detects the reuse and revokes the
+	// whole token family (RFC 6819 Section 5.2.2.3).
+	rotatedRefreshTokens map[string]*timedEntry[fosite.Requester]
+
 	// pkceRequests maps code signature -> Requester containing the PKCE challenge.
 	// Validated during token exchange per RFC 7636.
 	pkceRequests map[string]*timedEntry[fosite.Requester]
@@ -136,6 +142,7 @@ func NewMemoryStorage(opts ...MemoryStorageOption) *MemoryStorage {
 		authCodes:             make(map[string]*timedEntry[fosite.Requester]),
 		accessTokens:          make(map[string]*timedEntry[fosite.Requester]),
 		refreshTokens:         make(map[string]*timedEntry[fosite.Requester]),
+		rotatedRefreshTokens:  make(map[string]*timedEntry[fosite.Requester]),
 		pkceRequests:          make(map[string]*timedEntry[fosite.Requester]),
 		upstreamTokens:        make(map[upstreamKey]*timedEntry[*UpstreamTokens]),
 		pendingAuthorizations: make(map[string]*timedEntry[*PendingAuthorization]),
@@ -228,6 +235,13 @@ func (s *MemoryStorage) cleanupExpired() {
 		}
 	}
 
+	var expiredRotatedRefreshTokens []string
+	for k, v := range s.rotatedRefreshTokens {
+		if now.After(v.expiresAt) {
+			expiredRotatedRefreshTokens = append(expiredRotatedRefreshTokens, k)
+		}
+	}
+
 	var expiredPKCERequests []string
 	for k, v := range s.pkceRequests {
 		if now.After(v.expiresAt) {
@@ -267,6 +281,7 @@ func (s *MemoryStorage) cleanupExpired() {
 		len(expiredInvalidatedCodes) == 0 &&
 		len(expiredAccessTokens) == 0 &&
 		len(expiredRefreshTokens) == 0 &&
+		len(expiredRotatedRefreshTokens) == 0 &&
 		len(expiredPKCERequests) == 0 &&
 		len(expiredUpstreamTokens) == 0 &&
 		len(expiredPendingAuthorizations) == 0 &&
@@ -295,6 +310,10 @@ func (s *MemoryStorage) cleanupExpired() {
 		delete(s.refreshTokens, k)
 	}
 
+	for _, k := range expiredRotatedRefreshTokens {
+		delete(s.rotatedRefreshTokens, k)
+	}
+
 	for _, k := range expiredPKCERequests {
 		delete(s.pkceRequests, k)
 	}
@@ -558,10 +577,16 @@ func (s *MemoryStorage) CreateRefreshTokenSession(_ context.Context, signature s
 }
 
 // GetRefreshTokenSession retrieves the refresh token session by its signature.
+// A rotated-away token returns its Requester with fosite.ErrInactiveToken, which
+// fosite's refresh handler treats as reuse and answers by revoking the family.
 func (s *MemoryStorage) GetRefreshTokenSession(_ context.Context, signature string, _ fosite.Session) (fosite.Requester, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if entry, ok := s.rotatedRefreshTokens[signature]; ok {
+		return entry.value, fosite.ErrInactiveToken.WithHint("Refresh token was already rotated")
+	}
+
 	entry, ok := s.refreshTokens[signature]
 	if !ok {
 		slog.Debug("refresh token not found")
@@ -575,21 +600,29 @@ func (s *MemoryStorage) DeleteRefreshTokenSession(_ context.Context, signature s
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.refreshTokens[signature]; !ok {
+	_, active := s.refreshTokens[signature]
+	_, rotated := s.rotatedRefreshTokens[signature]
+	if !active && !rotated {
 		return fmt.Errorf("%w: %w", ErrNotFound, fosite.ErrNotFound.WithHint("Refresh token not found"))
 	}
 	delete(s.refreshTokens, signature)
+	delete(s.rotatedRefreshTokens, signature)
 	return nil
 }
 
 // RotateRefreshToken invalidates a refresh token and all its related token data.
 // This is called during token refresh to implement refresh token rotation.
+// The rotated token is remembered until it would have expired, so that a
+// replay is detected as reuse rather than reported as an unknown token.
 func (s *MemoryStorage) RotateRefreshToken(_ context.Context, requestID string, refreshTokenSignature string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Delete the specific refresh token
-	delete(s.refreshTokens, refreshTokenSignature)
+	// Move the specific refresh token to the rotated set
+	if entry, ok := s.refreshTokens[refreshTokenSignature]; ok {
+		delete(s.refreshTokens, refreshTokenSignature)
+		s.rotatedRefreshTokens[refreshTokenSignature] = entry
+	}
 
 	// TODO: Use the refreshToAccess map (once implemented) for direct access token lookup
 	// instead of O(n) scan by request ID, which may delete unrelated tokens sharing the same ID.
@@ -641,12 +674,18 @@ func (s *MemoryStorage) RevokeRefreshToken(_ context.Context, requestID string)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Find and remove all refresh tokens associated with this request ID.
+	// Find and remove all refresh tokens associated with this request ID,
+	// including rotated ones: the whole family is gone after a revocation.
 	for sig, entry := range s.refreshTokens {
 		if entry.value.GetID() == requestID {
 			delete(s.refreshTokens, sig)
 		}
 	}
+	for sig, entry := range s.rotatedRefreshTokens {
+		if entry.value.GetID() == requestID {
+			delete(s.rotatedRefreshTokens, sig)
+		}
+	}
 
 	return nil
 }
@@ -415,7 +415,7 @@ func TestMemoryStorage_AccessToken_DeleteNonExistent(t *testing.T) {
 
 func TestMemoryStorage_RotateRefreshToken(t *testing.T) {
 	t.Parallel()
-	t.Run("rotate deletes refresh and access tokens", func(t *testing.T) {
+	t.Run("rotate deactivates refresh token and deletes access tokens", func(t *testing.T) {
 		withStorage(t, func(ctx context.Context, s *MemoryStorage) {
 			client := testClient()
 			request := newMockRequester("request-123", client)
@@ -424,10 +424,55 @@ func TestMemoryStorage_RotateRefreshToken(t *testing.T) {
 			require.NoError(t, s.CreateAccessTokenSession(ctx, "access-sig", request))
 			require.NoError(t, s.RotateRefreshToken(ctx, "request-123", "refresh-sig"))
 
-			_, err := s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
-			requireNotFoundError(t, err)
+			// The rotated token is reported as inactive so reuse is detectable.
+			got, err := s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
+			require.ErrorIs(t, err, fosite.ErrInactiveToken)
+			require.NotNil(t, got)
+			assert.Equal(t, "request-123", got.GetID())
 			_, err = s.GetAccessTokenSession(ctx, "access-sig", nil)
 			requireNotFoundError(t, err)
+
+			require.NoError(t, s.DeleteRefreshTokenSession(ctx, "refresh-sig"))
+			_, err = s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
+			requireNotFoundError(t, err)
+		})
+	})
+
+	t.Run("revoke removes the whole token family", func(t *testing.T) {
+		withStorage(t, func(ctx context.Context, s *MemoryStorage) {
+			client := testClient()
+			request := newMockRequester("request-123", client)
+
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "refresh-sig-1", "access-sig-1", request))
+			require.NoError(t, s.RotateRefreshToken(ctx, "request-123", "refresh-sig-1"))
+			require.NoError(t, s.CreateAccessTokenSession(ctx, "access-sig-2", request))
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "refresh-sig-2", "access-sig-2", request))
+
+			require.NoError(t, s.RevokeRefreshToken(ctx, "request-123"))
+			require.NoError(t, s.RevokeAccessToken(ctx, "request-123"))
+
+			for _, sig := range []string{"refresh-sig-1", "refresh-sig-2"} {
+				_, err := s.GetRefreshTokenSession(ctx, sig, nil)
+				requireNotFoundError(t, err)
+			}
+			_, err := s.GetAccessTokenSession(ctx, "access-sig-2", nil)
+			requireNotFoundError(t, err)
+		})
+	})
+
+	t.Run("rotated token is cleaned up after expiry", func(t *testing.T) {
+		withStorage(t, func(ctx context.Context, s *MemoryStorage) {
+			request := newMockRequester("request-123", testClient())
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "refresh-sig", "access-sig", request))
+			require.NoError(t, s.RotateRefreshToken(ctx, "request-123", "refresh-sig"))
+
+			s.mu.Lock()
+			s.rotatedRefreshTokens["refresh-sig"].expiresAt = time.Now().Add(-time.Second)
+			s.mu.Unlock()
+			s.cleanupExpired()
+
+			_, err := s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
+			requireNotFoundError(t, err)
 		})
 	})
 
@@ -1028,6 +1073,26 @@ func TestMemoryStorage_CleanupExpired(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "pending authorizations",
+			setup: func(ctx context.Context, s *MemoryStorage) {
+				_ = s.StorePendingAuthorization(ctx, "expired", &PendingAuthorization{ClientID: "c", CreatedAt: time.Now()})
+				_ = s.StorePendingAuthorization(ctx, "valid", &PendingAuthorization{ClientID: "c", CreatedAt: time.Now()})
+				// Simulate a user who never came back from the upstream IDP.
+				s.mu.Lock()
+				s.pendingAuthorizations["expired"].expiresAt = time.Now().Add(-time.Minute)
+				s.mu.Unlock()
+			},
+			getStats: func(st Stats) int { return st.PendingAuthorizations },
+			verifyGone: func(ctx context.Context, s *MemoryStorage) error {
+				_, err := s.LoadPendingAuthorization(ctx, "expired")
+				return err
+			},
+			verifyKeep: func(ctx context.Context, s *MemoryStorage) error {
+				_, err := s.LoadPendingAuthorization(ctx, "valid")
+				return err
+			},
+		},
 		{
 			name: "client assertion JWTs",
 			setup: func(_ context.Context, s *MemoryStorage) {
@@ -89,6 +89,22 @@ func warnDroppedIndexMembers(operation, indexKey, expectedPrefix string, dropped
 type RedisStorage struct {
 	client    redis.UniversalClient
 	keyPrefix string
+
+	// tokenCipher encrypts upstream token secrets at rest. Nil stores them in
+	// plaintext.
+	tokenCipher *TokenCipher
+}
+
+// RedisStorageOption configures optional RedisStorage behavior.
+type RedisStorageOption func(*RedisStorage)
+
+// WithUpstreamTokenCipher encrypts the access, refresh, and ID tokens of
+// upstream IDPs with cipher before they are written to Redis. Entries written
+// before encryption was enabled remain readable.
+func WithUpstreamTokenCipher(cipher *TokenCipher) RedisStorageOption {
+	return func(s *RedisStorage) {
+		s.tokenCipher = cipher
+	}
 }
 
 // storedSession is a serializable wrapper for fosite.Requester.
@@ -114,7 +130,9 @@ type storedSession struct {
 // delegated to the shared toolhive-core redis package. cfg.Password may be
 // empty when the Redis server does not require authentication (the auth server
 // does not mandate ACL auth); the keyPrefix is storage-specific and required.
-func NewRedisStorage(ctx context.Context, cfg tcredis.Config, keyPrefix string) (*RedisStorage, error) {
+func NewRedisStorage(
+	ctx context.Context, cfg tcredis.Config, keyPrefix string, opts ...RedisStorageOption,
+) (*RedisStorage, error) {
 	if keyPrefix == "" {
 		return nil, errors.New("invalid redis configuration: key prefix is required")
 	}
@@ -124,19 +142,20 @@ func NewRedisStorage(ctx context.Context, cfg tcredis.Config, keyPrefix string)
 		return nil, err
 	}
 
-	return &RedisStorage{
-		client:    client,
-		keyPrefix: keyPrefix,
-	}, nil
+	return NewRedisStorageWithClient(client, keyPrefix, opts...), nil
 }
 
 // NewRedisStorageWithClient creates a RedisStorage with a pre-configured client.
 // This is useful for testing with miniredis.
-func NewRedisStorageWithClient(client redis.UniversalClient, keyPrefix string) *RedisStorage {
-	return &RedisStorage{
+func NewRedisStorageWithClient(client redis.UniversalClient, keyPrefix string, opts ...RedisStorageOption) *RedisStorage {
+	s := &RedisStorage{
 		client:    client,
 		keyPrefix: keyPrefix,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // defaultSessionFactory creates session prototypes for deserialization.
@@ -485,26 +504,45 @@ func (s *RedisStorage) CreateRefreshTokenSession(
 }
 
 // GetRefreshTokenSession retrieves the refresh token session by its signature.
+// A rotated-away token returns its Requester with fosite.ErrInactiveToken, which
+// fosite's refresh handler treats as reuse and answers by revoking the family.
 func (s *RedisStorage) GetRefreshTokenSession(ctx context.Context, signature string, _ fosite.Session) (fosite.Requester, error) {
 	key := redisKey(s.keyPrefix, KeyTypeRefresh, signature)
 
 	data, err := s.client.Get(ctx, key).Bytes()
+	if err == nil {
+		return unmarshalRequester(ctx, data, s)
+	}
+	if !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	rotatedKey := redisKey(s.keyPrefix, KeyTypeRotatedRefresh, signature)
+	data, err = s.client.Get(ctx, rotatedKey).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, fmt.Errorf("%w: %w", ErrNotFound, fosite.ErrNotFound.WithHint("Refresh token not found"))
 		}
-		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+		return nil, fmt.Errorf("failed to get rotated refresh token: %w", err)
 	}
-
-	return unmarshalRequester(ctx, data, s)
+	request, err := unmarshalRequester(ctx, data, s)
+	if err != nil {
+		return nil, err
+	}
+	return request, fosite.ErrInactiveToken.WithHint("Refresh token was already rotated")
 }
 
-// DeleteRefreshTokenSession removes the refresh token session.
+// DeleteRefreshTokenSession removes the refresh token session, whether it is
+// active or rotated.
 func (s *RedisStorage) DeleteRefreshTokenSession(ctx context.Context, signature string) error {
 	key := redisKey(s.keyPrefix, KeyTypeRefresh, signature)
 
 	// Get the request first to find the request ID for cleaning up the index
 	data, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		key = redisKey(s.keyPrefix, KeyTypeRotatedRefresh, signature)
+		data, err = s.client.Get(ctx, key).Bytes()
+	}
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return fmt.Errorf("%w: %w", ErrNotFound, fosite.ErrNotFound.WithHint("Refresh token not found"))
@@ -532,23 +570,30 @@ func (s *RedisStorage) DeleteRefreshTokenSession(ctx context.Context, signature
 // of the in-memory implementation. All cleanup operations are best-effort
 // (see warnOnCleanupErr); the new refresh token has already been issued by fosite,
 // so partial cleanup is acceptable.
+//
+// The rotated token moves to a KeyTypeRotatedRefresh key with its remaining TTL,
+// and stays in the request ID index, so that a replay is detected as reuse and
+// RevokeRefreshToken removes it with the rest of the family.
 func (s *RedisStorage) RotateRefreshToken(ctx context.Context, requestID string, refreshTokenSignature string) error {
-	// Delete the specific refresh token. Del returns the number of keys removed;
-	// 0 means the token did not exist (already rotated or never created).
 	refreshKey := redisKey(s.keyPrefix, KeyTypeRefresh, refreshTokenSignature)
-	deleted, err := s.client.Del(ctx, refreshKey).Result()
-	if err != nil {
+	pipe := s.client.TxPipeline()
+	getCmd := pipe.Get(ctx, refreshKey)
+	ttlCmd := pipe.PTTL(ctx, refreshKey)
+	pipe.Del(ctx, refreshKey)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
 		warnOnCleanupErr(err, "Del", refreshKey)
 	}
-	if deleted == 0 {
+
+	data, err := getCmd.Bytes()
+	if err != nil {
+		// The token did not exist (already rotated or never created).
 		slog.Debug("refresh token not found during rotation, treating as no-op",
 			"request_id", requestID, "signature", refreshTokenSignature)
+	} else if ttl := ttlCmd.Val(); ttl > 0 {
+		rotatedKey := redisKey(s.keyPrefix, KeyTypeRotatedRefresh, refreshTokenSignature)
+		warnOnCleanupErr(s.client.Set(ctx, rotatedKey, data, ttl).Err(), "Set", rotatedKey)
 	}
 
-	// Remove from the request ID index
-	reqIDRefreshKey := redisSetKey(s.keyPrefix, KeyTypeReqIDRefresh, requestID)
-	warnOnCleanupErr(s.client.SRem(ctx, reqIDRefreshKey, refreshTokenSignature).Err(), "SRem", reqIDRefreshKey)
-
 	// Delete all access tokens associated with this request ID
 	reqIDAccessKey := redisSetKey(s.keyPrefix, KeyTypeReqIDAccess, requestID)
 	signatures, err := s.client.SMembers(ctx, reqIDAccessKey).Result()
@@ -597,6 +642,8 @@ func (s *RedisStorage) RevokeRefreshToken(ctx context.Context, requestID string)
 	for _, sig := range signatures {
 		refreshKey := redisKey(s.keyPrefix, KeyTypeRefresh, sig)
 		warnOnCleanupErr(s.client.Del(ctx, refreshKey).Err(), "Del", refreshKey)
+		rotatedKey := redisKey(s.keyPrefix, KeyTypeRotatedRefresh, sig)
+		warnOnCleanupErr(s.client.Del(ctx, rotatedKey).Err(), "Del", rotatedKey)
 	}
 
 	// Clean up the index
@@ -709,6 +756,32 @@ func (s *storedUpstreamTokens) toUpstreamTokens() *UpstreamTokens {
 	}
 }
 
+// seal encrypts the token secrets in place. Identifying fields such as
+// UserID stay in plaintext because the Lua scripts and the user index read
+// them.
+func (s *storedUpstreamTokens) seal(cipher *TokenCipher) error {
+	for _, field := range []*string{&s.AccessToken, &s.RefreshToken, &s.IDToken} {
+		encrypted, err := cipher.Encrypt(*field)
+		if err != nil {
+			return err
+		}
+		*field = encrypted
+	}
+	return nil
+}
+
+// open decrypts the token secrets sealed by seal in place.
+func (s *storedUpstreamTokens) open(cipher *TokenCipher) error {
+	for _, field := range []*string{&s.AccessToken, &s.RefreshToken, &s.IDToken} {
+		decrypted, err := cipher.Decrypt(*field)
+		if err != nil {
+			return err
+		}
+		*field = decrypted
+	}
+	return nil
+}
+
 // storeUpstreamTokensScript atomically reads the existing UserID, writes new token
 // data, updates the session index set, and updates user reverse-index sets.
 // This prevents a race condition where concurrent writes for the same session
@@ -810,8 +883,9 @@ end
 return 1
 `)
 
-// marshalUpstreamTokensWithTTL marshals tokens and calculates TTL.
-func marshalUpstreamTokensWithTTL(tokens *UpstreamTokens) ([]byte, time.Duration, error) {
+// marshalUpstreamTokensWithTTL marshals tokens, with their secrets encrypted
+// by cipher, and calculates TTL.
+func marshalUpstreamTokensWithTTL(tokens *UpstreamTokens, cipher *TokenCipher) ([]byte, time.Duration, error) {
 	if tokens == nil {
 		return []byte(nullMarker), DefaultAccessTokenTTL, nil
 	}
@@ -839,6 +913,9 @@ func marshalUpstreamTokensWithTTL(tokens *UpstreamTokens) ([]byte, time.Duration
 		UpstreamSubject:  tokens.UpstreamSubject,
 		ClientID:         tokens.ClientID,
 	}
+	if err := stored.seal(cipher); err != nil {
+		return nil, 0, fmt.Errorf("failed to encrypt upstream tokens: %w", err)
+	}
 
 	data, err := json.Marshal(stored) //nolint:gosec // G117 - internal Redis storage serialization, not exposed to users
 	if err != nil {
@@ -881,7 +958,7 @@ func (s *RedisStorage) StoreUpstreamTokens(ctx context.Context, sessionID, provi
 	key := redisUpstreamKey(s.keyPrefix, sessionID, providerName)
 	idxKey := redisSetKey(s.keyPrefix, KeyTypeUpstreamIdx, sessionID)
 
-	data, ttl, err := marshalUpstreamTokensWithTTL(tokens)
+	data, ttl, err := marshalUpstreamTokensWithTTL(tokens, s.tokenCipher)
 	if err != nil {
 		return err
 	}
@@ -966,7 +1043,7 @@ func (s *RedisStorage) GetAllUpstreamTokens(ctx context.Context, sessionID strin
 			continue
 		}
 
-		tokens, parseErr := unmarshalUpstreamTokens([]byte(data))
+		tokens, parseErr := unmarshalUpstreamTokens([]byte(data), s.tokenCipher)
 		if parseErr != nil && !errors.Is(parseErr, ErrExpired) {
 			slog.Warn("skipping corrupt upstream token entry", "key", providerKeys[i], "error", parseErr)
 			continue
@@ -1137,6 +1214,9 @@ func (s *RedisStorage) GetLatestUpstreamTokensForUser(ctx context.Context, userI
 		return nil, fmt.Errorf("%w: %w", ErrNotFound, fosite.ErrNotFound.WithHint("Upstream tokens not found"))
 	}
 
+	if err := winner.open(s.tokenCipher); err != nil {
+		return nil, fmt.Errorf("failed to decrypt upstream tokens: %w", err)
+	}
 	return winner.toUpstreamTokens(), nil
 }
 
@@ -1205,11 +1285,12 @@ func (s *RedisStorage) getUpstreamTokensFromKey(ctx context.Context, key string)
 		return nil, fmt.Errorf("failed to get upstream tokens: %w", err)
 	}
 
-	return unmarshalUpstreamTokens(data)
+	return unmarshalUpstreamTokens(data, s.tokenCipher)
 }
 
-// unmarshalUpstreamTokens deserializes upstream tokens from JSON bytes.
-func unmarshalUpstreamTokens(data []byte) (*UpstreamTokens, error) {
+// unmarshalUpstreamTokens deserializes upstream tokens from JSON bytes and
+// decrypts their secrets with cipher.
+func unmarshalUpstreamTokens(data []byte, cipher *TokenCipher) (*UpstreamTokens, error) {
 	// Handle null marker
 	if string(data) == nullMarker {
 		return nil, nil
@@ -1219,6 +1300,9 @@ func unmarshalUpstreamTokens(data []byte) (*UpstreamTokens, error) {
 	if err := json.Unmarshal(data, &stored); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal upstream tokens: %w", err)
 	}
+	if err := stored.open(cipher); err != nil {
+		return nil, fmt.Errorf("failed to decrypt upstream tokens: %w", err)
+	}
 
 	tokens := stored.toUpstreamTokens()
 
@@ -504,7 +504,7 @@ func TestIntegration_RefreshTokenLifecycle(t *testing.T) {
 		})
 	})
 
-	t.Run("rotation deletes refresh and access tokens", func(t *testing.T) {
+	t.Run("rotation deactivates refresh token and deletes access tokens", func(t *testing.T) {
 		withIntegrationStorage(t, func(ctx context.Context, s *RedisStorage) {
 			client := testClient()
 			require.NoError(t, s.RegisterClient(ctx, client))
@@ -516,7 +516,7 @@ func TestIntegration_RefreshTokenLifecycle(t *testing.T) {
 			require.NoError(t, s.RotateRefreshToken(ctx, "req-rotate", "rt-rotate"))
 
 			_, err := s.GetRefreshTokenSession(ctx, "rt-rotate", nil)
-			requireRedisNotFoundError(t, err)
+			require.ErrorIs(t, err, fosite.ErrInactiveToken)
 			_, err = s.GetAccessTokenSession(ctx, "at-rotate", nil)
 			requireRedisNotFoundError(t, err)
 		})
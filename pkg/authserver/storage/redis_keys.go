@@ -14,6 +14,10 @@ const (
 	// KeyTypeRefresh is the key type for refresh tokens.
 	KeyTypeRefresh = "refresh"
 
+	// KeyTypeRotatedRefresh is the key type for rotated-away refresh tokens,
+	// kept until they would have expired to detect refresh token reuse.
+	KeyTypeRotatedRefresh = "refresh:rotated"
+
 	// KeyTypeAuthCode is the key type for authorization codes.
 	KeyTypeAuthCode = "authcode"
 
@@ -568,8 +568,8 @@ func TestRedisStorage_RefreshToken(t *testing.T) {
 func TestRedisStorage_RotateRefreshToken(t *testing.T) {
 	t.Parallel()
 
-	t.Run("rotate deletes refresh and access tokens", func(t *testing.T) {
-		withRedisStorage(t, func(ctx context.Context, s *RedisStorage, _ *miniredis.Miniredis) {
+	t.Run("rotate deactivates refresh token and deletes access tokens", func(t *testing.T) {
+		withRedisStorage(t, func(ctx context.Context, s *RedisStorage, mr *miniredis.Miniredis) {
 			client := testClient()
 			require.NoError(t, s.RegisterClient(ctx, client))
 
@@ -579,10 +579,44 @@ func TestRedisStorage_RotateRefreshToken(t *testing.T) {
 			require.NoError(t, s.CreateAccessTokenSession(ctx, "access-sig", request))
 			require.NoError(t, s.RotateRefreshToken(ctx, "request-123", "refresh-sig"))
 
-			_, err := s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
-			requireRedisNotFoundError(t, err)
+			// The rotated token is reported as inactive so reuse is detectable.
+			got, err := s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
+			require.ErrorIs(t, err, fosite.ErrInactiveToken)
+			require.NotNil(t, got)
+			assert.Equal(t, "request-123", got.GetID())
 			_, err = s.GetAccessTokenSession(ctx, "access-sig", nil)
 			requireRedisNotFoundError(t, err)
+
+			// The rotated token keeps the remaining lifetime of the original.
+			rotatedKey := redisKey(s.keyPrefix, KeyTypeRotatedRefresh, "refresh-sig")
+			assert.Positive(t, mr.TTL(rotatedKey))
+
+			require.NoError(t, s.DeleteRefreshTokenSession(ctx, "refresh-sig"))
+			_, err = s.GetRefreshTokenSession(ctx, "refresh-sig", nil)
+			requireRedisNotFoundError(t, err)
+		})
+	})
+
+	t.Run("revoke removes the whole token family", func(t *testing.T) {
+		withRedisStorage(t, func(ctx context.Context, s *RedisStorage, _ *miniredis.Miniredis) {
+			client := testClient()
+			require.NoError(t, s.RegisterClient(ctx, client))
+			request := newRedisTestRequester("request-123", client)
+
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "refresh-sig-1", "access-sig-1", request))
+			require.NoError(t, s.RotateRefreshToken(ctx, "request-123", "refresh-sig-1"))
+			require.NoError(t, s.CreateAccessTokenSession(ctx, "access-sig-2", request))
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "refresh-sig-2", "access-sig-2", request))
+
+			require.NoError(t, s.RevokeRefreshToken(ctx, "request-123"))
+			require.NoError(t, s.RevokeAccessToken(ctx, "request-123"))
+
+			for _, sig := range []string{"refresh-sig-1", "refresh-sig-2"} {
+				_, err := s.GetRefreshTokenSession(ctx, sig, nil)
+				requireRedisNotFoundError(t, err)
+			}
+			_, err := s.GetAccessTokenSession(ctx, "access-sig-2", nil)
+			requireRedisNotFoundError(t, err)
 		})
 	})
 
@@ -2985,3 +3019,62 @@ func TestFilterIndexMembersByPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisStorage_UpstreamTokenEncryption(t *testing.T) {
+	t.Parallel()
+
+	cipherA, err := NewTokenCipher(testEncryptionSecretA)
+	require.NoError(t, err)
+	rotated, err := NewTokenCipher(testEncryptionSecretB, testEncryptionSecretA)
+	require.NoError(t, err)
+
+	tokens := &UpstreamTokens{
+		ProviderID:   "prov-X",
+		AccessToken:  "plaintext-access-token",
+		RefreshToken: "plaintext-refresh-token",
+		IDToken:      "plaintext-id-token",
+		UserID:       "user-A",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := context.Background()
+
+	before := NewRedisStorageWithClient(client, "test:auth:", WithUpstreamTokenCipher(cipherA))
+	require.NoError(t, before.StoreUpstreamTokens(ctx, "session-1", "prov-X", tokens))
+
+	raw, err := mr.Get(redisUpstreamKey("test:auth:", "session-1", "prov-X"))
+	require.NoError(t, err)
+	assert.NotContains(t, raw, "plaintext-")
+	assert.Contains(t, raw, "user-A", "identifying fields stay readable by the Lua scripts")
+
+	// After rotating to a new key, entries written with the previous key
+	// remain readable and new entries use the new key.
+	after := NewRedisStorageWithClient(client, "test:auth:", WithUpstreamTokenCipher(rotated))
+	got, err := after.GetUpstreamTokens(ctx, "session-1", "prov-X")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.AccessToken, got.AccessToken)
+	assert.Equal(t, tokens.RefreshToken, got.RefreshToken)
+	assert.Equal(t, tokens.IDToken, got.IDToken)
+
+	latest, err := after.GetLatestUpstreamTokensForUser(ctx, "user-A", "prov-X")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.RefreshToken, latest.RefreshToken)
+
+	require.NoError(t, after.StoreUpstreamTokens(ctx, "session-2", "prov-X", tokens))
+	all, err := after.GetAllUpstreamTokens(ctx, "session-2")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.AccessToken, all["prov-X"].AccessToken)
+
+	_, err = before.GetUpstreamTokens(ctx, "session-2", "prov-X")
+	assert.ErrorIs(t, err, ErrUnknownEncryptionKey, "entries written after rotation use the new key")
+
+	// Entries written before encryption was enabled remain readable.
+	plain := NewRedisStorageWithClient(client, "test:auth:")
+	require.NoError(t, plain.StoreUpstreamTokens(ctx, "session-3", "prov-X", tokens))
+	got, err = after.GetUpstreamTokens(ctx, "session-3", "prov-X")
+	require.NoError(t, err)
+	assert.Equal(t, tokens.AccessToken, got.AccessToken)
+}
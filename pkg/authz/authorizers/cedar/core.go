@@ -96,6 +96,26 @@ func InjectUpstreamProvider(src *authorizers.Config, providerName string) (*auth
 	return authorizers.NewConfig(cedarCfg)
 }
 
+// ReplacePolicySet returns a new authorizers.Config that is identical to base
+// except that its Cedar policies and entities are replaced with policies and
+// entitiesJSON. Every other option of base, such as the claim mappings and the
+// primary upstream provider, is kept. This is used to apply a reloaded policy
+// set on top of the configuration a proxy was started with, and, with no
+// policies, to leave the policy set out of that configuration.
+//
+// Unlike InjectUpstreamProvider, a non-Cedar base is an error: only Cedar
+// policy sets can be replaced.
+func ReplacePolicySet(base *authorizers.Config, policies []string, entitiesJSON string) (*authorizers.Config, error) {
+	cedarCfg, err := ExtractConfig(base)
+	if err != nil {
+		return nil, fmt.Errorf("policy set can only be replaced in a Cedar config: %w", err)
+	}
+
+	cedarCfg.Options.Policies = policies
+	cedarCfg.Options.EntitiesJSON = entitiesJSON
+	return authorizers.NewConfig(cedarCfg)
+}
+
 // Factory implements the authorizers.AuthorizerFactory interface for Cedar.
 type Factory struct{}
 
@@ -32,6 +32,18 @@ var NewConfig = authorizers.NewConfig
 func CreateMiddlewareFromConfig(
 	c *Config, serverName string, passThroughTools map[string]struct{},
 ) (types.MiddlewareFunction, error) {
+	authz, err := NewAuthorizer(c, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the middleware
+	return func(handler http.Handler) http.Handler { return Middleware(authz, handler, passThroughTools) }, nil
+}
+
+// NewAuthorizer creates the authorizer for the configuration. For Cedar this
+// parses the policies and entities, so it also serves to validate them.
+func NewAuthorizer(c *Config, serverName string) (authorizers.Authorizer, error) {
 	// Get the factory for this config type
 	factory := authorizers.GetFactory(string(c.Type))
 	if factory == nil {
@@ -43,9 +55,7 @@ func CreateMiddlewareFromConfig(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create %s authorizer: %w", c.Type, err)
 	}
-
-	// Return the middleware
-	return func(handler http.Handler) http.Handler { return Middleware(authz, handler, passThroughTools) }, nil
+	return authz, nil
 }
 
 // GetMiddlewareFromFile loads the authorization configuration from a file and creates an HTTP middleware.
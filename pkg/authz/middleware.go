@@ -16,6 +16,7 @@ import (
 	"golang.org/x/exp/jsonrpc2"
 
 	"github.com/stacklok/toolhive/pkg/authz/authorizers"
+	"github.com/stacklok/toolhive/pkg/authz/authorizers/cedar"
 	"github.com/stacklok/toolhive/pkg/mcp"
 	"github.com/stacklok/toolhive/pkg/transport/ssecommon"
 	"github.com/stacklok/toolhive/pkg/transport/types"
@@ -340,11 +341,16 @@ const (
 type FactoryMiddlewareParams struct {
 	ConfigPath string  `json:"config_path,omitempty"` // Kept for backwards compatibility
 	ConfigData *Config `json:"config_data,omitempty"` // New field for config contents
+	// PolicyPath is an authorization config file whose Cedar policies and
+	// entities replace those of the config above, and are reloaded in place
+	// whenever the file changes.
+	PolicyPath string `json:"policy_path,omitempty"`
 }
 
 // FactoryMiddleware wraps authorization middleware functionality for factory pattern
 type FactoryMiddleware struct {
 	middleware types.MiddlewareFunction
+	watcher    *PolicyWatcher
 }
 
 // Handler returns the middleware function used by the proxy.
@@ -353,8 +359,11 @@ func (m *FactoryMiddleware) Handler() types.MiddlewareFunction {
 }
 
 // Close cleans up any resources used by the middleware.
-func (*FactoryMiddleware) Close() error {
-	// Authorization middleware doesn't need cleanup
+func (m *FactoryMiddleware) Close() error {
+	// Only a reloading policy set has anything to clean up
+	if m.watcher != nil {
+		return m.watcher.Close()
+	}
 	return nil
 }
 
@@ -382,6 +391,15 @@ func CreateMiddleware(config *types.MiddlewareConfig, runner types.MiddlewareRun
 		return fmt.Errorf("either config_data or config_path is required for authorization middleware")
 	}
 
+	if params.PolicyPath != "" {
+		authzMw, err := newReloadingMiddleware(authzConfig, params.PolicyPath, runner.GetConfig().GetName())
+		if err != nil {
+			return fmt.Errorf("failed to create authorization middleware: %w", err)
+		}
+		runner.AddMiddleware(config.Type, authzMw)
+		return nil
+	}
+
 	middleware, err := CreateMiddlewareFromConfig(authzConfig, runner.GetConfig().GetName(), nil)
 	if err != nil {
 		return fmt.Errorf("failed to create authorization middleware: %w", err)
@@ -391,3 +409,36 @@ func CreateMiddleware(config *types.MiddlewareConfig, runner types.MiddlewareRun
 	runner.AddMiddleware(config.Type, authzMw)
 	return nil
 }
+
+// newReloadingMiddleware creates authorization middleware enforcing the policy
+// set in policyPath on top of authzConfig, and reloading it whenever the file
+// changes. The file must hold a valid policy set at startup, since there is no
+// previous one to fall back to.
+func newReloadingMiddleware(authzConfig *Config, policyPath, serverName string) (*FactoryMiddleware, error) {
+	policyFile, err := LoadConfig(policyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load authorization policy file: %w", err)
+	}
+	policyCfg, err := cedar.ExtractConfig(policyFile)
+	if err != nil {
+		return nil, fmt.Errorf("authorization policy file is not a Cedar config: %w", err)
+	}
+	initial, err := cedar.ReplacePolicySet(authzConfig, policyCfg.Options.Policies, policyCfg.Options.EntitiesJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := NewReloadableAuthorizer(initial, serverName)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := WatchPolicyFile(authorizer, policyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FactoryMiddleware{
+		middleware: func(handler http.Handler) http.Handler { return Middleware(authorizer, handler, nil) },
+		watcher:    watcher,
+	}, nil
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/stacklok/toolhive/pkg/authz/authorizers"
+	"github.com/stacklok/toolhive/pkg/authz/authorizers/cedar"
+)
+
+// policyWatchDebounce is how long the policy file must stay quiet before it is
+// reloaded. A Kubernetes ConfigMap volume update, or an editor save, produces
+// several events for a single change.
+const policyWatchDebounce = 250 * time.Millisecond
+
+// ReloadableAuthorizer is an Authorizer whose Cedar policy set can be replaced
+// while it serves requests. A new policy set is compiled before it is swapped
+// in, so an invalid one is rejected and the previous set keeps being enforced.
+type ReloadableAuthorizer struct {
+	serverName string
+
+	mu         sync.RWMutex
+	config     *Config
+	authorizer authorizers.Authorizer
+}
+
+// NewReloadableAuthorizer creates a ReloadableAuthorizer enforcing cfg, which
+// must be a Cedar config.
+func NewReloadableAuthorizer(cfg *Config, serverName string) (*ReloadableAuthorizer, error) {
+	if _, err := cedar.ExtractConfig(cfg); err != nil {
+		return nil, fmt.Errorf("policy reloading requires a Cedar config: %w", err)
+	}
+	authorizer, err := NewAuthorizer(cfg, serverName)
+	if err != nil {
+		return nil, err
+	}
+	return &ReloadableAuthorizer{serverName: serverName, config: cfg, authorizer: authorizer}, nil
+}
+
+// AuthorizeWithJWTClaims authorizes the request with the current policy set.
+func (r *ReloadableAuthorizer) AuthorizeWithJWTClaims(
+	ctx context.Context,
+	feature authorizers.MCPFeature,
+	operation authorizers.MCPOperation,
+	resourceID string,
+	arguments map[string]interface{},
+) (bool, error) {
+	r.mu.RLock()
+	authorizer := r.authorizer
+	r.mu.RUnlock()
+	return authorizer.AuthorizeWithJWTClaims(ctx, feature, operation, resourceID, arguments)
+}
+
+// ReloadPolicySet replaces the policies and entities being enforced with those
+// of update, keeping every other option of the current config. If the new
+// policy set does not compile, an error is returned and the current policy set
+// stays in force.
+func (r *ReloadableAuthorizer) ReloadPolicySet(update *Config) error {
+	updateCfg, err := cedar.ExtractConfig(update)
+	if err != nil {
+		return fmt.Errorf("policy update is not a Cedar config: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := cedar.ReplacePolicySet(r.config, updateCfg.Options.Policies, updateCfg.Options.EntitiesJSON)
+	if err != nil {
+		return err
+	}
+	authorizer, err := NewAuthorizer(cfg, r.serverName)
+	if err != nil {
+		return fmt.Errorf("invalid policy set: %w", err)
+	}
+	r.config = cfg
+	r.authorizer = authorizer
+	return nil
+}
+
+// PolicyWatcher reloads the policy set of a ReloadableAuthorizer from a file
+// whenever the file changes. It must be stopped with Close.
+type PolicyWatcher struct {
+	watcher    *fsnotify.Watcher
+	path       string
+	debounce   time.Duration
+	authorizer *ReloadableAuthorizer
+
+	// last is the raw config of the most recently applied (or rejected)
+	// policy file, used to skip events that did not change it.
+	last []byte
+
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchPolicyFile watches the authorization config file at path and applies
+// its Cedar policies and entities to authorizer whenever it changes. Rejected
+// updates are logged and leave the previous policy set in force.
+//
+// The parent directory is watched rather than the file itself, so files
+// replaced by renaming, such as Kubernetes ConfigMap volume entries (which are
+// symlinks swapped through a "..data" directory), keep being tracked.
+func WatchPolicyFile(authorizer *ReloadableAuthorizer, path string) (*PolicyWatcher, error) {
+	return watchPolicyFile(authorizer, path, policyWatchDebounce)
+}
+
+func watchPolicyFile(authorizer *ReloadableAuthorizer, path string, debounce time.Duration) (*PolicyWatcher, error) {
+	path = filepath.Clean(path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	w := &PolicyWatcher{
+		watcher:    watcher,
+		path:       path,
+		debounce:   debounce,
+		authorizer: authorizer,
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	if cfg, err := LoadConfig(path); err == nil {
+		w.last = cfg.RawConfig()
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Close stops the watcher and waits for any in-flight reload to finish.
+func (w *PolicyWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.watcher.Close()
+		<-w.stopped
+	})
+	return err
+}
+
+func (w *PolicyWatcher) run() {
+	defer close(w.stopped)
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			// Every other event in the directory may be the file changing:
+			// ConfigMap volumes update by swapping a symlinked directory, so
+			// the file itself never shows up in the events.
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			timer.Reset(w.debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("error watching authorization policy file", "path", w.path, "error", err)
+		case <-timer.C:
+			w.reload()
+		}
+	}
+}
+
+// reload applies the policy file if its content changed since the last attempt.
+func (w *PolicyWatcher) reload() {
+	cfg, err := LoadConfig(w.path)
+	if err != nil {
+		slog.Warn("rejected authorization policy update, keeping the previous policy set",
+			"path", w.path, "error", err)
+		return
+	}
+	if bytes.Equal(cfg.RawConfig(), w.last) {
+		return
+	}
+	w.last = cfg.RawConfig()
+
+	if err := w.authorizer.ReloadPolicySet(cfg); err != nil {
+		slog.Warn("rejected authorization policy update, keeping the previous policy set",
+			"path", w.path, "error", err)
+		return
+	}
+	slog.Info("reloaded authorization policy set", "path", w.path)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/authz/authorizers"
+	"github.com/stacklok/toolhive/pkg/authz/authorizers/cedar"
+)
+
+const (
+	permitWeather = `permit(principal, action == Action::"call_tool", resource == Tool::"weather");`
+	permitSearch  = `permit(principal, action == Action::"call_tool", resource == Tool::"search");`
+	invalidPolicy = `permit(principal, action == Action::"call_tool", resource == Tool::"search"`
+)
+
+func cedarPolicyConfig(policies ...string) cedar.Config {
+	return cedar.Config{
+		Version: "1.0",
+		Type:    cedar.ConfigType,
+		Options: &cedar.ConfigOptions{
+			Policies:     policies,
+			EntitiesJSON: "[]",
+		},
+	}
+}
+
+func identityContext(t *testing.T) context.Context {
+	t.Helper()
+	claims := map[string]interface{}{"sub": "user123"}
+	identity := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "user123", Claims: claims}}
+	return auth.WithIdentity(t.Context(), identity)
+}
+
+// callAllowed reports whether the authorizer allows calling the named tool.
+func callAllowed(t *testing.T, authorizer authorizers.Authorizer, tool string) bool {
+	t.Helper()
+	allowed, err := authorizer.AuthorizeWithJWTClaims(
+		identityContext(t), authorizers.MCPFeatureTool, authorizers.MCPOperationCall, tool, nil)
+	require.NoError(t, err)
+	return allowed
+}
+
+func TestReloadableAuthorizer_ReloadPolicySet(t *testing.T) {
+	t.Parallel()
+
+	authorizer, err := NewReloadableAuthorizer(mustNewConfig(t, cedarPolicyConfig(permitWeather)), "testmodule")
+	require.NoError(t, err)
+	assert.True(t, callAllowed(t, authorizer, "weather"))
+	assert.False(t, callAllowed(t, authorizer, "search"))
+
+	// An invalid policy set is rejected and the previous one stays in force.
+	err = authorizer.ReloadPolicySet(mustNewConfig(t, cedarPolicyConfig(invalidPolicy)))
+	require.ErrorContains(t, err, "invalid policy set")
+	assert.True(t, callAllowed(t, authorizer, "weather"))
+	assert.False(t, callAllowed(t, authorizer, "search"))
+
+	// A valid policy set replaces the previous one.
+	require.NoError(t, authorizer.ReloadPolicySet(mustNewConfig(t, cedarPolicyConfig(permitSearch))))
+	assert.False(t, callAllowed(t, authorizer, "weather"))
+	assert.True(t, callAllowed(t, authorizer, "search"))
+}
+
+func TestNewReloadableAuthorizer_RequiresCedar(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewReloadableAuthorizer(&Config{Version: "1.0", Type: "unknown"}, "testmodule")
+	assert.Error(t, err)
+}
+
+func TestWatchPolicyFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "authz.json")
+	writePolicy := func(policies ...string) {
+		t.Helper()
+		data, err := json.Marshal(cedarPolicyConfig(policies...))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+	}
+
+	writePolicy(permitWeather)
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	authorizer, err := NewReloadableAuthorizer(cfg, "testmodule")
+	require.NoError(t, err)
+
+	watcher, err := watchPolicyFile(authorizer, path, 10*time.Millisecond)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = watcher.Close() })
+
+	// An invalid update is rejected; the weather policy keeps being enforced.
+	writePolicy(invalidPolicy)
+	assert.Never(t, func() bool {
+		return !callAllowed(t, authorizer, "weather")
+	}, 300*time.Millisecond, 20*time.Millisecond)
+
+	// A valid update is applied.
+	writePolicy(permitSearch)
+	require.Eventually(t, func() bool {
+		return callAllowed(t, authorizer, "search") && !callAllowed(t, authorizer, "weather")
+	}, 5*time.Second, 20*time.Millisecond)
+}
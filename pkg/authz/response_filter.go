@@ -589,6 +589,7 @@ func (rfw *ResponseFilteringWriter) filterFindToolResponse(response *jsonrpc2.Re
 	rfw.annotationCache.SetFromToolsList(output.Tools)
 
 	output.Tools = filterToolsByPolicy(rfw.request.Context(), rfw.authorizer, output.Tools)
+	output.Explanations = filterExplanations(output.Explanations, output.Tools)
 
 	filteredText, err := json.Marshal(output)
 	if err != nil {
@@ -607,3 +608,23 @@ func (rfw *ResponseFilteringWriter) filterFindToolResponse(response *jsonrpc2.Re
 		Result: json.RawMessage(filteredResult),
 	}, nil
 }
+
+// filterExplanations keeps only the find_tool explanations of tools that
+// remain after authorization filtering, so explain mode cannot reveal the
+// names of denied tools.
+func filterExplanations(explanations []optimizer.MatchExplanation, tools []mcp.Tool) []optimizer.MatchExplanation {
+	if explanations == nil {
+		return nil
+	}
+	allowed := make(map[string]struct{}, len(tools))
+	for _, t := range tools {
+		allowed[t.Name] = struct{}{}
+	}
+	filtered := make([]optimizer.MatchExplanation, 0, len(tools))
+	for _, e := range explanations {
+		if _, ok := allowed[e.ToolName]; ok {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
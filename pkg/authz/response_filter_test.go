@@ -32,7 +32,13 @@ import (
 // text is a serialised find_tool output containing the given tools.
 func buildFindToolJSONRPCResponse(t *testing.T, tools []mcp.Tool) []byte {
 	t.Helper()
-	output := optimizer.FindToolOutput{Tools: tools}
+	return buildFindToolOutputJSONRPCResponse(t, optimizer.FindToolOutput{Tools: tools})
+}
+
+// buildFindToolOutputJSONRPCResponse creates a JSON-RPC tools/call response whose
+// content text is the given serialised find_tool output.
+func buildFindToolOutputJSONRPCResponse(t *testing.T, output optimizer.FindToolOutput) []byte {
+	t.Helper()
 	outputJSON, err := json.Marshal(output)
 	require.NoError(t, err)
 
@@ -129,6 +135,30 @@ func TestFindToolResponseFilter(t *testing.T) {
 		assert.Equal(t, "weather", output.Tools[0].Name)
 	})
 
+	t.Run("explanations of unauthorized tools are removed", func(t *testing.T) {
+		t.Parallel()
+
+		responseBytes := buildFindToolOutputJSONRPCResponse(t, optimizer.FindToolOutput{
+			Tools: []mcp.Tool{
+				{Name: "weather", Description: "Get weather"},
+				{Name: "admin_tool", Description: "Admin operations"},
+			},
+			Explanations: []optimizer.MatchExplanation{
+				{ToolName: "weather", RankedBy: "keyword", Score: 0.5},
+				{ToolName: "admin_tool", RankedBy: "keyword", Score: 0.4},
+			},
+		})
+
+		rr, fw := newWriter(t, nil)
+		_, err := fw.Write(responseBytes)
+		require.NoError(t, err)
+		require.NoError(t, fw.FlushAndFilter())
+
+		output := decodeFindToolOutput(t, rr.Body.Bytes())
+		require.Len(t, output.Explanations, 1, "denied tools must not leak through explanations")
+		assert.Equal(t, "weather", output.Explanations[0].ToolName)
+	})
+
 	t.Run("isError response passes through unfiltered", func(t *testing.T) {
 		t.Parallel()
 
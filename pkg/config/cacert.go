@@ -6,8 +6,7 @@ package config
 import (
 	"fmt"
 	"os"
-
-	"github.com/stacklok/toolhive/pkg/certs"
+	"path/filepath"
 )
 
 // setCACert validates and sets the CA certificate path using the provided provider.
@@ -19,25 +18,14 @@ import (
 //
 // The function returns an error if any validation fails or if updating the configuration fails.
 func setCACert(provider Provider, certPath string) error {
-	// Validate and clean the file path
-	cleanPath, err := validateFilePath(certPath)
-	if err != nil {
-		return fmt.Errorf("CA certificate %w", err)
-	}
-
-	// Read the certificate
-	certContent, err := readFile(cleanPath)
-	if err != nil {
-		return fmt.Errorf("CA certificate %w", err)
-	}
-
-	// Validate the certificate format
-	if err := certs.ValidateCACertificate(certContent); err != nil {
-		return fmt.Errorf("invalid CA certificate: %w", err)
+	// Validate the file and the certificate format
+	if err := validateCACertificateFile(certPath); err != nil {
+		return err
 	}
+	cleanPath := filepath.Clean(certPath)
 
 	// Update the configuration
-	err = provider.UpdateConfig(func(c *Config) error {
+	err := provider.UpdateConfig(func(c *Config) error {
 		c.CACertificatePath = cleanPath
 		return nil
 	})
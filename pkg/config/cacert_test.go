@@ -204,7 +204,7 @@ func TestProviderInterfaceCACert(t *testing.T) {
 	tests := []struct {
 		name     string
 		provider func(tempDir string) Provider
-		expectOp bool // false for k8s no-ops
+		expectOp bool // false for k8s, where writes are rejected
 	}{
 		{
 			name: "PathProvider operations",
@@ -214,9 +214,9 @@ func TestProviderInterfaceCACert(t *testing.T) {
 			expectOp: true,
 		},
 		{
-			name: "KubernetesProvider no-ops",
+			name: "KubernetesProvider read-only",
 			provider: func(_ string) Provider {
-				return NewKubernetesProvider()
+				return NewKubernetesProviderWithDir("")
 			},
 			expectOp: false,
 		},
@@ -236,7 +236,11 @@ func TestProviderInterfaceCACert(t *testing.T) {
 
 			// Set
 			err := provider.SetCACert(certPath)
-			assert.NoError(t, err)
+			if tt.expectOp {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
+			}
 
 			// Get
 			path, exists, accessible := provider.GetCACert()
@@ -252,7 +256,11 @@ func TestProviderInterfaceCACert(t *testing.T) {
 
 			// Unset
 			err = provider.UnsetCACert()
-			assert.NoError(t, err)
+			if tt.expectOp {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
+			}
 
 			// Verify unset
 			_, exists, _ = provider.GetCACert()
@@ -10,8 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/adrg/xdg"
@@ -20,7 +25,6 @@ import (
 	"github.com/stacklok/toolhive-core/env"
 	"github.com/stacklok/toolhive/pkg/container/templates"
 	"github.com/stacklok/toolhive/pkg/llm"
-	"github.com/stacklok/toolhive/pkg/lockfile"
 	"github.com/stacklok/toolhive/pkg/oidc"
 	"github.com/stacklok/toolhive/pkg/secrets"
 )
@@ -43,6 +47,7 @@ type Config struct {
 	MiddlewareTelemetryMigration bool                                `yaml:"middleware_telemetry_migration,omitempty"`
 	SecretScopeMigration         bool                                `yaml:"secret_scope_migration,omitempty"`
 	DisableUsageMetrics          bool                                `yaml:"disable_usage_metrics,omitempty"`
+	LogLevel                     string                              `yaml:"log_level,omitempty"`
 	BuildEnv                     map[string]string                   `yaml:"build_env,omitempty"`
 	BuildEnvFromSecrets          map[string]string                   `yaml:"build_env_from_secrets,omitempty"`
 	BuildEnvFromShell            []string                            `yaml:"build_env_from_shell,omitempty"`
@@ -86,12 +91,15 @@ func validateProviderType(provider string) (secrets.ProviderType, error) {
 		return secrets.OnePasswordType, nil
 	case string(secrets.EnvironmentType):
 		return secrets.EnvironmentType, nil
+	case string(secrets.VaultType):
+		return secrets.VaultType, nil
 	default:
-		return "", fmt.Errorf("invalid secrets provider type: %s (valid types: %s, %s, %s)",
+		return "", fmt.Errorf("invalid secrets provider type: %s (valid types: %s, %s, %s, %s)",
 			provider,
 			string(secrets.EncryptedType),
 			string(secrets.OnePasswordType),
 			string(secrets.EnvironmentType),
+			string(secrets.VaultType),
 		)
 	}
 }
@@ -339,49 +347,7 @@ func UpdateConfig(updateFn func(*Config) error) error {
 // from the anonymous function, writes to disk and unlocks the file.
 // If configPath is empty, it uses the default path.
 func UpdateConfigAtPath(configPath string, updateFn func(*Config) error) error {
-	if configPath == "" {
-		var err error
-		configPath, err = getConfigPath()
-		if err != nil {
-			return fmt.Errorf("unable to fetch config path: %w", err)
-		}
-	}
-
-	// Use a separate lock file for cross-platform compatibility
-	lockPath := configPath + ".lock"
-	fileLock := lockfile.NewTrackedLock(lockPath)
-	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
-	defer cancel()
-
-	// Try and acquire a file lock.
-	locked, err := fileLock.TryLockContext(ctx, 100*time.Millisecond)
-	if err != nil {
-		return fmt.Errorf("failed to acquire lock: %w", err)
-	}
-	if !locked {
-		return fmt.Errorf("failed to acquire lock: timeout after %v", lockTimeout)
-	}
-	defer lockfile.ReleaseTrackedLock(lockPath, fileLock)
-
-	// Load the config after acquiring the lock to avoid race conditions
-	c, err := LoadOrCreateConfigWithPath(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to load config from disk: %w", err)
-	}
-
-	// Apply changes to the config file.
-	if err := updateFn(c); err != nil {
-		return err
-	}
-
-	// Write the updated config to disk.
-	err = c.saveToPath(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
-	// Lock is released automatically when the function returns.
-	return nil
+	return UpdateConfigAtPathContext(context.Background(), configPath, updateFn)
 }
 
 // OpenTelemetryConfig contains the settings for OpenTelemetry configuration.
@@ -400,6 +366,109 @@ type OpenTelemetryConfig struct {
 	Insecure                    bool     `yaml:"insecure,omitempty"`
 	EnablePrometheusMetricsPath bool     `yaml:"enable-prometheus-metrics-path,omitempty"`
 	UseLegacyAttributes         *bool    `yaml:"use-legacy-attributes"`
+	// Headers are sent with every OTLP export, e.g. an API key for an
+	// authenticated collector. Values are redacted when the config is printed.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// HeaderFiles maps OTLP export header names to files holding their values,
+	// so that credentials are not stored in this file. The files are read when
+	// telemetry is configured, and their values override Headers.
+	HeaderFiles map[string]string `yaml:"header-files,omitempty"`
+	// CACertPath is a CA certificate bundle trusted, in addition to the system
+	// CAs, when connecting to the OTLP endpoint.
+	CACertPath string `yaml:"ca-cert-path,omitempty"`
+	// SamplingOverrides maps operation names (e.g. "tools/call" or "ping") to
+	// a sampling rate used for them instead of SamplingRate.
+	SamplingOverrides map[string]float64 `yaml:"sampling-overrides,omitempty"`
+	// PrometheusBasicAuth, when set, protects the Prometheus /metrics endpoint
+	// with HTTP basic authentication.
+	PrometheusBasicAuth *PrometheusBasicAuthConfig `yaml:"prometheus-basic-auth,omitempty"`
+}
+
+// PrometheusBasicAuthConfig holds the credentials required to scrape the
+// Prometheus /metrics endpoint. The password is read from PasswordFile when
+// telemetry starts, so it is never stored in the config file.
+type PrometheusBasicAuthConfig struct {
+	Username     string `yaml:"username"`
+	PasswordFile string `yaml:"password-file"`
+}
+
+// String returns the username and password file.
+func (c *PrometheusBasicAuthConfig) String() string {
+	if c == nil {
+		return "<unset>"
+	}
+	return fmt.Sprintf("{Username: %q, PasswordFile: %q}", c.Username, c.PasswordFile)
+}
+
+var _ fmt.Stringer = OpenTelemetryConfig{}
+var _ fmt.GoStringer = OpenTelemetryConfig{}
+
+// GoString returns the same redacted representation as String().
+// This prevents credential leakage via the %#v format verb, which calls GoString() instead of String().
+func (c OpenTelemetryConfig) GoString() string {
+	return c.String()
+}
+
+// String returns a human-readable representation of the config with header values redacted.
+func (c OpenTelemetryConfig) String() string {
+	return fmt.Sprintf("OpenTelemetryConfig{Endpoint: %q, SamplingRate: %v, EnvVars: %v, MetricsEnabled: %s, "+
+		"TracingEnabled: %s, Insecure: %t, EnablePrometheusMetricsPath: %t, UseLegacyAttributes: %s, "+
+		"Headers: %v, HeaderFiles: %v, CACertPath: %q, SamplingOverrides: %v, PrometheusBasicAuth: %s}",
+		c.Endpoint, c.SamplingRate, c.EnvVars, formatOptionalBool(c.MetricsEnabled),
+		formatOptionalBool(c.TracingEnabled), c.Insecure, c.EnablePrometheusMetricsPath,
+		formatOptionalBool(c.UseLegacyAttributes), RedactOTELHeaders(c.Headers), c.HeaderFiles, c.CACertPath, c.SamplingOverrides,
+		c.PrometheusBasicAuth)
+}
+
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return "<unset>"
+	}
+	return strconv.FormatBool(*b)
+}
+
+// RedactOTELHeaders returns a copy of headers with every value replaced, so
+// that header names can be shown without revealing credentials.
+func RedactOTELHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(headers))
+	for name := range headers {
+		redacted[name] = "[REDACTED]"
+	}
+	return redacted
+}
+
+// ResolveHeaders returns the OTLP export headers: Headers, overridden by the
+// values read from HeaderFiles. Surrounding whitespace in a file, such as a
+// trailing newline, is ignored. A header whose file cannot be read or is empty
+// is left out, and the returned error reports it along with the headers that
+// could be resolved.
+func (c OpenTelemetryConfig) ResolveHeaders() (map[string]string, error) {
+	if len(c.Headers) == 0 && len(c.HeaderFiles) == 0 {
+		return c.Headers, nil
+	}
+	headers := maps.Clone(c.Headers)
+	if headers == nil {
+		headers = make(map[string]string, len(c.HeaderFiles))
+	}
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(c.HeaderFiles)) {
+		path := c.HeaderFiles[name]
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read OTLP header %s from file: %w", name, err))
+			continue
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "" {
+			errs = append(errs, fmt.Errorf("OTLP header %s file %s is empty", name, path))
+			continue
+		}
+		headers[name] = value
+	}
+	return headers, errors.Join(errs...)
 }
 
 // getRuntimeConfig returns the runtime configuration for a given transport type
@@ -5,6 +5,7 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -520,3 +521,74 @@ func TestSecrets_GetProviderType_EnvironmentVariable(t *testing.T) {
 		assert.Contains(t, err.Error(), "requires setup to be completed", "Error should mention setup requirement")
 	})
 }
+
+func TestOpenTelemetryConfig_StringRedactsHeaders(t *testing.T) {
+	t.Parallel()
+
+	otel := OpenTelemetryConfig{
+		Endpoint:   "https://api.honeycomb.io",
+		Headers:    map[string]string{"x-honeycomb-team": "super-secret-key"},
+		CACertPath: "/etc/ssl/collector-ca.pem",
+	}
+
+	for _, formatted := range []string{
+		otel.String(),
+		fmt.Sprintf("%v", otel),
+		fmt.Sprintf("%+v", otel),
+		fmt.Sprintf("%#v", otel),
+		fmt.Sprintf("%v", &Config{OTEL: otel}),
+	} {
+		assert.NotContains(t, formatted, "super-secret-key")
+		assert.Contains(t, formatted, "x-honeycomb-team")
+		assert.Contains(t, formatted, "/etc/ssl/collector-ca.pem")
+	}
+
+	// Redacting must not touch the configured headers.
+	assert.Equal(t, "super-secret-key", otel.Headers["x-honeycomb-team"])
+	assert.Nil(t, RedactOTELHeaders(nil))
+}
+
+func TestOpenTelemetryConfig_ResolveHeaders(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "api-key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("file-secret\n"), 0600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("  \n"), 0600))
+
+	t.Run("file values override inline headers", func(t *testing.T) {
+		t.Parallel()
+		otel := OpenTelemetryConfig{
+			Headers:     map[string]string{"x-api-key": "inline", "x-dataset": "toolhive"},
+			HeaderFiles: map[string]string{"x-api-key": keyFile},
+		}
+		headers, err := otel.ResolveHeaders()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"x-api-key": "file-secret", "x-dataset": "toolhive"}, headers)
+		assert.Equal(t, "inline", otel.Headers["x-api-key"], "resolving must not modify the config")
+	})
+
+	t.Run("unreadable and empty files are reported and skipped", func(t *testing.T) {
+		t.Parallel()
+		otel := OpenTelemetryConfig{
+			Headers: map[string]string{"x-dataset": "toolhive"},
+			HeaderFiles: map[string]string{
+				"x-missing": filepath.Join(dir, "missing"),
+				"x-empty":   emptyFile,
+			},
+		}
+		headers, err := otel.ResolveHeaders()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "x-missing")
+		assert.Contains(t, err.Error(), "x-empty")
+		assert.Equal(t, map[string]string{"x-dataset": "toolhive"}, headers)
+	})
+
+	t.Run("file values are never printed", func(t *testing.T) {
+		t.Parallel()
+		otel := OpenTelemetryConfig{HeaderFiles: map[string]string{"x-api-key": keyFile}}
+		assert.NotContains(t, otel.String(), "file-secret")
+		assert.Contains(t, otel.String(), keyFile)
+	})
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/lockfile"
+)
+
+// ContextProvider is implemented by providers whose config reads and writes
+// honor context cancellation and deadlines. Reading or writing the config can
+// block indefinitely when it lives on a stuck file system (e.g. an NFS-mounted
+// config directory), so callers that have a context should prefer these
+// methods, through LoadProviderConfig and UpdateProviderConfig, over the
+// context-free ones on Provider.
+type ContextProvider interface {
+	// LoadOrCreateConfigContext is LoadOrCreateConfig, returning ctx.Err()
+	// as soon as ctx is done.
+	LoadOrCreateConfigContext(ctx context.Context) (*Config, error)
+	// UpdateConfigContext is UpdateConfig, returning ctx.Err() as soon as
+	// ctx is done. updateFn is never called once ctx is done.
+	UpdateConfigContext(ctx context.Context, updateFn func(*Config) error) error
+}
+
+// LoadProviderConfig loads the config from provider, returning as soon as ctx
+// is done. Providers that do not implement ContextProvider are read on a
+// separate goroutine, which is left to finish in the background if ctx is done
+// first.
+func LoadProviderConfig(ctx context.Context, provider Provider) (*Config, error) {
+	if p, ok := provider.(ContextProvider); ok {
+		return p.LoadOrCreateConfigContext(ctx)
+	}
+	return runWithContext(ctx, provider.LoadOrCreateConfig)
+}
+
+// UpdateProviderConfig updates the config through provider, returning as soon
+// as ctx is done. A write cannot safely be abandoned half way, so for providers
+// that do not implement ContextProvider ctx is only checked before the update
+// starts.
+func UpdateProviderConfig(ctx context.Context, provider Provider, updateFn func(*Config) error) error {
+	if p, ok := provider.(ContextProvider); ok {
+		return p.UpdateConfigContext(ctx, updateFn)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return provider.UpdateConfig(updateFn)
+}
+
+// LoadOrCreateConfigContext is LoadOrCreateConfig, returning as soon as ctx is
+// done.
+func LoadOrCreateConfigContext(ctx context.Context) (*Config, error) {
+	return LoadProviderConfig(ctx, NewProvider())
+}
+
+// UpdateConfigContext is UpdateConfig, returning as soon as ctx is done.
+func UpdateConfigContext(ctx context.Context, updateFn func(*Config) error) error {
+	return UpdateProviderConfig(ctx, NewProvider(), updateFn)
+}
+
+// LoadOrCreateConfigWithPathContext is LoadOrCreateConfigWithPath, returning as
+// soon as ctx is done. The file is read on a separate goroutine, which is left
+// to finish in the background if ctx is done first.
+func LoadOrCreateConfigWithPathContext(ctx context.Context, configPath string) (*Config, error) {
+	if configPath == "" {
+		return LoadOrCreateConfigContext(ctx)
+	}
+	return runWithContext(ctx, func() (*Config, error) {
+		return LoadOrCreateConfigFromPath(configPath)
+	})
+}
+
+// loadResult is the outcome of reading the config in UpdateConfigAtPathContext.
+type loadResult struct {
+	config *Config
+	err    error
+}
+
+// UpdateConfigAtPathContext is UpdateConfigAtPath, returning as soon as ctx is
+// done: while waiting for the lock, reading the config or writing it back.
+//
+// The file operations run on a separate goroutine that holds the lock until
+// they finish, so a blocked read or write keeps other writers out even after
+// this call has returned. updateFn runs on the calling goroutine and is never
+// called once ctx is done, but a write that has already started when ctx is
+// done may still complete.
+func UpdateConfigAtPathContext(ctx context.Context, configPath string, updateFn func(*Config) error) error {
+	if configPath == "" {
+		var err error
+		configPath, err = getConfigPath()
+		if err != nil {
+			return fmt.Errorf("unable to fetch config path: %w", err)
+		}
+	}
+
+	// Use a separate lock file for cross-platform compatibility
+	lockPath := configPath + ".lock"
+	fileLock := lockfile.NewTrackedLock(lockPath)
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+
+	// Try and acquire a file lock.
+	locked, err := fileLock.TryLockContext(lockCtx, 100*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("failed to acquire lock: timeout after %v", lockTimeout)
+	}
+	lockfile.RecordOwner(lockPath)
+
+	// From here on the goroutine owns the lock. It waits for exactly one
+	// value on save: the config to write, or nil to release the lock without
+	// writing.
+	loaded := make(chan loadResult, 1)
+	save := make(chan *Config, 1)
+	saved := make(chan error, 1)
+	go func() {
+		defer lockfile.ReleaseTrackedLock(lockPath, fileLock)
+
+		// Load the config after acquiring the lock to avoid race conditions
+		c, err := LoadOrCreateConfigFromPath(configPath)
+		loaded <- loadResult{config: c, err: err}
+		if err != nil {
+			return
+		}
+		if c := <-save; c != nil {
+			saved <- c.saveToPath(configPath)
+		}
+	}()
+
+	sent := false
+	send := func(c *Config) {
+		if !sent {
+			sent = true
+			save <- c
+		}
+	}
+	defer send(nil)
+
+	var c *Config
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("failed to load config from disk: %w", ctx.Err())
+	case result := <-loaded:
+		if result.err != nil {
+			return fmt.Errorf("failed to load config from disk: %w", result.err)
+		}
+		c = result.config
+	}
+
+	// Apply changes to the config file.
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to load config from disk: %w", err)
+	}
+	if err := updateFn(c); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	// Write the updated config to disk.
+	send(c)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("failed to save config: %w", ctx.Err())
+	case err := <-saved:
+		if err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		return nil
+	}
+}
+
+// runWithContext calls fn on a separate goroutine and returns its result, or
+// ctx.Err() as soon as ctx is done. fn is left to finish in the background
+// after an early return, so it must be safe to abandon.
+func runWithContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-done:
+		return r.value, r.err
+	}
+}
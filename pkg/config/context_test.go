@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingProvider is a Provider whose config reads and writes block until
+// release is closed, like a config directory on a stuck file system.
+type blockingProvider struct {
+	Provider
+	release chan struct{}
+	updates int
+}
+
+func newBlockingProvider(t *testing.T) *blockingProvider {
+	t.Helper()
+	p := &blockingProvider{release: make(chan struct{})}
+	t.Cleanup(func() { close(p.release) })
+	return p
+}
+
+func (p *blockingProvider) LoadOrCreateConfig() (*Config, error) {
+	<-p.release
+	return defaultConfig(), nil
+}
+
+func (p *blockingProvider) UpdateConfig(updateFn func(*Config) error) error {
+	p.updates++
+	<-p.release
+	return updateFn(defaultConfig())
+}
+
+// returnsWithin fails the test if fn does not return within timeout.
+func returnsWithin[T any](t *testing.T, timeout time.Duration, fn func() T) T {
+	t.Helper()
+
+	done := make(chan T, 1)
+	go func() { done <- fn() }()
+	select {
+	case v := <-done:
+		return v
+	case <-time.After(timeout):
+		require.FailNow(t, "call did not return after the context was done")
+		panic("unreachable")
+	}
+}
+
+func TestLoadProviderConfig_ReturnsWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	provider := newBlockingProvider(t)
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		err := returnsWithin(t, 5*time.Second, func() error {
+			_, err := LoadProviderConfig(ctx, provider)
+			return err
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := returnsWithin(t, 5*time.Second, func() error {
+			_, err := LoadProviderConfig(ctx, provider)
+			return err
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestUpdateProviderConfig_DoesNotStartWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	provider := newBlockingProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := UpdateProviderConfig(ctx, provider, func(*Config) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, provider.updates)
+}
+
+func TestUpdateConfigAtPathContext_ReturnsWhileWaitingForLock(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	held := flock.New(configPath + ".lock")
+	locked, err := held.TryLock()
+	require.NoError(t, err)
+	require.True(t, locked)
+	t.Cleanup(func() { _ = held.Unlock() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	called := false
+	start := time.Now()
+	err = UpdateConfigAtPathContext(ctx, configPath, func(*Config) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), lockTimeout, "the call should not wait for the lock timeout")
+	assert.False(t, called)
+}
+
+func TestUpdateConfigAtPathContext_SkipsUpdateWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := UpdateConfigAtPathContext(ctx, configPath, func(*Config) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestPathProvider_ContextOperations(t *testing.T) {
+	t.Parallel()
+
+	provider := NewPathProvider(filepath.Join(t.TempDir(), "config.yaml"))
+	ctx := context.Background()
+
+	require.NoError(t, UpdateProviderConfig(ctx, provider, func(c *Config) error {
+		c.DisableUsageMetrics = true
+		return nil
+	}))
+
+	cfg, err := LoadProviderConfig(ctx, provider)
+	require.NoError(t, err)
+	assert.True(t, cfg.DisableUsageMetrics)
+
+	updateErr := errors.New("rejected")
+	err = UpdateProviderConfig(ctx, provider, func(c *Config) error {
+		c.DisableUsageMetrics = false
+		return updateErr
+	})
+	assert.ErrorIs(t, err, updateErr)
+
+	cfg, err = LoadProviderConfig(ctx, provider)
+	require.NoError(t, err)
+	assert.True(t, cfg.DisableUsageMetrics, "a failed update must not be written")
+
+	// The lock is released after every update, including failed ones.
+	require.NoError(t, UpdateProviderConfig(ctx, provider, func(*Config) error { return nil }))
+}
+
+func TestKubernetesProvider_UpdateConfigContextIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	err := UpdateProviderConfig(context.Background(), NewKubernetesProviderWithDir(""), func(*Config) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
+}
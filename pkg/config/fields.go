@@ -0,0 +1,428 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stacklok/toolhive/pkg/certs"
+	"github.com/stacklok/toolhive/pkg/networking"
+)
+
+// FieldValidator checks a single field of a loaded Config, applying the same
+// rules the CLI enforces when the field is set.
+type FieldValidator struct {
+	// Field is the YAML path of the field, e.g. "otel.sampling-rate".
+	Field string
+	// Validate returns why the field's current value is invalid, or nil.
+	// The zero value means the field is unset and must be accepted.
+	Validate func(*Config) error
+}
+
+// FieldError reports an invalid config field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the underlying validation error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	fieldValidatorsMu sync.RWMutex
+	fieldValidators   []FieldValidator
+)
+
+// RegisterFieldValidator registers a validator run by Config.ValidateFields.
+// It panics if a validator is already registered for the same field.
+func RegisterFieldValidator(validator FieldValidator) {
+	fieldValidatorsMu.Lock()
+	defer fieldValidatorsMu.Unlock()
+
+	for _, registered := range fieldValidators {
+		if registered.Field == validator.Field {
+			panic(fmt.Sprintf("config field validator already registered for %q", validator.Field))
+		}
+	}
+	fieldValidators = append(fieldValidators, validator)
+}
+
+// ValidateFields runs every registered field validator against the config and
+// returns all invalid fields, in registration order. It returns nil when the
+// config is valid.
+func (c *Config) ValidateFields() []*FieldError {
+	fieldValidatorsMu.RLock()
+	validators := slices.Clone(fieldValidators)
+	fieldValidatorsMu.RUnlock()
+
+	var fieldErrs []*FieldError
+	for _, validator := range validators {
+		if err := validator.Validate(c); err != nil {
+			fieldErrs = append(fieldErrs, &FieldError{Field: validator.Field, Err: err})
+		}
+	}
+	return fieldErrs
+}
+
+// LoadConfigFile reads and decodes the config file at configPath. Unlike
+// LoadOrCreateConfigFromPath it never creates the file or writes migrations
+// back to it, so it is safe to use for inspecting a config.
+func LoadConfigFile(configPath string) (*Config, error) {
+	if configPath == "" {
+		var err error
+		configPath, err = getConfigPath()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch config path: %w", err)
+		}
+	}
+
+	// #nosec G304: the path is the user's own config file
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %s: %w", configPath, err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file yaml: %w", err)
+	}
+	return &config, nil
+}
+
+// ValidateOTELEndpoint checks an OpenTelemetry collector endpoint, which is a
+// host and port without a URL scheme.
+func ValidateOTELEndpoint(endpoint string) error {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return errors.New("endpoint URL should not start with http:// or https://")
+	}
+	return nil
+}
+
+// ValidateSamplingRate checks that a trace sampling rate is between 0.0 and 1.0.
+func ValidateSamplingRate(rate float64) error {
+	if !(rate >= 0.0 && rate <= 1.0) {
+		return errors.New("sampling rate must be between 0.0 and 1.0")
+	}
+	return nil
+}
+
+// ValidateOTELHeaderFile checks an otel.header-files entry: the header name
+// must not be empty and path must name a readable file with a non-empty value.
+func ValidateOTELHeaderFile(name, path string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("header name must not be empty")
+	}
+	if path == "" {
+		return fmt.Errorf("%s: file path must not be empty", name)
+	}
+	data, err := readFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return fmt.Errorf("%s: file %s is empty", name, path)
+	}
+	return nil
+}
+
+// ParseLogLevel parses the log_level config field, which is one of "debug",
+// "info", "warn" or "error".
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unsupported log level %q: must be one of debug, info, warn, error", level)
+	}
+}
+
+// validateCACertificateFile checks that path names a readable, valid CA certificate.
+func validateCACertificateFile(path string) error {
+	cleanPath, err := validateFilePath(path)
+	if err != nil {
+		return fmt.Errorf("CA certificate %w", err)
+	}
+	certContent, err := readFile(cleanPath)
+	if err != nil {
+		return fmt.Errorf("CA certificate %w", err)
+	}
+	if err := certs.ValidateCACertificate(certContent); err != nil {
+		return fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order, so that errors are reported deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// init registers the validators for the built-in config fields.
+//
+//nolint:gocyclo // a flat list of independent field checks
+func init() {
+	RegisterFieldValidator(FieldValidator{
+		Field: "secrets.provider_type",
+		Validate: func(c *Config) error {
+			if c.Secrets.ProviderType == "" {
+				return nil
+			}
+			_, err := validateProviderType(c.Secrets.ProviderType)
+			return err
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "registry_url",
+		Validate: func(c *Config) error {
+			if c.RegistryUrl == "" {
+				return nil
+			}
+			_, err := validateURLScheme(c.RegistryUrl, c.AllowPrivateRegistryIp)
+			return err
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "registry_api_url",
+		Validate: func(c *Config) error {
+			if c.RegistryApiUrl == "" {
+				return nil
+			}
+			_, err := validateURLScheme(c.RegistryApiUrl, c.AllowPrivateRegistryIp)
+			return err
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "local_registry_path",
+		Validate: func(c *Config) error {
+			if c.LocalRegistryPath == "" {
+				return nil
+			}
+			if err := validateFileExists(c.LocalRegistryPath); err != nil {
+				return err
+			}
+			if err := validateJSONFile(c.LocalRegistryPath); err != nil {
+				return err
+			}
+			return validateRegistryFileStructure(c.LocalRegistryPath)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "ca_certificate_path",
+		Validate: func(c *Config) error {
+			if c.CACertificatePath == "" {
+				return nil
+			}
+			return validateCACertificateFile(c.CACertificatePath)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "log_level",
+		Validate: func(c *Config) error {
+			if c.LogLevel == "" {
+				return nil
+			}
+			_, err := ParseLogLevel(c.LogLevel)
+			return err
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "otel.endpoint",
+		Validate: func(c *Config) error {
+			return ValidateOTELEndpoint(c.OTEL.Endpoint)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "otel.sampling-rate",
+		Validate: func(c *Config) error {
+			return ValidateSamplingRate(c.OTEL.SamplingRate)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "otel.sampling-overrides",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, operation := range sortedKeys(c.OTEL.SamplingOverrides) {
+				if strings.TrimSpace(operation) == "" {
+					errs = append(errs, errors.New("operation name must not be empty"))
+					continue
+				}
+				if err := ValidateSamplingRate(c.OTEL.SamplingOverrides[operation]); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", operation, err))
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "otel.header-files",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, name := range sortedKeys(c.OTEL.HeaderFiles) {
+				if err := ValidateOTELHeaderFile(name, c.OTEL.HeaderFiles[name]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "otel.ca-cert-path",
+		Validate: func(c *Config) error {
+			if c.OTEL.CACertPath == "" {
+				return nil
+			}
+			return validateCACertificateFile(c.OTEL.CACertPath)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "otel.prometheus-basic-auth",
+		Validate: func(c *Config) error {
+			auth := c.OTEL.PrometheusBasicAuth
+			if auth == nil {
+				return nil
+			}
+			if auth.Username == "" {
+				return errors.New("username must not be empty")
+			}
+			// #nosec G304: the path is configured by the user for their own CLI
+			password, err := os.ReadFile(auth.PasswordFile)
+			if err != nil {
+				return fmt.Errorf("failed to read password file: %w", err)
+			}
+			if strings.TrimSpace(string(password)) == "" {
+				return fmt.Errorf("password file %s is empty", auth.PasswordFile)
+			}
+			return nil
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "build_env",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, key := range sortedKeys(c.BuildEnv) {
+				if err := ValidateBuildEnvEntry(key, c.BuildEnv[key]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "build_env_from_secrets",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, key := range sortedKeys(c.BuildEnvFromSecrets) {
+				if err := ValidateBuildEnvKey(key); err != nil {
+					errs = append(errs, err)
+				} else if c.BuildEnvFromSecrets[key] == "" {
+					errs = append(errs, fmt.Errorf("%s: secret name must not be empty", key))
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "build_env_from_shell",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, key := range c.BuildEnvFromShell {
+				if err := ValidateBuildEnvKey(key); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "build_auth_files",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, name := range sortedKeys(c.BuildAuthFiles) {
+				if err := ValidateBuildAuthFileName(name); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "runtime_configs",
+		Validate: func(c *Config) error {
+			var errs []error
+			for _, transport := range sortedKeys(c.RuntimeConfigs) {
+				runtimeConfig := c.RuntimeConfigs[transport]
+				if runtimeConfig == nil {
+					continue
+				}
+				if err := runtimeConfig.Validate(); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", transport, err))
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "registry_auth",
+		Validate: func(c *Config) error {
+			switch c.RegistryAuth.Type {
+			case "":
+				return nil
+			case RegistryAuthTypeOAuth:
+			default:
+				return fmt.Errorf("unsupported auth type %q: must be %q", c.RegistryAuth.Type, RegistryAuthTypeOAuth)
+			}
+			oauth := c.RegistryAuth.OAuth
+			if oauth == nil {
+				return errors.New("oauth configuration is missing")
+			}
+			var errs []error
+			if oauth.Issuer == "" {
+				errs = append(errs, errors.New("oauth.issuer is required"))
+			} else if err := networking.ValidateIssuerURL(oauth.Issuer); err != nil {
+				errs = append(errs, fmt.Errorf("oauth.issuer: %w", err))
+			}
+			if oauth.ClientID == "" {
+				errs = append(errs, errors.New("oauth.client_id is required"))
+			}
+			return errors.Join(errs...)
+		},
+	})
+	RegisterFieldValidator(FieldValidator{
+		Field: "llm",
+		Validate: func(c *Config) error {
+			// Whether the callback port is free depends on what else is running,
+			// not on the config, so only its range is checked here.
+			llmConfig := c.LLM
+			callbackPort := llmConfig.OIDC.CallbackPort
+			llmConfig.OIDC.CallbackPort = 0
+			err := llmConfig.ValidatePartial()
+			if callbackPort != 0 && (callbackPort < 1024 || callbackPort > 65535) {
+				err = errors.Join(err, fmt.Errorf("oidc.callback_port must be between 1024 and 65535, got: %d", callbackPort))
+			}
+			return err
+		},
+	})
+}
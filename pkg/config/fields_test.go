@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid config has no errors", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		headerPath := filepath.Join(dir, "api-key")
+		require.NoError(t, os.WriteFile(headerPath, []byte("s3cret\n"), 0600))
+		configPath := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+secrets:
+  provider_type: encrypted
+registry_url: https://example.com/registry.json
+log_level: warn
+otel:
+  endpoint: collector.example.com:4318
+  sampling-rate: 0.5
+  header-files:
+    x-api-key: `+headerPath+`
+build_env:
+  NPM_CONFIG_REGISTRY: https://npm.example.com
+`), 0600))
+
+		cfg, err := LoadConfigFile(configPath)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.ValidateFields())
+	})
+
+	t.Run("all invalid fields are reported", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		emptyHeaderPath := filepath.Join(dir, "empty")
+		require.NoError(t, os.WriteFile(emptyHeaderPath, []byte("\n"), 0600))
+		configPath := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+secrets:
+  provider_type: keychain
+registry_url: http://example.com/registry.json
+ca_certificate_path: /does/not/exist.pem
+log_level: verbose
+otel:
+  endpoint: https://collector.example.com
+  sampling-rate: 1.5
+  sampling-overrides:
+    tools/call: 2
+  header-files:
+    x-missing: /does/not/exist
+    x-empty: `+emptyHeaderPath+`
+build_env:
+  PATH: /tmp
+  lower_case: value
+registry_auth:
+  type: basic
+`), 0600))
+
+		cfg, err := LoadConfigFile(configPath)
+		require.NoError(t, err)
+
+		fieldErrs := cfg.ValidateFields()
+		fields := make([]string, 0, len(fieldErrs))
+		for _, fieldErr := range fieldErrs {
+			fields = append(fields, fieldErr.Field)
+		}
+		assert.Equal(t, []string{
+			"secrets.provider_type",
+			"registry_url",
+			"ca_certificate_path",
+			"log_level",
+			"otel.endpoint",
+			"otel.sampling-rate",
+			"otel.sampling-overrides",
+			"otel.header-files",
+			"build_env",
+			"registry_auth",
+		}, fields)
+
+		messages := make(map[string]string, len(fieldErrs))
+		for _, fieldErr := range fieldErrs {
+			messages[fieldErr.Field] = fieldErr.Error()
+		}
+		assert.Contains(t, messages["registry_url"], "https://")
+		assert.Contains(t, messages["otel.sampling-rate"], "between 0.0 and 1.0")
+		assert.Contains(t, messages["otel.sampling-overrides"], "tools/call")
+		assert.Contains(t, messages["otel.header-files"], "x-missing")
+		assert.Contains(t, messages["otel.header-files"], "x-empty")
+		assert.Contains(t, messages["build_env"], "PATH")
+		assert.Contains(t, messages["build_env"], "lower_case")
+	})
+}
+
+func TestLoadConfigFile_DoesNotCreateFile(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	_, err := LoadConfigFile(configPath)
+	require.Error(t, err)
+	assert.NoFileExists(t, configPath)
+}
+
+func TestRegisterFieldValidator_DuplicatePanics(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		RegisterFieldValidator(FieldValidator{Field: "registry_url", Validate: func(*Config) error { return nil }})
+	})
+}
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"context"
+
 	"github.com/stacklok/toolhive/pkg/container/runtime"
 	"github.com/stacklok/toolhive/pkg/container/templates"
 )
@@ -82,6 +84,18 @@ func (*DefaultProvider) LoadOrCreateConfig() (*Config, error) {
 	return LoadOrCreateConfigWithDefaultPath()
 }
 
+// LoadOrCreateConfigContext loads or creates config using the default path,
+// returning as soon as ctx is done
+func (*DefaultProvider) LoadOrCreateConfigContext(ctx context.Context) (*Config, error) {
+	return runWithContext(ctx, LoadOrCreateConfigWithDefaultPath)
+}
+
+// UpdateConfigContext updates the config using the default path, returning as
+// soon as ctx is done
+func (*DefaultProvider) UpdateConfigContext(ctx context.Context, updateFn func(*Config) error) error {
+	return UpdateConfigAtPathContext(ctx, "", updateFn)
+}
+
 // SetRegistryURL validates and sets a registry URL
 func (d *DefaultProvider) SetRegistryURL(registryURL string, allowPrivateRegistryIp bool) error {
 	return setRegistryURL(d, registryURL, allowPrivateRegistryIp)
@@ -253,6 +267,18 @@ func (p *PathProvider) LoadOrCreateConfig() (*Config, error) {
 	return LoadOrCreateConfigWithPath(p.configPath)
 }
 
+// LoadOrCreateConfigContext loads or creates config at the specific path,
+// returning as soon as ctx is done
+func (p *PathProvider) LoadOrCreateConfigContext(ctx context.Context) (*Config, error) {
+	return LoadOrCreateConfigWithPathContext(ctx, p.configPath)
+}
+
+// UpdateConfigContext updates the config at the specific path, returning as
+// soon as ctx is done
+func (p *PathProvider) UpdateConfigContext(ctx context.Context, updateFn func(*Config) error) error {
+	return UpdateConfigAtPathContext(ctx, p.configPath, updateFn)
+}
+
 // SetRegistryURL validates and sets a registry URL
 func (p *PathProvider) SetRegistryURL(registryURL string, allowPrivateRegistryIp bool) error {
 	return setRegistryURL(p, registryURL, allowPrivateRegistryIp)
@@ -393,172 +419,6 @@ func (p *PathProvider) SetRuntimeConfig(transportType string, config *templates.
 	return setRuntimeConfig(p, transportType, config)
 }
 
-// KubernetesProvider is a no-op implementation of Provider for Kubernetes environments.
-// In Kubernetes, configuration is managed by the cluster, not by local files.
-type KubernetesProvider struct{}
-
-// NewKubernetesProvider creates a new no-op config provider for Kubernetes environments
-func NewKubernetesProvider() *KubernetesProvider {
-	return &KubernetesProvider{}
-}
-
-// GetConfig returns a default config for Kubernetes environments
-func (*KubernetesProvider) GetConfig() *Config {
-	config := createNewConfigWithDefaults()
-	return &config
-}
-
-// UpdateConfig is a no-op for Kubernetes environments
-func (*KubernetesProvider) UpdateConfig(_ func(*Config) error) error {
-	return nil
-}
-
-// LoadOrCreateConfig returns a default config for Kubernetes environments
-func (*KubernetesProvider) LoadOrCreateConfig() (*Config, error) {
-	config := createNewConfigWithDefaults()
-	return &config, nil
-}
-
-// SetRegistryURL is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetRegistryURL(_ string, _ bool) error {
-	return nil
-}
-
-// SetRegistryAPI is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetRegistryAPI(_ string, _ bool) error {
-	return nil
-}
-
-// SetRegistryFile is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetRegistryFile(_ string) error {
-	return nil
-}
-
-// UnsetRegistry is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetRegistry() error {
-	return nil
-}
-
-// GetRegistryConfig returns empty registry configuration for Kubernetes environments
-func (*KubernetesProvider) GetRegistryConfig() (url, localPath string, allowPrivateIP bool, registryType string) {
-	return "", "", false, ""
-}
-
-// SetCACert is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetCACert(_ string) error {
-	return nil
-}
-
-// GetCACert returns empty CA cert configuration for Kubernetes environments
-func (*KubernetesProvider) GetCACert() (certPath string, exists bool, accessible bool) {
-	return "", false, false
-}
-
-// UnsetCACert is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetCACert() error {
-	return nil
-}
-
-// SetBuildEnv is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetBuildEnv(_, _ string) error {
-	return nil
-}
-
-// GetBuildEnv returns empty for Kubernetes environments
-func (*KubernetesProvider) GetBuildEnv(_ string) (value string, exists bool) {
-	return "", false
-}
-
-// GetAllBuildEnv returns empty map for Kubernetes environments
-func (*KubernetesProvider) GetAllBuildEnv() map[string]string {
-	return make(map[string]string)
-}
-
-// UnsetBuildEnv is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetBuildEnv(_ string) error {
-	return nil
-}
-
-// UnsetAllBuildEnv is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetAllBuildEnv() error {
-	return nil
-}
-
-// SetBuildEnvFromSecret is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetBuildEnvFromSecret(_, _ string) error {
-	return nil
-}
-
-// GetBuildEnvFromSecret returns empty for Kubernetes environments
-func (*KubernetesProvider) GetBuildEnvFromSecret(_ string) (secretName string, exists bool) {
-	return "", false
-}
-
-// GetAllBuildEnvFromSecrets returns empty map for Kubernetes environments
-func (*KubernetesProvider) GetAllBuildEnvFromSecrets() map[string]string {
-	return make(map[string]string)
-}
-
-// UnsetBuildEnvFromSecret is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetBuildEnvFromSecret(_ string) error {
-	return nil
-}
-
-// SetBuildEnvFromShell is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetBuildEnvFromShell(_ string) error {
-	return nil
-}
-
-// GetBuildEnvFromShell returns false for Kubernetes environments
-func (*KubernetesProvider) GetBuildEnvFromShell(_ string) bool {
-	return false
-}
-
-// GetAllBuildEnvFromShell returns empty slice for Kubernetes environments
-func (*KubernetesProvider) GetAllBuildEnvFromShell() []string {
-	return []string{}
-}
-
-// UnsetBuildEnvFromShell is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetBuildEnvFromShell(_ string) error {
-	return nil
-}
-
-// MarkBuildAuthFileConfigured is a no-op for Kubernetes environments
-func (*KubernetesProvider) MarkBuildAuthFileConfigured(_ string) error {
-	return nil
-}
-
-// IsBuildAuthFileConfigured returns false for Kubernetes environments
-func (*KubernetesProvider) IsBuildAuthFileConfigured(_ string) bool {
-	return false
-}
-
-// GetConfiguredBuildAuthFiles returns empty slice for Kubernetes environments
-func (*KubernetesProvider) GetConfiguredBuildAuthFiles() []string {
-	return []string{}
-}
-
-// UnsetBuildAuthFile is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetBuildAuthFile(_ string) error {
-	return nil
-}
-
-// UnsetAllBuildAuthFiles is a no-op for Kubernetes environments
-func (*KubernetesProvider) UnsetAllBuildAuthFiles() error {
-	return nil
-}
-
-// GetRuntimeConfig returns nil for Kubernetes environments (runtime config not supported)
-func (*KubernetesProvider) GetRuntimeConfig(_ string) (*templates.RuntimeConfig, error) {
-	return nil, nil
-}
-
-// SetRuntimeConfig is a no-op for Kubernetes environments
-func (*KubernetesProvider) SetRuntimeConfig(_ string, _ *templates.RuntimeConfig) error {
-	return nil
-}
-
 // NewProvider creates the appropriate config provider based on the runtime environment.
 // If a custom ProviderFactory has been registered via RegisterProviderFactory and it
 // returns a non-nil Provider, that provider is used. Otherwise, the built-in selection
@@ -181,7 +181,7 @@ func TestPathProvider(t *testing.T) {
 
 func TestKubernetesProvider(t *testing.T) {
 	t.Parallel()
-	provider := NewKubernetesProvider()
+	provider := NewKubernetesProviderWithDir("")
 
 	t.Run("GetConfig", func(t *testing.T) {
 		t.Parallel()
@@ -209,25 +209,25 @@ func TestKubernetesProvider(t *testing.T) {
 			c.RegistryUrl = "https://example.com"
 			return nil
 		})
-		assert.NoError(t, err) // Should be no-op
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 	})
 
 	t.Run("SetRegistryURL", func(t *testing.T) {
 		t.Parallel()
 		err := provider.SetRegistryURL("https://example.com", true)
-		assert.NoError(t, err) // Should be no-op
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 	})
 
 	t.Run("SetRegistryFile", func(t *testing.T) {
 		t.Parallel()
 		err := provider.SetRegistryFile("/path/to/registry.yaml")
-		assert.NoError(t, err) // Should be no-op
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 	})
 
 	t.Run("UnsetRegistry", func(t *testing.T) {
 		t.Parallel()
 		err := provider.UnsetRegistry()
-		assert.NoError(t, err) // Should be no-op
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 	})
 
 	t.Run("GetRegistryConfig", func(t *testing.T) {
@@ -236,7 +236,7 @@ func TestKubernetesProvider(t *testing.T) {
 		assert.Equal(t, "", url)
 		assert.Equal(t, "", localPath)
 		assert.False(t, allowPrivateIP)
-		assert.Equal(t, "", registryType)
+		assert.Equal(t, "default", registryType)
 	})
 }
 
@@ -442,11 +442,11 @@ func TestProviderBuildEnvOperations(t *testing.T) {
 
 	t.Run("KubernetesProvider_BuildEnvOperations", func(t *testing.T) {
 		t.Parallel()
-		provider := NewKubernetesProvider()
+		provider := NewKubernetesProviderWithDir("")
 
-		// Test SetBuildEnv (should be no-op)
+		// Test SetBuildEnv (should be rejected)
 		err := provider.SetBuildEnv("NPM_CONFIG_REGISTRY", "https://npm.corp.example.com")
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 
 		// Test GetBuildEnv (should return empty)
 		value, exists := provider.GetBuildEnv("NPM_CONFIG_REGISTRY")
@@ -457,12 +457,12 @@ func TestProviderBuildEnvOperations(t *testing.T) {
 		envVars := provider.GetAllBuildEnv()
 		assert.Empty(t, envVars)
 
-		// Test UnsetBuildEnv (should be no-op)
+		// Test UnsetBuildEnv (should be rejected)
 		err = provider.UnsetBuildEnv("NPM_CONFIG_REGISTRY")
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 
-		// Test UnsetAllBuildEnv (should be no-op)
+		// Test UnsetAllBuildEnv (should be rejected)
 		err = provider.UnsetAllBuildEnv()
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, ErrReadOnlyInKubernetes)
 	})
 }
@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stacklok/toolhive/pkg/container/templates"
+)
+
+// ConfigDirEnvVar is the environment variable naming the directory a ConfigMap
+// or Secret holding config.yaml is mounted at in Kubernetes.
+const ConfigDirEnvVar = "TOOLHIVE_CONFIG_DIR"
+
+// kubernetesConfigFile is the key of the mounted ConfigMap or Secret that holds
+// the config, in the same format as the CLI's config.yaml.
+const kubernetesConfigFile = "config.yaml"
+
+// ErrReadOnlyInKubernetes is returned by every KubernetesProvider operation that
+// would modify the config.
+var ErrReadOnlyInKubernetes = errors.New(
+	"config is read-only in Kubernetes; update the mounted ConfigMap or Secret instead")
+
+// KubernetesProvider implements Provider for Kubernetes environments. In
+// Kubernetes, configuration is managed by the cluster: the config is read from
+// config.yaml in a mounted ConfigMap or Secret volume, and every write is
+// rejected with ErrReadOnlyInKubernetes.
+//
+// The mounted file is checked on every read and reloaded when it changes, so
+// updates to the ConfigMap are picked up without a restart. Kubernetes updates
+// projected volumes by atomically swapping a symlink, so a reload always sees a
+// complete file. When no directory is configured, or the file does not exist,
+// the default config is used.
+type KubernetesProvider struct {
+	configPath string
+
+	mu sync.Mutex
+	// config is the last successfully loaded config.
+	config *Config
+	// loaded is the version of the file config (or loadErr) was read from.
+	loaded os.FileInfo
+	// loadErr is the error loading that version, if it was rejected.
+	loadErr error
+}
+
+// NewKubernetesProvider creates a config provider for Kubernetes environments
+// that reads the config mounted at the directory named by ConfigDirEnvVar.
+func NewKubernetesProvider() *KubernetesProvider {
+	return NewKubernetesProviderWithDir(os.Getenv(ConfigDirEnvVar))
+}
+
+// NewKubernetesProviderWithDir creates a config provider for Kubernetes
+// environments that reads the config mounted at configDir. An empty configDir
+// yields the default config.
+func NewKubernetesProviderWithDir(configDir string) *KubernetesProvider {
+	k := &KubernetesProvider{}
+	if configDir != "" {
+		k.configPath = filepath.Join(filepath.Clean(configDir), kubernetesConfigFile)
+	}
+	return k
+}
+
+// GetConfig returns the mounted config. If the mounted file cannot be loaded,
+// the last valid config (or the default config) is returned and a warning is
+// logged once per file version.
+func (k *KubernetesProvider) GetConfig() *Config {
+	config, fresh, err := k.load()
+	if err != nil && fresh {
+		slog.Warn("ignoring invalid mounted config", "path", k.configPath, "error", err)
+	}
+	return config
+}
+
+// UpdateConfig returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UpdateConfig(_ func(*Config) error) error {
+	return readOnlyError("update config")
+}
+
+// LoadOrCreateConfig returns the mounted config, or the default config if none
+// is mounted. Nothing is created in Kubernetes environments.
+func (k *KubernetesProvider) LoadOrCreateConfig() (*Config, error) {
+	config, _, err := k.load()
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// UpdateConfigContext returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UpdateConfigContext(_ context.Context, _ func(*Config) error) error {
+	return readOnlyError("update config")
+}
+
+// LoadOrCreateConfigContext is LoadOrCreateConfig, returning as soon as ctx is
+// done. The mounted file is read on a separate goroutine, which is left to
+// finish in the background if ctx is done first.
+func (k *KubernetesProvider) LoadOrCreateConfigContext(ctx context.Context) (*Config, error) {
+	return runWithContext(ctx, k.LoadOrCreateConfig)
+}
+
+// load returns the current config, re-reading the mounted file if it changed
+// since the last call. On error the last valid config is returned alongside it;
+// fresh reports whether the error came from reading a new file version.
+func (k *KubernetesProvider) load() (config *Config, fresh bool, err error) {
+	if k.configPath == "" {
+		return defaultConfig(), false, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	info, err := os.Stat(k.configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		k.config, k.loaded, k.loadErr = nil, nil, nil
+		return defaultConfig(), false, nil
+	}
+	if err != nil {
+		return k.current(), true, fmt.Errorf("failed to stat mounted config %s: %w", k.configPath, err)
+	}
+	if k.loaded != nil && sameFileVersion(k.loaded, info) {
+		return k.current(), false, k.loadErr
+	}
+
+	k.loaded = info
+	k.loadErr = nil
+	// #nosec G304: the path is set by the deployment, not by request input.
+	data, err := os.ReadFile(k.configPath)
+	if err != nil {
+		k.loadErr = fmt.Errorf("unable to read mounted config %s: %w", k.configPath, err)
+		return k.current(), true, k.loadErr
+	}
+	var loaded Config
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		k.loadErr = fmt.Errorf("failed to parse mounted config %s: %w", k.configPath, err)
+		return k.current(), true, k.loadErr
+	}
+	k.config = &loaded
+	return k.config, false, nil
+}
+
+// current returns the last valid config, or the default config if none was
+// loaded. The caller must hold k.mu.
+func (k *KubernetesProvider) current() *Config {
+	if k.config != nil {
+		return k.config
+	}
+	return defaultConfig()
+}
+
+func defaultConfig() *Config {
+	config := createNewConfigWithDefaults()
+	return &config
+}
+
+// sameFileVersion reports whether two stats describe the same version of a
+// file. A ConfigMap update replaces the file, changing its identity.
+func sameFileVersion(a, b os.FileInfo) bool {
+	return os.SameFile(a, b) && a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+}
+
+func readOnlyError(operation string) error {
+	return fmt.Errorf("cannot %s: %w", operation, ErrReadOnlyInKubernetes)
+}
+
+// SetRegistryURL returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetRegistryURL(_ string, _ bool) error {
+	return readOnlyError("set registry URL")
+}
+
+// SetRegistryAPI returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetRegistryAPI(_ string, _ bool) error {
+	return readOnlyError("set registry API")
+}
+
+// SetRegistryFile returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetRegistryFile(_ string) error {
+	return readOnlyError("set registry file")
+}
+
+// UnsetRegistry returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetRegistry() error {
+	return readOnlyError("unset registry")
+}
+
+// GetRegistryConfig returns the mounted registry configuration
+func (k *KubernetesProvider) GetRegistryConfig() (url, localPath string, allowPrivateIP bool, registryType string) {
+	return getRegistryConfig(k)
+}
+
+// SetCACert returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetCACert(_ string) error {
+	return readOnlyError("set CA certificate")
+}
+
+// GetCACert returns the mounted CA certificate path and its accessibility status
+func (k *KubernetesProvider) GetCACert() (certPath string, exists bool, accessible bool) {
+	return getCACert(k)
+}
+
+// UnsetCACert returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetCACert() error {
+	return readOnlyError("unset CA certificate")
+}
+
+// SetBuildEnv returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetBuildEnv(_, _ string) error {
+	return readOnlyError("set build environment variable")
+}
+
+// GetBuildEnv returns a specific build environment variable from the mounted config
+func (k *KubernetesProvider) GetBuildEnv(key string) (value string, exists bool) {
+	return getBuildEnv(k, key)
+}
+
+// GetAllBuildEnv returns all build environment variables from the mounted config
+func (k *KubernetesProvider) GetAllBuildEnv() map[string]string {
+	return getAllBuildEnv(k)
+}
+
+// UnsetBuildEnv returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetBuildEnv(_ string) error {
+	return readOnlyError("unset build environment variable")
+}
+
+// UnsetAllBuildEnv returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetAllBuildEnv() error {
+	return readOnlyError("unset build environment variables")
+}
+
+// SetBuildEnvFromSecret returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetBuildEnvFromSecret(_, _ string) error {
+	return readOnlyError("set build environment secret reference")
+}
+
+// GetBuildEnvFromSecret retrieves the secret name for a build environment variable from the mounted config
+func (k *KubernetesProvider) GetBuildEnvFromSecret(key string) (secretName string, exists bool) {
+	return getBuildEnvFromSecret(k, key)
+}
+
+// GetAllBuildEnvFromSecrets returns all build env secret references from the mounted config
+func (k *KubernetesProvider) GetAllBuildEnvFromSecrets() map[string]string {
+	return getAllBuildEnvFromSecrets(k)
+}
+
+// UnsetBuildEnvFromSecret returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetBuildEnvFromSecret(_ string) error {
+	return readOnlyError("unset build environment secret reference")
+}
+
+// SetBuildEnvFromShell returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetBuildEnvFromShell(_ string) error {
+	return readOnlyError("set build environment shell reference")
+}
+
+// GetBuildEnvFromShell checks if a key is configured to read from shell in the mounted config
+func (k *KubernetesProvider) GetBuildEnvFromShell(key string) bool {
+	return getBuildEnvFromShell(k, key)
+}
+
+// GetAllBuildEnvFromShell returns all keys configured to read from shell in the mounted config
+func (k *KubernetesProvider) GetAllBuildEnvFromShell() []string {
+	return getAllBuildEnvFromShell(k)
+}
+
+// UnsetBuildEnvFromShell returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetBuildEnvFromShell(_ string) error {
+	return readOnlyError("unset build environment shell reference")
+}
+
+// MarkBuildAuthFileConfigured returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) MarkBuildAuthFileConfigured(_ string) error {
+	return readOnlyError("configure build auth file")
+}
+
+// IsBuildAuthFileConfigured checks if an auth file type is configured in the mounted config
+func (k *KubernetesProvider) IsBuildAuthFileConfigured(name string) bool {
+	return isBuildAuthFileConfigured(k, name)
+}
+
+// GetConfiguredBuildAuthFiles returns the auth file types configured in the mounted config
+func (k *KubernetesProvider) GetConfiguredBuildAuthFiles() []string {
+	return getConfiguredBuildAuthFiles(k)
+}
+
+// UnsetBuildAuthFile returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetBuildAuthFile(_ string) error {
+	return readOnlyError("unset build auth file")
+}
+
+// UnsetAllBuildAuthFiles returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) UnsetAllBuildAuthFiles() error {
+	return readOnlyError("unset build auth files")
+}
+
+// GetRuntimeConfig returns the runtime configuration for a given transport type from the mounted config
+func (k *KubernetesProvider) GetRuntimeConfig(transportType string) (*templates.RuntimeConfig, error) {
+	return getRuntimeConfig(k, transportType)
+}
+
+// SetRuntimeConfig returns ErrReadOnlyInKubernetes
+func (*KubernetesProvider) SetRuntimeConfig(_ string, _ *templates.RuntimeConfig) error {
+	return readOnlyError("set runtime config")
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mountedVolume simulates a ConfigMap volume. Kubernetes writes each version of
+// the data to a timestamped directory and atomically repoints the ..data
+// symlink at it; the user-visible keys are symlinks through ..data.
+type mountedVolume struct {
+	t       *testing.T
+	dir     string
+	version int
+}
+
+func newMountedVolume(t *testing.T, config string) *mountedVolume {
+	t.Helper()
+	v := &mountedVolume{t: t, dir: t.TempDir()}
+	v.update(config)
+	require.NoError(t, os.Symlink(filepath.Join("..data", kubernetesConfigFile),
+		filepath.Join(v.dir, kubernetesConfigFile)))
+	return v
+}
+
+// update publishes a new version of config.yaml the way the kubelet does.
+func (v *mountedVolume) update(config string) {
+	v.t.Helper()
+	v.version++
+	versionDir := fmt.Sprintf("..version_%d", v.version)
+	require.NoError(v.t, os.Mkdir(filepath.Join(v.dir, versionDir), 0700))
+	require.NoError(v.t, os.WriteFile(filepath.Join(v.dir, versionDir, kubernetesConfigFile), []byte(config), 0600))
+
+	tmpLink := filepath.Join(v.dir, "..data_tmp")
+	require.NoError(v.t, os.Symlink(versionDir, tmpLink))
+	require.NoError(v.t, os.Rename(tmpLink, filepath.Join(v.dir, "..data")))
+}
+
+func TestKubernetesProvider_MountedConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads fields from the mounted file", func(t *testing.T) {
+		t.Parallel()
+		volume := newMountedVolume(t, `
+registry_url: https://registry.example.com
+allow_private_registry_ip: true
+build_env:
+  NPM_CONFIG_REGISTRY: https://npm.example.com
+`)
+		provider := NewKubernetesProviderWithDir(volume.dir)
+
+		config, err := provider.LoadOrCreateConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "https://registry.example.com", config.RegistryUrl)
+
+		url, _, allowPrivateIP, registryType := provider.GetRegistryConfig()
+		assert.Equal(t, "https://registry.example.com", url)
+		assert.True(t, allowPrivateIP)
+		assert.Equal(t, RegistryTypeURL, registryType)
+
+		value, exists := provider.GetBuildEnv("NPM_CONFIG_REGISTRY")
+		assert.True(t, exists)
+		assert.Equal(t, "https://npm.example.com", value)
+	})
+
+	t.Run("reflects updates to the mounted file", func(t *testing.T) {
+		t.Parallel()
+		volume := newMountedVolume(t, "registry_url: https://one.example.com\n")
+		provider := NewKubernetesProviderWithDir(volume.dir)
+		assert.Equal(t, "https://one.example.com", provider.GetConfig().RegistryUrl)
+
+		volume.update("registry_url: https://two.example.com\n")
+		assert.Equal(t, "https://two.example.com", provider.GetConfig().RegistryUrl)
+	})
+
+	t.Run("keeps the last valid config when an update is invalid", func(t *testing.T) {
+		t.Parallel()
+		volume := newMountedVolume(t, "registry_url: https://one.example.com\n")
+		provider := NewKubernetesProviderWithDir(volume.dir)
+		assert.Equal(t, "https://one.example.com", provider.GetConfig().RegistryUrl)
+
+		volume.update("registry_url: [not, a, string\n")
+		assert.Equal(t, "https://one.example.com", provider.GetConfig().RegistryUrl)
+		_, err := provider.LoadOrCreateConfig()
+		assert.Error(t, err)
+
+		volume.update("registry_url: https://three.example.com\n")
+		assert.Equal(t, "https://three.example.com", provider.GetConfig().RegistryUrl)
+	})
+
+	t.Run("uses defaults when nothing is mounted", func(t *testing.T) {
+		t.Parallel()
+		provider := NewKubernetesProviderWithDir(t.TempDir())
+
+		config, err := provider.LoadOrCreateConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "", config.RegistryUrl)
+	})
+
+	t.Run("rejects writes", func(t *testing.T) {
+		t.Parallel()
+		volume := newMountedVolume(t, "registry_url: https://one.example.com\n")
+		provider := NewKubernetesProviderWithDir(volume.dir)
+
+		err := provider.SetRegistryURL("https://other.example.com", false)
+		require.ErrorIs(t, err, ErrReadOnlyInKubernetes)
+		assert.Contains(t, err.Error(), "read-only in Kubernetes")
+		assert.ErrorIs(t, provider.UpdateConfig(func(*Config) error { return nil }), ErrReadOnlyInKubernetes)
+		assert.Equal(t, "https://one.example.com", provider.GetConfig().RegistryUrl)
+	})
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestNewKubernetesProvider_ConfigDirEnvVar(t *testing.T) {
+	volume := newMountedVolume(t, "registry_url: https://env.example.com\n")
+	t.Setenv(ConfigDirEnvVar, volume.dir)
+
+	provider := NewKubernetesProvider()
+	assert.Equal(t, "https://env.example.com", provider.GetConfig().RegistryUrl)
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"reflect"
+
+	"github.com/stacklok/toolhive/pkg/configschema"
+)
+
+// JSONSchema returns the JSON Schema of the ToolHive configuration file, for
+// editor validation and autocompletion. Unknown fields are allowed, as the
+// loader ignores them.
+func JSONSchema() (map[string]any, error) {
+	return configschema.Generate(reflect.TypeFor[Config](), configschema.Options{
+		Title: "ToolHive configuration",
+	})
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := JSONSchema()
+	require.NoError(t, err)
+
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string"}, properties["registry_url"])
+	assert.Equal(t, map[string]any{"type": "boolean"}, properties["allow_private_registry_ip"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, properties["build_env_from_shell"])
+	assert.Equal(t, map[string]any{"$ref": "#/definitions/config.Secrets"}, properties["secrets"])
+	assert.Contains(t, schema["definitions"], "llm.Config")
+
+	cfg := createNewConfigWithDefaults()
+	cfg.RegistryUrl = "https://example.com/registry.json"
+	cfg.BuildEnv = map[string]string{"NPM_CONFIG_REGISTRY": "https://npm.example.com"}
+	cfg.OTEL.SamplingRate = 0.5
+	data, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	schemaJSON, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		yaml      string
+		wantValid bool
+	}{
+		{name: "saved config", yaml: string(data), wantValid: true},
+		{name: "wrong type", yaml: "registry_url: [a, b]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var document any
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &document))
+			documentJSON, err := json.Marshal(document)
+			require.NoError(t, err)
+
+			result, err := gojsonschema.Validate(
+				gojsonschema.NewBytesLoader(schemaJSON),
+				gojsonschema.NewBytesLoader(documentJSON),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValid, result.Valid(), "errors: %v", result.Errors())
+		})
+	}
+}
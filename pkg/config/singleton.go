@@ -4,6 +4,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"sync"
@@ -53,3 +55,33 @@ func getSingletonConfig() *Config {
 	}
 	return appConfig
 }
+
+// ReloadConfig re-reads the config file at configPath, or at the default path
+// when configPath is empty, and makes it the config returned by
+// DefaultProvider.GetConfig. The new config is only used if it loads and every
+// field passes ValidateFields; otherwise the current config is kept and the
+// error is returned.
+func ReloadConfig(configPath string) (*Config, error) {
+	if configPath == "" {
+		var err error
+		configPath, err = getConfigPath()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch config path: %w", err)
+		}
+	}
+
+	config, err := LoadOrCreateConfigFromPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if fieldErrs := config.ValidateFields(); len(fieldErrs) > 0 {
+		errs := make([]error, 0, len(fieldErrs))
+		for _, fieldErr := range fieldErrs {
+			errs = append(errs, fieldErr)
+		}
+		return nil, fmt.Errorf("invalid config file %s: %w", configPath, errors.Join(errs...))
+	}
+
+	SetSingletonConfig(config)
+	return config, nil
+}
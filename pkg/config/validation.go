@@ -62,9 +62,8 @@ func readFile(path string) ([]byte, error) {
 // validateJSONFile validates that a file contains valid JSON.
 // It checks the file extension and attempts to parse the content.
 func validateJSONFile(path string) error {
-	// Check file extension
-	if !strings.HasSuffix(strings.ToLower(path), ".json") {
-		return errors.New(errJSONExtensionOnly)
+	if err := validateJSONExtension(path); err != nil {
+		return err
 	}
 
 	// Read and validate JSON content
@@ -73,6 +72,19 @@ func validateJSONFile(path string) error {
 		return err
 	}
 
+	return validateJSONData(data)
+}
+
+// validateJSONExtension checks that a path names a JSON file.
+func validateJSONExtension(path string) error {
+	if !strings.HasSuffix(strings.ToLower(path), ".json") {
+		return errors.New(errJSONExtensionOnly)
+	}
+	return nil
+}
+
+// validateJSONData checks that data holds a JSON object.
+func validateJSONData(data []byte) error {
 	// Basic JSON validation - unmarshal into generic map
 	var jsonData map[string]interface{}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
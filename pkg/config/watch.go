@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// RegistryFileField is the config field holding the local registry file path,
+// as set by `thv config set-registry <file>`.
+const RegistryFileField = "registry-file"
+
+// defaultWatchDebounce is how long a watched file must stay quiet before its
+// new content is validated and delivered. Editors and tools commonly produce
+// several events for a single save (truncate, write, chmod, rename).
+const defaultWatchDebounce = 250 * time.Millisecond
+
+// FieldChangeFunc is called with the path and validated content of a watched
+// file after it changes.
+type FieldChangeFunc func(path string, data []byte)
+
+// FieldWatcher watches the file referenced by a config field and reports
+// changes to it. It must be stopped with Close.
+type FieldWatcher struct {
+	watcher  *fsnotify.Watcher
+	path     string
+	debounce time.Duration
+	callback FieldChangeFunc
+
+	// last is the most recently delivered (or initial) content, used to
+	// suppress callbacks for events that did not change the file.
+	last []byte
+
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchConfigField watches the file referenced by the given config field and
+// invokes callback with its new content whenever it changes. Rapid successive
+// writes are debounced into a single callback, and content that fails
+// validation is logged and skipped, so callers only ever see a usable file.
+//
+// The parent directory is watched rather than the file itself, so editors that
+// save by writing a temporary file and renaming it over the original keep
+// being tracked.
+//
+// Only RegistryFileField is currently supported, and only when the provider is
+// configured with a local registry file. The callback runs on the watcher's
+// goroutine and must not call Close.
+func WatchConfigField(provider Provider, field string, callback FieldChangeFunc) (*FieldWatcher, error) {
+	return watchConfigField(provider, field, callback, defaultWatchDebounce)
+}
+
+func watchConfigField(
+	provider Provider, field string, callback FieldChangeFunc, debounce time.Duration,
+) (*FieldWatcher, error) {
+	if callback == nil {
+		return nil, fmt.Errorf("callback is required to watch %s", field)
+	}
+
+	path, err := resolveWatchedPath(provider, field)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	w := &FieldWatcher{
+		watcher:  watcher,
+		path:     path,
+		debounce: debounce,
+		callback: callback,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	// Missing or unreadable initial content just means the first valid write
+	// will be delivered.
+	w.last, _ = readFile(path)
+
+	go w.run()
+	return w, nil
+}
+
+// resolveWatchedPath returns the file path referenced by a watchable field.
+func resolveWatchedPath(provider Provider, field string) (string, error) {
+	switch field {
+	case RegistryFileField:
+		_, localPath, _, registryType := provider.GetRegistryConfig()
+		if registryType != RegistryTypeFile || localPath == "" {
+			return "", fmt.Errorf("no registry file is configured")
+		}
+		return filepath.Clean(localPath), nil
+	default:
+		return "", fmt.Errorf("watching config field %q is not supported", field)
+	}
+}
+
+// Path returns the path of the watched file.
+func (w *FieldWatcher) Path() string {
+	return w.path
+}
+
+// Close stops the watcher and waits for any in-flight callback to return.
+func (w *FieldWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.watcher.Close()
+		<-w.stopped
+	})
+	return err
+}
+
+func (w *FieldWatcher) run() {
+	defer close(w.stopped)
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	name := filepath.Base(w.path)
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			// Other files in the directory, and permission-only changes, are
+			// not interesting. Remove and rename events are kept: an atomic
+			// save removes the original before the new file appears.
+			if filepath.Base(event.Name) != name || event.Op == fsnotify.Chmod {
+				continue
+			}
+			timer.Reset(w.debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("error watching config file", "path", w.path, "error", err)
+		case <-timer.C:
+			w.reload()
+		}
+	}
+}
+
+// reload validates the watched file and delivers its content if it changed.
+// The file is read once so the callback receives exactly the validated bytes.
+func (w *FieldWatcher) reload() {
+	err := validateJSONExtension(w.path)
+	var data []byte
+	if err == nil {
+		data, err = readFile(w.path)
+	}
+	if err == nil {
+		err = validateJSONData(data)
+	}
+	if err != nil {
+		slog.Warn("ignoring invalid config file change", "path", w.path, "error", err)
+		return
+	}
+	if bytes.Equal(data, w.last) {
+		return
+	}
+
+	w.last = data
+	w.callback(w.path, data)
+}
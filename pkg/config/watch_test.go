@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWatchDebounce = 50 * time.Millisecond
+
+type fieldChange struct {
+	path string
+	data string
+}
+
+// setupWatchedRegistry creates a provider configured with a local registry
+// file and starts watching it. Changes are delivered on the returned channel.
+func setupWatchedRegistry(t *testing.T) (string, <-chan fieldChange) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	registryPath := filepath.Join(tempDir, "registry.json")
+	require.NoError(t, os.WriteFile(registryPath, []byte(`{"version": "1"}`), 0600))
+
+	provider := NewPathProvider(filepath.Join(tempDir, "config.yaml"))
+	require.NoError(t, provider.UpdateConfig(func(c *Config) error {
+		c.LocalRegistryPath = registryPath
+		return nil
+	}))
+
+	changes := make(chan fieldChange, 10)
+	watcher, err := watchConfigField(provider, RegistryFileField, func(path string, data []byte) {
+		changes <- fieldChange{path: path, data: string(data)}
+	}, testWatchDebounce)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = watcher.Close() })
+
+	return registryPath, changes
+}
+
+// expectSingleChange waits for one change and asserts no further change follows.
+func expectSingleChange(t *testing.T, changes <-chan fieldChange) fieldChange {
+	t.Helper()
+
+	var change fieldChange
+	select {
+	case change = <-changes:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for change callback")
+	}
+	expectNoChange(t, changes)
+	return change
+}
+
+func expectNoChange(t *testing.T, changes <-chan fieldChange) {
+	t.Helper()
+
+	select {
+	case change := <-changes:
+		assert.Failf(t, "unexpected change callback", "got %q", change.data)
+	case <-time.After(10 * testWatchDebounce):
+	}
+}
+
+func TestWatchConfigField(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rapid writes are debounced into one callback", func(t *testing.T) {
+		t.Parallel()
+		registryPath, changes := setupWatchedRegistry(t)
+
+		for _, version := range []string{"2", "3", "4"} {
+			require.NoError(t, os.WriteFile(registryPath, []byte(`{"version": "`+version+`"}`), 0600))
+		}
+
+		change := expectSingleChange(t, changes)
+		assert.Equal(t, registryPath, change.path)
+		assert.Equal(t, `{"version": "4"}`, change.data)
+	})
+
+	t.Run("atomic rename is detected", func(t *testing.T) {
+		t.Parallel()
+		registryPath, changes := setupWatchedRegistry(t)
+
+		tmpPath := registryPath + ".tmp"
+		require.NoError(t, os.WriteFile(tmpPath, []byte(`{"version": "2"}`), 0600))
+		require.NoError(t, os.Rename(tmpPath, registryPath))
+
+		change := expectSingleChange(t, changes)
+		assert.Equal(t, `{"version": "2"}`, change.data)
+
+		// The watch survives the rename.
+		require.NoError(t, os.WriteFile(registryPath, []byte(`{"version": "3"}`), 0600))
+		change = expectSingleChange(t, changes)
+		assert.Equal(t, `{"version": "3"}`, change.data)
+	})
+
+	t.Run("invalid content is skipped", func(t *testing.T) {
+		t.Parallel()
+		registryPath, changes := setupWatchedRegistry(t)
+
+		require.NoError(t, os.WriteFile(registryPath, []byte(`{"version": `), 0600))
+		expectNoChange(t, changes)
+
+		require.NoError(t, os.WriteFile(registryPath, []byte(`{"version": "2"}`), 0600))
+		change := expectSingleChange(t, changes)
+		assert.Equal(t, `{"version": "2"}`, change.data)
+	})
+
+	t.Run("unchanged content is skipped", func(t *testing.T) {
+		t.Parallel()
+		registryPath, changes := setupWatchedRegistry(t)
+
+		require.NoError(t, os.WriteFile(registryPath, []byte(`{"version": "1"}`), 0600))
+		expectNoChange(t, changes)
+	})
+}
+
+func TestWatchConfigField_Errors(t *testing.T) {
+	t.Parallel()
+
+	callback := func(string, []byte) {}
+
+	t.Run("unsupported field", func(t *testing.T) {
+		t.Parallel()
+		provider := NewPathProvider(filepath.Join(t.TempDir(), "config.yaml"))
+
+		_, err := WatchConfigField(provider, "registry-url", callback)
+		assert.ErrorContains(t, err, "not supported")
+	})
+
+	t.Run("no registry file configured", func(t *testing.T) {
+		t.Parallel()
+		provider := NewPathProvider(filepath.Join(t.TempDir(), "config.yaml"))
+
+		_, err := WatchConfigField(provider, RegistryFileField, callback)
+		assert.ErrorContains(t, err, "no registry file is configured")
+	})
+
+	t.Run("nil callback", func(t *testing.T) {
+		t.Parallel()
+		provider := NewPathProvider(filepath.Join(t.TempDir(), "config.yaml"))
+
+		_, err := WatchConfigField(provider, RegistryFileField, nil)
+		assert.ErrorContains(t, err, "callback is required")
+	})
+}
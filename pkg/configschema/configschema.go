@@ -0,0 +1,301 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configschema generates JSON Schemas for YAML configuration files
+// from the Go types they are decoded into, so editors can validate and
+// autocomplete them (e.g. yaml-language-server's $schema).
+//
+// Property names follow gopkg.in/yaml.v3: the yaml struct tag name, or the
+// lowercased field name when there is none. Fields tagged yaml:"-" are
+// omitted and ",inline" fields are merged into their parent.
+package configschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the JSON Schema draft of the generated schemas.
+const SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// Options configures schema generation.
+type Options struct {
+	// Title is the title of the schema.
+	Title string
+
+	// Strict disallows properties a type does not declare. Set it for
+	// configurations whose loader rejects unknown fields.
+	Strict bool
+
+	// TypeSchemas are the schemas of types whose YAML form differs from their
+	// Go structure, typically types with a custom YAML unmarshaler. Other types
+	// with a custom unmarshaler accept any value.
+	TypeSchemas map[reflect.Type]map[string]any
+}
+
+// Generate returns the JSON Schema of the YAML form of root, which must be a
+// struct or pointer to struct. Named struct types are emitted once under
+// "definitions" and referenced from every use, which also supports recursive
+// types.
+func Generate(root reflect.Type, opts Options) (map[string]any, error) {
+	for root.Kind() == reflect.Pointer {
+		root = root.Elem()
+	}
+	if root.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema root must be a struct, got %s", root)
+	}
+
+	g := &generator{
+		opts:        opts,
+		definitions: make(map[string]any),
+		names:       make(map[reflect.Type]string),
+		taken:       make(map[string]bool),
+	}
+	schema, err := g.objectSchema(root)
+	if err != nil {
+		return nil, err
+	}
+
+	schema["$schema"] = SchemaVersion
+	if opts.Title != "" {
+		schema["title"] = opts.Title
+	}
+	if len(g.definitions) > 0 {
+		schema["definitions"] = g.definitions
+	}
+	return schema, nil
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+
+	yamlUnmarshaler = reflect.TypeFor[yaml.Unmarshaler]()
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+	jsonUnmarshaler = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// invalidDefinitionChars matches the characters not kept in definition names.
+var invalidDefinitionChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// generator holds the state of one Generate call.
+type generator struct {
+	opts Options
+
+	// definitions are the schemas of named struct types, keyed by definition name.
+	definitions map[string]any
+
+	// names maps each named struct type to its definition name.
+	names map[reflect.Type]string
+
+	// taken records the definition names in use.
+	taken map[string]bool
+}
+
+// schemaFor returns the schema of t.
+func (g *generator) schemaFor(t reflect.Type) (map[string]any, error) {
+	if schema, ok := g.opts.TypeSchemas[t]; ok {
+		return schema, nil
+	}
+	if t.Kind() == reflect.Pointer {
+		schema, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(schema), nil
+	}
+
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case durationType:
+		// yaml.v3 decodes durations from strings such as "30s".
+		return map[string]any{"type": "string"}, nil
+	}
+	if hasCustomUnmarshaler(t) {
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.structRef(t)
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]any{"type": "object"}
+		if len(values) > 0 {
+			schema["additionalProperties"] = values
+		}
+		return schema, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// nullable returns schema extended to accept null, which a nil pointer
+// marshals to.
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		extended := maps.Clone(schema)
+		extended["type"] = []any{typ, "null"}
+		return extended
+	}
+	if _, ok := schema["$ref"]; ok {
+		return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+	}
+	return schema
+}
+
+// structRef returns a reference to the definition of struct type t, adding
+// the definition on first use.
+func (g *generator) structRef(t reflect.Type) (map[string]any, error) {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+	name, ok := g.names[t]
+	if !ok {
+		name = g.definitionName(t)
+		g.names[t] = name
+		// Reserve the definition before generating it so recursive references
+		// resolve to it.
+		g.definitions[name] = map[string]any{}
+		schema, err := g.objectSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		g.definitions[name] = schema
+	}
+	return map[string]any{"$ref": "#/definitions/" + name}, nil
+}
+
+// definitionName returns a unique definition name for t, qualified by its
+// package name.
+func (g *generator) definitionName(t reflect.Type) string {
+	base := t.Name()
+	if pkg := t.PkgPath(); pkg != "" {
+		base = pkg[strings.LastIndex(pkg, "/")+1:] + "." + base
+	}
+	base = invalidDefinitionChars.ReplaceAllString(base, "_")
+
+	name := base
+	for i := 2; g.taken[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.taken[name] = true
+	return name
+}
+
+// objectSchema returns the object schema of the fields of struct type t.
+func (g *generator) objectSchema(t reflect.Type) (map[string]any, error) {
+	properties := make(map[string]any)
+	if err := g.addProperties(t, properties); err != nil {
+		return nil, err
+	}
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if g.opts.Strict {
+		schema["additionalProperties"] = false
+	}
+	return schema, nil
+}
+
+// addProperties adds the schemas of the fields of struct type t to properties.
+func (g *generator) addProperties(t reflect.Type, properties map[string]any) error {
+	for field := range t.Fields() {
+		// Like yaml.v3, embedded fields are considered even when unexported.
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, inline, skip := yamlFieldName(field)
+		if skip {
+			continue
+		}
+
+		if inline {
+			inlined := field.Type
+			for inlined.Kind() == reflect.Pointer {
+				inlined = inlined.Elem()
+			}
+			switch inlined.Kind() {
+			case reflect.Struct:
+				if err := g.addProperties(inlined, properties); err != nil {
+					return err
+				}
+			case reflect.Map:
+				// An inline map collects the remaining keys; they are not declared.
+			default:
+				return fmt.Errorf("field %s.%s: inline requires a struct or map", t, field.Name)
+			}
+			continue
+		}
+
+		schema, err := g.schemaFor(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t, field.Name, err)
+		}
+		properties[name] = schema
+	}
+	return nil
+}
+
+// yamlFieldName returns the key yaml.v3 uses for field, whether the field is
+// inlined, and whether it is skipped.
+func yamlFieldName(field reflect.StructField) (name string, inline, skip bool) {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, inline, false
+}
+
+// hasCustomUnmarshaler reports whether values of t, or pointers to them, decode
+// themselves, so their YAML form cannot be derived from their Go structure.
+func hasCustomUnmarshaler(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{yamlUnmarshaler, textUnmarshaler, jsonUnmarshaler} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
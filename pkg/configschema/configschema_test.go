@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type schemaTestCustom struct{ value string }
+
+func (c *schemaTestCustom) UnmarshalText(text []byte) error {
+	c.value = string(text)
+	return nil
+}
+
+type schemaTestDuration time.Duration
+
+type schemaTestEmbedded struct {
+	Shared string `yaml:"shared"`
+}
+
+type schemaTestNode struct {
+	Name     string            `yaml:"name"`
+	Children []*schemaTestNode `yaml:"children,omitempty"`
+}
+
+type schemaTestConfig struct {
+	schemaTestEmbedded `yaml:",inline"`
+
+	Name     string                     `yaml:"name"`
+	Count    int                        `yaml:"count,omitempty"`
+	Ratio    float64                    `yaml:"ratio"`
+	Enabled  *bool                      `yaml:"enabled,omitempty"`
+	Tags     []string                   `yaml:"tags"`
+	Labels   map[string]string          `yaml:"labels"`
+	Anything any                        `yaml:"anything"`
+	Created  time.Time                  `yaml:"created"`
+	Timeout  time.Duration              `yaml:"timeout"`
+	Interval schemaTestDuration         `yaml:"interval"`
+	Custom   schemaTestCustom           `yaml:"custom"`
+	Root     *schemaTestNode            `yaml:"root"`
+	Nodes    map[string]*schemaTestNode `yaml:"nodes"`
+	Untagged string
+	Ignored  string `yaml:"-"`
+	internal string
+}
+
+func generateTestSchema(t *testing.T, opts Options) map[string]any {
+	t.Helper()
+	opts.TypeSchemas = map[reflect.Type]map[string]any{
+		reflect.TypeFor[schemaTestDuration](): {"type": "string", "pattern": "^[0-9]+s$"},
+	}
+	schema, err := Generate(reflect.TypeFor[*schemaTestConfig](), opts)
+	require.NoError(t, err)
+	return schema
+}
+
+func TestGenerate_Properties(t *testing.T) {
+	t.Parallel()
+
+	schema := generateTestSchema(t, Options{Title: "Test"})
+
+	assert.Equal(t, SchemaVersion, schema["$schema"])
+	assert.Equal(t, "Test", schema["title"])
+	assert.Equal(t, "object", schema["type"])
+	assert.NotContains(t, schema, "additionalProperties")
+
+	nodeRef := map[string]any{"$ref": "#/definitions/configschema.schemaTestNode"}
+	nullableNodeRef := map[string]any{"anyOf": []any{nodeRef, map[string]any{"type": "null"}}}
+	assert.Equal(t, map[string]any{
+		"shared":   map[string]any{"type": "string"},
+		"name":     map[string]any{"type": "string"},
+		"count":    map[string]any{"type": "integer"},
+		"ratio":    map[string]any{"type": "number"},
+		"enabled":  map[string]any{"type": []any{"boolean", "null"}},
+		"tags":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		"labels":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+		"anything": map[string]any{},
+		"created":  map[string]any{"type": "string", "format": "date-time"},
+		"timeout":  map[string]any{"type": "string"},
+		"interval": map[string]any{"type": "string", "pattern": "^[0-9]+s$"},
+		"custom":   map[string]any{},
+		"root":     nullableNodeRef,
+		"nodes":    map[string]any{"type": "object", "additionalProperties": nullableNodeRef},
+		"untagged": map[string]any{"type": "string"},
+	}, schema["properties"])
+
+	assert.Equal(t, map[string]any{
+		"configschema.schemaTestNode": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":     map[string]any{"type": "string"},
+				"children": map[string]any{"type": "array", "items": nullableNodeRef},
+			},
+		},
+	}, schema["definitions"])
+}
+
+func TestGenerate_Validation(t *testing.T) {
+	t.Parallel()
+
+	valid := `{
+		"shared": "s",
+		"name": "test",
+		"count": 3,
+		"tags": ["a"],
+		"timeout": "5s",
+		"interval": "10s",
+		"custom": 42,
+		"root": {"name": "r", "children": [{"name": "c"}]},
+		"extra": true
+	}`
+
+	tests := []struct {
+		name      string
+		opts      Options
+		document  string
+		wantValid bool
+	}{
+		{name: "valid document", document: valid, wantValid: true},
+		{name: "unknown property is rejected when strict", opts: Options{Strict: true}, document: valid},
+		{name: "nil pointers", document: `{"enabled": null, "root": null}`, wantValid: true},
+		{name: "null non-pointer", document: `{"name": null}`},
+		{name: "wrong scalar type", document: `{"count": "three"}`},
+		{name: "wrong nested type", document: `{"root": {"children": [{"name": 1}]}}`},
+		{name: "type schema is applied", document: `{"interval": "10m"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schemaJSON, err := json.Marshal(generateTestSchema(t, tt.opts))
+			require.NoError(t, err)
+
+			result, err := gojsonschema.Validate(
+				gojsonschema.NewBytesLoader(schemaJSON),
+				gojsonschema.NewStringLoader(tt.document),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValid, result.Valid(), "errors: %v", result.Errors())
+		})
+	}
+}
+
+func TestGenerate_DefinitionNameCollision(t *testing.T) {
+	t.Parallel()
+
+	type schemaTestNode struct {
+		Other string `yaml:"other"`
+	}
+	type root struct {
+		A schemaTestNode   `yaml:"a"`
+		B *schemaTestNode  `yaml:"b"`
+		C []schemaTestNode `yaml:"c"`
+	}
+	type collidingRoot struct {
+		root `yaml:",inline"`
+
+		Outer struct {
+			Node schemaTestNodeAlias `yaml:"node"`
+		} `yaml:"outer"`
+	}
+
+	schema, err := Generate(reflect.TypeFor[collidingRoot](), Options{})
+	require.NoError(t, err)
+
+	definitions := schema["definitions"].(map[string]any)
+	assert.Len(t, definitions, 2)
+	assert.Contains(t, definitions, "configschema.schemaTestNode")
+	assert.Contains(t, definitions, "configschema.schemaTestNode_2")
+}
+
+// schemaTestNodeAlias refers to the package-level schemaTestNode, whose name
+// collides with the function-local type of the same name.
+type schemaTestNodeAlias = schemaTestNode
+
+func TestGenerate_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(reflect.TypeFor[string](), Options{})
+	assert.ErrorContains(t, err, "schema root must be a struct")
+
+	type badMap struct {
+		Values map[int]string `yaml:"values"`
+	}
+	_, err = Generate(reflect.TypeFor[badMap](), Options{})
+	assert.ErrorContains(t, err, "unsupported map key type int")
+
+	type badInline struct {
+		Name string `yaml:",inline"`
+	}
+	_, err = Generate(reflect.TypeFor[badInline](), Options{})
+	assert.ErrorContains(t, err, "inline requires a struct or map")
+}
@@ -5,8 +5,10 @@ package kubernetes
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/k8s"
 )
 
 func init() {
@@ -17,7 +19,27 @@ func init() {
 			return NewClient(ctx)
 		},
 		AutoDetector: func() bool {
-			return runtime.IsKubernetesRuntime()
+			return detectKubernetes(runtime.IsKubernetesRuntime, k8s.AvailabilityStatus)
 		},
 	})
 }
+
+// detectKubernetes decides between the Kubernetes runtime and the local ones.
+// The Kubernetes runtime is selected when the environment asks for it and its
+// configuration can be loaded; otherwise auto-detection falls back to the
+// local runtimes, and the detection method and reason are logged so the
+// choice can be explained.
+func detectKubernetes(isKubernetesEnv func() bool, availability func() k8s.Availability) bool {
+	if !isKubernetesEnv() {
+		return false
+	}
+
+	status := availability()
+	if !status.Available {
+		slog.Warn("kubernetes environment detected but its configuration cannot be loaded, falling back to local runtimes",
+			"method", status.Method, "reason", status.Err)
+		return false
+	}
+	slog.Debug("selecting the kubernetes runtime", "method", status.Method)
+	return true
+}
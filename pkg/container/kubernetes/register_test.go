@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stacklok/toolhive/pkg/k8s"
+)
+
+func TestDetectKubernetes(t *testing.T) {
+	t.Parallel()
+
+	available := k8s.Availability{Available: true, Method: k8s.DetectionInCluster}
+	unavailable := k8s.Availability{Method: k8s.DetectionKubeconfig, Err: errors.New("no configuration")}
+
+	tests := []struct {
+		name         string
+		kubernetes   bool
+		availability k8s.Availability
+		want         bool
+		wantProbed   bool
+	}{
+		{name: "local environment", kubernetes: false, availability: available, want: false},
+		{name: "kubernetes available", kubernetes: true, availability: available, want: true, wantProbed: true},
+		{name: "kubernetes unavailable", kubernetes: true, availability: unavailable, want: false, wantProbed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			probed := false
+			got := detectKubernetes(
+				func() bool { return tt.kubernetes },
+				func() k8s.Availability {
+					probed = true
+					return tt.availability
+				},
+			)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantProbed, probed, "configuration is only probed in a kubernetes environment")
+		})
+	}
+}
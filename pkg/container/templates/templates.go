@@ -8,8 +8,10 @@ package templates
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"text/template"
 )
 
@@ -73,6 +75,18 @@ func stripVersionSuffix(pkg string) string {
 	return re.ReplaceAllString(pkg, "")
 }
 
+// jsonQuote renders s as a JSON string for an exec-form (JSON array) Dockerfile
+// instruction. Quotes and backslashes in s would otherwise make the array
+// invalid JSON, which Docker silently treats as shell form.
+func jsonQuote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// Encoding a string cannot fail.
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
 // GetDockerfileTemplate returns the Dockerfile template for the specified transport type.
 func GetDockerfileTemplate(transportType TransportType, data TemplateData) (string, error) {
 	// Populate MCPPackageClean with version-stripped package name
@@ -109,6 +123,7 @@ func GetDockerfileTemplate(transportType TransportType, data TemplateData) (stri
 		"contains": func(s, substr string) bool {
 			return bytes.Contains([]byte(s), []byte(substr))
 		},
+		"jsonQuote": jsonQuote,
 		"isAlpine": func(image string) bool {
 			return bytes.Contains([]byte(image), []byte("alpine"))
 		},
@@ -4,7 +4,9 @@
 package templates
 
 import (
+	"encoding/json"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -798,3 +800,61 @@ func TestStripVersionSuffix(t *testing.T) {
 		})
 	}
 }
+
+// TestEntrypointForwardsRuntimeArgs checks that every ENTRYPOINT stays in exec
+// form, even with build args that need escaping, so that runtime arguments
+// passed as container args ("thv run ... -- <args>") reach the MCP server.
+func TestEntrypointForwardsRuntimeArgs(t *testing.T) {
+	t.Parallel()
+
+	buildArgs := []string{"--name", `say "hi"`, `C:\path`}
+	tests := []struct {
+		name          string
+		transportType TransportType
+		want          []string
+	}{
+		{
+			name:          "npx appends container args to the package command",
+			transportType: TransportTypeNPX,
+			want:          []string{"npx", "example-package", "--name", `say "hi"`, `C:\path`},
+		},
+		{
+			name:          "go appends container args to the binary",
+			transportType: TransportTypeGO,
+			want:          []string{"/app/mcp-server", "--name", `say "hi"`, `C:\path`},
+		},
+		{
+			name:          "uvx forwards container args through \"$@\"",
+			transportType: TransportTypeUVX,
+			want: []string{
+				"sh", "-c", `exec 'example-package' '--name' 'say "hi"' 'C:\path' "$@"`, "--",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetDockerfileTemplate(tt.transportType, TemplateData{
+				MCPPackage: "example-package",
+				BuildArgs:  buildArgs,
+			})
+			if err != nil {
+				t.Fatalf("GetDockerfileTemplate() error = %v", err)
+			}
+
+			match := regexp.MustCompile(`(?m)^ENTRYPOINT (.*)$`).FindStringSubmatch(result)
+			if match == nil {
+				t.Fatalf("no ENTRYPOINT in rendered Dockerfile:\n%s", result)
+			}
+			var got []string
+			if err := json.Unmarshal([]byte(match[1]), &got); err != nil {
+				t.Fatalf("ENTRYPOINT %s is not in exec form: %v", match[1], err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ENTRYPOINT = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package correlation assigns every incoming request a correlation ID and
+// carries it through the request context, so a single client call can be
+// followed across logs, spans, audit events, and the requests sent to
+// backends.
+//
+// The package is dependency-light on purpose: the audit, telemetry, and vMCP
+// client packages all import it.
+package correlation
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// HeaderName is the HTTP header that carries the correlation ID, both on
+	// incoming client requests and on requests forwarded to backends.
+	HeaderName = "X-Correlation-ID"
+
+	// SpanAttributeKey is the span attribute that records the correlation ID.
+	SpanAttributeKey = "correlation.id"
+
+	// LogKey is the structured log key for the correlation ID.
+	LogKey = "correlation_id"
+
+	// maxIDLength bounds a client-supplied correlation ID. Longer values are
+	// replaced with a generated ID rather than truncated.
+	maxIDLength = 128
+)
+
+// correlationIDContextKey is an unexported key type for the correlation ID.
+type correlationIDContextKey struct{}
+
+// WithID returns a copy of ctx carrying the correlation ID id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, id)
+}
+
+// FromContext returns the correlation ID carried by ctx, or an empty string if
+// there is none. Returns an empty string for nil contexts.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDContextKey{}).(string)
+	return id
+}
+
+// Middleware assigns a correlation ID to every request. A valid ID supplied by
+// the client in the X-Correlation-ID header is reused; otherwise a new UUID is
+// generated. The ID is stored in the request context, echoed on the response
+// header, and recorded on the active span, if any.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HeaderName)
+		if !isValidID(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(HeaderName, id)
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.String(SpanAttributeKey, id))
+
+		next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+	})
+}
+
+// isValidID reports whether a client-supplied correlation ID can be reused: it
+// must be non-empty, at most maxIDLength bytes, and printable ASCII so it is
+// safe to copy into headers and log lines.
+func isValidID(id string) bool {
+	if id == "" || len(id) > maxIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// roundTripper sets the correlation ID from the request context on outgoing
+// requests.
+type roundTripper struct {
+	base http.RoundTripper
+}
+
+// NewRoundTripper wraps base so that every request whose context carries a
+// correlation ID is sent with the X-Correlation-ID header. A header already
+// set on the request is left untouched.
+func NewRoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &roundTripper{base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := FromContext(req.Context())
+	if id == "" || req.Header.Get(HeaderName) != "" {
+		return t.base.RoundTrip(req)
+	}
+	// Clone the request so the caller's headers are not modified, as required
+	// by the http.RoundTripper contract.
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderName, id)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package correlation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		clientID     string
+		wantClientID bool
+	}{
+		{name: "reuses client supplied ID", clientID: "client-abc-123", wantClientID: true},
+		{name: "generates ID when header missing", clientID: ""},
+		{name: "replaces ID with whitespace", clientID: "bad id", wantClientID: false},
+		{name: "replaces overlong ID", clientID: strings.Repeat("a", maxIDLength+1), wantClientID: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var seen string
+			handler := Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				seen = FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.clientID != "" {
+				req.Header.Set(HeaderName, tt.clientID)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.NotEmpty(t, seen)
+			assert.Equal(t, seen, rec.Header().Get(HeaderName), "response must echo the correlation ID")
+			if tt.wantClientID {
+				assert.Equal(t, tt.clientID, seen)
+			} else {
+				_, err := uuid.Parse(seen)
+				assert.NoError(t, err, "generated ID must be a UUID")
+			}
+		})
+	}
+}
+
+func TestRoundTripper(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ctxID      string
+		existing   string
+		wantHeader string
+	}{
+		{name: "sets header from context", ctxID: "abc", wantHeader: "abc"},
+		{name: "no header without context ID", ctxID: "", wantHeader: ""},
+		{name: "keeps header already set", ctxID: "abc", existing: "explicit", wantHeader: "explicit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get(HeaderName)
+			}))
+			t.Cleanup(server.Close)
+
+			ctx := context.Background()
+			if tt.ctxID != "" {
+				ctx = WithID(ctx, tt.ctxID)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			if tt.existing != "" {
+				req.Header.Set(HeaderName, tt.existing)
+			}
+
+			resp, err := NewRoundTripper(nil).RoundTrip(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.wantHeader, got)
+			if tt.existing == "" {
+				assert.Empty(t, req.Header.Get(HeaderName), "caller's request must not be modified")
+			}
+		})
+	}
+}
+
+func TestFromContext_Nil(t *testing.T) {
+	t.Parallel()
+
+	//nolint:staticcheck // SA1012: deliberately passing a nil context
+	assert.Empty(t, FromContext(nil))
+}
@@ -94,9 +94,9 @@ func runConfigToMCPServer(config *runner.RunConfig) (*v1beta1.MCPServer, error)
 
 	// Convert environment variables
 	if len(config.EnvVars) > 0 {
-		mcpServer.Spec.Env = make([]v1beta1.EnvVar, 0, len(config.EnvVars))
+		mcpServer.Spec.Env = make([]v1beta1.MCPServerEnvVar, 0, len(config.EnvVars))
 		for key, value := range config.EnvVars {
-			mcpServer.Spec.Env = append(mcpServer.Spec.Env, v1beta1.EnvVar{
+			mcpServer.Spec.Env = append(mcpServer.Spec.Env, v1beta1.MCPServerEnvVar{
 				Name:  key,
 				Value: value,
 			})
@@ -66,6 +66,7 @@ func WithFileLock(path string, fn func() error) error {
 		return fmt.Errorf("failed to acquire lock: timeout after %v", DefaultLockTimeout)
 	}
 	defer lockfile.ReleaseTrackedLock(lockPath, fileLock)
+	lockfile.RecordOwner(lockPath)
 
 	return fn()
 }
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// availabilityCacheTTL is how long an availability result is reused. It is
+// long enough to cover a single CLI invocation and short enough that a
+// long-running process notices a kubeconfig being added or removed.
+const availabilityCacheTTL = 30 * time.Second
+
+// DetectionMethod identifies how Kubernetes configuration was looked for.
+type DetectionMethod string
+
+const (
+	// DetectionInCluster is the service account config mounted into a pod.
+	DetectionInCluster DetectionMethod = "in-cluster"
+	// DetectionKubeconfig is a kubeconfig file found through KUBECONFIG or
+	// ~/.kube/config.
+	DetectionKubeconfig DetectionMethod = "kubeconfig"
+)
+
+// Availability describes whether Kubernetes is available and why.
+type Availability struct {
+	// Available reports whether a Kubernetes config could be loaded.
+	Available bool
+	// Method is the detection method that succeeded, or the last one tried
+	// when none did.
+	Method DetectionMethod
+	// Err explains why Kubernetes is unavailable. It is nil when Available
+	// is true.
+	Err error
+}
+
+// String returns a one-line, human-readable explanation of the result,
+// suitable for CLI output.
+func (a Availability) String() string {
+	if a.Available {
+		return fmt.Sprintf("kubernetes is available (detected via %s config)", a.Method)
+	}
+	return fmt.Sprintf("kubernetes is not available: %v", a.Err)
+}
+
+// availabilityChecker detects Kubernetes availability and caches the result.
+type availabilityChecker struct {
+	loader configLoader
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	cached    Availability
+	checkedAt time.Time
+}
+
+func newAvailabilityChecker(loader configLoader, ttl time.Duration) *availabilityChecker {
+	return &availabilityChecker{loader: loader, ttl: ttl, now: time.Now}
+}
+
+var defaultAvailabilityChecker = newAvailabilityChecker(&defaultConfigLoader{}, availabilityCacheTTL)
+
+// AvailabilityStatus reports whether Kubernetes is available, which detection
+// method found it, and why it is unavailable otherwise. Detection only loads
+// configuration; it does not contact the API server.
+//
+// Results are cached for a short time, so repeated calls within one command
+// do not probe again.
+func AvailabilityStatus() Availability {
+	return defaultAvailabilityChecker.status()
+}
+
+// IsAvailable reports whether Kubernetes configuration can be loaded. See
+// AvailabilityStatus for the reason behind the result.
+func IsAvailable() bool {
+	return AvailabilityStatus().Available
+}
+
+// status returns the cached result, probing again once it has expired.
+func (c *availabilityChecker) status() Availability {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < c.ttl {
+		return c.cached
+	}
+	c.cached = c.detect()
+	c.checkedAt = now
+	return c.cached
+}
+
+// detect tries the same sources as GetConfig, in the same order.
+func (c *availabilityChecker) detect() Availability {
+	_, inClusterErr := loadInClusterConfig(c.loader)
+	if inClusterErr == nil {
+		return Availability{Available: true, Method: DetectionInCluster}
+	}
+
+	_, kubeconfigErr := c.loader.LoadFromRules(clientcmd.NewDefaultClientConfigLoadingRules())
+	if kubeconfigErr == nil {
+		return Availability{Available: true, Method: DetectionKubeconfig}
+	}
+
+	return Availability{
+		Method: DetectionKubeconfig,
+		Err: fmt.Errorf("%s: %w; %s: %w",
+			DetectionInCluster, inClusterErr, DetectionKubeconfig, kubeconfigErr),
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// countingConfigLoader wraps mockConfigLoader and counts how often each
+// detection method is tried.
+type countingConfigLoader struct {
+	mockConfigLoader
+	inClusterCalls int
+	rulesCalls     int
+}
+
+func (c *countingConfigLoader) InClusterConfig() (*rest.Config, error) {
+	c.inClusterCalls++
+	return c.mockConfigLoader.InClusterConfig()
+}
+
+func (c *countingConfigLoader) LoadFromRules(rules *clientcmd.ClientConfigLoadingRules) (*rest.Config, error) {
+	c.rulesCalls++
+	return c.mockConfigLoader.LoadFromRules(rules)
+}
+
+func TestAvailabilityChecker_Detect(t *testing.T) {
+	t.Parallel()
+
+	noKubeconfig := errors.New("no kubeconfig")
+
+	tests := []struct {
+		name            string
+		inClusterError  error
+		rulesError      error
+		expectAvailable bool
+		expectMethod    DetectionMethod
+		expectRulesCall bool
+	}{
+		{
+			name:            "in-cluster",
+			expectAvailable: true,
+			expectMethod:    DetectionInCluster,
+		},
+		{
+			name:            "out-of-cluster",
+			inClusterError:  rest.ErrNotInCluster,
+			expectAvailable: true,
+			expectMethod:    DetectionKubeconfig,
+			expectRulesCall: true,
+		},
+		{
+			name:            "unavailable",
+			inClusterError:  rest.ErrNotInCluster,
+			rulesError:      noKubeconfig,
+			expectMethod:    DetectionKubeconfig,
+			expectRulesCall: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader := &countingConfigLoader{mockConfigLoader: mockConfigLoader{
+				inClusterConfig: &rest.Config{Host: "https://in-cluster:6443"},
+				inClusterError:  tt.inClusterError,
+				rulesConfig:     &rest.Config{Host: "https://kubeconfig:6443"},
+				rulesError:      tt.rulesError,
+			}}
+
+			status := newAvailabilityChecker(loader, time.Minute).status()
+
+			assert.Equal(t, tt.expectAvailable, status.Available)
+			assert.Equal(t, tt.expectMethod, status.Method)
+			assert.Equal(t, tt.expectRulesCall, loader.rulesCalls > 0)
+			if tt.expectAvailable {
+				assert.NoError(t, status.Err)
+				assert.Contains(t, status.String(), string(tt.expectMethod))
+			} else {
+				require.Error(t, status.Err)
+				assert.ErrorIs(t, status.Err, rest.ErrNotInCluster)
+				assert.ErrorIs(t, status.Err, noKubeconfig)
+				assert.Contains(t, status.String(), "in-cluster: ")
+				assert.Contains(t, status.String(), "kubeconfig: no kubeconfig")
+			}
+		})
+	}
+}
+
+func TestAvailabilityChecker_Cache(t *testing.T) {
+	t.Parallel()
+
+	loader := &countingConfigLoader{mockConfigLoader: mockConfigLoader{
+		inClusterError: rest.ErrNotInCluster,
+		rulesError:     errors.New("no kubeconfig"),
+	}}
+	checker := newAvailabilityChecker(loader, time.Minute)
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+
+	assert.False(t, checker.status().Available)
+
+	// A kubeconfig appearing is not noticed until the cached result expires.
+	loader.rulesError = nil
+	loader.rulesConfig = &rest.Config{Host: "https://kubeconfig:6443"}
+	now = now.Add(30 * time.Second)
+	assert.False(t, checker.status().Available)
+	assert.Equal(t, 1, loader.rulesCalls, "a cached result must not probe again")
+
+	now = now.Add(time.Minute)
+	status := checker.status()
+	assert.True(t, status.Available)
+	assert.Equal(t, DetectionKubeconfig, status.Method)
+	assert.Equal(t, 2, loader.rulesCalls)
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrCachedClientClosed is returned by reads on a CachedClient after Close.
+var ErrCachedClientClosed = errors.New("cached kubernetes client is closed")
+
+// informerCache is the subset of cache.Cache used by CachedClient.
+type informerCache interface {
+	client.Reader
+	Start(ctx context.Context) error
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CachedClient is a controller-runtime client whose Get and List calls are
+// served from a shared informer cache, while writes go straight to the API
+// server. It suits read-heavy callers, such as repeatedly listing MCPServers,
+// that would otherwise send every read to the API server.
+//
+// The cache starts on the first read, which blocks until the informers for
+// the requested type have synced. The informers then watch the API server
+// until Close is called.
+//
+// Reads are eventually consistent: an object written through this client, or
+// by anyone else, becomes visible only once its watch event reaches the
+// cache, typically within milliseconds but with no upper bound. Callers that
+// must read their own writes, or need a strongly consistent read, should use a
+// client from NewControllerRuntimeClient instead.
+type CachedClient struct {
+	client.Client
+
+	cache informerCache
+
+	mu      sync.Mutex
+	started bool
+	closed  bool
+	cancel  context.CancelFunc
+	// stopped is closed when the cache's Start returns.
+	stopped chan struct{}
+}
+
+// NewCachedControllerRuntimeClient creates a controller-runtime client that
+// serves reads from an informer cache scoped to the given namespaces. With no
+// namespaces the cache watches the whole cluster; with namespaces, reading an
+// object in any other namespace fails. The scheme should have all required
+// types registered. The returned client must be closed to stop its informers.
+func NewCachedControllerRuntimeClient(scheme *runtime.Scheme, namespaces ...string) (*CachedClient, error) {
+	config, err := GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes config: %w", err)
+	}
+
+	return newCachedControllerRuntimeClientWithConfig(config, scheme, namespaces...)
+}
+
+// newCachedControllerRuntimeClientWithConfig is the internal implementation for creating a cached client
+func newCachedControllerRuntimeClientWithConfig(
+	config *rest.Config, scheme *runtime.Scheme, namespaces ...string,
+) (*CachedClient, error) {
+	writer, err := newControllerRuntimeClientWithConfig(config, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := cache.Options{Scheme: scheme}
+	if len(namespaces) > 0 {
+		opts.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, ns := range namespaces {
+			opts.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+	informers, err := cache.New(config, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create informer cache: %w", err)
+	}
+
+	return newCachedClient(writer, informers), nil
+}
+
+func newCachedClient(writer client.Client, informers informerCache) *CachedClient {
+	return &CachedClient{Client: writer, cache: informers}
+}
+
+// Get retrieves an object from the cache, starting the cache if needed.
+func (c *CachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.start(ctx); err != nil {
+		return err
+	}
+	return c.cache.Get(ctx, key, obj, opts...)
+}
+
+// List retrieves a list of objects from the cache, starting the cache if needed.
+func (c *CachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.start(ctx); err != nil {
+		return err
+	}
+	return c.cache.List(ctx, list, opts...)
+}
+
+// Close stops the cache's informers and waits for them to exit. Reads after
+// Close fail with ErrCachedClientClosed; writes are unaffected.
+func (c *CachedClient) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	started := c.started
+	c.mu.Unlock()
+
+	if started {
+		c.cancel()
+		<-c.stopped
+	}
+	return nil
+}
+
+// start starts the cache on the first call and waits for it to sync.
+func (c *CachedClient) start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrCachedClientClosed
+	}
+	if !c.started {
+		c.started = true
+		// The cache outlives the request that starts it; it runs until Close.
+		cacheCtx, cancel := context.WithCancel(context.Background())
+		c.cancel = cancel
+		c.stopped = make(chan struct{})
+		go func() {
+			defer close(c.stopped)
+			if err := c.cache.Start(cacheCtx); err != nil {
+				slog.Warn("informer cache stopped", "error", err)
+			}
+		}()
+	}
+	c.mu.Unlock()
+
+	if !c.cache.WaitForCacheSync(ctx) {
+		if ctx.Err() != nil {
+			return fmt.Errorf("failed to sync informer cache: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to sync informer cache")
+	}
+	return nil
+}
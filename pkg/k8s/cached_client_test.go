@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeInformerCache serves reads from a fake client standing in for the
+// informer cache's local store, and records when it is started and stopped.
+type fakeInformerCache struct {
+	client.Client
+	starts  atomic.Int32
+	stopped atomic.Bool
+}
+
+func (f *fakeInformerCache) Start(ctx context.Context) error {
+	f.starts.Add(1)
+	<-ctx.Done()
+	f.stopped.Store(true)
+	return nil
+}
+
+func (*fakeInformerCache) WaitForCacheSync(context.Context) bool {
+	return true
+}
+
+func configMap(name, value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "toolhive"},
+		Data:       map[string]string{"value": value},
+	}
+}
+
+func TestCachedClient(t *testing.T) {
+	t.Parallel()
+
+	newClients := func() (*CachedClient, client.Client, *fakeInformerCache) {
+		scheme := createTestScheme()
+		apiServer := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(configMap("settings", "live")).Build()
+		informers := &fakeInformerCache{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(configMap("settings", "cached")).Build(),
+		}
+		return newCachedClient(apiServer, informers), apiServer, informers
+	}
+
+	t.Run("reads are served from the cache", func(t *testing.T) {
+		t.Parallel()
+		cached, _, informers := newClients()
+		t.Cleanup(func() { _ = cached.Close() })
+		assert.Zero(t, informers.starts.Load(), "cache must start lazily")
+
+		var cm corev1.ConfigMap
+		require.NoError(t, cached.Get(context.Background(), client.ObjectKey{Namespace: "toolhive", Name: "settings"}, &cm))
+		assert.Equal(t, "cached", cm.Data["value"])
+
+		var list corev1.ConfigMapList
+		require.NoError(t, cached.List(context.Background(), &list, client.InNamespace("toolhive")))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "cached", list.Items[0].Data["value"])
+
+		assert.Eventually(t, func() bool { return informers.starts.Load() == 1 },
+			time.Second, 10*time.Millisecond, "cache must start once")
+	})
+
+	t.Run("writes bypass the cache", func(t *testing.T) {
+		t.Parallel()
+		cached, apiServer, informers := newClients()
+		t.Cleanup(func() { _ = cached.Close() })
+
+		require.NoError(t, cached.Create(context.Background(), configMap("new", "written")))
+
+		var cm corev1.ConfigMap
+		require.NoError(t, apiServer.Get(context.Background(), client.ObjectKey{Namespace: "toolhive", Name: "new"}, &cm))
+		assert.Equal(t, "written", cm.Data["value"])
+		err := informers.Get(context.Background(), client.ObjectKey{Namespace: "toolhive", Name: "new"}, &cm)
+		assert.Error(t, err, "a write must not touch the cache")
+		assert.Zero(t, informers.starts.Load(), "a write must not start the cache")
+	})
+
+	t.Run("close stops the cache", func(t *testing.T) {
+		t.Parallel()
+		cached, _, informers := newClients()
+
+		var cm corev1.ConfigMap
+		require.NoError(t, cached.Get(context.Background(), client.ObjectKey{Namespace: "toolhive", Name: "settings"}, &cm))
+		require.NoError(t, cached.Close())
+		assert.True(t, informers.stopped.Load())
+
+		err := cached.Get(context.Background(), client.ObjectKey{Namespace: "toolhive", Name: "settings"}, &cm)
+		assert.ErrorIs(t, err, ErrCachedClientClosed)
+		assert.NoError(t, cached.Close(), "close is idempotent")
+	})
+
+	t.Run("close before first read", func(t *testing.T) {
+		t.Parallel()
+		cached, _, informers := newClients()
+
+		require.NoError(t, cached.Close())
+		assert.Zero(t, informers.starts.Load())
+	})
+}
+
+func TestNewCachedControllerRuntimeClient(t *testing.T) {
+	t.Parallel()
+
+	config := createTestConfig(t)
+
+	t.Run("nil scheme", func(t *testing.T) {
+		t.Parallel()
+		cached, err := newCachedControllerRuntimeClientWithConfig(config, nil, "toolhive")
+		require.Error(t, err)
+		assert.Nil(t, cached)
+		assert.Contains(t, err.Error(), "scheme cannot be nil")
+	})
+
+	t.Run("namespaced cache", func(t *testing.T) {
+		t.Parallel()
+		cached, err := newCachedControllerRuntimeClientWithConfig(config, createTestScheme(), "toolhive", "team-a")
+		require.NoError(t, err)
+		require.NotNil(t, cached)
+		assert.NoError(t, cached.Close())
+	})
+}
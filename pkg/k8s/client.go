@@ -5,6 +5,9 @@ package k8s
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -12,18 +15,72 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ClientOption configures a Kubernetes client created by NewClient or
+// NewClientWithConfig.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	connectTimeout time.Duration
+}
+
+// WithConnectTimeout bounds both the TCP dial and the TLS handshake with the
+// API server, so that an unreachable or unresponsive cluster fails fast
+// instead of waiting for client-go's defaults (30s to dial, 10s to
+// handshake). Non-positive values are ignored.
+func WithConnectTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		if timeout > 0 {
+			o.connectTimeout = timeout
+		}
+	}
+}
+
+// applyClientOptions returns a copy of config with the options applied. The
+// caller's config is never modified.
+func applyClientOptions(config *rest.Config, opts []ClientOption) *rest.Config {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.connectTimeout == 0 {
+		return config
+	}
+
+	config = rest.CopyConfig(config)
+	config.Dial = (&net.Dialer{Timeout: o.connectTimeout, KeepAlive: 30 * time.Second}).DialContext
+
+	// client-go hard-codes the TLS handshake timeout on the transport it
+	// builds, so override it on a copy of that transport before any other
+	// wrappers (auth, user-supplied) are layered on top. Setting Dial above
+	// also keeps client-go from sharing the transport with other clients.
+	wrap := config.WrapTransport
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if t, ok := rt.(*http.Transport); ok {
+			t = t.Clone()
+			t.TLSHandshakeTimeout = o.connectTimeout
+			rt = t
+		}
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return rt
+	}
+	return config
+}
+
 // NewClient creates a new standard Kubernetes clientset using the default config loading.
 // It tries in-cluster config first, then falls back to out-of-cluster config.
 // Use this when you only need to work with standard Kubernetes resources.
-func NewClient() (kubernetes.Interface, *rest.Config, error) {
+// The returned config is the loaded config, without the options applied.
+func NewClient(opts ...ClientOption) (kubernetes.Interface, *rest.Config, error) {
 	config, err := GetConfig()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create kubernetes config: %w", err)
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := NewClientWithConfig(config, opts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+		return nil, nil, err
 	}
 
 	return clientset, config, nil
@@ -31,12 +88,12 @@ func NewClient() (kubernetes.Interface, *rest.Config, error) {
 
 // NewClientWithConfig creates a new standard Kubernetes clientset from the provided config.
 // Use this when you have an existing config and only need standard Kubernetes resources.
-func NewClientWithConfig(config *rest.Config) (kubernetes.Interface, error) {
+func NewClientWithConfig(config *rest.Config, opts ...ClientOption) (kubernetes.Interface, error) {
 	if config == nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: config cannot be nil")
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := kubernetes.NewForConfig(applyClientOptions(config, opts))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
@@ -5,9 +5,12 @@ package k8s
 
 import (
 	"errors"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -82,6 +85,74 @@ func TestNewClientWithConfig(t *testing.T) {
 	}
 }
 
+func TestNewClientWithConfig_ConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	// The listener accepts connections but never completes a TLS handshake,
+	// like an API server that is reachable but unresponsive.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+		}
+	}()
+
+	config := &rest.Config{
+		Host:            "https://" + listener.Addr().String(),
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+	}
+	clientset, err := NewClientWithConfig(config, WithConnectTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = clientset.Discovery().ServerVersion()
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second, "call must fail within the connect timeout")
+	assert.Nil(t, config.Dial, "the caller's config must not be modified")
+	assert.Nil(t, config.WrapTransport, "the caller's config must not be modified")
+}
+
+func TestApplyClientOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no options returns the config unchanged", func(t *testing.T) {
+		t.Parallel()
+		config := &rest.Config{Host: "https://localhost:6443"}
+		assert.Same(t, config, applyClientOptions(config, nil))
+		assert.Same(t, config, applyClientOptions(config, []ClientOption{WithConnectTimeout(0)}))
+	})
+
+	t.Run("connect timeout overrides the transport and keeps existing wrappers", func(t *testing.T) {
+		t.Parallel()
+		var wrapped http.RoundTripper
+		config := &rest.Config{
+			Host: "https://localhost:6443",
+			WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+				wrapped = rt
+				return rt
+			},
+		}
+
+		got := applyClientOptions(config, []ClientOption{WithConnectTimeout(time.Second)})
+
+		require.NotNil(t, got.Dial)
+		base := &http.Transport{TLSHandshakeTimeout: 10 * time.Second}
+		rt := got.WrapTransport(base)
+		transport, ok := rt.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, time.Second, transport.TLSHandshakeTimeout)
+		assert.Equal(t, 10*time.Second, base.TLSHandshakeTimeout, "the shared transport must not be modified")
+		assert.Same(t, rt, wrapped, "the existing wrapper must wrap the adjusted transport")
+	})
+}
+
 func TestNewControllerRuntimeClientWithConfig(t *testing.T) {
 	t.Parallel()
 
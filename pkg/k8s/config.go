@@ -6,12 +6,24 @@
 package k8s
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+const (
+	// inClusterTokenAttempts bounds how many times the in-cluster config is
+	// loaded while the service account token file is missing.
+	inClusterTokenAttempts = 3
+	// inClusterTokenRetryInterval is the wait between those attempts.
+	inClusterTokenRetryInterval = 100 * time.Millisecond
+)
+
 // configLoader defines the interface for loading Kubernetes configs
 type configLoader interface {
 	// InClusterConfig returns the in-cluster config
@@ -34,7 +46,8 @@ func (*defaultConfigLoader) LoadFromRules(rules *clientcmd.ClientConfigLoadingRu
 }
 
 // GetConfig returns a Kubernetes REST config with the following fallback strategy:
-//  1. In-cluster config (when running inside a Kubernetes pod)
+//  1. In-cluster config (when running inside a Kubernetes pod), retried briefly
+//     if the service account token file is missing during rotation
 //  2. Out-of-cluster config using standard kubeconfig loading rules:
 //     a. KUBECONFIG environment variable (colon-separated paths)
 //     b. ~/.kube/config file
@@ -64,7 +77,7 @@ func GetConfig() (*rest.Config, error) {
 // getConfigWithLoader is the internal implementation that accepts a configLoader
 func getConfigWithLoader(loader configLoader) (*rest.Config, error) {
 	// Try in-cluster config first
-	config, err := loader.InClusterConfig()
+	config, err := loadInClusterConfig(loader)
 	if err == nil {
 		return config, nil
 	}
@@ -79,6 +92,27 @@ func getConfigWithLoader(loader configLoader) (*rest.Config, error) {
 	return config, nil
 }
 
+// loadInClusterConfig loads the in-cluster config, retrying a bounded number
+// of times while the service account token file is missing. The kubelet
+// replaces the token file when rotating it, so a read can briefly find
+// nothing there.
+func loadInClusterConfig(loader configLoader) (*rest.Config, error) {
+	var err error
+	for attempt := 1; attempt <= inClusterTokenAttempts; attempt++ {
+		var config *rest.Config
+		config, err = loader.InClusterConfig()
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return config, err
+		}
+		if attempt < inClusterTokenAttempts {
+			slog.Debug("service account token not found, retrying in-cluster config",
+				"attempt", attempt, "error", err)
+			time.Sleep(inClusterTokenRetryInterval)
+		}
+	}
+	return nil, err
+}
+
 // getConfigFromKubeconfigFile loads config from a specific kubeconfig file path
 // This is primarily useful for testing
 func getConfigFromKubeconfigFile(kubeconfigPath string) (*rest.Config, error) {
@@ -5,6 +5,7 @@ package k8s
 
 import (
 	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -96,6 +97,80 @@ func TestGetConfigWithLoader(t *testing.T) {
 	}
 }
 
+// flakyTokenLoader fails to load the in-cluster config a set number of times
+// before succeeding, as when the service account token is being rotated.
+type flakyTokenLoader struct {
+	mockConfigLoader
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakyTokenLoader) InClusterConfig() (*rest.Config, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return f.mockConfigLoader.InClusterConfig()
+}
+
+func TestGetConfigWithLoader_InClusterTokenRetry(t *testing.T) {
+	t.Parallel()
+
+	missingToken := &fs.PathError{Op: "open", Path: "/var/run/secrets/token", Err: fs.ErrNotExist}
+
+	tests := []struct {
+		name          string
+		failures      int
+		err           error
+		expectedHost  string
+		expectedCalls int
+	}{
+		{
+			name:          "retries while the token file is missing",
+			failures:      inClusterTokenAttempts - 1,
+			err:           missingToken,
+			expectedHost:  "https://in-cluster:6443",
+			expectedCalls: inClusterTokenAttempts,
+		},
+		{
+			name:          "falls back to kubeconfig after bounded retries",
+			failures:      inClusterTokenAttempts,
+			err:           missingToken,
+			expectedHost:  "https://kubeconfig:6443",
+			expectedCalls: inClusterTokenAttempts,
+		},
+		{
+			name:          "does not retry when not in cluster",
+			failures:      1,
+			err:           rest.ErrNotInCluster,
+			expectedHost:  "https://kubeconfig:6443",
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader := &flakyTokenLoader{
+				mockConfigLoader: mockConfigLoader{
+					inClusterConfig: &rest.Config{Host: "https://in-cluster:6443"},
+					rulesConfig:     &rest.Config{Host: "https://kubeconfig:6443"},
+				},
+				failures: tt.failures,
+				err:      tt.err,
+			}
+
+			config, err := getConfigWithLoader(loader)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHost, config.Host)
+			assert.Equal(t, tt.expectedCalls, loader.calls)
+		})
+	}
+}
+
 func TestGetConfigFromKubeconfigFile(t *testing.T) {
 	t.Parallel()
 
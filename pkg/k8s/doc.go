@@ -83,8 +83,8 @@
 //	// Customize config if needed
 //	config.Timeout = 30 * time.Second
 //
-//	// Create client from config
-//	clientset, err := k8s.NewClientWithConfig(config)
+//	// Create client from config, failing fast if the cluster is unreachable
+//	clientset, err := k8s.NewClientWithConfig(config, k8s.WithConnectTimeout(5*time.Second))
 //	if err != nil {
 //	    return err
 //	}
@@ -148,17 +148,26 @@
 //
 //	import "github.com/stacklok/toolhive/pkg/k8s"
 //
-//	if k8s.IsAvailable() {
-//	    fmt.Println("Kubernetes is available")
+//	status := k8s.AvailabilityStatus()
+//	if status.Available {
 //	    // Proceed with Kubernetes operations
+//	    slog.Debug("using kubernetes", "method", status.Method)
 //	} else {
-//	    fmt.Println("Kubernetes is not available, falling back to local mode")
-//	    // Use alternative runtime
+//	    // Use alternative runtime, explaining why
+//	    slog.Warn("falling back to local mode", "method", status.Method, "reason", status.Err)
 //	}
 //
+//	// Or print a one-line explanation, e.g. "kubernetes is available (detected via kubeconfig config)"
+//	fmt.Println(status)
+//
+// IsAvailable remains for callers that only need the boolean.
+//
+// Availability results are cached briefly, so repeated checks within one
+// command do not load the configuration again.
+//
 // # Client Types
 //
-// The package provides three specialized client creation functions:
+// The package provides four specialized client creation functions:
 //
 //  1. NewClient() - Standard Kubernetes clientset (kubernetes.Interface)
 //     - Use for working with built-in Kubernetes resources (Pods, Services, etc.)
@@ -176,6 +185,11 @@
 //     - Works with unstructured.Unstructured objects
 //     - Useful for discovery, generic tooling, or when resource types are unknown
 //
+//  4. NewCachedControllerRuntimeClient() - Cached controller-runtime client
+//     - Serves Get and List from an informer cache; writes go to the API server
+//     - Can be scoped to a set of namespaces
+//     - Reads are eventually consistent; call Close to stop the informers
+//
 // # Design Considerations
 //
 // This package is designed to:
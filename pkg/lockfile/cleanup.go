@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/adrg/xdg"
 	"github.com/gofrs/flock"
 )
 
@@ -41,12 +42,15 @@ func (lr *lockRegistry) UnregisterLock(lockPath string) {
 	delete(lr.locks, lockPath)
 }
 
-// CleanupAll unlocks and removes all registered lock files
+// CleanupAll unlocks and removes all registered lock files and their owner records
 func (lr *lockRegistry) CleanupAll() {
 	lr.mu.Lock()
 	defer lr.mu.Unlock()
 
 	for lockPath, lock := range lr.locks {
+		if lock.Locked() {
+			removeOwner(lockPath)
+		}
 		if err := lock.Unlock(); err != nil && !os.IsNotExist(err) {
 			slog.Warn("failed to unlock file", "path", lockPath, "error", err)
 		}
@@ -67,8 +71,15 @@ func NewTrackedLock(lockPath string) *flock.Flock {
 	return lock
 }
 
-// ReleaseTrackedLock unlocks, removes, and unregisters a lock file
+// ReleaseTrackedLock unlocks, removes, and unregisters a lock file. If the lock
+// is held exclusively, its owner record (see RecordOwner) is removed as well;
+// with a shared lock or no lock at all, the record belongs to another process.
 func ReleaseTrackedLock(lockPath string, lock *flock.Flock) {
+	// The owner record goes first, while the lock still excludes a new owner
+	// from writing its own.
+	if lock.Locked() {
+		removeOwner(lockPath)
+	}
 	if err := lock.Unlock(); err != nil && !os.IsNotExist(err) {
 		slog.Warn("failed to unlock file", "path", lockPath, "error", err)
 	}
@@ -85,11 +96,63 @@ func CleanupAllLocks() {
 	globalRegistry.CleanupAll()
 }
 
-// CleanupStaleLocks removes stale lock files from the specified directories
-// A lock file is considered stale if it's older than the maxAge duration
+// DefaultMaxAge is the age after which an unused lock file in one of the
+// DefaultDirectories is considered stale. It is long enough for any single
+// ToolHive operation holding a lock to finish.
+const DefaultMaxAge = 5 * time.Minute
+
+// DefaultDirectories returns the directories in which ToolHive creates lock
+// files: the config directory, and the data directory with its statuses
+// subdirectory.
+func DefaultDirectories() []string {
+	var directories []string
+
+	if configDir, err := xdg.ConfigFile("toolhive"); err == nil {
+		directories = append(directories, configDir)
+	}
+
+	// Data directory (for statuses and updates)
+	if dataDir, err := xdg.DataFile("toolhive"); err == nil {
+		directories = append(directories, dataDir)
+
+		if statusDir, err := xdg.DataFile("toolhive/statuses"); err == nil {
+			directories = append(directories, statusDir)
+		}
+	}
+
+	return directories
+}
+
+// CleanupStaleLocks removes stale lock files from the specified directories.
+// A lock file is considered stale if it's older than the maxAge duration, the
+// process recorded as its owner (see RecordOwner) is no longer a running
+// ToolHive process, and no process holds the lock. Lock files without a
+// recorded owner are judged by their age alone.
 func CleanupStaleLocks(directories []string, maxAge time.Duration) {
+	cleanupStaleLocks(directories, maxAge, isToolHiveOwnerAlive)
+}
+
+func cleanupStaleLocks(directories []string, maxAge time.Duration, ownerAlive ownerCheckFunc) {
+	for _, lockFile := range findStaleLocks(directories, maxAge, ownerAlive) {
+		if err := os.Remove(lockFile); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to remove stale lock file", "path", lockFile, "error", err)
+			continue
+		}
+		removeOwner(lockFile)
+		slog.Debug("removed stale lock file", "path", lockFile)
+	}
+}
+
+// FindStaleLocks returns the stale lock files in the specified directories,
+// as defined by CleanupStaleLocks, without removing them.
+func FindStaleLocks(directories []string, maxAge time.Duration) []string {
+	return findStaleLocks(directories, maxAge, isToolHiveOwnerAlive)
+}
+
+func findStaleLocks(directories []string, maxAge time.Duration, ownerAlive ownerCheckFunc) []string {
 	cutoff := time.Now().Add(-maxAge)
 
+	var stale []string
 	for _, dir := range directories {
 		matches, err := filepath.Glob(filepath.Join(dir, "*.lock"))
 		if err != nil {
@@ -98,26 +161,37 @@ func CleanupStaleLocks(directories []string, maxAge time.Duration) {
 		}
 
 		for _, lockFile := range matches {
-			info, err := os.Stat(lockFile)
-			if err != nil {
-				continue // File may have been removed already
-			}
-
-			if info.ModTime().Before(cutoff) {
-				// Try to acquire the lock to check if it's really stale
-				lock := flock.New(lockFile)
-				if locked, err := lock.TryLock(); err == nil && locked {
-					// Lock was acquired, so it was stale
-					if err := lock.Unlock(); err != nil && !os.IsNotExist(err) {
-						slog.Warn("failed to unlock stale lock file", "path", lockFile, "error", err)
-					}
-					if err := os.Remove(lockFile); err != nil && !os.IsNotExist(err) {
-						slog.Warn("failed to remove stale lock file", "path", lockFile, "error", err)
-					} else {
-						slog.Debug("removed stale lock file", "path", lockFile)
-					}
-				}
+			if isStaleLock(lockFile, cutoff, ownerAlive) {
+				stale = append(stale, lockFile)
 			}
 		}
 	}
+	return stale
+}
+
+// isStaleLock reports whether lockFile was last modified before cutoff, is not
+// owned by a running ToolHive process, and is not held by any process.
+func isStaleLock(lockFile string, cutoff time.Time, ownerAlive ownerCheckFunc) bool {
+	info, err := os.Stat(lockFile)
+	if err != nil {
+		return false // File may have been removed already
+	}
+	if !info.ModTime().Before(cutoff) {
+		return false
+	}
+
+	if pid, ok := readOwner(lockFile); ok && ownerAlive(pid) {
+		slog.Debug("keeping old lock file owned by a running process", "path", lockFile, "pid", pid)
+		return false
+	}
+
+	// Try to acquire the lock to check if it's really stale
+	lock := flock.New(lockFile)
+	if locked, err := lock.TryLock(); err != nil || !locked {
+		return false
+	}
+	if err := lock.Unlock(); err != nil && !os.IsNotExist(err) {
+		slog.Warn("failed to unlock stale lock file", "path", lockFile, "error", err)
+	}
+	return true
 }
@@ -5,7 +5,9 @@ package lockfile
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -459,3 +461,128 @@ func TestCleanupAllLocks_EmptyRegistry(t *testing.T) {
 	assert.Len(t, globalRegistry.locks, 0)
 	globalRegistry.mu.RUnlock()
 }
+
+func TestCleanupStaleLocks_RecordedOwner(t *testing.T) {
+	t.Parallel()
+
+	const deadPID = 999999
+
+	tempDir := t.TempDir()
+	livePath := filepath.Join(tempDir, "live.lock")
+	deadPath := filepath.Join(tempDir, "dead.lock")
+	legacyPath := filepath.Join(tempDir, "legacy.lock")
+
+	for _, path := range []string{livePath, deadPath, legacyPath} {
+		require.NoError(t, os.WriteFile(path, nil, 0600))
+	}
+	require.NoError(t, os.WriteFile(ownerPath(livePath), []byte(strconv.Itoa(os.Getpid())+"\n"), 0600))
+	require.NoError(t, os.WriteFile(ownerPath(deadPath), []byte(strconv.Itoa(deadPID)+"\n"), 0600))
+
+	oldTime := time.Now().Add(-10 * time.Minute)
+	for _, path := range []string{livePath, deadPath, legacyPath} {
+		require.NoError(t, os.Chtimes(path, oldTime, oldTime))
+	}
+
+	var checked []int
+	ownerAlive := func(pid int) bool {
+		checked = append(checked, pid)
+		return pid == os.Getpid()
+	}
+	cleanupStaleLocks([]string{tempDir}, 5*time.Minute, ownerAlive)
+
+	_, err := os.Stat(livePath)
+	assert.NoError(t, err, "lock owned by a live process should be kept")
+	_, err = os.Stat(deadPath)
+	assert.True(t, os.IsNotExist(err), "lock owned by a dead process should be removed")
+	assert.NoFileExists(t, ownerPath(deadPath), "owner of a removed lock should be removed")
+	assert.FileExists(t, ownerPath(livePath))
+	_, err = os.Stat(legacyPath)
+	assert.True(t, os.IsNotExist(err), "old lock without an owner should be removed")
+	assert.ElementsMatch(t, []int{os.Getpid(), deadPID}, checked)
+}
+
+//nolint:paralleltest // Modifies global state, cannot run in parallel
+func TestRecordOwner(t *testing.T) {
+	origRegistry := globalRegistry
+	defer func() { globalRegistry = origRegistry }()
+	globalRegistry = &lockRegistry{
+		locks: make(map[string]*flock.Flock),
+	}
+
+	lockPath := filepath.Join(t.TempDir(), "owned.lock")
+	lock := NewTrackedLock(lockPath)
+	require.NoError(t, lock.Lock())
+
+	RecordOwner(lockPath)
+
+	pid, ok := readOwner(lockPath)
+	require.True(t, ok)
+	assert.Equal(t, os.Getpid(), pid)
+
+	// The PID lives in a sidecar file: on Windows the locked file itself
+	// cannot be written while the lock is held.
+	data, err := os.ReadFile(lockPath)
+	require.NoError(t, err)
+	assert.Empty(t, data, "the locked file should not be written")
+
+	ReleaseTrackedLock(lockPath, lock)
+	assert.NoFileExists(t, lockPath)
+	assert.NoFileExists(t, ownerPath(lockPath), "releasing the lock should remove its owner")
+}
+
+//nolint:paralleltest // Modifies global state, cannot run in parallel
+func TestReleaseTrackedLock_KeepsOtherOwner(t *testing.T) {
+	origRegistry := globalRegistry
+	defer func() { globalRegistry = origRegistry }()
+	globalRegistry = &lockRegistry{
+		locks: make(map[string]*flock.Flock),
+	}
+
+	lockPath := filepath.Join(t.TempDir(), "owned.lock")
+	owner := flock.New(lockPath)
+	require.NoError(t, owner.Lock())
+	RecordOwner(lockPath)
+
+	// A lock that was never acquired does not own the record.
+	unacquired := NewTrackedLock(lockPath)
+	ReleaseTrackedLock(lockPath, unacquired)
+	pid, ok := readOwner(lockPath)
+	require.True(t, ok, "releasing an unacquired lock must keep the owner record")
+	assert.Equal(t, os.Getpid(), pid)
+
+	// Neither does a shared lock.
+	require.NoError(t, owner.Unlock())
+	reader := NewTrackedLock(lockPath)
+	require.NoError(t, reader.RLock())
+	ReleaseTrackedLock(lockPath, reader)
+	_, ok = readOwner(lockPath)
+	assert.True(t, ok, "releasing a shared lock must keep the owner record")
+}
+
+func TestIsToolHiveOwnerAlive(t *testing.T) {
+	t.Parallel()
+
+	// The test binary is running but is not a ToolHive process, which is what a
+	// reused PID looks like
+	assert.False(t, isToolHiveOwnerAlive(os.Getpid()))
+
+	cmd := exec.Command("go", "version")
+	require.NoError(t, cmd.Run())
+	assert.False(t, isToolHiveOwnerAlive(cmd.Process.Pid), "exited process should not be alive")
+}
+
+func TestFindStaleLocks_DoesNotRemove(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	stalePath := filepath.Join(tempDir, "stale.lock")
+	freshPath := filepath.Join(tempDir, "fresh.lock")
+	require.NoError(t, os.WriteFile(stalePath, nil, 0600))
+	require.NoError(t, os.WriteFile(freshPath, nil, 0600))
+	oldTime := time.Now().Add(-10 * time.Minute)
+	require.NoError(t, os.Chtimes(stalePath, oldTime, oldTime))
+
+	assert.Equal(t, []string{stalePath}, FindStaleLocks([]string{tempDir}, 5*time.Minute))
+	assert.FileExists(t, stalePath)
+	assert.FileExists(t, freshPath)
+}
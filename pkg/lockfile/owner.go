@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lockfile
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/process"
+)
+
+// ownerCheckFunc reports whether the process with the given PID is a live
+// ToolHive process that may still be using a lock.
+type ownerCheckFunc func(pid int) bool
+
+// ownerSuffix is appended to a lock path to name the file recording its owner.
+// The PID is kept out of the lock file itself because on Windows the lock is a
+// byte-range lock that stops every other handle, including one opened by the
+// holder, from writing to the file.
+const ownerSuffix = ".owner"
+
+// ownerPath returns the path of the file recording the owner of lockPath.
+func ownerPath(lockPath string) string {
+	return lockPath + ownerSuffix
+}
+
+// RecordOwner writes the PID of the current process next to the lock file at
+// lockPath, so that CleanupStaleLocks can tell whether its holder is still
+// running. It must only be called while holding an exclusive lock on the file,
+// and the record is removed with the lock by ReleaseTrackedLock. Recording is
+// best effort: a lock without an owner falls back to the age check.
+func RecordOwner(lockPath string) {
+	// #nosec G306: the owner file only holds a PID
+	if err := os.WriteFile(ownerPath(lockPath), []byte(strconv.Itoa(os.Getpid())+"\n"), 0600); err != nil {
+		slog.Debug("failed to record lock owner", "path", lockPath, "error", err)
+	}
+}
+
+// removeOwner removes the owner record of the lock file at lockPath, if any.
+func removeOwner(lockPath string) {
+	if err := os.Remove(ownerPath(lockPath)); err != nil && !os.IsNotExist(err) {
+		slog.Warn("failed to remove lock owner file", "path", lockPath, "error", err)
+	}
+}
+
+// readOwner returns the PID recorded for the lock file at lockPath, and false if
+// no valid PID is recorded, e.g. because the lock was taken by an older release.
+func readOwner(lockPath string) (int, bool) {
+	// #nosec G304: the path comes from globbing the lock directories
+	data, err := os.ReadFile(ownerPath(lockPath))
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
+
+// isToolHiveOwnerAlive reports whether pid is a running ToolHive process. A PID
+// that now belongs to another program means the owner exited and its PID was
+// reused. If liveness cannot be determined the owner is assumed to be alive, so
+// that a lock in use is never removed.
+func isToolHiveOwnerAlive(pid int) bool {
+	alive, err := process.FindProcess(pid)
+	if err != nil {
+		return true
+	}
+	if !alive {
+		return false
+	}
+	isToolHive, err := process.IsToolHiveProxyForWorkload(pid, "")
+	return err == nil && isToolHive
+}
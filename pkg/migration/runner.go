@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/lockfile"
+)
+
+// stateLockTimeout is how long Run waits for another process running the
+// migrations to finish before giving up for this invocation.
+const stateLockTimeout = 30 * time.Second
+
+// ErrDeferred is returned by a step that cannot run yet, e.g. because the
+// feature it migrates has not been set up. The step is not recorded, so it is
+// attempted again on the next run, and the following steps still run.
+var ErrDeferred = errors.New("migration deferred")
+
+// Step is a single versioned migration.
+type Step struct {
+	// Version orders the steps. Versions are unique and positive; a released
+	// step must never be renumbered or removed.
+	Version int
+	// Name identifies the step in logs and in the state file.
+	Name string
+	// Applied optionally reports whether the step's effect is already in
+	// place without it having been recorded, e.g. because an older ToolHive
+	// release ran it and tracked that in its own way. Such steps are
+	// recorded without running.
+	Applied func(ctx context.Context) (bool, error)
+	// Run performs the step. It must be idempotent: a step interrupted
+	// before it is recorded runs again on the next start.
+	Run func(ctx context.Context) error
+}
+
+// AppliedStep is the record of a completed step in the state file.
+type AppliedStep struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// State is the content of the migration-state file.
+type State struct {
+	Applied []AppliedStep `json:"applied"`
+}
+
+// isApplied reports whether the step with the given version was recorded.
+func (s *State) isApplied(version int) bool {
+	return slices.ContainsFunc(s.Applied, func(step AppliedStep) bool {
+		return step.Version == version
+	})
+}
+
+// Runner applies steps in version order and records each completed step in a
+// state file, so that every step runs once per installation.
+type Runner struct {
+	statePath string
+	steps     []Step
+	now       func() time.Time
+}
+
+// NewRunner creates a Runner recording its progress in statePath. It returns
+// an error if steps are malformed or share a version.
+func NewRunner(statePath string, steps ...Step) (*Runner, error) {
+	sorted := slices.Clone(steps)
+	slices.SortFunc(sorted, func(a, b Step) int { return a.Version - b.Version })
+
+	for i, step := range sorted {
+		if step.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive, got %d", step.Name, step.Version)
+		}
+		if step.Name == "" {
+			return nil, fmt.Errorf("migration %d: name must not be empty", step.Version)
+		}
+		if step.Run == nil {
+			return nil, fmt.Errorf("migration %d (%s): run function must not be nil", step.Version, step.Name)
+		}
+		if i > 0 && sorted[i-1].Version == step.Version {
+			return nil, fmt.Errorf("migrations %q and %q share version %d", sorted[i-1].Name, step.Name, step.Version)
+		}
+	}
+
+	return &Runner{statePath: statePath, steps: sorted, now: time.Now}, nil
+}
+
+// Run applies every step that has not been recorded yet, in version order.
+// A failing step stops the run, so that later steps can rely on earlier ones;
+// it is retried on the next run. Run holds a lock on the state file while it
+// works, and returns without doing anything if another process holds it for
+// longer than stateLockTimeout.
+func (r *Runner) Run(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(r.statePath), 0750); err != nil {
+		return fmt.Errorf("failed to create migration state directory: %w", err)
+	}
+
+	lockPath := r.statePath + ".lock"
+	fileLock := lockfile.NewTrackedLock(lockPath)
+	lockCtx, cancel := context.WithTimeout(ctx, stateLockTimeout)
+	defer cancel()
+	locked, err := fileLock.TryLockContext(lockCtx, 100*time.Millisecond)
+	if err != nil || !locked {
+		slog.Debug("migrations are being run by another process, skipping", "error", err)
+		return nil
+	}
+	defer lockfile.ReleaseTrackedLock(lockPath, fileLock)
+	lockfile.RecordOwner(lockPath)
+
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+
+	for _, step := range r.steps {
+		if state.isApplied(step.Version) {
+			slog.Debug("migration already applied, skipping", "version", step.Version, "name", step.Name)
+			continue
+		}
+
+		if step.Applied != nil {
+			applied, err := step.Applied(ctx)
+			if err != nil {
+				return fmt.Errorf("migration %d (%s): failed to check whether it was applied: %w",
+					step.Version, step.Name, err)
+			}
+			if applied {
+				slog.Debug("migration applied previously, recording it", "version", step.Version, "name", step.Name)
+				if err := r.record(state, step); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		slog.Debug("running migration", "version", step.Version, "name", step.Name)
+		if err := step.Run(ctx); err != nil {
+			if errors.Is(err, ErrDeferred) {
+				slog.Debug("migration deferred", "version", step.Version, "name", step.Name, "reason", err)
+				continue
+			}
+			return fmt.Errorf("migration %d (%s) failed: %w", step.Version, step.Name, err)
+		}
+		if err := r.record(state, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record marks step as applied and persists the state immediately, so that a
+// crash in a later step does not cause this one to run again.
+func (r *Runner) record(state *State, step Step) error {
+	state.Applied = append(state.Applied, AppliedStep{
+		Version:   step.Version,
+		Name:      step.Name,
+		AppliedAt: r.now().UTC(),
+	})
+	return r.saveState(state)
+}
+
+// loadState reads the state file, returning an empty state if it does not exist.
+func (r *Runner) loadState() (*State, error) {
+	data, err := os.ReadFile(r.statePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &State{}, nil
+		}
+		return nil, fmt.Errorf("failed to read migration state: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse migration state %s: %w", r.statePath, err)
+	}
+	return &state, nil
+}
+
+// saveState atomically replaces the state file.
+func (r *Runner) saveState(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode migration state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.statePath), filepath.Base(r.statePath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write migration state: %w", err)
+	}
+	defer func() {
+		// No-op once the rename succeeded
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write migration state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write migration state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.statePath); err != nil {
+		return fmt.Errorf("failed to write migration state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStep returns a step that appends its version to calls when run.
+func recordingStep(version int, calls *[]int, err error) Step {
+	return Step{
+		Version: version,
+		Name:    fmt.Sprintf("step-%d", version),
+		Run: func(context.Context) error {
+			*calls = append(*calls, version)
+			return err
+		},
+	}
+}
+
+func readState(t *testing.T, statePath string) State {
+	t.Helper()
+	data, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	var state State
+	require.NoError(t, json.Unmarshal(data, &state))
+	return state
+}
+
+func appliedVersions(state State) []int {
+	versions := make([]int, 0, len(state.Applied))
+	for _, step := range state.Applied {
+		versions = append(versions, step.Version)
+	}
+	return versions
+}
+
+func TestRunner_RunsStepsInOrderAndRecordsThem(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "toolhive", "migrations.json")
+	var calls []int
+	// Registered out of order on purpose
+	runner, err := NewRunner(statePath,
+		recordingStep(3, &calls, nil),
+		recordingStep(1, &calls, nil),
+		recordingStep(2, &calls, nil),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, runner.Run(t.Context()))
+	assert.Equal(t, []int{1, 2, 3}, calls)
+
+	state := readState(t, statePath)
+	assert.Equal(t, []int{1, 2, 3}, appliedVersions(state))
+	assert.Equal(t, "step-1", state.Applied[0].Name)
+	assert.False(t, state.Applied[0].AppliedAt.IsZero())
+
+	// A second run skips everything already recorded
+	require.NoError(t, runner.Run(t.Context()))
+	assert.Equal(t, []int{1, 2, 3}, calls)
+
+	// A new step added later is the only one to run
+	runner, err = NewRunner(statePath,
+		recordingStep(1, &calls, nil),
+		recordingStep(2, &calls, nil),
+		recordingStep(3, &calls, nil),
+		recordingStep(4, &calls, nil),
+	)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run(t.Context()))
+	assert.Equal(t, []int{1, 2, 3, 4}, calls)
+	assert.Equal(t, []int{1, 2, 3, 4}, appliedVersions(readState(t, statePath)))
+}
+
+func TestRunner_FailedStepStopsAndIsRetried(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "migrations.json")
+	var calls []int
+	failing := errors.New("boom")
+	runner, err := NewRunner(statePath,
+		recordingStep(1, &calls, nil),
+		recordingStep(2, &calls, failing),
+		recordingStep(3, &calls, nil),
+	)
+	require.NoError(t, err)
+
+	err = runner.Run(t.Context())
+	require.ErrorIs(t, err, failing)
+	assert.Contains(t, err.Error(), "migration 2 (step-2)")
+	assert.Equal(t, []int{1, 2}, calls, "steps after a failure must not run")
+	assert.Equal(t, []int{1}, appliedVersions(readState(t, statePath)))
+
+	runner, err = NewRunner(statePath,
+		recordingStep(1, &calls, nil),
+		recordingStep(2, &calls, nil),
+		recordingStep(3, &calls, nil),
+	)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run(t.Context()))
+	assert.Equal(t, []int{1, 2, 2, 3}, calls)
+	assert.Equal(t, []int{1, 2, 3}, appliedVersions(readState(t, statePath)))
+}
+
+func TestRunner_DeferredStepIsNotRecorded(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "migrations.json")
+	var calls []int
+	runner, err := NewRunner(statePath,
+		recordingStep(1, &calls, fmt.Errorf("%w: not set up", ErrDeferred)),
+		recordingStep(2, &calls, nil),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, runner.Run(t.Context()))
+	assert.Equal(t, []int{1, 2}, calls)
+	assert.Equal(t, []int{2}, appliedVersions(readState(t, statePath)))
+}
+
+func TestRunner_PreviouslyAppliedStepIsRecordedWithoutRunning(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "migrations.json")
+	var calls []int
+	legacy := recordingStep(1, &calls, nil)
+	legacy.Applied = func(context.Context) (bool, error) { return true, nil }
+	runner, err := NewRunner(statePath, legacy, recordingStep(2, &calls, nil))
+	require.NoError(t, err)
+
+	require.NoError(t, runner.Run(t.Context()))
+	assert.Equal(t, []int{2}, calls)
+	assert.Equal(t, []int{1, 2}, appliedVersions(readState(t, statePath)))
+}
+
+func TestNewRunner_InvalidSteps(t *testing.T) {
+	t.Parallel()
+
+	noop := func(context.Context) error { return nil }
+	tests := []struct {
+		name    string
+		steps   []Step
+		wantErr string
+	}{
+		{
+			name:    "duplicate version",
+			steps:   []Step{{Version: 1, Name: "a", Run: noop}, {Version: 1, Name: "b", Run: noop}},
+			wantErr: "share version 1",
+		},
+		{
+			name:    "non-positive version",
+			steps:   []Step{{Version: 0, Name: "a", Run: noop}},
+			wantErr: "version must be positive",
+		},
+		{
+			name:    "missing name",
+			steps:   []Step{{Version: 1, Run: noop}},
+			wantErr: "name must not be empty",
+		},
+		{
+			name:    "missing run function",
+			steps:   []Step{{Version: 1, Name: "a"}},
+			wantErr: "run function must not be nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewRunner(filepath.Join(t.TempDir(), "migrations.json"), tt.steps...)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSteps_AreValid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRunner(filepath.Join(t.TempDir(), "migrations.json"), Steps()...)
+	require.NoError(t, err)
+}
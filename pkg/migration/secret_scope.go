@@ -5,64 +5,45 @@ package migration
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
-	"sync"
 
 	"github.com/stacklok/toolhive/pkg/config"
 	"github.com/stacklok/toolhive/pkg/secrets"
 )
 
-var secretScopeMigrationOnce sync.Once
-
-// CheckAndPerformSecretScopeMigration checks if secret scope migration is needed and performs it.
-// It discovers bare system keys and renames them into their __thv_<scope>_ namespace.
-// This ensures system-owned secrets are hidden from user-facing secret commands.
-func CheckAndPerformSecretScopeMigration() {
-	secretScopeMigrationOnce.Do(func() {
-		appConfig := config.NewDefaultProvider().GetConfig()
-		if appConfig.SecretScopeMigration {
-			slog.Debug("secret scope migration already completed, skipping")
-			return
-		}
-
-		if !appConfig.Secrets.SetupCompleted {
-			slog.Debug("secrets not set up, skipping secret scope migration")
-			return
-		}
-
-		providerType, err := appConfig.Secrets.GetProviderType()
-		if err != nil {
-			slog.Error("failed to get secrets provider type for migration", "error", err)
-			return
-		}
-
-		provider, err := secrets.CreateSecretProvider(providerType)
-		if err != nil {
-			slog.Error("failed to create secrets provider for migration", "error", err)
-			return
-		}
-
-		migrations, err := secrets.DiscoverMigrations(context.Background(), provider)
-		if err != nil {
-			slog.Error("failed to discover secret migrations", "error", err)
-			return
-		}
-
-		if len(migrations) == 0 {
-			slog.Debug("no secret scope migrations needed")
-		} else {
-			slog.Debug("migrating system secrets to scoped namespace", "count", len(migrations))
-			if err := secrets.MigrateSystemKeys(context.Background(), provider, migrations); err != nil {
-				slog.Error("failed to migrate system secrets", "error", err)
-				return
-			}
-		}
-
-		if err := config.UpdateConfig(func(c *config.Config) error {
-			c.SecretScopeMigration = true
-			return nil
-		}); err != nil {
-			slog.Error("failed to update config after secret scope migration", "error", err)
-		}
-	})
+// performSecretScopeMigration discovers bare system keys and renames them into
+// their __thv_<scope>_ namespace, hiding system-owned secrets from user-facing
+// secret commands. It is deferred until secrets have been set up.
+func performSecretScopeMigration(ctx context.Context) error {
+	appConfig := config.NewDefaultProvider().GetConfig()
+	if !appConfig.Secrets.SetupCompleted {
+		return fmt.Errorf("%w: secrets not set up", ErrDeferred)
+	}
+
+	providerType, err := appConfig.Secrets.GetProviderType()
+	if err != nil {
+		return fmt.Errorf("failed to get secrets provider type: %w", err)
+	}
+
+	provider, err := secrets.CreateSecretProvider(providerType)
+	if err != nil {
+		return fmt.Errorf("failed to create secrets provider: %w", err)
+	}
+
+	migrations, err := secrets.DiscoverMigrations(ctx, provider)
+	if err != nil {
+		return fmt.Errorf("failed to discover secret migrations: %w", err)
+	}
+
+	if len(migrations) == 0 {
+		slog.Debug("no secret scope migrations needed")
+		return nil
+	}
+
+	slog.Debug("migrating system secrets to scoped namespace", "count", len(migrations))
+	if err := secrets.MigrateSystemKeys(ctx, provider, migrations); err != nil {
+		return fmt.Errorf("failed to migrate system secrets: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adrg/xdg"
+
+	"github.com/stacklok/toolhive/pkg/config"
+)
+
+// stateFile is the migration-state file of the CLI, relative to the XDG data directory.
+const stateFile = "toolhive/migrations.json"
+
+// Steps returns the migrations run by the CLI at startup. New migrations are
+// appended with the next version number.
+func Steps() []Step {
+	return []Step{
+		{
+			// Converts telemetry_config.samplingRate from float64 to string in run configs
+			Version: 1,
+			Name:    "telemetry-sampling-rate-string",
+			Applied: legacyConfigFlag(func(c *config.Config) bool { return c.TelemetryConfigMigration }),
+			Run:     performTelemetryConfigMigration,
+		},
+		{
+			// Repeats the previous step, which did not originally cover middleware telemetry configs
+			Version: 2,
+			Name:    "middleware-telemetry-sampling-rate-string",
+			Applied: legacyConfigFlag(func(c *config.Config) bool { return c.MiddlewareTelemetryMigration }),
+			Run:     performTelemetryConfigMigration,
+		},
+		{
+			// Renames bare system keys (BEARER_TOKEN_, REGISTRY_OAUTH_, etc.) to the __thv_<scope>_ namespace
+			Version: 3,
+			Name:    "secret-scope",
+			Applied: legacyConfigFlag(func(c *config.Config) bool { return c.SecretScopeMigration }),
+			Run:     performSecretScopeMigration,
+		},
+	}
+}
+
+// RunStartupMigrations applies the pending CLI migrations, recording them in
+// the migration-state file in the ToolHive data directory.
+func RunStartupMigrations(ctx context.Context) error {
+	statePath, err := xdg.DataFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to get migration state path: %w", err)
+	}
+
+	runner, err := NewRunner(statePath, Steps()...)
+	if err != nil {
+		return err
+	}
+	return runner.Run(ctx)
+}
+
+// legacyConfigFlag reports a step as applied when releases predating the
+// migration-state file recorded it with a flag in config.yaml.
+func legacyConfigFlag(flag func(*config.Config) bool) func(context.Context) (bool, error) {
+	return func(context.Context) (bool, error) {
+		return flag(config.NewDefaultProvider().GetConfig()), nil
+	}
+}
@@ -10,47 +10,13 @@ import (
 	"io"
 	"log/slog"
 	"strconv"
-	"sync"
 
-	"github.com/stacklok/toolhive/pkg/config"
 	"github.com/stacklok/toolhive/pkg/state"
 )
 
-// telemetryMigrationOnce ensures the telemetry migration only runs once
-var telemetryMigrationOnce sync.Once
-
-// CheckAndPerformTelemetryConfigMigration checks if telemetry config migration is needed and performs it.
-// This migration converts telemetry_config.samplingRate from float64 to string in run configs.
-// It handles both deprecated top-level telemetry_config and middleware-based telemetry configs.
-func CheckAndPerformTelemetryConfigMigration() {
-	telemetryMigrationOnce.Do(func() {
-		// Check if migration was already performed
-		appConfig := config.NewDefaultProvider().GetConfig()
-		if appConfig.TelemetryConfigMigration {
-			slog.Debug("telemetry config migration already completed, skipping")
-			return
-		}
-
-		if err := performTelemetryConfigMigration(); err != nil {
-			slog.Error("failed to perform telemetry config migration", "error", err)
-			return
-		}
-
-		// Mark migration as completed
-		if err := config.UpdateConfig(func(c *config.Config) error {
-			c.TelemetryConfigMigration = true
-			return nil
-		}); err != nil {
-			slog.Error("failed to update config after telemetry config migration", "error", err)
-		}
-	})
-}
-
 // performTelemetryConfigMigration migrates all run configs with float64 samplingRate to string.
 // It handles both deprecated top-level telemetry_config and middleware-based telemetry configs.
-func performTelemetryConfigMigration() error {
-	ctx := context.Background()
-
+func performTelemetryConfigMigration(ctx context.Context) error {
 	// Get all run config names
 	store, err := state.NewRunConfigStore(state.DefaultAppName)
 	if err != nil {
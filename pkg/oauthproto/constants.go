@@ -49,6 +49,19 @@ const (
 	// TokenEndpointAuthMethodClientSecretPost sends the client credentials in the
 	// request body at the token endpoint (RFC 6749 Section 2.3.1).
 	TokenEndpointAuthMethodClientSecretPost = "client_secret_post"
+
+	// TokenEndpointAuthMethodPrivateKeyJWT authenticates the client with a JWT
+	// signed by its private key (RFC 7523 Section 2.2, OpenID Connect Core Section 9).
+	TokenEndpointAuthMethodPrivateKeyJWT = "private_key_jwt"
+)
+
+// Client assertion types as defined by RFC 7523.
+//
+//nolint:gosec // G101: this is an RFC 7523 assertion-type URN identifier, not a credential
+const (
+	// ClientAssertionTypeJWTBearer is the client_assertion_type value for JWT
+	// client authentication (RFC 7523 Section 2.2).
+	ClientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
 )
 
 // PKCE (Proof Key for Code Exchange) methods as defined by RFC 7636.
@@ -339,6 +339,9 @@ type AuthorizationServerMetadata struct {
 	// RegistrationEndpoint is the URL of the Dynamic Client Registration endpoint (OPTIONAL).
 	RegistrationEndpoint string `json:"registration_endpoint,omitempty"`
 
+	// DeviceAuthorizationEndpoint is the URL of the device authorization endpoint (OPTIONAL, RFC 8628).
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+
 	// IntrospectionEndpoint is the URL of the token introspection endpoint (OPTIONAL, RFC 7662).
 	IntrospectionEndpoint string `json:"introspection_endpoint,omitempty"`
 
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenexchange
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// clientAssertionLifetime is how long a client assertion remains valid.
+	// Assertions are minted per exchange, so a short lifetime is enough.
+	clientAssertionLifetime = 5 * time.Minute
+
+	// minClientAssertionRSAKeyBits is the minimum accepted RSA key size.
+	minClientAssertionRSAKeyBits = 2048
+)
+
+// ClientAssertionKey is a private key used to authenticate the client at the
+// token endpoint with a signed JWT (private_key_jwt, RFC 7523 Section 2.2)
+// instead of a client secret.
+type ClientAssertionKey struct {
+	signer crypto.Signer
+	method jwt.SigningMethod
+	keyID  string
+}
+
+// ParseClientAssertionKey parses a PEM-encoded RSA or ECDSA private key
+// (PKCS#1, SEC 1, or PKCS#8) for client assertion signing. The signing
+// algorithm is derived from the key: RS256 for RSA keys, and ES256, ES384, or
+// ES512 for P-256, P-384, or P-521 keys. keyID is optional; when set it is sent
+// as the "kid" header so the authorization server can select the matching
+// public key.
+func ParseClientAssertionKey(pemData []byte, keyID string) (*ClientAssertionKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block from client assertion key")
+	}
+
+	signer, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := signingMethodForKey(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientAssertionKey{signer: signer, method: method, keyID: keyID}, nil
+}
+
+// Public returns the public half of the key.
+func (k *ClientAssertionKey) Public() crypto.PublicKey {
+	return k.signer.Public()
+}
+
+// sign creates a client assertion identifying clientID to the token endpoint
+// at audience, per RFC 7523 Section 3.
+func (k *ClientAssertionKey) sign(clientID, audience string) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  jwt.ClaimStrings{audience},
+		ID:        rand.Text(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(clientAssertionLifetime)),
+	}
+
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.keyID != "" {
+		token.Header["kid"] = k.keyID
+	}
+
+	assertion, err := token.SignedString(k.signer)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign client assertion: %w", err)
+	}
+	return assertion, nil
+}
+
+// parsePrivateKey parses a DER-encoded RSA or ECDSA private key.
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if rsaKey, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return rsaKey, nil
+	}
+	if ecKey, err := x509.ParseECPrivateKey(der); err == nil {
+		return ecKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client assertion key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported client assertion key type: %T", key)
+	}
+	return signer, nil
+}
+
+// signingMethodForKey returns the JWT signing method for the key.
+func signingMethodForKey(key crypto.Signer) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if bits := k.N.BitLen(); bits < minClientAssertionRSAKeyBits {
+			return nil, fmt.Errorf("RSA key size %d bits is below minimum required %d bits",
+				bits, minClientAssertionRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		default:
+			return nil, fmt.Errorf("unsupported EC curve: %s", k.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported client assertion key type: %T", key)
+	}
+}
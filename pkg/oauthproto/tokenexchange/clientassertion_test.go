@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenexchange
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/oauthproto"
+	"github.com/stacklok/toolhive/pkg/oauthproto/oauthtest"
+)
+
+func encodePEM(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func generateRSAKeyPEM(t *testing.T, bits int) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+}
+
+func generateECKeyPEM(t *testing.T, curve elliptic.Curve) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return encodePEM("EC PRIVATE KEY", der)
+}
+
+func TestParseClientAssertionKey(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8RSA, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pkcs8Ed, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		pemData    []byte
+		wantAlg    string
+		wantErrMsg string
+	}{
+		{
+			name:    "RSA PKCS1",
+			pemData: encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+			wantAlg: "RS256",
+		},
+		{
+			name:    "RSA PKCS8",
+			pemData: encodePEM("PRIVATE KEY", pkcs8RSA),
+			wantAlg: "RS256",
+		},
+		{
+			name:    "EC P-256",
+			pemData: generateECKeyPEM(t, elliptic.P256()),
+			wantAlg: "ES256",
+		},
+		{
+			name:    "EC P-384",
+			pemData: generateECKeyPEM(t, elliptic.P384()),
+			wantAlg: "ES384",
+		},
+		{
+			name:       "RSA key too small",
+			pemData:    generateRSAKeyPEM(t, 1024),
+			wantErrMsg: "below minimum required",
+		},
+		{
+			name:       "unsupported key type",
+			pemData:    encodePEM("PRIVATE KEY", pkcs8Ed),
+			wantErrMsg: "unsupported client assertion key type",
+		},
+		{
+			name:       "not PEM",
+			pemData:    []byte("not a key"),
+			wantErrMsg: "failed to decode PEM block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := ParseClientAssertionKey(tt.pemData, "")
+			if tt.wantErrMsg != "" {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlg, key.method.Alg())
+		})
+	}
+}
+
+// TestTokenSource_Token_ClientAssertion verifies that a client assertion key
+// replaces Basic Auth with a signed RFC 7523 assertion in the request body.
+func TestTokenSource_Token_ClientAssertion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pemData []byte
+		wantAlg string
+	}{
+		{name: "RSA key", pemData: generateRSAKeyPEM(t, 2048), wantAlg: "RS256"},
+		{name: "EC key", pemData: generateECKeyPEM(t, elliptic.P256()), wantAlg: "ES256"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := ParseClientAssertionKey(tt.pemData, "test-key-id")
+			require.NoError(t, err)
+
+			var tokenURL string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _, hasBasicAuth := r.BasicAuth()
+				assert.False(t, hasBasicAuth, "Basic Auth must not be used with a client assertion")
+
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, oauthproto.GrantTypeTokenExchange, r.Form.Get("grant_type"))
+				assert.Equal(t, testSubjectToken, r.Form.Get("subject_token"))
+				assert.Equal(t, "test-client-id", r.Form.Get("client_id"))
+				assert.Equal(t, oauthproto.ClientAssertionTypeJWTBearer, r.Form.Get("client_assertion_type"))
+				assert.Empty(t, r.Form.Get("client_secret"))
+
+				// The assertion must verify against the client's public key.
+				claims := &jwt.RegisteredClaims{}
+				token, err := jwt.ParseWithClaims(r.Form.Get("client_assertion"), claims,
+					func(*jwt.Token) (any, error) { return key.Public(), nil },
+					jwt.WithValidMethods([]string{tt.wantAlg}),
+					jwt.WithAudience(tokenURL),
+					jwt.WithIssuer("test-client-id"),
+					jwt.WithSubject("test-client-id"),
+					jwt.WithExpirationRequired(),
+					jwt.WithIssuedAt(),
+				)
+				require.NoError(t, err)
+				assert.Equal(t, "test-key-id", token.Header["kid"])
+				assert.NotEmpty(t, claims.ID, "assertion must carry a jti")
+				assert.WithinDuration(t, time.Now().Add(clientAssertionLifetime), claims.ExpiresAt.Time, 5*time.Second)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(oauthtest.NewResponse().WithAccessToken("exchanged-access-token").Build())
+			}))
+			t.Cleanup(server.Close)
+			tokenURL = server.URL
+
+			config := &ExchangeConfig{
+				TokenURL:           server.URL,
+				ClientID:           "test-client-id",
+				ClientAssertionKey: key,
+				SubjectTokenProvider: func() (string, error) {
+					return testSubjectToken, nil
+				},
+			}
+
+			token, err := config.TokenSource(t.Context()).Token()
+			require.NoError(t, err)
+			assert.Equal(t, "exchanged-access-token", token.AccessToken)
+		})
+	}
+}
+
+func TestExchangeConfig_Validate_ClientAssertionKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := ParseClientAssertionKey(generateECKeyPEM(t, elliptic.P256()), "")
+	require.NoError(t, err)
+	subjectTokenProvider := func() (string, error) { return testSubjectToken, nil }
+
+	tests := []struct {
+		name       string
+		config     ExchangeConfig
+		wantErrMsg string
+	}{
+		{
+			name: "valid",
+			config: ExchangeConfig{
+				TokenURL:             "https://sts.example.com/token",
+				ClientID:             "client",
+				ClientAssertionKey:   key,
+				SubjectTokenProvider: subjectTokenProvider,
+			},
+		},
+		{
+			name: "missing client ID",
+			config: ExchangeConfig{
+				TokenURL:             "https://sts.example.com/token",
+				ClientAssertionKey:   key,
+				SubjectTokenProvider: subjectTokenProvider,
+			},
+			wantErrMsg: "ClientID is required",
+		},
+		{
+			name: "client secret also set",
+			config: ExchangeConfig{
+				TokenURL:             "https://sts.example.com/token",
+				ClientID:             "client",
+				ClientSecret:         "secret",
+				ClientAssertionKey:   key,
+				SubjectTokenProvider: subjectTokenProvider,
+			},
+			wantErrMsg: "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.config.Validate()
+			if tt.wantErrMsg != "" {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -72,9 +72,12 @@ type exchangeRequest struct {
 }
 
 // clientAuthentication represents OAuth client credentials for token exchange.
+// When AssertionKey is set, the client authenticates with a signed JWT
+// (private_key_jwt) instead of a client secret.
 type clientAuthentication struct {
 	ClientID     string
 	ClientSecret string
+	AssertionKey *ClientAssertionKey
 }
 
 // ExchangeConfig holds the configuration for token exchange.
@@ -88,6 +91,10 @@ type ExchangeConfig struct {
 	// ClientSecret is the OAuth 2.0 client secret
 	ClientSecret string
 
+	// ClientAssertionKey authenticates the client with a signed JWT assertion
+	// (private_key_jwt, RFC 7523) instead of ClientSecret. Requires ClientID.
+	ClientAssertionKey *ClientAssertionKey
+
 	// Audience is the target audience for the exchanged token (optional per RFC 8693)
 	Audience string
 
@@ -141,6 +148,15 @@ func (c *ExchangeConfig) Validate() error {
 	// don't require client credentials and rely on the trust relationship
 	// configured in the identity provider (e.g., Workload Identity Federation)
 
+	if c.ClientAssertionKey != nil {
+		if c.ClientID == "" {
+			return fmt.Errorf("ClientID is required when using a client assertion key")
+		}
+		if c.ClientSecret != "" {
+			return fmt.Errorf("ClientSecret and ClientAssertionKey are mutually exclusive")
+		}
+	}
+
 	// Validate and normalize SubjectTokenType if provided
 	if c.SubjectTokenType != "" {
 		normalized, err := NormalizeTokenType(c.SubjectTokenType)
@@ -221,6 +237,7 @@ func (ts *tokenSource) Token() (*oauth2.Token, error) {
 	clientAuth := clientAuthentication{
 		ClientID:     conf.ClientID,
 		ClientSecret: conf.ClientSecret,
+		AssertionKey: conf.ClientAssertionKey,
 	}
 
 	// Perform the exchange
@@ -271,7 +288,21 @@ func exchangeToken(
 		return nil, err
 	}
 
-	req, err := oauthproto.NewFormRequest(ctx, endpoint, data, auth.ClientID, auth.ClientSecret)
+	clientSecret := auth.ClientSecret
+	if auth.AssertionKey != nil {
+		// RFC 7523 Section 2.2: the assertion replaces the client secret and
+		// is sent in the request body together with the client_id.
+		assertion, err := auth.AssertionKey.sign(auth.ClientID, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("tokenexchange: %w", err)
+		}
+		data.Set("client_id", auth.ClientID)
+		data.Set("client_assertion_type", oauthproto.ClientAssertionTypeJWTBearer)
+		data.Set("client_assertion", assertion)
+		clientSecret = ""
+	}
+
+	req, err := oauthproto.NewFormRequest(ctx, endpoint, data, auth.ClientID, clientSecret)
 	if err != nil {
 		return nil, fmt.Errorf("tokenexchange: build request: %w", err)
 	}
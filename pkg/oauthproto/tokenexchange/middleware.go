@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/oauth2"
@@ -38,6 +39,11 @@ const (
 	// This corresponds to the "client_secret" field in the token exchange configuration
 	//nolint:gosec // G101: this is an environment variable name, not a credential value
 	EnvClientSecret = "TOOLHIVE_TOKEN_EXCHANGE_CLIENT_SECRET"
+
+	// EnvClientAssertionKey is the environment variable name for the PEM-encoded
+	// private key used to sign client assertions (private_key_jwt)
+	//nolint:gosec // G101: this is an environment variable name, not a credential value
+	EnvClientAssertionKey = "TOOLHIVE_TOKEN_EXCHANGE_CLIENT_ASSERTION_KEY"
 )
 
 var errUnknownStrategy = errors.New("unknown token injection strategy")
@@ -65,6 +71,15 @@ type Config struct {
 	// ClientSecret is the OAuth 2.0 client secret
 	ClientSecret string `json:"client_secret"`
 
+	// ClientAssertionKeyFile is the path to a PEM-encoded private key used to
+	// authenticate the client with a signed JWT (private_key_jwt, RFC 7523)
+	// instead of a client secret. If empty, the key is read from the
+	// EnvClientAssertionKey environment variable when set.
+	ClientAssertionKeyFile string `json:"client_assertion_key_file,omitempty"`
+
+	// ClientAssertionKeyID is the key ID sent in the "kid" header of client assertions
+	ClientAssertionKeyID string `json:"client_assertion_key_id,omitempty"`
+
 	// Audience is the target audience for the exchanged token
 	Audience string `json:"audience"`
 
@@ -286,14 +301,28 @@ func createTokenExchangeMiddleware(
 		}
 	}
 
+	assertionKey, err := resolveClientAssertionKey(config, getEnv)
+	if err != nil {
+		return nil, err
+	}
+	if assertionKey != nil {
+		if clientSecret != "" {
+			return nil, errors.New("client secret and client assertion key are mutually exclusive")
+		}
+		if config.ClientID == "" {
+			return nil, errors.New("client_id is required when using a client assertion key")
+		}
+	}
+
 	// Create base exchange config at startup time with all static fields
 	baseExchangeConfig := ExchangeConfig{
-		TokenURL:         config.TokenURL,
-		ClientID:         config.ClientID,
-		ClientSecret:     clientSecret,
-		Audience:         config.Audience,
-		Scopes:           config.Scopes,
-		SubjectTokenType: config.SubjectTokenType,
+		TokenURL:           config.TokenURL,
+		ClientID:           config.ClientID,
+		ClientSecret:       clientSecret,
+		ClientAssertionKey: assertionKey,
+		Audience:           config.Audience,
+		Scopes:             config.Scopes,
+		SubjectTokenType:   config.SubjectTokenType,
 		// SubjectTokenProvider will be set per request
 	}
 
@@ -363,3 +392,25 @@ func createTokenExchangeMiddleware(
 		})
 	}, nil
 }
+
+// resolveClientAssertionKey loads the client assertion key from the configured
+// file or, failing that, from the EnvClientAssertionKey environment variable.
+// It returns nil when neither is set.
+func resolveClientAssertionKey(config Config, getEnv envGetter) (*ClientAssertionKey, error) {
+	var pemData []byte
+	if config.ClientAssertionKeyFile != "" {
+		keyPath := filepath.Clean(config.ClientAssertionKeyFile)
+		data, err := os.ReadFile(keyPath) // #nosec G304 - path is provided by the operator or CLI user
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client assertion key: %w", err)
+		}
+		pemData = data
+	} else if envKey := getEnv(EnvClientAssertionKey); envKey != "" {
+		slog.Debug("Using client assertion key from environment variable")
+		pemData = []byte(envKey)
+	} else {
+		return nil, nil
+	}
+
+	return ParseClientAssertionKey(pemData, config.ClientAssertionKeyID)
+}
@@ -4,10 +4,13 @@
 package tokenexchange
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -900,3 +903,80 @@ func TestCreateMiddlewareFromTokenSource_NilTokenSource(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "tokenSource cannot be nil")
 }
+
+// TestCreateTokenExchangeMiddleware_ClientAssertionKey tests resolving the client assertion key.
+func TestCreateTokenExchangeMiddleware_ClientAssertionKey(t *testing.T) {
+	t.Parallel()
+
+	keyPEM := generateECKeyPEM(t, elliptic.P256())
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+
+	tests := []struct {
+		name       string
+		keyFile    string
+		envKey     string
+		envSecret  string
+		wantErrMsg string
+	}{
+		{name: "key from file", keyFile: keyFile},
+		{name: "key from environment variable", envKey: string(keyPEM)},
+		{
+			name:       "missing key file",
+			keyFile:    filepath.Join(t.TempDir(), "missing.pem"),
+			wantErrMsg: "failed to read client assertion key",
+		},
+		{name: "invalid key", envKey: "not a key", wantErrMsg: "failed to decode PEM block"},
+		{name: "client secret also set", keyFile: keyFile, envSecret: "secret", wantErrMsg: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var receivedAssertion string
+			exchangeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedAssertion = r.FormValue("client_assertion")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(oauthtest.NewResponse().WithAccessToken("exchanged-token").Build())
+			}))
+			defer exchangeServer.Close()
+
+			mockEnvGetter := func(key string) string {
+				switch key {
+				case EnvClientAssertionKey:
+					return tt.envKey
+				case EnvClientSecret:
+					return tt.envSecret
+				}
+				return ""
+			}
+
+			config := Config{
+				TokenURL:               exchangeServer.URL,
+				ClientID:               "test-client-id",
+				ClientAssertionKeyFile: tt.keyFile,
+			}
+
+			middleware, err := createTokenExchangeMiddleware(config, nil, mockEnvGetter)
+			if tt.wantErrMsg != "" {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer original-token")
+			identity := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "user123"}}
+			req = req.WithContext(auth.WithIdentity(req.Context(), identity))
+
+			rec := httptest.NewRecorder()
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.NotEmpty(t, receivedAssertion, "client assertion should be sent to the token endpoint")
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+)
+
+const (
+	operatorInstrumentationName = "github.com/stacklok/toolhive/pkg/operator/telemetry"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// reconcileDurationBuckets are the histogram boundaries, in seconds, for
+// reconcile durations. Most reconciles finish well under a second; the upper
+// buckets catch ones that wait on the API server or on a rollout.
+var reconcileDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// resourceKinds lists the custom resources counted by the resources gauge,
+// keyed by kind. Every kind is watched by one of the operator's controllers, so
+// counting them is served from the informer cache.
+var resourceKinds = map[string]func() client.ObjectList{
+	"EmbeddingServer":       func() client.ObjectList { return &mcpv1beta1.EmbeddingServerList{} },
+	"MCPAuthzConfig":        func() client.ObjectList { return &mcpv1beta1.MCPAuthzConfigList{} },
+	"MCPExternalAuthConfig": func() client.ObjectList { return &mcpv1beta1.MCPExternalAuthConfigList{} },
+	"MCPGroup":              func() client.ObjectList { return &mcpv1beta1.MCPGroupList{} },
+	"MCPOIDCConfig":         func() client.ObjectList { return &mcpv1beta1.MCPOIDCConfigList{} },
+	"MCPRegistry":           func() client.ObjectList { return &mcpv1beta1.MCPRegistryList{} },
+	"MCPRemoteProxy":        func() client.ObjectList { return &mcpv1beta1.MCPRemoteProxyList{} },
+	"MCPServer":             func() client.ObjectList { return &mcpv1beta1.MCPServerList{} },
+	"MCPServerEntry":        func() client.ObjectList { return &mcpv1beta1.MCPServerEntryList{} },
+	"MCPTelemetryConfig":    func() client.ObjectList { return &mcpv1beta1.MCPTelemetryConfigList{} },
+	"MCPToolConfig":         func() client.ObjectList { return &mcpv1beta1.MCPToolConfigList{} },
+	"MCPWebhookConfig":      func() client.ObjectList { return &mcpv1alpha1.MCPWebhookConfigList{} },
+	"VirtualMCPServer":      func() client.ObjectList { return &mcpv1beta1.VirtualMCPServerList{} },
+}
+
+// defaultMetrics is the Metrics used by InstrumentReconciler and Default.
+var defaultMetrics atomic.Pointer[Metrics]
+
+// Metrics records the operator's own metrics: how long reconciles take and how
+// often they fail per controller, MCPRegistry sync outcomes, and the number of
+// custom resources of each kind.
+//
+// Reconciles only run on the elected leader, so the reconcile and sync metrics
+// are only ever recorded by one replica. The resource counts would be reported
+// by every replica, since each one has a full informer cache, so they are only
+// registered while this instance is the leader; see RegisterResourceCounts.
+//
+// All methods are no-ops on a nil *Metrics.
+type Metrics struct {
+	meter metric.Meter
+
+	reconcileDuration metric.Float64Histogram
+	reconcileErrors   metric.Int64Counter
+	registrySyncs     metric.Int64Counter
+	resources         metric.Int64ObservableGauge
+
+	mu           sync.Mutex
+	registration metric.Registration
+}
+
+// NewMeterProvider returns a meter provider that exports to registerer, which
+// is normally the controller-runtime registry served on the operator's metrics
+// endpoint.
+func NewMeterProvider(registerer prometheus.Registerer) (*sdkmetric.MeterProvider, error) {
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registerer))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+	}
+	return sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter)), nil
+}
+
+// NewMetrics creates the operator metrics on meterProvider. It returns nil if
+// meterProvider is nil.
+func NewMetrics(meterProvider metric.MeterProvider) (*Metrics, error) {
+	if meterProvider == nil {
+		return nil, nil
+	}
+
+	meter := meterProvider.Meter(operatorInstrumentationName)
+
+	reconcileDuration, err := meter.Float64Histogram(
+		"toolhive_operator_reconcile_duration",
+		metric.WithDescription("Duration of reconciles in seconds, by controller and result"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(reconcileDurationBuckets...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create reconcile duration histogram: %w", err)
+	}
+
+	reconcileErrors, err := meter.Int64Counter(
+		"toolhive_operator_reconcile_errors",
+		metric.WithDescription("Total number of reconciles that returned an error, by controller"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create reconcile error counter: %w", err)
+	}
+
+	registrySyncs, err := meter.Int64Counter(
+		"toolhive_operator_registry_syncs",
+		metric.WithDescription("Total number of MCPRegistry syncs, by namespace and result"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create registry sync counter: %w", err)
+	}
+
+	resources, err := meter.Int64ObservableGauge(
+		"toolhive_operator_resources",
+		metric.WithDescription("Number of ToolHive custom resources, by kind"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create resources gauge: %w", err)
+	}
+
+	return &Metrics{
+		meter:             meter,
+		reconcileDuration: reconcileDuration,
+		reconcileErrors:   reconcileErrors,
+		registrySyncs:     registrySyncs,
+		resources:         resources,
+	}, nil
+}
+
+// SetDefault makes m the Metrics used by InstrumentReconciler and Default.
+func SetDefault(m *Metrics) {
+	defaultMetrics.Store(m)
+}
+
+// Default returns the Metrics set with SetDefault, or nil if none was set.
+func Default() *Metrics {
+	return defaultMetrics.Load()
+}
+
+// InstrumentReconciler wraps r so that every reconcile is recorded in the
+// Default metrics under the given controller name. The metrics are looked up
+// on every reconcile, so controllers can be set up before SetDefault is called.
+func InstrumentReconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &instrumentedReconciler{controller: controller, next: r, metrics: Default}
+}
+
+// InstrumentReconciler wraps r so that every reconcile is recorded in m under
+// the given controller name.
+func (m *Metrics) InstrumentReconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &instrumentedReconciler{controller: controller, next: r, metrics: func() *Metrics { return m }}
+}
+
+// instrumentedReconciler records the duration and outcome of every reconcile.
+type instrumentedReconciler struct {
+	controller string
+	next       reconcile.Reconciler
+	metrics    func() *Metrics
+}
+
+// Reconcile implements reconcile.Reconciler
+func (r *instrumentedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	start := time.Now()
+	result, err := r.next.Reconcile(ctx, req)
+	r.metrics().RecordReconcile(ctx, r.controller, time.Since(start), err)
+	return result, err
+}
+
+// RecordReconcile records a reconcile of the given controller that took
+// duration and returned err.
+func (m *Metrics) RecordReconcile(ctx context.Context, controller string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+		m.reconcileErrors.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("controller", controller),
+		))
+	}
+	m.reconcileDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("controller", controller),
+		attribute.String("result", result),
+	))
+}
+
+// RecordRegistrySync records an MCPRegistry sync in namespace that returned err.
+func (m *Metrics) RecordRegistrySync(ctx context.Context, namespace string, err error) {
+	if m == nil {
+		return
+	}
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	m.registrySyncs.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("result", result),
+	))
+}
+
+// RegisterResourceCounts starts reporting the number of custom resources of
+// each kind, listed through reader. It should only be called on the leader, and
+// UnregisterResourceCounts called when leadership is lost. Calling it again
+// while the counts are registered does nothing.
+func (m *Metrics) RegisterResourceCounts(reader client.Reader) error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.registration != nil {
+		return nil
+	}
+
+	registration, err := m.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		for kind, newList := range resourceKinds {
+			list := newList()
+			if err := reader.List(ctx, list); err != nil {
+				// Skip the kind rather than failing the whole collection, e.g.
+				// when an optional CRD is not installed.
+				log.FromContext(ctx).V(1).Info("Failed to count resources", "kind", kind, "error", err)
+				continue
+			}
+			o.ObserveInt64(m.resources, int64(meta.LenList(list)), metric.WithAttributes(
+				attribute.String("kind", kind),
+			))
+		}
+		return nil
+	}, m.resources)
+	if err != nil {
+		return fmt.Errorf("register resources callback: %w", err)
+	}
+	m.registration = registration
+	return nil
+}
+
+// UnregisterResourceCounts stops reporting the resource counts started by
+// RegisterResourceCounts.
+func (m *Metrics) UnregisterResourceCounts() error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.registration == nil {
+		return nil
+	}
+	err := m.registration.Unregister()
+	m.registration = nil
+	if err != nil {
+		return fmt.Errorf("unregister resources callback: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
+)
+
+func newTestMetrics(t *testing.T) (*Metrics, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	m, err := NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	return m, reader
+}
+
+// findMetric collects reader and returns the named metric, or nil if it was
+// not reported.
+func findMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) *metricdata.Metrics {
+	t.Helper()
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &collected))
+	for _, scopeMetrics := range collected.ScopeMetrics {
+		for i := range scopeMetrics.Metrics {
+			if scopeMetrics.Metrics[i].Name == name {
+				return &scopeMetrics.Metrics[i]
+			}
+		}
+	}
+	return nil
+}
+
+func requireMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) *metricdata.Metrics {
+	t.Helper()
+	m := findMetric(t, reader, name)
+	require.NotNil(t, m, "metric %s was not recorded", name)
+	return m
+}
+
+func attributeSet(attrs map[string]string) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return attribute.NewSet(kvs...)
+}
+
+func histogramCount(t *testing.T, m *metricdata.Metrics, attrs map[string]string) uint64 {
+	t.Helper()
+	histogram, ok := m.Data.(metricdata.Histogram[float64])
+	require.True(t, ok, "%s is not a float64 histogram", m.Name)
+	want := attributeSet(attrs)
+	for _, dp := range histogram.DataPoints {
+		if dp.Attributes.Equals(&want) {
+			return dp.Count
+		}
+	}
+	return 0
+}
+
+func int64Value(t *testing.T, m *metricdata.Metrics, attrs map[string]string) int64 {
+	t.Helper()
+	want := attributeSet(attrs)
+	var dataPoints []metricdata.DataPoint[int64]
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		dataPoints = data.DataPoints
+	case metricdata.Gauge[int64]:
+		dataPoints = data.DataPoints
+	default:
+		require.FailNow(t, "unexpected metric type", "%s is %T", m.Name, m.Data)
+	}
+	for _, dp := range dataPoints {
+		if dp.Attributes.Equals(&want) {
+			return dp.Value
+		}
+	}
+	return 0
+}
+
+func TestMetrics_InstrumentReconciler(t *testing.T) {
+	t.Parallel()
+
+	m, reader := newTestMetrics(t)
+	reconcileErr := errors.New("conflict")
+	calls := 0
+	r := m.InstrumentReconciler("mcpserver", reconcile.Func(
+		func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			calls++
+			if calls == 3 {
+				return reconcile.Result{}, reconcileErr
+			}
+			return reconcile.Result{RequeueAfter: time.Minute}, nil
+		}))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "fetch"}}
+	for range 2 {
+		result, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, result.RequeueAfter, "the wrapped result is passed through")
+	}
+	_, err := r.Reconcile(t.Context(), req)
+	assert.ErrorIs(t, err, reconcileErr)
+
+	duration := requireMetric(t, reader, "toolhive_operator_reconcile_duration")
+	assert.Equal(t, "s", duration.Unit)
+	assert.Equal(t, uint64(2), histogramCount(t, duration, map[string]string{
+		"controller": "mcpserver", "result": resultSuccess,
+	}))
+	assert.Equal(t, uint64(1), histogramCount(t, duration, map[string]string{
+		"controller": "mcpserver", "result": resultError,
+	}))
+
+	errorsMetric := requireMetric(t, reader, "toolhive_operator_reconcile_errors")
+	assert.Equal(t, int64(1), int64Value(t, errorsMetric, map[string]string{"controller": "mcpserver"}))
+}
+
+func TestMetrics_RecordRegistrySync(t *testing.T) {
+	t.Parallel()
+
+	m, reader := newTestMetrics(t)
+	m.RecordRegistrySync(t.Context(), "toolhive-system", nil)
+	m.RecordRegistrySync(t.Context(), "toolhive-system", nil)
+	m.RecordRegistrySync(t.Context(), "toolhive-system", errors.New("deployment not ready"))
+
+	syncs := requireMetric(t, reader, "toolhive_operator_registry_syncs")
+	assert.Equal(t, int64(2), int64Value(t, syncs, map[string]string{
+		"namespace": "toolhive-system", "result": resultSuccess,
+	}))
+	assert.Equal(t, int64(1), int64Value(t, syncs, map[string]string{
+		"namespace": "toolhive-system", "result": resultError,
+	}))
+}
+
+func TestMetrics_NilIsNoOp(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMetrics(nil)
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	m.RecordReconcile(t.Context(), "mcpserver", time.Second, nil)
+	m.RecordRegistrySync(t.Context(), "default", nil)
+	assert.NoError(t, m.RegisterResourceCounts(nil))
+	assert.NoError(t, m.UnregisterResourceCounts())
+
+	r := m.InstrumentReconciler("mcpserver", reconcile.Func(
+		func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, nil
+		}))
+	_, err = r.Reconcile(t.Context(), reconcile.Request{})
+	assert.NoError(t, err)
+}
+
+func TestLeaderTelemetryRunnable_ReportsResourceCountsWhileLeader(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, mcpv1beta1.AddToScheme(scheme))
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&mcpv1beta1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "fetch", Namespace: "default"}},
+			&mcpv1beta1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "team-a"}},
+			&mcpv1beta1.MCPGroup{ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "default"}},
+		).
+		Build()
+
+	m, reader := newTestMetrics(t)
+	runnable := &LeaderTelemetryRunnable{
+		TelemetryService: &Service{
+			client:        fakeClient,
+			versionClient: &mockVersionClient{version: "v1.0.0"},
+			namespace:     configMapNamespace,
+		},
+		Metrics: m,
+	}
+
+	assert.Nil(t, findMetric(t, reader, "toolhive_operator_resources"),
+		"resource counts must not be reported before this instance is elected")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	stopped := make(chan error, 1)
+	go func() { stopped <- runnable.Start(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return findMetric(t, reader, "toolhive_operator_resources") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	resources := requireMetric(t, reader, "toolhive_operator_resources")
+	assert.Equal(t, int64(2), int64Value(t, resources, map[string]string{"kind": "MCPServer"}))
+	assert.Equal(t, int64(1), int64Value(t, resources, map[string]string{"kind": "MCPGroup"}))
+	assert.Equal(t, int64(0), int64Value(t, resources, map[string]string{"kind": "VirtualMCPServer"}))
+
+	// Registering again, e.g. from a second runnable, must not report the
+	// counts twice.
+	require.NoError(t, m.RegisterResourceCounts(fakeClient))
+	resources = requireMetric(t, reader, "toolhive_operator_resources")
+	gauge, ok := resources.Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	assert.Len(t, gauge.DataPoints, len(resourceKinds))
+
+	cancel()
+	require.NoError(t, <-stopped)
+	assert.Nil(t, findMetric(t, reader, "toolhive_operator_resources"),
+		"resource counts must stop once leadership is lost")
+}
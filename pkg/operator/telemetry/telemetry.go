@@ -43,6 +43,9 @@ type Service struct {
 // LeaderTelemetryRunnable runs telemetry checks only when this instance is the leader
 type LeaderTelemetryRunnable struct {
 	TelemetryService *Service
+	// Metrics, when set, reports the resource counts while this instance is
+	// the leader, so that only one replica exports them.
+	Metrics *Metrics
 }
 
 // Start starts the telemetry runner
@@ -50,6 +53,15 @@ func (t *LeaderTelemetryRunnable) Start(ctx context.Context) error {
 	ctxLogger := log.FromContext(ctx)
 	ctxLogger.Info("Leader elected, starting telemetry worker")
 
+	if err := t.Metrics.RegisterResourceCounts(t.TelemetryService.client); err != nil {
+		ctxLogger.Error(err, "Failed to register resource count metrics")
+	}
+	defer func() {
+		if err := t.Metrics.UnregisterResourceCounts(); err != nil {
+			ctxLogger.Error(err, "Failed to unregister resource count metrics")
+		}
+	}()
+
 	// Start telemetry worker in a goroutine with the leader context
 	// When leadership is lost, ctx will be cancelled and telemetry will stop
 	go func() {
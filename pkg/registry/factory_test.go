@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -218,3 +219,48 @@ func TestResetDefaultProvider_AllowsReinit(t *testing.T) {
 
 	assert.NotSame(t, first, second, "after ResetDefaultProvider the next call must return a new instance")
 }
+
+// TestWatchRegistryFile_ReloadsProvider verifies that editing the configured
+// local registry file replaces the cached default provider with one serving the
+// new content.
+//
+//nolint:paralleltest // Mutates global config factory and provider state singletons
+func TestWatchRegistryFile_ReloadsProvider(t *testing.T) {
+	resetGlobalState(t)
+
+	dir := t.TempDir()
+	registryPath := writeTempRegistryJSON(t, dir, "before-edit")
+	configProvider := config.NewPathProvider(writeTempConfigYAML(t, dir, registryPath))
+	ResetDefaultProvider()
+
+	first, err := GetDefaultProviderWithConfig(configProvider)
+	require.NoError(t, err)
+
+	watcher, err := WatchRegistryFile(configProvider)
+	require.NoError(t, err)
+	require.NotNil(t, watcher)
+	t.Cleanup(func() { _ = watcher.Close() })
+
+	writeTempRegistryJSON(t, dir, "after-edit")
+
+	require.Eventually(t, func() bool {
+		provider, err := GetDefaultProviderWithConfig(configProvider)
+		if err != nil || provider == first {
+			return false
+		}
+		_, err = provider.GetServer("after-edit")
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond, "registry must be reloaded after the file changes")
+}
+
+// TestWatchRegistryFile_NoLocalFile verifies that nothing is watched when the
+// registry is not a local file.
+func TestWatchRegistryFile_NoLocalFile(t *testing.T) {
+	t.Parallel()
+
+	configProvider := config.NewPathProvider(filepath.Join(t.TempDir(), "config.yaml"))
+
+	watcher, err := WatchRegistryFile(configProvider)
+	require.NoError(t, err)
+	assert.Nil(t, watcher)
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"log/slog"
+
+	"github.com/stacklok/toolhive/pkg/config"
+)
+
+// WatchRegistryFile reloads the default registry provider in place whenever the
+// local registry file configured in configProvider changes. It returns nil
+// without error when no local registry file is configured. The returned watcher
+// must be closed by the caller.
+func WatchRegistryFile(configProvider config.Provider) (*config.FieldWatcher, error) {
+	if _, _, _, registryType := configProvider.GetRegistryConfig(); registryType != config.RegistryTypeFile {
+		return nil, nil
+	}
+
+	return config.WatchConfigField(configProvider, config.RegistryFileField, func(path string, _ []byte) {
+		slog.Info("registry file changed, reloading registry", "path", path)
+		ResetDefaultProvider()
+	})
+}
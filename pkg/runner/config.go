@@ -160,6 +160,11 @@ type RunConfig struct {
 	// AuthzConfigPath is the path to the authorization configuration file
 	AuthzConfigPath string `json:"authz_config_path,omitempty" yaml:"authz_config_path,omitempty"`
 
+	// AuthzPolicyPath is the path to an authorization configuration file whose
+	// Cedar policies and entities are enforced on top of AuthzConfig, and
+	// reloaded without a restart whenever the file changes
+	AuthzPolicyPath string `json:"authz_policy_path,omitempty" yaml:"authz_policy_path,omitempty"`
+
 	// DEPRECATED: Middleware configuration.
 	// AuditConfig contains the audit logging configuration
 	AuditConfig *audit.Config `json:"audit_config,omitempty" yaml:"audit_config,omitempty"`
@@ -264,6 +264,14 @@ func WithAuthzConfigPath(path string) RunConfigBuilderOption {
 	}
 }
 
+// WithAuthzPolicyPath sets the path of the hot-reloaded authorization policy file
+func WithAuthzPolicyPath(path string) RunConfigBuilderOption {
+	return func(b *runConfigBuilder) error {
+		b.config.AuthzPolicyPath = path
+		return nil
+	}
+}
+
 // WithAuthzConfig sets the authorization config data
 func WithAuthzConfig(config *authz.Config) RunConfigBuilderOption {
 	return func(b *runConfigBuilder) error {
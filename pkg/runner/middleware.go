@@ -215,6 +215,7 @@ func PopulateMiddlewareConfigs(config *RunConfig) error {
 		authzParams := authz.FactoryMiddlewareParams{
 			ConfigPath: config.AuthzConfigPath, // Keep for backwards compatibility
 			ConfigData: authzCfgData,           // Use the (possibly-enriched) config data
+			PolicyPath: config.AuthzPolicyPath,
 		}
 		authzConfig, err := types.NewMiddlewareConfig(authz.MiddlewareType, authzParams)
 		if err != nil {
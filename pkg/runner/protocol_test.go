@@ -285,6 +285,14 @@ func TestBuildFromProtocolSchemeWithNameDryRun(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			// A single quote would break out of the quoting of the UVX
+			// entrypoint script, so it is rejected before anything is built.
+			name:          "UVX with single-quoted buildArg is rejected",
+			serverOrImage: "uvx://example-package",
+			buildArgs:     []string{"--name", "it's"},
+			wantErr:       true,
+		},
 		{
 			name:          "NPX with buildArgs and invalid CA cert path",
 			serverOrImage: "npx://@launchdarkly/mcp-server",
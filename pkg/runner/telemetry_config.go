@@ -5,6 +5,7 @@ package runner
 
 import (
 	"log/slog"
+	"maps"
 	"strings"
 
 	appconfig "github.com/stacklok/toolhive/pkg/config"
@@ -23,10 +24,10 @@ const otelHeadersEnvVar = "OTEL_EXPORTER_OTLP_HEADERS"
 //
 // Both the "thv run" CLI and the "POST /api/v1/workloads" API path call this
 // so workloads created via either surface inherit the same telemetry
-// settings. Export headers come from two sources: the caller's header flags
-// (the CLI surfaces "--otel-headers"; the API passes none) and an
-// OTEL_EXPORTER_OTLP_HEADERS entry in the config's EnvVars. Flags take
-// precedence. customAttributes has no equivalent in the application config
+// settings. Export headers come from three sources, in increasing order of
+// precedence: an OTEL_EXPORTER_OTLP_HEADERS entry in the config's EnvVars, the
+// config's Headers and HeaderFiles, and the caller's header flags (the CLI surfaces
+// "--otel-headers"; the API passes none). customAttributes has no equivalent in the application config
 // and is passed through by the caller.
 //
 // Returns nil when no telemetry should be enabled: either no endpoint and no
@@ -63,7 +64,11 @@ func BuildTelemetryConfigFromAppConfig(
 		return nil
 	}
 
-	parsedHeaders := mergeTelemetryHeaders(headers, otel.EnvVars)
+	configHeaders, err := otel.ResolveHeaders()
+	if err != nil {
+		slog.Warn("Failed to resolve OTLP headers from files", "error", err)
+	}
+	parsedHeaders := mergeTelemetryHeaders(headers, configHeaders, otel.EnvVars)
 
 	var processedEnvVars []string
 	for _, entry := range otel.EnvVars {
@@ -93,19 +98,27 @@ func BuildTelemetryConfigFromAppConfig(
 		EnvironmentVariables:        processedEnvVars,
 		CustomAttributes:            customAttrs,
 		UseLegacyAttributes:         useLegacyAttributes,
+		CACertPath:                  otel.CACertPath,
 	}
 	cfg.SetSamplingRateFromFloat(otel.SamplingRate)
+	cfg.SetSamplingOverridesFromFloat(otel.SamplingOverrides)
+	if auth := otel.PrometheusBasicAuth; auth != nil {
+		cfg.PrometheusBasicAuth = &telemetry.PrometheusBasicAuth{
+			Username:     auth.Username,
+			PasswordFile: auth.PasswordFile,
+		}
+	}
 	return cfg
 }
 
-// mergeTelemetryHeaders builds the OTLP export header map from two sources:
-// an OTEL_EXPORTER_OTLP_HEADERS entry in the config's EnvVars (used when the
-// caller passes no header flags, such as the API path) and caller-supplied
-// header flags ("key=value", from the CLI's --otel-headers). Env headers are
-// applied first so flags override them on conflict. Values are split on the
+// mergeTelemetryHeaders builds the OTLP export header map from three sources:
+// an OTEL_EXPORTER_OTLP_HEADERS entry in the config's EnvVars, the config's
+// Headers and caller-supplied header flags ("key=value", from the CLI's
+// --otel-headers). They are applied in that order, so config headers override
+// env headers and flags override both on conflict. Values are split on the
 // first '=' only, so a value containing '=' (e.g. base64 padding) or spaces
 // (e.g. "Basic <token>") is preserved intact.
-func mergeTelemetryHeaders(flagHeaders, envVars []string) map[string]string {
+func mergeTelemetryHeaders(flagHeaders []string, configHeaders map[string]string, envVars []string) map[string]string {
 	headers := make(map[string]string)
 	for _, entry := range envVars {
 		name, value, ok := strings.Cut(entry, "=")
@@ -119,6 +132,8 @@ func mergeTelemetryHeaders(flagHeaders, envVars []string) map[string]string {
 		}
 	}
 
+	maps.Copy(headers, configHeaders)
+
 	for _, h := range flagHeaders {
 		if key, val, ok := strings.Cut(h, "="); ok {
 			headers[key] = val
@@ -4,12 +4,15 @@
 package runner
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	appconfig "github.com/stacklok/toolhive/pkg/config"
+	"github.com/stacklok/toolhive/pkg/telemetry"
 )
 
 func TestBuildTelemetryConfigFromAppConfig(t *testing.T) {
@@ -90,6 +93,13 @@ func TestBuildTelemetryConfigFromAppConfig_AppliesAllFields(t *testing.T) {
 		Insecure:                    true,
 		EnablePrometheusMetricsPath: true,
 		UseLegacyAttributes:         boolPtr(false),
+		Headers:                     map[string]string{"y": "2"},
+		CACertPath:                  "/etc/ssl/collector-ca.pem",
+		SamplingOverrides:           map[string]float64{"tools/call": 1, "ping": 0.01},
+		PrometheusBasicAuth: &appconfig.PrometheusBasicAuthConfig{
+			Username:     "prometheus",
+			PasswordFile: "/etc/toolhive/metrics-password",
+		},
 	}
 
 	cfg := BuildTelemetryConfigFromAppConfig(otel, "thv-osv", []string{"x=1"}, "")
@@ -103,7 +113,13 @@ func TestBuildTelemetryConfigFromAppConfig_AppliesAllFields(t *testing.T) {
 	assert.True(t, cfg.EnablePrometheusMetricsPath)
 	assert.False(t, cfg.UseLegacyAttributes)
 	assert.Equal(t, []string{"FOO", "BAR", "BAZ"}, cfg.EnvironmentVariables)
-	assert.Equal(t, map[string]string{"x": "1"}, cfg.Headers)
+	assert.Equal(t, map[string]string{"x": "1", "y": "2"}, cfg.Headers)
+	assert.Equal(t, "/etc/ssl/collector-ca.pem", cfg.CACertPath)
+	assert.Equal(t, map[string]string{"tools/call": "1", "ping": "0.01"}, cfg.SamplingOverrides)
+	assert.Equal(t, &telemetry.PrometheusBasicAuth{
+		Username:     "prometheus",
+		PasswordFile: "/etc/toolhive/metrics-password",
+	}, cfg.PrometheusBasicAuth)
 	// Sampling rate is stored as a string; just verify it was set from the float.
 	assert.NotEmpty(t, cfg.SamplingRate)
 }
@@ -153,6 +169,36 @@ func TestBuildTelemetryConfigFromAppConfig_OTLPHeadersFromEnvVars(t *testing.T)
 		assert.Equal(t, "fromflag", cfg.Headers["Authorization"], "explicit --otel-headers flag must take precedence")
 		assert.Equal(t, "prod", cfg.Headers["x-env"], "env-provided headers other than the overridden one must still apply")
 	})
+
+	t.Run("configured headers override env headers, flags override both", func(t *testing.T) {
+		t.Parallel()
+		otel := appconfig.OpenTelemetryConfig{
+			Endpoint: "https://otel.example.com",
+			EnvVars:  []string{"OTEL_EXPORTER_OTLP_HEADERS=Authorization=fromenv,x-env=prod,x-api-key=envkey"},
+			Headers:  map[string]string{"Authorization": "fromconfig", "x-api-key": "configkey"},
+		}
+		cfg := BuildTelemetryConfigFromAppConfig(otel, "thv-osv", []string{"x-api-key=flagkey"}, "")
+		require.NotNil(t, cfg)
+		assert.Equal(t, map[string]string{
+			"Authorization": "fromconfig",
+			"x-env":         "prod",
+			"x-api-key":     "flagkey",
+		}, cfg.Headers)
+	})
+
+	t.Run("header files are read into the exported headers", func(t *testing.T) {
+		t.Parallel()
+		keyFile := filepath.Join(t.TempDir(), "api-key")
+		require.NoError(t, os.WriteFile(keyFile, []byte("filekey\n"), 0600))
+		otel := appconfig.OpenTelemetryConfig{
+			Endpoint:    "https://otel.example.com",
+			Headers:     map[string]string{"x-dataset": "toolhive"},
+			HeaderFiles: map[string]string{"x-api-key": keyFile},
+		}
+		cfg := BuildTelemetryConfigFromAppConfig(otel, "thv-osv", nil, "")
+		require.NotNil(t, cfg)
+		assert.Equal(t, map[string]string{"x-dataset": "toolhive", "x-api-key": "filekey"}, cfg.Headers)
+	})
 }
 
 // TestBuildTelemetryConfigFromAppConfig_DefaultsForNilBools verifies the CLI-style
@@ -57,6 +57,9 @@ const (
 
 	// EnvironmentType represents the environment variable secret provider
 	EnvironmentType ProviderType = "environment"
+
+	// VaultType represents the HashiCorp Vault secret provider.
+	VaultType ProviderType = "vault"
 )
 
 // ErrUnknownManagerType is returned when an invalid value for ProviderType is specified.
@@ -109,6 +112,8 @@ func ValidateProviderWithPassword(ctx context.Context, providerType ProviderType
 		return validateOnePasswordProvider(ctx, provider, result)
 	case EnvironmentType:
 		return ValidateEnvironmentProvider(ctx, provider, result)
+	case VaultType:
+		return validateVaultProvider(ctx, provider, result)
 	default:
 		result.Error = fmt.Errorf("unknown provider type: %s", providerType)
 		result.Message = "Unknown provider type"
@@ -190,6 +195,23 @@ func validateOnePasswordProvider(ctx context.Context, provider Provider, result
 	return result
 }
 
+// validateVaultProvider tests Vault connectivity and access to the configured KV mount
+func validateVaultProvider(ctx context.Context, provider Provider, result *SetupResult) *SetupResult {
+	// Renewals started while validating are not needed once validation is done
+	defer func() { _ = provider.Cleanup() }()
+
+	_, err := provider.ListSecrets(ctx)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to connect to Vault: %w", err)
+		result.Message = "Failed to list secrets in Vault"
+		return result
+	}
+
+	result.Success = true
+	result.Message = "Vault provider validation successful"
+	return result
+}
+
 // ErrKeyringNotAvailable is returned when the OS keyring is not available for the encrypted provider.
 var ErrKeyringNotAvailable = httperr.WithCode(
 	errors.New("OS keyring is not available. "+
@@ -251,6 +273,8 @@ func CreateSecretProviderWithPassword(managerType ProviderType, password string)
 		}
 	case OnePasswordType:
 		primary, err = NewOnePasswordManager()
+	case VaultType:
+		primary, err = NewVaultManager()
 	case EnvironmentType:
 		// Direct environment provider - no fallback needed
 		return NewEnvironmentProvider(), nil
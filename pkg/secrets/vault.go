@@ -0,0 +1,778 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/networking"
+)
+
+const (
+	// VaultAddrEnvVar is the environment variable holding the Vault server address.
+	VaultAddrEnvVar = "VAULT_ADDR"
+	// VaultTokenEnvVar is the environment variable holding the Vault token used with token auth.
+	VaultTokenEnvVar = "VAULT_TOKEN" //nolint:gosec // G101: this is an environment variable name, not a credential
+	// VaultNamespaceEnvVar is the environment variable holding the Vault Enterprise namespace.
+	VaultNamespaceEnvVar = "VAULT_NAMESPACE"
+	// VaultCACertEnvVar is the environment variable holding the path to a CA bundle for the Vault server.
+	VaultCACertEnvVar = "VAULT_CACERT"
+	// VaultMountEnvVar is the environment variable holding the mount path of the KV v2 secrets engine.
+	VaultMountEnvVar = "TOOLHIVE_VAULT_MOUNT"
+	// VaultPathPrefixEnvVar is the environment variable holding the path under the mount where secrets are kept.
+	VaultPathPrefixEnvVar = "TOOLHIVE_VAULT_PATH_PREFIX"
+	// VaultAuthMethodEnvVar is the environment variable selecting the Vault auth method (token or kubernetes).
+	VaultAuthMethodEnvVar = "TOOLHIVE_VAULT_AUTH_METHOD"
+	// VaultKubernetesRoleEnvVar is the environment variable holding the role used with Kubernetes auth.
+	VaultKubernetesRoleEnvVar = "TOOLHIVE_VAULT_KUBERNETES_ROLE"
+	// VaultKubernetesMountEnvVar is the environment variable holding the mount path of the Kubernetes auth method.
+	VaultKubernetesMountEnvVar = "TOOLHIVE_VAULT_KUBERNETES_MOUNT"
+	// VaultKubernetesTokenPathEnvVar is the environment variable holding the path to the service account token.
+	VaultKubernetesTokenPathEnvVar = "TOOLHIVE_VAULT_KUBERNETES_TOKEN_PATH" //nolint:gosec // G101: not a credential
+
+	// VaultDynamicSecretPrefix marks secret names that refer to an arbitrary Vault path rather
+	// than a key in the KV v2 store, e.g. "vault://database/creds/readonly#password". Such
+	// secrets are read-only, and their leases are cached and renewed until they expire.
+	VaultDynamicSecretPrefix = "vault://"
+
+	defaultVaultMount               = "secret"
+	defaultVaultPathPrefix          = "toolhive"
+	defaultVaultKubernetesMount     = "kubernetes"
+	defaultVaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec // G101: file path
+
+	// vaultValueField is the field of a KV v2 secret that holds its value when the
+	// name does not select a field with "#field".
+	vaultValueField = "value"
+)
+
+// VaultAuthMethod is the method used to authenticate to Vault.
+type VaultAuthMethod string
+
+const (
+	// VaultAuthToken authenticates with a Vault token.
+	VaultAuthToken VaultAuthMethod = "token"
+	// VaultAuthKubernetes authenticates with the pod's service account token through
+	// Vault's Kubernetes auth method. The resulting Vault token is renewed while it is in use.
+	VaultAuthKubernetes VaultAuthMethod = "kubernetes"
+)
+
+// ErrVaultReadOnlySecret is returned when writing or deleting a secret that can only be read,
+// such as a dynamic secret or a single field of a KV secret.
+var ErrVaultReadOnlySecret = errors.New("vault secret is read-only, only plain KV secret names can be written")
+
+// VaultConfig configures the Vault secrets provider.
+type VaultConfig struct {
+	// Address is the URL of the Vault server, e.g. https://vault.example.com:8200.
+	Address string
+	// Namespace is the Vault Enterprise namespace. Optional.
+	Namespace string
+	// CACertPath is the path to a PEM bundle used to verify the Vault server. Optional.
+	CACertPath string
+	// MountPath is the mount path of the KV v2 secrets engine. Defaults to "secret".
+	MountPath string
+	// PathPrefix is the path under the mount where ToolHive's secrets are kept. Defaults to "toolhive".
+	PathPrefix string
+	// AuthMethod selects how to authenticate. Defaults to token auth.
+	AuthMethod VaultAuthMethod
+	// Token is the Vault token used with token auth.
+	Token string //nolint:gosec // G117: field legitimately holds sensitive data
+	// KubernetesRole is the Vault role used with Kubernetes auth.
+	KubernetesRole string
+	// KubernetesMountPath is the mount path of the Kubernetes auth method. Defaults to "kubernetes".
+	KubernetesMountPath string
+	// KubernetesTokenPath is the path to the service account token used with Kubernetes auth.
+	// Defaults to the token mounted into every pod.
+	KubernetesTokenPath string
+}
+
+// VaultConfigFromEnv builds a VaultConfig from the VAULT_* and TOOLHIVE_VAULT_* environment variables.
+func VaultConfigFromEnv() VaultConfig {
+	return VaultConfig{
+		Address:             os.Getenv(VaultAddrEnvVar),
+		Namespace:           os.Getenv(VaultNamespaceEnvVar),
+		CACertPath:          os.Getenv(VaultCACertEnvVar),
+		MountPath:           os.Getenv(VaultMountEnvVar),
+		PathPrefix:          os.Getenv(VaultPathPrefixEnvVar),
+		AuthMethod:          VaultAuthMethod(os.Getenv(VaultAuthMethodEnvVar)),
+		Token:               os.Getenv(VaultTokenEnvVar),
+		KubernetesRole:      os.Getenv(VaultKubernetesRoleEnvVar),
+		KubernetesMountPath: os.Getenv(VaultKubernetesMountEnvVar),
+		KubernetesTokenPath: os.Getenv(VaultKubernetesTokenPathEnvVar),
+	}
+}
+
+// withDefaults validates the configuration and returns a copy with defaults applied.
+func (c VaultConfig) withDefaults() (VaultConfig, error) {
+	if c.Address == "" {
+		return c, fmt.Errorf("%s is not set", VaultAddrEnvVar)
+	}
+	if c.MountPath == "" {
+		c.MountPath = defaultVaultMount
+	}
+	if c.PathPrefix == "" {
+		c.PathPrefix = defaultVaultPathPrefix
+	}
+	c.MountPath = strings.Trim(c.MountPath, "/")
+	c.PathPrefix = strings.Trim(c.PathPrefix, "/")
+
+	switch c.AuthMethod {
+	case "", VaultAuthToken:
+		c.AuthMethod = VaultAuthToken
+		if c.Token == "" {
+			return c, fmt.Errorf("%s is not set", VaultTokenEnvVar)
+		}
+	case VaultAuthKubernetes:
+		if c.KubernetesRole == "" {
+			return c, fmt.Errorf("%s is required for kubernetes auth", VaultKubernetesRoleEnvVar)
+		}
+		if c.KubernetesMountPath == "" {
+			c.KubernetesMountPath = defaultVaultKubernetesMount
+		}
+		if c.KubernetesTokenPath == "" {
+			c.KubernetesTokenPath = defaultVaultKubernetesTokenPath
+		}
+		c.KubernetesMountPath = strings.Trim(c.KubernetesMountPath, "/")
+	default:
+		return c, fmt.Errorf("unknown vault auth method: %s (valid methods: %s, %s)",
+			c.AuthMethod, VaultAuthToken, VaultAuthKubernetes)
+	}
+	return c, nil
+}
+
+// VaultManager manages secrets in the KV v2 secrets engine of a HashiCorp Vault server.
+//
+// A plain secret name maps to the KV secret <mount>/<prefix>/<name>, whose "value" field
+// holds the secret. Reading "<name>#<field>" returns another field of the same KV secret.
+// Names starting with VaultDynamicSecretPrefix read any Vault path, such as database
+// credentials; their leases are cached so that fields read separately belong to the same
+// credentials, and renewed in the background until Cleanup is called.
+type VaultManager struct {
+	config  VaultConfig
+	client  *http.Client
+	baseURL *url.URL
+
+	mu    sync.Mutex
+	token string
+	// tokenRenewCancel stops renewing the current token. Guarded by mu.
+	tokenRenewCancel context.CancelFunc
+	// loginMu serializes logins so that concurrent requests rejected with the
+	// same expired token log in only once.
+	loginMu sync.Mutex
+
+	// dynamicMu serializes reads of dynamic secrets so concurrent callers share one lease.
+	dynamicMu sync.Mutex
+	// leases caches dynamic secrets by Vault path. Guarded by mu.
+	leases map[string]*vaultLease
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// refs counts the users of a manager shared through NewVaultManager.
+	// Guarded by sharedVaultManagersMu.
+	refs int
+}
+
+var (
+	// sharedVaultManagers holds the managers created by NewVaultManager, one per
+	// configuration. Providers are created per request (e.g. by the API server), and
+	// sharing the manager keeps them from each logging in and renewing their own token.
+	sharedVaultManagers   = make(map[VaultConfig]*VaultManager)
+	sharedVaultManagersMu sync.Mutex
+)
+
+// vaultLease is a cached dynamic secret.
+type vaultLease struct {
+	data    map[string]any
+	expires time.Time
+}
+
+// NewVaultManager returns a VaultManager configured from the environment. Managers
+// are shared per configuration: the Vault token and the leases of dynamic secrets
+// are renewed until every caller has called Cleanup.
+func NewVaultManager() (Provider, error) {
+	config := VaultConfigFromEnv()
+
+	sharedVaultManagersMu.Lock()
+	defer sharedVaultManagersMu.Unlock()
+	if v, ok := sharedVaultManagers[config]; ok {
+		v.refs++
+		return v, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	v, err := NewVaultManagerWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	v.refs = 1
+	sharedVaultManagers[config] = v
+	return v, nil
+}
+
+// NewVaultManagerWithConfig creates a VaultManager and authenticates to Vault.
+// ctx only bounds the initial login; background renewals run until Cleanup is called.
+func NewVaultManagerWithConfig(ctx context.Context, config VaultConfig) (*VaultManager, error) {
+	config, err := config.withDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault configuration: %w", err)
+	}
+
+	baseURL, err := url.Parse(config.Address)
+	if err != nil || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid vault address: %s", config.Address)
+	}
+
+	client, err := networking.NewHostScopedClientBuilder(baseURL.Hostname(), true, false).
+		WithCABundle(config.CACertPath).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault HTTP client: %w", err)
+	}
+
+	renewCtx, renewCancel := context.WithCancel(context.Background())
+	v := &VaultManager{
+		config:  config,
+		client:  client,
+		baseURL: baseURL,
+		token:   config.Token,
+		leases:  make(map[string]*vaultLease),
+		ctx:     renewCtx,
+		cancel:  renewCancel,
+	}
+
+	if config.AuthMethod == VaultAuthKubernetes {
+		if err := v.login(ctx); err != nil {
+			renewCancel()
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// GetSecret retrieves a secret from Vault.
+func (v *VaultManager) GetSecret(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("secret name cannot be empty")
+	}
+	if dynamicPath, ok := strings.CutPrefix(name, VaultDynamicSecretPrefix); ok {
+		return v.getDynamicSecret(ctx, name, dynamicPath)
+	}
+
+	secretName, field := splitVaultField(name)
+	if field == "" {
+		field = vaultValueField
+	}
+	kvPath, err := v.kvPath("data", secretName)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := v.request(ctx, http.MethodGet, kvPath, nil, &resp); err != nil {
+		if isVaultNotFound(err) {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return "", fmt.Errorf("failed to read secret %s from vault: %w", name, err)
+	}
+	return vaultField(resp.Data.Data, field, name)
+}
+
+// SetSecret stores a secret in the "value" field of a KV v2 secret, creating a new version.
+func (v *VaultManager) SetSecret(ctx context.Context, name, value string) error {
+	kvPath, err := v.writablePath("data", name)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]any{"data": map[string]string{vaultValueField: value}}
+	if err := v.request(ctx, http.MethodPost, kvPath, body, nil); err != nil {
+		return fmt.Errorf("failed to write secret %s to vault: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteSecret deletes a KV v2 secret, including all of its versions.
+func (v *VaultManager) DeleteSecret(ctx context.Context, name string) error {
+	metadataPath, err := v.writablePath("metadata", name)
+	if err != nil {
+		return err
+	}
+
+	// Vault does not report whether a deleted secret existed, so check first to
+	// report missing secrets the same way as the other providers.
+	if err := v.request(ctx, http.MethodGet, metadataPath, nil, nil); err != nil {
+		if isVaultNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return fmt.Errorf("failed to read secret %s from vault: %w", name, err)
+	}
+	if err := v.request(ctx, http.MethodDelete, metadataPath, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete secret %s from vault: %w", name, err)
+	}
+	return nil
+}
+
+// ListSecrets lists the KV v2 secrets under the configured path prefix.
+func (v *VaultManager) ListSecrets(ctx context.Context) ([]SecretDescription, error) {
+	var secrets []SecretDescription
+	if err := v.listSecrets(ctx, "", &secrets); err != nil {
+		return nil, fmt.Errorf("failed to list secrets from vault: %w", err)
+	}
+	return secrets, nil
+}
+
+// listSecrets appends the secrets in dir, and recursively in its subdirectories, to secrets.
+func (v *VaultManager) listSecrets(ctx context.Context, dir string, secrets *[]SecretDescription) error {
+	var resp struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	listPath := path.Join("/v1", v.config.MountPath, "metadata", v.config.PathPrefix, dir) + "/?list=true"
+	if err := v.request(ctx, http.MethodGet, listPath, nil, &resp); err != nil {
+		if isVaultNotFound(err) {
+			// Vault reports an empty directory as not found.
+			return nil
+		}
+		return err
+	}
+
+	for _, key := range resp.Data.Keys {
+		name := dir + key
+		if strings.HasSuffix(key, "/") {
+			if err := v.listSecrets(ctx, name, secrets); err != nil {
+				return err
+			}
+			continue
+		}
+		*secrets = append(*secrets, SecretDescription{Key: name})
+	}
+	return nil
+}
+
+// DeleteSecrets deletes the named KV v2 secrets, ignoring secrets that do not exist.
+func (v *VaultManager) DeleteSecrets(ctx context.Context, keys []string) error {
+	var errs []error
+	for _, key := range keys {
+		if err := v.DeleteSecret(ctx, key); err != nil && !IsNotFoundError(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cleanup stops renewing the Vault token and the leases of dynamic secrets once the
+// last user of a shared manager has called it. Secrets stored in Vault are left untouched.
+func (v *VaultManager) Cleanup() error {
+	if !v.release() {
+		return nil
+	}
+	v.cancel()
+	v.wg.Wait()
+	return nil
+}
+
+// release drops a reference to a shared manager and reports whether it was the last one.
+// Managers that are not shared have a single user.
+func (v *VaultManager) release() bool {
+	sharedVaultManagersMu.Lock()
+	defer sharedVaultManagersMu.Unlock()
+	for config, shared := range sharedVaultManagers {
+		if shared != v {
+			continue
+		}
+		v.refs--
+		if v.refs > 0 {
+			return false
+		}
+		delete(sharedVaultManagers, config)
+	}
+	return true
+}
+
+// Capabilities returns the capabilities of the Vault provider.
+func (*VaultManager) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CanRead:    true,
+		CanWrite:   true,
+		CanDelete:  true,
+		CanList:    true,
+		CanCleanup: false, // Secrets in Vault outlive ToolHive and are never bulk-deleted
+	}
+}
+
+// getDynamicSecret returns a field of the secret at an arbitrary Vault path, reusing
+// the cached response while its lease is valid.
+func (v *VaultManager) getDynamicSecret(ctx context.Context, name, dynamicPath string) (string, error) {
+	secretPath, field := splitVaultField(dynamicPath)
+	if field == "" {
+		return "", fmt.Errorf("dynamic vault secret %s must select a field with #<field>", name)
+	}
+	secretPath = strings.Trim(secretPath, "/")
+	if secretPath == "" || !isCleanVaultPath(secretPath) {
+		return "", fmt.Errorf("invalid vault secret path: %s", name)
+	}
+
+	v.dynamicMu.Lock()
+	defer v.dynamicMu.Unlock()
+
+	v.mu.Lock()
+	lease, ok := v.leases[secretPath]
+	v.mu.Unlock()
+	if ok && time.Now().Before(lease.expires) {
+		return vaultField(lease.data, field, name)
+	}
+
+	var resp struct {
+		LeaseID       string         `json:"lease_id"`
+		LeaseDuration int            `json:"lease_duration"`
+		Renewable     bool           `json:"renewable"`
+		Data          map[string]any `json:"data"`
+	}
+	if err := v.request(ctx, http.MethodGet, "/v1/"+secretPath, nil, &resp); err != nil {
+		if isVaultNotFound(err) {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return "", fmt.Errorf("failed to read secret %s from vault: %w", name, err)
+	}
+
+	if resp.LeaseID != "" && resp.LeaseDuration > 0 {
+		ttl := time.Duration(resp.LeaseDuration) * time.Second
+		lease := &vaultLease{data: resp.Data, expires: time.Now().Add(ttl)}
+		v.mu.Lock()
+		v.leases[secretPath] = lease
+		v.mu.Unlock()
+
+		if resp.Renewable {
+			leaseID := resp.LeaseID
+			v.keepRenewed(v.ctx, "lease "+leaseID, ttl,
+				func(ctx context.Context) (time.Duration, error) {
+					renewedTTL, err := v.renewLease(ctx, leaseID, ttl)
+					if err == nil {
+						v.mu.Lock()
+						lease.expires = time.Now().Add(renewedTTL)
+						v.mu.Unlock()
+					}
+					return renewedTTL, err
+				},
+				func() {
+					v.mu.Lock()
+					if v.leases[secretPath] == lease {
+						delete(v.leases, secretPath)
+					}
+					v.mu.Unlock()
+				})
+		}
+	}
+	return vaultField(resp.Data, field, name)
+}
+
+// renewLease extends a lease by its previous duration and returns the new duration.
+func (v *VaultManager) renewLease(ctx context.Context, leaseID string, increment time.Duration) (time.Duration, error) {
+	var resp struct {
+		LeaseDuration int `json:"lease_duration"`
+	}
+	body := map[string]any{"lease_id": leaseID, "increment": int(increment.Seconds())}
+	if err := v.request(ctx, http.MethodPut, "/v1/sys/leases/renew", body, &resp); err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// keepRenewed calls renew once two thirds of the lease duration has passed, for as long
+// as renewals succeed and ctx is not cancelled. When a renewal fails or the lease cannot
+// be extended any further, it calls expired and stops.
+func (v *VaultManager) keepRenewed(
+	ctx context.Context,
+	what string,
+	ttl time.Duration,
+	renew func(ctx context.Context) (time.Duration, error),
+	expired func(),
+) {
+	v.wg.Add(1)
+	go func() {
+		defer v.wg.Done()
+		for {
+			timer := time.NewTimer(ttl * 2 / 3)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			newTTL, err := renew(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil || newTTL <= 0 {
+				//nolint:gosec // G706: lease and token identifiers are not secret values
+				slog.Debug("stopped renewing vault lease", "lease", what, "error", err)
+				expired()
+				return
+			}
+			ttl = newTTL
+		}
+	}()
+}
+
+// login authenticates with the Kubernetes auth method and keeps the resulting token
+// renewed, replacing the previous token and stopping its renewal.
+func (v *VaultManager) login(ctx context.Context) error {
+	jwt, err := os.ReadFile(v.config.KubernetesTokenPath)
+	if err != nil {
+		return fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+			Renewable     bool   `json:"renewable"`
+		} `json:"auth"`
+	}
+	body := map[string]string{"role": v.config.KubernetesRole, "jwt": strings.TrimSpace(string(jwt))}
+	loginPath := path.Join("/v1/auth", v.config.KubernetesMountPath, "login")
+	if err := v.send(ctx, http.MethodPost, loginPath, "", body, &resp); err != nil {
+		return fmt.Errorf("failed to log in to vault with kubernetes auth: %w", err)
+	}
+	if resp.Auth.ClientToken == "" {
+		return errors.New("failed to log in to vault with kubernetes auth: no token returned")
+	}
+
+	renewCtx, renewCancel := context.WithCancel(v.ctx)
+	v.mu.Lock()
+	if v.tokenRenewCancel != nil {
+		v.tokenRenewCancel()
+	}
+	v.token = resp.Auth.ClientToken
+	v.tokenRenewCancel = renewCancel
+	v.mu.Unlock()
+
+	if resp.Auth.Renewable && resp.Auth.LeaseDuration > 0 {
+		token := resp.Auth.ClientToken
+		v.keepRenewed(renewCtx, "auth token", time.Duration(resp.Auth.LeaseDuration)*time.Second,
+			func(ctx context.Context) (time.Duration, error) {
+				var renewed struct {
+					Auth struct {
+						LeaseDuration int `json:"lease_duration"`
+					} `json:"auth"`
+				}
+				err := v.send(ctx, http.MethodPost, "/v1/auth/token/renew-self", token, struct{}{}, &renewed)
+				return time.Duration(renewed.Auth.LeaseDuration) * time.Second, err
+			},
+			// A token that can no longer be renewed is replaced by logging in again
+			// when Vault rejects it.
+			func() {})
+	}
+	return nil
+}
+
+// request sends an authenticated request to Vault. With Kubernetes auth, a request
+// rejected because the token expired is retried once after logging in again. Vault
+// answers 403 both for expired tokens and for paths the token's policies deny, so
+// only a token that Vault no longer recognizes is replaced.
+func (v *VaultManager) request(ctx context.Context, method, reqPath string, body, out any) error {
+	v.mu.Lock()
+	token := v.token
+	v.mu.Unlock()
+
+	err := v.send(ctx, method, reqPath, token, body, out)
+	if v.config.AuthMethod != VaultAuthKubernetes || !isVaultForbidden(err) {
+		return err
+	}
+
+	renewed, loginErr := v.relogin(ctx, token)
+	if loginErr != nil {
+		return loginErr
+	}
+	if !renewed {
+		return err
+	}
+	v.mu.Lock()
+	token = v.token
+	v.mu.Unlock()
+	return v.send(ctx, method, reqPath, token, body, out)
+}
+
+// relogin logs in again if rejected is still the current token and Vault no longer
+// accepts it. It reports whether the token has been replaced, by this call or by a
+// concurrent one.
+func (v *VaultManager) relogin(ctx context.Context, rejected string) (bool, error) {
+	v.loginMu.Lock()
+	defer v.loginMu.Unlock()
+
+	v.mu.Lock()
+	current := v.token
+	v.mu.Unlock()
+	if current != rejected {
+		return true, nil
+	}
+
+	// Every token may look itself up, so a rejected lookup means the token expired
+	// or was revoked rather than that the original request was not permitted.
+	err := v.send(ctx, http.MethodGet, "/v1/auth/token/lookup-self", rejected, nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !isVaultForbidden(err) {
+		return false, fmt.Errorf("failed to look up vault token: %w", err)
+	}
+	if err := v.login(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// send sends a request to Vault and decodes the JSON response into out, if non-nil.
+func (v *VaultManager) send(ctx context.Context, method, reqPath, token string, body, out any) error {
+	reqURL, err := v.baseURL.Parse(reqPath)
+	if err != nil {
+		return fmt.Errorf("invalid vault path %s: %w", reqPath, err)
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode vault request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create vault request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if v.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.config.Namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		vaultErr := &vaultError{StatusCode: resp.StatusCode}
+		// The error list is best effort; the status code alone identifies the failure.
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(vaultErr)
+		return vaultErr
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return nil
+}
+
+// kvPath returns the API path of a KV v2 secret, where kind is "data" or "metadata".
+func (v *VaultManager) kvPath(kind, name string) (string, error) {
+	if !isCleanVaultPath(name) {
+		return "", fmt.Errorf("invalid secret name: %s", name)
+	}
+	return path.Join("/v1", v.config.MountPath, kind, v.config.PathPrefix, name), nil
+}
+
+// writablePath returns the API path of a KV v2 secret that can be written or deleted.
+func (v *VaultManager) writablePath(kind, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("secret name cannot be empty")
+	}
+	if strings.HasPrefix(name, VaultDynamicSecretPrefix) || strings.Contains(name, "#") {
+		return "", fmt.Errorf("%w: %s", ErrVaultReadOnlySecret, name)
+	}
+	return v.kvPath(kind, name)
+}
+
+// vaultError is an error response from the Vault API.
+type vaultError struct {
+	StatusCode int      `json:"-"`
+	Errors     []string `json:"errors"`
+}
+
+func (e *vaultError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("vault returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("vault returned status %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+func isVaultForbidden(err error) bool {
+	var vaultErr *vaultError
+	return errors.As(err, &vaultErr) && vaultErr.StatusCode == http.StatusForbidden
+}
+
+func isVaultNotFound(err error) bool {
+	var vaultErr *vaultError
+	return errors.As(err, &vaultErr) && vaultErr.StatusCode == http.StatusNotFound
+}
+
+// splitVaultField splits "name#field" into the name and the field. The field is
+// empty if the name does not select one.
+func splitVaultField(name string) (string, string) {
+	if i := strings.LastIndex(name, "#"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// isCleanVaultPath reports whether p is a relative path without empty, "." or ".."
+// segments, so that it cannot escape the configured mount and prefix.
+func isCleanVaultPath(p string) bool {
+	if p == "" {
+		return false
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// vaultField returns the named field of a secret's data as a string. Non-string
+// values are returned as JSON.
+func vaultField(data map[string]any, field, name string) (string, error) {
+	value, ok := data[field]
+	if !ok || value == nil {
+		return "", fmt.Errorf("%w: field %q of %s", ErrSecretNotFound, field, name)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode field %q of %s: %w", field, name, err)
+	}
+	return string(encoded), nil
+}
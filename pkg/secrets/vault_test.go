@@ -0,0 +1,521 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/secrets"
+)
+
+// stubVault is a minimal Vault HTTP API: a KV v2 engine mounted at "secret",
+// a dynamic secrets engine at "database/creds/readonly", lease renewal and
+// Kubernetes auth.
+type stubVault struct {
+	mu       sync.Mutex
+	tokens   map[string]bool
+	kv       map[string]map[string]any
+	logins   int
+	reads    int
+	renewals int
+	// authTTL is the lease duration of tokens issued by Kubernetes auth; they are
+	// renewable if it is set, and last an hour otherwise.
+	authTTL int
+	// tokenRenewals counts the renewal attempts per token.
+	tokenRenewals map[string]int
+	// denied lists paths that the policies of every token deny.
+	denied map[string]bool
+}
+
+func newStubVault(t *testing.T) (*stubVault, *httptest.Server) {
+	t.Helper()
+	stub := &stubVault{
+		tokens:        map[string]bool{"root": true},
+		kv:            map[string]map[string]any{},
+		tokenRenewals: map[string]int{},
+		denied:        map[string]bool{},
+	}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func (s *stubVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/v1/auth/kubernetes/login" {
+		var req struct {
+			Role string `json:"role"`
+			JWT  string `json:"jwt"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Role != "toolhive" || req.JWT != "sa-token" {
+			writeVaultJSON(w, http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		s.logins++
+		token := fmt.Sprintf("k8s-token-%d", s.logins)
+		s.tokens[token] = true
+		ttl, renewable := 3600, false
+		if s.authTTL > 0 {
+			ttl, renewable = s.authTTL, true
+		}
+		writeVaultJSON(w, http.StatusOK, map[string]any{
+			"auth": map[string]any{"client_token": token, "lease_duration": ttl, "renewable": renewable},
+		})
+		return
+	}
+	if r.URL.Path == "/v1/auth/token/renew-self" {
+		s.tokenRenewals[r.Header.Get("X-Vault-Token")]++
+	}
+	if !s.tokens[r.Header.Get("X-Vault-Token")] || s.denied[r.URL.Path] {
+		writeVaultJSON(w, http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/v1/auth/token/lookup-self":
+		writeVaultJSON(w, http.StatusOK, map[string]any{"data": map[string]any{}})
+	case r.URL.Path == "/v1/auth/token/renew-self":
+		writeVaultJSON(w, http.StatusOK, map[string]any{"auth": map[string]any{"lease_duration": s.authTTL}})
+	case r.URL.Path == "/v1/database/creds/readonly":
+		s.reads++
+		writeVaultJSON(w, http.StatusOK, map[string]any{
+			"lease_id":       "database/creds/readonly/lease",
+			"lease_duration": 1,
+			"renewable":      true,
+			"data":           map[string]any{"username": fmt.Sprintf("user-%d", s.reads), "password": "pass"},
+		})
+	case r.URL.Path == "/v1/sys/leases/renew" && r.Method == http.MethodPut:
+		s.renewals++
+		writeVaultJSON(w, http.StatusOK, map[string]any{"lease_id": "database/creds/readonly/lease", "lease_duration": 1})
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+		s.serveData(w, r, strings.TrimPrefix(r.URL.Path, "/v1/secret/data/"))
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/"):
+		s.serveMetadata(w, r, strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/"))
+	default:
+		writeVaultJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+	}
+}
+
+func (s *stubVault) serveData(w http.ResponseWriter, r *http.Request, key string) {
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := s.kv[key]
+		if !ok {
+			writeVaultJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		writeVaultJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"data": data, "metadata": map[string]any{}}})
+	case http.MethodPost:
+		var req struct {
+			Data map[string]any `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeVaultJSON(w, http.StatusBadRequest, map[string]any{"errors": []string{err.Error()}})
+			return
+		}
+		s.kv[key] = req.Data
+		writeVaultJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"version": 1}})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *stubVault) serveMetadata(w http.ResponseWriter, r *http.Request, key string) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list") == "true":
+		var keys []string
+		for k := range s.kv {
+			rest, ok := strings.CutPrefix(k, key)
+			if !ok {
+				continue
+			}
+			if dir, _, nested := strings.Cut(rest, "/"); nested {
+				rest = dir + "/"
+			}
+			if !slices.Contains(keys, rest) {
+				keys = append(keys, rest)
+			}
+		}
+		if len(keys) == 0 {
+			writeVaultJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		slices.Sort(keys)
+		writeVaultJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"keys": keys}})
+	case r.Method == http.MethodGet:
+		if _, ok := s.kv[key]; !ok {
+			writeVaultJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		writeVaultJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"current_version": 1}})
+	case r.Method == http.MethodDelete:
+		delete(s.kv, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeVaultJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newTestVaultManager(t *testing.T, address string) *secrets.VaultManager {
+	t.Helper()
+	manager, err := secrets.NewVaultManagerWithConfig(context.Background(), secrets.VaultConfig{
+		Address: address,
+		Token:   "root",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, manager.Cleanup()) })
+	return manager
+}
+
+func TestVaultManager_ReadWriteDelete(t *testing.T) {
+	t.Parallel()
+	stub, server := newStubVault(t)
+	manager := newTestVaultManager(t, server.URL)
+	ctx := context.Background()
+
+	require.NoError(t, manager.SetSecret(ctx, "github-token", "ghp_123"))
+	require.NoError(t, manager.SetSecret(ctx, "team/slack-token", "xoxb-456"))
+
+	stub.mu.Lock()
+	assert.Equal(t, map[string]any{"value": "ghp_123"}, stub.kv["toolhive/github-token"],
+		"secrets are stored in the value field under the path prefix")
+	stub.kv["toolhive/database"] = map[string]any{"username": "admin", "port": 5432}
+	stub.mu.Unlock()
+
+	value, err := manager.GetSecret(ctx, "github-token")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_123", value)
+
+	value, err = manager.GetSecret(ctx, "team/slack-token")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-456", value)
+
+	value, err = manager.GetSecret(ctx, "database#username")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", value)
+
+	value, err = manager.GetSecret(ctx, "database#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", value, "non-string fields are returned as JSON")
+
+	list, err := manager.ListSecrets(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []secrets.SecretDescription{
+		{Key: "database"}, {Key: "github-token"}, {Key: "team/slack-token"},
+	}, list)
+
+	require.NoError(t, manager.DeleteSecret(ctx, "github-token"))
+	_, err = manager.GetSecret(ctx, "github-token")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	require.NoError(t, manager.DeleteSecrets(ctx, []string{"team/slack-token", "does-not-exist"}))
+	list, err = manager.ListSecrets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.SecretDescription{{Key: "database"}}, list)
+}
+
+func TestVaultManager_MissingSecret(t *testing.T) {
+	t.Parallel()
+	stub, server := newStubVault(t)
+	manager := newTestVaultManager(t, server.URL)
+	ctx := context.Background()
+
+	_, err := manager.GetSecret(ctx, "missing")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+	assert.True(t, secrets.IsNotFoundError(err))
+
+	stub.mu.Lock()
+	stub.kv["toolhive/database"] = map[string]any{"username": "admin"}
+	stub.mu.Unlock()
+
+	_, err = manager.GetSecret(ctx, "database")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound, "a secret without a value field is not found")
+	_, err = manager.GetSecret(ctx, "database#password")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	err = manager.DeleteSecret(ctx, "missing")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	list, err := manager.ListSecrets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.SecretDescription{{Key: "database"}}, list)
+}
+
+func TestVaultManager_InvalidNames(t *testing.T) {
+	t.Parallel()
+	_, server := newStubVault(t)
+	manager := newTestVaultManager(t, server.URL)
+	ctx := context.Background()
+
+	err := manager.SetSecret(ctx, "vault://database/creds/readonly#password", "x")
+	assert.ErrorIs(t, err, secrets.ErrVaultReadOnlySecret)
+	err = manager.SetSecret(ctx, "database#password", "x")
+	assert.ErrorIs(t, err, secrets.ErrVaultReadOnlySecret)
+	err = manager.DeleteSecret(ctx, "vault://database/creds/readonly#password")
+	assert.ErrorIs(t, err, secrets.ErrVaultReadOnlySecret)
+
+	for _, name := range []string{"../escape", "a//b", "/absolute", "vault://../sys/raw#x"} {
+		_, err := manager.GetSecret(ctx, name)
+		assert.ErrorContains(t, err, "invalid", name)
+	}
+	_, err = manager.GetSecret(ctx, "vault://database/creds/readonly")
+	assert.ErrorContains(t, err, "must select a field")
+}
+
+func TestVaultManager_TokenRejected(t *testing.T) {
+	t.Parallel()
+	_, server := newStubVault(t)
+	manager, err := secrets.NewVaultManagerWithConfig(context.Background(), secrets.VaultConfig{
+		Address: server.URL,
+		Token:   "wrong",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, manager.Cleanup()) })
+
+	_, err = manager.GetSecret(context.Background(), "github-token")
+	require.Error(t, err)
+	assert.False(t, secrets.IsNotFoundError(err))
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestVaultManager_DynamicSecretLeaseRenewal(t *testing.T) {
+	t.Parallel()
+	stub, server := newStubVault(t)
+	manager := newTestVaultManager(t, server.URL)
+	ctx := context.Background()
+
+	username, err := manager.GetSecret(ctx, "vault://database/creds/readonly#username")
+	require.NoError(t, err)
+	password, err := manager.GetSecret(ctx, "vault://database/creds/readonly#password")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", username)
+	assert.Equal(t, "pass", password)
+
+	// The lease lasts one second and is renewed after two thirds of it.
+	assert.Eventually(t, func() bool {
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		return stub.renewals >= 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	username, err = manager.GetSecret(ctx, "vault://database/creds/readonly#username")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", username, "renewed credentials are served from the cache")
+
+	stub.mu.Lock()
+	assert.Equal(t, 1, stub.reads, "fields of a leased secret come from a single read")
+	stub.mu.Unlock()
+
+	require.NoError(t, manager.Cleanup())
+	stub.mu.Lock()
+	renewals := stub.renewals
+	stub.mu.Unlock()
+	time.Sleep(time.Second)
+	stub.mu.Lock()
+	assert.Equal(t, renewals, stub.renewals, "Cleanup stops renewing leases")
+	stub.mu.Unlock()
+}
+
+func TestVaultManager_KubernetesAuth(t *testing.T) {
+	t.Parallel()
+	stub, server := newStubVault(t)
+	manager := newKubernetesVaultManager(t, server.URL)
+
+	ctx := context.Background()
+	require.NoError(t, manager.SetSecret(ctx, "github-token", "ghp_123"))
+
+	// Expire the token; the next request logs in again and is retried.
+	stub.mu.Lock()
+	delete(stub.tokens, "k8s-token-1")
+	stub.mu.Unlock()
+
+	value, err := manager.GetSecret(ctx, "github-token")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_123", value)
+
+	stub.mu.Lock()
+	assert.Equal(t, 2, stub.logins)
+	stub.mu.Unlock()
+}
+
+func TestVaultManager_KubernetesAuthPermissionDenied(t *testing.T) {
+	t.Parallel()
+	stub, server := newStubVault(t)
+	stub.denied["/v1/secret/data/toolhive/other-team"] = true
+	manager := newKubernetesVaultManager(t, server.URL)
+
+	// The token is valid, so a denied path is reported without logging in again.
+	_, err := manager.GetSecret(context.Background(), "other-team")
+	require.ErrorContains(t, err, "permission denied")
+
+	stub.mu.Lock()
+	assert.Equal(t, 1, stub.logins)
+	stub.mu.Unlock()
+}
+
+func TestVaultManager_KubernetesAuthReloginStopsRenewal(t *testing.T) {
+	t.Parallel()
+	stub, server := newStubVault(t)
+	stub.authTTL = 1
+	manager := newKubernetesVaultManager(t, server.URL)
+
+	// Revoke the token before its first renewal; the next request logs in again.
+	stub.mu.Lock()
+	delete(stub.tokens, "k8s-token-1")
+	stub.mu.Unlock()
+	_, err := manager.GetSecret(context.Background(), "github-token")
+	require.True(t, secrets.IsNotFoundError(err), "unexpected error: %v", err)
+
+	// The new token is renewed, and the replaced one is not.
+	assert.Eventually(t, func() bool {
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		return stub.tokenRenewals["k8s-token-2"] >= 2
+	}, 5*time.Second, 50*time.Millisecond)
+	stub.mu.Lock()
+	assert.Equal(t, 2, stub.logins)
+	assert.Zero(t, stub.tokenRenewals["k8s-token-1"])
+	stub.mu.Unlock()
+}
+
+func newKubernetesVaultManager(t *testing.T, address string) *secrets.VaultManager {
+	t.Helper()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("sa-token\n"), 0600))
+
+	manager, err := secrets.NewVaultManagerWithConfig(context.Background(), secrets.VaultConfig{
+		Address:             address,
+		AuthMethod:          secrets.VaultAuthKubernetes,
+		KubernetesRole:      "toolhive",
+		KubernetesTokenPath: tokenPath,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, manager.Cleanup()) })
+	return manager
+}
+
+func TestNewVaultManagerWithConfig_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  secrets.VaultConfig
+		wantErr string
+	}{
+		{
+			name:    "missing address",
+			config:  secrets.VaultConfig{Token: "root"},
+			wantErr: "VAULT_ADDR is not set",
+		},
+		{
+			name:    "missing token",
+			config:  secrets.VaultConfig{Address: "http://127.0.0.1:8200"},
+			wantErr: "VAULT_TOKEN is not set",
+		},
+		{
+			name:    "kubernetes auth without role",
+			config:  secrets.VaultConfig{Address: "http://127.0.0.1:8200", AuthMethod: secrets.VaultAuthKubernetes},
+			wantErr: "TOOLHIVE_VAULT_KUBERNETES_ROLE is required",
+		},
+		{
+			name:    "unknown auth method",
+			config:  secrets.VaultConfig{Address: "http://127.0.0.1:8200", AuthMethod: "approle"},
+			wantErr: "unknown vault auth method: approle",
+		},
+		{
+			name: "kubernetes auth without service account token",
+			config: secrets.VaultConfig{
+				Address:             "http://127.0.0.1:8200",
+				AuthMethod:          secrets.VaultAuthKubernetes,
+				KubernetesRole:      "toolhive",
+				KubernetesTokenPath: "/nonexistent/token",
+			},
+			wantErr: "failed to read service account token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := secrets.NewVaultManagerWithConfig(context.Background(), tt.config)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreateSecretProvider_Vault(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	stub, server := newStubVault(t)
+	t.Setenv(secrets.VaultAddrEnvVar, server.URL)
+	t.Setenv(secrets.VaultTokenEnvVar, "root")
+	t.Setenv(secrets.VaultPathPrefixEnvVar, "team-a")
+
+	result := secrets.ValidateProvider(context.Background(), secrets.VaultType)
+	require.NoError(t, result.Error)
+	assert.True(t, result.Success)
+
+	provider, err := secrets.CreateSecretProvider(secrets.VaultType)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Cleanup() })
+
+	require.NoError(t, provider.SetSecret(context.Background(), "api-key", "secret"))
+	stub.mu.Lock()
+	assert.Contains(t, stub.kv, "team-a/api-key")
+	stub.mu.Unlock()
+}
+
+func TestNewVaultManager_SharedPerConfig(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	stub, server := newStubVault(t)
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("sa-token\n"), 0600))
+	t.Setenv(secrets.VaultAddrEnvVar, server.URL)
+	t.Setenv(secrets.VaultAuthMethodEnvVar, string(secrets.VaultAuthKubernetes))
+	t.Setenv(secrets.VaultKubernetesRoleEnvVar, "toolhive")
+	t.Setenv(secrets.VaultKubernetesTokenPathEnvVar, tokenPath)
+
+	first, err := secrets.NewVaultManager()
+	require.NoError(t, err)
+	second, err := secrets.NewVaultManager()
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	stub.mu.Lock()
+	assert.Equal(t, 1, stub.logins, "providers created for the same configuration share one login")
+	stub.mu.Unlock()
+
+	// The manager keeps working until its last user cleans it up.
+	require.NoError(t, first.Cleanup())
+	_, err = second.ListSecrets(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, second.Cleanup())
+
+	third, err := secrets.NewVaultManager()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = third.Cleanup() })
+	assert.NotSame(t, first, third)
+	stub.mu.Lock()
+	assert.Equal(t, 2, stub.logins)
+	stub.mu.Unlock()
+}
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -60,6 +62,16 @@ type Config struct {
 	// +optional
 	SamplingRate string `json:"samplingRate,omitempty" yaml:"samplingRate,omitempty"`
 
+	// SamplingOverrides maps operation names to a trace sampling rate (0.0-1.0, as a
+	// string) used for them instead of SamplingRate, e.g. to sample every
+	// "tools/call" span but only a few "ping" spans. A key matches spans with that
+	// exact name and MCP spans for that method, which are named
+	// "{mcp.method.name} {target}"; the most specific key wins.
+	// Spans whose parent is sampled are always sampled.
+	// Example: {"tools/call": "1.0", "ping": "0.01"}
+	// +optional
+	SamplingOverrides map[string]string `json:"samplingOverrides,omitempty" yaml:"samplingOverrides,omitempty"`
+
 	// Headers contains authentication headers for the OTLP endpoint.
 	// +optional
 	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
@@ -76,6 +88,11 @@ type Config struct {
 	// +optional
 	EnablePrometheusMetricsPath bool `json:"enablePrometheusMetricsPath,omitempty" yaml:"enablePrometheusMetricsPath,omitempty"`
 
+	// PrometheusBasicAuth, when set, requires HTTP basic authentication on the
+	// Prometheus /metrics endpoint. Only used when EnablePrometheusMetricsPath is true.
+	// +optional
+	PrometheusBasicAuth *PrometheusBasicAuth `json:"prometheusBasicAuth,omitempty" yaml:"prometheusBasicAuth,omitempty"`
+
 	// EnvironmentVariables is a list of environment variable names that should be
 	// included in telemetry spans as attributes. Only variables in this list will
 	// be read from the host machine and included in spans for observability.
@@ -104,6 +121,29 @@ type Config struct {
 	CACertPath string `json:"caCertPath,omitempty" yaml:"caCertPath,omitempty"`
 }
 
+// PrometheusBasicAuth holds the credentials required to scrape the Prometheus
+// /metrics endpoint.
+// +kubebuilder:object:generate=true
+// +gendoc
+type PrometheusBasicAuth struct {
+	// Username is the user name scrapers must present.
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username" yaml:"username"`
+
+	// PasswordFile is the path to a file containing the password scrapers must
+	// present. Leading and trailing whitespace in the file is ignored.
+	// +kubebuilder:validation:MinLength=1
+	PasswordFile string `json:"passwordFile" yaml:"passwordFile"`
+}
+
+// String returns the username and password file; the password itself is never read here.
+func (a *PrometheusBasicAuth) String() string {
+	if a == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{Username: %q, PasswordFile: %q}", a.Username, a.PasswordFile)
+}
+
 // Ensure Config implements fmt.Stringer and fmt.GoStringer
 var _ fmt.Stringer = Config{}
 var _ fmt.GoStringer = Config{}
@@ -123,13 +163,13 @@ func (c Config) String() string {
 	}
 
 	return fmt.Sprintf("Config{Endpoint: %q, ServiceName: %q, ServiceVersion: %q, TracingEnabled: %t, "+
-		"MetricsEnabled: %t, SamplingRate: %q, Headers: %v, Insecure: %t, "+
+		"MetricsEnabled: %t, SamplingRate: %q, SamplingOverrides: %v, Headers: %v, Insecure: %t, "+
 		"EnablePrometheusMetricsPath: %t, EnvironmentVariables: %v, CustomAttributes: %v, "+
-		"UseLegacyAttributes: %t, CACertPath: %q}",
+		"UseLegacyAttributes: %t, CACertPath: %q, PrometheusBasicAuth: %s}",
 		c.Endpoint, c.ServiceName, c.ServiceVersion, c.TracingEnabled,
-		c.MetricsEnabled, c.SamplingRate, redactedHeaders, c.Insecure,
+		c.MetricsEnabled, c.SamplingRate, c.SamplingOverrides, redactedHeaders, c.Insecure,
 		c.EnablePrometheusMetricsPath, c.EnvironmentVariables, c.CustomAttributes,
-		c.UseLegacyAttributes, c.CACertPath)
+		c.UseLegacyAttributes, c.CACertPath, c.PrometheusBasicAuth)
 }
 
 // GetSamplingRateFloat parses the SamplingRate string and returns it as float64.
@@ -145,11 +185,41 @@ func (c *Config) GetSamplingRateFloat() float64 {
 	return rate
 }
 
+// GetSamplingOverridesFloat parses the SamplingOverrides rates and returns them
+// as float64. Operations whose rate cannot be parsed are left out; NewProvider
+// rejects such configurations.
+func (c *Config) GetSamplingOverridesFloat() map[string]float64 {
+	if len(c.SamplingOverrides) == 0 {
+		return nil
+	}
+	overrides := make(map[string]float64, len(c.SamplingOverrides))
+	for operation, value := range c.SamplingOverrides {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		overrides[operation] = rate
+	}
+	return overrides
+}
+
 // SetSamplingRateFromFloat sets the SamplingRate from a float64 value.
 func (c *Config) SetSamplingRateFromFloat(rate float64) {
 	c.SamplingRate = strconv.FormatFloat(rate, 'f', -1, 64)
 }
 
+// SetSamplingOverridesFromFloat sets SamplingOverrides from float64 rates.
+func (c *Config) SetSamplingOverridesFromFloat(overrides map[string]float64) {
+	if len(overrides) == 0 {
+		c.SamplingOverrides = nil
+		return
+	}
+	c.SamplingOverrides = make(map[string]string, len(overrides))
+	for operation, rate := range overrides {
+		c.SamplingOverrides[operation] = strconv.FormatFloat(rate, 'f', -1, 64)
+	}
+}
+
 // DefaultServiceNamePrefix is prepended to the workload name when deriving the
 // OTel service name automatically (e.g. "thv-fetch", "thv-github").
 const DefaultServiceNamePrefix = "thv-"
@@ -270,10 +340,20 @@ func NewProvider(ctx context.Context, config Config, extraProcessors ...sdktrace
 		providers.WithTracingEnabled(config.TracingEnabled),
 		providers.WithMetricsEnabled(config.MetricsEnabled),
 		providers.WithSamplingRate(config.GetSamplingRateFloat()),
+		providers.WithSamplingOverrides(config.GetSamplingOverridesFloat()),
 		providers.WithEnablePrometheusMetricsPath(config.EnablePrometheusMetricsPath),
 		providers.WithCustomAttributes(config.CustomAttributes),
 	}
 
+	if config.EnablePrometheusMetricsPath && config.PrometheusBasicAuth != nil {
+		password, err := readPrometheusPassword(config.PrometheusBasicAuth.PasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		telemetryOptions = append(telemetryOptions,
+			providers.WithPrometheusBasicAuth(config.PrometheusBasicAuth.Username, password))
+	}
+
 	// Merge globally registered processors (self-registered by integrations such
 	// as a Sentry bridge) with any explicitly passed ones.
 	allProcessors := append(registeredSpanProcessors(), extraProcessors...)
@@ -349,5 +429,52 @@ func validateOtelConfig(config Config) error {
 		return fmt.Errorf("OTLP endpoint is configured but both tracing and metrics are disabled; " +
 			"either enable tracing or metrics, or remove the endpoint")
 	}
+	if config.SamplingRate != "" {
+		if err := validateSamplingRate(config.SamplingRate); err != nil {
+			return fmt.Errorf("invalid sampling rate: %w", err)
+		}
+	}
+	for operation, rate := range config.SamplingOverrides {
+		if operation == "" {
+			return fmt.Errorf("invalid sampling override: operation name must not be empty")
+		}
+		if err := validateSamplingRate(rate); err != nil {
+			return fmt.Errorf("invalid sampling override for %q: %w", operation, err)
+		}
+	}
+	if auth := config.PrometheusBasicAuth; auth != nil {
+		if auth.Username == "" {
+			return fmt.Errorf("invalid prometheus basic auth: username must not be empty")
+		}
+		if auth.PasswordFile == "" {
+			return fmt.Errorf("invalid prometheus basic auth: password file must not be empty")
+		}
+	}
+	return nil
+}
+
+// readPrometheusPassword reads the /metrics basic auth password from path,
+// ignoring surrounding whitespace such as a trailing newline.
+func readPrometheusPassword(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to read prometheus basic auth password file: %w", err)
+	}
+	password := strings.TrimSpace(string(data))
+	if password == "" {
+		return "", fmt.Errorf("prometheus basic auth password file %s is empty", path)
+	}
+	return password, nil
+}
+
+// validateSamplingRate checks that value is a number between 0.0 and 1.0.
+func validateSamplingRate(value string) error {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	if !(rate >= 0.0 && rate <= 1.0) {
+		return fmt.Errorf("%s must be between 0.0 and 1.0", value)
+	}
 	return nil
 }
@@ -7,6 +7,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -162,6 +165,89 @@ func TestTelemetryProviderValidation(t *testing.T) {
 	}
 }
 
+func TestValidateOtelConfig_SamplingRates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		samplingRate  string
+		overrides     map[string]string
+		errorContains string
+	}{
+		{
+			name:         "valid rates",
+			samplingRate: "0.05",
+			overrides:    map[string]string{"tools/call": "1.0", "ping": "0", "initialize": "0.5"},
+		},
+		{
+			name: "empty sampling rate is allowed",
+		},
+		{
+			name:          "global rate above 1",
+			samplingRate:  "1.5",
+			errorContains: "invalid sampling rate",
+		},
+		{
+			name:          "override below 0",
+			samplingRate:  "0.1",
+			overrides:     map[string]string{"ping": "-0.1"},
+			errorContains: `invalid sampling override for "ping"`,
+		},
+		{
+			name:          "override above 1",
+			overrides:     map[string]string{"tools/call": "2"},
+			errorContains: `invalid sampling override for "tools/call"`,
+		},
+		{
+			name:          "override is not a number",
+			overrides:     map[string]string{"tools/call": "all"},
+			errorContains: "is not a number",
+		},
+		{
+			name:          "override is NaN",
+			overrides:     map[string]string{"tools/call": "NaN"},
+			errorContains: "must be between 0.0 and 1.0",
+		},
+		{
+			name:          "empty operation name",
+			overrides:     map[string]string{"": "1.0"},
+			errorContains: "operation name must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateOtelConfig(Config{
+				Endpoint:          "localhost:4318",
+				TracingEnabled:    true,
+				SamplingRate:      tt.samplingRate,
+				SamplingOverrides: tt.overrides,
+			})
+			if tt.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestConfig_SamplingOverridesFloat(t *testing.T) {
+	t.Parallel()
+
+	var cfg Config
+	cfg.SetSamplingOverridesFromFloat(map[string]float64{"tools/call": 1, "ping": 0.01})
+	assert.Equal(t, map[string]string{"tools/call": "1", "ping": "0.01"}, cfg.SamplingOverrides)
+	assert.Equal(t, map[string]float64{"tools/call": 1, "ping": 0.01}, cfg.GetSamplingOverridesFloat())
+
+	cfg.SetSamplingOverridesFromFloat(nil)
+	assert.Nil(t, cfg.SamplingOverrides)
+	assert.Nil(t, cfg.GetSamplingOverridesFloat())
+}
+
 // getProviderTypeName returns a readable type name for telemetry providers
 func getProviderTypeName(provider interface{}) string {
 	t := reflect.TypeOf(provider)
@@ -301,6 +387,76 @@ func TestProvider_ShutdownTimeout(t *testing.T) {
 	_ = provider.Shutdown(shutdownCtx)
 }
 
+// TestProvider_PrometheusBasicAuth tests that the /metrics handler requires the
+// configured credentials, with the password read from a file.
+func TestProvider_PrometheusBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600))
+
+	newConfig := func(auth *PrometheusBasicAuth) Config {
+		return Config{
+			ServiceName:                 "test-service",
+			ServiceVersion:              "1.0.0",
+			EnablePrometheusMetricsPath: true,
+			PrometheusBasicAuth:         auth,
+		}
+	}
+
+	t.Run("enforces credentials", func(t *testing.T) {
+		t.Parallel()
+
+		provider, err := NewProvider(context.Background(), newConfig(&PrometheusBasicAuth{
+			Username:     "prometheus",
+			PasswordFile: passwordFile,
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+		handler := provider.PrometheusHandler()
+		require.NotNil(t, handler)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.SetBasicAuth("prometheus", "s3cret")
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, "the trailing newline in the password file is ignored")
+	})
+
+	t.Run("missing password file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewProvider(context.Background(), newConfig(&PrometheusBasicAuth{
+			Username:     "prometheus",
+			PasswordFile: filepath.Join(t.TempDir(), "missing"),
+		}))
+		assert.ErrorContains(t, err, "failed to read prometheus basic auth password file")
+	})
+
+	t.Run("empty password file", func(t *testing.T) {
+		t.Parallel()
+
+		emptyFile := filepath.Join(t.TempDir(), "empty")
+		require.NoError(t, os.WriteFile(emptyFile, []byte("  \n"), 0o600))
+		_, err := NewProvider(context.Background(), newConfig(&PrometheusBasicAuth{
+			Username:     "prometheus",
+			PasswordFile: emptyFile,
+		}))
+		assert.ErrorContains(t, err, "is empty")
+	})
+
+	t.Run("missing username", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewProvider(context.Background(), newConfig(&PrometheusBasicAuth{PasswordFile: passwordFile}))
+		assert.ErrorContains(t, err, "username must not be empty")
+	})
+}
+
 // TestConfigString_HeaderRedaction tests that String() and GoString() redact header values
 // across all header scenarios (populated, empty, nil).
 func TestConfigString_HeaderRedaction(t *testing.T) {
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
+	"github.com/stacklok/toolhive/pkg/correlation"
 	mcpparser "github.com/stacklok/toolhive/pkg/mcp"
 	"github.com/stacklok/toolhive/pkg/transport/types"
 )
@@ -256,6 +257,10 @@ func (m *HTTPMiddleware) addHTTPAttributes(span trace.Span, r *http.Request) {
 		span.SetAttributes(attribute.String("url.query", r.URL.RawQuery))
 	}
 
+	if correlationID := correlation.FromContext(r.Context()); correlationID != "" {
+		span.SetAttributes(attribute.String(correlation.SpanAttributeKey, correlationID))
+	}
+
 	// Legacy attribute names (emitted only when UseLegacyAttributes is true)
 	if m.config.UseLegacyAttributes {
 		span.SetAttributes(
@@ -6,9 +6,10 @@ package otlp
 
 // Config holds OTLP-specific configuration
 type Config struct {
-	Endpoint     string
-	Headers      map[string]string
-	Insecure     bool
-	SamplingRate float64
-	CACertPath   string
+	Endpoint          string
+	Headers           map[string]string
+	Insecure          bool
+	SamplingRate      float64
+	SamplingOverrides map[string]float64
+	CACertPath        string
 }
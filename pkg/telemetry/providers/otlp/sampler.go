@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newSampler returns the sampler for root spans: spans named in overrides are
+// sampled at their own rate, all others at samplingRate. The result is wrapped
+// in a parent-based sampler, so spans with a remote or local parent follow the
+// parent's sampling decision regardless of their name.
+func newSampler(samplingRate float64, overrides map[string]float64) sdktrace.Sampler {
+	root := sdktrace.TraceIDRatioBased(samplingRate)
+	if len(overrides) > 0 {
+		root = newOperationSampler(root, overrides)
+	}
+	return sdktrace.ParentBased(root)
+}
+
+// operationSampler samples spans at a per-operation rate. A span matches an
+// override when its name equals the override key, or when the name starts with
+// the key followed by a space. The latter matches MCP span names, which are
+// "{mcp.method.name} {target}" (e.g. "tools/call fetch"), so an override for
+// "tools/call" applies to every tool call while one for "tools/call fetch"
+// applies to a single tool. The longest matching key wins.
+//
+// Sampling decisions are made when a span starts, so overrides can only select
+// spans by name, not by how they end.
+type operationSampler struct {
+	fallback  sdktrace.Sampler
+	overrides map[string]sdktrace.Sampler
+	// keys holds the override keys, longest first, so the first match is the
+	// most specific one.
+	keys        []string
+	description string
+}
+
+func newOperationSampler(fallback sdktrace.Sampler, overrides map[string]float64) *operationSampler {
+	s := &operationSampler{
+		fallback:  fallback,
+		overrides: make(map[string]sdktrace.Sampler, len(overrides)),
+	}
+	for name, rate := range overrides {
+		s.overrides[name] = sdktrace.TraceIDRatioBased(rate)
+	}
+
+	keys := slices.Sorted(maps.Keys(overrides))
+	parts := make([]string, 0, len(keys))
+	for _, name := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, s.overrides[name].Description()))
+	}
+	s.description = fmt.Sprintf("OperationSampler{fallback:%s,overrides:{%s}}",
+		fallback.Description(), strings.Join(parts, ","))
+
+	slices.SortStableFunc(keys, func(a, b string) int { return len(b) - len(a) })
+	s.keys = keys
+	return s
+}
+
+// ShouldSample implements sdktrace.Sampler
+func (s *operationSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return s.samplerFor(p.Name).ShouldSample(p)
+}
+
+// Description implements sdktrace.Sampler
+func (s *operationSampler) Description() string {
+	return s.description
+}
+
+func (s *operationSampler) samplerFor(spanName string) sdktrace.Sampler {
+	if sampler, ok := s.overrides[spanName]; ok {
+		return sampler
+	}
+	for _, name := range s.keys {
+		if rest, ok := strings.CutPrefix(spanName, name); ok && strings.HasPrefix(rest, " ") {
+			return s.overrides[name]
+		}
+	}
+	return s.fallback
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler_OperationOverrides(t *testing.T) {
+	t.Parallel()
+
+	sampler := newSampler(0.0, map[string]float64{
+		"tools/call":       1.0,
+		"tools/call fetch": 0.0,
+		"ping":             0.0,
+		"initialize":       1.0,
+	})
+
+	tests := []struct {
+		spanName    string
+		wantSampled bool
+	}{
+		{spanName: "tools/call", wantSampled: true},
+		{spanName: "tools/call github", wantSampled: true},
+		{spanName: "tools/call fetch", wantSampled: false},
+		{spanName: "ping", wantSampled: false},
+		{spanName: "initialize", wantSampled: true},
+		{spanName: "tools/list", wantSampled: false},
+		{spanName: "tools/caller", wantSampled: false},
+		{spanName: "GET /health", wantSampled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spanName, func(t *testing.T) {
+			t.Parallel()
+
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+				Name:          tt.spanName,
+			})
+			assert.Equal(t, tt.wantSampled, result.Decision == sdktrace.RecordAndSample)
+		})
+	}
+}
+
+func TestNewSampler_FallsBackToGlobalRate(t *testing.T) {
+	t.Parallel()
+
+	sampler := newSampler(1.0, map[string]float64{"ping": 0.0})
+
+	for _, name := range []string{"tools/call fetch", "resources/read", "GET /health"} {
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       trace.TraceID{0x01},
+			Name:          name,
+		})
+		assert.Equal(t, sdktrace.RecordAndSample, result.Decision, name)
+	}
+
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       trace.TraceID{0x01},
+		Name:          "ping",
+	})
+	assert.Equal(t, sdktrace.Drop, result.Decision)
+}
+
+func TestNewSampler_FollowsSampledParent(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(newSampler(1.0, map[string]float64{"ping": 0.0})),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "tools/call fetch")
+	_, child := tracer.Start(ctx, "ping")
+	child.End()
+	parent.End()
+
+	_, root := tracer.Start(context.Background(), "ping")
+	root.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2, "only the root ping span should be dropped")
+	assert.Equal(t, "ping", ended[0].Name())
+	assert.Equal(t, "tools/call fetch", ended[1].Name())
+}
+
+func TestNewSampler_Description(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.5)).Description(),
+		newSampler(0.5, nil).Description(), "without overrides the sampler is unchanged")
+	assert.Contains(t, newSampler(0.5, map[string]float64{"ping": 0.01}).Description(), "ping=TraceIDRatioBased{0.01}")
+}
@@ -5,12 +5,20 @@ package otlp
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
@@ -218,3 +226,56 @@ func TestNewTracerProviderWithShutdown(t *testing.T) {
 		})
 	}
 }
+
+// TestCreateTraceExporter_AppliesHeadersAndCACert exports a span to a TLS
+// collector whose certificate is only trusted through CACertPath, and checks
+// that the configured headers arrive with the request.
+func TestCreateTraceExporter_AppliesHeadersAndCACert(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan http.Header, 1)
+	collector := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case received <- r.Header.Clone():
+		default:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(collector.Close)
+
+	caCertPath := filepath.Join(t.TempDir(), "collector-ca.pem")
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: collector.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertPath, caCertPEM, 0o600))
+
+	endpoint := strings.TrimPrefix(collector.URL, "https://")
+	spans := tracetest.SpanStubs{{Name: "test-span"}}.Snapshots()
+
+	t.Run("headers and CA certificate are applied", func(t *testing.T) {
+		t.Parallel()
+
+		exporter, err := createTraceExporter(t.Context(), Config{
+			Endpoint:   endpoint,
+			Headers:    map[string]string{"x-api-key": "secret"},
+			CACertPath: caCertPath,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = exporter.Shutdown(context.Background()) })
+
+		require.NoError(t, exporter.ExportSpans(t.Context(), spans))
+		headers := <-received
+		assert.Equal(t, "secret", headers.Get("x-api-key"))
+	})
+
+	t.Run("collector certificate is rejected without the CA", func(t *testing.T) {
+		t.Parallel()
+
+		exporter, err := createTraceExporter(t.Context(), Config{Endpoint: endpoint})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = exporter.Shutdown(context.Background()) })
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		err = exporter.ExportSpans(ctx, spans)
+		require.Error(t, err)
+	})
+}
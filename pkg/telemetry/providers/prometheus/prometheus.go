@@ -5,6 +5,8 @@
 package prometheus
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 
@@ -21,6 +23,14 @@ type Config struct {
 	EnableMetricsPath bool
 	// IncludeRuntimeMetrics adds Go runtime metrics to the registry
 	IncludeRuntimeMetrics bool
+	// BasicAuth, when set, requires HTTP basic authentication on the handler
+	BasicAuth *BasicAuth
+}
+
+// BasicAuth holds the credentials required to scrape the metrics handler
+type BasicAuth struct {
+	Username string
+	Password string
 }
 
 // NewReader creates a Prometheus metric reader and HTTP handler for use in a unified meter provider
@@ -45,10 +55,43 @@ func NewReader(config Config) (sdkmetric.Reader, http.Handler, error) {
 	}
 
 	// Create HTTP handler
-	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+	var handler http.Handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{
 		ErrorHandling: promhttp.ContinueOnError,
 		ErrorLog:      nil,
 	})
+	if config.BasicAuth != nil {
+		if config.BasicAuth.Username == "" || config.BasicAuth.Password == "" {
+			return nil, nil, fmt.Errorf("prometheus basic auth requires both a username and a password")
+		}
+		handler = requireBasicAuth(handler, *config.BasicAuth)
+	}
 
 	return exporter, handler, nil
 }
+
+// requireBasicAuth wraps next so that only requests carrying the expected
+// basic auth credentials reach it. Credentials are compared as hashes in
+// constant time so that neither their content nor their length leaks through
+// response timing.
+func requireBasicAuth(next http.Handler, credentials BasicAuth) http.Handler {
+	wantUsername := sha256.Sum256([]byte(credentials.Username))
+	wantPassword := sha256.Sum256([]byte(credentials.Password))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if ok {
+			gotUsername := sha256.Sum256([]byte(username))
+			gotPassword := sha256.Sum256([]byte(password))
+			// Evaluate both comparisons so the response time does not reveal
+			// which of the two was wrong.
+			usernameMatch := subtle.ConstantTimeCompare(gotUsername[:], wantUsername[:])
+			passwordMatch := subtle.ConstantTimeCompare(gotPassword[:], wantPassword[:])
+			if usernameMatch&passwordMatch == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="metrics", charset="UTF-8"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -47,6 +49,15 @@ func TestNewReader(t *testing.T) {
 			checkHandler:        true,
 			checkRuntimeMetrics: false,
 		},
+		{
+			name: "basic auth without password",
+			config: Config{
+				EnableMetricsPath: true,
+				BasicAuth:         &BasicAuth{Username: "prometheus"},
+			},
+			wantErr: true,
+			errMsg:  "requires both a username and a password",
+		},
 		{
 			name: "metrics path not enabled",
 			config: Config{
@@ -143,3 +154,102 @@ func TestNewReader_Integration(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Contains(t, rec.Body.String(), "test_reader_counter")
 }
+
+// newTestHandler returns a metrics handler with a single counter recorded.
+func newTestHandler(t *testing.T, config Config) http.Handler {
+	t.Helper()
+
+	reader, handler, err := NewReader(config)
+	require.NoError(t, err)
+
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = meterProvider.Shutdown(context.Background()) })
+
+	counter, err := meterProvider.Meter("test").Int64Counter("test_requests")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3)
+
+	return handler
+}
+
+func TestNewReader_ServesPrometheusTextFormat(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, Config{EnableMetricsPath: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(rec.Body)
+	require.NoError(t, err, "the response must be valid Prometheus text exposition format")
+
+	family, ok := families["test_requests_total"]
+	require.True(t, ok, "the OTEL counter must be exposed")
+	require.Len(t, family.GetMetric(), 1)
+	assert.Equal(t, 3.0, family.GetMetric()[0].GetCounter().GetValue())
+}
+
+func TestNewReader_BasicAuth(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, Config{
+		EnableMetricsPath: true,
+		BasicAuth:         &BasicAuth{Username: "prometheus", Password: "s3cret"},
+	})
+
+	tests := []struct {
+		name     string
+		setAuth  func(r *http.Request)
+		wantCode int
+	}{
+		{
+			name:     "no credentials",
+			setAuth:  func(*http.Request) {},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "wrong password",
+			setAuth:  func(r *http.Request) { r.SetBasicAuth("prometheus", "wrong") },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "wrong username",
+			setAuth:  func(r *http.Request) { r.SetBasicAuth("admin", "s3cret") },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "bearer token",
+			setAuth:  func(r *http.Request) { r.Header.Set("Authorization", "Bearer s3cret") },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "valid credentials",
+			setAuth:  func(r *http.Request) { r.SetBasicAuth("prometheus", "s3cret") },
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			tt.setAuth(req)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode == http.StatusUnauthorized {
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+				assert.NotContains(t, rec.Body.String(), "test_requests")
+			} else {
+				assert.Contains(t, rec.Body.String(), "test_requests_total{")
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 	tracenoop "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/stacklok/toolhive/pkg/telemetry/providers/prometheus"
 )
 
 // Config holds the telemetry configuration for all providers.
@@ -36,8 +38,12 @@ type Config struct {
 	MetricsEnabled bool              // MetricsEnabled controls whether metrics are enabled for OTLP
 	SamplingRate   float64           // SamplingRate controls trace sampling (0.0 to 1.0)
 
+	// SamplingOverrides maps operation (span) names to the sampling rate used for them instead of SamplingRate
+	SamplingOverrides map[string]float64
+
 	// Prometheus configuration
-	EnablePrometheusMetricsPath bool // EnablePrometheusMetricsPath enables Prometheus /metrics endpoint
+	EnablePrometheusMetricsPath bool                  // EnablePrometheusMetricsPath enables Prometheus /metrics endpoint
+	PrometheusBasicAuth         *prometheus.BasicAuth // PrometheusBasicAuth, when set, protects the /metrics endpoint with basic auth
 
 	// TLS configuration
 	CACertPath string // CACertPath is the file path to a custom CA certificate bundle for the OTLP endpoint
@@ -132,6 +138,22 @@ func WithSamplingRate(samplingRate float64) ProviderOption {
 	}
 }
 
+// WithSamplingOverrides sets per-operation sampling rates
+func WithSamplingOverrides(overrides map[string]float64) ProviderOption {
+	return func(config *Config) error {
+		config.SamplingOverrides = overrides
+		return nil
+	}
+}
+
+// WithPrometheusBasicAuth requires basic auth with the given credentials on the /metrics endpoint
+func WithPrometheusBasicAuth(username, password string) ProviderOption {
+	return func(config *Config) error {
+		config.PrometheusBasicAuth = &prometheus.BasicAuth{Username: username, Password: password}
+		return nil
+	}
+}
+
 // WithEnablePrometheusMetricsPath sets the enable prometheus metrics path flag
 func WithEnablePrometheusMetricsPath(enablePrometheusMetricsPath bool) ProviderOption {
 	return func(config *Config) error {
@@ -56,14 +56,16 @@ func (*OTLPTracerStrategy) CreateTracerProvider(
 	slog.Debug("creating OTLP tracer provider",
 		"endpoint", config.OTLPEndpoint,
 		"sampling_rate", config.SamplingRate,
+		"sampling_overrides", config.SamplingOverrides,
 		"extra_processors", len(config.ExtraSpanProcessors))
 
 	otlpConfig := otlp.Config{
-		Endpoint:     config.OTLPEndpoint,
-		Headers:      config.Headers,
-		Insecure:     config.Insecure,
-		SamplingRate: config.SamplingRate,
-		CACertPath:   config.CACertPath,
+		Endpoint:          config.OTLPEndpoint,
+		Headers:           config.Headers,
+		Insecure:          config.Insecure,
+		SamplingRate:      config.SamplingRate,
+		SamplingOverrides: config.SamplingOverrides,
+		CACertPath:        config.CACertPath,
 	}
 
 	provider, shutdown, err := otlp.NewTracerProviderWithShutdown(ctx, otlpConfig, res, config.ExtraSpanProcessors...)
@@ -146,6 +148,7 @@ func (s *UnifiedMeterStrategy) CreateMeterProvider(
 		promConfig := prometheus.Config{
 			EnableMetricsPath:     true,
 			IncludeRuntimeMetrics: true,
+			BasicAuth:             config.PrometheusBasicAuth,
 		}
 		reader, handler, err := prometheus.NewReader(promConfig)
 		if err != nil {
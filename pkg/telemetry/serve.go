@@ -36,12 +36,19 @@ func NewServeProvider(ctx context.Context) (provider *Provider, otelEnabled bool
 		return nil, false, nil
 	}
 
+	headers, err := otelCfg.ResolveHeaders()
+	if err != nil {
+		return nil, false, err
+	}
+
 	telemetryCfg := Config{
 		ServiceName:                 "thv-api",
 		Endpoint:                    otelCfg.Endpoint,
 		TracingEnabled:              otelCfg.TracingEnabled != nil && *otelCfg.TracingEnabled,
 		MetricsEnabled:              otelCfg.MetricsEnabled != nil && *otelCfg.MetricsEnabled,
+		Headers:                     headers,
 		Insecure:                    otelCfg.Insecure,
+		CACertPath:                  otelCfg.CACertPath,
 		EnablePrometheusMetricsPath: otelCfg.EnablePrometheusMetricsPath,
 		EnvironmentVariables:        otelCfg.EnvVars,
 	}
@@ -51,6 +58,13 @@ func NewServeProvider(ctx context.Context) (provider *Provider, otelEnabled bool
 	if telemetryCfg.SamplingRate == "" {
 		telemetryCfg.SamplingRate = "0.05"
 	}
+	telemetryCfg.SetSamplingOverridesFromFloat(otelCfg.SamplingOverrides)
+	if auth := otelCfg.PrometheusBasicAuth; auth != nil {
+		telemetryCfg.PrometheusBasicAuth = &PrometheusBasicAuth{
+			Username:     auth.Username,
+			PasswordFile: auth.PasswordFile,
+		}
+	}
 
 	// No OTLP endpoint but registered processors are active (e.g. a Sentry bridge).
 	// Force tracing on with 100% OTEL sampling so every span reaches the processors.
@@ -63,6 +77,7 @@ func NewServeProvider(ctx context.Context) (provider *Provider, otelEnabled bool
 	if otelCfg.Endpoint == "" && hasRegisteredProcessors {
 		telemetryCfg.TracingEnabled = true
 		telemetryCfg.SamplingRate = "1.0"
+		telemetryCfg.SamplingOverrides = nil
 	}
 
 	p, err := NewProvider(ctx, telemetryCfg)
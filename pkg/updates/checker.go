@@ -210,6 +210,7 @@ func (d *defaultUpdateChecker) CheckLatestVersion() error {
 		return fmt.Errorf("failed to acquire lock on update file: %w", err)
 	}
 	defer lockfile.ReleaseTrackedLock(lockPath, lockFile)
+	lockfile.RecordOwner(lockPath)
 
 	//nolint:gosec // G703 - path from trusted app config directory
 	if err := os.WriteFile(d.updateFilePath, updatedData, 0600); err != nil {
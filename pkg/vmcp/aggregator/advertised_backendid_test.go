@@ -193,7 +193,8 @@ func TestDefaultAggregator_AdvertisedCapabilitiesCarryBackendID(t *testing.T) {
 			}
 			resolver := resolverForStrategy(t, tt.strategy, tt.priorityOrder, tt.wlConfigs)
 
-			agg := NewDefaultAggregator(mockClient, resolver, aggCfg, nil)
+			agg, err := NewDefaultAggregator(mockClient, resolver, aggCfg, nil, nil)
+			require.NoError(t, err)
 			result, err := agg.AggregateCapabilities(context.Background(), backends)
 			require.NoError(t, err)
 			require.NotNil(t, result)
@@ -278,7 +279,8 @@ func TestDefaultAggregator_AdvertisingFilterPreservesBackendID(t *testing.T) {
 			mockClient := mocks.NewMockBackendClient(ctrl)
 			expectListCapabilities(mockClient, capsByID)
 
-			agg := NewDefaultAggregator(mockClient, NewPrefixConflictResolver("{workload}_"), tt.aggCfg, nil)
+			agg, err := NewDefaultAggregator(mockClient, NewPrefixConflictResolver("{workload}_"), tt.aggCfg, nil, nil)
+			require.NoError(t, err)
 			result, err := agg.AggregateCapabilities(context.Background(), []vmcp.Backend{
 				newTestBackend(backendID, withBackendName("Backend")),
 			})
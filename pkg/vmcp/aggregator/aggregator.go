@@ -189,6 +189,10 @@ type AggregationMetadata struct {
 
 	// ConflictStrategy is the strategy used for conflict resolution.
 	ConflictStrategy vmcp.ConflictResolutionStrategy
+
+	// UnreachableBackends lists the IDs of backends whose capability query
+	// failed, sorted. They contribute no capabilities to this view.
+	UnreachableBackends []string
 }
 
 // ConflictResolver handles tool name conflicts across backends.
@@ -230,6 +234,17 @@ type CacheInvalidator interface {
 	InvalidateAll()
 }
 
+// BackendCacheInvalidator is optionally implemented by an Aggregator that
+// caches capabilities per backend. InvalidateBackend drops everything cached
+// about one backend, for every caller, so the next aggregation that includes
+// it queries it again. The core calls it when a backend's health drops, since
+// a backend that comes back may have been restarted with different
+// capabilities.
+type BackendCacheInvalidator interface {
+	// InvalidateBackend drops every cached entry that includes backendID.
+	InvalidateBackend(backendID string)
+}
+
 // Common aggregation errors.
 var (
 	// ErrNoBackendsFound indicates no backends were discovered.
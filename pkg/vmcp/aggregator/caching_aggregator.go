@@ -7,19 +7,25 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"io"
+	"maps"
 	"sort"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 
 	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/headerforward"
+	healthcontext "github.com/stacklok/toolhive/pkg/vmcp/health/context"
 )
 
 // capabilityCacheMaxEntries bounds the per-identity capability cache so it cannot grow
-// without limit (one entry per distinct identity + forwarded-credential + backend-set key).
+// without limit (one entry per distinct identity + forwarded-credential key).
 // Beyond it, the LRU evicts the least-recently-used entry. 1024 distinct active keys per
 // node is generous for a vMCP instance; tune if real workloads exceed it.
 const capabilityCacheMaxEntries = 1024
@@ -40,9 +46,9 @@ const capabilityCacheMaxEntries = 1024
 //
 // Security: backend enumeration is identity-dependent — what each backend returns depends on
 // the credential presented to it — so the cache key MUST include the identity and the
-// forwarded credentials, not just the backend set. Keying on (subject, forwarded headers,
-// backend IDs) ensures one caller's capability view is never served to another, while a
-// single caller's view is shared across their sessions. The key is a SHA-256 digest, so raw
+// forwarded credentials. Keying on (subject, forwarded headers) ensures one caller's
+// capability view is never served to another, while a single caller's view is shared across
+// their sessions; a view is only served restricted to the backends requested. The key is a SHA-256 digest, so raw
 // credential values are not retained as map keys. The cache is node-local and never persisted
 // (it would be a credentialed view in shared state otherwise).
 type cachingAggregator struct {
@@ -52,43 +58,72 @@ type cachingAggregator struct {
 
 	ttl   time.Duration
 	cache *lru.Cache[string, cacheEntry]
+
+	// lookups counts cache lookups by result (hit or miss).
+	lookups metric.Int64Counter
 }
 
 type cacheEntry struct {
 	caps *AggregatedCapabilities
 	at   time.Time
+	// backends maps the ID of each backend caps was aggregated from to the
+	// fingerprint of its config at the time (see backendFingerprint).
+	backends map[string]string
 }
 
 // NewCachingAggregator wraps next so AggregateCapabilities results are memoized per identity
 // for ttl, backed by a size-bounded LRU. A ttl <= 0 disables caching (next is returned
 // unwrapped) so a misconfiguration cannot silently serve permanently-stale capabilities. A
 // nil next is returned as-is so the downstream nil-aggregator validation (core.New) still
-// fires rather than being masked by a non-nil wrapper.
-func NewCachingAggregator(next Aggregator, ttl time.Duration) Aggregator {
+// fires rather than being masked by a non-nil wrapper. meterProvider records cache hits and
+// misses (pass nil for no metrics).
+func NewCachingAggregator(next Aggregator, ttl time.Duration, meterProvider metric.MeterProvider) (Aggregator, error) {
 	if next == nil || ttl <= 0 {
-		return next
+		return next, nil
 	}
 	cache, err := lru.New[string, cacheEntry](capabilityCacheMaxEntries)
 	if err != nil {
 		// lru.New only errors on a non-positive size, which is a positive constant here, so
 		// this is unreachable; degrade to the uncached aggregator rather than panicking.
-		return next
+		return next, nil
+	}
+	if meterProvider == nil {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+	lookups, err := meterProvider.Meter(vmcp.InstrumentationName).Int64Counter(
+		"toolhive_vmcp_capability_cache_lookups",
+		metric.WithDescription("Total number of capability cache lookups by result (hit or miss)"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capability cache lookups counter: %w", err)
 	}
-	return &cachingAggregator{Aggregator: next, ttl: ttl, cache: cache}
+	return &cachingAggregator{Aggregator: next, ttl: ttl, cache: cache, lookups: lookups}, nil
 }
 
 // AggregateCapabilities returns a cached view when a fresh entry exists for the caller's
-// identity + forwarded credentials + backend set, and otherwise sweeps the backends (via the
-// wrapped aggregator) and caches the result. Errors are never cached. The returned value is
-// treated as immutable by the core (it derives fresh per-call routers from it), so the cached
-// pointer is shared rather than deep-copied.
+// identity + forwarded credentials that covers the requested backend set, and otherwise sweeps
+// the backends (via the wrapped aggregator) and caches the result. Errors are never cached. The
+// returned value is treated as immutable by the core (it derives fresh per-call routers from
+// it), so the cached pointer is shared rather than deep-copied.
+//
+// The core passes only the currently healthy backends, so a backend's health flipping changes
+// the requested set. When the set shrinks, the cached view is pruned to the remaining backends
+// instead of re-sweeping them all. The core also calls InvalidateBackend for every backend whose
+// health changed (see invalidateHealthTransitions in the core package), so a backend that
+// recovers is no longer covered by any entry and is queried again rather than served what it
+// advertised before it failed.
 func (c *cachingAggregator) AggregateCapabilities(
 	ctx context.Context, backends []vmcp.Backend,
 ) (*AggregatedCapabilities, error) {
-	key := cacheKey(ctx, backends)
+	key := cacheKey(ctx)
+	requested := backendFingerprints(backends)
 	if e, ok := c.cache.Get(key); ok && time.Since(e.at) < c.ttl {
-		return e.caps, nil
+		if caps, ok := e.viewFor(requested); ok {
+			c.lookups.Add(ctx, 1, metric.WithAttributes(attribute.String("cache.result", "hit")))
+			return caps, nil
+		}
 	}
+	c.lookups.Add(ctx, 1, metric.WithAttributes(attribute.String("cache.result", "miss")))
 
 	// Miss/expiry: sweep with the lock released (Get/Add are individually locked) so callers
 	// with different keys are not serialized behind one backend sweep. Concurrent misses for
@@ -97,10 +132,104 @@ func (c *cachingAggregator) AggregateCapabilities(
 	if err != nil {
 		return nil, err
 	}
-	c.cache.Add(key, cacheEntry{caps: caps, at: time.Now()})
+	c.cache.Add(key, cacheEntry{caps: caps, at: time.Now(), backends: requested})
 	return caps, nil
 }
 
+// viewFor returns the entry's capabilities restricted to the requested backends, or false when
+// the entry cannot serve them without a sweep: it lacks one of the requested backends or has it
+// with an older config, or the view would need pruning but was not aggregated with prefix
+// conflict resolution. Under the priority and manual strategies a capability dropped as a
+// conflict may belong to a backend that remains, so removing the winner's backend cannot be
+// done by pruning alone.
+func (e cacheEntry) viewFor(requested map[string]string) (*AggregatedCapabilities, bool) {
+	for id, fingerprint := range requested {
+		if cached, ok := e.backends[id]; !ok || cached != fingerprint {
+			return nil, false
+		}
+	}
+	if len(requested) == len(e.backends) {
+		return e.caps, true
+	}
+	if e.caps.Metadata == nil || e.caps.Metadata.ConflictStrategy != vmcp.ConflictStrategyPrefix {
+		return nil, false
+	}
+	return pruneToBackends(e.caps, requested), true
+}
+
+// pruneToBackends returns a copy of caps without the capabilities and routes of backends not in
+// keep. Composite tools and the session-level flags are carried over unchanged.
+func pruneToBackends(caps *AggregatedCapabilities, keep map[string]string) *AggregatedCapabilities {
+	kept := func(backendID string) bool {
+		_, ok := keep[backendID]
+		return ok
+	}
+
+	pruned := &AggregatedCapabilities{
+		Tools:             filterByBackend(caps.Tools, func(t vmcp.Tool) bool { return kept(t.BackendID) }),
+		CompositeTools:    caps.CompositeTools,
+		Resources:         filterByBackend(caps.Resources, func(r vmcp.Resource) bool { return kept(r.BackendID) }),
+		ResourceTemplates: filterByBackend(caps.ResourceTemplates, func(r vmcp.ResourceTemplate) bool { return kept(r.BackendID) }),
+		Prompts:           filterByBackend(caps.Prompts, func(p vmcp.Prompt) bool { return kept(p.BackendID) }),
+		SupportsLogging:   caps.SupportsLogging,
+		SupportsSampling:  caps.SupportsSampling,
+	}
+
+	if rt := caps.RoutingTable; rt != nil {
+		pruned.RoutingTable = &vmcp.RoutingTable{
+			Tools:             filterRoutes(rt.Tools, kept),
+			Resources:         filterRoutes(rt.Resources, kept),
+			ResourceTemplates: filterRoutes(rt.ResourceTemplates, kept),
+			Prompts:           filterRoutes(rt.Prompts, kept),
+		}
+	}
+
+	metadata := *caps.Metadata
+	metadata.BackendCount = len(keep)
+	metadata.ToolCount = len(pruned.Tools) + len(pruned.CompositeTools)
+	metadata.ResourceCount = len(pruned.Resources)
+	metadata.ResourceTemplateCount = len(pruned.ResourceTemplates)
+	metadata.PromptCount = len(pruned.Prompts)
+	metadata.UnreachableBackends = filterByBackend(caps.Metadata.UnreachableBackends, kept)
+	pruned.Metadata = &metadata
+
+	return pruned
+}
+
+func filterByBackend[T any](items []T, keep func(T) bool) []T {
+	if items == nil {
+		return nil
+	}
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func filterRoutes(routes map[string]*vmcp.BackendTarget, keep func(string) bool) map[string]*vmcp.BackendTarget {
+	if routes == nil {
+		return nil
+	}
+	filtered := make(map[string]*vmcp.BackendTarget, len(routes))
+	for name, target := range routes {
+		if keep(target.WorkloadID) {
+			filtered[name] = target
+		}
+	}
+	return filtered
+}
+
+func backendFingerprints(backends []vmcp.Backend) map[string]string {
+	fingerprints := make(map[string]string, len(backends))
+	for i := range backends {
+		fingerprints[backends[i].ID] = backendFingerprint(&backends[i])
+	}
+	return fingerprints
+}
+
 // Compile-time assertion: cachingAggregator implements CacheInvalidator.
 var _ CacheInvalidator = (*cachingAggregator)(nil)
 
@@ -110,14 +239,56 @@ var _ CacheInvalidator = (*cachingAggregator)(nil)
 // for why this is coarse (whole-cache) rather than per-backend.
 func (c *cachingAggregator) InvalidateAll() {
 	c.cache.Purge()
+	if next, ok := c.Aggregator.(CacheInvalidator); ok {
+		next.InvalidateAll()
+	}
+}
+
+// Compile-time assertion: cachingAggregator implements BackendCacheInvalidator.
+var _ BackendCacheInvalidator = (*cachingAggregator)(nil)
+
+// InvalidateBackend implements BackendCacheInvalidator. Every entry that includes backendID is
+// replaced by its view without that backend, keeping its age, or dropped when the view cannot
+// be pruned (see viewFor). The next request that includes the backend then misses and sweeps,
+// and the wrapped aggregator, having dropped its own cached results for the backend too, queries
+// it again.
+func (c *cachingAggregator) InvalidateBackend(backendID string) {
+	for _, key := range c.cache.Keys() {
+		e, ok := c.cache.Peek(key)
+		if !ok {
+			continue
+		}
+		if _, covered := e.backends[backendID]; !covered {
+			continue
+		}
+		remaining := maps.Clone(e.backends)
+		delete(remaining, backendID)
+		caps, ok := e.viewFor(remaining)
+		if !ok {
+			c.cache.Remove(key)
+			continue
+		}
+		c.cache.Add(key, cacheEntry{caps: caps, at: e.at, backends: remaining})
+	}
+	if next, ok := c.Aggregator.(BackendCacheInvalidator); ok {
+		next.InvalidateBackend(backendID)
+	}
 }
 
 // cacheKey derives a collision-resistant key from the inputs that drive backend enumeration:
-// the caller's subject, the forwarded headers (passthrough credentials/scopes), and the
-// backend ID set. Hashing keeps raw credential values out of the cache keys.
-func cacheKey(ctx context.Context, backends []vmcp.Backend) string {
+// the caller's subject and the forwarded headers (passthrough credentials/scopes). A health
+// check aggregates without authenticating to identity-bound backends, so its view gets a key of
+// its own and is never served to a client. The backend set is not part of the key; each entry
+// records the set it covers (see viewFor). Hashing keeps raw credential values out of the cache
+// keys.
+func cacheKey(ctx context.Context) string {
 	h := sha256.New()
 
+	if healthcontext.IsHealthCheck(ctx) {
+		_, _ = io.WriteString(h, "health-check")
+	}
+	_, _ = h.Write([]byte{0})
+
 	if id, ok := auth.IdentityFromContext(ctx); ok && id != nil {
 		_, _ = io.WriteString(h, id.Subject)
 	}
@@ -135,17 +306,6 @@ func cacheKey(ctx context.Context, backends []vmcp.Backend) string {
 		_, _ = io.WriteString(h, fwd[k])
 		_, _ = h.Write([]byte{0})
 	}
-	_, _ = h.Write([]byte{0})
-
-	ids := make([]string, 0, len(backends))
-	for _, b := range backends {
-		ids = append(ids, b.ID)
-	}
-	sort.Strings(ids)
-	for _, id := range ids {
-		_, _ = io.WriteString(h, id)
-		_, _ = h.Write([]byte{0})
-	}
 
 	return hex.EncodeToString(h.Sum(nil))
 }
@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stacklok/toolhive/pkg/auth"
@@ -36,7 +38,8 @@ func TestCachingAggregator_CacheHitWithinTTL(t *testing.T) {
 	caps := &aggregator.AggregatedCapabilities{}
 	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).Return(caps, nil).Times(1)
 
-	c := aggregator.NewCachingAggregator(mock, time.Hour)
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
 	ctx := ctxWithSubject("alice")
 
 	first, err := c.AggregateCapabilities(ctx, testBackends)
@@ -58,9 +61,10 @@ func TestCachingAggregator_KeyedByIdentityAndHeaders(t *testing.T) {
 	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).
 		Return(&aggregator.AggregatedCapabilities{}, nil).Times(3)
 
-	c := aggregator.NewCachingAggregator(mock, time.Hour)
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
 
-	_, err := c.AggregateCapabilities(ctxWithSubject("alice"), testBackends)
+	_, err = c.AggregateCapabilities(ctxWithSubject("alice"), testBackends)
 	require.NoError(t, err)
 	_, err = c.AggregateCapabilities(ctxWithSubject("bob"), testBackends)
 	require.NoError(t, err)
@@ -78,10 +82,11 @@ func TestCachingAggregator_TTLExpiry(t *testing.T) {
 	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).
 		Return(&aggregator.AggregatedCapabilities{}, nil).Times(2)
 
-	c := aggregator.NewCachingAggregator(mock, 20*time.Millisecond)
+	c, err := aggregator.NewCachingAggregator(mock, 20*time.Millisecond, nil)
+	require.NoError(t, err)
 	ctx := ctxWithSubject("alice")
 
-	_, err := c.AggregateCapabilities(ctx, testBackends)
+	_, err = c.AggregateCapabilities(ctx, testBackends)
 	require.NoError(t, err)
 	time.Sleep(40 * time.Millisecond)
 	_, err = c.AggregateCapabilities(ctx, testBackends)
@@ -95,13 +100,18 @@ func TestCachingAggregator_DisabledWhenTTLNonPositive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mock := mocks.NewMockAggregator(ctrl)
 
-	assert.Same(t, mock, aggregator.NewCachingAggregator(mock, 0))
-	assert.Same(t, mock, aggregator.NewCachingAggregator(mock, -time.Second))
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		c, err := aggregator.NewCachingAggregator(mock, ttl, nil)
+		require.NoError(t, err)
+		assert.Same(t, mock, c)
+	}
 
 	// A nil aggregator is returned as-is so the downstream nil check (core.New) still fires
 	// rather than being masked by a non-nil caching wrapper.
 	var nilAgg aggregator.Aggregator
-	assert.Nil(t, aggregator.NewCachingAggregator(nilAgg, time.Hour))
+	c, err := aggregator.NewCachingAggregator(nilAgg, time.Hour, nil)
+	require.NoError(t, err)
+	assert.Nil(t, c)
 }
 
 // TestCachingAggregator_InvalidateAll verifies the CacheInvalidator seam
@@ -116,12 +126,13 @@ func TestCachingAggregator_InvalidateAll(t *testing.T) {
 	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).
 		Return(&aggregator.AggregatedCapabilities{}, nil).Times(2)
 
-	c := aggregator.NewCachingAggregator(mock, time.Hour)
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
 	invalidator, ok := c.(aggregator.CacheInvalidator)
 	require.True(t, ok, "cachingAggregator must implement CacheInvalidator")
 
 	ctx := ctxWithSubject("alice")
-	_, err := c.AggregateCapabilities(ctx, testBackends)
+	_, err = c.AggregateCapabilities(ctx, testBackends)
 	require.NoError(t, err)
 
 	invalidator.InvalidateAll()
@@ -144,11 +155,220 @@ func TestCachingAggregator_ErrorNotCached(t *testing.T) {
 			Return(&aggregator.AggregatedCapabilities{}, nil),
 	)
 
-	c := aggregator.NewCachingAggregator(mock, time.Hour)
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
 	ctx := ctxWithSubject("alice")
 
-	_, err := c.AggregateCapabilities(ctx, testBackends)
+	_, err = c.AggregateCapabilities(ctx, testBackends)
 	require.Error(t, err)
 	_, err = c.AggregateCapabilities(ctx, testBackends)
 	require.NoError(t, err, "the error must not have been cached")
 }
+
+// TestCachingAggregator_RecordsLookups: each lookup increments the cache lookups counter,
+// labeled hit or miss.
+func TestCachingAggregator_RecordsLookups(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mock := mocks.NewMockAggregator(ctrl)
+	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).
+		Return(&aggregator.AggregatedCapabilities{}, nil).Times(1)
+
+	reader := sdkmetric.NewManualReader()
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour,
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	ctx := ctxWithSubject("alice")
+
+	for range 3 {
+		_, err := c.AggregateCapabilities(ctx, testBackends)
+		require.NoError(t, err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	results := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "toolhive_vmcp_capability_cache_lookups" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, dp := range sum.DataPoints {
+				v, _ := dp.Attributes.Value("cache.result")
+				results[v.AsString()] += dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"miss": 1, "hit": 2}, results)
+}
+
+// twoBackendCaps returns a view aggregated from b1 and b2 with the given conflict strategy,
+// with one tool, resource, and prompt per backend.
+func twoBackendCaps(strategy vmcp.ConflictResolutionStrategy) *aggregator.AggregatedCapabilities {
+	caps := &aggregator.AggregatedCapabilities{
+		CompositeTools: []vmcp.Tool{{Name: "workflow"}},
+		RoutingTable: &vmcp.RoutingTable{
+			Tools:     map[string]*vmcp.BackendTarget{},
+			Resources: map[string]*vmcp.BackendTarget{},
+			Prompts:   map[string]*vmcp.BackendTarget{},
+		},
+		Metadata: &aggregator.AggregationMetadata{BackendCount: 2, ConflictStrategy: strategy},
+	}
+	for _, id := range []string{"b1", "b2"} {
+		target := &vmcp.BackendTarget{WorkloadID: id}
+		caps.Tools = append(caps.Tools, vmcp.Tool{Name: id + "_echo", BackendID: id})
+		caps.Resources = append(caps.Resources, vmcp.Resource{URI: "file:///" + id, BackendID: id})
+		caps.Prompts = append(caps.Prompts, vmcp.Prompt{Name: id + "_greet", BackendID: id})
+		caps.RoutingTable.Tools[id+"_echo"] = target
+		caps.RoutingTable.Resources["file:///"+id] = target
+		caps.RoutingTable.Prompts[id+"_greet"] = target
+	}
+	return caps
+}
+
+// TestCachingAggregator_HealthChangePrunesCachedView: when a backend drops out of the requested
+// set (as the core does when it turns unhealthy), the cached view is pruned to the remaining
+// backends instead of re-sweeping them, and the backend's return within the TTL is served from
+// the same entry. Only a backend the entry never covered forces a sweep.
+func TestCachingAggregator_HealthChangePrunesCachedView(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mock := mocks.NewMockAggregator(ctrl)
+	caps := twoBackendCaps(vmcp.ConflictStrategyPrefix)
+	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).Return(caps, nil).Times(1)
+
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
+	ctx := ctxWithSubject("alice")
+
+	_, err = c.AggregateCapabilities(ctx, testBackends)
+	require.NoError(t, err)
+
+	// b2 turns unhealthy: its capabilities and routes disappear without a sweep.
+	pruned, err := c.AggregateCapabilities(ctx, []vmcp.Backend{{ID: "b1"}})
+	require.NoError(t, err)
+	require.Len(t, pruned.Tools, 1)
+	assert.Equal(t, "b1_echo", pruned.Tools[0].Name)
+	require.Len(t, pruned.Resources, 1)
+	assert.Equal(t, "b1", pruned.Resources[0].BackendID)
+	require.Len(t, pruned.Prompts, 1)
+	assert.Equal(t, "b1", pruned.Prompts[0].BackendID)
+	assert.Equal(t, caps.CompositeTools, pruned.CompositeTools)
+	assert.Contains(t, pruned.RoutingTable.Tools, "b1_echo")
+	assert.NotContains(t, pruned.RoutingTable.Tools, "b2_echo")
+	assert.NotContains(t, pruned.RoutingTable.Resources, "file:///b2")
+	assert.NotContains(t, pruned.RoutingTable.Prompts, "b2_greet")
+	assert.Equal(t, 1, pruned.Metadata.BackendCount)
+	assert.Equal(t, 2, pruned.Metadata.ToolCount)
+
+	// The cached view itself is left intact.
+	assert.Len(t, caps.Tools, 2)
+	assert.Contains(t, caps.RoutingTable.Tools, "b2_echo")
+
+	// b2 recovers: the entry still covers it.
+	recovered, err := c.AggregateCapabilities(ctx, testBackends)
+	require.NoError(t, err)
+	assert.Same(t, caps, recovered)
+}
+
+// TestCachingAggregator_SweepsWhenViewCannotBePruned: a backend the entry has never aggregated,
+// or a smaller set under a strategy where dropping a backend can surface a previously
+// shadowed capability, re-sweeps.
+func TestCachingAggregator_SweepsWhenViewCannotBePruned(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		strategy  vmcp.ConflictResolutionStrategy
+		requested []vmcp.Backend
+	}{
+		{
+			name:      "new backend",
+			strategy:  vmcp.ConflictStrategyPrefix,
+			requested: []vmcp.Backend{{ID: "b1"}, {ID: "b2"}, {ID: "b3"}},
+		},
+		{
+			name:      "priority strategy",
+			strategy:  vmcp.ConflictStrategyPriority,
+			requested: []vmcp.Backend{{ID: "b1"}},
+		},
+		{
+			name:      "manual strategy",
+			strategy:  vmcp.ConflictStrategyManual,
+			requested: []vmcp.Backend{{ID: "b1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mock := mocks.NewMockAggregator(ctrl)
+			mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).
+				Return(twoBackendCaps(tt.strategy), nil).Times(2)
+
+			c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+			require.NoError(t, err)
+			ctx := ctxWithSubject("alice")
+
+			_, err = c.AggregateCapabilities(ctx, testBackends)
+			require.NoError(t, err)
+			_, err = c.AggregateCapabilities(ctx, tt.requested)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestCachingAggregator_InvalidateBackend: invalidating a backend prunes it out of every cached
+// view, so the next request that includes it sweeps again, and the invalidation is forwarded to
+// the wrapped aggregator so that sweep queries the backend rather than its cached capabilities.
+func TestCachingAggregator_InvalidateBackend(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mock := struct {
+		*mocks.MockAggregator
+		*mocks.MockBackendCacheInvalidator
+	}{mocks.NewMockAggregator(ctrl), mocks.NewMockBackendCacheInvalidator(ctrl)}
+	caps := twoBackendCaps(vmcp.ConflictStrategyPrefix)
+	mock.MockAggregator.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).Return(caps, nil).Times(2)
+	mock.MockBackendCacheInvalidator.EXPECT().InvalidateBackend("b2").Times(1)
+
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
+	ctx := ctxWithSubject("alice")
+
+	_, err = c.AggregateCapabilities(ctx, testBackends)
+	require.NoError(t, err)
+
+	c.(aggregator.BackendCacheInvalidator).InvalidateBackend("b2")
+
+	// The rest of the view is still served from the cache.
+	pruned, err := c.AggregateCapabilities(ctx, []vmcp.Backend{{ID: "b1"}})
+	require.NoError(t, err)
+	require.Len(t, pruned.Tools, 1)
+	assert.Equal(t, "b1_echo", pruned.Tools[0].Name)
+
+	_, err = c.AggregateCapabilities(ctx, testBackends)
+	require.NoError(t, err)
+}
+
+// TestCachingAggregator_ConfigChangeSweeps: a backend whose connection config changed since the
+// view was cached is not served from it.
+func TestCachingAggregator_ConfigChangeSweeps(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mock := mocks.NewMockAggregator(ctrl)
+	mock.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).
+		Return(twoBackendCaps(vmcp.ConflictStrategyPrefix), nil).Times(2)
+
+	c, err := aggregator.NewCachingAggregator(mock, time.Hour, nil)
+	require.NoError(t, err)
+	ctx := ctxWithSubject("alice")
+
+	_, err = c.AggregateCapabilities(ctx, testBackends)
+	require.NoError(t, err)
+	_, err = c.AggregateCapabilities(ctx, []vmcp.Backend{{ID: "b1"}, {ID: "b2", BaseURL: "http://moved:8080"}})
+	require.NoError(t, err)
+}
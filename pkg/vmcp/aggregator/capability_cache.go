@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// defaultCapabilityCacheTTL is how long a backend's queried capabilities are
+// reused when the aggregation config does not set CapabilityCacheTTL. It
+// matches the Serve path's aggregated-view TTL, so enabling the per-backend
+// cache does not make any view staler than it already was.
+const defaultCapabilityCacheTTL = 30 * time.Second
+
+// backendCapabilityCacheMaxEntries bounds the per-backend capability cache.
+// Entries are per (identity, backend), so this is larger than the
+// aggregated-view cache's bound.
+const backendCapabilityCacheMaxEntries = 4096
+
+// capabilityCache memoizes QueryCapabilities results per backend, so an
+// aggregation over a changed backend set only queries the backends it has not
+// seen recently. MCP has no capability version or ETag to revalidate against,
+// so entries live for a fixed TTL; InvalidateBackend and InvalidateAll drop
+// them early.
+//
+// Like cachingAggregator, entries are keyed on the caller's identity and
+// forwarded credentials as well as the backend ID, since what a backend
+// advertises depends on who asks. Each entry also records a fingerprint of the
+// backend's connection config, so a backend whose URL, transport, or auth
+// changes is queried again rather than served from its old entry.
+type capabilityCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	entries *lru.Cache[capabilityCacheKey, capabilityCacheEntry]
+}
+
+type capabilityCacheKey struct {
+	identity  string
+	backendID string
+}
+
+type capabilityCacheEntry struct {
+	caps        *BackendCapabilities
+	at          time.Time
+	fingerprint string
+}
+
+// newCapabilityCache returns a cache with the given TTL, or nil when ttl is
+// not positive. A nil cache is valid and caches nothing.
+func newCapabilityCache(ttl time.Duration) *capabilityCache {
+	if ttl <= 0 {
+		return nil
+	}
+	entries, err := lru.New[capabilityCacheKey, capabilityCacheEntry](backendCapabilityCacheMaxEntries)
+	if err != nil {
+		// Unreachable: lru.New only errors on a non-positive size.
+		return nil
+	}
+	return &capabilityCache{ttl: ttl, now: time.Now, entries: entries}
+}
+
+// get returns the cached capabilities of backend for the caller in ctx, if
+// they are fresh and were queried with the backend's current config.
+func (c *capabilityCache) get(ctx context.Context, backend *vmcp.Backend) (*BackendCapabilities, bool) {
+	if c == nil {
+		return nil, false
+	}
+	e, ok := c.entries.Get(capabilityCacheKey{identity: cacheKey(ctx), backendID: backend.ID})
+	if !ok || c.now().Sub(e.at) >= c.ttl || e.fingerprint == "" || e.fingerprint != backendFingerprint(backend) {
+		return nil, false
+	}
+	return e.caps, true
+}
+
+// put caches the capabilities queried from backend for the caller in ctx.
+func (c *capabilityCache) put(ctx context.Context, backend *vmcp.Backend, caps *BackendCapabilities) {
+	if c == nil {
+		return
+	}
+	c.entries.Add(
+		capabilityCacheKey{identity: cacheKey(ctx), backendID: backend.ID},
+		capabilityCacheEntry{caps: caps, at: c.now(), fingerprint: backendFingerprint(backend)},
+	)
+}
+
+// invalidateBackend drops every caller's entry for backendID.
+func (c *capabilityCache) invalidateBackend(backendID string) {
+	if c == nil {
+		return
+	}
+	for _, key := range c.entries.Keys() {
+		if key.backendID == backendID {
+			c.entries.Remove(key)
+		}
+	}
+}
+
+// invalidateAll drops every entry.
+func (c *capabilityCache) invalidateAll() {
+	if c == nil {
+		return
+	}
+	c.entries.Purge()
+}
+
+// backendFingerprint hashes the parts of a backend's config that determine how
+// it is reached and what it is asked as. Health status is deliberately left
+// out: health changes are handled by the callers that filter backends on it.
+func backendFingerprint(backend *vmcp.Backend) string {
+	data, err := json.Marshal(struct {
+		BaseURL       string
+		TransportType string
+		Type          vmcp.BackendType
+		CABundlePath  string
+		CABundleData  []byte
+		AuthConfig    any
+		AuthConfigRef string
+		HeaderForward any
+		Stdio         any
+	}{
+		BaseURL:       backend.BaseURL,
+		TransportType: backend.TransportType,
+		Type:          backend.Type,
+		CABundlePath:  backend.CABundlePath,
+		CABundleData:  backend.CABundleData,
+		AuthConfig:    backend.AuthConfig,
+		AuthConfigRef: backend.AuthConfigRef,
+		HeaderForward: backend.HeaderForward,
+		Stdio:         backend.Stdio,
+	})
+	if err != nil {
+		// An empty fingerprint never matches, so the backend is always queried.
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+)
+
+// newCachingTestAggregator returns a default aggregator over mockClient whose
+// capability cache reads the time from *now.
+func newCachingTestAggregator(t *testing.T, mockClient vmcp.BackendClient, now *time.Time) *defaultAggregator {
+	t.Helper()
+	agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+	require.NoError(t, err)
+	a := agg.(*defaultAggregator)
+	a.capabilities.now = func() time.Time { return *now }
+	return a
+}
+
+func TestDefaultAggregator_CapabilityCache(t *testing.T) {
+	t.Parallel()
+
+	backends := []vmcp.Backend{
+		newTestBackend("backend1"),
+		newTestBackend("backend2", withBackendURL("http://localhost:8081")),
+	}
+	caps := newTestCapabilityList(withTools(newTestTool("tool", "backend")))
+
+	tests := []struct {
+		name string
+		// between runs after the first aggregation and returns the backends
+		// and context for the second.
+		between func(a *defaultAggregator, now *time.Time) ([]vmcp.Backend, context.Context)
+		// requeried is how many backends the second aggregation queries.
+		requeried int
+	}{
+		{
+			name: "second aggregation within the TTL reuses results",
+			between: func(_ *defaultAggregator, now *time.Time) ([]vmcp.Backend, context.Context) {
+				*now = now.Add(defaultCapabilityCacheTTL - time.Second)
+				return backends, context.Background()
+			},
+		},
+		{
+			name: "expired results are queried again",
+			between: func(_ *defaultAggregator, now *time.Time) ([]vmcp.Backend, context.Context) {
+				*now = now.Add(defaultCapabilityCacheTTL)
+				return backends, context.Background()
+			},
+			requeried: 2,
+		},
+		{
+			name: "invalidating a backend queries it again",
+			between: func(a *defaultAggregator, _ *time.Time) ([]vmcp.Backend, context.Context) {
+				a.InvalidateBackend("backend2")
+				return backends, context.Background()
+			},
+			requeried: 1,
+		},
+		{
+			name: "invalidating all queries every backend again",
+			between: func(a *defaultAggregator, _ *time.Time) ([]vmcp.Backend, context.Context) {
+				a.InvalidateAll()
+				return backends, context.Background()
+			},
+			requeried: 2,
+		},
+		{
+			name: "a config change queries the backend again",
+			between: func(_ *defaultAggregator, _ *time.Time) ([]vmcp.Backend, context.Context) {
+				return []vmcp.Backend{
+					backends[0],
+					newTestBackend("backend2", withBackendURL("http://localhost:9090")),
+				}, context.Background()
+			},
+			requeried: 1,
+		},
+		{
+			name: "a health change alone reuses results",
+			between: func(_ *defaultAggregator, _ *time.Time) ([]vmcp.Backend, context.Context) {
+				degraded := backends[1]
+				degraded.HealthStatus = vmcp.BackendDegraded
+				return []vmcp.Backend{backends[0], degraded}, context.Background()
+			},
+		},
+		{
+			name: "results are not shared across identities",
+			between: func(_ *defaultAggregator, _ *time.Time) ([]vmcp.Backend, context.Context) {
+				return backends, auth.WithIdentity(context.Background(),
+					&auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "bob"}})
+			},
+			requeried: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockClient := mocks.NewMockBackendClient(ctrl)
+			mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+				Return(caps, nil).Times(len(backends) + tt.requeried)
+
+			now := time.Now()
+			a := newCachingTestAggregator(t, mockClient, &now)
+
+			_, err := a.AggregateCapabilities(context.Background(), backends)
+			require.NoError(t, err)
+			second, ctx := tt.between(a, &now)
+			result, err := a.AggregateCapabilities(ctx, second)
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.Metadata.BackendCount)
+		})
+	}
+}
+
+func TestDefaultAggregator_CapabilityCacheSkipsFailures(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	backends := []vmcp.Backend{newTestBackend("backend1"), newTestBackend("backend2")}
+	caps := newTestCapabilityList(withTools(newTestTool("tool", "backend1")))
+
+	gomock.InOrder(
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+				if target.WorkloadID == "backend2" {
+					return nil, assert.AnError
+				}
+				return caps, nil
+			}).Times(2),
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(caps, nil).Times(1),
+	)
+
+	now := time.Now()
+	a := newCachingTestAggregator(t, mockClient, &now)
+
+	_, err := a.AggregateCapabilities(context.Background(), backends)
+	require.NoError(t, err)
+	result, err := a.AggregateCapabilities(context.Background(), backends)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Metadata.BackendCount, "a failed query must not be cached")
+}
+
+func TestNewDefaultAggregator_CapabilityCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	agg, err := NewDefaultAggregator(nil, nil,
+		&config.AggregationConfig{CapabilityCacheTTL: config.Duration(time.Minute)}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, agg.(*defaultAggregator).capabilities.ttl)
+
+	agg, err = NewDefaultAggregator(nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, defaultCapabilityCacheTTL, agg.(*defaultAggregator).capabilities.ttl)
+}
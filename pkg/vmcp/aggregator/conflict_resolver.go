@@ -34,12 +34,16 @@ func NewConflictResolver(aggregationConfig *config.AggregationConfig) (ConflictR
 		return NewPrefixConflictResolver(prefixFormat), nil
 
 	case vmcp.ConflictStrategyPriority:
-		if aggregationConfig.ConflictResolutionConfig == nil ||
-			len(aggregationConfig.ConflictResolutionConfig.PriorityOrder) == 0 {
-			return nil, fmt.Errorf("priority strategy requires priority_order in conflict_resolution_config")
+		resConfig := aggregationConfig.ConflictResolutionConfig
+		if resConfig != nil && len(resConfig.BackendPriorities) > 0 {
+			slog.Info("using priority conflict resolution strategy", "priorities", resConfig.BackendPriorities)
+			return NewPriorityConflictResolverFromPriorities(resConfig.BackendPriorities)
 		}
-		slog.Info("using priority conflict resolution strategy", "order", aggregationConfig.ConflictResolutionConfig.PriorityOrder)
-		return NewPriorityConflictResolver(aggregationConfig.ConflictResolutionConfig.PriorityOrder)
+		if resConfig == nil || len(resConfig.PriorityOrder) == 0 {
+			return nil, fmt.Errorf("priority strategy requires priority_order or backend_priorities in conflict_resolution_config")
+		}
+		slog.Info("using priority conflict resolution strategy", "order", resConfig.PriorityOrder)
+		return NewPriorityConflictResolver(resConfig.PriorityOrder)
 
 	case vmcp.ConflictStrategyManual:
 		slog.Info("using manual conflict resolution strategy")
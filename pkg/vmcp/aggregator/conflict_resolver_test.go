@@ -8,6 +8,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 )
@@ -271,6 +274,66 @@ func TestPriorityConflictResolver(t *testing.T) {
 	}
 }
 
+func TestPriorityConflictResolverFromPriorities(t *testing.T) {
+	t.Parallel()
+
+	toolsByBackend := map[string][]vmcp.Tool{
+		"alpha":   {{Name: "search", Description: "Alpha search"}, {Name: "fetch", Description: "Alpha fetch"}},
+		"beta":    {{Name: "search", Description: "Beta search"}, {Name: "fetch", Description: "Beta fetch"}},
+		"charlie": {{Name: "search", Description: "Charlie search"}},
+	}
+
+	tests := []struct {
+		name        string
+		priorities  map[string]int
+		wantOrder   []string
+		wantWinners map[string]string
+	}{
+		{
+			name:        "higher priority wins",
+			priorities:  map[string]int{"alpha": 1, "beta": 10, "charlie": 5},
+			wantOrder:   []string{"beta", "charlie", "alpha"},
+			wantWinners: map[string]string{"search": "beta", "fetch": "beta"},
+		},
+		{
+			name:        "ties fall back to backend ID",
+			priorities:  map[string]int{"alpha": 5, "beta": 5, "charlie": 10},
+			wantOrder:   []string{"charlie", "alpha", "beta"},
+			wantWinners: map[string]string{"search": "charlie", "fetch": "alpha"},
+		},
+		{
+			name:        "negative priorities rank below zero",
+			priorities:  map[string]int{"alpha": -1, "beta": 0},
+			wantOrder:   []string{"beta", "alpha"},
+			wantWinners: map[string]string{"fetch": "beta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.wantOrder, PriorityOrderFromPriorities(tt.priorities))
+
+			// Map iteration makes discovery order vary between runs; the
+			// resolution must not.
+			for range 20 {
+				resolver, err := NewPriorityConflictResolverFromPriorities(tt.priorities)
+				require.NoError(t, err)
+				resolved, err := resolver.ResolveToolConflicts(context.Background(), toolsByBackend)
+				require.NoError(t, err)
+				for tool, backend := range tt.wantWinners {
+					require.Contains(t, resolved, tool)
+					assert.Equal(t, backend, resolved[tool].BackendID, "winner of %s", tool)
+				}
+			}
+		})
+	}
+
+	_, err := NewPriorityConflictResolverFromPriorities(nil)
+	assert.Error(t, err)
+}
+
 func TestManualConflictResolver(t *testing.T) {
 	t.Parallel()
 
@@ -525,6 +588,15 @@ func TestNewConflictResolver(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "priority strategy with backend priorities",
+			config: &config.AggregationConfig{
+				ConflictResolution: vmcp.ConflictStrategyPriority,
+				ConflictResolutionConfig: &config.ConflictResolutionConfig{
+					BackendPriorities: map[string]int{"backend1": 2, "backend2": 1},
+				},
+			},
+		},
 		{
 			name: "manual strategy",
 			config: &config.AggregationConfig{
@@ -8,18 +8,28 @@ import (
 	"fmt"
 	"log/slog"
 	"sort"
+	"strings"
 	"sync"
+	"text/template"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/stacklok/toolhive/pkg/telemetry"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 )
 
+// defaultMaxConcurrentQueries is how many backends are queried at once when
+// the aggregation config does not set MaxConcurrentQueries.
+const defaultMaxConcurrentQueries = 10
+
 // defaultAggregator implements the Aggregator interface for capability aggregation.
 // It queries backends in parallel, handles failures gracefully, and merges capabilities.
 type defaultAggregator struct {
@@ -27,25 +37,82 @@ type defaultAggregator struct {
 	conflictResolver ConflictResolver
 	toolConfigMap    map[string]*config.WorkloadToolConfig // Maps backend ID to tool config
 	excludeAllTools  bool                                  // Global flag to exclude all tools
+	maxConcurrent    int                                   // Max backends queried at once
+	toolTimeout      time.Duration                         // Call timeout for tools without their own
+	descTemplates    map[string]*template.Template         // Maps backend ID to its description template
+	capabilities     *capabilityCache                      // Recent per-backend query results
 	tracer           trace.Tracer
+	metrics          aggregatorMetrics
+}
+
+// aggregatorMetrics holds the OTEL instruments recorded by the default aggregator.
+type aggregatorMetrics struct {
+	// duration records how long a full AggregateCapabilities pipeline takes.
+	duration metric.Float64Histogram
+	// conflicts counts tool names advertised by more than one backend.
+	conflicts metric.Int64Counter
+}
+
+// newAggregatorMetrics creates the aggregator instruments on meterProvider,
+// falling back to a noop provider when nil.
+func newAggregatorMetrics(meterProvider metric.MeterProvider) (aggregatorMetrics, error) {
+	if meterProvider == nil {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+	meter := meterProvider.Meter(vmcp.InstrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		"toolhive_vmcp_aggregation_duration",
+		metric.WithDescription("Duration of backend capability aggregation in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetry.MCPHistogramBuckets...),
+	)
+	if err != nil {
+		return aggregatorMetrics{}, fmt.Errorf("failed to create aggregation duration histogram: %w", err)
+	}
+	conflicts, err := meter.Int64Counter(
+		"toolhive_vmcp_aggregation_conflicts",
+		metric.WithDescription("Total number of tool names advertised by more than one backend"),
+	)
+	if err != nil {
+		return aggregatorMetrics{}, fmt.Errorf("failed to create aggregation conflicts counter: %w", err)
+	}
+	return aggregatorMetrics{duration: duration, conflicts: conflicts}, nil
 }
 
 // NewDefaultAggregator creates a new default aggregator implementation.
 // conflictResolver handles tool name conflicts across backends.
 // aggregationConfig specifies aggregation settings including tool filtering/overrides and excludeAllTools.
 // tracerProvider is used to create a tracer for distributed tracing (pass nil for no tracing).
+// meterProvider records aggregation duration and conflict counts (pass nil for no metrics).
 func NewDefaultAggregator(
 	backendClient vmcp.BackendClient,
 	conflictResolver ConflictResolver,
 	aggregationConfig *config.AggregationConfig,
 	tracerProvider trace.TracerProvider,
-) Aggregator {
+	meterProvider metric.MeterProvider,
+) (Aggregator, error) {
+	metrics, err := newAggregatorMetrics(meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build tool config map for quick lookup by backend ID
 	toolConfigMap := make(map[string]*config.WorkloadToolConfig)
 	var excludeAllTools bool
+	maxConcurrent := defaultMaxConcurrentQueries
+	capabilityCacheTTL := defaultCapabilityCacheTTL
+	var toolTimeout time.Duration
 
 	if aggregationConfig != nil {
 		excludeAllTools = aggregationConfig.ExcludeAllTools
+		toolTimeout = time.Duration(aggregationConfig.ToolTimeout)
+		if aggregationConfig.MaxConcurrentQueries > 0 {
+			maxConcurrent = aggregationConfig.MaxConcurrentQueries
+		}
+		if aggregationConfig.CapabilityCacheTTL > 0 {
+			capabilityCacheTTL = time.Duration(aggregationConfig.CapabilityCacheTTL)
+		}
 		for _, wlConfig := range aggregationConfig.Tools {
 			if wlConfig != nil {
 				toolConfigMap[wlConfig.Workload] = wlConfig
@@ -53,6 +120,18 @@ func NewDefaultAggregator(
 		}
 	}
 
+	descTemplates := make(map[string]*template.Template)
+	for backendID, wlConfig := range toolConfigMap {
+		if wlConfig.DescriptionTemplate == "" {
+			continue
+		}
+		tmpl, err := config.ParseDescriptionTemplate(wlConfig.DescriptionTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid description template for workload %s: %w", backendID, err)
+		}
+		descTemplates[backendID] = tmpl
+	}
+
 	// Create tracer from provider (use noop tracer if provider is nil)
 	var tracer trace.Tracer
 	if tracerProvider != nil {
@@ -66,8 +145,13 @@ func NewDefaultAggregator(
 		conflictResolver: conflictResolver,
 		toolConfigMap:    toolConfigMap,
 		excludeAllTools:  excludeAllTools,
+		maxConcurrent:    maxConcurrent,
+		toolTimeout:      toolTimeout,
+		descTemplates:    descTemplates,
+		capabilities:     newCapabilityCache(capabilityCacheTTL),
 		tracer:           tracer,
-	}
+		metrics:          metrics,
+	}, nil
 }
 
 // QueryCapabilities queries a single backend for its MCP capabilities.
@@ -129,6 +213,8 @@ func (a *defaultAggregator) QueryCapabilities(ctx context.Context, backend vmcp.
 }
 
 // QueryAllCapabilities queries all backends for their capabilities in parallel.
+// Backends queried for the same caller within the capability cache TTL are
+// served from the cache instead of being queried again.
 // Handles backend failures gracefully (logs and continues with remaining backends).
 func (a *defaultAggregator) QueryAllCapabilities(
 	ctx context.Context,
@@ -151,7 +237,7 @@ func (a *defaultAggregator) QueryAllCapabilities(
 
 	// Use errgroup for parallel queries with context cancellation
 	g, ctx := errgroup.WithContext(ctx)
-	g.SetLimit(10) // Limit concurrent queries to avoid overwhelming backends
+	g.SetLimit(a.maxConcurrent) // Limit concurrent queries to avoid overwhelming backends
 
 	// Thread-safe map for results
 	var mu sync.Mutex
@@ -161,11 +247,16 @@ func (a *defaultAggregator) QueryAllCapabilities(
 	for _, backend := range backends {
 		backend := backend // Capture loop variable
 		g.Go(func() error {
-			caps, err := a.QueryCapabilities(ctx, backend)
-			if err != nil {
-				// Log the error but continue with other backends
-				slog.Warn("failed to query backend", "backend", backend.ID, "error", err)
-				return nil // Don't fail the entire operation
+			caps, cached := a.capabilities.get(ctx, &backend)
+			if !cached {
+				var err error
+				caps, err = a.QueryCapabilities(ctx, backend)
+				if err != nil {
+					// Log the error but continue with other backends
+					slog.Warn("failed to query backend", "backend", backend.ID, "error", err)
+					return nil // Don't fail the entire operation
+				}
+				a.capabilities.put(ctx, &backend, caps)
 			}
 
 			// Store result safely
@@ -221,6 +312,12 @@ func (a *defaultAggregator) ResolveConflicts(
 		toolsByBackend[backendID] = caps.Tools
 	}
 
+	// Count names that collide across backends before resolution so operators can
+	// see how often the configured strategy is exercised.
+	if conflicts := countToolConflicts(toolsByBackend); conflicts > 0 {
+		a.metrics.conflicts.Add(ctx, int64(conflicts))
+	}
+
 	// Use the configured conflict resolver to resolve tool conflicts
 	var resolvedTools map[string]*ResolvedTool
 	var err error
@@ -317,6 +414,10 @@ func (a *defaultAggregator) MergeCapabilities(
 		Prompts:           make(map[string]*vmcp.BackendTarget),
 	}
 
+	// Resolve aliases up front so collisions are checked against every
+	// resolved tool name, whichever backend it comes from.
+	aliases := a.resolveToolAliases(resolved.Tools)
+
 	// Convert resolved tools to final vmcp.Tool format
 	// The routing table gets ALL tools (for composite tool routing)
 	// The advertised tools list only gets non-excluded/non-filtered tools (for MCP clients)
@@ -325,12 +426,23 @@ func (a *defaultAggregator) MergeCapabilities(
 		// Check if this tool should be excluded from the advertised list
 		// ExcludeAll and Filter only affect advertising, not routing
 		shouldAdvertise := a.shouldAdvertiseTool(resolvedTool.BackendID, resolvedTool.OriginalName)
+		backend := registry.Get(ctx, resolvedTool.BackendID)
+
+		// An aliased tool is advertised under its alias only, but stays
+		// routable under its resolved name as well.
+		alias, hasAlias := aliases[resolvedTool.ResolvedName]
+		advertisedName := resolvedTool.ResolvedName
+		if hasAlias {
+			advertisedName = alias
+		}
+
+		defaults := a.parameterDefaults(resolvedTool)
 
 		if shouldAdvertise {
 			tools = append(tools, vmcp.Tool{
-				Name:         resolvedTool.ResolvedName,
-				Description:  resolvedTool.Description,
-				InputSchema:  resolvedTool.InputSchema,
+				Name:         advertisedName,
+				Description:  a.rewriteDescription(resolvedTool, backend),
+				InputSchema:  withoutDefaultedRequired(resolvedTool.InputSchema, defaults),
 				OutputSchema: resolvedTool.OutputSchema,
 				Annotations:  resolvedTool.Annotations,
 				BackendID:    resolvedTool.BackendID,
@@ -338,23 +450,33 @@ func (a *defaultAggregator) MergeCapabilities(
 		}
 
 		// ALWAYS add to routing table (for composite tools to call excluded backend tools)
-		// Look up full backend information from registry
-		backend := registry.Get(ctx, resolvedTool.BackendID)
+		// using the full backend information from the registry
+		var target *vmcp.BackendTarget
 		if backend == nil {
 			slog.Warn("backend not found in registry for tool, creating minimal target",
 				"backend", resolvedTool.BackendID, "tool", resolvedTool.ResolvedName)
-			routingTable.Tools[resolvedTool.ResolvedName] = &vmcp.BackendTarget{
+			target = &vmcp.BackendTarget{
 				WorkloadID:             resolvedTool.BackendID,
 				OriginalCapabilityName: actualBackendCapabilityName(a.toolConfigMap, resolvedTool.BackendID, resolvedTool.OriginalName),
+				CallTimeout:            a.callTimeout(resolvedTool.BackendID, resolvedTool.OriginalName),
+				Idempotent:             isIdempotent(resolvedTool.Annotations),
+				ParameterDefaults:      defaults,
 			}
 		} else {
 			// Use the backendToTarget helper from registry package
-			target := vmcp.BackendToTarget(backend)
+			target = vmcp.BackendToTarget(backend)
 			// Store the actual backend capability name for forwarding to backend.
 			// resolvedTool.OriginalName is the post-override name; reverse the override
 			// to get the name the backend itself uses.
 			target.OriginalCapabilityName = actualBackendCapabilityName(a.toolConfigMap, resolvedTool.BackendID, resolvedTool.OriginalName)
-			routingTable.Tools[resolvedTool.ResolvedName] = target
+			target.CallTimeout = a.callTimeout(resolvedTool.BackendID, resolvedTool.OriginalName)
+			target.Idempotent = isIdempotent(resolvedTool.Annotations)
+			target.ParameterDefaults = defaults
+		}
+		routingTable.Tools[resolvedTool.ResolvedName] = target
+		if hasAlias {
+			aliasTarget := *target
+			routingTable.Tools[alias] = &aliasTarget
 		}
 	}
 
@@ -480,11 +602,16 @@ func (a *defaultAggregator) AggregateCapabilities(
 			attribute.Int("backends.count", len(backends)),
 		),
 	)
+	start := time.Now()
 	defer func() {
+		outcome := "success"
 		if retErr != nil {
+			outcome = "error"
 			span.RecordError(retErr)
 			span.SetStatus(codes.Error, retErr.Error())
 		}
+		a.metrics.duration.Record(ctx, time.Since(start).Seconds(),
+			metric.WithAttributes(attribute.String("outcome", outcome)))
 		span.End()
 	}()
 
@@ -512,11 +639,13 @@ func (a *defaultAggregator) AggregateCapabilities(
 		return nil, fmt.Errorf("failed to merge capabilities: %w", err)
 	}
 
-	// Update metadata with backend count
+	// Update metadata with backend count and the backends that could not be queried
 	aggregated.Metadata.BackendCount = len(backends)
+	aggregated.Metadata.UnreachableBackends = unreachableBackends(backends, capabilities)
 
 	span.SetAttributes(
 		attribute.Int("aggregated.backends", aggregated.Metadata.BackendCount),
+		attribute.Int("unreachable.backends", len(aggregated.Metadata.UnreachableBackends)),
 		attribute.Int("aggregated.tools", aggregated.Metadata.ToolCount),
 		attribute.Int("aggregated.resources", aggregated.Metadata.ResourceCount),
 		attribute.Int("aggregated.prompts", aggregated.Metadata.PromptCount),
@@ -553,6 +682,106 @@ func actualBackendCapabilityName(toolConfigMap map[string]*config.WorkloadToolCo
 	return postOverrideName
 }
 
+// resolveToolAliases returns the alias of each aliased resolved tool, keyed by
+// its resolved name. WorkloadToolConfig.Aliases is keyed by the backend's own
+// tool name, so the post-override name is reversed first. An alias that
+// collides with a resolved tool name, or with an alias already taken by
+// another tool, is dropped with a warning so it never shadows a real tool.
+func (a *defaultAggregator) resolveToolAliases(resolvedTools map[string]*ResolvedTool) map[string]string {
+	// Visit tools in name order so that alias collisions resolve deterministically.
+	names := make([]string, 0, len(resolvedTools))
+	for name := range resolvedTools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliases := make(map[string]string)
+	taken := make(map[string]string)
+	for _, name := range names {
+		tool := resolvedTools[name]
+		wlConfig := a.toolConfigMap[tool.BackendID]
+		if wlConfig == nil || len(wlConfig.Aliases) == 0 {
+			continue
+		}
+		backendName := actualBackendCapabilityName(a.toolConfigMap, tool.BackendID, tool.OriginalName)
+		alias, ok := wlConfig.Aliases[backendName]
+		if !ok {
+			continue
+		}
+		if _, exists := resolvedTools[alias]; exists {
+			slog.Warn("tool alias collides with an existing tool name, ignoring alias",
+				"tool", name, "alias", alias, "backend", tool.BackendID)
+			continue
+		}
+		if owner, exists := taken[alias]; exists {
+			slog.Warn("tool alias is already used by another tool, ignoring alias",
+				"tool", name, "alias", alias, "backend", tool.BackendID, "existing_tool", owner)
+			continue
+		}
+		taken[alias] = name
+		aliases[name] = alias
+	}
+	return aliases
+}
+
+// callTimeout returns the call timeout for a backend tool: the workload's
+// ToolTimeouts entry for the tool if there is one, otherwise the
+// aggregation-wide ToolTimeout. postOverrideName is reversed to the backend's
+// own tool name first, since that is what ToolTimeouts is keyed by.
+func (a *defaultAggregator) callTimeout(backendID, postOverrideName string) time.Duration {
+	if wlConfig := a.toolConfigMap[backendID]; wlConfig != nil {
+		toolName := actualBackendCapabilityName(a.toolConfigMap, backendID, postOverrideName)
+		if timeout, ok := wlConfig.ToolTimeouts[toolName]; ok {
+			return time.Duration(timeout)
+		}
+	}
+	return a.toolTimeout
+}
+
+// isIdempotent reports whether a tool's annotations mark it idempotent, which
+// lets the backend client retry its calls on transient transport errors.
+func isIdempotent(annotations *vmcp.ToolAnnotations) bool {
+	return annotations != nil && annotations.IdempotentHint != nil && *annotations.IdempotentHint
+}
+
+// rewriteDescription returns the advertised description of a resolved tool,
+// rewritten by its workload's description template if it has one. backend
+// may be nil when the registry doesn't know the tool's backend, in which case
+// the backend ID stands in for its name.
+func (a *defaultAggregator) rewriteDescription(tool *ResolvedTool, backend *vmcp.Backend) string {
+	tmpl, ok := a.descTemplates[tool.BackendID]
+	if !ok {
+		return tool.Description
+	}
+
+	backendName := tool.BackendID
+	if backend != nil && backend.Name != "" {
+		backendName = backend.Name
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, config.DescriptionTemplateData{
+		BackendName:         backendName,
+		OriginalDescription: tool.Description,
+	}); err != nil {
+		slog.Warn("failed to rewrite tool description, keeping the original",
+			"tool", tool.ResolvedName, "backend", tool.BackendID, "error", err)
+		return tool.Description
+	}
+	return b.String()
+}
+
+// countToolConflicts returns the number of tool names advertised by more than
+// one backend.
+func countToolConflicts(toolsByBackend map[string][]vmcp.Tool) int {
+	conflicts := 0
+	for _, candidates := range groupToolsByName(toolsByBackend) {
+		if len(candidates) > 1 {
+			conflicts++
+		}
+	}
+	return conflicts
+}
+
 // shouldAdvertiseTool returns true if a tool from the given backend should be
 // advertised to MCP clients (included in tools/list response).
 //
@@ -600,3 +829,35 @@ func (a *defaultAggregator) shouldAdvertiseTool(backendID, originalToolName stri
 	// No filter configured, advertise the tool
 	return true
 }
+
+// Compile-time assertions: the default aggregator's per-backend capability
+// cache can be invalidated.
+var (
+	_ CacheInvalidator        = (*defaultAggregator)(nil)
+	_ BackendCacheInvalidator = (*defaultAggregator)(nil)
+)
+
+// InvalidateAll implements CacheInvalidator by dropping every cached backend
+// query result.
+func (a *defaultAggregator) InvalidateAll() {
+	a.capabilities.invalidateAll()
+}
+
+// InvalidateBackend implements BackendCacheInvalidator by dropping every
+// caller's cached query result for backendID.
+func (a *defaultAggregator) InvalidateBackend(backendID string) {
+	a.capabilities.invalidateBackend(backendID)
+}
+
+// unreachableBackends returns the sorted IDs of backends that returned no
+// capabilities because their query failed.
+func unreachableBackends(backends []vmcp.Backend, capabilities map[string]*BackendCapabilities) []string {
+	var unreachable []string
+	for _, backend := range backends {
+		if _, ok := capabilities[backend.ID]; !ok {
+			unreachable = append(unreachable, backend.ID)
+		}
+	}
+	sort.Strings(unreachable)
+	return unreachable
+}
@@ -6,10 +6,15 @@ package aggregator
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stacklok/toolhive/pkg/vmcp"
@@ -38,7 +43,8 @@ func TestDefaultAggregator_QueryCapabilities(t *testing.T) {
 
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(expectedCaps, nil)
 
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryCapabilities(context.Background(), backend)
 
 		require.NoError(t, err)
@@ -62,7 +68,8 @@ func TestDefaultAggregator_QueryCapabilities(t *testing.T) {
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("connection failed"))
 
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryCapabilities(context.Background(), backend)
 
 		require.Error(t, err)
@@ -93,7 +100,8 @@ func TestDefaultAggregator_QueryAllCapabilities(t *testing.T) {
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(caps1, nil)
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(caps2, nil)
 
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryAllCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -123,7 +131,8 @@ func TestDefaultAggregator_QueryAllCapabilities(t *testing.T) {
 				return nil, errors.New("connection timeout")
 			}).Times(2)
 
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryAllCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -143,7 +152,8 @@ func TestDefaultAggregator_QueryAllCapabilities(t *testing.T) {
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("connection failed"))
 
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryAllCapabilities(context.Background(), backends)
 
 		require.Error(t, err)
@@ -174,7 +184,8 @@ func TestDefaultAggregator_ResolveConflicts(t *testing.T) {
 			},
 		}
 
-		agg := NewDefaultAggregator(nil, nil, nil, nil)
+		agg, err := NewDefaultAggregator(nil, nil, nil, nil, nil)
+		require.NoError(t, err)
 		resolved, err := agg.ResolveConflicts(context.Background(), capabilities)
 
 		require.NoError(t, err)
@@ -207,7 +218,8 @@ func TestDefaultAggregator_ResolveConflicts(t *testing.T) {
 			},
 		}
 
-		agg := NewDefaultAggregator(nil, nil, nil, nil)
+		agg, err := NewDefaultAggregator(nil, nil, nil, nil, nil)
+		require.NoError(t, err)
 		resolved, err := agg.ResolveConflicts(context.Background(), capabilities)
 
 		require.NoError(t, err)
@@ -215,6 +227,32 @@ func TestDefaultAggregator_ResolveConflicts(t *testing.T) {
 		assert.Contains(t, resolved.Tools, "unique1")
 		assert.Contains(t, resolved.Tools, "unique2")
 	})
+
+	t.Run("records conflict count", func(t *testing.T) {
+		t.Parallel()
+		capabilities := map[string]*BackendCapabilities{
+			"backend1": {BackendID: "backend1", Tools: []vmcp.Tool{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+			"backend2": {BackendID: "backend2", Tools: []vmcp.Tool{{Name: "a"}, {Name: "b"}}},
+			"backend3": {BackendID: "backend3", Tools: []vmcp.Tool{{Name: "a"}}},
+		}
+
+		reader := sdkmetric.NewManualReader()
+		agg, err := NewDefaultAggregator(nil, nil, nil, nil, sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+		require.NoError(t, err)
+		_, err = agg.ResolveConflicts(context.Background(), capabilities)
+		require.NoError(t, err)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		conflicts := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "toolhive_vmcp_aggregation_conflicts", conflicts.Name)
+		sum, ok := conflicts.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, int64(2), sum.DataPoints[0].Value, "a and b are each advertised by more than one backend")
+	})
 }
 
 func TestDefaultAggregator_MergeCapabilities(t *testing.T) {
@@ -266,7 +304,8 @@ func TestDefaultAggregator_MergeCapabilities(t *testing.T) {
 		}
 		registry := vmcp.NewImmutableRegistry(backends)
 
-		agg := NewDefaultAggregator(nil, nil, nil, nil)
+		agg, err := NewDefaultAggregator(nil, nil, nil, nil, nil)
+		require.NoError(t, err)
 		aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
 
 		require.NoError(t, err)
@@ -325,7 +364,8 @@ func TestDefaultAggregator_MergeCapabilities(t *testing.T) {
 		}
 		registry := vmcp.NewImmutableRegistry(backends)
 
-		agg := NewDefaultAggregator(nil, nil, nil, nil)
+		agg, err := NewDefaultAggregator(nil, nil, nil, nil, nil)
+		require.NoError(t, err)
 		aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
 		require.NoError(t, err)
 
@@ -354,6 +394,208 @@ func TestDefaultAggregator_MergeCapabilities(t *testing.T) {
 	})
 }
 
+func TestDefaultAggregator_MergeCapabilities_ToolTimeouts(t *testing.T) {
+	t.Parallel()
+
+	resolved := &ResolvedCapabilities{
+		Tools: map[string]*ResolvedTool{
+			"deep_search": {ResolvedName: "deep_search", OriginalName: "deep_search", BackendID: "search"},
+			"find":        {ResolvedName: "find", OriginalName: "find", BackendID: "search"},
+			"lookup":      {ResolvedName: "lookup", OriginalName: "quick_lookup", BackendID: "search"},
+			"echo":        {ResolvedName: "echo", OriginalName: "echo", BackendID: "other"},
+		},
+	}
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{newTestBackend("search"), newTestBackend("other")})
+
+	agg, err := NewDefaultAggregator(nil, nil, &config.AggregationConfig{
+		ToolTimeout: config.Duration(10 * time.Second),
+		Tools: []*config.WorkloadToolConfig{{
+			Workload: "search",
+			Overrides: map[string]*config.ToolOverride{
+				"quick": {Name: "quick_lookup"},
+			},
+			ToolTimeouts: map[string]config.Duration{
+				"deep_search": config.Duration(5 * time.Minute),
+				"quick":       config.Duration(time.Second),
+			},
+		}},
+	}, nil, nil)
+	require.NoError(t, err)
+	aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
+	require.NoError(t, err)
+
+	timeouts := map[string]time.Duration{}
+	for name, target := range aggregated.RoutingTable.Tools {
+		timeouts[name] = target.CallTimeout
+	}
+	assert.Equal(t, map[string]time.Duration{
+		"deep_search": 5 * time.Minute,
+		"find":        10 * time.Second,
+		"lookup":      time.Second, // keyed by the backend's name, before the override
+		"echo":        10 * time.Second,
+	}, timeouts)
+}
+
+func TestDefaultAggregator_MergeCapabilities_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+	resolved := &ResolvedCapabilities{
+		Tools: map[string]*ResolvedTool{
+			"get":    {ResolvedName: "get", OriginalName: "get", BackendID: "b1", Annotations: &vmcp.ToolAnnotations{IdempotentHint: &yes}},
+			"post":   {ResolvedName: "post", OriginalName: "post", BackendID: "b1", Annotations: &vmcp.ToolAnnotations{IdempotentHint: &no}},
+			"plain":  {ResolvedName: "plain", OriginalName: "plain", BackendID: "b1"},
+			"orphan": {ResolvedName: "orphan", OriginalName: "orphan", BackendID: "gone", Annotations: &vmcp.ToolAnnotations{IdempotentHint: &yes}},
+		},
+	}
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{newTestBackend("b1")})
+
+	agg, err := NewDefaultAggregator(nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
+	require.NoError(t, err)
+
+	idempotent := map[string]bool{}
+	for name, target := range aggregated.RoutingTable.Tools {
+		idempotent[name] = target.Idempotent
+	}
+	assert.Equal(t, map[string]bool{"get": true, "post": false, "plain": false, "orphan": true}, idempotent)
+}
+
+func TestDefaultAggregator_MergeCapabilities_DescriptionTemplate(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}}}
+	resolved := &ResolvedCapabilities{
+		Tools: map[string]*ResolvedTool{
+			"search": {
+				ResolvedName: "search", OriginalName: "search", BackendID: "docs",
+				Description: "Search the docs", InputSchema: schema,
+			},
+			"echo": {ResolvedName: "echo", OriginalName: "echo", BackendID: "other", Description: "Echo input"},
+		},
+	}
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{
+		newTestBackend("docs", func(b *vmcp.Backend) { b.Name = "Product Docs" }),
+		newTestBackend("other"),
+	})
+
+	agg, err := NewDefaultAggregator(nil, nil, &config.AggregationConfig{
+		Tools: []*config.WorkloadToolConfig{{
+			Workload:            "docs",
+			DescriptionTemplate: "[{{.BackendName}}] {{.OriginalDescription}}",
+		}},
+	}, nil, nil)
+	require.NoError(t, err)
+	aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
+	require.NoError(t, err)
+
+	require.Len(t, aggregated.Tools, 2)
+	assert.Equal(t, "echo", aggregated.Tools[0].Name)
+	assert.Equal(t, "Echo input", aggregated.Tools[0].Description, "workloads without a template keep their descriptions")
+	assert.Equal(t, "search", aggregated.Tools[1].Name)
+	assert.Equal(t, "[Product Docs] Search the docs", aggregated.Tools[1].Description)
+	assert.Equal(t, schema, aggregated.Tools[1].InputSchema)
+	assert.Equal(t, "search", aggregated.RoutingTable.Tools["search"].OriginalCapabilityName)
+}
+
+func TestDefaultAggregator_MergeCapabilities_Aliases(t *testing.T) {
+	t.Parallel()
+
+	resolved := &ResolvedCapabilities{
+		Tools: map[string]*ResolvedTool{
+			"search_issues_and_pull_requests": {
+				ResolvedName: "search_issues_and_pull_requests", OriginalName: "search_issues_and_pull_requests",
+				BackendID: "github", Description: "Search issues and pull requests",
+			},
+			"gh_list": {ResolvedName: "gh_list", OriginalName: "gh_list", BackendID: "github"},
+			"get_me":  {ResolvedName: "get_me", OriginalName: "get_me", BackendID: "github"},
+			"echo":    {ResolvedName: "echo", OriginalName: "echo", BackendID: "other"},
+		},
+	}
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{newTestBackend("github"), newTestBackend("other")})
+
+	agg, err := NewDefaultAggregator(nil, nil, &config.AggregationConfig{
+		Tools: []*config.WorkloadToolConfig{{
+			Workload: "github",
+			Overrides: map[string]*config.ToolOverride{
+				"list_repositories": {Name: "gh_list"},
+			},
+			Aliases: map[string]string{
+				"search_issues_and_pull_requests": "search_issues",
+				"list_repositories":               "list_repos", // keyed by the backend's name, before the override
+				"get_me":                          "echo",       // collides with a tool of another backend
+			},
+		}},
+	}, nil, nil)
+	require.NoError(t, err)
+	aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(aggregated.Tools))
+	for _, tool := range aggregated.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"echo", "get_me", "list_repos", "search_issues"}, names,
+		"aliased tools are advertised under their alias only; colliding aliases are ignored")
+	assert.Equal(t, "Search issues and pull requests", aggregated.Tools[3].Description)
+
+	// Both the alias and the original name route to the backend's own tool.
+	routes := map[string]string{}
+	for name, target := range aggregated.RoutingTable.Tools {
+		routes[name] = target.WorkloadID + "/" + target.OriginalCapabilityName
+	}
+	assert.Equal(t, map[string]string{
+		"search_issues_and_pull_requests": "github/search_issues_and_pull_requests",
+		"search_issues":                   "github/search_issues_and_pull_requests",
+		"gh_list":                         "github/list_repositories",
+		"list_repos":                      "github/list_repositories",
+		"get_me":                          "github/get_me",
+		"echo":                            "other/echo",
+	}, routes)
+	assert.NotSame(t, aggregated.RoutingTable.Tools["search_issues"], aggregated.RoutingTable.Tools["search_issues_and_pull_requests"])
+}
+
+func TestDefaultAggregator_MergeCapabilities_DuplicateAlias(t *testing.T) {
+	t.Parallel()
+
+	resolved := &ResolvedCapabilities{
+		Tools: map[string]*ResolvedTool{
+			"a_search": {ResolvedName: "a_search", OriginalName: "a_search", BackendID: "a"},
+			"b_search": {ResolvedName: "b_search", OriginalName: "b_search", BackendID: "b"},
+		},
+	}
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{newTestBackend("a"), newTestBackend("b")})
+
+	agg, err := NewDefaultAggregator(nil, nil, &config.AggregationConfig{
+		Tools: []*config.WorkloadToolConfig{
+			{Workload: "a", Aliases: map[string]string{"a_search": "search"}},
+			{Workload: "b", Aliases: map[string]string{"b_search": "search"}},
+		},
+	}, nil, nil)
+	require.NoError(t, err)
+	aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
+	require.NoError(t, err)
+
+	// The first tool in name order keeps the alias; the other keeps its own name.
+	require.Len(t, aggregated.Tools, 2)
+	assert.Equal(t, "b_search", aggregated.Tools[0].Name)
+	assert.Equal(t, "search", aggregated.Tools[1].Name)
+	assert.Equal(t, "a", aggregated.RoutingTable.Tools["search"].WorkloadID)
+	assert.Equal(t, "a_search", aggregated.RoutingTable.Tools["search"].OriginalCapabilityName)
+}
+
+func TestNewDefaultAggregator_InvalidDescriptionTemplate(t *testing.T) {
+	t.Parallel()
+
+	for _, tmpl := range []string{"{{.BackendName", "{{.Unknown}}"} {
+		_, err := NewDefaultAggregator(nil, nil, &config.AggregationConfig{
+			Tools: []*config.WorkloadToolConfig{{Workload: "docs", DescriptionTemplate: tmpl}},
+		}, nil, nil)
+		assert.ErrorContains(t, err, "invalid description template for workload docs", tmpl)
+	}
+}
+
 func TestDefaultAggregator_MergeCapabilities_DeterministicToolOrder(t *testing.T) {
 	t.Parallel()
 
@@ -376,7 +618,8 @@ func TestDefaultAggregator_MergeCapabilities_DeterministicToolOrder(t *testing.T
 			HealthStatus:  vmcp.BackendHealthy,
 		},
 	})
-	agg := NewDefaultAggregator(nil, nil, nil, nil)
+	agg, err := NewDefaultAggregator(nil, nil, nil, nil, nil)
+	require.NoError(t, err)
 
 	want := []string{"alpha_tool", "beta_tool", "delta_tool", "gamma_tool", "middle_tool", "omega_tool", "zebra_tool"}
 
@@ -423,7 +666,8 @@ func TestDefaultAggregator_AggregateCapabilities(t *testing.T) {
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(caps1, nil)
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(caps2, nil)
 
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -435,7 +679,102 @@ func TestDefaultAggregator_AggregateCapabilities(t *testing.T) {
 		assert.Equal(t, 2, result.Metadata.BackendCount)
 		assert.Equal(t, 2, result.Metadata.ToolCount)
 		assert.Equal(t, 1, result.Metadata.ResourceCount)
+		assert.Empty(t, result.Metadata.UnreachableBackends)
 	})
+
+	t.Run("unreachable backend does not drop the others", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockClient := mocks.NewMockBackendClient(ctrl)
+		backends := []vmcp.Backend{
+			newTestBackend("backend1"),
+			newTestBackend("backend2"),
+			newTestBackend("backend3"),
+		}
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+				if target.WorkloadID == "backend2" {
+					return nil, errors.New("connection refused")
+				}
+				return newTestCapabilityList(withTools(newTestTool("tool-"+target.WorkloadID, target.WorkloadID))), nil
+			}).Times(3)
+
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
+		result, err := agg.AggregateCapabilities(context.Background(), backends)
+
+		require.NoError(t, err)
+		require.Len(t, result.Tools, 2)
+		assert.Contains(t, result.RoutingTable.Tools, "tool-backend1")
+		assert.Contains(t, result.RoutingTable.Tools, "tool-backend3")
+		assert.Equal(t, 3, result.Metadata.BackendCount)
+		assert.Equal(t, []string{"backend2"}, result.Metadata.UnreachableBackends)
+	})
+}
+
+func TestDefaultAggregator_QueryAllCapabilities_BoundedConcurrency(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	const limit = 3
+	backends := make([]vmcp.Backend, 12)
+	for i := range backends {
+		backends[i] = newTestBackend(fmt.Sprintf("backend%d", i))
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+			n := inFlight.Add(1)
+			for {
+				peak := maxInFlight.Load()
+				if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			return newTestCapabilityList(), nil
+		}).Times(len(backends))
+
+	agg, err := NewDefaultAggregator(mockClient, nil,
+		&config.AggregationConfig{MaxConcurrentQueries: limit}, nil, nil)
+	require.NoError(t, err)
+	result, err := agg.QueryAllCapabilities(context.Background(), backends)
+
+	require.NoError(t, err)
+	assert.Len(t, result, len(backends))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(limit))
+	assert.Greater(t, maxInFlight.Load(), int32(1), "backends must be queried in parallel")
+}
+
+// BenchmarkDefaultAggregator_AggregateCapabilities aggregates 50 backends that
+// each take a millisecond to answer, as a group of remote backends would.
+func BenchmarkDefaultAggregator_AggregateCapabilities(b *testing.B) {
+	ctrl := gomock.NewController(b)
+
+	backends := make([]vmcp.Backend, 50)
+	for i := range backends {
+		backends[i] = newTestBackend(fmt.Sprintf("backend%d", i))
+	}
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+			time.Sleep(time.Millisecond)
+			return newTestCapabilityList(withTools(newTestTool("tool-"+target.WorkloadID, target.WorkloadID))), nil
+		}).AnyTimes()
+
+	agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := agg.AggregateCapabilities(context.Background(), backends); err != nil {
+			b.Fatal(err)
+		}
+	}
 }
 
 func TestDefaultAggregator_ExcludeAllTools(t *testing.T) {
@@ -467,7 +806,8 @@ func TestDefaultAggregator_ExcludeAllTools(t *testing.T) {
 		aggregationConfig := &config.AggregationConfig{
 			ExcludeAllTools: true,
 		}
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryCapabilities(context.Background(), backend)
 
 		require.NoError(t, err)
@@ -498,7 +838,8 @@ func TestDefaultAggregator_ExcludeAllTools(t *testing.T) {
 		aggregationConfig := &config.AggregationConfig{
 			ExcludeAllTools: false,
 		}
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryCapabilities(context.Background(), backend)
 
 		require.NoError(t, err)
@@ -521,7 +862,8 @@ func TestDefaultAggregator_ExcludeAllTools(t *testing.T) {
 		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(expectedCaps, nil)
 
 		// Create aggregator with nil aggregationConfig (default behavior)
-		agg := NewDefaultAggregator(mockClient, nil, nil, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, nil, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.QueryCapabilities(context.Background(), backend)
 
 		require.NoError(t, err)
@@ -572,7 +914,8 @@ func TestDefaultAggregator_ExcludeAllPreservesRoutingTableForCompositeTools(t *t
 			},
 		}
 
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -619,7 +962,8 @@ func TestDefaultAggregator_ExcludeAllPreservesRoutingTableForCompositeTools(t *t
 			ExcludeAllTools: true,
 		}
 
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -681,7 +1025,8 @@ func TestDefaultAggregator_FilterPreservesRoutingTableForCompositeTools(t *testi
 			},
 		}
 
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -738,7 +1083,8 @@ func TestDefaultAggregator_FilterPreservesRoutingTableForCompositeTools(t *testi
 			},
 		}
 
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -792,7 +1138,8 @@ func TestDefaultAggregator_FilterPreservesRoutingTableForCompositeTools(t *testi
 			},
 		}
 
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -842,7 +1189,8 @@ func TestDefaultAggregator_FilterPreservesRoutingTableForCompositeTools(t *testi
 			},
 		}
 
-		agg := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil)
+		agg, err := NewDefaultAggregator(mockClient, nil, aggregationConfig, nil, nil)
+		require.NoError(t, err)
 		result, err := agg.AggregateCapabilities(context.Background(), backends)
 
 		require.NoError(t, err)
@@ -282,6 +282,13 @@ func (d *backendDiscoverer) discoverFromStaticConfig() []vmcp.Backend {
 			HeaderForward: d.headerForwardByBackend[wirefmt.NormalizeForEnvVar(staticBackend.Name)],
 			Metadata:      staticBackend.Metadata,
 		}
+		if staticBackend.Transport == config.TransportStdio {
+			backend.Stdio = &vmcp.StdioCommand{
+				Command: staticBackend.Command,
+				Args:    staticBackend.Args,
+				Env:     staticBackend.Env,
+			}
+		}
 
 		// Apply auth configuration from OutgoingAuthConfig
 		d.applyAuthConfigToBackend(&backend, staticBackend.Name)
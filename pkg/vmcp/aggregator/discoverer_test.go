@@ -1422,6 +1422,41 @@ func TestStaticBackendDiscoverer_EntryBackendFields(t *testing.T) {
 	}
 }
 
+func TestStaticBackendDiscoverer_StdioBackend(t *testing.T) {
+	t.Parallel()
+
+	discoverer := NewUnifiedBackendDiscovererWithStaticBackends(
+		[]config.StaticBackendConfig{
+			{
+				Name:      "filesystem",
+				Transport: config.TransportStdio,
+				Command:   "npx",
+				Args:      []string{"-y", "@modelcontextprotocol/server-filesystem"},
+				Env:       map[string]string{"LOG_LEVEL": "debug"},
+			},
+			{Name: "remote", URL: "http://localhost:8080/mcp", Transport: "streamable-http"},
+		},
+		nil, // No auth config needed for this test
+		"test-group",
+		nil, // No headerForward map for this test
+	)
+
+	backends, err := discoverer.Discover(context.Background(), "test-group")
+	require.NoError(t, err)
+	require.Len(t, backends, 2)
+
+	require.Equal(t, "filesystem", backends[0].Name)
+	assert.Equal(t, config.TransportStdio, backends[0].TransportType)
+	assert.Equal(t, &vmcp.StdioCommand{
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-filesystem"},
+		Env:     map[string]string{"LOG_LEVEL": "debug"},
+	}, backends[0].Stdio)
+
+	require.Equal(t, "remote", backends[1].Name)
+	assert.Nil(t, backends[1].Stdio, "only stdio backends carry a command")
+}
+
 // TestBackendDiscoverer_Discover_DeterministicOrdering tests that Discover returns backends
 // in a deterministic order (sorted alphabetically by name) regardless of input order.
 // This prevents non-deterministic ConfigMap content that would cause unnecessary
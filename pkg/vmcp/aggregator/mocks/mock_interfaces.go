@@ -308,3 +308,39 @@ func (mr *MockCacheInvalidatorMockRecorder) InvalidateAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAll", reflect.TypeOf((*MockCacheInvalidator)(nil).InvalidateAll))
 }
+
+// MockBackendCacheInvalidator is a mock of BackendCacheInvalidator interface.
+type MockBackendCacheInvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendCacheInvalidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockBackendCacheInvalidatorMockRecorder is the mock recorder for MockBackendCacheInvalidator.
+type MockBackendCacheInvalidatorMockRecorder struct {
+	mock *MockBackendCacheInvalidator
+}
+
+// NewMockBackendCacheInvalidator creates a new mock instance.
+func NewMockBackendCacheInvalidator(ctrl *gomock.Controller) *MockBackendCacheInvalidator {
+	mock := &MockBackendCacheInvalidator{ctrl: ctrl}
+	mock.recorder = &MockBackendCacheInvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackendCacheInvalidator) EXPECT() *MockBackendCacheInvalidatorMockRecorder {
+	return m.recorder
+}
+
+// InvalidateBackend mocks base method.
+func (m *MockBackendCacheInvalidator) InvalidateBackend(backendID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateBackend", backendID)
+}
+
+// InvalidateBackend indicates an expected call of InvalidateBackend.
+func (mr *MockBackendCacheInvalidatorMockRecorder) InvalidateBackend(backendID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateBackend", reflect.TypeOf((*MockBackendCacheInvalidator)(nil).InvalidateBackend), backendID)
+}
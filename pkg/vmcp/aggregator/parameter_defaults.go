@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// parameterDefaults returns the parameter defaults configured for a resolved
+// tool via WorkloadToolConfig.ParameterDefaults, keeping only those the tool's
+// input schema accepts. Defaults are keyed by the backend's own tool name, so
+// the post-override name is reversed first. Returns nil when the tool has no
+// usable defaults.
+func (a *defaultAggregator) parameterDefaults(tool *ResolvedTool) map[string]any {
+	wlConfig := a.toolConfigMap[tool.BackendID]
+	if wlConfig == nil || len(wlConfig.ParameterDefaults) == 0 {
+		return nil
+	}
+	backendName := actualBackendCapabilityName(a.toolConfigMap, tool.BackendID, tool.OriginalName)
+	configured, ok := wlConfig.ParameterDefaults[backendName]
+	if !ok || len(configured.Value) == 0 {
+		return nil
+	}
+
+	defaults := make(map[string]any, len(configured.Value))
+	for param, value := range configured.Value {
+		if err := validateParameterDefault(tool.InputSchema, param, value); err != nil {
+			slog.Warn("dropping tool parameter default rejected by the input schema",
+				"tool", tool.ResolvedName, "backend", tool.BackendID, "parameter", param, "error", err)
+			continue
+		}
+		defaults[param] = value
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+	return defaults
+}
+
+// withoutDefaultedRequired returns inputSchema with the defaulted parameters
+// removed from its required list, so clients may omit them and let the
+// configured default apply. The schema is copied rather than modified, since
+// the resolved tool's schema is shared with the routing table.
+func withoutDefaultedRequired(inputSchema map[string]any, defaults map[string]any) map[string]any {
+	if len(defaults) == 0 {
+		return inputSchema
+	}
+	var required []any
+	switch list := inputSchema["required"].(type) {
+	case []any:
+		required = list
+	case []string:
+		for _, name := range list {
+			required = append(required, name)
+		}
+	default:
+		return inputSchema
+	}
+
+	remaining := make([]any, 0, len(required))
+	for _, name := range required {
+		if param, isString := name.(string); isString {
+			if _, defaulted := defaults[param]; defaulted {
+				continue
+			}
+		}
+		remaining = append(remaining, name)
+	}
+	if len(remaining) == len(required) {
+		return inputSchema
+	}
+
+	schema := maps.Clone(inputSchema)
+	if len(remaining) == 0 {
+		delete(schema, "required")
+	} else {
+		schema["required"] = remaining
+	}
+	return schema
+}
+
+// validateParameterDefault checks a default value for param against a tool's
+// input schema. The parameter must be declared in the schema's properties, and
+// an object holding only that parameter must validate against the schema with
+// its required list dropped, so references to shared definitions resolve.
+func validateParameterDefault(inputSchema map[string]any, param string, value any) error {
+	properties, _ := inputSchema["properties"].(map[string]any)
+	if _, declared := properties[param]; !declared {
+		return fmt.Errorf("parameter %q is not declared in the input schema", param)
+	}
+
+	schema := maps.Clone(inputSchema)
+	delete(schema, "required")
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewGoLoader(schema),
+		gojsonschema.NewGoLoader(map[string]any{param: value}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate input schema: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, desc := range result.Errors() {
+		violations = append(violations, desc.String())
+	}
+	return errors.New(strings.Join(violations, "; "))
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/json"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+)
+
+func TestDefaultAggregator_MergeCapabilities_ParameterDefaults(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"org":   map[string]any{"type": "string"},
+			"limit": map[string]any{"type": "integer", "minimum": 1},
+			"state": map[string]any{"$ref": "#/$defs/state"},
+			"query": map[string]any{"type": "string"},
+		},
+		"required": []any{"query", "org"},
+		"$defs": map[string]any{
+			"state": map[string]any{"type": "string", "enum": []any{"open", "closed"}},
+		},
+	}
+	resolved := &ResolvedCapabilities{
+		Tools: map[string]*ResolvedTool{
+			"search_issues": {ResolvedName: "search_issues", OriginalName: "search_issues", BackendID: "github", InputSchema: schema},
+			"gh_search":     {ResolvedName: "gh_search", OriginalName: "gh_search", BackendID: "github", InputSchema: schema},
+			"get_me":        {ResolvedName: "get_me", OriginalName: "get_me", BackendID: "github", InputSchema: schema},
+			"echo":          {ResolvedName: "echo", OriginalName: "echo", BackendID: "other", InputSchema: schema},
+		},
+	}
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{newTestBackend("github"), newTestBackend("other")})
+
+	agg, err := NewDefaultAggregator(nil, nil, &config.AggregationConfig{
+		Tools: []*config.WorkloadToolConfig{{
+			Workload: "github",
+			Overrides: map[string]*config.ToolOverride{
+				"search_code": {Name: "gh_search"},
+			},
+			Aliases: map[string]string{"search_issues": "find_issues"},
+			ParameterDefaults: map[string]json.Map{
+				"search_issues": json.NewMap(map[string]any{
+					"org":     "acme",
+					"state":   "open",
+					"limit":   0,     // rejected: below the minimum
+					"unknown": "foo", // rejected: not declared in the schema
+				}),
+				// keyed by the backend's name, before the override
+				"search_code": json.NewMap(map[string]any{"org": "acme", "limit": 5}),
+				// rejected entirely: wrong type and not in the enum
+				"get_me": json.NewMap(map[string]any{"org": 42, "state": "merged"}),
+			},
+		}},
+	}, nil, nil)
+	require.NoError(t, err)
+	aggregated, err := agg.MergeCapabilities(context.Background(), resolved, registry)
+	require.NoError(t, err)
+
+	routes := aggregated.RoutingTable.Tools
+	assert.Equal(t, map[string]any{"org": "acme", "state": "open"}, routes["search_issues"].ParameterDefaults)
+	assert.Equal(t, map[string]any{"org": "acme", "state": "open"}, routes["find_issues"].ParameterDefaults,
+		"an alias routes with the same defaults")
+	assert.Equal(t, map[string]any{"org": "acme", "limit": 5}, routes["gh_search"].ParameterDefaults)
+	assert.Nil(t, routes["get_me"].ParameterDefaults)
+	assert.Nil(t, routes["echo"].ParameterDefaults, "other workloads are unaffected")
+
+	// Defaulted parameters are no longer required of clients.
+	advertised := make(map[string]vmcp.Tool, len(aggregated.Tools))
+	for _, tool := range aggregated.Tools {
+		advertised[tool.Name] = tool
+	}
+	assert.Equal(t, []any{"query"}, advertised["find_issues"].InputSchema["required"])
+	assert.Equal(t, []any{"query"}, advertised["gh_search"].InputSchema["required"])
+	assert.Equal(t, []any{"query", "org"}, advertised["get_me"].InputSchema["required"])
+	assert.Equal(t, []any{"query", "org"}, advertised["echo"].InputSchema["required"])
+	assert.Equal(t, []any{"query", "org"}, schema["required"], "the backend schema is not modified")
+}
+
+func TestWithoutDefaultedRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		schema       map[string]any
+		defaults     map[string]any
+		wantRequired any
+	}{
+		{
+			name:         "no defaults",
+			schema:       map[string]any{"required": []any{"org"}},
+			wantRequired: []any{"org"},
+		},
+		{
+			name:         "drops defaulted parameters",
+			schema:       map[string]any{"required": []any{"org", "query"}},
+			defaults:     map[string]any{"org": "acme"},
+			wantRequired: []any{"query"},
+		},
+		{
+			name:         "string list",
+			schema:       map[string]any{"required": []string{"org", "query"}},
+			defaults:     map[string]any{"query": "is:open"},
+			wantRequired: []any{"org"},
+		},
+		{
+			name:     "removes an emptied list",
+			schema:   map[string]any{"required": []any{"org"}},
+			defaults: map[string]any{"org": "acme"},
+		},
+		{
+			name:     "no required list",
+			schema:   map[string]any{"type": "object"},
+			defaults: map[string]any{"org": "acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := withoutDefaultedRequired(tt.schema, tt.defaults)
+			assert.Equal(t, tt.wantRequired, got["required"])
+		})
+	}
+}
+
+func TestValidateParameterDefault(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"org":  map[string]any{"type": "string"},
+			"tags": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+		"required":             []any{"org", "tags"},
+		"additionalProperties": false,
+	}
+
+	tests := []struct {
+		name       string
+		schema     map[string]any
+		param      string
+		value      any
+		wantErrMsg string
+	}{
+		{name: "valid string", schema: schema, param: "org", value: "acme"},
+		{name: "valid array", schema: schema, param: "tags", value: []any{"a", "b"}},
+		{name: "wrong type", schema: schema, param: "org", value: true, wantErrMsg: "Invalid type"},
+		{name: "wrong item type", schema: schema, param: "tags", value: []any{1}, wantErrMsg: "Invalid type"},
+		{name: "undeclared", schema: schema, param: "team", value: "x", wantErrMsg: "not declared"},
+		{name: "no schema", schema: nil, param: "org", value: "acme", wantErrMsg: "not declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateParameterDefault(tt.schema, tt.param, tt.value)
+			if tt.wantErrMsg != "" {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
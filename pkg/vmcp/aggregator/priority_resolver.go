@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/stacklok/toolhive/pkg/vmcp"
 )
@@ -50,6 +52,33 @@ func NewPriorityConflictResolver(priorityOrder []string) (*PriorityConflictResol
 	}, nil
 }
 
+// NewPriorityConflictResolverFromPriorities creates a priority-based conflict
+// resolver from explicit per-backend priority values, where a higher value
+// wins. Backends with equal priority are ordered by backend ID, so the winner
+// of a conflict never depends on discovery order.
+func NewPriorityConflictResolverFromPriorities(priorities map[string]int) (*PriorityConflictResolver, error) {
+	if len(priorities) == 0 {
+		return nil, fmt.Errorf("backend priorities cannot be empty")
+	}
+	return NewPriorityConflictResolver(PriorityOrderFromPriorities(priorities))
+}
+
+// PriorityOrderFromPriorities converts per-backend priority values into a
+// priority order: highest priority first, ties broken by ascending backend ID.
+func PriorityOrderFromPriorities(priorities map[string]int) []string {
+	order := make([]string, 0, len(priorities))
+	for backendID := range priorities {
+		order = append(order, backendID)
+	}
+	slices.SortFunc(order, func(a, b string) int {
+		if priorities[a] != priorities[b] {
+			return priorities[b] - priorities[a]
+		}
+		return strings.Compare(a, b)
+	})
+	return order
+}
+
 // ResolveToolConflicts applies priority strategy to resolve conflicts.
 // Returns a map of resolved tool names to ResolvedTool structs.
 func (r *PriorityConflictResolver) ResolveToolConflicts(
@@ -8,8 +8,6 @@ import (
 	"context"
 	"fmt"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	mcpv1beta1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1beta1"
@@ -48,15 +46,17 @@ func (*TokenExchangeConverter) ConvertToStrategy(
 	}
 
 	tokenExchangeConfig := &authtypes.TokenExchangeConfig{
-		TokenURL:            tokenExchange.TokenURL,
-		ClientID:            tokenExchange.ClientID,
-		Audience:            tokenExchange.Audience,
-		Scopes:              tokenExchange.Scopes,
-		SubjectTokenType:    subjectTokenType,
-		SubjectProviderName: tokenExchange.SubjectProviderName,
+		TokenURL:             tokenExchange.TokenURL,
+		ClientID:             tokenExchange.ClientID,
+		ClientAssertionKeyID: tokenExchange.ClientAssertionKeyID,
+		Audience:             tokenExchange.Audience,
+		Scopes:               tokenExchange.Scopes,
+		SubjectTokenType:     subjectTokenType,
+		SubjectProviderName:  tokenExchange.SubjectProviderName,
 	}
 
-	// Note: ClientSecretEnv is set by the controller when used in operator-managed ConfigMaps.
+	// Note: ClientSecretEnv and ClientAssertionKeyEnv are set by the controller when used in
+	// operator-managed ConfigMaps.
 	// For runtime discovery, secrets are resolved via ResolveSecrets instead.
 
 	strategy := &authtypes.BackendAuthStrategy{
@@ -67,17 +67,17 @@ func (*TokenExchangeConverter) ConvertToStrategy(
 	return strategy, nil
 }
 
-// ResolveSecrets fetches the client secret from Kubernetes and sets it in the strategy.
-// Unlike non-discovered mode where secrets can be mounted as environment variables at pod creation time,
-// discovered mode requires dynamic secret resolution because the vMCP pod doesn't know about backend
-// auth configs at pod creation time.
+// ResolveSecrets fetches the client secret or client assertion key from Kubernetes and sets it
+// in the strategy. Unlike non-discovered mode where secrets can be mounted as environment variables
+// at pod creation time, discovered mode requires dynamic secret resolution because the vMCP pod
+// doesn't know about backend auth configs at pod creation time.
 //
 // This method:
-//  1. Checks if ClientSecretEnv is set in the strategy
+//  1. Checks if ClientSecretRef or ClientAssertionKeyRef is set in the config
 //  2. Fetches the referenced Kubernetes secret
-//  3. Replaces ClientSecretEnv with ClientSecret containing the actual value
+//  3. Replaces the corresponding environment variable reference with the actual value
 //
-// If ClientSecretEnv is not set, the strategy is returned unchanged.
+// If neither reference is set, the strategy is returned unchanged.
 func (*TokenExchangeConverter) ResolveSecrets(
 	ctx context.Context,
 	externalAuth *mcpv1beta1.MCPExternalAuthConfig,
@@ -94,32 +94,24 @@ func (*TokenExchangeConverter) ResolveSecrets(
 		return nil, fmt.Errorf("token exchange config is nil")
 	}
 
-	// If ClientSecretRef is not configured, nothing to resolve
-	if tokenExchange.ClientSecretRef == nil {
-		return strategy, nil
-	}
-
-	// Fetch and resolve the secret
-	secret := &corev1.Secret{}
-	secretKey := types.NamespacedName{
-		Name:      tokenExchange.ClientSecretRef.Name,
-		Namespace: namespace,
-	}
-
-	if err := k8sClient.Get(ctx, secretKey, secret); err != nil {
-		return nil, fmt.Errorf("failed to get secret %s/%s: %w",
-			namespace, tokenExchange.ClientSecretRef.Name, err)
+	if tokenExchange.ClientSecretRef != nil {
+		secretValue, err := resolveSecretKeyRef(ctx, k8sClient, namespace, tokenExchange.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		// Replace the env var reference with actual secret value
+		strategy.TokenExchange.ClientSecretEnv = ""
+		strategy.TokenExchange.ClientSecret = secretValue
 	}
 
-	secretValue, ok := secret.Data[tokenExchange.ClientSecretRef.Key]
-	if !ok {
-		return nil, fmt.Errorf("secret %s/%s does not contain key %s",
-			namespace, tokenExchange.ClientSecretRef.Name, tokenExchange.ClientSecretRef.Key)
+	if tokenExchange.ClientAssertionKeyRef != nil {
+		keyValue, err := resolveSecretKeyRef(ctx, k8sClient, namespace, tokenExchange.ClientAssertionKeyRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve client assertion key: %w", err)
+		}
+		strategy.TokenExchange.ClientAssertionKeyEnv = ""
+		strategy.TokenExchange.ClientAssertionKey = keyValue
 	}
 
-	// Replace the env var reference with actual secret value
-	strategy.TokenExchange.ClientSecretEnv = ""
-	strategy.TokenExchange.ClientSecret = string(secretValue)
-
 	return strategy, nil
 }
@@ -34,6 +34,8 @@ import (
 // Parameters:
 //   - ctx: Context for any initialization that requires it
 //   - envReader: Environment variable reader for dependency injection
+//   - exchangeOpts: Options of the strategies that exchange the user's token
+//     (token_exchange and xaa), such as strategies.WithTokenCache
 //
 // Returns:
 //   - auth.OutgoingAuthRegistry: Registry with all strategies registered
@@ -41,6 +43,7 @@ import (
 func NewOutgoingAuthRegistry(
 	_ context.Context,
 	envReader env.Reader,
+	exchangeOpts ...strategies.ExchangeOption,
 ) (auth.OutgoingAuthRegistry, error) {
 	registry := auth.NewDefaultOutgoingAuthRegistry()
 
@@ -59,7 +62,7 @@ func NewOutgoingAuthRegistry(
 	}
 	if err := registry.RegisterStrategy(
 		authtypes.StrategyTypeTokenExchange,
-		strategies.NewTokenExchangeStrategy(envReader),
+		strategies.NewTokenExchangeStrategy(envReader, exchangeOpts...),
 	); err != nil {
 		return nil, err
 	}
@@ -83,7 +86,7 @@ func NewOutgoingAuthRegistry(
 	}
 	if err := registry.RegisterStrategy(
 		authtypes.StrategyTypeXAA,
-		strategies.NewXAAStrategy(envReader),
+		strategies.NewXAAStrategy(envReader, exchangeOpts...),
 	); err != nil {
 		return nil, err
 	}
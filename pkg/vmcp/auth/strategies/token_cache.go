@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package strategies
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
+)
+
+// tokenRefreshMargin is how long before its expiry a cached token is no longer
+// used, so that a backend never receives a token that expires in flight.
+const tokenRefreshMargin = 30 * time.Second
+
+// ExchangeOption configures a strategy that exchanges the user's token for a
+// backend token (token_exchange and xaa).
+type ExchangeOption func(*exchangedTokens)
+
+// WithTokenCache makes the strategy reuse the tokens it exchanged, from and to
+// tokenCache, until shortly before they expire.
+func WithTokenCache(tokenCache cache.TokenCache) ExchangeOption {
+	return func(e *exchangedTokens) {
+		e.cache = tokenCache
+	}
+}
+
+// exchangedTokens reads and writes the exchanged tokens of a strategy through
+// an optional TokenCache. Without a cache every call is a miss.
+type exchangedTokens struct {
+	cache cache.TokenCache
+}
+
+func newExchangedTokens(opts []ExchangeOption) exchangedTokens {
+	var e exchangedTokens
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return e
+}
+
+// key returns the cache key of the token exchanged for subjectToken, or "" when
+// tokens are not cached: without a cache, or for a request whose context does
+// not name its backend (see cache.WithBackendID).
+func (e *exchangedTokens) key(ctx context.Context, subjectToken, audience string) string {
+	if e.cache == nil {
+		return ""
+	}
+	backendID := cache.BackendIDFromContext(ctx)
+	if backendID == "" {
+		return ""
+	}
+	return cache.BuildKey(backendID, subjectToken, audience)
+}
+
+// get returns the access token cached under key, or "" on a miss. A cache
+// error is a miss: the token is exchanged again.
+func (e *exchangedTokens) get(ctx context.Context, key string) string {
+	if key == "" {
+		return ""
+	}
+	token, err := e.cache.Get(ctx, key)
+	if err != nil {
+		slog.Warn("failed to read cached backend token", "error", err)
+		return ""
+	}
+	if token == nil || token.ShouldRefresh(tokenRefreshMargin) {
+		return ""
+	}
+	return token.Token
+}
+
+// store caches token under key for the user identified by subject. Tokens
+// without an expiry are not cached, since they could never be refreshed.
+func (e *exchangedTokens) store(ctx context.Context, key string, token *oauth2.Token, subject string, scopes []string) {
+	if key == "" || token.Expiry.IsZero() {
+		return
+	}
+	tokenType := token.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+	err := e.cache.Set(ctx, key, &cache.CachedToken{
+		Token:     token.AccessToken,
+		TokenType: tokenType,
+		ExpiresAt: token.Expiry,
+		Scopes:    scopes,
+		Metadata:  map[string]string{cache.MetadataKeySubject: subject},
+	})
+	if err != nil {
+		slog.Warn("failed to cache backend token", "error", err)
+	}
+}
@@ -43,6 +43,9 @@ const (
 //   - client_id: OAuth 2.0 client identifier (required for some token endpoints)
 //   - client_secret: OAuth 2.0 client secret (directly provided, mutually exclusive with client_secret_env)
 //   - client_secret_env: Name of environment variable containing the client secret (mutually exclusive with client_secret)
+//   - client_assertion_key / client_assertion_key_env: PEM-encoded private key (or the name of the environment
+//     variable containing it) used for private_key_jwt client authentication instead of a client secret
+//   - client_assertion_key_id: Key ID sent in the "kid" header of client assertions
 //   - audience: Target audience for the exchanged token
 //   - scopes: Array of scope strings to request
 //   - subject_token_type: Type of the subject token (default: "access_token")
@@ -58,13 +61,17 @@ type TokenExchangeStrategy struct {
 	exchangeConfigs map[string]*tokenexchange.ExchangeConfig
 	mu              sync.RWMutex
 	envReader       env.Reader
+	// tokens caches the exchanged tokens per user and backend, when
+	// configured with WithTokenCache.
+	tokens exchangedTokens
 }
 
 // NewTokenExchangeStrategy creates a new TokenExchangeStrategy instance.
-func NewTokenExchangeStrategy(envReader env.Reader) *TokenExchangeStrategy {
+func NewTokenExchangeStrategy(envReader env.Reader, opts ...ExchangeOption) *TokenExchangeStrategy {
 	return &TokenExchangeStrategy{
 		exchangeConfigs: make(map[string]*tokenexchange.ExchangeConfig),
 		envReader:       envReader,
+		tokens:          newExchangedTokens(opts),
 	}
 }
 
@@ -83,8 +90,9 @@ func (*TokenExchangeStrategy) Name() string {
 //     gets or creates a cached ExchangeConfig, performs the token exchange, and injects
 //     the token into the backend request's Authorization header
 //
-// Token caching per user is handled by the upper vMCP TokenCache layer.
-// This strategy only caches the ExchangeConfig template per backend.
+// With WithTokenCache, the exchanged token is cached per user and backend and
+// reused until shortly before it expires. The ExchangeConfig template is always
+// cached per backend.
 //
 // Parameters:
 //   - ctx: Request context containing the authenticated identity (or health check marker)
@@ -132,6 +140,12 @@ func (s *TokenExchangeStrategy) Authenticate(
 		subjectToken = identity.Token
 	}
 
+	cacheKey := s.tokens.key(ctx, subjectToken, config.Audience)
+	if cached := s.tokens.get(ctx, cacheKey); cached != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cached))
+		return nil
+	}
+
 	// Get user-specific exchange config. This creates a fresh config instance
 	// with the current user's token. The underlying server config is cached.
 	exchangeConfig := s.createUserConfig(config, subjectToken)
@@ -141,6 +155,7 @@ func (s *TokenExchangeStrategy) Authenticate(
 	if err != nil {
 		return fmt.Errorf("token exchange failed: %w", err)
 	}
+	s.tokens.store(ctx, cacheKey, token, identity.Subject, config.Scopes)
 
 	// Inject exchanged token into request
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
@@ -191,6 +206,7 @@ type tokenExchangeConfig struct {
 	TokenURL            string
 	ClientID            string
 	ClientSecret        string //nolint:gosec // G117: field legitimately holds sensitive data
+	ClientAssertionKey  *tokenexchange.ClientAssertionKey
 	Audience            string
 	Scopes              []string
 	SubjectTokenType    string
@@ -225,6 +241,36 @@ func (s *TokenExchangeStrategy) parseClientSecret(config *authtypes.TokenExchang
 	return "", nil
 }
 
+// parseClientAssertionKey parses and validates ClientAssertionKey or ClientAssertionKeyEnv from
+// TokenExchangeConfig. Returns nil if no client assertion key is configured.
+func (s *TokenExchangeStrategy) parseClientAssertionKey(
+	config *authtypes.TokenExchangeConfig, clientID, clientSecret string,
+) (*tokenexchange.ClientAssertionKey, error) {
+	keyPEM := config.ClientAssertionKey
+	if keyPEM == "" && config.ClientAssertionKeyEnv != "" {
+		keyPEM = s.envReader.Getenv(config.ClientAssertionKeyEnv)
+		if keyPEM == "" {
+			return nil, fmt.Errorf("environment variable %s not set or empty", config.ClientAssertionKeyEnv)
+		}
+	}
+	if keyPEM == "" {
+		return nil, nil
+	}
+
+	if clientID == "" {
+		return nil, fmt.Errorf("ClientAssertionKey cannot be provided without ClientID")
+	}
+	if clientSecret != "" {
+		return nil, fmt.Errorf("ClientSecret and ClientAssertionKey are mutually exclusive")
+	}
+
+	key, err := tokenexchange.ParseClientAssertionKey([]byte(keyPEM), config.ClientAssertionKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ClientAssertionKey: %w", err)
+	}
+	return key, nil
+}
+
 // parseTokenExchangeConfig parses and validates token exchange configuration from BackendAuthStrategy.
 func (s *TokenExchangeStrategy) parseTokenExchangeConfig(strategy *authtypes.BackendAuthStrategy) (*tokenExchangeConfig, error) {
 	if strategy == nil || strategy.TokenExchange == nil {
@@ -250,6 +296,13 @@ func (s *TokenExchangeStrategy) parseTokenExchangeConfig(strategy *authtypes.Bac
 	}
 	config.ClientSecret = clientSecret
 
+	// Optional: ClientAssertionKey or ClientAssertionKeyEnv (private_key_jwt)
+	assertionKey, err := s.parseClientAssertionKey(tokenExchangeCfg, config.ClientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientAssertionKey = assertionKey
+
 	// Optional: Audience
 	config.Audience = tokenExchangeCfg.Audience
 
@@ -305,12 +358,13 @@ func (s *TokenExchangeStrategy) getOrCreateServerConfig(
 
 	// Create template (without SubjectTokenProvider)
 	template := &tokenexchange.ExchangeConfig{
-		TokenURL:         config.TokenURL,
-		ClientID:         config.ClientID,
-		ClientSecret:     config.ClientSecret,
-		Audience:         config.Audience,
-		Scopes:           config.Scopes,
-		SubjectTokenType: config.SubjectTokenType,
+		TokenURL:           config.TokenURL,
+		ClientID:           config.ClientID,
+		ClientSecret:       config.ClientSecret,
+		ClientAssertionKey: config.ClientAssertionKey,
+		Audience:           config.Audience,
+		Scopes:             config.Scopes,
+		SubjectTokenType:   config.SubjectTokenType,
 	}
 
 	s.exchangeConfigs[cacheKey] = template
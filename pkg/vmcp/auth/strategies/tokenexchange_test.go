@@ -5,18 +5,25 @@ package strategies
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stacklok/toolhive-core/env/mocks"
 	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/oauthproto"
 	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
 	healthcontext "github.com/stacklok/toolhive/pkg/vmcp/health/context"
 )
@@ -800,3 +807,114 @@ func TestTokenExchangeStrategy_ClientSecretEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenExchangeStrategy_ClientAssertionKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+
+	tests := []struct {
+		name          string
+		envKey        string
+		configure     func(cfg *authtypes.TokenExchangeConfig)
+		errorContains string
+	}{
+		{
+			name: "signs client assertion with inline key",
+			configure: func(cfg *authtypes.TokenExchangeConfig) {
+				cfg.ClientID = testClientID
+				cfg.ClientAssertionKey = keyPEM
+				cfg.ClientAssertionKeyID = "key-1"
+			},
+		},
+		{
+			name:   "resolves key from environment variable",
+			envKey: keyPEM,
+			configure: func(cfg *authtypes.TokenExchangeConfig) {
+				cfg.ClientID = testClientID
+				cfg.ClientAssertionKeyEnv = "TEST_CLIENT_ASSERTION_KEY"
+				cfg.ClientAssertionKeyID = "key-1"
+			},
+		},
+		{
+			name: "error when environment variable is not set",
+			configure: func(cfg *authtypes.TokenExchangeConfig) {
+				cfg.ClientID = testClientID
+				cfg.ClientAssertionKeyEnv = "TEST_CLIENT_ASSERTION_KEY"
+			},
+			errorContains: "environment variable TEST_CLIENT_ASSERTION_KEY not set",
+		},
+		{
+			name: "error without client ID",
+			configure: func(cfg *authtypes.TokenExchangeConfig) {
+				cfg.ClientAssertionKey = keyPEM
+			},
+			errorContains: "ClientAssertionKey cannot be provided without ClientID",
+		},
+		{
+			name: "error with client secret",
+			configure: func(cfg *authtypes.TokenExchangeConfig) {
+				cfg.ClientID = testClientID
+				cfg.ClientSecret = "secret"
+				cfg.ClientAssertionKey = keyPEM
+			},
+			errorContains: "mutually exclusive",
+		},
+		{
+			name: "error with invalid key",
+			configure: func(cfg *authtypes.TokenExchangeConfig) {
+				cfg.ClientID = testClientID
+				cfg.ClientAssertionKey = "not a key"
+			},
+			errorContains: "invalid ClientAssertionKey",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockEnv := mocks.NewMockReader(ctrl)
+			mockEnv.EXPECT().Getenv("TEST_CLIENT_ASSERTION_KEY").Return(tt.envKey).AnyTimes()
+			strategy := NewTokenExchangeStrategy(mockEnv)
+
+			if tt.errorContains != "" {
+				err := strategy.Validate(createTokenExchangeStrategy("https://auth.example.com/token", tt.configure))
+				require.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+
+			var tokenURL string
+			server := createSuccessfulTokenServer(t, "backend-token", func(t *testing.T, r *http.Request) {
+				t.Helper()
+				_, _, hasBasicAuth := r.BasicAuth()
+				assert.False(t, hasBasicAuth)
+				assert.Equal(t, testClientID, r.Form.Get("client_id"))
+				assert.Equal(t, oauthproto.ClientAssertionTypeJWTBearer, r.Form.Get("client_assertion_type"))
+
+				token, err := jwt.Parse(r.Form.Get("client_assertion"),
+					func(*jwt.Token) (any, error) { return key.Public(), nil },
+					jwt.WithValidMethods([]string{"ES256"}),
+					jwt.WithAudience(tokenURL),
+					jwt.WithIssuer(testClientID),
+					jwt.WithSubject(testClientID),
+				)
+				require.NoError(t, err)
+				assert.Equal(t, "key-1", token.Header["kid"])
+			})
+			defer server.Close()
+			tokenURL = server.URL
+
+			ctx := createContextWithIdentity("test-user", "user-token")
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			err := strategy.Authenticate(ctx, req, createTokenExchangeStrategy(server.URL, tt.configure))
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer backend-token", req.Header.Get("Authorization"))
+		})
+	}
+}
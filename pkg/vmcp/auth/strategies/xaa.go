@@ -31,9 +31,9 @@ import (
 //   - IdP exchange (RFC 8693): Exchange the user's ID token at the IdP for an ID-JAG JWT.
 //   - Target grant (RFC 7523): Exchange the ID-JAG at the target AS for an access token.
 //
-// Both steps run on every Authenticate call. The upper vMCP TokenCache layer is
-// responsible for reusing the resulting access token across requests; this
-// strategy holds no local cache.
+// Both steps run on every Authenticate call unless the strategy is configured
+// with WithTokenCache, which reuses the resulting access token per user and
+// backend until shortly before it expires.
 //
 // The subject ID token is not validated locally before IdP exchange. The IdP
 // enforces its own exp check; if the token is expired, IdP exchange returns an
@@ -44,12 +44,16 @@ import (
 //	if errors.As(err, &re) && re.ErrorCode == "invalid_grant" { ... }
 type XAAStrategy struct {
 	envReader env.Reader
+	// tokens caches the access tokens per user and backend, when configured
+	// with WithTokenCache.
+	tokens exchangedTokens
 }
 
 // NewXAAStrategy creates a new XAAStrategy instance.
-func NewXAAStrategy(envReader env.Reader) *XAAStrategy {
+func NewXAAStrategy(envReader env.Reader, opts ...ExchangeOption) *XAAStrategy {
 	return &XAAStrategy{
 		envReader: envReader,
+		tokens:    newExchangedTokens(opts),
 	}
 }
 
@@ -121,6 +125,13 @@ func (s *XAAStrategy) Authenticate(
 
 	slog.Debug("xaa: found ID token for provider", "provider", config.subjectProviderName)
 
+	cacheKey := s.tokens.key(ctx, idToken, config.targetAudience)
+	if cached := s.tokens.get(ctx, cacheKey); cached != "" {
+		slog.Debug("xaa: using cached access token")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cached))
+		return nil
+	}
+
 	// IdP exchange: Exchange the user's ID token for an ID-JAG at the IdP.
 	assertion, err := s.performIDPExchange(ctx, idToken, config)
 	if err != nil {
@@ -131,15 +142,16 @@ func (s *XAAStrategy) Authenticate(
 	slog.Debug("xaa: IdP exchange succeeded, got ID-JAG")
 
 	// Target grant: Exchange the ID-JAG for an access token at the target AS.
-	accessToken, err := s.performTargetGrant(ctx, assertion, config)
+	token, err := s.performTargetGrant(ctx, assertion, config)
 	if err != nil {
 		slog.Debug("xaa: target grant failed", "error", err)
 		return fmt.Errorf("target grant failed: %w", err)
 	}
+	s.tokens.store(ctx, cacheKey, token, identity.Subject, config.scopes)
 
 	slog.Debug("xaa: target grant succeeded, setting Bearer token")
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
 	return nil
 }
 
@@ -385,7 +397,7 @@ func claimContainsString(claims jwt.MapClaims, key, want string) bool {
 // the ID-JAG assertion returned by IdP exchange.
 func (*XAAStrategy) performTargetGrant(
 	ctx context.Context, assertion string, config *xaaParsedConfig,
-) (string, error) {
+) (*oauth2.Token, error) {
 	bearerCfg := &jwtbearer.Config{
 		TokenURL:          config.targetTokenURL,
 		ClientID:          config.targetClientID,
@@ -399,10 +411,10 @@ func (*XAAStrategy) performTargetGrant(
 
 	token, err := bearerCfg.TokenSource(ctx).Token()
 	if err != nil {
-		return "", fmt.Errorf("target grant: %w", err)
+		return nil, fmt.Errorf("target grant: %w", err)
 	}
 
-	return token.AccessToken, nil
+	return token, nil
 }
 
 // resolveClientSecret resolves a client secret from either a direct value or an
@@ -132,6 +132,18 @@ type TokenExchangeConfig struct {
 	// The value will be resolved at runtime from this environment variable.
 	ClientSecretEnv string `json:"clientSecretEnv,omitempty" yaml:"clientSecretEnv,omitempty"`
 
+	// ClientAssertionKey is the PEM-encoded private key used to authenticate with a signed
+	// JWT (private_key_jwt, RFC 7523) instead of a client secret (use ClientAssertionKeyEnv for security).
+	//nolint:gosec // G117: field legitimately holds sensitive data
+	ClientAssertionKey string `json:"clientAssertionKey,omitempty" yaml:"clientAssertionKey,omitempty"`
+
+	// ClientAssertionKeyEnv is the environment variable name containing the client assertion key.
+	// The value will be resolved at runtime from this environment variable.
+	ClientAssertionKeyEnv string `json:"clientAssertionKeyEnv,omitempty" yaml:"clientAssertionKeyEnv,omitempty"`
+
+	// ClientAssertionKeyID is the key ID sent in the "kid" header of client assertions.
+	ClientAssertionKeyID string `json:"clientAssertionKeyId,omitempty" yaml:"clientAssertionKeyId,omitempty"`
+
 	// Audience is the target audience for the exchanged token.
 	Audience string `json:"audience,omitempty" yaml:"audience,omitempty"`
 
@@ -29,6 +29,14 @@ type TokenCache interface {
 	// Delete removes a token from the cache.
 	Delete(ctx context.Context, key string) error
 
+	// List returns the unexpired cache entries, without their token values.
+	List(ctx context.Context) ([]Entry, error)
+
+	// InvalidateByPrefix removes every token whose key starts with prefix,
+	// such as the BackendKeyPrefix of a backend, and returns how many were
+	// removed.
+	InvalidateByPrefix(ctx context.Context, prefix string) (int, error)
+
 	// Clear removes all tokens from the cache.
 	Clear(ctx context.Context) error
 
@@ -57,6 +65,48 @@ type CachedToken struct {
 	Metadata map[string]string
 }
 
+// MetadataKeySubject is the CachedToken metadata key holding the subject of
+// the user the token was issued for. Token values never identify the user in
+// listings, so this is what an operator invalidates a user's tokens by.
+const MetadataKeySubject = "subject"
+
+// Entry describes a cached token without exposing its values.
+type Entry struct {
+	// Key is the cache key of the token.
+	Key string `json:"key"`
+
+	// Subject is the subject of the user the token was issued for, from the
+	// token's MetadataKeySubject metadata. Empty if unknown.
+	Subject string `json:"subject,omitempty"`
+
+	// TokenType is the token type (e.g., "Bearer").
+	TokenType string `json:"token_type,omitempty"`
+
+	// ExpiresAt is when the token expires.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Scopes are the token scopes.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// NewEntry describes token, cached under key, without its values.
+func NewEntry(key string, token *CachedToken) Entry {
+	return Entry{
+		Key:       key,
+		Subject:   token.Metadata[MetadataKeySubject],
+		TokenType: token.TokenType,
+		ExpiresAt: token.ExpiresAt,
+		Scopes:    token.Scopes,
+	}
+}
+
+// BackendKeyPrefix returns the prefix of the cache keys of every token for
+// backend, following the {backend}:{hash(subject_token)}:{audience} key
+// format.
+func BackendKeyPrefix(backend string) string {
+	return backend + ":"
+}
+
 // IsExpired checks if the token has expired.
 func (t *CachedToken) IsExpired() bool {
 	return time.Now().After(t.ExpiresAt)
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// BuildKey returns the cache key of the token exchanged for subjectToken to
+// call backend with audience, in the {backend}:{hash(subject_token)}:{audience}
+// format. The subject token is hashed so that keys, which the admin API lists,
+// never carry it.
+func BuildKey(backend, subjectToken, audience string) string {
+	sum := sha256.Sum256([]byte(subjectToken))
+	return BackendKeyPrefix(backend) + hex.EncodeToString(sum[:]) + ":" + audience
+}
+
+// backendIDKey is the context key for the ID of the backend a request goes to.
+type backendIDKey struct{}
+
+// WithBackendID returns a context carrying the ID of the backend an outgoing
+// request is sent to. Outgoing auth strategies that cache exchanged tokens use
+// it to key them per backend.
+func WithBackendID(ctx context.Context, backendID string) context.Context {
+	return context.WithValue(ctx, backendIDKey{}, backendID)
+}
+
+// BackendIDFromContext returns the backend ID set by WithBackendID, or "" when
+// there is none.
+func BackendIDFromContext(ctx context.Context) string {
+	backendID, _ := ctx.Value(backendIDKey{}).(string)
+	return backendID
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryTokenCache is an in-process TokenCache. Expired tokens are dropped
+// when they are read or listed.
+type MemoryTokenCache struct {
+	mu     sync.RWMutex
+	tokens map[string]*CachedToken
+}
+
+var _ TokenCache = (*MemoryTokenCache)(nil)
+
+// NewMemoryTokenCache creates an empty in-process token cache.
+func NewMemoryTokenCache() *MemoryTokenCache {
+	return &MemoryTokenCache{tokens: make(map[string]*CachedToken)}
+}
+
+// Get retrieves a cached token, or nil if it doesn't exist or has expired.
+func (c *MemoryTokenCache) Get(_ context.Context, key string) (*CachedToken, error) {
+	c.mu.RLock()
+	token, ok := c.tokens[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	if token.IsExpired() {
+		c.mu.Lock()
+		// Only drop the token read above; a concurrent Set may have replaced it.
+		if c.tokens[key] == token {
+			delete(c.tokens, key)
+		}
+		c.mu.Unlock()
+		return nil, nil
+	}
+	return token, nil
+}
+
+// Set stores a token in the cache until it expires.
+func (c *MemoryTokenCache) Set(_ context.Context, key string, token *CachedToken) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens[key] = token
+	return nil
+}
+
+// Delete removes a token from the cache.
+func (c *MemoryTokenCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tokens, key)
+	return nil
+}
+
+// List returns the unexpired cache entries, sorted by key.
+func (c *MemoryTokenCache) List(_ context.Context) ([]Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]Entry, 0, len(c.tokens))
+	for key, token := range c.tokens {
+		if token.IsExpired() {
+			delete(c.tokens, key)
+			continue
+		}
+		entries = append(entries, NewEntry(key, token))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// InvalidateByPrefix removes every token whose key starts with prefix.
+func (c *MemoryTokenCache) InvalidateByPrefix(_ context.Context, prefix string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key := range c.tokens {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.tokens, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Clear removes all tokens from the cache.
+func (c *MemoryTokenCache) Clear(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.tokens)
+	return nil
+}
+
+// Close is a no-op for the in-process cache.
+func (*MemoryTokenCache) Close() error {
+	return nil
+}
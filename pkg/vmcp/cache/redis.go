@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	tcredis "github.com/stacklok/toolhive-core/redis"
+
+	"github.com/stacklok/toolhive/pkg/authserver/storage"
+)
+
+// redisScanCount is the SCAN page size used to list and invalidate tokens.
+const redisScanCount = 100
+
+// RedisTokenCache is a TokenCache shared by every vMCP replica through Redis.
+// Tokens are stored as JSON under keyPrefix followed by the cache key, and
+// Redis expires them with the token. With WithTokenCipher the JSON is
+// encrypted before it is written.
+type RedisTokenCache struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	cipher    *storage.TokenCipher
+}
+
+var _ TokenCache = (*RedisTokenCache)(nil)
+
+// RedisTokenCacheOption configures optional RedisTokenCache behavior.
+type RedisTokenCacheOption func(*RedisTokenCache)
+
+// WithTokenCipher encrypts cached tokens with cipher before they are written
+// to Redis. Entries written before encryption was enabled remain readable.
+func WithTokenCipher(cipher *storage.TokenCipher) RedisTokenCacheOption {
+	return func(c *RedisTokenCache) {
+		c.cipher = cipher
+	}
+}
+
+// NewRedisTokenCache creates a token cache on the Redis server described by
+// cfg. keyPrefix namespaces the cache keys and is required.
+func NewRedisTokenCache(
+	ctx context.Context, cfg tcredis.Config, keyPrefix string, opts ...RedisTokenCacheOption,
+) (*RedisTokenCache, error) {
+	if keyPrefix == "" {
+		return nil, errors.New("invalid redis configuration: key prefix is required")
+	}
+
+	client, err := tcredis.NewClient(ctx, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisTokenCacheWithClient(client, keyPrefix, opts...), nil
+}
+
+// NewRedisTokenCacheWithClient creates a token cache using an existing Redis
+// client. Close closes the client.
+func NewRedisTokenCacheWithClient(
+	client redis.UniversalClient, keyPrefix string, opts ...RedisTokenCacheOption,
+) *RedisTokenCache {
+	c := &RedisTokenCache{client: client, keyPrefix: keyPrefix}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get retrieves a cached token, or nil if it doesn't exist or has expired.
+func (c *RedisTokenCache) Get(ctx context.Context, key string) (*CachedToken, error) {
+	value, err := c.client.Get(ctx, c.keyPrefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached token: %w", err)
+	}
+	data, err := c.cipher.Decrypt(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cached token: %w", err)
+	}
+
+	var token CachedToken
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, fmt.Errorf("failed to decode cached token: %w", err)
+	}
+	if token.IsExpired() {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Set stores a token in the cache until it expires. An expired token is not
+// stored.
+func (c *RedisTokenCache) Set(ctx context.Context, key string, token *CachedToken) error {
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return c.Delete(ctx, key)
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached token: %w", err)
+	}
+	value, err := c.cipher.Encrypt(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt cached token: %w", err)
+	}
+	if err := c.client.Set(ctx, c.keyPrefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache token: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a token from the cache.
+func (c *RedisTokenCache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, c.keyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to delete cached token: %w", err)
+	}
+	return nil
+}
+
+// List returns the unexpired cache entries, sorted by key.
+func (c *RedisTokenCache) List(ctx context.Context) ([]Entry, error) {
+	redisKeys, err := c.scan(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(redisKeys))
+	for _, redisKey := range redisKeys {
+		key := strings.TrimPrefix(redisKey, c.keyPrefix)
+		// A token may expire or be deleted between SCAN and GET.
+		token, err := c.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if token != nil {
+			entries = append(entries, NewEntry(key, token))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// InvalidateByPrefix removes every token whose key starts with prefix.
+func (c *RedisTokenCache) InvalidateByPrefix(ctx context.Context, prefix string) (int, error) {
+	redisKeys, err := c.scan(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, redisKey := range redisKeys {
+		// Keys are deleted one at a time: in a Redis cluster they can live in
+		// different slots, which a multi-key DEL rejects.
+		n, err := c.client.Del(ctx, redisKey).Result()
+		if err != nil {
+			return removed, fmt.Errorf("failed to delete cached token: %w", err)
+		}
+		removed += int(n)
+	}
+	return removed, nil
+}
+
+// Clear removes all tokens from the cache.
+func (c *RedisTokenCache) Clear(ctx context.Context) error {
+	_, err := c.InvalidateByPrefix(ctx, "")
+	return err
+}
+
+// Close closes the Redis client.
+func (c *RedisTokenCache) Close() error {
+	return c.client.Close()
+}
+
+// scan returns the Redis keys of the tokens whose cache key starts with
+// prefix.
+func (c *RedisTokenCache) scan(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	iter := c.client.Scan(ctx, 0, escapeGlob(c.keyPrefix+prefix)+"*", redisScanCount).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan cached tokens: %w", err)
+	}
+	return keys, nil
+}
+
+// escapeGlob escapes the characters of s that are special in a Redis MATCH
+// pattern.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
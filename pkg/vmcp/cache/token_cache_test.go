@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/authserver/storage"
+)
+
+// tokenCacheImplementations returns a constructor for a fresh cache of every
+// TokenCache implementation.
+func tokenCacheImplementations() map[string]func(t *testing.T) TokenCache {
+	return map[string]func(t *testing.T) TokenCache{
+		"memory": func(*testing.T) TokenCache {
+			return NewMemoryTokenCache()
+		},
+		"redis": func(t *testing.T) TokenCache {
+			t.Helper()
+			mr := miniredis.RunT(t)
+			c := NewRedisTokenCacheWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:tokens:")
+			t.Cleanup(func() { _ = c.Close() })
+			return c
+		},
+		"encrypted redis": func(t *testing.T) TokenCache {
+			t.Helper()
+			mr := miniredis.RunT(t)
+			c := NewRedisTokenCacheWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:tokens:",
+				WithTokenCipher(newTestCipher(t)))
+			t.Cleanup(func() { _ = c.Close() })
+			return c
+		},
+	}
+}
+
+func newTestCipher(t *testing.T) *storage.TokenCipher {
+	t.Helper()
+	cipher, err := storage.NewTokenCipherForPurpose("test", []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	return cipher
+}
+
+func newTestToken(subject string, ttl time.Duration) *CachedToken {
+	return &CachedToken{
+		Token:        "secret-access-" + subject,
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(ttl).Truncate(time.Second),
+		RefreshToken: "secret-refresh-" + subject,
+		Scopes:       []string{"read"},
+		Metadata:     map[string]string{MetadataKeySubject: subject},
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	t.Parallel()
+
+	for name, newCache := range tokenCacheImplementations() {
+		t.Run(name+"/get set delete", func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			c := newCache(t)
+
+			got, err := c.Get(ctx, "github:abc:api")
+			require.NoError(t, err)
+			assert.Nil(t, got)
+
+			token := newTestToken("alice", time.Hour)
+			require.NoError(t, c.Set(ctx, "github:abc:api", token))
+			got, err = c.Get(ctx, "github:abc:api")
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, token.Token, got.Token)
+			assert.True(t, token.ExpiresAt.Equal(got.ExpiresAt))
+
+			require.NoError(t, c.Delete(ctx, "github:abc:api"))
+			got, err = c.Get(ctx, "github:abc:api")
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		})
+
+		t.Run(name+"/expired tokens are not returned", func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			c := newCache(t)
+
+			require.NoError(t, c.Set(ctx, "github:abc:api", newTestToken("alice", -time.Minute)))
+			got, err := c.Get(ctx, "github:abc:api")
+			require.NoError(t, err)
+			assert.Nil(t, got)
+
+			entries, err := c.List(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+
+		t.Run(name+"/list does not expose token values", func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			c := newCache(t)
+
+			require.NoError(t, c.Set(ctx, "jira:def:api", newTestToken("bob", time.Hour)))
+			require.NoError(t, c.Set(ctx, "github:abc:api", newTestToken("alice", time.Hour)))
+
+			entries, err := c.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, "github:abc:api", entries[0].Key)
+			assert.Equal(t, "alice", entries[0].Subject)
+			assert.Equal(t, "Bearer", entries[0].TokenType)
+			assert.Equal(t, []string{"read"}, entries[0].Scopes)
+			assert.Equal(t, "jira:def:api", entries[1].Key)
+
+			listing, err := json.Marshal(entries)
+			require.NoError(t, err)
+			assert.NotContains(t, string(listing), "secret")
+		})
+
+		t.Run(name+"/invalidate by prefix", func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			c := newCache(t)
+
+			for _, key := range []string{"github:abc:api", "github:def:api", "github-enterprise:abc:api", "jira:abc:api"} {
+				require.NoError(t, c.Set(ctx, key, newTestToken("alice", time.Hour)))
+			}
+
+			removed, err := c.InvalidateByPrefix(ctx, BackendKeyPrefix("github"))
+			require.NoError(t, err)
+			assert.Equal(t, 2, removed)
+
+			entries, err := c.List(ctx)
+			require.NoError(t, err)
+			keys := make([]string, len(entries))
+			for i, e := range entries {
+				keys[i] = e.Key
+			}
+			assert.Equal(t, []string{"github-enterprise:abc:api", "jira:abc:api"}, keys)
+
+			removed, err = c.InvalidateByPrefix(ctx, "gitlab:")
+			require.NoError(t, err)
+			assert.Zero(t, removed)
+
+			require.NoError(t, c.Clear(ctx))
+			entries, err = c.List(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestRedisTokenCache_PrefixIsNotAPattern(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	mr := miniredis.RunT(t)
+	c := NewRedisTokenCacheWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:tokens:")
+	t.Cleanup(func() { _ = c.Close() })
+
+	require.NoError(t, c.Set(ctx, "backend-1:abc:api", newTestToken("alice", time.Hour)))
+	removed, err := c.InvalidateByPrefix(ctx, "backend-?")
+	require.NoError(t, err)
+	assert.Zero(t, removed, "glob characters in a prefix match literally")
+
+	// Keys outside the cache's namespace are never touched.
+	mr.Set("other:backend-1:abc:api", "value")
+	require.NoError(t, c.Clear(ctx))
+	assert.True(t, mr.Exists("other:backend-1:abc:api"))
+}
+
+func TestRedisTokenCache_EncryptsTokens(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	c := NewRedisTokenCacheWithClient(client, "test:tokens:", WithTokenCipher(newTestCipher(t)))
+	t.Cleanup(func() { _ = c.Close() })
+
+	require.NoError(t, c.Set(ctx, "backend-1:abc:api", newTestToken("alice", time.Hour)))
+	stored, err := mr.Get("test:tokens:backend-1:abc:api")
+	require.NoError(t, err)
+	assert.NotContains(t, stored, "secret-access-alice")
+	assert.NotContains(t, stored, "secret-refresh-alice")
+
+	token, err := c.Get(ctx, "backend-1:abc:api")
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, "secret-access-alice", token.Token)
+
+	// A cache without the key cannot read the entry.
+	plain := NewRedisTokenCacheWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:tokens:")
+	t.Cleanup(func() { _ = plain.Close() })
+	_, err = plain.Get(ctx, "backend-1:abc:api")
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/stacklok/toolhive/pkg/secrets"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+)
+
+// resolveBackendTLS reads the CA bundles and client certificates of the
+// per-backend TLS settings. Files are read from their configured paths;
+// secrets resolve via provider, which in production is
+// secrets.EnvironmentProvider reading TOOLHIVE_SECRET_<name> env vars. A client
+// certificate that does not match its key fails here, at startup, rather than
+// on the first request to the backend.
+func resolveBackendTLS(
+	ctx context.Context,
+	backends map[string]*config.BackendTLSConfig,
+	provider secrets.Provider,
+) (map[string]vmcpclient.BackendTLSConfig, error) {
+	resolved := make(map[string]vmcpclient.BackendTLSConfig, len(backends))
+	for name, tlsCfg := range backends {
+		if tlsCfg == nil {
+			continue
+		}
+
+		var out vmcpclient.BackendTLSConfig
+		var err error
+		if tlsCfg.CABundlePath != "" {
+			out.CABundle, err = os.ReadFile(tlsCfg.CABundlePath) //nolint:gosec // path is validated by config validator
+			if err != nil {
+				return nil, fmt.Errorf("backend %s: failed to read CA bundle: %w", name, err)
+			}
+		}
+		if out.ClientCert, err = readPEM(ctx, provider, tlsCfg.ClientCertPath, tlsCfg.ClientCertSecret); err != nil {
+			return nil, fmt.Errorf("backend %s: failed to read client certificate: %w", name, err)
+		}
+		if out.ClientKey, err = readPEM(ctx, provider, tlsCfg.ClientKeyPath, tlsCfg.ClientKeySecret); err != nil {
+			return nil, fmt.Errorf("backend %s: failed to read client key: %w", name, err)
+		}
+		if len(out.ClientCert) > 0 || len(out.ClientKey) > 0 {
+			if _, err := tls.X509KeyPair(out.ClientCert, out.ClientKey); err != nil {
+				return nil, fmt.Errorf("backend %s: invalid client certificate: %w", name, err)
+			}
+		}
+
+		if tlsCfg.InsecureSkipVerify {
+			slog.Warn("TLS certificate verification is disabled for backend", "backend", name)
+			out.InsecureSkipVerify = true
+		}
+		resolved[name] = out
+	}
+	return resolved, nil
+}
+
+// readPEM returns the PEM data at path, or of the named secret. Returns nil
+// when neither is set.
+func readPEM(ctx context.Context, provider secrets.Provider, path, secretName string) ([]byte, error) {
+	switch {
+	case path != "":
+		return os.ReadFile(path) //nolint:gosec // path is validated by config validator
+	case secretName != "":
+		value, err := provider.GetSecret(ctx, secretName)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(value), nil
+	default:
+		return nil, nil
+	}
+}
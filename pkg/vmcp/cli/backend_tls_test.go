@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/secrets"
+	secretsmocks "github.com/stacklok/toolhive/pkg/secrets/mocks"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+)
+
+// generateTestKeyPair returns a self-signed certificate and its private key in
+// PEM form.
+func generateTestKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "vmcp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestResolveBackendTLS(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := generateTestKeyPair(t)
+	otherCertPEM, _ := generateTestKeyPair(t)
+
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+	caPath := writeFile("ca.crt", certPEM)
+	certPath := writeFile("tls.crt", certPEM)
+	keyPath := writeFile("tls.key", keyPEM)
+
+	ctrl := gomock.NewController(t)
+	provider := secretsmocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetSecret(gomock.Any(), "BACKEND_CERT").Return(string(certPEM), nil).AnyTimes()
+	provider.EXPECT().GetSecret(gomock.Any(), "BACKEND_KEY").Return(string(keyPEM), nil).AnyTimes()
+	provider.EXPECT().GetSecret(gomock.Any(), "OTHER_CERT").Return(string(otherCertPEM), nil).AnyTimes()
+	provider.EXPECT().GetSecret(gomock.Any(), "MISSING").Return("", secrets.ErrSecretNotFound).AnyTimes()
+
+	tests := []struct {
+		name    string
+		tls     *config.BackendTLSConfig
+		wantErr string
+		check   func(t *testing.T, caBundle, cert, key []byte, skipVerify bool)
+	}{
+		{
+			name: "files",
+			tls:  &config.BackendTLSConfig{CABundlePath: caPath, ClientCertPath: certPath, ClientKeyPath: keyPath},
+			check: func(t *testing.T, caBundle, cert, key []byte, skipVerify bool) {
+				t.Helper()
+				assert.Equal(t, certPEM, caBundle)
+				assert.Equal(t, certPEM, cert)
+				assert.Equal(t, keyPEM, key)
+				assert.False(t, skipVerify)
+			},
+		},
+		{
+			name: "secrets",
+			tls:  &config.BackendTLSConfig{ClientCertSecret: "BACKEND_CERT", ClientKeySecret: "BACKEND_KEY"},
+			check: func(t *testing.T, caBundle, cert, key []byte, _ bool) {
+				t.Helper()
+				assert.Empty(t, caBundle)
+				assert.Equal(t, certPEM, cert)
+				assert.Equal(t, keyPEM, key)
+			},
+		},
+		{
+			name: "insecure skip verify",
+			tls:  &config.BackendTLSConfig{InsecureSkipVerify: true},
+			check: func(t *testing.T, _, _, _ []byte, skipVerify bool) {
+				t.Helper()
+				assert.True(t, skipVerify)
+			},
+		},
+		{
+			name:    "missing file",
+			tls:     &config.BackendTLSConfig{CABundlePath: filepath.Join(dir, "missing.crt")},
+			wantErr: "failed to read CA bundle",
+		},
+		{
+			name:    "missing secret",
+			tls:     &config.BackendTLSConfig{ClientCertSecret: "MISSING", ClientKeySecret: "BACKEND_KEY"},
+			wantErr: "failed to read client certificate",
+		},
+		{
+			name:    "mismatched key",
+			tls:     &config.BackendTLSConfig{ClientCertSecret: "OTHER_CERT", ClientKeySecret: "BACKEND_KEY"},
+			wantErr: "invalid client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolved, err := resolveBackendTLS(t.Context(), map[string]*config.BackendTLSConfig{"github": tt.tls}, provider)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, resolved, "github")
+			got := resolved["github"]
+			tt.check(t, got.CABundle, got.ClientCert, got.ClientKey, got.InsecureSkipVerify)
+		})
+	}
+}
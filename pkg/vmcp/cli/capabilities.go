@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/aggregator"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+)
+
+// CapabilitiesConfig holds parameters for the capabilities command.
+type CapabilitiesConfig struct {
+	// ConfigPath is the path to the vMCP YAML configuration file.
+	ConfigPath string
+
+	// JSON prints the report as JSON instead of tables.
+	JSON bool
+
+	// NoConnect only validates the configuration, without discovering or
+	// querying any backend.
+	NoConnect bool
+
+	// Out receives the report. Defaults to os.Stdout.
+	Out io.Writer
+}
+
+// CapabilitiesReport is the aggregated capability view of a vMCP
+// configuration, as printed by the capabilities command.
+type CapabilitiesReport struct {
+	// Backends are the discovered backends, or the statically configured ones
+	// in --no-connect mode.
+	Backends []BackendSummary `json:"backends"`
+
+	// Tools are the routable tools, advertised or not, sorted by name.
+	Tools []CapabilityTarget `json:"tools"`
+
+	// Resources are the routable resources, sorted by URI.
+	Resources []CapabilityTarget `json:"resources"`
+
+	// Prompts are the routable prompts, sorted by name.
+	Prompts []CapabilityTarget `json:"prompts"`
+
+	// Conflicts are the tool names exposed by more than one backend.
+	Conflicts []ToolConflict `json:"conflicts,omitempty"`
+
+	// UnreachableBackends lists the backends whose capabilities could not be
+	// queried.
+	UnreachableBackends []string `json:"unreachableBackends,omitempty"`
+}
+
+// BackendSummary identifies a backend in a CapabilitiesReport.
+type BackendSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// CapabilityTarget is a capability exposed by the vMCP and the backend it
+// routes to.
+type CapabilityTarget struct {
+	// Name is the name (or URI) clients use.
+	Name string `json:"name"`
+
+	// Description is the description advertised to clients.
+	Description string `json:"description,omitempty"`
+
+	// BackendID identifies the backend the capability routes to.
+	BackendID string `json:"backendId"`
+
+	// BackendCapabilityName is the name the backend itself uses.
+	BackendCapabilityName string `json:"backendCapabilityName"`
+
+	// Advertised is false for tools hidden from clients by filtering, which
+	// remain routable for composite tools.
+	Advertised bool `json:"advertised"`
+}
+
+// ToolConflict is a tool name exposed by more than one backend, with the
+// outcome of conflict resolution.
+type ToolConflict struct {
+	// Name is the conflicting tool name.
+	Name string `json:"name"`
+
+	// Backends are the backends exposing the tool.
+	Backends []string `json:"backends"`
+
+	// Resolved maps the tool names kept after conflict resolution to their
+	// backend. Backends missing from it had their tool dropped.
+	Resolved map[string]string `json:"resolved"`
+}
+
+// Capabilities loads a vMCP configuration, runs discovery and aggregation
+// against its backends, and prints the resulting capabilities with their
+// backend targets and any conflicts.
+func Capabilities(ctx context.Context, cfg CapabilitiesConfig) error {
+	if cfg.ConfigPath == "" {
+		return fmt.Errorf("no configuration file specified, use --config flag")
+	}
+	out := cfg.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	vmcpCfg, err := loadAndValidateConfig(cfg.ConfigPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if cfg.NoConnect {
+		report := newCapabilitiesReport()
+		for _, b := range vmcpCfg.Backends {
+			report.Backends = append(report.Backends, BackendSummary{ID: b.Name, Name: b.Name, URL: b.URL})
+		}
+		return writeCapabilitiesReport(out, report, cfg.JSON)
+	}
+
+	backends, backendClient, _, err := discoverBackends(ctx, vmcpCfg)
+	if err != nil {
+		return err
+	}
+	report, err := inspectCapabilities(ctx, vmcpCfg, backends, backendClient)
+	if err != nil {
+		return err
+	}
+	return writeCapabilitiesReport(out, report, cfg.JSON)
+}
+
+// inspectCapabilities runs the aggregation pipeline of vmcpCfg over backends
+// and builds its report.
+func inspectCapabilities(
+	ctx context.Context,
+	vmcpCfg *config.Config,
+	backends []vmcp.Backend,
+	backendClient vmcp.BackendClient,
+) (*CapabilitiesReport, error) {
+	conflictResolver, err := aggregator.NewConflictResolver(vmcpCfg.Aggregation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conflict resolver: %w", err)
+	}
+	agg, err := aggregator.NewDefaultAggregator(backendClient, conflictResolver, vmcpCfg.Aggregation, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator: %w", err)
+	}
+
+	// The pipeline runs step by step, rather than through
+	// AggregateCapabilities, to report the conflicts between raw capabilities.
+	capabilities, err := agg.QueryAllCapabilities(ctx, backends)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backend capabilities: %w", err)
+	}
+	resolved, err := agg.ResolveConflicts(ctx, capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve conflicts: %w", err)
+	}
+	aggregated, err := agg.MergeCapabilities(ctx, resolved, vmcp.NewImmutableRegistry(backends))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge capabilities: %w", err)
+	}
+
+	report := newCapabilitiesReport()
+	report.Conflicts = toolConflicts(capabilities, resolved)
+	for _, b := range backends {
+		report.Backends = append(report.Backends, BackendSummary{ID: b.ID, Name: b.Name, URL: b.BaseURL})
+		if _, ok := capabilities[b.ID]; !ok {
+			report.UnreachableBackends = append(report.UnreachableBackends, b.ID)
+		}
+	}
+
+	advertised := make(map[string]string, len(aggregated.Tools))
+	for _, tool := range aggregated.Tools {
+		advertised[tool.Name] = tool.Description
+	}
+	for name, target := range aggregated.RoutingTable.Tools {
+		description, ok := advertised[name]
+		if !ok {
+			description = resolved.Tools[name].Description
+		}
+		report.Tools = append(report.Tools, capabilityTarget(name, description, target, ok))
+	}
+	for _, resource := range aggregated.Resources {
+		report.Resources = append(report.Resources, capabilityTarget(
+			resource.URI, resource.Description, aggregated.RoutingTable.Resources[resource.URI], true))
+	}
+	for _, prompt := range aggregated.Prompts {
+		report.Prompts = append(report.Prompts, capabilityTarget(
+			prompt.Name, prompt.Description, aggregated.RoutingTable.Prompts[prompt.Name], true))
+	}
+	for _, targets := range [][]CapabilityTarget{report.Tools, report.Resources, report.Prompts} {
+		sort.Slice(targets, func(i, j int) bool { return targets[i].Name < targets[j].Name })
+	}
+
+	slog.Debug("capabilities inspected",
+		"tools", len(report.Tools), "resources", len(report.Resources), "prompts", len(report.Prompts))
+	return report, nil
+}
+
+// newCapabilitiesReport returns an empty report whose lists encode as empty
+// JSON arrays rather than null.
+func newCapabilitiesReport() *CapabilitiesReport {
+	return &CapabilitiesReport{
+		Backends:  []BackendSummary{},
+		Tools:     []CapabilityTarget{},
+		Resources: []CapabilityTarget{},
+		Prompts:   []CapabilityTarget{},
+	}
+}
+
+func capabilityTarget(name, description string, target *vmcp.BackendTarget, advertised bool) CapabilityTarget {
+	c := CapabilityTarget{Name: name, Description: description, BackendCapabilityName: name, Advertised: advertised}
+	if target != nil {
+		c.BackendID = target.WorkloadID
+		c.BackendCapabilityName = target.GetBackendCapabilityName(name)
+	}
+	return c
+}
+
+// toolConflicts returns the tool names queried from more than one backend,
+// sorted by name, with the tools conflict resolution kept for each.
+func toolConflicts(
+	capabilities map[string]*aggregator.BackendCapabilities,
+	resolved *aggregator.ResolvedCapabilities,
+) []ToolConflict {
+	backendsByTool := make(map[string][]string)
+	for backendID, caps := range capabilities {
+		for _, tool := range caps.Tools {
+			backendsByTool[tool.Name] = append(backendsByTool[tool.Name], backendID)
+		}
+	}
+
+	var conflicts []ToolConflict
+	for name, backendIDs := range backendsByTool {
+		if len(backendIDs) < 2 {
+			continue
+		}
+		slices.Sort(backendIDs)
+		conflict := ToolConflict{Name: name, Backends: backendIDs, Resolved: map[string]string{}}
+		for resolvedName, tool := range resolved.Tools {
+			if tool.OriginalName == name {
+				conflict.Resolved[resolvedName] = tool.BackendID
+			}
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Name < conflicts[j].Name })
+	return conflicts
+}
+
+// writeCapabilitiesReport prints report to w, as JSON or as tables.
+func writeCapabilitiesReport(w io.Writer, report *CapabilitiesReport, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "BACKENDS (%d)\n", len(report.Backends))
+	for _, b := range report.Backends {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", b.ID, b.Name, b.URL)
+	}
+	for _, section := range []struct {
+		title   string
+		targets []CapabilityTarget
+	}{
+		{"TOOLS", report.Tools},
+		{"RESOURCES", report.Resources},
+		{"PROMPTS", report.Prompts},
+	} {
+		fmt.Fprintf(tw, "\n%s (%d)\n", section.title, len(section.targets))
+		for _, c := range section.targets {
+			hidden := ""
+			if !c.Advertised {
+				hidden = "(hidden)"
+			}
+			fmt.Fprintf(tw, "  %s\t-> %s/%s\t%s\n", c.Name, c.BackendID, c.BackendCapabilityName, hidden)
+		}
+	}
+	if len(report.Conflicts) > 0 {
+		fmt.Fprintf(tw, "\nCONFLICTS (%d)\n", len(report.Conflicts))
+		for _, c := range report.Conflicts {
+			kept := make([]string, 0, len(c.Resolved))
+			for name, backendID := range c.Resolved {
+				kept = append(kept, name+" ("+backendID+")")
+			}
+			slices.Sort(kept)
+			fmt.Fprintf(tw, "  %s\t%v\tkept: %v\n", c.Name, c.Backends, kept)
+		}
+	}
+	if len(report.UnreachableBackends) > 0 {
+		fmt.Fprintf(tw, "\nUNREACHABLE BACKENDS: %v\n", report.UnreachableBackends)
+	}
+	return tw.Flush()
+}
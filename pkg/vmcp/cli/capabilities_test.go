@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+	vmcpmocks "github.com/stacklok/toolhive/pkg/vmcp/mocks"
+)
+
+// stubCapabilities is what each stub backend reports when queried, stamped
+// with the backend ID like the HTTP backend client does.
+var stubCapabilities = map[string]*vmcp.CapabilityList{
+	"github": {
+		Tools: []vmcp.Tool{
+			{Name: "search", Description: "Search GitHub"},
+			{Name: "create_issue", Description: "Create an issue"},
+		},
+		Prompts: []vmcp.Prompt{{Name: "review", Description: "Review a PR", BackendID: "github"}},
+	},
+	"docs": {
+		Tools:     []vmcp.Tool{{Name: "search", Description: "Search the docs"}},
+		Resources: []vmcp.Resource{{URI: "docs://index", Name: "index", Description: "Docs index", BackendID: "docs"}},
+	},
+}
+
+func newStubBackendClient(t *testing.T) *vmcpmocks.MockBackendClient {
+	t.Helper()
+
+	backendClient := vmcpmocks.NewMockBackendClient(gomock.NewController(t))
+	backendClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+			caps, ok := stubCapabilities[target.WorkloadID]
+			if !ok {
+				return nil, errors.New("connection refused")
+			}
+			return caps, nil
+		}).AnyTimes()
+	return backendClient
+}
+
+func stubBackend(id string) vmcp.Backend {
+	return vmcp.Backend{
+		ID:            id,
+		Name:          id,
+		BaseURL:       "http://" + id + ":8080/mcp",
+		TransportType: "streamable-http",
+		HealthStatus:  vmcp.BackendHealthy,
+	}
+}
+
+func TestInspectCapabilities(t *testing.T) {
+	t.Parallel()
+
+	vmcpCfg := &config.Config{
+		Aggregation: &config.AggregationConfig{
+			ConflictResolution:       vmcp.ConflictStrategyPrefix,
+			ConflictResolutionConfig: &config.ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+			Tools: []*config.WorkloadToolConfig{
+				{Workload: "github", Filter: []string{"search"}},
+			},
+		},
+	}
+	backends := []vmcp.Backend{stubBackend("github"), stubBackend("docs"), stubBackend("offline")}
+
+	report, err := inspectCapabilities(context.Background(), vmcpCfg, backends, newStubBackendClient(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []CapabilityTarget{
+		{Name: "docs_search", Description: "Search the docs", BackendID: "docs", BackendCapabilityName: "search", Advertised: true},
+		{
+			Name: "github_create_issue", Description: "Create an issue",
+			BackendID: "github", BackendCapabilityName: "create_issue", Advertised: false,
+		},
+		{Name: "github_search", Description: "Search GitHub", BackendID: "github", BackendCapabilityName: "search", Advertised: true},
+	}, report.Tools)
+	assert.Equal(t, []CapabilityTarget{
+		{Name: "docs://index", Description: "Docs index", BackendID: "docs", BackendCapabilityName: "docs://index", Advertised: true},
+	}, report.Resources)
+	assert.Equal(t, []CapabilityTarget{
+		{Name: "review", Description: "Review a PR", BackendID: "github", BackendCapabilityName: "review", Advertised: true},
+	}, report.Prompts)
+
+	assert.Equal(t, []ToolConflict{{
+		Name:     "search",
+		Backends: []string{"docs", "github"},
+		Resolved: map[string]string{"docs_search": "docs", "github_search": "github"},
+	}}, report.Conflicts)
+	assert.Equal(t, []string{"offline"}, report.UnreachableBackends)
+	assert.Len(t, report.Backends, 3)
+}
+
+func TestWriteCapabilitiesReport(t *testing.T) {
+	t.Parallel()
+
+	report := newCapabilitiesReport()
+	report.Backends = []BackendSummary{{ID: "github", Name: "github", URL: "http://github:8080/mcp"}}
+	report.Tools = []CapabilityTarget{
+		{Name: "github_search", BackendID: "github", BackendCapabilityName: "search", Advertised: true},
+		{Name: "github_create_issue", BackendID: "github", BackendCapabilityName: "create_issue"},
+	}
+	report.Conflicts = []ToolConflict{{
+		Name: "search", Backends: []string{"docs", "github"}, Resolved: map[string]string{"github_search": "github"},
+	}}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		require.NoError(t, writeCapabilitiesReport(&out, report, true))
+
+		var got CapabilitiesReport
+		require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+		assert.Equal(t, *report, got)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		require.NoError(t, writeCapabilitiesReport(&out, report, false))
+
+		text := out.String()
+		assert.Contains(t, text, "TOOLS (2)")
+		assert.Regexp(t, `github_search\s+-> github/search`, text)
+		assert.Regexp(t, `github_create_issue\s+-> github/create_issue\s+\(hidden\)`, text)
+		assert.Contains(t, text, "CONFLICTS (1)")
+		assert.Contains(t, text, "kept: [github_search (github)]")
+	})
+}
+
+func TestCapabilities_NoConnect(t *testing.T) {
+	t.Parallel()
+
+	const staticConfigYAML = validConfigYAML + `
+backends:
+  - name: github
+    url: http://github:8080/mcp
+    transport: streamable-http
+`
+	path := filepath.Join(t.TempDir(), "vmcp.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(staticConfigYAML), 0o600))
+
+	var out bytes.Buffer
+	require.NoError(t, Capabilities(context.Background(), CapabilitiesConfig{
+		ConfigPath: path,
+		JSON:       true,
+		NoConnect:  true,
+		Out:        &out,
+	}))
+
+	var report CapabilitiesReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, []BackendSummary{{ID: "github", Name: "github", URL: "http://github:8080/mcp"}}, report.Backends)
+	assert.Empty(t, report.Tools)
+
+	err := Capabilities(context.Background(), CapabilitiesConfig{NoConnect: true, Out: &out})
+	assert.ErrorContains(t, err, "no configuration file specified")
+}
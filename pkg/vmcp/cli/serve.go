@@ -11,35 +11,44 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 	"k8s.io/client-go/rest"
 
 	"github.com/stacklok/toolhive-core/env"
+	tcredis "github.com/stacklok/toolhive-core/redis"
 	"github.com/stacklok/toolhive/pkg/audit"
 	"github.com/stacklok/toolhive/pkg/auth/upstreamtoken"
 	authserverconfig "github.com/stacklok/toolhive/pkg/authserver"
 	authserverrunner "github.com/stacklok/toolhive/pkg/authserver/runner"
 	"github.com/stacklok/toolhive/pkg/authserver/server/keys"
+	authstorage "github.com/stacklok/toolhive/pkg/authserver/storage"
 	"github.com/stacklok/toolhive/pkg/container"
 	"github.com/stacklok/toolhive/pkg/container/runtime"
 	"github.com/stacklok/toolhive/pkg/groups"
 	"github.com/stacklok/toolhive/pkg/migration"
+	"github.com/stacklok/toolhive/pkg/secrets"
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	"github.com/stacklok/toolhive/pkg/versions"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/aggregator"
 	vmcpauth "github.com/stacklok/toolhive/pkg/vmcp/auth"
 	authfactory "github.com/stacklok/toolhive/pkg/vmcp/auth/factory"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth/strategies"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
 	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
 	"github.com/stacklok/toolhive/pkg/vmcp/codemode"
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 	"github.com/stacklok/toolhive/pkg/vmcp/health"
 	"github.com/stacklok/toolhive/pkg/vmcp/k8s"
@@ -63,9 +72,12 @@ const modernDispatchEnvVar = "TOOLHIVE_VMCP_MODERN_STATELESS"
 // At least one of ConfigPath or GroupRef must be non-empty; ConfigPath takes
 // precedence when both are provided.
 type ServeConfig struct {
-	// ConfigPath is the path to the vMCP YAML configuration file.
+	// ConfigPath is the path to the vMCP YAML configuration file, or
+	// StdinConfigPath to read the configuration from Stdin.
 	// When set, takes precedence over GroupRef.
 	ConfigPath string
+	// Stdin is read when ConfigPath is StdinConfigPath. Nil means os.Stdin.
+	Stdin io.Reader
 	// GroupRef is a ToolHive group name used for zero-config quick mode when
 	// ConfigPath is empty. A minimal in-memory config is generated from this value.
 	GroupRef string
@@ -133,7 +145,7 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 	vmcpCfg, err := func() (*config.Config, error) {
 		switch {
 		case cfg.ConfigPath != "":
-			return loadAndValidateConfig(cfg.ConfigPath)
+			return loadAndValidateConfig(cfg.ConfigPath, cfg.Stdin)
 		case cfg.GroupRef != "":
 			return generateQuickModeConfig(cfg.GroupRef)
 		default:
@@ -152,9 +164,10 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 	}
 
 	// Load auth server config from sibling file if present.
-	// Skip in quick mode (no config file) — there is no sibling directory to search.
+	// Skip in quick mode and for stdin configs (no config file) — there is no
+	// sibling directory to search.
 	var authServerRC *authserverconfig.RunConfig
-	if cfg.ConfigPath != "" {
+	if cfg.ConfigPath != "" && cfg.ConfigPath != StdinConfigPath {
 		authServerRC, err = loadAuthServerConfig(cfg.ConfigPath)
 		if err != nil {
 			return err
@@ -182,11 +195,31 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 		slog.Info("embedded authorization server initialized")
 	}
 
+	// Cache the backend tokens that outgoing auth exchanges for users. The admin
+	// API lists and invalidates them.
+	tokenCache, err := buildTokenCache(ctx, vmcpCfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := tokenCache.Close(); closeErr != nil {
+			slog.Error(fmt.Sprintf("failed to close token cache: %v", closeErr))
+		}
+	}()
+
 	// Discover backends and create client.
-	backends, backendClient, outgoingRegistry, err := discoverBackends(ctx, vmcpCfg)
+	backends, backendClient, outgoingRegistry, err := discoverBackends(ctx, vmcpCfg, strategies.WithTokenCache(tokenCache))
 	if err != nil {
 		return err
 	}
+	// The client runs the processes of stdio backends; stop them on exit.
+	if closer, ok := backendClient.(io.Closer); ok {
+		defer func() {
+			if closeErr := closer.Close(); closeErr != nil {
+				slog.Error(fmt.Sprintf("failed to close backend client: %v", closeErr))
+			}
+		}()
+	}
 
 	// Create conflict resolver based on configuration.
 	conflictResolver, err := aggregator.NewConflictResolver(vmcpCfg.Aggregation)
@@ -208,12 +241,18 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 		}()
 	}
 
-	// Create aggregator with tracer provider (nil if telemetry not configured).
+	// Create aggregator with tracer and meter providers (nil if telemetry not configured).
 	var tracerProvider trace.TracerProvider
+	var meterProvider metric.MeterProvider
 	if telemetryProvider != nil {
 		tracerProvider = telemetryProvider.TracerProvider()
+		meterProvider = telemetryProvider.MeterProvider()
+	}
+	agg, err := aggregator.NewDefaultAggregator(
+		backendClient, conflictResolver, vmcpCfg.Aggregation, tracerProvider, meterProvider)
+	if err != nil {
+		return fmt.Errorf("failed to create aggregator: %w", err)
 	}
-	agg := aggregator.NewDefaultAggregator(backendClient, conflictResolver, vmcpCfg.Aggregation, tracerProvider)
 
 	// DynamicRegistry tracks backends for dynamic discovery in Kubernetes mode.
 	dynamicRegistry := vmcp.NewDynamicRegistry(backends)
@@ -289,8 +328,10 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 		healthMonitorConfig = &health.MonitorConfig{
 			CheckInterval:      checkInterval,
 			UnhealthyThreshold: vmcpCfg.Operational.FailureHandling.UnhealthyThreshold,
+			HealthyThreshold:   vmcpCfg.Operational.FailureHandling.HealthyThreshold,
 			Timeout:            healthCheckTimeout,
 			DegradedThreshold:  defaults.DegradedThreshold,
+			Backends:           getBackendHealthCheckSettings(vmcpCfg.Operational.FailureHandling.BackendHealthChecks),
 		}
 
 		if vmcpCfg.Operational.FailureHandling.CircuitBreaker != nil {
@@ -310,7 +351,7 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 	}
 
 	// Create status reporter.
-	statusReporter, err := vmcpstatus.NewReporter()
+	statusReporter, err := vmcpstatus.NewReporter(vmcpstatus.Options{})
 	if err != nil {
 		return fmt.Errorf("failed to create status reporter: %w", err)
 	}
@@ -357,7 +398,9 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 	}
 	// The factory never aggregates — the core is the single source of capability
 	// aggregation (agg feeds it via Config.Aggregator below).
-	sessionFactory := vmcpsession.NewSessionFactory(outgoingRegistry)
+	// Stdio backends share the processes run by the backend client.
+	sessionFactory := vmcpsession.NewSessionFactory(outgoingRegistry,
+		vmcpsession.WithStdioBackendClient(backendClient))
 
 	// When the optimizer is enabled, its meta-tools are pass-through tools.
 	// Authz uses this for optimizer-aware authorization/filtering.
@@ -453,12 +496,18 @@ func Serve(ctx context.Context, cfg ServeConfig) error {
 		AuditConfig:             vmcpCfg.Audit,
 		HealthMonitorConfig:     healthMonitorConfig,
 		StatusReportingInterval: getStatusReportingInterval(vmcpCfg),
+		ShutdownGracePeriod:     getShutdownGracePeriod(vmcpCfg),
+		Warmup:                  getWarmupConfig(vmcpCfg),
+		WorkflowLimits:          getWorkflowLimits(vmcpCfg),
+		WorkflowEvents:          composer.NewWorkflowEventBroker(getRedactFields(vmcpCfg)),
+		WorkflowFailureSink:     getWorkflowFailureSink(vmcpCfg),
 		Watcher:                 nil, // set below if backendWatcher is non-nil
 		StatusReporter:          statusReporter,
 		OptimizerConfig:         optCfg,
 		CodeModeConfig:          codemode.FromConfig(vmcpCfg.CodeMode),
 		SessionFactory:          sessionFactory,
 		SessionStorage:          vmcpCfg.SessionStorage,
+		TokenCache:              tokenCache,
 		// Core collaborators: server.New routes through core.New + Serve, so the core
 		// is the single aggregator and authorizer. The aggregator is the same instance
 		// that backs discovery; Authz feeds the core admission seam (nil = allow-all).
@@ -539,12 +588,126 @@ func getStatusReportingInterval(cfg *config.Config) time.Duration {
 	return 0
 }
 
-// loadAndValidateConfig loads and validates the vMCP configuration file.
-func loadAndValidateConfig(configPath string) (*config.Config, error) {
-	slog.Info(fmt.Sprintf("Loading configuration from: %s", configPath))
+// getShutdownGracePeriod extracts the shutdown drain grace period from config.
+// Returns 0 if not configured, which uses the default grace period.
+func getShutdownGracePeriod(cfg *config.Config) time.Duration {
+	if cfg.Operational != nil && cfg.Operational.ShutdownGracePeriod > 0 {
+		return time.Duration(cfg.Operational.ShutdownGracePeriod)
+	}
+	return 0
+}
 
-	envReader := &env.OSReader{}
-	loader := config.NewYAMLLoader(configPath, envReader)
+// getWarmupConfig extracts the backend warmup configuration from config.
+// Returns nil if not configured, which disables the warmup.
+func getWarmupConfig(cfg *config.Config) *vmcpserver.WarmupConfig {
+	if cfg.Operational == nil || cfg.Operational.Warmup == nil {
+		return nil
+	}
+	return &vmcpserver.WarmupConfig{
+		Timeout:           time.Duration(cfg.Operational.Warmup.Timeout),
+		MinHealthyPercent: cfg.Operational.Warmup.MinHealthyPercent,
+	}
+}
+
+// getRedactFields returns the audit redaction fields, which also apply to the
+// streamed workflow step events. Returns nil when auditing is not configured.
+func getRedactFields(cfg *config.Config) []string {
+	if cfg.Audit == nil {
+		return nil
+	}
+	return cfg.Audit.RedactFields
+}
+
+// getWorkflowLimits extracts the composite workflow limits from config.
+// Unset limits are zero, which selects the composer defaults.
+func getWorkflowLimits(cfg *config.Config) composer.WorkflowLimits {
+	if cfg.Operational == nil || cfg.Operational.WorkflowLimits == nil {
+		return composer.WorkflowLimits{}
+	}
+	return composer.WorkflowLimits{
+		MaxSteps:            cfg.Operational.WorkflowLimits.MaxSteps,
+		MaxCompositionDepth: cfg.Operational.WorkflowLimits.MaxCompositionDepth,
+	}
+}
+
+// getBackendClientOptions builds the connection pool and retry options of the
+// backend client. Unset settings keep the client defaults.
+func getBackendClientOptions(cfg *config.Config) []vmcpclient.Option {
+	policy := vmcpclient.DefaultRetryPolicy()
+	if cfg.Operational == nil || cfg.Operational.BackendClient == nil {
+		return []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)}
+	}
+
+	bc := cfg.Operational.BackendClient
+	if bc.RetryAttempts > 0 {
+		policy.MaxAttempts = bc.RetryAttempts
+	}
+	if bc.RetryBackoff > 0 {
+		policy.InitialBackoff = time.Duration(bc.RetryBackoff)
+	}
+	return []vmcpclient.Option{
+		vmcpclient.WithConnectionPool(vmcpclient.ConnectionPoolConfig{
+			MaxIdleConns:        bc.MaxIdleConns,
+			MaxIdleConnsPerHost: bc.MaxIdleConnsPerHost,
+			IdleConnTimeout:     time.Duration(bc.IdleConnTimeout),
+		}),
+		vmcpclient.WithRetryPolicy(policy),
+	}
+}
+
+// getBackendHealthCheckSettings converts the per-backend health check overrides
+// to monitor settings keyed by backend ID. Unset fields stay zero so the monitor
+// fills them in from its monitor-wide settings.
+func getBackendHealthCheckSettings(overrides map[string]*config.HealthCheckConfig) map[string]health.CheckSettings {
+	if len(overrides) == 0 {
+		return nil
+	}
+	settings := make(map[string]health.CheckSettings, len(overrides))
+	for name, hc := range overrides {
+		if hc == nil {
+			continue
+		}
+		settings[name] = health.CheckSettings{
+			Interval:           time.Duration(hc.Interval),
+			Timeout:            time.Duration(hc.Timeout),
+			UnhealthyThreshold: hc.UnhealthyThreshold,
+			HealthyThreshold:   hc.HealthyThreshold,
+			Probe:              health.ProbeMethod(hc.Probe),
+			ProbePath:          hc.ProbePath,
+		}
+	}
+	return settings
+}
+
+// getWorkflowFailureSink builds the sink failed composite workflows are recorded
+// to. Returns nil, which disables failure recording, when it is not configured.
+func getWorkflowFailureSink(cfg *config.Config) composer.FailureSink {
+	if cfg.Operational == nil || cfg.Operational.WorkflowFailures == nil {
+		return nil
+	}
+	switch cfg.Operational.WorkflowFailures.Destination {
+	case config.WorkflowFailureDestinationFile:
+		return composer.NewFileFailureSink(cfg.Operational.WorkflowFailures.Path)
+	default:
+		return composer.NewLogFailureSink()
+	}
+}
+
+// StdinConfigPath is the configuration path that reads the configuration from
+// stdin, for configurations generated on the fly by templating pipelines.
+const StdinConfigPath = "-"
+
+// loadAndValidateConfig loads and validates the vMCP configuration file. When
+// configPath is StdinConfigPath the configuration is read from stdin instead
+// (os.Stdin if nil) and validated identically.
+func loadAndValidateConfig(configPath string, stdin io.Reader) (*config.Config, error) {
+	if configPath == StdinConfigPath {
+		slog.Info("Loading configuration from stdin")
+	} else {
+		slog.Info(fmt.Sprintf("Loading configuration from: %s", configPath))
+	}
+
+	loader := newConfigLoader(configPath, stdin)
 	cfg, err := loader.Load()
 	if err != nil {
 		slog.Error(fmt.Sprintf("Failed to load configuration: %v", err))
@@ -568,6 +731,19 @@ func loadAndValidateConfig(configPath string) (*config.Config, error) {
 	return cfg, nil
 }
 
+// newConfigLoader returns the loader of the configuration at configPath, which
+// reads stdin (os.Stdin if nil) when configPath is StdinConfigPath.
+func newConfigLoader(configPath string, stdin io.Reader) *config.YAMLLoader {
+	envReader := &env.OSReader{}
+	if configPath != StdinConfigPath {
+		return config.NewYAMLLoader(configPath, envReader)
+	}
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	return config.NewYAMLReaderLoader(stdin, envReader)
+}
+
 // generateQuickModeConfig constructs a minimal in-memory config for zero-config
 // quick mode (thv vmcp serve --group <name>). It sets groupRef from groupRef,
 // incomingAuth to anonymous, and outgoingAuth.source to "inline" so no
@@ -629,21 +805,74 @@ func loadAuthServerConfig(configPath string) (*authserverconfig.RunConfig, error
 	return &rc, nil
 }
 
+// tokenCacheKeyPurpose binds the token cache encryption key to its use.
+const tokenCacheKeyPurpose = "toolhive vmcp token cache encryption"
+
+// buildTokenCache creates the cache of exchanged backend tokens. Tokens are
+// kept in process unless session storage is on Redis and an encryption key is
+// set in THV_TOKEN_CACHE_ENCRYPTION_KEY, in which case they are encrypted and
+// shared by every replica through the same Redis server, under their own key
+// prefix; the password is read from the THV_SESSION_REDIS_PASSWORD environment
+// variable, as for sessions. Tokens are never written to Redis in plaintext.
+func buildTokenCache(ctx context.Context, cfg *config.Config) (cache.TokenCache, error) {
+	storage := cfg.SessionStorage
+	if storage == nil || !strings.EqualFold(storage.Provider, "redis") {
+		return cache.NewMemoryTokenCache(), nil
+	}
+
+	encryptionKey := os.Getenv(config.TokenCacheEncryptionKeyEnvVar)
+	if encryptionKey == "" {
+		slog.Warn("token cache encryption key not set, keeping exchanged tokens in memory instead of Redis",
+			"env_var", config.TokenCacheEncryptionKeyEnvVar)
+		return cache.NewMemoryTokenCache(), nil
+	}
+	cipher, err := authstorage.NewTokenCipherForPurpose(tokenCacheKeyPurpose, []byte(encryptionKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", config.TokenCacheEncryptionKeyEnvVar, err)
+	}
+
+	keyPrefix := "thv:vmcp:token:"
+	if storage.KeyPrefix != "" {
+		keyPrefix = storage.KeyPrefix + "token:"
+	}
+	tokenCache, err := cache.NewRedisTokenCache(ctx, tcredis.Config{
+		Addr:     storage.Address,
+		Password: os.Getenv(config.RedisPasswordEnvVar),
+		DB:       int(storage.DB),
+	}, keyPrefix, cache.WithTokenCipher(cipher))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Redis token cache: %w", err)
+	}
+	slog.Info("using Redis token cache", "address", storage.Address, "key_prefix", keyPrefix)
+	return tokenCache, nil
+}
+
 // discoverBackends initializes managers, discovers backends, and creates the
 // backend client. Returns an empty backends list (with no error) when
 // discovery succeeds but finds no backends (static or dynamic mode).
+// exchangeOpts configure the outgoing auth strategies that exchange tokens.
 func discoverBackends(
 	ctx context.Context,
 	cfg *config.Config,
+	exchangeOpts ...strategies.ExchangeOption,
 ) ([]vmcp.Backend, vmcp.BackendClient, vmcpauth.OutgoingAuthRegistry, error) {
 	slog.Info("initializing outgoing authentication")
 	envReader := &env.OSReader{}
-	outgoingRegistry, err := authfactory.NewOutgoingAuthRegistry(ctx, envReader)
+	outgoingRegistry, err := authfactory.NewOutgoingAuthRegistry(ctx, envReader, exchangeOpts...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create outgoing authentication registry: %w", err)
 	}
 
-	backendClient, err := vmcpclient.NewHTTPBackendClient(outgoingRegistry)
+	clientOpts := getBackendClientOptions(cfg)
+	if len(cfg.BackendTLS) > 0 {
+		backendTLS, err := resolveBackendTLS(ctx, cfg.BackendTLS, secrets.NewEnvironmentProvider())
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to resolve backend TLS configuration: %w", err)
+		}
+		clientOpts = append(clientOpts, vmcpclient.WithBackendTLS(backendTLS))
+	}
+
+	backendClient, err := vmcpclient.NewHTTPBackendClient(outgoingRegistry, clientOpts...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create backend client: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,6 +19,7 @@ import (
 	"github.com/stacklok/toolhive/pkg/groups"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	aggregatormocks "github.com/stacklok/toolhive/pkg/vmcp/aggregator/mocks"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
 	clientmocks "github.com/stacklok/toolhive/pkg/vmcp/client/mocks"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 	vmcpmocks "github.com/stacklok/toolhive/pkg/vmcp/mocks"
@@ -77,7 +79,7 @@ aggregation:
 				require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
 			}
 
-			cfg, err := loadAndValidateConfig(path)
+			cfg, err := loadAndValidateConfig(path, nil)
 			if tc.wantErr {
 				require.Error(t, err)
 				require.ErrorContains(t, err, tc.errContains)
@@ -91,6 +93,64 @@ aggregation:
 	}
 }
 
+// TestLoadAndValidateConfig_Stdin covers loading the config piped through stdin,
+// which must be validated identically to a config file.
+func TestLoadAndValidateConfig_Stdin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{
+			name:    "valid config",
+			content: validConfigYAML,
+		},
+		{
+			name:        "empty stdin",
+			content:     "",
+			errContains: "configuration is empty",
+		},
+		{
+			name:        "malformed YAML",
+			content:     ":::invalid yaml:::",
+			errContains: "configuration loading failed",
+		},
+		{
+			name: "fails semantic validation — missing groupRef",
+			content: `
+name: test-vmcp
+incomingAuth:
+  type: anonymous
+outgoingAuth:
+  source: inline
+aggregation:
+  conflictResolution: prefix
+  conflictResolutionConfig:
+    prefixFormat: "{workload}_"
+`,
+			errContains: "group reference is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := loadAndValidateConfig(StdinConfigPath, strings.NewReader(tc.content))
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				require.Nil(t, cfg)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, cfg)
+			assert.Equal(t, "test-group", cfg.Group)
+		})
+	}
+}
+
 // TestLoadAuthServerConfig covers all auth-server-config side-loading paths.
 // (Additional cases live in auth_server_config_test.go, moved from cmd/vmcp/app.)
 func TestLoadAuthServerConfig_NestedDir(t *testing.T) {
@@ -147,7 +207,7 @@ backends:
     transport: sse
 `), 0o600))
 
-	cfg, err := loadAndValidateConfig(path)
+	cfg, err := loadAndValidateConfig(path, nil)
 	require.NoError(t, err)
 	require.Len(t, cfg.Backends, 1)
 
@@ -329,3 +389,18 @@ func TestRunDiscovery_ZeroBackends(t *testing.T) {
 	assert.Same(t, backendClient, gotClient)
 	assert.Same(t, registry, gotRegistry)
 }
+
+//nolint:paralleltest // Uses environment variables
+func TestBuildTokenCache_RedisRequiresEncryptionKey(t *testing.T) {
+	cfg := &config.Config{SessionStorage: &config.SessionStorageConfig{Provider: "redis", Address: "127.0.0.1:0"}}
+
+	// Without a key, tokens stay in memory rather than reaching Redis in plaintext.
+	t.Setenv(config.TokenCacheEncryptionKeyEnvVar, "")
+	tokenCache, err := buildTokenCache(t.Context(), cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &cache.MemoryTokenCache{}, tokenCache)
+
+	t.Setenv(config.TokenCacheEncryptionKeyEnvVar, "too-short")
+	_, err = buildTokenCache(t.Context(), cfg)
+	require.ErrorContains(t, err, config.TokenCacheEncryptionKeyEnvVar)
+}
@@ -6,16 +6,19 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 
-	"github.com/stacklok/toolhive-core/env"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 )
 
 // ValidateConfig holds parameters for the validate command.
 type ValidateConfig struct {
-	// ConfigPath is the path to the vMCP YAML configuration file to validate.
+	// ConfigPath is the path to the vMCP YAML configuration file to validate,
+	// or StdinConfigPath to validate the configuration read from Stdin.
 	ConfigPath string
+	// Stdin is read when ConfigPath is StdinConfigPath. Nil means os.Stdin.
+	Stdin io.Reader
 }
 
 // Validate loads and validates a vMCP configuration file, printing a summary
@@ -28,8 +31,7 @@ func Validate(_ context.Context, cfg ValidateConfig) error {
 
 	slog.Info(fmt.Sprintf("Validating configuration: %s", cfg.ConfigPath))
 
-	envReader := &env.OSReader{}
-	loader := config.NewYAMLLoader(cfg.ConfigPath, envReader)
+	loader := newConfigLoader(cfg.ConfigPath, cfg.Stdin)
 	vmcpCfg, err := loader.Load()
 	if err != nil {
 		slog.Error(fmt.Sprintf("Failed to load configuration: %v", err))
@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -99,6 +100,21 @@ aggregation:
 			wantErr:     true,
 			errContains: "group reference is required",
 		},
+		{
+			name: "valid config from stdin",
+			setup: func(_ *testing.T) ValidateConfig {
+				return ValidateConfig{ConfigPath: StdinConfigPath, Stdin: strings.NewReader(validConfigYAML)}
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed YAML from stdin",
+			setup: func(_ *testing.T) ValidateConfig {
+				return ValidateConfig{ConfigPath: StdinConfigPath, Stdin: strings.NewReader(":::not valid yaml:::")}
+			},
+			wantErr:     true,
+			errContains: "configuration loading failed",
+		},
 	}
 
 	for _, tc := range tests {
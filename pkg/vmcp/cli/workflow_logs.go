@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
+)
+
+// workflowEventsPath is the admin API endpoint of running workflows on a vMCP server.
+const workflowEventsPath = "/api/admin/workflows"
+
+// WorkflowLogsConfig holds parameters for the workflow-logs command.
+type WorkflowLogsConfig struct {
+	// URL is the base URL of the running vMCP server, e.g. http://localhost:4483.
+	URL string
+
+	// WorkflowID is the workflow to tail. When empty, the running workflows
+	// are listed instead.
+	WorkflowID string
+
+	// Token is the bearer token sent to the server. It must grant the
+	// vmcp:admin scope.
+	Token string
+
+	// JSON prints the raw step events as newline-delimited JSON.
+	JSON bool
+
+	// Out receives the output. Defaults to os.Stdout.
+	Out io.Writer
+
+	// HTTPClient performs the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// WorkflowLogs tails the step events of a composite-tool workflow running on
+// a vMCP server until the workflow finishes, or lists the running workflows
+// when no workflow ID is given.
+func WorkflowLogs(ctx context.Context, cfg WorkflowLogsConfig) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("no server URL specified, use --url flag")
+	}
+	out := cfg.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	endpoint := strings.TrimSuffix(cfg.URL, "/") + workflowEventsPath
+	if cfg.WorkflowID != "" {
+		endpoint += "/" + url.PathEscape(cfg.WorkflowID) + "/events"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach vMCP server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vMCP server returned %s: %s", resp.Status, readErrorMessage(resp.Body))
+	}
+
+	if cfg.WorkflowID == "" {
+		return writeRunningWorkflows(out, resp.Body)
+	}
+	return writeStepEvents(out, resp.Body, cfg.JSON)
+}
+
+// writeRunningWorkflows prints the workflow IDs of a running workflow listing.
+func writeRunningWorkflows(w io.Writer, body io.Reader) error {
+	var listing struct {
+		Workflows []string `json:"workflows"`
+	}
+	if err := json.NewDecoder(body).Decode(&listing); err != nil {
+		return fmt.Errorf("failed to decode running workflows: %w", err)
+	}
+	if len(listing.Workflows) == 0 {
+		_, err := fmt.Fprintln(w, "No running workflows")
+		return err
+	}
+	for _, id := range listing.Workflows {
+		if _, err := fmt.Fprintln(w, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeStepEvents prints the newline-delimited step events read from body as
+// they arrive, until body ends.
+func writeStepEvents(w io.Writer, body io.Reader, asJSON bool) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if asJSON {
+			if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+				return err
+			}
+			continue
+		}
+		var event composer.StepEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("failed to decode step event: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, formatStepEvent(event)); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read step events: %w", err)
+	}
+	return nil
+}
+
+// formatStepEvent renders a step event as a single log line.
+func formatStepEvent(event composer.StepEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %-20s %-6s", event.Time.Local().Format(time.TimeOnly), event.StepID, event.Type)
+	switch event.Type {
+	case composer.StepEventStart:
+		fmt.Fprintf(&b, " type=%s", event.StepType)
+		if event.Tool != "" {
+			fmt.Fprintf(&b, " tool=%s", event.Tool)
+		}
+	case composer.StepEventError:
+		fmt.Fprintf(&b, " %s", event.Error)
+	}
+	if len(event.Data) > 0 {
+		if data, err := json.Marshal(event.Data); err == nil {
+			fmt.Fprintf(&b, " %s", data)
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// readErrorMessage returns the message of an admin API error response, or
+// the raw body when it is not one.
+func readErrorMessage(body io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(body, 4096))
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+		return apiErr.Error
+	}
+	return strings.TrimSpace(string(data))
+}
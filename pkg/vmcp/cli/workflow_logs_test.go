@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
+)
+
+func newWorkflowEventsServer(t *testing.T, events []composer.StepEvent) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer admin-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"scope vmcp:admin required"}`))
+			return
+		}
+		switch r.URL.Path {
+		case workflowEventsPath:
+			_ = json.NewEncoder(w).Encode(map[string][]string{"workflows": {"wf-1"}})
+		case workflowEventsPath + "/wf-1/events":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			enc := json.NewEncoder(w)
+			for _, event := range events {
+				_ = enc.Encode(event)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"workflow wf-2 is not running"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWorkflowLogs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	events := []composer.StepEvent{
+		{WorkflowID: "wf-1", StepID: "fetch", Type: composer.StepEventStart, StepType: composer.StepTypeTool, Tool: "github.fetch", Time: now},
+		{WorkflowID: "wf-1", StepID: "fetch", Type: composer.StepEventInput, StepType: composer.StepTypeTool, Data: map[string]any{"token": "[REDACTED]"}, Time: now},
+		{WorkflowID: "wf-1", StepID: "fetch", Type: composer.StepEventOutput, StepType: composer.StepTypeTool, Data: map[string]any{"data": "x"}, Time: now},
+		{WorkflowID: "wf-1", StepID: "store", Type: composer.StepEventError, StepType: composer.StepTypeTool, Error: "tool call failed", Time: now},
+	}
+	srv := newWorkflowEventsServer(t, events)
+
+	tests := []struct {
+		name      string
+		cfg       WorkflowLogsConfig
+		wantLines []string
+		wantErr   string
+	}{
+		{
+			name: "tail workflow events",
+			cfg:  WorkflowLogsConfig{WorkflowID: "wf-1", Token: "admin-token"},
+			wantLines: []string{
+				"fetch                start  type=tool tool=github.fetch",
+				`fetch                input  {"token":"[REDACTED]"}`,
+				`fetch                output {"data":"x"}`,
+				"store                error  tool call failed",
+			},
+		},
+		{
+			name:      "list running workflows",
+			cfg:       WorkflowLogsConfig{Token: "admin-token"},
+			wantLines: []string{"wf-1"},
+		},
+		{
+			name:    "workflow not running",
+			cfg:     WorkflowLogsConfig{WorkflowID: "wf-2", Token: "admin-token"},
+			wantErr: "workflow wf-2 is not running",
+		},
+		{
+			name:    "missing admin scope",
+			cfg:     WorkflowLogsConfig{WorkflowID: "wf-1"},
+			wantErr: "scope vmcp:admin required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			cfg := tc.cfg
+			cfg.URL = srv.URL
+			cfg.Out = &out
+			err := WorkflowLogs(context.Background(), cfg)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			require.Len(t, lines, len(tc.wantLines))
+			for i, want := range tc.wantLines {
+				assert.True(t, strings.HasSuffix(lines[i], want), "line %d: %q", i, lines[i])
+			}
+		})
+	}
+}
+
+func TestWorkflowLogs_JSON(t *testing.T) {
+	t.Parallel()
+
+	events := []composer.StepEvent{
+		{WorkflowID: "wf-1", StepID: "fetch", Type: composer.StepEventStart, Time: time.Now()},
+		{WorkflowID: "wf-1", StepID: "fetch", Type: composer.StepEventOutput, Time: time.Now()},
+	}
+	srv := newWorkflowEventsServer(t, events)
+
+	var out bytes.Buffer
+	require.NoError(t, WorkflowLogs(context.Background(), WorkflowLogsConfig{
+		URL: srv.URL, WorkflowID: "wf-1", Token: "admin-token", JSON: true, Out: &out,
+	}))
+
+	var got []composer.StepEvent
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var event composer.StepEvent
+		require.NoError(t, dec.Decode(&event))
+		got = append(got, event)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, composer.StepEventStart, got[0].Type)
+	assert.Equal(t, composer.StepEventOutput, got[1].Type)
+}
@@ -18,6 +18,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -29,12 +30,14 @@ import (
 	"github.com/stacklok/toolhive-core/mcpcompat/client/transport"
 	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
 	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/correlation"
 	"github.com/stacklok/toolhive/pkg/secrets"
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	"github.com/stacklok/toolhive/pkg/versions"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	vmcpauth "github.com/stacklok/toolhive/pkg/vmcp/auth"
 	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
 	"github.com/stacklok/toolhive/pkg/vmcp/conversion"
 	"github.com/stacklok/toolhive/pkg/vmcp/headerforward"
 	healthcontext "github.com/stacklok/toolhive/pkg/vmcp/health/context"
@@ -104,7 +107,8 @@ func WithDialControl(control func(network, address string, c syscall.RawConn) er
 }
 
 // httpBackendClient implements vmcp.BackendClient using stacklok/toolhive-core/mcpcompat HTTP client.
-// It supports streamable-HTTP and SSE transports for backend MCP servers.
+// It supports streamable-HTTP and SSE transports for backend MCP servers, and
+// stdio backends, which it runs as local processes (see stdioProcess).
 type httpBackendClient struct {
 	// clientFactory creates MCP clients for backends. The forwarding flag is set
 	// only for the tools/call path — the sole operation during which a backend
@@ -139,10 +143,34 @@ type httpBackendClient struct {
 	// delivered. Nil (unbound) reproduces the pre-forwarding behavior exactly, so
 	// direct embedders and unit tests without a bound server are unaffected.
 	forwarders atomic.Pointer[boundForwarders]
+
+	// backendTLS holds the TLS settings of backends, keyed by workload ID,
+	// injected via WithBackendTLS.
+	backendTLS map[string]BackendTLSConfig
+
+	// pool holds the idle-connection limits of the per-backend transports.
+	pool ConnectionPoolConfig
+
+	// retry is the policy for retrying idempotent operations on transient
+	// transport errors. The zero value disables retries.
+	retry RetryPolicy
+
+	// transports holds the shared transport of every backend, keyed by workload
+	// ID, so calls to a backend reuse its connections. See backendTransport.
+	transports   map[string]*pooledTransport
+	transportsMu sync.Mutex
+
+	// stdio holds the process of every stdio backend, keyed by workload ID.
+	// See stdioBackend.
+	stdio   map[string]*stdioProcess
+	stdioMu sync.Mutex
 }
 
 // NewHTTPBackendClient creates a new HTTP-based backend client.
-// This client supports streamable-HTTP and SSE transports.
+// This client supports streamable-HTTP and SSE transports, and stdio backends
+// (targets with a Stdio command). The processes of stdio backends are started
+// on first use and run until the client is closed; the returned client
+// implements io.Closer for that purpose.
 //
 // The registry parameter manages authentication strategies for outgoing requests to backend MCP servers.
 // It must not be nil. To disable authentication, use a registry configured with the
@@ -150,7 +178,9 @@ type httpBackendClient struct {
 //
 // Options are additive: nil or absent options reproduce the default behavior exactly.
 // See [WithDialControl] to install a per-connection dial hook for SSRF /
-// DNS-rebinding defense.
+// DNS-rebinding defense, [WithConnectionPool] to tune the per-backend connection
+// pools, [WithRetryPolicy] to retry idempotent operations on transient errors, and
+// [WithBackendTLS] to set per-backend CA bundles and client certificates.
 //
 // Returns an error if registry is nil.
 func NewHTTPBackendClient(registry vmcpauth.OutgoingAuthRegistry, opts ...Option) (vmcp.BackendClient, error) {
@@ -161,6 +191,7 @@ func NewHTTPBackendClient(registry vmcpauth.OutgoingAuthRegistry, opts ...Option
 	c := &httpBackendClient{
 		registry:        registry,
 		secretsProvider: secrets.NewEnvironmentProvider(),
+		pool:            DefaultConnectionPoolConfig(),
 	}
 	for _, o := range opts {
 		o(c)
@@ -384,8 +415,9 @@ type authRoundTripper struct {
 // The authentication strategy was pre-resolved and validated at client creation time,
 // so this method simply applies the authentication without any lookups or validation.
 func (a *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	// Clone request to avoid modifying the original
-	reqClone := req.Clone(req.Context())
+	// Clone request to avoid modifying the original, and name the backend so
+	// strategies can cache the tokens they exchange for it.
+	reqClone := req.Clone(cache.WithBackendID(req.Context(), a.target.WorkloadID))
 
 	// Apply pre-resolved authentication strategy
 	if err := a.authStrategy.Authenticate(reqClone.Context(), reqClone, a.authConfig); err != nil {
@@ -500,12 +532,68 @@ func (*httpBackendClient) newSSEClient(
 func (h *httpBackendClient) defaultClientFactory(
 	ctx context.Context, target *vmcp.BackendTarget, forwarding bool,
 ) (*client.Client, error) {
+	baseTransport, err := h.backendRoundTripper(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshot the bound server->client forwarders (nil when unbound). When set,
+	// the client is built with elicitation/sampling handlers and continuous
+	// listening so a backend's mid-call server->client traffic reaches the
+	// downstream client; when nil, construction is byte-for-byte the pre-forwarding
+	// path.
+	fwd := h.forwarders.Load()
+
+	var c *client.Client
+
+	switch target.TransportType {
+	case "streamable-http", "streamable":
+		// "streamable" is a legacy alias for "streamable-http".
+		c, err = h.newStreamableHTTPClient(ctx, target, baseTransport, forwarding, fwd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create streamable-http client: %w", err)
+		}
+
+	case "sse":
+		c, err = h.newSSEClient(ctx, target, baseTransport, forwarding, fwd)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %s (supported: streamable-http, sse)", vmcp.ErrUnsupportedTransport, target.TransportType)
+	}
+
+	// Register the notification forwarder before Initialize (the caller runs it
+	// after this factory returns) so a backend's mid-call progress/logging
+	// notifications are relayed to the downstream client. OnNotification is a
+	// post-construction method, so it applies to both transports.
+	if fwd != nil && forwarding && fwd.notifier != nil {
+		c.OnNotification(newNotificationForwarder(ctx, fwd.notifier))
+	}
+
+	// Start the client connection
+	if err := c.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start client connection: %w", err)
+	}
+
+	// Note: Initialization is deferred to the caller (e.g., ListCapabilities)
+	// so that ServerCapabilities can be captured and used for conditional querying
+	return c, nil
+}
+
+// backendRoundTripper builds the round tripper carrying every request to
+// target's backend. The MCP clients add a response size limit around it.
+func (h *httpBackendClient) backendRoundTripper(
+	ctx context.Context, target *vmcp.BackendTarget,
+) (http.RoundTripper, error) {
 	// Build transport chain (outermost to innermost, request execution order):
-	// size limit (response body) → trace propagation → identity propagation → authentication → HTTP
+	// correlation → trace propagation → identity propagation → header forwarding → authentication → HTTP
 	//
-	// Build an isolated per-call transport so each client gets its own connection pool,
-	// preventing stale keep-alive connections from one backend affecting others.
-	httpTransport, err := newBackendTransport(target.CABundlePath, target.CABundleData, h.dialControl)
+	// Reuse the backend's shared transport so consecutive calls reuse its pooled
+	// connections. Each backend has its own transport, preventing stale keep-alive
+	// connections from one backend affecting others.
+	httpTransport, err := h.backendTransport(target)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transport for backend %s: %w", target.WorkloadID, err)
 	}
@@ -595,49 +683,22 @@ func (h *httpBackendClient) defaultClientFactory(
 		propagator: otel.GetTextMapPropagator(),
 	}
 
-	// Snapshot the bound server->client forwarders (nil when unbound). When set,
-	// the client is built with elicitation/sampling handlers and continuous
-	// listening so a backend's mid-call server->client traffic reaches the
-	// downstream client; when nil, construction is byte-for-byte the pre-forwarding
-	// path.
-	fwd := h.forwarders.Load()
-
-	var c *client.Client
-
-	switch target.TransportType {
-	case "streamable-http", "streamable":
-		// "streamable" is a legacy alias for "streamable-http".
-		c, err = h.newStreamableHTTPClient(ctx, target, baseTransport, forwarding, fwd)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create streamable-http client: %w", err)
-		}
-
-	case "sse":
-		c, err = h.newSSEClient(ctx, target, baseTransport, forwarding, fwd)
-		if err != nil {
-			return nil, err
-		}
-
-	default:
-		return nil, fmt.Errorf("%w: %s (supported: streamable-http, sse)", vmcp.ErrUnsupportedTransport, target.TransportType)
-	}
-
-	// Register the notification forwarder before Initialize (the caller runs it
-	// after this factory returns) so a backend's mid-call progress/logging
-	// notifications are relayed to the downstream client. OnNotification is a
-	// post-construction method, so it applies to both transports.
-	if fwd != nil && forwarding && fwd.notifier != nil {
-		c.OnNotification(newNotificationForwarder(ctx, fwd.notifier))
-	}
+	// Send the request's correlation ID to the backend so a single client call
+	// can be followed from the vMCP audit log into the backend's logs.
+	return correlation.NewRoundTripper(baseTransport), nil
+}
 
-	// Start the client connection
-	if err := c.Start(ctx); err != nil {
-		return nil, fmt.Errorf("failed to start client connection: %w", err)
+// HTTPClient returns an HTTP client sending requests to target the way MCP
+// traffic is sent: over the backend's shared transport, with its TLS settings
+// and dial control, outgoing authentication, forwarded headers and identity,
+// trace and correlation propagation. The health monitor's HTTP GET probe uses
+// it so that the probe reaches the backend like MCP calls do.
+func (h *httpBackendClient) HTTPClient(ctx context.Context, target *vmcp.BackendTarget) (*http.Client, error) {
+	transport, err := h.backendRoundTripper(ctx, target)
+	if err != nil {
+		return nil, err
 	}
-
-	// Note: Initialization is deferred to the caller (e.g., ListCapabilities)
-	// so that ServerCapabilities can be captured and used for conditional querying
-	return c, nil
+	return &http.Client{Transport: transport}, nil
 }
 
 // isAuthorizationRequired reports whether err is one of the mcp-go authorization-required
@@ -760,6 +821,58 @@ func wrapBackendError(err error, backendID string, operation string) error {
 		vmcp.ErrBackendUnavailable, operation, backendID, err)
 }
 
+// backendSession is the MCP client API the backend operations use. It is
+// implemented by the per-call mcpcompat client of network backends and by
+// the shared session of a stdio backend.
+type backendSession interface {
+	ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error)
+	ListResources(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error)
+	ListResourceTemplates(
+		ctx context.Context, request mcp.ListResourceTemplatesRequest,
+	) (*mcp.ListResourceTemplatesResult, error)
+	ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error)
+	CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ReadResource(ctx context.Context, request mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error)
+	GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+	Complete(ctx context.Context, request mcp.CompleteRequest) (*mcp.CompleteResult, error)
+}
+
+// openSession returns an initialized session to target's backend, the
+// capabilities the backend advertised, and a function that releases the
+// session when the operation is done. A network backend gets a new client,
+// closed on release; a stdio backend shares the session of its process,
+// which release leaves running.
+func (h *httpBackendClient) openSession(
+	ctx context.Context, target *vmcp.BackendTarget, forwarding bool,
+) (backendSession, *mcp.ServerCapabilities, func(), error) {
+	if target.Stdio != nil {
+		session, serverCaps, err := h.stdioBackend(target).connect(ctx)
+		if err != nil {
+			return nil, nil, nil, wrapBackendError(err, target.WorkloadID, "start process")
+		}
+		return session, serverCaps, func() {}, nil
+	}
+
+	// Create a client for this backend (not yet initialized)
+	c, err := h.clientFactory(ctx, target, forwarding)
+	if err != nil {
+		return nil, nil, nil, wrapBackendError(err, target.WorkloadID, "create client")
+	}
+	release := func() {
+		if err := c.Close(); err != nil {
+			slog.Debug("failed to close client", "error", err)
+		}
+	}
+
+	// Initialize the client and get server capabilities
+	serverCaps, err := initializeClient(ctx, c)
+	if err != nil {
+		release()
+		return nil, nil, nil, wrapBackendError(err, target.WorkloadID, "initialize client")
+	}
+	return c, serverCaps, release, nil
+}
+
 // initializeClient performs MCP protocol initialization handshake and returns server capabilities.
 // This allows the caller to determine which optional features the server supports.
 func initializeClient(ctx context.Context, c *client.Client) (*mcp.ServerCapabilities, error) {
@@ -790,7 +903,7 @@ func initializeClient(ctx context.Context, c *client.Client) (*mcp.ServerCapabil
 // It follows MCP pagination cursors so backends that paginate (mcpcompat
 // paginates at DefaultPageSize=1000) contribute their complete tool set rather
 // than only the first page.
-func queryTools(ctx context.Context, c *client.Client, supported bool, backendID string) (*mcp.ListToolsResult, error) {
+func queryTools(ctx context.Context, c backendSession, supported bool, backendID string) (*mcp.ListToolsResult, error) {
 	if supported {
 		tools, err := pagination.ListAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
 			req := mcp.ListToolsRequest{}
@@ -812,7 +925,7 @@ func queryTools(ctx context.Context, c *client.Client, supported bool, backendID
 
 // queryResources queries resources from a backend if the server advertises
 // resource support. It follows MCP pagination cursors (see queryTools).
-func queryResources(ctx context.Context, c *client.Client, supported bool, backendID string) (*mcp.ListResourcesResult, error) {
+func queryResources(ctx context.Context, c backendSession, supported bool, backendID string) (*mcp.ListResourcesResult, error) {
 	if supported {
 		resources, err := pagination.ListAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Resource, mcp.Cursor, error) {
 			req := mcp.ListResourcesRequest{}
@@ -837,7 +950,7 @@ func queryResources(ctx context.Context, c *client.Client, supported bool, backe
 // serverCaps.Resources advertisement as plain resources (there is no separate
 // capability flag for templates). It follows MCP pagination cursors (see queryTools).
 func queryResourceTemplates(
-	ctx context.Context, c *client.Client, supported bool, backendID string,
+	ctx context.Context, c backendSession, supported bool, backendID string,
 ) (*mcp.ListResourceTemplatesResult, error) {
 	if supported {
 		templates, err := pagination.ListAll(
@@ -870,7 +983,7 @@ func queryResourceTemplates(
 
 // queryPrompts queries prompts from a backend if the server advertises prompt
 // support. It follows MCP pagination cursors (see queryTools).
-func queryPrompts(ctx context.Context, c *client.Client, supported bool, backendID string) (*mcp.ListPromptsResult, error) {
+func queryPrompts(ctx context.Context, c backendSession, supported bool, backendID string) (*mcp.ListPromptsResult, error) {
 	if supported {
 		prompts, err := pagination.ListAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Prompt, mcp.Cursor, error) {
 			req := mcp.ListPromptsRequest{}
@@ -893,25 +1006,22 @@ func queryPrompts(ctx context.Context, c *client.Client, supported bool, backend
 // ListCapabilities queries a backend for its MCP capabilities.
 // Returns tools, resources, and prompts exposed by the backend.
 // Only queries capabilities that the server advertises during initialization.
+// Transient transport errors are retried according to the client's RetryPolicy.
 func (h *httpBackendClient) ListCapabilities(ctx context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
-	slog.Debug("querying capabilities from backend", "backend", target.WorkloadName, "url", target.BaseURL)
+	return withRetry(ctx, h, target, "list capabilities", true, func() (*vmcp.CapabilityList, error) {
+		return h.listCapabilities(ctx, target)
+	})
+}
 
-	// Create a client for this backend (not yet initialized)
-	c, err := h.clientFactory(ctx, target, false)
-	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "create client")
-	}
-	defer func() {
-		if err := c.Close(); err != nil {
-			slog.Debug("failed to close client", "error", err)
-		}
-	}()
+// listCapabilities performs a single ListCapabilities attempt.
+func (h *httpBackendClient) listCapabilities(ctx context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+	slog.Debug("querying capabilities from backend", "backend", target.WorkloadName, "url", target.BaseURL)
 
-	// Initialize the client and get server capabilities
-	serverCaps, err := initializeClient(ctx, c)
+	c, serverCaps, release, err := h.openSession(ctx, target, false)
 	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "initialize client")
+		return nil, err
 	}
+	defer release()
 
 	slog.Debug("backend capabilities",
 		"backend", target.WorkloadID,
@@ -1018,39 +1128,53 @@ func (h *httpBackendClient) ListCapabilities(ctx context.Context, target *vmcp.B
 
 // CallTool invokes a tool on the backend MCP server.
 // Returns the complete tool result including _meta field.
+// The target's parameter defaults are merged into arguments the caller left out.
+// The call is retried on transient transport errors only when the target tool
+// is annotated idempotent, since a failed call may already have taken effect.
+func (h *httpBackendClient) CallTool(
+	ctx context.Context,
+	target *vmcp.BackendTarget,
+	toolName string,
+	arguments map[string]any,
+	meta map[string]any,
+) (*vmcp.ToolCallResult, error) {
+	arguments = target.WithParameterDefaults(arguments)
+	return withRetry(ctx, h, target, "call tool", target.Idempotent, func() (*vmcp.ToolCallResult, error) {
+		return h.callTool(ctx, target, toolName, arguments, meta)
+	})
+}
+
+// callTool performs a single CallTool attempt.
 //
 //nolint:gocyclo // this function is complex because it handles tool calls with various content types and error handling.
-func (h *httpBackendClient) CallTool(
+func (h *httpBackendClient) callTool(
 	ctx context.Context,
 	target *vmcp.BackendTarget,
 	toolName string,
 	arguments map[string]any,
 	meta map[string]any,
 ) (*vmcp.ToolCallResult, error) {
-	slog.Debug("calling tool on backend", "tool", toolName, "backend", target.WorkloadName)
+	slog.Debug("calling tool on backend", "tool", toolName, "backend", target.WorkloadName,
+		correlation.LogKey, correlation.FromContext(ctx))
 
-	// Create a client for this backend
-	c, err := h.clientFactory(ctx, target, true)
+	// Open a session to this backend and capture its advertised capabilities.
+	c, serverCaps, release, err := h.openSession(ctx, target, true)
 	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "create client")
+		return nil, err
 	}
-	defer func() {
-		if err := c.Close(); err != nil {
-			slog.Debug("failed to close client", "error", err)
-		}
-	}()
+	defer release()
 
-	// Initialize the client and capture the backend's advertised capabilities.
-	serverCaps, err := initializeClient(ctx, c)
-	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "initialize client")
-	}
+	// Server->client forwarding is bound to per-call clients; the shared
+	// session of a stdio backend outlives the call, so it is not forwarded.
+	perCall, _ := c.(*client.Client)
 
 	// When forwarders are bound and the backend advertises logging, request debug
 	// level so the backend emits notifications/message during the call; the
 	// notification forwarder relays them to the downstream client. Best-effort:
 	// a failure here must not fail the tool call.
-	h.enableBackendLogging(ctx, c, serverCaps, target.WorkloadID)
+	if perCall != nil {
+		h.enableBackendLogging(ctx, perCall, serverCaps, target.WorkloadID)
+	}
 
 	// Call the tool using the original capability name from the backend's perspective.
 	// When conflict resolution renames tools (e.g., "fetch" → "fetch_fetch"),
@@ -1076,7 +1200,9 @@ func (h *httpBackendClient) CallTool(
 	// down this per-call client, so a fire-and-forget notification the backend
 	// emitted mid-call (progress/logging) is relayed downstream instead of being
 	// dropped. See drainServerToClientNotifications for the lost-notification race.
-	h.drainServerToClientNotifications(ctx, c)
+	if perCall != nil {
+		h.drainServerToClientNotifications(ctx, perCall)
+	}
 
 	// Extract _meta field from backend response
 	responseMeta := conversion.FromMCPMeta(result.Meta)
@@ -1140,26 +1266,27 @@ func (h *httpBackendClient) CallTool(
 
 // ReadResource retrieves a resource from the backend MCP server.
 // Returns the complete resource result including _meta field.
+// Transient transport errors are retried according to the client's RetryPolicy.
 func (h *httpBackendClient) ReadResource(
 	ctx context.Context, target *vmcp.BackendTarget, uri string,
 ) (*vmcp.ResourceReadResult, error) {
-	slog.Debug("reading resource from backend", "resource", uri, "backend", target.WorkloadName)
+	return withRetry(ctx, h, target, "read resource", true, func() (*vmcp.ResourceReadResult, error) {
+		return h.readResource(ctx, target, uri)
+	})
+}
 
-	// Create a client for this backend
-	c, err := h.clientFactory(ctx, target, false)
-	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "create client")
-	}
-	defer func() {
-		if err := c.Close(); err != nil {
-			slog.Debug("failed to close client", "error", err)
-		}
-	}()
+// readResource performs a single ReadResource attempt.
+func (h *httpBackendClient) readResource(
+	ctx context.Context, target *vmcp.BackendTarget, uri string,
+) (*vmcp.ResourceReadResult, error) {
+	slog.Debug("reading resource from backend", "resource", uri, "backend", target.WorkloadName,
+		correlation.LogKey, correlation.FromContext(ctx))
 
-	// Initialize the client
-	if _, err := initializeClient(ctx, c); err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "initialize client")
+	c, _, release, err := h.openSession(ctx, target, false)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
 	// Read the resource using the original URI from the backend's perspective.
 	// When conflict resolution renames resources, we must use the original backend URI.
@@ -1193,29 +1320,33 @@ func (h *httpBackendClient) ReadResource(
 
 // GetPrompt retrieves a prompt from the backend MCP server.
 // Returns the complete prompt result including _meta field.
+// Transient transport errors are retried according to the client's RetryPolicy.
 func (h *httpBackendClient) GetPrompt(
 	ctx context.Context,
 	target *vmcp.BackendTarget,
 	name string,
 	arguments map[string]any,
 ) (*vmcp.PromptGetResult, error) {
-	slog.Debug("getting prompt from backend", "prompt", name, "backend", target.WorkloadName)
+	return withRetry(ctx, h, target, "get prompt", true, func() (*vmcp.PromptGetResult, error) {
+		return h.getPrompt(ctx, target, name, arguments)
+	})
+}
 
-	// Create a client for this backend
-	c, err := h.clientFactory(ctx, target, false)
-	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "create client")
-	}
-	defer func() {
-		if err := c.Close(); err != nil {
-			slog.Debug("failed to close client", "error", err)
-		}
-	}()
+// getPrompt performs a single GetPrompt attempt.
+func (h *httpBackendClient) getPrompt(
+	ctx context.Context,
+	target *vmcp.BackendTarget,
+	name string,
+	arguments map[string]any,
+) (*vmcp.PromptGetResult, error) {
+	slog.Debug("getting prompt from backend", "prompt", name, "backend", target.WorkloadName,
+		correlation.LogKey, correlation.FromContext(ctx))
 
-	// Initialize the client
-	if _, err := initializeClient(ctx, c); err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "initialize client")
+	c, _, release, err := h.openSession(ctx, target, false)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
 	// Get the prompt using the original prompt name from the backend's perspective.
 	// When conflict resolution renames prompts, we must use the original backend name.
@@ -1259,22 +1390,12 @@ func (h *httpBackendClient) Complete(
 	slog.Debug("requesting completion from backend",
 		"ref_type", ref.Type, "backend", target.WorkloadName)
 
-	// Create a client for this backend
-	c, err := h.clientFactory(ctx, target, false)
+	// Open a session to this backend and capture its advertised capabilities.
+	c, serverCaps, release, err := h.openSession(ctx, target, false)
 	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "create client")
-	}
-	defer func() {
-		if err := c.Close(); err != nil {
-			slog.Debug("failed to close client", "error", err)
-		}
-	}()
-
-	// Initialize the client and capture the backend's advertised capabilities.
-	serverCaps, err := initializeClient(ctx, c)
-	if err != nil {
-		return nil, wrapBackendError(err, target.WorkloadID, "initialize client")
+		return nil, err
 	}
+	defer release()
 
 	// Backends that do not advertise completions cannot serve completion/complete;
 	// return an empty result rather than erroring (lenient completion semantics).
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/audit"
+	"github.com/stacklok/toolhive/pkg/correlation"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth/strategies"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+)
+
+// TestCorrelationID_AuditAndBackendHeader drives a single client call through
+// the correlation middleware, the HTTP backend client, and the vMCP auditor,
+// and verifies the audit event and the backend request carry the same ID.
+func TestCorrelationID_AuditAndBackendHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		clientID string
+	}{
+		{name: "client supplied ID is reused", clientID: "client-trace-42"},
+		{name: "generated ID when client sends none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			captured := newCapturingMCPServer(t)
+			t.Cleanup(captured.server.Close)
+
+			registry := auth.NewDefaultOutgoingAuthRegistry()
+			require.NoError(t, registry.RegisterStrategy("unauthenticated", &strategies.UnauthenticatedStrategy{}))
+			backendClient, err := vmcpclient.NewHTTPBackendClient(registry)
+			require.NoError(t, err)
+
+			logFile := filepath.Join(t.TempDir(), "audit.log")
+			auditor, err := audit.NewVMCPAuditor(&audit.Config{
+				EventTypes: []string{audit.EventTypeVMCPToolCall},
+				LogFile:    logFile,
+			})
+			require.NoError(t, err)
+
+			target := &vmcp.BackendTarget{
+				WorkloadID:    "backend-correlation",
+				WorkloadName:  "Backend Correlation",
+				BaseURL:       captured.server.URL,
+				TransportType: "streamable-http",
+			}
+
+			handler := correlation.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				start := time.Now()
+				_, callErr := backendClient.CallTool(r.Context(), target, "anything", map[string]any{}, nil)
+				outcome := audit.OutcomeSuccess
+				if callErr != nil {
+					outcome = audit.OutcomeError
+				}
+				auditor.LogToolCall(r.Context(), audit.VMCPCall{
+					BackendID: target.WorkloadID,
+					Name:      "anything",
+					Duration:  time.Since(start),
+					Outcome:   outcome,
+				})
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.clientID != "" {
+				req.Header.Set(correlation.HeaderName, tt.clientID)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(correlation.HeaderName)
+			require.NotEmpty(t, id)
+			if tt.clientID != "" {
+				assert.Equal(t, tt.clientID, id)
+			}
+
+			captured.mu.Lock()
+			backendID := captured.headers.Get(correlation.HeaderName)
+			captured.mu.Unlock()
+			assert.Equal(t, id, backendID, "backend request must carry the correlation ID")
+
+			data, err := os.ReadFile(logFile)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			require.Len(t, lines, 1)
+
+			var event struct {
+				Metadata struct {
+					Extra map[string]any `json:"extra"`
+				} `json:"metadata"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+			assert.Equal(t, id, event.Metadata.Extra[audit.MetadataExtraKeyCorrelationID],
+				"audit event must carry the correlation ID")
+		})
+	}
+}
@@ -543,6 +543,20 @@ func startTestMCPServer(t *testing.T) (string, *metaCapture, func()) {
 			}, nil
 		},
 	)
+	mcpServer.AddTool(
+		mcp.NewTool("test_tool_echo_args",
+			mcp.WithDescription("Tool that echoes the arguments it receives"),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args, err := json.Marshal(request.GetArguments())
+			if err != nil {
+				return nil, err
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.NewTextContent(string(args))},
+			}, nil
+		},
+	)
 	mcpServer.AddPrompt(
 		mcp.NewPrompt("test_prompt_capture_meta",
 			mcp.WithPromptDescription("Capture-only prompt that records inbound _meta"),
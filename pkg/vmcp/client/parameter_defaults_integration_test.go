@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth/strategies"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+)
+
+// TestCallTool_ParameterDefaults verifies that a target's parameter defaults
+// reach the backend when the caller leaves them out, and that caller-provided
+// values win.
+func TestCallTool_ParameterDefaults(t *testing.T) {
+	t.Parallel()
+
+	port, _, cleanup := startTestMCPServer(t)
+	t.Cleanup(cleanup)
+
+	registry := auth.NewDefaultOutgoingAuthRegistry()
+	require.NoError(t, registry.RegisterStrategy("unauthenticated", &strategies.UnauthenticatedStrategy{}))
+	backendClient, err := vmcpclient.NewHTTPBackendClient(registry)
+	require.NoError(t, err)
+
+	target := &vmcp.BackendTarget{
+		WorkloadID:        "test-backend",
+		WorkloadName:      "Test Backend",
+		BaseURL:           "http://127.0.0.1:" + port,
+		TransportType:     "streamable-http",
+		ParameterDefaults: map[string]any{"org": "acme", "limit": 10},
+	}
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		expected string
+	}{
+		{
+			name:     "defaults applied when absent",
+			args:     map[string]any{"query": "bug"},
+			expected: `{"limit":10,"org":"acme","query":"bug"}`,
+		},
+		{
+			name:     "client value overrides default",
+			args:     map[string]any{"query": "bug", "org": "other"},
+			expected: `{"limit":10,"org":"other","query":"bug"}`,
+		},
+		{
+			name:     "defaults applied without arguments",
+			args:     nil,
+			expected: `{"limit":10,"org":"acme"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := backendClient.CallTool(ctx, target, "test_tool_echo_args", tt.args, nil)
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			assert.JSONEq(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// ConnectionPoolConfig tunes the idle connections kept to each backend.
+// Zero fields use the values of DefaultConnectionPoolConfig.
+type ConnectionPoolConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept per backend
+	// across all of its hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept to a
+	// single backend host.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept before it is closed.
+	IdleConnTimeout time.Duration
+}
+
+// DefaultConnectionPoolConfig returns the connection pool settings used when
+// none are configured.
+func DefaultConnectionPoolConfig() ConnectionPoolConfig {
+	return ConnectionPoolConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// WithConnectionPool sets the idle-connection limits of the per-backend
+// connection pools. Zero fields keep their defaults.
+func WithConnectionPool(cfg ConnectionPoolConfig) Option {
+	return func(h *httpBackendClient) {
+		defaults := DefaultConnectionPoolConfig()
+		h.pool = ConnectionPoolConfig{
+			MaxIdleConns:        cmp.Or(cfg.MaxIdleConns, defaults.MaxIdleConns),
+			MaxIdleConnsPerHost: cmp.Or(cfg.MaxIdleConnsPerHost, defaults.MaxIdleConnsPerHost),
+			IdleConnTimeout:     cmp.Or(cfg.IdleConnTimeout, defaults.IdleConnTimeout),
+		}
+	}
+}
+
+// pooledTransport is the shared transport of one backend, with the TLS inputs
+// it was built from so a changed CA bundle replaces it.
+type pooledTransport struct {
+	caBundlePath string
+	caBundleData []byte
+	transport    *http.Transport
+}
+
+// backendTransport returns the shared transport for target's backend, building
+// it on first use. Every call to the same backend reuses the transport, and so
+// its idle connections, instead of dialing (and TLS handshaking) anew for each
+// MCP operation. Backends never share a transport, so a stale keep-alive
+// connection of one backend cannot affect another.
+//
+// The transport carries the backend's configured TLS settings (WithBackendTLS).
+// When the backend's CA bundle changes (dynamic discovery refreshes it), the
+// transport is rebuilt and the idle connections of the previous one closed.
+func (h *httpBackendClient) backendTransport(target *vmcp.BackendTarget) (*http.Transport, error) {
+	h.transportsMu.Lock()
+	defer h.transportsMu.Unlock()
+
+	if pooled, ok := h.transports[target.WorkloadID]; ok {
+		if pooled.caBundlePath == target.CABundlePath && bytes.Equal(pooled.caBundleData, target.CABundleData) {
+			return pooled.transport, nil
+		}
+		pooled.transport.CloseIdleConnections()
+	}
+
+	t, err := newBackendTransport(target.CABundlePath, target.CABundleData, h.dialControl)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig, ok := h.backendTLS[target.WorkloadID]; ok {
+		if err := applyBackendTLS(t, tlsConfig); err != nil {
+			return nil, fmt.Errorf("backend %s: %w", target.WorkloadID, err)
+		}
+	}
+	if h.pool.MaxIdleConns > 0 {
+		t.MaxIdleConns = h.pool.MaxIdleConns
+	}
+	if h.pool.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = h.pool.MaxIdleConnsPerHost
+	}
+	if h.pool.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = h.pool.IdleConnTimeout
+	}
+
+	if h.transports == nil {
+		h.transports = make(map[string]*pooledTransport)
+	}
+	h.transports[target.WorkloadID] = &pooledTransport{
+		caBundlePath: target.CABundlePath,
+		caBundleData: bytes.Clone(target.CABundleData),
+		transport:    t,
+	}
+	return t, nil
+}
+
+// RetainBackends closes and drops the transports and stdio processes of every
+// backend that is no longer in current. It implements vmcp.BackendReleaser.
+func (h *httpBackendClient) RetainBackends(current []vmcp.Backend) {
+	keep := make(map[string]struct{}, len(current))
+	for i := range current {
+		keep[current[i].ID] = struct{}{}
+	}
+
+	h.transportsMu.Lock()
+	for id, pooled := range h.transports {
+		if _, ok := keep[id]; !ok {
+			pooled.transport.CloseIdleConnections()
+			delete(h.transports, id)
+		}
+	}
+	h.transportsMu.Unlock()
+
+	h.stdioMu.Lock()
+	defer h.stdioMu.Unlock()
+	for id, p := range h.stdio {
+		if _, ok := keep[id]; ok {
+			continue
+		}
+		if err := p.close(); err != nil {
+			slog.Debug("failed to stop stdio backend removed from the registry", "backend", id, "error", err)
+		}
+		delete(h.stdio, id)
+	}
+}
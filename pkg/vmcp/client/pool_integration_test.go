@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
+	"github.com/stacklok/toolhive-core/mcpcompat/server"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth/strategies"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+)
+
+// flakyBackend is a test MCP backend that counts the TCP connections it
+// accepts and fails the first requests for chosen methods, either by dropping
+// the connection or by answering with an HTTP error status, simulating
+// transient transport failures.
+type flakyBackend struct {
+	url         string
+	connections atomic.Int32
+
+	mu           sync.Mutex
+	failures     map[string]int
+	failStatuses map[string]int
+	calls        map[string]int
+}
+
+// failNext makes the next n requests for method fail with a dropped connection.
+func (b *flakyBackend) failNext(method string, n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[method] = n
+}
+
+// failNextWithStatus makes the next n requests for method fail with the HTTP
+// status code.
+func (b *flakyBackend) failNextWithStatus(method string, n int, status int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[method] = n
+	b.failStatuses[method] = status
+}
+
+// callCount returns how many requests for method the backend received.
+func (b *flakyBackend) callCount(method string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.calls[method]
+}
+
+func startFlakyBackend(t *testing.T) *flakyBackend {
+	t.Helper()
+
+	mcpServer := server.NewMCPServer("flaky-backend", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("echo", mcp.WithString("input")),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("echoed")}}, nil
+		})
+	mcpServer.AddResource(mcp.Resource{URI: "file:///data.txt", Name: "data", MIMEType: "text/plain"},
+		func(_ context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{URI: req.Params.URI, MIMEType: "text/plain", Text: "data"},
+			}, nil
+		})
+
+	backend := &flakyBackend{failures: map[string]int{}, failStatuses: map[string]int{}, calls: map[string]int{}}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		rawMessage, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var request struct {
+			Method string `json:"method"`
+		}
+		_ = json.Unmarshal(rawMessage, &request)
+		backend.mu.Lock()
+		backend.calls[request.Method]++
+		fail := backend.failures[request.Method] > 0
+		if fail {
+			backend.failures[request.Method]--
+		}
+		failStatus := backend.failStatuses[request.Method]
+		backend.mu.Unlock()
+
+		if fail && failStatus != 0 {
+			http.Error(w, "backend temporarily failing", failStatus)
+			return
+		}
+		if fail {
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+
+		response := mcpServer.HandleMessage(r.Context(), rawMessage)
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		responseBytes, err := json.Marshal(response)
+		if err != nil {
+			http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.Copy(w, bytes.NewReader(responseBytes))
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				backend.connections.Add(1)
+			}
+		},
+	}
+	go func() { _ = httpServer.Serve(listener) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(ctx)
+	})
+
+	backend.url = "http://127.0.0.1:" + strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	return backend
+}
+
+func newPoolTestClient(t *testing.T, opts ...vmcpclient.Option) vmcp.BackendClient {
+	t.Helper()
+	registry := auth.NewDefaultOutgoingAuthRegistry()
+	require.NoError(t, registry.RegisterStrategy("unauthenticated", &strategies.UnauthenticatedStrategy{}))
+	backendClient, err := vmcpclient.NewHTTPBackendClient(registry, opts...)
+	require.NoError(t, err)
+	return backendClient
+}
+
+// TestBackendClient_ReusesConnections verifies that consecutive operations on
+// a backend share its pooled connection instead of dialing for each call.
+func TestBackendClient_ReusesConnections(t *testing.T) {
+	t.Parallel()
+
+	backend := startFlakyBackend(t)
+	backendClient := newPoolTestClient(t)
+	target := &vmcp.BackendTarget{WorkloadID: "flaky", BaseURL: backend.url, TransportType: "streamable-http"}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	for range 3 {
+		_, err := backendClient.ListCapabilities(ctx, target)
+		require.NoError(t, err)
+	}
+	_, err := backendClient.ReadResource(ctx, target, "file:///data.txt")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, backend.callCount("initialize"), "every operation initializes its own MCP session")
+	assert.Equal(t, int32(1), backend.connections.Load(), "operations must reuse the pooled connection")
+}
+
+// TestBackendClient_RetainBackendsDropsRemovedTransports verifies that a
+// backend's pooled connection is closed once the backend leaves the registry,
+// while the connections of the remaining backends are kept.
+func TestBackendClient_RetainBackendsDropsRemovedTransports(t *testing.T) {
+	t.Parallel()
+
+	kept := startFlakyBackend(t)
+	removed := startFlakyBackend(t)
+	backendClient := newPoolTestClient(t)
+	keptTarget := &vmcp.BackendTarget{WorkloadID: "kept", BaseURL: kept.url, TransportType: "streamable-http"}
+	removedTarget := &vmcp.BackendTarget{WorkloadID: "removed", BaseURL: removed.url, TransportType: "streamable-http"}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	for _, target := range []*vmcp.BackendTarget{keptTarget, removedTarget} {
+		_, err := backendClient.ListCapabilities(ctx, target)
+		require.NoError(t, err)
+	}
+
+	releaser, ok := backendClient.(vmcp.BackendReleaser)
+	require.True(t, ok, "the backend client must release the resources of removed backends")
+	releaser.RetainBackends([]vmcp.Backend{{ID: "kept"}})
+
+	for _, target := range []*vmcp.BackendTarget{keptTarget, removedTarget} {
+		_, err := backendClient.ListCapabilities(ctx, target)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), kept.connections.Load(), "a retained backend keeps its pooled connection")
+	assert.Equal(t, int32(2), removed.connections.Load(), "a removed backend's connection must be closed")
+}
+
+// TestBackendClient_RetriesTransientErrors verifies which operations are
+// retried after a dropped connection.
+func TestBackendClient_RetriesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	policy := vmcpclient.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	tests := []struct {
+		name       string
+		opts       []vmcpclient.Option
+		failMethod string
+		failures   int
+		failStatus int
+		idempotent bool
+		call       func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error
+		wantErr    bool
+		wantCalls  int
+	}{
+		{
+			name:       "list capabilities recovers",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "tools/list",
+			failures:   2,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.ListCapabilities(ctx, target)
+				return err
+			},
+			wantCalls: 3,
+		},
+		{
+			name:       "read resource recovers",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "resources/read",
+			failures:   1,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.ReadResource(ctx, target, "file:///data.txt")
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name:       "service unavailable response recovers",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "resources/read",
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.ReadResource(ctx, target, "file:///data.txt")
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name:       "not implemented response is not retried",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "resources/read",
+			failures:   1,
+			failStatus: http.StatusNotImplemented,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.ReadResource(ctx, target, "file:///data.txt")
+				return err
+			},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:       "attempts are bounded by the policy",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "resources/read",
+			failures:   5,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.ReadResource(ctx, target, "file:///data.txt")
+				return err
+			},
+			wantErr:   true,
+			wantCalls: 3,
+		},
+		{
+			name:       "no retries without a policy",
+			failMethod: "resources/read",
+			failures:   1,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.ReadResource(ctx, target, "file:///data.txt")
+				return err
+			},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:       "non-idempotent tool call is not retried",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "tools/call",
+			failures:   1,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.CallTool(ctx, target, "echo", map[string]any{"input": "x"}, nil)
+				return err
+			},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:       "idempotent tool call recovers",
+			opts:       []vmcpclient.Option{vmcpclient.WithRetryPolicy(policy)},
+			failMethod: "tools/call",
+			failures:   1,
+			idempotent: true,
+			call: func(ctx context.Context, c vmcp.BackendClient, target *vmcp.BackendTarget) error {
+				_, err := c.CallTool(ctx, target, "echo", map[string]any{"input": "x"}, nil)
+				return err
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend := startFlakyBackend(t)
+			if tt.failStatus != 0 {
+				backend.failNextWithStatus(tt.failMethod, tt.failures, tt.failStatus)
+			} else {
+				backend.failNext(tt.failMethod, tt.failures)
+			}
+			backendClient := newPoolTestClient(t, tt.opts...)
+			target := &vmcp.BackendTarget{
+				WorkloadID:    "flaky",
+				BaseURL:       backend.url,
+				TransportType: "streamable-http",
+				Idempotent:    tt.idempotent,
+			}
+
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			err := tt.call(ctx, backendClient, target)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, backend.callCount(tt.failMethod))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	healthcontext "github.com/stacklok/toolhive/pkg/vmcp/health/context"
+)
+
+// RetryPolicy controls how idempotent backend operations are retried after a
+// transient transport error: a refused, reset, or prematurely closed
+// connection, or an HTTP 500, 502, 503, or 504 response. ListCapabilities, ReadResource, and
+// GetPrompt are retried; CallTool only when the target tool is annotated
+// idempotent (vmcp.BackendTarget.Idempotent).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. It doubles with every
+	// further retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns a policy of three attempts with a backoff starting
+// at 100ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+	}
+}
+
+// WithRetryPolicy makes the client retry idempotent operations on transient
+// transport errors according to policy. Without it, no operation is retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(h *httpBackendClient) {
+		h.retry = policy
+	}
+}
+
+// withRetry runs attempt until it succeeds, fails with a non-transient error,
+// or the retry policy is exhausted. Non-idempotent operations and health
+// checks run once: the health monitor's own failure threshold already absorbs
+// transient failures, and retrying inside a probe would hide them from it.
+func withRetry[T any](
+	ctx context.Context, h *httpBackendClient, target *vmcp.BackendTarget, operation string, idempotent bool,
+	attempt func() (T, error),
+) (T, error) {
+	maxAttempts := h.retry.MaxAttempts
+	if !idempotent || healthcontext.IsHealthCheck(ctx) {
+		maxAttempts = 1
+	}
+
+	backoff := h.retry.InitialBackoff
+	for n := 1; ; n++ {
+		result, err := attempt()
+		if err == nil || n >= maxAttempts || !isTransientError(ctx, err) {
+			return result, err
+		}
+
+		slog.Debug("retrying backend operation after transient error",
+			"operation", operation, "backend", target.WorkloadID, "attempt", n, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if h.retry.MaxBackoff > 0 {
+			backoff = min(backoff, h.retry.MaxBackoff)
+		}
+	}
+}
+
+// isTransientError reports whether err is a transport failure a retry may
+// overcome. Errors after the caller's context ended, and authentication or
+// protocol errors, are never transient.
+func isTransientError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, vmcp.ErrAuthenticationFailed) || errors.Is(err, vmcp.ErrAuthorizationFailed) {
+		return false
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		vmcp.IsConnectionError(err) || isRetryableStatus(err)
+}
+
+// httpStatusPattern matches the HTTP status the MCP transport embeds in its
+// errors, as in "request failed with status 503: ..." or "status code 503".
+var httpStatusPattern = regexp.MustCompile(`\bstatus(?: code)? (\d{3})\b`)
+
+// isRetryableStatus reports whether err carries an HTTP status that signals a
+// temporarily unavailable backend. Other 5xx statuses, such as 501 Not
+// Implemented, would fail the same way on every attempt.
+func isRetryableStatus(err error) bool {
+	match := httpStatusPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return false
+	}
+	status, _ := strconv.Atoi(match[1])
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,419 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	gosdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
+	"github.com/stacklok/toolhive/pkg/versions"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+const (
+	// stdioStartTimeout bounds starting a stdio backend process and completing
+	// the MCP initialize handshake with it.
+	stdioStartTimeout = 30 * time.Second
+
+	// stdioInitialRestartBackoff is the delay before restarting a stdio backend
+	// process that exited. It doubles with every crash of a process that ran
+	// for less than stdioMaxRestartBackoff.
+	stdioInitialRestartBackoff = time.Second
+
+	// stdioMaxRestartBackoff caps the delay between restarts.
+	stdioMaxRestartBackoff = 30 * time.Second
+)
+
+// stdioProcess is the long-running process of one stdio backend. Unlike a
+// network backend, which gets a new client for every operation, a stdio
+// backend is a single process with a single MCP session, shared by every
+// operation on the backend. The process is started on first use and, when it
+// exits while vMCP still needs it, restarted with an exponential backoff.
+type stdioProcess struct {
+	backendID string
+	command   vmcp.StdioCommand
+
+	mu        sync.Mutex
+	session   *gosdk.ClientSession
+	caps      *mcp.ServerCapabilities
+	startedAt time.Time
+	closed    bool
+	// backoff is the delay before the next restart after a crash.
+	backoff time.Duration
+	// retryAt is the earliest time the process may be started again after a
+	// crash or a failed start.
+	retryAt time.Time
+	// stop is closed by close to cancel a pending restart.
+	stop chan struct{}
+}
+
+func newStdioProcess(backendID string, command *vmcp.StdioCommand) *stdioProcess {
+	return &stdioProcess{
+		backendID: backendID,
+		command: vmcp.StdioCommand{
+			Command: command.Command,
+			Args:    slices.Clone(command.Args),
+			Env:     maps.Clone(command.Env),
+		},
+		backoff: stdioInitialRestartBackoff,
+		stop:    make(chan struct{}),
+	}
+}
+
+// runs reports whether the process runs command.
+func (p *stdioProcess) runs(command *vmcp.StdioCommand) bool {
+	return p.command.Command == command.Command &&
+		slices.Equal(p.command.Args, command.Args) &&
+		maps.Equal(p.command.Env, command.Env)
+}
+
+// connect returns the MCP session of the backend process and the capabilities
+// the backend advertised, starting the process if it is not running.
+func (p *stdioProcess) connect(ctx context.Context) (*stdioSession, *mcp.ServerCapabilities, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, nil, fmt.Errorf("stdio backend %s is stopped", p.backendID)
+	}
+	if p.session == nil {
+		// An on-demand start must not bypass the restart backoff, or every
+		// request would restart a crash-looping process
+		if wait := time.Until(p.retryAt); wait > 0 {
+			return nil, nil, fmt.Errorf("stdio backend %s is restarting, retry in %s",
+				p.backendID, wait.Round(time.Millisecond))
+		}
+		if err := p.startLocked(ctx); err != nil {
+			p.backOffLocked()
+			return nil, nil, err
+		}
+	}
+	return &stdioSession{session: p.session}, p.caps, nil
+}
+
+// startLocked starts the process and performs the MCP initialize handshake.
+// ctx only bounds the start: the process keeps running until close.
+func (p *stdioProcess) startLocked(ctx context.Context) error {
+	// #nosec G204: the command comes from the vMCP configuration
+	cmd := exec.Command(p.command.Command, p.command.Args...)
+	cmd.Env = os.Environ()
+	for _, name := range slices.Sorted(maps.Keys(p.command.Env)) {
+		cmd.Env = append(cmd.Env, name+"="+p.command.Env[name])
+	}
+	// Stdout carries the MCP messages; the backend's own logs go to stderr
+	cmd.Stderr = os.Stderr
+
+	startCtx, cancel := context.WithTimeout(ctx, stdioStartTimeout)
+	defer cancel()
+
+	c := gosdk.NewClient(&gosdk.Implementation{Name: "toolhive-vmcp", Version: versions.Version}, nil)
+	session, err := c.Connect(startCtx, &gosdk.CommandTransport{Command: cmd}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start stdio backend %s: %w", p.backendID, err)
+	}
+
+	caps := &mcp.ServerCapabilities{}
+	if err := convertSDK(session.InitializeResult().Capabilities, caps); err != nil {
+		_ = session.Close()
+		return fmt.Errorf("failed to read capabilities of stdio backend %s: %w", p.backendID, err)
+	}
+
+	p.session = session
+	p.caps = caps
+	p.startedAt = time.Now()
+	slog.Debug("started stdio backend", "backend", p.backendID, "command", p.command.Command, "pid", cmd.Process.Pid)
+
+	go p.watch(session)
+	return nil
+}
+
+// watch waits for the process of session to exit and, unless the exit was
+// requested with close, schedules a restart.
+func (p *stdioProcess) watch(session *gosdk.ClientSession) {
+	err := session.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.session != session || p.closed {
+		return
+	}
+	p.session = nil
+	p.caps = nil
+
+	// A process that stayed up for a while crashed for a new reason
+	if time.Since(p.startedAt) > stdioMaxRestartBackoff {
+		p.backoff = stdioInitialRestartBackoff
+	}
+	slog.Warn("stdio backend exited, restarting", "backend", p.backendID, "backoff", p.backoff, "error", err)
+	go p.restart(p.backOffLocked())
+}
+
+// backOffLocked holds off starting the process for the current backoff, which
+// it returns, and doubles the backoff for the next crash.
+func (p *stdioProcess) backOffLocked() time.Duration {
+	delay := p.backoff
+	p.retryAt = time.Now().Add(delay)
+	p.backoff = min(p.backoff*2, stdioMaxRestartBackoff)
+	return delay
+}
+
+// restart starts the process again after delay, unless it was stopped or
+// already started on demand in the meantime.
+func (p *stdioProcess) restart(delay time.Duration) {
+	select {
+	case <-p.stop:
+		return
+	case <-time.After(delay):
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || p.session != nil {
+		return
+	}
+	if err := p.startLocked(context.Background()); err != nil {
+		slog.Error("failed to restart stdio backend", "backend", p.backendID, "backoff", p.backoff, "error", err)
+		go p.restart(p.backOffLocked())
+	}
+}
+
+// close stops the process, and any pending restart. The process is asked to
+// exit by closing its standard input, and killed if it does not.
+func (p *stdioProcess) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.stop)
+	if p.session == nil {
+		return nil
+	}
+	err := p.session.Close()
+	p.session = nil
+	return err
+}
+
+// stdioBackend returns the process of target's stdio backend, creating it on
+// first use. A changed command replaces the process.
+func (h *httpBackendClient) stdioBackend(target *vmcp.BackendTarget) *stdioProcess {
+	h.stdioMu.Lock()
+	defer h.stdioMu.Unlock()
+
+	if p, ok := h.stdio[target.WorkloadID]; ok {
+		if p.runs(target.Stdio) {
+			return p
+		}
+		if err := p.close(); err != nil {
+			slog.Debug("failed to stop replaced stdio backend", "backend", target.WorkloadID, "error", err)
+		}
+	}
+
+	if h.stdio == nil {
+		h.stdio = make(map[string]*stdioProcess)
+	}
+	p := newStdioProcess(target.WorkloadID, target.Stdio)
+	h.stdio[target.WorkloadID] = p
+	return p
+}
+
+// Close stops the processes of all stdio backends.
+func (h *httpBackendClient) Close() error {
+	h.stdioMu.Lock()
+	defer h.stdioMu.Unlock()
+
+	var errs []error
+	for id, p := range h.stdio {
+		if err := p.close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop stdio backend %s: %w", id, err))
+		}
+	}
+	h.stdio = nil
+	return errors.Join(errs...)
+}
+
+// stdioSession serves backend operations from the shared MCP session of a
+// stdio backend process, converting between the go-sdk types of the session
+// and the mcpcompat types the backend client works with.
+type stdioSession struct {
+	session *gosdk.ClientSession
+}
+
+func (s *stdioSession) ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	res, err := s.session.ListTools(ctx, &gosdk.ListToolsParams{Cursor: string(request.Params.Cursor)})
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+	return convertSDKResult[mcp.ListToolsResult](res)
+}
+
+func (s *stdioSession) ListResources(
+	ctx context.Context, request mcp.ListResourcesRequest,
+) (*mcp.ListResourcesResult, error) {
+	res, err := s.session.ListResources(ctx, &gosdk.ListResourcesParams{Cursor: string(request.Params.Cursor)})
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+	return convertSDKResult[mcp.ListResourcesResult](res)
+}
+
+func (s *stdioSession) ListResourceTemplates(
+	ctx context.Context, request mcp.ListResourceTemplatesRequest,
+) (*mcp.ListResourceTemplatesResult, error) {
+	res, err := s.session.ListResourceTemplates(ctx,
+		&gosdk.ListResourceTemplatesParams{Cursor: string(request.Params.Cursor)})
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+	return convertSDKResult[mcp.ListResourceTemplatesResult](res)
+}
+
+func (s *stdioSession) ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	res, err := s.session.ListPrompts(ctx, &gosdk.ListPromptsParams{Cursor: string(request.Params.Cursor)})
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+	return convertSDKResult[mcp.ListPromptsResult](res)
+}
+
+func (s *stdioSession) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := &gosdk.CallToolParams{
+		Name:      request.Params.Name,
+		Arguments: request.Params.Arguments,
+	}
+	if request.Params.Meta != nil {
+		meta := gosdk.Meta{}
+		if err := convertSDK(request.Params.Meta, &meta); err != nil {
+			return nil, fmt.Errorf("converting call meta: %w", err)
+		}
+		params.Meta = meta
+	}
+	res, err := s.session.CallTool(ctx, params)
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+	return convertSDKResult[mcp.CallToolResult](res)
+}
+
+func (s *stdioSession) ReadResource(ctx context.Context, request mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	res, err := s.session.ReadResource(ctx, &gosdk.ReadResourceParams{URI: request.Params.URI})
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+
+	// mcp.ResourceContents is an interface a JSON round-trip cannot populate
+	out := &mcp.ReadResourceResult{}
+	if len(res.Meta) > 0 {
+		out.Meta = mcp.NewMetaFromMap(res.Meta)
+	}
+	for _, rc := range res.Contents {
+		if rc == nil {
+			continue
+		}
+		if len(rc.Blob) > 0 {
+			out.Contents = append(out.Contents, mcp.BlobResourceContents{
+				URI:      rc.URI,
+				MIMEType: rc.MIMEType,
+				Blob:     base64.StdEncoding.EncodeToString(rc.Blob),
+			})
+			continue
+		}
+		out.Contents = append(out.Contents, mcp.TextResourceContents{
+			URI:      rc.URI,
+			MIMEType: rc.MIMEType,
+			Text:     rc.Text,
+		})
+	}
+	return out, nil
+}
+
+func (s *stdioSession) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	res, err := s.session.GetPrompt(ctx, &gosdk.GetPromptParams{
+		Name:      request.Params.Name,
+		Arguments: request.Params.Arguments,
+	})
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+
+	// mcp.Content is an interface a JSON round-trip cannot populate
+	out := &mcp.GetPromptResult{Description: res.Description}
+	if len(res.Meta) > 0 {
+		out.Meta = mcp.NewMetaFromMap(res.Meta)
+	}
+	for _, pm := range res.Messages {
+		if pm == nil {
+			continue
+		}
+		msg := mcp.PromptMessage{Role: mcp.Role(pm.Role)}
+		if pm.Content != nil {
+			data, err := json.Marshal(pm.Content)
+			if err != nil {
+				return nil, fmt.Errorf("marshaling prompt content: %w", err)
+			}
+			if msg.Content, err = mcp.UnmarshalContent(data); err != nil {
+				return nil, fmt.Errorf("converting prompt content: %w", err)
+			}
+		}
+		out.Messages = append(out.Messages, msg)
+	}
+	return out, nil
+}
+
+func (s *stdioSession) Complete(ctx context.Context, request mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	params := &gosdk.CompleteParams{}
+	if err := convertSDK(request.Params, params); err != nil {
+		return nil, fmt.Errorf("converting completion request: %w", err)
+	}
+	res, err := s.session.Complete(ctx, params)
+	if err != nil {
+		return nil, mapStdioError(err)
+	}
+	return convertSDKResult[mcp.CompleteResult](res)
+}
+
+// mapStdioError maps a JSON-RPC "method not found" error to
+// mcp.ErrMethodNotFound, as the mcpcompat client does.
+func mapStdioError(err error) error {
+	var wireErr *jsonrpc.Error
+	if errors.As(err, &wireErr) && wireErr.Code == jsonrpc.CodeMethodNotFound {
+		return errors.Join(mcp.ErrMethodNotFound, err)
+	}
+	return err
+}
+
+// convertSDK converts between a go-sdk type and its mcpcompat equivalent,
+// which share the MCP wire format.
+func convertSDK(src, dst any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// convertSDKResult converts a go-sdk result into its mcpcompat equivalent.
+func convertSDKResult[T any](src any) (*T, error) {
+	out := new(T)
+	if err := convertSDK(src, out); err != nil {
+		return nil, fmt.Errorf("converting result: %w", err)
+	}
+	return out, nil
+}
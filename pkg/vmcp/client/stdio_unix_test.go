@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package client
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	gosdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// fakeStdioServerEnv makes the test binary run fakeStdioServer instead of the
+// tests, so that stdio backends can be tested against a real child process.
+const fakeStdioServerEnv = "VMCP_FAKE_STDIO_SERVER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeStdioServerEnv) == "1" {
+		fakeStdioServer()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeStdioServer serves MCP over stdin/stdout with a "pid" tool returning the
+// process ID and a "greeting" tool returning the FAKE_GREETING variable.
+func fakeStdioServer() {
+	server := gosdk.NewServer(&gosdk.Implementation{Name: "fake-stdio", Version: "1.0.0"}, nil)
+	textTool := func(text func() string) gosdk.ToolHandlerFor[struct{}, any] {
+		return func(context.Context, *gosdk.CallToolRequest, struct{}) (*gosdk.CallToolResult, any, error) {
+			return &gosdk.CallToolResult{Content: []gosdk.Content{&gosdk.TextContent{Text: text()}}}, nil, nil
+		}
+	}
+	gosdk.AddTool(server, &gosdk.Tool{Name: "pid", Description: "Returns the process ID"},
+		textTool(func() string { return strconv.Itoa(os.Getpid()) }))
+	gosdk.AddTool(server, &gosdk.Tool{Name: "greeting", Description: "Returns FAKE_GREETING"},
+		textTool(func() string { return os.Getenv("FAKE_GREETING") }))
+	_ = server.Run(context.Background(), &gosdk.StdioTransport{})
+}
+
+func newStdioTestTarget(t *testing.T) *vmcp.BackendTarget {
+	t.Helper()
+
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	return &vmcp.BackendTarget{
+		WorkloadID:    "fake-stdio",
+		WorkloadName:  "fake-stdio",
+		TransportType: "stdio",
+		Stdio: &vmcp.StdioCommand{
+			Command: executable,
+			Env:     map[string]string{fakeStdioServerEnv: "1", "FAKE_GREETING": "hello"},
+		},
+	}
+}
+
+func callStdioTool(ctx context.Context, h *httpBackendClient, target *vmcp.BackendTarget, tool string) (string, error) {
+	result, err := h.CallTool(ctx, target, tool, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(result.Content) != 1 {
+		return "", assert.AnError
+	}
+	return result.Content[0].Text, nil
+}
+
+func stdioProcessAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
+
+func TestStdioBackend_ListCapabilitiesAndCallTool(t *testing.T) {
+	t.Parallel()
+
+	h := &httpBackendClient{}
+	t.Cleanup(func() { _ = h.Close() })
+	target := newStdioTestTarget(t)
+	ctx := t.Context()
+
+	caps, err := h.ListCapabilities(ctx, target)
+	require.NoError(t, err)
+	names := make([]string, 0, len(caps.Tools))
+	for _, tool := range caps.Tools {
+		names = append(names, tool.Name)
+		assert.Equal(t, "fake-stdio", tool.BackendID)
+	}
+	assert.ElementsMatch(t, []string{"pid", "greeting"}, names)
+
+	greeting, err := callStdioTool(ctx, h, target, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", greeting, "env must be passed to the process")
+
+	// Every operation shares the same process
+	first, err := callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+	_, err = h.ListCapabilities(ctx, target)
+	require.NoError(t, err)
+	second, err := callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestStdioBackend_RestartsAfterCrash(t *testing.T) {
+	t.Parallel()
+
+	h := &httpBackendClient{}
+	t.Cleanup(func() { _ = h.Close() })
+	target := newStdioTestTarget(t)
+	ctx := t.Context()
+
+	before, err := callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(before)
+	require.NoError(t, err)
+
+	p := h.stdioBackend(target)
+	p.mu.Lock()
+	p.backoff = 10 * time.Millisecond
+	p.mu.Unlock()
+	require.NoError(t, syscall.Kill(pid, syscall.SIGKILL))
+
+	assert.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.session != nil && !stdioProcessAlive(pid)
+	}, 10*time.Second, 20*time.Millisecond, "the process must be restarted")
+
+	after, err := callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+}
+
+func TestStdioBackend_CloseStopsProcess(t *testing.T) {
+	t.Parallel()
+
+	h := &httpBackendClient{}
+	target := newStdioTestTarget(t)
+	ctx := t.Context()
+
+	out, err := callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(out)
+	require.NoError(t, err)
+	require.True(t, stdioProcessAlive(pid))
+
+	_ = h.Close()
+	assert.False(t, stdioProcessAlive(pid), "Close must stop and reap the process")
+
+	// A closed client starts the process again on the next call
+	_, err = h.ListCapabilities(ctx, target)
+	require.NoError(t, err)
+	require.NoError(t, h.Close())
+}
+
+func TestStdioBackend_CommandFailsToStart(t *testing.T) {
+	t.Parallel()
+
+	h := &httpBackendClient{}
+	t.Cleanup(func() { _ = h.Close() })
+	target := newStdioTestTarget(t)
+	target.Stdio = &vmcp.StdioCommand{Command: "/nonexistent/mcp-server"}
+
+	_, err := h.ListCapabilities(t.Context(), target)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fake-stdio")
+}
+
+func TestStdioBackend_OnDemandStartRespectsBackoff(t *testing.T) {
+	t.Parallel()
+
+	h := &httpBackendClient{}
+	t.Cleanup(func() { _ = h.Close() })
+	target := newStdioTestTarget(t)
+	ctx := t.Context()
+
+	out, err := callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(out)
+	require.NoError(t, err)
+
+	p := h.stdioBackend(target)
+	p.mu.Lock()
+	p.backoff = time.Hour
+	p.mu.Unlock()
+	require.NoError(t, syscall.Kill(pid, syscall.SIGKILL))
+
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.session == nil
+	}, 10*time.Second, 20*time.Millisecond, "the crash must be noticed")
+
+	// A call during the backoff fails instead of restarting the process
+	_, err = callStdioTool(ctx, h, target, "pid")
+	require.ErrorIs(t, err, vmcp.ErrBackendUnavailable)
+	assert.Contains(t, err.Error(), "restarting")
+	p.mu.Lock()
+	assert.Nil(t, p.session, "the process must not be started before the backoff expires")
+	p.retryAt = time.Time{}
+	p.mu.Unlock()
+
+	// Once the backoff expired, the next call starts the process
+	_, err = callStdioTool(ctx, h, target, "pid")
+	require.NoError(t, err)
+}
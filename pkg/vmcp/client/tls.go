@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// BackendTLSConfig holds the resolved TLS settings of one backend.
+type BackendTLSConfig struct {
+	// CABundle is PEM-encoded CA certificates trusted, in addition to the
+	// system roots and any CA bundle the backend was discovered with, when
+	// verifying the backend's certificate.
+	CABundle []byte
+
+	// ClientCert and ClientKey are the PEM-encoded certificate and private key
+	// presented to backends that require mutual TLS. Both or neither are set.
+	ClientCert []byte
+	ClientKey  []byte
+
+	// InsecureSkipVerify disables verification of the backend's certificate.
+	// It is never enabled implicitly.
+	InsecureSkipVerify bool
+}
+
+// WithBackendTLS sets per-backend TLS settings, keyed by backend workload ID.
+// Backends without an entry use the default TLS configuration.
+func WithBackendTLS(backends map[string]BackendTLSConfig) Option {
+	return func(h *httpBackendClient) {
+		h.backendTLS = maps.Clone(backends)
+	}
+}
+
+// applyBackendTLS layers cfg onto the TLS configuration of t.
+func applyBackendTLS(t *http.Transport, cfg BackendTLSConfig) error {
+	var tlsConfig *tls.Config
+	if t.TLSClientConfig != nil {
+		tlsConfig = t.TLSClientConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{}
+	}
+	tlsConfig.MinVersion = max(tlsConfig.MinVersion, tls.VersionTLS12)
+
+	if len(cfg.CABundle) > 0 {
+		var pool *x509.CertPool
+		if tlsConfig.RootCAs != nil {
+			pool = tlsConfig.RootCAs.Clone()
+		} else if systemPool, err := x509.SystemCertPool(); err == nil {
+			pool = systemPool
+		} else {
+			// Fall back to empty pool if system certs can't be loaded
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CABundle) {
+			return fmt.Errorf("failed to parse backend CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(cfg.ClientCert) > 0 || len(cfg.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return fmt.Errorf("failed to load backend client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // explicit per-backend opt-in
+	}
+
+	t.TLSClientConfig = tlsConfig
+	return nil
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-core/mcpcompat/server"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+)
+
+// startTLSBackend starts an HTTPS MCP backend with a certificate issued by a
+// CA unknown to the system trust store, and returns its URL and that CA in PEM
+// form. When clientCAs is non-nil the backend requires a client certificate
+// signed by one of them.
+func startTLSBackend(t *testing.T, clientCAs *x509.CertPool) (string, []byte) {
+	t.Helper()
+
+	mcpServer := server.NewMCPServer("tls-backend", "1.0.0")
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		rawMessage, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request", http.StatusBadRequest)
+			return
+		}
+		response := mcpServer.HandleMessage(r.Context(), rawMessage)
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	if clientCAs != nil {
+		backend.TLS = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	backend.StartTLS()
+	t.Cleanup(backend.Close)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: backend.Certificate().Raw})
+	return backend.URL, caPEM
+}
+
+// newClientCertificate returns a self-signed client CA pool and a client
+// certificate and key, in PEM form, issued by it.
+func newClientCertificate(t *testing.T) (*x509.CertPool, []byte, []byte) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test client CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	clientTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "vmcp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	clientDER, err := x509.CreateCertificate(rand.Reader, clientTemplate, caCert, &clientKey.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(clientKey)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	return pool,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// TestBackendClient_BackendTLS verifies that a backend's custom CA and client
+// certificate are used for its connections, and that certificate verification
+// is only skipped when explicitly configured.
+func TestBackendClient_BackendTLS(t *testing.T) {
+	t.Parallel()
+
+	clientCAs, clientCert, clientKey := newClientCertificate(t)
+
+	tests := []struct {
+		name        string
+		requireMTLS bool
+		tlsConfig   func(caPEM []byte) map[string]vmcpclient.BackendTLSConfig
+		wantErr     bool
+	}{
+		{
+			name:      "untrusted CA is rejected by default",
+			tlsConfig: func([]byte) map[string]vmcpclient.BackendTLSConfig { return nil },
+			wantErr:   true,
+		},
+		{
+			name: "custom CA is trusted",
+			tlsConfig: func(caPEM []byte) map[string]vmcpclient.BackendTLSConfig {
+				return map[string]vmcpclient.BackendTLSConfig{"private": {CABundle: caPEM}}
+			},
+		},
+		{
+			name: "custom CA of another backend is not trusted",
+			tlsConfig: func(caPEM []byte) map[string]vmcpclient.BackendTLSConfig {
+				return map[string]vmcpclient.BackendTLSConfig{"other": {CABundle: caPEM}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "client certificate alone does not skip verification",
+			tlsConfig: func([]byte) map[string]vmcpclient.BackendTLSConfig {
+				return map[string]vmcpclient.BackendTLSConfig{"private": {ClientCert: clientCert, ClientKey: clientKey}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "explicit insecure skip verify is honored",
+			tlsConfig: func([]byte) map[string]vmcpclient.BackendTLSConfig {
+				return map[string]vmcpclient.BackendTLSConfig{"private": {InsecureSkipVerify: true}}
+			},
+		},
+		{
+			name:        "mutual TLS without a client certificate is rejected",
+			requireMTLS: true,
+			tlsConfig: func(caPEM []byte) map[string]vmcpclient.BackendTLSConfig {
+				return map[string]vmcpclient.BackendTLSConfig{"private": {CABundle: caPEM}}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "mutual TLS with a client certificate",
+			requireMTLS: true,
+			tlsConfig: func(caPEM []byte) map[string]vmcpclient.BackendTLSConfig {
+				return map[string]vmcpclient.BackendTLSConfig{
+					"private": {CABundle: caPEM, ClientCert: clientCert, ClientKey: clientKey},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requiredCAs *x509.CertPool
+			if tt.requireMTLS {
+				requiredCAs = clientCAs
+			}
+			url, caPEM := startTLSBackend(t, requiredCAs)
+			backendClient := newPoolTestClient(t, vmcpclient.WithBackendTLS(tt.tlsConfig(caPEM)))
+			target := &vmcp.BackendTarget{WorkloadID: "private", BaseURL: url, TransportType: "streamable-http"}
+
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			_, err := backendClient.ListCapabilities(ctx, target)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestBackendClient_HTTPClientUsesBackendTLS verifies that the HTTP client used
+// by the health monitor's HTTP GET probe carries the backend's TLS settings.
+func TestBackendClient_HTTPClientUsesBackendTLS(t *testing.T) {
+	t.Parallel()
+
+	clientCAs, clientCert, clientKey := newClientCertificate(t)
+	url, caPEM := startTLSBackend(t, clientCAs)
+	backendClient := newPoolTestClient(t, vmcpclient.WithBackendTLS(map[string]vmcpclient.BackendTLSConfig{
+		"private": {CABundle: caPEM, ClientCert: clientCert, ClientKey: clientKey},
+	}))
+	target := &vmcp.BackendTarget{WorkloadID: "private", BaseURL: url, TransportType: "streamable-http"}
+
+	httpClients, ok := backendClient.(interface {
+		HTTPClient(context.Context, *vmcp.BackendTarget) (*http.Client, error)
+	})
+	require.True(t, ok, "backend client must build HTTP clients for health probes")
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	probeClient, err := httpClients.HTTPClient(ctx, target)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/healthz", nil)
+	require.NoError(t, err)
+	resp, err := probeClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/health"
+)
+
+// WithBackendHealthCheck makes the engine verify, before a workflow starts,
+// that every backend its tool steps route to is available, failing fast with
+// ErrBackendUnavailable instead of running steps that leave partial state.
+//
+// provider supplies live health status. When it is nil, or does not monitor a
+// backend, the status carried by the routing target is used. Workflows with
+// SkipBackendHealthCheck set are not checked.
+func WithBackendHealthCheck(provider health.StatusProvider) EngineOption {
+	return func(e *workflowEngine) {
+		e.checkBackendHealth = true
+		e.backendHealth = provider
+	}
+}
+
+// checkBackendsAvailable returns ErrBackendUnavailable listing every
+// unavailable backend referenced by the workflow's tool steps (including
+// forEach inner steps). Tools that do not route are left to fail in their step.
+// Composite steps are checked when their nested workflow starts.
+func (e *workflowEngine) checkBackendsAvailable(ctx context.Context, def *WorkflowDefinition) error {
+	if !e.checkBackendHealth || def.SkipBackendHealthCheck {
+		return nil
+	}
+
+	checked := make(map[string]bool)
+	var unavailable []string
+	for i := range def.Steps {
+		tool := stepBackendTool(&def.Steps[i])
+		if tool == "" {
+			continue
+		}
+		target, err := e.router.RouteTool(ctx, tool)
+		if err != nil || checked[target.WorkloadID] {
+			continue
+		}
+		checked[target.WorkloadID] = true
+
+		status := target.HealthStatus
+		if e.backendHealth != nil {
+			if current, ok := e.backendHealth.QueryBackendStatus(target.WorkloadID); ok {
+				status = current
+			}
+		}
+		if !isBackendAvailable(status) {
+			unavailable = append(unavailable, fmt.Sprintf("%s (%s)", target.WorkloadID, status))
+		}
+	}
+
+	if len(unavailable) == 0 {
+		return nil
+	}
+	slices.Sort(unavailable)
+	return fmt.Errorf("%w: %s", ErrBackendUnavailable, strings.Join(unavailable, ", "))
+}
+
+// stepBackendTool returns the backend tool a step calls, or "" if it calls none.
+func stepBackendTool(step *WorkflowStep) string {
+	switch {
+	case step.Type == StepTypeTool:
+		return step.Tool
+	case step.Type == StepTypeForEach && step.InnerStep != nil && step.InnerStep.Type == StepTypeTool:
+		return step.InnerStep.Tool
+	default:
+		return ""
+	}
+}
+
+// isBackendAvailable reports whether a backend in the given state accepts
+// requests. It matches the capability filtering applied during aggregation:
+// healthy, degraded, and unset (assumed healthy) backends are available.
+func isBackendAvailable(status vmcp.BackendHealthStatus) bool {
+	return status == "" || status == vmcp.BackendHealthy || status == vmcp.BackendDegraded
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// fakeStatusProvider reports fixed health states for the backends it monitors.
+type fakeStatusProvider map[string]vmcp.BackendHealthStatus
+
+func (f fakeStatusProvider) QueryBackendStatus(backendID string) (vmcp.BackendHealthStatus, bool) {
+	status, ok := f[backendID]
+	return status, ok
+}
+
+// newHealthCheckedTestEngine creates a test engine that checks backend health
+// against provider before running workflows.
+func newHealthCheckedTestEngine(t *testing.T, provider fakeStatusProvider) *testEngine {
+	t.Helper()
+	te := newTestEngine(t)
+	te.Engine = NewWorkflowEngine(te.Router, te.Backend, nil, nil, nil, nil, WithBackendHealthCheck(provider))
+	return te
+}
+
+func TestWorkflowEngine_BackendHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	github := &vmcp.BackendTarget{WorkloadID: "github", BaseURL: "http://github:8080"}
+	jira := &vmcp.BackendTarget{WorkloadID: "jira", BaseURL: "http://jira:8080"}
+	workflow := func() *WorkflowDefinition {
+		return simpleWorkflow("triage",
+			toolStep("issue", "github.get_issue", map[string]any{"number": 1}),
+			toolStepWithDeps("ticket", "jira.create_ticket", map[string]any{"title": "bug"}, []string{"issue"}),
+		)
+	}
+	okResult := &vmcp.ToolCallResult{StructuredContent: map[string]any{"ok": true}}
+
+	t.Run("runs the workflow when all backends are healthy", func(t *testing.T) {
+		t.Parallel()
+		te := newHealthCheckedTestEngine(t, fakeStatusProvider{
+			"github": vmcp.BackendHealthy,
+			"jira":   vmcp.BackendDegraded,
+		})
+		// Each tool is routed once by the pre-check and once by its step.
+		te.Router.EXPECT().RouteTool(gomock.Any(), "github.get_issue").Return(github, nil).Times(2)
+		te.Router.EXPECT().RouteTool(gomock.Any(), "jira.create_ticket").Return(jira, nil).Times(2)
+		te.Backend.EXPECT().CallTool(gomock.Any(), github, "github.get_issue", gomock.Any(), gomock.Any()).Return(okResult, nil)
+		te.Backend.EXPECT().CallTool(gomock.Any(), jira, "jira.create_ticket", gomock.Any(), gomock.Any()).Return(okResult, nil)
+
+		result, err := execute(t, te.Engine, workflow(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, WorkflowStatusCompleted, result.Status)
+	})
+
+	t.Run("fails fast listing the unavailable backend", func(t *testing.T) {
+		t.Parallel()
+		te := newHealthCheckedTestEngine(t, fakeStatusProvider{
+			"github": vmcp.BackendHealthy,
+			"jira":   vmcp.BackendUnhealthy,
+		})
+		te.Router.EXPECT().RouteTool(gomock.Any(), "github.get_issue").Return(github, nil)
+		te.Router.EXPECT().RouteTool(gomock.Any(), "jira.create_ticket").Return(jira, nil)
+		// No CallTool expectation: no step may run.
+
+		_, err := execute(t, te.Engine, workflow(), nil)
+		require.ErrorIs(t, err, ErrBackendUnavailable)
+		assert.Contains(t, err.Error(), "jira (unhealthy)")
+		assert.NotContains(t, err.Error(), "github")
+	})
+
+	t.Run("falls back to the routing target status for unmonitored backends", func(t *testing.T) {
+		t.Parallel()
+		te := newHealthCheckedTestEngine(t, nil)
+		down := &vmcp.BackendTarget{WorkloadID: "github", HealthStatus: vmcp.BackendUnauthenticated}
+		te.Router.EXPECT().RouteTool(gomock.Any(), "github.get_issue").Return(down, nil)
+
+		def := simpleWorkflow("fetch", toolStep("issue", "github.get_issue", nil))
+		_, err := execute(t, te.Engine, def, nil)
+		require.ErrorIs(t, err, ErrBackendUnavailable)
+		assert.Contains(t, err.Error(), "github (unauthenticated)")
+	})
+
+	t.Run("skips the check when the workflow opts out", func(t *testing.T) {
+		t.Parallel()
+		te := newHealthCheckedTestEngine(t, fakeStatusProvider{"github": vmcp.BackendUnhealthy})
+		te.expectToolCall("github.get_issue", map[string]any{"number": 1}, map[string]any{"ok": true})
+
+		def := simpleWorkflow("fetch", toolStep("issue", "github.get_issue", map[string]any{"number": 1}))
+		def.SkipBackendHealthCheck = true
+		result, err := execute(t, te.Engine, def, nil)
+		require.NoError(t, err)
+		assert.Equal(t, WorkflowStatusCompleted, result.Status)
+	})
+}
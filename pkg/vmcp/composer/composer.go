@@ -65,10 +65,18 @@ type WorkflowDefinition struct {
 	// Options: "abort" (default), "continue", "best_effort"
 	FailureMode string
 
+	// Join defines how the parallel branches of each execution level are
+	// joined. If nil, every branch must complete (JoinStrategyAll).
+	Join *JoinConfig
+
 	// Output defines the structured output schema for this workflow.
 	// If nil, the workflow returns the last step's output (backward compatible).
 	Output *config.OutputConfig
 
+	// SkipBackendHealthCheck disables the pre-execution check that every
+	// backend referenced by the workflow's tool steps is available.
+	SkipBackendHealthCheck bool
+
 	// Metadata stores additional workflow information.
 	Metadata map[string]string
 }
@@ -78,11 +86,12 @@ type WorkflowStep struct {
 	// ID uniquely identifies this step within the workflow.
 	ID string
 
-	// Type is the step type: "tool", "elicitation"
+	// Type is the step type: "tool", "elicitation", "forEach", "composite"
 	Type StepType
 
 	// Tool is the tool to call (for tool steps).
 	// Format: "toolname" or "backend.toolname"
+	// For composite steps, it is the name of the composite tool to invoke.
 	Tool string
 
 	// Arguments are the tool arguments with template expansion support.
@@ -150,6 +159,10 @@ const (
 
 	// StepTypeForEach iterates over a collection and executes an inner step for each item.
 	StepTypeForEach StepType = "forEach"
+
+	// StepTypeComposite invokes another composite tool. Its Arguments become the
+	// nested workflow's parameters and its output is the nested workflow's output.
+	StepTypeComposite StepType = "composite"
 )
 
 // ErrorHandler defines how to handle step failures.
@@ -169,6 +182,32 @@ type ErrorHandler struct {
 	ContinueOnError bool
 }
 
+// JoinStrategy defines when a level of parallel branches is done.
+type JoinStrategy string
+
+const (
+	// JoinStrategyAll waits for every branch to complete.
+	JoinStrategyAll JoinStrategy = "all"
+
+	// JoinStrategyAny completes on the first branch that succeeds and cancels
+	// the rest. Useful for racing equivalent backends.
+	JoinStrategyAny JoinStrategy = "any"
+
+	// JoinStrategyNOfM completes once N branches succeed and cancels the rest,
+	// tolerating failures of the remaining branches.
+	JoinStrategyNOfM JoinStrategy = "n-of-m"
+)
+
+// JoinConfig defines how parallel branches are joined.
+type JoinConfig struct {
+	// Strategy is the join strategy. Default: "all".
+	Strategy JoinStrategy
+
+	// N is the number of branches that must succeed for the n-of-m strategy.
+	// Levels with fewer branches require all of them.
+	N int
+}
+
 // ElicitationConfig defines parameters for elicitation steps.
 type ElicitationConfig struct {
 	// Message is the prompt message shown to the user.
@@ -187,6 +226,12 @@ type ElicitationConfig struct {
 
 	// OnCancel defines what to do if user cancels.
 	OnCancel *ElicitationHandler
+
+	// TimeoutDefault is the response content used when the user does not
+	// respond before Timeout. The step then completes with action "timeout"
+	// and this content. When nil, a timeout is handled by OnCancel, or fails
+	// the step.
+	TimeoutDefault map[string]any
 }
 
 // ElicitationHandler defines how to handle elicitation responses.
@@ -208,9 +253,18 @@ type WorkflowResult struct {
 	// Typically the output of the last step.
 	Output map[string]any
 
+	// PartialOutputs contains the outputs of the steps that completed, keyed by
+	// step ID. Only set when the workflow times out, so clients can salvage work.
+	PartialOutputs map[string]any
+
 	// Steps contains the results of each step.
 	Steps map[string]*StepResult
 
+	// JoinedBranches lists, in completion order, the parallel steps whose
+	// success satisfied an "any" or "n-of-m" join. The branches cancelled
+	// once a join was satisfied are in Steps with StepStatusCancelled.
+	JoinedBranches []string
+
 	// Error contains error information if the workflow failed.
 	Error error
 
@@ -235,6 +289,9 @@ type StepResult struct {
 	// Status is the step status.
 	Status StepStatusType
 
+	// Input contains the expanded arguments of tool and composite steps.
+	Input map[string]any
+
 	// Output contains the step output data (from StructuredContent or ContentArrayToMap fallback).
 	Output map[string]any
 
@@ -342,6 +399,10 @@ const (
 
 	// StepStatusSkipped indicates the step was skipped (condition was false).
 	StepStatusSkipped StepStatusType = "skipped"
+
+	// StepStatusCancelled indicates the step was cancelled because the join
+	// of its parallel branches was already satisfied.
+	StepStatusCancelled StepStatusType = "cancelled"
 )
 
 // TemplateExpander handles template expansion for workflow arguments.
@@ -386,6 +447,10 @@ type WorkflowContext struct {
 	// Access must be synchronized using mu.
 	Workflow *WorkflowMetadata
 
+	// pendingElicitations holds the elicitations awaiting a user response,
+	// keyed by step ID. Access must be synchronized using mu.
+	pendingElicitations map[string]*PendingElicitation
+
 	// mu protects concurrent access to Steps map and Workflow metadata during parallel execution.
 	mu sync.RWMutex
 }
@@ -425,6 +490,25 @@ type WorkflowStateStore interface {
 	ListActiveWorkflows(ctx context.Context) ([]string, error)
 }
 
+// IdempotencyStore deduplicates composite tool calls that clients retry with
+// the same idempotency key, so a retry does not repeat the workflow's side
+// effects. It is implemented by the in-memory workflow state store.
+type IdempotencyStore interface {
+	// ExecuteOnce runs execute for the first call with key and returns its
+	// result. A call with the same key made while the first is in flight waits
+	// for it and shares its result; one made after it succeeded gets the stored
+	// result until the key expires. A failed call (an IsError result) releases
+	// the key so the client can retry it.
+	//
+	// fingerprint identifies the call's arguments. Reusing a key with a
+	// different fingerprint returns ErrIdempotencyKeyReused.
+	ExecuteOnce(
+		ctx context.Context,
+		key, fingerprint string,
+		execute func() *vmcp.ToolCallResult,
+	) (*vmcp.ToolCallResult, error)
+}
+
 // ElicitationProtocolHandler handles MCP elicitation protocol interactions.
 //
 // This interface provides an SDK-agnostic abstraction for elicitation requests,
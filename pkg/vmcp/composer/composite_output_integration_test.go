@@ -557,6 +557,99 @@ func TestCompositeToolWithOutputConfig_TypeCoercionErrors(t *testing.T) {
 	}
 }
 
+// TestCompositeToolWithOutputConfig_SchemaEnforcement tests that the output
+// is checked against its schema: step references statically by ValidateWorkflow,
+// and the constructed values at execution time.
+func TestCompositeToolWithOutputConfig_SchemaEnforcement(t *testing.T) {
+	t.Parallel()
+
+	outputWithCount := func(value string) *config.OutputConfig {
+		return &config.OutputConfig{
+			Properties: map[string]config.OutputProperty{
+				"count": {Type: "integer", Description: "Item count", Value: value},
+			},
+			Required: []string{"count"},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		steps          []WorkflowStep
+		output         *config.OutputConfig
+		fetchOutput    map[string]any
+		wantValidation string
+		wantErr        *OutputSchemaError
+		wantOutput     map[string]any
+	}{
+		{
+			name:        "valid output",
+			steps:       []WorkflowStep{toolStep("fetch", "data.fetch", nil)},
+			output:      outputWithCount("{{.steps.fetch.output.count}}"),
+			fetchOutput: map[string]any{"count": 3},
+			wantOutput:  map[string]any{"count": int64(3)},
+		},
+		{
+			name:           "output references a missing step",
+			steps:          []WorkflowStep{toolStep("fetch", "data.fetch", nil)},
+			output:         outputWithCount("{{.steps.fetch_all.output.count}}"),
+			wantValidation: "output references unknown step fetch_all",
+		},
+		{
+			name: "step references a step it does not depend on",
+			steps: []WorkflowStep{
+				toolStep("fetch", "data.fetch", nil),
+				toolStep("store", "data.store", map[string]any{"count": "{{.steps.fetch.output.count}}"}),
+			},
+			wantValidation: "step store references step fetch, which it does not depend on",
+		},
+		{
+			name:        "type mismatch",
+			steps:       []WorkflowStep{toolStep("fetch", "data.fetch", nil)},
+			output:      outputWithCount("{{.steps.fetch.output.count}}"),
+			fetchOutput: map[string]any{"count": "many"},
+			wantErr:     &OutputSchemaError{Property: "count", Type: "integer"},
+		},
+		{
+			name:        "reference the workflow did not produce",
+			steps:       []WorkflowStep{toolStep("fetch", "data.fetch", nil)},
+			output:      outputWithCount("{{.steps.fetch.output.total}}"),
+			fetchOutput: map[string]any{"count": 3},
+			wantErr:     &OutputSchemaError{Property: "count", Type: "integer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			te := newTestEngine(t)
+			workflow := &WorkflowDefinition{Name: "schema_test", Steps: tt.steps, Output: tt.output}
+
+			err := te.Engine.ValidateWorkflow(t.Context(), workflow)
+			if tt.wantValidation != "" {
+				require.ErrorContains(t, err, tt.wantValidation)
+				return
+			}
+			require.NoError(t, err)
+
+			te.expectToolCall("data.fetch", nil, tt.fetchOutput)
+			result, err := execute(t, te.Engine, workflow, nil)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, ErrOutputSchemaMismatch)
+				var schemaErr *OutputSchemaError
+				require.ErrorAs(t, err, &schemaErr)
+				assert.Equal(t, tt.wantErr.Property, schemaErr.Property)
+				assert.Equal(t, tt.wantErr.Type, schemaErr.Type)
+				assert.Equal(t, WorkflowStatusFailed, result.Status)
+				assert.Nil(t, result.Output)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOutput, result.Output)
+		})
+	}
+}
+
 // TestCompositeToolWithOutputConfig_ConditionalStepsWithOutput tests output from conditionally skipped steps.
 func TestCompositeToolWithOutputConfig_ConditionalStepsWithOutput(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// newCompositeTestEngine creates a test engine that knows the given composite
+// tool definitions.
+func newCompositeTestEngine(t *testing.T, limits WorkflowLimits, defs ...*WorkflowDefinition) *testEngine {
+	t.Helper()
+	te := newTestEngine(t)
+	registry := make(map[string]*WorkflowDefinition, len(defs))
+	for _, def := range defs {
+		registry[def.Name] = def
+	}
+	te.Engine = NewWorkflowEngine(te.Router, te.Backend, nil, nil, nil, nil,
+		WithWorkflowLimits(limits), WithCompositeDefinitions(registry))
+	return te
+}
+
+func TestWorkflowEngine_CompositeStep(t *testing.T) {
+	t.Parallel()
+
+	fetchIssue := simpleWorkflow("fetch_issue",
+		toolStep("get", "github.get_issue", map[string]any{"number": "{{.params.number}}"}),
+	)
+	summarizeIssue := simpleWorkflow("summarize_issue",
+		compositeStep("fetch", "fetch_issue", map[string]any{"number": "{{.params.id}}"}),
+		toolStepWithDeps("summarize", "llm.summarize",
+			map[string]any{"text": "{{.steps.fetch.output.body}}"}, []string{"fetch"}),
+	)
+
+	t.Run("passes mapped parameters and exposes nested output", func(t *testing.T) {
+		t.Parallel()
+		te := newCompositeTestEngine(t, WorkflowLimits{}, fetchIssue, summarizeIssue)
+		te.expectToolCall("github.get_issue", map[string]any{"number": "42"}, map[string]any{"body": "it broke"})
+		te.expectToolCall("llm.summarize", map[string]any{"text": "it broke"}, map[string]any{"summary": "broken"})
+
+		require.NoError(t, te.Engine.ValidateWorkflow(context.Background(), summarizeIssue))
+		result, err := execute(t, te.Engine, summarizeIssue, map[string]any{"id": 42})
+		require.NoError(t, err)
+		assert.Equal(t, WorkflowStatusCompleted, result.Status)
+		assert.Equal(t, map[string]any{"body": "it broke"}, result.Steps["fetch"].Output)
+		assert.Equal(t, map[string]any{"summary": "broken"}, result.Output)
+	})
+
+	t.Run("nested failure fails the composite step", func(t *testing.T) {
+		t.Parallel()
+		te := newCompositeTestEngine(t, WorkflowLimits{}, fetchIssue, summarizeIssue)
+		te.expectToolCallWithError("github.get_issue", map[string]any{"number": "42"}, errors.New("backend down"))
+
+		result, err := execute(t, te.Engine, summarizeIssue, map[string]any{"id": 42})
+		require.ErrorIs(t, err, ErrToolCallFailed)
+		assert.Equal(t, WorkflowStatusFailed, result.Status)
+		assert.Equal(t, StepStatusFailed, result.Steps["fetch"].Status)
+		assert.NotContains(t, result.Steps, "summarize")
+	})
+
+	t.Run("nested steps count against the caller's step budget", func(t *testing.T) {
+		t.Parallel()
+		te := newCompositeTestEngine(t, WorkflowLimits{MaxSteps: 2}, fetchIssue, summarizeIssue)
+		te.expectToolCall("github.get_issue", map[string]any{"number": "42"}, map[string]any{"body": "it broke"})
+		// llm.summarize would be the third step and must never be called.
+
+		_, err := execute(t, te.Engine, summarizeIssue, map[string]any{"id": 42})
+		require.ErrorIs(t, err, ErrMaxStepsExceeded)
+	})
+
+	t.Run("nested timeout keeps partial outputs on the step", func(t *testing.T) {
+		t.Parallel()
+		slowFetch := simpleWorkflow("slow_fetch",
+			toolStep("get", "github.get_issue", map[string]any{}),
+			toolStepWithDeps("comments", "github.list_comments", map[string]any{}, []string{"get"}),
+		)
+		slowFetch.Timeout = 50 * time.Millisecond
+		parent := simpleWorkflow("parent", compositeStep("fetch", "slow_fetch", map[string]any{}))
+
+		te := newCompositeTestEngine(t, WorkflowLimits{}, slowFetch, parent)
+		te.expectToolCall("github.get_issue", map[string]any{}, map[string]any{"body": "it broke"})
+		target := &vmcp.BackendTarget{WorkloadID: "test", BaseURL: "http://test:8080"}
+		te.Router.EXPECT().RouteTool(gomock.Any(), "github.list_comments").Return(target, nil)
+		te.Backend.EXPECT().CallTool(gomock.Any(), target, "github.list_comments", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, _ string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+		result, err := execute(t, te.Engine, parent, nil)
+		require.ErrorIs(t, err, ErrWorkflowTimeout)
+		assert.Equal(t, StepStatusFailed, result.Steps["fetch"].Status)
+		assert.Equal(t, map[string]any{"get": map[string]any{"body": "it broke"}}, result.Steps["fetch"].Output)
+	})
+
+	t.Run("validation rejects unknown composite tools", func(t *testing.T) {
+		t.Parallel()
+		te := newCompositeTestEngine(t, WorkflowLimits{}, summarizeIssue)
+
+		err := te.Engine.ValidateWorkflow(context.Background(), summarizeIssue)
+		require.ErrorIs(t, err, ErrWorkflowNotFound)
+		assert.Contains(t, err.Error(), "unknown composite tool fetch_issue")
+	})
+}
@@ -6,6 +6,7 @@ package composer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -51,28 +52,44 @@ type executionLevel struct {
 	steps []*WorkflowStep
 }
 
+// joinOutcome records how the parallel levels of a DAG execution were joined.
+type joinOutcome struct {
+	// joined lists, in completion order, the branches whose success satisfied
+	// an "any" or "n-of-m" join.
+	joined []string
+
+	// cancelled lists the branches cancelled once their level's join was satisfied.
+	cancelled []string
+}
+
 // executeDAG executes workflow steps using DAG-based parallel execution.
 //
 // The algorithm works as follows:
 //  1. Build a dependency graph from the steps
 //  2. Perform topological sort to identify execution levels
 //  3. Execute each level in parallel (steps within a level are independent)
-//  4. Wait for all steps in a level to complete before proceeding to next level
+//  4. Wait for the steps in a level to be joined before proceeding to next level
 //  5. Aggregate errors and handle based on failure mode
+//
+// Under an "any" or "n-of-m" join, a level with several branches is done as
+// soon as enough of them succeed; the returned joinOutcome lists the branches
+// that contributed and the ones that were cancelled. It is never nil.
 func (d *dagExecutor) executeDAG(
 	ctx context.Context,
 	steps []WorkflowStep,
 	execFunc func(context.Context, *WorkflowStep) error,
 	failureMode string,
-) error {
+	join *JoinConfig,
+) (*joinOutcome, error) {
+	outcome := &joinOutcome{}
 	if len(steps) == 0 {
-		return nil
+		return outcome, nil
 	}
 
 	// Build execution levels using topological sort
 	levels, err := d.buildExecutionLevels(steps)
 	if err != nil {
-		return fmt.Errorf("failed to build execution levels: %w", err)
+		return outcome, fmt.Errorf("failed to build execution levels: %w", err)
 	}
 
 	// Log execution plan statistics for observability
@@ -85,12 +102,33 @@ func (d *dagExecutor) executeDAG(
 		slog.Debug("executing level", "level", levelIdx, "steps", len(level.steps))
 
 		// Execute all steps in this level in parallel
-		if err := d.executeLevel(ctx, level, execFunc, failureMode); err != nil {
-			return err
+		if required, ok := requiredBranches(join, len(level.steps)); ok {
+			err = d.executeJoinLevel(ctx, level, execFunc, failureMode, required, outcome)
+		} else {
+			err = d.executeLevel(ctx, level, execFunc, failureMode)
+		}
+		if err != nil {
+			return outcome, err
 		}
 	}
 
-	return nil
+	return outcome, nil
+}
+
+// requiredBranches returns how many branches of a level must succeed under
+// join, and false when the level waits for every branch instead.
+func requiredBranches(join *JoinConfig, branches int) (int, bool) {
+	if join == nil || branches < 2 {
+		return branches, false
+	}
+	switch join.Strategy {
+	case JoinStrategyAny:
+		return 1, true
+	case JoinStrategyNOfM:
+		return max(min(join.N, branches), 1), true
+	case JoinStrategyAll:
+	}
+	return branches, false
 }
 
 // executeLevel executes all steps in a level in parallel.
@@ -125,8 +163,9 @@ func (d *dagExecutor) executeLevel(
 			if err != nil {
 				slog.Error("step failed", "step", step.ID, "error", err)
 
-				// Check if we should continue despite the error
-				shouldContinue := d.shouldContinueOnError(step, failureMode)
+				// Check if we should continue despite the error. Exceeding a
+				// workflow limit always aborts, regardless of the failure mode.
+				shouldContinue := !isLimitExceeded(err) && d.shouldContinueOnError(step, failureMode)
 				if shouldContinue {
 					errorsMu.Lock()
 					continuedErrors = append(continuedErrors, err)
@@ -155,6 +194,94 @@ func (d *dagExecutor) executeLevel(
 	return nil
 }
 
+// executeJoinLevel executes the branches of a level in parallel until required
+// of them succeed, then cancels the rest. Branch failures are tolerated while
+// the join can still be satisfied; exceeding a workflow limit always aborts.
+func (d *dagExecutor) executeJoinLevel(
+	ctx context.Context,
+	level *executionLevel,
+	execFunc func(context.Context, *WorkflowStep) error,
+	failureMode string,
+	required int,
+	outcome *joinOutcome,
+) error {
+	joinCtx, cancelJoin := context.WithCancel(ctx)
+	defer cancelJoin()
+	g, groupCtx := errgroup.WithContext(joinCtx)
+
+	var mu sync.Mutex
+	var joined, cancelled []string
+	var branchErrors []error
+	satisfied := false
+
+	for _, step := range level.steps {
+		g.Go(func() error {
+			select {
+			case d.semaphore <- struct{}{}:
+				defer func() { <-d.semaphore }()
+			case <-groupCtx.Done():
+				mu.Lock()
+				defer mu.Unlock()
+				if satisfied {
+					cancelled = append(cancelled, step.ID)
+					return nil
+				}
+				return groupCtx.Err()
+			}
+
+			err := execFunc(groupCtx, step)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case satisfied:
+				// The join completed while this branch was running.
+				if err != nil {
+					cancelled = append(cancelled, step.ID)
+				}
+				return nil
+			case err == nil:
+				joined = append(joined, step.ID)
+				if len(joined) == required {
+					satisfied = true
+					cancelJoin()
+				}
+				return nil
+			case isLimitExceeded(err):
+				return err
+			}
+
+			slog.Warn("parallel branch failed", "step", step.ID, "error", err)
+			branchErrors = append(branchErrors, err)
+			if len(level.steps)-len(branchErrors) < required && failureMode != failureModeContinue {
+				return joinNotSatisfied(len(joined), required, branchErrors)
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	outcome.joined = append(outcome.joined, joined...)
+	outcome.cancelled = append(outcome.cancelled, cancelled...)
+	if err != nil {
+		return fmt.Errorf("level execution failed: %w", err)
+	}
+	if !satisfied {
+		slog.Warn("level completed without satisfying its join",
+			"succeeded", len(joined), "required", required, "mode", failureMode)
+	} else if len(cancelled) > 0 {
+		slog.Debug("cancelled parallel branches after join", "steps", cancelled)
+	}
+
+	return nil
+}
+
+// joinNotSatisfied returns the error of a level whose join can no longer be satisfied.
+func joinNotSatisfied(succeeded, required int, branchErrors []error) error {
+	return fmt.Errorf("%w: %d of %d required branches succeeded: %w",
+		ErrJoinNotSatisfied, succeeded, required, errors.Join(branchErrors...))
+}
+
 // shouldContinueOnError determines if execution should continue after a step error.
 func (*dagExecutor) shouldContinueOnError(step *WorkflowStep, failureMode string) bool {
 	// Check step-level error handling
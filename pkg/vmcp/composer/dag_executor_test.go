@@ -203,7 +203,7 @@ func TestDAGExecutor_ParallelExecution(t *testing.T) {
 		return nil
 	}
 
-	err := executor.executeDAG(context.Background(), steps, execFunc, "abort")
+	_, err := executor.executeDAG(context.Background(), steps, execFunc, "abort", nil)
 	require.NoError(t, err)
 
 	// All 3 independent steps should have run concurrently
@@ -235,7 +235,7 @@ func TestDAGExecutor_DependencyOrder(t *testing.T) {
 		return nil
 	}
 
-	err := executor.executeDAG(context.Background(), steps, execFunc, "abort")
+	_, err := executor.executeDAG(context.Background(), steps, execFunc, "abort", nil)
 	require.NoError(t, err)
 
 	// Steps must execute in order
@@ -293,7 +293,7 @@ func TestDAGExecutor_ErrorHandling(t *testing.T) {
 				return nil
 			}
 
-			err := executor.executeDAG(context.Background(), steps, execFunc, tt.failureMode)
+			_, err := executor.executeDAG(context.Background(), steps, execFunc, tt.failureMode, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -308,6 +308,98 @@ func TestDAGExecutor_ErrorHandling(t *testing.T) {
 	}
 }
 
+// TestDAGExecutor_JoinStrategies tests joining parallel branches with the
+// all, any and n-of-m strategies.
+func TestDAGExecutor_JoinStrategies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		join          *JoinConfig
+		slowSucceeds  bool
+		wantJoined    []string
+		wantCancelled []string
+		wantAfter     bool
+		wantErr       error
+	}{
+		{
+			name:         "all fails on the failing branch",
+			join:         &JoinConfig{Strategy: JoinStrategyAll},
+			slowSucceeds: true,
+			wantErr:      errBranchFailed,
+		},
+		{
+			name:          "any cancels losing branches",
+			join:          &JoinConfig{Strategy: JoinStrategyAny},
+			wantJoined:    []string{"fast"},
+			wantCancelled: []string{"slow"},
+			wantAfter:     true,
+		},
+		{
+			name:         "n-of-m tolerates a failed branch",
+			join:         &JoinConfig{Strategy: JoinStrategyNOfM, N: 2},
+			slowSucceeds: true,
+			wantJoined:   []string{"fast", "slow"},
+			wantAfter:    true,
+		},
+		{
+			name:    "n-of-m fails once the join is unreachable",
+			join:    &JoinConfig{Strategy: JoinStrategyNOfM, N: 3},
+			wantErr: ErrJoinNotSatisfied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			executor := newDAGExecutor(10)
+
+			steps := []WorkflowStep{
+				{ID: "fast"},
+				{ID: "slow"},
+				{ID: "failing"},
+				{ID: "after", DependsOn: []string{"fast", "slow", "failing"}},
+			}
+
+			failed := make(chan struct{})
+			var afterRan atomic.Bool
+			execFunc := func(ctx context.Context, step *WorkflowStep) error {
+				switch step.ID {
+				case "failing":
+					close(failed)
+					return errBranchFailed
+				case "fast":
+					<-failed
+					return nil
+				case "slow":
+					if tt.slowSucceeds {
+						<-failed
+						time.Sleep(10 * time.Millisecond)
+						return nil
+					}
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				afterRan.Store(true)
+				return nil
+			}
+
+			outcome, err := executor.executeDAG(context.Background(), steps, execFunc, "abort", tt.join)
+			assert.Equal(t, tt.wantAfter, afterRan.Load())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.wantJoined, outcome.joined)
+			assert.ElementsMatch(t, tt.wantCancelled, outcome.cancelled)
+		})
+	}
+}
+
+// errBranchFailed is returned by the failing branch of the join tests.
+var errBranchFailed = errors.New("branch failed")
+
 // TestDAGExecutor_StepLevelErrorHandling tests per-step error handling.
 func TestDAGExecutor_StepLevelErrorHandling(t *testing.T) {
 	t.Parallel()
@@ -334,7 +426,7 @@ func TestDAGExecutor_StepLevelErrorHandling(t *testing.T) {
 	}
 
 	// Even with "abort" mode, step2's ContinueOnError should allow execution to continue
-	err := executor.executeDAG(context.Background(), steps, execFunc, "abort")
+	_, err := executor.executeDAG(context.Background(), steps, execFunc, "abort", nil)
 	assert.NoError(t, err)
 	assert.Len(t, executed, 3, "all steps should execute")
 }
@@ -380,7 +472,7 @@ func TestDAGExecutor_Concurrency(t *testing.T) {
 		return nil
 	}
 
-	err := executor.executeDAG(context.Background(), steps, execFunc, "abort")
+	_, err := executor.executeDAG(context.Background(), steps, execFunc, "abort", nil)
 	require.NoError(t, err)
 
 	// Max concurrent should not exceed the semaphore limit
@@ -428,7 +520,7 @@ func TestDAGExecutor_ContextCancellation(t *testing.T) {
 		return nil
 	}
 
-	err := executor.executeDAG(ctx, steps, execFunc, "abort")
+	_, err := executor.executeDAG(ctx, steps, execFunc, "abort", nil)
 	assert.Error(t, err)
 
 	// Only first step should have executed
@@ -505,7 +597,7 @@ func TestDAGExecutor_ComplexWorkflow(t *testing.T) {
 		return nil
 	}
 
-	err := executor.executeDAG(context.Background(), steps, execFunc, "abort")
+	_, err := executor.executeDAG(context.Background(), steps, execFunc, "abort", nil)
 
 	require.NoError(t, err)
 	assert.Len(t, executionOrder, 8, "all steps should execute")
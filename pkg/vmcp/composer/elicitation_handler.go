@@ -140,15 +140,11 @@ func (h *DefaultElicitationHandler) RequestElicitation(
 	}
 
 	// Apply and validate timeout (security: prevent timeout bomb attacks)
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = defaultElicitationTimeout
-	}
-	if timeout > maxElicitationTimeout {
+	if config.Timeout > maxElicitationTimeout {
 		slog.Warn("elicitation timeout exceeds maximum, capping to maximum",
-			"timeout", timeout, "max", maxElicitationTimeout, "step", stepID)
-		timeout = maxElicitationTimeout
+			"timeout", config.Timeout, "max", maxElicitationTimeout, "step", stepID)
 	}
+	timeout := elicitationTimeout(config)
 
 	// Validate schema size and structure (security: prevent memory exhaustion)
 	if err := validateSchemaSize(config.Schema); err != nil {
@@ -214,6 +210,16 @@ func (h *DefaultElicitationHandler) RequestElicitation(
 	return response, nil
 }
 
+// elicitationTimeout returns how long an elicitation waits for the user: the
+// configured timeout, defaulted and capped to maxElicitationTimeout.
+func elicitationTimeout(config *ElicitationConfig) time.Duration {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultElicitationTimeout
+	}
+	return min(timeout, maxElicitationTimeout)
+}
+
 // validateConfig validates elicitation configuration.
 func validateConfig(config *ElicitationConfig) error {
 	if config == nil {
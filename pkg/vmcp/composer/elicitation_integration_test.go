@@ -592,3 +592,101 @@ func TestWorkflowEngine_ElicitationMessageTemplateExpansion(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowEngine_ElicitationTimeoutDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		respond        bool
+		timeoutDefault map[string]any
+		wantStatus     WorkflowStatusType
+		wantOutput     map[string]any
+	}{
+		{
+			name:       "client responds",
+			respond:    true,
+			wantStatus: WorkflowStatusCompleted,
+			wantOutput: map[string]any{"action": "accept", "content": map[string]any{"environment": "production"}},
+		},
+		{
+			name:           "client times out with a default",
+			timeoutDefault: map[string]any{"environment": "staging"},
+			wantStatus:     WorkflowStatusCompleted,
+			wantOutput:     map[string]any{"action": "timeout", "content": map[string]any{"environment": "staging"}},
+		},
+		{
+			name:       "client times out without a default",
+			wantStatus: WorkflowStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			te := newTestEngine(t)
+			mockSDK := mocks.NewMockElicitationRequester(te.Ctrl)
+			stateStore := NewInMemoryStateStore(1*time.Minute, 1*time.Hour)
+			engine := NewWorkflowEngine(te.Router, te.Backend, NewDefaultElicitationHandler(mockSDK), stateStore, nil, nil)
+
+			var workflowID string
+			mockSDK.EXPECT().RequestElicitation(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, _ vmcp.ElicitationRequest) (*vmcp.ElicitationResult, error) {
+					// The workflow waits for the user while the request is open.
+					active, err := stateStore.ListActiveWorkflows(ctx)
+					require.NoError(t, err)
+					require.Len(t, active, 1)
+					workflowID = active[0]
+
+					status, err := engine.GetWorkflowStatus(ctx, workflowID)
+					require.NoError(t, err)
+					assert.Equal(t, WorkflowStatusWaitingForElicitation, status.Status)
+					require.Len(t, status.PendingElicitations, 1)
+					assert.Equal(t, "confirm", status.PendingElicitations[0].StepID)
+					assert.Equal(t, "Which environment?", status.PendingElicitations[0].Message)
+					assert.True(t, status.PendingElicitations[0].ExpiresAt.After(time.Now()))
+
+					if tt.respond {
+						return &vmcp.ElicitationResult{
+							Action:  "accept",
+							Content: map[string]any{"environment": "production"},
+						}, nil
+					}
+					<-ctx.Done()
+					return nil, ctx.Err()
+				})
+
+			workflow := &WorkflowDefinition{
+				Name: "deployment-workflow",
+				Steps: []WorkflowStep{
+					{
+						ID:   "confirm",
+						Type: StepTypeElicitation,
+						Elicitation: &ElicitationConfig{
+							Message:        "Which environment?",
+							Schema:         map[string]any{"type": "object"},
+							Timeout:        50 * time.Millisecond,
+							TimeoutDefault: tt.timeoutDefault,
+						},
+					},
+				},
+			}
+
+			result, err := engine.ExecuteWorkflow(context.Background(), workflow, nil)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			if tt.wantStatus == WorkflowStatusFailed {
+				require.ErrorIs(t, err, ErrElicitationTimeout)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantOutput, result.Steps["confirm"].Output)
+			}
+
+			// The final state reflects the resolution.
+			status, err := engine.GetWorkflowStatus(context.Background(), workflowID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status.Status)
+			assert.Empty(t, status.PendingElicitations)
+		})
+	}
+}
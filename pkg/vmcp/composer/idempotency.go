@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// IdempotencyKeyMetaKey is the _meta key a client sets on a composite tool
+// call to make retrying it safe. Calls to the same tool by the same caller
+// with the same key are executed once.
+const IdempotencyKeyMetaKey = "dev.stacklok.toolhive/idempotencyKey"
+
+// idempotentCall is a composite tool call claimed by an idempotency key.
+type idempotentCall struct {
+	fingerprint string
+
+	// done is closed when the call finishes. result and completedAt are only
+	// read after done is closed.
+	done chan struct{}
+
+	// result is the stored result, or nil if the call failed and released its key.
+	result *vmcp.ToolCallResult
+
+	// completedAt is when the call succeeded; zero while it is in flight.
+	completedAt time.Time
+}
+
+// expired reports whether a completed call's result is older than ttl. Calls
+// in flight never expire.
+func (c *idempotentCall) expired(now time.Time, ttl time.Duration) bool {
+	return !c.completedAt.IsZero() && now.Sub(c.completedAt) > ttl
+}
+
+// ExecuteOnce implements IdempotencyStore. Successful results are kept for the
+// store's maxAge, the same time completed workflow state is retained.
+func (s *inMemoryStateStore) ExecuteOnce(
+	ctx context.Context,
+	key, fingerprint string,
+	execute func() *vmcp.ToolCallResult,
+) (*vmcp.ToolCallResult, error) {
+	for {
+		s.mu.Lock()
+		call, exists := s.idempotentCalls[key]
+		if exists && call.expired(time.Now(), s.maxAge) {
+			delete(s.idempotentCalls, key)
+			exists = false
+		}
+		if !exists {
+			call = &idempotentCall{fingerprint: fingerprint, done: make(chan struct{})}
+			s.idempotentCalls[key] = call
+			s.mu.Unlock()
+			return s.runIdempotent(key, call, execute), nil
+		}
+		s.mu.Unlock()
+
+		if call.fingerprint != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for in-flight call: %w", ctx.Err())
+		}
+		if call.result != nil {
+			return call.result, nil
+		}
+		// The call failed and released the key; claim it and execute again.
+	}
+}
+
+// runIdempotent executes a call that claimed key, stores its result if it
+// succeeded, and releases the key otherwise.
+func (s *inMemoryStateStore) runIdempotent(
+	key string,
+	call *idempotentCall,
+	execute func() *vmcp.ToolCallResult,
+) (result *vmcp.ToolCallResult) {
+	// Release waiters and the key even if execute panics.
+	defer func() {
+		s.mu.Lock()
+		if result != nil && !result.IsError {
+			call.result = result
+			call.completedAt = time.Now()
+		} else if s.idempotentCalls[key] == call {
+			delete(s.idempotentCalls, key)
+		}
+		s.mu.Unlock()
+		close(call.done)
+	}()
+	return execute()
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+func newTestIdempotencyStore(t *testing.T, ttl time.Duration) *inMemoryStateStore {
+	t.Helper()
+	store := NewInMemoryStateStore(time.Hour, ttl).(*inMemoryStateStore)
+	t.Cleanup(store.Stop)
+	return store
+}
+
+// countingExecute returns an execute func that counts its invocations and
+// returns result.
+func countingExecute(calls *atomic.Int32, result *vmcp.ToolCallResult) func() *vmcp.ToolCallResult {
+	return func() *vmcp.ToolCallResult {
+		calls.Add(1)
+		return result
+	}
+}
+
+func TestInMemoryStateStore_ExecuteOnce(t *testing.T) {
+	t.Parallel()
+
+	ok := &vmcp.ToolCallResult{StructuredContent: map[string]any{"ok": true}}
+	failed := &vmcp.ToolCallResult{IsError: true}
+
+	t.Run("retry returns the stored result", func(t *testing.T) {
+		t.Parallel()
+		store := newTestIdempotencyStore(t, time.Hour)
+		var calls atomic.Int32
+
+		first, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+		retry, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Same(t, first, retry)
+	})
+
+	t.Run("retry of an in-flight call waits for it", func(t *testing.T) {
+		t.Parallel()
+		store := newTestIdempotencyStore(t, time.Hour)
+		var calls atomic.Int32
+		started, release := make(chan struct{}), make(chan struct{})
+		slow := func() *vmcp.ToolCallResult {
+			calls.Add(1)
+			close(started)
+			<-release
+			return ok
+		}
+
+		var wg sync.WaitGroup
+		results := make([]*vmcp.ToolCallResult, 2)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[0], _ = store.ExecuteOnce(context.Background(), "k", "args", slow)
+		}()
+		<-started
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[1], _ = store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, failed))
+		}()
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Same(t, ok, results[0])
+		assert.Same(t, ok, results[1])
+	})
+
+	t.Run("failed call releases the key", func(t *testing.T) {
+		t.Parallel()
+		store := newTestIdempotencyStore(t, time.Hour)
+		var calls atomic.Int32
+
+		first, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, failed))
+		require.NoError(t, err)
+		assert.True(t, first.IsError)
+		retry, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Same(t, ok, retry)
+	})
+
+	t.Run("key reused with different arguments", func(t *testing.T) {
+		t.Parallel()
+		store := newTestIdempotencyStore(t, time.Hour)
+		var calls atomic.Int32
+
+		_, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+		_, err = store.ExecuteOnce(context.Background(), "k", "other-args", countingExecute(&calls, ok))
+
+		require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("expired key executes again", func(t *testing.T) {
+		t.Parallel()
+		store := newTestIdempotencyStore(t, time.Millisecond)
+		var calls atomic.Int32
+
+		_, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		_, err = store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("cleanup removes expired keys", func(t *testing.T) {
+		t.Parallel()
+		store := newTestIdempotencyStore(t, time.Millisecond)
+		var calls atomic.Int32
+
+		_, err := store.ExecuteOnce(context.Background(), "k", "args", countingExecute(&calls, ok))
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		store.cleanup()
+
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+		assert.Empty(t, store.idempotentCalls)
+	})
+}
@@ -9,9 +9,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
 
 	thvjson "github.com/stacklok/toolhive/pkg/json"
+	"github.com/stacklok/toolhive/pkg/templates"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 )
 
@@ -23,6 +26,9 @@ const (
 	typeBoolean = "boolean"
 	typeObject  = "object"
 	typeArray   = "array"
+
+	// noValuePlaceholder is what text/template prints for a missing map key.
+	noValuePlaceholder = "<no value>"
 )
 
 // constructOutputFromConfig builds the workflow output from the output configuration.
@@ -48,17 +54,9 @@ func (e *workflowEngine) constructOutputFromConfig(
 		output[propertyName] = value
 	}
 
-	// Validate required fields are present and non-nil
-	if len(outputConfig.Required) > 0 {
-		for _, requiredField := range outputConfig.Required {
-			value, exists := output[requiredField]
-			if !exists {
-				return nil, fmt.Errorf("required output field %q is missing", requiredField)
-			}
-			if value == nil {
-				return nil, fmt.Errorf("required output field %q is nil", requiredField)
-			}
-		}
+	// Validate required fields and value types against the declared schema
+	if err := validateConstructedOutput(output, outputConfig.Properties, outputConfig.Required, ""); err != nil {
+		return nil, err
 	}
 
 	return output, nil
@@ -86,12 +84,18 @@ func (e *workflowEngine) constructOutputProperty(
 }
 
 // constructOutputPropertyFromValue constructs a property value from a template.
+// A value that cannot be constructed as declared falls back to the property's
+// default; without one, an *OutputSchemaError is returned.
 func (e *workflowEngine) constructOutputPropertyFromValue(
 	ctx context.Context,
 	propertyName string,
 	propertyDef config.OutputProperty,
 	workflowCtx *WorkflowContext,
 ) (any, error) {
+	schemaErr := func(format string, args ...any) error {
+		return &OutputSchemaError{Property: propertyName, Type: propertyDef.Type, Message: fmt.Sprintf(format, args...)}
+	}
+
 	// Expand the template using a map wrapper
 	templateMap := map[string]any{"_value": propertyDef.Value}
 	expanded, err := e.templateExpander.Expand(ctx, templateMap, workflowCtx)
@@ -102,7 +106,7 @@ func (e *workflowEngine) constructOutputPropertyFromValue(
 			return e.coerceRawJSONDefaultValue(propertyDef.Default, propertyDef.Type)
 		}
 		// No default - propagate error
-		return nil, fmt.Errorf("failed to expand template for property %q: %w", propertyName, err)
+		return nil, schemaErr("failed to expand template: %v", err)
 	}
 
 	// Extract the expanded string value
@@ -113,11 +117,18 @@ func (e *workflowEngine) constructOutputPropertyFromValue(
 		return expandedVal, nil
 	}
 
-	// Check if template expansion returned "<no value>" placeholder (missing field)
-	// In this case, fallback to default value if available
-	if expandedStr == "<no value>" && !propertyDef.Default.IsEmpty() {
-		slog.Warn("template expanded to <no value> for property, using default value", "property", propertyName)
-		return e.coerceRawJSONDefaultValue(propertyDef.Default, propertyDef.Type)
+	// Check if template expansion returned the "<no value>" placeholder of a
+	// reference the workflow did not produce. Fall back to the default value
+	// if available rather than emitting a malformed result.
+	if strings.Contains(expandedStr, noValuePlaceholder) {
+		if !propertyDef.Default.IsEmpty() {
+			slog.Warn("template expanded to <no value> for property, using default value", "property", propertyName)
+			return e.coerceRawJSONDefaultValue(propertyDef.Default, propertyDef.Type)
+		}
+		if ref := unresolvedStepReference(propertyDef.Value, workflowCtx); ref != "" {
+			return nil, schemaErr("template references %s, which the workflow did not produce", ref)
+		}
+		return nil, schemaErr("template expanded to %s", noValuePlaceholder)
 	}
 
 	// For object types, attempt JSON deserialization
@@ -132,7 +143,7 @@ func (e *workflowEngine) constructOutputPropertyFromValue(
 				slog.Warn("failed to deserialize JSON for property, using default value", "property", propertyName, "error", err)
 				return e.coerceRawJSONDefaultValue(propertyDef.Default, propertyDef.Type)
 			}
-			return nil, fmt.Errorf("failed to deserialize JSON for object property %q: %w", propertyName, err)
+			return nil, schemaErr("failed to deserialize JSON object: %v", err)
 		}
 		return obj, nil
 	}
@@ -146,7 +157,7 @@ func (e *workflowEngine) constructOutputPropertyFromValue(
 				slog.Warn("failed to deserialize JSON array for property, using default value", "property", propertyName, "error", err)
 				return e.coerceRawJSONDefaultValue(propertyDef.Default, propertyDef.Type)
 			}
-			return nil, fmt.Errorf("failed to deserialize JSON array for property %q: %w", propertyName, err)
+			return nil, schemaErr("failed to deserialize JSON array: %v", err)
 		}
 		return arr, nil
 	}
@@ -159,12 +170,47 @@ func (e *workflowEngine) constructOutputPropertyFromValue(
 			slog.Warn("failed to coerce value for property, using default value", "property", propertyName, "error", err)
 			return e.coerceRawJSONDefaultValue(propertyDef.Default, propertyDef.Type)
 		}
-		return nil, fmt.Errorf("failed to coerce value for property %q: %w", propertyName, err)
+		return nil, schemaErr("%v", err)
 	}
 
 	return typedValue, nil
 }
 
+// unresolvedStepReference returns the first {{.steps.<id>.output...}}
+// reference of tmpl that does not resolve to a value the workflow produced,
+// or "" if they all resolve.
+func unresolvedStepReference(tmpl string, workflowCtx *WorkflowContext) string {
+	refs, err := templates.ExtractReferences(tmpl)
+	if err != nil {
+		return ""
+	}
+	slices.Sort(refs)
+	for _, ref := range refs {
+		parts := strings.Split(strings.TrimPrefix(ref, "."), ".")
+		if len(parts) < 2 || parts[0] != "steps" {
+			continue
+		}
+		result, ok := workflowCtx.GetStepResult(parts[1])
+		if !ok {
+			return ref
+		}
+		if len(parts) < 3 || parts[2] != "output" {
+			continue
+		}
+		var value any = result.Output
+		for _, key := range parts[3:] {
+			fields, ok := value.(map[string]any)
+			if !ok {
+				return ref
+			}
+			if value, ok = fields[key]; !ok {
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
 // constructOutputPropertyFromProperties constructs a property value from nested properties.
 func (e *workflowEngine) constructOutputPropertyFromProperties(
 	ctx context.Context,
@@ -216,7 +216,7 @@ func TestConstructOutputFromConfig(t *testing.T) {
 			errMsg:      "required output field",
 		},
 		{
-			name: "missing step reference returns no value placeholder",
+			name: "missing step reference is a schema error",
 			outputCfg: &config.OutputConfig{
 				Properties: map[string]config.OutputProperty{
 					"result": {
@@ -229,11 +229,8 @@ func TestConstructOutputFromConfig(t *testing.T) {
 			workflowCtx: &WorkflowContext{
 				Steps: map[string]*StepResult{},
 			},
-			// Template expansion returns "<no value>" for missing fields
-			want: map[string]any{
-				"result": "<no value>",
-			},
-			wantErr: false,
+			wantErr: true,
+			errMsg:  "template references .steps.missing_step.output.data, which the workflow did not produce",
 		},
 		{
 			name: "invalid JSON for object type",
@@ -297,11 +294,9 @@ func TestConstructOutputFromConfig(t *testing.T) {
 					},
 				},
 			},
-			// Without default, <no value> is returned as-is
-			want: map[string]any{
-				"result": "<no value>",
-			},
-			wantErr: false,
+			// Without default, the unresolved reference is a schema error
+			wantErr: true,
+			errMsg:  "template references .steps.step1.output.nonexistent, which the workflow did not produce",
 		},
 		{
 			name: "missing field with no value placeholder and default",
@@ -6,6 +6,7 @@ package composer
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 )
@@ -164,6 +165,93 @@ func validateOutputProperty(name string, prop config.OutputProperty, depth int)
 	return nil
 }
 
+// validateConstructedOutput checks a constructed workflow output against its
+// declared schema at execution time: every required field is present and
+// non-nil, and every value has its property's declared type. path is the
+// dotted path of the enclosing property, empty at the top level.
+func validateConstructedOutput(
+	output map[string]any,
+	properties map[string]config.OutputProperty,
+	required []string,
+	path string,
+) error {
+	for _, name := range required {
+		if output[name] == nil {
+			return &OutputSchemaError{
+				Property: joinPropertyPath(path, name),
+				Type:     properties[name].Type,
+				Message:  "required output field is missing",
+			}
+		}
+	}
+
+	for name, prop := range properties {
+		value := output[name]
+		if value == nil {
+			continue
+		}
+		propertyPath := joinPropertyPath(path, name)
+		if actual := jsonSchemaType(value); !outputTypeMatches(prop.Type, actual) {
+			return &OutputSchemaError{
+				Property: propertyPath,
+				Type:     prop.Type,
+				Message:  fmt.Sprintf("expected %s, got %s", prop.Type, actual),
+			}
+		}
+		if nested, ok := value.(map[string]any); ok && len(prop.Properties) > 0 {
+			if err := validateConstructedOutput(nested, prop.Properties, nil, propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// joinPropertyPath appends name to a dotted property path.
+func joinPropertyPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonSchemaType returns the JSON Schema type of a constructed output value.
+func jsonSchemaType(value any) string {
+	switch v := value.(type) {
+	case string:
+		return typeString
+	case bool:
+		return typeBoolean
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return typeInteger
+	case float32:
+		return numberType(float64(v))
+	case float64:
+		return numberType(v)
+	case map[string]any:
+		return typeObject
+	case []any:
+		return typeArray
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// numberType returns "integer" for integral numbers and "number" otherwise.
+func numberType(v float64) string {
+	if v == math.Trunc(v) {
+		return typeInteger
+	}
+	return typeNumber
+}
+
+// outputTypeMatches reports whether a value of the actual JSON Schema type
+// satisfies the declared type. Integers are numbers.
+func outputTypeMatches(declared, actual string) bool {
+	return declared == actual || (declared == typeNumber && actual == typeInteger)
+}
+
 // validateTemplateSyntax performs basic template syntax validation.
 // This doesn't validate template variable references (like .steps.foo.output)
 // since those depend on runtime workflow structure. This is validated separately.
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// validateParameters checks workflow parameters against the composite tool's
+// declared parameter schema (JSON Schema). Defaults must already be applied.
+// A workflow without a parameter schema accepts any parameters.
+//
+// All violations are reported in a single error wrapping ErrInvalidParameters,
+// so a client can fix every argument in one round trip.
+func validateParameters(inputSchema map[string]any, params map[string]any) error {
+	if len(inputSchema) == 0 {
+		return nil
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewGoLoader(inputSchema),
+		gojsonschema.NewGoLoader(params),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: failed to evaluate parameter schema: %v", ErrInvalidParameters, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, desc := range result.Errors() {
+		violations = append(violations, desc.String())
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidParameters, strings.Join(violations, "; "))
+}
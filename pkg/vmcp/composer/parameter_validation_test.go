@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParameters(t *testing.T) {
+	t.Parallel()
+
+	inputSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"repo":  map[string]any{"type": "string"},
+			"limit": map[string]any{"type": "integer", "minimum": 1},
+		},
+		"required": []any{"repo"},
+	}
+
+	tests := []struct {
+		name    string
+		schema  map[string]any
+		params  map[string]any
+		wantErr []string
+	}{
+		{
+			name:   "valid parameters",
+			schema: inputSchema,
+			params: map[string]any{"repo": "stacklok/toolhive", "limit": float64(5)},
+		},
+		{
+			name:    "missing required parameter",
+			schema:  inputSchema,
+			params:  nil,
+			wantErr: []string{"repo is required"},
+		},
+		{
+			name:    "reports every violation",
+			schema:  inputSchema,
+			params:  map[string]any{"repo": 42, "limit": float64(0)},
+			wantErr: []string{"repo: Invalid type", "limit: Must be greater than or equal to 1"},
+		},
+		{
+			name:   "no schema accepts anything",
+			params: map[string]any{"anything": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateParameters(tt.schema, tt.params)
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidParameters)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestWorkflowEngine_ExecuteWorkflow_RejectsInvalidParameters(t *testing.T) {
+	t.Parallel()
+	te := newTestEngine(t)
+
+	def := &WorkflowDefinition{
+		Name: "typed",
+		Parameters: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"count": map[string]any{"type": "integer"}},
+			"required":   []any{"count"},
+		},
+		Steps: []WorkflowStep{toolStep("s1", "tool", map[string]any{})},
+	}
+
+	// No backend call may happen for a rejected workflow.
+	result, err := execute(t, te.Engine, def, map[string]any{"count": "many"})
+	require.ErrorIs(t, err, ErrInvalidParameters)
+	assert.Nil(t, result)
+}
@@ -19,10 +19,14 @@ type inMemoryStateStore struct {
 	mu     sync.RWMutex
 	states map[string]*WorkflowStatus
 
+	// idempotentCalls tracks composite tool calls by idempotency key.
+	idempotentCalls map[string]*idempotentCall
+
 	// cleanupInterval defines how often to run cleanup of stale workflows.
 	cleanupInterval time.Duration
 
-	// maxAge defines how long to keep completed/failed workflows.
+	// maxAge defines how long to keep completed/failed workflows, and the
+	// results of idempotent calls.
 	maxAge time.Duration
 
 	// stopCleanup signals the cleanup goroutine to stop.
@@ -44,6 +48,7 @@ func NewInMemoryStateStore(cleanupInterval, maxAge time.Duration) WorkflowStateS
 
 	store := &inMemoryStateStore{
 		states:          make(map[string]*WorkflowStatus),
+		idempotentCalls: make(map[string]*idempotentCall),
 		cleanupInterval: cleanupInterval,
 		maxAge:          maxAge,
 		stopCleanup:     make(chan struct{}),
@@ -212,6 +217,12 @@ func (s *inMemoryStateStore) cleanup() {
 		slog.Debug("cleaned up stale workflows", "count", removed)
 	}
 
+	for key, call := range s.idempotentCalls {
+		if call.expired(now, s.maxAge) {
+			delete(s.idempotentCalls, key)
+		}
+	}
+
 	// Log state store metrics for observability (every cleanup cycle)
 	s.logMetrics()
 }
@@ -11,6 +11,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/templates"
 	"github.com/stacklok/toolhive/pkg/vmcp/conversion"
 )
@@ -25,6 +26,20 @@ const (
 	maxTemplateOutputSize = 10 * 1024 * 1024 // 10 MB
 )
 
+// identityTemplateClaims lists the token claims exposed to templates via
+// {{.identity.claims.<name>}}. Only OIDC profile claims are exposed: claims that
+// bind sessions or carry credentials (e.g. tsid, nonce, at_hash) and
+// provider-specific claims must never be forwarded to backends in step
+// arguments.
+var identityTemplateClaims = []string{
+	"email",
+	"email_verified",
+	"name",
+	"preferred_username",
+	"given_name",
+	"family_name",
+}
+
 // defaultTemplateExpander implements TemplateExpander using Go's text/template.
 type defaultTemplateExpander struct {
 	// funcMap provides custom template functions.
@@ -153,12 +168,14 @@ func (e *defaultTemplateExpander) expandStringInternal(
 		return "", fmt.Errorf("context cancelled before template expansion: %w", err)
 	}
 
-	// Create template context with params, steps, vars, and workflow metadata
+	// Create template context with params, steps, vars, workflow metadata, and
+	// the caller's identity
 	tmplCtx := map[string]any{
 		"params":   workflowCtx.Params,
 		"steps":    e.buildStepsContext(workflowCtx),
 		"vars":     workflowCtx.Variables,
 		"workflow": e.buildWorkflowContext(workflowCtx),
+		"identity": buildIdentityContext(ctx),
 	}
 
 	// Merge extra context (e.g., forEach variables)
@@ -239,6 +256,43 @@ func (*defaultTemplateExpander) buildWorkflowContext(workflowCtx *WorkflowContex
 	}
 }
 
+// buildIdentityContext exposes the authenticated caller to templates via
+// {{.identity.subject}}, {{.identity.name}}, {{.identity.email}},
+// {{.identity.groups}} and {{.identity.claims.<name>}}. Tokens, metadata, and
+// claims outside identityTemplateClaims are never exposed, so a template cannot
+// forward credentials to a backend. Anonymous callers get empty values, so
+// templates referencing identity fields still expand.
+func buildIdentityContext(ctx context.Context) map[string]any {
+	identityCtx := map[string]any{
+		"subject": "",
+		"name":    "",
+		"email":   "",
+		"groups":  []string{},
+		"claims":  map[string]any{},
+	}
+
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || identity == nil {
+		return identityCtx
+	}
+
+	claims := make(map[string]any, len(identityTemplateClaims))
+	for _, name := range identityTemplateClaims {
+		if value, ok := identity.Claims[name]; ok {
+			claims[name] = value
+		}
+	}
+
+	identityCtx["subject"] = identity.Subject
+	identityCtx["name"] = identity.Name
+	identityCtx["email"] = identity.Email
+	if identity.Groups != nil {
+		identityCtx["groups"] = identity.Groups
+	}
+	identityCtx["claims"] = claims
+	return identityCtx
+}
+
 // EvaluateCondition evaluates a condition template to a boolean.
 // The condition string must evaluate to "true" or "false".
 func (e *defaultTemplateExpander) EvaluateCondition(
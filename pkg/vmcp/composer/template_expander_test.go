@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 )
 
@@ -439,6 +440,54 @@ func TestTemplateExpander_WorkflowMetadataEmpty(t *testing.T) {
 	assert.Equal(t, map[string]any{"id": "<no value>"}, result)
 }
 
+func TestTemplateExpander_Identity(t *testing.T) {
+	t.Parallel()
+
+	expander := NewTemplateExpander()
+	workflowCtx := newWorkflowContext(nil)
+	data := map[string]any{
+		"email":    "{{.identity.email}}",
+		"subject":  "{{.identity.subject}}",
+		"username": "{{.identity.claims.preferred_username}}",
+		"session":  "{{.identity.claims.tsid}}",
+		"token":    "{{.identity.Token}}",
+	}
+
+	t.Run("exposes profile fields and allow-listed claims only", func(t *testing.T) {
+		t.Parallel()
+		identity := &auth.Identity{
+			PrincipalInfo: auth.PrincipalInfo{
+				Subject: "user-123",
+				Email:   "alice@example.com",
+				Claims: map[string]any{
+					"preferred_username": "alice",
+					"tsid":               "session-secret",
+				},
+			},
+			Token: "bearer-secret",
+		}
+		ctx := auth.WithIdentity(context.Background(), identity)
+
+		result, err := expander.Expand(ctx, data, workflowCtx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"email":    "alice@example.com",
+			"subject":  "user-123",
+			"username": "alice",
+			"session":  "<no value>",
+			"token":    "<no value>",
+		}, result)
+	})
+
+	t.Run("anonymous caller expands to empty values", func(t *testing.T) {
+		t.Parallel()
+		result, err := expander.Expand(context.Background(),
+			map[string]any{"email": "{{.identity.email}}"}, workflowCtx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"email": ""}, result)
+	})
+}
+
 func TestTemplateExpander_FromJsonFunction(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package composer provides composite tool workflow execution for Virtual MCP Server.
+package composer
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/templates"
+	"github.com/stacklok/toolhive/pkg/vmcp/config"
+)
+
+// validateTemplateReferences checks that the templates of a workflow only
+// reference steps that exist and that run earlier. A step may only reference
+// the steps it depends on, directly or transitively, since any other step may
+// run concurrently or later; the output may reference any step.
+//
+// Template syntax is not checked here: it is validated when the configuration
+// loads, and a template that does not parse fails when it is expanded.
+func validateTemplateReferences(def *WorkflowDefinition) error {
+	stepIDs := make(map[string]bool, len(def.Steps))
+	dependsOn := make(map[string][]string, len(def.Steps))
+	for i := range def.Steps {
+		stepIDs[def.Steps[i].ID] = true
+		dependsOn[def.Steps[i].ID] = def.Steps[i].DependsOn
+	}
+
+	for i := range def.Steps {
+		step := &def.Steps[i]
+		earlier := transitiveDependencies(step.ID, dependsOn)
+		for _, ref := range stepTemplateReferences(step) {
+			switch {
+			case !stepIDs[ref]:
+				return NewValidationError("step.templates",
+					fmt.Sprintf("step %s references unknown step %s", step.ID, ref), nil)
+			case !earlier[ref]:
+				return NewValidationError("step.templates",
+					fmt.Sprintf("step %s references step %s, which it does not depend on", step.ID, ref), nil)
+			}
+		}
+	}
+
+	if def.Output != nil {
+		for _, ref := range outputTemplateReferences(def.Output.Properties) {
+			if !stepIDs[ref] {
+				return NewValidationError("output.properties.value",
+					fmt.Sprintf("output references unknown step %s", ref), nil)
+			}
+		}
+	}
+
+	return nil
+}
+
+// transitiveDependencies returns the steps that complete before stepID runs.
+func transitiveDependencies(stepID string, dependsOn map[string][]string) map[string]bool {
+	deps := make(map[string]bool)
+	pending := slices.Clone(dependsOn[stepID])
+	for len(pending) > 0 {
+		dep := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if deps[dep] {
+			continue
+		}
+		deps[dep] = true
+		pending = append(pending, dependsOn[dep]...)
+	}
+	return deps
+}
+
+// stepTemplateReferences returns the IDs of the steps referenced by the
+// templates of a step, including the inner step of a forEach step.
+func stepTemplateReferences(step *WorkflowStep) []string {
+	tmpls := []string{step.Condition, step.Collection}
+	tmpls = appendTemplateStrings(tmpls, step.Arguments)
+	if step.Elicitation != nil {
+		tmpls = append(tmpls, step.Elicitation.Message)
+	}
+	if step.InnerStep != nil {
+		tmpls = append(tmpls, step.InnerStep.Condition)
+		tmpls = appendTemplateStrings(tmpls, step.InnerStep.Arguments)
+	}
+	return stepReferences(tmpls)
+}
+
+// outputTemplateReferences returns the IDs of the steps referenced by the
+// templates of the output properties, including nested properties.
+func outputTemplateReferences(properties map[string]config.OutputProperty) []string {
+	var tmpls []string
+	var collect func(map[string]config.OutputProperty)
+	collect = func(props map[string]config.OutputProperty) {
+		for _, prop := range props {
+			tmpls = append(tmpls, prop.Value)
+			collect(prop.Properties)
+		}
+	}
+	collect(properties)
+	return stepReferences(tmpls)
+}
+
+// appendTemplateStrings appends the string values of a step's arguments,
+// descending into nested maps and slices.
+func appendTemplateStrings(tmpls []string, value any) []string {
+	switch v := value.(type) {
+	case string:
+		return append(tmpls, v)
+	case map[string]any:
+		for _, nested := range v {
+			tmpls = appendTemplateStrings(tmpls, nested)
+		}
+	case []any:
+		for _, nested := range v {
+			tmpls = appendTemplateStrings(tmpls, nested)
+		}
+	}
+	return tmpls
+}
+
+// stepReferences returns the sorted IDs of the steps referenced as
+// {{.steps.<id>...}} by the given templates.
+func stepReferences(tmpls []string) []string {
+	seen := make(map[string]bool)
+	for _, tmpl := range tmpls {
+		if !strings.Contains(tmpl, "{{") {
+			continue
+		}
+		refs, err := templates.ExtractReferences(tmpl)
+		if err != nil {
+			continue
+		}
+		for _, ref := range refs {
+			parts := strings.SplitN(ref, ".", 4)
+			if len(parts) >= 3 && parts[1] == "steps" {
+				seen[parts[2]] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
@@ -135,6 +135,16 @@ func toolStepWithDeps(id, tool string, args map[string]any, deps []string) Workf
 	return step
 }
 
+// compositeStep creates a step that invokes the named composite tool.
+func compositeStep(id, composite string, args map[string]any) WorkflowStep {
+	return WorkflowStep{
+		ID:        id,
+		Type:      StepTypeComposite,
+		Tool:      composite,
+		Arguments: args,
+	}
+}
+
 // simpleWorkflow creates a simple workflow for testing.
 func simpleWorkflow(name string, steps ...WorkflowStep) *WorkflowDefinition {
 	return &WorkflowDefinition{
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// ToolCallResult converts the outcome of ExecuteWorkflow into the result of
+// the composite tool call that ran it.
+//
+// A successful workflow returns its output, shaped by the workflow's
+// OutputConfig when one is set, both as structured content and as a JSON text
+// block for clients that only read text content. MCP requires structured
+// content to be an object, so a workflow without output yields an empty object.
+//
+// Failures are returned as MCP tool errors (IsError) rather than Go errors, so
+// they reach the client as tool results. A timed-out workflow also carries the
+// outputs of the steps that completed, keyed by step ID, so clients can salvage
+// the work.
+func ToolCallResult(result *WorkflowResult, err error) *vmcp.ToolCallResult {
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return timeoutToolCallResult(result)
+		}
+		return errorToolCallResult(fmt.Sprintf("Workflow execution failed: %v", err))
+	}
+	if result == nil {
+		return errorToolCallResult("Workflow executor returned nil result")
+	}
+	if result.Error != nil {
+		return errorToolCallResult(fmt.Sprintf("Workflow error: %v", result.Error))
+	}
+
+	output := result.Output
+	if output == nil {
+		output = map[string]any{}
+	}
+	jsonBytes, err := json.Marshal(output)
+	if err != nil {
+		return errorToolCallResult(fmt.Sprintf("failed to marshal output: %v", err))
+	}
+	return &vmcp.ToolCallResult{
+		Content:           []vmcp.Content{{Type: vmcp.ContentTypeText, Text: string(jsonBytes)}},
+		StructuredContent: output,
+	}
+}
+
+// timeoutToolCallResult builds the tool error result for a timed-out workflow.
+func timeoutToolCallResult(result *WorkflowResult) *vmcp.ToolCallResult {
+	const msg = "Workflow execution timeout exceeded"
+	if result == nil || len(result.PartialOutputs) == 0 {
+		return errorToolCallResult(msg)
+	}
+	jsonBytes, err := json.Marshal(result.PartialOutputs)
+	if err != nil {
+		return errorToolCallResult(msg)
+	}
+	return &vmcp.ToolCallResult{
+		Content: []vmcp.Content{{
+			Type: vmcp.ContentTypeText,
+			Text: fmt.Sprintf("%s; partial results from %d completed steps: %s", msg, len(result.PartialOutputs), jsonBytes),
+		}},
+		StructuredContent: result.PartialOutputs,
+		IsError:           true,
+	}
+}
+
+// errorToolCallResult builds a tool error result carrying msg as text content.
+func errorToolCallResult(msg string) *vmcp.ToolCallResult {
+	return &vmcp.ToolCallResult{
+		Content: []vmcp.Content{{Type: vmcp.ContentTypeText, Text: msg}},
+		IsError: true,
+	}
+}
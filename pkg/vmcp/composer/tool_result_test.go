@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+func TestToolCallResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success returns structured and text content", func(t *testing.T) {
+		t.Parallel()
+		output := map[string]any{"summary": "done", "count": float64(3)}
+
+		got := ToolCallResult(&WorkflowResult{Status: WorkflowStatusCompleted, Output: output}, nil)
+
+		assert.False(t, got.IsError)
+		assert.Equal(t, output, got.StructuredContent)
+		require.Len(t, got.Content, 1)
+		assert.Equal(t, vmcp.ContentTypeText, got.Content[0].Type)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(got.Content[0].Text), &decoded))
+		assert.Equal(t, output, decoded)
+	})
+
+	t.Run("missing output is an empty object", func(t *testing.T) {
+		t.Parallel()
+		got := ToolCallResult(&WorkflowResult{Status: WorkflowStatusCompleted}, nil)
+
+		assert.False(t, got.IsError)
+		assert.Equal(t, map[string]any{}, got.StructuredContent)
+		require.Len(t, got.Content, 1)
+		assert.Equal(t, "{}", got.Content[0].Text)
+	})
+
+	t.Run("timeout returns partial outputs as an error", func(t *testing.T) {
+		t.Parallel()
+		partial := map[string]any{"fetch": map[string]any{"body": "ok"}}
+		result := &WorkflowResult{Status: WorkflowStatusTimedOut, PartialOutputs: partial}
+
+		got := ToolCallResult(result, fmt.Errorf("workflow: %w", context.DeadlineExceeded))
+
+		assert.True(t, got.IsError)
+		assert.Equal(t, partial, got.StructuredContent)
+		require.Len(t, got.Content, 1)
+		assert.Contains(t, got.Content[0].Text, "Workflow execution timeout exceeded")
+		assert.Contains(t, got.Content[0].Text, `"fetch"`)
+	})
+
+	errorCases := []struct {
+		name    string
+		result  *WorkflowResult
+		err     error
+		wantMsg string
+	}{
+		{
+			name:    "timeout without completed steps",
+			err:     context.DeadlineExceeded,
+			wantMsg: "Workflow execution timeout exceeded",
+		},
+		{
+			name:    "execution error",
+			err:     ErrInvalidParameters,
+			wantMsg: "Workflow execution failed: invalid workflow parameters",
+		},
+		{
+			name:    "nil result",
+			wantMsg: "Workflow executor returned nil result",
+		},
+		{
+			name:    "workflow error",
+			result:  &WorkflowResult{Status: WorkflowStatusFailed, Error: errors.New("step fetch failed")},
+			wantMsg: "Workflow error: step fetch failed",
+		},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ToolCallResult(tc.result, tc.err)
+
+			assert.True(t, got.IsError)
+			assert.Nil(t, got.StructuredContent)
+			require.Len(t, got.Content, 1)
+			assert.Equal(t, vmcp.ContentTypeText, got.Content[0].Type)
+			assert.Contains(t, got.Content[0].Text, tc.wantMsg)
+		})
+	}
+}
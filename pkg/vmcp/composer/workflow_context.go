@@ -87,6 +87,17 @@ func (ctx *WorkflowContext) RecordStepStart(stepID string) {
 	}
 }
 
+// RecordStepInput records the expanded arguments a step was called with.
+// Thread-safe for concurrent step execution.
+func (ctx *WorkflowContext) RecordStepInput(stepID string, input map[string]any) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if result, exists := ctx.Steps[stepID]; exists {
+		result.Input = input
+	}
+}
+
 // RecordStepSuccess records a successful step completion.
 // Thread-safe for concurrent step execution.
 // The content parameter is optional (may be nil for non-tool steps like elicitation).
@@ -133,6 +144,46 @@ func (ctx *WorkflowContext) RecordStepSkipped(stepID string, defaultResults map[
 	}
 }
 
+// RecordStepCancelled records that a step was cancelled because the join of
+// its parallel branches was satisfied before it completed.
+// Thread-safe for concurrent step execution.
+func (ctx *WorkflowContext) RecordStepCancelled(stepID string) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	now := time.Now()
+	result, exists := ctx.Steps[stepID]
+	if !exists {
+		result = &StepResult{StepID: stepID, StartTime: now}
+		ctx.Steps[stepID] = result
+	}
+	result.Status = StepStatusCancelled
+	result.Error = nil
+	result.EndTime = now
+	result.Duration = now.Sub(result.StartTime)
+}
+
+// addPendingElicitation records that an elicitation is awaiting a user response.
+// Thread-safe for concurrent step execution.
+func (ctx *WorkflowContext) addPendingElicitation(pending *PendingElicitation) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if ctx.pendingElicitations == nil {
+		ctx.pendingElicitations = make(map[string]*PendingElicitation)
+	}
+	ctx.pendingElicitations[pending.StepID] = pending
+}
+
+// removePendingElicitation records that the elicitation of a step was resolved.
+// Thread-safe for concurrent step execution.
+func (ctx *WorkflowContext) removePendingElicitation(stepID string) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	delete(ctx.pendingElicitations, stepID)
+}
+
 // GetStepResult retrieves a step result by ID.
 // Thread-safe for concurrent step execution.
 func (ctx *WorkflowContext) GetStepResult(stepID string) (*StepResult, bool) {
@@ -183,6 +234,22 @@ func (ctx *WorkflowContext) GetLastStepOutput() map[string]any {
 	return lastOutput
 }
 
+// GetCompletedStepOutputs returns the outputs of all completed steps keyed by
+// step ID. Used to salvage partial results when a workflow does not finish.
+// Thread-safe for concurrent step execution.
+func (ctx *WorkflowContext) GetCompletedStepOutputs() map[string]any {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+
+	outputs := make(map[string]any)
+	for stepID, result := range ctx.Steps {
+		if result.Status == StepStatusCompleted {
+			outputs[stepID] = result.Output
+		}
+	}
+	return outputs
+}
+
 // Clone creates a shallow copy of the workflow context.
 // Maps and step results are cloned, but nested values within maps are shared.
 // This is useful for testing and validation.
@@ -219,6 +286,7 @@ func (ctx *WorkflowContext) Clone() *WorkflowContext {
 		clone.Steps[stepID] = &StepResult{
 			StepID:     result.StepID,
 			Status:     result.Status,
+			Input:      cloneMap(result.Input),
 			Output:     cloneMap(result.Output),
 			Content:    contentCopy,
 			Error:      result.Error,
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"slices"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
@@ -20,6 +21,7 @@ import (
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 	"github.com/stacklok/toolhive/pkg/vmcp/conversion"
+	"github.com/stacklok/toolhive/pkg/vmcp/health"
 	"github.com/stacklok/toolhive/pkg/vmcp/router"
 	"github.com/stacklok/toolhive/pkg/vmcp/schema"
 )
@@ -31,10 +33,6 @@ const (
 	// defaultStepTimeout is the default maximum execution time for individual steps.
 	defaultStepTimeout = 5 * time.Minute
 
-	// maxWorkflowSteps is the maximum number of steps allowed in a workflow.
-	// This prevents resource exhaustion from maliciously large workflows.
-	maxWorkflowSteps = 100
-
 	// maxRetryCount is the maximum number of retries allowed per step.
 	// This prevents infinite retry loops from malicious configurations.
 	maxRetryCount = 10
@@ -69,6 +67,35 @@ type workflowEngine struct {
 
 	// auditor provides audit logging for workflow execution (optional).
 	auditor *audit.WorkflowAuditor
+
+	// stepTelemetry records per-step spans and metrics (optional).
+	stepTelemetry *StepTelemetry
+
+	// stepEvents streams step events to subscribers (optional).
+	stepEvents *WorkflowEventBroker
+
+	// failureSink records failed workflow executions (optional).
+	failureSink FailureSink
+
+	// failureRedactFields are redacted from the records sent to failureSink.
+	failureRedactFields []string
+
+	// maxSteps bounds the steps in a definition and the steps an execution
+	// may run. This prevents resource exhaustion from maliciously large or
+	// recursive workflows.
+	maxSteps int
+
+	// maxDepth bounds the nesting depth of composite tool executions.
+	maxDepth int
+
+	// composites holds the composite tool definitions workflows may reference.
+	composites map[string]*WorkflowDefinition
+
+	// checkBackendHealth enables the pre-execution backend health check.
+	checkBackendHealth bool
+
+	// backendHealth provides live backend health for the check (optional).
+	backendHealth health.StatusProvider
 }
 
 // NewWorkflowEngine creates a new workflow execution engine.
@@ -80,6 +107,7 @@ type workflowEngine struct {
 // The stateStore parameter is optional. If nil, workflow status tracking and cancellation
 // will not be available. Use NewInMemoryStateStore() for basic state tracking.
 // The auditor parameter is optional. If nil, workflow execution will not be audited.
+// Optional behaviour such as step telemetry is configured through opts.
 func NewWorkflowEngine(
 	rtr router.Router,
 	backendClient vmcp.BackendClient,
@@ -87,8 +115,9 @@ func NewWorkflowEngine(
 	stateStore WorkflowStateStore,
 	auditor *audit.WorkflowAuditor,
 	tools []vmcp.Tool,
+	opts ...EngineOption,
 ) Composer {
-	return &workflowEngine{
+	e := &workflowEngine{
 		router:             rtr,
 		backendClient:      backendClient,
 		templateExpander:   NewTemplateExpander(),
@@ -98,7 +127,13 @@ func NewWorkflowEngine(
 		stateStore:         stateStore,
 		auditor:            auditor,
 		tools:              tools,
+		maxSteps:           defaultMaxWorkflowSteps,
+		maxDepth:           defaultMaxCompositionDepth,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // ExecuteWorkflow executes a composite tool workflow.
@@ -117,13 +152,33 @@ func (e *workflowEngine) ExecuteWorkflow(
 ) (*WorkflowResult, error) {
 	slog.Info("starting workflow execution", "workflow", def.Name)
 
+	// Enforce the composition-depth limit before doing any work. The returned
+	// context carries the step budget shared with nested composite executions.
+	ctx, err := enterWorkflow(ctx, e.maxDepth)
+	if err != nil {
+		slog.Error("workflow rejected", "workflow", def.Name, "error", err)
+		return nil, err
+	}
+
 	// Apply parameter defaults from JSON Schema before execution
 	paramsWithDefaults := applyParameterDefaults(def.Parameters, params)
+	if err := validateParameters(def.Parameters, paramsWithDefaults); err != nil {
+		slog.Error("workflow rejected", "workflow", def.Name, "error", err)
+		return nil, err
+	}
+	if err := e.checkBackendsAvailable(ctx, def); err != nil {
+		slog.Error("workflow rejected", "workflow", def.Name, "error", err)
+		return nil, err
+	}
 
 	// Create workflow context
 	workflowCtx := e.contextManager.CreateContext(paramsWithDefaults)
 	defer e.contextManager.DeleteContext(workflowCtx.WorkflowID)
 
+	// Make the workflow's step events available to subscribers until it ends.
+	e.stepEvents.begin(workflowCtx.WorkflowID)
+	defer e.stepEvents.end(workflowCtx.WorkflowID)
+
 	// Apply workflow timeout
 	timeout := def.Timeout
 	if timeout == 0 {
@@ -174,11 +229,23 @@ func (e *workflowEngine) ExecuteWorkflow(
 		}
 
 		// Execute step
-		return e.executeStep(ctx, step, workflowCtx, def.FailureMode)
+		err := e.executeStep(ctx, def.Name, step, workflowCtx, def.FailureMode)
+		if err == nil && joinsPartially(def.Join) && workflowCtx.HasStepFailed(step.ID) {
+			// A branch that failed but was allowed to continue must not
+			// satisfy an "any" or "n-of-m" join.
+			if result, ok := workflowCtx.GetStepResult(step.ID); ok {
+				return result.Error
+			}
+		}
+		return err
 	}
 
 	// Execute DAG
-	dagErr := e.dagExecutor.executeDAG(execCtx, def.Steps, stepExecutor, def.FailureMode)
+	joins, dagErr := e.dagExecutor.executeDAG(execCtx, def.Steps, stepExecutor, def.FailureMode, def.Join)
+	for _, stepID := range joins.cancelled {
+		workflowCtx.RecordStepCancelled(stepID)
+	}
+	result.JoinedBranches = joins.joined
 
 	// Copy step results to workflow result
 	// Acquire read lock to safely copy Steps map
@@ -193,12 +260,17 @@ func (e *workflowEngine) ExecuteWorkflow(
 		// Check if it was a timeout
 		if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
 			result.Status = WorkflowStatusTimedOut
-			result.Error = ErrWorkflowTimeout
+			result.Error = fmt.Errorf("%w: %w", ErrWorkflowTimeout, context.DeadlineExceeded)
+			// Return the outputs of the steps that finished so clients can
+			// salvage completed work. Steps interrupted by the deadline were
+			// recorded as failed by their error handling and are not included.
+			result.PartialOutputs = workflowCtx.GetCompletedStepOutputs()
 			result.EndTime = time.Now()
 			result.Duration = result.EndTime.Sub(result.StartTime)
 
 			// Audit workflow timeout
 			e.auditWorkflowTimeout(ctx, workflowCtx.WorkflowID, def.Name, result.Duration, len(result.Steps))
+			e.recordFailure(ctx, def, workflowCtx, result)
 
 			// Save timeout state
 			if e.stateStore != nil {
@@ -209,11 +281,14 @@ func (e *workflowEngine) ExecuteWorkflow(
 				// the final state for audit and status tracking purposes.
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
-				_ = e.stateStore.SaveState(ctx, workflowCtx.WorkflowID, finalState)
+				if err := e.stateStore.SaveState(ctx, workflowCtx.WorkflowID, finalState); err != nil {
+					slog.Warn("failed to save timed out workflow state", "error", err)
+				}
 			}
 
-			slog.Warn("workflow timed out", "workflow", def.Name, "duration", result.Duration)
-			return result, ErrWorkflowTimeout
+			slog.Warn("workflow timed out", "workflow", def.Name, "duration", result.Duration,
+				"completed_steps", len(result.PartialOutputs))
+			return result, result.Error
 		}
 
 		// Otherwise it's a failure
@@ -224,6 +299,7 @@ func (e *workflowEngine) ExecuteWorkflow(
 
 		// Audit workflow failure
 		e.auditWorkflowFailure(ctx, workflowCtx.WorkflowID, def.Name, result.Duration, len(result.Steps), dagErr)
+		e.recordFailure(ctx, def, workflowCtx, result)
 
 		// Save failure state
 		if e.stateStore != nil {
@@ -262,6 +338,7 @@ func (e *workflowEngine) ExecuteWorkflow(
 
 			// Audit workflow failure
 			e.auditWorkflowFailure(ctx, workflowCtx.WorkflowID, def.Name, result.Duration, len(result.Steps), result.Error)
+			e.recordFailure(ctx, def, workflowCtx, result)
 
 			// Save failure state
 			if e.stateStore != nil {
@@ -308,24 +385,40 @@ func (e *workflowEngine) ExecuteWorkflow(
 // executeStep executes a single workflow step.
 func (e *workflowEngine) executeStep(
 	ctx context.Context,
+	workflowName string,
 	step *WorkflowStep,
 	workflowCtx *WorkflowContext,
 	_ string, // failureMode is handled at workflow level
-) error {
+) (err error) {
 	slog.Debug("executing step", "step", step.ID, "type", step.Type)
 
 	// Record step start time for audit logging
 	stepStartTime := time.Now()
 
+	// Start the step span. Backend calls made by the step use ctx, so they
+	// become children of the step span.
+	ctx, span := e.stepTelemetry.startStep(ctx, workflowName, step)
+	defer func() {
+		result, _ := workflowCtx.GetStepResult(step.ID)
+		e.stepTelemetry.endStep(ctx, span, workflowName, step, result, time.Since(stepStartTime), err)
+	}()
+
 	// Record step start
 	workflowCtx.RecordStepStart(step.ID)
 
+	// Charge the step against the execution's step budget
+	if err := consumeStep(ctx, e.maxSteps); err != nil {
+		workflowCtx.RecordStepFailure(step.ID, err)
+		return err
+	}
+
 	// Audit step start
 	toolName := ""
-	if step.Type == StepTypeTool {
+	if step.Type == StepTypeTool || step.Type == StepTypeComposite {
 		toolName = step.Tool
 	}
 	e.auditStepStart(ctx, workflowCtx.WorkflowID, step.ID, string(step.Type), toolName)
+	e.publishStepEvent(workflowCtx, step, StepEventStart, nil, nil)
 
 	// Apply step timeout
 	timeout := step.Timeout
@@ -348,6 +441,7 @@ func (e *workflowEngine) executeStep(
 
 			// Audit step failure
 			e.auditStepFailure(ctx, workflowCtx.WorkflowID, step.ID, time.Since(stepStartTime), 0, condErr)
+			e.publishStepEvent(workflowCtx, step, StepEventError, nil, condErr)
 
 			return condErr
 		}
@@ -357,13 +451,13 @@ func (e *workflowEngine) executeStep(
 
 			// Audit step skipped
 			e.auditStepSkipped(ctx, workflowCtx.WorkflowID, step.ID, step.Condition)
+			e.publishStepEvent(workflowCtx, step, StepEventSkip, nil, nil)
 
 			return nil
 		}
 	}
 
 	// Execute based on step type
-	var err error
 	switch step.Type {
 	case StepTypeTool:
 		err = e.executeToolStep(stepCtx, step, workflowCtx)
@@ -371,12 +465,15 @@ func (e *workflowEngine) executeStep(
 		err = e.executeElicitationStep(stepCtx, step, workflowCtx)
 	case StepTypeForEach:
 		err = e.executeForEachStep(stepCtx, step, workflowCtx)
+	case StepTypeComposite:
+		err = e.executeCompositeStep(stepCtx, step, workflowCtx)
 	default:
 		err = fmt.Errorf("unsupported step type: %s", step.Type)
 		workflowCtx.RecordStepFailure(step.ID, err)
 
 		// Audit step failure
 		e.auditStepFailure(ctx, workflowCtx.WorkflowID, step.ID, time.Since(stepStartTime), 0, err)
+		e.publishStepEvent(workflowCtx, step, StepEventError, nil, err)
 
 		return err
 	}
@@ -384,14 +481,22 @@ func (e *workflowEngine) executeStep(
 	// Audit step completion or failure
 	duration := time.Since(stepStartTime)
 	retryCount := 0
-	if result, exists := workflowCtx.GetStepResult(step.ID); exists {
+	result, exists := workflowCtx.GetStepResult(step.ID)
+	if exists {
 		retryCount = result.RetryCount
 	}
 
 	if err != nil {
 		e.auditStepFailure(ctx, workflowCtx.WorkflowID, step.ID, duration, retryCount, err)
+		e.publishStepEvent(workflowCtx, step, StepEventError, nil, err)
 	} else {
 		e.auditStepCompletion(ctx, workflowCtx.WorkflowID, step.ID, duration, retryCount)
+		if exists && result.Status == StepStatusFailed {
+			// The step failed but its error handler let the workflow continue.
+			e.publishStepEvent(workflowCtx, step, StepEventError, result.Output, result.Error)
+		} else if exists {
+			e.publishStepEvent(workflowCtx, step, StepEventOutput, result.Output, nil)
+		}
 	}
 
 	return err
@@ -422,6 +527,8 @@ func (e *workflowEngine) executeToolStep(
 	if coerced, ok := s.TryCoerce(expandedArgs).(map[string]any); ok {
 		expandedArgs = coerced
 	}
+	workflowCtx.RecordStepInput(step.ID, expandedArgs)
+	e.publishStepEvent(workflowCtx, step, StepEventInput, expandedArgs, nil)
 
 	// Route tool to backend
 	target, err := e.router.RouteTool(ctx, step.Tool)
@@ -471,7 +578,7 @@ func (e *workflowEngine) callToolWithRetry(
 	operation := func() (*vmcp.ToolCallResult, error) {
 		attemptCount++
 		// TODO: For composite tools, we may want to propagate metadata from the parent request
-		result, err := e.backendClient.CallTool(ctx, target, step.Tool, args, nil)
+		result, err := e.callBackendTool(ctx, target, step.Tool, args)
 		if err != nil {
 			slog.Warn("tool call failed for step",
 				"step", step.ID, "attempt", attemptCount, "max_attempts", maxRetries+1, "error", err)
@@ -511,6 +618,34 @@ func (e *workflowEngine) callToolWithRetry(
 	return result, attemptCount - 1, err // Return retry count (attempts - 1)
 }
 
+// callBackendTool makes a single attempt at a step's tool call, bounded by the
+// target tool's CallTimeout when one is set, as for a direct client call. An
+// attempt cut off by that timeout fails with vmcp.ErrTimeout, so the step's
+// retry policy and failure handling apply to it; a deadline or cancellation of
+// the step itself is returned unchanged.
+func (e *workflowEngine) callBackendTool(
+	ctx context.Context,
+	target *vmcp.BackendTarget,
+	toolName string,
+	args map[string]any,
+) (*vmcp.ToolCallResult, error) {
+	if target.CallTimeout <= 0 {
+		return e.backendClient.CallTool(ctx, target, toolName, args, nil)
+	}
+
+	callCtx, cancel := context.WithTimeoutCause(ctx, target.CallTimeout, errToolCallTimeout)
+	defer cancel()
+	result, err := e.backendClient.CallTool(callCtx, target, toolName, args, nil)
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(callCtx), errToolCallTimeout) {
+		return nil, fmt.Errorf("%w: tool %s timed out after %s", vmcp.ErrTimeout, toolName, target.CallTimeout)
+	}
+	return result, err
+}
+
+// errToolCallTimeout is the cause recorded when a tool's CallTimeout expires,
+// distinguishing it from the step's own deadline.
+var errToolCallTimeout = errors.New("tool call timeout exceeded")
+
 // extractErrorMessage extracts a user-friendly error message from a failed tool call result.
 // It tries Content array first, then StructuredContent, then falls back to a generic message.
 func (*workflowEngine) extractErrorMessage(result *vmcp.ToolCallResult) string {
@@ -615,6 +750,63 @@ func (e *workflowEngine) handleToolStepSuccess(
 	return nil
 }
 
+// executeCompositeStep executes a composite step by running the referenced
+// composite tool's workflow with the step's expanded arguments as parameters.
+// The nested execution runs under ctx, so it counts against this execution's
+// composition depth and step budget. Nested steps handle their own retries, so
+// the step's retry policy does not apply. The nested run is traced as this
+// step; it is not a separate top-level execution for the caller's workflow
+// metrics.
+func (e *workflowEngine) executeCompositeStep(
+	ctx context.Context,
+	step *WorkflowStep,
+	workflowCtx *WorkflowContext,
+) error {
+	slog.Debug("executing composite step", "step", step.ID, "composite", step.Tool)
+
+	def, ok := e.composites[step.Tool]
+	if !ok {
+		err := fmt.Errorf("%w: composite tool %s in step %s", ErrWorkflowNotFound, step.Tool, step.ID)
+		workflowCtx.RecordStepFailure(step.ID, err)
+		return err
+	}
+
+	expandedArgs, err := e.templateExpander.Expand(ctx, step.Arguments, workflowCtx)
+	if err != nil {
+		expandErr := fmt.Errorf("%w: failed to expand arguments for step %s: %v",
+			ErrTemplateExpansion, step.ID, err)
+		workflowCtx.RecordStepFailure(step.ID, expandErr)
+		return expandErr
+	}
+
+	// Coerce expanded arguments to the types declared by the composite's
+	// parameter schema, as executeToolStep does for backend tools.
+	if coerced, ok := schema.MakeSchema(def.Parameters).TryCoerce(expandedArgs).(map[string]any); ok {
+		expandedArgs = coerced
+	}
+	workflowCtx.RecordStepInput(step.ID, expandedArgs)
+	e.publishStepEvent(workflowCtx, step, StepEventInput, expandedArgs, nil)
+
+	result, err := e.ExecuteWorkflow(ctx, def, expandedArgs)
+	if err != nil {
+		// Limit violations abort the whole execution regardless of OnError.
+		if isLimitExceeded(err) {
+			workflowCtx.RecordStepFailure(step.ID, err)
+			return err
+		}
+		// Keep the completed work of a timed-out nested run as the step's
+		// output. DefaultResults still take precedence on continue_on_error.
+		if result != nil && len(result.PartialOutputs) > 0 {
+			if stepResult, exists := workflowCtx.GetStepResult(step.ID); exists {
+				stepResult.Output = result.PartialOutputs
+			}
+		}
+		return e.handleToolStepFailure(step, workflowCtx, 0, err)
+	}
+
+	return e.handleToolStepSuccess(ctx, step, workflowCtx, result.Output, nil, 0)
+}
+
 // executeElicitationStep executes an elicitation step.
 // Per MCP 2025-06-18: SDK handles JSON-RPC ID correlation, we provide validation and error handling.
 func (e *workflowEngine) executeElicitationStep(
@@ -655,9 +847,34 @@ func (e *workflowEngine) executeElicitationStep(
 		}
 	}
 
+	// Report the workflow as waiting for the user until the elicitation is
+	// resolved, then checkpoint the resolution.
+	workflowCtx.addPendingElicitation(&PendingElicitation{
+		StepID:    step.ID,
+		Message:   elicitationCfg.Message,
+		Schema:    elicitationCfg.Schema,
+		ExpiresAt: time.Now().Add(elicitationTimeout(&elicitationCfg)),
+	})
+	e.checkpointWorkflowState(ctx, workflowCtx)
+
+	err := e.resolveElicitation(ctx, step, workflowCtx, &elicitationCfg)
+
+	workflowCtx.removePendingElicitation(step.ID)
+	e.checkpointWorkflowState(ctx, workflowCtx)
+	return err
+}
+
+// resolveElicitation requests the elicitation of a step from the user and
+// records the step result according to the response.
+func (e *workflowEngine) resolveElicitation(
+	ctx context.Context,
+	step *WorkflowStep,
+	workflowCtx *WorkflowContext,
+	elicitationCfg *ElicitationConfig,
+) error {
 	// Request elicitation (synchronous - blocks until response or timeout)
 	// Per MCP 2025-06-18: SDK handles JSON-RPC ID correlation internally
-	response, err := e.elicitationHandler.RequestElicitation(ctx, workflowCtx.WorkflowID, step.ID, &elicitationCfg)
+	response, err := e.elicitationHandler.RequestElicitation(ctx, workflowCtx.WorkflowID, step.ID, elicitationCfg)
 	if err != nil {
 		// Handle timeout
 		if errors.Is(err, ErrElicitationTimeout) {
@@ -1006,6 +1223,17 @@ func (e *workflowEngine) handleElicitationTimeout(
 ) error {
 	slog.Warn("elicitation timed out for step", "step", step.ID)
 
+	// A default response lets the workflow proceed without the user.
+	if step.Elicitation != nil && step.Elicitation.TimeoutDefault != nil {
+		slog.Debug("using default response for timed out elicitation", "step", step.ID)
+		output := map[string]any{
+			"action":  "timeout",
+			"content": maps.Clone(step.Elicitation.TimeoutDefault),
+		}
+		workflowCtx.RecordStepSuccess(step.ID, output, nil)
+		return nil
+	}
+
 	// Timeout is treated as cancel by default
 	if step.Elicitation != nil && step.Elicitation.OnCancel != nil {
 		return e.handleElicitationAction(step, workflowCtx, step.Elicitation.OnCancel.Action, "timeout")
@@ -1078,12 +1306,22 @@ func (*workflowEngine) buildWorkflowStatus(workflowCtx *WorkflowContext, status
 		}
 	}
 
+	// A running workflow is waiting for the user while any of its
+	// elicitations is unresolved.
+	pending := make([]*PendingElicitation, 0, len(workflowCtx.pendingElicitations))
+	for _, stepID := range slices.Sorted(maps.Keys(workflowCtx.pendingElicitations)) {
+		pending = append(pending, workflowCtx.pendingElicitations[stepID])
+	}
+	if status == WorkflowStatusRunning && len(pending) > 0 {
+		status = WorkflowStatusWaitingForElicitation
+	}
+
 	return &WorkflowStatus{
 		WorkflowID:          workflowCtx.WorkflowID,
 		Status:              status,
 		CurrentStep:         "",
 		CompletedSteps:      completedSteps,
-		PendingElicitations: []*PendingElicitation{},
+		PendingElicitations: pending,
 		StartTime:           time.Now(),
 		LastUpdateTime:      time.Now(),
 	}
@@ -1124,10 +1362,10 @@ func (e *workflowEngine) ValidateWorkflow(_ context.Context, def *WorkflowDefini
 	}
 
 	// Enforce maximum steps limit to prevent resource exhaustion
-	if len(def.Steps) > maxWorkflowSteps {
+	if len(def.Steps) > e.maxSteps {
 		return NewValidationError("steps",
-			fmt.Sprintf("too many steps: %d (max %d)", len(def.Steps), maxWorkflowSteps),
-			nil)
+			fmt.Sprintf("too many steps: %d (max %d)", len(def.Steps), e.maxSteps),
+			ErrMaxStepsExceeded)
 	}
 
 	// Check for duplicate step IDs
@@ -1148,6 +1386,11 @@ func (e *workflowEngine) ValidateWorkflow(_ context.Context, def *WorkflowDefini
 		return err
 	}
 
+	// Validate the parallel join strategy
+	if err := e.validateJoin(def.Join, def.Steps); err != nil {
+		return err
+	}
+
 	// Validate step types and configurations
 	for _, step := range def.Steps {
 		if err := e.validateStep(&step, stepIDs); err != nil {
@@ -1155,6 +1398,16 @@ func (e *workflowEngine) ValidateWorkflow(_ context.Context, def *WorkflowDefini
 		}
 	}
 
+	// Check that templates only reference declared, earlier steps
+	if err := validateTemplateReferences(def); err != nil {
+		return err
+	}
+
+	// Detect direct and indirect composite recursion
+	if err := e.validateCompositeReferences(def); err != nil {
+		return err
+	}
+
 	// Validate output configuration if present
 	if def.Output != nil {
 		if err := ValidateOutputConfig(def.Output); err != nil {
@@ -1165,6 +1418,41 @@ func (e *workflowEngine) ValidateWorkflow(_ context.Context, def *WorkflowDefini
 	return nil
 }
 
+// joinsPartially reports whether join completes a level before all of its
+// branches succeed.
+func joinsPartially(join *JoinConfig) bool {
+	return join != nil && (join.Strategy == JoinStrategyAny || join.Strategy == JoinStrategyNOfM)
+}
+
+// validateJoin checks that a join strategy is known and that n-of-m requires
+// between one branch and the branches of the widest execution level.
+func (e *workflowEngine) validateJoin(join *JoinConfig, steps []WorkflowStep) error {
+	if join == nil {
+		return nil
+	}
+	switch join.Strategy {
+	case "", JoinStrategyAll, JoinStrategyAny:
+		return nil
+	case JoinStrategyNOfM:
+		levels, err := e.dagExecutor.buildExecutionLevels(steps)
+		if err != nil {
+			return err
+		}
+		branches := 0
+		for _, level := range levels {
+			branches = max(branches, len(level.steps))
+		}
+		if join.N < 1 || join.N > branches {
+			return NewValidationError("join.n",
+				fmt.Sprintf("n-of-m join requires 1 <= n <= %d (the most parallel branches of any level), got %d",
+					branches, join.N), nil)
+		}
+		return nil
+	}
+	return NewValidationError("join.strategy",
+		fmt.Sprintf("unknown join strategy %q (valid: all, any, n-of-m)", join.Strategy), nil)
+}
+
 // validateDependencies checks for circular dependencies using DFS.
 func (*workflowEngine) validateDependencies(steps []WorkflowStep) error {
 	// Build adjacency list
@@ -1223,7 +1511,7 @@ func (*workflowEngine) validateDependencies(steps []WorkflowStep) error {
 }
 
 // validateStep validates a single step configuration.
-func (*workflowEngine) validateStep(step *WorkflowStep, validStepIDs map[string]bool) error {
+func (e *workflowEngine) validateStep(step *WorkflowStep, validStepIDs map[string]bool) error {
 	// Validate step type
 	switch step.Type {
 	case StepTypeTool:
@@ -1243,6 +1531,17 @@ func (*workflowEngine) validateStep(step *WorkflowStep, validStepIDs map[string]
 				fmt.Sprintf("elicitation message is required for step %s", step.ID),
 				nil)
 		}
+	case StepTypeComposite:
+		if step.Tool == "" {
+			return NewValidationError("step.tool",
+				fmt.Sprintf("composite tool name is required for composite step %s", step.ID),
+				nil)
+		}
+		if _, ok := e.composites[step.Tool]; !ok {
+			return NewValidationError("step.tool",
+				fmt.Sprintf("composite step %s references unknown composite tool %s", step.ID, step.Tool),
+				ErrWorkflowNotFound)
+		}
 	case StepTypeForEach:
 		if step.Collection == "" {
 			return NewValidationError("step.collection",
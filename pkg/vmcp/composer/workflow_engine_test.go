@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/config"
 	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
@@ -249,6 +250,13 @@ func TestWorkflowEngine_ValidateWorkflow(t *testing.T) {
 			toolStepWithDeps("s2", "t2", nil, []string{"s1"})), "circular dependency"},
 		{"invalid dep", simpleWorkflow("test", toolStepWithDeps("s1", "t1", nil, []string{"unknown"})), "non-existent"},
 		{"too many steps", &WorkflowDefinition{Name: "test", Steps: make([]WorkflowStep, 101)}, "too many steps"},
+		{"unknown join strategy", &WorkflowDefinition{Name: "test", Steps: []WorkflowStep{toolStep("s1", "t1", nil)},
+			Join: &JoinConfig{Strategy: "most"}}, "unknown join strategy"},
+		{"n-of-m without n", &WorkflowDefinition{Name: "test", Steps: []WorkflowStep{toolStep("s1", "t1", nil)},
+			Join: &JoinConfig{Strategy: JoinStrategyNOfM}}, "requires 1 <= n <= 1"},
+		{"n-of-m with n above branches", &WorkflowDefinition{Name: "test",
+			Steps: []WorkflowStep{toolStep("s1", "t1", nil), toolStep("s2", "t2", nil)},
+			Join:  &JoinConfig{Strategy: JoinStrategyNOfM, N: 3}}, "requires 1 <= n <= 2"},
 	}
 
 	te := newTestEngine(t)
@@ -304,6 +312,79 @@ func TestWorkflowEngine_ExecuteWorkflow_Timeout(t *testing.T) {
 	assert.Equal(t, WorkflowStatusTimedOut, result.Status)
 }
 
+func TestWorkflowEngine_ExecuteWorkflow_TimeoutReturnsPartialResults(t *testing.T) {
+	t.Parallel()
+	te := newTestEngine(t)
+
+	def := &WorkflowDefinition{
+		Name:    "partial-timeout",
+		Timeout: 50 * time.Millisecond,
+		Steps: []WorkflowStep{
+			toolStep("fetch", "fast.tool", map[string]any{}),
+			toolStepWithDeps("store", "slow.tool", map[string]any{}, []string{"fetch"}),
+		},
+	}
+
+	te.expectToolCall("fast.tool", map[string]any{}, map[string]any{"data": "x"})
+	target := &vmcp.BackendTarget{WorkloadID: "test", BaseURL: "http://test:8080"}
+	te.Router.EXPECT().RouteTool(gomock.Any(), "slow.tool").Return(target, nil)
+	te.Backend.EXPECT().CallTool(gomock.Any(), target, "slow.tool", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, _ string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	result, err := execute(t, te.Engine, def, nil)
+
+	require.ErrorIs(t, err, ErrWorkflowTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, result)
+	assert.Equal(t, WorkflowStatusTimedOut, result.Status)
+	assert.Equal(t, map[string]any{"fetch": map[string]any{"data": "x"}}, result.PartialOutputs)
+	assert.Nil(t, result.Output)
+	assert.Equal(t, StepStatusCompleted, result.Steps["fetch"].Status)
+	assert.Equal(t, StepStatusFailed, result.Steps["store"].Status)
+}
+
+func TestWorkflowEngine_ExecuteWorkflow_ToolCallTimeout(t *testing.T) {
+	t.Parallel()
+	te := newTestEngine(t)
+
+	def := simpleWorkflow("call-timeout", toolStep("slow", "slow.tool", map[string]any{}))
+
+	target := &vmcp.BackendTarget{WorkloadID: "test", BaseURL: "http://test:8080", CallTimeout: 20 * time.Millisecond}
+	te.Router.EXPECT().RouteTool(gomock.Any(), "slow.tool").Return(target, nil)
+	te.Backend.EXPECT().CallTool(gomock.Any(), target, "slow.tool", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, _ string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	result, err := execute(t, te.Engine, def, nil)
+
+	require.ErrorIs(t, err, vmcp.ErrTimeout, "the tool's CallTimeout bounds the step's call")
+	assert.NotErrorIs(t, err, ErrWorkflowTimeout)
+	require.NotNil(t, result)
+	assert.Equal(t, StepStatusFailed, result.Steps["slow"].Status)
+}
+
+func TestWorkflowEngine_ExecuteWorkflow_IdentityInStepArguments(t *testing.T) {
+	t.Parallel()
+	te := newTestEngine(t)
+
+	def := simpleWorkflow("assign",
+		toolStep("assign", "tracker.assign", map[string]any{"assignee": "{{.identity.email}}"}),
+	)
+	te.expectToolCall("tracker.assign", map[string]any{"assignee": "alice@example.com"}, map[string]any{"ok": true})
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{
+		PrincipalInfo: auth.PrincipalInfo{Subject: "user-123", Email: "alice@example.com"},
+	})
+	result, err := te.Engine.ExecuteWorkflow(ctx, def, nil)
+	require.NoError(t, err)
+	assert.Equal(t, WorkflowStatusCompleted, result.Status)
+}
+
 func TestWorkflowEngine_ExecuteWorkflow_ParameterDefaults(t *testing.T) {
 	t.Parallel()
 	te := newTestEngine(t)
@@ -548,6 +629,50 @@ func TestWorkflowEngine_ParallelExecution(t *testing.T) {
 		endSeq["fetch_metrics"], startSeq["create_report"])
 }
 
+func TestWorkflowEngine_ParallelJoinAny(t *testing.T) {
+	t.Parallel()
+
+	te := newTestEngine(t)
+	workflow := &WorkflowDefinition{
+		Name: "race-mirrors",
+		Join: &JoinConfig{Strategy: JoinStrategyAny},
+		Steps: []WorkflowStep{
+			toolStep("primary", "mirror.primary", nil),
+			toolStep("secondary", "mirror.secondary", nil),
+			toolStepWithDeps("use", "report.create",
+				map[string]any{"data": "{{.steps.primary.output.data}}"}, []string{"primary", "secondary"}),
+		},
+	}
+
+	// The primary wins once the secondary is in flight; the secondary blocks
+	// until the join cancels it.
+	secondaryStarted := make(chan struct{})
+	target := &vmcp.BackendTarget{WorkloadID: "test-backend", BaseURL: "http://test:8080"}
+	te.Router.EXPECT().RouteTool(gomock.Any(), "mirror.primary").Return(target, nil)
+	te.Backend.EXPECT().CallTool(gomock.Any(), target, "mirror.primary", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *vmcp.BackendTarget, string, map[string]any, map[string]any) (*vmcp.ToolCallResult, error) {
+			<-secondaryStarted
+			return &vmcp.ToolCallResult{StructuredContent: map[string]any{"data": "fresh"}}, nil
+		})
+	te.Router.EXPECT().RouteTool(gomock.Any(), "mirror.secondary").Return(target, nil)
+	te.Backend.EXPECT().CallTool(gomock.Any(), target, "mirror.secondary", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, _ string, _ map[string]any, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			close(secondaryStarted)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	te.expectToolCall("report.create", map[string]any{"data": "fresh"}, map[string]any{"ok": true})
+
+	result, err := te.Engine.ExecuteWorkflow(context.Background(), workflow, nil)
+	require.NoError(t, err)
+	assert.Equal(t, WorkflowStatusCompleted, result.Status)
+	assert.Equal(t, []string{"primary"}, result.JoinedBranches)
+	assert.Equal(t, StepStatusCompleted, result.Steps["primary"].Status)
+	assert.Equal(t, StepStatusCancelled, result.Steps["secondary"].Status)
+	assert.NoError(t, result.Steps["secondary"].Error)
+	assert.Equal(t, StepStatusCompleted, result.Steps["use"].Status)
+}
+
 func TestWorkflowEngine_ExecuteWorkflow_WithWorkflowMetadata(t *testing.T) {
 	t.Parallel()
 
@@ -35,10 +35,64 @@ var (
 	// ErrDependencyNotMet indicates a step dependency hasn't completed.
 	ErrDependencyNotMet = errors.New("dependency not met")
 
+	// ErrJoinNotSatisfied indicates too few parallel branches succeeded to
+	// satisfy the workflow's join strategy.
+	ErrJoinNotSatisfied = errors.New("parallel join not satisfied")
+
 	// ErrToolCallFailed indicates a tool call failed.
 	ErrToolCallFailed = errors.New("tool call failed")
+
+	// ErrMaxStepsExceeded indicates a workflow exceeded its step limit.
+	ErrMaxStepsExceeded = errors.New("maximum workflow steps exceeded")
+
+	// ErrMaxDepthExceeded indicates composite tools nested beyond the depth limit.
+	ErrMaxDepthExceeded = errors.New("maximum composition depth exceeded")
+
+	// ErrRecursiveComposite indicates a composite tool that invokes itself,
+	// directly or through other composite tools.
+	ErrRecursiveComposite = errors.New("recursive composite tool reference")
+
+	// ErrInvalidParameters indicates workflow parameters do not match the
+	// composite tool's declared parameter schema.
+	ErrInvalidParameters = errors.New("invalid workflow parameters")
+
+	// ErrBackendUnavailable indicates a backend referenced by the workflow is
+	// not available, so the workflow was not started.
+	ErrBackendUnavailable = errors.New("backend unavailable")
+
+	// ErrIdempotencyKeyReused indicates an idempotency key was reused for a
+	// composite tool call with different arguments.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different arguments")
+
+	// ErrOutputSchemaMismatch indicates the constructed workflow output does
+	// not match the composite tool's declared output schema.
+	ErrOutputSchemaMismatch = errors.New("workflow output does not match its schema")
 )
 
+// OutputSchemaError reports an output property that could not be constructed
+// as declared: a template referencing a value the workflow did not produce,
+// a value of the wrong type, or a missing required field.
+type OutputSchemaError struct {
+	// Property is the dotted path of the offending output property.
+	Property string
+
+	// Type is the declared JSON Schema type of the property.
+	Type string
+
+	// Message describes the mismatch.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *OutputSchemaError) Error() string {
+	return fmt.Sprintf("output property %q: %s", e.Property, e.Message)
+}
+
+// Unwrap returns ErrOutputSchemaMismatch.
+func (*OutputSchemaError) Unwrap() error {
+	return ErrOutputSchemaMismatch
+}
+
 // ValidationError wraps workflow validation errors.
 type ValidationError struct {
 	// Field is the field that failed validation.
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/audit"
+)
+
+const (
+	// stepEventHistorySize is the number of past events kept per running
+	// workflow and replayed to new subscribers, so a subscriber that joins
+	// mid-workflow sees the steps that already ran.
+	stepEventHistorySize = 256
+
+	// stepEventBufferSize is the number of live events buffered per subscriber.
+	// Events published while a subscriber's buffer is full are dropped for that
+	// subscriber, so a slow reader never stalls workflow execution.
+	stepEventBufferSize = 256
+)
+
+// ErrWorkflowNotRunning indicates that no running workflow has the requested ID.
+var ErrWorkflowNotRunning = errors.New("workflow not running")
+
+// StepEventType identifies what happened to a workflow step.
+type StepEventType string
+
+const (
+	// StepEventStart is published when a step starts executing.
+	StepEventStart StepEventType = "start"
+
+	// StepEventInput is published with the expanded arguments of a tool or
+	// composite step, just before they are sent.
+	StepEventInput StepEventType = "input"
+
+	// StepEventOutput is published with the output of a step that completed.
+	StepEventOutput StepEventType = "output"
+
+	// StepEventError is published when a step fails.
+	StepEventError StepEventType = "error"
+
+	// StepEventSkip is published when a step is skipped because its condition
+	// evaluated to false.
+	StepEventSkip StepEventType = "skip"
+)
+
+// StepEvent describes a single step transition of a running workflow.
+type StepEvent struct {
+	// WorkflowID identifies the workflow execution.
+	WorkflowID string `json:"workflowId"`
+
+	// StepID identifies the step within the workflow.
+	StepID string `json:"stepId"`
+
+	// Type is what happened to the step.
+	Type StepEventType `json:"type"`
+
+	// StepType is the type of the step (tool, elicitation, forEach, composite).
+	StepType StepType `json:"stepType,omitempty"`
+
+	// Tool is the tool or composite tool the step calls, if any.
+	Tool string `json:"tool,omitempty"`
+
+	// Data holds the step arguments for input events and the step output for
+	// output events, with redacted fields replaced by audit.RedactedValue.
+	Data map[string]any `json:"data,omitempty"`
+
+	// Error is the error message of error events.
+	Error string `json:"error,omitempty"`
+
+	// Time is when the event occurred.
+	Time time.Time `json:"time"`
+}
+
+// WorkflowEventBroker fans out the step events of running workflows to
+// subscribers, so operators can tail a workflow while it executes. Engines
+// publish to it when configured with WithStepEvents; share one broker across
+// all engines of a server.
+//
+// A nil *WorkflowEventBroker is valid and publishes nothing.
+type WorkflowEventBroker struct {
+	redactFields []string
+
+	mu sync.Mutex
+	// workflows holds the events and subscribers of every running workflow,
+	// keyed by workflow ID.
+	workflows map[string]*workflowEvents
+}
+
+// workflowEvents holds the event history and subscribers of a running workflow.
+type workflowEvents struct {
+	history     []StepEvent
+	subscribers []chan StepEvent
+}
+
+// NewWorkflowEventBroker creates a broker. The values of redactFields are
+// replaced in the data of every event, with the same matching rules as
+// audit.Config.RedactFields, so sensitive step inputs are never streamed.
+func NewWorkflowEventBroker(redactFields []string) *WorkflowEventBroker {
+	return &WorkflowEventBroker{
+		redactFields: slices.Clone(redactFields),
+		workflows:    make(map[string]*workflowEvents),
+	}
+}
+
+// WithStepEvents makes the engine publish the step events of the workflows it
+// executes to broker. A nil broker disables step events.
+func WithStepEvents(broker *WorkflowEventBroker) EngineOption {
+	return func(e *workflowEngine) {
+		e.stepEvents = broker
+	}
+}
+
+// Subscribe returns a channel receiving the step events of the running
+// workflow workflowID, in the order they are published, and a function that
+// cancels the subscription. The events published before the subscription are
+// replayed first. The channel is closed when the workflow finishes
+// or the subscription is cancelled. Subscribe returns ErrWorkflowNotRunning
+// when no running workflow has that ID.
+func (b *WorkflowEventBroker) Subscribe(workflowID string) (<-chan StepEvent, func(), error) {
+	if b == nil {
+		return nil, nil, ErrWorkflowNotRunning
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events, ok := b.workflows[workflowID]
+	if !ok {
+		return nil, nil, ErrWorkflowNotRunning
+	}
+	ch := make(chan StepEvent, stepEventHistorySize+stepEventBufferSize)
+	for _, event := range events.history {
+		ch <- event
+	}
+	events.subscribers = append(events.subscribers, ch)
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// The workflow may have ended, and closed ch, since Subscribe.
+		if events, ok := b.workflows[workflowID]; ok {
+			if i := slices.Index(events.subscribers, ch); i >= 0 {
+				events.subscribers = slices.Delete(events.subscribers, i, i+1)
+				close(ch)
+			}
+		}
+	}
+	return ch, cancel, nil
+}
+
+// RunningWorkflows returns the IDs of the running workflows, sorted.
+func (b *WorkflowEventBroker) RunningWorkflows() []string {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ids := make([]string, 0, len(b.workflows))
+	for id := range b.workflows {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// begin registers a workflow as running so it can be subscribed to.
+func (b *WorkflowEventBroker) begin(workflowID string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.workflows[workflowID] = &workflowEvents{}
+}
+
+// end unregisters a finished workflow and closes its subscribers' channels.
+func (b *WorkflowEventBroker) end(workflowID string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if events, ok := b.workflows[workflowID]; ok {
+		for _, ch := range events.subscribers {
+			close(ch)
+		}
+	}
+	delete(b.workflows, workflowID)
+}
+
+// publish records event in the history of its workflow and delivers it to the
+// workflow's subscribers, redacting its data first. Events are dropped for
+// subscribers whose buffer is full.
+func (b *WorkflowEventBroker) publish(event StepEvent) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events, ok := b.workflows[event.WorkflowID]
+	if !ok {
+		return
+	}
+	if event.Data != nil {
+		if redacted, ok := audit.Redact(event.Data, b.redactFields).(map[string]any); ok {
+			event.Data = redacted
+		}
+	}
+	event.Time = time.Now()
+	if len(events.history) < stepEventHistorySize {
+		events.history = append(events.history, event)
+	}
+	for _, ch := range events.subscribers {
+		select {
+		case ch <- event:
+		default:
+			slog.Debug("dropping workflow step event for slow subscriber",
+				"workflow", event.WorkflowID, "step", event.StepID, "type", event.Type)
+		}
+	}
+}
+
+// publishStepEvent publishes an event of the given type for step.
+func (e *workflowEngine) publishStepEvent(
+	workflowCtx *WorkflowContext,
+	step *WorkflowStep,
+	eventType StepEventType,
+	data map[string]any,
+	err error,
+) {
+	if e.stepEvents == nil {
+		return
+	}
+
+	event := StepEvent{
+		WorkflowID: workflowCtx.WorkflowID,
+		StepID:     step.ID,
+		Type:       eventType,
+		StepType:   step.Type,
+		Data:       data,
+	}
+	if step.Type == StepTypeTool || step.Type == StepTypeComposite {
+		event.Tool = step.Tool
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	e.stepEvents.publish(event)
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/audit"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+	routermocks "github.com/stacklok/toolhive/pkg/vmcp/router/mocks"
+)
+
+func TestWorkflowEngine_StepEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRouter := routermocks.NewMockRouter(ctrl)
+	mockRouter.EXPECT().ResolveToolName(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, name string) string { return name }).
+		AnyTimes()
+	mockBackend := mocks.NewMockBackendClient(ctrl)
+
+	broker := NewWorkflowEventBroker([]string{"token"})
+	var events <-chan StepEvent
+
+	target := &vmcp.BackendTarget{WorkloadID: "test-backend", BaseURL: "http://test:8080"}
+	mockRouter.EXPECT().RouteTool(gomock.Any(), gomock.Any()).Return(target, nil).Times(2)
+	// Subscribe while the first step is in flight, as an operator tailing a
+	// running workflow would. The events published so far are replayed.
+	mockBackend.EXPECT().CallTool(gomock.Any(), target, "fetch", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *vmcp.BackendTarget, string, map[string]any, map[string]any) (*vmcp.ToolCallResult, error) {
+			running := broker.RunningWorkflows()
+			require.Len(t, running, 1)
+			ch, cancel, err := broker.Subscribe(running[0])
+			require.NoError(t, err)
+			t.Cleanup(cancel)
+			events = ch
+			return &vmcp.ToolCallResult{StructuredContent: map[string]any{"data": "x"}}, nil
+		})
+	mockBackend.EXPECT().CallTool(gomock.Any(), target, "store", gomock.Any(), gomock.Any()).
+		Return(&vmcp.ToolCallResult{StructuredContent: map[string]any{"ok": true}}, nil)
+
+	engine := NewWorkflowEngine(mockRouter, mockBackend, nil, nil, nil, nil, WithStepEvents(broker))
+
+	def := simpleWorkflow("pipeline",
+		toolStep("fetch", "fetch", map[string]any{"url": "https://example.com", "token": "secret"}),
+		toolStepWithDeps("store", "store", map[string]any{"data": "{{.steps.fetch.output.data}}"}, []string{"fetch"}),
+	)
+	result, err := engine.ExecuteWorkflow(context.Background(), def, nil)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+
+	// The channel is closed when the workflow ends.
+	var got []StepEvent
+	for event := range events {
+		got = append(got, event)
+	}
+
+	type summary struct {
+		Step string
+		Type StepEventType
+		Data map[string]any
+	}
+	var summaries []summary
+	for _, event := range got {
+		assert.Equal(t, result.WorkflowID, event.WorkflowID)
+		assert.Equal(t, StepTypeTool, event.StepType)
+		assert.False(t, event.Time.IsZero())
+		summaries = append(summaries, summary{Step: event.StepID, Type: event.Type, Data: event.Data})
+	}
+	assert.Equal(t, []summary{
+		{Step: "fetch", Type: StepEventStart},
+		{Step: "fetch", Type: StepEventInput, Data: map[string]any{"url": "https://example.com", "token": audit.RedactedValue}},
+		{Step: "fetch", Type: StepEventOutput, Data: map[string]any{"data": "x"}},
+		{Step: "store", Type: StepEventStart},
+		{Step: "store", Type: StepEventInput, Data: map[string]any{"data": "x"}},
+		{Step: "store", Type: StepEventOutput, Data: map[string]any{"ok": true}},
+	}, summaries)
+
+	assert.Empty(t, broker.RunningWorkflows())
+	_, _, err = broker.Subscribe(result.WorkflowID)
+	assert.ErrorIs(t, err, ErrWorkflowNotRunning)
+}
+
+func TestWorkflowEngine_StepEventsSkipAndError(t *testing.T) {
+	t.Parallel()
+
+	te := newTestEngine(t)
+	broker := NewWorkflowEventBroker(nil)
+	engine := NewWorkflowEngine(te.Router, te.Backend, nil, nil, nil, nil, WithStepEvents(broker))
+
+	var events <-chan StepEvent
+	target := &vmcp.BackendTarget{WorkloadID: "test-backend", BaseURL: "http://test:8080"}
+	te.Router.EXPECT().RouteTool(gomock.Any(), "fail").Return(target, nil)
+	te.Backend.EXPECT().CallTool(gomock.Any(), target, "fail", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *vmcp.BackendTarget, string, map[string]any, map[string]any) (*vmcp.ToolCallResult, error) {
+			ch, cancel, err := broker.Subscribe(broker.RunningWorkflows()[0])
+			require.NoError(t, err)
+			t.Cleanup(cancel)
+			events = ch
+			return nil, assert.AnError
+		})
+
+	skipped := toolStep("skipped", "never", map[string]any{})
+	skipped.Condition = "false"
+	def := simpleWorkflow("failing", skipped, toolStepWithDeps("fail", "fail", map[string]any{}, []string{"skipped"}))
+
+	_, err := engine.ExecuteWorkflow(context.Background(), def, nil)
+	require.Error(t, err)
+	require.NotNil(t, events)
+
+	var types []StepEventType
+	for event := range events {
+		types = append(types, event.Type)
+		if event.Type == StepEventError {
+			assert.Equal(t, "fail", event.StepID)
+			assert.NotEmpty(t, event.Error)
+		}
+	}
+	assert.Equal(t, []StepEventType{
+		StepEventStart, StepEventSkip, StepEventStart, StepEventInput, StepEventError,
+	}, types)
+}
+
+func TestWorkflowEventBroker_NilAndCancel(t *testing.T) {
+	t.Parallel()
+
+	var nilBroker *WorkflowEventBroker
+	nilBroker.begin("wf")
+	nilBroker.publish(StepEvent{WorkflowID: "wf"})
+	nilBroker.end("wf")
+	assert.Nil(t, nilBroker.RunningWorkflows())
+	_, _, err := nilBroker.Subscribe("wf")
+	assert.ErrorIs(t, err, ErrWorkflowNotRunning)
+
+	broker := NewWorkflowEventBroker(nil)
+	broker.begin("wf")
+	ch, cancel, err := broker.Subscribe("wf")
+	require.NoError(t, err)
+	cancel()
+	_, open := <-ch
+	assert.False(t, open, "cancel closes the channel")
+
+	// Cancelling after the workflow ended must not close the channel twice.
+	ch, cancel, err = broker.Subscribe("wf")
+	require.NoError(t, err)
+	broker.end("wf")
+	cancel()
+	_, open = <-ch
+	assert.False(t, open)
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/audit"
+)
+
+// failureSinkTimeout bounds how long recording a failed workflow may take, so
+// a slow sink cannot delay the workflow's result.
+const failureSinkTimeout = 5 * time.Second
+
+// FailureRecord describes a failed or timed-out workflow execution, with the
+// work it performed before failing, for later inspection.
+type FailureRecord struct {
+	// WorkflowID identifies the workflow execution.
+	WorkflowID string `json:"workflowId"`
+
+	// WorkflowName is the name of the composite tool.
+	WorkflowName string `json:"workflowName"`
+
+	// Status is WorkflowStatusFailed or WorkflowStatusTimedOut.
+	Status WorkflowStatusType `json:"status"`
+
+	// FailedStep is the first step that failed. It is empty when no step failed,
+	// for example when output construction failed.
+	FailedStep string `json:"failedStep,omitempty"`
+
+	// Error is the error the workflow failed with.
+	Error string `json:"error"`
+
+	// Params holds the workflow parameters, including applied defaults.
+	Params map[string]any `json:"params,omitempty"`
+
+	// Steps holds the steps that ran, ordered by start time.
+	Steps []FailureStepRecord `json:"steps,omitempty"`
+
+	// StartTime is when the workflow started.
+	StartTime time.Time `json:"startTime"`
+
+	// EndTime is when the workflow failed.
+	EndTime time.Time `json:"endTime"`
+}
+
+// FailureStepRecord captures a single step of a failed workflow.
+type FailureStepRecord struct {
+	// StepID identifies the step within the workflow.
+	StepID string `json:"stepId"`
+
+	// Status is the step status when the workflow failed.
+	Status StepStatusType `json:"status"`
+
+	// Input holds the expanded arguments of tool and composite steps.
+	Input map[string]any `json:"input,omitempty"`
+
+	// Output holds the step output, if the step produced one.
+	Output map[string]any `json:"output,omitempty"`
+
+	// Error is the error message of a failed step.
+	Error string `json:"error,omitempty"`
+}
+
+// FailureSink receives a record of every workflow execution that fails or
+// times out. Implementations must be safe for concurrent use.
+type FailureSink interface {
+	RecordFailure(ctx context.Context, record *FailureRecord) error
+}
+
+// FailureSinkFunc adapts a function to the FailureSink interface.
+type FailureSinkFunc func(ctx context.Context, record *FailureRecord) error
+
+// RecordFailure calls f.
+func (f FailureSinkFunc) RecordFailure(ctx context.Context, record *FailureRecord) error {
+	return f(ctx, record)
+}
+
+// WithFailureSink makes the engine record the workflows that fail or time out
+// to sink. The values of redactFields are replaced in the recorded parameters,
+// step inputs and step outputs, with the same matching rules as
+// audit.Config.RedactFields. A nil sink disables failure recording.
+func WithFailureSink(sink FailureSink, redactFields []string) EngineOption {
+	return func(e *workflowEngine) {
+		e.failureSink = sink
+		e.failureRedactFields = slices.Clone(redactFields)
+	}
+}
+
+// NewLogFailureSink returns a FailureSink that writes every record to the
+// server log at error level.
+func NewLogFailureSink() FailureSink {
+	return FailureSinkFunc(func(_ context.Context, record *FailureRecord) error {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode workflow failure record: %w", err)
+		}
+		slog.Error("workflow failure recorded",
+			"workflow", record.WorkflowName, "workflow_id", record.WorkflowID, "record", string(data))
+		return nil
+	})
+}
+
+// NewFileFailureSink returns a FailureSink that appends every record to the
+// file at path as a line of JSON. The file is created if it does not exist.
+func NewFileFailureSink(path string) FailureSink {
+	return &fileFailureSink{path: path}
+}
+
+// fileFailureSink appends failure records to a JSON lines file. The file is
+// opened for each record, so it may be rotated or removed while the server runs.
+type fileFailureSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// RecordFailure appends record to the file.
+func (s *fileFailureSink) RecordFailure(_ context.Context, record *FailureRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow failure record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open workflow failure file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write workflow failure record: %w", err)
+	}
+	return f.Close()
+}
+
+// recordFailure sends a record of the failed workflow to the failure sink.
+// Sink errors are logged and never change the workflow's result.
+func (e *workflowEngine) recordFailure(
+	ctx context.Context,
+	def *WorkflowDefinition,
+	workflowCtx *WorkflowContext,
+	result *WorkflowResult,
+) {
+	if e.failureSink == nil {
+		return
+	}
+
+	record := &FailureRecord{
+		WorkflowID:   result.WorkflowID,
+		WorkflowName: def.Name,
+		Status:       result.Status,
+		Params:       e.redactFailureData(workflowCtx.Params),
+		StartTime:    result.StartTime,
+		EndTime:      result.EndTime,
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+	}
+
+	steps := slices.SortedFunc(maps.Values(result.Steps), func(a, b *StepResult) int {
+		return cmp.Or(a.StartTime.Compare(b.StartTime), cmp.Compare(a.StepID, b.StepID))
+	})
+	var firstFailure *StepResult
+	for _, step := range steps {
+		stepRecord := FailureStepRecord{
+			StepID: step.StepID,
+			Status: step.Status,
+			Input:  e.redactFailureData(step.Input),
+			Output: e.redactFailureData(step.Output),
+		}
+		if step.Error != nil {
+			stepRecord.Error = step.Error.Error()
+		}
+		if step.Status == StepStatusFailed && (firstFailure == nil || step.EndTime.Before(firstFailure.EndTime)) {
+			firstFailure = step
+		}
+		record.Steps = append(record.Steps, stepRecord)
+	}
+	if firstFailure != nil {
+		record.FailedStep = firstFailure.StepID
+	}
+
+	// The execution context may be cancelled by the failure; the record must
+	// still be written.
+	sinkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), failureSinkTimeout)
+	defer cancel()
+	if err := e.failureSink.RecordFailure(sinkCtx, record); err != nil {
+		slog.Warn("failed to record workflow failure",
+			"workflow", def.Name, "workflow_id", result.WorkflowID, "error", err)
+	}
+}
+
+// redactFailureData returns a copy of data with the configured fields redacted.
+func (e *workflowEngine) redactFailureData(data map[string]any) map[string]any {
+	if data == nil {
+		return nil
+	}
+	if redacted, ok := audit.Redact(data, e.failureRedactFields).(map[string]any); ok {
+		return redacted
+	}
+	return data
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/audit"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+	routermocks "github.com/stacklok/toolhive/pkg/vmcp/router/mocks"
+)
+
+func TestWorkflowEngine_FailureSink(t *testing.T) {
+	t.Parallel()
+
+	pipeline := simpleWorkflow("pipeline",
+		toolStep("fetch", "fetch", map[string]any{"url": "https://example.com"}),
+		toolStepWithDeps("store", "store",
+			map[string]any{"data": "{{.steps.fetch.output.data}}", "token": "{{.params.token}}"},
+			[]string{"fetch"}),
+	)
+
+	tests := []struct {
+		name       string
+		storeErr   error
+		wantRecord bool
+	}{
+		{name: "success records nothing"},
+		{name: "failure records the workflow", storeErr: errors.New("backend unavailable"), wantRecord: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRouter := routermocks.NewMockRouter(ctrl)
+			mockRouter.EXPECT().ResolveToolName(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, name string) string { return name }).
+				AnyTimes()
+			mockBackend := mocks.NewMockBackendClient(ctrl)
+
+			target := &vmcp.BackendTarget{WorkloadID: "test-backend", BaseURL: "http://test:8080"}
+			mockRouter.EXPECT().RouteTool(gomock.Any(), gomock.Any()).Return(target, nil).Times(2)
+			mockBackend.EXPECT().CallTool(gomock.Any(), target, "fetch", gomock.Any(), gomock.Any()).
+				Return(&vmcp.ToolCallResult{StructuredContent: map[string]any{"data": "x"}}, nil)
+			if tc.storeErr != nil {
+				mockBackend.EXPECT().CallTool(gomock.Any(), target, "store", gomock.Any(), gomock.Any()).
+					Return(nil, tc.storeErr)
+			} else {
+				mockBackend.EXPECT().CallTool(gomock.Any(), target, "store", gomock.Any(), gomock.Any()).
+					Return(&vmcp.ToolCallResult{StructuredContent: map[string]any{"ok": true}}, nil)
+			}
+
+			var (
+				mu      sync.Mutex
+				records []*FailureRecord
+			)
+			sink := FailureSinkFunc(func(_ context.Context, record *FailureRecord) error {
+				mu.Lock()
+				defer mu.Unlock()
+				records = append(records, record)
+				return nil
+			})
+			engine := NewWorkflowEngine(mockRouter, mockBackend, nil, nil, nil, nil,
+				WithFailureSink(sink, []string{"token"}))
+
+			result, err := engine.ExecuteWorkflow(context.Background(), pipeline, map[string]any{"token": "secret"})
+			if !tc.wantRecord {
+				require.NoError(t, err)
+				assert.Empty(t, records)
+				return
+			}
+			require.Error(t, err)
+			require.Len(t, records, 1)
+
+			record := records[0]
+			assert.Equal(t, result.WorkflowID, record.WorkflowID)
+			assert.Equal(t, "pipeline", record.WorkflowName)
+			assert.Equal(t, WorkflowStatusFailed, record.Status)
+			assert.Equal(t, "store", record.FailedStep)
+			assert.Contains(t, record.Error, "backend unavailable")
+			assert.Equal(t, map[string]any{"token": audit.RedactedValue}, record.Params)
+
+			require.Len(t, record.Steps, 2)
+			assert.Equal(t, FailureStepRecord{
+				StepID: "fetch",
+				Status: StepStatusCompleted,
+				Input:  map[string]any{"url": "https://example.com"},
+				Output: map[string]any{"data": "x"},
+			}, record.Steps[0])
+			assert.Equal(t, "store", record.Steps[1].StepID)
+			assert.Equal(t, StepStatusFailed, record.Steps[1].Status)
+			assert.Equal(t, map[string]any{"data": "x", "token": audit.RedactedValue}, record.Steps[1].Input)
+			assert.Contains(t, record.Steps[1].Error, "backend unavailable")
+		})
+	}
+}
+
+func TestFileFailureSink(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "failures.jsonl")
+	sink := NewFileFailureSink(path)
+
+	for _, id := range []string{"wf-1", "wf-2"} {
+		require.NoError(t, sink.RecordFailure(context.Background(), &FailureRecord{
+			WorkflowID: id, WorkflowName: "pipeline", Status: WorkflowStatusFailed, Error: "boom",
+		}))
+	}
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record FailureRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Equal(t, "boom", record.Error)
+		ids = append(ids, record.WorkflowID)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"wf-1", "wf-2"}, ids)
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// defaultMaxWorkflowSteps is the default maximum number of steps allowed in
+	// a workflow definition, and the default budget of steps a single workflow
+	// execution may run, including steps of nested composite tools.
+	defaultMaxWorkflowSteps = 100
+
+	// defaultMaxCompositionDepth is the default maximum nesting depth of
+	// composite tool executions. A top-level workflow runs at depth 1.
+	defaultMaxCompositionDepth = 5
+)
+
+// WorkflowLimits bounds the work a composite tool workflow may perform.
+// Zero values select the defaults.
+type WorkflowLimits struct {
+	// MaxSteps is the maximum number of steps in a workflow definition and the
+	// maximum number of steps a single execution may run, counting steps of
+	// nested composite tools. Default: 100.
+	MaxSteps int
+
+	// MaxCompositionDepth is the maximum nesting depth of composite tool
+	// executions (a composite invoking a composite). Default: 5.
+	MaxCompositionDepth int
+}
+
+// WithWorkflowLimits overrides the default step and composition-depth limits.
+func WithWorkflowLimits(limits WorkflowLimits) EngineOption {
+	return func(e *workflowEngine) {
+		if limits.MaxSteps > 0 {
+			e.maxSteps = limits.MaxSteps
+		}
+		if limits.MaxCompositionDepth > 0 {
+			e.maxDepth = limits.MaxCompositionDepth
+		}
+	}
+}
+
+// WithCompositeDefinitions registers the composite tool definitions that
+// composite steps may invoke. ValidateWorkflow also uses them to reject unknown
+// references and direct or indirect composite recursion.
+func WithCompositeDefinitions(defs map[string]*WorkflowDefinition) EngineOption {
+	return func(e *workflowEngine) {
+		e.composites = defs
+	}
+}
+
+// executionBudget tracks the composition depth and the number of steps run by
+// a workflow execution. It is carried in the context so nested composite
+// executions share the budget of the top-level workflow.
+type executionBudget struct {
+	depth int
+	steps *atomic.Int64
+}
+
+type executionBudgetKey struct{}
+
+// enterWorkflow returns a context for executing a workflow one composition
+// level below ctx, or ErrMaxDepthExceeded if that would exceed maxDepth.
+func enterWorkflow(ctx context.Context, maxDepth int) (context.Context, error) {
+	parent, ok := ctx.Value(executionBudgetKey{}).(*executionBudget)
+	budget := &executionBudget{depth: 1, steps: &atomic.Int64{}}
+	if ok {
+		budget = &executionBudget{depth: parent.depth + 1, steps: parent.steps}
+	}
+	if budget.depth > maxDepth {
+		return nil, fmt.Errorf("%w: depth %d (max %d)", ErrMaxDepthExceeded, budget.depth, maxDepth)
+	}
+	return context.WithValue(ctx, executionBudgetKey{}, budget), nil
+}
+
+// consumeStep charges one step against the execution budget in ctx and returns
+// ErrMaxStepsExceeded once more than maxSteps steps have run.
+func consumeStep(ctx context.Context, maxSteps int) error {
+	budget, ok := ctx.Value(executionBudgetKey{}).(*executionBudget)
+	if !ok {
+		return nil
+	}
+	if n := budget.steps.Add(1); n > int64(maxSteps) {
+		return fmt.Errorf("%w: %d steps executed (max %d)", ErrMaxStepsExceeded, n, maxSteps)
+	}
+	return nil
+}
+
+// isLimitExceeded reports whether err is a step or composition-depth limit
+// violation. Such errors abort the workflow even when the failure mode or the
+// step's error handler would otherwise continue.
+func isLimitExceeded(err error) bool {
+	return errors.Is(err, ErrMaxStepsExceeded) || errors.Is(err, ErrMaxDepthExceeded)
+}
+
+// compositeReferences returns the names of registered composite tools that
+// step invokes.
+func (e *workflowEngine) compositeReferences(step *WorkflowStep) []string {
+	if step.Type == StepTypeComposite {
+		if _, ok := e.composites[step.Tool]; ok {
+			return []string{step.Tool}
+		}
+	}
+	return nil
+}
+
+// validateCompositeReferences rejects workflows that reach themselves through
+// composite tool references, directly or through other composites, and
+// reference chains deeper than the composition-depth limit.
+func (e *workflowEngine) validateCompositeReferences(def *WorkflowDefinition) error {
+	var visit func(name string, steps []WorkflowStep, path []string) error
+	visit = func(name string, steps []WorkflowStep, path []string) error {
+		path = append(path, name)
+		if len(path) > e.maxDepth {
+			return NewValidationError("steps",
+				fmt.Sprintf("composite nesting exceeds max depth %d: %s", e.maxDepth, strings.Join(path, " -> ")),
+				ErrMaxDepthExceeded)
+		}
+		for i := range steps {
+			for _, ref := range e.compositeReferences(&steps[i]) {
+				if slices.Contains(path, ref) {
+					return NewValidationError("steps",
+						fmt.Sprintf("step %s: recursive composite reference: %s -> %s",
+							steps[i].ID, strings.Join(path, " -> "), ref),
+						ErrRecursiveComposite)
+				}
+				if err := visit(ref, e.composites[ref].Steps, path); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return visit(def.Name, def.Steps, nil)
+}
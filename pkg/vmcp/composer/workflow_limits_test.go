@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+	routermocks "github.com/stacklok/toolhive/pkg/vmcp/router/mocks"
+)
+
+func TestWorkflowEngine_MaxStepsGuard(t *testing.T) {
+	t.Parallel()
+
+	def := simpleWorkflow("three-steps",
+		toolStep("s1", "tool1", map[string]any{}),
+		toolStepWithDeps("s2", "tool2", map[string]any{}, []string{"s1"}),
+		toolStepWithDeps("s3", "tool3", map[string]any{}, []string{"s2"}),
+	)
+
+	t.Run("validation rejects definitions over the limit", func(t *testing.T) {
+		t.Parallel()
+		engine := NewWorkflowEngine(nil, nil, nil, nil, nil, nil, WithWorkflowLimits(WorkflowLimits{MaxSteps: 2}))
+
+		err := engine.ValidateWorkflow(context.Background(), def)
+		require.ErrorIs(t, err, ErrMaxStepsExceeded)
+		assert.Contains(t, err.Error(), "too many steps: 3 (max 2)")
+	})
+
+	t.Run("execution aborts once the step budget is spent", func(t *testing.T) {
+		t.Parallel()
+		te := newTestEngine(t)
+		te.Engine = NewWorkflowEngine(te.Router, te.Backend, nil, nil, nil, nil,
+			WithWorkflowLimits(WorkflowLimits{MaxSteps: 2}))
+		te.expectToolCall("tool1", map[string]any{}, map[string]any{"ok": true})
+		te.expectToolCall("tool2", map[string]any{}, map[string]any{"ok": true})
+		// tool3 must never be called.
+
+		// continue failure mode must not swallow a limit violation.
+		def := *def
+		def.FailureMode = failureModeContinue
+		result, err := execute(t, te.Engine, &def, nil)
+		require.ErrorIs(t, err, ErrMaxStepsExceeded)
+		assert.Equal(t, WorkflowStatusFailed, result.Status)
+		assert.Equal(t, StepStatusFailed, result.Steps["s3"].Status)
+	})
+}
+
+func TestWorkflowEngine_MaxCompositionDepthGuard(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	engine := NewWorkflowEngine(routermocks.NewMockRouter(ctrl), mocks.NewMockBackendClient(ctrl),
+		nil, nil, nil, nil, WithWorkflowLimits(WorkflowLimits{MaxCompositionDepth: 2}))
+
+	// Simulate an execution already nested at the maximum depth.
+	ctx, err := enterWorkflow(context.Background(), 2)
+	require.NoError(t, err)
+	ctx, err = enterWorkflow(ctx, 2)
+	require.NoError(t, err)
+
+	result, err := engine.ExecuteWorkflow(ctx, simpleWorkflow("nested", toolStep("s1", "tool1", nil)), nil)
+	require.ErrorIs(t, err, ErrMaxDepthExceeded)
+	assert.Nil(t, result)
+}
+
+func TestWorkflowEngine_ValidateCompositeRecursion(t *testing.T) {
+	t.Parallel()
+
+	// composite builds a definition with one step per reference; references
+	// containing a dot are backend tools, the rest are composite tools.
+	composite := func(name string, refs ...string) *WorkflowDefinition {
+		def := &WorkflowDefinition{Name: name}
+		for i, ref := range refs {
+			id := "step" + string(rune('a'+i))
+			if strings.Contains(ref, ".") {
+				def.Steps = append(def.Steps, toolStep(id, ref, nil))
+			} else {
+				def.Steps = append(def.Steps, compositeStep(id, ref, nil))
+			}
+		}
+		return def
+	}
+
+	tests := []struct {
+		name      string
+		defs      []*WorkflowDefinition
+		limits    WorkflowLimits
+		validate  string
+		wantErrIs error
+		wantMsg   string
+	}{
+		{
+			name:      "direct recursion",
+			defs:      []*WorkflowDefinition{composite("loop", "backend.fetch", "loop")},
+			validate:  "loop",
+			wantErrIs: ErrRecursiveComposite,
+			wantMsg:   "loop -> loop",
+		},
+		{
+			name: "indirect recursion",
+			defs: []*WorkflowDefinition{
+				composite("a", "b"),
+				composite("b", "backend.fetch", "c"),
+				composite("c", "a"),
+			},
+			validate:  "a",
+			wantErrIs: ErrRecursiveComposite,
+			wantMsg:   "a -> b -> c -> a",
+		},
+		{
+			name: "nesting deeper than the limit",
+			defs: []*WorkflowDefinition{
+				composite("a", "b"),
+				composite("b", "c"),
+				composite("c", "backend.fetch"),
+			},
+			limits:    WorkflowLimits{MaxCompositionDepth: 2},
+			validate:  "a",
+			wantErrIs: ErrMaxDepthExceeded,
+		},
+		{
+			name: "shared non-recursive composite",
+			defs: []*WorkflowDefinition{
+				composite("a", "b", "c"),
+				composite("b", "c"),
+				composite("c", "backend.fetch"),
+			},
+			validate: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry := make(map[string]*WorkflowDefinition, len(tt.defs))
+			for _, def := range tt.defs {
+				registry[def.Name] = def
+			}
+			engine := NewWorkflowEngine(nil, nil, nil, nil, nil, nil,
+				WithWorkflowLimits(tt.limits), WithCompositeDefinitions(registry))
+
+			err := engine.ValidateWorkflow(context.Background(), registry[tt.validate])
+			if tt.wantErrIs == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErrIs)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/stacklok/toolhive/pkg/telemetry"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// Step outcomes recorded on the step duration histogram.
+const (
+	stepOutcomeSuccess = "success"
+	stepOutcomeError   = "error"
+	stepOutcomeSkipped = "skipped"
+)
+
+// EngineOption configures optional workflow engine behaviour.
+type EngineOption func(*workflowEngine)
+
+// WithStepTelemetry instruments every executed step with a span and step
+// duration/retry metrics. A nil StepTelemetry disables step instrumentation.
+func WithStepTelemetry(st *StepTelemetry) EngineOption {
+	return func(e *workflowEngine) {
+		e.stepTelemetry = st
+	}
+}
+
+// StepTelemetry holds the OTEL instruments for per-step workflow telemetry.
+// Build it once with NewStepTelemetry and share it across engines.
+// A nil *StepTelemetry is valid and records nothing.
+type StepTelemetry struct {
+	tracer       trace.Tracer
+	stepDuration metric.Float64Histogram
+	stepRetries  metric.Int64Counter
+}
+
+// NewStepTelemetry creates the per-step instruments from the given providers.
+// A nil provider falls back to a noop provider. Step spans are started from the
+// context passed to ExecuteWorkflow, so they become children of any workflow
+// span the caller already started.
+func NewStepTelemetry(meterProvider metric.MeterProvider, tracerProvider trace.TracerProvider) (*StepTelemetry, error) {
+	if meterProvider == nil {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+	if tracerProvider == nil {
+		tracerProvider = tracenoop.NewTracerProvider()
+	}
+	meter := meterProvider.Meter(vmcp.InstrumentationName)
+
+	stepDuration, err := meter.Float64Histogram(
+		"toolhive_vmcp_workflow_step_duration",
+		metric.WithDescription("Duration of composite tool workflow steps in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetry.MCPHistogramBuckets...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow step duration histogram: %w", err)
+	}
+
+	stepRetries, err := meter.Int64Counter(
+		"toolhive_vmcp_workflow_step_retries",
+		metric.WithDescription("Total number of composite tool workflow step retries"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow step retries counter: %w", err)
+	}
+
+	return &StepTelemetry{
+		tracer:       tracerProvider.Tracer(vmcp.InstrumentationName),
+		stepDuration: stepDuration,
+		stepRetries:  stepRetries,
+	}, nil
+}
+
+// startStep starts the span for a step. The returned context carries the step
+// span and must be used for the step's backend calls.
+func (st *StepTelemetry) startStep(
+	ctx context.Context, workflowName string, step *WorkflowStep,
+) (context.Context, trace.Span) {
+	if st == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("workflow.name", workflowName),
+		attribute.String("workflow.step.id", step.ID),
+		attribute.String("workflow.step.type", string(step.Type)),
+	}
+	if step.Type == StepTypeTool || step.Type == StepTypeComposite {
+		attrs = append(attrs, attribute.String("workflow.step.tool", step.Tool))
+	}
+	return st.tracer.Start(ctx, "composer.ExecuteStep", trace.WithAttributes(attrs...))
+}
+
+// endStep records the step metrics and ends the span started by startStep.
+// result is the step's recorded result and may be nil; err is the error
+// returned by the step. A step that failed but was allowed to continue
+// (continue_on_error) returns a nil err and is still recorded as an error.
+func (st *StepTelemetry) endStep(
+	ctx context.Context,
+	span trace.Span,
+	workflowName string,
+	step *WorkflowStep,
+	result *StepResult,
+	duration time.Duration,
+	err error,
+) {
+	if st == nil {
+		return
+	}
+	defer span.End()
+
+	outcome := stepOutcomeSuccess
+	retryCount := 0
+	if result != nil {
+		retryCount = result.RetryCount
+		switch result.Status {
+		case StepStatusFailed:
+			outcome = stepOutcomeError
+			if err == nil {
+				err = result.Error
+			}
+		case StepStatusSkipped:
+			outcome = stepOutcomeSkipped
+		case StepStatusPending, StepStatusRunning, StepStatusCompleted, StepStatusCancelled:
+		}
+	}
+	if err != nil {
+		outcome = stepOutcomeError
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("workflow.name", workflowName),
+		attribute.String("workflow.step.id", step.ID),
+		attribute.String("workflow.step.type", string(step.Type)),
+	}
+	st.stepDuration.Record(ctx, duration.Seconds(),
+		metric.WithAttributes(append(attrs, attribute.String("outcome", outcome))...))
+	if retryCount > 0 {
+		st.stepRetries.Add(ctx, int64(retryCount), metric.WithAttributes(attrs...))
+	}
+
+	span.SetAttributes(
+		attribute.String("workflow.step.outcome", outcome),
+		attribute.Int("workflow.step.retries", retryCount),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package composer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/internal/backendtelemetry"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+	routermocks "github.com/stacklok/toolhive/pkg/vmcp/router/mocks"
+)
+
+func TestWorkflowEngine_StepTelemetry(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRouter := routermocks.NewMockRouter(ctrl)
+	mockRouter.EXPECT().ResolveToolName(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, name string) string { return name }).
+		AnyTimes()
+	mockBackend := mocks.NewMockBackendClient(ctrl)
+
+	target := &vmcp.BackendTarget{WorkloadID: "test-backend", BaseURL: "http://test:8080"}
+	mockRouter.EXPECT().RouteTool(gomock.Any(), gomock.Any()).Return(target, nil).Times(2)
+	mockBackend.EXPECT().CallTool(gomock.Any(), target, "fetch", gomock.Any(), gomock.Any()).
+		Return(&vmcp.ToolCallResult{StructuredContent: map[string]any{"data": "x"}}, nil)
+	// The second step fails once and succeeds on retry.
+	gomock.InOrder(
+		mockBackend.EXPECT().CallTool(gomock.Any(), target, "store", gomock.Any(), gomock.Any()).
+			Return(nil, assert.AnError),
+		mockBackend.EXPECT().CallTool(gomock.Any(), target, "store", gomock.Any(), gomock.Any()).
+			Return(&vmcp.ToolCallResult{StructuredContent: map[string]any{"ok": true}}, nil),
+	)
+
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	stepTelemetry, err := NewStepTelemetry(meterProvider, tracerProvider)
+	require.NoError(t, err)
+	engine := NewWorkflowEngine(mockRouter, mockBackend, nil, nil, nil, nil, WithStepTelemetry(stepTelemetry))
+
+	store := toolStepWithDeps("store", "store", map[string]any{}, []string{"fetch"})
+	store.OnError = &ErrorHandler{Action: "retry", RetryCount: 1, RetryDelay: 1}
+	def := simpleWorkflow("pipeline", toolStep("fetch", "fetch", map[string]any{}), store)
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "workflow")
+	result, err := engine.ExecuteWorkflow(ctx, def, nil)
+	parent.End()
+	require.NoError(t, err)
+	assert.Equal(t, WorkflowStatusCompleted, result.Status)
+
+	// Both step spans are children of the workflow span.
+	stepSpans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range spanRecorder.Ended() {
+		if s.Name() != "composer.ExecuteStep" {
+			continue
+		}
+		for _, kv := range s.Attributes() {
+			if kv.Key == "workflow.step.id" {
+				stepSpans[kv.Value.AsString()] = s
+			}
+		}
+	}
+	require.Len(t, stepSpans, 2)
+	for id, s := range stepSpans {
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID(), "step %s parent", id)
+		assert.Equal(t, parent.SpanContext().TraceID(), s.SpanContext().TraceID(), "step %s trace", id)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	durations := map[string]uint64{}
+	var retries int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch m.Name {
+			case "toolhive_vmcp_workflow_step_duration":
+				hist, ok := m.Data.(metricdata.Histogram[float64])
+				require.True(t, ok)
+				for _, dp := range hist.DataPoints {
+					stepID, _ := dp.Attributes.Value(attribute.Key("workflow.step.id"))
+					outcome, _ := dp.Attributes.Value(attribute.Key("outcome"))
+					assert.Equal(t, stepOutcomeSuccess, outcome.AsString())
+					durations[stepID.AsString()] += dp.Count
+				}
+			case "toolhive_vmcp_workflow_step_retries":
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				require.True(t, ok)
+				for _, dp := range sum.DataPoints {
+					stepID, _ := dp.Attributes.Value(attribute.Key("workflow.step.id"))
+					assert.Equal(t, "store", stepID.AsString())
+					retries += dp.Value
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]uint64{"fetch": 1, "store": 1}, durations)
+	assert.Equal(t, int64(1), retries)
+}
+
+func TestWorkflowEngine_TracePropagationToBackends(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRouter := routermocks.NewMockRouter(ctrl)
+	mockRouter.EXPECT().ResolveToolName(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, name string) string { return name }).
+		AnyTimes()
+	mockBackend := mocks.NewMockBackendClient(ctrl)
+
+	target := &vmcp.BackendTarget{WorkloadID: "backend-a", BaseURL: "http://a:8080"}
+	mockRouter.EXPECT().RouteTool(gomock.Any(), gomock.Any()).Return(target, nil).Times(2)
+
+	// Capture the traceparent each backend call would send on the wire.
+	traceparents := map[string]string{}
+	capture := func(ctx context.Context, toolName string) {
+		carrier := propagation.MapCarrier{}
+		propagation.TraceContext{}.Inject(ctx, carrier)
+		traceparents[toolName] = carrier.Get("traceparent")
+	}
+	mockBackend.EXPECT().CallTool(gomock.Any(), target, "fetch", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, name string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			capture(ctx, name)
+			return &vmcp.ToolCallResult{StructuredContent: map[string]any{"data": "x"}}, nil
+		})
+	mockBackend.EXPECT().CallTool(gomock.Any(), target, "store", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, name string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			capture(ctx, name)
+			return &vmcp.ToolCallResult{
+				IsError: true,
+				Content: []vmcp.Content{{Type: "text", Text: "disk full"}},
+			}, nil
+		})
+
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	meterProvider := sdkmetric.NewMeterProvider()
+
+	backendClient, err := backendtelemetry.MonitorBackends(
+		context.Background(), meterProvider, tracerProvider, nil, mockBackend)
+	require.NoError(t, err)
+	stepTelemetry, err := NewStepTelemetry(meterProvider, tracerProvider)
+	require.NoError(t, err)
+	engine := NewWorkflowEngine(mockRouter, backendClient, nil, nil, nil, nil, WithStepTelemetry(stepTelemetry))
+
+	store := toolStepWithDeps("store", "store", map[string]any{}, []string{"fetch"})
+	store.OnError = &ErrorHandler{ContinueOnError: true}
+	def := simpleWorkflow("pipeline", toolStep("fetch", "fetch", map[string]any{}), store)
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "workflow")
+	_, err = engine.ExecuteWorkflow(ctx, def, nil)
+	parent.End()
+	require.NoError(t, err)
+
+	stepSpans := map[string]sdktrace.ReadOnlySpan{}
+	backendSpans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range spanRecorder.Ended() {
+		switch {
+		case s.Name() == "composer.ExecuteStep":
+			for _, kv := range s.Attributes() {
+				if kv.Key == "workflow.step.id" {
+					stepSpans[kv.Value.AsString()] = s
+				}
+			}
+		case strings.HasPrefix(s.Name(), "tools/call "):
+			backendSpans[strings.TrimPrefix(s.Name(), "tools/call ")] = s
+		}
+	}
+	require.Len(t, stepSpans, 2)
+	require.Len(t, backendSpans, 2)
+
+	traceID := parent.SpanContext().TraceID()
+	for stepID, tool := range map[string]string{"fetch": "fetch", "store": "store"} {
+		step, backend := stepSpans[stepID], backendSpans[tool]
+		assert.Equal(t, parent.SpanContext().SpanID(), step.Parent().SpanID(), "step %s parent", stepID)
+		assert.Equal(t, step.SpanContext().SpanID(), backend.Parent().SpanID(), "backend call %s parent", tool)
+		assert.Equal(t, traceID, backend.SpanContext().TraceID())
+		assert.Equal(t, trace.SpanKindClient, backend.SpanKind())
+		assert.Contains(t, backend.Attributes(), attribute.String("target.workload_id", "backend-a"))
+		assert.Contains(t, backend.Attributes(), attribute.String("gen_ai.tool.name", tool))
+
+		// The outgoing traceparent carries the backend span, linking the
+		// backend's own spans under vmcp's.
+		assert.Equal(t,
+			"00-"+traceID.String()+"-"+backend.SpanContext().SpanID().String()+"-01",
+			traceparents[tool])
+	}
+
+	assert.Equal(t, codes.Unset, backendSpans["fetch"].Status().Code)
+	assert.Equal(t, codes.Error, backendSpans["store"].Status().Code)
+	assert.Equal(t, codes.Error, stepSpans["store"].Status().Code)
+}
+
+func TestNewStepTelemetry_NilProviders(t *testing.T) {
+	t.Parallel()
+
+	stepTelemetry, err := NewStepTelemetry(nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, stepTelemetry)
+
+	ctx, span := stepTelemetry.startStep(context.Background(), "wf", &WorkflowStep{ID: "s1", Type: StepTypeTool})
+	assert.NotNil(t, ctx)
+	span.End()
+}
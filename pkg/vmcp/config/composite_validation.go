@@ -22,6 +22,7 @@ const (
 	WorkflowStepTypeToolCall    = "tool"
 	WorkflowStepTypeElicitation = "elicitation"
 	WorkflowStepTypeForEach     = "forEach"
+	WorkflowStepTypeComposite   = "composite"
 )
 
 // Constants for error actions
@@ -77,6 +78,11 @@ func ValidateCompositeToolConfig(pathPrefix string, tool *CompositeToolConfig) e
 		}
 	}
 
+	// Validate the parallel join strategy
+	if err := ValidateJoinConfig(pathPrefix+".join", tool.Join, tool.Steps); err != nil {
+		errors = append(errors, err.Error())
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("validation failed: %s", strings.Join(errors, "; "))
 	}
@@ -190,6 +196,8 @@ func ValidateWorkflowStep(pathPrefix string, index int, step *WorkflowStepConfig
 				return err
 			}
 		}
+	} else if !step.TimeoutDefault.IsEmpty() {
+		return fmt.Errorf("%s[%d].timeoutDefault is only valid for elicitation steps", pathPrefix, index)
 	}
 
 	return nil
@@ -213,9 +221,10 @@ func ValidateStepType(pathPrefix string, index int, step *WorkflowStepConfig) er
 		WorkflowStepTypeToolCall:    true,
 		WorkflowStepTypeElicitation: true,
 		WorkflowStepTypeForEach:     true,
+		WorkflowStepTypeComposite:   true,
 	}
 	if !validTypes[stepType] {
-		return fmt.Errorf("%s[%d].type must be one of: tool, elicitation, forEach", pathPrefix, index)
+		return fmt.Errorf("%s[%d].type must be one of: tool, elicitation, forEach, composite", pathPrefix, index)
 	}
 
 	if stepType == WorkflowStepTypeToolCall {
@@ -227,6 +236,10 @@ func ValidateStepType(pathPrefix string, index int, step *WorkflowStepConfig) er
 		}
 	}
 
+	if stepType == WorkflowStepTypeComposite && step.Tool == "" {
+		return fmt.Errorf("%s[%d].tool is required when type is composite", pathPrefix, index)
+	}
+
 	if stepType == WorkflowStepTypeElicitation && step.Message == "" {
 		return fmt.Errorf("%s[%d].message is required when type is elicitation", pathPrefix, index)
 	}
@@ -446,6 +459,78 @@ func ValidateElicitationResponseHandler(
 	return nil
 }
 
+// Constants for parallel join strategies
+const (
+	JoinStrategyAll  = "all"
+	JoinStrategyAny  = "any"
+	JoinStrategyNOfM = "n-of-m"
+)
+
+// ValidateJoinConfig validates the join strategy of a workflow. For n-of-m, N must
+// be between 1 and the number of branches of the widest execution level.
+func ValidateJoinConfig(pathPrefix string, join *JoinConfig, steps []WorkflowStepConfig) error {
+	if join == nil {
+		return nil
+	}
+
+	switch join.Strategy {
+	case "", JoinStrategyAll, JoinStrategyAny:
+		return nil
+	case JoinStrategyNOfM:
+		branches := widestLevel(steps)
+		if join.N < 1 || join.N > branches {
+			return fmt.Errorf("%s.n must be between 1 and %d (the most parallel branches of any level), got %d",
+				pathPrefix, branches, join.N)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s.strategy must be one of: all, any, n-of-m", pathPrefix)
+}
+
+// widestLevel returns the number of steps of the largest execution level, where
+// a step's level is the length of its longest dependency chain. Steps on a
+// dependency cycle are ignored; ValidateDependencyCycles reports them.
+func widestLevel(steps []WorkflowStepConfig) int {
+	graph := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		graph[step.ID] = step.DependsOn
+	}
+
+	depths := make(map[string]int, len(steps))
+	visiting := make(map[string]bool)
+	var depth func(string) int
+	depth = func(stepID string) int {
+		if d, ok := depths[stepID]; ok {
+			return d
+		}
+		if visiting[stepID] {
+			return -1
+		}
+		visiting[stepID] = true
+		d := 0
+		for _, depID := range graph[stepID] {
+			depDepth := depth(depID)
+			if depDepth < 0 {
+				return -1
+			}
+			d = max(d, depDepth+1)
+		}
+		visiting[stepID] = false
+		depths[stepID] = d
+		return d
+	}
+
+	perLevel := make(map[int]int)
+	widest := 0
+	for stepID := range graph {
+		if d := depth(stepID); d >= 0 {
+			perLevel[d]++
+			widest = max(widest, perLevel[d])
+		}
+	}
+	return widest
+}
+
 // ValidateDependencyCycles validates that step dependencies don't create cycles.
 func ValidateDependencyCycles(pathPrefix string, steps []WorkflowStepConfig) error {
 	// Build adjacency list
@@ -431,6 +431,36 @@ func TestValidateCompositeToolConfig(t *testing.T) {
 			expectError: true,
 			errorMsg:    "references unknown step",
 		},
+		{
+			name: "elicitation step with timeout default",
+			tool: &CompositeToolConfig{
+				Name:        "test-tool",
+				Description: "A test tool",
+				Steps: []WorkflowStepConfig{
+					{
+						ID: "step1", Type: "elicitation", Message: "Which environment?",
+						Schema:         thvjson.NewMap(map[string]any{"type": "object"}),
+						TimeoutDefault: thvjson.NewMap(map[string]any{"environment": "staging"}),
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "timeout default on a tool step",
+			tool: &CompositeToolConfig{
+				Name:        "test-tool",
+				Description: "A test tool",
+				Steps: []WorkflowStepConfig{
+					{
+						ID: "step1", Type: "tool", Tool: "backend.echo",
+						TimeoutDefault: thvjson.NewMap(map[string]any{"environment": "staging"}),
+					},
+				},
+			},
+			expectError: true,
+			errorMsg:    "timeoutDefault is only valid for elicitation steps",
+		},
 	}
 
 	for _, tt := range tests {
@@ -480,6 +510,17 @@ func TestValidateWorkflowStepTypes(t *testing.T) {
 			expectError: true,
 			errorMsg:    "message is required",
 		},
+		{
+			name:        "valid composite step",
+			step:        WorkflowStepConfig{ID: "step1", Type: "composite", Tool: "fetch_issue"},
+			expectError: false,
+		},
+		{
+			name:        "composite step missing tool field",
+			step:        WorkflowStepConfig{ID: "step1", Type: "composite"},
+			expectError: true,
+			errorMsg:    "tool is required when type is composite",
+		},
 		{
 			name:        "invalid step type",
 			step:        WorkflowStepConfig{ID: "step1", Type: "invalid"},
@@ -630,3 +671,54 @@ func TestValidateDependencyCycles(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJoinConfig(t *testing.T) {
+	t.Parallel()
+
+	// Two parallel branches, then a step joining them.
+	steps := []WorkflowStepConfig{
+		{ID: "a", Tool: "backend.a"},
+		{ID: "b", Tool: "backend.b"},
+		{ID: "merge", Tool: "backend.merge", DependsOn: []string{"a", "b"}},
+	}
+
+	tests := []struct {
+		name    string
+		join    *JoinConfig
+		wantErr string
+	}{
+		{name: "no join", join: nil},
+		{name: "all", join: &JoinConfig{Strategy: JoinStrategyAll}},
+		{name: "any", join: &JoinConfig{Strategy: JoinStrategyAny}},
+		{name: "n-of-m within branches", join: &JoinConfig{Strategy: JoinStrategyNOfM, N: 2}},
+		{
+			name:    "n-of-m without n",
+			join:    &JoinConfig{Strategy: JoinStrategyNOfM},
+			wantErr: "join.n must be between 1 and 2",
+		},
+		{
+			name:    "n-of-m above widest level",
+			join:    &JoinConfig{Strategy: JoinStrategyNOfM, N: 3},
+			wantErr: "join.n must be between 1 and 2",
+		},
+		{
+			name:    "unknown strategy",
+			join:    &JoinConfig{Strategy: "most"},
+			wantErr: "join.strategy must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateJoinConfig("spec.join", tt.join, steps)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
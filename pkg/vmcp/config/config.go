@@ -11,6 +11,8 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"text/template"
 	"time"
 
 	"github.com/stacklok/toolhive/pkg/audit"
@@ -27,6 +29,13 @@ import (
 // #nosec G101 -- This is an environment variable name, not a hardcoded credential
 const RedisPasswordEnvVar = "THV_SESSION_REDIS_PASSWORD"
 
+// TokenCacheEncryptionKeyEnvVar is the environment variable holding the secret
+// that encrypts exchanged backend tokens cached in Redis session storage. It
+// must be at least 32 bytes. Without it, the token cache stays in process
+// memory even when sessions are stored in Redis.
+// #nosec G101 -- This is an environment variable name, not a hardcoded credential
+const TokenCacheEncryptionKeyEnvVar = "THV_TOKEN_CACHE_ENCRYPTION_KEY"
+
 // Transport type constants for static backend configuration.
 // These define the allowed network transport protocols for vMCP backends in static mode.
 const (
@@ -34,6 +43,11 @@ const (
 	TransportSSE = "sse"
 	// TransportStreamableHTTP is the streamable HTTP transport protocol.
 	TransportStreamableHTTP = "streamable-http"
+	// TransportStdio runs the backend as a local process and speaks MCP over its
+	// standard input and output. It is only available to the vmcp CLI: the
+	// operator never runs local processes, so it is not part of
+	// StaticModeAllowedTransports.
+	TransportStdio = "stdio"
 )
 
 // StaticModeAllowedTransports lists all transport types allowed for static backend configuration.
@@ -125,6 +139,12 @@ type Config struct {
 	// +optional
 	OutgoingAuth *OutgoingAuthConfig `json:"outgoingAuth,omitempty" yaml:"outgoingAuth,omitempty"`
 
+	// BackendTLS configures TLS for connections to backends, keyed by backend name.
+	// Backends without an entry use the system trust store and any CA bundle
+	// they are discovered with.
+	// +optional
+	BackendTLS map[string]*BackendTLSConfig `json:"backendTLS,omitempty" yaml:"backendTLS,omitempty"`
+
 	// Aggregation defines tool aggregation and conflict resolution strategies.
 	// Supports ToolConfigRef for Kubernetes-native MCPToolConfig resource references.
 	// +optional
@@ -374,6 +394,61 @@ type StaticBackendConfig struct {
 	// Reserved keys: "group" is automatically set by vMCP and any user-provided value will be overridden.
 	// +optional
 	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Command is the executable of a backend with the "stdio" transport, which
+	// vMCP runs as a local process instead of connecting to URL. Required for,
+	// and only valid with, the "stdio" transport.
+	// The stdio fields are only read from the vmcp CLI configuration file and
+	// are not part of the CRD.
+	Command string `json:"-" yaml:"command,omitempty"`
+
+	// Args are the command-line arguments of Command.
+	Args []string `json:"-" yaml:"args,omitempty"`
+
+	// Env holds environment variables set for Command in addition to the
+	// environment of vMCP.
+	Env map[string]string `json:"-" yaml:"env,omitempty"`
+}
+
+// BackendTLSConfig configures TLS for connections to one backend: a private CA to
+// trust, and a client certificate for backends that require mutual TLS.
+//
+// The client certificate and key are read either from files or from secrets.
+// A secret is resolved from the TOOLHIVE_SECRET_<name> environment variable;
+// in Kubernetes, populate it from a Secret with valueFrom.secretKeyRef, or
+// mount the Secret as a volume and use the path fields.
+// +kubebuilder:object:generate=true
+// +gendoc
+type BackendTLSConfig struct {
+	// CABundlePath is the absolute path to a PEM-encoded CA certificate bundle
+	// trusted, in addition to the system roots, when verifying the backend.
+	// +optional
+	CABundlePath string `json:"caBundlePath,omitempty" yaml:"caBundlePath,omitempty"`
+
+	// ClientCertPath is the absolute path to the PEM-encoded client certificate
+	// presented to the backend. Mutually exclusive with ClientCertSecret.
+	// +optional
+	ClientCertPath string `json:"clientCertPath,omitempty" yaml:"clientCertPath,omitempty"`
+
+	// ClientKeyPath is the absolute path to the PEM-encoded private key of the
+	// client certificate. Mutually exclusive with ClientKeySecret.
+	// +optional
+	ClientKeyPath string `json:"clientKeyPath,omitempty" yaml:"clientKeyPath,omitempty"`
+
+	// ClientCertSecret names the secret holding the PEM-encoded client certificate.
+	// Mutually exclusive with ClientCertPath.
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty" yaml:"clientCertSecret,omitempty"`
+
+	// ClientKeySecret names the secret holding the PEM-encoded private key of the
+	// client certificate. Mutually exclusive with ClientKeyPath.
+	// +optional
+	ClientKeySecret string `json:"clientKeySecret,omitempty" yaml:"clientKeySecret,omitempty"`
+
+	// InsecureSkipVerify disables verification of the backend's certificate.
+	// WARNING: This is insecure and should NEVER be used in production
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty"`
 }
 
 // OutgoingAuthConfig configures backend authentication.
@@ -460,6 +535,23 @@ type AggregationConfig struct {
 	// direct client access while exposing curated composite tool workflows.
 	// +optional
 	ExcludeAllTools bool `json:"excludeAllTools,omitempty" yaml:"excludeAllTools,omitempty"`
+
+	// MaxConcurrentQueries bounds how many backends are queried for their
+	// capabilities at the same time during aggregation. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentQueries int `json:"maxConcurrentQueries,omitempty" yaml:"maxConcurrentQueries,omitempty"`
+
+	// CapabilityCacheTTL is how long a backend's queried capabilities are reused
+	// before the backend is queried again. Defaults to 30s.
+	// +optional
+	CapabilityCacheTTL Duration `json:"capabilityCacheTTL,omitempty" yaml:"capabilityCacheTTL,omitempty"`
+
+	// ToolTimeout is the maximum duration of a backend tool call for tools with
+	// no entry in their workload's ToolTimeouts. When unset, calls are bounded
+	// only by the backend client's own timeouts.
+	// +optional
+	ToolTimeout Duration `json:"toolTimeout,omitempty" yaml:"toolTimeout,omitempty"`
 }
 
 // ConflictResolutionConfig provides configuration for conflict resolution strategies.
@@ -475,6 +567,13 @@ type ConflictResolutionConfig struct {
 	// PriorityOrder defines the workload priority order for the "priority" strategy.
 	// +optional
 	PriorityOrder []string `json:"priorityOrder,omitempty" yaml:"priorityOrder,omitempty"`
+
+	// BackendPriorities assigns an explicit priority to each workload for the
+	// "priority" strategy. When two workloads expose the same tool, the one with
+	// the higher value wins; equal values are ordered by workload name.
+	// Mutually exclusive with PriorityOrder.
+	// +optional
+	BackendPriorities map[string]int `json:"backendPriorities,omitempty" yaml:"backendPriorities,omitempty"`
 }
 
 // WorkloadToolConfig defines tool filtering and overrides for a specific workload.
@@ -507,6 +606,24 @@ type WorkloadToolConfig struct {
 	// +optional
 	Overrides map[string]*ToolOverride `json:"overrides,omitempty" yaml:"overrides,omitempty"`
 
+	// Aliases maps this workload's tool names, as the backend reports them
+	// (before any override), to an alias the tool is advertised under instead.
+	// Unlike an override rename, the original name keeps routing to the tool,
+	// so callers that use it are not broken; only the alias is advertised.
+	// An alias that collides with the name of another aggregated tool is ignored.
+	// Only used if ToolConfigRef is not specified.
+	// +optional
+	Aliases map[string]string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// ParameterDefaults maps this workload's tool names, as the backend reports
+	// them (before any override), to argument values injected into calls to that
+	// tool when the client does not provide them. A value the client provides
+	// always wins. Defaults are checked against the tool's input schema when
+	// capabilities are aggregated; a default the schema rejects is dropped.
+	// Only used if ToolConfigRef is not specified.
+	// +optional
+	ParameterDefaults map[string]thvjson.Map `json:"parameterDefaults,omitempty" yaml:"parameterDefaults,omitempty"`
+
 	// ExcludeAll hides all tools from this workload from MCP clients when true.
 	// Hidden tools are NOT advertised in tools/list responses, but they ARE
 	// available in the routing table for composite tools to use.
@@ -514,6 +631,43 @@ type WorkloadToolConfig struct {
 	// direct client access while exposing curated composite tool workflows.
 	// +optional
 	ExcludeAll bool `json:"excludeAll,omitempty" yaml:"excludeAll,omitempty"`
+
+	// ToolTimeouts maps this workload's tool names, as the backend reports them
+	// (before any override), to the maximum duration of a call to that tool.
+	// Tools without an entry use the aggregation-wide ToolTimeout.
+	// +optional
+	ToolTimeouts map[string]Duration `json:"toolTimeouts,omitempty" yaml:"toolTimeouts,omitempty"`
+
+	// DescriptionTemplate rewrites the descriptions of this workload's advertised
+	// tools, as a Go template with the fields {{.BackendName}} and
+	// {{.OriginalDescription}}. Tool names and schemas are never changed.
+	// Example: "[{{.BackendName}}] {{.OriginalDescription}}"
+	// +optional
+	DescriptionTemplate string `json:"descriptionTemplate,omitempty" yaml:"descriptionTemplate,omitempty"`
+}
+
+// DescriptionTemplateData is the data a WorkloadToolConfig.DescriptionTemplate
+// is executed with.
+type DescriptionTemplateData struct {
+	// BackendName is the name of the backend that provides the tool.
+	BackendName string
+	// OriginalDescription is the tool description before the rewrite, after
+	// any override.
+	OriginalDescription string
+}
+
+// ParseDescriptionTemplate parses a WorkloadToolConfig.DescriptionTemplate.
+// The template is also executed once against empty data, so references to
+// unknown fields fail here rather than during aggregation.
+func ParseDescriptionTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("descriptionTemplate").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(io.Discard, DescriptionTemplateData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
 }
 
 // ToolConfigRef references an MCPToolConfig resource for tool filtering and renaming.
@@ -590,6 +744,133 @@ type OperationalConfig struct {
 	// FailureHandling configures failure handling behavior.
 	// +optional
 	FailureHandling *FailureHandlingConfig `json:"failureHandling,omitempty" yaml:"failureHandling,omitempty"`
+
+	// WorkflowLimits bounds the work composite tool workflows may perform.
+	// +optional
+	WorkflowLimits *WorkflowLimitsConfig `json:"workflowLimits,omitempty" yaml:"workflowLimits,omitempty"`
+
+	// WorkflowFailures records composite tool workflows that fail or time out.
+	// +optional
+	WorkflowFailures *WorkflowFailuresConfig `json:"workflowFailures,omitempty" yaml:"workflowFailures,omitempty"`
+
+	// BackendClient tunes the connection pools and retries of requests to backends.
+	// +optional
+	BackendClient *BackendClientConfig `json:"backendClient,omitempty" yaml:"backendClient,omitempty"`
+
+	// Warmup delays readiness until enough backends pass an initial health check.
+	// +optional
+	Warmup *WarmupConfig `json:"warmup,omitempty" yaml:"warmup,omitempty"`
+
+	// ShutdownGracePeriod is how long the server waits on shutdown for in-flight
+	// tool calls and workflows to complete before cancelling them. New requests
+	// are rejected while draining. Defaults to 20s, which leaves time for the
+	// HTTP server to close within the default pod termination grace period.
+	// +optional
+	ShutdownGracePeriod Duration `json:"shutdownGracePeriod,omitempty" yaml:"shutdownGracePeriod,omitempty"`
+}
+
+// WorkflowLimitsConfig bounds the work composite tool workflows may perform.
+// Unset fields use the built-in defaults.
+// +kubebuilder:object:generate=true
+// +gendoc
+type WorkflowLimitsConfig struct {
+	// MaxSteps is the maximum number of steps in a composite tool workflow, and the
+	// maximum number of steps a single invocation may run, including the steps of
+	// nested composite tools. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSteps int `json:"maxSteps,omitempty" yaml:"maxSteps,omitempty"`
+
+	// MaxCompositionDepth is the maximum nesting depth of composite tools that
+	// invoke other composite tools. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCompositionDepth int `json:"maxCompositionDepth,omitempty" yaml:"maxCompositionDepth,omitempty"`
+}
+
+// BackendClientConfig tunes the connections and retries of requests to backends.
+// Every backend has its own connection pool, reused by all requests to it.
+// Unset fields use the built-in defaults.
+// +kubebuilder:object:generate=true
+// +gendoc
+type BackendClientConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept per backend.
+	// Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConns int `json:"maxIdleConns,omitempty" yaml:"maxIdleConns,omitempty"`
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept to a
+	// single backend host. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty" yaml:"maxIdleConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is how long an idle backend connection is kept open.
+	// Defaults to 90s.
+	// +optional
+	IdleConnTimeout Duration `json:"idleConnTimeout,omitempty" yaml:"idleConnTimeout,omitempty"`
+
+	// RetryAttempts is the total number of attempts, including the first, for
+	// backend requests that fail with a transient transport error such as a
+	// refused or reset connection. Capability listing, resource reads and prompt
+	// requests are retried; tool calls only when the tool is annotated idempotent.
+	// Set to 1 to disable retries. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RetryAttempts int `json:"retryAttempts,omitempty" yaml:"retryAttempts,omitempty"`
+
+	// RetryBackoff is the delay before the first retry. It doubles with every
+	// further retry, up to 2s. Defaults to 100ms.
+	// +optional
+	RetryBackoff Duration `json:"retryBackoff,omitempty" yaml:"retryBackoff,omitempty"`
+}
+
+// WarmupConfig delays readiness until enough backends answer an initial
+// capability query, so the server does not take traffic while its backends
+// are still starting. Backends that are not healthy when the warmup completes
+// are reported degraded but do not hold back readiness.
+// +kubebuilder:object:generate=true
+// +gendoc
+type WarmupConfig struct {
+	// Timeout bounds the warmup. When it elapses the server reports ready even
+	// if fewer than MinHealthyPercent of the backends are healthy.
+	// Defaults to 60s.
+	// +optional
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// MinHealthyPercent is the percentage of backends that must be healthy
+	// before the server reports ready. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinHealthyPercent int `json:"minHealthyPercent,omitempty" yaml:"minHealthyPercent,omitempty"`
+}
+
+// Workflow failure destinations for WorkflowFailuresConfig.Destination.
+const (
+	// WorkflowFailureDestinationLog writes failure records to the server log.
+	WorkflowFailureDestinationLog = "log"
+	// WorkflowFailureDestinationFile appends failure records to a JSON lines file.
+	WorkflowFailureDestinationFile = "file"
+)
+
+// WorkflowFailuresConfig records composite tool workflows that fail or time out,
+// with their parameters, the inputs and outputs of the steps that ran, and the
+// error. Fields listed in audit.redactFields are redacted from the records.
+// +kubebuilder:object:generate=true
+// +gendoc
+type WorkflowFailuresConfig struct {
+	// Destination is where failure records are written: "log" writes them to the
+	// server log, "file" appends them as JSON lines to Path.
+	// +kubebuilder:validation:Enum=log;file
+	// +kubebuilder:validation:Required
+	Destination string `json:"destination" yaml:"destination"`
+
+	// Path is the file failure records are appended to. Required when
+	// Destination is "file".
+	// +optional
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
 }
 
 // TimeoutConfig configures timeout settings.
@@ -620,6 +901,14 @@ type FailureHandlingConfig struct {
 	// +optional
 	UnhealthyThreshold int `json:"unhealthyThreshold,omitempty" yaml:"unhealthyThreshold,omitempty"`
 
+	// HealthyThreshold is the number of consecutive successful health checks a
+	// failing backend needs before it is reported healthy again. Until then it is
+	// reported degraded.
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HealthyThreshold int `json:"healthyThreshold,omitempty" yaml:"healthyThreshold,omitempty"`
+
 	// HealthCheckTimeout is the maximum duration for a single health check operation.
 	// Should be less than HealthCheckInterval to prevent checks from queuing up.
 	// +kubebuilder:default="10s"
@@ -644,6 +933,48 @@ type FailureHandlingConfig struct {
 	// CircuitBreaker configures circuit breaker behavior.
 	// +optional
 	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
+
+	// BackendHealthChecks overrides the health check settings of individual
+	// backends, keyed by backend name. Unset fields inherit the settings above.
+	// +optional
+	BackendHealthChecks map[string]*HealthCheckConfig `json:"backendHealthChecks,omitempty" yaml:"backendHealthChecks,omitempty"`
+}
+
+// HealthCheckConfig configures the health checks of one backend.
+// +kubebuilder:object:generate=true
+// +gendoc
+type HealthCheckConfig struct {
+	// Interval is the interval between health checks of the backend.
+	// +optional
+	Interval Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+
+	// Timeout is the maximum duration of a single health check of the backend.
+	// Must be less than the effective interval.
+	// +optional
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failures before the backend
+	// is marked unhealthy.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnhealthyThreshold int `json:"unhealthyThreshold,omitempty" yaml:"unhealthyThreshold,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successes before a failing
+	// backend is reported healthy again.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HealthyThreshold int `json:"healthyThreshold,omitempty" yaml:"healthyThreshold,omitempty"`
+
+	// Probe is how the backend's health is checked.
+	// - mcp: MCP initialize handshake and capability listing (default)
+	// - http-get: HTTP GET of ProbePath on the backend's origin; any 2xx response is healthy
+	// +kubebuilder:validation:Enum=mcp;http-get
+	// +optional
+	Probe string `json:"probe,omitempty" yaml:"probe,omitempty"`
+
+	// ProbePath is the path requested by the http-get probe. Defaults to /health.
+	// +optional
+	ProbePath string `json:"probePath,omitempty" yaml:"probePath,omitempty"`
 }
 
 // CircuitBreakerConfig configures circuit breaker behavior.
@@ -711,6 +1042,36 @@ type CompositeToolConfig struct {
 	// If not specified, the workflow returns the last step's output (backward compatible).
 	// +optional
 	Output *OutputConfig `json:"output,omitempty" yaml:"output,omitempty"`
+
+	// SkipBackendHealthCheck disables the check, made before the workflow starts,
+	// that every backend referenced by its tool steps is available.
+	// +optional
+	SkipBackendHealthCheck bool `json:"skipBackendHealthCheck,omitempty" yaml:"skipBackendHealthCheck,omitempty"`
+
+	// Join defines how the parallel branches of each execution level are joined.
+	// If not specified, every branch must complete.
+	// +optional
+	Join *JoinConfig `json:"join,omitempty" yaml:"join,omitempty"`
+}
+
+// JoinConfig defines how the parallel branches of a composite tool workflow are joined.
+// +kubebuilder:object:generate=true
+// +gendoc
+type JoinConfig struct {
+	// Strategy is the join strategy: "all" waits for every branch, "any" completes on
+	// the first branch that succeeds, and "n-of-m" completes once N branches succeed.
+	// Branches still running when a level completes are cancelled.
+	// +kubebuilder:validation:Enum=all;any;n-of-m
+	// +kubebuilder:default=all
+	// +optional
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+
+	// N is the number of branches that must succeed for the n-of-m strategy.
+	// It must be between 1 and the number of branches of the widest level;
+	// levels with fewer branches require all of them.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	N int `json:"n,omitempty" yaml:"n,omitempty"`
 }
 
 // CompositeToolRef defines a reference to a VirtualMCPCompositeToolDefinition resource.
@@ -733,13 +1094,14 @@ type WorkflowStepConfig struct {
 	ID string `json:"id" yaml:"id"`
 
 	// Type is the step type (tool, elicitation, etc.)
-	// +kubebuilder:validation:Enum=tool;elicitation;forEach
+	// +kubebuilder:validation:Enum=tool;elicitation;forEach;composite
 	// +kubebuilder:default=tool
 	// +optional
 	Type string `json:"type,omitempty" yaml:"type,omitempty"`
 
 	// Tool is the tool to call (format: "workload.tool_name")
-	// Only used when Type is "tool"
+	// Only used when Type is "tool", or, when Type is "composite", the name
+	// of the composite tool to invoke
 	// +optional
 	Tool string `json:"tool,omitempty" yaml:"tool,omitempty"`
 
@@ -789,6 +1151,15 @@ type WorkflowStepConfig struct {
 	// +optional
 	OnCancel *ElicitationResponseConfig `json:"onCancel,omitempty" yaml:"onCancel,omitempty"`
 
+	// TimeoutDefault is the response content used when the user does not respond
+	// to the elicitation before Timeout. The step then completes with action "timeout"
+	// and this content. Without it, a timeout is handled by OnCancel, or fails the step.
+	// Only used when Type is "elicitation"
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	TimeoutDefault thvjson.Map `json:"timeoutDefault,omitempty" yaml:"timeoutDefault,omitempty"`
+
 	// DefaultResults provides fallback output values when this step is skipped
 	// (due to condition evaluating to false) or fails (when onError.action is "continue").
 	// Each key corresponds to an output field name referenced by downstream steps.
@@ -983,6 +1354,40 @@ type OptimizerConfig struct {
 	// +optional
 	EmbeddingHeaders map[string]EmbeddingHeaderValue `json:"embeddingHeaders,omitempty" yaml:"embeddingHeaders,omitempty"`
 
+	// EmbeddingCachePath is the path of a SQLite file in which computed
+	// embeddings are cached, so a restarted vMCP server does not re-embed tools
+	// whose name and description are unchanged. Entries are keyed by text,
+	// embedding model and dimension, and are discarded when the model or
+	// dimension changes. When empty, embeddings are cached in memory only.
+	// In Kubernetes, point this at a path on a persistent volume.
+	// +optional
+	EmbeddingCachePath string `json:"embeddingCachePath,omitempty" yaml:"embeddingCachePath,omitempty"`
+
+	// EmbeddingCacheMaxEntries is the maximum number of cached embeddings. The
+	// least recently used entries are evicted beyond it.
+	// Defaults to 10000 if not specified or zero.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EmbeddingCacheMaxEntries int `json:"embeddingCacheMaxEntries,omitempty" yaml:"embeddingCacheMaxEntries,omitempty"`
+
+	// RerankService is the full base URL of a HuggingFace Text Embeddings
+	// Inference server running a cross-encoder (re-ranker) model. When set,
+	// the top RerankTopK search candidates are re-scored against the query
+	// with it and returned in re-ranked order. If re-ranking fails, the
+	// search order is kept. Requests use EmbeddingServiceTimeout.
+	// When empty, re-ranking is disabled.
+	// +optional
+	RerankService string `json:"rerankService,omitempty" yaml:"rerankService,omitempty"`
+
+	// RerankTopK is the number of search candidates re-ranked when
+	// RerankService is set. It bounds the cost of re-ranking; values below
+	// MaxToolsToReturn are raised to it.
+	// Defaults to 20 if not specified or zero.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	RerankTopK int `json:"rerankTopK,omitempty" yaml:"rerankTopK,omitempty"`
+
 	// MaxToolsToReturn is the maximum number of tool results returned by a search query.
 	// Defaults to 8 if not specified or zero.
 	// +kubebuilder:validation:Minimum=1
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stacklok/toolhive-core/env"
+)
+
+// envReferencePattern matches ${VAR} and ${VAR:-default} references, and the
+// $${ escape for a literal ${.
+var envReferencePattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// interpolateEnv replaces ${VAR} and ${VAR:-default} references in the string
+// values of a YAML document with environment variables:
+//   - ${VAR} is the value of VAR, which must be set (it may be empty).
+//   - ${VAR:-default} is the value of VAR, or default when VAR is unset or empty.
+//   - $${ is a literal ${.
+//
+// Interpolation applies to values only, after parsing, so an environment value
+// cannot change the structure of the document. An unquoted value is re-typed
+// after interpolation, so "port: ${PORT}" yields an integer. Returns data
+// unchanged when it holds no references.
+func interpolateEnv(data []byte, envReader env.Reader) ([]byte, error) {
+	if !bytes.Contains(data, []byte("${")) {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	var errs []error
+	interpolateNode(&doc, envReader, &errs)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode interpolated YAML: %w", err)
+	}
+	return out, nil
+}
+
+// interpolateNode interpolates the scalar values under node, appending an error
+// to errs for every reference to an unset variable without a default.
+func interpolateNode(node *yaml.Node, envReader env.Reader, errs *[]error) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			interpolateNode(child, envReader, errs)
+		}
+	case yaml.MappingNode:
+		// Content alternates keys and values; keys are never interpolated.
+		for i := 1; i < len(node.Content); i += 2 {
+			interpolateNode(node.Content[i], envReader, errs)
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "${") {
+			return
+		}
+		node.Value = envReferencePattern.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			if ref == "$${" {
+				return "${"
+			}
+			match := envReferencePattern.FindStringSubmatch(ref)
+			name, hasDefault, fallback := match[1], strings.Contains(ref, ":-"), match[2]
+			value, ok := envReader.LookupEnv(name)
+			switch {
+			case hasDefault && value == "":
+				return fallback
+			case !ok:
+				*errs = append(*errs, fmt.Errorf("line %d: environment variable %s is not set", node.Line, name))
+				return ""
+			default:
+				return value
+			}
+		})
+		if node.Style == 0 {
+			// Let the plain value resolve to its own type (int, bool, ...).
+			node.Tag = ""
+		}
+	case yaml.AliasNode:
+		// Aliases share the anchored node, which is interpolated where it is defined.
+	}
+}
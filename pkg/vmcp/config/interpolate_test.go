@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestYAMLLoader_EnvInterpolation(t *testing.T) {
+	t.Parallel()
+
+	const base = `
+name: ${VMCP_NAME}
+groupRef: test-group
+incomingAuth:
+  type: anonymous
+outgoingAuth:
+  source: inline
+aggregation:
+  conflictResolution: prefix
+  conflictResolutionConfig:
+    prefixFormat: "{workload}_"
+`
+
+	tests := []struct {
+		name    string
+		yaml    string
+		envVars map[string]string
+		want    func(*testing.T, *Config)
+		wantErr string
+	}{
+		{
+			name: "defined variables are substituted",
+			yaml: base + `
+backends:
+  - name: github
+    url: http://${BACKEND_HOST}:${BACKEND_PORT}/mcp
+    transport: streamable-http
+`,
+			envVars: map[string]string{"VMCP_NAME": "from-env", "BACKEND_HOST": "10.0.0.5", "BACKEND_PORT": "9090"},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.Name != "from-env" {
+					t.Errorf("Name = %q, want from-env", cfg.Name)
+				}
+				if len(cfg.Backends) != 1 || cfg.Backends[0].URL != "http://10.0.0.5:9090/mcp" {
+					t.Errorf("Backends = %+v, want URL http://10.0.0.5:9090/mcp", cfg.Backends)
+				}
+			},
+		},
+		{
+			name:    "undefined variable is an error",
+			yaml:    base,
+			wantErr: "line 2: environment variable VMCP_NAME is not set",
+		},
+		{
+			name: "every undefined variable is reported",
+			yaml: base + `
+operational:
+  timeouts:
+    default: ${DEFAULT_TIMEOUT}
+`,
+			wantErr: "environment variable DEFAULT_TIMEOUT is not set",
+		},
+		{
+			name:    "variable defined as empty is substituted",
+			yaml:    strings.Replace(base, "groupRef: test-group", "groupRef: test-group${SUFFIX}", 1),
+			envVars: map[string]string{"VMCP_NAME": "from-env", "SUFFIX": ""},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.Group != "test-group" {
+					t.Errorf("Group = %q, want test-group", cfg.Group)
+				}
+			},
+		},
+		{
+			name: "default is used for undefined and empty variables",
+			yaml: strings.Replace(base, "${VMCP_NAME}", "${VMCP_NAME:-default-vmcp}", 1) + `
+operational:
+  timeouts:
+    default: ${DEFAULT_TIMEOUT:-45s}
+`,
+			envVars: map[string]string{"DEFAULT_TIMEOUT": ""},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.Name != "default-vmcp" {
+					t.Errorf("Name = %q, want default-vmcp", cfg.Name)
+				}
+				if got := time.Duration(cfg.Operational.Timeouts.Default); got != 45*time.Second {
+					t.Errorf("Timeouts.Default = %v, want 45s", got)
+				}
+			},
+		},
+		{
+			name:    "defined variable takes precedence over default",
+			yaml:    strings.Replace(base, "${VMCP_NAME}", "${VMCP_NAME:-default-vmcp}", 1),
+			envVars: map[string]string{"VMCP_NAME": "from-env"},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.Name != "from-env" {
+					t.Errorf("Name = %q, want from-env", cfg.Name)
+				}
+			},
+		},
+		{
+			name: "unquoted values are re-typed",
+			yaml: base + `
+operational:
+  failureHandling:
+    healthCheckInterval: 30s
+    unhealthyThreshold: ${UNHEALTHY_THRESHOLD}
+    partialFailureMode: fail
+`,
+			envVars: map[string]string{"VMCP_NAME": "from-env", "UNHEALTHY_THRESHOLD": "5"},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if got := cfg.Operational.FailureHandling.UnhealthyThreshold; got != 5 {
+					t.Errorf("UnhealthyThreshold = %d, want 5", got)
+				}
+			},
+		},
+		{
+			name:    "values cannot change the document structure",
+			yaml:    base,
+			envVars: map[string]string{"VMCP_NAME": "evil\ngroupRef: other-group"},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.Name != "evil\ngroupRef: other-group" || cfg.Group != "test-group" {
+					t.Errorf("Name, Group = %q, %q; want the value kept as the name", cfg.Name, cfg.Group)
+				}
+			},
+		},
+		{
+			name:    "escaped reference is kept literally",
+			yaml:    strings.Replace(base, "${VMCP_NAME}", `"$${VMCP_NAME}"`, 1),
+			envVars: map[string]string{"VMCP_NAME": "from-env"},
+			want: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.Name != "${VMCP_NAME}" {
+					t.Errorf("Name = %q, want ${VMCP_NAME}", cfg.Name)
+				}
+			},
+		},
+		{
+			name:    "interpolated values are validated",
+			yaml:    strings.Replace(base, "conflictResolution: prefix", "conflictResolution: ${STRATEGY}", 1),
+			envVars: map[string]string{"VMCP_NAME": "from-env", "STRATEGY": "bogus"},
+			wantErr: "conflictResolution",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader := NewYAMLReaderLoader(strings.NewReader(tt.yaml), createMockEnvReader(t, tt.envVars))
+			cfg, err := loader.Load()
+			if err == nil && tt.wantErr != "" {
+				err = NewValidator().Validate(cfg)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error = %v", err)
+			}
+			tt.want(t, cfg)
+		})
+	}
+}
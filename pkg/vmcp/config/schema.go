@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stacklok/toolhive/pkg/configschema"
+	thvjson "github.com/stacklok/toolhive/pkg/json"
+)
+
+// durationPattern matches Go duration strings such as "30s" or "1h30m".
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// JSONSchema returns the JSON Schema of the vMCP configuration file, for
+// editor validation and autocompletion. Unknown fields are disallowed, as
+// they are by the YAML loader.
+func JSONSchema() (map[string]any, error) {
+	return configschema.Generate(reflect.TypeFor[Config](), configschema.Options{
+		Title:  "Virtual MCP Server configuration",
+		Strict: true,
+		TypeSchemas: map[reflect.Type]map[string]any{
+			reflect.TypeFor[Duration]():        {"type": "string", "pattern": durationPattern},
+			reflect.TypeFor[metav1.Duration](): {"type": "string", "pattern": durationPattern},
+			reflect.TypeFor[thvjson.Map]():     {"type": "object"},
+			reflect.TypeFor[thvjson.Any]():     {},
+		},
+	})
+}
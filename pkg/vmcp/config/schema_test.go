@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+func TestJSONSchema_CoversEveryField(t *testing.T) {
+	t.Parallel()
+
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	definitions, _ := schema["definitions"].(map[string]any)
+
+	// resolve follows a $ref to its definition.
+	resolve := func(s map[string]any) map[string]any {
+		if ref, ok := s["$ref"].(string); ok {
+			def, _ := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]any)
+			return def
+		}
+		return s
+	}
+
+	visited := map[reflect.Type]bool{}
+	var checkStruct func(path string, typ reflect.Type, s map[string]any)
+	var checkValue func(path string, typ reflect.Type, s map[string]any)
+
+	checkValue = func(path string, typ reflect.Type, s map[string]any) {
+		if typ.Kind() == reflect.Pointer {
+			// Pointers also accept null: {"anyOf": [<ref>, null]} or {"type": [<type>, "null"]}.
+			typ = typ.Elem()
+			if anyOf, ok := s["anyOf"].([]any); ok {
+				s, _ = anyOf[0].(map[string]any)
+			} else if types, ok := s["type"].([]any); ok {
+				s = maps.Clone(s)
+				s["type"] = types[0]
+			}
+		}
+		s = resolve(s)
+		if s == nil {
+			t.Errorf("%s: unresolved schema reference", path)
+			return
+		}
+		for _, method := range []string{"UnmarshalYAML", "UnmarshalJSON"} {
+			if _, custom := reflect.PointerTo(typ).MethodByName(method); custom {
+				// Custom forms (Duration, json.Map, ...) come from TypeSchemas.
+				return
+			}
+		}
+
+		wantType := map[reflect.Kind]string{
+			reflect.String: "string", reflect.Bool: "boolean",
+			reflect.Int: "integer", reflect.Int32: "integer", reflect.Int64: "integer",
+			reflect.Float64: "number", reflect.Slice: "array", reflect.Map: "object", reflect.Struct: "object",
+		}[typ.Kind()]
+		if wantType != "" && s["type"] != wantType {
+			t.Errorf("%s: schema type = %v, want %s", path, s["type"], wantType)
+			return
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			checkStruct(path, typ, s)
+		case reflect.Slice:
+			items, _ := s["items"].(map[string]any)
+			checkValue(path+"[]", typ.Elem(), items)
+		case reflect.Map:
+			values, _ := s["additionalProperties"].(map[string]any)
+			checkValue(path+"{}", typ.Elem(), values)
+		}
+	}
+
+	checkStruct = func(path string, typ reflect.Type, s map[string]any) {
+		if visited[typ] {
+			return
+		}
+		visited[typ] = true
+		if s["additionalProperties"] != false {
+			t.Errorf("%s: unknown properties are not disallowed", path)
+		}
+		properties, _ := s["properties"].(map[string]any)
+		for field := range typ.Fields() {
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				t.Errorf("%s.%s: field has no yaml name", path, field.Name)
+				continue
+			}
+			prop, ok := properties[name].(map[string]any)
+			if !ok {
+				t.Errorf("%s: schema is missing property %q", path, name)
+				continue
+			}
+			checkValue(path+"."+name, field.Type, prop)
+		}
+	}
+
+	checkStruct("config", reflect.TypeFor[Config](), schema)
+	if len(visited) < 10 {
+		t.Errorf("only %d config types were checked, want the nested types to be covered", len(visited))
+	}
+}
+
+func TestJSONSchema_ValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	example, err := os.ReadFile("../../../examples/vmcp-config.yaml")
+	if err != nil {
+		t.Fatalf("failed to read example config: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		yaml      string
+		wantValid bool
+	}{
+		{name: "example config", yaml: string(example), wantValid: true},
+		{name: "unknown field", yaml: "name: test\ngroupRef: group\nunknownField: true\n"},
+		{name: "wrong type", yaml: "name: test\ngroupRef: group\nbackends: github\n"},
+		{name: "invalid duration", yaml: "name: test\ngroupRef: group\noperational:\n  timeouts:\n    default: soon\n"},
+	}
+
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to encode schema: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var document any
+			if err := yaml.Unmarshal([]byte(tt.yaml), &document); err != nil {
+				t.Fatalf("failed to parse YAML: %v", err)
+			}
+			documentJSON, err := json.Marshal(document)
+			if err != nil {
+				t.Fatalf("failed to encode document: %v", err)
+			}
+
+			result, err := gojsonschema.Validate(
+				gojsonschema.NewBytesLoader(schemaJSON),
+				gojsonschema.NewBytesLoader(documentJSON),
+			)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if result.Valid() != tt.wantValid {
+				t.Errorf("Valid() = %v, want %v (errors: %v)", result.Valid(), tt.wantValid, result.Errors())
+			}
+		})
+	}
+}
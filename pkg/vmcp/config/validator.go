@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 	"path/filepath"
 	"slices"
@@ -77,6 +78,11 @@ func (v *DefaultValidator) Validate(cfg *Config) error {
 		errors = append(errors, err.Error())
 	}
 
+	// Validate per-backend TLS
+	if err := v.validateBackendTLS(cfg.BackendTLS); err != nil {
+		errors = append(errors, err.Error())
+	}
+
 	// Validate composite tools
 	if err := v.validateCompositeTools(cfg.CompositeTools); err != nil {
 		errors = append(errors, err.Error())
@@ -121,6 +127,10 @@ func (*DefaultValidator) validateStaticBackends(backends []StaticBackendConfig)
 			return fmt.Errorf("backends[%d].type must be empty or %q, got %q", i, vmcp.BackendTypeEntry, b.Type)
 		}
 
+		if err := validateStdioBackend(i, b); err != nil {
+			return err
+		}
+
 		// CABundlePath is only valid for entry backends
 		if b.CABundlePath != "" && b.Type != string(vmcp.BackendTypeEntry) {
 			return fmt.Errorf("backends[%d].caBundlePath is only valid when type is %q", i, vmcp.BackendTypeEntry)
@@ -139,6 +149,80 @@ func (*DefaultValidator) validateStaticBackends(backends []StaticBackendConfig)
 	return nil
 }
 
+// validateStdioBackend checks the stdio fields of a static backend: a stdio
+// backend runs its command instead of connecting to a URL, and no other
+// transport takes a command.
+func validateStdioBackend(i int, b StaticBackendConfig) error {
+	if b.Transport != TransportStdio {
+		if b.Command != "" || len(b.Args) > 0 || len(b.Env) > 0 {
+			return fmt.Errorf("backends[%d].command, args and env are only valid when transport is %q", i, TransportStdio)
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(b.Command) == "" {
+		return fmt.Errorf("backends[%d].command is required when transport is %q", i, TransportStdio)
+	}
+	if b.URL != "" {
+		return fmt.Errorf("backends[%d].url must be empty when transport is %q", i, TransportStdio)
+	}
+	if b.Type != "" {
+		return fmt.Errorf("backends[%d].type must be empty when transport is %q", i, TransportStdio)
+	}
+	for _, name := range slices.Sorted(maps.Keys(b.Env)) {
+		if name == "" || strings.ContainsAny(name, "=\x00") {
+			return fmt.Errorf("backends[%d].env has an invalid variable name %q", i, name)
+		}
+	}
+	return nil
+}
+
+func (*DefaultValidator) validateBackendTLS(backends map[string]*BackendTLSConfig) error {
+	for _, name := range slices.Sorted(maps.Keys(backends)) {
+		tlsCfg := backends[name]
+		if tlsCfg == nil {
+			continue
+		}
+		if name == "" {
+			return fmt.Errorf("backendTLS keys must not be empty")
+		}
+
+		paths := []struct {
+			field string
+			path  string
+		}{
+			{"caBundlePath", tlsCfg.CABundlePath},
+			{"clientCertPath", tlsCfg.ClientCertPath},
+			{"clientKeyPath", tlsCfg.ClientKeyPath},
+		}
+		for _, p := range paths {
+			if p.path == "" {
+				continue
+			}
+			// Reject null bytes, path traversal, and relative paths
+			if strings.ContainsRune(p.path, 0) || strings.Contains(p.path, "..") {
+				return fmt.Errorf("backendTLS[%s].%s contains invalid path characters", name, p.field)
+			}
+			if !filepath.IsAbs(p.path) {
+				return fmt.Errorf("backendTLS[%s].%s must be an absolute path", name, p.field)
+			}
+		}
+
+		if tlsCfg.ClientCertPath != "" && tlsCfg.ClientCertSecret != "" {
+			return fmt.Errorf("backendTLS[%s]: only one of clientCertPath or clientCertSecret must be set", name)
+		}
+		if tlsCfg.ClientKeyPath != "" && tlsCfg.ClientKeySecret != "" {
+			return fmt.Errorf("backendTLS[%s]: only one of clientKeyPath or clientKeySecret must be set", name)
+		}
+		hasCert := tlsCfg.ClientCertPath != "" || tlsCfg.ClientCertSecret != ""
+		hasKey := tlsCfg.ClientKeyPath != "" || tlsCfg.ClientKeySecret != ""
+		if hasCert != hasKey {
+			return fmt.Errorf("backendTLS[%s]: client certificate and key must be set together", name)
+		}
+	}
+	return nil
+}
+
 func (v *DefaultValidator) validateIncomingAuth(auth *IncomingAuthConfig) error {
 	if auth == nil {
 		return fmt.Errorf("incomingAuth is required")
@@ -350,6 +434,14 @@ func (v *DefaultValidator) validateAggregation(agg *AggregationConfig) error {
 		return err
 	}
 
+	if agg.MaxConcurrentQueries < 0 {
+		return fmt.Errorf("maxConcurrentQueries must be positive")
+	}
+
+	if agg.ToolTimeout < 0 {
+		return fmt.Errorf("toolTimeout must be positive")
+	}
+
 	return v.validateToolConfigurations(agg.Tools)
 }
 
@@ -362,8 +454,17 @@ func (*DefaultValidator) validateConflictStrategy(agg *AggregationConfig) error
 		}
 
 	case vmcp.ConflictStrategyPriority:
-		if len(agg.ConflictResolutionConfig.PriorityOrder) == 0 {
-			return fmt.Errorf("priorityOrder is required for priority strategy")
+		resConfig := agg.ConflictResolutionConfig
+		if len(resConfig.PriorityOrder) > 0 && len(resConfig.BackendPriorities) > 0 {
+			return fmt.Errorf("priorityOrder and backendPriorities are mutually exclusive")
+		}
+		if len(resConfig.PriorityOrder) == 0 && len(resConfig.BackendPriorities) == 0 {
+			return fmt.Errorf("priorityOrder or backendPriorities is required for priority strategy")
+		}
+		for workload := range resConfig.BackendPriorities {
+			if workload == "" {
+				return fmt.Errorf("backendPriorities contains an empty workload name")
+			}
 		}
 
 	case vmcp.ConflictStrategyManual:
@@ -392,6 +493,28 @@ func (v *DefaultValidator) validateToolConfigurations(tools []*WorkloadToolConfi
 		if err := v.validateToolOverrides(tool.Overrides, i); err != nil {
 			return err
 		}
+
+		if err := v.validateToolAliases(tool, i); err != nil {
+			return err
+		}
+
+		for toolName, defaults := range tool.ParameterDefaults {
+			if _, hasEmpty := defaults.Value[""]; hasEmpty {
+				return fmt.Errorf("tools[%d].parameterDefaults.%s: parameter name must not be empty", i, toolName)
+			}
+		}
+
+		for toolName, timeout := range tool.ToolTimeouts {
+			if timeout <= 0 {
+				return fmt.Errorf("tools[%d].toolTimeouts.%s must be positive", i, toolName)
+			}
+		}
+
+		if tool.DescriptionTemplate != "" {
+			if _, err := ParseDescriptionTemplate(tool.DescriptionTemplate); err != nil {
+				return fmt.Errorf("tools[%d].descriptionTemplate is invalid: %w", i, err)
+			}
+		}
 	}
 
 	return nil
@@ -407,6 +530,38 @@ func (*DefaultValidator) validateToolOverrides(overrides map[string]*ToolOverrid
 	return nil
 }
 
+// validateToolAliases validates the aliases of a workload. An alias must not
+// reuse a name the workload's tools are already known by, whether a tool name
+// that is itself aliased or overridden, or the new name of an override.
+func (*DefaultValidator) validateToolAliases(tool *WorkloadToolConfig, toolIndex int) error {
+	knownNames := make(map[string]bool, len(tool.Aliases)+len(tool.Overrides))
+	for toolName := range tool.Aliases {
+		knownNames[toolName] = true
+	}
+	for toolName, override := range tool.Overrides {
+		knownNames[toolName] = true
+		if override != nil && override.Name != "" {
+			knownNames[override.Name] = true
+		}
+	}
+
+	aliasedBy := make(map[string]string, len(tool.Aliases))
+	for _, toolName := range slices.Sorted(maps.Keys(tool.Aliases)) {
+		alias := tool.Aliases[toolName]
+		if alias == "" {
+			return fmt.Errorf("tools[%d].aliases.%s: alias must not be empty", toolIndex, toolName)
+		}
+		if knownNames[alias] {
+			return fmt.Errorf("tools[%d].aliases.%s: alias %q collides with a tool name", toolIndex, toolName, alias)
+		}
+		if other, exists := aliasedBy[alias]; exists {
+			return fmt.Errorf("tools[%d].aliases.%s: alias %q is already used by %s", toolIndex, toolName, alias, other)
+		}
+		aliasedBy[alias] = toolName
+	}
+	return nil
+}
+
 func (v *DefaultValidator) validateOperational(ops *OperationalConfig) error {
 	if ops == nil {
 		return nil // Operational config is optional (defaults apply)
@@ -432,6 +587,56 @@ func (v *DefaultValidator) validateOperational(ops *OperationalConfig) error {
 		}
 	}
 
+	// Validate workflow limits (zero selects the default)
+	if ops.WorkflowLimits != nil {
+		if ops.WorkflowLimits.MaxSteps < 0 {
+			return fmt.Errorf("operational.workflowLimits.maxSteps must be positive")
+		}
+		if ops.WorkflowLimits.MaxCompositionDepth < 0 {
+			return fmt.Errorf("operational.workflowLimits.maxCompositionDepth must be positive")
+		}
+	}
+
+	// Validate workflow failure recording
+	if ops.WorkflowFailures != nil {
+		switch ops.WorkflowFailures.Destination {
+		case WorkflowFailureDestinationLog:
+		case WorkflowFailureDestinationFile:
+			if ops.WorkflowFailures.Path == "" {
+				return fmt.Errorf("operational.workflowFailures.path is required when destination is %q",
+					WorkflowFailureDestinationFile)
+			}
+		default:
+			return fmt.Errorf("operational.workflowFailures.destination must be one of: %s, %s",
+				WorkflowFailureDestinationLog, WorkflowFailureDestinationFile)
+		}
+	}
+
+	// Validate backend client tuning (zero selects the default)
+	if bc := ops.BackendClient; bc != nil {
+		if bc.MaxIdleConns < 0 || bc.MaxIdleConnsPerHost < 0 || bc.RetryAttempts < 0 {
+			return fmt.Errorf("operational.backendClient: maxIdleConns, maxIdleConnsPerHost and retryAttempts must be positive")
+		}
+		if bc.IdleConnTimeout < 0 || bc.RetryBackoff < 0 {
+			return fmt.Errorf("operational.backendClient: idleConnTimeout and retryBackoff must not be negative")
+		}
+	}
+
+	// Validate backend warmup (zero selects the default)
+	if wu := ops.Warmup; wu != nil {
+		if wu.Timeout < 0 {
+			return fmt.Errorf("operational.warmup.timeout must not be negative")
+		}
+		if wu.MinHealthyPercent < 0 || wu.MinHealthyPercent > 100 {
+			return fmt.Errorf("operational.warmup.minHealthyPercent must be between 1 and 100")
+		}
+	}
+
+	// Validate shutdown grace period (zero selects the default)
+	if ops.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("operational.shutdownGracePeriod must not be negative")
+	}
+
 	return nil
 }
 
@@ -444,6 +649,11 @@ func (*DefaultValidator) validateFailureHandling(fh *FailureHandlingConfig) erro
 		return fmt.Errorf("unhealthyThreshold must be positive")
 	}
 
+	// Zero means the default healthy threshold
+	if fh.HealthyThreshold < 0 {
+		return fmt.Errorf("healthyThreshold must be positive, got %d", fh.HealthyThreshold)
+	}
+
 	// Validate health check timeout
 	// Zero means no timeout (not recommended but valid)
 	// Negative values are invalid
@@ -486,6 +696,64 @@ func (*DefaultValidator) validateFailureHandling(fh *FailureHandlingConfig) erro
 		}
 	}
 
+	return validateBackendHealthChecks(fh)
+}
+
+// validateBackendHealthChecks validates the per-backend health check overrides.
+// Unset fields inherit the failure handling settings, which are used to check
+// that each backend's effective timeout is less than its effective interval.
+func validateBackendHealthChecks(fh *FailureHandlingConfig) error {
+	validProbes := []string{"mcp", "http-get"}
+
+	for _, name := range slices.Sorted(maps.Keys(fh.BackendHealthChecks)) {
+		hc := fh.BackendHealthChecks[name]
+		if name == "" {
+			return fmt.Errorf("backendHealthChecks: backend name is required")
+		}
+		if hc == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("backendHealthChecks[%s]", name)
+
+		interval := time.Duration(hc.Interval)
+		timeout := time.Duration(hc.Timeout)
+		if interval < 0 {
+			return fmt.Errorf("%s.interval must be positive, got %v", prefix, interval)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("%s.timeout must be positive, got %v", prefix, timeout)
+		}
+		if interval == 0 {
+			interval = time.Duration(fh.HealthCheckInterval)
+		}
+		if timeout == 0 {
+			timeout = time.Duration(fh.HealthCheckTimeout)
+		}
+		if timeout > 0 && timeout >= interval {
+			return fmt.Errorf("%s: timeout (%v) must be less than interval (%v) to prevent checks from queuing up",
+				prefix, timeout, interval)
+		}
+
+		if hc.UnhealthyThreshold < 0 {
+			return fmt.Errorf("%s.unhealthyThreshold must be positive, got %d", prefix, hc.UnhealthyThreshold)
+		}
+		if hc.HealthyThreshold < 0 {
+			return fmt.Errorf("%s.healthyThreshold must be positive, got %d", prefix, hc.HealthyThreshold)
+		}
+
+		if hc.Probe != "" && !slices.Contains(validProbes, hc.Probe) {
+			return fmt.Errorf("%s.probe must be one of: %s", prefix, strings.Join(validProbes, ", "))
+		}
+		if hc.ProbePath != "" {
+			if hc.Probe != "http-get" {
+				return fmt.Errorf("%s.probePath requires probe http-get", prefix)
+			}
+			if !strings.HasPrefix(hc.ProbePath, "/") {
+				return fmt.Errorf("%s.probePath must start with /, got %q", prefix, hc.ProbePath)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -581,7 +581,29 @@ func TestValidator_ValidateAggregation(t *testing.T) {
 				ConflictResolutionConfig: &ConflictResolutionConfig{},
 			},
 			wantErr: true,
-			errMsg:  "priorityOrder is required",
+			errMsg:  "priorityOrder or backendPriorities is required",
+		},
+		{
+			name: "valid priority strategy with backend priorities",
+			agg: &AggregationConfig{
+				ConflictResolution: vmcp.ConflictStrategyPriority,
+				ConflictResolutionConfig: &ConflictResolutionConfig{
+					BackendPriorities: map[string]int{"github": 10, "jira": 5},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "priority order and backend priorities together",
+			agg: &AggregationConfig{
+				ConflictResolution: vmcp.ConflictStrategyPriority,
+				ConflictResolutionConfig: &ConflictResolutionConfig{
+					PriorityOrder:     []string{"github"},
+					BackendPriorities: map[string]int{"github": 10},
+				},
+			},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
 		},
 		{
 			name: "manual strategy missing overrides",
@@ -592,6 +614,169 @@ func TestValidator_ValidateAggregation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "tool overrides are required",
 		},
+		{
+			name: "valid description template",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", DescriptionTemplate: "[{{.BackendName}}] {{.OriginalDescription}}"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "description template with invalid syntax",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", DescriptionTemplate: "{{.BackendName"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "tools[0].descriptionTemplate is invalid",
+		},
+		{
+			name: "description template with unknown field",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", DescriptionTemplate: "{{.Backend}}"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "tools[0].descriptionTemplate is invalid",
+		},
+		{
+			name: "valid tool aliases",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", Aliases: map[string]string{"search_issues_and_pull_requests": "search_issues"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty tool alias",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", Aliases: map[string]string{"search": ""}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "tools[0].aliases.search: alias must not be empty",
+		},
+		{
+			name: "tool alias collides with an aliased tool name",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", Aliases: map[string]string{"search_code": "search", "search": "find"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  `tools[0].aliases.search_code: alias "search" collides with a tool name`,
+		},
+		{
+			name: "tool alias collides with an override name",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{
+						Workload:  "github",
+						Overrides: map[string]*ToolOverride{"create_issue": {Name: "new_issue"}},
+						Aliases:   map[string]string{"search_issues": "new_issue"},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `alias "new_issue" collides with a tool name`,
+		},
+		{
+			name: "duplicate tool alias",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", Aliases: map[string]string{"search_code": "search", "search_issues": "search"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  `tools[0].aliases.search_issues: alias "search" is already used by search_code`,
+		},
+		{
+			name: "valid parameter defaults",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", ParameterDefaults: map[string]thvjson.Map{
+						"search_issues": thvjson.NewMap(map[string]any{"org": "acme"}),
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "parameter default with empty parameter name",
+			agg: &AggregationConfig{
+				ConflictResolution:       vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{PrefixFormat: "{workload}_"},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "github", ParameterDefaults: map[string]thvjson.Map{
+						"search_issues": thvjson.NewMap(map[string]any{"": "acme"}),
+					}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "tools[0].parameterDefaults.search_issues: parameter name must not be empty",
+		},
+		{
+			name: "negative max concurrent queries",
+			agg: &AggregationConfig{
+				ConflictResolution: vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{
+					PrefixFormat: "{workload}_",
+				},
+				MaxConcurrentQueries: -1,
+			},
+			wantErr: true,
+			errMsg:  "maxConcurrentQueries must be positive",
+		},
+		{
+			name: "negative tool timeout",
+			agg: &AggregationConfig{
+				ConflictResolution: vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{
+					PrefixFormat: "{workload}_",
+				},
+				ToolTimeout: Duration(-time.Second),
+			},
+			wantErr: true,
+			errMsg:  "toolTimeout must be positive",
+		},
+		{
+			name: "zero per-tool timeout",
+			agg: &AggregationConfig{
+				ConflictResolution: vmcp.ConflictStrategyPrefix,
+				ConflictResolutionConfig: &ConflictResolutionConfig{
+					PrefixFormat: "{workload}_",
+				},
+				Tools: []*WorkloadToolConfig{
+					{Workload: "search", ToolTimeouts: map[string]Duration{"deep_search": 0}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "tools[0].toolTimeouts.deep_search must be positive",
+		},
 	}
 
 	for _, tt := range tests {
@@ -992,6 +1177,103 @@ func TestValidator_ValidateFailureHandling(t *testing.T) {
 			wantErr: true,
 			errMsg:  "unhealthyThreshold must be positive",
 		},
+		{
+			name: "negative healthy threshold",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				UnhealthyThreshold:  3,
+				HealthyThreshold:    -1,
+				PartialFailureMode:  "fail",
+			},
+			wantErr: true,
+			errMsg:  "healthyThreshold must be positive",
+		},
+		{
+			name: "valid per-backend health checks",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				HealthCheckTimeout:  Duration(10 * time.Second),
+				UnhealthyThreshold:  3,
+				HealthyThreshold:    2,
+				PartialFailureMode:  "fail",
+				BackendHealthChecks: map[string]*HealthCheckConfig{
+					"github": {
+						Interval:           Duration(60 * time.Second),
+						Timeout:            Duration(20 * time.Second),
+						UnhealthyThreshold: 5,
+						HealthyThreshold:   3,
+					},
+					"legacy": {Probe: "http-get", ProbePath: "/healthz"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "per-backend interval not greater than inherited timeout",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				HealthCheckTimeout:  Duration(10 * time.Second),
+				UnhealthyThreshold:  3,
+				PartialFailureMode:  "fail",
+				BackendHealthChecks: map[string]*HealthCheckConfig{
+					"github": {Interval: Duration(5 * time.Second)},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backendHealthChecks[github]: timeout (10s) must be less than interval (5s)",
+		},
+		{
+			name: "per-backend negative threshold",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				UnhealthyThreshold:  3,
+				PartialFailureMode:  "fail",
+				BackendHealthChecks: map[string]*HealthCheckConfig{
+					"github": {HealthyThreshold: -2},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backendHealthChecks[github].healthyThreshold must be positive",
+		},
+		{
+			name: "per-backend unknown probe",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				UnhealthyThreshold:  3,
+				PartialFailureMode:  "fail",
+				BackendHealthChecks: map[string]*HealthCheckConfig{
+					"github": {Probe: "tcp"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backendHealthChecks[github].probe must be one of: mcp, http-get",
+		},
+		{
+			name: "per-backend probe path without http-get probe",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				UnhealthyThreshold:  3,
+				PartialFailureMode:  "fail",
+				BackendHealthChecks: map[string]*HealthCheckConfig{
+					"github": {ProbePath: "/healthz"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backendHealthChecks[github].probePath requires probe http-get",
+		},
+		{
+			name: "per-backend relative probe path",
+			fh: &FailureHandlingConfig{
+				HealthCheckInterval: Duration(30 * time.Second),
+				UnhealthyThreshold:  3,
+				PartialFailureMode:  "fail",
+				BackendHealthChecks: map[string]*HealthCheckConfig{
+					"github": {Probe: "http-get", ProbePath: "healthz"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backendHealthChecks[github].probePath must start with /",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1014,6 +1296,222 @@ func TestValidator_ValidateFailureHandling(t *testing.T) {
 	}
 }
 
+func TestValidator_ValidateWorkflowLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		limits  *WorkflowLimitsConfig
+		wantErr string
+	}{
+		{
+			name:   "valid limits",
+			limits: &WorkflowLimitsConfig{MaxSteps: 200, MaxCompositionDepth: 3},
+		},
+		{
+			name:   "unset limits use defaults",
+			limits: &WorkflowLimitsConfig{},
+		},
+		{
+			name:    "negative max steps",
+			limits:  &WorkflowLimitsConfig{MaxSteps: -1},
+			wantErr: "operational.workflowLimits.maxSteps must be positive",
+		},
+		{
+			name:    "negative max composition depth",
+			limits:  &WorkflowLimitsConfig{MaxCompositionDepth: -1},
+			wantErr: "operational.workflowLimits.maxCompositionDepth must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewValidator()
+			err := v.validateOperational(&OperationalConfig{WorkflowLimits: tt.limits})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateOperational() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateOperational() error = %v, want to contain %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateWorkflowFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		failures *WorkflowFailuresConfig
+		wantErr  string
+	}{
+		{
+			name:     "log destination",
+			failures: &WorkflowFailuresConfig{Destination: WorkflowFailureDestinationLog},
+		},
+		{
+			name:     "file destination",
+			failures: &WorkflowFailuresConfig{Destination: WorkflowFailureDestinationFile, Path: "/var/log/vmcp/failures.jsonl"},
+		},
+		{
+			name:     "file destination without path",
+			failures: &WorkflowFailuresConfig{Destination: WorkflowFailureDestinationFile},
+			wantErr:  "operational.workflowFailures.path is required",
+		},
+		{
+			name:     "unknown destination",
+			failures: &WorkflowFailuresConfig{Destination: "s3"},
+			wantErr:  "operational.workflowFailures.destination must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewValidator()
+			err := v.validateOperational(&OperationalConfig{WorkflowFailures: tt.failures})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateOperational() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateOperational() error = %v, want to contain %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateBackendClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		client  *BackendClientConfig
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			client: &BackendClientConfig{},
+		},
+		{
+			name: "tuned",
+			client: &BackendClientConfig{
+				MaxIdleConns: 50, MaxIdleConnsPerHost: 20, IdleConnTimeout: Duration(time.Minute),
+				RetryAttempts: 5, RetryBackoff: Duration(50 * time.Millisecond),
+			},
+		},
+		{
+			name:    "negative pool size",
+			client:  &BackendClientConfig{MaxIdleConnsPerHost: -1},
+			wantErr: "must be positive",
+		},
+		{
+			name:    "negative backoff",
+			client:  &BackendClientConfig{RetryBackoff: Duration(-time.Second)},
+			wantErr: "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewValidator()
+			err := v.validateOperational(&OperationalConfig{BackendClient: tt.client})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateOperational() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateOperational() error = %v, want to contain %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateBackendTLS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tls     *BackendTLSConfig
+		wantErr string
+	}{
+		{
+			name: "custom CA",
+			tls:  &BackendTLSConfig{CABundlePath: "/etc/certs/ca.crt"},
+		},
+		{
+			name: "client certificate from files",
+			tls:  &BackendTLSConfig{ClientCertPath: "/etc/certs/tls.crt", ClientKeyPath: "/etc/certs/tls.key"},
+		},
+		{
+			name: "client certificate from secrets",
+			tls:  &BackendTLSConfig{ClientCertSecret: "BACKEND_CERT", ClientKeySecret: "BACKEND_KEY"},
+		},
+		{
+			name: "insecure skip verify",
+			tls:  &BackendTLSConfig{InsecureSkipVerify: true},
+		},
+		{
+			name:    "relative path",
+			tls:     &BackendTLSConfig{CABundlePath: "certs/ca.crt"},
+			wantErr: "caBundlePath must be an absolute path",
+		},
+		{
+			name:    "path traversal",
+			tls:     &BackendTLSConfig{ClientCertPath: "/etc/../tls.crt", ClientKeyPath: "/etc/certs/tls.key"},
+			wantErr: "clientCertPath contains invalid path characters",
+		},
+		{
+			name: "certificate path and secret",
+			tls: &BackendTLSConfig{
+				ClientCertPath: "/etc/certs/tls.crt", ClientCertSecret: "BACKEND_CERT", ClientKeySecret: "BACKEND_KEY",
+			},
+			wantErr: "only one of clientCertPath or clientCertSecret",
+		},
+		{
+			name:    "certificate without key",
+			tls:     &BackendTLSConfig{ClientCertPath: "/etc/certs/tls.crt"},
+			wantErr: "client certificate and key must be set together",
+		},
+		{
+			name:    "key without certificate",
+			tls:     &BackendTLSConfig{ClientKeySecret: "BACKEND_KEY"},
+			wantErr: "client certificate and key must be set together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewValidator()
+			err := v.validateBackendTLS(map[string]*BackendTLSConfig{"github": tt.tls})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateBackendTLS() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateBackendTLS() error = %v, want to contain %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateAuthServerIntegration(t *testing.T) {
 	t.Parallel()
 
@@ -1537,6 +2035,77 @@ func TestValidator_ValidateStaticBackends(t *testing.T) {
 			wantErr: true,
 			errMsg:  "backends[1]",
 		},
+		{
+			name: "valid stdio backend",
+			backends: []StaticBackendConfig{
+				{
+					Transport: TransportStdio,
+					Command:   "npx",
+					Args:      []string{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"},
+					Env:       map[string]string{"LOG_LEVEL": "debug"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "stdio backend without command",
+			backends: []StaticBackendConfig{
+				{
+					Transport: TransportStdio,
+					Args:      []string{"--flag"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backends[0].command is required",
+		},
+		{
+			name: "stdio backend with URL",
+			backends: []StaticBackendConfig{
+				{
+					Transport: TransportStdio,
+					Command:   "mcp-server",
+					URL:       "http://localhost:8080/mcp",
+				},
+			},
+			wantErr: true,
+			errMsg:  "backends[0].url must be empty",
+		},
+		{
+			name: "stdio entry backend",
+			backends: []StaticBackendConfig{
+				{
+					Transport: TransportStdio,
+					Command:   "mcp-server",
+					Type:      "entry",
+				},
+			},
+			wantErr: true,
+			errMsg:  "backends[0].type must be empty when transport is",
+		},
+		{
+			name: "stdio backend with invalid env name",
+			backends: []StaticBackendConfig{
+				{
+					Transport: TransportStdio,
+					Command:   "mcp-server",
+					Env:       map[string]string{"A=B": "c"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "backends[0].env has an invalid variable name",
+		},
+		{
+			name: "command on non-stdio backend",
+			backends: []StaticBackendConfig{
+				{
+					Transport: TransportStreamableHTTP,
+					URL:       "http://localhost:8080/mcp",
+					Command:   "mcp-server",
+				},
+			},
+			wantErr: true,
+			errMsg:  "only valid when transport is",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1552,3 +2121,69 @@ func TestValidator_ValidateStaticBackends(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_ValidateShutdownGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		grace   Duration
+		wantErr bool
+	}{
+		{name: "unset uses default", grace: 0},
+		{name: "positive", grace: Duration(45 * time.Second)},
+		{name: "negative", grace: Duration(-time.Second), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewValidator().validateOperational(&OperationalConfig{ShutdownGracePeriod: tt.grace})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "operational.shutdownGracePeriod must not be negative") {
+					t.Errorf("validateOperational() error = %v, want negative grace period error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("validateOperational() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateWarmup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		warmup  *WarmupConfig
+		wantErr string
+	}{
+		{name: "unset fields use defaults", warmup: &WarmupConfig{}},
+		{name: "valid", warmup: &WarmupConfig{Timeout: Duration(30 * time.Second), MinHealthyPercent: 50}},
+		{
+			name:    "negative timeout",
+			warmup:  &WarmupConfig{Timeout: Duration(-time.Second)},
+			wantErr: "operational.warmup.timeout must not be negative",
+		},
+		{
+			name:    "percent above 100",
+			warmup:  &WarmupConfig{MinHealthyPercent: 101},
+			wantErr: "operational.warmup.minHealthyPercent must be between 1 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewValidator().validateOperational(&OperationalConfig{Warmup: tt.warmup})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -15,14 +16,15 @@ import (
 	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
 )
 
-// YAMLLoader loads configuration from a YAML file.
+// YAMLLoader loads configuration from a YAML file or reader.
 // This is the CLI-specific loader that parses the YAML format defined in the proposal.
 type YAMLLoader struct {
 	filePath  string
+	reader    io.Reader
 	envReader env.Reader
 }
 
-// NewYAMLLoader creates a new YAML configuration loader.
+// NewYAMLLoader creates a new YAML configuration loader reading filePath.
 func NewYAMLLoader(filePath string, envReader env.Reader) *YAMLLoader {
 	return &YAMLLoader{
 		filePath:  filePath,
@@ -30,12 +32,32 @@ func NewYAMLLoader(filePath string, envReader env.Reader) *YAMLLoader {
 	}
 }
 
-// Load reads and parses the YAML configuration file.
+// NewYAMLReaderLoader creates a new YAML configuration loader reading from r,
+// such as stdin. The configuration is parsed and post-processed exactly like a
+// file loaded by NewYAMLLoader.
+func NewYAMLReaderLoader(r io.Reader, envReader env.Reader) *YAMLLoader {
+	return &YAMLLoader{
+		reader:    r,
+		envReader: envReader,
+	}
+}
+
+// Load reads and parses the YAML configuration.
 // Uses strict unmarshalling to reject unknown fields.
 func (l *YAMLLoader) Load() (*Config, error) {
-	data, err := os.ReadFile(l.filePath)
+	data, err := l.read()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("configuration is empty")
+	}
+
+	// Interpolate ${VAR} references before decoding, so the interpolated
+	// values are validated like any other.
+	data, err = interpolateEnv(data, l.envReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate environment variables: %w", err)
 	}
 
 	// Use yaml.Decoder with KnownFields for strict unmarshalling
@@ -55,6 +77,23 @@ func (l *YAMLLoader) Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// read returns the raw configuration from the loader's reader or file.
+func (l *YAMLLoader) read() ([]byte, error) {
+	if l.reader != nil {
+		data, err := io.ReadAll(l.reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(l.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return data, nil
+}
+
 // postProcess applies post-load processing to the config:
 // - Resolves environment variables for secrets
 // - Applies type inference for workflow steps
@@ -140,6 +179,11 @@ func (l *YAMLLoader) processBackendAuthStrategy(name string, strategy *authtypes
 				return fmt.Errorf("backend %s: environment variable %s not set", name, te.ClientSecretEnv)
 			}
 		}
+		if te.ClientAssertionKeyEnv != "" {
+			if l.envReader.Getenv(te.ClientAssertionKeyEnv) == "" {
+				return fmt.Errorf("backend %s: environment variable %s not set", name, te.ClientAssertionKeyEnv)
+			}
+		}
 
 	case authtypes.StrategyTypeUnauthenticated:
 		// No validation needed
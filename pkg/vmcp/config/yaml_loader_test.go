@@ -6,6 +6,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -26,10 +27,12 @@ func createMockEnvReader(t *testing.T, envVars map[string]string) *mocks.MockRea
 	// Set up expectations for each env var
 	for key, value := range envVars {
 		mockEnv.EXPECT().Getenv(key).Return(value).AnyTimes()
+		mockEnv.EXPECT().LookupEnv(key).Return(value, true).AnyTimes()
 	}
 
 	// For any other keys, return empty string
 	mockEnv.EXPECT().Getenv(gomock.Any()).Return("").AnyTimes()
+	mockEnv.EXPECT().LookupEnv(gomock.Any()).Return("", false).AnyTimes()
 
 	return mockEnv
 }
@@ -666,6 +669,111 @@ func TestYAMLLoader_LoadFileNotFound(t *testing.T) {
 	}
 }
 
+func TestYAMLReaderLoader_Load(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "valid configuration",
+			yaml: `
+name: test-vmcp
+groupRef: test-group
+incomingAuth:
+  type: anonymous
+outgoingAuth:
+  source: inline
+aggregation:
+  conflictResolution: prefix
+  conflictResolutionConfig:
+    prefixFormat: "{workload}_"
+`,
+		},
+		{
+			name:    "malformed YAML",
+			yaml:    ":::invalid yaml:::",
+			wantErr: "failed to parse YAML",
+		},
+		{
+			name: "unknown field",
+			yaml: `
+name: test-vmcp
+groupRef: test-group
+unknownField: true
+`,
+			wantErr: "field unknownField not found",
+		},
+		{
+			name:    "empty input",
+			yaml:    "\n  \n",
+			wantErr: "configuration is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader := NewYAMLReaderLoader(strings.NewReader(tt.yaml), createMockEnvReader(t, nil))
+			cfg, err := loader.Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error = %v", err)
+			}
+			if cfg.Name != "test-vmcp" || cfg.Group != "test-group" {
+				t.Errorf("Load() = name %q, group %q, want test-vmcp, test-group", cfg.Name, cfg.Group)
+			}
+		})
+	}
+}
+
+func TestYAMLReaderLoader_MatchesFileLoader(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+name: test-vmcp
+groupRef: test-group
+incomingAuth:
+  type: anonymous
+outgoingAuth:
+  source: inline
+  backends:
+    github:
+      type: header_injection
+      headerInjection:
+        headerName: Authorization
+        headerValueEnv: GITHUB_TOKEN
+aggregation:
+  conflictResolution: prefix
+  conflictResolutionConfig:
+    prefixFormat: "{workload}_"
+`
+	envVars := map[string]string{"GITHUB_TOKEN": "secret"}
+	path := filepath.Join(t.TempDir(), "vmcp.yaml")
+	if err := os.WriteFile(path, []byte(yamlContent), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	fromFile, err := NewYAMLLoader(path, createMockEnvReader(t, envVars)).Load()
+	if err != nil {
+		t.Fatalf("file Load() error = %v", err)
+	}
+	fromReader, err := NewYAMLReaderLoader(strings.NewReader(yamlContent), createMockEnvReader(t, envVars)).Load()
+	if err != nil {
+		t.Fatalf("reader Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(fromFile, fromReader) {
+		t.Errorf("reader Load() = %+v, want %+v", fromReader, fromFile)
+	}
+}
+
 func TestYAMLLoader_IntegrationWithValidator(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
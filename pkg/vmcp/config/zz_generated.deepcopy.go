@@ -22,6 +22,7 @@ package config
 
 import (
 	"github.com/stacklok/toolhive/pkg/audit"
+	"github.com/stacklok/toolhive/pkg/json"
 	"github.com/stacklok/toolhive/pkg/ratelimit/types"
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
@@ -78,6 +79,36 @@ func (in *AuthzConfig) DeepCopy() *AuthzConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendClientConfig) DeepCopyInto(out *BackendClientConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendClientConfig.
+func (in *BackendClientConfig) DeepCopy() *BackendClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLSConfig) DeepCopyInto(out *BackendTLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLSConfig.
+func (in *BackendTLSConfig) DeepCopy() *BackendTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreakerConfig) DeepCopyInto(out *CircuitBreakerConfig) {
 	*out = *in
@@ -124,6 +155,11 @@ func (in *CompositeToolConfig) DeepCopyInto(out *CompositeToolConfig) {
 		*out = new(OutputConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Join != nil {
+		in, out := &in.Join, &out.Join
+		*out = new(JoinConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeToolConfig.
@@ -171,6 +207,22 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(OutgoingAuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackendTLS != nil {
+		in, out := &in.BackendTLS, &out.BackendTLS
+		*out = make(map[string]*BackendTLSConfig, len(*in))
+		for key, val := range *in {
+			var outVal *BackendTLSConfig
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(BackendTLSConfig)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.Aggregation != nil {
 		in, out := &in.Aggregation, &out.Aggregation
 		*out = new(AggregationConfig)
@@ -255,6 +307,13 @@ func (in *ConflictResolutionConfig) DeepCopyInto(out *ConflictResolutionConfig)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BackendPriorities != nil {
+		in, out := &in.BackendPriorities, &out.BackendPriorities
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConflictResolutionConfig.
@@ -290,6 +349,22 @@ func (in *FailureHandlingConfig) DeepCopyInto(out *FailureHandlingConfig) {
 		*out = new(CircuitBreakerConfig)
 		**out = **in
 	}
+	if in.BackendHealthChecks != nil {
+		in, out := &in.BackendHealthChecks, &out.BackendHealthChecks
+		*out = make(map[string]*HealthCheckConfig, len(*in))
+		for key, val := range *in {
+			var outVal *HealthCheckConfig
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(HealthCheckConfig)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureHandlingConfig.
@@ -302,6 +377,21 @@ func (in *FailureHandlingConfig) DeepCopy() *FailureHandlingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckConfig.
+func (in *HealthCheckConfig) DeepCopy() *HealthCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IncomingAuthConfig) DeepCopyInto(out *IncomingAuthConfig) {
 	*out = *in
@@ -327,6 +417,21 @@ func (in *IncomingAuthConfig) DeepCopy() *IncomingAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinConfig) DeepCopyInto(out *JoinConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinConfig.
+func (in *JoinConfig) DeepCopy() *JoinConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 	*out = *in
@@ -360,6 +465,26 @@ func (in *OperationalConfig) DeepCopyInto(out *OperationalConfig) {
 		*out = new(FailureHandlingConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WorkflowLimits != nil {
+		in, out := &in.WorkflowLimits, &out.WorkflowLimits
+		*out = new(WorkflowLimitsConfig)
+		**out = **in
+	}
+	if in.WorkflowFailures != nil {
+		in, out := &in.WorkflowFailures, &out.WorkflowFailures
+		*out = new(WorkflowFailuresConfig)
+		**out = **in
+	}
+	if in.BackendClient != nil {
+		in, out := &in.BackendClient, &out.BackendClient
+		*out = new(BackendClientConfig)
+		**out = **in
+	}
+	if in.Warmup != nil {
+		in, out := &in.Warmup, &out.Warmup
+		*out = new(WarmupConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationalConfig.
@@ -505,6 +630,18 @@ func (in *StaticBackendConfig) DeepCopyInto(out *StaticBackendConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticBackendConfig.
@@ -629,6 +766,21 @@ func (in *ToolOverride) DeepCopy() *ToolOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmupConfig) DeepCopyInto(out *WarmupConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmupConfig.
+func (in *WarmupConfig) DeepCopy() *WarmupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowStepConfig) DeepCopyInto(out *WorkflowStepConfig) {
 	*out = *in
@@ -654,6 +806,7 @@ func (in *WorkflowStepConfig) DeepCopyInto(out *WorkflowStepConfig) {
 		*out = new(ElicitationResponseConfig)
 		**out = **in
 	}
+	in.TimeoutDefault.DeepCopyInto(&out.TimeoutDefault)
 	in.DefaultResults.DeepCopyInto(&out.DefaultResults)
 	if in.InnerStep != nil {
 		in, out := &in.InnerStep, &out.InnerStep
@@ -672,6 +825,36 @@ func (in *WorkflowStepConfig) DeepCopy() *WorkflowStepConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowFailuresConfig) DeepCopyInto(out *WorkflowFailuresConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowFailuresConfig.
+func (in *WorkflowFailuresConfig) DeepCopy() *WorkflowFailuresConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowFailuresConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowLimitsConfig) DeepCopyInto(out *WorkflowLimitsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowLimitsConfig.
+func (in *WorkflowLimitsConfig) DeepCopy() *WorkflowLimitsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowLimitsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadToolConfig) DeepCopyInto(out *WorkloadToolConfig) {
 	*out = *in
@@ -701,6 +884,27 @@ func (in *WorkloadToolConfig) DeepCopyInto(out *WorkloadToolConfig) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ParameterDefaults != nil {
+		in, out := &in.ParameterDefaults, &out.ParameterDefaults
+		*out = make(map[string]json.Map, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ToolTimeouts != nil {
+		in, out := &in.ToolTimeouts, &out.ToolTimeouts
+		*out = make(map[string]Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadToolConfig.
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	sdkmcp "github.com/stacklok/toolhive-core/mcpcompat/mcp"
+	thvmcp "github.com/stacklok/toolhive/pkg/mcp"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// JSON-RPC error codes vMCP returns for operational failures. They are in the
+// implementation-defined server error range (-32000 to -32099), clear of the
+// MCP-reserved codes and of the protocol classifier's -3202x codes.
+const (
+	// JSONRPCCodeBackendUnavailable is returned when the backend serving a
+	// request cannot be reached.
+	JSONRPCCodeBackendUnavailable = -32010
+
+	// JSONRPCCodeBackendAuthFailed is returned when the backend serving a
+	// request rejected the credentials vMCP sent it.
+	JSONRPCCodeBackendAuthFailed = -32011
+)
+
+// Client-facing messages for errors whose details stay in the server log.
+const (
+	messageBackendUnavailable = "backend unavailable"
+	messageBackendAuthFailed  = "backend authentication failed"
+	messageTimeout            = "request timed out"
+	messageCancelled          = "request cancelled"
+	messageInternal           = "internal error"
+)
+
+// JSONRPCError is the JSON-RPC error returned to a client for a failed
+// request.
+type JSONRPCError struct {
+	Code    int
+	Message string
+}
+
+// ErrorToJSONRPC maps an error from a vMCP core call, read or get to the
+// JSON-RPC error returned to the client:
+//
+//   - vmcp.ErrAuthorizationFailed: thvmcp.JSONRPCCodeDenied with denyMessage,
+//     the kind-only denial message of the request (vmcp.DenyMessage*).
+//   - vmcp.ErrNotFound and vmcp.ErrInvalidInput: INVALID_PARAMS with the
+//     error text, which names only what the client asked for.
+//   - vmcp.ErrTimeout, vmcp.ErrCancelled and their context equivalents:
+//     REQUEST_INTERRUPTED.
+//   - vmcp.ErrAuthenticationFailed: JSONRPCCodeBackendAuthFailed.
+//   - vmcp.ErrBackendUnavailable: JSONRPCCodeBackendUnavailable.
+//   - vmcp.ErrToolExecutionFailed and vmcp.ErrWorkflowFailed: INTERNAL_ERROR
+//     with the error text, which the LLM needs to see.
+//   - thvmcp.CodedError: its own code with the error text.
+//   - anything else: INTERNAL_ERROR with a generic message.
+//
+// Backend, timeout and internal errors can carry backend IDs and upstream
+// addresses, so they are returned with a generic message and logged here with
+// the full error.
+func ErrorToJSONRPC(ctx context.Context, err error, denyMessage string) JSONRPCError {
+	if errors.Is(err, vmcp.ErrAuthorizationFailed) {
+		return JSONRPCError{Code: thvmcp.JSONRPCCodeDenied, Message: denyMessage}
+	}
+
+	var coded thvmcp.CodedError
+	switch {
+	case errors.As(err, &coded):
+		return JSONRPCError{Code: int(coded.Code()), Message: err.Error()}
+	case errors.Is(err, vmcp.ErrNotFound), errors.Is(err, vmcp.ErrInvalidInput):
+		return JSONRPCError{Code: sdkmcp.INVALID_PARAMS, Message: err.Error()}
+	case errors.Is(err, vmcp.ErrToolExecutionFailed), errors.Is(err, vmcp.ErrWorkflowFailed):
+		return JSONRPCError{Code: sdkmcp.INTERNAL_ERROR, Message: err.Error()}
+	}
+
+	mapped := JSONRPCError{Code: sdkmcp.INTERNAL_ERROR, Message: messageInternal}
+	switch {
+	case errors.Is(err, vmcp.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		mapped = JSONRPCError{Code: sdkmcp.REQUEST_INTERRUPTED, Message: messageTimeout}
+	case errors.Is(err, vmcp.ErrCancelled), errors.Is(err, context.Canceled):
+		mapped = JSONRPCError{Code: sdkmcp.REQUEST_INTERRUPTED, Message: messageCancelled}
+	case errors.Is(err, vmcp.ErrAuthenticationFailed):
+		mapped = JSONRPCError{Code: JSONRPCCodeBackendAuthFailed, Message: messageBackendAuthFailed}
+	case errors.Is(err, vmcp.ErrBackendUnavailable):
+		mapped = JSONRPCError{Code: JSONRPCCodeBackendUnavailable, Message: messageBackendUnavailable}
+	}
+	slog.ErrorContext(ctx, "vmcp request failed", "code", mapped.Code, "error", err)
+	return mapped
+}
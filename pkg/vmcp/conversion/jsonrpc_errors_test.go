@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
+	thvmcp "github.com/stacklok/toolhive/pkg/mcp"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/conversion"
+)
+
+// codedError is a thvmcp.CodedError with a fixed code.
+type codedError struct{}
+
+func (codedError) Error() string        { return "rate limited" }
+func (codedError) Code() int64          { return -32050 }
+func (codedError) Data() map[string]any { return nil }
+
+func TestErrorToJSONRPC(t *testing.T) {
+	t.Parallel()
+
+	const secret = "http://backend-1.internal:8080"
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    int
+		wantMessage string
+	}{
+		{
+			name:        "authorization failure uses the deny message",
+			err:         fmt.Errorf("%w: policy forbids %s", vmcp.ErrAuthorizationFailed, secret),
+			wantCode:    thvmcp.JSONRPCCodeDenied,
+			wantMessage: vmcp.DenyMessageToolCall,
+		},
+		{
+			name:        "tool not found",
+			err:         fmt.Errorf("%w: tool %q", vmcp.ErrNotFound, "missing"),
+			wantCode:    mcp.INVALID_PARAMS,
+			wantMessage: `not found: tool "missing"`,
+		},
+		{
+			name:        "invalid input",
+			err:         fmt.Errorf("%w: arguments must be an object", vmcp.ErrInvalidInput),
+			wantCode:    mcp.INVALID_PARAMS,
+			wantMessage: "invalid input: arguments must be an object",
+		},
+		{
+			name:        "backend unavailable",
+			err:         fmt.Errorf("%w: dial %s: connection refused", vmcp.ErrBackendUnavailable, secret),
+			wantCode:    conversion.JSONRPCCodeBackendUnavailable,
+			wantMessage: "backend unavailable",
+		},
+		{
+			name:        "backend authentication failure",
+			err:         fmt.Errorf("%w: 401 from %s", vmcp.ErrAuthenticationFailed, secret),
+			wantCode:    conversion.JSONRPCCodeBackendAuthFailed,
+			wantMessage: "backend authentication failed",
+		},
+		{
+			name:        "timeout",
+			err:         fmt.Errorf("%w: calling %s", vmcp.ErrTimeout, secret),
+			wantCode:    mcp.REQUEST_INTERRUPTED,
+			wantMessage: "request timed out",
+		},
+		{
+			name:        "context deadline",
+			err:         fmt.Errorf("calling %s: %w", secret, context.DeadlineExceeded),
+			wantCode:    mcp.REQUEST_INTERRUPTED,
+			wantMessage: "request timed out",
+		},
+		{
+			name:        "cancellation",
+			err:         fmt.Errorf("calling %s: %w", secret, context.Canceled),
+			wantCode:    mcp.REQUEST_INTERRUPTED,
+			wantMessage: "request cancelled",
+		},
+		{
+			name:        "tool execution failure is forwarded",
+			err:         fmt.Errorf("%w: file does not exist", vmcp.ErrToolExecutionFailed),
+			wantCode:    mcp.INTERNAL_ERROR,
+			wantMessage: "tool execution failed: file does not exist",
+		},
+		{
+			name:        "coded error keeps its code",
+			err:         fmt.Errorf("calling tool: %w", codedError{}),
+			wantCode:    -32050,
+			wantMessage: "calling tool: rate limited",
+		},
+		{
+			name:        "internal error is hidden",
+			err:         fmt.Errorf("routing tool %q: aggregator state for %s corrupted", "echo", secret),
+			wantCode:    mcp.INTERNAL_ERROR,
+			wantMessage: "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := conversion.ErrorToJSONRPC(context.Background(), tt.err, vmcp.DenyMessageToolCall)
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantMessage, got.Message)
+			assert.NotContains(t, got.Message, secret, "details must not leak to the client")
+		})
+	}
+}
+
+func TestErrorToJSONRPC_AuthorizationTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	// A denial wrapping another domain error is still reported as a denial,
+	// so it cannot be used to tell denied capabilities from missing ones.
+	err := fmt.Errorf("%w: %w", vmcp.ErrAuthorizationFailed, vmcp.ErrNotFound)
+	got := conversion.ErrorToJSONRPC(context.Background(), err, vmcp.DenyMessageResourceRead)
+	assert.Equal(t, conversion.JSONRPCError{
+		Code:    thvmcp.JSONRPCCodeDenied,
+		Message: vmcp.DenyMessageResourceRead,
+	}, got)
+}
@@ -208,6 +208,14 @@ type VMCP interface {
 	// corresponding authorized list would not show.
 	LookupBackend(ctx context.Context, identity *auth.Identity, backendID string) (*vmcp.Backend, error)
 
+	// RoutingTable returns the routing table of the admin view: every capability
+	// the health-filtered backends advertise, mapped to the backend target that
+	// serves it, with no per-identity admission filtering. Like
+	// ListBackends(filterUnauthorized=false), authorizing the caller for this view
+	// is the transport/API layer's responsibility. The table is re-derived on every
+	// call, so it reflects the routing the next call of any session will use.
+	RoutingTable(ctx context.Context) (*vmcp.RoutingTable, error)
+
 	// Discover returns identity's capability-presence flags -- whether it is
 	// admitted to at least one tool, resource, resource template, and prompt --
 	// from a SINGLE aggregation of backend capabilities, for server/discover.
@@ -266,6 +274,19 @@ type Config struct {
 	// WorkflowDefs holds the composite-tool workflow definitions, keyed by name.
 	WorkflowDefs map[string]*composer.WorkflowDefinition
 
+	// WorkflowLimits bounds the steps a workflow may run and how deeply composite
+	// tools may nest. Zero values select the composer defaults.
+	WorkflowLimits composer.WorkflowLimits
+
+	// WorkflowEvents receives the step events of the composite-tool workflows the
+	// core executes, so they can be tailed while running. Nil disables step events.
+	WorkflowEvents *composer.WorkflowEventBroker
+
+	// WorkflowFailureSink receives a record of every composite-tool workflow that
+	// fails or times out, with AuditConfig.RedactFields redacted. Nil disables
+	// failure recording.
+	WorkflowFailureSink composer.FailureSink
+
 	// Authz feeds the admission seam New builds. A nil Authz means authorization
 	// is unconfigured (allow-all), matching today's `AuthzMiddleware != nil` guard:
 	// the composition root only populates this when Cedar policies exist (mirroring
@@ -363,3 +363,44 @@ func TestLookupBackend_NotFound(t *testing.T) {
 		assert.ErrorIs(t, err, vmcp.ErrNotFound)
 	})
 }
+
+// TestRoutingTable_FollowsHealthTransitions proves the admin routing table is
+// re-derived from the health-filtered registry on every call: a backend that
+// turns unhealthy loses its routes on the next call and regains them once it
+// recovers, so in-flight sessions route by its current health.
+func TestRoutingTable_FollowsHealthTransitions(t *testing.T) {
+	t.Parallel()
+	cfg, m := baseConfig(t)
+
+	m.reg.EXPECT().List(gomock.Any()).Return([]vmcp.Backend{{ID: "b1"}, {ID: "b2"}}).Times(3)
+	m.agg.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, backends []vmcp.Backend) (*aggregator.AggregatedCapabilities, error) {
+			table := &vmcp.RoutingTable{Tools: map[string]*vmcp.BackendTarget{}}
+			for _, backend := range backends {
+				table.Tools["tool_"+backend.ID] = &vmcp.BackendTarget{WorkloadID: backend.ID}
+			}
+			return &aggregator.AggregatedCapabilities{RoutingTable: table}, nil
+		}).Times(3)
+
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	health := fakeStatusProvider{"b1": vmcp.BackendHealthy, "b2": vmcp.BackendHealthy}
+	c.(*coreVMCP).health = health
+
+	routedTools := func() []string {
+		table, err := c.RoutingTable(context.Background())
+		require.NoError(t, err)
+		var names []string
+		for name := range table.Tools {
+			names = append(names, name)
+		}
+		return names
+	}
+
+	assert.ElementsMatch(t, []string{"tool_b1", "tool_b2"}, routedTools())
+	health["b2"] = vmcp.BackendUnhealthy
+	assert.ElementsMatch(t, []string{"tool_b1"}, routedTools(), "an unhealthy backend must lose its routes")
+	health["b2"] = vmcp.BackendHealthy
+	assert.ElementsMatch(t, []string{"tool_b1", "tool_b2"}, routedTools(), "a recovered backend must regain its routes")
+}
@@ -10,7 +10,10 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"strings"
+	"time"
 
+	"github.com/stacklok/toolhive/pkg/audit"
 	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/composer"
@@ -33,10 +36,26 @@ func (c *coreVMCP) CallTool(
 	name string,
 	args map[string]any,
 	meta map[string]any,
-) (*vmcp.ToolCallResult, error) {
+) (_ *vmcp.ToolCallResult, retErr error) {
 	argsCopy := maps.Clone(args)
 	metaCopy := maps.Clone(meta)
 
+	// Audit the call once it ends. backendID is set once the name routes to a
+	// backend and toolError when the backend (or workflow) reports a failure.
+	start := time.Now()
+	var backendID string
+	var toolError bool
+	defer func() {
+		c.callAuditor.LogToolCall(ctx, audit.VMCPCall{
+			Identity:  identity,
+			BackendID: backendID,
+			Name:      name,
+			Arguments: argsCopy,
+			Duration:  time.Since(start),
+			Outcome:   auditOutcome(toolError, retErr),
+		})
+	}()
+
 	agg, err := c.aggregatedView(ctx)
 	if err != nil {
 		return nil, err
@@ -53,7 +72,15 @@ func (c *coreVMCP) CallTool(
 	// through to backend routing, matching the legacy decorator.
 	if def, ok := c.accessibleComposites(agg)[name]; ok {
 		engine := c.composerFactory(agg.RoutingTable, agg.Tools)
-		return executeComposite(ctx, engine, def, argsCopy)
+		// Workflow templates resolve {{.identity.*}} from the context, so carry
+		// an explicitly passed identity there.
+		if identity != nil {
+			ctx = auth.WithIdentity(ctx, identity)
+		}
+		execute := func() *vmcp.ToolCallResult { return executeComposite(ctx, engine, def, argsCopy) }
+		result, err := c.executeIdempotent(ctx, identity, name, argsCopy, metaCopy, execute)
+		toolError = err == nil && result.IsError
+		return result, err
 	}
 
 	// Backend tool: route through a session router bound to the fresh table. The
@@ -62,18 +89,72 @@ func (c *coreVMCP) CallTool(
 	target, err := router.NewSessionRouter(agg.RoutingTable).RouteTool(ctx, name)
 	if err != nil {
 		if errors.Is(err, router.ErrToolNotFound) {
+			c.routing.recordToolCall(ctx, "", name, routingOutcomeNotFound)
 			return nil, fmt.Errorf("%w: tool %q", vmcp.ErrNotFound, name)
 		}
+		c.routing.recordToolCall(ctx, "", name, routingOutcomeError)
 		return nil, fmt.Errorf("routing tool %q: %w", name, err)
 	}
-	result, err := c.backendClient.CallTool(ctx, target, name, argsCopy, metaCopy)
+	backendID = target.WorkloadID
+	result, err := c.callBackendTool(ctx, target, name, argsCopy, metaCopy)
 	if err != nil {
+		c.routing.recordToolCall(ctx, target.WorkloadID, name, routingOutcomeError)
 		return nil, err
 	}
+	c.routing.recordToolCall(ctx, target.WorkloadID, name, routingOutcomeSuccess)
+	toolError = result.IsError
 	result.BackendID = target.WorkloadID
 	return result, nil
 }
 
+// callBackendTool calls name on target, bounded by the target's CallTimeout
+// when one is set. A call cut off by that timeout is reported as a tool error
+// result naming the tool, like a timed-out composite tool, rather than as a
+// protocol error: the tool ran too long, the request itself was fine. A
+// deadline or cancellation coming from the caller's own context is returned
+// unchanged.
+func (c *coreVMCP) callBackendTool(
+	ctx context.Context,
+	target *vmcp.BackendTarget,
+	name string,
+	args, meta map[string]any,
+) (*vmcp.ToolCallResult, error) {
+	if target.CallTimeout <= 0 {
+		return c.backendClient.CallTool(ctx, target, name, args, meta)
+	}
+
+	callCtx, cancel := context.WithTimeoutCause(ctx, target.CallTimeout, errToolCallTimeout)
+	defer cancel()
+	result, err := c.backendClient.CallTool(callCtx, target, name, args, meta)
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(callCtx), errToolCallTimeout) {
+		slog.Warn("backend tool call timed out",
+			"tool", name, "backend", target.WorkloadID, "timeout", target.CallTimeout)
+		return toolTimeoutResult(name, target.CallTimeout), nil
+	}
+	return result, err
+}
+
+// errToolCallTimeout is the cause recorded when a tool's CallTimeout expires,
+// distinguishing it from a deadline on the caller's context.
+var errToolCallTimeout = errors.New("tool call timeout exceeded")
+
+// toolTimeoutResult builds the tool error result for a backend tool call cut
+// off by its CallTimeout.
+func toolTimeoutResult(name string, timeout time.Duration) *vmcp.ToolCallResult {
+	return &vmcp.ToolCallResult{
+		Content: []vmcp.Content{{
+			Type: vmcp.ContentTypeText,
+			Text: fmt.Sprintf("Tool %q timed out after %s", name, timeout),
+		}},
+		StructuredContent: map[string]any{
+			"error":   "timeout",
+			"tool":    name,
+			"timeout": timeout.String(),
+		},
+		IsError: true,
+	}
+}
+
 // ReadResource reads the resource at uri from its backend. Returns
 // vmcp.ErrNotFound for an unadvertised URI and vmcp.ErrAuthorizationFailed when
 // admission denies identity the read. See ListTools for identity semantics.
@@ -81,7 +162,19 @@ func (c *coreVMCP) ReadResource(
 	ctx context.Context,
 	identity *auth.Identity,
 	uri string,
-) (*vmcp.ResourceReadResult, error) {
+) (_ *vmcp.ResourceReadResult, retErr error) {
+	start := time.Now()
+	var backendID string
+	defer func() {
+		c.callAuditor.LogResourceRead(ctx, audit.VMCPCall{
+			Identity:  identity,
+			BackendID: backendID,
+			URI:       uri,
+			Duration:  time.Since(start),
+			Outcome:   auditOutcome(false, retErr),
+		})
+	}()
+
 	agg, err := c.aggregatedView(ctx)
 	if err != nil {
 		return nil, err
@@ -100,6 +193,7 @@ func (c *coreVMCP) ReadResource(
 	}
 	// Pass the advertised URI; the backend client owns the single translation to
 	// the backend's capability name (client.go:874), matching CallTool.
+	backendID = target.WorkloadID
 	result, err := c.backendClient.ReadResource(ctx, target, uri)
 	if err != nil {
 		return nil, err
@@ -117,7 +211,20 @@ func (c *coreVMCP) GetPrompt(
 	identity *auth.Identity,
 	name string,
 	args map[string]any,
-) (*vmcp.PromptGetResult, error) {
+) (_ *vmcp.PromptGetResult, retErr error) {
+	start := time.Now()
+	var backendID string
+	defer func() {
+		c.callAuditor.LogPromptGet(ctx, audit.VMCPCall{
+			Identity:  identity,
+			BackendID: backendID,
+			Name:      name,
+			Arguments: args,
+			Duration:  time.Since(start),
+			Outcome:   auditOutcome(false, retErr),
+		})
+	}()
+
 	agg, err := c.aggregatedView(ctx)
 	if err != nil {
 		return nil, err
@@ -136,6 +243,7 @@ func (c *coreVMCP) GetPrompt(
 	}
 	// Pass the advertised name; the backend client owns the single translation to
 	// the backend's capability name (client.go:927), matching CallTool.
+	backendID = target.WorkloadID
 	result, err := c.backendClient.GetPrompt(ctx, target, name, maps.Clone(args))
 	if err != nil {
 		return nil, err
@@ -212,47 +320,71 @@ func emptyCompletion() *vmcp.CompletionResult {
 }
 
 // executeComposite runs a composite-tool workflow and converts the result to a
-// ToolCallResult. Workflow failures are returned as an IsError result (not a
-// transport error), mirroring the legacy compositeToolsDecorator
-// (internal/compositetools/decorator.go:76-114).
+// ToolCallResult with composer.ToolCallResult. Workflow failures are returned as
+// an IsError result (not a transport error), mirroring the legacy
+// compositeToolsDecorator (internal/compositetools/decorator.go:76-114).
 func executeComposite(
 	ctx context.Context,
 	engine composer.Composer,
 	def *composer.WorkflowDefinition,
 	params map[string]any,
-) (*vmcp.ToolCallResult, error) {
+) *vmcp.ToolCallResult {
 	result, err := engine.ExecuteWorkflow(ctx, def, params)
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			slog.Warn("workflow execution timeout", "tool", def.Name, "error", err)
-			return compositeErrorResult("Workflow execution timeout exceeded"), nil
-		}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		slog.Warn("workflow execution timeout", "tool", def.Name, "error", err)
+	case err != nil:
 		slog.Error("workflow execution failed", "tool", def.Name, "error", err)
-		return compositeErrorResult(fmt.Sprintf("Workflow execution failed: %v", err)), nil
-	}
-	if result == nil {
+	case result == nil:
 		slog.Error("workflow executor returned nil result", "tool", def.Name)
-		return compositeErrorResult("Workflow executor returned nil result"), nil
-	}
-	if result.Error != nil {
+	case result.Error != nil:
 		slog.Error("workflow completed with error", "tool", def.Name, "error", result.Error)
-		return compositeErrorResult(fmt.Sprintf("Workflow error: %v", result.Error)), nil
 	}
+	return composer.ToolCallResult(result, err)
+}
 
-	jsonBytes, err := json.Marshal(result.Output)
+// executeIdempotent runs execute, deduplicating it against earlier calls when
+// meta carries an idempotency key (composer.IdempotencyKeyMetaKey). Keys are
+// scoped to the caller and the tool, so one caller can never receive the
+// result of another's call.
+func (c *coreVMCP) executeIdempotent(
+	ctx context.Context,
+	identity *auth.Identity,
+	name string,
+	args, meta map[string]any,
+	execute func() *vmcp.ToolCallResult,
+) (*vmcp.ToolCallResult, error) {
+	key, _ := meta[composer.IdempotencyKeyMetaKey].(string)
+	if key == "" || c.idempotency == nil {
+		return execute(), nil
+	}
+	fingerprint, err := json.Marshal(args)
 	if err != nil {
-		return compositeErrorResult(fmt.Sprintf("failed to marshal output: %v", err)), nil
+		return nil, fmt.Errorf("%w: arguments are not serializable: %v", vmcp.ErrInvalidInput, err)
 	}
-	return &vmcp.ToolCallResult{
-		Content:           []vmcp.Content{{Type: vmcp.ContentTypeText, Text: string(jsonBytes)}},
-		StructuredContent: result.Output,
-	}, nil
+	var subject string
+	if identity != nil {
+		subject = identity.Subject
+	}
+	scopedKey := strings.Join([]string{subject, name, key}, "\x00")
+	result, err := c.idempotency.ExecuteOnce(ctx, scopedKey, string(fingerprint), execute)
+	if errors.Is(err, composer.ErrIdempotencyKeyReused) {
+		return nil, fmt.Errorf("%w: %w", vmcp.ErrInvalidInput, err)
+	}
+	return result, err
 }
 
-// compositeErrorResult builds a tool-level error result for a failed workflow.
-func compositeErrorResult(msg string) *vmcp.ToolCallResult {
-	return &vmcp.ToolCallResult{
-		Content: []vmcp.Content{{Type: vmcp.ContentTypeText, Text: msg}},
-		IsError: true,
+// auditOutcome maps how a dispatched call ended to an audit event outcome.
+// toolError is true when the call completed but the tool reported a failure.
+func auditOutcome(toolError bool, err error) string {
+	switch {
+	case errors.Is(err, vmcp.ErrAuthorizationFailed):
+		return audit.OutcomeDenied
+	case err != nil:
+		return audit.OutcomeError
+	case toolError:
+		return audit.OutcomeFailure
+	default:
+		return audit.OutcomeSuccess
 	}
 }
@@ -5,13 +5,20 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/stacklok/toolhive/pkg/audit"
+	"github.com/stacklok/toolhive/pkg/auth"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/aggregator"
 	"github.com/stacklok/toolhive/pkg/vmcp/composer"
@@ -62,6 +69,44 @@ func TestCallTool_NotFound(t *testing.T) {
 	assert.ErrorIs(t, err, vmcp.ErrNotFound)
 }
 
+func TestCallTool_EmitsAuditEvent(t *testing.T) {
+	t.Parallel()
+	cfg, m := baseConfig(t)
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	cfg.AuditConfig = &audit.Config{
+		LogFile:            logFile,
+		EventTypes:         []string{audit.EventTypeVMCPToolCall},
+		IncludeRequestData: true,
+		RedactFields:       []string{"token"},
+	}
+
+	target := backendTarget()
+	expectAggregation(m, &aggregator.AggregatedCapabilities{
+		Tools:        []vmcp.Tool{backendTool("tool_a")},
+		RoutingTable: &vmcp.RoutingTable{Tools: map[string]*vmcp.BackendTarget{"tool_a": target}},
+	})
+	m.client.EXPECT().
+		CallTool(gomock.Any(), gomock.Any(), "tool_a", gomock.Any(), gomock.Any()).
+		Return(&vmcp.ToolCallResult{IsError: true}, nil)
+
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	_, err = c.CallTool(context.Background(), nil, "tool_a", map[string]any{"token": "secret"}, nil)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(raw, &entry))
+	assert.Equal(t, audit.EventTypeVMCPToolCall, entry["type"])
+	assert.Equal(t, audit.OutcomeFailure, entry["outcome"], "a tool-reported error is a failure, not an error")
+	assert.Equal(t, testBackendID, entry["target"].(map[string]any)[audit.TargetKeyBackendID])
+	assert.Equal(t, map[string]any{"token": audit.RedactedValue},
+		entry["data"].(map[string]any)["request"])
+}
+
 func TestCallTool_CopyBeforeMutate(t *testing.T) {
 	t.Parallel()
 	cfg, m := baseConfig(t)
@@ -125,6 +170,58 @@ func TestCallTool_CompositeWorkflow(t *testing.T) {
 	assert.Empty(t, got.BackendID, "a composite tool has no single serving backend")
 }
 
+func TestCallTool_CompositeIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	cfg, m := baseConfig(t)
+	cfg.WorkflowDefs = map[string]*composer.WorkflowDefinition{
+		"wf": {Name: "wf", Steps: []composer.WorkflowStep{{ID: "s1", Type: composer.StepTypeTool, Tool: "be1.echo"}}},
+	}
+
+	target := backendTarget()
+	expectAggregationAnyTimes(m, &aggregator.AggregatedCapabilities{
+		Tools:        []vmcp.Tool{backendTool("be1.echo")},
+		RoutingTable: &vmcp.RoutingTable{Tools: map[string]*vmcp.BackendTarget{"be1.echo": target}},
+	})
+
+	// A retry with the same key returns the stored result; a new key or another
+	// caller executes the workflow again.
+	var executions int
+	m.client.EXPECT().
+		CallTool(gomock.Any(), gomock.Any(), "be1.echo", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *vmcp.BackendTarget, string, map[string]any, map[string]any) (*vmcp.ToolCallResult, error) {
+			executions++
+			return &vmcp.ToolCallResult{StructuredContent: map[string]any{"execution": executions}}, nil
+		}).Times(3)
+
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	alice := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "alice"}}
+	bob := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "bob"}}
+	keyed := func(key string) map[string]any { return map[string]any{composer.IdempotencyKeyMetaKey: key} }
+	args := map[string]any{"n": 1}
+
+	first, err := c.CallTool(context.Background(), alice, "wf", args, keyed("k1"))
+	require.NoError(t, err)
+	retry, err := c.CallTool(context.Background(), alice, "wf", args, keyed("k1"))
+	require.NoError(t, err)
+	assert.Equal(t, first.StructuredContent, retry.StructuredContent)
+	assert.Equal(t, 1, first.StructuredContent["execution"])
+
+	other, err := c.CallTool(context.Background(), alice, "wf", args, keyed("k2"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, other.StructuredContent["execution"])
+
+	otherCaller, err := c.CallTool(context.Background(), bob, "wf", args, keyed("k1"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, otherCaller.StructuredContent["execution"])
+
+	_, err = c.CallTool(context.Background(), alice, "wf", map[string]any{"n": 2}, keyed("k1"))
+	require.ErrorIs(t, err, vmcp.ErrInvalidInput)
+	assert.ErrorIs(t, err, composer.ErrIdempotencyKeyReused)
+}
+
 func TestCallTool_CompositeNotAccessible(t *testing.T) {
 	t.Parallel()
 	cfg, m := baseConfig(t)
@@ -269,6 +366,101 @@ func TestCallTool_ResolvesRenamedTool(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestCallTool_PerToolTimeout verifies that a tool's CallTimeout bounds its backend
+// call: a fast tool under a generous timeout completes, a slow tool under a tight one
+// is cut off with a tool error result naming it, and a tool without a timeout runs
+// unbounded.
+func TestCallTool_PerToolTimeout(t *testing.T) {
+	t.Parallel()
+
+	// backend answers after delay unless the call's context ends first.
+	backend := func(delay time.Duration) func(context.Context, *vmcp.BackendTarget, string, map[string]any, map[string]any) (*vmcp.ToolCallResult, error) {
+		return func(ctx context.Context, _ *vmcp.BackendTarget, _ string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			select {
+			case <-time.After(delay):
+				return &vmcp.ToolCallResult{StructuredContent: map[string]any{"result": "ok"}}, nil
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w: %v", vmcp.ErrTimeout, ctx.Err())
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		delay       time.Duration
+		callTimeout time.Duration
+		wantTimeout bool
+	}{
+		{name: "fast tool within its timeout", delay: 0, callTimeout: time.Minute},
+		{name: "slow tool past its timeout", delay: time.Minute, callTimeout: 20 * time.Millisecond, wantTimeout: true},
+		{name: "slow tool with a longer timeout", delay: 50 * time.Millisecond, callTimeout: time.Minute},
+		{name: "no timeout configured", delay: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, m := baseConfig(t)
+
+			target := backendTarget()
+			target.CallTimeout = tt.callTimeout
+			expectAggregation(m, &aggregator.AggregatedCapabilities{
+				Tools:        []vmcp.Tool{backendTool("search")},
+				RoutingTable: &vmcp.RoutingTable{Tools: map[string]*vmcp.BackendTarget{"search": target}},
+			})
+			m.client.EXPECT().CallTool(gomock.Any(), target, "search", gomock.Any(), gomock.Any()).
+				DoAndReturn(backend(tt.delay))
+
+			c, err := New(cfg)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = c.Close() })
+
+			got, err := c.CallTool(context.Background(), nil, "search", nil, nil)
+			require.NoError(t, err)
+			if !tt.wantTimeout {
+				assert.False(t, got.IsError)
+				assert.Equal(t, "ok", got.StructuredContent["result"])
+				return
+			}
+			assert.True(t, got.IsError)
+			assert.Equal(t, "search", got.StructuredContent["tool"])
+			assert.Equal(t, "timeout", got.StructuredContent["error"])
+			require.Len(t, got.Content, 1)
+			assert.Contains(t, got.Content[0].Text, `"search"`)
+			assert.Equal(t, testBackendID, got.BackendID)
+		})
+	}
+}
+
+// TestCallTool_CallerDeadlineNotReportedAsToolTimeout verifies that when the caller's
+// own context ends first, the backend error is returned rather than a tool timeout
+// result.
+func TestCallTool_CallerDeadlineNotReportedAsToolTimeout(t *testing.T) {
+	t.Parallel()
+	cfg, m := baseConfig(t)
+
+	target := backendTarget()
+	target.CallTimeout = time.Minute
+	expectAggregation(m, &aggregator.AggregatedCapabilities{
+		Tools:        []vmcp.Tool{backendTool("search")},
+		RoutingTable: &vmcp.RoutingTable{Tools: map[string]*vmcp.BackendTarget{"search": target}},
+	})
+	m.client.EXPECT().CallTool(gomock.Any(), target, "search", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *vmcp.BackendTarget, _ string, _, _ map[string]any) (*vmcp.ToolCallResult, error) {
+			<-ctx.Done()
+			return nil, fmt.Errorf("%w: %v", vmcp.ErrTimeout, ctx.Err())
+		})
+
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.CallTool(ctx, nil, "search", nil, nil)
+	require.ErrorIs(t, err, vmcp.ErrTimeout)
+}
+
 // TestCompositeNameConflict_AdvertisedEqualsExecuted is the F1 parity guard: when a
 // composite tool name collides with a backend tool name, ListTools advertises the
 // backend tool (composites dropped) and CallTool must ALSO route that name to the
@@ -540,6 +732,19 @@ func TestExecuteComposite(t *testing.T) {
 			wantIsError: true,
 			wantMsg:     "timeout",
 		},
+		{
+			name: "timeout with partial results",
+			composer: stubComposer{
+				result: &composer.WorkflowResult{
+					Status:         composer.WorkflowStatusTimedOut,
+					PartialOutputs: map[string]any{"fetch": map[string]any{"data": "x"}},
+				},
+				err: fmt.Errorf("%w: %w", composer.ErrWorkflowTimeout, context.DeadlineExceeded),
+			},
+			wantIsError: true,
+			wantMsg:     `partial results from 1 completed steps: {"fetch":{"data":"x"}}`,
+			wantOutput:  map[string]any{"fetch": map[string]any{"data": "x"}},
+		},
 		{
 			name:        "nil result",
 			composer:    stubComposer{},
@@ -564,8 +769,8 @@ func TestExecuteComposite(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := executeComposite(context.Background(), tt.composer, def, nil)
-			require.NoError(t, err) // workflow failures are returned as IsError results, not errors
+			// Workflow failures are returned as IsError results, not errors.
+			got := executeComposite(context.Background(), tt.composer, def, nil)
 			require.NotNil(t, got)
 			assert.Equal(t, tt.wantIsError, got.IsError)
 			if tt.wantMsg != "" {
@@ -6,6 +6,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -14,14 +15,10 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/stacklok/toolhive/pkg/telemetry"
+	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 )
 
-// instrumentationName is the OTEL scope used for all metrics and traces emitted
-// by the core. Must match the scope used by pkg/vmcp/internal/backendtelemetry
-// and pkg/vmcp/server/sessionmanager so they share the same Prometheus namespace.
-const instrumentationName = "github.com/stacklok/toolhive/pkg/vmcp"
-
 // workflowInstruments holds pre-built OTEL instruments for workflow execution
 // telemetry. Created once in core.New when TelemetryProvider is set and reused
 // across all per-call composer factories.
@@ -42,7 +39,7 @@ func newWorkflowInstruments(provider *telemetry.Provider) (*workflowInstruments,
 		return nil, nil
 	}
 
-	meter := provider.MeterProvider().Meter(instrumentationName)
+	meter := provider.MeterProvider().Meter(vmcp.InstrumentationName)
 
 	executions, err := meter.Int64Counter(
 		"toolhive_vmcp_workflow_executions",
@@ -71,13 +68,114 @@ func newWorkflowInstruments(provider *telemetry.Provider) (*workflowInstruments,
 	}
 
 	return &workflowInstruments{
-		tracer:            provider.TracerProvider().Tracer(instrumentationName),
+		tracer:            provider.TracerProvider().Tracer(vmcp.InstrumentationName),
 		executionsTotal:   executions,
 		errorsTotal:       errors,
 		executionDuration: duration,
 	}, nil
 }
 
+// Routing outcomes recorded on the toolhive_vmcp_tool_routing counter.
+const (
+	routingOutcomeSuccess  = "success"
+	routingOutcomeNotFound = "not_found"
+	routingOutcomeError    = "error"
+)
+
+const (
+	// maxToolNameLabels bounds the number of distinct tool.name values the
+	// routing counter records. Routed names come from the aggregated routing
+	// table, which is bounded by what backends advertise, but a backend that
+	// advertises thousands of tools must not be able to blow up the metric
+	// series count. Names beyond the limit are recorded as overflowLabelValue.
+	maxToolNameLabels = 512
+
+	// overflowLabelValue replaces a label value once its limit is reached.
+	overflowLabelValue = "_other"
+
+	// unknownLabelValue replaces a client-supplied tool name that did not
+	// resolve. Unresolved names are arbitrary client input and are never used
+	// as label values.
+	unknownLabelValue = "_unknown"
+)
+
+// routingInstruments holds the OTEL instruments for backend tool-call routing.
+// A nil *routingInstruments is valid and records nothing (telemetry disabled).
+type routingInstruments struct {
+	routedCalls metric.Int64Counter
+	toolNames   *labelLimiter
+}
+
+// newRoutingInstruments creates the OTEL instruments for tool-call routing.
+// Returns nil if provider is nil (telemetry disabled).
+func newRoutingInstruments(provider *telemetry.Provider) (*routingInstruments, error) {
+	if provider == nil {
+		return nil, nil
+	}
+	return newRoutingInstrumentsFromMeter(provider.MeterProvider().Meter(vmcp.InstrumentationName))
+}
+
+// newRoutingInstrumentsFromMeter creates the routing instruments on meter.
+func newRoutingInstrumentsFromMeter(meter metric.Meter) (*routingInstruments, error) {
+	routedCalls, err := meter.Int64Counter(
+		"toolhive_vmcp_tool_routing",
+		metric.WithDescription("Total number of tool calls routed to a backend, by backend and outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool routing counter: %w", err)
+	}
+	return &routingInstruments{
+		routedCalls: routedCalls,
+		toolNames:   newLabelLimiter(maxToolNameLabels),
+	}, nil
+}
+
+// recordToolCall records one routed tool call. backendID is empty when the name
+// did not resolve to a backend, in which case the tool name is recorded as
+// unknownLabelValue.
+func (r *routingInstruments) recordToolCall(ctx context.Context, backendID, toolName, outcome string) {
+	if r == nil {
+		return
+	}
+	toolLabel := unknownLabelValue
+	if backendID != "" {
+		toolLabel = r.toolNames.value(toolName)
+	}
+	r.routedCalls.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("backend.id", backendID),
+		attribute.String("tool.name", toolLabel),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// labelLimiter caps the number of distinct values a metric label may take.
+// The first max distinct values are passed through; any further value is
+// replaced by overflowLabelValue. Safe for concurrent use.
+type labelLimiter struct {
+	mu     sync.Mutex
+	max    int
+	values map[string]struct{}
+}
+
+func newLabelLimiter(maxValues int) *labelLimiter {
+	return &labelLimiter{max: maxValues, values: make(map[string]struct{}, maxValues)}
+}
+
+// value returns v if it is already tracked or there is room to track it, and
+// overflowLabelValue otherwise.
+func (l *labelLimiter) value(v string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.values[v]; ok {
+		return v
+	}
+	if len(l.values) >= l.max {
+		return overflowLabelValue
+	}
+	l.values[v] = struct{}{}
+	return v
+}
+
 // telemetryComposer wraps composer.Composer.ExecuteWorkflow with OTEL metrics
 // and tracing. ValidateWorkflow is delegated to the base without instrumentation
 // (validation is called once at startup, not on the hot path).
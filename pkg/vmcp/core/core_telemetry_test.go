@@ -10,11 +10,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
 
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/aggregator"
 	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 )
 
@@ -30,7 +34,7 @@ func newTestInstruments(t *testing.T) (*workflowInstruments, *sdkmetric.ManualRe
 	reader := sdkmetric.NewManualReader()
 	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
 	tp := tracesdk.NewTracerProvider()
-	meter := mp.Meter(instrumentationName)
+	meter := mp.Meter(vmcp.InstrumentationName)
 
 	executions, err := meter.Int64Counter("toolhive_vmcp_workflow_executions")
 	require.NoError(t, err)
@@ -40,7 +44,7 @@ func newTestInstruments(t *testing.T) (*workflowInstruments, *sdkmetric.ManualRe
 	require.NoError(t, err)
 
 	return &workflowInstruments{
-		tracer:            tp.Tracer(instrumentationName),
+		tracer:            tp.Tracer(vmcp.InstrumentationName),
 		executionsTotal:   executions,
 		errorsTotal:       errs,
 		executionDuration: duration,
@@ -169,3 +173,82 @@ func TestTelemetryComposer_DelegatesNonExecuteMethods(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, tc.CancelWorkflow(context.Background(), "any-id"))
 }
+
+// int64CounterValueWith sums the data points of m whose attributes contain the
+// given key/value pair.
+func int64CounterValueWith(m *metricdata.Metrics, key, value string) int64 {
+	if m == nil {
+		return 0
+	}
+	s, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		return 0
+	}
+	var total int64
+	for _, dp := range s.DataPoints {
+		if v, ok := dp.Attributes.Value(attribute.Key(key)); ok && v.AsString() == value {
+			total += dp.Value
+		}
+	}
+	return total
+}
+
+// TestCallTool_RecordsRoutingMetrics verifies that routed backend tool calls
+// increment toolhive_vmcp_tool_routing labeled by backend and outcome, and that
+// an unresolved name is recorded without leaking the client-supplied name.
+func TestCallTool_RecordsRoutingMetrics(t *testing.T) {
+	t.Parallel()
+	cfg, m := baseConfig(t)
+
+	target := backendTarget()
+	m.reg.EXPECT().List(gomock.Any()).
+		Return([]vmcp.Backend{{ID: testBackendID, HealthStatus: vmcp.BackendHealthy}}).Times(3)
+	m.agg.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).Return(&aggregator.AggregatedCapabilities{
+		Tools:        []vmcp.Tool{backendTool("tool_a")},
+		RoutingTable: &vmcp.RoutingTable{Tools: map[string]*vmcp.BackendTarget{"tool_a": target}},
+	}, nil).Times(3)
+	gomock.InOrder(
+		m.client.EXPECT().CallTool(gomock.Any(), gomock.Any(), "tool_a", gomock.Any(), gomock.Any()).
+			Return(&vmcp.ToolCallResult{}, nil),
+		m.client.EXPECT().CallTool(gomock.Any(), gomock.Any(), "tool_a", gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("backend down")),
+	)
+
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	routing, err := newRoutingInstrumentsFromMeter(mp.Meter(vmcp.InstrumentationName))
+	require.NoError(t, err)
+	c.(*coreVMCP).routing = routing
+
+	_, err = c.CallTool(context.Background(), nil, "tool_a", nil, nil)
+	require.NoError(t, err)
+	_, err = c.CallTool(context.Background(), nil, "tool_a", nil, nil)
+	require.Error(t, err)
+	_, err = c.CallTool(context.Background(), nil, "missing", nil, nil)
+	require.ErrorIs(t, err, vmcp.ErrNotFound)
+
+	routed := findMetricByName(collectMetrics(t, reader), "toolhive_vmcp_tool_routing")
+	require.NotNil(t, routed)
+	assert.Equal(t, int64(3), int64CounterValue(routed))
+	assert.Equal(t, int64(2), int64CounterValueWith(routed, "backend.id", testBackendID))
+	assert.Equal(t, int64(1), int64CounterValueWith(routed, "outcome", routingOutcomeSuccess))
+	assert.Equal(t, int64(1), int64CounterValueWith(routed, "outcome", routingOutcomeError))
+	assert.Equal(t, int64(1), int64CounterValueWith(routed, "outcome", routingOutcomeNotFound))
+	assert.Equal(t, int64(1), int64CounterValueWith(routed, "tool.name", unknownLabelValue))
+	assert.Zero(t, int64CounterValueWith(routed, "tool.name", "missing"),
+		"unresolved client-supplied names must never become label values")
+}
+
+func TestLabelLimiter(t *testing.T) {
+	t.Parallel()
+
+	l := newLabelLimiter(2)
+	assert.Equal(t, "a", l.value("a"))
+	assert.Equal(t, "b", l.value("b"))
+	assert.Equal(t, overflowLabelValue, l.value("c"), "values past the limit collapse to the overflow label")
+	assert.Equal(t, "a", l.value("a"), "already-tracked values pass through after the limit is reached")
+}
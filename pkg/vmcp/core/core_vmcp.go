@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
@@ -75,12 +76,31 @@ type coreVMCP struct {
 	// table, generalizing server.New's sessionComposerFactory (server.go:393).
 	composerFactory func(sessionRT *vmcp.RoutingTable, sessionTools []vmcp.Tool) composer.Composer
 
+	// idempotency deduplicates composite tool calls retried with the same
+	// idempotency key. Nil when the state store does not support it, in which
+	// case every call executes.
+	idempotency composer.IdempotencyStore
+
+	// callAuditor emits audit events for dispatched tool calls, resource reads,
+	// and prompt fetches. Nil when auditing is disabled (its methods are nil-safe).
+	callAuditor *audit.VMCPAuditor
+
+	// routing records tool-call routing metrics. Nil when telemetry is disabled
+	// (its methods are nil-safe).
+	routing *routingInstruments
+
 	// stopStore stops the workflow state store's background cleanup goroutine.
 	// Captured at construction (the store is created internally, not injected) so
 	// Close is not a silent capability assertion. Guarded by closeOnce.
 	stopStore func()
 
 	closeOnce sync.Once
+
+	// excludedMu guards excluded, the IDs of the backends aggregatedView last
+	// left out on health. A backend entering or leaving that set has its cached
+	// capabilities invalidated (see invalidateHealthTransitions).
+	excludedMu sync.Mutex
+	excluded   map[string]struct{}
 }
 
 var _ VMCP = (*coreVMCP)(nil)
@@ -125,8 +145,15 @@ func New(cfg *Config) (VMCP, error) {
 		backendClient = decorated
 	}
 
-	// Workflow auditor (server.go:370-381).
+	routing, err := newRoutingInstruments(cfg.TelemetryProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create routing telemetry instruments: %w", err)
+	}
+
+	// Workflow auditor (server.go:370-381) and the dispatch auditor for
+	// tool calls, resource reads, and prompt fetches.
 	var workflowAuditor *audit.WorkflowAuditor
+	var callAuditor *audit.VMCPAuditor
 	if cfg.AuditConfig != nil {
 		if err := cfg.AuditConfig.Validate(); err != nil {
 			return nil, fmt.Errorf("invalid audit configuration: %w", err)
@@ -136,6 +163,10 @@ func New(cfg *Config) (VMCP, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create workflow auditor: %w", err)
 		}
+		callAuditor, err = audit.NewVMCPAuditor(cfg.AuditConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create call auditor: %w", err)
+		}
 		slog.Info("workflow audit logging enabled")
 	}
 
@@ -160,6 +191,10 @@ func New(cfg *Config) (VMCP, error) {
 			"type", fmt.Sprintf("%T", stateStore))
 	}
 
+	// The in-memory store also deduplicates composite tool calls retried with an
+	// idempotency key; a store without that capability leaves calls undeduplicated.
+	idempotency, _ := stateStore.(composer.IdempotencyStore)
+
 	// Build workflow telemetry instruments once here so they are reused across all
 	// per-call composer factories. Nil when TelemetryProvider is absent (disabled).
 	// Uses the same metric names as the session-factory composite-tool decorator in
@@ -171,26 +206,42 @@ func New(cfg *Config) (VMCP, error) {
 		return nil, fmt.Errorf("failed to create workflow telemetry instruments: %w", err)
 	}
 
-	// composerFactory builds a composite-tool engine bound to a specific routing
-	// table. When telemetry is configured, it wraps the engine with OTEL metrics so
-	// workflow executions are instrumented the same way as the session-factory path
-	// (sessionmanager/factory.go). The telemetryComposer is in core_telemetry.go.
-	composerFactory := func(sessionRT *vmcp.RoutingTable, sessionTools []vmcp.Tool) composer.Composer {
-		engine := composer.NewWorkflowEngine(
-			router.NewSessionRouter(sessionRT), backendClient, elicitationHandler,
-			stateStore, workflowAuditor, sessionTools,
+	// Step and composition-depth limits apply to both the validation engine and
+	// the per-call engines.
+	limitOpts := []composer.EngineOption{composer.WithWorkflowLimits(cfg.WorkflowLimits)}
+
+	// Per-step spans and metrics. Step spans are children of the
+	// core.ExecuteWorkflow span started by telemetryComposer.
+	engineOpts := slices.Clone(limitOpts)
+	if cfg.TelemetryProvider != nil {
+		stepTelemetry, err := composer.NewStepTelemetry(
+			cfg.TelemetryProvider.MeterProvider(), cfg.TelemetryProvider.TracerProvider(),
 		)
-		if instruments != nil {
-			return &telemetryComposer{base: engine, instruments: instruments}
+		if err != nil {
+			stopStore()
+			return nil, fmt.Errorf("failed to create workflow step telemetry: %w", err)
 		}
-		return engine
+		engineOpts = append(engineOpts, composer.WithStepTelemetry(stepTelemetry))
+	}
+	if cfg.WorkflowEvents != nil {
+		engineOpts = append(engineOpts, composer.WithStepEvents(cfg.WorkflowEvents))
+	}
+	if cfg.WorkflowFailureSink != nil {
+		var redactFields []string
+		if cfg.AuditConfig != nil {
+			redactFields = cfg.AuditConfig.RedactFields
+		}
+		engineOpts = append(engineOpts, composer.WithFailureSink(cfg.WorkflowFailureSink, redactFields))
 	}
 
 	// Validate workflows fail-fast (server.go:400-405). The validation engine uses
 	// cfg.Router; ValidateWorkflow checks structure (cycles, references) and does
 	// not route, so the context-coupled default router is acceptable here.
+	// It sees every configured composite so composite steps can be resolved and
+	// recursion detected.
 	validationEngine := composer.NewWorkflowEngine(
 		cfg.Router, backendClient, elicitationHandler, stateStore, workflowAuditor, nil,
+		append(slices.Clone(limitOpts), composer.WithCompositeDefinitions(cfg.WorkflowDefs))...,
 	)
 	workflowDefs, err := validateWorkflowDefs(validationEngine, cfg.WorkflowDefs)
 	if err != nil {
@@ -198,17 +249,41 @@ func New(cfg *Config) (VMCP, error) {
 		return nil, fmt.Errorf("workflow validation failed: %w", err)
 	}
 
+	// Per-call engines only resolve composite steps against workflows that
+	// passed validation.
+	engineOpts = append(engineOpts, composer.WithCompositeDefinitions(workflowDefs))
+
 	// Build and start the backend health monitor (#5443 reversal: the core owns its
 	// lifecycle). The core filters capabilities with it and stops it in Close. It is started
 	// with context.Background() and torn down via Close — like the state store — since New has
 	// no request-scoped context. The monitor uses the undecorated backend client so health
-	// checks do not emit backend-call telemetry. Built last so few error paths must stop it.
+	// checks do not emit backend-call telemetry. It is built ahead of composerFactory, whose
+	// engines need its status, but after every fallible step, so no later error path must stop it.
 	healthMonitor, healthProvider, err := buildHealthMonitor(cfg)
 	if err != nil {
 		stopStore()
 		return nil, err
 	}
 
+	// Per-call engines refuse to start a workflow whose backends are unavailable,
+	// using the monitor's live status (or the registry status when unmonitored).
+	engineOpts = append(engineOpts, composer.WithBackendHealthCheck(healthProvider))
+
+	// composerFactory builds a composite-tool engine bound to a specific routing
+	// table. When telemetry is configured, it wraps the engine with OTEL metrics so
+	// workflow executions are instrumented the same way as the session-factory path
+	// (sessionmanager/factory.go). The telemetryComposer is in core_telemetry.go.
+	composerFactory := func(sessionRT *vmcp.RoutingTable, sessionTools []vmcp.Tool) composer.Composer {
+		engine := composer.NewWorkflowEngine(
+			router.NewSessionRouter(sessionRT), backendClient, elicitationHandler,
+			stateStore, workflowAuditor, sessionTools, engineOpts...,
+		)
+		if instruments != nil {
+			return &telemetryComposer{base: engine, instruments: instruments}
+		}
+		return engine
+	}
+
 	return &coreVMCP{
 		aggregator:      cfg.Aggregator,
 		backendRegistry: cfg.BackendRegistry,
@@ -218,6 +293,9 @@ func New(cfg *Config) (VMCP, error) {
 		admission:       admission,
 		workflowDefs:    workflowDefs,
 		composerFactory: composerFactory,
+		idempotency:     idempotency,
+		routing:         routing,
+		callAuditor:     callAuditor,
 		stopStore:       stopStore,
 	}, nil
 }
@@ -501,6 +579,16 @@ func (c *coreVMCP) ListBackends(
 	return c.authorizedBackends(ctx, identity, all)
 }
 
+// RoutingTable returns the routing table of the current aggregated view, without
+// admission filtering. See the [VMCP] interface contract.
+func (c *coreVMCP) RoutingTable(ctx context.Context) (*vmcp.RoutingTable, error) {
+	agg, err := c.aggregatedView(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return agg.RoutingTable, nil
+}
+
 // LookupBackend resolves a single backend id in the authorized view, mirroring
 // LookupTool: it delegates to ListBackends(ctx, identity, true) and returns
 // vmcp.ErrNotFound for an id that view does not contain (unknown, out-of-group, or
@@ -558,7 +646,46 @@ func (c *coreVMCP) Close() error {
 // lookup-then-call flow aggregates twice. This is intentional — caching is the
 // Serve layer's responsibility, not the core's (vmcp anti-patterns #8/#9).
 func (c *coreVMCP) aggregatedView(ctx context.Context) (*aggregator.AggregatedCapabilities, error) {
-	return c.aggregateBackends(ctx, filterHealthyBackends(c.backendRegistry.List(ctx), c.health))
+	all := c.backendRegistry.List(ctx)
+	healthy := filterHealthyBackends(all, c.health)
+	c.invalidateHealthTransitions(all, healthy)
+	return c.aggregateBackends(ctx, healthy)
+}
+
+// invalidateHealthTransitions drops the aggregator's cached capabilities for every
+// backend whose health moved it into or out of the healthy set since the previous
+// aggregatedView, so a backend that recovers is queried again rather than served
+// what it advertised before it failed. It is a no-op when the aggregator caches
+// nothing per backend.
+func (c *coreVMCP) invalidateHealthTransitions(all, healthy []vmcp.Backend) {
+	invalidator, ok := c.aggregator.(aggregator.BackendCacheInvalidator)
+	if !ok {
+		return
+	}
+
+	excluded := make(map[string]struct{}, len(all)-len(healthy))
+	for i := range all {
+		excluded[all[i].ID] = struct{}{}
+	}
+	for i := range healthy {
+		delete(excluded, healthy[i].ID)
+	}
+
+	c.excludedMu.Lock()
+	previous := c.excluded
+	c.excluded = excluded
+	c.excludedMu.Unlock()
+
+	for id := range excluded {
+		if _, was := previous[id]; !was {
+			invalidator.InvalidateBackend(id)
+		}
+	}
+	for id := range previous {
+		if _, is := excluded[id]; !is {
+			invalidator.InvalidateBackend(id)
+		}
+	}
 }
 
 // aggregateBackends aggregates capabilities across the given backends. The caller
@@ -680,6 +680,51 @@ func TestInvalidateCapabilityCache_WarnsWhenUnsupported(t *testing.T) {
 		"unsupported aggregator must WARN-log, not silently no-op")
 }
 
+// TestCallTool_HealthChangeReroutes verifies that behind the Serve path's capability cache a
+// backend's health flipping changes which tools route. Losing a backend is served by pruning
+// the cached view; a recovered backend has its cached capabilities invalidated, so the one
+// sweep after recovery queries it again.
+func TestCallTool_HealthChangeReroutes(t *testing.T) {
+	t.Parallel()
+	cfg, m := baseConfig(t)
+
+	m.reg.EXPECT().List(gomock.Any()).Return([]vmcp.Backend{{ID: "be1"}, {ID: "be2"}}).AnyTimes()
+	routes := map[string]*vmcp.BackendTarget{}
+	var tools []vmcp.Tool
+	for _, id := range []string{"be1", "be2"} {
+		routes[id+"_echo"] = &vmcp.BackendTarget{WorkloadID: id}
+		tools = append(tools, vmcp.Tool{Name: id + "_echo", BackendID: id})
+	}
+	m.agg.EXPECT().AggregateCapabilities(gomock.Any(), gomock.Any()).Return(&aggregator.AggregatedCapabilities{
+		Tools:        tools,
+		RoutingTable: &vmcp.RoutingTable{Tools: routes},
+		Metadata:     &aggregator.AggregationMetadata{ConflictStrategy: vmcp.ConflictStrategyPrefix},
+	}, nil).Times(2)
+	m.client.EXPECT().CallTool(gomock.Any(), gomock.Any(), "be2_echo", gomock.Any(), gomock.Any()).
+		Return(&vmcp.ToolCallResult{}, nil).Times(2)
+
+	cached, err := aggregator.NewCachingAggregator(m.agg, time.Hour, nil)
+	require.NoError(t, err)
+	cfg.Aggregator = cached
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	health := fakeStatusProvider{"be1": vmcp.BackendHealthy, "be2": vmcp.BackendHealthy}
+	c.(*coreVMCP).health = health
+
+	ctx := context.Background()
+	_, err = c.CallTool(ctx, nil, "be2_echo", nil, nil)
+	require.NoError(t, err)
+
+	health["be2"] = vmcp.BackendUnhealthy
+	_, err = c.CallTool(ctx, nil, "be2_echo", nil, nil)
+	require.ErrorIs(t, err, vmcp.ErrNotFound, "an unhealthy backend's tools must stop routing")
+
+	health["be2"] = vmcp.BackendHealthy
+	_, err = c.CallTool(ctx, nil, "be2_echo", nil, nil)
+	require.NoError(t, err, "a recovered backend's tools must route again")
+}
+
 // Must run serially: it swaps the global slog default to capture output into a
 // non-thread-safe buffer, which is unsafe if other tests log concurrently.
 //
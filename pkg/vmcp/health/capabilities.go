@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// CapabilityChange reports which capability kinds of a backend changed between
+// two successful health checks.
+type CapabilityChange struct {
+	// BackendID identifies the backend whose capabilities changed.
+	BackendID string
+
+	// Tools is true when the backend's tool set changed.
+	Tools bool
+
+	// Resources is true when the backend's resources or resource templates changed.
+	Resources bool
+
+	// Prompts is true when the backend's prompt set changed.
+	Prompts bool
+}
+
+// CapabilityChangeFunc is called when a health check observes that a backend's
+// capabilities differ from the previous check. It runs on the backend's health
+// check goroutine and must not block.
+type CapabilityChangeFunc func(change CapabilityChange)
+
+// capabilityFingerprint summarizes a backend's capability list, one digest per
+// capability kind, so consecutive health checks can be compared cheaply.
+type capabilityFingerprint struct {
+	tools     string
+	resources string
+	prompts   string
+}
+
+// capabilityTracker remembers the last capability fingerprint of every backend
+// and notifies the registered handlers when it changes. The first observation
+// of a backend only records its baseline.
+type capabilityTracker struct {
+	mu           sync.Mutex
+	fingerprints map[string]capabilityFingerprint
+	handlers     []CapabilityChangeFunc
+}
+
+func newCapabilityTracker() *capabilityTracker {
+	return &capabilityTracker{fingerprints: make(map[string]capabilityFingerprint)}
+}
+
+// subscribe registers fn to be called on every capability change.
+func (t *capabilityTracker) subscribe(fn CapabilityChangeFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handlers = append(t.handlers, fn)
+}
+
+// observe records caps as the current capabilities of backendID and notifies
+// the handlers when they differ from the previous observation.
+func (t *capabilityTracker) observe(backendID string, caps *vmcp.CapabilityList) {
+	current := fingerprintCapabilities(caps)
+
+	t.mu.Lock()
+	previous, seen := t.fingerprints[backendID]
+	t.fingerprints[backendID] = current
+	handlers := slices.Clone(t.handlers)
+	t.mu.Unlock()
+
+	if !seen || previous == current {
+		return
+	}
+
+	change := CapabilityChange{
+		BackendID: backendID,
+		Tools:     previous.tools != current.tools,
+		Resources: previous.resources != current.resources,
+		Prompts:   previous.prompts != current.prompts,
+	}
+	slog.Debug("backend capabilities changed",
+		"backend", backendID, "tools", change.Tools, "resources", change.Resources, "prompts", change.Prompts)
+	for _, handler := range handlers {
+		handler(change)
+	}
+}
+
+// forget drops the fingerprint of a backend that is no longer monitored, so a
+// backend re-added later starts from a fresh baseline.
+func (t *capabilityTracker) forget(backendID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.fingerprints, backendID)
+}
+
+// fingerprintCapabilities digests caps per capability kind. Entries are sorted
+// by their identifying field first, so a backend listing the same capabilities
+// in a different order does not register as a change.
+func fingerprintCapabilities(caps *vmcp.CapabilityList) capabilityFingerprint {
+	tools := slices.SortedFunc(slices.Values(caps.Tools), func(a, b vmcp.Tool) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	resources := slices.SortedFunc(slices.Values(caps.Resources), func(a, b vmcp.Resource) int {
+		return cmp.Compare(a.URI, b.URI)
+	})
+	templates := slices.SortedFunc(slices.Values(caps.ResourceTemplates), func(a, b vmcp.ResourceTemplate) int {
+		return cmp.Compare(a.URITemplate, b.URITemplate)
+	})
+	prompts := slices.SortedFunc(slices.Values(caps.Prompts), func(a, b vmcp.Prompt) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return capabilityFingerprint{
+		tools:     digest(tools),
+		resources: digest([]any{resources, templates}),
+		prompts:   digest(prompts),
+	}
+}
+
+// digest returns a hex-encoded SHA-256 digest of v's JSON encoding. JSON map
+// keys are sorted, so equal values always produce the same digest.
+func digest(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Capability lists hold only JSON-encodable values, so this is not
+		// expected in practice.
+		slog.Debug("failed to encode capabilities for fingerprinting", "error", err)
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -30,6 +30,10 @@ type healthChecker struct {
 	// If a health check succeeds but takes longer than this duration, the backend is marked degraded.
 	// Zero means disabled (backends will never be marked degraded based on response time alone).
 	degradedThreshold time.Duration
+
+	// observeCapabilities, when set, receives the capabilities returned by every
+	// successful health check. The Monitor uses it to detect capability changes.
+	observeCapabilities func(backendID string, caps *vmcp.CapabilityList)
 }
 
 // NewHealthChecker creates a new health checker that uses BackendClient.ListCapabilities
@@ -96,7 +100,7 @@ func (h *healthChecker) CheckHealth(ctx context.Context, target *vmcp.BackendTar
 	// 2. MCP protocol initialization handshake
 	// 3. Capabilities query (tools, resources, prompts)
 	// This validates the full communication stack
-	_, err := h.client.ListCapabilities(checkCtx, target)
+	caps, err := h.client.ListCapabilities(checkCtx, target)
 	responseDuration := time.Since(startTime)
 
 	if err != nil {
@@ -121,6 +125,10 @@ func (h *healthChecker) CheckHealth(ctx context.Context, target *vmcp.BackendTar
 		return status, fmt.Errorf("health check failed: %w", err)
 	}
 
+	if h.observeCapabilities != nil && caps != nil {
+		h.observeCapabilities(target.WorkloadID, caps)
+	}
+
 	// Check if response time indicates degraded performance
 	if h.degradedThreshold > 0 && responseDuration > h.degradedThreshold {
 		slog.Warn("health check succeeded but response was slow - marking as degraded",
@@ -4,6 +4,7 @@
 package health
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -70,6 +71,9 @@ type Reporter interface {
 	UpdateBackends(newBackends []vmcp.Backend)
 	// BuildStatus assembles the aggregate vMCP status from current backend health.
 	BuildStatus() *vmcp.Status
+	// OnCapabilitiesChanged registers fn to be called when a health check observes
+	// that a backend's capabilities changed since its previous check.
+	OnCapabilitiesChanged(fn CapabilityChangeFunc)
 }
 
 var _ Reporter = (*Monitor)(nil)
@@ -116,15 +120,28 @@ func (bc *backendCheck) stop() {
 // and consecutive failure counts. The monitor supports graceful shutdown and
 // provides thread-safe access to backend health information.
 type Monitor struct {
-	// checker performs health checks on backends.
+	// checker performs health checks on backends using the MCP probe.
 	checker vmcp.HealthChecker
 
+	// httpProbe performs health checks on backends using the HTTP GET probe.
+	httpProbe *httpGetChecker
+
 	// statusTracker tracks health status for all backends.
 	statusTracker *statusTracker
 
+	// capabilities detects capability changes between health checks.
+	capabilities *capabilityTracker
+
 	// checkInterval is how often to perform health checks.
 	checkInterval time.Duration
 
+	// defaultSettings are the check settings of backends without an override.
+	defaultSettings CheckSettings
+
+	// backendSettings are per-backend check setting overrides, keyed by backend ID.
+	// Zero fields inherit defaultSettings.
+	backendSettings map[string]CheckSettings
+
 	// backends is the list of backends to monitor.
 	// Protected by backendsMu for thread-safe updates during backend changes.
 	backends   []vmcp.Backend
@@ -182,6 +199,22 @@ type MonitorConfig struct {
 	// CircuitBreaker contains circuit breaker configuration.
 	// nil means circuit breaker is disabled.
 	CircuitBreaker *CircuitBreakerConfig
+
+	// HealthyThreshold is the number of consecutive successes a failing backend
+	// needs before it is reported healthy again; until then it is degraded.
+	// Zero means 2.
+	HealthyThreshold int
+
+	// Probe is the probe method. Empty means ProbeMCP.
+	Probe ProbeMethod
+
+	// ProbePath is the path requested when Probe is ProbeHTTPGet.
+	// Empty means DefaultProbePath.
+	ProbePath string
+
+	// Backends overrides the check settings of individual backends, keyed by
+	// backend ID. Zero fields inherit the values above.
+	Backends map[string]CheckSettings
 }
 
 // CircuitBreakerConfig contains circuit breaker configuration.
@@ -234,6 +267,23 @@ func NewMonitor(
 	if config.UnhealthyThreshold < 1 {
 		return nil, fmt.Errorf("unhealthy threshold must be >= 1, got %d", config.UnhealthyThreshold)
 	}
+	if config.HealthyThreshold < 0 {
+		return nil, fmt.Errorf("healthy threshold must not be negative, got %d", config.HealthyThreshold)
+	}
+	if err := validateProbe(config.Probe); err != nil {
+		return nil, err
+	}
+	for id, settings := range config.Backends {
+		if settings.Interval < 0 || settings.Timeout < 0 {
+			return nil, fmt.Errorf("backend %s: interval and timeout must not be negative", id)
+		}
+		if settings.UnhealthyThreshold < 0 || settings.HealthyThreshold < 0 {
+			return nil, fmt.Errorf("backend %s: thresholds must not be negative", id)
+		}
+		if err := validateProbe(settings.Probe); err != nil {
+			return nil, fmt.Errorf("backend %s: %w", id, err)
+		}
+	}
 
 	// Validate circuit breaker configuration if provided
 	if config.CircuitBreaker != nil && config.CircuitBreaker.Enabled {
@@ -245,22 +295,80 @@ func NewMonitor(
 		}
 	}
 
-	// Create health checker with degraded threshold
-	checker := NewHealthChecker(client, config.Timeout, config.DegradedThreshold)
+	// Create health checker with degraded threshold. Every successful check feeds
+	// the capability tracker, so capability changes are detected without extra
+	// backend calls. The timeout is applied per backend by performHealthCheck.
+	capabilities := newCapabilityTracker()
+	checker := &healthChecker{
+		client:              client,
+		degradedThreshold:   config.DegradedThreshold,
+		observeCapabilities: capabilities.observe,
+	}
+
+	defaultSettings := CheckSettings{
+		Interval:           config.CheckInterval,
+		Timeout:            config.Timeout,
+		UnhealthyThreshold: config.UnhealthyThreshold,
+		HealthyThreshold:   cmp.Or(config.HealthyThreshold, defaultHealthyThreshold),
+		Probe:              config.Probe,
+		ProbePath:          config.ProbePath,
+	}.withDefaults(CheckSettings{})
 
 	// Create status tracker with circuit breaker configuration
 	// The status tracker will lazily initialize circuit breakers as needed
 	statusTracker := newStatusTracker(config.UnhealthyThreshold, config.CircuitBreaker)
+	statusTracker.healthyThreshold = defaultSettings.HealthyThreshold
+
+	backendSettings := make(map[string]CheckSettings, len(config.Backends))
+	statusTracker.backendThresholds = make(map[string]thresholds, len(config.Backends))
+	for id, settings := range config.Backends {
+		settings = settings.withDefaults(defaultSettings)
+		backendSettings[id] = settings
+		statusTracker.backendThresholds[id] = thresholds{
+			unhealthy: settings.UnhealthyThreshold,
+			healthy:   settings.HealthyThreshold,
+		}
+	}
 
 	return &Monitor{
-		checker:       checker,
-		statusTracker: statusTracker,
-		checkInterval: config.CheckInterval,
-		backends:      backends,
-		activeChecks:  make(map[string]*backendCheck),
+		checker:         checker,
+		httpProbe:       newHTTPGetChecker(client, config.DegradedThreshold),
+		statusTracker:   statusTracker,
+		capabilities:    capabilities,
+		checkInterval:   config.CheckInterval,
+		defaultSettings: defaultSettings,
+		backendSettings: backendSettings,
+		backends:        backends,
+		activeChecks:    make(map[string]*backendCheck),
 	}, nil
 }
 
+// validateProbe returns an error if probe is not a known probe method.
+func validateProbe(probe ProbeMethod) error {
+	switch probe {
+	case "", ProbeMCP, ProbeHTTPGet:
+		return nil
+	default:
+		return fmt.Errorf("unknown health check probe %q", probe)
+	}
+}
+
+// settingsFor returns the effective check settings of a backend.
+func (m *Monitor) settingsFor(backendID string) CheckSettings {
+	if settings, ok := m.backendSettings[backendID]; ok {
+		return settings
+	}
+	return m.defaultSettings
+}
+
+// checkerFor returns the health checker implementing the probe of settings.
+func (m *Monitor) checkerFor(settings CheckSettings) vmcp.HealthChecker {
+	if settings.Probe == ProbeHTTPGet && m.httpProbe != nil {
+		return m.httpProbe.withPath(settings.ProbePath)
+	}
+	return m.checker
+}
+
 // Start begins health monitoring for all backends.
 // This spawns a background goroutine for each backend that performs periodic health checks.
 // Returns an error if the monitor is already started, has been stopped, or if the parent context is invalid.
@@ -402,6 +510,7 @@ func (m *Monitor) UpdateBackends(newBackends []vmcp.Backend) {
 			delete(m.activeChecks, id)
 			// Remove backend from status tracker so it no longer appears in status reports
 			m.statusTracker.RemoveBackend(id)
+			m.capabilities.forget(id)
 		}
 	}
 }
@@ -417,7 +526,7 @@ func (m *Monitor) monitorBackend(ctx context.Context, backend *vmcp.Backend, isI
 	slog.Debug("starting health monitoring for backend", "backend", backend.Name)
 
 	// Create ticker for periodic checks
-	ticker := time.NewTicker(m.checkInterval)
+	ticker := time.NewTicker(cmp.Or(m.settingsFor(backend.ID).Interval, m.checkInterval))
 	defer ticker.Stop()
 
 	// Perform initial health check immediately
@@ -466,6 +575,7 @@ func (m *Monitor) performHealthCheck(ctx context.Context, backend *vmcp.Backend)
 		CABundleData:  backend.CABundleData,
 		AuthConfig:    backend.AuthConfig,
 		HeaderForward: backend.HeaderForward,
+		Stdio:         backend.Stdio,
 		HealthStatus:  vmcp.BackendUnknown, // Status is determined by the health check
 		Metadata:      backend.Metadata,
 	}
@@ -474,8 +584,15 @@ func (m *Monitor) performHealthCheck(ctx context.Context, backend *vmcp.Backend)
 	// Health checks verify backend availability and should not require user credentials
 	healthCheckCtx := WithHealthCheckMarker(ctx)
 
+	settings := m.settingsFor(backend.ID)
+	if settings.Timeout > 0 {
+		var cancel context.CancelFunc
+		healthCheckCtx, cancel = context.WithTimeout(healthCheckCtx, settings.Timeout)
+		defer cancel()
+	}
+
 	// Perform health check
-	status, err := m.checker.CheckHealth(healthCheckCtx, target)
+	status, err := m.checkerFor(settings).CheckHealth(healthCheckCtx, target)
 
 	// Record result in status tracker
 	if err != nil {
@@ -489,6 +606,14 @@ func (m *Monitor) performHealthCheck(ctx context.Context, backend *vmcp.Backend)
 	}
 }
 
+// OnCapabilitiesChanged registers fn to be called when a health check observes that a
+// backend's tools, resources, or prompts differ from its previous successful check. The
+// first check of a backend only records a baseline, so fn is never called for a backend's
+// initial capabilities. fn runs on the backend's health check goroutine and must not block.
+func (m *Monitor) OnCapabilitiesChanged(fn CapabilityChangeFunc) {
+	m.capabilities.subscribe(fn)
+}
+
 // GetBackendStatus returns the current health status for a backend.
 // Returns (status, error). Error is returned if the backend is not being monitored.
 func (m *Monitor) GetBackendStatus(backendID string) (vmcp.BackendHealthStatus, error) {
@@ -510,20 +635,27 @@ func (m *Monitor) QueryBackendStatus(backendID string) (vmcp.BackendHealthStatus
 	return m.statusTracker.GetStatus(backendID)
 }
 
-// GetBackendState returns the full health state for a backend.
+// GetBackendState returns the full health state for a backend, including its
+// effective check settings.
 // Returns (state, error). Error is returned if the backend is not being monitored.
 func (m *Monitor) GetBackendState(backendID string) (*State, error) {
 	state, exists := m.statusTracker.GetState(backendID)
 	if !exists {
 		return nil, fmt.Errorf("backend %s not found", backendID)
 	}
+	state.Settings = m.settingsFor(backendID)
 	return state, nil
 }
 
-// GetAllBackendStates returns health states for all monitored backends.
+// GetAllBackendStates returns health states, including effective check
+// settings, for all monitored backends.
 // Returns a map of backend ID to State.
 func (m *Monitor) GetAllBackendStates() map[string]*State {
-	return m.statusTracker.GetAllStates()
+	states := m.statusTracker.GetAllStates()
+	for backendID, state := range states {
+		state.Settings = m.settingsFor(backendID)
+	}
+	return states
 }
 
 // IsBackendHealthy returns true if the backend is currently healthy.
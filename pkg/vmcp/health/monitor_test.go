@@ -1256,3 +1256,68 @@ func TestMonitor_CircuitBreakerStatusReporting(t *testing.T) {
 	err = monitor.Stop()
 	require.NoError(t, err)
 }
+
+func TestMonitor_CapabilityChanges(t *testing.T) {
+	t.Parallel()
+
+	toolA := vmcp.Tool{Name: "a", Description: "tool a"}
+	toolB := vmcp.Tool{Name: "b", Description: "tool b"}
+	prompt := vmcp.Prompt{Name: "p"}
+
+	tests := []struct {
+		name    string
+		checks  []*vmcp.CapabilityList
+		changes []CapabilityChange
+	}{
+		{
+			name: "unchanged capabilities are not reported",
+			checks: []*vmcp.CapabilityList{
+				{Tools: []vmcp.Tool{toolA, toolB}, Prompts: []vmcp.Prompt{prompt}},
+				{Tools: []vmcp.Tool{toolB, toolA}, Prompts: []vmcp.Prompt{prompt}},
+			},
+		},
+		{
+			name: "added tool is reported",
+			checks: []*vmcp.CapabilityList{
+				{Tools: []vmcp.Tool{toolA}, Prompts: []vmcp.Prompt{prompt}},
+				{Tools: []vmcp.Tool{toolA, toolB}, Prompts: []vmcp.Prompt{prompt}},
+				{Tools: []vmcp.Tool{toolA, toolB}, Prompts: []vmcp.Prompt{prompt}},
+			},
+			changes: []CapabilityChange{{BackendID: "backend-1", Tools: true}},
+		},
+		{
+			name: "removed tool and prompt are reported",
+			checks: []*vmcp.CapabilityList{
+				{Tools: []vmcp.Tool{toolA, toolB}, Prompts: []vmcp.Prompt{prompt}},
+				{Tools: []vmcp.Tool{toolA}},
+			},
+			changes: []CapabilityChange{{BackendID: "backend-1", Tools: true, Prompts: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockClient := mocks.NewMockBackendClient(ctrl)
+			backend := vmcp.Backend{ID: "backend-1", Name: "Backend 1", BaseURL: "http://localhost:8080"}
+			for _, caps := range tt.checks {
+				mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(caps, nil)
+			}
+
+			monitor, err := NewMonitor(mockClient, []vmcp.Backend{backend}, DefaultConfig())
+			require.NoError(t, err)
+
+			var changes []CapabilityChange
+			monitor.OnCapabilitiesChanged(func(change CapabilityChange) {
+				changes = append(changes, change)
+			})
+
+			for range tt.checks {
+				monitor.performHealthCheck(context.Background(), &backend)
+			}
+			assert.Equal(t, tt.changes, changes)
+		})
+	}
+}
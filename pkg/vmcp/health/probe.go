@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// backendHTTPClients is implemented by backend clients that can build the HTTP
+// client their MCP traffic to a backend uses.
+type backendHTTPClients interface {
+	HTTPClient(ctx context.Context, target *vmcp.BackendTarget) (*http.Client, error)
+}
+
+// httpGetChecker implements vmcp.HealthChecker with an HTTP GET of a fixed path
+// on the backend's origin, for backends that expose a plain health endpoint.
+// Unlike healthChecker it does not exercise the MCP protocol.
+type httpGetChecker struct {
+	// backendClients builds the probe's HTTP client from the backend client, so
+	// the probe uses the backend's TLS settings, dial control and outgoing auth.
+	// Nil if the backend client cannot build HTTP clients.
+	backendClients backendHTTPClients
+
+	// client performs the probe requests of backends without a custom CA bundle
+	// when backendClients is nil.
+	client *http.Client
+
+	// path is the path requested on the backend's origin.
+	path string
+
+	// degradedThreshold is the response time above which a successful probe
+	// marks the backend degraded. Zero disables it.
+	degradedThreshold time.Duration
+}
+
+// newHTTPGetChecker creates an HTTP GET checker requesting DefaultProbePath.
+// Probes are sent like the MCP traffic of backendClient when it can build HTTP
+// clients (see backendHTTPClients).
+func newHTTPGetChecker(backendClient vmcp.BackendClient, degradedThreshold time.Duration) *httpGetChecker {
+	backendClients, _ := backendClient.(backendHTTPClients)
+	return &httpGetChecker{
+		backendClients:    backendClients,
+		client:            &http.Client{},
+		path:              DefaultProbePath,
+		degradedThreshold: degradedThreshold,
+	}
+}
+
+// withPath returns a copy of h requesting path. The copy shares h's client.
+func (h *httpGetChecker) withPath(path string) *httpGetChecker {
+	probe := *h
+	probe.path = path
+	return &probe
+}
+
+// CheckHealth requests the probe path of target. A 2xx response is healthy; a
+// 401/403 is categorized like an MCP authentication error (see authErrorStatus);
+// any other response or a transport error is unhealthy. The timeout is taken
+// from ctx.
+func (h *httpGetChecker) CheckHealth(ctx context.Context, target *vmcp.BackendTarget) (vmcp.BackendHealthStatus, error) {
+	probeURL, err := url.Parse(target.BaseURL)
+	if err != nil {
+		return vmcp.BackendUnhealthy, fmt.Errorf("health check failed: invalid backend URL: %w", err)
+	}
+	probeURL.Path = h.path
+	probeURL.RawPath = ""
+	probeURL.RawQuery = ""
+	probeURL.Fragment = ""
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL.String(), nil)
+	if err != nil {
+		return vmcp.BackendUnhealthy, fmt.Errorf("health check failed: %w", err)
+	}
+
+	client, err := h.clientFor(ctx, target)
+	if err != nil {
+		return vmcp.BackendUnhealthy, fmt.Errorf("health check failed: %w", err)
+	}
+	if client != h.client && h.backendClients == nil {
+		defer client.CloseIdleConnections()
+	}
+
+	startTime := time.Now()
+	resp, err := client.Do(req)
+	responseDuration := time.Since(startTime)
+	if err != nil {
+		return vmcp.BackendUnhealthy, fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		status := authErrorStatus(target)
+		if status == vmcp.BackendHealthy {
+			return vmcp.BackendHealthy, nil
+		}
+		return status, fmt.Errorf("health check failed: %w: HTTP %d", vmcp.ErrAuthenticationFailed, resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return vmcp.BackendUnhealthy, fmt.Errorf("health check failed: %w: HTTP %d", vmcp.ErrBackendUnavailable, resp.StatusCode)
+	}
+
+	if h.degradedThreshold > 0 && responseDuration > h.degradedThreshold {
+		slog.Warn("health check succeeded but response was slow - marking as degraded",
+			"backend", target.WorkloadName,
+			"duration", responseDuration,
+			"threshold", h.degradedThreshold)
+		return vmcp.BackendDegraded, nil
+	}
+	return vmcp.BackendHealthy, nil
+}
+
+// clientFor returns the HTTP client for target: the backend client's when
+// available, which shares the backend's pooled connections. Otherwise backends
+// with a custom CA bundle get a dedicated client trusting it, so the probe sees
+// the same TLS trust as MCP traffic.
+func (h *httpGetChecker) clientFor(ctx context.Context, target *vmcp.BackendTarget) (*http.Client, error) {
+	if h.backendClients != nil {
+		return h.backendClients.HTTPClient(ctx, target)
+	}
+
+	caBundle := target.CABundleData
+	if len(caBundle) == 0 && target.CABundlePath != "" {
+		data, err := os.ReadFile(target.CABundlePath) //nolint:gosec // CA bundle path is validated by config validator
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		caBundle = data
+	}
+	if len(caBundle) == 0 {
+		return h.client, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("failed to parse CA bundle")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return &http.Client{Transport: transport}, nil
+}
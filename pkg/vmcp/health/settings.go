@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"cmp"
+	"time"
+)
+
+// ProbeMethod selects how a backend's health is checked.
+type ProbeMethod string
+
+const (
+	// ProbeMCP checks health with an MCP initialize handshake followed by a
+	// capability listing. This is the default.
+	ProbeMCP ProbeMethod = "mcp"
+
+	// ProbeHTTPGet checks health with an HTTP GET of ProbePath on the backend's
+	// origin. Any 2xx response is healthy.
+	ProbeHTTPGet ProbeMethod = "http-get"
+)
+
+// DefaultProbePath is the path requested by ProbeHTTPGet when none is configured.
+const DefaultProbePath = "/health"
+
+// CheckSettings are the health check settings of a backend. As a per-backend
+// override in MonitorConfig.Backends, zero fields inherit the monitor-wide value.
+type CheckSettings struct {
+	// Interval is how often the backend is checked.
+	Interval time.Duration
+
+	// Timeout is the maximum duration of a single check. Zero means no timeout.
+	Timeout time.Duration
+
+	// UnhealthyThreshold is the number of consecutive failures before the
+	// backend is marked unhealthy.
+	UnhealthyThreshold int
+
+	// HealthyThreshold is the number of consecutive successes a failing backend
+	// needs before it is reported healthy again. Until then it is degraded.
+	HealthyThreshold int
+
+	// Probe is the probe method.
+	Probe ProbeMethod
+
+	// ProbePath is the path requested when Probe is ProbeHTTPGet.
+	ProbePath string
+}
+
+// withDefaults returns s with its zero fields taken from defaults.
+func (s CheckSettings) withDefaults(defaults CheckSettings) CheckSettings {
+	s.Interval = cmp.Or(s.Interval, defaults.Interval)
+	s.Timeout = cmp.Or(s.Timeout, defaults.Timeout)
+	s.UnhealthyThreshold = cmp.Or(s.UnhealthyThreshold, defaults.UnhealthyThreshold)
+	s.HealthyThreshold = cmp.Or(s.HealthyThreshold, defaults.HealthyThreshold)
+	s.Probe = cmp.Or(s.Probe, defaults.Probe, ProbeMCP)
+	if s.Probe == ProbeHTTPGet {
+		s.ProbePath = cmp.Or(s.ProbePath, defaults.ProbePath, DefaultProbePath)
+	} else {
+		s.ProbePath = ""
+	}
+	return s
+}
@@ -0,0 +1,398 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+)
+
+// fakeClock is a manually advanced clock for the status tracker.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestMonitor_PerBackendThresholds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		config             MonitorConfig
+		unhealthyThreshold int
+		healthyThreshold   int
+	}{
+		{
+			name: "monitor-wide thresholds",
+			config: MonitorConfig{
+				CheckInterval:      time.Minute,
+				UnhealthyThreshold: 2,
+				HealthyThreshold:   3,
+			},
+			unhealthyThreshold: 2,
+			healthyThreshold:   3,
+		},
+		{
+			name: "default healthy threshold",
+			config: MonitorConfig{
+				CheckInterval:      time.Minute,
+				UnhealthyThreshold: 3,
+			},
+			unhealthyThreshold: 3,
+			healthyThreshold:   2,
+		},
+		{
+			name: "per-backend override",
+			config: MonitorConfig{
+				CheckInterval:      time.Minute,
+				UnhealthyThreshold: 3,
+				Backends: map[string]CheckSettings{
+					"backend-1": {UnhealthyThreshold: 1, HealthyThreshold: 4},
+				},
+			},
+			unhealthyThreshold: 1,
+			healthyThreshold:   4,
+		},
+		{
+			name: "override of another backend is ignored",
+			config: MonitorConfig{
+				CheckInterval:      time.Minute,
+				UnhealthyThreshold: 2,
+				HealthyThreshold:   1,
+				Backends: map[string]CheckSettings{
+					"backend-2": {UnhealthyThreshold: 5, HealthyThreshold: 5},
+				},
+			},
+			unhealthyThreshold: 2,
+			healthyThreshold:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockClient := mocks.NewMockBackendClient(ctrl)
+			backend := vmcp.Backend{ID: "backend-1", Name: "Backend 1", BaseURL: "http://localhost:8080"}
+
+			monitor, err := NewMonitor(mockClient, []vmcp.Backend{backend}, tt.config)
+			require.NoError(t, err)
+			clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+			monitor.statusTracker.now = clock.Now
+
+			fail := mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("connection refused"))
+			succeed := mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+				Return(&vmcp.CapabilityList{}, nil)
+
+			// Fails N-1 times without becoming unhealthy, then once more to cross the threshold.
+			fail.Times(tt.unhealthyThreshold)
+			for i := 1; i < tt.unhealthyThreshold; i++ {
+				clock.Advance(time.Minute)
+				monitor.performHealthCheck(t.Context(), &backend)
+				state, err := monitor.GetBackendState(backend.ID)
+				require.NoError(t, err)
+				assert.NotEqual(t, vmcp.BackendUnhealthy, state.Status, "failure %d", i)
+			}
+			clock.Advance(time.Minute)
+			unhealthyAt := clock.Now()
+			monitor.performHealthCheck(t.Context(), &backend)
+			state, err := monitor.GetBackendState(backend.ID)
+			require.NoError(t, err)
+			assert.Equal(t, vmcp.BackendUnhealthy, state.Status)
+			assert.Equal(t, tt.unhealthyThreshold, state.ConsecutiveFailures)
+			assert.Equal(t, unhealthyAt, state.LastTransitionTime)
+
+			// Recovers after M consecutive successes; degraded until then.
+			succeed.Times(tt.healthyThreshold).After(fail)
+			for i := 1; i < tt.healthyThreshold; i++ {
+				clock.Advance(time.Minute)
+				monitor.performHealthCheck(t.Context(), &backend)
+				state, err := monitor.GetBackendState(backend.ID)
+				require.NoError(t, err)
+				assert.Equal(t, vmcp.BackendDegraded, state.Status, "success %d", i)
+			}
+			clock.Advance(time.Minute)
+			recoveredAt := clock.Now()
+			monitor.performHealthCheck(t.Context(), &backend)
+			state, err = monitor.GetBackendState(backend.ID)
+			require.NoError(t, err)
+			assert.Equal(t, vmcp.BackendHealthy, state.Status)
+			assert.Equal(t, recoveredAt, state.LastTransitionTime)
+			assert.Equal(t, recoveredAt, state.LastCheckTime)
+		})
+	}
+}
+
+func TestMonitor_HealthyThresholdResetByFailure(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	backend := vmcp.Backend{ID: "backend-1", Name: "Backend 1", BaseURL: "http://localhost:8080"}
+
+	monitor, err := NewMonitor(mockClient, []vmcp.Backend{backend}, MonitorConfig{
+		CheckInterval:      time.Minute,
+		UnhealthyThreshold: 1,
+		HealthyThreshold:   2,
+	})
+	require.NoError(t, err)
+
+	gomock.InOrder(
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")),
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(&vmcp.CapabilityList{}, nil),
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")),
+		mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(&vmcp.CapabilityList{}, nil),
+	)
+
+	want := []vmcp.BackendHealthStatus{
+		vmcp.BackendUnhealthy,
+		vmcp.BackendDegraded,
+		vmcp.BackendUnhealthy,
+		vmcp.BackendDegraded, // the success count restarted after the second failure
+	}
+	for i, status := range want {
+		monitor.performHealthCheck(t.Context(), &backend)
+		got, err := monitor.GetBackendStatus(backend.ID)
+		require.NoError(t, err)
+		assert.Equal(t, status, got, "check %d", i+1)
+	}
+}
+
+func TestMonitor_HTTPGetProbe(t *testing.T) {
+	t.Parallel()
+
+	var probedPath string
+	probeStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probedPath = r.URL.Path
+		w.WriteHeader(probeStatus)
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	// The MCP client must not be used by the HTTP GET probe.
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	backend := vmcp.Backend{ID: "backend-1", Name: "Backend 1", BaseURL: server.URL + "/mcp?session=1"}
+
+	monitor, err := NewMonitor(mockClient, []vmcp.Backend{backend}, MonitorConfig{
+		CheckInterval:      time.Minute,
+		UnhealthyThreshold: 1,
+		Backends: map[string]CheckSettings{
+			"backend-1": {Probe: ProbeHTTPGet, ProbePath: "/healthz"},
+		},
+	})
+	require.NoError(t, err)
+
+	monitor.performHealthCheck(t.Context(), &backend)
+	status, err := monitor.GetBackendStatus(backend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vmcp.BackendHealthy, status)
+	assert.Equal(t, "/healthz", probedPath)
+
+	probeStatus = http.StatusServiceUnavailable
+	monitor.performHealthCheck(t.Context(), &backend)
+	state, err := monitor.GetBackendState(backend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vmcp.BackendUnhealthy, state.Status)
+	assert.ErrorIs(t, state.LastError, vmcp.ErrBackendUnavailable)
+
+	probeStatus = http.StatusUnauthorized
+	monitor.performHealthCheck(t.Context(), &backend)
+	status, err = monitor.GetBackendStatus(backend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vmcp.BackendUnauthenticated, status)
+}
+
+// headerBackendClient is a backend client whose HTTP clients add a header, as
+// outgoing auth does for MCP traffic.
+type headerBackendClient struct {
+	vmcp.BackendClient
+}
+
+func (headerBackendClient) HTTPClient(context.Context, *vmcp.BackendTarget) (*http.Client, error) {
+	return &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer backend-token")
+		return http.DefaultTransport.RoundTrip(req)
+	})}, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestMonitor_HTTPGetProbeUsesBackendClientTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer backend-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	backendClient := headerBackendClient{BackendClient: mocks.NewMockBackendClient(ctrl)}
+	backend := vmcp.Backend{ID: "backend-1", Name: "Backend 1", BaseURL: server.URL + "/mcp"}
+
+	monitor, err := NewMonitor(backendClient, []vmcp.Backend{backend}, MonitorConfig{
+		CheckInterval:      time.Minute,
+		UnhealthyThreshold: 1,
+		Backends: map[string]CheckSettings{
+			"backend-1": {Probe: ProbeHTTPGet, ProbePath: "/healthz"},
+		},
+	})
+	require.NoError(t, err)
+
+	monitor.performHealthCheck(t.Context(), &backend)
+	status, err := monitor.GetBackendStatus(backend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vmcp.BackendHealthy, status, "the probe must be sent through the backend client's transport")
+}
+
+func TestMonitor_PerBackendTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	backends := []vmcp.Backend{
+		{ID: "fast", Name: "fast", BaseURL: "http://localhost:8080"},
+		{ID: "slow", Name: "slow", BaseURL: "http://localhost:8081"},
+	}
+
+	monitor, err := NewMonitor(mockClient, backends, MonitorConfig{
+		CheckInterval:      time.Minute,
+		UnhealthyThreshold: 1,
+		Timeout:            10 * time.Second,
+		Backends: map[string]CheckSettings{
+			"fast": {Timeout: 50 * time.Millisecond},
+		},
+	})
+	require.NoError(t, err)
+
+	deadlines := map[string]time.Duration{}
+	mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, target *vmcp.BackendTarget) (*vmcp.CapabilityList, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			deadlines[target.WorkloadID] = time.Until(deadline)
+			return &vmcp.CapabilityList{}, nil
+		}).Times(2)
+
+	for i := range backends {
+		monitor.performHealthCheck(t.Context(), &backends[i])
+	}
+	assert.LessOrEqual(t, deadlines["fast"], 50*time.Millisecond)
+	assert.Greater(t, deadlines["slow"], time.Second)
+}
+
+func TestMonitor_StatesIncludeEffectiveSettings(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockBackendClient(ctrl)
+	mockClient.EXPECT().ListCapabilities(gomock.Any(), gomock.Any()).Return(&vmcp.CapabilityList{}, nil).AnyTimes()
+	backends := []vmcp.Backend{
+		{ID: "backend-1", Name: "Backend 1", BaseURL: "http://localhost:8080"},
+		{ID: "backend-2", Name: "Backend 2", BaseURL: "http://localhost:8081"},
+	}
+
+	monitor, err := NewMonitor(mockClient, backends, MonitorConfig{
+		CheckInterval:      30 * time.Second,
+		UnhealthyThreshold: 3,
+		Timeout:            10 * time.Second,
+		Backends: map[string]CheckSettings{
+			"backend-2": {Interval: 5 * time.Second, HealthyThreshold: 4, Probe: ProbeHTTPGet},
+		},
+	})
+	require.NoError(t, err)
+	for i := range backends {
+		monitor.performHealthCheck(t.Context(), &backends[i])
+	}
+
+	states := monitor.GetAllBackendStates()
+	require.Len(t, states, 2)
+	assert.Equal(t, CheckSettings{
+		Interval:           30 * time.Second,
+		Timeout:            10 * time.Second,
+		UnhealthyThreshold: 3,
+		HealthyThreshold:   2,
+		Probe:              ProbeMCP,
+	}, states["backend-1"].Settings)
+	assert.Equal(t, CheckSettings{
+		Interval:           5 * time.Second,
+		Timeout:            10 * time.Second,
+		UnhealthyThreshold: 3,
+		HealthyThreshold:   4,
+		Probe:              ProbeHTTPGet,
+		ProbePath:          DefaultProbePath,
+	}, states["backend-2"].Settings)
+
+	state, err := monitor.GetBackendState("backend-2")
+	require.NoError(t, err)
+	assert.Equal(t, states["backend-2"].Settings, state.Settings)
+}
+
+func TestNewMonitor_BackendSettingsValidation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockBackendClient(ctrl)
+
+	tests := []struct {
+		name    string
+		config  MonitorConfig
+		wantErr string
+	}{
+		{
+			name:    "unknown probe",
+			config:  MonitorConfig{Probe: "tcp"},
+			wantErr: `unknown health check probe "tcp"`,
+		},
+		{
+			name:    "negative healthy threshold",
+			config:  MonitorConfig{HealthyThreshold: -1},
+			wantErr: "healthy threshold must not be negative",
+		},
+		{
+			name:    "unknown backend probe",
+			config:  MonitorConfig{Backends: map[string]CheckSettings{"b": {Probe: "grpc"}}},
+			wantErr: "backend b: unknown health check probe",
+		},
+		{
+			name:    "negative backend interval",
+			config:  MonitorConfig{Backends: map[string]CheckSettings{"b": {Interval: -time.Second}}},
+			wantErr: "backend b: interval and timeout must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.config.CheckInterval = time.Minute
+			tt.config.UnhealthyThreshold = 1
+			_, err := NewMonitor(mockClient, nil, tt.config)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
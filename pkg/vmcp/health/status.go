@@ -20,6 +20,13 @@ type backendHealthState struct {
 	// consecutiveFailures is the number of consecutive failed health checks.
 	consecutiveFailures int
 
+	// consecutiveSuccesses is the number of consecutive successful health checks.
+	consecutiveSuccesses int
+
+	// recovering is set by a failed health check and cleared once the backend
+	// reaches its healthy threshold. A recovering backend is reported degraded.
+	recovering bool
+
 	// lastCheckTime is when the last health check was performed.
 	lastCheckTime time.Time
 
@@ -50,11 +57,33 @@ type statusTracker struct {
 	// unhealthyThreshold is the number of consecutive failures before marking unhealthy.
 	unhealthyThreshold int
 
+	// healthyThreshold is the number of consecutive successes a recovering
+	// backend needs before its status is no longer held at degraded.
+	healthyThreshold int
+
+	// backendThresholds overrides unhealthyThreshold and healthyThreshold for
+	// individual backends, keyed by backend ID. Set before the first check.
+	backendThresholds map[string]thresholds
+
+	// now returns the current time. Tests replace it to control timestamps.
+	now func() time.Time
+
 	// circuitBreakerConfig contains circuit breaker configuration.
 	// nil means circuit breaker is disabled.
 	circuitBreakerConfig *CircuitBreakerConfig
 }
 
+// thresholds are the unhealthy and healthy thresholds of a backend.
+type thresholds struct {
+	unhealthy int
+	healthy   int
+}
+
+// defaultHealthyThreshold is the number of consecutive successes a backend that
+// failed needs to be reported healthy again: the first success after a failure
+// reports it degraded (recovering), the second healthy.
+const defaultHealthyThreshold = 2
+
 // newStatusTracker creates a new status tracker.
 //
 // Parameters:
@@ -73,10 +102,21 @@ func newStatusTracker(unhealthyThreshold int, circuitBreakerConfig *CircuitBreak
 		states:               make(map[string]*backendHealthState),
 		removedBackends:      make(map[string]bool),
 		unhealthyThreshold:   unhealthyThreshold,
+		healthyThreshold:     defaultHealthyThreshold,
+		now:                  time.Now,
 		circuitBreakerConfig: circuitBreakerConfig,
 	}
 }
 
+// thresholdsFor returns the unhealthy and healthy thresholds of a backend.
+// Must be called with lock held.
+func (t *statusTracker) thresholdsFor(backendID string) thresholds {
+	if th, ok := t.backendThresholds[backendID]; ok {
+		return th
+	}
+	return thresholds{unhealthy: t.unhealthyThreshold, healthy: t.healthyThreshold}
+}
+
 // isRemoved checks if a backend has been explicitly removed.
 // Must be called with lock held.
 func (t *statusTracker) isRemoved(backendID string) bool {
@@ -98,12 +138,14 @@ func (t *statusTracker) getOrCreateState(
 	}
 
 	// Create new state with circuit breaker initialized inline
+	now := t.now()
 	state = &backendHealthState{
 		status:              initialStatus,
 		consecutiveFailures: consecutiveFailures,
-		lastCheckTime:       time.Now(),
+		recovering:          consecutiveFailures > 0,
+		lastCheckTime:       now,
 		lastError:           err,
-		lastTransitionTime:  time.Now(),
+		lastTransitionTime:  now,
 		circuitBreaker: func() CircuitBreaker {
 			if t.circuitBreakerConfig == nil || !t.circuitBreakerConfig.Enabled {
 				return &alwaysClosedCircuit{}
@@ -204,6 +246,7 @@ func (t *statusTracker) RecordSuccess(backendID string, backendName string, stat
 	if !exists {
 		// Initialize new state - no failure history, so accept status as-is
 		slog.Debug("backend initialized", "backend", backendName, "status", status)
+		state.consecutiveSuccesses = 1
 		state.circuitBreaker.RecordSuccess()
 		return
 	}
@@ -211,31 +254,39 @@ func (t *statusTracker) RecordSuccess(backendID string, backendName string, stat
 	// Check for status transition
 	previousStatus := state.status
 	previousFailures := state.consecutiveFailures
+	state.consecutiveSuccesses++
 
-	// If backend had recent failures, mark as degraded (recovering state)
-	// This takes precedence over the health check's status determination
-	if previousFailures > 0 {
+	// A backend that failed is held at degraded (recovering state) until it reaches
+	// its healthy threshold. This takes precedence over the health check's status
+	// determination.
+	healthyThreshold := t.thresholdsFor(backendID).healthy
+	if state.recovering && state.consecutiveSuccesses < healthyThreshold {
 		state.status = vmcp.BackendDegraded
 		slog.Info("backend recovering from failures",
 			"backend", backendName,
 			"previous_status", previousStatus,
 			"status", vmcp.BackendDegraded,
-			"consecutive_failures", previousFailures)
+			"consecutive_failures", previousFailures,
+			"consecutive_successes", state.consecutiveSuccesses,
+			"threshold", healthyThreshold)
 	} else {
-		// No recent failures, use the status from health check (healthy or degraded from slow response)
+		// Recovered or no recent failures, use the status from health check
+		// (healthy or degraded from slow response)
+		state.recovering = false
 		state.status = status
 		if previousStatus != status {
 			slog.Info("backend status changed", "backend", backendName, "previous_status", previousStatus, "status", status)
 		}
 	}
 
+	now := t.now()
 	state.consecutiveFailures = 0
-	state.lastCheckTime = time.Now()
+	state.lastCheckTime = now
 	state.lastError = nil
 
 	// Update transition time if status changed
 	if previousStatus != state.status {
-		state.lastTransitionTime = time.Now()
+		state.lastTransitionTime = now
 	}
 
 	// Update circuit breaker
@@ -261,22 +312,23 @@ func (t *statusTracker) RecordFailure(backendID string, backendName string, stat
 		return
 	}
 
+	unhealthyThreshold := t.thresholdsFor(backendID).unhealthy
 	state, exists := t.getOrCreateState(backendID, backendName, vmcp.BackendUnknown, 1, err)
 	if !exists {
 		// Check if threshold is reached on initialization (e.g., threshold of 1)
-		if state.consecutiveFailures >= t.unhealthyThreshold {
+		if state.consecutiveFailures >= unhealthyThreshold {
 			state.status = status
 			slog.Warn("backend initialized with failure and reached threshold",
 				"backend", backendName,
 				"status", status,
 				"failures", state.consecutiveFailures,
-				"threshold", t.unhealthyThreshold,
+				"threshold", unhealthyThreshold,
 				"error", err)
 		} else {
 			slog.Warn("backend initialized with failure",
 				"backend", backendName,
 				"failures", 1,
-				"threshold", t.unhealthyThreshold,
+				"threshold", unhealthyThreshold,
 				"status", vmcp.BackendUnknown,
 				"error", err)
 		}
@@ -286,25 +338,28 @@ func (t *statusTracker) RecordFailure(backendID string, backendName string, stat
 	}
 
 	// Record the failure
+	now := t.now()
 	previousStatus := state.status
 	state.consecutiveFailures++
-	state.lastCheckTime = time.Now()
+	state.consecutiveSuccesses = 0
+	state.recovering = true
+	state.lastCheckTime = now
 	state.lastError = err
 
 	// Check if threshold is reached and status has changed
-	thresholdReached := state.consecutiveFailures >= t.unhealthyThreshold
+	thresholdReached := state.consecutiveFailures >= unhealthyThreshold
 	statusChanged := previousStatus != status
 
 	if thresholdReached && statusChanged {
 		// Transition to new unhealthy status
 		state.status = status
-		state.lastTransitionTime = time.Now()
+		state.lastTransitionTime = now
 		slog.Warn("backend health degraded",
 			"backend", backendName,
 			"previous_status", previousStatus,
 			"status", status,
 			"consecutive_failures", state.consecutiveFailures,
-			"threshold", t.unhealthyThreshold,
+			"threshold", unhealthyThreshold,
 			"error", err)
 	} else if thresholdReached {
 		// Already at threshold with same status - no transition needed
@@ -319,7 +374,7 @@ func (t *statusTracker) RecordFailure(backendID string, backendName string, stat
 		slog.Debug("backend health check failed",
 			"backend", backendName,
 			"consecutive_failures", state.consecutiveFailures,
-			"threshold", t.unhealthyThreshold,
+			"threshold", unhealthyThreshold,
 			"current_status", state.status,
 			"incoming_status", status,
 			"error", err)
@@ -514,4 +569,8 @@ type State struct {
 	// CircuitLastChanged is when the circuit breaker state last changed.
 	// When circuit breaker is disabled, this will be zero time (via alwaysClosedCircuit).
 	CircuitLastChanged time.Time
+
+	// Settings are the effective health check settings of the backend.
+	// Only set on states returned by Monitor.
+	Settings CheckSettings
 }
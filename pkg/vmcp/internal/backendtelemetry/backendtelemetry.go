@@ -26,10 +26,6 @@ import (
 	"github.com/stacklok/toolhive/pkg/vmcp"
 )
 
-const (
-	instrumentationName = "github.com/stacklok/toolhive/pkg/vmcp"
-)
-
 // MonitorBackends decorates the backend client so it records telemetry on each method call.
 // It also emits a gauge for the number of backends discovered once, since the number of backends is static.
 func MonitorBackends(
@@ -39,7 +35,7 @@ func MonitorBackends(
 	backends []vmcp.Backend,
 	backendClient vmcp.BackendClient,
 ) (vmcp.BackendClient, error) {
-	meter := meterProvider.Meter(instrumentationName)
+	meter := meterProvider.Meter(vmcp.InstrumentationName)
 
 	backendCount, err := meter.Int64Gauge(
 		"toolhive_vmcp_backends_discovered",
@@ -83,7 +79,7 @@ func MonitorBackends(
 
 	return telemetryBackendClient{
 		backendClient:           backendClient,
-		tracer:                  tracerProvider.Tracer(instrumentationName),
+		tracer:                  tracerProvider.Tracer(vmcp.InstrumentationName),
 		requestsTotal:           requestsTotal,
 		errorsTotal:             errorsTotal,
 		requestsDuration:        requestsDuration,
@@ -216,7 +212,15 @@ func (t telemetryBackendClient) CallTool(
 	}
 	ctx, done := t.record(ctx, target, "call_tool", toolName, &retErr, attrs...)
 	defer done()
-	return t.backendClient.CallTool(ctx, target, toolName, arguments, meta)
+	result, err := t.backendClient.CallTool(ctx, target, toolName, arguments, meta)
+	if err == nil && result != nil && result.IsError {
+		// A tool execution error is a successful transport round-trip, so it is
+		// not counted as a backend error, but the span still reports the failure.
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(attribute.String("error.type", "tool_error"))
+		span.SetStatus(codes.Error, "tool execution error")
+	}
+	return result, err
 }
 
 func (t telemetryBackendClient) ReadResource(
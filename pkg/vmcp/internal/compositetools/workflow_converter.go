@@ -13,7 +13,8 @@ import (
 )
 
 // FilterWorkflowDefsForSession returns only the workflow definitions whose every
-// tool step references a backend tool that is present in the session routing table.
+// tool step references a backend tool that is present in the session routing table,
+// and whose every composite step references a composite tool that is itself kept.
 //
 // If a session does not have access to a backend tool (e.g. due to identity-based
 // filtering), any composite tool that depends on that backend tool is also excluded.
@@ -33,9 +34,38 @@ func FilterWorkflowDefsForSession(
 			filtered[name] = def
 		}
 	}
+
+	// A composite step is only accessible if the composite it invokes is. Drop
+	// definitions referencing excluded composites until no more are removed, so
+	// exclusion propagates through chains of nested composites.
+	for removed := true; removed; {
+		removed = false
+		for name, def := range filtered {
+			if !allCompositeStepsAccessible(def, filtered) {
+				delete(filtered, name)
+				removed = true
+			}
+		}
+	}
 	return filtered
 }
 
+// allCompositeStepsAccessible reports whether every composite step in the
+// workflow references a composite tool present in accessible.
+func allCompositeStepsAccessible(
+	def *composer.WorkflowDefinition,
+	accessible map[string]*composer.WorkflowDefinition,
+) bool {
+	for _, step := range def.Steps {
+		if step.Type == composer.StepTypeComposite {
+			if _, ok := accessible[step.Tool]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // allToolStepsAccessible reports whether every tool step in the workflow
 // references a backend tool that is present in the session routing table.
 // Returns false if rt is nil and the workflow contains any tool steps,
@@ -114,7 +144,7 @@ func isToolStepAccessible(stepTool string, rt *vmcp.RoutingTable) bool {
 // Each workflow definition becomes a tool with:
 //   - Name: workflow.Name
 //   - Description: workflow.Description
-//   - InputSchema: workflow.Parameters (JSON Schema format)
+//   - InputSchema: built from workflow.Parameters (see buildInputSchema)
 //   - OutputSchema: workflow.Output (JSON Schema format, if defined)
 //
 // Returns a slice of vmcp.Tool ready for aggregation and exposure to clients.
@@ -128,7 +158,7 @@ func ConvertWorkflowDefsToTools(defs map[string]*composer.WorkflowDefinition) []
 		tool := vmcp.Tool{
 			Name:        def.Name,
 			Description: def.Description,
-			InputSchema: def.Parameters,
+			InputSchema: buildInputSchema(def.Parameters),
 		}
 
 		// Include output schema if defined
@@ -173,6 +203,29 @@ func ValidateNoToolConflicts(backendTools, compositeTools []vmcp.Tool) error {
 	return nil
 }
 
+// buildInputSchema builds the MCP input schema advertised for a composite tool
+// from its declared parameters.
+//
+// Parameters are already JSON Schema, so every declared keyword (property
+// types, descriptions, defaults, required, enum, ...) is kept as is. The result
+// is always a complete object schema: "type" is "object" and "properties" is
+// present, even for a workflow that declares no parameters, so clients can
+// construct calls without special-casing missing schemas. The declared
+// parameters are never modified.
+//
+// Incoming calls are validated against the same schema by the workflow engine.
+func buildInputSchema(params map[string]any) map[string]any {
+	schema := make(map[string]any, len(params)+2)
+	for k, v := range params {
+		schema[k] = v
+	}
+	schema["type"] = "object"
+	if _, ok := schema["properties"].(map[string]any); !ok {
+		schema["properties"] = map[string]any{}
+	}
+	return schema
+}
+
 // buildOutputSchema converts an OutputConfig to MCP-compliant JSON Schema format.
 //
 // This builds the output schema that is exposed to MCP clients via tools/list.
@@ -515,6 +515,36 @@ func TestFilterWorkflowDefsForSession(t *testing.T) {
 			rt:        nil,
 			wantNames: []string{"wf_elicit_only"},
 		},
+		{
+			name: "composite step excluded when referenced composite is inaccessible",
+			defs: map[string]*composer.WorkflowDefinition{
+				"wf_inner": {
+					Name:  "wf_inner",
+					Steps: []composer.WorkflowStep{{ID: "s1", Type: composer.StepTypeTool, Tool: "tool_secret"}},
+				},
+				"wf_middle": {
+					Name:  "wf_middle",
+					Steps: []composer.WorkflowStep{{ID: "s1", Type: composer.StepTypeComposite, Tool: "wf_inner"}},
+				},
+				"wf_outer": {
+					Name: "wf_outer",
+					Steps: []composer.WorkflowStep{
+						{ID: "s1", Type: composer.StepTypeTool, Tool: "tool_a"},
+						{ID: "s2", Type: composer.StepTypeComposite, Tool: "wf_middle"},
+					},
+				},
+				"wf_ok": {
+					Name:  "wf_ok",
+					Steps: []composer.WorkflowStep{{ID: "s1", Type: composer.StepTypeComposite, Tool: "wf_tool"}},
+				},
+				"wf_tool": {
+					Name:  "wf_tool",
+					Steps: []composer.WorkflowStep{{ID: "s1", Type: composer.StepTypeTool, Tool: "tool_a"}},
+				},
+			},
+			rt:        makeRT("tool_a"),
+			wantNames: []string{"wf_ok", "wf_tool"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -627,3 +657,76 @@ func TestBuildOutputPropertySchema(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildInputSchema(t *testing.T) {
+	t.Parallel()
+
+	declared := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"repo": map[string]any{
+				"type":        "string",
+				"description": "Repository in owner/name form",
+			},
+			"limit": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of issues",
+				"default":     float64(10),
+			},
+		},
+		"required": []any{"repo"},
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   map[string]any
+	}{
+		{
+			name:   "keeps declared types, descriptions, defaults and required",
+			params: declared,
+			want:   declared,
+		},
+		{
+			name:   "no parameters yields an empty object schema",
+			params: nil,
+			want:   map[string]any{"type": "object", "properties": map[string]any{}},
+		},
+		{
+			name:   "fills in missing type and properties",
+			params: map[string]any{"additionalProperties": false},
+			want: map[string]any{
+				"type":                 "object",
+				"properties":           map[string]any{},
+				"additionalProperties": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := buildInputSchema(tt.params)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildInputSchema() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("advertised on the converted tool without mutating the definition", func(t *testing.T) {
+		t.Parallel()
+		params := map[string]any{"additionalProperties": false}
+		tools := ConvertWorkflowDefsToTools(map[string]*composer.WorkflowDefinition{
+			"wf": {Name: "wf", Parameters: params},
+		})
+		if len(tools) != 1 {
+			t.Fatalf("expected 1 tool, got %d", len(tools))
+		}
+		if tools[0].InputSchema["type"] != "object" {
+			t.Errorf("expected object input schema, got %v", tools[0].InputSchema)
+		}
+		if _, ok := params["type"]; ok {
+			t.Error("declared parameters were modified")
+		}
+	})
+}
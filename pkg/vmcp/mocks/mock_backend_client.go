@@ -154,3 +154,39 @@ func (mr *MockBackendClientMockRecorder) ReadResource(ctx, target, uri any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadResource", reflect.TypeOf((*MockBackendClient)(nil).ReadResource), ctx, target, uri)
 }
+
+// MockBackendReleaser is a mock of BackendReleaser interface.
+type MockBackendReleaser struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendReleaserMockRecorder
+	isgomock struct{}
+}
+
+// MockBackendReleaserMockRecorder is the mock recorder for MockBackendReleaser.
+type MockBackendReleaserMockRecorder struct {
+	mock *MockBackendReleaser
+}
+
+// NewMockBackendReleaser creates a new mock instance.
+func NewMockBackendReleaser(ctrl *gomock.Controller) *MockBackendReleaser {
+	mock := &MockBackendReleaser{ctrl: ctrl}
+	mock.recorder = &MockBackendReleaserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackendReleaser) EXPECT() *MockBackendReleaserMockRecorder {
+	return m.recorder
+}
+
+// RetainBackends mocks base method.
+func (m *MockBackendReleaser) RetainBackends(current []vmcp.Backend) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RetainBackends", current)
+}
+
+// RetainBackends indicates an expected call of RetainBackends.
+func (mr *MockBackendReleaserMockRecorder) RetainBackends(current any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetainBackends", reflect.TypeOf((*MockBackendReleaser)(nil).RetainBackends), current)
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package optimizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
+)
+
+// validateArguments checks call_tool parameters against the input schema of
+// the target tool, so that arguments an LLM got wrong are reported back before
+// the call reaches a backend. It returns a description of every violation, or
+// an empty string if the arguments are valid.
+//
+// A tool without an input schema accepts any arguments. A schema that cannot
+// be evaluated is logged and skipped: the backend owns its schema and remains
+// the final judge of its arguments.
+func validateArguments(tool mcp.Tool, params map[string]any) string {
+	schema, ok := inputSchema(tool)
+	if !ok {
+		return ""
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewBytesLoader(schema),
+		gojsonschema.NewGoLoader(params),
+	)
+	if err != nil {
+		slog.Debug("call_tool skipped argument validation, input schema cannot be evaluated",
+			"tool", tool.Name, "error", err)
+		return ""
+	}
+	if result.Valid() {
+		return ""
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, desc := range result.Errors() {
+		violations = append(violations, desc.String())
+	}
+	return strings.Join(violations, "; ")
+}
+
+// inputSchema returns the JSON input schema of tool. It reports false if the
+// tool does not declare one.
+func inputSchema(tool mcp.Tool) ([]byte, bool) {
+	if len(tool.RawInputSchema) > 0 {
+		return tool.RawInputSchema, true
+	}
+	if tool.InputSchema.Type == "" {
+		return nil, false
+	}
+	schema, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		slog.Debug("call_tool skipped argument validation, input schema cannot be encoded",
+			"tool", tool.Name, "error", err)
+		return nil, false
+	}
+	return schema, true
+}
+
+// invalidArgumentsResult is the tool error returned when call_tool parameters
+// do not match the target tool's input schema.
+func invalidArgumentsResult(toolName, violations string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("invalid arguments for tool %s: %s", toolName, violations))
+}
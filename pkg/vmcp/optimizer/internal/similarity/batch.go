@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package similarity
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultMaxConcurrentChunks bounds the number of chunk requests an
+// embedding client has in flight for a single EmbedBatch call.
+const defaultMaxConcurrentChunks = 4
+
+// BatchEmbedError is returned by EmbedBatch when some inputs could not be
+// embedded. The embeddings of the other inputs are still returned, with nil
+// at the failed indexes.
+type BatchEmbedError struct {
+	// Errors maps the index of each failed input to its error.
+	Errors map[int]error
+	// Total is the number of inputs in the batch.
+	Total int
+}
+
+// Error implements error.
+func (e *BatchEmbedError) Error() string {
+	indexes := e.Indexes()
+	return fmt.Sprintf("failed to embed %d of %d inputs: %v", len(indexes), e.Total, e.Errors[indexes[0]])
+}
+
+// Unwrap returns the distinct errors of the failed inputs.
+func (e *BatchEmbedError) Unwrap() []error {
+	var errs []error
+	for _, i := range e.Indexes() {
+		if !slices.Contains(errs, e.Errors[i]) {
+			errs = append(errs, e.Errors[i])
+		}
+	}
+	return errs
+}
+
+// Indexes returns the indexes of the failed inputs in ascending order.
+func (e *BatchEmbedError) Indexes() []int {
+	return slices.Sorted(maps.Keys(e.Errors))
+}
+
+// embedChunks splits texts into chunks of at most chunkSize and embeds them
+// through embed, with at most concurrency chunks in flight. Embeddings are
+// returned in input order. A failed chunk does not stop the others: its
+// inputs are reported in a *BatchEmbedError and left nil in the result.
+func embedChunks(
+	ctx context.Context,
+	texts []string,
+	chunkSize, concurrency int,
+	embed func(context.Context, []string) ([][]float32, error),
+) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+
+	var mu sync.Mutex
+	failed := make(map[int]error)
+
+	var g errgroup.Group
+	g.SetLimit(max(concurrency, 1))
+	for start := 0; start < len(texts); start += chunkSize {
+		end := min(start+chunkSize, len(texts))
+		g.Go(func() error {
+			chunk, err := embed(ctx, texts[start:end])
+			if err == nil && len(chunk) != end-start {
+				err = fmt.Errorf("received %d embeddings for %d inputs", len(chunk), end-start)
+			}
+			if err != nil {
+				mu.Lock()
+				for i := start; i < end; i++ {
+					failed[i] = err
+				}
+				mu.Unlock()
+				return nil
+			}
+			copy(embeddings[start:end], chunk)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if len(failed) > 0 {
+		return embeddings, &BatchEmbedError{Errors: failed, Total: len(texts)}
+	}
+	return embeddings, nil
+}
+
+// chunkCount returns the number of chunks of at most chunkSize that n inputs
+// are split into.
+func chunkCount(n, chunkSize int) int {
+	return (n + chunkSize - 1) / chunkSize
+}
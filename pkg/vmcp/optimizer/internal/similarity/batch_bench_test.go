@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package similarity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// benchEmbedLatency simulates the per-request round-trip to an embedding
+// service, which dominates embedding latency for small inputs.
+const benchEmbedLatency = 2 * time.Millisecond
+
+// benchTexts returns n tool-like texts, as embedded by the tool store.
+func benchTexts(n int) []string {
+	texts := make([]string, n)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("name: tool_%d description: Performs operation %d on the backend", i, i)
+	}
+	return texts
+}
+
+// newBenchTEIClient returns a teiClient for a stub TEI server that answers
+// every request after benchEmbedLatency.
+func newBenchTEIClient(b *testing.B) *teiClient {
+	b.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req embedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		time.Sleep(benchEmbedLatency)
+		embeddings := make([][]float32, len(req.Inputs))
+		for i := range embeddings {
+			embeddings[i] = randomVector(384)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(embeddings)
+	}))
+	b.Cleanup(srv.Close)
+
+	return &teiClient{
+		baseURL:        srv.URL,
+		httpClient:     srv.Client(),
+		maxBatchSize:   16,
+		maxConcurrency: defaultMaxConcurrentChunks,
+	}
+}
+
+func BenchmarkTEIClient_EmbedBatch_100(b *testing.B) {
+	client := newBenchTEIClient(b)
+	texts := benchTexts(100)
+	ctx := context.Background()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := client.EmbedBatch(ctx, texts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTEIClient_EmbedPerItem_100(b *testing.B) {
+	client := newBenchTEIClient(b)
+	texts := benchTexts(100)
+	ctx := context.Background()
+	b.ResetTimer()
+	for b.Loop() {
+		for _, text := range texts {
+			if _, err := client.Embed(ctx, text); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,391 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package similarity
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	_ "embed"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+
+	"github.com/stacklok/toolhive/pkg/vmcp/optimizer/internal/types"
+)
+
+// DefaultEmbeddingCacheMaxEntries is the number of embeddings kept by the
+// embedding cache when no limit is configured.
+const DefaultEmbeddingCacheMaxEntries = 10000
+
+//go:embed embedding_cache.sql
+var embeddingCacheSchemaSQL string
+
+// EmbeddingCacheStats reports the effectiveness of a CachingEmbeddingClient.
+type EmbeddingCacheStats struct {
+	// Hits is the number of texts whose embedding was served from the cache.
+	Hits uint64
+	// Misses is the number of texts that had to be embedded by the backend.
+	Misses uint64
+	// Evictions is the number of entries removed to stay within the size limit.
+	Evictions uint64
+}
+
+// CachingEmbeddingClient is a types.EmbeddingClient that stores embeddings in
+// a SQLite database, so a text is only sent to the embedding backend once.
+// When the database is a file, embeddings survive restarts and a new client
+// opened on the same file starts warm.
+//
+// Entries are keyed by a hash of the text, the model and the embedding
+// dimension. The model and dimension in use are recorded in the database:
+// opening the cache for a different model, or receiving embeddings of a
+// different dimension from the backend, discards every entry. Beyond
+// maxEntries, the least recently used entries are evicted.
+type CachingEmbeddingClient struct {
+	client     types.EmbeddingClient
+	db         *sql.DB
+	model      string
+	maxEntries int
+
+	// mu serializes cache reads and writes, and guards dimension and clock.
+	mu sync.Mutex
+	// dimension is the length of cached embeddings, or 0 before the first
+	// embedding is stored.
+	dimension int
+	// clock orders entries by last use for eviction.
+	clock int64
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// NewCachingEmbeddingClient wraps client with an embedding cache stored in the
+// SQLite file at path, creating it if needed. An empty path keeps the cache in
+// memory for the lifetime of the client. model identifies the embedding model
+// so that cached embeddings are not reused across models. A non-positive
+// maxEntries uses DefaultEmbeddingCacheMaxEntries.
+func NewCachingEmbeddingClient(
+	client types.EmbeddingClient, path, model string, maxEntries int,
+) (*CachingEmbeddingClient, error) {
+	if maxEntries <= 0 {
+		maxEntries = DefaultEmbeddingCacheMaxEntries
+	}
+
+	dsn := path
+	if dsn == "" {
+		dsn = ":memory:"
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedding cache: %w", err)
+	}
+	// An in-memory database exists per connection, and SQLite serializes
+	// writers anyway, so a single connection is used.
+	db.SetMaxOpenConns(1)
+
+	c := &CachingEmbeddingClient{
+		client:     client,
+		db:         db,
+		model:      model,
+		maxEntries: maxEntries,
+	}
+	if err := c.init(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	slog.Debug("embedding cache opened",
+		"path", path, "model", model, "dimension", c.dimension, "max_entries", maxEntries)
+
+	return c, nil
+}
+
+// init creates the schema and loads the recorded model and dimension,
+// discarding every entry if the recorded model differs from c.model.
+func (c *CachingEmbeddingClient) init() error {
+	if _, err := c.db.Exec(embeddingCacheSchemaSQL); err != nil {
+		return fmt.Errorf("failed to initialize embedding cache schema: %w", err)
+	}
+
+	var model string
+	var dimension int
+	err := c.db.QueryRow("SELECT model, dimension FROM embedding_cache_meta WHERE id = 1").Scan(&model, &dimension)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return c.reset(context.Background(), 0)
+	case err != nil:
+		return fmt.Errorf("failed to read embedding cache metadata: %w", err)
+	case model != c.model:
+		slog.Info("embedding model changed, discarding cached embeddings",
+			"cached_model", model, "model", c.model)
+		return c.reset(context.Background(), 0)
+	}
+
+	c.dimension = dimension
+	if err := c.db.QueryRow("SELECT COALESCE(MAX(last_used), 0) FROM embedding_cache").Scan(&c.clock); err != nil {
+		return fmt.Errorf("failed to read embedding cache: %w", err)
+	}
+	return nil
+}
+
+// reset discards every entry and records c.model and dimension as the
+// current model and dimension. Callers other than init must hold c.mu.
+func (c *CachingEmbeddingClient) reset(ctx context.Context, dimension int) (retErr error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if retErr != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM embedding_cache"); err != nil {
+		return fmt.Errorf("failed to clear embedding cache: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO embedding_cache_meta (id, model, dimension) VALUES (1, ?, ?)",
+		c.model, dimension,
+	); err != nil {
+		return fmt.Errorf("failed to write embedding cache metadata: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit embedding cache reset: %w", err)
+	}
+
+	c.dimension = dimension
+	c.clock = 0
+	return nil
+}
+
+// Embed returns the embedding for text from the cache, or from the backend
+// on a miss.
+func (c *CachingEmbeddingClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("embedding backend returned empty response for single input")
+	}
+	return embeddings[0], nil
+}
+
+// EmbedBatch returns embeddings for texts. Cached embeddings are reused and
+// only the remaining texts are sent to the backend, in a single batch. Cache
+// failures are logged and fall back to the backend rather than failing the
+// request.
+func (c *CachingEmbeddingClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	results := make([][]float32, len(texts))
+	var missTexts []string
+	var missIndexes []int
+	for i, text := range texts {
+		emb, err := c.lookup(ctx, text)
+		if err != nil {
+			slog.Warn("embedding cache lookup failed", "error", err)
+		}
+		if emb == nil {
+			missTexts = append(missTexts, text)
+			missIndexes = append(missIndexes, i)
+			continue
+		}
+		results[i] = emb
+	}
+
+	c.hits.Add(uint64(len(texts) - len(missTexts)))
+	c.misses.Add(uint64(len(missTexts)))
+
+	var batchErr *BatchEmbedError
+	if len(missTexts) > 0 {
+		embeddings, err := c.client.EmbedBatch(ctx, missTexts)
+		if err != nil && !errors.As(err, &batchErr) {
+			return nil, err
+		}
+		if len(embeddings) != len(missTexts) {
+			return nil, fmt.Errorf("embedding backend returned %d embeddings for %d inputs",
+				len(embeddings), len(missTexts))
+		}
+		for j, emb := range embeddings {
+			results[missIndexes[j]] = emb
+		}
+		if err := c.store(ctx, missTexts, embeddings); err != nil {
+			slog.Warn("failed to store embeddings in cache", "error", err)
+		}
+	}
+
+	slog.Debug("embedding cache batch completed",
+		"inputs", len(texts), "hits", len(texts)-len(missTexts), "misses", len(missTexts))
+
+	if batchErr != nil {
+		// Report the failures at their indexes in texts rather than in the
+		// batch of misses sent to the backend.
+		failed := make(map[int]error, len(batchErr.Errors))
+		for j, err := range batchErr.Errors {
+			failed[missIndexes[j]] = err
+		}
+		return results, &BatchEmbedError{Errors: failed, Total: len(texts)}
+	}
+	return results, nil
+}
+
+// lookup returns the cached embedding for text and marks it as recently
+// used, or nil if it is not cached.
+func (c *CachingEmbeddingClient) lookup(ctx context.Context, text string) ([]float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dimension == 0 {
+		return nil, nil
+	}
+
+	key := c.key(text, c.dimension)
+	var blob []byte
+	err := c.db.QueryRowContext(ctx, "SELECT embedding FROM embedding_cache WHERE key = ?", key).Scan(&blob)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached embedding: %w", err)
+	}
+
+	c.clock++
+	if _, err := c.db.ExecContext(ctx,
+		"UPDATE embedding_cache SET last_used = ? WHERE key = ?", c.clock, key,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update cached embedding: %w", err)
+	}
+	return DecodeEmbedding(blob), nil
+}
+
+// store caches embeddings for texts and evicts the least recently used
+// entries beyond maxEntries. Nil embeddings, left by failed inputs, are
+// skipped. Embeddings of a different dimension than the cached ones
+// invalidate the cache first.
+func (c *CachingEmbeddingClient) store(ctx context.Context, texts []string, embeddings [][]float32) (retErr error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var dimension int
+	for _, emb := range embeddings {
+		if len(emb) > 0 {
+			dimension = len(emb)
+			break
+		}
+	}
+	if dimension == 0 {
+		return nil
+	}
+	if dimension != c.dimension {
+		if c.dimension != 0 {
+			slog.Info("embedding dimension changed, discarding cached embeddings",
+				"cached_dimension", c.dimension, "dimension", dimension)
+		}
+		if err := c.reset(ctx, dimension); err != nil {
+			return err
+		}
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if retErr != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx,
+		"INSERT OR REPLACE INTO embedding_cache (key, embedding, last_used) VALUES (?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for i, emb := range embeddings {
+		if len(emb) != dimension {
+			continue
+		}
+		c.clock++
+		if _, err := stmt.ExecContext(ctx, c.key(texts[i], dimension), EncodeEmbedding(emb), c.clock); err != nil {
+			return fmt.Errorf("failed to store embedding: %w", err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM embedding_cache WHERE key IN (
+		SELECT key FROM embedding_cache ORDER BY last_used DESC LIMIT -1 OFFSET ?)`, c.maxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to evict cached embeddings: %w", err)
+	}
+	if evicted, err := res.RowsAffected(); err == nil && evicted > 0 {
+		c.evictions.Add(uint64(evicted))
+	}
+
+	return tx.Commit()
+}
+
+// key returns the cache key for text embedded by c.model with dimension.
+func (c *CachingEmbeddingClient) key(text string, dimension int) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%d\x00%s", c.model, dimension, text)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Stats returns the cache hit, miss and eviction counts since the client was
+// created.
+func (c *CachingEmbeddingClient) Stats() EmbeddingCacheStats {
+	return EmbeddingCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// Len returns the number of cached embeddings.
+func (c *CachingEmbeddingClient) Len(ctx context.Context) (int, error) {
+	var n int
+	if err := c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM embedding_cache").Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count cached embeddings: %w", err)
+	}
+	return n, nil
+}
+
+// Close closes the cache database and the wrapped client.
+func (c *CachingEmbeddingClient) Close() error {
+	stats := c.Stats()
+	slog.Debug("embedding cache closed",
+		"hits", stats.Hits, "misses", stats.Misses, "evictions", stats.Evictions)
+	return errors.Join(c.client.Close(), c.db.Close())
+}
+
+// EncodeEmbedding serializes a float32 slice to a little-endian byte slice.
+func EncodeEmbedding(vec []float32) []byte {
+	buf := make([]byte, len(vec)*4)
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+// DecodeEmbedding deserializes a little-endian byte slice to a float32 slice.
+func DecodeEmbedding(buf []byte) []float32 {
+	vec := make([]float32, len(buf)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return vec
+}
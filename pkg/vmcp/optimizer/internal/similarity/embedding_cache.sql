@@ -0,0 +1,20 @@
+-- SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+-- SPDX-License-Identifier: Apache-2.0
+
+-- Cached embeddings keyed by a hash of the embedding model, dimension and text.
+-- last_used orders entries for least-recently-used eviction.
+CREATE TABLE IF NOT EXISTS embedding_cache (
+    key TEXT PRIMARY KEY,
+    embedding BLOB NOT NULL,
+    last_used INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS embedding_cache_last_used ON embedding_cache (last_used);
+
+-- The model and dimension of the cached embeddings. A single row; a change of
+-- either discards every cached embedding.
+CREATE TABLE IF NOT EXISTS embedding_cache_meta (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    model TEXT NOT NULL,
+    dimension INTEGER NOT NULL
+);
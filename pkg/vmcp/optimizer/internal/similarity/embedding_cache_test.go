@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package similarity
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingEmbeddingClient returns deterministic embeddings of a fixed
+// dimension and counts the texts it is asked to embed.
+type countingEmbeddingClient struct {
+	dimension int
+	embedded  atomic.Int32
+}
+
+func (c *countingEmbeddingClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+func (c *countingEmbeddingClient) EmbedBatch(_ context.Context, texts []string) ([][]float32, error) {
+	c.embedded.Add(int32(len(texts)))
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		emb := make([]float32, c.dimension)
+		for j := range emb {
+			emb[j] = float32(len(text) + j)
+		}
+		embeddings[i] = emb
+	}
+	return embeddings, nil
+}
+
+func (*countingEmbeddingClient) Close() error {
+	return nil
+}
+
+// failingEmbeddingClient fails to embed the text fail, reporting the
+// failure per index.
+type failingEmbeddingClient struct {
+	*countingEmbeddingClient
+	fail string
+}
+
+func (c *failingEmbeddingClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings, err := c.countingEmbeddingClient.EmbedBatch(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[int]error)
+	for i, text := range texts {
+		if text == c.fail {
+			embeddings[i] = nil
+			failed[i] = errors.New("embedding failed")
+		}
+	}
+	if len(failed) > 0 {
+		return embeddings, &BatchEmbedError{Errors: failed, Total: len(texts)}
+	}
+	return embeddings, nil
+}
+
+func newTestCache(t *testing.T, backend *countingEmbeddingClient, path, model string, maxEntries int) *CachingEmbeddingClient {
+	t.Helper()
+	cache, err := NewCachingEmbeddingClient(backend, path, model, maxEntries)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cache.Close() })
+	return cache
+}
+
+func TestCachingEmbeddingClient_ReusesEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingEmbeddingClient{dimension: 4}
+	cache := newTestCache(t, backend, "", "model-a", 0)
+	ctx := context.Background()
+
+	first, err := cache.EmbedBatch(ctx, []string{"a", "bb"})
+	require.NoError(t, err)
+
+	second, err := cache.EmbedBatch(ctx, []string{"bb", "ccc", "a"})
+	require.NoError(t, err)
+	require.Equal(t, first[1], second[0])
+	require.Equal(t, first[0], second[2])
+
+	emb, err := cache.Embed(ctx, "ccc")
+	require.NoError(t, err)
+	require.Equal(t, second[1], emb)
+
+	require.Equal(t, int32(3), backend.embedded.Load(), "each text must be embedded once")
+	require.Equal(t, EmbeddingCacheStats{Hits: 3, Misses: 3}, cache.Stats())
+}
+
+func TestCachingEmbeddingClient_WarmStart(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "embeddings.db")
+	texts := []string{"read a file", "write a file", "list issues"}
+	ctx := context.Background()
+
+	first := &countingEmbeddingClient{dimension: 4}
+	cache, err := NewCachingEmbeddingClient(first, path, "model-a", 0)
+	require.NoError(t, err)
+	want, err := cache.EmbedBatch(ctx, texts)
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	// A new client on the same file, as after a restart, serves every
+	// embedding from the cache.
+	second := &countingEmbeddingClient{dimension: 4}
+	cache = newTestCache(t, second, path, "model-a", 0)
+	got, err := cache.EmbedBatch(ctx, texts)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Zero(t, second.embedded.Load())
+	require.Equal(t, EmbeddingCacheStats{Hits: 3}, cache.Stats())
+}
+
+func TestCachingEmbeddingClient_ModelChangeInvalidates(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "embeddings.db")
+	ctx := context.Background()
+
+	cache, err := NewCachingEmbeddingClient(&countingEmbeddingClient{dimension: 4}, path, "model-a", 0)
+	require.NoError(t, err)
+	_, err = cache.EmbedBatch(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	backend := &countingEmbeddingClient{dimension: 4}
+	cache = newTestCache(t, backend, path, "model-b", 0)
+	n, err := cache.Len(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n, "opening the cache for another model must discard its entries")
+
+	_, err = cache.EmbedBatch(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), backend.embedded.Load())
+}
+
+func TestCachingEmbeddingClient_DimensionChangeInvalidates(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingEmbeddingClient{dimension: 4}
+	cache := newTestCache(t, backend, "", "model-a", 0)
+	ctx := context.Background()
+
+	_, err := cache.EmbedBatch(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+
+	// The backend now returns embeddings of another dimension, e.g. because
+	// the model behind it was swapped. Storing one discards the old entries.
+	backend.dimension = 8
+	emb, err := cache.Embed(ctx, "c")
+	require.NoError(t, err)
+	require.Len(t, emb, 8)
+
+	n, err := cache.Len(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	emb, err = cache.Embed(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, emb, 8)
+	require.Equal(t, int32(4), backend.embedded.Load())
+}
+
+func TestCachingEmbeddingClient_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingEmbeddingClient{dimension: 2}
+	cache := newTestCache(t, backend, "", "model-a", 2)
+	ctx := context.Background()
+
+	_, err := cache.EmbedBatch(ctx, []string{"a", "bb"})
+	require.NoError(t, err)
+	// Using "a" makes "bb" the least recently used entry.
+	_, err = cache.Embed(ctx, "a")
+	require.NoError(t, err)
+	_, err = cache.Embed(ctx, "ccc")
+	require.NoError(t, err)
+
+	n, err := cache.Len(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, uint64(1), cache.Stats().Evictions)
+
+	embedded := backend.embedded.Load()
+	_, err = cache.EmbedBatch(ctx, []string{"a", "ccc"})
+	require.NoError(t, err)
+	require.Equal(t, embedded, backend.embedded.Load(), "recently used entries must be kept")
+
+	_, err = cache.Embed(ctx, "bb")
+	require.NoError(t, err)
+	require.Equal(t, embedded+1, backend.embedded.Load(), "the evicted entry must be embedded again")
+}
+
+func TestCachingEmbeddingClient_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingEmbeddingClient{dimension: 2}
+	cache := newTestCache(t, backend, "", "model-a", 0)
+	ctx := context.Background()
+
+	_, err := cache.Embed(ctx, "cached")
+	require.NoError(t, err)
+
+	// The backend only sees the misses; its failure at index 1 of that batch
+	// must be reported at the index of the same text in the caller's batch.
+	failing := &failingEmbeddingClient{countingEmbeddingClient: backend, fail: "bad"}
+	cache.client = failing
+	results, err := cache.EmbedBatch(ctx, []string{"cached", "good", "bad"})
+	var batchErr *BatchEmbedError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []int{2}, batchErr.Indexes())
+	require.Equal(t, 3, batchErr.Total)
+	require.NotNil(t, results[0])
+	require.NotNil(t, results[1])
+	require.Nil(t, results[2])
+
+	// The successful embedding was cached; the failed one was not.
+	n, err := cache.Len(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestEmbeddingRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Verify that embeddings survive encode/decode round-trip
+	original := []float32{0.1, -0.2, 0.3, 0.0, -1.0, 1.0}
+	require.Equal(t, original, DecodeEmbedding(EncodeEmbedding(original)))
+}
@@ -10,7 +10,8 @@ import (
 )
 
 // NewEmbeddingClient creates an EmbeddingClient from the given optimizer
-// configuration, selecting the backend implementation from EmbeddingProvider.
+// configuration, selecting the backend implementation from EmbeddingProvider
+// and wrapping it in a CachingEmbeddingClient stored at EmbeddingCachePath.
 // It returns (nil, nil) if cfg is nil or no embedding service URL is configured,
 // meaning semantic search will be disabled.
 func NewEmbeddingClient(cfg *types.OptimizerConfig) (types.EmbeddingClient, error) {
@@ -18,14 +19,31 @@ func NewEmbeddingClient(cfg *types.OptimizerConfig) (types.EmbeddingClient, erro
 		return nil, nil
 	}
 
+	var client types.EmbeddingClient
+	var model string
 	switch cfg.EmbeddingProvider {
 	case "", types.EmbeddingProviderTEI:
-		return newTEIClient(cfg.EmbeddingService, cfg.EmbeddingServiceTimeout)
+		tei, err := newTEIClient(cfg.EmbeddingService, cfg.EmbeddingServiceTimeout)
+		if err != nil {
+			return nil, err
+		}
+		client, model = tei, types.EmbeddingProviderTEI+":"+tei.modelID
 	case types.EmbeddingProviderOpenAI:
-		return newOpenAIClient(cfg.EmbeddingService, cfg.EmbeddingModel, cfg.EmbeddingAPIKey,
+		openAI, err := newOpenAIClient(cfg.EmbeddingService, cfg.EmbeddingModel, cfg.EmbeddingAPIKey,
 			cfg.EmbeddingHeaders, cfg.EmbeddingServiceTimeout)
+		if err != nil {
+			return nil, err
+		}
+		client, model = openAI, types.EmbeddingProviderOpenAI+":"+openAI.model
 	default:
 		return nil, fmt.Errorf("unsupported embedding provider %q (supported: %q, %q)",
 			cfg.EmbeddingProvider, types.EmbeddingProviderTEI, types.EmbeddingProviderOpenAI)
 	}
+
+	cached, err := NewCachingEmbeddingClient(client, cfg.EmbeddingCachePath, model, cfg.EmbeddingCacheMaxEntries)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return cached, nil
 }
@@ -46,7 +46,9 @@ func TestNewEmbeddingClient(t *testing.T) {
 		t.Parallel()
 		client, err := NewEmbeddingClient(&types.OptimizerConfig{EmbeddingService: teiInfo.URL})
 		require.NoError(t, err)
-		require.IsType(t, &teiClient{}, client)
+		t.Cleanup(func() { _ = client.Close() })
+		require.IsType(t, &CachingEmbeddingClient{}, client)
+		require.IsType(t, &teiClient{}, client.(*CachingEmbeddingClient).client)
 	})
 
 	t.Run("tei provider", func(t *testing.T) {
@@ -56,7 +58,9 @@ func TestNewEmbeddingClient(t *testing.T) {
 			EmbeddingProvider: types.EmbeddingProviderTEI,
 		})
 		require.NoError(t, err)
-		require.IsType(t, &teiClient{}, client)
+		t.Cleanup(func() { _ = client.Close() })
+		require.IsType(t, &CachingEmbeddingClient{}, client)
+		require.IsType(t, &teiClient{}, client.(*CachingEmbeddingClient).client)
 	})
 
 	t.Run("openai provider", func(t *testing.T) {
@@ -67,7 +71,9 @@ func TestNewEmbeddingClient(t *testing.T) {
 			EmbeddingModel:    "text-embedding-3-small",
 		})
 		require.NoError(t, err)
-		require.IsType(t, &openAIClient{}, client)
+		t.Cleanup(func() { _ = client.Close() })
+		require.IsType(t, &CachingEmbeddingClient{}, client)
+		require.IsType(t, &openAIClient{}, client.(*CachingEmbeddingClient).client)
 	})
 
 	t.Run("unsupported provider returns error", func(t *testing.T) {
@@ -33,6 +33,8 @@ type openAIClient struct {
 	headers      map[string]string
 	httpClient   *http.Client
 	maxBatchSize int
+	// maxConcurrency bounds the chunk requests in flight per EmbedBatch call.
+	maxConcurrency int
 }
 
 // newOpenAIClient creates a client that POSTs to baseURL+"/embeddings" using the
@@ -57,12 +59,13 @@ func newOpenAIClient(baseURL, model, apiKey string, headers map[string]string, t
 		"base_url", baseURL, "model", model, "timeout", timeout, "custom_headers", len(headers))
 
 	return &openAIClient{
-		baseURL:      baseURL,
-		apiKey:       apiKey,
-		model:        model,
-		headers:      maps.Clone(headers),
-		httpClient:   &http.Client{Timeout: timeout},
-		maxBatchSize: openAIMaxBatchSize,
+		baseURL:        baseURL,
+		apiKey:         apiKey,
+		model:          model,
+		headers:        maps.Clone(headers),
+		httpClient:     &http.Client{Timeout: timeout},
+		maxBatchSize:   openAIMaxBatchSize,
+		maxConcurrency: defaultMaxConcurrentChunks,
 	}, nil
 }
 
@@ -96,28 +99,21 @@ func (c *openAIClient) Embed(ctx context.Context, text string) ([]float32, error
 }
 
 // EmbedBatch returns embeddings for multiple texts, chunking to respect the
-// OpenAI /embeddings input batch size.
+// OpenAI /embeddings input batch size. Most batches fit in a single request;
+// larger ones are sent concurrently, up to maxConcurrency chunks at a time.
+// If some chunks fail, the error is a *BatchEmbedError and the other
+// embeddings are returned.
 func (c *openAIClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
 	if len(texts) == 0 {
 		return nil, nil
 	}
 
-	allEmbeddings := make([][]float32, 0, len(texts))
-
-	for start := 0; start < len(texts); start += c.maxBatchSize {
-		end := min(start+c.maxBatchSize, len(texts))
-		embeddings, err := c.embedChunk(ctx, texts[start:end])
-		if err != nil {
-			return nil, err
-		}
-		allEmbeddings = append(allEmbeddings, embeddings...)
-	}
+	embeddings, err := embedChunks(ctx, texts, c.maxBatchSize, c.maxConcurrency, c.embedChunk)
 
 	slog.Debug("OpenAI embedding batch completed",
-		"inputs", len(texts), "chunks", (len(texts)+c.maxBatchSize-1)/c.maxBatchSize,
-		"dimensions", len(allEmbeddings[0]))
+		"inputs", len(texts), "chunks", chunkCount(len(texts), c.maxBatchSize), "error", err)
 
-	return allEmbeddings, nil
+	return embeddings, err
 }
 
 // embedChunk sends one batch to the /embeddings endpoint and returns the
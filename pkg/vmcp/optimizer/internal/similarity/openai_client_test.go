@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -216,13 +218,13 @@ func TestOpenAIClient_EmbedBatch_Chunking(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			var chunkCount int
+			var chunkCount atomic.Int32
 			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				var req openAIEmbedRequest
 				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
 				require.LessOrEqual(t, len(req.Input), tt.maxBatchSize,
 					"chunk size should not exceed maxBatchSize")
-				chunkCount++
+				chunkCount.Add(1)
 
 				embeddings := make([][]float32, len(req.Input))
 				for i := range embeddings {
@@ -241,23 +243,29 @@ func TestOpenAIClient_EmbedBatch_Chunking(t *testing.T) {
 			results, err := client.EmbedBatch(context.Background(), texts)
 			require.NoError(t, err)
 			require.Len(t, results, tt.numInputs)
-			require.Equal(t, tt.wantChunks, chunkCount)
+			require.Equal(t, int32(tt.wantChunks), chunkCount.Load())
 		})
 	}
 }
 
-func TestOpenAIClient_EmbedBatch_ChunkErrorStopsEarly(t *testing.T) {
+func TestOpenAIClient_EmbedBatch_ChunkErrorIsReportedPerIndex(t *testing.T) {
 	t.Parallel()
 
-	var callCount int
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		callCount++
-		if callCount == 2 {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		var req openAIEmbedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if slices.Contains(req.Input, "text-2") {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("server overloaded"))
 			return
 		}
-		writeOpenAIEmbeddings(t, w, [][]float32{{0.1}, {0.2}})
+		embeddings := make([][]float32, len(req.Input))
+		for i, text := range req.Input {
+			embeddings[i] = []float32{float32(len(text))}
+		}
+		writeOpenAIEmbeddings(t, w, embeddings)
 	}))
 	t.Cleanup(srv.Close)
 
@@ -267,9 +275,21 @@ func TestOpenAIClient_EmbedBatch_ChunkErrorStopsEarly(t *testing.T) {
 	}
 
 	client := newTestOpenAIClientWithBatch(t, srv.URL, 2)
-	_, err := client.EmbedBatch(context.Background(), texts)
+	results, err := client.EmbedBatch(context.Background(), texts)
 	require.ErrorContains(t, err, "OpenAI returned status 500")
-	require.Equal(t, 2, callCount, "should stop after the failing chunk")
+
+	var batchErr *BatchEmbedError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []int{2, 3}, batchErr.Indexes())
+	require.Equal(t, int32(3), callCount.Load(), "a failed chunk must not stop the others")
+
+	// The other chunks are returned in input order.
+	require.Len(t, results, 6)
+	for _, i := range []int{0, 1, 4, 5} {
+		require.Equal(t, []float32{float32(len(texts[i]))}, results[i])
+	}
+	require.Nil(t, results[2])
+	require.Nil(t, results[3])
 }
 
 func TestOpenAIClient_OmitsAuthHeaderWhenKeyless(t *testing.T) {
@@ -362,10 +382,11 @@ func newTestOpenAIClient(t *testing.T, baseURL, apiKey string) *openAIClient {
 func newTestOpenAIClientWithBatch(t *testing.T, baseURL string, maxBatchSize int) *openAIClient {
 	t.Helper()
 	return &openAIClient{
-		baseURL:      baseURL,
-		apiKey:       "test-key",
-		model:        "text-embedding-3-small",
-		httpClient:   &http.Client{Timeout: defaultTimeout},
-		maxBatchSize: maxBatchSize,
+		baseURL:        baseURL,
+		apiKey:         "test-key",
+		model:          "text-embedding-3-small",
+		httpClient:     &http.Client{Timeout: defaultTimeout},
+		maxBatchSize:   maxBatchSize,
+		maxConcurrency: defaultMaxConcurrentChunks,
 	}
 }
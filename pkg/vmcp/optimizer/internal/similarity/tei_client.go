@@ -34,10 +34,16 @@ type teiClient struct {
 	baseURL      string
 	httpClient   *http.Client
 	maxBatchSize int
+	// maxConcurrency bounds the chunk requests in flight per EmbedBatch call.
+	maxConcurrency int
+	// modelID is the model served by TEI as reported by /info, or empty if
+	// /info could not be queried.
+	modelID string
 }
 
 // newTEIClient creates a new TEI embedding client that calls the specified endpoint.
-// It queries the TEI /info endpoint to discover the server's maximum batch size.
+// It queries the TEI /info endpoint to discover the server's maximum batch size
+// and model.
 func newTEIClient(baseURL string, timeout time.Duration) (*teiClient, error) {
 	if baseURL == "" {
 		return nil, fmt.Errorf("TEI BaseURL is required")
@@ -49,50 +55,54 @@ func newTEIClient(baseURL string, timeout time.Duration) (*teiClient, error) {
 
 	httpClient := &http.Client{Timeout: timeout}
 
-	maxBatch, err := fetchMaxBatchSize(baseURL, httpClient)
+	info, err := fetchInfo(baseURL, httpClient)
 	if err != nil {
 		slog.Warn("failed to query TEI /info, using default max batch size",
 			"error", err, "default", defaultMaxBatchSize)
-		maxBatch = defaultMaxBatchSize
+		info = &teiInfoResponse{MaxClientBatchSize: defaultMaxBatchSize}
 	}
 
 	slog.Debug("TEI embedding client created",
-		"base_url", baseURL, "timeout", timeout, "max_batch_size", maxBatch)
+		"base_url", baseURL, "timeout", timeout, "max_batch_size", info.MaxClientBatchSize, "model_id", info.ModelID)
 
 	return &teiClient{
-		baseURL:      baseURL,
-		httpClient:   httpClient,
-		maxBatchSize: maxBatch,
+		baseURL:        baseURL,
+		httpClient:     httpClient,
+		maxBatchSize:   info.MaxClientBatchSize,
+		maxConcurrency: defaultMaxConcurrentChunks,
+		modelID:        info.ModelID,
 	}, nil
 }
 
 // teiInfoResponse is a subset of the TEI /info endpoint response.
 type teiInfoResponse struct {
-	MaxClientBatchSize int `json:"max_client_batch_size"`
+	ModelID            string `json:"model_id"`
+	MaxClientBatchSize int    `json:"max_client_batch_size"`
 }
 
-// fetchMaxBatchSize queries the TEI /info endpoint and returns the max client batch size.
-func fetchMaxBatchSize(baseURL string, httpClient *http.Client) (int, error) {
+// fetchInfo queries the TEI /info endpoint. A missing or non-positive max
+// client batch size is replaced with defaultMaxBatchSize.
+func fetchInfo(baseURL string, httpClient *http.Client) (*teiInfoResponse, error) {
 	resp, err := httpClient.Get(baseURL + infoPath) // #nosec G107 -- URL is built from the configured TEI base URL
 	if err != nil {
-		return 0, fmt.Errorf("TEI /info request failed: %w", err)
+		return nil, fmt.Errorf("TEI /info request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("TEI /info returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("TEI /info returned status %d", resp.StatusCode)
 	}
 
 	var info teiInfoResponse
 	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
-		return 0, fmt.Errorf("failed to decode TEI /info response: %w", err)
+		return nil, fmt.Errorf("failed to decode TEI /info response: %w", err)
 	}
 
 	if info.MaxClientBatchSize <= 0 {
-		return defaultMaxBatchSize, nil
+		info.MaxClientBatchSize = defaultMaxBatchSize
 	}
 
-	return info.MaxClientBatchSize, nil
+	return &info, nil
 }
 
 // embedRequest is the JSON body sent to the TEI /embed endpoint.
@@ -118,30 +128,20 @@ func (c *teiClient) Embed(ctx context.Context, text string) ([]float32, error) {
 }
 
 // EmbedBatch returns vector embeddings for multiple texts, automatically
-// chunking requests to respect the TEI server's maximum batch size.
+// chunking requests to respect the TEI server's maximum batch size. Chunks
+// are sent concurrently, up to maxConcurrency at a time. If some chunks fail,
+// the error is a *BatchEmbedError and the other embeddings are returned.
 func (c *teiClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
 	if len(texts) == 0 {
 		return nil, nil
 	}
 
-	allEmbeddings := make([][]float32, 0, len(texts))
-
-	for start := 0; start < len(texts); start += c.maxBatchSize {
-		end := min(start+c.maxBatchSize, len(texts))
-		chunk := texts[start:end]
-
-		embeddings, err := c.embedChunk(ctx, chunk)
-		if err != nil {
-			return nil, err
-		}
-		allEmbeddings = append(allEmbeddings, embeddings...)
-	}
+	embeddings, err := embedChunks(ctx, texts, c.maxBatchSize, c.maxConcurrency, c.embedChunk)
 
 	slog.Debug("TEI embedding batch completed",
-		"inputs", len(texts), "chunks", (len(texts)+c.maxBatchSize-1)/c.maxBatchSize,
-		"dimensions", len(allEmbeddings[0]))
+		"inputs", len(texts), "chunks", chunkCount(len(texts), c.maxBatchSize), "error", err)
 
-	return allEmbeddings, nil
+	return embeddings, err
 }
 
 // embedChunk sends a single batch of texts to the TEI /embed endpoint.
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -225,13 +227,13 @@ func TestTEIClient_EmbedBatch_Chunking(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			var chunkCount int
+			var chunkCount atomic.Int32
 			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				var req embedRequest
 				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
 				require.LessOrEqual(t, len(req.Inputs), tt.maxBatchSize,
 					"chunk size should not exceed maxBatchSize")
-				chunkCount++
+				chunkCount.Add(1)
 
 				embeddings := make([][]float32, len(req.Inputs))
 				for i := range embeddings {
@@ -251,26 +253,32 @@ func TestTEIClient_EmbedBatch_Chunking(t *testing.T) {
 			results, err := client.EmbedBatch(context.Background(), texts)
 			require.NoError(t, err)
 			require.Len(t, results, tt.numInputs)
-			require.Equal(t, tt.wantChunks, chunkCount)
+			require.Equal(t, int32(tt.wantChunks), chunkCount.Load())
 		})
 	}
 }
 
-func TestTEIClient_EmbedBatch_ChunkErrorStopsEarly(t *testing.T) {
+func TestTEIClient_EmbedBatch_ChunkErrorIsReportedPerIndex(t *testing.T) {
 	t.Parallel()
 
-	var callCount int
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		callCount++
-		if callCount == 2 {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		var req embedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if slices.Contains(req.Inputs, "text-2") {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("server overloaded"))
 			return
 		}
+		embeddings := make([][]float32, len(req.Inputs))
+		for i, text := range req.Inputs {
+			embeddings[i] = []float32{float32(len(text))}
+		}
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode([][]float32{{0.1}, {0.2}})
+		_ = json.NewEncoder(w).Encode(embeddings)
 	}))
-	defer srv.Close()
+	t.Cleanup(srv.Close)
 
 	texts := make([]string, 6) // 3 chunks of 2
 	for i := range texts {
@@ -278,27 +286,41 @@ func TestTEIClient_EmbedBatch_ChunkErrorStopsEarly(t *testing.T) {
 	}
 
 	client := newTestTEIClientWithBatch(t, srv.URL, 2)
-	_, err := client.EmbedBatch(context.Background(), texts)
+	results, err := client.EmbedBatch(context.Background(), texts)
 	require.ErrorContains(t, err, "TEI returned status 500")
-	require.Equal(t, 2, callCount, "should stop after the failing chunk")
+
+	var batchErr *BatchEmbedError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []int{2, 3}, batchErr.Indexes())
+	require.Equal(t, int32(3), callCount.Load(), "a failed chunk must not stop the others")
+
+	// The other chunks are returned in input order.
+	require.Len(t, results, 6)
+	for _, i := range []int{0, 1, 4, 5} {
+		require.Equal(t, []float32{float32(len(texts[i]))}, results[i])
+	}
+	require.Nil(t, results[2])
+	require.Nil(t, results[3])
 }
 
-func Test_fetchMaxBatchSize(t *testing.T) {
+func Test_fetchInfo(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name     string
-		handler  http.HandlerFunc
-		wantSize int
-		wantErr  string
+		name        string
+		handler     http.HandlerFunc
+		wantSize    int
+		wantModelID string
+		wantErr     string
 	}{
 		{
-			name: "returns reported batch size",
+			name: "returns reported batch size and model",
 			handler: func(w http.ResponseWriter, _ *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
-				_, _ = w.Write([]byte(`{"max_client_batch_size": 64, "model_type": "bert"}`))
+				_, _ = w.Write([]byte(`{"model_id": "BAAI/bge-small-en-v1.5", "max_client_batch_size": 64, "model_type": "bert"}`))
 			},
-			wantSize: 64,
+			wantSize:    64,
+			wantModelID: "BAAI/bge-small-en-v1.5",
 		},
 		{
 			name: "zero batch size returns default",
@@ -340,21 +362,22 @@ func Test_fetchMaxBatchSize(t *testing.T) {
 			srv := httptest.NewServer(tt.handler)
 			defer srv.Close()
 
-			size, err := fetchMaxBatchSize(srv.URL, srv.Client())
+			info, err := fetchInfo(srv.URL, srv.Client())
 			if tt.wantErr != "" {
 				require.ErrorContains(t, err, tt.wantErr)
 				return
 			}
 			require.NoError(t, err)
-			require.Equal(t, tt.wantSize, size)
+			require.Equal(t, tt.wantSize, info.MaxClientBatchSize)
+			require.Equal(t, tt.wantModelID, info.ModelID)
 		})
 	}
 }
 
-func Test_fetchMaxBatchSize_ConnectionRefused(t *testing.T) {
+func Test_fetchInfo_ConnectionRefused(t *testing.T) {
 	t.Parallel()
 
-	_, err := fetchMaxBatchSize("http://localhost:1", &http.Client{Timeout: time.Second})
+	_, err := fetchInfo("http://localhost:1", &http.Client{Timeout: time.Second})
 	require.Error(t, err)
 	require.ErrorContains(t, err, "TEI /info request failed")
 }
@@ -379,8 +402,9 @@ func newTestTEIClient(t *testing.T, baseURL string) *teiClient {
 func newTestTEIClientWithBatch(t *testing.T, baseURL string, maxBatchSize int) *teiClient {
 	t.Helper()
 	return &teiClient{
-		baseURL:      baseURL,
-		httpClient:   &http.Client{Timeout: defaultTimeout},
-		maxBatchSize: maxBatchSize,
+		baseURL:        baseURL,
+		httpClient:     &http.Client{Timeout: defaultTimeout},
+		maxBatchSize:   maxBatchSize,
+		maxConcurrency: defaultMaxConcurrentChunks,
 	}
 }
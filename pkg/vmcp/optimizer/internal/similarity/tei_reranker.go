@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package similarity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/optimizer/internal/types"
+)
+
+// rerankPath is the TEI endpoint path for cross-encoder re-ranking.
+const rerankPath = "/rerank"
+
+// NewReranker creates a Reranker from the given optimizer configuration.
+// It returns (nil, nil) if cfg is nil or no re-ranking service URL is
+// configured, meaning re-ranking will be disabled.
+func NewReranker(cfg *types.OptimizerConfig) (types.Reranker, error) {
+	if cfg == nil || cfg.RerankService == "" {
+		return nil, nil
+	}
+	return newTEIReranker(cfg.RerankService, cfg.EmbeddingServiceTimeout)
+}
+
+// teiReranker implements types.Reranker by calling the /rerank endpoint of a
+// HuggingFace Text Embeddings Inference (TEI) server running a cross-encoder
+// (re-ranker) model.
+type teiReranker struct {
+	baseURL      string
+	httpClient   *http.Client
+	maxBatchSize int
+}
+
+// newTEIReranker creates a TEI re-ranking client that calls the specified
+// endpoint. Like the embedding client, it queries /info for the server's
+// maximum batch size.
+func newTEIReranker(baseURL string, timeout time.Duration) (*teiReranker, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("TEI BaseURL is required")
+	}
+
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+
+	info, err := fetchInfo(baseURL, httpClient)
+	if err != nil {
+		slog.Warn("failed to query TEI /info, using default max batch size",
+			"error", err, "default", defaultMaxBatchSize)
+		info = &teiInfoResponse{MaxClientBatchSize: defaultMaxBatchSize}
+	}
+
+	slog.Debug("TEI re-ranker created",
+		"base_url", baseURL, "timeout", timeout, "max_batch_size", info.MaxClientBatchSize, "model_id", info.ModelID)
+
+	return &teiReranker{
+		baseURL:      baseURL,
+		httpClient:   httpClient,
+		maxBatchSize: info.MaxClientBatchSize,
+	}, nil
+}
+
+// rerankRequest is the JSON body sent to the TEI /rerank endpoint.
+type rerankRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+	// Truncate has the same purpose as embedRequest.Truncate.
+	Truncate bool `json:"truncate"`
+}
+
+// rerankResult is one element of the TEI /rerank response. TEI returns the
+// results sorted by score, identified by the index of the input text.
+type rerankResult struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// Rerank returns the cross-encoder score of each document for query, in
+// input order. Documents are sent in chunks of at most the server's maximum
+// batch size; the scores of a cross-encoder do not depend on the other
+// documents in a request.
+func (r *teiReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	scores := make([]float64, 0, len(documents))
+	for start := 0; start < len(documents); start += r.maxBatchSize {
+		end := min(start+r.maxBatchSize, len(documents))
+		chunk, err := r.rerankChunk(ctx, query, documents[start:end])
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, chunk...)
+	}
+	return scores, nil
+}
+
+// rerankChunk sends a single batch of documents to the TEI /rerank endpoint.
+func (r *teiReranker) rerankChunk(ctx context.Context, query string, documents []string) ([]float64, error) {
+	bodyBytes, err := json.Marshal(rerankRequest{Query: query, Texts: documents, Truncate: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TEI rerank request: %w", err)
+	}
+
+	url := r.baseURL + rerankPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TEI rerank request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req) // #nosec G704 -- URL is built from the configured TEI base URL
+	if err != nil {
+		return nil, fmt.Errorf("TEI rerank request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("TEI rerank returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var results []rerankResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode TEI rerank response: %w", err)
+	}
+
+	if len(results) != len(documents) {
+		return nil, fmt.Errorf("TEI rerank returned %d scores for %d inputs", len(results), len(documents))
+	}
+
+	scores := make([]float64, len(documents))
+	seen := make([]bool, len(documents))
+	for _, res := range results {
+		if res.Index < 0 || res.Index >= len(documents) || seen[res.Index] {
+			return nil, fmt.Errorf("TEI rerank returned invalid index %d for %d inputs", res.Index, len(documents))
+		}
+		seen[res.Index] = true
+		scores[res.Index] = res.Score
+	}
+	return scores, nil
+}
+
+// Close is a no-op for the TEI re-ranker.
+func (*teiReranker) Close() error {
+	return nil
+}
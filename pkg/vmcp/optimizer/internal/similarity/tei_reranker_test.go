@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package similarity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/optimizer/internal/types"
+)
+
+// newRerankServer returns a TEI server whose /rerank endpoint scores each text
+// by the number of query words it contains, and reports results sorted by
+// score like TEI does.
+func newRerankServer(t *testing.T, maxBatchSize int, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case infoPath:
+			_ = json.NewEncoder(w).Encode(map[string]any{"model_id": "reranker", "max_client_batch_size": maxBatchSize})
+		case rerankPath:
+			requests.Add(1)
+			var req rerankRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if len(req.Texts) > maxBatchSize {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			results := make([]rerankResult, len(req.Texts))
+			for i, text := range req.Texts {
+				results[i] = rerankResult{Index: i}
+				for _, word := range strings.Fields(req.Query) {
+					if strings.Contains(text, word) {
+						results[i].Score++
+					}
+				}
+			}
+			sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+			_ = json.NewEncoder(w).Encode(results)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewReranker(t *testing.T) {
+	t.Parallel()
+
+	reranker, err := NewReranker(nil)
+	require.NoError(t, err)
+	require.Nil(t, reranker)
+
+	reranker, err = NewReranker(&types.OptimizerConfig{})
+	require.NoError(t, err)
+	require.Nil(t, reranker, "re-ranking is disabled without a service")
+
+	var requests atomic.Int32
+	srv := newRerankServer(t, 8, &requests)
+	reranker, err = NewReranker(&types.OptimizerConfig{RerankService: srv.URL})
+	require.NoError(t, err)
+	require.NotNil(t, reranker)
+	require.NoError(t, reranker.Close())
+}
+
+func TestTEIReranker_Rerank(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := newRerankServer(t, 2, &requests)
+	reranker, err := newTEIReranker(srv.URL, 0)
+	require.NoError(t, err)
+
+	// Five documents with a maximum batch size of 2 take three requests, and
+	// the scores come back in input order although TEI sorts them.
+	scores, err := reranker.Rerank(context.Background(), "send email", []string{
+		"read a file",
+		"send an email",
+		"send a message",
+		"list issues",
+		"email archive",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 2, 1, 0, 1}, scores)
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestTEIReranker_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("model not loaded"))
+			},
+			wantErr: "TEI rerank returned status 500: model not loaded",
+		},
+		{
+			name: "missing scores",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`[{"index": 0, "score": 0.5}]`))
+			},
+			wantErr: "TEI rerank returned 1 scores for 2 inputs",
+		},
+		{
+			name: "duplicate index",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`[{"index": 0, "score": 0.5}, {"index": 0, "score": 0.4}]`))
+			},
+			wantErr: "TEI rerank returned invalid index 0 for 2 inputs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(tt.handler)
+			t.Cleanup(srv.Close)
+
+			reranker := &teiReranker{baseURL: srv.URL, httpClient: srv.Client(), maxBatchSize: defaultMaxBatchSize}
+			_, err := reranker.Rerank(context.Background(), "query", []string{"a", "b"})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
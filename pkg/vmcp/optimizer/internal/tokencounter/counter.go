@@ -6,6 +6,7 @@ package tokencounter
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
 )
@@ -15,6 +16,10 @@ import (
 // real tokenizers.
 type Counter interface {
 	CountTokens(tool mcp.Tool) int
+
+	// Tokenizer names the tokenizer or estimation strategy that produced the
+	// counts, so metrics can report what they are based on.
+	Tokenizer() string
 }
 
 // JSONByteDivisionCounter estimates token count by serialising the full mcp.Tool
@@ -36,6 +41,11 @@ func (c JSONByteDivisionCounter) CountTokens(tool mcp.Tool) int {
 	return len(data) / c.Divisor
 }
 
+// Tokenizer returns "json_bytes/<divisor>".
+func (c JSONByteDivisionCounter) Tokenizer() string {
+	return fmt.Sprintf("json_bytes/%d", c.Divisor)
+}
+
 // NewJSONByteCounter returns a JSONByteDivisionCounter with a divisor of 4,
 // which is a reasonable approximation for most LLM tokenizers.
 func NewJSONByteCounter() Counter {
@@ -52,6 +62,10 @@ type TokenMetrics struct {
 
 	// SavingsPercent is the percentage of tokens saved.
 	SavingsPercent float64 `json:"savings_percent"`
+
+	// Tokenizer names the tokenizer or estimation strategy that produced the
+	// counts (see Counter.Tokenizer).
+	Tokenizer string `json:"tokenizer,omitempty"`
 }
 
 // ComputeTokenMetrics calculates token savings by comparing the precomputed
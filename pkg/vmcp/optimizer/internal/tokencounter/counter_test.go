@@ -73,6 +73,7 @@ func TestNewJSONByteCounter(t *testing.T) {
 	cdc, ok := counter.(JSONByteDivisionCounter)
 	require.True(t, ok)
 	require.Equal(t, 4, cdc.Divisor)
+	require.Equal(t, "json_bytes/4", counter.Tokenizer())
 }
 
 func TestComputeTokenMetrics(t *testing.T) {
@@ -10,7 +10,6 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
-	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -41,6 +40,10 @@ const (
 	// Results with distance > threshold are filtered out in searchSemantic only.
 	// Cosine distance: 0 = identical, 2 = opposite.
 	DefaultSemanticDistanceThreshold = 1.0
+
+	// DefaultRerankTopK is the number of search candidates passed to the
+	// re-ranker, when one is configured.
+	DefaultRerankTopK = 20
 )
 
 //go:embed schema.sql
@@ -52,25 +55,30 @@ var schemaSQL string
 type sqliteToolStore struct {
 	db                        *sql.DB
 	embeddingClient           types.EmbeddingClient // nil = FTS5-only
+	reranker                  types.Reranker        // nil = no re-ranking
 	maxToolsToReturn          int
 	hybridSemanticRatio       float64
 	semanticDistanceThreshold float64
+	rerankTopK                int
 }
 
 // NewSQLiteToolStore creates a new ToolStore backed by a shared in-memory
 // SQLite database. All callers of this constructor share the same database,
 // which is the intended production behavior (one shared store per server).
 // If embeddingClient is non-nil, semantic search is enabled alongside FTS5.
+// If reranker is non-nil, the top search candidates are re-ranked with it.
 // If cfg is non-nil, its search parameters override the defaults; nil values use defaults.
-func NewSQLiteToolStore(embeddingClient types.EmbeddingClient, cfg *types.OptimizerConfig) (types.ToolStore, error) {
-	return newSQLiteToolStore("file:memdb?mode=memory&cache=shared", embeddingClient, cfg)
+func NewSQLiteToolStore(
+	embeddingClient types.EmbeddingClient, reranker types.Reranker, cfg *types.OptimizerConfig,
+) (types.ToolStore, error) {
+	return newSQLiteToolStore("file:memdb?mode=memory&cache=shared", embeddingClient, reranker, cfg)
 }
 
 // newSQLiteToolStore creates a tool store backed by a database described
 // in the connectionString. It is useful for tests, where we want multiple
 // isolated (non-shared) databases.
 func newSQLiteToolStore(
-	connectionString string, embeddingClient types.EmbeddingClient, cfg *types.OptimizerConfig,
+	connectionString string, embeddingClient types.EmbeddingClient, reranker types.Reranker, cfg *types.OptimizerConfig,
 ) (sqliteToolStore, error) {
 	db, err := sql.Open("sqlite", connectionString)
 	if err != nil {
@@ -86,6 +94,7 @@ func newSQLiteToolStore(
 	maxTools := DefaultMaxToolsToReturn
 	hybridRatio := DefaultHybridSemanticToolsRatio
 	semanticThreshold := DefaultSemanticDistanceThreshold
+	rerankTopK := DefaultRerankTopK
 	if cfg != nil {
 		if cfg.MaxToolsToReturn != nil {
 			maxTools = *cfg.MaxToolsToReturn
@@ -96,14 +105,22 @@ func newSQLiteToolStore(
 		if cfg.SemanticDistanceThreshold != nil {
 			semanticThreshold = *cfg.SemanticDistanceThreshold
 		}
+		if cfg.RerankTopK != 0 {
+			rerankTopK = cfg.RerankTopK
+		}
 	}
+	// Every returned tool is re-ranked, so at least as many candidates as
+	// are returned are passed to the re-ranker.
+	rerankTopK = max(rerankTopK, maxTools)
 
 	store := sqliteToolStore{
 		db:                        db,
 		embeddingClient:           embeddingClient,
+		reranker:                  reranker,
 		maxToolsToReturn:          maxTools,
 		hybridSemanticRatio:       hybridRatio,
 		semanticDistanceThreshold: semanticThreshold,
+		rerankTopK:                rerankTopK,
 	}
 
 	slog.Debug("optimizer tool store created",
@@ -111,6 +128,8 @@ func newSQLiteToolStore(
 		"hybrid_semantic_ratio", hybridRatio,
 		"semantic_distance_threshold", semanticThreshold,
 		"semantic_search_enabled", embeddingClient != nil,
+		"rerank_enabled", reranker != nil,
+		"rerank_top_k", rerankTopK,
 	)
 
 	return store, nil
@@ -150,8 +169,11 @@ func (s sqliteToolStore) UpsertTools(ctx context.Context, tools []server.ServerT
 	return tx.Commit()
 }
 
-// generateEmbeddings produces encoded embedding blobs for each tool.
+// generateEmbeddings produces encoded embedding blobs for each tool, embedding
+// all of them in one batch call.
 // If no embedding client is configured, it returns a slice of nil byte slices.
+// Tools that fail to embed while others succeed keep a nil blob: they remain
+// reachable through FTS5 search and the failure is logged.
 func (s sqliteToolStore) generateEmbeddings(ctx context.Context, tools []server.ServerTool) ([][]byte, error) {
 	blobs := make([][]byte, len(tools))
 
@@ -161,27 +183,123 @@ func (s sqliteToolStore) generateEmbeddings(ctx context.Context, tools []server.
 
 	texts := make([]string, len(tools))
 	for i, tool := range tools {
-		texts[i] = fmt.Sprintf("name: %s description: %s", tool.Tool.Name, tool.Tool.Description)
+		texts[i] = toolText(tool.Tool)
 	}
 
 	embeddings, err := s.embeddingClient.EmbedBatch(ctx, texts)
-	if err != nil {
+	var batchErr *similarity.BatchEmbedError
+	if errors.As(err, &batchErr) && len(batchErr.Errors) < len(tools) {
+		failed := make([]string, 0, len(batchErr.Errors))
+		for _, i := range batchErr.Indexes() {
+			failed = append(failed, tools[i].Tool.Name)
+		}
+		slog.Warn("failed to embed some tools, they are only reachable through keyword search",
+			"tools", failed, "error", err)
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
 	}
 
 	for i, emb := range embeddings {
-		blobs[i] = encodeEmbedding(emb)
+		if emb != nil {
+			blobs[i] = similarity.EncodeEmbedding(emb)
+		}
 	}
 
 	return blobs, nil
 }
 
+// toolText is the text of a tool that is embedded and re-ranked.
+func toolText(tool mcp.Tool) string {
+	return fmt.Sprintf("name: %s description: %s", tool.Name, tool.Description)
+}
+
+// scoredTool is a search result together with the signals it was ranked by.
+type scoredTool struct {
+	tool mcp.Tool
+
+	// bm25 is the FTS5 BM25 score, negated from FTS5's rank so that higher is
+	// better. Nil if the tool was not a keyword match.
+	bm25 *float64
+
+	// matchedTerms are the query terms FTS5 highlighted in the tool's name and
+	// description.
+	matchedTerms []string
+
+	// similarity is the cosine similarity to the query. Nil if the tool was not
+	// a semantic match.
+	similarity *float64
+
+	// rerankScore is the re-ranker score. Nil if the tool was not re-ranked.
+	rerankScore *float64
+}
+
 // Search finds tools matching the query string using FTS5 full-text search
 // and optional semantic search when an embedding client is configured.
 // The allowedTools parameter limits results to only tools with names in the given set.
 // If allowedTools is empty, no results are returned (empty = no access).
 // Returns matches ranked by relevance.
 func (s sqliteToolStore) Search(ctx context.Context, query string, allowedTools []string) ([]mcp.Tool, error) {
+	results, err := s.search(ctx, query, allowedTools)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		return nil, nil
+	}
+	tools := make([]mcp.Tool, len(results))
+	for i, r := range results {
+		tools[i] = r.tool
+	}
+	return tools, nil
+}
+
+// SearchExplain runs the same search as Search and explains each result.
+// See types.MatchExplanation for how the final score is derived.
+func (s sqliteToolStore) SearchExplain(ctx context.Context, query string, allowedTools []string) ([]types.ToolMatch, error) {
+	results, err := s.search(ctx, query, allowedTools)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		return nil, nil
+	}
+	matches := make([]types.ToolMatch, len(results))
+	for i, r := range results {
+		matches[i] = types.ToolMatch{Tool: r.tool, Explanation: explain(r)}
+	}
+	return matches, nil
+}
+
+// explain builds the explanation of a merged search result. mergeResults
+// ranks every semantic match above every keyword-only match, so semantic
+// matches are scored in [1, 2] by cosine similarity and keyword-only matches
+// in [0, 1) by BM25, keeping the score monotonic with the ranking. Re-ranked
+// results are ordered by, and scored with, the re-ranker score.
+func explain(r scoredTool) types.MatchExplanation {
+	e := types.MatchExplanation{
+		ToolName:         r.tool.Name,
+		MatchedKeywords:  r.matchedTerms,
+		BM25Score:        r.bm25,
+		CosineSimilarity: r.similarity,
+		RerankScore:      r.rerankScore,
+	}
+	switch {
+	case r.rerankScore != nil:
+		e.RankedBy = types.RankedByRerank
+		e.Score = *r.rerankScore
+	case r.similarity != nil:
+		e.RankedBy = types.RankedBySemantic
+		e.Score = 1 + (max(-1, min(1, *r.similarity))+1)/2
+	case r.bm25 != nil:
+		e.RankedBy = types.RankedByKeyword
+		bm25 := max(0, *r.bm25)
+		e.Score = bm25 / (1 + bm25)
+	}
+	return e
+}
+
+// search runs the FTS5-only or hybrid search behind Search and SearchExplain.
+func (s sqliteToolStore) search(ctx context.Context, query string, allowedTools []string) ([]scoredTool, error) {
 	if len(allowedTools) == 0 {
 		slog.Debug("search skipped, no allowed tools")
 		return nil, nil
@@ -189,26 +307,34 @@ func (s sqliteToolStore) Search(ctx context.Context, query string, allowedTools
 
 	ftsExpr := sanitizeFTS5Query(query)
 
+	// With a re-ranker, retrieve its top-K candidates rather than only the
+	// results to return, so that re-ranking can promote lower candidates.
+	limit := s.maxToolsToReturn
+	if s.reranker != nil {
+		limit = s.rerankTopK
+	}
+
 	// FTS5-only path (no embedding client)
 	if s.embeddingClient == nil {
 		if ftsExpr == "" {
 			slog.Debug("search skipped, empty FTS5 expression", "query", query)
 			return nil, nil
 		}
-		results, err := s.searchFTS5(ctx, ftsExpr, allowedTools, s.maxToolsToReturn)
+		results, err := s.searchFTS5(ctx, ftsExpr, allowedTools, limit)
 		if err != nil {
 			return nil, err
 		}
+		results = s.rerank(ctx, query, results)
 		slog.Debug("search completed (FTS5-only)", "query", query, "results", len(results), "matched_tools", matchNames(results))
 		return results, nil
 	}
 
 	// Hybrid search: derive per-method limits from the ratio.
-	ftsLimit, semanticLimit := hybridSearchLimits(s.maxToolsToReturn, s.hybridSemanticRatio)
+	ftsLimit, semanticLimit := hybridSearchLimits(limit, s.hybridSemanticRatio)
 
 	g, gCtx := errgroup.WithContext(ctx)
 
-	var ftsResults []mcp.Tool
+	var ftsResults []scoredTool
 	if ftsExpr != "" && ftsLimit > 0 {
 		g.Go(func() error {
 			var err error
@@ -217,7 +343,7 @@ func (s sqliteToolStore) Search(ctx context.Context, query string, allowedTools
 		})
 	}
 
-	var semanticResults []mcp.Tool
+	var semanticResults []scoredTool
 	if semanticLimit > 0 {
 		g.Go(func() error {
 			var err error
@@ -230,7 +356,7 @@ func (s sqliteToolStore) Search(ctx context.Context, query string, allowedTools
 		return nil, err
 	}
 
-	merged := mergeResults(ftsResults, semanticResults, s.maxToolsToReturn)
+	merged := s.rerank(ctx, query, mergeResults(ftsResults, semanticResults, limit))
 
 	slog.Debug("search completed (hybrid)",
 		"query", query,
@@ -243,14 +369,49 @@ func (s sqliteToolStore) Search(ctx context.Context, query string, allowedTools
 	return merged, nil
 }
 
-// Close releases the underlying database connection.
+// rerank reorders the search candidates by re-ranker score, when a re-ranker
+// is configured, and truncates them to the number of tools to return. If the
+// re-ranker fails, the candidates keep their search order.
+func (s sqliteToolStore) rerank(ctx context.Context, query string, candidates []scoredTool) []scoredTool {
+	if s.reranker != nil && len(candidates) > 0 {
+		documents := make([]string, len(candidates))
+		for i, c := range candidates {
+			documents[i] = toolText(c.tool)
+		}
+		scores, err := s.reranker.Rerank(ctx, query, documents)
+		if err == nil && len(scores) != len(candidates) {
+			err = fmt.Errorf("received %d scores for %d candidates", len(scores), len(candidates))
+		}
+		if err != nil {
+			slog.Warn("re-ranking failed, keeping search order", "query", query, "error", err)
+		} else {
+			for i := range candidates {
+				candidates[i].rerankScore = &scores[i]
+			}
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return *candidates[i].rerankScore > *candidates[j].rerankScore
+			})
+		}
+	}
+
+	if len(candidates) > s.maxToolsToReturn {
+		candidates = candidates[:s.maxToolsToReturn]
+	}
+	return candidates
+}
+
+// Close releases the underlying database connection, the embedding client and
+// the re-ranker.
 func (s sqliteToolStore) Close() error {
-	var embErr error
+	var embErr, rerankErr error
 	if s.embeddingClient != nil {
 		embErr = s.embeddingClient.Close()
 	}
+	if s.reranker != nil {
+		rerankErr = s.reranker.Close()
+	}
 	dbErr := s.db.Close()
-	return errors.Join(embErr, dbErr)
+	return errors.Join(embErr, rerankErr, dbErr)
 }
 
 // searchFTS5 performs a full-text search using FTS5 MATCH with BM25 ranking.
@@ -267,13 +428,17 @@ func (s sqliteToolStore) Close() error {
 // parameterized ? value, never interpolated into SQL.
 func (s sqliteToolStore) searchFTS5(
 	ctx context.Context, ftsExpr string, allowedTools []string, limit int,
-) ([]mcp.Tool, error) {
+) ([]scoredTool, error) {
 	allowedJSON, err := json.Marshal(allowedTools)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal allowed tools: %w", err)
 	}
 
-	queryStr := `SELECT t.name, t.description, rank
+	// highlight() wraps the matched terms of each column in the markers, from
+	// which the matched keywords are extracted.
+	queryStr := `SELECT t.name, t.description, rank,
+			highlight(llm_capabilities_fts, 0, '` + highlightStart + `', '` + highlightEnd + `'),
+			highlight(llm_capabilities_fts, 1, '` + highlightStart + `', '` + highlightEnd + `')
 		FROM llm_capabilities_fts fts
 		JOIN llm_capabilities t ON t.rowid = fts.rowid
 		WHERE llm_capabilities_fts MATCH ?
@@ -287,16 +452,21 @@ func (s sqliteToolStore) searchFTS5(
 	}
 	defer func() { _ = rows.Close() }()
 
-	var matches []mcp.Tool
+	var matches []scoredTool
 	for rows.Next() {
-		var name, description string
+		var name, description, nameHighlight, descriptionHighlight string
 		var rank float64
-		if err := rows.Scan(&name, &description, &rank); err != nil {
+		if err := rows.Scan(&name, &description, &rank, &nameHighlight, &descriptionHighlight); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		matches = append(matches, mcp.Tool{
-			Name:        name,
-			Description: description,
+		bm25 := -rank
+		matches = append(matches, scoredTool{
+			tool: mcp.Tool{
+				Name:        name,
+				Description: description,
+			},
+			bm25:         &bm25,
+			matchedTerms: highlightedTerms(nameHighlight, descriptionHighlight),
 		})
 	}
 
@@ -329,7 +499,7 @@ func (s sqliteToolStore) searchFTS5(
 //nolint:unparam // limit kept for API consistency with searchFTS5
 func (s sqliteToolStore) searchSemantic(
 	ctx context.Context, query string, allowedTools []string, limit int,
-) ([]mcp.Tool, error) {
+) ([]scoredTool, error) {
 	queryVec, err := s.embeddingClient.Embed(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to embed query: %w", err)
@@ -367,7 +537,7 @@ func (s sqliteToolStore) searchSemantic(
 		}
 
 		candidatesEvaluated++
-		emb := decodeEmbedding(embBlob)
+		emb := similarity.DecodeEmbedding(embBlob)
 		dist := similarity.CosineDistance(queryVec, emb)
 
 		// Filter by semantic distance threshold.
@@ -397,11 +567,15 @@ func (s sqliteToolStore) searchSemantic(
 		ranked = ranked[:limit]
 	}
 
-	matches := make([]mcp.Tool, len(ranked))
+	matches := make([]scoredTool, len(ranked))
 	for i, r := range ranked {
-		matches[i] = mcp.Tool{
-			Name:        r.name,
-			Description: r.description,
+		similarity := 1 - r.dist
+		matches[i] = scoredTool{
+			tool: mcp.Tool{
+				Name:        r.name,
+				Description: r.description,
+			},
+			similarity: &similarity,
 		}
 	}
 
@@ -419,25 +593,31 @@ func (s sqliteToolStore) searchSemantic(
 // mergeResults combines semantic and FTS5 results, deduplicating by name.
 // Semantic results are listed first (preserving their distance-based order),
 // followed by FTS5 results not already present, and truncated to maxResults.
-func mergeResults(fts, semantic []mcp.Tool, maxResults int) []mcp.Tool {
-	seen := make(map[string]struct{}, len(fts)+len(semantic))
-	merged := make([]mcp.Tool, 0, len(fts)+len(semantic))
+// A tool found by both keeps its semantic position and gains the FTS5 score
+// and matched terms.
+func mergeResults(fts, semantic []scoredTool, maxResults int) []scoredTool {
+	seen := make(map[string]int, len(fts)+len(semantic))
+	merged := make([]scoredTool, 0, len(fts)+len(semantic))
 
 	// Semantic results first.
 	for _, m := range semantic {
-		if _, ok := seen[m.Name]; ok {
+		if _, ok := seen[m.tool.Name]; ok {
 			continue
 		}
-		seen[m.Name] = struct{}{}
+		seen[m.tool.Name] = len(merged)
 		merged = append(merged, m)
 	}
 
 	// Then FTS5 results not already seen.
 	for _, m := range fts {
-		if _, ok := seen[m.Name]; ok {
+		if i, ok := seen[m.tool.Name]; ok {
+			if merged[i].bm25 == nil {
+				merged[i].bm25 = m.bm25
+				merged[i].matchedTerms = m.matchedTerms
+			}
 			continue
 		}
-		seen[m.Name] = struct{}{}
+		seen[m.tool.Name] = len(merged)
 		merged = append(merged, m)
 	}
 
@@ -448,15 +628,50 @@ func mergeResults(fts, semantic []mcp.Tool, maxResults int) []mcp.Tool {
 	return merged
 }
 
-// matchNames extracts tool names from a slice of ToolMatch results for logging.
-func matchNames(matches []mcp.Tool) []string {
+// matchNames extracts tool names from search results for logging.
+func matchNames(matches []scoredTool) []string {
 	names := make([]string, len(matches))
 	for i, m := range matches {
-		names[i] = m.Name
+		names[i] = m.tool.Name
 	}
 	return names
 }
 
+// Markers passed to FTS5 highlight() around matched terms. They are control
+// characters, which do not occur in tool names and descriptions.
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// highlightedTerms returns the distinct terms wrapped in highlight markers in
+// the given highlight() outputs, lower-cased, in order of first appearance.
+func highlightedTerms(highlights ...string) []string {
+	var terms []string
+	seen := make(map[string]struct{})
+	for _, h := range highlights {
+		for {
+			start := strings.Index(h, highlightStart)
+			if start < 0 {
+				break
+			}
+			h = h[start+len(highlightStart):]
+			end := strings.Index(h, highlightEnd)
+			if end < 0 {
+				break
+			}
+			term := strings.ToLower(h[:end])
+			h = h[end+len(highlightEnd):]
+			if _, ok := seen[term]; ok {
+				continue
+			}
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
 // problematicWords contains words that FTS5 interprets as operators or that
 // are too common in tool metadata to be useful search terms. This set aligns
 // with Python mcp_optimizer's DEFAULT_FTS_PROBLEMATIC_WORDS.
@@ -520,21 +735,3 @@ func hybridSearchLimits(total int, semanticRatio float64) (ftsLimit, semanticLim
 	ftsLimit = total - semanticLimit
 	return ftsLimit, semanticLimit
 }
-
-// encodeEmbedding serializes a float32 slice to a little-endian byte slice.
-func encodeEmbedding(vec []float32) []byte {
-	buf := make([]byte, len(vec)*4)
-	for i, v := range vec {
-		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
-	}
-	return buf
-}
-
-// decodeEmbedding deserializes a little-endian byte slice to a float32 slice.
-func decodeEmbedding(buf []byte) []float32 {
-	vec := make([]float32, len(buf)/4)
-	for i := range vec {
-		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
-	}
-	return vec
-}
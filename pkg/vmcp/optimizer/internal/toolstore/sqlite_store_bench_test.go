@@ -21,10 +21,11 @@ import (
 
 const benchToolCount = 1000
 
-func newBenchStore(b *testing.B, embeddingClient types.EmbeddingClient) sqliteToolStore {
+func newBenchStore(b *testing.B, embeddingClient types.EmbeddingClient, reranker types.Reranker) sqliteToolStore {
 	b.Helper()
 	id := testDBCounter.Add(1)
-	store, err := newSQLiteToolStore(fmt.Sprintf("file:benchdb_%d?mode=memory&cache=shared", id), embeddingClient, nil)
+	store, err := newSQLiteToolStore(
+		fmt.Sprintf("file:benchdb_%d?mode=memory&cache=shared", id), embeddingClient, reranker, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() { _ = store.Close() })
 	return store
@@ -47,7 +48,7 @@ func generateTools() ([]server.ServerTool, []string) {
 }
 
 func BenchmarkSearch_FTS5Only_1000Tools(b *testing.B) {
-	store := newBenchStore(b, nil)
+	store := newBenchStore(b, nil, nil)
 
 	ctx := context.Background()
 	tools, names := generateTools()
@@ -62,7 +63,7 @@ func BenchmarkSearch_FTS5Only_1000Tools(b *testing.B) {
 
 func BenchmarkSearch_Semantic_1000Tools_384Dim(b *testing.B) {
 	client := newFakeEmbeddingClient(384)
-	store := newBenchStore(b, client)
+	store := newBenchStore(b, client, nil)
 
 	ctx := context.Background()
 	tools, names := generateTools()
@@ -77,7 +78,25 @@ func BenchmarkSearch_Semantic_1000Tools_384Dim(b *testing.B) {
 
 func BenchmarkSearch_Hybrid_1000Tools(b *testing.B) {
 	client := newFakeEmbeddingClient(384)
-	store := newBenchStore(b, client)
+	store := newBenchStore(b, client, nil)
+
+	ctx := context.Background()
+	tools, names := generateTools()
+	require.NoError(b, store.UpsertTools(ctx, tools))
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = store.Search(ctx, "task operation", names)
+	}
+}
+
+// BenchmarkSearch_Hybrid_1000Tools_Reranked measures the latency re-ranking
+// adds to BenchmarkSearch_Hybrid_1000Tools, excluding the re-ranker itself:
+// the stub scores by term overlap instead of calling a cross-encoder.
+func BenchmarkSearch_Hybrid_1000Tools_Reranked(b *testing.B) {
+	client := newFakeEmbeddingClient(384)
+	store := newBenchStore(b, client, newTermOverlapReranker())
 
 	ctx := context.Background()
 	tools, names := generateTools()
@@ -92,7 +111,7 @@ func BenchmarkSearch_Hybrid_1000Tools(b *testing.B) {
 
 func BenchmarkSearch_Semantic_1000Tools_768Dim(b *testing.B) {
 	client := newFakeEmbeddingClient(768)
-	store := newBenchStore(b, client)
+	store := newBenchStore(b, client, nil)
 
 	ctx := context.Background()
 	tools, names := generateTools()
@@ -5,7 +5,9 @@ package toolstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/stacklok/toolhive-core/mcpcompat/mcp"
 	"github.com/stacklok/toolhive-core/mcpcompat/server"
+	"github.com/stacklok/toolhive/pkg/vmcp/optimizer/internal/similarity"
 	"github.com/stacklok/toolhive/pkg/vmcp/optimizer/internal/types"
 )
 
@@ -21,9 +24,16 @@ import (
 var testDBCounter atomic.Int64
 
 func newTestStore(t *testing.T, embeddingClient types.EmbeddingClient, cfg *types.OptimizerConfig) sqliteToolStore {
+	t.Helper()
+	return newRerankTestStore(t, embeddingClient, nil, cfg)
+}
+
+func newRerankTestStore(
+	t *testing.T, embeddingClient types.EmbeddingClient, reranker types.Reranker, cfg *types.OptimizerConfig,
+) sqliteToolStore {
 	t.Helper()
 	id := testDBCounter.Add(1)
-	store, err := newSQLiteToolStore(fmt.Sprintf("file:testdb_%d?mode=memory&cache=shared", id), embeddingClient, cfg)
+	store, err := newSQLiteToolStore(fmt.Sprintf("file:testdb_%d?mode=memory&cache=shared", id), embeddingClient, reranker, cfg)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		_ = store.Close()
@@ -142,6 +152,34 @@ func TestSQLiteToolStore_UpsertTools_WithEmbeddings(t *testing.T) {
 	require.Equal(t, 2, count)
 }
 
+func TestSQLiteToolStore_UpsertTools_PartialEmbeddingFailure(t *testing.T) {
+	t.Parallel()
+	client := &partialFailureEmbeddingClient{
+		fakeEmbeddingClient: newFakeEmbeddingClient(384),
+		fail:                "send_email",
+	}
+	store := newTestStore(t, client, nil)
+	ctx := context.Background()
+
+	tools := makeTools(
+		mcp.NewTool("read_file", mcp.WithDescription("Read a file from disk")),
+		mcp.NewTool("send_email", mcp.WithDescription("Send an email message")),
+	)
+	require.NoError(t, store.UpsertTools(ctx, tools))
+
+	// The tool that failed to embed is stored without an embedding and can
+	// still be found by keyword.
+	var embedded string
+	err := store.db.QueryRow("SELECT name FROM llm_capabilities WHERE embedding IS NOT NULL").Scan(&embedded)
+	require.NoError(t, err)
+	require.Equal(t, "read_file", embedded)
+
+	results, err := store.searchFTS5(ctx, sanitizeFTS5Query("email"), toolNames(tools), DefaultMaxToolsToReturn)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "send_email", results[0].tool.Name)
+}
+
 func TestSQLiteToolStore_Search(t *testing.T) {
 	t.Parallel()
 
@@ -315,6 +353,116 @@ func TestSQLiteToolStore_Search_ResultsCapped(t *testing.T) {
 	}
 }
 
+func TestSQLiteToolStore_Rerank(t *testing.T) {
+	t.Parallel()
+
+	tools := makeTools(
+		mcp.NewTool("file_read", mcp.WithDescription("Read files")),
+		mcp.NewTool("file_write", mcp.WithDescription("Write files")),
+		mcp.NewTool("file_delete", mcp.WithDescription("Delete files")),
+		mcp.NewTool("file_copy", mcp.WithDescription("Copy files")),
+		mcp.NewTool("file_move", mcp.WithDescription("Move files")),
+		mcp.NewTool("file_list", mcp.WithDescription("List files")),
+	)
+	// The re-ranker prefers file_list, then file_move; every other tool scores 0.
+	preferred := func(_, document string) float64 {
+		switch {
+		case strings.Contains(document, "file_list"):
+			return 2
+		case strings.Contains(document, "file_move"):
+			return 1
+		default:
+			return 0
+		}
+	}
+	maxTools := 2
+	// In hybrid mode, an all-semantic search without a distance threshold
+	// makes every tool a candidate.
+	semanticRatio, threshold := 1.0, 2.0
+
+	for _, tc := range []struct {
+		name            string
+		embeddingClient types.EmbeddingClient
+		cfg             types.OptimizerConfig
+	}{
+		{name: "FTS5-only", embeddingClient: nil},
+		{
+			name:            "hybrid",
+			embeddingClient: newFakeEmbeddingClient(384),
+			cfg: types.OptimizerConfig{
+				HybridSemanticRatio:       &semanticRatio,
+				SemanticDistanceThreshold: &threshold,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			reranker := &stubReranker{score: preferred}
+			cfg := tc.cfg
+			cfg.MaxToolsToReturn = &maxTools
+			cfg.RerankTopK = len(tools)
+			store := newRerankTestStore(t, tc.embeddingClient, reranker, &cfg)
+			require.NoError(t, store.UpsertTools(ctx, tools))
+
+			results, err := store.SearchExplain(ctx, "file", toolNames(tools))
+			require.NoError(t, err)
+			require.Len(t, results, maxTools)
+			require.Equal(t, "file_list", results[0].Tool.Name)
+			require.Equal(t, "file_move", results[1].Tool.Name)
+			require.Equal(t, int32(len(tools)), reranker.documents.Load(), "every top-K candidate is re-ranked")
+
+			for i, want := range []float64{2, 1} {
+				e := results[i].Explanation
+				require.Equal(t, types.RankedByRerank, e.RankedBy)
+				require.NotNil(t, e.RerankScore)
+				require.InDelta(t, want, *e.RerankScore, 1e-9)
+				require.InDelta(t, want, e.Score, 1e-9)
+			}
+		})
+	}
+
+	t.Run("only the top-K candidates are re-ranked", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		reranker := &stubReranker{score: preferred}
+		store := newRerankTestStore(t, nil, reranker, &types.OptimizerConfig{
+			MaxToolsToReturn: &maxTools,
+			RerankTopK:       3,
+		})
+		require.NoError(t, store.UpsertTools(ctx, tools))
+
+		results, err := store.Search(ctx, "file", toolNames(tools))
+		require.NoError(t, err)
+		require.Len(t, results, maxTools)
+		require.Equal(t, int32(3), reranker.documents.Load())
+	})
+
+	t.Run("failed re-ranking keeps the search order", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		plain := newTestStore(t, nil, &types.OptimizerConfig{MaxToolsToReturn: &maxTools})
+		require.NoError(t, plain.UpsertTools(ctx, tools))
+		want, err := plain.Search(ctx, "file", toolNames(tools))
+		require.NoError(t, err)
+
+		reranker := &stubReranker{err: errors.New("re-ranker unavailable")}
+		store := newRerankTestStore(t, nil, reranker, &types.OptimizerConfig{MaxToolsToReturn: &maxTools})
+		require.NoError(t, store.UpsertTools(ctx, tools))
+		got, err := store.SearchExplain(ctx, "file", toolNames(tools))
+		require.NoError(t, err)
+		require.Len(t, got, len(want))
+		for i := range want {
+			require.Equal(t, want[i].Name, got[i].Tool.Name)
+			require.Nil(t, got[i].Explanation.RerankScore)
+			require.Equal(t, types.RankedByKeyword, got[i].Explanation.RankedBy)
+		}
+	})
+}
+
 func TestSQLiteToolStore_Close(t *testing.T) {
 	t.Parallel()
 
@@ -401,6 +549,91 @@ func TestSQLiteToolStore_SemanticSearch(t *testing.T) {
 	require.NotEmpty(t, results)
 }
 
+func TestSQLiteToolStore_SearchExplain(t *testing.T) {
+	t.Parallel()
+
+	tools := makeTools(
+		mcp.NewTool("read_file", mcp.WithDescription("Read a file from disk")),
+		mcp.NewTool("write_file", mcp.WithDescription("Write content to a file")),
+		mcp.NewTool("send_email", mcp.WithDescription("Send an email message")),
+		mcp.NewTool("list_repos", mcp.WithDescription("List GitHub repositories")),
+	)
+
+	tests := []struct {
+		name            string
+		embeddingClient types.EmbeddingClient
+	}{
+		{name: "FTS5 only"},
+		{name: "hybrid", embeddingClient: newFakeEmbeddingClient(384)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			store := newTestStore(t, tc.embeddingClient, nil)
+			ctx := context.Background()
+			require.NoError(t, store.UpsertTools(ctx, tools))
+
+			plain, err := store.Search(ctx, "send email message", toolNames(tools))
+			require.NoError(t, err)
+			explained, err := store.SearchExplain(ctx, "send email message", toolNames(tools))
+			require.NoError(t, err)
+
+			// The explained search returns the same results in the same order.
+			require.Len(t, explained, len(plain))
+			require.NotEmpty(t, explained)
+			for i, m := range explained {
+				require.Equal(t, plain[i], m.Tool)
+				require.Equal(t, m.Tool.Name, m.Explanation.ToolName)
+			}
+
+			// Scores are consistent with the ranking order and with the
+			// signal each result was ranked by.
+			for i, m := range explained {
+				e := m.Explanation
+				if i > 0 {
+					require.LessOrEqual(t, e.Score, explained[i-1].Explanation.Score,
+						"result %d (%s) scores above its predecessor", i, e.ToolName)
+				}
+				switch e.RankedBy {
+				case types.RankedBySemantic:
+					require.NotNil(t, e.CosineSimilarity)
+					require.InDelta(t, 1+(*e.CosineSimilarity+1)/2, e.Score, 1e-9)
+				case types.RankedByKeyword:
+					require.Nil(t, e.CosineSimilarity)
+					require.NotNil(t, e.BM25Score)
+					require.Positive(t, *e.BM25Score)
+					require.InDelta(t, *e.BM25Score/(1+*e.BM25Score), e.Score, 1e-9)
+				default:
+					t.Fatalf("unexpected ranked_by %q", e.RankedBy)
+				}
+			}
+
+			// send_email matches the query terms by keyword.
+			var sendEmail *types.MatchExplanation
+			for _, m := range explained {
+				if m.Tool.Name == "send_email" {
+					sendEmail = &m.Explanation
+				}
+			}
+			require.NotNil(t, sendEmail)
+			require.NotNil(t, sendEmail.BM25Score)
+			require.Subset(t, sendEmail.MatchedKeywords, []string{"send", "email", "message"})
+		})
+	}
+}
+
+func TestHighlightedTerms(t *testing.T) {
+	t.Parallel()
+
+	got := highlightedTerms(
+		"send_"+highlightStart+"email"+highlightEnd,
+		highlightStart+"Send"+highlightEnd+" an "+highlightStart+"email"+highlightEnd+" message",
+	)
+	require.Equal(t, []string{"email", "send"}, got)
+	require.Empty(t, highlightedTerms("no matches", ""))
+}
+
 func TestSQLiteToolStore_HybridSearch(t *testing.T) {
 	t.Parallel()
 	client := newFakeEmbeddingClient(384)
@@ -450,16 +683,6 @@ func TestSQLiteToolStore_ConcurrentSemantic(t *testing.T) {
 	wg.Wait()
 }
 
-func TestSQLiteToolStore_EmbeddingRoundTrip(t *testing.T) {
-	t.Parallel()
-
-	// Verify that embeddings survive encode/decode round-trip
-	original := []float32{0.1, -0.2, 0.3, 0.0, -1.0, 1.0}
-	encoded := encodeEmbedding(original)
-	decoded := decodeEmbedding(encoded)
-	require.Equal(t, original, decoded)
-}
-
 func TestSanitizeFTS5Query(t *testing.T) {
 	t.Parallel()
 
@@ -614,17 +837,45 @@ func TestMergeResults(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			merged := mergeResults(tc.fts, tc.semantic, tc.maxResults)
+			merged := mergeResults(asScored(tc.fts), asScored(tc.semantic), tc.maxResults)
 
 			var gotNames []string
 			for _, m := range merged {
-				gotNames = append(gotNames, m.Name)
+				gotNames = append(gotNames, m.tool.Name)
 			}
 			require.Equal(t, tc.wantNames, gotNames)
 		})
 	}
 }
 
+func TestMergeResults_CombinesSignals(t *testing.T) {
+	t.Parallel()
+
+	bm25, similarity := 2.5, 0.8
+	merged := mergeResults(
+		[]scoredTool{{tool: mcp.Tool{Name: "dup"}, bm25: &bm25, matchedTerms: []string{"email"}}},
+		[]scoredTool{{tool: mcp.Tool{Name: "dup"}, similarity: &similarity}},
+		10,
+	)
+
+	// A tool found by both searches keeps both signals.
+	require.Len(t, merged, 1)
+	require.Equal(t, &similarity, merged[0].similarity)
+	require.Equal(t, &bm25, merged[0].bm25)
+	require.Equal(t, []string{"email"}, merged[0].matchedTerms)
+}
+
+func asScored(tools []mcp.Tool) []scoredTool {
+	if tools == nil {
+		return nil
+	}
+	scored := make([]scoredTool, len(tools))
+	for i, tool := range tools {
+		scored[i] = scoredTool{tool: tool}
+	}
+	return scored
+}
+
 func TestSQLiteToolStore_ConfigDefaults(t *testing.T) {
 	t.Parallel()
 
@@ -746,3 +997,66 @@ func (f *fakeEmbeddingClient) EmbedBatch(ctx context.Context, texts []string) ([
 }
 
 func (*fakeEmbeddingClient) Close() error { return nil }
+
+// partialFailureEmbeddingClient fails to embed texts containing fail, and
+// reports the failures per index as the real clients do.
+type partialFailureEmbeddingClient struct {
+	*fakeEmbeddingClient
+	fail string
+}
+
+func (f *partialFailureEmbeddingClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	result := make([][]float32, len(texts))
+	failed := make(map[int]error)
+	for i, text := range texts {
+		if strings.Contains(text, f.fail) {
+			failed[i] = errors.New("embedding service unavailable")
+			continue
+		}
+		vec, err := f.Embed(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = vec
+	}
+	if len(failed) > 0 {
+		return result, &similarity.BatchEmbedError{Errors: failed, Total: len(texts)}
+	}
+	return result, nil
+}
+
+// stubReranker is a deterministic types.Reranker that scores documents with
+// score, or fails with err. It counts the documents it is asked to score.
+type stubReranker struct {
+	score     func(query, document string) float64
+	err       error
+	documents atomic.Int32
+}
+
+// newTermOverlapReranker returns a stubReranker that scores a document by the
+// number of query words it contains.
+func newTermOverlapReranker() *stubReranker {
+	return &stubReranker{score: func(query, document string) float64 {
+		var score float64
+		for _, word := range strings.Fields(query) {
+			if strings.Contains(document, word) {
+				score++
+			}
+		}
+		return score
+	}}
+}
+
+func (r *stubReranker) Rerank(_ context.Context, query string, documents []string) ([]float64, error) {
+	r.documents.Add(int32(len(documents)))
+	if r.err != nil {
+		return nil, r.err
+	}
+	scores := make([]float64, len(documents))
+	for i, document := range documents {
+		scores[i] = r.score(query, document)
+	}
+	return scores, nil
+}
+
+func (*stubReranker) Close() error { return nil }
@@ -15,6 +15,7 @@ import (
 
 	mcp "github.com/stacklok/toolhive-core/mcpcompat/mcp"
 	server "github.com/stacklok/toolhive-core/mcpcompat/server"
+	types "github.com/stacklok/toolhive/pkg/vmcp/optimizer/internal/types"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -71,6 +72,21 @@ func (mr *MockToolStoreMockRecorder) Search(ctx, query, allowedTools any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockToolStore)(nil).Search), ctx, query, allowedTools)
 }
 
+// SearchExplain mocks base method.
+func (m *MockToolStore) SearchExplain(ctx context.Context, query string, allowedTools []string) ([]types.ToolMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchExplain", ctx, query, allowedTools)
+	ret0, _ := ret[0].([]types.ToolMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchExplain indicates an expected call of SearchExplain.
+func (mr *MockToolStoreMockRecorder) SearchExplain(ctx, query, allowedTools any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchExplain", reflect.TypeOf((*MockToolStore)(nil).SearchExplain), ctx, query, allowedTools)
+}
+
 // UpsertTools mocks base method.
 func (m *MockToolStore) UpsertTools(ctx context.Context, tools []server.ServerTool) error {
 	m.ctrl.T.Helper()
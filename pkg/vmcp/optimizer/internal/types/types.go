@@ -31,12 +31,69 @@ type ToolStore interface {
 	// only Name and Description; the caller is responsible for enriching with schemas.
 	Search(ctx context.Context, query string, allowedTools []string) ([]mcp.Tool, error)
 
+	// SearchExplain runs the same search as Search and returns the same tools
+	// in the same order, each with an explanation of why it matched and how
+	// it was ranked.
+	SearchExplain(ctx context.Context, query string, allowedTools []string) ([]ToolMatch, error)
+
 	// Close releases any resources held by the store (e.g., database connections).
 	// For in-memory stores this is a no-op.
 	// It is safe to call Close multiple times.
 	Close() error
 }
 
+// Ranking sources of a search result, reported in MatchExplanation.RankedBy.
+const (
+	// RankedBySemantic marks a result ranked by cosine similarity.
+	RankedBySemantic = "semantic"
+
+	// RankedByKeyword marks a result ranked by FTS5 BM25 score.
+	RankedByKeyword = "keyword"
+
+	// RankedByRerank marks a result ranked by a Reranker score.
+	RankedByRerank = "rerank"
+)
+
+// ToolMatch is a search result together with the explanation of its ranking.
+type ToolMatch struct {
+	Tool        mcp.Tool
+	Explanation MatchExplanation
+}
+
+// MatchExplanation describes why a tool matched a search and how it was
+// ranked. Hybrid search lists semantic matches first, by cosine similarity,
+// followed by keyword-only matches, by BM25 score. Score encodes that order:
+// semantic matches score in [1, 2] and keyword-only matches in [0, 1), so
+// results are always in non-increasing Score order. When a Reranker is
+// configured, every result is ranked by, and scored with, its re-ranker score.
+type MatchExplanation struct {
+	// ToolName is the name of the matched tool.
+	ToolName string `json:"tool_name"`
+
+	// MatchedKeywords are the query terms FTS5 matched in the tool's name or
+	// description, as they appear there. Empty for semantic-only matches.
+	MatchedKeywords []string `json:"matched_keywords,omitempty"`
+
+	// BM25Score is the FTS5 BM25 relevance score; higher is better. Nil if the
+	// tool was not a keyword match.
+	BM25Score *float64 `json:"bm25_score,omitempty"`
+
+	// CosineSimilarity is the cosine similarity between the query and tool
+	// embeddings, from -1 to 1. Nil if the tool was not a semantic match.
+	CosineSimilarity *float64 `json:"cosine_similarity,omitempty"`
+
+	// RerankScore is the relevance score the Reranker assigned to the tool.
+	// Nil if no re-ranker is configured or re-ranking failed.
+	RerankScore *float64 `json:"rerank_score,omitempty"`
+
+	// RankedBy is the signal the result was ranked by: RankedBySemantic,
+	// RankedByKeyword or RankedByRerank.
+	RankedBy string `json:"ranked_by"`
+
+	// Score is the final score the result was ranked by.
+	Score float64 `json:"score"`
+}
+
 // Embedding provider identifiers select the wire protocol used to talk to the
 // embedding service. They match config.OptimizerConfig.EmbeddingProvider.
 const (
@@ -61,6 +118,18 @@ type EmbeddingClient interface {
 	Close() error
 }
 
+// Reranker scores the relevance of documents to a query, typically with a
+// cross-encoder. It is the optional second ranking stage applied to the top
+// hybrid search candidates.
+type Reranker interface {
+	// Rerank returns a relevance score for each document, in input order.
+	// Higher scores are more relevant.
+	Rerank(ctx context.Context, query string, documents []string) ([]float64, error)
+
+	// Close releases any resources held by the re-ranker.
+	Close() error
+}
+
 // OptimizerConfig defines runtime configuration options for the Optimizer.
 //
 // This struct intentionally duplicates some fields from config.OptimizerConfig
@@ -99,6 +168,22 @@ type OptimizerConfig struct {
 	// provider.
 	EmbeddingHeaders map[string]string
 
+	// EmbeddingCachePath is the SQLite file in which embeddings are cached
+	// across restarts. Empty caches embeddings in memory only.
+	EmbeddingCachePath string
+
+	// EmbeddingCacheMaxEntries caps the number of cached embeddings. Zero means
+	// use the default (10000).
+	EmbeddingCacheMaxEntries int
+
+	// RerankService is the URL of a TEI re-ranking service. Empty disables
+	// re-ranking.
+	RerankService string
+
+	// RerankTopK is the number of search candidates re-ranked. Zero means use
+	// the default (20). Values below MaxToolsToReturn are raised to it.
+	RerankTopK int
+
 	// MaxToolsToReturn limits the number of tools returned by FindTool.
 	MaxToolsToReturn *int
 
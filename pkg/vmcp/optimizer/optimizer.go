@@ -56,12 +56,28 @@ func GetAndValidateConfig(cfg *vmcpconfig.OptimizerConfig) (*Config, error) {
 		EmbeddingProvider:       cfg.EmbeddingProvider,
 		EmbeddingModel:          cfg.EmbeddingModel,
 		EmbeddingHeaders:        convertEmbeddingHeaders(cfg.EmbeddingHeaders),
+		EmbeddingCachePath:      cfg.EmbeddingCachePath,
+		RerankService:           cfg.RerankService,
 	}
 
 	if err := resolveEmbeddingProvider(optCfg); err != nil {
 		return nil, err
 	}
 
+	if cfg.EmbeddingCacheMaxEntries != 0 {
+		if cfg.EmbeddingCacheMaxEntries < 1 {
+			return nil, fmt.Errorf("optimizer.embeddingCacheMaxEntries must be at least 1, got %d", cfg.EmbeddingCacheMaxEntries)
+		}
+		optCfg.EmbeddingCacheMaxEntries = cfg.EmbeddingCacheMaxEntries
+	}
+
+	if cfg.RerankTopK != 0 {
+		if cfg.RerankTopK < 1 || cfg.RerankTopK > 100 {
+			return nil, fmt.Errorf("optimizer.rerankTopK must be between 1 and 100, got %d", cfg.RerankTopK)
+		}
+		optCfg.RerankTopK = cfg.RerankTopK
+	}
+
 	if cfg.MaxToolsToReturn != 0 {
 		if cfg.MaxToolsToReturn < 1 || cfg.MaxToolsToReturn > 50 {
 			return nil, fmt.Errorf("optimizer.maxToolsToReturn must be between 1 and 50, got %d", cfg.MaxToolsToReturn)
@@ -202,6 +218,10 @@ type FindToolInput struct {
 	// ToolKeywords is an optional list of keywords to narrow the search.
 	//nolint:lll // Long description tag provides essential context for LLM tool usage.
 	ToolKeywords []string `json:"tool_keywords,omitempty" description:"Optional keywords for BM25 text search to narrow results (e.g. ['list', 'issues', 'github'] or ['SQL', 'query', 'postgres']). Combined with tool_description for hybrid search."`
+
+	// Explain requests an explanation of how each result matched and was ranked.
+	//nolint:lll // Long description tag provides essential context for LLM tool usage.
+	Explain bool `json:"explain,omitempty" description:"Optional. When true, the result includes explanations: for each returned tool, the matched keywords, BM25 score, cosine similarity and the final score it was ranked by. Intended for debugging search results."`
 }
 
 // FindToolOutput contains the results of a tool search.
@@ -211,8 +231,18 @@ type FindToolOutput struct {
 
 	// TokenMetrics provides information about token savings from using the optimizer.
 	TokenMetrics TokenMetrics `json:"token_metrics"`
+
+	// Explanations describes, for each tool in Tools and in the same order,
+	// why it matched and how it was ranked. Only set when
+	// FindToolInput.Explain is true.
+	Explanations []MatchExplanation `json:"explanations,omitempty"`
 }
 
+// MatchExplanation describes why a tool matched a find_tool search and how it
+// was ranked. It is defined in the internal/types package and aliased here so
+// that external consumers can use optimizer.MatchExplanation.
+type MatchExplanation = types.MatchExplanation
+
 // TokenMetrics provides information about token usage optimization.
 // It is defined in the internal/tokencounter package and aliased here so that
 // external consumers continue to use optimizer.TokenMetrics.
@@ -243,7 +273,12 @@ func NewOptimizerFactory(cfg *Config) (
 		return nil, nil, fmt.Errorf("failed to create embedding client: %w", err)
 	}
 
-	store, err := toolstore.NewSQLiteToolStore(embClient, cfg)
+	reranker, err := similarity.NewReranker(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create re-ranker: %w", err)
+	}
+
+	store, err := toolstore.NewSQLiteToolStore(embClient, reranker, cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create optimizer store: %w", err)
 	}
@@ -256,6 +291,7 @@ func NewOptimizerFactory(cfg *Config) (
 	slog.Debug("optimizer factory created",
 		"embedding_service", cfg.EmbeddingService,
 		"semantic_search_enabled", embClient != nil,
+		"rerank_service", cfg.RerankService,
 	)
 
 	return factory, cleanup, nil
@@ -287,6 +323,10 @@ type toolOptimizer struct {
 	// baselineTokens is the precomputed sum of all per-tool token counts.
 	// Immutable after construction; used as the denominator for savings metrics.
 	baselineTokens int
+
+	// tokenizer names the tokencounter.Counter that produced tokenCounts and is
+	// reported in TokenMetrics.
+	tokenizer string
 }
 
 // newToolOptimizer creates a new toolOptimizer backed by the given ToolStore.
@@ -316,6 +356,7 @@ func newToolOptimizer(
 	slog.Debug("optimizer session created",
 		"tools", len(tools),
 		"baseline_tokens", baselineTokens,
+		"tokenizer", counter.Tokenizer(),
 	)
 
 	return &toolOptimizer{
@@ -324,6 +365,7 @@ func newToolOptimizer(
 		toolNames:      names,
 		tokenCounts:    tokenCounts,
 		baselineTokens: baselineTokens,
+		tokenizer:      counter.Tokenizer(),
 	}, nil
 }
 
@@ -336,7 +378,7 @@ func (d *toolOptimizer) FindTool(ctx context.Context, input FindToolInput) (*Fin
 		return nil, fmt.Errorf("tool_description is required")
 	}
 
-	matches, err := d.store.Search(ctx, input.ToolDescription, d.toolNames)
+	matches, explanations, err := d.search(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("tool search failed: %w", err)
 	}
@@ -355,6 +397,7 @@ func (d *toolOptimizer) FindTool(ctx context.Context, input FindToolInput) (*Fin
 		matchedNames[i] = m.Name
 	}
 	metrics := tokencounter.ComputeTokenMetrics(d.baselineTokens, d.tokenCounts, matchedNames)
+	metrics.Tokenizer = d.tokenizer
 
 	slog.Debug("find_tool completed",
 		"query", input.ToolDescription,
@@ -368,13 +411,37 @@ func (d *toolOptimizer) FindTool(ctx context.Context, input FindToolInput) (*Fin
 	return &FindToolOutput{
 		Tools:        matches,
 		TokenMetrics: metrics,
+		Explanations: explanations,
 	}, nil
 }
 
+// search runs the store search for input, scoped to this instance's tools.
+// When input.Explain is set it also returns an explanation per match.
+func (d *toolOptimizer) search(ctx context.Context, input FindToolInput) ([]mcp.Tool, []MatchExplanation, error) {
+	if !input.Explain {
+		matches, err := d.store.Search(ctx, input.ToolDescription, d.toolNames)
+		return matches, nil, err
+	}
+
+	results, err := d.store.SearchExplain(ctx, input.ToolDescription, d.toolNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	matches := make([]mcp.Tool, len(results))
+	explanations := make([]MatchExplanation, len(results))
+	for i, r := range results {
+		matches[i] = r.Tool
+		explanations[i] = r.Explanation
+	}
+	return matches, explanations, nil
+}
+
 // CallTool invokes a tool by name using its registered handler.
 //
-// The tool is looked up by exact name match. If found, the handler
-// is invoked directly with the given parameters.
+// The tool is looked up by exact name match. If found, the parameters are
+// validated against the tool's input schema and the handler is invoked
+// directly with them. An unknown tool or invalid parameters are reported as
+// a tool error, so the caller can correct the call.
 func (d *toolOptimizer) CallTool(ctx context.Context, input CallToolInput) (*mcp.CallToolResult, error) {
 	if input.ToolName == "" {
 		return nil, fmt.Errorf("tool_name is required")
@@ -387,6 +454,11 @@ func (d *toolOptimizer) CallTool(ctx context.Context, input CallToolInput) (*mcp
 		return mcp.NewToolResultError(fmt.Sprintf("tool not found: %s", input.ToolName)), nil
 	}
 
+	if violations := validateArguments(tool.Tool, input.Parameters); violations != "" {
+		slog.Debug("call_tool failed, invalid arguments", "tool", input.ToolName, "violations", violations)
+		return invalidArgumentsResult(input.ToolName, violations), nil
+	}
+
 	slog.Debug("call_tool invoking backend tool", "tool", input.ToolName)
 
 	// Build the MCP request
@@ -274,6 +274,42 @@ func TestGetAndValidateConfig(t *testing.T) {
 			},
 			expected: &Config{},
 		},
+		{
+			name: "embedding cache settings are passed through",
+			cfg: &vmcpconfig.OptimizerConfig{
+				EmbeddingCachePath:       "/var/lib/vmcp/embeddings.db",
+				EmbeddingCacheMaxEntries: 500,
+			},
+			expected: &Config{
+				EmbeddingCachePath:       "/var/lib/vmcp/embeddings.db",
+				EmbeddingCacheMaxEntries: 500,
+			},
+		},
+		{
+			name: "error: EmbeddingCacheMaxEntries negative",
+			cfg: &vmcpconfig.OptimizerConfig{
+				EmbeddingCacheMaxEntries: -1,
+			},
+			errContains: "optimizer.embeddingCacheMaxEntries must be at least 1",
+		},
+		{
+			name: "re-ranking settings are passed through",
+			cfg: &vmcpconfig.OptimizerConfig{
+				RerankService: "http://reranker:8080",
+				RerankTopK:    30,
+			},
+			expected: &Config{
+				RerankService: "http://reranker:8080",
+				RerankTopK:    30,
+			},
+		},
+		{
+			name: "error: RerankTopK too high",
+			cfg: &vmcpconfig.OptimizerConfig{
+				RerankTopK: 101,
+			},
+			errContains: "optimizer.rerankTopK must be between 1 and 100",
+		},
 		{
 			name: "error: MaxToolsToReturn too high",
 			cfg: &vmcpconfig.OptimizerConfig{
@@ -507,6 +543,49 @@ func TestOptimizer_SearchDelegation(t *testing.T) {
 	require.Greater(t, result.TokenMetrics.BaselineTokens, 0)
 	require.Greater(t, result.TokenMetrics.ReturnedTokens, 0)
 	require.Greater(t, result.TokenMetrics.SavingsPercent, 0.0)
+	require.Equal(t, "json_bytes/4", result.TokenMetrics.Tokenizer)
+}
+
+// TestOptimizer_FindToolExplain verifies that explain mode returns the store's
+// explanations aligned with the returned tools, and that normal calls do not
+// request them.
+func TestOptimizer_FindToolExplain(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockToolStore(ctrl)
+
+	tools := []server.ServerTool{
+		{Tool: mcp.Tool{Name: "tool_a", Description: "Tool A"}},
+		{Tool: mcp.Tool{Name: "tool_b", Description: "Tool B"}},
+	}
+	similarity, bm25 := 0.9, 1.5
+	explanations := []MatchExplanation{
+		{ToolName: "tool_b", CosineSimilarity: &similarity, RankedBy: types.RankedBySemantic, Score: 1.95},
+		{ToolName: "tool_a", BM25Score: &bm25, MatchedKeywords: []string{"tool"}, RankedBy: types.RankedByKeyword, Score: 0.6},
+	}
+
+	store.EXPECT().UpsertTools(gomock.Any(), gomock.Any()).Return(nil)
+	store.EXPECT().SearchExplain(gomock.Any(), "query", gomock.Any()).Return([]types.ToolMatch{
+		{Tool: mcp.Tool{Name: "tool_b"}, Explanation: explanations[0]},
+		{Tool: mcp.Tool{Name: "tool_a"}, Explanation: explanations[1]},
+	}, nil)
+	store.EXPECT().Search(gomock.Any(), "query", gomock.Any()).Return([]mcp.Tool{{Name: "tool_b"}}, nil)
+
+	opt, err := newToolOptimizer(context.Background(), store, tokencounter.NewJSONByteCounter(), tools)
+	require.NoError(t, err)
+
+	result, err := opt.FindTool(context.Background(), FindToolInput{ToolDescription: "query", Explain: true})
+	require.NoError(t, err)
+	require.Len(t, result.Tools, 2)
+	require.Equal(t, "tool_b", result.Tools[0].Name)
+	require.Equal(t, "Tool B", result.Tools[0].Description, "explained results are enriched like normal ones")
+	require.Equal(t, explanations, result.Explanations)
+
+	result, err = opt.FindTool(context.Background(), FindToolInput{ToolDescription: "query"})
+	require.NoError(t, err)
+	require.Len(t, result.Tools, 1)
+	require.Nil(t, result.Explanations)
 }
 
 // TestOptimizer_FindToolEnrichesSchema verifies that FindTool populates
@@ -778,6 +857,28 @@ func TestOptimizer_CallTool(t *testing.T) {
 				return mcp.NewToolResultText("Hello, " + input + "!"), nil
 			},
 		},
+		{
+			Tool: mcp.Tool{
+				Name:        "greet",
+				Description: "Greet someone",
+				InputSchema: mcp.ToolInputSchema{
+					Type: "object",
+					Properties: map[string]any{
+						"name":  map[string]any{"type": "string"},
+						"times": map[string]any{"type": "integer", "minimum": 1},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				// Echo the forwarded arguments so the test can check them.
+				args, err := json.Marshal(req.Params.Arguments)
+				if err != nil {
+					return nil, err
+				}
+				return mcp.NewToolResultText(req.Params.Name + " " + string(args)), nil
+			},
+		},
 	}
 
 	ctrl := gomock.NewController(t)
@@ -810,6 +911,32 @@ func TestOptimizer_CallTool(t *testing.T) {
 			isToolError:  true,
 			expectedText: "tool not found: nonexistent",
 		},
+		{
+			name: "arguments are forwarded to the tool",
+			input: CallToolInput{
+				ToolName:   "greet",
+				Parameters: map[string]any{"name": "Ada", "times": 2},
+			},
+			expectedText: `greet {"name":"Ada","times":2}`,
+		},
+		{
+			name: "missing required argument",
+			input: CallToolInput{
+				ToolName:   "greet",
+				Parameters: map[string]any{"times": 2},
+			},
+			isToolError:  true,
+			expectedText: "invalid arguments for tool greet: (root): name is required",
+		},
+		{
+			name: "argument of the wrong type",
+			input: CallToolInput{
+				ToolName:   "greet",
+				Parameters: map[string]any{"name": "Ada", "times": "twice"},
+			},
+			isToolError:  true,
+			expectedText: "invalid arguments for tool greet: times: Invalid type. Expected: integer, given: string",
+		},
 		{
 			name: "empty tool name",
 			input: CallToolInput{
@@ -376,6 +376,7 @@ func BackendToTarget(backend *Backend) *BackendTarget {
 		SessionAffinity: false, // TODO: Add session affinity support in future phases
 		HealthStatus:    backend.HealthStatus,
 		HeaderForward:   backend.HeaderForward,
+		Stdio:           backend.Stdio,
 		Metadata:        backend.Metadata,
 	}
 }
@@ -864,6 +864,24 @@ func TestBackendTarget_GetBackendCapabilityName(t *testing.T) {
 	}
 }
 
+func TestBackendTarget_WithParameterDefaults(t *testing.T) {
+	t.Parallel()
+
+	target := &BackendTarget{ParameterDefaults: map[string]any{"org": "acme", "limit": 10}}
+
+	args := map[string]any{"query": "bug", "org": "other"}
+	merged := target.WithParameterDefaults(args)
+	assert.Equal(t, map[string]any{"query": "bug", "org": "other", "limit": 10}, merged,
+		"defaults fill in absent arguments and client values win")
+	assert.Equal(t, map[string]any{"query": "bug", "org": "other"}, args, "arguments are not mutated")
+
+	assert.Equal(t, map[string]any{"org": "acme", "limit": 10}, target.WithParameterDefaults(nil))
+
+	noDefaults := &BackendTarget{}
+	assert.Equal(t, args, noDefaults.WithParameterDefaults(args))
+	assert.Nil(t, noDefaults.WithParameterDefaults(nil))
+}
+
 // DynamicRegistry Tests
 
 func TestNewDynamicRegistry(t *testing.T) {
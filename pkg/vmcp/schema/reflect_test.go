@@ -27,6 +27,10 @@ func TestGenerateSchema_FindToolInput(t *testing.T) {
 				"items":       map[string]any{"type": "string"},
 				"description": "Optional keywords for BM25 text search to narrow results (e.g. ['list', 'issues', 'github'] or ['SQL', 'query', 'postgres']). Combined with tool_description for hybrid search.",
 			},
+			"explain": map[string]any{
+				"type":        "boolean",
+				"description": "Optional. When true, the result includes explanations: for each returned tool, the matched keywords, BM25 score, cosine similarity and the final score it was ranked by. Intended for debugging search results.",
+			},
 		},
 		"required": []string{"tool_description"},
 	}
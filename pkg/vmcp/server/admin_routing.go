@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// adminRoutingPath is the admin API endpoint for the routing table.
+const adminRoutingPath = "/api/admin/routing"
+
+// AdminRoute is one entry of the routing table: a capability exposed by vMCP
+// and the backend that serves it. Backend auth configuration is never included.
+type AdminRoute struct {
+	// Name is the tool or prompt name, or the resource URI, exposed by vMCP.
+	Name string `json:"name"`
+	// BackendID is the ID of the backend the capability is routed to.
+	BackendID string `json:"backend_id"`
+	// BackendName is the name of the backend the capability is routed to.
+	BackendName string `json:"backend_name"`
+	// OriginalName is the capability name on the backend, when conflict
+	// resolution renamed it.
+	OriginalName string `json:"original_name,omitempty"`
+	// HealthStatus is the health of the backend when the table was built.
+	HealthStatus vmcp.BackendHealthStatus `json:"health_status,omitempty"`
+}
+
+// AdminRoutingResponse is the response of a routing table dump. Each list is
+// sorted by name.
+type AdminRoutingResponse struct {
+	Tools             []AdminRoute `json:"tools"`
+	Resources         []AdminRoute `json:"resources"`
+	ResourceTemplates []AdminRoute `json:"resource_templates"`
+	Prompts           []AdminRoute `json:"prompts"`
+}
+
+// handleAdminRouting serves a read-only dump of the routing table, for
+// operators debugging which backend a capability goes to:
+//
+//	GET /api/admin/routing    lists every routed capability and its backend
+//
+// The table is the core's admin view: the capabilities of all healthy
+// backends, without per-identity admission filtering, so Handler mounts it
+// behind the incoming authentication middleware and the authenticated
+// identity must carry AdminScope.
+func (s *Server) handleAdminRouting(w http.ResponseWriter, r *http.Request) {
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok || identity == nil {
+		writeAdminError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+	if !hasScope(identity, AdminScope) {
+		slog.Warn("admin routing API request denied", "subject", identity.Subject, "method", r.Method)
+		writeAdminError(w, http.StatusForbidden, "scope "+AdminScope+" required")
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeAdminError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	table, err := s.core.RoutingTable(r.Context())
+	if err != nil {
+		slog.Error("failed to build routing table", "error", err)
+		writeAdminError(w, http.StatusInternalServerError, "failed to build routing table")
+		return
+	}
+	if table == nil {
+		table = &vmcp.RoutingTable{}
+	}
+	writeAdminJSON(w, http.StatusOK, AdminRoutingResponse{
+		Tools:             adminRoutes(table.Tools),
+		Resources:         adminRoutes(table.Resources),
+		ResourceTemplates: adminRoutes(table.ResourceTemplates),
+		Prompts:           adminRoutes(table.Prompts),
+	})
+}
+
+// adminRoutes converts routing table entries to AdminRoutes sorted by name.
+func adminRoutes(targets map[string]*vmcp.BackendTarget) []AdminRoute {
+	routes := make([]AdminRoute, 0, len(targets))
+	for name, target := range targets {
+		if target == nil {
+			continue
+		}
+		route := AdminRoute{
+			Name:         name,
+			BackendID:    target.WorkloadID,
+			BackendName:  target.WorkloadName,
+			HealthStatus: target.HealthStatus,
+		}
+		if target.OriginalCapabilityName != name {
+			route.OriginalName = target.OriginalCapabilityName
+		}
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Name < routes[j].Name })
+	return routes
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
+)
+
+func newAdminRoutingHandler(t *testing.T, table *vmcp.RoutingTable) http.Handler {
+	t.Helper()
+
+	cfg := testMinimalServeConfig()
+	cfg.AuthMiddleware = headerIdentityMiddleware
+	srv, err := Serve(context.Background(), &stubVMCP{routingTable: table}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	handler, err := srv.Handler(context.Background())
+	require.NoError(t, err)
+	return handler
+}
+
+func TestAdminRouting_Dump(t *testing.T) {
+	t.Parallel()
+
+	github := &vmcp.BackendTarget{
+		WorkloadID:             "github-id",
+		WorkloadName:           "github",
+		OriginalCapabilityName: "create_issue",
+		HealthStatus:           vmcp.BackendHealthy,
+		AuthConfig: &authtypes.BackendAuthStrategy{
+			Type: authtypes.StrategyTypeTokenExchange,
+			TokenExchange: &authtypes.TokenExchangeConfig{
+				TokenURL:     "https://sts.example.com/token",
+				ClientSecret: "backend-client-secret",
+			},
+		},
+	}
+	jira := &vmcp.BackendTarget{
+		WorkloadID:             "jira-id",
+		WorkloadName:           "jira",
+		OriginalCapabilityName: "search",
+		HealthStatus:           vmcp.BackendDegraded,
+	}
+	handler := newAdminRoutingHandler(t, &vmcp.RoutingTable{
+		Tools: map[string]*vmcp.BackendTarget{
+			"jira_search":         jira,
+			"github_create_issue": github,
+		},
+		Resources: map[string]*vmcp.BackendTarget{
+			"file:///readme": {WorkloadID: "github-id", WorkloadName: "github", OriginalCapabilityName: "file:///readme"},
+		},
+	})
+
+	rec := adminRequest(handler, http.MethodGet, adminRoutingPath, AdminScope)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "backend-client-secret", "backend auth must never be dumped")
+
+	var got AdminRoutingResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, []AdminRoute{
+		{
+			Name:         "github_create_issue",
+			BackendID:    "github-id",
+			BackendName:  "github",
+			OriginalName: "create_issue",
+			HealthStatus: vmcp.BackendHealthy,
+		},
+		{
+			Name:         "jira_search",
+			BackendID:    "jira-id",
+			BackendName:  "jira",
+			OriginalName: "search",
+			HealthStatus: vmcp.BackendDegraded,
+		},
+	}, got.Tools)
+	assert.Equal(t, []AdminRoute{{Name: "file:///readme", BackendID: "github-id", BackendName: "github"}}, got.Resources)
+	assert.Empty(t, got.ResourceTemplates)
+	assert.Empty(t, got.Prompts)
+}
+
+func TestAdminRouting_Authorization(t *testing.T) {
+	t.Parallel()
+
+	handler := newAdminRoutingHandler(t, &vmcp.RoutingTable{})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, adminRoutingPath, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("missing admin scope", func(t *testing.T) {
+		t.Parallel()
+		rec := adminRequest(handler, http.MethodGet, adminRoutingPath, "openid vmcp:read")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		t.Parallel()
+		rec := adminRequest(handler, http.MethodDelete, adminRoutingPath, AdminScope)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET", rec.Header().Get("Allow"))
+	})
+}
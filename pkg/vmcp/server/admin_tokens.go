@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
+)
+
+// AdminScope is the token scope an identity needs to use the vMCP admin API.
+const AdminScope = "vmcp:admin"
+
+// adminTokensPath is the admin API endpoint for the backend token cache.
+const adminTokensPath = "/api/admin/tokens"
+
+// AdminTokensResponse is the response of a token cache listing.
+type AdminTokensResponse struct {
+	Tokens []cache.Entry `json:"tokens"`
+}
+
+// AdminTokensInvalidatedResponse is the response of a token invalidation.
+type AdminTokensInvalidatedResponse struct {
+	Invalidated int `json:"invalidated"`
+}
+
+// handleAdminTokens serves the admin API of the backend token cache, for
+// operators who must expire cached tokens after a credential rotation:
+//
+//	GET    /api/admin/tokens                 lists cached tokens, without values
+//	DELETE /api/admin/tokens?backend=<id>    invalidates the tokens of a backend
+//	DELETE /api/admin/tokens?subject=<sub>   invalidates the tokens of a user
+//
+// Handler mounts it behind the incoming authentication middleware, and the
+// authenticated identity must carry AdminScope.
+func (s *Server) handleAdminTokens(w http.ResponseWriter, r *http.Request) {
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok || identity == nil {
+		writeAdminError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+	if !hasScope(identity, AdminScope) {
+		slog.Warn("admin token API request denied", "subject", identity.Subject, "method", r.Method)
+		writeAdminError(w, http.StatusForbidden, "scope "+AdminScope+" required")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		entries, err := s.config.TokenCache.List(r.Context())
+		if err != nil {
+			slog.Error("failed to list cached tokens", "error", err)
+			writeAdminError(w, http.StatusInternalServerError, "failed to list cached tokens")
+			return
+		}
+		writeAdminJSON(w, http.StatusOK, AdminTokensResponse{Tokens: entries})
+	case http.MethodDelete:
+		s.invalidateTokens(w, r, identity)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		writeAdminError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// invalidateTokens removes the cached tokens of the backend or the subject
+// named in the query.
+func (s *Server) invalidateTokens(w http.ResponseWriter, r *http.Request, identity *auth.Identity) {
+	backend := r.URL.Query().Get("backend")
+	subject := r.URL.Query().Get("subject")
+	if (backend == "") == (subject == "") {
+		writeAdminError(w, http.StatusBadRequest, "exactly one of backend or subject is required")
+		return
+	}
+
+	ctx := r.Context()
+	tokenCache := s.config.TokenCache
+	var invalidated int
+	if backend != "" {
+		n, err := tokenCache.InvalidateByPrefix(ctx, cache.BackendKeyPrefix(backend))
+		if err != nil {
+			slog.Error("failed to invalidate cached tokens", "backend", backend, "error", err)
+			writeAdminError(w, http.StatusInternalServerError, "failed to invalidate cached tokens")
+			return
+		}
+		invalidated = n
+	} else {
+		// Keys hold a hash of the subject token, not the subject, so the
+		// subject's entries are found through their metadata.
+		entries, err := tokenCache.List(ctx)
+		if err != nil {
+			slog.Error("failed to list cached tokens", "error", err)
+			writeAdminError(w, http.StatusInternalServerError, "failed to invalidate cached tokens")
+			return
+		}
+		for _, entry := range entries {
+			if entry.Subject != subject {
+				continue
+			}
+			if err := tokenCache.Delete(ctx, entry.Key); err != nil {
+				slog.Error("failed to invalidate cached token", "key", entry.Key, "error", err)
+				writeAdminError(w, http.StatusInternalServerError, "failed to invalidate cached tokens")
+				return
+			}
+			invalidated++
+		}
+	}
+
+	slog.Info("cached tokens invalidated by admin",
+		"admin", identity.Subject, "backend", backend, "subject", subject, "count", invalidated)
+	writeAdminJSON(w, http.StatusOK, AdminTokensInvalidatedResponse{Invalidated: invalidated})
+}
+
+// hasScope reports whether identity's token grants scope, from either the
+// space-separated "scope" claim (RFC 8693) or the "scp" list claim.
+func hasScope(identity *auth.Identity, scope string) bool {
+	switch scopes := identity.Claims["scope"].(type) {
+	case string:
+		if slices.Contains(strings.Fields(scopes), scope) {
+			return true
+		}
+	case []string:
+		if slices.Contains(scopes, scope) {
+			return true
+		}
+	}
+	switch scopes := identity.Claims["scp"].(type) {
+	case string:
+		return slices.Contains(strings.Fields(scopes), scope)
+	case []string:
+		return slices.Contains(scopes, scope)
+	case []any:
+		return slices.Contains(scopes, any(scope))
+	}
+	return false
+}
+
+// writeAdminJSON encodes response before writing headers to ensure encoding
+// succeeds.
+func writeAdminJSON(w http.ResponseWriter, status int, response any) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		slog.Error("failed to encode admin response", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(data); err != nil {
+		slog.Error("failed to write admin response", "error", err)
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, status int, message string) {
+	writeAdminJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-core/env"
+	mcpserver "github.com/stacklok/toolhive-core/mcpcompat/server"
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	authfactory "github.com/stacklok/toolhive/pkg/vmcp/auth/factory"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth/strategies"
+	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+)
+
+// headerIdentityMiddleware authenticates requests from test headers: the
+// subject from X-Test-Subject and the scope claim from X-Test-Scope.
+func headerIdentityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject := r.Header.Get("X-Test-Subject")
+		if subject == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		identity := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{
+			Subject: subject,
+			Claims:  map[string]any{"scope": r.Header.Get("X-Test-Scope")},
+		}}
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+func newAdminTokensHandler(t *testing.T, tokenCache cache.TokenCache) http.Handler {
+	t.Helper()
+
+	cfg := testMinimalServeConfig()
+	cfg.TokenCache = tokenCache
+	cfg.AuthMiddleware = headerIdentityMiddleware
+	srv, err := Serve(context.Background(), &stubVMCP{}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	handler, err := srv.Handler(context.Background())
+	require.NoError(t, err)
+	return handler
+}
+
+func seedTokenCache(t *testing.T) *cache.MemoryTokenCache {
+	t.Helper()
+
+	tokenCache := cache.NewMemoryTokenCache()
+	for key, subject := range map[string]string{
+		"github:h1:api": "alice",
+		"github:h2:api": "bob",
+		"jira:h1:api":   "alice",
+	} {
+		require.NoError(t, tokenCache.Set(context.Background(), key, &cache.CachedToken{
+			Token:     "secret-" + key,
+			TokenType: "Bearer",
+			ExpiresAt: time.Now().Add(time.Hour),
+			Metadata:  map[string]string{cache.MetadataKeySubject: subject},
+		}))
+	}
+	return tokenCache
+}
+
+func adminRequest(handler http.Handler, method, target, scope string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("X-Test-Subject", "admin")
+	req.Header.Set("X-Test-Scope", scope)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func cachedKeys(t *testing.T, tokenCache cache.TokenCache) []string {
+	t.Helper()
+
+	entries, err := tokenCache.List(context.Background())
+	require.NoError(t, err)
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+func TestAdminTokens_List(t *testing.T) {
+	t.Parallel()
+
+	handler := newAdminTokensHandler(t, seedTokenCache(t))
+	rec := adminRequest(handler, http.MethodGet, adminTokensPath, "openid "+AdminScope)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.NotContains(t, rec.Body.String(), "secret", "token values must never be listed")
+
+	var resp AdminTokensResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Tokens, 3)
+	assert.Equal(t, "github:h1:api", resp.Tokens[0].Key)
+	assert.Equal(t, "alice", resp.Tokens[0].Subject)
+}
+
+func TestAdminTokens_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		query           string
+		wantStatus      int
+		wantInvalidated int
+		wantKeys        []string
+	}{
+		{
+			name:            "by backend",
+			query:           "?backend=github",
+			wantStatus:      http.StatusOK,
+			wantInvalidated: 2,
+			wantKeys:        []string{"jira:h1:api"},
+		},
+		{
+			name:            "by subject",
+			query:           "?subject=alice",
+			wantStatus:      http.StatusOK,
+			wantInvalidated: 2,
+			wantKeys:        []string{"github:h2:api"},
+		},
+		{
+			name:            "unknown backend",
+			query:           "?backend=gitlab",
+			wantStatus:      http.StatusOK,
+			wantInvalidated: 0,
+			wantKeys:        []string{"github:h1:api", "github:h2:api", "jira:h1:api"},
+		},
+		{
+			name:       "no target",
+			query:      "",
+			wantStatus: http.StatusBadRequest,
+			wantKeys:   []string{"github:h1:api", "github:h2:api", "jira:h1:api"},
+		},
+		{
+			name:       "both targets",
+			query:      "?backend=github&subject=alice",
+			wantStatus: http.StatusBadRequest,
+			wantKeys:   []string{"github:h1:api", "github:h2:api", "jira:h1:api"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenCache := seedTokenCache(t)
+			handler := newAdminTokensHandler(t, tokenCache)
+			rec := adminRequest(handler, http.MethodDelete, adminTokensPath+tt.query, AdminScope)
+
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				var resp AdminTokensInvalidatedResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tt.wantInvalidated, resp.Invalidated)
+			}
+			assert.Equal(t, tt.wantKeys, cachedKeys(t, tokenCache))
+		})
+	}
+}
+
+func TestAdminTokens_Authorization(t *testing.T) {
+	t.Parallel()
+
+	tokenCache := seedTokenCache(t)
+	handler := newAdminTokensHandler(t, tokenCache)
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, adminTokensPath, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("missing admin scope", func(t *testing.T) {
+		t.Parallel()
+		rec := adminRequest(handler, http.MethodDelete, adminTokensPath+"?backend=github", "openid vmcp:read")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Len(t, cachedKeys(t, tokenCache), 3)
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		t.Parallel()
+		rec := adminRequest(handler, http.MethodPost, adminTokensPath, AdminScope)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, DELETE", rec.Header().Get("Allow"))
+	})
+}
+
+func TestAdminTokens_NotServedWithoutAuthentication(t *testing.T) {
+	t.Parallel()
+
+	cfg := testMinimalServeConfig()
+	cfg.TokenCache = seedTokenCache(t)
+	srv, err := Serve(context.Background(), &stubVMCP{}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+	handler, err := srv.Handler(context.Background())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, adminTokensPath, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.NotEqual(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "github:h1:api")
+}
+
+// TestAdminTokens_ExchangedTokens drives real token exchanges through the
+// outgoing auth registry built by the serve path, then lists and invalidates
+// the cached token through the admin API.
+func TestAdminTokens_ExchangedTokens(t *testing.T) {
+	t.Parallel()
+
+	var exchanges atomic.Int32
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "alice-token", r.PostForm.Get("subject_token"))
+		n := exchanges.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      fmt.Sprintf("backend-token-%d", n),
+			"token_type":        "Bearer",
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+			"expires_in":        3600,
+		})
+	}))
+	t.Cleanup(sts.Close)
+
+	var lastAuthorization atomic.Value
+	mcpServer := mcpserver.NewMCPServer("exchange-backend", "1.0.0")
+	streamServer := mcpserver.NewStreamableHTTPServer(mcpServer)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastAuthorization.Store(r.Header.Get("Authorization"))
+		streamServer.ServeHTTP(w, r)
+	}))
+	t.Cleanup(backend.Close)
+
+	tokenCache := cache.NewMemoryTokenCache()
+	registry, err := authfactory.NewOutgoingAuthRegistry(
+		context.Background(), &env.OSReader{}, strategies.WithTokenCache(tokenCache))
+	require.NoError(t, err)
+	backendClient, err := vmcpclient.NewHTTPBackendClient(registry)
+	require.NoError(t, err)
+
+	target := &vmcp.BackendTarget{
+		WorkloadID:    "exchange-backend",
+		WorkloadName:  "exchange-backend",
+		BaseURL:       backend.URL + "/mcp",
+		TransportType: "streamable-http",
+		AuthConfig: &authtypes.BackendAuthStrategy{
+			Type: authtypes.StrategyTypeTokenExchange,
+			TokenExchange: &authtypes.TokenExchangeConfig{
+				TokenURL: sts.URL,
+				Audience: "backend-api",
+			},
+		},
+	}
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{
+		PrincipalInfo: auth.PrincipalInfo{Subject: "alice"},
+		Token:         "alice-token",
+	})
+
+	// The first call exchanges the user's token; the second reuses it.
+	_, err = backendClient.ListCapabilities(ctx, target)
+	require.NoError(t, err)
+	_, err = backendClient.ListCapabilities(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), exchanges.Load())
+	assert.Equal(t, "Bearer backend-token-1", lastAuthorization.Load())
+
+	handler := newAdminTokensHandler(t, tokenCache)
+	rec := adminRequest(handler, http.MethodGet, adminTokensPath, AdminScope)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "backend-token-1", "token values must never be listed")
+	assert.NotContains(t, rec.Body.String(), "alice-token", "subject tokens must never be listed")
+	var listed AdminTokensResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Len(t, listed.Tokens, 1)
+	assert.Equal(t, "alice", listed.Tokens[0].Subject)
+	assert.True(t, strings.HasPrefix(listed.Tokens[0].Key, cache.BackendKeyPrefix("exchange-backend")))
+
+	// After invalidation the next call exchanges a new token.
+	rec = adminRequest(handler, http.MethodDelete, adminTokensPath+"?backend=exchange-backend", AdminScope)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"invalidated":1}`, rec.Body.String())
+
+	_, err = backendClient.ListCapabilities(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), exchanges.Load())
+	assert.Equal(t, "Bearer backend-token-2", lastAuthorization.Load())
+}
+
+func TestHasScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   bool
+	}{
+		{name: "scope string", claims: map[string]any{"scope": "openid vmcp:admin"}, want: true},
+		{name: "scp list", claims: map[string]any{"scp": []any{"openid", "vmcp:admin"}}, want: true},
+		{name: "scp string list", claims: map[string]any{"scp": []string{"vmcp:admin"}}, want: true},
+		{name: "prefix is not a match", claims: map[string]any{"scope": "vmcp:admin:read"}, want: false},
+		{name: "no claims", claims: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			identity := &auth.Identity{PrincipalInfo: auth.PrincipalInfo{Subject: "s", Claims: tt.claims}}
+			assert.Equal(t, tt.want, hasScope(identity, AdminScope))
+		})
+	}
+}
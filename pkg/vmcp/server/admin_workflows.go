@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
+)
+
+// adminWorkflowsPath is the admin API endpoint for running composite-tool workflows.
+const adminWorkflowsPath = "/api/admin/workflows"
+
+// AdminWorkflowsResponse is the response of a running workflow listing.
+type AdminWorkflowsResponse struct {
+	Workflows []string `json:"workflows"`
+}
+
+// handleAdminWorkflows serves the admin API of running composite-tool
+// workflows, for operators debugging composite tools step by step:
+//
+//	GET /api/admin/workflows              lists the IDs of running workflows
+//	GET /api/admin/workflows/<id>/events  streams the step events of a workflow
+//
+// Events are streamed as newline-delimited JSON composer.StepEvent objects,
+// starting with the events the workflow already published. The stream ends
+// when the workflow finishes. Handler mounts it behind the incoming
+// authentication middleware, and the authenticated identity must carry
+// AdminScope.
+func (s *Server) handleAdminWorkflows(w http.ResponseWriter, r *http.Request) {
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok || identity == nil {
+		writeAdminError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+	if !hasScope(identity, AdminScope) {
+		slog.Warn("admin workflow API request denied", "subject", identity.Subject, "method", r.Method)
+		writeAdminError(w, http.StatusForbidden, "scope "+AdminScope+" required")
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeAdminError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, adminWorkflowsPath), "/")
+	if rest == "" {
+		writeAdminJSON(w, http.StatusOK, AdminWorkflowsResponse{Workflows: s.config.WorkflowEvents.RunningWorkflows()})
+		return
+	}
+	workflowID, ok := strings.CutSuffix(rest, "/events")
+	if !ok || workflowID == "" || strings.Contains(workflowID, "/") {
+		writeAdminError(w, http.StatusNotFound, "not found")
+		return
+	}
+	s.streamWorkflowEvents(w, r, workflowID)
+}
+
+// streamWorkflowEvents writes the step events of a running workflow until it
+// finishes or the client disconnects.
+func (s *Server) streamWorkflowEvents(w http.ResponseWriter, r *http.Request, workflowID string) {
+	events, cancel, err := s.config.WorkflowEvents.Subscribe(workflowID)
+	if errors.Is(err, composer.ErrWorkflowNotRunning) {
+		writeAdminError(w, http.StatusNotFound, "workflow "+workflowID+" is not running")
+		return
+	}
+	if err != nil {
+		slog.Error("failed to subscribe to workflow events", "workflow", workflowID, "error", err)
+		writeAdminError(w, http.StatusInternalServerError, "failed to subscribe to workflow events")
+		return
+	}
+	defer cancel()
+
+	// The stream lasts as long as the workflow, so it must outlive the
+	// server's WriteTimeout (golang/go#16100).
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		slog.Warn("failed to clear write deadline for workflow event stream; stream may be killed by server WriteTimeout",
+			"workflow", workflowID, "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := encoder.Encode(event); err != nil {
+				slog.Debug("stopped streaming workflow events", "workflow", workflowID, "error", err)
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+	routermocks "github.com/stacklok/toolhive/pkg/vmcp/router/mocks"
+)
+
+func newAdminWorkflowsHandler(t *testing.T) http.Handler {
+	t.Helper()
+	return newAdminWorkflowsHandlerWithBroker(t, composer.NewWorkflowEventBroker(nil))
+}
+
+func newAdminWorkflowsHandlerWithBroker(t *testing.T, broker *composer.WorkflowEventBroker) http.Handler {
+	t.Helper()
+
+	cfg := testMinimalServeConfig()
+	cfg.WorkflowEvents = broker
+	cfg.AuthMiddleware = headerIdentityMiddleware
+	srv, err := Serve(context.Background(), &stubVMCP{}, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	handler, err := srv.Handler(context.Background())
+	require.NoError(t, err)
+	return handler
+}
+
+func TestAdminWorkflows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		scope      string
+		wantStatus int
+	}{
+		{
+			name:       "list running workflows",
+			method:     http.MethodGet,
+			target:     adminWorkflowsPath,
+			scope:      AdminScope,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "events of a workflow that is not running",
+			method:     http.MethodGet,
+			target:     adminWorkflowsPath + "/unknown/events",
+			scope:      AdminScope,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown sub-resource",
+			method:     http.MethodGet,
+			target:     adminWorkflowsPath + "/unknown/steps",
+			scope:      AdminScope,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "missing admin scope",
+			method:     http.MethodGet,
+			target:     adminWorkflowsPath,
+			scope:      "openid",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unsupported method",
+			method:     http.MethodDelete,
+			target:     adminWorkflowsPath,
+			scope:      AdminScope,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := newAdminWorkflowsHandler(t)
+			rec := adminRequest(handler, tc.method, tc.target, tc.scope)
+			require.Equal(t, tc.wantStatus, rec.Code, rec.Body.String())
+
+			if tc.wantStatus == http.StatusOK {
+				var resp AdminWorkflowsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Empty(t, resp.Workflows)
+			}
+		})
+	}
+}
+
+// TestAdminWorkflows_EventStreamOutlivesWriteTimeout verifies that a workflow
+// event stream is not cut off by the server's WriteTimeout while the workflow
+// is still running.
+func TestAdminWorkflows_EventStreamOutlivesWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	const writeTimeout = 200 * time.Millisecond
+
+	// A workflow whose only tool call blocks until release is closed.
+	ctrl := gomock.NewController(t)
+	mockRouter := routermocks.NewMockRouter(ctrl)
+	mockBackend := mocks.NewMockBackendClient(ctrl)
+	target := &vmcp.BackendTarget{WorkloadID: "backend", WorkloadName: "backend", BaseURL: "http://backend:8080"}
+	release := make(chan struct{})
+	mockRouter.EXPECT().ResolveToolName(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, name string) string { return name }).AnyTimes()
+	mockRouter.EXPECT().RouteTool(gomock.Any(), "slow").Return(target, nil)
+	mockBackend.EXPECT().CallTool(gomock.Any(), target, "slow", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *vmcp.BackendTarget, string, map[string]any, map[string]any) (*vmcp.ToolCallResult, error) {
+			<-release
+			return &vmcp.ToolCallResult{StructuredContent: map[string]any{"done": true}}, nil
+		})
+
+	broker := composer.NewWorkflowEventBroker(nil)
+	engine := composer.NewWorkflowEngine(mockRouter, mockBackend, nil, nil, nil, nil, composer.WithStepEvents(broker))
+	workflowDone := make(chan error, 1)
+	go func() {
+		_, err := engine.ExecuteWorkflow(context.Background(), &composer.WorkflowDefinition{
+			Name:  "slow_workflow",
+			Steps: []composer.WorkflowStep{{ID: "s1", Type: composer.StepTypeTool, Tool: "slow"}},
+		}, nil)
+		workflowDone <- err
+	}()
+	require.Eventually(t, func() bool { return len(broker.RunningWorkflows()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	workflowID := broker.RunningWorkflows()[0]
+
+	ts := httptest.NewUnstartedServer(newAdminWorkflowsHandlerWithBroker(t, broker))
+	ts.Config.WriteTimeout = writeTimeout
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+adminWorkflowsPath+"/"+workflowID+"/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Test-Subject", "admin")
+	req.Header.Set("X-Test-Scope", AdminScope)
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Finish the workflow well past the write deadline.
+	time.Sleep(3 * writeTimeout)
+	close(release)
+	require.NoError(t, <-workflowDone)
+
+	var types []composer.StepEventType
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var event composer.StepEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		types = append(types, event.Type)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Contains(t, types, composer.StepEventOutput, "events published after the write deadline are streamed")
+}
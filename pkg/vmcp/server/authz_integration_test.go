@@ -116,7 +116,8 @@ func buildCedarAuthzServer(
 	// Cedar policies below can name Tool::"echo".
 	resolver, err := aggregator.NewPriorityConflictResolver([]string{backend.Name})
 	require.NoError(t, err)
-	agg := aggregator.NewDefaultAggregator(backendClient, resolver, nil, nil)
+	agg, err := aggregator.NewDefaultAggregator(backendClient, resolver, nil, nil, nil)
+	require.NoError(t, err)
 
 	// Inject an authenticated identity on every request so the session binds to it at
 	// initialize and the Cedar authorizer can resolve the principal on subsequent calls.
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// releaseRemovedBackends watches a dynamic registry and hands the current
+// backend set to releaser whenever the registry version changes, so the
+// backend client closes the connections and processes of backends that left
+// the registry. It polls the version like status reporting does, because the
+// registry has no change callbacks. It returns when ctx is cancelled.
+func releaseRemovedBackends(
+	ctx context.Context, registry vmcp.DynamicRegistry, releaser vmcp.BackendReleaser, interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastVersion := registry.Version()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			v := registry.Version()
+			if v == lastVersion {
+				continue
+			}
+			lastVersion = v
+			backends := registry.List(ctx)
+			slog.Debug("backend registry changed, releasing resources of removed backends",
+				"version", v, "backends", len(backends))
+			releaser.RetainBackends(backends)
+		}
+	}
+}
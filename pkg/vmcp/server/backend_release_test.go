@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// recordingReleaser records the backend IDs of every RetainBackends call.
+type recordingReleaser struct {
+	mu    sync.Mutex
+	calls [][]string
+}
+
+func (r *recordingReleaser) RetainBackends(current []vmcp.Backend) {
+	ids := make([]string, 0, len(current))
+	for _, b := range current {
+		ids = append(ids, b.ID)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, ids)
+}
+
+func (r *recordingReleaser) retained() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]string(nil), r.calls...)
+}
+
+func TestReleaseRemovedBackends(t *testing.T) {
+	t.Parallel()
+
+	registry := vmcp.NewDynamicRegistry([]vmcp.Backend{{ID: "a", Name: "a"}, {ID: "b", Name: "b"}})
+	releaser := &recordingReleaser{}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		releaseRemovedBackends(ctx, registry, releaser, 5*time.Millisecond)
+	}()
+
+	// An unchanged registry releases nothing.
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, releaser.retained())
+
+	require.NoError(t, registry.Remove("b"))
+	require.Eventually(t, func() bool {
+		return len(releaser.retained()) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, [][]string{{"a"}}, releaser.retained())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("releaseRemovedBackends did not return after the context was cancelled")
+	}
+}
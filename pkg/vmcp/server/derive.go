@@ -83,14 +83,18 @@ func deriveServerConfig(
 		PassthroughHeaders:      cfg.PassthroughHeaders,
 		AuthServer:              cfg.AuthServer,
 		StatusReportingInterval: cfg.StatusReportingInterval,
+		ShutdownGracePeriod:     cfg.ShutdownGracePeriod,
+		Warmup:                  cfg.Warmup,
 		StatusReporter:          cfg.StatusReporter,
 		Watcher:                 cfg.Watcher,
+		TokenCache:              cfg.TokenCache,
 		BackendRegistry:         backendRegistry,
 		SessionStorage:          cfg.SessionStorage,
 		SessionManagerConfig:    sessionManagerConfig,
 		// Cross-cutting (also on core.Config) — R3, not a clean partition:
 		TelemetryProvider: cfg.TelemetryProvider,
 		AuditConfig:       cfg.AuditConfig,
+		WorkflowEvents:    cfg.WorkflowEvents,
 	}
 }
 
@@ -105,7 +109,8 @@ func deriveServerConfig(
 //     name is used (not the transport default) so authorization keys on the real
 //     VirtualMCPServer name rather than the synthetic "toolhive-vmcp" fallback.
 //   - TelemetryProvider / AuditConfig — the cross-cutting fields shared with the transport
-//     (R3, not a clean partition); deriveServerConfig copies the same two.
+//     (R3, not a clean partition); deriveServerConfig copies the same two, plus the
+//     WorkflowEvents broker the core publishes to and the transport streams from.
 //
 // Everything else is a collaborator passed through rather than reached out of cfg: the
 // aggregator, router, backend client, backend registry, and workflow definitions that
@@ -115,6 +120,8 @@ func deriveServerConfig(
 // health monitor configuration (cfg.HealthMonitorConfig), from which the core builds, runs,
 // and stops the monitor it owns (#5443 reversal). A nil HealthMonitorConfig means no health
 // filtering; a nil authzCfg means allow-all (matching today's AuthzMiddleware != nil guard).
+// cfg.WorkflowLimits and cfg.WorkflowFailureSink are likewise core-only: they configure
+// the composite tool engines.
 func deriveCoreConfig(
 	cfg *Config,
 	agg aggregator.Aggregator,
@@ -137,6 +144,9 @@ func deriveCoreConfig(
 		TelemetryProvider:   cfg.TelemetryProvider,
 		AuditConfig:         cfg.AuditConfig,
 		HealthMonitorConfig: cfg.HealthMonitorConfig,
+		WorkflowLimits:      cfg.WorkflowLimits,
+		WorkflowEvents:      cfg.WorkflowEvents,
+		WorkflowFailureSink: cfg.WorkflowFailureSink,
 		Elicitation:         elicitation,
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	aggmocks "github.com/stacklok/toolhive/pkg/vmcp/aggregator/mocks"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
 	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 	vmcpconfig "github.com/stacklok/toolhive/pkg/vmcp/config"
 	"github.com/stacklok/toolhive/pkg/vmcp/health"
@@ -49,9 +50,13 @@ func populatedLegacyConfig() *Config {
 		TelemetryProvider:       &telemetry.Provider{},
 		AuditConfig:             &audit.Config{},
 		StatusReportingInterval: 11 * time.Second,
+		ShutdownGracePeriod:     13 * time.Second,
+		Warmup:                  &WarmupConfig{MinHealthyPercent: 50},
 		Watcher:                 stubWatcher{},
 		StatusReporter:          stubServeReporter{},
 		SessionStorage:          &vmcpconfig.SessionStorageConfig{},
+		TokenCache:              cache.NewMemoryTokenCache(),
+		WorkflowEvents:          composer.NewWorkflowEventBroker(nil),
 	}
 }
 
@@ -75,6 +80,7 @@ func TestDeriveServerConfigProjectsTransportFields(t *testing.T) {
 	assert.Equal(t, 5*time.Second, got.HeartbeatInterval)
 	assert.True(t, got.ModernDispatchEnabled)
 	assert.Equal(t, 11*time.Second, got.StatusReportingInterval)
+	assert.Equal(t, 13*time.Second, got.ShutdownGracePeriod)
 
 	// Func/handler/pointer fields projected by reference.
 	assert.NotNil(t, got.AuthMiddleware)
@@ -82,6 +88,7 @@ func TestDeriveServerConfigProjectsTransportFields(t *testing.T) {
 	assert.Equal(t, cfg.PassthroughHeaders, got.PassthroughHeaders)
 	assert.Same(t, cfg.AuthServer, got.AuthServer)
 	assert.Same(t, cfg.SessionStorage, got.SessionStorage)
+	assert.Same(t, cfg.Warmup, got.Warmup)
 	assert.Equal(t, cfg.Watcher, got.Watcher)
 	assert.Equal(t, cfg.StatusReporter, got.StatusReporter)
 
@@ -206,6 +213,9 @@ func TestDeriveCoreConfigMapsAllFields(t *testing.T) {
 		TelemetryProvider:   &telemetry.Provider{},
 		AuditConfig:         &audit.Config{},
 		HealthMonitorConfig: &health.MonitorConfig{},
+		WorkflowLimits:      composer.WorkflowLimits{MaxSteps: 1},
+		WorkflowEvents:      composer.NewWorkflowEventBroker(nil),
+		WorkflowFailureSink: composer.NewLogFailureSink(),
 	}
 
 	got := deriveCoreConfig(
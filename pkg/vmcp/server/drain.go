@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// drainProgressInterval is how often draining progress is reported while Stop
+// waits for in-flight requests.
+const drainProgressInterval = 5 * time.Second
+
+// shuttingDownBody is the JSON-RPC error returned for requests that arrive
+// while the server is draining.
+var shuttingDownBody = []byte(
+	`{"jsonrpc":"2.0","id":"server-error","error":` +
+		`{"code":-32603,"message":"Service Unavailable: server is shutting down"}}`,
+)
+
+// requestDrainer tracks in-flight MCP requests so Stop can let them finish
+// before the HTTP server goes away. Once draining starts it admits no new
+// requests. The zero value is ready to use.
+type requestDrainer struct {
+	mu       sync.Mutex
+	draining bool
+	nextID   uint64
+	inFlight map[uint64]context.CancelFunc
+	// idle is created when draining starts and closed once no request is in
+	// flight.
+	idle chan struct{}
+}
+
+// track registers a request and returns a context that is cancelled if the
+// drain grace period expires, plus a func to call when the request completes.
+// It returns false once draining has started.
+func (d *requestDrainer) track(ctx context.Context) (context.Context, func(), bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return nil, nil, false
+	}
+	if d.inFlight == nil {
+		d.inFlight = make(map[uint64]context.CancelFunc)
+	}
+	id := d.nextID
+	d.nextID++
+	ctx, cancel := context.WithCancel(ctx)
+	d.inFlight[id] = cancel
+
+	return ctx, func() {
+		cancel()
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		delete(d.inFlight, id)
+		if d.draining && len(d.inFlight) == 0 {
+			close(d.idle)
+		}
+	}, true
+}
+
+// startDraining stops admitting requests and returns a channel that is closed
+// once every in-flight request has completed. It is safe to call repeatedly.
+func (d *requestDrainer) startDraining() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.draining {
+		d.draining = true
+		d.idle = make(chan struct{})
+		if len(d.inFlight) == 0 {
+			close(d.idle)
+		}
+	}
+	return d.idle
+}
+
+// isDraining reports whether draining has started.
+func (d *requestDrainer) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// count returns the number of in-flight requests.
+func (d *requestDrainer) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.inFlight)
+}
+
+// cancelAll cancels the context of every in-flight request and returns how
+// many there were.
+func (d *requestDrainer) cancelAll() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, cancel := range d.inFlight {
+		cancel()
+	}
+	return len(d.inFlight)
+}
+
+// drainingMiddleware tracks MCP requests for Stop and rejects new ones with
+// 503 once draining has started. Only POSTed JSON-RPC messages are tracked: a
+// GET is a long-lived SSE stream that is closed by the HTTP server shutdown,
+// and a DELETE terminates a session, which a client may still need to do
+// while the server drains.
+func (s *Server) drainingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, done, ok := s.drain.track(r.Context())
+		if !ok {
+			// Close the connection so the client reconnects, through a load
+			// balancer, to an instance that is not shutting down.
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			if _, err := w.Write(shuttingDownBody); err != nil {
+				slog.Error("failed to write shutting-down response", "error", err)
+			}
+			return
+		}
+		defer done()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// drainRequests stops admitting MCP requests and waits up to the configured
+// grace period for in-flight ones to complete, cancelling any still running
+// when it expires or ctx is done. Progress is sent to the status reporter.
+func (s *Server) drainRequests(ctx context.Context) {
+	idle := s.drain.startDraining()
+	inFlight := s.drain.count()
+	if inFlight == 0 {
+		return
+	}
+
+	var grace time.Duration
+	if s.config != nil {
+		grace = s.config.ShutdownGracePeriod
+	}
+	grace = shutdownGracePeriod(grace)
+
+	// Reports must still reach the control plane if ctx is what ends the wait.
+	reportCtx := context.WithoutCancel(ctx)
+	slog.Info("draining in-flight requests", "in_flight", inFlight, "grace_period", grace)
+	s.reportDraining(reportCtx, fmt.Sprintf("Draining %d in-flight request(s)", inFlight))
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	ticker := time.NewTicker(drainProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-idle:
+			slog.Info("in-flight requests drained")
+			s.reportDraining(reportCtx, "All in-flight requests completed")
+			return
+		case <-ticker.C:
+			s.reportDraining(reportCtx, fmt.Sprintf("Draining %d in-flight request(s)", s.drain.count()))
+		case <-timer.C:
+			remaining := s.drain.cancelAll()
+			slog.Warn("shutdown grace period expired, cancelling in-flight requests",
+				"remaining", remaining, "grace_period", grace)
+			s.reportDraining(reportCtx, fmt.Sprintf(
+				"Cancelled %d in-flight request(s) after the %s grace period", remaining, grace))
+			return
+		case <-ctx.Done():
+			remaining := s.drain.cancelAll()
+			slog.Warn("shutdown context done, cancelling in-flight requests", "remaining", remaining)
+			s.reportDraining(reportCtx, fmt.Sprintf("Cancelled %d in-flight request(s)", remaining))
+			return
+		}
+	}
+}
+
+// reportDraining sends the current status with message to the status reporter,
+// if one is configured.
+func (s *Server) reportDraining(ctx context.Context, message string) {
+	if s.statusReporter == nil {
+		return
+	}
+	status := s.buildStatus()
+	status.Message = message
+	status.Timestamp = time.Now()
+	if err := s.statusReporter.ReportStatus(ctx, status); err != nil {
+		slog.Error("failed to report draining status", "error", err)
+	}
+}
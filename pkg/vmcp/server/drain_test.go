@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startDrainTestServer serves a handler behind drainingMiddleware. The handler
+// signals started when a request arrives, then blocks until release is closed
+// or the request context is cancelled, reporting which happened via the
+// status code.
+func startDrainTestServer(t *testing.T, s *Server) (url string, started chan struct{}, release chan struct{}) {
+	t.Helper()
+
+	started = make(chan struct{}, 1)
+	release = make(chan struct{})
+	ts := httptest.NewServer(s.drainingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+	})))
+	t.Cleanup(ts.Close)
+	return ts.URL, started, release
+}
+
+func postMCPRequest(url string) (*http.Response, error) {
+	//nolint:gosec // G107: test server URL
+	return http.Post(url, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call"}`))
+}
+
+func TestStop_DrainsInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	reporter := &mockReporter{}
+	s := &Server{config: &Config{ShutdownGracePeriod: 5 * time.Second}, statusReporter: reporter}
+	url, started, release := startDrainTestServer(t, s)
+
+	slow := make(chan int, 1)
+	go func() {
+		resp, err := postMCPRequest(url)
+		if err != nil {
+			slow <- 0
+			return
+		}
+		resp.Body.Close()
+		slow <- resp.StatusCode
+	}()
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop(context.Background()) }()
+	require.Eventually(t, s.drain.isDraining, time.Second, 5*time.Millisecond)
+
+	// New requests are rejected while the slow one drains.
+	resp, err := postMCPRequest(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	readiness := httptest.NewRecorder()
+	s.handleReadiness(readiness, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, readiness.Code)
+
+	select {
+	case err := <-stopped:
+		t.Fatalf("Stop returned before the in-flight request completed: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-slow, "the in-flight request must complete within the grace period")
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return after the in-flight request completed")
+	}
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	assert.GreaterOrEqual(t, reporter.callCount, 2, "draining start and completion must be reported")
+	assert.Equal(t, "All in-flight requests completed", reporter.lastStatus.Message)
+}
+
+func TestStop_CancelsRequestsAfterGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	reporter := &mockReporter{}
+	s := &Server{config: &Config{ShutdownGracePeriod: 50 * time.Millisecond}, statusReporter: reporter}
+	url, started, _ := startDrainTestServer(t, s)
+
+	slow := make(chan int, 1)
+	go func() {
+		resp, err := postMCPRequest(url)
+		if err != nil {
+			slow <- 0
+			return
+		}
+		resp.Body.Close()
+		slow <- resp.StatusCode
+	}()
+	<-started
+
+	require.NoError(t, s.Stop(context.Background()))
+	assert.Equal(t, http.StatusGatewayTimeout, <-slow, "a request outliving the grace period must be cancelled")
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	assert.Contains(t, reporter.lastStatus.Message, "Cancelled 1 in-flight request(s)")
+}
+
+func TestDrainingMiddleware_UntrackedMethods(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{}
+	s.drain.startDraining()
+	handler := s.drainingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// SSE streams and session termination are not gated by draining.
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/mcp", nil))
+		assert.Equal(t, http.StatusOK, rec.Code, method)
+	}
+}
+
+func TestStop_NoInFlightRequestsDoesNotReport(t *testing.T) {
+	t.Parallel()
+
+	reporter := &mockReporter{}
+	s := &Server{statusReporter: reporter}
+	require.NoError(t, s.Stop(context.Background()))
+	assert.Zero(t, reporter.getCallCount())
+}
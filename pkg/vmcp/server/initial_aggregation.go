@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/health"
+)
+
+// initialAggregationTimeout bounds the capability aggregation a readiness
+// probe runs. It matches the cache sync wait, and stays within the probe
+// timeout the operator configures.
+const initialAggregationTimeout = 5 * time.Second
+
+// initialAggregationPending reports whether readiness still waits for the
+// first successful capability aggregation, running that aggregation if no
+// other probe is running it already. A server without backends has nothing to
+// aggregate and is never pending.
+//
+// The aggregation runs without a user identity and is marked as a health check,
+// like the health monitor's probes: it only proves that a routing table can be
+// built, and identity-bound outgoing auth strategies (token exchange, upstream
+// injection, ...) skip authentication for health checks instead of failing on
+// the missing identity.
+func (s *Server) initialAggregationPending(ctx context.Context) bool {
+	if s.core == nil || s.initialAggregationDone.Load() {
+		return false
+	}
+	if s.backendRegistry == nil || s.backendRegistry.Count() == 0 {
+		return false
+	}
+
+	// A concurrent probe is aggregating; report its outcome on the next probe.
+	if !s.initialAggregationMu.TryLock() {
+		return true
+	}
+	defer s.initialAggregationMu.Unlock()
+	if s.initialAggregationDone.Load() {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(health.WithHealthCheckMarker(ctx), initialAggregationTimeout)
+	defer cancel()
+	if _, err := s.core.Discover(ctx, nil); err != nil {
+		slog.Warn("initial capability aggregation failed, server not ready", "error", err)
+		return true
+	}
+
+	s.initialAggregationDone.Store(true)
+	slog.Info("initial capability aggregation completed, server ready")
+	return false
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mcpmcp "github.com/stacklok/toolhive-core/mcpcompat/mcp"
+	mcpserver "github.com/stacklok/toolhive-core/mcpcompat/server"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/aggregator"
+	vmcpauth "github.com/stacklok/toolhive/pkg/vmcp/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp/auth/strategies"
+	authtypes "github.com/stacklok/toolhive/pkg/vmcp/auth/types"
+	vmcpclient "github.com/stacklok/toolhive/pkg/vmcp/client"
+	"github.com/stacklok/toolhive/pkg/vmcp/core"
+	"github.com/stacklok/toolhive/pkg/vmcp/router"
+	vmcpsession "github.com/stacklok/toolhive/pkg/vmcp/session"
+)
+
+// aggregatingVMCP is a stubVMCP whose Discover fails until aggregate is set,
+// like a core whose backends are not reachable yet.
+type aggregatingVMCP struct {
+	stubVMCP
+	aggregate atomic.Bool
+	calls     atomic.Int32
+}
+
+func (v *aggregatingVMCP) Discover(context.Context, *auth.Identity) (core.DiscoverCapabilities, error) {
+	v.calls.Add(1)
+	if !v.aggregate.Load() {
+		return core.DiscoverCapabilities{}, errors.New("no backends returned capabilities")
+	}
+	return core.DiscoverCapabilities{HasTools: true}, nil
+}
+
+// readinessOf returns the status code and body of a /readyz request to srv.
+func readinessOf(t *testing.T, srv *Server) (int, map[string]string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.handleReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	return rec.Code, body
+}
+
+func TestReadiness_WaitsForInitialAggregation(t *testing.T) {
+	t.Parallel()
+
+	v := &aggregatingVMCP{}
+	cfg := testMinimalServeConfig()
+	cfg.BackendRegistry = vmcp.NewImmutableRegistry([]vmcp.Backend{
+		{ID: "backend-1", Name: "backend-1", BaseURL: "http://backend-1:8080"},
+	})
+	srv, err := Serve(context.Background(), v, cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	select {
+	case <-srv.Ready():
+	case err := <-errCh:
+		t.Fatalf("Server failed to start: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not become ready within 5s")
+	}
+
+	// The aggregation fails: the server is live but not ready.
+	resp, err := http.Get("http://" + srv.Address() + "/readyz")
+	require.NoError(t, err)
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, map[string]string{
+		"status": "not_ready",
+		"mode":   "static",
+		"reason": "initial_aggregation_pending",
+	}, body)
+
+	resp, err = http.Get("http://" + srv.Address() + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "liveness does not wait for aggregation")
+
+	// The next probe aggregates successfully and readiness flips.
+	v.aggregate.Store(true)
+	resp, err = http.Get("http://" + srv.Address() + "/readyz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var ready map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ready))
+	assert.Equal(t, map[string]string{"status": "ready", "mode": "static"}, ready)
+
+	// Readiness sticks: later probes do not aggregate again.
+	calls := v.calls.Load()
+	code, _ := readinessOf(t, srv)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, calls, v.calls.Load())
+}
+
+func TestReadiness_NoBackendsIsNotPending(t *testing.T) {
+	t.Parallel()
+
+	// No backends: there is nothing to aggregate.
+	v := &aggregatingVMCP{}
+	srv, err := Serve(context.Background(), v, testMinimalServeConfig())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	code, body := readinessOf(t, srv)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ready", body["status"])
+	assert.Zero(t, v.calls.Load())
+}
+
+func TestReadiness_DynamicModeWaitsForInitialAggregation(t *testing.T) {
+	t.Parallel()
+
+	v := &aggregatingVMCP{}
+	srv := &Server{
+		config:          &Config{Watcher: &stubWatcher{}},
+		core:            v,
+		backendRegistry: vmcp.NewImmutableRegistry([]vmcp.Backend{{ID: "backend-1"}}),
+	}
+
+	code, body := readinessOf(t, srv)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "initial_aggregation_pending", body["reason"])
+	assert.Equal(t, "dynamic", body["mode"])
+
+	v.aggregate.Store(true)
+	code, body = readinessOf(t, srv)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"status": "ready", "mode": "dynamic"}, body)
+}
+
+// TestReadiness_IdentityBoundOutgoingAuth covers a backend whose outgoing auth
+// needs the caller's identity. The readiness aggregation has no identity, so it
+// must run as a health check for the strategy to skip authentication rather
+// than fail every backend.
+func TestReadiness_IdentityBoundOutgoingAuth(t *testing.T) {
+	t.Parallel()
+
+	var authorization atomic.Value
+	backend := vmcp.Backend{
+		ID:            "backend-1",
+		Name:          "backend-1",
+		BaseURL:       startAuthRecordingBackend(t, &authorization),
+		TransportType: "streamable-http",
+		AuthConfig: &authtypes.BackendAuthStrategy{
+			Type:           authtypes.StrategyTypeUpstreamInject,
+			UpstreamInject: &authtypes.UpstreamInjectConfig{ProviderName: "github"},
+		},
+	}
+
+	authReg := vmcpauth.NewDefaultOutgoingAuthRegistry()
+	require.NoError(t, authReg.RegisterStrategy(
+		authtypes.StrategyTypeUpstreamInject,
+		strategies.NewUpstreamInjectStrategy(),
+	))
+	backendClient, err := vmcpclient.NewHTTPBackendClient(authReg)
+	require.NoError(t, err)
+	agg, err := aggregator.NewDefaultAggregator(backendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil, nil)
+	require.NoError(t, err)
+
+	srv, err := New(
+		t.Context(),
+		&Config{
+			Host:           "127.0.0.1",
+			SessionTTL:     5 * time.Minute,
+			SessionFactory: vmcpsession.NewSessionFactory(authReg),
+			Aggregator:     agg,
+		},
+		router.NewSessionRouter(&vmcp.RoutingTable{}),
+		backendClient,
+		vmcp.NewImmutableRegistry([]vmcp.Backend{backend}),
+		nil,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	code, body := readinessOf(t, srv)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"status": "ready", "mode": "static"}, body)
+	assert.Equal(t, "", authorization.Load(), "health checks send no user credentials")
+}
+
+// startAuthRecordingBackend starts an MCP backend with one tool that stores the
+// Authorization header of every request it receives. Returns the /mcp URL.
+func startAuthRecordingBackend(t *testing.T, authorization *atomic.Value) string {
+	t.Helper()
+
+	mcpSrv := mcpserver.NewMCPServer("auth-backend", "1.0.0")
+	mcpSrv.AddTool(
+		mcpmcp.NewTool("echo", mcpmcp.WithDescription("Echoes the input back")),
+		func(context.Context, mcpmcp.CallToolRequest) (*mcpmcp.CallToolResult, error) {
+			return mcpmcp.NewToolResultText("ok"), nil
+		},
+	)
+	streamable := mcpserver.NewStreamableHTTPServer(mcpSrv)
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		streamable.ServeHTTP(w, r)
+	}))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts.URL + "/mcp"
+}
@@ -116,12 +116,14 @@ func TestIntegration_AggregatorToRouterToServer(t *testing.T) {
 
 	// Step 2: Create aggregator with prefix conflict resolver
 	conflictResolver := aggregator.NewPrefixConflictResolver("{workload}_")
-	agg := aggregator.NewDefaultAggregator(
+	agg, err := aggregator.NewDefaultAggregator(
 		mockBackendClient,
 		conflictResolver,
 		nil, // no tool configs
 		nil, // no tracer provider in tests
+		nil, // no meter provider in tests
 	)
+	require.NoError(t, err)
 
 	// Step 3: Run aggregation on mock backends
 	backends := []vmcp.Backend{
@@ -271,7 +273,8 @@ func TestIntegration_ConflictResolutionStrategies(t *testing.T) {
 			Times(2)
 
 		resolver := aggregator.NewPrefixConflictResolver("{workload}_")
-		agg := aggregator.NewDefaultAggregator(mockBackendClient, resolver, nil, nil)
+		agg, err := aggregator.NewDefaultAggregator(mockBackendClient, resolver, nil, nil, nil)
+		require.NoError(t, err)
 
 		result, err := agg.AggregateCapabilities(ctx, createBackendsWithConflicts())
 		require.NoError(t, err)
@@ -309,7 +312,8 @@ func TestIntegration_ConflictResolutionStrategies(t *testing.T) {
 
 		resolver, err := aggregator.NewPriorityConflictResolver([]string{"backend1", "backend2"})
 		require.NoError(t, err)
-		agg := aggregator.NewDefaultAggregator(mockBackendClient, resolver, nil, nil)
+		agg, err := aggregator.NewDefaultAggregator(mockBackendClient, resolver, nil, nil, nil)
+		require.NoError(t, err)
 
 		result, err := agg.AggregateCapabilities(ctx, createBackendsWithConflicts())
 		require.NoError(t, err)
@@ -495,8 +499,9 @@ func TestIntegration_AuditLogging(t *testing.T) {
 	// The core sources the advertised set by aggregating over mockBackendClient with the
 	// same prefix resolver the legacy discovery path used, so tools/call and resources/read
 	// route through the core and are audit-logged with the prefixed names.
-	auditAgg := aggregator.NewDefaultAggregator(
-		mockBackendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil)
+	auditAgg, err := aggregator.NewDefaultAggregator(
+		mockBackendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil, nil)
+	require.NoError(t, err)
 
 	srv, err := server.New(ctx, &server.Config{
 		Host:           "127.0.0.1",
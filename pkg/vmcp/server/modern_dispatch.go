@@ -14,6 +14,7 @@ import (
 	"github.com/stacklok/toolhive/pkg/auth"
 	mcpparser "github.com/stacklok/toolhive/pkg/mcp"
 	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/conversion"
 )
 
 // Standard JSON-RPC 2.0 reserved error codes (spec-fixed, never change). Kept
@@ -222,7 +223,7 @@ func (s *Server) dispatchModernToolCall(
 	}
 	result, err := s.core.CallTool(ctx, identity, parsed.ResourceID, parsed.Arguments, parsed.Meta)
 	if err != nil {
-		writeModernDispatchError(w, parsed.ID, vmcp.DenyMessageToolCall, err)
+		writeModernDispatchError(ctx, w, parsed.ID, vmcp.DenyMessageToolCall, err)
 		return
 	}
 	// Label the audit backend on the success path only. The stateless dispatcher
@@ -251,7 +252,7 @@ func (s *Server) dispatchModernResourceRead(
 	}
 	result, err := s.core.ReadResource(ctx, identity, parsed.ResourceID)
 	if err != nil {
-		writeModernDispatchError(w, parsed.ID, vmcp.DenyMessageResourceRead, err)
+		writeModernDispatchError(ctx, w, parsed.ID, vmcp.DenyMessageResourceRead, err)
 		return
 	}
 	if result.BackendID != "" {
@@ -283,7 +284,7 @@ func (s *Server) dispatchModernPromptGet(
 	}
 	result, err := s.core.GetPrompt(ctx, identity, parsed.ResourceID, parsed.Arguments)
 	if err != nil {
-		writeModernDispatchError(w, parsed.ID, vmcp.DenyMessagePromptGet, err)
+		writeModernDispatchError(ctx, w, parsed.ID, vmcp.DenyMessagePromptGet, err)
 		return
 	}
 	if result.BackendID != "" {
@@ -352,7 +353,7 @@ func (s *Server) dispatchModernComplete(
 
 	result, err := s.core.Complete(ctx, identity, ref, params.Argument.Name, params.Argument.Value, contextArgs)
 	if err != nil {
-		writeModernDispatchError(w, parsed.ID, completionDenyMessage(ref.Type), err)
+		writeModernDispatchError(ctx, w, parsed.ID, completionDenyMessage(ref.Type), err)
 		return
 	}
 	writeModernResult(w, parsed.ID, newModernComplete(result, s.config.Name, s.config.Version))
@@ -431,19 +432,17 @@ func writeModernListError(ctx context.Context, w http.ResponseWriter, id any, me
 // (TOCTOU) can have Check* allow and the call itself deny. That denial MUST
 // still surface as 403 + denyMsg -- the same as the pre-dispatch gate -- so
 // the audit middleware logs it as "denied" rather than "failure"; it is
-// therefore tested FIRST, before falling through to the generic internal
-// error.
+// therefore tested FIRST, before the domain error mapping.
 //
-// The -32603 message reuses err.Error() verbatim. This matches the SDK path's
-// existing posture rather than inventing a new one: conversion.ErrorToToolResult's
-// generic branch, and the resources/read/prompts/get Serve handlers
-// (serve_handlers.go), already surface the raw error text for a non-coded,
-// non-authz error. Re-sanitizing here would just diverge from what the SDK
-// path already exposes for the identical failure.
-func writeModernDispatchError(w http.ResponseWriter, id any, denyMsg string, err error) {
+// Every other error goes through conversion.ErrorToJSONRPC, the central
+// mapping of vMCP domain errors to JSON-RPC codes: a not-found or invalid
+// input error keeps its text, while backend, timeout and internal errors are
+// logged server-side and returned with a generic message.
+func writeModernDispatchError(ctx context.Context, w http.ResponseWriter, id any, denyMsg string, err error) {
 	if errors.Is(err, vmcp.ErrAuthorizationFailed) {
 		writeModernDenied(w, id, denyMsg)
 		return
 	}
-	writeModernError(w, id, jsonRPCCodeInternalError, err.Error())
+	mapped := conversion.ErrorToJSONRPC(ctx, err, denyMsg)
+	writeModernError(w, id, mapped.Code, mapped.Message)
 }
@@ -668,6 +668,31 @@ func TestDispatchModern_AuthzGate(t *testing.T) {
 			assert.Equal(t, float64(jsonRPCCodeInternalError), errObj["code"])
 		})
 
+		t.Run(c.method+"/not-found error at dispatch time maps to -32602", func(t *testing.T) {
+			t.Parallel()
+			notFound := fmt.Errorf("%w: %q", vmcp.ErrNotFound, c.resourceID)
+			fc := &modernFakeCore{callToolErr: notFound, readResourceErr: notFound, getPromptErr: notFound}
+
+			rec, body := dispatchModernTest(t.Context(), t, fc, true, parsed)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			errObj, ok := body["error"].(map[string]any)
+			require.True(t, ok)
+			assert.Equal(t, float64(jsonRPCCodeInvalidParams), errObj["code"])
+			assert.Equal(t, notFound.Error(), errObj["message"])
+		})
+
+		t.Run(c.method+"/internal error message is not leaked", func(t *testing.T) {
+			t.Parallel()
+			fc := &modernFakeCore{callToolErr: infra, readResourceErr: infra, getPromptErr: infra}
+
+			_, body := dispatchModernTest(t.Context(), t, fc, true, parsed)
+
+			errObj, ok := body["error"].(map[string]any)
+			require.True(t, ok)
+			assert.Equal(t, "internal error", errObj["message"])
+		})
+
 		t.Run(c.method+"/authz disabled skips Check* and dispatches", func(t *testing.T) {
 			t.Parallel()
 			// Even a would-be-denying Check* must never run when the gate is off.
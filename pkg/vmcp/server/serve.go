@@ -16,6 +16,8 @@ import (
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	transportsession "github.com/stacklok/toolhive/pkg/transport/session"
 	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 	vmcpconfig "github.com/stacklok/toolhive/pkg/vmcp/config"
 	"github.com/stacklok/toolhive/pkg/vmcp/core"
 	"github.com/stacklok/toolhive/pkg/vmcp/server/sessionmanager"
@@ -92,6 +94,14 @@ type ServerConfig struct {
 	// If zero, the default reporting interval is used.
 	StatusReportingInterval time.Duration
 
+	// ShutdownGracePeriod is how long Stop waits for in-flight MCP requests to
+	// finish before cancelling them. If zero, the default grace period is used.
+	ShutdownGracePeriod time.Duration
+
+	// Warmup is the optional backend warmup configuration. When set, /readyz
+	// reports not ready until enough backends pass an initial health check.
+	Warmup *WarmupConfig
+
 	// StatusReporter enables the vMCP runtime to report operational status.
 	// If nil, status reporting is disabled.
 	StatusReporter vmcpstatus.Reporter
@@ -100,6 +110,15 @@ type ServerConfig struct {
 	// the /readyz endpoint to gate readiness on cache sync.
 	Watcher Watcher
 
+	// TokenCache is the optional cache of exchanged backend tokens, exposed to
+	// operators through the /api/admin/tokens admin API.
+	TokenCache cache.TokenCache
+
+	// WorkflowEvents is the optional broker of composite-tool workflow step
+	// events, exposed to operators through the /api/admin/workflows admin API.
+	// Pass the broker given to the core (core.Config.WorkflowEvents).
+	WorkflowEvents *composer.WorkflowEventBroker
+
 	// BackendRegistry enumerates the configured backends. It is a shared
 	// collaborator: the core (core.Config.BackendRegistry) consumes it for
 	// capability aggregation, and the Serve session layer consumes it here — when
@@ -163,7 +182,7 @@ type ServerConfig struct {
 // session manager, the session data storage backend, and the vMCP session manager).
 // It does NOT build the route mux or the HTTP lifecycle here — those remain in the
 // carried-forward (*Server).Handler/Start/Stop, which register the unauthenticated
-// routes (/health, /ping, /readyz, /status, /api/backends/health, the metrics and
+// routes (/health, /healthz, /ping, /readyz, /status, /api/backends/health, the metrics and
 // .well-known endpoints, and any embedded auth-server routes) when Serve's *Server
 // is served.
 //
@@ -357,6 +376,23 @@ func Serve(ctx context.Context, v core.VMCP, cfg *ServerConfig) (*Server, error)
 		return nil
 	})
 
+	// Forward the capability changes the core's health monitor observes during
+	// its periodic backend checks to every connected session, debounced so a
+	// burst of changes produces one resync per capability kind.
+	if reporter := v.BackendHealth(); reporter != nil {
+		notifier := newCapabilityChangeNotifier(resyncCtx, capabilityChangeDebounce,
+			func(ctx context.Context, sessionID string) bool {
+				_, ok := vmcpSessMgr.GetMultiSession(ctx, sessionID)
+				return ok
+			})
+		reporter.OnCapabilitiesChanged(notifier.capabilitiesChanged)
+		srv.capabilityNotifier = notifier
+		srv.shutdownFuncs = append(srv.shutdownFuncs, func(context.Context) error {
+			notifier.stop()
+			return nil
+		})
+	}
+
 	if optimizerCleanup != nil {
 		srv.shutdownFuncs = append(srv.shutdownFuncs, optimizerCleanup)
 	}
@@ -427,7 +463,11 @@ func buildServeConfig(cfg *ServerConfig) *Config {
 		TelemetryProvider:       cfg.TelemetryProvider,
 		AuditConfig:             cfg.AuditConfig,
 		StatusReportingInterval: cfg.StatusReportingInterval,
+		ShutdownGracePeriod:     cfg.ShutdownGracePeriod,
+		Warmup:                  cfg.Warmup,
 		Watcher:                 cfg.Watcher,
+		TokenCache:              cfg.TokenCache,
+		WorkflowEvents:          cfg.WorkflowEvents,
 		SessionStorage:          cfg.SessionStorage,
 	}
 }
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/health"
+	vmcpsession "github.com/stacklok/toolhive/pkg/vmcp/session"
+)
+
+// This file forwards capability changes the health monitor observes during its
+// periodic backend checks to every connected session. It complements the
+// backend-originated list_changed path in serve_list_changed.go: a backend
+// that never emits notifications/*/list_changed (or whose notification is
+// lost) still has its changes reach clients within one health check interval.
+
+// capabilityChangeDebounce is how long capability changes are collected before
+// connected sessions are resynced. Changes reported by several backends, or by
+// one backend over consecutive checks, within the window produce a single
+// resync per capability kind and session.
+const capabilityChangeDebounce = 2 * time.Second
+
+// capabilityPruneMinimum is the number of registered sessions below which the
+// notifier never sweeps terminated sessions on registration.
+const capabilityPruneMinimum = 64
+
+// capabilityChangeNotifier fans health-monitor capability changes out to the
+// list_changed sinks of the sessions registered on this server. Each sink
+// hands the work to the session's coalescing resync workers (see
+// buildListChangedSink), which invalidate the capability cache, re-derive the
+// session's advertised set, and let the go-sdk emit the list_changed
+// notification to the client.
+//
+// capabilitiesChanged runs on a backend's health check goroutine, so it only
+// records the change and arms the debounce timer; the fan-out runs on the
+// timer goroutine.
+type capabilityChangeNotifier struct {
+	// window is the debounce window; see capabilityChangeDebounce.
+	window time.Duration
+	// baseCtx is the server-lifetime context passed to the sinks. No fan-out
+	// starts once it is cancelled.
+	baseCtx context.Context
+	// isLive reports whether a registered session still exists. Sessions that
+	// do not are dropped instead of being resynced.
+	isLive func(ctx context.Context, sessionID string) bool
+
+	mu    sync.Mutex
+	sinks map[string]vmcpsession.ListChangedSink
+	// pruneAt is the registered-session count at which register sweeps
+	// terminated sessions, so sessions that end while no capability changes
+	// occur cannot accumulate without bound.
+	pruneAt  int
+	kinds    map[vmcpsession.ChangeKind]struct{}
+	backends map[string]struct{}
+	timer    *time.Timer
+	stopped  bool
+}
+
+// newCapabilityChangeNotifier returns a notifier that debounces changes over
+// window and resyncs the sessions for which isLive reports true.
+func newCapabilityChangeNotifier(
+	baseCtx context.Context, window time.Duration, isLive func(ctx context.Context, sessionID string) bool,
+) *capabilityChangeNotifier {
+	return &capabilityChangeNotifier{
+		window:   window,
+		baseCtx:  baseCtx,
+		isLive:   isLive,
+		sinks:    make(map[string]vmcpsession.ListChangedSink),
+		pruneAt:  capabilityPruneMinimum,
+		kinds:    make(map[vmcpsession.ChangeKind]struct{}),
+		backends: make(map[string]struct{}),
+	}
+}
+
+// register adds a session's list_changed sink to the set resynced on
+// capability changes.
+func (n *capabilityChangeNotifier) register(sessionID string, sink vmcpsession.ListChangedSink) {
+	n.mu.Lock()
+	n.sinks[sessionID] = sink
+	shouldPrune := len(n.sinks) >= n.pruneAt
+	n.mu.Unlock()
+
+	if shouldPrune {
+		live := n.prune()
+		n.mu.Lock()
+		n.pruneAt = max(2*len(live), capabilityPruneMinimum)
+		n.mu.Unlock()
+	}
+}
+
+// prune drops the sinks of sessions that no longer exist and returns the
+// sinks of the live ones. Liveness is checked without holding n.mu, because it
+// may reach the session storage and capabilitiesChanged must never wait on it.
+func (n *capabilityChangeNotifier) prune() map[string]vmcpsession.ListChangedSink {
+	n.mu.Lock()
+	sinks := maps.Clone(n.sinks)
+	n.mu.Unlock()
+
+	for sessionID := range sinks {
+		if n.isLive(n.baseCtx, sessionID) {
+			continue
+		}
+		delete(sinks, sessionID)
+		n.mu.Lock()
+		delete(n.sinks, sessionID)
+		n.mu.Unlock()
+	}
+	return sinks
+}
+
+// capabilitiesChanged is the health.CapabilityChangeFunc registered with the
+// backend health monitor. It is non-blocking: it records the changed kinds
+// and, if no fan-out is pending, schedules one after the debounce window.
+func (n *capabilityChangeNotifier) capabilitiesChanged(change health.CapabilityChange) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped {
+		return
+	}
+
+	if change.Tools {
+		n.kinds[vmcpsession.KindTools] = struct{}{}
+	}
+	if change.Resources {
+		n.kinds[vmcpsession.KindResources] = struct{}{}
+	}
+	if change.Prompts {
+		n.kinds[vmcpsession.KindPrompts] = struct{}{}
+	}
+	if len(n.kinds) == 0 {
+		return
+	}
+	n.backends[change.BackendID] = struct{}{}
+
+	// The timer is armed once per window rather than reset on every change, so
+	// a backend whose capabilities keep changing cannot postpone the fan-out
+	// indefinitely.
+	if n.timer == nil {
+		n.timer = time.AfterFunc(n.window, n.flush)
+	}
+}
+
+// flush resyncs every live registered session for the capability kinds
+// changed since the previous flush, and drops the sinks of terminated sessions.
+func (n *capabilityChangeNotifier) flush() {
+	n.mu.Lock()
+	n.timer = nil
+	if n.stopped || n.baseCtx.Err() != nil {
+		n.mu.Unlock()
+		return
+	}
+	kinds := n.kinds
+	backends := strings.Join(slices.Sorted(maps.Keys(n.backends)), ",")
+	n.kinds = make(map[vmcpsession.ChangeKind]struct{})
+	n.backends = make(map[string]struct{})
+	n.mu.Unlock()
+
+	sinks := n.prune()
+
+	slog.Debug("backend capabilities changed; resyncing connected sessions",
+		"backends", backends, "sessions", len(sinks))
+
+	// The sinks only trigger the session's resync workers, so the fan-out does
+	// not wait on backend calls.
+	for _, kind := range []vmcpsession.ChangeKind{
+		vmcpsession.KindTools, vmcpsession.KindResources, vmcpsession.KindPrompts,
+	} {
+		if _, ok := kinds[kind]; !ok {
+			continue
+		}
+		for _, sink := range sinks {
+			sink(n.baseCtx, backends, kind)
+		}
+	}
+}
+
+// stop cancels any pending fan-out and ignores later changes.
+func (n *capabilityChangeNotifier) stop() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stopped = true
+	if n.timer != nil {
+		n.timer.Stop()
+		n.timer = nil
+	}
+}
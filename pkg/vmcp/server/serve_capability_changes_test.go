@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp/health"
+	vmcpsession "github.com/stacklok/toolhive/pkg/vmcp/session"
+)
+
+// recordingSink returns a ListChangedSink that records every kind it receives.
+func recordingSink(mu *sync.Mutex, got *[]vmcpsession.ChangeKind) vmcpsession.ListChangedSink {
+	return func(_ context.Context, _ string, kind vmcpsession.ChangeKind) {
+		mu.Lock()
+		defer mu.Unlock()
+		*got = append(*got, kind)
+	}
+}
+
+func TestCapabilityChangeNotifier(t *testing.T) {
+	t.Parallel()
+
+	live := map[string]bool{"live": true}
+	isLive := func(_ context.Context, sessionID string) bool { return live[sessionID] }
+
+	t.Run("changes are debounced into one resync per kind", func(t *testing.T) {
+		t.Parallel()
+
+		notifier := newCapabilityChangeNotifier(context.Background(), 20*time.Millisecond, isLive)
+		var (
+			mu             sync.Mutex
+			liveKinds      []vmcpsession.ChangeKind
+			terminatedKind []vmcpsession.ChangeKind
+		)
+		notifier.register("live", recordingSink(&mu, &liveKinds))
+		notifier.register("terminated", recordingSink(&mu, &terminatedKind))
+
+		notifier.capabilitiesChanged(health.CapabilityChange{BackendID: "b1", Tools: true})
+		notifier.capabilitiesChanged(health.CapabilityChange{BackendID: "b2", Tools: true, Prompts: true})
+		notifier.capabilitiesChanged(health.CapabilityChange{BackendID: "b1", Tools: true})
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(liveKinds) == 2
+		}, time.Second, 5*time.Millisecond)
+
+		// Give a wrongly re-armed timer the chance to fire again.
+		time.Sleep(60 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []vmcpsession.ChangeKind{vmcpsession.KindTools, vmcpsession.KindPrompts}, liveKinds)
+		assert.Empty(t, terminatedKind)
+
+		notifier.mu.Lock()
+		defer notifier.mu.Unlock()
+		assert.NotContains(t, notifier.sinks, "terminated", "terminated sessions are pruned")
+	})
+
+	t.Run("no resync without a change", func(t *testing.T) {
+		t.Parallel()
+
+		notifier := newCapabilityChangeNotifier(context.Background(), time.Millisecond, isLive)
+		var (
+			mu    sync.Mutex
+			kinds []vmcpsession.ChangeKind
+		)
+		notifier.register("live", recordingSink(&mu, &kinds))
+
+		notifier.capabilitiesChanged(health.CapabilityChange{BackendID: "b1"})
+
+		time.Sleep(30 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Empty(t, kinds)
+	})
+
+	t.Run("stop cancels a pending resync", func(t *testing.T) {
+		t.Parallel()
+
+		notifier := newCapabilityChangeNotifier(context.Background(), 20*time.Millisecond, isLive)
+		var (
+			mu    sync.Mutex
+			kinds []vmcpsession.ChangeKind
+		)
+		notifier.register("live", recordingSink(&mu, &kinds))
+
+		notifier.capabilitiesChanged(health.CapabilityChange{BackendID: "b1", Resources: true})
+		notifier.stop()
+		notifier.capabilitiesChanged(health.CapabilityChange{BackendID: "b1", Resources: true})
+
+		time.Sleep(60 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Empty(t, kinds)
+	})
+}
@@ -356,6 +356,10 @@ func (*fakeCore) ListBackends(context.Context, *auth.Identity, bool) ([]vmcp.Bac
 	return nil, nil
 }
 
+func (*fakeCore) RoutingTable(context.Context) (*vmcp.RoutingTable, error) {
+	return &vmcp.RoutingTable{}, nil
+}
+
 func (*fakeCore) LookupBackend(context.Context, *auth.Identity, string) (*vmcp.Backend, error) {
 	return nil, vmcp.ErrNotFound
 }
@@ -19,6 +19,8 @@ import (
 	asrunner "github.com/stacklok/toolhive/pkg/authserver/runner"
 	"github.com/stacklok/toolhive/pkg/telemetry"
 	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
+	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 	vmcpconfig "github.com/stacklok/toolhive/pkg/vmcp/config"
 	"github.com/stacklok/toolhive/pkg/vmcp/core"
 	"github.com/stacklok/toolhive/pkg/vmcp/health"
@@ -31,7 +33,8 @@ import (
 // asserted. Tests that exercise session registration or request handling use the
 // configurable fakeCore (serve_session_test.go) instead.
 type stubVMCP struct {
-	closed bool
+	closed       bool
+	routingTable *vmcp.RoutingTable
 }
 
 var _ core.VMCP = (*stubVMCP)(nil)
@@ -79,6 +82,9 @@ func (*stubVMCP) CheckPromptGet(context.Context, *auth.Identity, string) error
 func (*stubVMCP) ListBackends(context.Context, *auth.Identity, bool) ([]vmcp.Backend, error) {
 	return nil, nil
 }
+func (s *stubVMCP) RoutingTable(context.Context) (*vmcp.RoutingTable, error) {
+	return s.routingTable, nil
+}
 func (*stubVMCP) LookupBackend(context.Context, *auth.Identity, string) (*vmcp.Backend, error) {
 	return nil, nil
 }
@@ -348,6 +354,8 @@ func TestBuildServeConfigMapsSharedFields(t *testing.T) {
 		"RateLimiter":         {}, // consumed by New to wrap the core (rate-limit decorator) before Serve; not a transport field
 		"Aggregator":          {}, // core collaborator: fed to core.New via deriveCoreConfig, not the transport
 		"Authz":               {}, // core collaborator: fed to the core admission seam via deriveCoreConfig
+		"WorkflowLimits":      {}, // core-only: bounds composite tool engines via deriveCoreConfig
+		"WorkflowFailureSink": {}, // core-only: records failed composite workflows via deriveCoreConfig
 	}
 
 	// Every field set to a non-zero value so a dropped mapping surfaces as a zero
@@ -369,13 +377,17 @@ func TestBuildServeConfigMapsSharedFields(t *testing.T) {
 		PassthroughHeaders:      []string{"x-test"},
 		AuthServer:              &asrunner.EmbeddedAuthServer{},
 		StatusReportingInterval: time.Second,
+		ShutdownGracePeriod:     time.Second,
+		Warmup:                  &WarmupConfig{},
 		StatusReporter:          stubServeReporter{},
 		Watcher:                 stubWatcher{},
+		TokenCache:              cache.NewMemoryTokenCache(),
 		BackendRegistry:         vmcp.NewImmutableRegistry([]vmcp.Backend{}),
 		SessionStorage:          &vmcpconfig.SessionStorageConfig{},
 		SessionManagerConfig:    testMinimalSessionManagerConfig(),
 		TelemetryProvider:       &telemetry.Provider{},
 		AuditConfig:             &audit.Config{},
+		WorkflowEvents:          composer.NewWorkflowEventBroker(nil),
 	}
 
 	got := reflect.ValueOf(*buildServeConfig(src))
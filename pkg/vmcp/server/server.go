@@ -19,8 +19,11 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+
 	"github.com/stacklok/toolhive-core/mcpcompat/server"
 	tcredis "github.com/stacklok/toolhive-core/redis"
 	"github.com/stacklok/toolhive/pkg/audit"
@@ -28,6 +31,7 @@ import (
 	asrunner "github.com/stacklok/toolhive/pkg/authserver/runner"
 	"github.com/stacklok/toolhive/pkg/authz"
 	"github.com/stacklok/toolhive/pkg/bodylimit"
+	"github.com/stacklok/toolhive/pkg/correlation"
 	mcpparser "github.com/stacklok/toolhive/pkg/mcp"
 	baseratelimit "github.com/stacklok/toolhive/pkg/ratelimit"
 	"github.com/stacklok/toolhive/pkg/recovery"
@@ -36,6 +40,7 @@ import (
 	transportsession "github.com/stacklok/toolhive/pkg/transport/session"
 	"github.com/stacklok/toolhive/pkg/vmcp"
 	"github.com/stacklok/toolhive/pkg/vmcp/aggregator"
+	"github.com/stacklok/toolhive/pkg/vmcp/cache"
 	"github.com/stacklok/toolhive/pkg/vmcp/codemode"
 	"github.com/stacklok/toolhive/pkg/vmcp/composer"
 	vmcpconfig "github.com/stacklok/toolhive/pkg/vmcp/config"
@@ -72,6 +77,11 @@ const (
 	// defaultShutdownTimeout is the maximum time to wait for graceful shutdown.
 	defaultShutdownTimeout = 10 * time.Second
 
+	// defaultShutdownGracePeriod is how long Stop lets in-flight MCP requests run
+	// before cancelling them. Together with defaultShutdownTimeout it fits inside
+	// the default 30s Kubernetes termination grace period.
+	defaultShutdownGracePeriod = 20 * time.Second
+
 	// defaultHeartbeatInterval sends SSE heartbeat pings on GET connections.
 	// Prevents proxies/load balancers from closing idle SSE connections.
 	defaultHeartbeatInterval = 30 * time.Second
@@ -104,6 +114,15 @@ func heartbeatInterval(d time.Duration) time.Duration {
 	return d
 }
 
+// shutdownGracePeriod returns the configured drain grace period, or the default
+// when d is not positive.
+func shutdownGracePeriod(d time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultShutdownGracePeriod
+	}
+	return d
+}
+
 //go:generate mockgen -destination=mocks/mock_watcher.go -package=mocks -source=server.go Watcher
 
 // Watcher is the interface for Kubernetes backend watcher integration.
@@ -208,16 +227,37 @@ type Config struct {
 	// If nil, health monitoring is disabled.
 	HealthMonitorConfig *health.MonitorConfig
 
+	// Warmup is the optional backend warmup configuration. When set, /readyz
+	// reports not ready until enough backends pass an initial health check or
+	// the warmup times out. If nil, readiness does not wait for backend health.
+	Warmup *WarmupConfig
+
 	// StatusReportingInterval is the interval for reporting status updates.
 	// If zero, defaults to 30 seconds.
 	// Lower values provide faster status updates but increase API server load.
 	StatusReportingInterval time.Duration
 
+	// ShutdownGracePeriod is how long Stop waits for in-flight MCP requests
+	// (tool calls, workflows) to finish before cancelling them.
+	// If zero, defaults to 20 seconds.
+	ShutdownGracePeriod time.Duration
+
 	// Watcher is the optional Kubernetes backend watcher for dynamic mode.
 	// Only set when running in K8s with outgoingAuth.source: discovered.
 	// Used for /readyz endpoint to gate readiness on cache sync.
 	Watcher Watcher
 
+	// TokenCache is the optional cache of exchanged backend tokens. When set
+	// together with AuthMiddleware, Handler exposes the /api/admin/tokens admin
+	// API to list and invalidate cached tokens.
+	TokenCache cache.TokenCache
+
+	// WorkflowEvents is the optional broker of composite-tool workflow step
+	// events. It is shared with the core (core.Config.WorkflowEvents), whose
+	// workflow engines publish to it. When set together with AuthMiddleware,
+	// Handler exposes the /api/admin/workflows admin API to tail running workflows.
+	WorkflowEvents *composer.WorkflowEventBroker
+
 	// OptimizerFactory builds an optimizer from a list of tools.
 	// If not set, the optimizer is disabled.
 	OptimizerFactory func(context.Context, []server.ServerTool) (optimizer.Optimizer, error)
@@ -244,7 +284,7 @@ type Config struct {
 
 	// StatusReporter enables vMCP runtime to report operational status.
 	// In Kubernetes mode: Updates VirtualMCPServer.Status (requires RBAC)
-	// In CLI mode: NoOpReporter (no persistent status)
+	// In CLI mode: LoggingReporter (no persistent status)
 	// If nil, status reporting is disabled.
 	StatusReporter vmcpstatus.Reporter
 
@@ -274,6 +314,14 @@ type Config struct {
 	// from the HTTP middleware. When non-nil, Name must be non-empty (the Cedar resource
 	// entity name).
 	Authz *authz.Config
+
+	// WorkflowLimits bounds the steps and composite nesting depth of composite tool
+	// workflows (core.Config.WorkflowLimits). Zero values select the composer defaults.
+	WorkflowLimits composer.WorkflowLimits
+
+	// WorkflowFailureSink records the composite tool workflows that fail or time
+	// out (core.Config.WorkflowFailureSink). Nil disables failure recording.
+	WorkflowFailureSink composer.FailureSink
 }
 
 // Server is the Virtual MCP Server that aggregates multiple backends.
@@ -340,10 +388,24 @@ type Server struct {
 	ready     chan struct{}
 	readyOnce sync.Once
 
+	// initialAggregationDone is set once the core has aggregated backend
+	// capabilities for the first time. /readyz reports not ready until then.
+	// initialAggregationMu keeps concurrent probes from aggregating at once.
+	initialAggregationDone atomic.Bool
+	initialAggregationMu   sync.Mutex
+
+	// warmup delays readiness until a quorum of backends is healthy. Set by New
+	// when Config.Warmup is configured; nil otherwise.
+	warmup *backendWarmup
+
 	// statusReporter enables vMCP to report operational status to control plane.
 	// Nil if status reporting is disabled.
 	statusReporter vmcpstatus.Reporter
 
+	// drain tracks in-flight MCP requests so Stop can let them finish, and
+	// rejects new ones once shutdown starts.
+	drain requestDrainer
+
 	// shutdownFuncs contains cleanup functions to run during Stop().
 	// Populated during Start() initialization before blocking; no mutex needed
 	// since Stop() is only called after Start()'s select returns.
@@ -356,6 +418,11 @@ type Server struct {
 	// server. Set by Serve; nil for direct-Serve callers that never register a
 	// list_changed sink.
 	resyncBaseCtx context.Context
+
+	// capabilityNotifier resyncs the registered sessions when the backend health
+	// monitor observes a capability change. Set by Serve when health monitoring
+	// is enabled; nil otherwise.
+	capabilityNotifier *capabilityChangeNotifier
 }
 
 // buildSessionDataStorage constructs the DataStorage backend from cfg.
@@ -471,8 +538,16 @@ func New(
 	// Wrap the aggregator in a per-identity caching decorator: the core re-derives the
 	// advertised view on every call, so without this the Serve path re-sweeps every backend's
 	// tools/list per tool call. The cache is keyed on identity + forwarded credentials, so it
-	// never serves one caller's capability view to another.
-	cachedAgg := aggregator.NewCachingAggregator(cfg.Aggregator, capabilityCacheTTL)
+	// never serves one caller's capability view to another. A backend's health changing
+	// prunes the cached view rather than re-sweeping every backend.
+	var cacheMeterProvider metric.MeterProvider
+	if cfg.TelemetryProvider != nil {
+		cacheMeterProvider = cfg.TelemetryProvider.MeterProvider()
+	}
+	cachedAgg, err := aggregator.NewCachingAggregator(cfg.Aggregator, capabilityCacheTTL, cacheMeterProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capability cache: %w", err)
+	}
 
 	coreVMCP, err := core.New(deriveCoreConfig(
 		cfg, cachedAgg, rt, backendClient, backendRegistry, workflowDefs,
@@ -530,6 +605,31 @@ func New(
 	// from, so New — which holds cfg.Authz — sets the flag here (see Server.authzGateEnabled).
 	srv.authzGateEnabled = cfg.Authz != nil
 
+	// The warmup health checks backends through the backend client, which only New
+	// holds, so it is wired here rather than in Serve. Its context is cancelled on
+	// Stop so a warmup still in progress does not outlive the server.
+	if cfg.Warmup != nil {
+		warmupCtx, warmupCancel := context.WithCancel(context.Background())
+		srv.warmup = newBackendWarmup(warmupCtx, cfg.Warmup, backendClient, backendRegistry)
+		srv.shutdownFuncs = append(srv.shutdownFuncs, func(context.Context) error {
+			warmupCancel()
+			return nil
+		})
+	}
+
+	// In dynamic mode backends come and go at runtime; the backend client is told
+	// whenever the registry changes so it can close what it holds for a removed
+	// backend (pooled connections, stdio processes).
+	dynamicReg, isDynamic := backendRegistry.(vmcp.DynamicRegistry)
+	if releaser, ok := backendClient.(vmcp.BackendReleaser); ok && isDynamic {
+		releaseCtx, releaseCancel := context.WithCancel(context.Background())
+		go releaseRemovedBackends(releaseCtx, dynamicReg, releaser, versionPollInterval)
+		srv.shutdownFuncs = append(srv.shutdownFuncs, func(context.Context) error {
+			releaseCancel()
+			return nil
+		})
+	}
+
 	// Bind the elicitation adapter to the SDK server Serve built so composite-workflow
 	// elicitation reaches the same mcp-go server that serves client traffic.
 	elicitAdapter := NewSDKElicitationAdapter(srv.MCPServer())
@@ -585,12 +685,45 @@ func (s *Server) Handler(_ context.Context) (http.Handler, error) {
 
 	// Unauthenticated health endpoints
 	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/healthz", s.handleHealth)
 	mux.HandleFunc("/ping", s.handleHealth)
 	mux.HandleFunc("/readyz", s.handleReadiness)
 	mux.HandleFunc("/status", s.handleStatus)
 	mux.HandleFunc("/api/backends/health", s.handleBackendHealth)
 
-	// Optional Prometheus metrics endpoint (unauthenticated)
+	// Optional token cache admin API. It is never served unauthenticated: the
+	// identity established by AuthMiddleware is checked for AdminScope.
+	if s.config.TokenCache != nil {
+		if s.config.AuthMiddleware != nil {
+			mux.Handle(adminTokensPath, s.config.AuthMiddleware(http.HandlerFunc(s.handleAdminTokens)))
+			slog.Info("token cache admin API enabled", "path", adminTokensPath)
+		} else {
+			slog.Warn("token cache admin API disabled: it requires incoming authentication")
+		}
+	}
+
+	// Read-only routing table dump. It shows every routed capability regardless
+	// of the caller's authorization, so it requires an identity with AdminScope.
+	if s.config.AuthMiddleware != nil {
+		mux.Handle(adminRoutingPath, s.config.AuthMiddleware(http.HandlerFunc(s.handleAdminRouting)))
+	}
+
+	// Optional workflow admin API to tail running composite-tool workflows. Step
+	// inputs and outputs may carry sensitive data, so, like the token cache admin
+	// API, it requires an identity with AdminScope.
+	if s.config.WorkflowEvents != nil {
+		if s.config.AuthMiddleware != nil {
+			workflowsHandler := s.config.AuthMiddleware(http.HandlerFunc(s.handleAdminWorkflows))
+			mux.Handle(adminWorkflowsPath, workflowsHandler)
+			mux.Handle(adminWorkflowsPath+"/", workflowsHandler)
+			slog.Info("workflow admin API enabled", "path", adminWorkflowsPath)
+		} else {
+			slog.Warn("workflow admin API disabled: it requires incoming authentication")
+		}
+	}
+
+	// Optional Prometheus metrics endpoint, outside the MCP auth middleware; it is
+	// protected by basic auth when telemetry.prometheusBasicAuth is configured
 	if s.config.TelemetryProvider != nil {
 		if prometheusHandler := s.config.TelemetryProvider.PrometheusHandler(); prometheusHandler != nil {
 			mux.Handle("/metrics", prometheusHandler)
@@ -619,7 +752,7 @@ func (s *Server) Handler(_ context.Context) (http.Handler, error) {
 
 	// MCP endpoint - apply middleware chain (wrapping order, execution happens in reverse):
 	// Code wraps: auth → rate-limit → audit → MCP-parsing → telemetry → classification
-	// Execution order: recovery → body-limit → header-val → auth →
+	// Execution order: recovery → correlation → body-limit → header-val → auth →
 	//   rate-limit → audit → MCP-parsing → telemetry → classification → handler
 	//
 	// Upstream token refresh failures are detected inside AuthMiddleware itself:
@@ -704,6 +837,15 @@ func (s *Server) Handler(_ context.Context) (http.Handler, error) {
 	// request body and does not affect long-lived SSE response streams.
 	mcpHandler = bodylimit.Middleware(bodylimit.DefaultMaxRequestBodySize)(mcpHandler)
 
+	// Track in-flight requests for graceful draining, and reject new ones with
+	// 503 once Stop starts. Outside auth so a rejected request does no work.
+	mcpHandler = s.drainingMiddleware(mcpHandler)
+
+	// Assign every request a correlation ID (reusing the client's X-Correlation-ID
+	// when present) before any layer that logs, audits, or calls a backend, so
+	// all of them record the same ID.
+	mcpHandler = correlation.Middleware(mcpHandler)
+
 	// Apply recovery middleware as outermost (catches panics from all inner middleware)
 	mcpHandler = recovery.Middleware(mcpHandler)
 	slog.Info("recovery middleware enabled for MCP endpoints")
@@ -762,7 +904,9 @@ func (s *Server) Start(ctx context.Context) error {
 	slog.Info("starting Virtual MCP Server", "address", actualAddr, "endpoint", s.config.EndpointPath)
 	slog.Info("health endpoints available",
 		"health", actualAddr+"/health",
+		"healthz", actualAddr+"/healthz",
 		"ping", actualAddr+"/ping",
+		"readyz", actualAddr+"/readyz",
 		"status", actualAddr+"/status",
 		"backends_health", actualAddr+"/api/backends/health")
 
@@ -828,12 +972,18 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 }
 
-// Stop gracefully stops the Virtual MCP Server.
+// Stop gracefully stops the Virtual MCP Server. It first drains MCP requests:
+// new ones are rejected with 503 while in-flight tool calls and workflows get
+// up to Config.ShutdownGracePeriod to complete, after which they are cancelled.
 func (s *Server) Stop(ctx context.Context) error {
 	slog.Info("stopping Virtual MCP Server")
 
 	var errs []error
 
+	// Let in-flight tool calls and workflows finish (up to the grace period)
+	// before the HTTP server goes away; new MCP requests are rejected from here.
+	s.drainRequests(ctx)
+
 	// Stop HTTP server (this internally closes the listener)
 	if s.httpServer != nil {
 		// Create shutdown context with timeout
@@ -896,7 +1046,7 @@ func (s *Server) Address() string {
 	return fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 }
 
-// handleHealth handles /health and /ping HTTP requests.
+// handleHealth handles /health, /healthz and /ping HTTP requests.
 // Returns 200 OK if the server is running and able to respond.
 //
 // Security Note: This endpoint is unauthenticated and intentionally minimal.
@@ -929,13 +1079,26 @@ func (*Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 // not be marked ready until the manager has populated its cache with current
 // backend information from the MCPGroup.
 //
-// In static mode (CLI or K8s with inline backends), this always returns 200 OK
-// since there's no cache to sync.
+// In static mode (CLI or K8s with inline backends), there's no cache to sync.
+//
+// When a backend warmup is configured, readiness then waits until enough
+// backends pass a health check or the warmup times out. Backends that are not
+// healthy when the warmup completes are listed as degraded_backends.
+//
+// In both modes readiness also waits until the core has aggregated backend
+// capabilities once, so traffic only reaches a server that has a routing
+// table. The probe itself runs that aggregation until it succeeds, and the
+// probe's period paces the retries. A server without backends is not held
+// back. The incoming
+// authenticator needs no check here: Serve's callers build it before the
+// server exists and fail startup if it cannot be configured.
 //
 // Design Pattern:
 // This follows the same readiness gating pattern used by cert-manager and ArgoCD:
-// - /health: Always returns 200 if server is responding (liveness probe)
-// - /readyz: Returns 503 until caches synced, then 200 (readiness probe)
+// - /health, /healthz: Always return 200 if server is responding (liveness probe)
+// - /readyz: Returns 503 until caches synced and capabilities aggregated, then 200 (readiness probe)
+//
+// In both modes /readyz returns 503 once Stop starts draining requests.
 //
 // K8s Configuration:
 //
@@ -947,14 +1110,14 @@ func (*Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 //	  periodSeconds: 5
 //	  timeoutSeconds: 5
 func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
-	// Static mode: always ready (no watcher, no cache to sync)
-	if s.config.Watcher == nil {
+	// Draining: stop receiving traffic while in-flight requests finish.
+	if s.drain.isDraining() {
 		response := map[string]string{
-			"status": "ready",
-			"mode":   "static",
+			"status": "not_ready",
+			"reason": "draining",
 		}
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(http.StatusServiceUnavailable)
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			slog.Error("failed to encode readiness response", "error", err)
 		}
@@ -962,15 +1125,35 @@ func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Dynamic mode: gate readiness on cache sync
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-	defer cancel()
+	mode := "static"
+	if s.config.Watcher != nil {
+		mode = "dynamic"
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		if !s.config.Watcher.WaitForCacheSync(ctx) {
+			// Cache not synced yet - return 503 Service Unavailable
+			response := map[string]string{
+				"status": "not_ready",
+				"mode":   mode,
+				"reason": "cache_sync_pending",
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				slog.Error("failed to encode readiness response", "error", err)
+			}
+			return
+		}
+	}
 
-	if !s.config.Watcher.WaitForCacheSync(ctx) {
-		// Cache not synced yet - return 503 Service Unavailable
+	// Both modes: an optional warmup waits for a quorum of healthy backends.
+	if s.warmup != nil && s.warmup.pending() {
 		response := map[string]string{
 			"status": "not_ready",
-			"mode":   "dynamic",
-			"reason": "cache_sync_pending",
+			"mode":   mode,
+			"reason": "backend_warmup_pending",
 		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -980,10 +1163,31 @@ func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Cache synced - ready to serve requests
+	// Both modes: the core cannot route requests before it has aggregated
+	// backend capabilities once.
+	if s.initialAggregationPending(r.Context()) {
+		response := map[string]string{
+			"status": "not_ready",
+			"mode":   mode,
+			"reason": "initial_aggregation_pending",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			slog.Error("failed to encode readiness response", "error", err)
+		}
+		return
+	}
+
+	// Ready to serve requests
 	response := map[string]string{
 		"status": "ready",
-		"mode":   "dynamic",
+		"mode":   mode,
+	}
+	if s.warmup != nil {
+		if degraded := s.warmup.degradedBackends(); len(degraded) > 0 {
+			response["degraded_backends"] = strings.Join(degraded, ",")
+		}
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -1256,7 +1460,16 @@ func (s *Server) handleSessionRegistrationImpl(ctx context.Context, session serv
 	// handlers that route through the core. CreateSession above still establishes the bound
 	// session record (identity binding, TTL, Validate). The returned error becomes retErr
 	// (named return), so the defer terminates the session on failure.
-	return s.injectCoreSessionCapabilities(ctx, session)
+	if retErr = s.injectCoreSessionCapabilities(ctx, session); retErr != nil {
+		return retErr
+	}
+
+	// Resync this session too when the health monitor observes a backend
+	// capability change (see serve_capability_changes.go).
+	if s.capabilityNotifier != nil {
+		s.capabilityNotifier.register(sessionID, sink)
+	}
+	return nil
 }
 
 // backendHealth returns the core-owned backend health reporter, or nil when health
@@ -94,7 +94,8 @@ func newRealTestHandlerWithConfig(t *testing.T, backendURL string, modernDispatc
 	require.NoError(t, err)
 	resolver, err := aggregator.NewPriorityConflictResolver([]string{backend.Name})
 	require.NoError(t, err)
-	agg := aggregator.NewDefaultAggregator(backendClient, resolver, nil, nil)
+	agg, err := aggregator.NewDefaultAggregator(backendClient, resolver, nil, nil, nil)
+	require.NoError(t, err)
 
 	rt := router.NewSessionRouter(&vmcp.RoutingTable{})
 	srv, err := server.New(
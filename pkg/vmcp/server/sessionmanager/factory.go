@@ -28,8 +28,6 @@ import (
 	sessiontypes "github.com/stacklok/toolhive/pkg/vmcp/session/types"
 )
 
-const instrumentationName = "github.com/stacklok/toolhive/pkg/vmcp"
-
 // defaultCacheCapacity is the fallback used when FactoryConfig.CacheCapacity is
 // zero (the Go zero value). This ensures the cache is always bounded; omitting
 // CacheCapacity from a config does not silently enable unbounded growth.
@@ -261,7 +259,7 @@ func monitorOptimizer(
 	tracerProvider trace.TracerProvider,
 	factory func(context.Context, []mcpserver.ServerTool) (optimizer.Optimizer, error),
 ) (func(context.Context, []mcpserver.ServerTool) (optimizer.Optimizer, error), error) {
-	meter := meterProvider.Meter(instrumentationName)
+	meter := meterProvider.Meter(vmcp.InstrumentationName)
 
 	findToolRequests, err := meter.Int64Counter(
 		"toolhive_vmcp_optimizer_find_tool_requests",
@@ -343,7 +341,7 @@ func monitorOptimizer(
 		return nil, fmt.Errorf("failed to create call_tool duration histogram: %w", err)
 	}
 
-	tracer := tracerProvider.Tracer(instrumentationName)
+	tracer := tracerProvider.Tracer(vmcp.InstrumentationName)
 
 	wrapped := func(ctx context.Context, tools []mcpserver.ServerTool) (optimizer.Optimizer, error) {
 		opt, err := factory(ctx, tools)
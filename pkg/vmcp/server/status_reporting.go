@@ -111,6 +111,19 @@ func (s *Server) periodicStatusReporting(ctx context.Context, config StatusRepor
 	}
 }
 
+// buildStatus builds the current status from the core-owned health monitor, or
+// a minimal Ready status when health monitoring is disabled.
+func (s *Server) buildStatus() *vmcp.Status {
+	if healthMon := s.backendHealth(); healthMon != nil {
+		return healthMon.BuildStatus()
+	}
+	return &vmcp.Status{
+		Phase:     vmcp.PhaseReady,
+		Message:   "Health monitoring disabled",
+		Timestamp: time.Now(),
+	}
+}
+
 // reportStatus collects current runtime status and sends it to the reporter.
 func (s *Server) reportStatus(ctx context.Context, reporter vmcpstatus.Reporter) {
 	// Update health monitor with current backends from registry (for dynamic discovery)
@@ -122,18 +135,7 @@ func (s *Server) reportStatus(ctx context.Context, reporter vmcpstatus.Reporter)
 		}
 	}
 
-	// Build status from the core-owned health monitor if available
-	var status *vmcp.Status
-	if healthMon := s.backendHealth(); healthMon != nil {
-		status = healthMon.BuildStatus()
-	} else {
-		// No health monitor - create minimal status
-		status = &vmcp.Status{
-			Phase:     vmcp.PhaseReady,
-			Message:   "Health monitoring disabled",
-			Timestamp: time.Now(),
-		}
-	}
+	status := s.buildStatus()
 
 	// Log status at debug level
 	slog.Debug("reporting status",
@@ -222,7 +222,7 @@ func createTestServerWithHealthMonitor(
 	ctx, cancel := context.WithCancel(t.Context())
 
 	var healthMonCfg *health.MonitorConfig
-	if (monitorCfg != health.MonitorConfig{}) {
+	if monitorCfg.CheckInterval > 0 {
 		healthMonCfg = &monitorCfg
 	}
 	srv, err := server.New(ctx, &server.Config{
@@ -224,8 +224,9 @@ func TestIntegration_TelemetryMiddleware(t *testing.T) {
 	// for telemetry, and core.CallTool routes tool calls through that wrapped client — so
 	// the backend instrumentation (toolhive_vmcp_backend_requests) is exercised without the
 	// session factory needing to hold the wrapped client.
-	telemetryAgg := aggregator.NewDefaultAggregator(
-		mockBackendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil)
+	telemetryAgg, err := aggregator.NewDefaultAggregator(
+		mockBackendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil, nil)
+	require.NoError(t, err)
 	telemetryFactory := newBackendAwareTestFactory(telemetryTools, telemetryRoutingTable)
 	srv, err := New(ctx, &Config{
 		Name:              "telemetry-vmcp",
@@ -430,8 +431,9 @@ func TestIntegration_TelemetryRunsBeforeClassificationRejection(t *testing.T) {
 	}
 
 	rt := router.NewSessionRouter(&vmcp.RoutingTable{})
-	agg := aggregator.NewDefaultAggregator(
-		mockBackendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil)
+	agg, err := aggregator.NewDefaultAggregator(
+		mockBackendClient, aggregator.NewPrefixConflictResolver("{workload}_"), nil, nil, nil)
+	require.NoError(t, err)
 	factory := newBackendAwareTestFactory(nil, &vmcp.RoutingTable{})
 
 	srv, err := New(ctx, &Config{
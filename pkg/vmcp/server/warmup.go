@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/health"
+)
+
+const (
+	// defaultWarmupTimeout bounds the backend warmup when WarmupConfig.Timeout is zero.
+	defaultWarmupTimeout = 60 * time.Second
+
+	// defaultWarmupMinHealthyPercent is the backend quorum when
+	// WarmupConfig.MinHealthyPercent is zero.
+	defaultWarmupMinHealthyPercent = 100
+
+	// warmupRetryInterval is how long the warmup waits before checking the
+	// backends that are not yet healthy again.
+	warmupRetryInterval = 2 * time.Second
+
+	// warmupCheckTimeout bounds a single warmup health check of a backend.
+	warmupCheckTimeout = 5 * time.Second
+)
+
+// WarmupConfig delays readiness until enough backends answer a health check,
+// so the server does not take traffic while its backends are still starting.
+type WarmupConfig struct {
+	// Timeout bounds the warmup. When it elapses the server reports ready even
+	// if fewer than MinHealthyPercent of the backends are healthy.
+	// If zero, defaults to 60 seconds.
+	Timeout time.Duration
+
+	// MinHealthyPercent is the percentage of backends, from 1 to 100, that must
+	// pass a health check before the warmup completes.
+	// If zero, every backend must be healthy.
+	MinHealthyPercent int
+}
+
+// backendWarmup runs an initial capability query against every backend, which
+// doubles as a health check, and retries the failing backends until a quorum
+// is healthy or the warmup timeout elapses. Backends that are not healthy when
+// the warmup completes are reported degraded; they no longer hold back
+// readiness.
+//
+// The warmup starts on the first readiness probe that gets past the cache sync
+// gate, so in dynamic mode it sees the backends of the synced MCPGroup.
+type backendWarmup struct {
+	// ctx bounds the warmup goroutine; it is cancelled when the server stops.
+	ctx               context.Context
+	checker           vmcp.HealthChecker
+	registry          vmcp.BackendRegistry
+	timeout           time.Duration
+	minHealthyPercent int
+	retryInterval     time.Duration
+
+	startOnce sync.Once
+	done      chan struct{}
+
+	// mu protects degraded.
+	mu       sync.Mutex
+	degraded []string
+}
+
+// newBackendWarmup creates a backend warmup. Health checks go through
+// backendClient the same way the health monitor's MCP probe does.
+func newBackendWarmup(
+	ctx context.Context,
+	cfg *WarmupConfig,
+	backendClient vmcp.BackendClient,
+	registry vmcp.BackendRegistry,
+) *backendWarmup {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultWarmupTimeout
+	}
+	minHealthyPercent := cfg.MinHealthyPercent
+	if minHealthyPercent <= 0 || minHealthyPercent > 100 {
+		minHealthyPercent = defaultWarmupMinHealthyPercent
+	}
+	return &backendWarmup{
+		ctx:               ctx,
+		checker:           health.NewHealthChecker(backendClient, warmupCheckTimeout, 0),
+		registry:          registry,
+		timeout:           timeout,
+		minHealthyPercent: minHealthyPercent,
+		retryInterval:     warmupRetryInterval,
+		done:              make(chan struct{}),
+	}
+}
+
+// pending reports whether readiness still waits for the warmup, starting the
+// warmup on the first call.
+func (w *backendWarmup) pending() bool {
+	w.startOnce.Do(func() {
+		go w.run()
+	})
+	select {
+	case <-w.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// degradedBackends returns the names of the backends that were not healthy
+// when the warmup completed, sorted by name.
+func (w *backendWarmup) degradedBackends() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.degraded)
+}
+
+// run checks the backends until the quorum is healthy or the timeout elapses.
+func (w *backendWarmup) run() {
+	defer close(w.done)
+
+	ctx, cancel := context.WithTimeout(w.ctx, w.timeout)
+	defer cancel()
+
+	backends := w.registry.List(ctx)
+	required := requiredHealthyBackends(len(backends), w.minHealthyPercent)
+	healthy := make(map[string]bool, len(backends))
+
+	slog.Info("warming up backends before reporting ready",
+		"backends", len(backends), "required_healthy", required, "timeout", w.timeout)
+
+	for {
+		w.checkBackends(ctx, backends, healthy)
+		if len(healthy) >= required {
+			w.finish(backends, healthy)
+			slog.Info("backend warmup completed", "healthy", len(healthy), "backends", len(backends))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			w.finish(backends, healthy)
+			slog.Warn("backend warmup timed out before enough backends were healthy, reporting ready",
+				"healthy", len(healthy), "required_healthy", required, "degraded", w.degradedBackends())
+			return
+		case <-time.After(w.retryInterval):
+		}
+	}
+}
+
+// checkBackends health checks, concurrently, every backend not yet in healthy
+// and adds the ones that pass.
+func (w *backendWarmup) checkBackends(ctx context.Context, backends []vmcp.Backend, healthy map[string]bool) {
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for i := range backends {
+		if healthy[backends[i].ID] {
+			continue
+		}
+		wg.Add(1)
+		go func(backend *vmcp.Backend) {
+			defer wg.Done()
+			status, err := w.checker.CheckHealth(ctx, vmcp.BackendToTarget(backend))
+			if err != nil {
+				slog.Debug("backend not healthy during warmup", "backend", backend.Name, "status", status, "error", err)
+				return
+			}
+			mu.Lock()
+			healthy[backend.ID] = true
+			mu.Unlock()
+		}(&backends[i])
+	}
+	wg.Wait()
+}
+
+// finish records the backends that are not healthy as degraded.
+func (w *backendWarmup) finish(backends []vmcp.Backend, healthy map[string]bool) {
+	var degraded []string
+	for _, backend := range backends {
+		if !healthy[backend.ID] {
+			degraded = append(degraded, backend.Name)
+		}
+	}
+	slices.Sort(degraded)
+
+	w.mu.Lock()
+	w.degraded = degraded
+	w.mu.Unlock()
+}
+
+// requiredHealthyBackends returns how many of total backends must be healthy
+// to meet minHealthyPercent, rounding up.
+func requiredHealthyBackends(total, minHealthyPercent int) int {
+	return (total*minHealthyPercent + 99) / 100
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// switchableChecker reports the backends in healthy as healthy and every other
+// backend as unhealthy.
+type switchableChecker struct {
+	mu      sync.Mutex
+	healthy map[string]bool
+}
+
+func (c *switchableChecker) setHealthy(backendID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.healthy[backendID] = true
+}
+
+func (c *switchableChecker) CheckHealth(_ context.Context, target *vmcp.BackendTarget) (vmcp.BackendHealthStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.healthy[target.WorkloadID] {
+		return vmcp.BackendHealthy, nil
+	}
+	return vmcp.BackendUnhealthy, errors.New("connection refused")
+}
+
+// newTestWarmupServer returns a server whose readiness is gated only by a
+// backend warmup over three backends checked by checker.
+func newTestWarmupServer(t *testing.T, cfg *WarmupConfig, checker vmcp.HealthChecker) *Server {
+	t.Helper()
+
+	registry := vmcp.NewImmutableRegistry([]vmcp.Backend{
+		{ID: "backend-a", Name: "backend-a"},
+		{ID: "backend-b", Name: "backend-b"},
+		{ID: "backend-c", Name: "backend-c"},
+	})
+	warmup := newBackendWarmup(t.Context(), cfg, nil, registry)
+	warmup.checker = checker
+	warmup.retryInterval = 10 * time.Millisecond
+	return &Server{config: &Config{}, warmup: warmup}
+}
+
+func TestReadiness_WaitsForBackendWarmupQuorum(t *testing.T) {
+	t.Parallel()
+
+	checker := &switchableChecker{healthy: map[string]bool{"backend-a": true}}
+	srv := newTestWarmupServer(t, &WarmupConfig{Timeout: time.Minute, MinHealthyPercent: 60}, checker)
+
+	// One of three backends is healthy, below the quorum of two.
+	code, body := readinessOf(t, srv)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]string{
+		"status": "not_ready",
+		"mode":   "static",
+		"reason": "backend_warmup_pending",
+	}, body)
+
+	time.Sleep(50 * time.Millisecond)
+	code, _ = readinessOf(t, srv)
+	assert.Equal(t, http.StatusServiceUnavailable, code, "readiness waits while the quorum is not met")
+
+	// A second backend becomes healthy: the quorum is met and the backend still
+	// failing is reported degraded without holding back readiness.
+	checker.setHealthy("backend-b")
+	require.Eventually(t, func() bool {
+		code, _ := readinessOf(t, srv)
+		return code == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, body = readinessOf(t, srv)
+	assert.Equal(t, map[string]string{
+		"status":            "ready",
+		"mode":              "static",
+		"degraded_backends": "backend-c",
+	}, body)
+}
+
+func TestReadiness_BackendWarmupTimeoutAllowsStartup(t *testing.T) {
+	t.Parallel()
+
+	checker := &switchableChecker{healthy: map[string]bool{}}
+	srv := newTestWarmupServer(t, &WarmupConfig{Timeout: 100 * time.Millisecond}, checker)
+
+	code, body := readinessOf(t, srv)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "backend_warmup_pending", body["reason"])
+
+	// No backend ever becomes healthy, but the timeout lets the server start.
+	require.Eventually(t, func() bool {
+		code, _ := readinessOf(t, srv)
+		return code == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, body = readinessOf(t, srv)
+	assert.Equal(t, "backend-a,backend-b,backend-c", body["degraded_backends"])
+}
+
+func TestRequiredHealthyBackends(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		total, percent, want int
+	}{
+		{total: 0, percent: 100, want: 0},
+		{total: 3, percent: 100, want: 3},
+		{total: 3, percent: 60, want: 2},
+		{total: 3, percent: 1, want: 1},
+		{total: 10, percent: 50, want: 5},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, requiredHealthyBackends(tt.total, tt.percent),
+			"total=%d percent=%d", tt.total, tt.percent)
+	}
+}
@@ -72,6 +72,14 @@ func ConvertConfigToWorkflowDefinitions(
 			Timeout:     timeout,
 			Output:      ct.Output,
 			Metadata:    make(map[string]string),
+
+			SkipBackendHealthCheck: ct.SkipBackendHealthCheck,
+		}
+		if ct.Join != nil {
+			def.Join = &composer.JoinConfig{
+				Strategy: composer.JoinStrategy(ct.Join.Strategy),
+				N:        ct.Join.N,
+			}
 		}
 
 		workflowDefs[ct.Name] = def
@@ -194,6 +202,11 @@ func parseStepType(cs *config.WorkflowStepConfig) (composer.StepType, error) {
 		stepType = composer.StepTypeElicitation
 	case "forEach":
 		stepType = composer.StepTypeForEach
+	case "composite":
+		stepType = composer.StepTypeComposite
+		if cs.Tool == "" {
+			return "", fmt.Errorf("step %s: composite tool name is required for composite steps", cs.ID)
+		}
 	default:
 		return "", fmt.Errorf("step %s: invalid step type %s", cs.ID, cs.Type)
 	}
@@ -246,10 +259,16 @@ func convertElicitation(
 		timeout = time.Duration(cs.Timeout)
 	}
 
+	timeoutDefault, err := cs.TimeoutDefault.ToMap()
+	if err != nil {
+		return nil, fmt.Errorf("step %s: failed to unmarshal timeoutDefault: %w", cs.ID, err)
+	}
+
 	elicitation := &composer.ElicitationConfig{
-		Message: cs.Message,
-		Schema:  schema,
-		Timeout: timeout,
+		Message:        cs.Message,
+		Schema:         schema,
+		Timeout:        timeout,
+		TimeoutDefault: timeoutDefault,
 	}
 
 	// Convert elicitation response handlers
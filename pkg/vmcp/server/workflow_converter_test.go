@@ -139,13 +139,14 @@ func TestConvertSteps_ComplexWorkflow(t *testing.T) {
 			},
 		},
 		{
-			ID:        "confirm",
-			Type:      "elicitation",
-			Message:   "Deploy?",
-			Schema:    thvjson.NewMap(map[string]any{"type": "object"}),
-			Timeout:   config.Duration(5 * time.Minute),
-			DependsOn: []string{"merge"},
-			OnDecline: &config.ElicitationResponseConfig{Action: "abort"},
+			ID:             "confirm",
+			Type:           "elicitation",
+			Message:        "Deploy?",
+			Schema:         thvjson.NewMap(map[string]any{"type": "object"}),
+			Timeout:        config.Duration(5 * time.Minute),
+			DependsOn:      []string{"merge"},
+			OnDecline:      &config.ElicitationResponseConfig{Action: "abort"},
+			TimeoutDefault: thvjson.NewMap(map[string]any{"approved": false}),
 		},
 		{
 			ID:        "deploy",
@@ -172,6 +173,7 @@ func TestConvertSteps_ComplexWorkflow(t *testing.T) {
 	assert.Equal(t, composer.StepTypeElicitation, result[1].Type)
 	assert.NotNil(t, result[1].Elicitation)
 	assert.Equal(t, "Deploy?", result[1].Elicitation.Message)
+	assert.Equal(t, map[string]any{"approved": false}, result[1].Elicitation.TimeoutDefault)
 
 	// Verify step 3
 	assert.Equal(t, "deploy", result[2].ID)
@@ -297,3 +299,48 @@ func TestConvertConfigToWorkflowDefinitions_WithOutputConfig(t *testing.T) {
 		})
 	}
 }
+
+// TestConvertConfigToWorkflowDefinitions_Join tests that the parallel join
+// strategy is carried from the config onto the workflow definition.
+func TestConvertConfigToWorkflowDefinitions_Join(t *testing.T) {
+	t.Parallel()
+
+	steps := []config.WorkflowStepConfig{
+		{ID: "a", Type: "tool", Tool: "mirror_a.fetch"},
+		{ID: "b", Type: "tool", Tool: "mirror_b.fetch"},
+		{ID: "c", Type: "tool", Tool: "mirror_c.fetch"},
+	}
+
+	tests := []struct {
+		name string
+		join *config.JoinConfig
+		want *composer.JoinConfig
+	}{
+		{
+			name: "no join waits for every branch",
+		},
+		{
+			name: "any",
+			join: &config.JoinConfig{Strategy: config.JoinStrategyAny},
+			want: &composer.JoinConfig{Strategy: composer.JoinStrategyAny},
+		},
+		{
+			name: "n-of-m",
+			join: &config.JoinConfig{Strategy: config.JoinStrategyNOfM, N: 2},
+			want: &composer.JoinConfig{Strategy: composer.JoinStrategyNOfM, N: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defs, err := ConvertConfigToWorkflowDefinitions([]config.CompositeToolConfig{
+				{Name: "race", Steps: steps, Join: tt.join},
+			})
+			require.NoError(t, err)
+			require.Contains(t, defs, "race")
+			assert.Equal(t, tt.want, defs["race"].Join)
+		})
+	}
+}
@@ -125,7 +125,10 @@ type backendConnector func(
 
 // defaultMultiSessionFactory is the production MultiSessionFactory implementation.
 type defaultMultiSessionFactory struct {
-	connector          backendConnector
+	connector backendConnector
+	// stdioConnector, when set, connects the backends that run as local
+	// processes (targets with a Stdio command). See WithStdioBackendClient.
+	stdioConnector     backendConnector
 	maxConcurrency     int
 	backendInitTimeout time.Duration
 }
@@ -153,6 +156,18 @@ func WithBackendInitTimeout(d time.Duration) MultiSessionFactoryOption {
 	}
 }
 
+// WithStdioBackendClient routes the backends that run as local processes
+// through client, which owns their processes and shares them between
+// sessions. Without it such backends fail to connect and are excluded from
+// every session.
+func WithStdioBackendClient(client vmcp.BackendClient) MultiSessionFactoryOption {
+	return func(f *defaultMultiSessionFactory) {
+		if client != nil {
+			f.stdioConnector = backend.NewClientConnector(client)
+		}
+	}
+}
+
 // NewSessionFactory creates a MultiSessionFactory that connects to backends
 // over HTTP using the given outgoing auth registry.
 func NewSessionFactory(registry vmcpauth.OutgoingAuthRegistry, opts ...MultiSessionFactoryOption) MultiSessionFactory {
@@ -197,7 +212,11 @@ func (f *defaultMultiSessionFactory) initOneBackend(
 	defer cancel()
 
 	target := vmcp.BackendToTarget(b)
-	conn, caps, err := f.connector(bCtx, target, identity, sessionHint, sink)
+	connector := f.connector
+	if target.Stdio != nil && f.stdioConnector != nil {
+		connector = f.stdioConnector
+	}
+	conn, caps, err := connector(bCtx, target, identity, sessionHint, sink)
 	if err != nil {
 		if conn != nil {
 			_ = conn.Close()
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+	"github.com/stacklok/toolhive/pkg/vmcp/mocks"
+	internalbk "github.com/stacklok/toolhive/pkg/vmcp/session/internal/backend"
+)
+
+func TestNewSessionFactory_StdioBackendsUseBackendClient(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockBackendClient(ctrl)
+
+	httpBackend := &vmcp.Backend{ID: "http", Name: "http", BaseURL: "http://x:9", TransportType: "streamable-http"}
+	stdioBackend := &vmcp.Backend{
+		ID:            "local",
+		Name:          "local",
+		TransportType: "stdio",
+		Stdio:         &vmcp.StdioCommand{Command: "mcp-server"},
+	}
+
+	connector := func(_ context.Context, target *vmcp.BackendTarget, _ *auth.Identity, _ string, _ internalbk.ListChangedSink) (internalbk.Session, *vmcp.CapabilityList, error) {
+		if target.Stdio != nil {
+			return nil, nil, errors.New("stdio backends must not use the HTTP connector")
+		}
+		return &mockConnectedBackend{}, &vmcp.CapabilityList{
+			Tools: []vmcp.Tool{{Name: "search", BackendID: "http"}},
+		}, nil
+	}
+
+	isStdioTarget := gomock.Cond(func(target *vmcp.BackendTarget) bool {
+		return target.WorkloadID == "local" && target.Stdio != nil
+	})
+	client.EXPECT().ListCapabilities(gomock.Any(), isStdioTarget).Return(&vmcp.CapabilityList{
+		Tools: []vmcp.Tool{{Name: "read_file", BackendID: "local"}},
+	}, nil)
+	client.EXPECT().CallTool(gomock.Any(), isStdioTarget, "read_file", map[string]any{"path": "a"}, gomock.Any()).
+		Return(&vmcp.ToolCallResult{Content: []vmcp.Content{{Type: vmcp.ContentTypeText, Text: "contents"}}}, nil)
+
+	factory := newSessionFactoryWithConnector(connector, WithStdioBackendClient(client))
+	sess, err := factory.MakeSessionWithID(
+		context.Background(), uuid.New().String(), nil, []*vmcp.Backend{httpBackend, stdioBackend}, nil)
+	require.NoError(t, err)
+	require.Len(t, sess.Tools(), 2)
+
+	result, err := sess.CallTool(context.Background(), nil, "read_file", map[string]any{"path": "a"}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "contents", result.Content[0].Text)
+
+	// Closing the session leaves the process, owned by the client, running.
+	require.NoError(t, sess.Close())
+}
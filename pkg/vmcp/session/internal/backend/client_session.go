@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright 2026 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stacklok/toolhive/pkg/auth"
+	"github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// clientSession is a Session that delegates every operation to a
+// vmcp.BackendClient bound to a single target. It is used for backends whose
+// connection is owned by the client rather than by the session, such as stdio
+// backends: the client runs one process per backend and shares it between all
+// sessions, so closing a clientSession leaves the process running.
+type clientSession struct {
+	client vmcp.BackendClient
+	target *vmcp.BackendTarget
+}
+
+// SessionID returns "" because the connection is not owned by this session.
+func (*clientSession) SessionID() string { return "" }
+
+// Close is a no-op; the client owns the backend connection.
+func (*clientSession) Close() error { return nil }
+
+// CallTool invokes a named tool on this backend.
+func (c *clientSession) CallTool(
+	ctx context.Context,
+	toolName string,
+	arguments map[string]any,
+	meta map[string]any,
+) (*vmcp.ToolCallResult, error) {
+	return c.client.CallTool(ctx, c.target, toolName, arguments, meta)
+}
+
+// ReadResource reads a resource from this backend.
+func (c *clientSession) ReadResource(ctx context.Context, uri string) (*vmcp.ResourceReadResult, error) {
+	return c.client.ReadResource(ctx, c.target, uri)
+}
+
+// GetPrompt retrieves a prompt from this backend.
+func (c *clientSession) GetPrompt(
+	ctx context.Context,
+	name string,
+	arguments map[string]any,
+) (*vmcp.PromptGetResult, error) {
+	return c.client.GetPrompt(ctx, c.target, name, arguments)
+}
+
+// NewClientConnector returns a function that creates a Session backed by
+// client for each backend. The session hint, identity and list-changed sink
+// are ignored: the client holds a single connection per backend, shared by
+// every session.
+func NewClientConnector(client vmcp.BackendClient) func(
+	ctx context.Context,
+	target *vmcp.BackendTarget,
+	identity *auth.Identity,
+	sessionHint string,
+	sink ListChangedSink,
+) (Session, *vmcp.CapabilityList, error) {
+	return func(
+		ctx context.Context,
+		target *vmcp.BackendTarget,
+		_ *auth.Identity,
+		_ string,
+		_ ListChangedSink,
+	) (Session, *vmcp.CapabilityList, error) {
+		caps, err := client.ListCapabilities(ctx, target)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialise backend %s: %w", target.WorkloadID, err)
+		}
+		return &clientSession{client: client, target: target}, caps, nil
+	}
+}
@@ -8,6 +8,10 @@
 //   - LoggingReporter (CLI): logs updates at Debug level, no persistence.
 //     Debug logging is controlled by the --debug flag; logs may not be visible
 //     in production configurations where log level is set to Info.
+//   - K8sReporter (Kubernetes): writes updates to VirtualMCPServer.Status.
+//   - MultiReporter: fans updates out to several reporters. NewReporter uses
+//     it to log updates alongside the K8sReporter when VMCP_STATUS_LOGGING is
+//     "compose"; "force" selects the LoggingReporter in any environment.
 //   - Future reporters: Kubernetes status writer, file/metrics sinks.
 //
 // Reporter lifecycle: Start(ctx) returns a shutdown func; server collects and
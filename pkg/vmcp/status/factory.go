@@ -17,8 +17,36 @@ const (
 
 	// EnvVMCPNamespace is the environment variable for the VirtualMCPServer namespace
 	EnvVMCPNamespace = "VMCP_NAMESPACE"
+
+	// EnvVMCPStatusLogging is the environment variable selecting a LoggingMode
+	// when Options.Logging is unset.
+	EnvVMCPStatusLogging = "VMCP_STATUS_LOGGING"
 )
 
+// LoggingMode controls how the LoggingReporter is used alongside the reporter
+// selected for the runtime environment.
+type LoggingMode string
+
+const (
+	// LoggingModeAuto uses the reporter selected for the environment.
+	LoggingModeAuto LoggingMode = ""
+
+	// LoggingModeForce uses the LoggingReporter instead of the reporter
+	// selected for the environment.
+	LoggingModeForce LoggingMode = "force"
+
+	// LoggingModeCompose reports to the LoggingReporter in addition to the
+	// reporter selected for the environment.
+	LoggingModeCompose LoggingMode = "compose"
+)
+
+// Options configures NewReporter.
+type Options struct {
+	// Logging selects how status updates are logged. When unset, the value of
+	// VMCP_STATUS_LOGGING is used, and LoggingModeAuto if that is unset too.
+	Logging LoggingMode
+}
+
 // NewReporter creates an appropriate Reporter based on the runtime environment.
 //
 // Detection logic:
@@ -28,13 +56,46 @@ const (
 // In Kubernetes mode, the function uses in-cluster configuration to create
 // a Kubernetes client for updating VirtualMCPServer status.
 //
+// opts.Logging overrides the detected reporter: LoggingModeForce always
+// returns a LoggingReporter, and LoggingModeCompose returns a MultiReporter
+// that also logs every update the detected reporter receives.
+//
 // Returns:
-//   - Reporter instance (K8sReporter or LoggingReporter)
-//   - Error if Kubernetes mode is detected but client creation fails
-func NewReporter() (Reporter, error) {
-	vmcpName := os.Getenv(EnvVMCPName)
-	vmcpNamespace := os.Getenv(EnvVMCPNamespace)
-	return newReporterFromEnv(vmcpName, vmcpNamespace)
+//   - Reporter instance (K8sReporter, LoggingReporter, or MultiReporter)
+//   - Error if the logging mode is unknown, or Kubernetes mode is detected but
+//     client creation fails
+func NewReporter(opts Options) (Reporter, error) {
+	if opts.Logging == LoggingModeAuto {
+		opts.Logging = LoggingMode(os.Getenv(EnvVMCPStatusLogging))
+	}
+	return newReporter(opts, func() (Reporter, error) {
+		return newReporterFromEnv(os.Getenv(EnvVMCPName), os.Getenv(EnvVMCPNamespace))
+	})
+}
+
+// newReporter applies opts.Logging to the reporter built by detect. detect is
+// injected so tests can exercise composition without a Kubernetes cluster.
+func newReporter(opts Options, detect func() (Reporter, error)) (Reporter, error) {
+	switch opts.Logging {
+	case LoggingModeAuto:
+		return detect()
+	case LoggingModeForce:
+		slog.Debug("status logging forced, creating LoggingReporter")
+		return NewLoggingReporter(), nil
+	case LoggingModeCompose:
+		reporter, err := detect()
+		if err != nil {
+			return nil, err
+		}
+		if _, isLogging := reporter.(*LoggingReporter); isLogging {
+			return reporter, nil
+		}
+		slog.Debug("status logging composed with the detected reporter")
+		return NewMultiReporter(reporter, NewLoggingReporter()), nil
+	default:
+		return nil, fmt.Errorf("unknown status logging mode %q (supported: %q, %q)",
+			opts.Logging, LoggingModeForce, LoggingModeCompose)
+	}
 }
 
 // newReporterFromEnv creates a Reporter based on the provided environment variable values.
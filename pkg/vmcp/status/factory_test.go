@@ -4,10 +4,14 @@
 package status
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	vmcptypes "github.com/stacklok/toolhive/pkg/vmcp"
 )
 
 func TestNewReporter_CLIMode(t *testing.T) {
@@ -62,3 +66,122 @@ func TestEnvVarConstants(t *testing.T) {
 	assert.Equal(t, "VMCP_NAME", EnvVMCPName)
 	assert.Equal(t, "VMCP_NAMESPACE", EnvVMCPNamespace)
 }
+
+// stubReporter records ReportStatus calls and shutdowns, and can be made to fail.
+type stubReporter struct {
+	reports   int
+	shutdowns int
+	startErr  error
+	reportErr error
+}
+
+func (s *stubReporter) ReportStatus(context.Context, *vmcptypes.Status) error {
+	s.reports++
+	return s.reportErr
+}
+
+func (s *stubReporter) Start(context.Context) (func(context.Context) error, error) {
+	if s.startErr != nil {
+		return nil, s.startErr
+	}
+	return func(context.Context) error {
+		s.shutdowns++
+		return nil
+	}, nil
+}
+
+func TestNewReporter_LoggingModes(t *testing.T) {
+	t.Parallel()
+
+	detected := &stubReporter{}
+	detect := func() (Reporter, error) { return detected, nil }
+
+	t.Run("auto uses the detected reporter", func(t *testing.T) {
+		t.Parallel()
+		reporter, err := newReporter(Options{}, detect)
+		require.NoError(t, err)
+		assert.Same(t, detected, reporter)
+	})
+
+	t.Run("force uses the logging reporter", func(t *testing.T) {
+		t.Parallel()
+		reporter, err := newReporter(Options{Logging: LoggingModeForce}, func() (Reporter, error) {
+			t.Fatal("detection must be skipped when logging is forced")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &LoggingReporter{}, reporter)
+	})
+
+	t.Run("compose adds the logging reporter", func(t *testing.T) {
+		t.Parallel()
+		reporter, err := newReporter(Options{Logging: LoggingModeCompose}, detect)
+		require.NoError(t, err)
+		require.IsType(t, &MultiReporter{}, reporter)
+		assert.Len(t, reporter.(*MultiReporter).reporters, 2)
+	})
+
+	t.Run("compose does not duplicate a detected logging reporter", func(t *testing.T) {
+		t.Parallel()
+		reporter, err := newReporter(Options{Logging: LoggingModeCompose}, func() (Reporter, error) {
+			return newReporterFromEnv("", "")
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &LoggingReporter{}, reporter)
+	})
+
+	t.Run("compose propagates detection errors", func(t *testing.T) {
+		t.Parallel()
+		_, err := newReporter(Options{Logging: LoggingModeCompose}, func() (Reporter, error) {
+			return nil, errors.New("no cluster")
+		})
+		require.ErrorContains(t, err, "no cluster")
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		t.Parallel()
+		_, err := newReporter(Options{Logging: "verbose"}, detect)
+		require.ErrorContains(t, err, `unknown status logging mode "verbose"`)
+	})
+}
+
+func TestMultiReporter_ReportStatus(t *testing.T) {
+	t.Parallel()
+
+	failing := &stubReporter{reportErr: errors.New("write failed")}
+	ok := &stubReporter{}
+	reporter := NewMultiReporter(failing, nil, ok)
+
+	err := reporter.ReportStatus(context.Background(), &vmcptypes.Status{Phase: vmcptypes.PhaseReady})
+	require.ErrorContains(t, err, "write failed")
+	assert.Equal(t, 1, failing.reports)
+	assert.Equal(t, 1, ok.reports, "a failing reporter must not stop the others")
+
+	require.NoError(t, reporter.ReportStatus(context.Background(), nil))
+	assert.Equal(t, 1, ok.reports, "nil status is skipped")
+}
+
+func TestMultiReporter_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shutdown reaches every reporter", func(t *testing.T) {
+		t.Parallel()
+		first, second := &stubReporter{}, &stubReporter{}
+		shutdown, err := NewMultiReporter(first, second).Start(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, shutdown(context.Background()))
+		assert.Equal(t, 1, first.shutdowns)
+		assert.Equal(t, 1, second.shutdowns)
+	})
+
+	t.Run("start failure shuts down started reporters", func(t *testing.T) {
+		t.Parallel()
+		started := &stubReporter{}
+		shutdown, err := NewMultiReporter(started, &stubReporter{startErr: errors.New("rbac denied")}).
+			Start(context.Background())
+		require.ErrorContains(t, err, "rbac denied")
+		assert.Nil(t, shutdown)
+		assert.Equal(t, 1, started.shutdowns)
+	})
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	vmcptypes "github.com/stacklok/toolhive/pkg/vmcp"
+)
+
+// MultiReporter is a Reporter that fans every status update out to several
+// reporters, e.g. a K8sReporter and a LoggingReporter.
+type MultiReporter struct {
+	reporters []Reporter
+}
+
+// NewMultiReporter creates a Reporter that forwards to each of reporters in
+// order. Nil reporters are ignored.
+func NewMultiReporter(reporters ...Reporter) *MultiReporter {
+	m := &MultiReporter{}
+	for _, r := range reporters {
+		if r != nil {
+			m.reporters = append(m.reporters, r)
+		}
+	}
+	return m
+}
+
+// ReportStatus sends status to every reporter. A failing reporter does not
+// stop the others; their errors are joined.
+func (m *MultiReporter) ReportStatus(ctx context.Context, status *vmcptypes.Status) error {
+	if shouldSkipStatus(status) {
+		return nil
+	}
+
+	var errs []error
+	for _, r := range m.reporters {
+		if err := r.ReportStatus(ctx, status); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start starts every reporter and returns a shutdown function that shuts all
+// of them down, joining their errors. If a reporter fails to start, the ones
+// already started are shut down before the error is returned.
+func (m *MultiReporter) Start(ctx context.Context) (func(context.Context) error, error) {
+	shutdowns := make([]func(context.Context) error, 0, len(m.reporters))
+	shutdownAll := func(ctx context.Context) error {
+		var errs []error
+		for _, shutdown := range shutdowns {
+			if err := shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, r := range m.reporters {
+		shutdown, err := r.Start(ctx)
+		if err != nil {
+			if shutdownErr := shutdownAll(ctx); shutdownErr != nil {
+				return nil, fmt.Errorf("failed to start status reporter: %w (cleanup: %v)", err, shutdownErr)
+			}
+			return nil, fmt.Errorf("failed to start status reporter: %w", err)
+		}
+		if shutdown != nil {
+			shutdowns = append(shutdowns, shutdown)
+		}
+	}
+	return shutdownAll, nil
+}
+
+// Verify MultiReporter implements Reporter interface
+var _ Reporter = (*MultiReporter)(nil)
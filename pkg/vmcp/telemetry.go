@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: Copyright 2025 Stacklok, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmcp
+
+// InstrumentationName is the OTEL instrumentation scope for the metrics and
+// spans emitted by vMCP packages. Sharing one scope keeps all vMCP metrics in
+// the same Prometheus namespace.
+const InstrumentationName = "github.com/stacklok/toolhive/pkg/vmcp"
@@ -5,6 +5,7 @@ package vmcp
 
 import (
 	"context"
+	"maps"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +37,21 @@ type HeaderForwardConfig struct {
 	AddHeadersFromSecret map[string]string `json:"addHeadersFromSecret,omitempty" yaml:"addHeadersFromSecret,omitempty"`
 }
 
+// StdioCommand is the local process of a backend that speaks MCP over its
+// standard input and output. vMCP starts the process on first use, keeps it
+// running for all requests to the backend, and restarts it if it exits.
+type StdioCommand struct {
+	// Command is the executable to run, either a path or a name looked up in PATH.
+	Command string
+
+	// Args are the command-line arguments passed to Command.
+	Args []string
+
+	// Env holds environment variables set for the process in addition to the
+	// environment of vMCP.
+	Env map[string]string
+}
+
 // BackendTarget identifies a specific backend workload and provides
 // the information needed to forward requests to it.
 type BackendTarget struct {
@@ -104,6 +120,27 @@ type BackendTarget struct {
 	// (list, call, health-check). Nil when no headers are configured.
 	HeaderForward *HeaderForwardConfig
 
+	// Stdio is the process serving this backend over stdio. When set, the
+	// backend is reached through the process instead of BaseURL. Nil for
+	// network backends.
+	Stdio *StdioCommand
+
+	// CallTimeout bounds a tool call routed to this target. It is resolved per
+	// tool when the routing table is built. Zero means no deadline beyond the
+	// backend client's own timeouts.
+	CallTimeout time.Duration
+
+	// Idempotent reports whether the tool this target routes to is annotated
+	// idempotent (idempotentHint). The backend client retries only idempotent
+	// tool calls on transient transport errors. It is resolved per tool when the
+	// routing table is built.
+	Idempotent bool
+
+	// ParameterDefaults are argument values injected into a tool call routed to
+	// this target when the caller does not provide them. They are resolved per
+	// tool when the routing table is built. Use WithParameterDefaults to apply them.
+	ParameterDefaults map[string]any
+
 	// Metadata stores additional backend-specific information.
 	Metadata map[string]string
 }
@@ -134,6 +171,20 @@ func (t *BackendTarget) GetBackendCapabilityName(resolvedName string) string {
 	return resolvedName
 }
 
+// WithParameterDefaults returns the arguments to forward to the backend for a
+// tool call routed to this target: arguments merged over the target's
+// ParameterDefaults, so a value the caller provided always wins. arguments is
+// never mutated; it is returned as-is when the target has no defaults.
+func (t *BackendTarget) WithParameterDefaults(arguments map[string]any) map[string]any {
+	if len(t.ParameterDefaults) == 0 {
+		return arguments
+	}
+	merged := make(map[string]any, len(t.ParameterDefaults)+len(arguments))
+	maps.Copy(merged, t.ParameterDefaults)
+	maps.Copy(merged, arguments)
+	return merged
+}
+
 // BackendType represents the type of backend workload.
 type BackendType string
 
@@ -362,6 +413,10 @@ type Backend struct {
 	// spec.headerForward. Nil when the entry has no header forwarding configured.
 	HeaderForward *HeaderForwardConfig
 
+	// Stdio is the process serving this backend when TransportType is "stdio".
+	// Only populated for static backends of the CLI configuration.
+	Stdio *StdioCommand
+
 	// Metadata stores additional backend information.
 	Metadata map[string]string
 }
@@ -757,6 +812,16 @@ type BackendClient interface {
 	ListCapabilities(ctx context.Context, target *BackendTarget) (*CapabilityList, error)
 }
 
+// BackendReleaser is implemented by a BackendClient that holds per-backend
+// resources, such as pooled connections or stdio processes. In dynamic
+// discovery mode the server calls it whenever the backend registry changes, so
+// the resources of a backend that left the registry do not outlive it.
+type BackendReleaser interface {
+	// RetainBackends releases the resources of every backend whose ID is not
+	// in current.
+	RetainBackends(current []Backend)
+}
+
 // CapabilityList contains the capabilities from a backend's MCP server.
 // This is returned by BackendClient.ListCapabilities().
 type CapabilityList struct {
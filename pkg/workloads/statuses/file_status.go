@@ -609,7 +609,6 @@ func (f *fileStatusManager) withFileLock(ctx context.Context, workloadName strin
 
 	// Create file lock
 	fileLock := lockfile.NewTrackedLock(lockFilePath)
-	defer lockfile.ReleaseTrackedLock(lockFilePath, fileLock)
 
 	// Create context with timeout
 	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
@@ -623,6 +622,8 @@ func (f *fileStatusManager) withFileLock(ctx context.Context, workloadName strin
 	if !locked {
 		return fmt.Errorf("could not acquire lock for workload %s: timeout after %v", workloadName, lockTimeout)
 	}
+	defer lockfile.ReleaseTrackedLock(lockFilePath, fileLock)
+	lockfile.RecordOwner(lockFilePath)
 
 	return fn(statusFilePath)
 }
@@ -644,7 +645,6 @@ func (f *fileStatusManager) withFileReadLock(ctx context.Context, workloadName s
 
 	// Create file lock
 	fileLock := lockfile.NewTrackedLock(lockFilePath)
-	defer lockfile.ReleaseTrackedLock(lockFilePath, fileLock)
 
 	// Create context with timeout
 	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
@@ -658,6 +658,7 @@ func (f *fileStatusManager) withFileReadLock(ctx context.Context, workloadName s
 	if !locked {
 		return fmt.Errorf("could not acquire read lock for workload %s: timeout after %v", workloadName, lockTimeout)
 	}
+	defer lockfile.ReleaseTrackedLock(lockFilePath, fileLock)
 
 	return fn(statusFilePath)
 }
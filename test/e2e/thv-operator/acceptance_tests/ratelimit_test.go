@@ -57,7 +57,7 @@ var _ = Describe("MCPServer Rate Limiting", Ordered, func() {
 					Transport: "streamable-http",
 					ProxyPort: 8080,
 					MCPPort:   8080,
-					Env: []mcpv1beta1.EnvVar{
+					Env: []mcpv1beta1.MCPServerEnvVar{
 						{Name: "TRANSPORT", Value: "streamable-http"},
 					},
 					SessionStorage: &mcpv1beta1.SessionStorageConfig{
@@ -149,7 +149,7 @@ var _ = Describe("MCPServer Rate Limiting", Ordered, func() {
 					Transport: "streamable-http",
 					ProxyPort: 8080,
 					MCPPort:   8080,
-					Env: []mcpv1beta1.EnvVar{
+					Env: []mcpv1beta1.MCPServerEnvVar{
 						{Name: "TRANSPORT", Value: "streamable-http"},
 					},
 					SessionStorage: &mcpv1beta1.SessionStorageConfig{
@@ -228,7 +228,7 @@ var _ = Describe("MCPServer Rate Limiting", Ordered, func() {
 					Transport: "streamable-http",
 					ProxyPort: 8080,
 					MCPPort:   8080,
-					Env: []mcpv1beta1.EnvVar{
+					Env: []mcpv1beta1.MCPServerEnvVar{
 						{Name: "TRANSPORT", Value: "streamable-http"},
 					},
 					SessionStorage: &mcpv1beta1.SessionStorageConfig{
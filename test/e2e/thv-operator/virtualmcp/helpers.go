@@ -467,7 +467,7 @@ func CreateMCPServerAndWait(
 			ProxyPort: 8080,
 			MCPPort:   8080,
 			Resources: defaultMCPServerResources(),
-			Env: []mcpv1beta1.EnvVar{
+			Env: []mcpv1beta1.MCPServerEnvVar{
 				{Name: "TRANSPORT", Value: "streamable-http"},
 			},
 		},
@@ -501,7 +501,7 @@ type BackendConfig struct {
 	Transport             string // defaults to "streamable-http" if empty
 	ExternalAuthConfigRef *mcpv1beta1.ExternalAuthConfigRef
 	Secrets               []mcpv1beta1.SecretRef
-	Env                   []mcpv1beta1.EnvVar // additional env vars beyond TRANSPORT
+	Env                   []mcpv1beta1.MCPServerEnvVar // additional env vars beyond TRANSPORT
 	// Resources overrides the default resource requests/limits. When nil,
 	// defaultMCPServerResources() is used to ensure containers are scheduled
 	// with reasonable resource guarantees and do not compete excessively.
@@ -559,7 +559,7 @@ func CreateMultipleMCPServersInParallel(
 				ExternalAuthConfigRef: backends[idx].ExternalAuthConfigRef,
 				Secrets:               backends[idx].Secrets,
 				Resources:             resources,
-				Env: append([]mcpv1beta1.EnvVar{
+				Env: append([]mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: backendTransport},
 				}, backends[idx].Env...),
 			},
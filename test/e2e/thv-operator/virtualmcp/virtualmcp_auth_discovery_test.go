@@ -1456,7 +1456,7 @@ var _ = Describe("Auth Config Error Handling", Ordered, func() {
 				ExternalAuthConfigRef: &mcpv1beta1.ExternalAuthConfigRef{
 					Name: workingAuthConfigName,
 				},
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
@@ -1479,7 +1479,7 @@ var _ = Describe("Auth Config Error Handling", Ordered, func() {
 				ExternalAuthConfigRef: &mcpv1beta1.ExternalAuthConfigRef{
 					Name: missingAuthConfigName,
 				},
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
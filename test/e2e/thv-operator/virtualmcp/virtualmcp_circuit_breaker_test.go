@@ -59,7 +59,7 @@ var _ = Describe("VirtualMCPServer Circuit Breaker Lifecycle", Ordered, func() {
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
@@ -78,7 +78,7 @@ var _ = Describe("VirtualMCPServer Circuit Breaker Lifecycle", Ordered, func() {
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
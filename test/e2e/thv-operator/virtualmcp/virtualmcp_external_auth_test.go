@@ -812,7 +812,7 @@ var _ = Describe("VirtualMCPServer Health Check with HeaderInjection Auth", Orde
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 				ExternalAuthConfigRef: &mcpv1beta1.ExternalAuthConfigRef{
@@ -1023,7 +1023,7 @@ var _ = Describe("VirtualMCPServer Health Check with TokenExchange Auth", Ordere
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 				ExternalAuthConfigRef: &mcpv1beta1.ExternalAuthConfigRef{
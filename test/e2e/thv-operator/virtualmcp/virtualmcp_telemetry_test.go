@@ -47,7 +47,7 @@ var _ = Describe("VirtualMCPServer Telemetry Config", Ordered, func() {
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
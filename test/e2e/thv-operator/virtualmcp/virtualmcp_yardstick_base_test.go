@@ -52,7 +52,7 @@ var _ = Describe("VirtualMCPServer Yardstick Base", Ordered, func() {
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
@@ -70,7 +70,7 @@ var _ = Describe("VirtualMCPServer Yardstick Base", Ordered, func() {
 				Transport: "streamable-http",
 				ProxyPort: 8080,
 				MCPPort:   8080,
-				Env: []mcpv1beta1.EnvVar{
+				Env: []mcpv1beta1.MCPServerEnvVar{
 					{Name: "TRANSPORT", Value: "streamable-http"},
 				},
 			},
@@ -172,7 +172,8 @@ func NewVMCPServer(
 		conflictResolver = aggregator.NewPrefixConflictResolver(config.prefixFormat)
 	}
 
-	agg := aggregator.NewDefaultAggregator(backendClient, conflictResolver, nil, nil)
+	agg, err := aggregator.NewDefaultAggregator(backendClient, conflictResolver, nil, nil, nil)
+	require.NoError(tb, err)
 	rtr := router.NewSessionRouter(&vmcptypes.RoutingTable{})
 	backendRegistry := vmcptypes.NewImmutableRegistry(backends)
 
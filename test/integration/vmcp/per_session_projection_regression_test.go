@@ -77,7 +77,8 @@ func newCedarVMCPServer(t *testing.T, backendURL string, policies ...string) *ht
 	// Tool::"secret-tool" rather than a prefixed variant.
 	resolver, err := aggregator.NewPriorityConflictResolver([]string{backend.Name})
 	require.NoError(t, err)
-	agg := aggregator.NewDefaultAggregator(backendClient, resolver, nil, nil)
+	agg, err := aggregator.NewDefaultAggregator(backendClient, resolver, nil, nil, nil)
+	require.NoError(t, err)
 
 	// Identity middleware: derive the principal from the X-Test-Principal
 	// header so two sessions (alice, bob) bind to different identities. The